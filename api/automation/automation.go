@@ -0,0 +1,370 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	openaiHelper "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	EndpointChat    = "chat"
+	EndpointImage   = "image"
+	EndpointBalance = "balance"
+)
+
+// Controller 面向 Zapier/IFTTT 等无代码自动化平台的简化 API
+// 所有接口均使用 API Key 认证，返回结构保持稳定，不使用 SSE 流
+type Controller struct {
+	conf     *config.Config
+	chat     chat.Chat            `autowire:"@"`
+	client   openaiHelper.Client  `autowire:"@"`
+	userSrv  *service.UserService `autowire:"@"`
+	userRepo *repo.UserRepo       `autowire:"@"`
+	quotaRep *repo.QuotaRepo      `autowire:"@"`
+	limiter  *rate.RateLimiter    `autowire:"@"`
+}
+
+// NewController 创建自动化 API 控制器
+func NewController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &Controller{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *Controller) Register(router web.Router) {
+	router.Group("/automation", func(router web.Router) {
+		router.Post("/chat", ctl.Chat)
+		router.Post("/images", ctl.Images)
+		router.Get("/balance", ctl.Balance)
+		router.Get("/usage", ctl.Usage)
+	})
+}
+
+// Error 自动化平台统一的错误响应结构
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func errorResponse(webCtx web.Context, code string, message string, statusCode int) web.Response {
+	return webCtx.JSONWithCode(web.M{"error": Error{Code: code, Message: message}}, statusCode)
+}
+
+// resolveAPIKey 查询当前请求使用的 API Key，并执行基于 API Key 的流控
+func (ctl *Controller) resolveAPIKey(ctx context.Context, webCtx web.Context, cred *auth.APIKeyCredential) (*model.UserApiKey, web.Response) {
+	key, err := ctl.userRepo.GetAPIKeyByToken(ctx, cred.Token)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, errorResponse(webCtx, "invalid_api_key", "api key not found or disabled", http.StatusUnauthorized)
+		}
+
+		log.F(log.M{"token": cred.Masked()}).Errorf("query api key failed: %s", err)
+		return nil, errorResponse(webCtx, "internal_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	if ctl.conf.AutomationRateLimit > 0 {
+		if err := ctl.limiter.Allow(ctx, fmt.Sprintf("automation:key:%d:minute", key.Id), redis_rate.PerMinute(ctl.conf.AutomationRateLimit)); err != nil {
+			if errors.Is(err, rate.ErrRateLimitExceeded) {
+				return nil, errorResponse(webCtx, "rate_limit_exceeded", "too many requests, please retry later", http.StatusTooManyRequests)
+			}
+
+			log.F(log.M{"key_id": key.Id}).Errorf("check rate limit failed: %s", err)
+		}
+	}
+
+	return key, nil
+}
+
+// recordUsage 记录 API Key 的使用情况，用于用量面板展示
+func (ctl *Controller) recordUsage(ctx context.Context, key *model.UserApiKey, endpoint string, consumed int64) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := ctl.userRepo.RecordAPIKeyUsage(ctx, key.UserId, key.Id, endpoint, consumed); err != nil {
+		log.F(log.M{"key_id": key.Id, "endpoint": endpoint}).Errorf("record api key usage failed: %s", err)
+	}
+}
+
+// freezeQuota 检查用户智慧果余量，并冻结本次请求所需的智慧果，返回的函数用于解冻
+func (ctl *Controller) freezeQuota(ctx context.Context, webCtx web.Context, userID int64, needCoins int64) (func(), web.Response) {
+	quota, err := ctl.userSrv.UserQuota(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return nil, errorResponse(webCtx, "internal_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < needCoins {
+		return nil, errorResponse(webCtx, "insufficient_balance", "insufficient balance", http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, userID, needCoins); err != nil {
+		log.F(log.M{"user_id": userID, "quota": needCoins}).Errorf("freeze user quota failed: %s", err)
+		return func() {}, nil
+	}
+
+	return func() {
+		if err := ctl.userSrv.UnfreezeUserQuota(ctx, userID, needCoins); err != nil {
+			log.F(log.M{"user_id": userID, "quota": needCoins}).Errorf("unfreeze user quota failed: %s", err)
+		}
+	}, nil
+}
+
+type ChatRequest struct {
+	Model        string `json:"model"`
+	Prompt       string `json:"prompt"`
+	SystemPrompt string `json:"system_prompt,omitempty"`
+	MaxTokens    int    `json:"max_tokens,omitempty"`
+}
+
+type ChatResponse struct {
+	Model        string `json:"model"`
+	Text         string `json:"text"`
+	InputTokens  int64  `json:"input_tokens"`
+	OutputTokens int64  `json:"output_tokens"`
+	Coins        int64  `json:"coins"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// Chat 创建一次非流式的聊天补全
+func (ctl *Controller) Chat(ctx context.Context, webCtx web.Context, user *auth.User, cred *auth.APIKeyCredential) web.Response {
+	key, errResp := ctl.resolveAPIKey(ctx, webCtx, cred)
+	if errResp != nil {
+		return errResp
+	}
+
+	var req ChatRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return errorResponse(webCtx, "invalid_request", "invalid request body", http.StatusBadRequest)
+	}
+
+	req.Prompt = strings.TrimSpace(req.Prompt)
+	if req.Prompt == "" || req.Model == "" {
+		return errorResponse(webCtx, "invalid_request", "model and prompt are required", http.StatusBadRequest)
+	}
+
+	if !array.In(req.Model, array.Map(chat.Models(ctl.conf, false), func(item chat.Model, _ int) string { return item.RealID() })) {
+		return errorResponse(webCtx, "model_not_found", "model not found", http.StatusNotFound)
+	}
+
+	messages := make(chat.Messages, 0)
+	if req.SystemPrompt != "" {
+		messages = append(messages, chat.Message{Role: "system", Content: req.SystemPrompt})
+	}
+	messages = append(messages, chat.Message{Role: "user", Content: req.Prompt})
+
+	chatReq := chat.Request{Model: req.Model, Messages: messages, MaxTokens: req.MaxTokens}
+	inputTokens, err := chat.MessageTokenCount(chatReq.Messages, chatReq.Model)
+	if err != nil {
+		return errorResponse(webCtx, "invalid_request", err.Error(), http.StatusBadRequest)
+	}
+
+	// 假设本次请求将会消耗 3 个智慧果
+	unfreeze, errResp := ctl.freezeQuota(ctx, webCtx, user.ID, coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), int64(inputTokens))+3)
+	if errResp != nil {
+		return errResp
+	}
+	defer unfreeze()
+
+	chatCtx, cancel := context.WithTimeout(ctx, 180*time.Second)
+	defer cancel()
+
+	resp, err := ctl.chat.Chat(chatCtx, chatReq)
+	if err != nil {
+		if errors.Is(err, chat.ErrContentFilter) {
+			return errorResponse(webCtx, "content_filter", "request rejected by content policy", http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "key_id": key.Id, "model": req.Model}).Errorf("automation chat failed: %s", err)
+		return errorResponse(webCtx, "upstream_error", "model service is temporarily unavailable", http.StatusBadGateway)
+	}
+
+	if resp.ErrorCode != "" {
+		log.F(log.M{"user_id": user.ID, "key_id": key.Id, "model": req.Model}).Errorf("automation chat failed: %s", resp.Error)
+		return errorResponse(webCtx, "upstream_error", "model service is temporarily unavailable", http.StatusBadGateway)
+	}
+
+	realTokens, _ := chat.MessageTokenCount(append(chatReq.Messages, chat.Message{Role: "assistant", Content: resp.Text}), chatReq.Model)
+	consumed := coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), int64(realTokens))
+	if resp.Text != "" && consumed > 0 {
+		if err := ctl.quotaRep.QuotaConsume(ctx, user.ID, consumed, repo.NewQuotaUsedMeta("automation-chat", req.Model)); err != nil {
+			log.Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	ctl.recordUsage(ctx, key, EndpointChat, consumed)
+
+	return webCtx.JSON(ChatResponse{
+		Model:        req.Model,
+		Text:         resp.Text,
+		InputTokens:  int64(inputTokens),
+		OutputTokens: int64(realTokens - inputTokens),
+		Coins:        consumed,
+		CreatedAt:    time.Now().Unix(),
+	})
+}
+
+type ImageRequest struct {
+	Model  string `json:"model,omitempty"`
+	Prompt string `json:"prompt"`
+	Size   string `json:"size,omitempty"`
+	N      int    `json:"n,omitempty"`
+}
+
+type ImageResponse struct {
+	Model     string   `json:"model"`
+	Images    []string `json:"images"`
+	Coins     int64    `json:"coins"`
+	CreatedAt int64    `json:"created_at"`
+}
+
+// Images 同步生成图片，直接返回图片地址
+func (ctl *Controller) Images(ctx context.Context, webCtx web.Context, user *auth.User, cred *auth.APIKeyCredential) web.Response {
+	key, errResp := ctl.resolveAPIKey(ctx, webCtx, cred)
+	if errResp != nil {
+		return errResp
+	}
+
+	var req ImageRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return errorResponse(webCtx, "invalid_request", "invalid request body", http.StatusBadRequest)
+	}
+
+	req.Prompt = strings.TrimSpace(req.Prompt)
+	if req.Prompt == "" {
+		return errorResponse(webCtx, "invalid_request", "prompt is required", http.StatusBadRequest)
+	}
+
+	if req.N <= 0 {
+		req.N = 1
+	}
+
+	if req.N > 4 {
+		return errorResponse(webCtx, "invalid_request", "n must be between 1 and 4", http.StatusBadRequest)
+	}
+
+	if req.Model == "" {
+		req.Model = "dall-e-3"
+	}
+
+	if !array.In(req.Model, []string{"dall-e-2", "dall-e-3"}) {
+		return errorResponse(webCtx, "model_not_found", "model not found", http.StatusNotFound)
+	}
+
+	needCoins := int64(coins.GetUnifiedImageGenCoins(req.Model) * req.N)
+	unfreeze, errResp := ctl.freezeQuota(ctx, webCtx, user.ID, needCoins)
+	if errResp != nil {
+		return errResp
+	}
+	defer unfreeze()
+
+	resp, err := ctl.client.CreateImage(ctx, openai.ImageRequest{
+		Prompt:         req.Prompt,
+		Model:          req.Model,
+		N:              req.N,
+		Size:           req.Size,
+		ResponseFormat: openai.CreateImageResponseFormatURL,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "key_id": key.Id, "model": req.Model}).Errorf("automation image failed: %s", err)
+		return errorResponse(webCtx, "upstream_error", "image service is temporarily unavailable", http.StatusBadGateway)
+	}
+
+	if err := ctl.quotaRep.QuotaConsume(ctx, user.ID, needCoins, repo.NewQuotaUsedMeta("automation-image", req.Model)); err != nil {
+		log.Errorf("used quota add failed: %s", err)
+	}
+
+	ctl.recordUsage(ctx, key, EndpointImage, needCoins)
+
+	return webCtx.JSON(ImageResponse{
+		Model:     req.Model,
+		Images:    array.Map(resp.Data, func(item openai.ImageResponseDataInner, _ int) string { return item.URL }),
+		Coins:     needCoins,
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
+// Balance 查询账户智慧果余额
+func (ctl *Controller) Balance(ctx context.Context, webCtx web.Context, user *auth.User, cred *auth.APIKeyCredential) web.Response {
+	key, errResp := ctl.resolveAPIKey(ctx, webCtx, cred)
+	if errResp != nil {
+		return errResp
+	}
+
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return errorResponse(webCtx, "internal_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	ctl.recordUsage(ctx, key, EndpointBalance, 0)
+
+	return webCtx.JSON(web.M{
+		"balance":    quota.Rest - quota.Freezed,
+		"total":      quota.Quota,
+		"used":       quota.Used,
+		"freezed":    quota.Freezed,
+		"queried_at": time.Now().Unix(),
+	})
+}
+
+type UsageItem struct {
+	APIKeyID     int64  `json:"api_key_id"`
+	APIKeyName   string `json:"api_key_name,omitempty"`
+	Endpoint     string `json:"endpoint"`
+	Date         string `json:"date"`
+	RequestCount int64  `json:"request_count"`
+	Coins        int64  `json:"coins"`
+}
+
+// Usage 用量面板，返回当前用户所有 API Key 最近一段时间的调用次数与消耗
+func (ctl *Controller) Usage(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	days := webCtx.Int64Input("days", 7)
+	if days <= 0 || days > 90 {
+		return errorResponse(webCtx, "invalid_request", "days must be between 1 and 90", http.StatusBadRequest)
+	}
+
+	usages, err := ctl.userRepo.GetAPIKeyUsages(ctx, user.ID, days)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query api key usages failed: %s", err)
+		return errorResponse(webCtx, "internal_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	keys, err := ctl.userRepo.GetAPIKeys(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query api keys failed: %s", err)
+		return errorResponse(webCtx, "internal_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	keyNames := array.ToMap(keys, func(item model.UserApiKey, _ int) int64 { return item.Id })
+	items := array.Map(usages, func(item model.UserApiKeyUsage, _ int) UsageItem {
+		return UsageItem{
+			APIKeyID:     item.ApiKeyId,
+			APIKeyName:   keyNames[item.ApiKeyId].Name,
+			Endpoint:     item.Endpoint,
+			Date:         item.StatDate.Format("2006-01-02"),
+			RequestCount: item.RequestCount,
+			Coins:        item.Coins,
+		}
+	})
+
+	return webCtx.JSON(web.M{"data": items, "days": days})
+}
@@ -0,0 +1,317 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/must"
+)
+
+// Controller 批量聊天接口，接口设计参考 OpenAI Batch API
+type Controller struct {
+	conf      *config.Config
+	queue     *queue.Queue         `autowire:"@"`
+	userSrv   *service.UserService `autowire:"@"`
+	batchRepo *repo.BatchRepo      `autowire:"@"`
+}
+
+// NewController 创建批量聊天控制器
+func NewController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &Controller{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *Controller) Register(router web.Router) {
+	router.Group("/batches", func(router web.Router) {
+		router.Post("/", ctl.Create)
+		router.Get("/", ctl.Batches)
+		router.Get("/{id}", ctl.Batch)
+		router.Get("/{id}/results", ctl.Results)
+		router.Post("/{id}/cancel", ctl.Cancel)
+	})
+}
+
+type CreateRequest struct {
+	Model string        `json:"model"`
+	Items []RequestItem `json:"items"`
+}
+
+// RequestItem 批次中的单个请求
+type RequestItem struct {
+	CustomID string        `json:"custom_id,omitempty"`
+	Messages chat.Messages `json:"messages"`
+}
+
+// Batch 批次信息
+type Batch struct {
+	ID          string `json:"id"`
+	Object      string `json:"object"`
+	Model       string `json:"model"`
+	Status      string `json:"status"`
+	Total       int64  `json:"total"`
+	Succeed     int64  `json:"succeed"`
+	Failed      int64  `json:"failed"`
+	Coins       int64  `json:"coins"`
+	CreatedAt   int64  `json:"created_at"`
+	CompletedAt int64  `json:"completed_at,omitempty"`
+}
+
+var batchStatusText = map[int64]string{
+	repo.BatchStatusPending:   "pending",
+	repo.BatchStatusRunning:   "in_progress",
+	repo.BatchStatusSucceed:   "completed",
+	repo.BatchStatusFailed:    "failed",
+	repo.BatchStatusCancelled: "cancelled",
+}
+
+func buildBatch(batch model.ChatBatch) Batch {
+	ret := Batch{
+		ID:        fmt.Sprintf("batch_%d", batch.Id),
+		Object:    "batch",
+		Model:     batch.Model,
+		Status:    batchStatusText[batch.Status],
+		Total:     batch.Total,
+		Succeed:   batch.Succeed,
+		Failed:    batch.Failed,
+		Coins:     batch.Coins,
+		CreatedAt: batch.CreatedAt.Unix(),
+	}
+
+	if !batch.CompletedAt.IsZero() {
+		ret.CompletedAt = batch.CompletedAt.Unix()
+	}
+
+	return ret
+}
+
+// parseBatchID 解析批次 ID，支持 batch_123 与 123 两种格式
+func parseBatchID(id string) int64 {
+	var batchID int64
+	_, _ = fmt.Sscanf(strings.TrimPrefix(id, "batch_"), "%d", &batchID)
+	return batchID
+}
+
+// Create 提交一个批量聊天任务
+func (ctl *Controller) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req CreateRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError("invalid request body", http.StatusBadRequest)
+	}
+
+	if req.Model == "" {
+		return webCtx.JSONError("model is required", http.StatusBadRequest)
+	}
+
+	if !array.In(req.Model, array.Map(chat.Models(ctl.conf, false), func(item chat.Model, _ int) string { return item.RealID() })) {
+		return webCtx.JSONError("model not found", http.StatusNotFound)
+	}
+
+	if len(req.Items) == 0 || len(req.Items) > ctl.conf.BatchMaxItems {
+		return webCtx.JSONError(fmt.Sprintf("items must be between 1 and %d", ctl.conf.BatchMaxItems), http.StatusBadRequest)
+	}
+
+	// 预估所有条目输入部分的费用，余额不足时直接拒绝
+	var inputTokens int64
+	items := make([]repo.BatchItemAddReq, 0, len(req.Items))
+	for i, item := range req.Items {
+		item.Messages = array.Filter(item.Messages, func(msg chat.Message, _ int) bool { return strings.TrimSpace(msg.Content) != "" })
+		if len(item.Messages) == 0 {
+			return webCtx.JSONError(fmt.Sprintf("items[%d].messages is empty", i), http.StatusBadRequest)
+		}
+
+		cnt, err := chat.MessageTokenCount(item.Messages, req.Model)
+		if err != nil {
+			return webCtx.JSONError(fmt.Sprintf("items[%d]: %s", i, err), http.StatusBadRequest)
+		}
+
+		inputTokens += int64(cnt)
+		items = append(items, repo.BatchItemAddReq{
+			CustomID: item.CustomID,
+			Messages: string(must.Must(json.Marshal(item.Messages))),
+		})
+	}
+
+	needCoins := coins.GetBatchChatCoins(req.Model, inputTokens) + int64(len(req.Items))
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < needCoins {
+		return webCtx.JSONError("insufficient balance", http.StatusPaymentRequired)
+	}
+
+	batchID, err := ctl.batchRepo.CreateBatch(ctx, user.ID, req.Model, items)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("create batch failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	// 冻结预估的智慧果，任务执行完毕后释放
+	var freezedCoins int64
+	if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, needCoins); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": needCoins}).Errorf("freeze user quota failed: %s", err)
+	} else {
+		freezedCoins = needCoins
+	}
+
+	payload := queue.BatchChatPayload{
+		BatchID:      batchID,
+		UserID:       user.ID,
+		OrgID:        repo.BillingOrgFromContext(ctx),
+		Model:        req.Model,
+		CreatedAt:    time.Now(),
+		FreezedCoins: freezedCoins,
+	}
+
+	taskID, err := ctl.queue.Enqueue(&payload, queue.NewBatchChatTask, asynq.Queue(queue.BatchQueueName), asynq.Timeout(24*time.Hour))
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "batch_id": batchID}).Errorf("enqueue batch task failed: %s", err)
+		if freezedCoins > 0 {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, freezedCoins); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": freezedCoins}).Errorf("unfreeze user quota failed: %s", err)
+			}
+		}
+
+		if err := ctl.batchRepo.UpdateBatchStatus(ctx, batchID, repo.BatchStatusFailed); err != nil {
+			log.F(log.M{"batch_id": batchID}).Errorf("update batch status failed: %s", err)
+		}
+
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	if err := ctl.batchRepo.UpdateBatchTaskID(ctx, batchID, taskID); err != nil {
+		log.F(log.M{"batch_id": batchID, "task_id": taskID}).Errorf("update batch task id failed: %s", err)
+	}
+
+	batch, err := ctl.batchRepo.GetBatch(ctx, user.ID, batchID)
+	if err != nil {
+		log.F(log.M{"batch_id": batchID}).Errorf("query batch failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildBatch(*batch))
+}
+
+// Batches 获取最近的批次列表
+func (ctl *Controller) Batches(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	limit := webCtx.Int64Input("limit", 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	batches, err := ctl.batchRepo.GetBatches(ctx, user.ID, limit)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query batches failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"object": "list",
+		"data":   array.Map(batches, func(item model.ChatBatch, _ int) Batch { return buildBatch(item) }),
+	})
+}
+
+// Batch 获取批次状态
+func (ctl *Controller) Batch(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	batch, err := ctl.batchRepo.GetBatch(ctx, user.ID, parseBatchID(webCtx.PathVar("id")))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError("batch not found", http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("query batch failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildBatch(*batch))
+}
+
+// Result 批次中单个条目的处理结果
+type Result struct {
+	ID           int64  `json:"id"`
+	CustomID     string `json:"custom_id,omitempty"`
+	Status       string `json:"status"`
+	Output       string `json:"output,omitempty"`
+	InputTokens  int64  `json:"input_tokens"`
+	OutputTokens int64  `json:"output_tokens"`
+	Coins        int64  `json:"coins"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Results 获取批次中每个条目的状态与输出
+func (ctl *Controller) Results(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	batch, err := ctl.batchRepo.GetBatch(ctx, user.ID, parseBatchID(webCtx.PathVar("id")))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError("batch not found", http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("query batch failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	items, err := ctl.batchRepo.GetBatchItems(ctx, batch.Id)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "batch_id": batch.Id}).Errorf("query batch items failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"object": "list",
+		"batch":  buildBatch(*batch),
+		"data": array.Map(items, func(item model.ChatBatchItem, _ int) Result {
+			return Result{
+				ID:           item.Id,
+				CustomID:     item.CustomId,
+				Status:       batchStatusText[item.Status],
+				Output:       item.Output,
+				InputTokens:  item.InputTokens,
+				OutputTokens: item.OutputTokens,
+				Coins:        item.Coins,
+				Error:        item.Error,
+			}
+		}),
+	})
+}
+
+// Cancel 取消批次，已处理完成的条目不受影响
+func (ctl *Controller) Cancel(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	batchID := parseBatchID(webCtx.PathVar("id"))
+	if err := ctl.batchRepo.CancelBatch(ctx, user.ID, batchID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError("batch not found or already finished", http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "batch_id": batchID}).Errorf("cancel batch failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	batch, err := ctl.batchRepo.GetBatch(ctx, user.ID, batchID)
+	if err != nil {
+		log.F(log.M{"batch_id": batchID}).Errorf("query batch failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildBatch(*batch))
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/glacier/web"
+)
+
+// keywordFilterContent 开放 API 中需要执行关键词过滤的用户输入，不需要过滤时返回空
+//
+// 聊天（/v1/chat/completions）在处理器中过滤，打码规则以及模型输出的过滤由聊天客户端统一执行
+func keywordFilterContent(webCtx web.Context) string {
+	if webCtx.Method() != http.MethodPost {
+		return ""
+	}
+
+	switch strings.TrimSuffix(webCtx.Request().Raw().URL.Path, "/") {
+	case "/v1/automation/chat":
+		return strings.TrimSpace(webCtx.Input("system_prompt") + "\n" + webCtx.Input("prompt"))
+	case "/v1/automation/images", "/v1/images/generations":
+		return strings.TrimSpace(webCtx.Input("prompt"))
+	case "/v1/batches":
+		// 批量聊天的每个条目都是独立的对话，分别取最后一条消息
+		var req struct {
+			Items []struct {
+				Messages chat.Messages `json:"messages"`
+			} `json:"items"`
+		}
+		if err := webCtx.Unmarshal(&req); err != nil {
+			return ""
+		}
+
+		contents := make([]string, 0, len(req.Items))
+		for _, item := range req.Items {
+			if len(item.Messages) > 0 {
+				contents = append(contents, item.Messages[len(item.Messages)-1].Content)
+			}
+		}
+
+		return strings.TrimSpace(strings.Join(contents, "\n"))
+	}
+
+	return ""
+}
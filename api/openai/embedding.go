@@ -0,0 +1,201 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/embedding"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// EmbeddingRequest 兼容 OpenAI 的 Embedding 请求
+type EmbeddingRequest struct {
+	Model string          `json:"model"`
+	Input json.RawMessage `json:"input"`
+	// Collection 可选，指定后会将输入文本及其向量保存到用户的向量集合中，集合不存在时自动创建
+	Collection string `json:"collection,omitempty"`
+	// Metadata 可选，保存到向量集合时附加的元数据，与 Input 一一对应
+	Metadata []map[string]any `json:"metadata,omitempty"`
+}
+
+// Inputs 解析输入，支持单个字符串或字符串数组
+func (req EmbeddingRequest) Inputs() ([]string, error) {
+	var single string
+	if err := json.Unmarshal(req.Input, &single); err == nil {
+		return []string{single}, nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(req.Input, &multi); err != nil {
+		return nil, errors.New("input must be a string or an array of strings")
+	}
+
+	return multi, nil
+}
+
+type EmbeddingData struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+	// DocumentID 保存到向量集合时对应的文档 ID
+	DocumentID int64 `json:"document_id,omitempty"`
+}
+
+type EmbeddingUsage struct {
+	PromptTokens int64 `json:"prompt_tokens"`
+	TotalTokens  int64 `json:"total_tokens"`
+}
+
+type EmbeddingResponse struct {
+	Object string          `json:"object"`
+	Data   []EmbeddingData `json:"data"`
+	Model  string          `json:"model"`
+	Usage  EmbeddingUsage  `json:"usage"`
+}
+
+func openaiError(webCtx web.Context, typ string, message string, statusCode int) web.Response {
+	return webCtx.JSONWithCode(web.M{"error": web.M{"message": message, "type": typ}}, statusCode)
+}
+
+// Embeddings 文本向量化
+// https://platform.openai.com/docs/api-reference/embeddings/create
+func (ctl *CompatibleController) Embeddings(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req EmbeddingRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return openaiError(webCtx, "invalid_request_error", "invalid request body", http.StatusBadRequest)
+	}
+
+	if !ctl.embed.Support(req.Model) {
+		return openaiError(webCtx, "invalid_request_error", "model not found", http.StatusNotFound)
+	}
+
+	inputs, err := req.Inputs()
+	if err != nil {
+		return openaiError(webCtx, "invalid_request_error", err.Error(), http.StatusBadRequest)
+	}
+
+	if len(inputs) == 0 || len(array.Filter(inputs, func(item string, _ int) bool { return strings.TrimSpace(item) == "" })) > 0 {
+		return openaiError(webCtx, "invalid_request_error", "input must not be empty", http.StatusBadRequest)
+	}
+
+	if ctl.conf.EmbeddingMaxInputs > 0 && len(inputs) > ctl.conf.EmbeddingMaxInputs {
+		return openaiError(webCtx, "invalid_request_error", "too many inputs", http.StatusBadRequest)
+	}
+
+	req.Collection = strings.TrimSpace(req.Collection)
+	if len(req.Metadata) > 0 && len(req.Metadata) != len(inputs) {
+		return openaiError(webCtx, "invalid_request_error", "metadata must match input length", http.StatusBadRequest)
+	}
+
+	resp, errResp := ctl.embedWithBilling(ctx, webCtx, user.ID, req.Model, inputs)
+	if errResp != nil {
+		return errResp
+	}
+
+	data := make([]EmbeddingData, len(resp.Data))
+	for i, vec := range resp.Data {
+		data[i] = EmbeddingData{Object: "embedding", Index: i, Embedding: vec}
+	}
+
+	if req.Collection != "" {
+		docIDs, err := ctl.storeEmbeddings(ctx, user.ID, req, inputs, resp)
+		if err != nil {
+			if errors.Is(err, repo.ErrVectorCollectionMismatch) {
+				return openaiError(webCtx, "invalid_request_error", "collection model or dimension mismatch", http.StatusBadRequest)
+			}
+
+			log.F(log.M{"user_id": user.ID, "collection": req.Collection}).Errorf("store embeddings failed: %s", err)
+			return openaiError(webCtx, "server_error", "store embeddings failed", http.StatusInternalServerError)
+		}
+
+		for i := range data {
+			data[i].DocumentID = docIDs[i]
+		}
+	}
+
+	return webCtx.JSON(EmbeddingResponse{
+		Object: "list",
+		Data:   data,
+		Model:  req.Model,
+		Usage:  EmbeddingUsage{PromptTokens: resp.Tokens, TotalTokens: resp.Tokens},
+	})
+}
+
+// storeEmbeddings 将向量化结果保存到用户的向量集合
+func (ctl *CompatibleController) storeEmbeddings(ctx context.Context, userID int64, req EmbeddingRequest, inputs []string, resp *embedding.Response) ([]int64, error) {
+	var dimension int64
+	if len(resp.Data) > 0 {
+		dimension = int64(len(resp.Data[0]))
+	}
+
+	col, err := ctl.vectorRepo.GetOrCreateCollection(ctx, userID, req.Collection, req.Model, dimension)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]repo.VectorDocumentAddReq, len(inputs))
+	for i, input := range inputs {
+		docs[i] = repo.VectorDocumentAddReq{Content: input, Vector: resp.Data[i]}
+		if len(req.Metadata) > 0 {
+			docs[i].Metadata = req.Metadata[i]
+		}
+	}
+
+	return ctl.vectorRepo.AddDocuments(ctx, userID, col.Id, docs)
+}
+
+// embedWithBilling 执行文本向量化，并按照实际消耗的 Token 扣除智慧果
+func (ctl *CompatibleController) embedWithBilling(ctx context.Context, webCtx web.Context, userID int64, modelID string, inputs []string) (*embedding.Response, web.Response) {
+	// 按照字数预估本次请求消耗的智慧果
+	estimated := coins.GetEmbeddingCoins(modelID, array.Reduce(inputs, func(carry int64, item string) int64 {
+		return carry + misc.WordCount(item)
+	}, 0))
+
+	quota, err := ctl.userSrv.UserQuota(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return nil, openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < estimated {
+		return nil, openaiError(webCtx, "insufficient_quota", "insufficient balance", http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, userID, estimated); err != nil {
+		log.F(log.M{"user_id": userID, "quota": estimated}).Errorf("freeze user quota failed: %s", err)
+	} else {
+		defer func(ctx context.Context) {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, userID, estimated); err != nil {
+				log.F(log.M{"user_id": userID, "quota": estimated}).Errorf("unfreeze user quota failed: %s", err)
+			}
+		}(ctx)
+	}
+
+	embedCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	resp, err := ctl.embed.Embedding(embedCtx, embedding.Request{Model: modelID, Input: inputs})
+	if err != nil {
+		log.F(log.M{"user_id": userID, "model": modelID}).Errorf("embedding failed: %s", err)
+		return nil, openaiError(webCtx, "server_error", "model service is temporarily unavailable", http.StatusBadGateway)
+	}
+
+	consumed := coins.GetEmbeddingCoins(modelID, resp.Tokens)
+	if consumed > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, userID, consumed, repo.NewQuotaUsedMeta("embedding", modelID)); err != nil {
+			log.F(log.M{"user_id": userID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	return resp, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"github.com/mylxsw/aidea-server/config"
 	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/ai/embedding"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/glacier/infra"
 	"github.com/mylxsw/glacier/web"
 	"github.com/mylxsw/go-utils/array"
@@ -11,7 +14,11 @@ import (
 )
 
 type CompatibleController struct {
-	conf *config.Config `autowire:"@"`
+	conf       *config.Config       `autowire:"@"`
+	embed      embedding.Embedding  `autowire:"@"`
+	userSrv    *service.UserService `autowire:"@"`
+	quotaRepo  *repo.QuotaRepo      `autowire:"@"`
+	vectorRepo *repo.VectorRepo     `autowire:"@"`
 }
 
 func NewOpenAICompatibleController(resolver infra.Resolver) web.Controller {
@@ -25,6 +32,21 @@ func (ctl *CompatibleController) Register(router web.Router) {
 		router.Get("/", ctl.Models)
 		router.Get("/{model_id}", ctl.Model)
 	})
+
+	router.Group("/embeddings", func(router web.Router) {
+		router.Post("/", ctl.Embeddings)
+	})
+
+	// 向量集合
+	router.Group("/collections", func(router web.Router) {
+		router.Post("/", ctl.CreateCollection)
+		router.Get("/", ctl.Collections)
+		router.Get("/{name}", ctl.Collection)
+		router.Delete("/{name}", ctl.DeleteCollection)
+		router.Post("/{name}/documents", ctl.AddDocuments)
+		router.Delete("/{name}/documents/{id}", ctl.DeleteDocument)
+		router.Post("/{name}/query", ctl.Query)
+	})
 }
 
 type Model struct {
@@ -0,0 +1,274 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/embedding"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// Collection 向量集合
+type Collection struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Model       string `json:"model"`
+	Dimension   int64  `json:"dimension"`
+	DocCount    int64  `json:"doc_count"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func buildCollection(col model.VectorCollection) Collection {
+	return Collection{
+		Name:        col.Name,
+		Description: col.Description,
+		Model:       col.Model,
+		Dimension:   col.Dimension,
+		DocCount:    col.DocCount,
+		CreatedAt:   col.CreatedAt.Unix(),
+	}
+}
+
+// resolveCollection 查询当前用户的向量集合
+func (ctl *CompatibleController) resolveCollection(ctx context.Context, webCtx web.Context, user *auth.User) (*model.VectorCollection, web.Response) {
+	col, err := ctl.vectorRepo.GetCollectionByName(ctx, user.ID, webCtx.PathVar("name"))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, openaiError(webCtx, "invalid_request_error", "collection not found", http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "collection": webCtx.PathVar("name")}).Errorf("query vector collection failed: %s", err)
+		return nil, openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	return col, nil
+}
+
+type CreateCollectionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Model       string `json:"model"`
+}
+
+// CreateCollection 创建向量集合
+func (ctl *CompatibleController) CreateCollection(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req CreateCollectionRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return openaiError(webCtx, "invalid_request_error", "invalid request body", http.StatusBadRequest)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 100 {
+		return openaiError(webCtx, "invalid_request_error", "invalid collection name", http.StatusBadRequest)
+	}
+
+	if !ctl.embed.Support(req.Model) {
+		return openaiError(webCtx, "invalid_request_error", "model not found", http.StatusNotFound)
+	}
+
+	col, err := ctl.vectorRepo.CreateCollection(ctx, user.ID, req.Name, req.Description, req.Model)
+	if err != nil {
+		if errors.Is(err, repo.ErrVectorCollectionExists) {
+			return openaiError(webCtx, "invalid_request_error", "collection already exists", http.StatusConflict)
+		}
+
+		log.F(log.M{"user_id": user.ID, "collection": req.Name}).Errorf("create vector collection failed: %s", err)
+		return openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildCollection(*col))
+}
+
+// Collections 获取当前用户的向量集合列表
+func (ctl *CompatibleController) Collections(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cols, err := ctl.vectorRepo.GetCollections(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query vector collections failed: %s", err)
+		return openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"object": "list",
+		"data":   array.Map(cols, func(item model.VectorCollection, _ int) Collection { return buildCollection(item) }),
+	})
+}
+
+// Collection 获取向量集合详情
+func (ctl *CompatibleController) Collection(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	col, errResp := ctl.resolveCollection(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	return webCtx.JSON(buildCollection(*col))
+}
+
+// DeleteCollection 删除向量集合
+func (ctl *CompatibleController) DeleteCollection(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	col, errResp := ctl.resolveCollection(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := ctl.vectorRepo.DeleteCollection(ctx, user.ID, col.Id); err != nil {
+		log.F(log.M{"user_id": user.ID, "collection": col.Name}).Errorf("delete vector collection failed: %s", err)
+		return openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"name": col.Name, "deleted": true})
+}
+
+type DocumentAddRequest struct {
+	Documents []struct {
+		Content  string         `json:"content"`
+		Metadata map[string]any `json:"metadata,omitempty"`
+	} `json:"documents"`
+}
+
+// AddDocuments 向集合中添加文档，文档内容会使用集合指定的模型向量化
+func (ctl *CompatibleController) AddDocuments(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	col, errResp := ctl.resolveCollection(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	var req DocumentAddRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return openaiError(webCtx, "invalid_request_error", "invalid request body", http.StatusBadRequest)
+	}
+
+	if len(req.Documents) == 0 {
+		return openaiError(webCtx, "invalid_request_error", "documents must not be empty", http.StatusBadRequest)
+	}
+
+	if ctl.conf.EmbeddingMaxInputs > 0 && len(req.Documents) > ctl.conf.EmbeddingMaxInputs {
+		return openaiError(webCtx, "invalid_request_error", "too many documents", http.StatusBadRequest)
+	}
+
+	if ctl.conf.VectorCollectionMaxDocs > 0 && col.DocCount+int64(len(req.Documents)) > int64(ctl.conf.VectorCollectionMaxDocs) {
+		return openaiError(webCtx, "invalid_request_error", "collection document limit exceeded", http.StatusBadRequest)
+	}
+
+	inputs := make([]string, len(req.Documents))
+	for i, doc := range req.Documents {
+		inputs[i] = strings.TrimSpace(doc.Content)
+		if inputs[i] == "" {
+			return openaiError(webCtx, "invalid_request_error", "document content must not be empty", http.StatusBadRequest)
+		}
+	}
+
+	resp, errResp := ctl.embedWithBilling(ctx, webCtx, user.ID, col.Model, inputs)
+	if errResp != nil {
+		return errResp
+	}
+
+	if col.Dimension > 0 && len(resp.Data) > 0 && int64(len(resp.Data[0])) != col.Dimension {
+		return openaiError(webCtx, "invalid_request_error", "collection model or dimension mismatch", http.StatusBadRequest)
+	}
+
+	docs := make([]repo.VectorDocumentAddReq, len(inputs))
+	for i, input := range inputs {
+		docs[i] = repo.VectorDocumentAddReq{Content: input, Metadata: req.Documents[i].Metadata, Vector: resp.Data[i]}
+	}
+
+	// 集合创建时未指定维度，以第一次写入的向量维度为准
+	if col.Dimension == 0 && len(resp.Data) > 0 {
+		if err := ctl.vectorRepo.UpdateCollectionDimension(ctx, col.Id, int64(len(resp.Data[0]))); err != nil {
+			log.F(log.M{"user_id": user.ID, "collection": col.Name}).Errorf("update vector collection dimension failed: %s", err)
+		}
+	}
+
+	ids, err := ctl.vectorRepo.AddDocuments(ctx, user.ID, col.Id, docs)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "collection": col.Name}).Errorf("add vector documents failed: %s", err)
+		return openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"object": "list", "data": ids})
+}
+
+// DeleteDocument 从集合中删除文档
+func (ctl *CompatibleController) DeleteDocument(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	col, errResp := ctl.resolveCollection(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	docID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return openaiError(webCtx, "invalid_request_error", "document not found", http.StatusNotFound)
+	}
+
+	if err := ctl.vectorRepo.DeleteDocument(ctx, user.ID, col.Id, int64(docID)); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return openaiError(webCtx, "invalid_request_error", "document not found", http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "collection": col.Name, "doc_id": docID}).Errorf("delete vector document failed: %s", err)
+		return openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": docID, "deleted": true})
+}
+
+type QueryRequest struct {
+	Query    string  `json:"query"`
+	TopK     int     `json:"top_k,omitempty"`
+	MinScore float64 `json:"min_score,omitempty"`
+}
+
+type QueryResult struct {
+	ID       int64          `json:"id"`
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+	Score    float64        `json:"score"`
+}
+
+// Query 在集合中按照相似度检索文档
+func (ctl *CompatibleController) Query(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	col, errResp := ctl.resolveCollection(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	var req QueryRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return openaiError(webCtx, "invalid_request_error", "invalid request body", http.StatusBadRequest)
+	}
+
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		return openaiError(webCtx, "invalid_request_error", "query must not be empty", http.StatusBadRequest)
+	}
+
+	if req.TopK <= 0 || req.TopK > 100 {
+		req.TopK = 5
+	}
+
+	resp, errResp := ctl.embedWithBilling(ctx, webCtx, user.ID, col.Model, []string{req.Query})
+	if errResp != nil {
+		return errResp
+	}
+
+	docs, err := ctl.vectorRepo.GetDocuments(ctx, col.Id)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "collection": col.Name}).Errorf("query vector documents failed: %s", err)
+		return openaiError(webCtx, "server_error", "internal server error", http.StatusInternalServerError)
+	}
+
+	vectors := array.Map(docs, func(item repo.VectorDocument, _ int) []float32 { return item.Vector })
+	results := array.Map(embedding.TopK(resp.Data[0], vectors, req.TopK, req.MinScore), func(item embedding.Scored, _ int) QueryResult {
+		doc := docs[item.Index]
+		return QueryResult{ID: doc.ID, Content: doc.Content, Metadata: doc.Metadata, Score: item.Score}
+	})
+
+	return webCtx.JSON(web.M{"object": "list", "data": results})
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis_rate/v10"
+	"github.com/mylxsw/aidea-server/api/automation"
+	"github.com/mylxsw/aidea-server/api/batch"
 	"github.com/mylxsw/aidea-server/api/billing"
 	"github.com/mylxsw/aidea-server/api/openai"
 	"github.com/mylxsw/aidea-server/pkg/rate"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/aidea-server/pkg/token"
+	"github.com/mylxsw/aidea-server/pkg/trace"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"net/http"
 	"runtime/debug"
@@ -29,6 +32,9 @@ import (
 
 var ErrUserDestroyed = errors.New("user is destroyed")
 
+// contextKeyRequestContext 鉴权之后绑定到请求上的 context，携带请求关联的用户
+const contextKeyRequestContext = "aidea-request-context"
+
 type Provider struct{}
 
 func (Provider) ShouldLoad(c infra.FlagContext) bool {
@@ -73,7 +79,7 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 	}
 
 	// 添加 web 中间件
-	resolver.MustResolve(func(tk *token.Token, userSrv *service.UserService, limiter *redis_rate.Limiter, translater youdao.Translater) {
+	resolver.MustResolve(func(tk *token.Token, userSrv *service.UserService, keywordFilterSrv *service.KeywordFilterService, limiter *redis_rate.Limiter, translater youdao.Translater) {
 		mws = append(mws, mw.BeforeInterceptor(func(webCtx web.Context) web.Response {
 			// 跨域请求处理，OPTIONS 请求直接返回
 			if webCtx.Method() == http.MethodOptions {
@@ -155,6 +161,11 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 				}
 
 				webCtx.Provide(func() *auth.User { return user })
+				// 请求关联的用户，模型输出的关键词命中统计记录在该用户下
+				reqCtx := trace.WithUserID(webCtx.Context(), user.ID)
+				webCtx.Set(contextKeyRequestContext, reqCtx)
+				webCtx.Provide(func() context.Context { return reqCtx })
+				webCtx.Provide(func() *auth.APIKeyCredential { return &auth.APIKeyCredential{Token: credential} })
 				webCtx.Provide(func() *auth.UserOptional {
 					return &auth.UserOptional{User: user}
 				})
@@ -162,6 +173,27 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 				return nil
 			}),
 		)
+
+		mws = append(mws, mw.BeforeInterceptor(func(webCtx web.Context) web.Response {
+			// 部署方自定义的关键词过滤，用户输入命中拦截规则时在请求分发之前统一拒绝，需要在鉴权之后执行
+			content := keywordFilterContent(webCtx)
+			if content == "" {
+				return nil
+			}
+
+			reqCtx, ok := webCtx.Get(contextKeyRequestContext).(context.Context)
+			if !ok {
+				return nil
+			}
+
+			userID := trace.UserID(reqCtx)
+			if res := keywordFilterSrv.Filter(reqCtx, userID, repo2.KeywordFilterScopePrompt, content); res.Blocked {
+				log.F(log.M{"user_id": userID, "hits": res.Hits}).Warningf("用户 %d 的请求命中关键词拦截规则", userID)
+				return webCtx.JSONError(common.Text(webCtx, translater, "内容违规，已被系统拦截，如有疑问邮件联系：support@aicode.cc"), http.StatusNotAcceptable)
+			}
+
+			return nil
+		}))
 	})
 
 	// 注册控制器，所有的控制器 API 都以 `/server` 作为接口前缀
@@ -170,6 +202,8 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 		"/v1",
 		controllers.NewOpenAIController(resolver, conf, true),
 		openai.NewOpenAICompatibleController(resolver),
+		automation.NewController(resolver, conf),
+		batch.NewController(resolver, conf),
 	)
 
 	r.Controllers(
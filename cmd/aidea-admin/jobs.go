@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/spf13/cobra"
+)
+
+// queueNames 服务端消费的全部队列
+var queueNames = []string{"default", "mail", "user", queue.BatchQueueName}
+
+func jobsCommand(opt *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "异步任务管理",
+	}
+
+	var queues []string
+	requeue := &cobra.Command{
+		Use:   "requeue",
+		Short: "将重试次数耗尽的死信任务重新加入队列",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inspector := asynq.NewInspector(asynq.RedisClientOpt{
+				Addr:     opt.config().RedisAddr(),
+				Password: opt.redisPassword,
+			})
+			defer inspector.Close()
+
+			var total int
+			for _, name := range queues {
+				n, err := inspector.RunAllArchivedTasks(name)
+				if err != nil {
+					// 队列中还没有任何任务时，Redis 中不存在该队列
+					if errors.Is(err, asynq.ErrQueueNotFound) {
+						continue
+					}
+
+					return fmt.Errorf("requeue archived tasks in queue %s failed: %w", name, err)
+				}
+
+				cmd.Printf("%s: %d\n", name, n)
+				total += n
+			}
+
+			cmd.Printf("共 %d 个任务重新加入队列\n", total)
+			return nil
+		},
+	}
+	requeue.Flags().StringSliceVar(&queues, "queue", queueNames, "队列名称")
+
+	cmd.AddCommand(requeue)
+	return cmd
+}
@@ -0,0 +1,175 @@
+// aidea-admin 服务端管理工具，用于无界面的私有化部署场景执行常见的管理操作
+//
+// 默认直接连接数据库与 Redis，连接信息可以通过 --conf 读取服务端的配置文件；
+// 部分命令在指定 --server 与 --token 后通过管理员接口完成操作
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/migrate"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// options 全局选项，与服务端配置文件中的配置项同名
+type options struct {
+	conf string
+
+	dbURI         string
+	redisHost     string
+	redisPort     int
+	redisPassword string
+
+	server string
+	token  string
+
+	encryptionKeys        []string
+	encryptionActiveKey   string
+	encryptionVaultServer string
+	encryptionVaultToken  string
+}
+
+func main() {
+	if err := rootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func rootCommand() *cobra.Command {
+	opt := &options{}
+	root := &cobra.Command{
+		Use:          "aidea-admin",
+		Short:        "AIdea 服务端管理工具",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opt.loadConfigFile(cmd.Flags())
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opt.conf, "conf", "", "服务端配置文件路径，未通过命令行指定的连接信息从配置文件中读取")
+	flags.StringVar(&opt.dbURI, "db-uri", "root:12345@tcp(127.0.0.1:3306)/aiserver?charset=utf8mb4&parseTime=True&loc=Local", "数据库连接地址")
+	flags.StringVar(&opt.redisHost, "redis-host", "127.0.0.1", "Redis 地址")
+	flags.IntVar(&opt.redisPort, "redis-port", 6379, "Redis 端口")
+	flags.StringVar(&opt.redisPassword, "redis-password", "", "Redis 密码")
+	flags.StringVar(&opt.server, "server", "", "服务端地址，例如 https://ai.example.com，指定后支持的命令通过管理员接口完成操作")
+	flags.StringVar(&opt.token, "token", "", "管理员用户的登录 Token，与 --server 一起使用")
+	flags.StringSliceVar(&opt.encryptionKeys, "message-encryption-keys", nil, "消息加密的主密钥，格式为 <主密钥 ID>:<base64 编码的 32 字节密钥>")
+	flags.StringVar(&opt.encryptionActiveKey, "message-encryption-active-key", "", "加密消息使用的主密钥 ID")
+	flags.StringVar(&opt.encryptionVaultServer, "message-encryption-vault-server", "", "Vault 服务地址")
+	flags.StringVar(&opt.encryptionVaultToken, "message-encryption-vault-token", "", "Vault 访问 Token")
+
+	root.AddCommand(
+		userCommand(opt),
+		coinsCommand(opt),
+		modelCommand(opt),
+		jobsCommand(opt),
+		messagesCommand(opt),
+		migrateCommand(opt),
+	)
+
+	return root
+}
+
+// loadConfigFile 从服务端配置文件中加载未通过命令行指定的选项
+func (opt *options) loadConfigFile(flags *pflag.FlagSet) error {
+	if opt.conf == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(opt.conf)
+	if err != nil {
+		return fmt.Errorf("read config file failed: %w", err)
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("parse config file failed: %w", err)
+	}
+
+	for _, name := range []string{
+		"db-uri", "redis-host", "redis-port", "redis-password",
+		"message-encryption-keys", "message-encryption-active-key", "message-encryption-vault-server", "message-encryption-vault-token",
+	} {
+		val, ok := values[name]
+		if !ok || flags.Changed(name) {
+			continue
+		}
+
+		// 列表类型的配置项，例如 message-encryption-keys
+		if items, ok := val.([]any); ok {
+			val = strings.Join(array.Map(items, func(item any, _ int) string { return fmt.Sprintf("%v", item) }), ",")
+		}
+
+		if err := flags.Set(name, fmt.Sprintf("%v", val)); err != nil {
+			return fmt.Errorf("invalid config %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// apiMode 是否通过管理员接口完成操作
+func (opt *options) apiMode() bool {
+	return opt.server != ""
+}
+
+func (opt *options) config() *config.Config {
+	return &config.Config{
+		DBURI:         opt.dbURI,
+		RedisHost:     opt.redisHost,
+		RedisPort:     opt.redisPort,
+		RedisPassword: opt.redisPassword,
+
+		EnableMessageEncryption:      opt.encryptionActiveKey != "",
+		MessageEncryptionKeys:        opt.encryptionKeys,
+		MessageEncryptionActiveKey:   opt.encryptionActiveKey,
+		MessageEncryptionVaultServer: opt.encryptionVaultServer,
+		MessageEncryptionVaultToken:  opt.encryptionVaultToken,
+	}
+}
+
+func (opt *options) openDB() (*sql.DB, error) {
+	db, err := sql.Open("mysql", opt.dbURI)
+	if err != nil {
+		return nil, fmt.Errorf("数据库连接失败: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("数据库连接失败: %w", err)
+	}
+
+	return db, nil
+}
+
+func migrateCommand(opt *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "执行数据库迁移",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := opt.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			if err := migrate.Migrate(cmd.Context(), db); err != nil {
+				return fmt.Errorf("数据库迁移失败: %w", err)
+			}
+
+			cmd.Println("数据库迁移完成")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/pkg/msgcrypt"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+func messagesCommand(opt *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "messages",
+		Short: "聊天消息管理",
+	}
+
+	var tables []string
+	var startID, batchSize int64
+	encrypt := &cobra.Command{
+		Use:   "encrypt",
+		Short: "使用当前主密钥加密历史消息，轮换主密钥后用于重新加密使用旧主密钥加密的消息",
+		Long:  "需要通过 --message-encryption-active-key 或配置文件指定当前使用的主密钥，旧的主密钥需要保留在 --message-encryption-keys 中用于解密",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opt.encryptionActiveKey == "" {
+				return fmt.Errorf("请指定 --message-encryption-active-key")
+			}
+
+			cipher, err := msgcrypt.NewFromConfig(opt.config())
+			if err != nil {
+				return err
+			}
+
+			db, err := opt.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			msgRepo := repo.NewMessageRepo(db, cipher)
+			for _, table := range tables {
+				lastID, total, err := msgRepo.EncryptHistory(cmd.Context(), table, startID, batchSize, func(lastID, encrypted int64) {
+					cmd.Printf("%s: 已处理至 ID %d，加密 %d 条\n", table, lastID, encrypted)
+				})
+				if err != nil {
+					// 中断后可以通过 --start-id 从中断的位置继续执行
+					return fmt.Errorf("%s: 处理至 ID %d 时失败: %w", table, lastID, err)
+				}
+
+				cmd.Printf("%s: 完成，共加密 %d 条消息\n", table, total)
+			}
+
+			return nil
+		},
+	}
+	encrypt.Flags().StringSliceVar(&tables, "table", repo.EncryptedMessageTables, "需要加密的表")
+	encrypt.Flags().Int64Var(&startID, "start-id", 0, "从该消息 ID 之后开始处理")
+	encrypt.Flags().Int64Var(&batchSize, "batch", 500, "每批处理的消息数量")
+
+	cmd.AddCommand(encrypt)
+	return cmd
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+func modelCommand(opt *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "model",
+		Short: "模型管理，禁用的模型不再出现在客户端模型列表中，也无法再用于聊天",
+	}
+
+	var reason string
+	disable := &cobra.Command{
+		Use:   "disable <model-id>",
+		Short: "禁用模型，模型 ID 格式为 分类:模型，例如 openai:gpt-4",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opt.apiMode() {
+				return opt.callAdminAPI(cmd.Context(), http.MethodPost, "/disabled-models", url.Values{"model": {args[0]}, "reason": {reason}}, nil)
+			}
+
+			return opt.withDisabledModelRepo(func(rep *repo.DisabledModelRepo) error {
+				return rep.Disable(cmd.Context(), args[0], reason)
+			})
+		},
+	}
+	disable.Flags().StringVar(&reason, "reason", "", "禁用原因")
+
+	enable := &cobra.Command{
+		Use:   "enable <model-id>",
+		Short: "取消禁用模型",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opt.apiMode() {
+				return opt.callAdminAPI(cmd.Context(), http.MethodDelete, "/disabled-models", url.Values{"model": {args[0]}}, nil)
+			}
+
+			return opt.withDisabledModelRepo(func(rep *repo.DisabledModelRepo) error {
+				return rep.Enable(cmd.Context(), args[0])
+			})
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "查看被禁用的模型",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var models []repo.DisabledModel
+			if opt.apiMode() {
+				var res struct {
+					Data []repo.DisabledModel `json:"data"`
+				}
+				if err := opt.callAdminAPI(cmd.Context(), http.MethodGet, "/disabled-models", nil, &res); err != nil {
+					return err
+				}
+				models = res.Data
+			} else if err := opt.withDisabledModelRepo(func(rep *repo.DisabledModelRepo) (err error) {
+				models, err = rep.DisabledModels(cmd.Context())
+				return err
+			}); err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "MODEL\tDISABLED AT\tREASON")
+			for _, m := range models {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", m.ModelID, m.CreatedAt.Format("2006-01-02 15:04:05"), m.Reason)
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.AddCommand(disable, enable, list)
+	return cmd
+}
+
+// withDisabledModelRepo 直接连接数据库操作，服务端会在一分钟内加载最新的禁用模型列表
+func (opt *options) withDisabledModelRepo(cb func(rep *repo.DisabledModelRepo) error) error {
+	db, err := opt.openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return cb(repo.NewDisabledModelRepo(db, opt.config()))
+}
+
+// callAdminAPI 调用服务端的管理员接口，path 为 /v1/admin 之后的部分
+func (opt *options) callAdminAPI(ctx context.Context, method, path string, params url.Values, res any) error {
+	if opt.token == "" {
+		return errors.New("请通过 --token 指定管理员用户的登录 Token")
+	}
+
+	endpoint := strings.TrimRight(opt.server, "/") + "/v1/admin" + path
+
+	var body io.Reader
+	if method == http.MethodGet || method == http.MethodDelete {
+		if len(params) > 0 {
+			endpoint += "?" + params.Encode()
+		}
+	} else {
+		body = strings.NewReader(params.Encode())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+opt.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request admin api failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read admin api response failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error != "" {
+			return fmt.Errorf("admin api error [%d]: %s", resp.StatusCode, e.Error)
+		}
+
+		return fmt.Errorf("admin api error [%d]: %s", resp.StatusCode, string(data))
+	}
+
+	if res == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, res)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/spf13/cobra"
+)
+
+func userCommand(opt *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "用户管理",
+	}
+
+	var account, password, realname string
+	createAdmin := &cobra.Command{
+		Use:   "create-admin",
+		Short: "创建管理员用户，用户已存在时将其设置为管理员",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			account = strings.TrimSpace(account)
+			if account == "" {
+				return errors.New("请通过 --account 指定邮箱或手机号")
+			}
+
+			db, err := opt.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			userRepo := repo.NewUserRepo(db, opt.config())
+			user, err := findUser(cmd, userRepo, account)
+			if err != nil && !errors.Is(err, repo.ErrNotFound) {
+				return err
+			}
+
+			if user == nil {
+				if password == "" {
+					return errors.New("创建新用户时请通过 --password 指定登录密码")
+				}
+
+				if strings.Contains(account, "@") {
+					user, _, err = userRepo.SignUpEmail(cmd.Context(), account, password, realname)
+				} else {
+					user, _, err = userRepo.SignUpPhone(cmd.Context(), account, password, realname)
+				}
+				if err != nil {
+					return fmt.Errorf("创建用户失败: %w", err)
+				}
+			} else if password != "" {
+				if err := userRepo.UpdatePassword(cmd.Context(), user.Id, password); err != nil {
+					return fmt.Errorf("更新密码失败: %w", err)
+				}
+			}
+
+			if err := userRepo.UpdateUserType(cmd.Context(), user.Id, repo.UserTypeInternal); err != nil {
+				return fmt.Errorf("设置管理员失败: %w", err)
+			}
+
+			cmd.Printf("用户 %s（ID: %d）已设置为管理员\n", account, user.Id)
+			return nil
+		},
+	}
+	createAdmin.Flags().StringVar(&account, "account", "", "邮箱或手机号")
+	createAdmin.Flags().StringVar(&password, "password", "", "登录密码，用户已存在时指定则重置密码")
+	createAdmin.Flags().StringVar(&realname, "name", "管理员", "用户昵称")
+
+	cmd.AddCommand(createAdmin)
+	return cmd
+}
+
+// findUser 根据用户 ID、邮箱或手机号查询用户
+func findUser(cmd *cobra.Command, userRepo *repo.UserRepo, account string) (*model.Users, error) {
+	if strings.Contains(account, "@") {
+		return userRepo.GetUserByEmail(cmd.Context(), account)
+	}
+
+	// 手机号同样是纯数字，按照用户 ID 查询不到时再按照手机号查询
+	if id, err := strconv.ParseInt(account, 10, 64); err == nil {
+		if user, err := userRepo.GetUserByID(cmd.Context(), id); err == nil || !errors.Is(err, repo.ErrNotFound) {
+			return user, err
+		}
+	}
+
+	return userRepo.GetUserByPhone(cmd.Context(), account)
+}
+
+func coinsCommand(opt *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "coins",
+		Short: "智慧果管理",
+	}
+
+	var account, note string
+	var amount int64
+	var days int
+	grant := &cobra.Command{
+		Use:   "grant",
+		Short: "为用户充值智慧果",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if amount <= 0 || days <= 0 {
+				return errors.New("--amount 与 --days 必须大于 0")
+			}
+
+			db, err := opt.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			user, err := findUser(cmd, repo.NewUserRepo(db, opt.config()), strings.TrimSpace(account))
+			if err != nil {
+				return fmt.Errorf("查询用户失败: %w", err)
+			}
+
+			endAt := time.Now().AddDate(0, 0, days)
+			if _, err := repo.NewQuotaRepo(db, opt.config()).AddUserQuota(cmd.Context(), user.Id, amount, endAt, note, ""); err != nil {
+				return fmt.Errorf("充值失败: %w", err)
+			}
+
+			cmd.Printf("已为用户 %d 充值 %d 个智慧果，有效期至 %s\n", user.Id, amount, endAt.Format("2006-01-02 15:04:05"))
+			return nil
+		},
+	}
+	grant.Flags().StringVar(&account, "user", "", "用户 ID、邮箱或手机号")
+	grant.Flags().Int64Var(&amount, "amount", 0, "智慧果数量")
+	grant.Flags().IntVar(&days, "days", 30, "有效期（天）")
+	grant.Flags().StringVar(&note, "note", "管理员充值", "充值备注")
+
+	cmd.AddCommand(grant)
+	return cmd
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/mylxsw/aidea-server/pkg/ai/deepseek"
 	"github.com/mylxsw/aidea-server/pkg/ai/google"
+	"github.com/mylxsw/aidea-server/pkg/ai/moonshot"
 	"github.com/mylxsw/aidea-server/pkg/ai/openrouter"
 	"github.com/mylxsw/aidea-server/pkg/ai/sky"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
 	"github.com/mylxsw/aidea-server/pkg/file"
 	"math/rand"
 	"path/filepath"
@@ -16,9 +19,11 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/anthropic"
 	"github.com/mylxsw/aidea-server/pkg/ai/baichuan"
 	"github.com/mylxsw/aidea-server/pkg/ai/baidu"
+	"github.com/mylxsw/aidea-server/pkg/ai/channel"
 	"github.com/mylxsw/aidea-server/pkg/ai/chat"
 	"github.com/mylxsw/aidea-server/pkg/ai/dashscope"
 	"github.com/mylxsw/aidea-server/pkg/ai/deepai"
+	"github.com/mylxsw/aidea-server/pkg/ai/embedding"
 	"github.com/mylxsw/aidea-server/pkg/ai/fromston"
 	"github.com/mylxsw/aidea-server/pkg/ai/getimgai"
 	"github.com/mylxsw/aidea-server/pkg/ai/gpt360"
@@ -26,6 +31,7 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/lepton"
 	"github.com/mylxsw/aidea-server/pkg/ai/oneapi"
 	"github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/pkg/ai/replicate"
 	"github.com/mylxsw/aidea-server/pkg/ai/sensenova"
 	"github.com/mylxsw/aidea-server/pkg/ai/stabilityai"
 	"github.com/mylxsw/aidea-server/pkg/ai/tencentai"
@@ -33,10 +39,13 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/aliyun"
 	"github.com/mylxsw/aidea-server/pkg/dingding"
 	"github.com/mylxsw/aidea-server/pkg/mail"
+	"github.com/mylxsw/aidea-server/pkg/ocr"
+	"github.com/mylxsw/aidea-server/pkg/pdf"
 	"github.com/mylxsw/aidea-server/pkg/proxy"
 	"github.com/mylxsw/aidea-server/pkg/rate"
 	"github.com/mylxsw/aidea-server/pkg/redis"
 	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/sandbox"
 	"github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/aidea-server/pkg/sms"
 	"github.com/mylxsw/aidea-server/pkg/tencent"
@@ -82,6 +91,10 @@ func main() {
 			log.All().LogFormatter(formatter.NewJSONFormatter())
 		}
 
+		if le := level.GetLevelByName(f.String("log-level")); le > 0 {
+			log.All().LogLevel(le)
+		}
+
 		if f.String("log-path") != "" {
 			log.All().LogWriter(writer.NewDefaultRotatingFileWriter(context.TODO(), func(le level.Level, module string) string {
 				return filepath.Join(f.String("log-path"), fmt.Sprintf("%s.%s.log", le.GetLevelName(), time.Now().Format("20060102")))
@@ -118,9 +131,13 @@ func main() {
 		service.Provider{},
 		jobs.Provider{},
 		chat.Provider{},
+		embedding.Provider{},
 		proxy.Provider{},
 		file.Provider{},
 		migrate.Provider{},
+		sandbox.Provider{},
+		ocr.Provider{},
+		pdf.Provider{},
 	)
 
 	// 普通云服务商
@@ -144,6 +161,7 @@ func main() {
 		deepai.Provider{},
 		fromston.Provider{},
 		getimgai.Provider{},
+		replicate.Provider{},
 		dashscope.Provider{},
 		xfyun.Provider{},
 		leap.Provider{},
@@ -158,6 +176,10 @@ func main() {
 		google.Provider{},
 		openrouter.Provider{},
 		sky.Provider{},
+		deepseek.Provider{},
+		moonshot.Provider{},
+		zhipu.Provider{},
+		channel.Provider{},
 	)
 
 	app.MustRun(ins)
@@ -5,6 +5,7 @@ import (
 	"github.com/mylxsw/aidea-server/internal/coins"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mylxsw/glacier/infra"
 	"github.com/mylxsw/glacier/starter/app"
@@ -27,6 +28,10 @@ type Config struct {
 	DebugWithSQL bool `json:"debug_with_sql" yaml:"debug_with_sql"`
 	// 是否启用 API Keys 功能
 	EnableAPIKeys bool `json:"enable_api_keys" yaml:"enable_api_keys"`
+	// AutomationRateLimit 自动化 API 每个 API Key 每分钟最大请求次数，0 表示不限制
+	AutomationRateLimit int `json:"automation_rate_limit" yaml:"automation_rate_limit"`
+	// BatchMaxItems 批量聊天任务单个批次最多包含的条目数
+	BatchMaxItems int `json:"batch_max_items" yaml:"batch_max_items"`
 
 	// BaseURL 服务的基础 URL
 	BaseURL string `json:"base_url" yaml:"base_url"`
@@ -129,6 +134,33 @@ type Config struct {
 	OpenRouterAutoProxy     bool     `json:"openrouter_auto_proxy" yaml:"openrouter_auto_proxy"`
 	OpenRouterServer        string   `json:"openrouter_server" yaml:"openrouter_server"`
 	OpenRouterKey           string   `json:"openrouter_key" yaml:"openrouter_key"`
+	// OpenRouterSyncModels 是否从 OpenRouter 同步模型列表，同步的模型全部加入模型目录
+	OpenRouterSyncModels bool `json:"openrouter_sync_models" yaml:"openrouter_sync_models"`
+	// OpenRouterSyncInterval 同步模型列表和价格的时间间隔
+	OpenRouterSyncInterval time.Duration `json:"openrouter_sync_interval" yaml:"openrouter_sync_interval"`
+	// OpenRouterPriceMarkup 同步模型按照 OpenRouter 价格计费时的加价系数，例如 1.5 表示上游价格的 1.5 倍
+	OpenRouterPriceMarkup float64 `json:"openrouter_price_markup" yaml:"openrouter_price_markup"`
+
+	// ChannelKeepAliveInterval 自定义渠道中开启了保持加载的模型的预热间隔，为 0 时不预热
+	ChannelKeepAliveInterval time.Duration `json:"channel_keepalive_interval" yaml:"channel_keepalive_interval"`
+	// OpenRouterSiteURL 和 OpenRouterSiteName 通过请求头传递给 OpenRouter，用于在 OpenRouter 的排行榜中展示应用
+	OpenRouterSiteURL  string `json:"openrouter_site_url" yaml:"openrouter_site_url"`
+	OpenRouterSiteName string `json:"openrouter_site_name" yaml:"openrouter_site_name"`
+
+	// DeepSeek https://platform.deepseek.com
+	EnableDeepSeek bool   `json:"enable_deepseek" yaml:"enable_deepseek"`
+	DeepSeekServer string `json:"deepseek_server" yaml:"deepseek_server"`
+	DeepSeekKey    string `json:"-" yaml:"-"`
+
+	// Moonshot https://platform.moonshot.cn
+	EnableMoonshot bool   `json:"enable_moonshot" yaml:"enable_moonshot"`
+	MoonshotServer string `json:"moonshot_server" yaml:"moonshot_server"`
+	MoonshotKey    string `json:"-" yaml:"-"`
+
+	// 智谱 AI https://open.bigmodel.cn
+	EnableZhipu bool   `json:"enable_zhipu" yaml:"enable_zhipu"`
+	ZhipuServer string `json:"zhipu_server" yaml:"zhipu_server"`
+	ZhipuKey    string `json:"-" yaml:"-"`
 
 	// Proxy
 	Socks5Proxy string `json:"socks5_proxy" yaml:"socks5_proxy"`
@@ -160,6 +192,20 @@ type Config struct {
 	FromstonServer   string `json:"fromston_server" yaml:"fromston_server"`
 	FromstonKey      string `json:"fromston_key" yaml:"fromston_key"`
 
+	// replicate.com，用于数字分身写真（InstantID）
+	ReplicateServer string `json:"replicate_server" yaml:"replicate_server"`
+	ReplicateKey    string `json:"replicate_key" yaml:"replicate_key"`
+
+	// EnableAvatarPack 是否启用数字分身写真
+	EnableAvatarPack bool `json:"enable_avatar_pack" yaml:"enable_avatar_pack"`
+	// AvatarPackModelVersion 数字分身写真使用的 Replicate 模型版本（InstantID 类模型）
+	AvatarPackModelVersion string `json:"avatar_pack_model_version" yaml:"avatar_pack_model_version"`
+	// AvatarPackSelfieRetention 用户上传的自拍照最长保留时间，生成完成后立即删除，超过该时间未删除的由定时任务清理
+	AvatarPackSelfieRetention time.Duration `json:"avatar_pack_selfie_retention" yaml:"avatar_pack_selfie_retention"`
+
+	// CreativeQuoteTTL 创作岛任务报价的有效期，有效期内确认时按照报价的价格扣费
+	CreativeQuoteTTL time.Duration `json:"creative_quote_ttl" yaml:"creative_quote_ttl"`
+
 	// getimg.ai
 	EnableGetimgAI    bool   `json:"enable_getimgai" yaml:"enable_getimgai"`
 	GetimgAIAutoProxy bool   `json:"getimgai_auto_proxy" yaml:"getimgai_auto_proxy"`
@@ -172,6 +218,166 @@ type Config struct {
 	LeptonAIQRServers []string `json:"leptonai_qr_servers" yaml:"leptonai_qr_servers"`
 	LeptonAIKeys      []string `json:"leptonai_keys" yaml:"leptonai_keys"`
 
+	// EnableLocalEmbedding 是否启用本地部署的 Embedding 模型服务（兼容 OpenAI Embedding 接口）
+	EnableLocalEmbedding bool     `json:"enable_local_embedding" yaml:"enable_local_embedding"`
+	LocalEmbeddingServer string   `json:"local_embedding_server" yaml:"local_embedding_server"`
+	LocalEmbeddingKey    string   `json:"-" yaml:"local_embedding_key"`
+	LocalEmbeddingModels []string `json:"local_embedding_models" yaml:"local_embedding_models"`
+	// EmbeddingMaxInputs Embedding 接口单次请求最多支持的输入条数
+	EmbeddingMaxInputs int `json:"embedding_max_inputs" yaml:"embedding_max_inputs"`
+	// EnableSandbox 是否启用代码执行沙箱（Code Interpreter）
+	EnableSandbox    bool          `json:"enable_sandbox" yaml:"enable_sandbox"`
+	SandboxDockerBin string        `json:"sandbox_docker_bin" yaml:"sandbox_docker_bin"`
+	SandboxImage     string        `json:"sandbox_image" yaml:"sandbox_image"`
+	SandboxRuntime   string        `json:"sandbox_runtime" yaml:"sandbox_runtime"`
+	SandboxMemory    string        `json:"sandbox_memory" yaml:"sandbox_memory"`
+	SandboxCPUs      string        `json:"sandbox_cpus" yaml:"sandbox_cpus"`
+	SandboxTimeout   time.Duration `json:"sandbox_timeout" yaml:"sandbox_timeout"`
+
+	// OCREngine 文字识别引擎：paddle（本地部署的 PaddleOCR）/tencent（腾讯云），留空则不启用
+	OCREngine       string `json:"ocr_engine" yaml:"ocr_engine"`
+	PaddleOCRServer string `json:"paddle_ocr_server" yaml:"paddle_ocr_server"`
+	// OCRMaxPages 单次文字识别最多支持的页数（图片数量）
+	OCRMaxPages int `json:"ocr_max_pages" yaml:"ocr_max_pages"`
+
+	// PDFToTextBin pdftotext 命令路径（poppler-utils），用于提取 PDF 文本
+	PDFToTextBin string `json:"pdftotext_bin" yaml:"pdftotext_bin"`
+	// PDFMaxPages PDF 文档最多支持的页数
+	PDFMaxPages int `json:"pdf_max_pages" yaml:"pdf_max_pages"`
+	// PDFEmbeddingModel PDF 文档向量化使用的模型
+	PDFEmbeddingModel string `json:"pdf_embedding_model" yaml:"pdf_embedding_model"`
+
+	// TableMaxRows 表格问答上传的 CSV/XLSX 文件最多支持的行数
+	TableMaxRows int `json:"table_max_rows" yaml:"table_max_rows"`
+
+	// EssayGradingModel 作文批改使用的模型，留空则不启用作文批改
+	EssayGradingModel string `json:"essay_grading_model" yaml:"essay_grading_model"`
+	// MockInterviewModel 模拟面试使用的模型，留空则不启用模拟面试
+	MockInterviewModel string `json:"mock_interview_model" yaml:"mock_interview_model"`
+
+	// ResumePolishModel 简历优化默认使用的模型
+	ResumePolishModel string `json:"resume_polish_model" yaml:"resume_polish_model"`
+
+	// GroupJudgeModel 群聊中对多个成员的回答进行评审的模型，留空则不启用评审
+	GroupJudgeModel string `json:"group_judge_model" yaml:"group_judge_model"`
+
+	// GroupConsensusModel 群聊中将多个成员的回答合并为一个综合回答的模型，留空则不启用
+	GroupConsensusModel string `json:"group_consensus_model" yaml:"group_consensus_model"`
+
+	// PromptSuggestionModel 生成追问建议使用的模型，留空则不启用
+	PromptSuggestionModel string `json:"prompt_suggestion_model" yaml:"prompt_suggestion_model"`
+	// PromptSuggestionUserTypes 允许使用追问建议的用户类型，为空时所有用户都可以使用
+	PromptSuggestionUserTypes []string `json:"prompt_suggestion_user_types" yaml:"prompt_suggestion_user_types"`
+
+	// RestrictedModeModels 受限模式（家长模式）下允许使用的模型
+	RestrictedModeModels []string `json:"restricted_mode_models" yaml:"restricted_mode_models"`
+	// RestrictedModeImageVendors 受限模式下允许使用的图片生成服务商，只应包含自带内容安全过滤的服务商
+	RestrictedModeImageVendors []string `json:"restricted_mode_image_vendors" yaml:"restricted_mode_image_vendors"`
+	// RestrictedModeDailyLimit 受限模式下默认的每日智慧果使用上限
+	RestrictedModeDailyLimit int64 `json:"restricted_mode_daily_limit" yaml:"restricted_mode_daily_limit"`
+
+	// EnableAbuseDetect 是否启用异常使用行为检测
+	EnableAbuseDetect bool `json:"enable_abuse_detect" yaml:"enable_abuse_detect"`
+	// AbuseIdenticalPromptThreshold 一小时内相同请求内容的次数达到该值时标记为异常
+	AbuseIdenticalPromptThreshold int64 `json:"abuse_identical_prompt_threshold" yaml:"abuse_identical_prompt_threshold"`
+	// AbuseDistinctIPThreshold 一小时内请求来源 IP 数量达到该值时标记为异常（疑似转售）
+	AbuseDistinctIPThreshold int64 `json:"abuse_distinct_ip_threshold" yaml:"abuse_distinct_ip_threshold"`
+	// AbuseThrottleDuration 标记为异常后自动限流的时长
+	AbuseThrottleDuration time.Duration `json:"abuse_throttle_duration" yaml:"abuse_throttle_duration"`
+
+	// GeoRegionHeader 请求来源地区（ISO 3166-1 国家代码）的请求头，由 CDN 或反向代理设置，例如 Cloudflare 的 CF-IPCountry
+	GeoRegionHeader string `json:"geo_region_header" yaml:"geo_region_header"`
+
+	// BackupDir 备份文件的存储目录
+	BackupDir string `json:"backup_dir" yaml:"backup_dir"`
+
+	// LogLevel 默认日志级别，可以通过管理员接口临时调整
+	LogLevel string `json:"log_level" yaml:"log_level"`
+
+	// SlowQueryThreshold 慢查询阈值，超过阈值的数据库查询会记录到日志
+	SlowQueryThreshold time.Duration `json:"slow_query_threshold" yaml:"slow_query_threshold"`
+	// SlowEndpointThreshold 慢接口阈值
+	SlowEndpointThreshold time.Duration `json:"slow_endpoint_threshold" yaml:"slow_endpoint_threshold"`
+	// EnablePprof 是否启用管理员 pprof 接口
+	EnablePprof bool `json:"enable_pprof" yaml:"enable_pprof"`
+
+	// DeletedMessageRetention 已删除的群聊消息的保留期限，超过期限后物理删除
+	DeletedMessageRetention time.Duration `json:"deleted_message_retention" yaml:"deleted_message_retention"`
+	// RoomTrashRetention 删除的会话在回收站中的保留期限，期间可以恢复，超过期限后物理删除
+	RoomTrashRetention time.Duration `json:"room_trash_retention" yaml:"room_trash_retention"`
+
+	// EnableMessageEncryption 是否加密保存聊天消息内容
+	EnableMessageEncryption bool `json:"enable_message_encryption" yaml:"enable_message_encryption"`
+	// MessageEncryptionKeys 消息加密的主密钥，格式为 <主密钥 ID>:<base64 编码的 32 字节密钥>，使用 Vault 时不需要配置
+	MessageEncryptionKeys []string `json:"-" yaml:"-"`
+	// MessageEncryptionActiveKey 加密新消息使用的主密钥 ID，使用 Vault 时为 Transit 中的密钥名称
+	MessageEncryptionActiveKey string `json:"message_encryption_active_key" yaml:"message_encryption_active_key"`
+	// MessageEncryptionVaultServer Vault 服务地址，配置后主密钥托管在 Vault Transit 中
+	MessageEncryptionVaultServer string `json:"message_encryption_vault_server" yaml:"message_encryption_vault_server"`
+	// MessageEncryptionVaultToken Vault 访问 Token
+	MessageEncryptionVaultToken string `json:"-" yaml:"-"`
+
+	// EnableLDAP 是否启用 LDAP 登录，用于企业私有化部署
+	EnableLDAP bool `json:"enable_ldap" yaml:"enable_ldap"`
+	// LDAPServer LDAP 服务地址，格式为 ldap://host:389 或 ldaps://host:636
+	LDAPServer string `json:"ldap_server" yaml:"ldap_server"`
+	// LDAPStartTLS 使用 ldap:// 连接后，是否通过 StartTLS 升级为加密连接
+	LDAPStartTLS bool `json:"ldap_start_tls" yaml:"ldap_start_tls"`
+	// LDAPBindDN 查询用户使用的服务账号，留空时匿名查询
+	LDAPBindDN string `json:"ldap_bind_dn" yaml:"ldap_bind_dn"`
+	// LDAPBindPassword 服务账号密码
+	LDAPBindPassword string `json:"-" yaml:"-"`
+	// LDAPBaseDN 查询用户的根节点
+	LDAPBaseDN string `json:"ldap_base_dn" yaml:"ldap_base_dn"`
+	// LDAPUserAttr 用户登录名对应的属性
+	LDAPUserAttr string `json:"ldap_user_attr" yaml:"ldap_user_attr"`
+	// LDAPEmailAttr 用户邮箱对应的属性
+	LDAPEmailAttr string `json:"ldap_email_attr" yaml:"ldap_email_attr"`
+	// LDAPNameAttr 用户姓名对应的属性
+	LDAPNameAttr string `json:"ldap_name_attr" yaml:"ldap_name_attr"`
+	// LDAPGroupAttr 用户所属分组对应的属性
+	LDAPGroupAttr string `json:"ldap_group_attr" yaml:"ldap_group_attr"`
+
+	// EnableOIDC 是否启用 OIDC 登录，用于企业私有化部署
+	EnableOIDC bool `json:"enable_oidc" yaml:"enable_oidc"`
+	// OIDCIssuer OIDC 身份提供方地址，用于自动发现授权、Token 以及公钥地址
+	OIDCIssuer string `json:"oidc_issuer" yaml:"oidc_issuer"`
+	// OIDCClientID OIDC 客户端 ID
+	OIDCClientID string `json:"oidc_client_id" yaml:"oidc_client_id"`
+	// OIDCClientSecret OIDC 客户端密钥
+	OIDCClientSecret string `json:"-" yaml:"-"`
+	// OIDCRedirectURL 授权完成后的回调地址
+	OIDCRedirectURL string `json:"oidc_redirect_url" yaml:"oidc_redirect_url"`
+	// OIDCScopes 申请的授权范围
+	OIDCScopes []string `json:"oidc_scopes" yaml:"oidc_scopes"`
+	// OIDCGroupsClaim ID Token 中用户所属分组对应的字段
+	OIDCGroupsClaim string `json:"oidc_groups_claim" yaml:"oidc_groups_claim"`
+
+	// SSOGroupUserTypes 企业身份源中的分组与用户类型的映射，格式为 <分组>=<用户类型>，按顺序匹配
+	SSOGroupUserTypes []string `json:"sso_group_user_types" yaml:"sso_group_user_types"`
+
+	// EnableChaos 是否启用模型服务商的故障注入，仅用于测试环境验证重试、计费等流程的容错能力
+	EnableChaos bool `json:"enable_chaos" yaml:"enable_chaos"`
+	// ChaosRate 注入故障的概率，取值范围 0-1
+	ChaosRate float64 `json:"chaos_rate" yaml:"chaos_rate"`
+	// ChaosFaults 注入的故障类型，可选值为 timeout、rate_limit、malformed_sse、truncated，为空时注入全部类型
+	ChaosFaults []string `json:"chaos_faults" yaml:"chaos_faults"`
+
+	// EnableRequestSign 是否校验客户端请求签名，未启用 RequestSignEnforce 时只记录校验失败的请求
+	EnableRequestSign bool `json:"enable_request_sign" yaml:"enable_request_sign"`
+	// RequestSignEnforce 是否拒绝签名校验失败的请求
+	RequestSignEnforce bool `json:"request_sign_enforce" yaml:"request_sign_enforce"`
+	// RequestSignKeys 客户端内置的签名密钥，支持多个以便轮换
+	RequestSignKeys []string `json:"-" yaml:"request_sign_keys"`
+	// RequestSignTolerance 请求时间戳允许的最大误差
+	RequestSignTolerance time.Duration `json:"request_sign_tolerance" yaml:"request_sign_tolerance"`
+
+	// EnableImageWatermark 是否在生成的图片中嵌入不可见的 AI 生成内容水印
+	EnableImageWatermark bool `json:"enable_image_watermark" yaml:"enable_image_watermark"`
+
+	// VectorCollectionMaxDocs 单个向量集合最多支持的文档数量
+	VectorCollectionMaxDocs int `json:"vector_collection_max_docs" yaml:"vector_collection_max_docs"`
+
 	// DBURI 数据库连接地址
 	DBURI string `json:"db_uri" yaml:"db_uri"`
 	// Redis
@@ -205,6 +411,9 @@ type Config struct {
 	EnableMail bool `json:"enable_mail" yaml:"enable_mail"`
 	Mail       Mail `json:"mail" yaml:"mail"`
 
+	// RealtimeVoiceMaxDuration 实时语音对话单次会话的最长时长
+	RealtimeVoiceMaxDuration time.Duration `json:"realtime_voice_max_duration" yaml:"realtime_voice_max_duration"`
+
 	// Tencent
 	UseTencentVoiceToText bool   `json:"use_tencent_voice_to_text" yaml:"use_tencent_voice_to_text"`
 	TencentSecretID       string `json:"tencent_secret_id" yaml:"tencent_secret_id"`
@@ -224,6 +433,42 @@ type Config struct {
 
 	// Apple 应用内支付
 	EnableApplePay bool `json:"enable_apple_pay" yaml:"enable_apple_pay"`
+	// ApplePayBundleID App Bundle ID，用于校验 App Store 服务器通知
+	ApplePayBundleID string `json:"apple_pay_bundle_id" yaml:"apple_pay_bundle_id"`
+	// ExchangeRateAPI 人民币汇率查询接口，返回格式与 open.er-api.com 一致
+	ExchangeRateAPI string `json:"exchange_rate_api" yaml:"exchange_rate_api"`
+
+	// 智慧果转赠
+	EnableCoinTransfer bool `json:"enable_coin_transfer" yaml:"enable_coin_transfer"`
+	// CoinTransferDailyLimit 每个用户每天最多转出的智慧果数量，0 表示不限制
+	CoinTransferDailyLimit int `json:"coin_transfer_daily_limit" yaml:"coin_transfer_daily_limit"`
+	// CoinTransferMonthlyLimit 每个用户每月最多转出的智慧果数量，0 表示不限制
+	CoinTransferMonthlyLimit int `json:"coin_transfer_monthly_limit" yaml:"coin_transfer_monthly_limit"`
+	// CoinTransferDailyRecipients 每个用户每天最多向多少个不同的账号转赠，0 表示不限制
+	CoinTransferDailyRecipients int `json:"coin_transfer_daily_recipients" yaml:"coin_transfer_daily_recipients"`
+	// CoinTransferMinAccountAge 注册时间超过该时长的账号才能转出智慧果
+	CoinTransferMinAccountAge time.Duration `json:"coin_transfer_min_account_age" yaml:"coin_transfer_min_account_age"`
+
+	// MaxCoinsPerRequest 单次请求预计消耗的智慧果超过该值时，需要用户确认后才会执行，0 表示不限制，用户可以在自定义配置中调整
+	MaxCoinsPerRequest int `json:"max_coins_per_request" yaml:"max_coins_per_request"`
+
+	// EnableReplyLanguage 是否检测用户提问的语言，并要求模型使用相同的语言回复
+	EnableReplyLanguage bool `json:"enable_reply_language" yaml:"enable_reply_language"`
+
+	// WritingToolModel 写作工具使用的模型，留空则不启用写作工具
+	WritingToolModel string `json:"writing_tool_model" yaml:"writing_tool_model"`
+	// StudyModel 学习模式生成记忆卡片使用的模型，留空则不启用学习模式
+	StudyModel string `json:"study_model" yaml:"study_model"`
+	// TutorModel 作业辅导模式使用的模型，留空则不启用作业辅导模式
+	TutorModel string `json:"tutor_model" yaml:"tutor_model"`
+	// TutorAnswerPolicy 作业辅导模式默认的答案策略：guide-只引导不给出最终答案 answer-允许给出答案，用户可以单独设置
+	TutorAnswerPolicy string `json:"tutor_answer_policy" yaml:"tutor_answer_policy"`
+
+	// 余额校验
+	// BalanceAutoRepair 配额记录中的余额与记账分录不一致时，是否自动以记账分录为准修正
+	BalanceAutoRepair bool `json:"balance_auto_repair" yaml:"balance_auto_repair"`
+	// BalanceRepairMaxDrift 差额超过该值时只通知管理员，不自动修正，0 表示不限制
+	BalanceRepairMaxDrift int `json:"balance_repair_max_drift" yaml:"balance_repair_max_drift"`
 
 	// 支付宝
 	AlipaySandbox           bool   `json:"alipay_sandbox" yaml:"alipay_sandbox"`
@@ -235,6 +480,12 @@ type Config struct {
 	AliPayPublicKeyPath     string `json:"alipay_public_key_path" yaml:"alipay_public_key_path"`
 	AliPayNotifyURL         string `json:"alipay_notify_url" yaml:"alipay_notify_url"`
 	AliPayReturnURL         string `json:"alipay_return_url" yaml:"alipay_return_url"`
+	// EnableAutoTopup 是否允许用户签约支付宝商家扣款，余额不足时自动充值
+	EnableAutoTopup bool `json:"enable_auto_topup" yaml:"enable_auto_topup"`
+	// AutoTopupNoticePeriod 自动充值扣款前提前通知用户的时间，用户可以在此期间取消
+	AutoTopupNoticePeriod time.Duration `json:"auto_topup_notice_period" yaml:"auto_topup_notice_period"`
+	// AliPayAgreementNotifyURL 支付宝商家扣款签约、解约结果通知地址
+	AliPayAgreementNotifyURL string `json:"alipay_agreement_notify_url" yaml:"alipay_agreement_notify_url"`
 
 	// 短信通道
 	SMSChannels []string `json:"sms_channels" yaml:"sms_channels"`
@@ -252,6 +503,8 @@ type Config struct {
 	// 文生图、图生图控制
 	DefaultImageToImageModel string `json:"default_image_to_image_model" yaml:"default_image_to_image_model"`
 	DefaultTextToImageModel  string `json:"default_text_to_image_model" yaml:"default_text_to_image_model"`
+	// MagicPromptModel 优化（扩写）图片提示语使用的模型
+	MagicPromptModel string `json:"magic_prompt_model" yaml:"magic_prompt_model"`
 
 	// 虚拟模型
 	EnableVirtualModel bool         `json:"enable_virtual_model" yaml:"enable_virtual_model"`
@@ -267,6 +520,25 @@ func (conf *Config) SupportProxy() bool {
 	return conf.Socks5Proxy != "" || conf.ProxyURL != ""
 }
 
+// PromptSuggestionEnabled 判断指定类型的用户是否可以使用追问建议
+func (conf *Config) PromptSuggestionEnabled(userType int64) bool {
+	if conf.PromptSuggestionModel == "" {
+		return false
+	}
+
+	if len(conf.PromptSuggestionUserTypes) == 0 {
+		return true
+	}
+
+	for _, t := range conf.PromptSuggestionUserTypes {
+		if strings.TrimSpace(t) == fmt.Sprintf("%d", userType) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Mail struct {
 	From         string `json:"from" yaml:"from"`
 	SMTPHost     string `json:"smtp_host" yaml:"smtp_host"`
@@ -337,6 +609,8 @@ func Register(ins *app.App) {
 			EnableModelRateLimit:   ctx.Bool("enable-model-rate-limit"),
 			EnableCustomHomeModels: ctx.Bool("enable-custom-home-models"),
 			EnableAPIKeys:          ctx.Bool("enable-api-keys"),
+			AutomationRateLimit:    ctx.Int("automation-rate-limit"),
+			BatchMaxItems:          ctx.Int("batch-max-items"),
 
 			RedisHost:     ctx.String("redis-host"),
 			RedisPort:     ctx.Int("redis-port"),
@@ -413,11 +687,29 @@ func Register(ins *app.App) {
 			OneAPIServer:        ctx.String("oneapi-server"),
 			OneAPIKey:           ctx.String("oneapi-key"),
 
-			OpenRouterSupportModels: ctx.StringSlice("openrouter-support-models"),
-			EnableOpenRouter:        ctx.Bool("enable-openrouter"),
-			OpenRouterAutoProxy:     ctx.Bool("openrouter-autoproxy"),
-			OpenRouterServer:        ctx.String("openrouter-server"),
-			OpenRouterKey:           ctx.String("openrouter-key"),
+			OpenRouterSupportModels:  ctx.StringSlice("openrouter-support-models"),
+			EnableOpenRouter:         ctx.Bool("enable-openrouter"),
+			OpenRouterAutoProxy:      ctx.Bool("openrouter-autoproxy"),
+			OpenRouterServer:         ctx.String("openrouter-server"),
+			OpenRouterKey:            ctx.String("openrouter-key"),
+			OpenRouterSyncModels:     ctx.Bool("openrouter-sync-models"),
+			OpenRouterSyncInterval:   ctx.Duration("openrouter-sync-interval"),
+			OpenRouterPriceMarkup:    ctx.Float64("openrouter-price-markup"),
+			ChannelKeepAliveInterval: ctx.Duration("channel-keepalive-interval"),
+			OpenRouterSiteURL:        ctx.String("openrouter-site-url"),
+			OpenRouterSiteName:       ctx.String("openrouter-site-name"),
+
+			EnableDeepSeek: ctx.Bool("enable-deepseek"),
+			DeepSeekServer: ctx.String("deepseek-server"),
+			DeepSeekKey:    ctx.String("deepseek-key"),
+
+			EnableMoonshot: ctx.Bool("enable-moonshot"),
+			MoonshotServer: ctx.String("moonshot-server"),
+			MoonshotKey:    ctx.String("moonshot-key"),
+
+			EnableZhipu: ctx.Bool("enable-zhipu"),
+			ZhipuServer: ctx.String("zhipu-server"),
+			ZhipuKey:    ctx.String("zhipu-key"),
 
 			Socks5Proxy: ctx.String("socks5-proxy"),
 			ProxyURL:    ctx.String("proxy-url"),
@@ -438,6 +730,13 @@ func Register(ins *app.App) {
 			LeapAIKey:       ctx.String("leapai-key"),
 			LeapAIServers:   ctx.StringSlice("leapai-servers"),
 
+			ReplicateServer:           ctx.String("replicate-server"),
+			ReplicateKey:              ctx.String("replicate-key"),
+			EnableAvatarPack:          ctx.Bool("enable-avatar-pack"),
+			AvatarPackModelVersion:    ctx.String("avatar-pack-model-version"),
+			AvatarPackSelfieRetention: ctx.Duration("avatar-pack-selfie-retention"),
+			CreativeQuoteTTL:          ctx.Duration("creative-quote-ttl"),
+
 			EnableGetimgAI:    ctx.Bool("enable-getimgai"),
 			GetimgAIAutoProxy: ctx.Bool("getimgai-autoproxy"),
 			GetimgAIServer:    ctx.String("getimgai-server"),
@@ -448,6 +747,103 @@ func Register(ins *app.App) {
 			LeptonAIQRServers: ctx.StringSlice("leptonai-qr-servers"),
 			LeptonAIKeys:      ctx.StringSlice("leptonai-keys"),
 
+			EnableLocalEmbedding: ctx.Bool("enable-local-embedding"),
+			LocalEmbeddingServer: strings.TrimSuffix(ctx.String("local-embedding-server"), "/"),
+			LocalEmbeddingKey:    ctx.String("local-embedding-key"),
+			LocalEmbeddingModels: ctx.StringSlice("local-embedding-models"),
+			EmbeddingMaxInputs:   ctx.Int("embedding-max-inputs"),
+
+			VectorCollectionMaxDocs: ctx.Int("vector-collection-max-docs"),
+
+			OCREngine:       ctx.String("ocr-engine"),
+			PaddleOCRServer: strings.TrimSuffix(ctx.String("paddle-ocr-server"), "/"),
+			OCRMaxPages:     ctx.Int("ocr-max-pages"),
+
+			PDFToTextBin:      ctx.String("pdftotext-bin"),
+			PDFMaxPages:       ctx.Int("pdf-max-pages"),
+			PDFEmbeddingModel: ctx.String("pdf-embedding-model"),
+
+			TableMaxRows: ctx.Int("table-max-rows"),
+
+			EssayGradingModel:  ctx.String("essay-grading-model"),
+			MockInterviewModel: ctx.String("mock-interview-model"),
+
+			ResumePolishModel: ctx.String("resume-polish-model"),
+
+			GroupJudgeModel:     ctx.String("group-judge-model"),
+			GroupConsensusModel: ctx.String("group-consensus-model"),
+
+			PromptSuggestionModel:     ctx.String("prompt-suggestion-model"),
+			PromptSuggestionUserTypes: ctx.StringSlice("prompt-suggestion-user-types"),
+
+			RestrictedModeModels:       ctx.StringSlice("restricted-mode-models"),
+			RestrictedModeImageVendors: ctx.StringSlice("restricted-mode-image-vendors"),
+			RestrictedModeDailyLimit:   int64(ctx.Int("restricted-mode-daily-limit")),
+
+			EnableAbuseDetect:             ctx.Bool("enable-abuse-detect"),
+			AbuseIdenticalPromptThreshold: int64(ctx.Int("abuse-identical-prompt-threshold")),
+			AbuseDistinctIPThreshold:      int64(ctx.Int("abuse-distinct-ip-threshold")),
+			AbuseThrottleDuration:         ctx.Duration("abuse-throttle-duration"),
+
+			GeoRegionHeader: ctx.String("geo-region-header"),
+
+			BackupDir: ctx.String("backup-dir"),
+
+			LogLevel: ctx.String("log-level"),
+
+			SlowQueryThreshold:    ctx.Duration("slow-query-threshold"),
+			SlowEndpointThreshold: ctx.Duration("slow-endpoint-threshold"),
+			EnablePprof:           ctx.Bool("enable-pprof"),
+
+			DeletedMessageRetention: ctx.Duration("deleted-message-retention"),
+			RoomTrashRetention:      ctx.Duration("room-trash-retention"),
+
+			EnableMessageEncryption:      ctx.Bool("enable-message-encryption"),
+			MessageEncryptionKeys:        ctx.StringSlice("message-encryption-keys"),
+			MessageEncryptionActiveKey:   ctx.String("message-encryption-active-key"),
+			MessageEncryptionVaultServer: ctx.String("message-encryption-vault-server"),
+			MessageEncryptionVaultToken:  ctx.String("message-encryption-vault-token"),
+
+			EnableLDAP:       ctx.Bool("enable-ldap"),
+			LDAPServer:       ctx.String("ldap-server"),
+			LDAPStartTLS:     ctx.Bool("ldap-start-tls"),
+			LDAPBindDN:       ctx.String("ldap-bind-dn"),
+			LDAPBindPassword: ctx.String("ldap-bind-password"),
+			LDAPBaseDN:       ctx.String("ldap-base-dn"),
+			LDAPUserAttr:     ctx.String("ldap-user-attr"),
+			LDAPEmailAttr:    ctx.String("ldap-email-attr"),
+			LDAPNameAttr:     ctx.String("ldap-name-attr"),
+			LDAPGroupAttr:    ctx.String("ldap-group-attr"),
+
+			EnableOIDC:       ctx.Bool("enable-oidc"),
+			OIDCIssuer:       ctx.String("oidc-issuer"),
+			OIDCClientID:     ctx.String("oidc-client-id"),
+			OIDCClientSecret: ctx.String("oidc-client-secret"),
+			OIDCRedirectURL:  ctx.String("oidc-redirect-url"),
+			OIDCScopes:       ctx.StringSlice("oidc-scopes"),
+			OIDCGroupsClaim:  ctx.String("oidc-groups-claim"),
+
+			SSOGroupUserTypes: ctx.StringSlice("sso-group-user-types"),
+
+			EnableChaos: ctx.Bool("enable-chaos"),
+			ChaosRate:   ctx.Float64("chaos-rate"),
+			ChaosFaults: ctx.StringSlice("chaos-faults"),
+
+			EnableRequestSign:    ctx.Bool("enable-request-sign"),
+			RequestSignEnforce:   ctx.Bool("request-sign-enforce"),
+			RequestSignKeys:      ctx.StringSlice("request-sign-keys"),
+			RequestSignTolerance: ctx.Duration("request-sign-tolerance"),
+
+			EnableImageWatermark: ctx.Bool("enable-image-watermark"),
+
+			EnableSandbox:    ctx.Bool("enable-sandbox"),
+			SandboxDockerBin: ctx.String("sandbox-docker-bin"),
+			SandboxImage:     ctx.String("sandbox-image"),
+			SandboxRuntime:   ctx.String("sandbox-runtime"),
+			SandboxMemory:    ctx.String("sandbox-memory"),
+			SandboxCPUs:      ctx.String("sandbox-cpus"),
+			SandboxTimeout:   ctx.Duration("sandbox-timeout"),
+
 			EnableFromstonAI: ctx.Bool("enable-fromstonai"),
 			FromstonServer:   ctx.String("fromston-server"),
 			FromstonKey:      ctx.String("fromston-key"),
@@ -480,6 +876,8 @@ func Register(ins *app.App) {
 				UseSSL:       ctx.Bool("mail-ssl"),
 			},
 
+			RealtimeVoiceMaxDuration: ctx.Duration("realtime-voice-max-duration"),
+
 			UseTencentVoiceToText: ctx.Bool("tencent-voice"),
 			TencentSecretID:       ctx.String("tencent-id"),
 			TencentSecretKey:      ctx.String("tencent-key"),
@@ -495,7 +893,27 @@ func Register(ins *app.App) {
 			AliyunSMSTemplateID: ctx.String("aliyun-smstemplateid"),
 			AliyunSMSSign:       ctx.String("aliyun-smssign"),
 
-			EnableApplePay: ctx.Bool("enable-applepay"),
+			EnableApplePay:   ctx.Bool("enable-applepay"),
+			ApplePayBundleID: ctx.String("applepay-bundle-id"),
+			ExchangeRateAPI:  ctx.String("exchange-rate-api"),
+
+			EnableCoinTransfer:          ctx.Bool("enable-coin-transfer"),
+			CoinTransferDailyLimit:      ctx.Int("coin-transfer-daily-limit"),
+			CoinTransferMonthlyLimit:    ctx.Int("coin-transfer-monthly-limit"),
+			CoinTransferDailyRecipients: ctx.Int("coin-transfer-daily-recipients"),
+			CoinTransferMinAccountAge:   ctx.Duration("coin-transfer-min-account-age"),
+
+			MaxCoinsPerRequest: ctx.Int("max-coins-per-request"),
+
+			EnableReplyLanguage: ctx.Bool("enable-reply-language"),
+
+			WritingToolModel:  ctx.String("writing-tool-model"),
+			StudyModel:        ctx.String("study-model"),
+			TutorModel:        ctx.String("tutor-model"),
+			TutorAnswerPolicy: ctx.String("tutor-answer-policy"),
+
+			BalanceAutoRepair:     ctx.Bool("balance-auto-repair"),
+			BalanceRepairMaxDrift: ctx.Int("balance-repair-max-drift"),
 
 			EnableAlipay:            ctx.Bool("enable-alipay"),
 			AliPayAppID:             ctx.String("alipay-appid"),
@@ -507,6 +925,10 @@ func Register(ins *app.App) {
 			AliPayReturnURL:         ctx.String("alipay-return-url"),
 			AlipaySandbox:           ctx.Bool("alipay-sandbox"),
 
+			EnableAutoTopup:          ctx.Bool("enable-auto-topup"),
+			AutoTopupNoticePeriod:    ctx.Duration("auto-topup-notice-period"),
+			AliPayAgreementNotifyURL: ctx.String("alipay-agreement-notify-url"),
+
 			SMSChannels: ctx.StringSlice("sms-channels"),
 
 			DingDingToken:  ctx.String("dingding-token"),
@@ -519,6 +941,7 @@ func Register(ins *app.App) {
 
 			DefaultImageToImageModel: ctx.String("default-img2img-model"),
 			DefaultTextToImageModel:  ctx.String("default-txt2img-model"),
+			MagicPromptModel:         ctx.String("magic-prompt-model"),
 
 			EnableVirtualModel: ctx.Bool("enable-virtual-model"),
 			VirtualModel: VirtualModel{
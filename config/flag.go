@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/mylxsw/glacier/starter/app"
 )
@@ -23,6 +24,8 @@ func initCmdFlags(ins *app.App) {
 	ins.AddBoolFlag("enable-websocket", "是否启用 WebSocket 支持")
 	ins.AddBoolFlag("debug-with-sql", "是否在日志中输出 SQL 语句")
 	ins.AddBoolFlag("enable-api-keys", "是否启用 API Keys 功能")
+	ins.AddIntFlag("batch-max-items", 100, "批量聊天任务单个批次最多包含的条目数")
+	ins.AddIntFlag("automation-rate-limit", 30, "自动化 API（Zapier/IFTTT 等）每个 API Key 每分钟最大请求次数，设置为 0 则不限制")
 	ins.AddBoolFlag("enable-model-rate-limit", "是否启用模型请求频率限制，当前限制只支持每分钟 5 次/用户")
 	ins.AddStringFlag("universal-link-config", "", "universal link 配置文件路径，留空则使用默认的 universal link，配置文件格式参考 https://developer.apple.com/documentation/xcode/supporting-associated-domains")
 
@@ -108,6 +111,24 @@ func initCmdFlags(ins *app.App) {
 	ins.AddBoolFlag("openrouter-autoproxy", "使用 socks5 代理访问 OpenRouter 服务")
 	ins.AddStringFlag("openrouter-server", "https://openrouter.ai/api/v1", "openrouter server")
 	ins.AddStringFlag("openrouter-key", "", "openrouter key")
+	ins.AddBoolFlag("openrouter-sync-models", "是否从 OpenRouter 同步模型列表，同步的模型按照上游价格加价计费")
+	ins.AddDurationFlag("openrouter-sync-interval", 6*time.Hour, "OpenRouter 模型列表同步间隔")
+	ins.AddFloat64Flag("openrouter-price-markup", 1.5, "OpenRouter 同步模型的加价系数")
+	ins.AddDurationFlag("channel-keepalive-interval", 4*time.Minute, "自定义渠道中开启保持加载的自托管模型（Ollama、vLLM 等）的预热间隔，应小于模型的空闲卸载时间，为 0 时不预热")
+	ins.AddStringFlag("openrouter-site-url", "https://aidea.aicode.cc", "通过 HTTP-Referer 请求头传递给 OpenRouter 的应用地址")
+	ins.AddStringFlag("openrouter-site-name", "AIdea", "通过 X-Title 请求头传递给 OpenRouter 的应用名称")
+
+	ins.AddBoolFlag("enable-deepseek", "是否启用 DeepSeek")
+	ins.AddStringFlag("deepseek-server", "https://api.deepseek.com/v1", "DeepSeek 服务地址")
+	ins.AddStringFlag("deepseek-key", "", "DeepSeek API Key")
+
+	ins.AddBoolFlag("enable-moonshot", "是否启用 Moonshot（Kimi）")
+	ins.AddStringFlag("moonshot-server", "https://api.moonshot.cn/v1", "Moonshot 服务地址")
+	ins.AddStringFlag("moonshot-key", "", "Moonshot API Key")
+
+	ins.AddBoolFlag("enable-zhipu", "是否启用智谱 AI")
+	ins.AddStringFlag("zhipu-server", "https://open.bigmodel.cn/api/paas/v4", "智谱 AI 服务地址")
+	ins.AddStringFlag("zhipu-key", "", "智谱 AI API Key，格式为 {id}.{secret}")
 
 	ins.AddBoolFlag("enable-stabilityai", "是否启用 StabilityAI 文生图、图生图服务")
 	ins.AddBoolFlag("stabilityai-autoproxy", "使用 socks5 代理访问 StabilityAI 服务")
@@ -125,6 +146,13 @@ func initCmdFlags(ins *app.App) {
 	ins.AddStringSliceFlag("deepai-servers", []string{"https://api.deepai.org"}, "deepai servers")
 	ins.AddFlags(app.StringEnvFlag("deepai-key", "", "deepai key", "DEEPAI_KEY"))
 
+	ins.AddStringFlag("replicate-server", "https://api.replicate.com", "replicate.com 服务地址")
+	ins.AddFlags(app.StringEnvFlag("replicate-key", "", "replicate.com API Token", "REPLICATE_KEY"))
+	ins.AddBoolFlag("enable-avatar-pack", "是否启用数字分身写真，需要配置 replicate.com API Token")
+	ins.AddStringFlag("avatar-pack-model-version", "", "数字分身写真使用的 Replicate 模型版本（InstantID 类模型，输入参数为 image、prompt、negative_prompt）")
+	ins.AddDurationFlag("avatar-pack-selfie-retention", 24*time.Hour, "用户上传的自拍照最长保留时间，生成完成后立即删除")
+	ins.AddDurationFlag("creative-quote-ttl", 10*time.Minute, "创作岛任务报价的有效期，有效期内确认时按照报价的价格扣费")
+
 	ins.AddBoolFlag("enable-getimgai", "是否启用 getimg.ai 文生图、图生图服务")
 	ins.AddBoolFlag("getimgai-autoproxy", "使用 socks5 代理访问 getimg.ai 服务")
 	ins.AddStringFlag("getimgai-server", "https://api.getimg.ai", "getimgai server")
@@ -135,6 +163,66 @@ func initCmdFlags(ins *app.App) {
 	ins.AddStringSliceFlag("leptonai-qr-servers", []string{"https://aiqr.lepton.run"}, "lepton.ai QR servers")
 	ins.AddStringSliceFlag("leptonai-keys", []string{os.Getenv("LEPTONAI_KEY")}, "lepton.ai keys")
 
+	ins.AddBoolFlag("enable-local-embedding", "是否启用本地部署的 Embedding 模型服务，服务需要兼容 OpenAI 的 /v1/embeddings 接口")
+	ins.AddStringFlag("local-embedding-server", "http://127.0.0.1:9997/v1", "本地 Embedding 模型服务地址，不要忘记在在 URL 后面添加 /v1")
+	ins.AddStringFlag("local-embedding-key", "", "本地 Embedding 模型服务的访问密钥")
+	ins.AddStringSliceFlag("local-embedding-models", []string{"bge-large-zh"}, "本地 Embedding 服务支持的模型列表")
+	ins.AddIntFlag("embedding-max-inputs", 100, "Embedding 接口单次请求最多支持的输入条数")
+	ins.AddIntFlag("vector-collection-max-docs", 10000, "单个向量集合最多支持的文档数量")
+
+	ins.AddStringFlag("ocr-engine", "", "文字识别引擎：paddle（本地部署的 PaddleOCR）/tencent（腾讯云，使用 tencent-id 和 tencent-key），留空则不启用")
+	ins.AddStringFlag("paddle-ocr-server", "http://127.0.0.1:8868", "PaddleOCR（PaddleHub Serving）服务地址")
+	ins.AddIntFlag("ocr-max-pages", 10, "单次文字识别最多支持的页数（图片数量）")
+
+	ins.AddStringFlag("pdftotext-bin", "pdftotext", "pdftotext 命令路径（poppler-utils），用于提取 PDF 文本")
+	ins.AddIntFlag("pdf-max-pages", 200, "PDF 文档最多支持的页数")
+	ins.AddStringFlag("pdf-embedding-model", "text-embedding-ada-002", "PDF 文档向量化使用的模型")
+
+	ins.AddIntFlag("table-max-rows", 20000, "表格问答上传的 CSV/XLSX 文件最多支持的行数")
+
+	ins.AddStringFlag("essay-grading-model", "", "作文批改使用的模型，留空则不启用作文批改")
+	ins.AddStringFlag("mock-interview-model", "", "模拟面试使用的模型，留空则不启用模拟面试")
+
+	ins.AddStringFlag("resume-polish-model", "gpt-3.5-turbo", "简历优化默认使用的模型")
+
+	ins.AddStringFlag("group-judge-model", "", "群聊中对多个成员的回答进行评审的模型，留空则不启用评审")
+	ins.AddStringFlag("group-consensus-model", "", "群聊中将多个成员的回答合并为一个综合回答的模型，留空则不启用")
+
+	ins.AddStringFlag("prompt-suggestion-model", "gpt-3.5-turbo", "生成追问建议使用的模型，留空则不启用")
+	ins.AddStringSliceFlag("prompt-suggestion-user-types", []string{}, "允许使用追问建议的用户类型（0-普通用户 1-内部用户 2-测试用户 3-例外用户），为空时所有用户都可以使用")
+
+	ins.AddStringSliceFlag("restricted-mode-models", []string{"gpt-3.5-turbo"}, "受限模式（家长模式）下允许使用的模型")
+	ins.AddStringSliceFlag("restricted-mode-image-vendors", []string{"dalle"}, "受限模式下允许使用的图片生成服务商，只应包含自带内容安全过滤的服务商")
+	ins.AddIntFlag("restricted-mode-daily-limit", 100, "受限模式下默认的每日智慧果使用上限")
+
+	ins.AddBoolFlag("enable-abuse-detect", "是否启用异常使用行为检测（重复请求、脚本化请求、疑似转售等），检测到异常时自动限流并进入人工审核队列")
+	ins.AddIntFlag("abuse-identical-prompt-threshold", 200, "一小时内相同请求内容的次数达到该值时标记为异常")
+	ins.AddIntFlag("abuse-distinct-ip-threshold", 20, "一小时内请求来源 IP 数量达到该值时标记为异常（疑似转售）")
+	ins.AddDurationFlag("abuse-throttle-duration", 24*time.Hour, "标记为异常后自动限流的时长")
+
+	ins.AddStringFlag("geo-region-header", "CF-IPCountry", "请求来源地区（ISO 3166-1 国家代码）的请求头，由 CDN 或反向代理设置，用于地区访问策略")
+
+	ins.AddStringFlag("backup-dir", "/data/backups", "备份文件的存储目录，备份中包含用户数据，请妥善设置目录权限")
+
+	ins.AddBoolFlag("enable-chaos", "是否启用模型服务商的故障注入（随机返回超时、429、异常的 SSE 数据以及被截断的响应），仅用于测试环境，禁止在生产环境中启用")
+	ins.AddFloat64Flag("chaos-rate", 0.1, "故障注入的概率，取值范围 0-1")
+	ins.AddStringSliceFlag("chaos-faults", []string{}, "注入的故障类型，可选值为 timeout、rate_limit、malformed_sse、truncated，为空时注入全部类型")
+
+	ins.AddBoolFlag("enable-request-sign", "是否校验客户端请求签名（HMAC-SHA256，包含时间戳与随机数），未启用 request-sign-enforce 时只记录校验失败的请求")
+	ins.AddBoolFlag("request-sign-enforce", "是否拒绝签名校验失败的请求")
+	ins.AddStringSliceFlag("request-sign-keys", []string{}, "客户端内置的请求签名密钥，支持多个以便轮换")
+	ins.AddDurationFlag("request-sign-tolerance", 5*time.Minute, "请求签名时间戳允许的最大误差")
+
+	ins.AddBoolFlag("enable-image-watermark", "是否在生成的图片中嵌入不可见的 AI 生成内容水印（包含模型、服务商与生成时间），启用后生成的图片统一保存为 PNG 格式")
+
+	ins.AddBoolFlag("enable-sandbox", "是否启用代码执行沙箱（Code Interpreter），需要服务器安装 Docker")
+	ins.AddStringFlag("sandbox-docker-bin", "docker", "代码执行沙箱使用的 docker 命令路径")
+	ins.AddStringFlag("sandbox-image", "python:3.11-slim", "代码执行沙箱使用的镜像")
+	ins.AddStringFlag("sandbox-runtime", "", "代码执行沙箱使用的容器运行时，例如 runsc（gVisor），留空则使用 Docker 默认运行时")
+	ins.AddStringFlag("sandbox-memory", "256m", "代码执行沙箱内存限制")
+	ins.AddStringFlag("sandbox-cpus", "0.5", "代码执行沙箱 CPU 限制")
+	ins.AddDurationFlag("sandbox-timeout", 30*time.Second, "代码执行沙箱单次执行超时时间")
+
 	ins.AddBoolFlag("enable-fromstonai", "是否启用 6pen 的文生图、图生图服务")
 	ins.AddStringFlag("fromston-server", "https://ston.6pen.art", "fromston server")
 	ins.AddStringFlag("fromston-key", "", "fromston key")
@@ -168,6 +256,7 @@ func initCmdFlags(ins *app.App) {
 	ins.AddStringFlag("tencent-smssdkappid", "", "tencent sms sdk app id")
 	ins.AddStringFlag("tencent-smstemplateid", "", "腾讯短信验证码模板 ID")
 	ins.AddStringFlag("tencent-smssign", "AIdea", "腾讯短信签名")
+	ins.AddDurationFlag("realtime-voice-max-duration", 30*time.Minute, "实时语音对话单次会话的最长时长，需要启用 Websocket 支持")
 	ins.AddBoolFlag("tencent-voice", "是否使用腾讯的语音转文本服务，不启用则使用 OpenAI 的 Whisper 模型")
 	ins.AddIntFlag("tencent-appid", 0, "腾讯云 APP ID，用于腾讯混元大模型")
 	ins.AddBoolFlag("enable-tencentai", "是否启用腾讯混元大模型 AI 服务")
@@ -179,6 +268,26 @@ func initCmdFlags(ins *app.App) {
 	ins.AddBoolFlag("enable-contentdetect", "是否启用内容安全检测（使用阿里云的内容安全服务）")
 
 	ins.AddBoolFlag("enable-applepay", "启用 Apple 应用内支付")
+	ins.AddStringFlag("exchange-rate-api", "https://open.er-api.com/v6/latest/CNY", "人民币汇率查询接口，用于计算海外用户看到的商品展示价格，留空则不换算")
+	ins.AddStringFlag("applepay-bundle-id", "", "Apple 应用内支付的 App Bundle ID，用于校验 App Store 服务器通知，留空则不校验")
+
+	ins.AddBoolFlag("enable-coin-transfer", "是否允许用户之间转赠智慧果")
+	ins.AddIntFlag("coin-transfer-daily-limit", 10000, "每个用户每天最多转出的智慧果数量，设置为 0 则不限制")
+	ins.AddIntFlag("coin-transfer-monthly-limit", 100000, "每个用户每月最多转出的智慧果数量，设置为 0 则不限制")
+	ins.AddIntFlag("coin-transfer-daily-recipients", 5, "每个用户每天最多向多少个不同的账号转赠智慧果，设置为 0 则不限制")
+	ins.AddDurationFlag("coin-transfer-min-account-age", 7*24*time.Hour, "注册时间超过该时长的账号才能转出智慧果，用于防止批量注册账号薅羊毛")
+
+	ins.AddIntFlag("max-coins-per-request", 1000, "单次请求预计消耗的智慧果超过该值时，需要用户确认后才会执行，避免误操作（例如粘贴了整本书）消耗大量智慧果，0 表示不限制")
+
+	ins.AddBoolFlag("enable-reply-language", "是否检测用户提问的语言，并要求模型使用相同的语言回复，用户可以为每个会话单独设置回复语言")
+
+	ins.AddStringFlag("writing-tool-model", "gpt-3.5-turbo", "写作工具（改写、扩写、缩写、调整语气）使用的模型，留空则不启用写作工具")
+	ins.AddStringFlag("study-model", "gpt-3.5-turbo", "学习模式生成记忆卡片使用的模型，留空则不启用学习模式")
+	ins.AddStringFlag("tutor-model", "gpt-3.5-turbo", "作业辅导模式使用的模型，留空则不启用作业辅导模式，开启受限模式的用户还需要该模型在 restricted-mode-models 中")
+	ins.AddStringFlag("tutor-answer-policy", "guide", "作业辅导模式默认的答案策略：guide-只引导解题思路，不直接给出最终答案 answer-引导之后允许给出最终答案")
+
+	ins.AddBoolFlag("balance-auto-repair", "余额校验发现配额记录与记账分录不一致时，是否自动以记账分录为准修正")
+	ins.AddIntFlag("balance-repair-max-drift", 10000, "余额差额超过该值时只通知管理员，不自动修正，0 表示不限制")
 
 	ins.AddBoolFlag("enable-alipay", "启用支付宝支付支持，需要指定 alipay-xxx 的所有配置项")
 	ins.AddStringFlag("alipay-appid", "", "支付宝 APP ID")
@@ -189,11 +298,43 @@ func initCmdFlags(ins *app.App) {
 	ins.AddStringFlag("alipay-notify-url", "https://ai-api.aicode.cc/v1/payment/callback/alipay-notify", "支付宝支付回调地址")
 	ins.AddStringFlag("alipay-return-url", "https://ai-api.aicode.cc/public/payment/alipay-return", "支付宝支付 return url")
 	ins.AddBoolFlag("alipay-sandbox", "是否使用支付宝沙箱环境")
+	ins.AddBoolFlag("enable-auto-topup", "是否允许用户签约支付宝商家扣款，余额低于设定值时自动充值，需要开通支付宝商家扣款产品")
+	ins.AddDurationFlag("auto-topup-notice-period", time.Hour, "自动充值扣款前提前通知用户的时间，用户可以在此期间取消本次扣款")
+	ins.AddStringFlag("alipay-agreement-notify-url", "https://ai-api.aicode.cc/v1/payment/callback/alipay-agreement-notify", "支付宝商家扣款签约、解约结果通知地址")
 
 	ins.AddStringSliceFlag("sms-channels", []string{}, "启用的短信通道，支持腾讯云和阿里云: tencent, aliyun，多个值时随机每次发送随机选择")
 
 	ins.AddStringFlag("log-path", "", "日志文件存储目录，留空则写入到标准输出")
 	ins.AddBoolFlag("log-colorful", "是否启用彩色日志")
+	ins.AddStringFlag("log-level", "DEBUG", "日志级别：DEBUG/INFO/NOTICE/WARNING/ERROR/CRITICAL/ALERT/EMERGENCY，运行时可以通过管理员接口调整")
+	ins.AddDurationFlag("slow-query-threshold", 200*time.Millisecond, "慢查询阈值，超过阈值的数据库查询会记录到日志，设置为 0 时不记录")
+	ins.AddDurationFlag("slow-endpoint-threshold", 2*time.Second, "慢接口阈值，用于每周性能报告中统计慢请求次数，设置为 0 时不统计")
+	ins.AddBoolFlag("enable-pprof", "是否启用管理员 pprof 接口（/v1/admin/pprof/）")
+	ins.AddDurationFlag("deleted-message-retention", 30*24*time.Hour, "已删除的群聊消息的保留期限，期间仍可用于计费核对，超过期限后物理删除")
+	ins.AddDurationFlag("room-trash-retention", 30*24*time.Hour, "删除的会话在回收站中的保留期限，期间可以恢复，超过期限后物理删除")
+	ins.AddBoolFlag("enable-message-encryption", "是否加密保存聊天消息内容，启用后可以使用 aidea-admin messages encrypt 加密历史消息")
+	ins.AddStringSliceFlag("message-encryption-keys", []string{}, "消息加密的主密钥，格式为 <主密钥 ID>:<base64 编码的 32 字节密钥>，轮换密钥时需要保留旧的主密钥用于解密历史消息")
+	ins.AddStringFlag("message-encryption-active-key", "", "加密新消息使用的主密钥 ID，使用 Vault 时为 Transit 中的密钥名称")
+	ins.AddStringFlag("message-encryption-vault-server", "", "Vault 服务地址，配置后消息加密的主密钥托管在 Vault Transit 中")
+	ins.AddStringFlag("message-encryption-vault-token", "", "Vault 访问 Token")
+	ins.AddBoolFlag("enable-ldap", "是否启用 LDAP 登录，首次登录时自动创建用户")
+	ins.AddStringFlag("ldap-server", "", "LDAP 服务地址，格式为 ldap://host:389 或 ldaps://host:636")
+	ins.AddBoolFlag("ldap-start-tls", "使用 ldap:// 连接 LDAP 服务时，是否通过 StartTLS 升级为加密连接")
+	ins.AddStringFlag("ldap-bind-dn", "", "查询用户使用的 LDAP 服务账号，留空时匿名查询")
+	ins.AddStringFlag("ldap-bind-password", "", "LDAP 服务账号密码")
+	ins.AddStringFlag("ldap-base-dn", "", "查询用户的根节点，例如 ou=users,dc=example,dc=com")
+	ins.AddStringFlag("ldap-user-attr", "uid", "用户登录名对应的 LDAP 属性，Active Directory 一般为 sAMAccountName")
+	ins.AddStringFlag("ldap-email-attr", "mail", "用户邮箱对应的 LDAP 属性")
+	ins.AddStringFlag("ldap-name-attr", "cn", "用户姓名对应的 LDAP 属性")
+	ins.AddStringFlag("ldap-group-attr", "memberOf", "用户所属分组对应的 LDAP 属性")
+	ins.AddBoolFlag("enable-oidc", "是否启用 OIDC 登录，首次登录时自动创建用户")
+	ins.AddStringFlag("oidc-issuer", "", "OIDC 身份提供方地址，例如 https://keycloak.example.com/realms/aidea")
+	ins.AddStringFlag("oidc-client-id", "", "OIDC 客户端 ID")
+	ins.AddStringFlag("oidc-client-secret", "", "OIDC 客户端密钥")
+	ins.AddStringFlag("oidc-redirect-url", "", "OIDC 授权完成后的回调地址，客户端从回调地址中获取 code 和 state 完成登录")
+	ins.AddStringSliceFlag("oidc-scopes", []string{"openid", "profile", "email"}, "OIDC 申请的授权范围")
+	ins.AddStringFlag("oidc-groups-claim", "groups", "ID Token 中用户所属分组对应的字段")
+	ins.AddStringSliceFlag("sso-group-user-types", []string{}, "LDAP/OIDC 分组与用户类型的映射，格式为 <分组>=<用户类型>，按顺序匹配，例如 aidea-admins=1，配置后每次登录时按分组更新用户类型")
 
 	ins.AddStringFlag("dingding-token", "", "钉钉群通知 Token，留空则不通知")
 	ins.AddStringFlag("dingding-secret", "", "钉钉群通知 Secret")
@@ -205,6 +346,7 @@ func initCmdFlags(ins *app.App) {
 
 	ins.AddStringFlag("default-img2img-model", "lb-realistic-versionv4.0", "默认的图生图模型，值取自数据表 image_model.model_id")
 	ins.AddStringFlag("default-txt2img-model", "sb-stable-diffusion-xl-1024-v1-0", "默认的文生图模型，值取自数据表 image_model.model_id")
+	ins.AddStringFlag("magic-prompt-model", "gpt-3.5-turbo", "优化（扩写）图片提示语使用的模型，建议使用价格较低的模型")
 
 	ins.AddBoolFlag("enable-virtual-model", "是否启用虚拟模型")
 	ins.AddStringFlag("virtual-model-implementation", "openai", "虚拟模型实现厂商")
@@ -3,6 +3,7 @@ package coins
 import (
 	"github.com/mylxsw/asteria/log"
 	"os"
+	"strings"
 
 	"github.com/mylxsw/go-utils/array"
 	"gopkg.in/yaml.v3"
@@ -13,6 +14,12 @@ type PriceInfo struct {
 	CoinTables map[string]CoinTable `json:"coin_tables" yaml:"coin_tables"`
 	// Products 在线支付产品列表
 	Products []Product `json:"products,omitempty" yaml:"products,omitempty"`
+	// UpstreamPrices 服务商的模型价格，用于计算上游成本
+	UpstreamPrices map[string]UpstreamPrice `json:"upstream_prices,omitempty" yaml:"upstream_prices,omitempty"`
+	// RegionCurrencies 地区（ISO 3166-1 国家代码）对应的展示货币，例如 {CN: CNY, HK: HKD}
+	RegionCurrencies map[string]string `json:"region_currencies,omitempty" yaml:"region_currencies,omitempty"`
+	// DefaultCurrency 未配置货币的地区使用的展示货币，默认为 USD
+	DefaultCurrency string `json:"default_currency,omitempty" yaml:"default_currency,omitempty"`
 	// FreeModels 免费模型列表
 	FreeModels []ModelWithName `json:"free_models,omitempty" yaml:"free_models,omitempty"`
 
@@ -54,6 +61,11 @@ func LoadPriceInfo(tableFile string) error {
 		}
 	}
 
+	// 加载服务商的模型价格
+	for k, v := range priceInfo.UpstreamPrices {
+		upstreamPrices[k] = v
+	}
+
 	// 加载在线支付产品
 	// 如果配置了产品列表，则使用配置文件为主，否则使用默认产品列表
 	if len(priceInfo.Products) > 0 {
@@ -62,10 +74,27 @@ func LoadPriceInfo(tableFile string) error {
 				item.Description = buildDescription(item.Quota)
 			}
 
+			prices := make(map[string]int64, len(item.Prices))
+			for currency, price := range item.Prices {
+				prices[strings.ToUpper(currency)] = price
+			}
+			item.Prices = prices
+			item.Regions = array.Map(item.Regions, func(region string, _ int) string { return strings.ToUpper(region) })
+			item.ExcludeRegions = array.Map(item.ExcludeRegions, func(region string, _ int) string { return strings.ToUpper(region) })
+
 			return item
 		})
 	}
 
+	// 地区展示货币
+	for k, v := range priceInfo.RegionCurrencies {
+		regionCurrencies[strings.ToUpper(k)] = strings.ToUpper(v)
+	}
+
+	if priceInfo.DefaultCurrency != "" {
+		defaultCurrency = strings.ToUpper(priceInfo.DefaultCurrency)
+	}
+
 	// 免费模型列表
 	freeModels = priceInfo.FreeModels
 
@@ -82,6 +111,8 @@ func LoadPriceInfo(tableFile string) error {
 func DebugPrintPriceInfo() {
 	log.WithFields(log.Fields{
 		"products":                 Products,
+		"region_currencies":        regionCurrencies,
+		"default_currency":         defaultCurrency,
 		"free":                     freeModels,
 		"coins":                    coinTables,
 		"signup_gift_coins":        SignupGiftCoins,
@@ -0,0 +1,118 @@
+package coins
+
+import "sync"
+
+// UpstreamPrice 服务商公布的模型价格，单位为 人民币/1K Token，美元价格按照 1:7.2 换算
+type UpstreamPrice struct {
+	Input  float64 `json:"input" yaml:"input"`
+	Output float64 `json:"output" yaml:"output"`
+}
+
+// upstreamPrices 服务商的模型价格，用于计算每次请求的上游成本
+var upstreamPrices = map[string]UpstreamPrice{
+	// OpenAI https://openai.com/pricing
+	"gpt-3.5-turbo":          {Input: 0.0108, Output: 0.0144}, // $0.0015/$0.002
+	"gpt-3.5-turbo-0613":     {Input: 0.0108, Output: 0.0144}, // $0.0015/$0.002
+	"gpt-3.5-turbo-1106":     {Input: 0.0072, Output: 0.0144}, // $0.001/$0.002
+	"gpt-3.5-turbo-16k":      {Input: 0.0216, Output: 0.0288}, // $0.003/$0.004
+	"gpt-3.5-turbo-16k-0613": {Input: 0.0216, Output: 0.0288}, // $0.003/$0.004
+	"gpt-4":                  {Input: 0.216, Output: 0.432},   // $0.03/$0.06
+	"gpt-4-8k":               {Input: 0.216, Output: 0.432},   // $0.03/$0.06
+	"gpt-4-32k":              {Input: 0.432, Output: 0.864},   // $0.06/$0.12
+	"gpt-4-1106-preview":     {Input: 0.072, Output: 0.216},   // $0.01/$0.03
+	"gpt-4-vision-preview":   {Input: 0.072, Output: 0.216},   // $0.01/$0.03
+
+	// Anthropic https://www-cdn.anthropic.com/files/4zrzovbb/website/31021aea87c30ccaecbd2e966e49a03834bfd1d2.pdf
+	"claude-instant-1": {Input: 0.0118, Output: 0.0397}, // $1.63/$5.51 per million
+	"claude-2":         {Input: 0.0806, Output: 0.2353}, // $11.2/$32.68 per million
+
+	// Google https://ai.google.dev/pricing
+	"gemini-pro":        {Input: 0.0018, Output: 0.0036}, // $0.00025/$0.0005 per 1K characters
+	"gemini-pro-vision": {Input: 0.0018, Output: 0.0036}, // $0.00025/$0.0005 per 1K characters
+	"PaLM-2":            {Input: 0.0148, Output: 0.0148},
+
+	// 百度
+	"model_ernie_bot_turbo":       {Input: 0.008, Output: 0.008},
+	"model_ernie_bot":             {Input: 0.012, Output: 0.012},
+	"model_ernie_bot_8k":          {Input: 0.024, Output: 0.048},
+	"model_ernie_bot_4":           {Input: 0.12, Output: 0.12},
+	"model_badiu_llama2_70b":      {Input: 0.044, Output: 0.044},
+	"model_baidu_llama2_7b_cn":    {Input: 0.006, Output: 0.006},
+	"model_baidu_llama2_13b":      {Input: 0.008, Output: 0.008},
+	"model_baidu_chatglm2_6b_32k": {Input: 0.006, Output: 0.006},
+	"model_baidu_aquila_chat7b":   {Input: 0.006, Output: 0.006},
+	"model_baidu_bloomz_7b":       {Input: 0.006, Output: 0.006},
+
+	// 阿里
+	"qwen-v1":              {Input: 0.008, Output: 0.008},
+	"qwen-plus-v1":         {Input: 0.02, Output: 0.02},
+	"qwen-turbo":           {Input: 0.008, Output: 0.008},
+	"qwen-plus":            {Input: 0.02, Output: 0.02},
+	"baichuan2-7b-chat-v1": {Input: 0.006, Output: 0.006},
+	"qwen-7b-chat":         {Input: 0.006, Output: 0.006},
+	"qwen-14b-chat":        {Input: 0.008, Output: 0.008},
+
+	// 讯飞星火
+	"generalv3": {Input: 0.036, Output: 0.036},
+	"generalv2": {Input: 0.036, Output: 0.036},
+	"general":   {Input: 0.018, Output: 0.018},
+
+	// 腾讯
+	"hyllm":       {Input: 0.1, Output: 0.1},
+	"hunyuan-std": {Input: 0.01, Output: 0.01},
+	"hunyuan-pro": {Input: 0.1, Output: 0.1},
+
+	// 其它国产模型
+	"Baichuan2-53B":     {Input: 0.02, Output: 0.02},
+	"360GPT_S2_V9":      {Input: 0.012, Output: 0.012},
+	"chatglm_turbo":     {Input: 0.005, Output: 0.005},
+	"chatglm_pro":       {Input: 0.01, Output: 0.01},
+	"chatglm_std":       {Input: 0.005, Output: 0.005},
+	"chatglm_lite":      {Input: 0.004, Output: 0.004},
+	"01-ai.yi-34b-chat": {Input: 0.0058, Output: 0.0058},
+	"SkyChat-MegaVerse": {Input: 0.01, Output: 0.01},
+	"deepseek-chat":     {Input: 0.001, Output: 0.002},
+	"deepseek-coder":    {Input: 0.001, Output: 0.002},
+	"moonshot-v1-8k":    {Input: 0.012, Output: 0.012},
+	"moonshot-v1-32k":   {Input: 0.024, Output: 0.024},
+	"moonshot-v1-128k":  {Input: 0.06, Output: 0.06},
+	"glm-4":             {Input: 0.1, Output: 0.1},
+	"glm-4v":            {Input: 0.1, Output: 0.1},
+	"glm-3-turbo":       {Input: 0.005, Output: 0.005},
+}
+
+// dynamicUpstreamPrices 运行时从服务商同步或管理员配置的模型价格，价格表中配置的价格优先
+var dynamicUpstreamPrices sync.Map
+
+// SetDynamicUpstreamPrice 设置运行时同步的服务商模型价格
+func SetDynamicUpstreamPrice(model string, price UpstreamPrice) {
+	dynamicUpstreamPrices.Store(model, price)
+}
+
+// GetUpstreamPrice 查询服务商的模型价格
+func GetUpstreamPrice(model string) (UpstreamPrice, bool) {
+	if price, ok := upstreamPrices[model]; ok {
+		return price, true
+	}
+
+	if price, ok := dynamicUpstreamPrices.Load(model); ok {
+		return price.(UpstreamPrice), true
+	}
+
+	return UpstreamPrice{}, false
+}
+
+// GetUpstreamCost 计算请求的上游成本（人民币），模型没有配置价格时返回 false
+func GetUpstreamCost(model string, inputTokens, outputTokens int64) (float64, bool) {
+	price, ok := GetUpstreamPrice(ResolveContextTierModel(model, inputTokens+outputTokens))
+	if !ok {
+		return 0, false
+	}
+
+	return (price.Input*float64(inputTokens) + price.Output*float64(outputTokens)) / 1000, true
+}
+
+// CoinsToPrice 智慧果数量转换为价格（人民币），1 元 = 100 智慧果
+func CoinsToPrice(coins int64) float64 {
+	return float64(coins) / 100
+}
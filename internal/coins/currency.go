@@ -0,0 +1,117 @@
+package coins
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	CurrencyCNY = "CNY"
+	CurrencyUSD = "USD"
+
+	// DefaultRegion 无法识别用户所在地区时，按照中国大陆处理
+	DefaultRegion = "CN"
+)
+
+var (
+	// regionCurrencies 地区（ISO 3166-1 国家代码）对应的展示货币，未配置的地区使用 defaultCurrency
+	regionCurrencies = map[string]string{"CN": CurrencyCNY}
+	// defaultCurrency 未配置货币的地区使用的展示货币
+	defaultCurrency = CurrencyUSD
+)
+
+// currencySymbols 常用货币的符号，未配置的货币使用货币代码展示
+var currencySymbols = map[string]string{
+	"CNY": "¥",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"HKD": "HK$",
+	"TWD": "NT$",
+	"SGD": "S$",
+	"KRW": "₩",
+}
+
+// zeroDecimalCurrencies 没有辅币单位的货币，价格以元为单位保存
+var zeroDecimalCurrencies = []string{"JPY", "KRW", "TWD"}
+
+// CurrencyForRegion 返回地区使用的展示货币
+func CurrencyForRegion(region string) string {
+	region = normalizeRegion(region)
+	if currency, ok := regionCurrencies[region]; ok {
+		return currency
+	}
+
+	return defaultCurrency
+}
+
+// CurrencyDecimals 货币的小数位数，价格统一使用最小货币单位（例如 分、美分）保存
+func CurrencyDecimals(currency string) int {
+	if array.In(currency, zeroDecimalCurrencies) {
+		return 0
+	}
+
+	return 2
+}
+
+// FormatPrice 格式化价格，price 为最小货币单位，例如 FormatPrice("USD", 99) 返回 $0.99
+func FormatPrice(currency string, price int64) string {
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency + " "
+	}
+
+	decimals := CurrencyDecimals(currency)
+	if decimals == 0 {
+		return fmt.Sprintf("%s%d", symbol, price)
+	}
+
+	return fmt.Sprintf("%s%.*f", symbol, decimals, float64(price)/math.Pow10(decimals))
+}
+
+// ConvertPrice 将人民币价格（分）按照汇率换算为目标货币的价格（最小货币单位），rate 为 1 人民币可以兑换的目标货币数量
+func ConvertPrice(priceInFen int64, currency string, rate float64) int64 {
+	return int64(math.Round(float64(priceInFen) / 100 * rate * math.Pow10(CurrencyDecimals(currency))))
+}
+
+// VisibleIn 产品在指定地区是否可见，Regions 为空表示所有地区可见，ExcludeRegions 中的地区不可见
+func (ap Product) VisibleIn(region string) bool {
+	region = normalizeRegion(region)
+	if array.In(region, ap.ExcludeRegions) {
+		return false
+	}
+
+	return len(ap.Regions) == 0 || array.In(region, ap.Regions)
+}
+
+// LocalPrice 产品在指定货币下的展示价格（最小货币单位），rates 为人民币对各货币的汇率
+// 优先使用产品配置的价格（例如 App Store 各地区的价格档位），没有配置时按照汇率换算，此时 estimated 为 true
+func (ap Product) LocalPrice(currency string, rates map[string]float64) (price int64, estimated bool, ok bool) {
+	if p, exist := ap.Prices[currency]; exist {
+		return p, false, true
+	}
+
+	if currency == CurrencyCNY {
+		return ap.RetailPrice, false, true
+	}
+
+	rate, exist := rates[currency]
+	if !exist || rate <= 0 {
+		return 0, false, false
+	}
+
+	return ConvertPrice(ap.RetailPrice, currency, rate), true, true
+}
+
+func normalizeRegion(region string) string {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if region == "" {
+		return DefaultRegion
+	}
+
+	return region
+}
@@ -0,0 +1,55 @@
+package coins
+
+import (
+	"testing"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestCurrencyForRegion(t *testing.T) {
+	assert.Equal(t, CurrencyCNY, CurrencyForRegion("cn"))
+	assert.Equal(t, CurrencyCNY, CurrencyForRegion(""))
+	assert.Equal(t, CurrencyUSD, CurrencyForRegion("US"))
+	assert.Equal(t, CurrencyUSD, CurrencyForRegion("JP"))
+}
+
+func TestFormatPrice(t *testing.T) {
+	assert.Equal(t, "$0.99", FormatPrice("USD", 99))
+	assert.Equal(t, "¥6.00", FormatPrice("CNY", 600))
+	assert.Equal(t, "¥160", FormatPrice("JPY", 160))
+	assert.Equal(t, "CHF 1.20", FormatPrice("CHF", 120))
+}
+
+func TestProductLocalPrice(t *testing.T) {
+	prod := Product{RetailPrice: 600, Prices: map[string]int64{"USD": 99}}
+
+	price, estimated, ok := prod.LocalPrice("CNY", nil)
+	assert.True(t, ok)
+	assert.False(t, estimated)
+	assert.EqualValues(t, 600, price)
+
+	price, estimated, ok = prod.LocalPrice("USD", nil)
+	assert.True(t, ok)
+	assert.False(t, estimated)
+	assert.EqualValues(t, 99, price)
+
+	price, estimated, ok = prod.LocalPrice("EUR", map[string]float64{"EUR": 0.128})
+	assert.True(t, ok)
+	assert.True(t, estimated)
+	assert.EqualValues(t, 77, price)
+
+	price, _, ok = prod.LocalPrice("JPY", map[string]float64{"JPY": 20.5})
+	assert.True(t, ok)
+	assert.EqualValues(t, 123, price)
+
+	_, _, ok = prod.LocalPrice("GBP", map[string]float64{})
+	assert.False(t, ok)
+}
+
+func TestProductVisibleIn(t *testing.T) {
+	assert.True(t, Product{}.VisibleIn("US"))
+	assert.True(t, Product{Regions: []string{"CN"}}.VisibleIn(""))
+	assert.False(t, Product{Regions: []string{"CN"}}.VisibleIn("us"))
+	assert.False(t, Product{ExcludeRegions: []string{"US"}}.VisibleIn("US"))
+	assert.True(t, Product{ExcludeRegions: []string{"US"}}.VisibleIn("GB"))
+}
@@ -0,0 +1,96 @@
+package coins
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ForecastDailyUsage 根据最近每天的智慧果消耗（按日期从近到远排列，没有消耗的日期为 0）估算每天的消耗速度
+//
+// 使用指数加权平均，越近的日期权重越大，halfLife 天前的消耗权重减半，能够较快地反映用户使用习惯的变化
+func ForecastDailyUsage(usages []int64, halfLife float64) float64 {
+	if len(usages) == 0 {
+		return 0
+	}
+
+	if halfLife <= 0 {
+		halfLife = 7
+	}
+
+	var total, weights float64
+	for i, used := range usages {
+		if used < 0 {
+			used = 0
+		}
+
+		weight := math.Pow(0.5, float64(i)/halfLife)
+		total += float64(used) * weight
+		weights += weight
+	}
+
+	return total / weights
+}
+
+// Balance 一笔配额的剩余智慧果以及过期时间
+type Balance struct {
+	Rest  int64
+	EndAt time.Time
+}
+
+// ForecastRunOut 按照每天的消耗速度估算智慧果用完的时间，ok 为 false 表示没有消耗，无法估算
+//
+// 消耗时优先使用最早过期的配额，配额在用完之前过期时，剩余部分不再计入，因此结果会早于 余额 / 速度
+func ForecastRunOut(now time.Time, dailyUsage float64, balances []Balance) (runOutAt time.Time, ok bool) {
+	if dailyUsage <= 0 {
+		return time.Time{}, false
+	}
+
+	items := make([]Balance, 0, len(balances))
+	for _, b := range balances {
+		if b.Rest > 0 && b.EndAt.After(now) {
+			items = append(items, b)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].EndAt.Before(items[j].EndAt) })
+
+	perSecond := dailyUsage / (24 * 3600)
+	cur := now
+	for _, b := range items {
+		if !b.EndAt.After(cur) {
+			continue
+		}
+
+		// 当前配额在过期前能够消耗的数量
+		consumable := b.EndAt.Sub(cur).Seconds() * perSecond
+		if float64(b.Rest) <= consumable {
+			cur = cur.Add(time.Duration(float64(b.Rest) / perSecond * float64(time.Second)))
+		} else {
+			cur = b.EndAt
+		}
+	}
+
+	return cur, true
+}
+
+// RecommendProduct 推荐能够覆盖 need 个智慧果的最便宜的产品，没有足够大的产品时推荐智慧果最多的产品
+func RecommendProduct(products []Product, need int64) *Product {
+	var best, largest *Product
+	for i := range products {
+		p := &products[i]
+		if largest == nil || p.Quota > largest.Quota {
+			largest = p
+		}
+
+		if p.Quota >= need && (best == nil || p.RetailPrice < best.RetailPrice || (p.RetailPrice == best.RetailPrice && p.Quota > best.Quota)) {
+			best = p
+		}
+	}
+
+	if best != nil {
+		return best
+	}
+
+	return largest
+}
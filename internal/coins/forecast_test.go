@@ -0,0 +1,49 @@
+package coins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestForecastDailyUsage(t *testing.T) {
+	assert.Equal(t, float64(0), ForecastDailyUsage(nil, 7))
+	assert.Equal(t, float64(100), ForecastDailyUsage([]int64{100, 100, 100}, 7))
+
+	// 最近的消耗权重更大
+	assert.True(t, ForecastDailyUsage([]int64{200, 0, 0, 0}, 7) > ForecastDailyUsage([]int64{0, 0, 0, 200}, 7))
+}
+
+func TestForecastRunOut(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, ok := ForecastRunOut(now, 0, []Balance{{Rest: 100, EndAt: now.AddDate(1, 0, 0)}})
+	assert.False(t, ok)
+
+	runOut, ok := ForecastRunOut(now, 10, []Balance{{Rest: 100, EndAt: now.AddDate(1, 0, 0)}})
+	assert.True(t, ok)
+	assert.Equal(t, now.AddDate(0, 0, 10), runOut)
+
+	// 7 天后过期的 100 个智慧果只能用掉 70 个，剩余的 50 个再用 5 天
+	runOut, ok = ForecastRunOut(now, 10, []Balance{
+		{Rest: 50, EndAt: now.AddDate(1, 0, 0)},
+		{Rest: 100, EndAt: now.AddDate(0, 0, 7)},
+		{Rest: 100, EndAt: now.AddDate(0, 0, -1)},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, now.AddDate(0, 0, 12), runOut)
+}
+
+func TestRecommendProduct(t *testing.T) {
+	products := []Product{
+		{ID: "small", Quota: 100, RetailPrice: 100},
+		{ID: "medium", Quota: 1000, RetailPrice: 800},
+		{ID: "large", Quota: 5000, RetailPrice: 3000},
+	}
+
+	assert.Equal(t, "small", RecommendProduct(products, 50).ID)
+	assert.Equal(t, "medium", RecommendProduct(products, 101).ID)
+	assert.Equal(t, "large", RecommendProduct(products, 100000).ID)
+	assert.True(t, RecommendProduct(nil, 10) == nil)
+}
@@ -2,7 +2,10 @@ package coins
 
 import (
 	"math"
+	"sync"
 	"time"
+
+	"github.com/mylxsw/go-utils/array"
 )
 
 var coinTables = map[string]CoinTable{
@@ -19,6 +22,9 @@ var coinTables = map[string]CoinTable{
 		// 512x512   -> $0.018
 		// 256x256   -> $0.016
 		"dall-e-2": 20,
+		// 智谱 CogView https://open.bigmodel.cn/pricing
+		// ¥0.25/张
+		"cogview-3": 30,
 	},
 
 	"openai": {
@@ -43,6 +49,7 @@ var coinTables = map[string]CoinTable{
 		// 百度 https://console.bce.baidu.com/qianfan/chargemanage/list
 		"model_ernie_bot_turbo":       2,  // valid 文心一言 ¥0.008/1K tokens
 		"model_ernie_bot":             4,  // valid 文心一言 ¥0.012/1K tokens
+		"model_ernie_bot_8k":          5,  // valid 文心一言 8K ¥0.024/1K tokens
 		"model_ernie_bot_4":           15, // valid 文心一言 4.0 ¥0.12/1K tokens
 		"model_badiu_llama2_70b":      6,  // valid llama2 70b ¥0.044元/千tokens
 		"model_baidu_llama2_7b_cn":    2,  // valid llama2 7b cn ¥0.006元/千tokens
@@ -62,6 +69,7 @@ var coinTables = map[string]CoinTable{
 		"qwen-max":             1, // 官方限时免费
 		"qwen-max-longcontext": 1, // 官方限时免费
 		"qwen-vl-plus":         1, // 官方限时免费
+		"qwen-vl-max":          1, // 官方限时免费
 
 		// 讯飞星火 https://xinghuo.xfyun.cn/sparkapi
 		"generalv3": 5, // valid 讯飞星火 v3    ¥0.036/1K tokens
@@ -73,7 +81,9 @@ var coinTables = map[string]CoinTable{
 		"nova-ptc-xs-v1": 2, // 小参数量
 
 		// 腾讯
-		"hyllm": 15, // valid 腾讯混元大模型 ¥0.10/1K tokens
+		"hyllm":       15, // valid 腾讯混元大模型 ¥0.10/1K tokens
+		"hunyuan-std": 2,  // valid 腾讯混元大模型标准版 ¥0.01/1K tokens
+		"hunyuan-pro": 15, // valid 腾讯混元大模型高级版 ¥0.10/1K tokens
 
 		// 百川 https://platform.baichuan-ai.com/price
 		"Baichuan2-53B": 3, // valid 百川 53b ¥0.02/1K tokens
@@ -98,6 +108,52 @@ var coinTables = map[string]CoinTable{
 
 		// 天工 https://model-platform.tiangong.cn/pricing
 		"SkyChat-MegaVerse": 2, // valid ¥0.01/1K tokens
+
+		// DeepSeek https://platform.deepseek.com/api-docs/pricing
+		"deepseek-chat":  1, // valid ¥0.002/1K tokens
+		"deepseek-coder": 1, // valid ¥0.002/1K tokens
+
+		// Moonshot https://platform.moonshot.cn/pricing
+		// 长上下文模型按照实际使用的上下文长度分档计费，参考 ResolveContextTierModel
+		"moonshot-v1-8k":   2, // valid ¥0.012/1K tokens
+		"moonshot-v1-32k":  4, // valid ¥0.024/1K tokens
+		"moonshot-v1-128k": 9, // valid ¥0.06/1K tokens
+
+		// 智谱 AI https://open.bigmodel.cn/pricing
+		"glm-4":       15, // valid ¥0.1/1K tokens
+		"glm-4v":      15, // valid ¥0.1/1K tokens
+		"glm-3-turbo": 1,  // valid ¥0.005/1K tokens
+	},
+
+	// 文本向量化，1000 Token 计费
+	"embedding": {
+		"default":                1,
+		"text-embedding-ada-002": 1, // valid $0.0001/1K tokens -> ¥0.0007/1K tokens
+	},
+
+	// 代码执行沙箱，按照执行秒数计费
+	"sandbox": {
+		"per-second": 1,
+	},
+
+	// 实时语音对话，按照会话时长计费
+	"realtime-voice": {
+		"per-second": 1,
+	},
+
+	// 文字识别，按照页数（图片数量）计费
+	"ocr": {
+		"per-page": 2,
+	},
+
+	// 作文批改，按篇计费，拍照上传时额外收取文字识别费用
+	"essay-grading": {
+		"per-essay": 10,
+	},
+
+	// 数字分身写真，按照生成的图片数量计费
+	"avatar-pack": {
+		"per-image": 30,
 	},
 
 	"voice-recognition": {
@@ -119,6 +175,17 @@ var coinTables = map[string]CoinTable{
 	"upload": {
 		"qiniu": 1,
 	},
+
+	// 折扣（百分比）
+	"discount": {
+		// 批量离线任务按照 50% 计费
+		"batch": 50,
+		// 写作工具（改写、扩写等）使用固定的提示语，按照 50% 计费
+		"writing-tool": 50,
+	},
+
+	// 高级模型试用，每个用户对每个模型的免费试用次数（不按天重置），例如 "gpt-4": 3
+	"trial": {},
 }
 
 func GetCoinsTable() map[string]CoinTable {
@@ -132,8 +199,61 @@ func PriceToCoins(price float64, serviceFeeRate float64) int64 {
 
 // 智慧果计费
 
+// contextTiers 按照上下文长度分档计费的模型，同一系列的模型按照上下文长度从小到大排列
+var contextTiers = [][]contextTier{
+	{
+		{model: "moonshot-v1-8k", size: 8 * 1024},
+		{model: "moonshot-v1-32k", size: 32 * 1024},
+		{model: "moonshot-v1-128k", size: 128 * 1024},
+	},
+}
+
+type contextTier struct {
+	model string
+	size  int64
+}
+
+// ResolveContextTierModel 长上下文模型按照实际使用的 Token 数选择能够容纳的最小档位计费，不会超过用户选择的档位
+func ResolveContextTierModel(model string, tokenCount int64) string {
+	for _, tiers := range contextTiers {
+		if !array.In(model, array.Map(tiers, func(tier contextTier, _ int) string { return tier.model })) {
+			continue
+		}
+
+		for _, tier := range tiers {
+			if tier.model == model || tokenCount <= tier.size {
+				return tier.model
+			}
+		}
+	}
+
+	return model
+}
+
+// dynamicTextPrices 运行时从服务商同步的模型价格（每 1K Token 消耗的智慧果），价格表中配置的价格优先
+var dynamicTextPrices sync.Map
+
+// SetDynamicTextPrice 设置运行时同步的模型价格，例如 OpenRouter 按照上游价格加价后的价格
+func SetDynamicTextPrice(model string, unit int64) {
+	dynamicTextPrices.Store(model, unit)
+}
+
+func textCoinUnit(model string) (int64, bool) {
+	if unit, ok := coinTables["openai"][model]; ok {
+		return unit, true
+	}
+
+	if unit, ok := dynamicTextPrices.Load(model); ok {
+		return unit.(int64), true
+	}
+
+	return 0, false
+}
+
 func GetOpenAITextCoins(model string, wordCount int64) int64 {
-	unit, ok := coinTables["openai"][model]
+	model = ResolveContextTierModel(model, wordCount)
+
+	unit, ok := textCoinUnit(model)
 	if !ok {
 		return 50
 	}
@@ -148,8 +268,72 @@ func GetOpenAITextCoins(model string, wordCount int64) int64 {
 	return int64(math.Ceil(float64(unit) * float64(wordCount) / 1000.0))
 }
 
+// GetBatchChatCoins 批量离线聊天任务计费，在常规价格基础上按照折扣计算
+func GetBatchChatCoins(model string, wordCount int64) int64 {
+	return discountedTextCoins("batch", model, wordCount)
+}
+
+// GetWritingToolCoins 写作工具计费，在常规价格基础上按照折扣计算
+func GetWritingToolCoins(model string, wordCount int64) int64 {
+	return discountedTextCoins("writing-tool", model, wordCount)
+}
+
+func discountedTextCoins(discount string, model string, wordCount int64) int64 {
+	normal := GetOpenAITextCoins(model, wordCount)
+
+	rate, ok := coinTables["discount"][discount]
+	if !ok || rate <= 0 || rate >= 100 {
+		return normal
+	}
+
+	return int64(math.Ceil(float64(normal) * float64(rate) / 100.0))
+}
+
+// GetEmbeddingCoins 文本向量化计费，按照 1000 Token 计费
+func GetEmbeddingCoins(model string, tokenCount int64) int64 {
+	unit, ok := coinTables["embedding"][model]
+	if !ok {
+		unit = coinTables["embedding"]["default"]
+	}
+
+	return int64(math.Ceil(float64(unit) * float64(tokenCount) / 1000.0))
+}
+
+// GetSandboxCoins 代码执行沙箱计费，按照执行时长（秒，向上取整）计费
+func GetSandboxCoins(duration time.Duration) int64 {
+	if duration <= 0 {
+		return 0
+	}
+
+	return int64(math.Ceil(duration.Seconds())) * coinTables["sandbox"]["per-second"]
+}
+
+// GetRealtimeVoiceCoins 实时语音对话计费，按照会话时长（秒，向上取整）计费
+func GetRealtimeVoiceCoins(duration time.Duration) int64 {
+	if duration <= 0 {
+		return 0
+	}
+
+	return int64(math.Ceil(duration.Seconds())) * coinTables["realtime-voice"]["per-second"]
+}
+
+// GetOCRCoins 文字识别计费，按照页数计费
+func GetOCRCoins(pages int) int64 {
+	return int64(pages) * coinTables["ocr"]["per-page"]
+}
+
+// GetAvatarPackCoins 数字分身写真计费，images 为生成的图片数量
+func GetAvatarPackCoins(images int) int64 {
+	return coinTables["avatar-pack"]["per-image"] * int64(images)
+}
+
+// GetEssayGradingCoins 作文批改计费，images 为需要文字识别的图片数量
+func GetEssayGradingCoins(images int) int64 {
+	return coinTables["essay-grading"]["per-essay"] + GetOCRCoins(images)
+}
+
 func GetOpenAITokensForCoins(model string, coins int64) int64 {
-	unit, ok := coinTables["openai"][model]
+	unit, ok := textCoinUnit(model)
 	if !ok {
 		return 0
 	}
@@ -2,7 +2,9 @@ package coins_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/mylxsw/aidea-server/internal/coins"
 	"github.com/mylxsw/go-utils/assert"
@@ -102,3 +104,65 @@ func TestLoadCoinsTable(t *testing.T) {
 func TestSpeechCoins(t *testing.T) {
 	fmt.Println(coins.GetTextToVoiceCoins("tts-1", 100))
 }
+
+func TestGetBatchChatCoins(t *testing.T) {
+	assert.Equal(t, int64(2), coins.GetBatchChatCoins("gpt-3.5-turbo", 1000))
+	assert.Equal(t, int64(24), coins.GetBatchChatCoins("gpt-4-1106-preview", 1600))
+	assert.Equal(t, int64(1), coins.GetBatchChatCoins("gpt-3.5-turbo", 1))
+	assert.Equal(t, int64(0), coins.GetBatchChatCoins("gpt-3.5-turbo", 0))
+}
+
+func TestGetWritingToolCoins(t *testing.T) {
+	assert.Equal(t, int64(2), coins.GetWritingToolCoins("gpt-3.5-turbo", 1000))
+	assert.Equal(t, int64(3), coins.GetWritingToolCoins("gpt-3.5-turbo", 2000))
+	assert.Equal(t, int64(0), coins.GetWritingToolCoins("gpt-3.5-turbo", 0))
+}
+
+func TestGetEmbeddingCoins(t *testing.T) {
+	assert.Equal(t, int64(1), coins.GetEmbeddingCoins("text-embedding-ada-002", 1))
+	assert.Equal(t, int64(3), coins.GetEmbeddingCoins("text-embedding-ada-002", 2500))
+	assert.Equal(t, int64(2), coins.GetEmbeddingCoins("bge-large-zh", 1001))
+	assert.Equal(t, int64(0), coins.GetEmbeddingCoins("bge-large-zh", 0))
+}
+
+func TestGetSandboxCoins(t *testing.T) {
+	assert.Equal(t, int64(0), coins.GetSandboxCoins(0))
+	assert.Equal(t, int64(1), coins.GetSandboxCoins(200*time.Millisecond))
+	assert.Equal(t, int64(3), coins.GetSandboxCoins(2100*time.Millisecond))
+}
+
+func TestGetOCRCoins(t *testing.T) {
+	assert.Equal(t, int64(0), coins.GetOCRCoins(0))
+	assert.Equal(t, int64(6), coins.GetOCRCoins(3))
+}
+
+func TestGetEssayGradingCoins(t *testing.T) {
+	assert.Equal(t, int64(10), coins.GetEssayGradingCoins(0))
+	assert.Equal(t, int64(14), coins.GetEssayGradingCoins(2))
+}
+
+func TestResolveContextTierModel(t *testing.T) {
+	assert.Equal(t, "moonshot-v1-8k", coins.ResolveContextTierModel("moonshot-v1-128k", 3000))
+	assert.Equal(t, "moonshot-v1-32k", coins.ResolveContextTierModel("moonshot-v1-128k", 10000))
+	assert.Equal(t, "moonshot-v1-128k", coins.ResolveContextTierModel("moonshot-v1-128k", 100000))
+	assert.Equal(t, "moonshot-v1-8k", coins.ResolveContextTierModel("moonshot-v1-8k", 10000))
+	assert.Equal(t, "gpt-4", coins.ResolveContextTierModel("gpt-4", 100))
+
+	assert.Equal(t, coins.GetOpenAITextCoins("moonshot-v1-8k", 3000), coins.GetOpenAITextCoins("moonshot-v1-128k", 3000))
+}
+
+func TestGetUpstreamCost(t *testing.T) {
+	cost, ok := coins.GetUpstreamCost("deepseek-chat", 2000, 1000)
+	assert.True(t, ok)
+	assert.True(t, math.Abs(cost-0.004) < 1e-9)
+
+	_, ok = coins.GetUpstreamCost("unknown-model", 1000, 1000)
+	assert.False(t, ok)
+
+	coins.SetDynamicUpstreamPrice("unknown-model", coins.UpstreamPrice{Input: 1, Output: 2})
+	cost, ok = coins.GetUpstreamCost("unknown-model", 1000, 500)
+	assert.True(t, ok)
+	assert.True(t, math.Abs(cost-2) < 1e-9)
+
+	assert.True(t, math.Abs(coins.CoinsToPrice(150)-1.5) < 1e-9)
+}
@@ -27,6 +27,18 @@ type Product struct {
 	Recommend        bool         `json:"recommend,omitempty" yaml:"recommend,omitempty"`
 	Description      string       `json:"description,omitempty" yaml:"description,omitempty"`
 	PlatformLimit    Platform     `json:"platform_limits,omitempty" yaml:"platform_limits,omitempty"`
+	// Prices 各货币的价格（最小货币单位），例如 {USD: 99}，人民币价格使用 RetailPrice
+	Prices map[string]int64 `json:"prices,omitempty" yaml:"prices,omitempty"`
+	// Regions 可见的地区（ISO 3166-1 国家代码），为空时所有地区可见
+	Regions []string `json:"regions,omitempty" yaml:"regions,omitempty"`
+	// ExcludeRegions 不可见的地区
+	ExcludeRegions []string `json:"exclude_regions,omitempty" yaml:"exclude_regions,omitempty"`
+
+	// 以下字段为根据用户所在地区计算的展示价格
+	Currency       string `json:"currency,omitempty" yaml:"-"`
+	Price          int64  `json:"price,omitempty" yaml:"-"`
+	PriceText      string `json:"price_text,omitempty" yaml:"-"`
+	PriceEstimated bool   `json:"price_estimated,omitempty" yaml:"-"`
 }
 
 type Platform string
@@ -0,0 +1,14 @@
+package coins
+
+import "strings"
+
+// GetModelTrialCount 模型的免费试用次数，未配置试用的模型返回 0
+func GetModelTrialCount(modelID string) int64 {
+	segs := strings.SplitN(modelID, ":", 2)
+	return coinTables["trial"][segs[len(segs)-1]]
+}
+
+// TrialModels 配置了免费试用的模型及其试用次数
+func TrialModels() CoinTable {
+	return coinTables["trial"]
+}
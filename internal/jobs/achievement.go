@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// AchievementCheckJob 为最近一段时间内有消费记录的用户检查新解锁的成就
+func AchievementCheckJob(ctx context.Context, db *sql.DB, svc *service.AchievementService) error {
+	q := query.Builder().
+		Table(model.QuotaUsageTable()).
+		Select(query.Raw("DISTINCT user_id")).
+		Where(model.FieldQuotaUsageCreatedAt, ">=", time.Now().Add(-70*time.Minute))
+
+	userIDs, err := eloquent.Query(ctx, db, q, func(row eloquent.Scanner) (int64, error) {
+		var userID int64
+		if err := row.Scan(&userID); err != nil {
+			return 0, err
+		}
+
+		return userID, nil
+	})
+	if err != nil {
+		log.Errorf("查询活跃用户失败: %v", err)
+		return err
+	}
+
+	for _, userID := range userIDs {
+		unlocked, err := svc.Check(ctx, userID)
+		if err != nil {
+			log.F(log.M{"user_id": userID}).Errorf("检查用户成就失败: %v", err)
+			continue
+		}
+
+		if len(unlocked) > 0 {
+			log.F(log.M{"user_id": userID, "count": len(unlocked)}).Debugf("用户解锁了新成就")
+		}
+	}
+
+	return nil
+}
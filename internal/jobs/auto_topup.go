@@ -0,0 +1,222 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/internal/payment/alipay"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/aidea-server/pkg/dingding"
+	"github.com/mylxsw/aidea-server/pkg/mail"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// AutoTopupJob 检查开启了自动充值的用户，余额低于设定值时先通知用户，通知后经过 AutoTopupNoticePeriod 仍然不足时通过支付宝商家扣款充值
+func AutoTopupJob(ctx context.Context, conf *config.Config, rep *repo.Repository, pay alipay.Alipay, que *queue.Queue, mailer *mail.Sender, ding *dingding.Dingding) error {
+	if !conf.EnableAutoTopup || !pay.Enabled() {
+		return nil
+	}
+
+	items, err := rep.AutoTopup.ActiveItems(ctx)
+	if err != nil {
+		log.Errorf("查询自动充值设置失败: %v", err)
+		return err
+	}
+
+	for _, item := range items {
+		if err := processAutoTopup(ctx, conf, rep, pay, que, mailer, ding, item); err != nil {
+			log.F(log.M{"user_id": item.UserID, "auto_topup_id": item.ID}).Errorf("处理自动充值失败: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func processAutoTopup(ctx context.Context, conf *config.Config, rep *repo.Repository, pay alipay.Alipay, que *queue.Queue, mailer *mail.Sender, ding *dingding.Dingding, item repo.AutoTopup) error {
+	product := coins.GetProduct(item.ProductID)
+	if product == nil {
+		return fmt.Errorf("product %s not found", item.ProductID)
+	}
+
+	quota, err := rep.Quota.GetUserQuota(ctx, item.UserID)
+	if err != nil {
+		return fmt.Errorf("query user quota failed: %w", err)
+	}
+
+	if quota.Rest >= item.Threshold {
+		// 通知之后用户已经自行充值，取消本次扣款
+		if item.NotifiedAt != nil {
+			return rep.AutoTopup.ClearNotified(ctx, item.ID)
+		}
+
+		return nil
+	}
+
+	spent, err := rep.AutoTopup.MonthSpent(ctx, item.UserID)
+	if err != nil {
+		return fmt.Errorf("query month spent failed: %w", err)
+	}
+
+	if spent+product.RetailPrice > item.MonthlyCap {
+		log.F(log.M{"user_id": item.UserID, "spent": spent, "monthly_cap": item.MonthlyCap}).Debug("auto topup exceeds monthly cap, skip")
+		if item.NotifiedAt != nil {
+			return rep.AutoTopup.ClearNotified(ctx, item.ID)
+		}
+
+		return nil
+	}
+
+	user, err := rep.User.GetUserByID(ctx, item.UserID)
+	if err != nil {
+		return fmt.Errorf("query user failed: %w", err)
+	}
+
+	if item.NotifiedAt == nil {
+		// 没有可以送达的通知渠道时不扣款，避免用户在没有收到提醒的情况下被扣款
+		if !conf.EnableMail || user.Email == "" {
+			log.F(log.M{"user_id": item.UserID, "auto_topup_id": item.ID}).Warning("用户没有可用的通知渠道，跳过自动充值")
+			return nil
+		}
+
+		notified, err := rep.AutoTopup.MarkNotified(ctx, item.ID)
+		if err != nil || !notified {
+			return err
+		}
+
+		now := time.Now()
+		body := fmt.Sprintf(
+			"您的智慧果余额为 %d，低于您设置的 %d，我们将在 %s 之后通过支付宝自动购买「%s」（%.2f 元）。如不需要，请在此之前前往 App 关闭自动充值。",
+			quota.Rest, item.Threshold, now.Add(conf.AutoTopupNoticePeriod).Format("2006-01-02 15:04"), product.Name, float64(product.RetailPrice)/100.0,
+		)
+		if err := mailer.Send([]string{user.Email}, "【AIdea】智慧果自动充值提醒", body); err != nil {
+			// 提醒发送失败时撤销通知状态，下次执行时重新发送，只有通知成功后才会进入扣款等待期
+			if e := rep.AutoTopup.ClearNotified(ctx, item.ID); e != nil {
+				log.F(log.M{"auto_topup_id": item.ID}).Errorf("撤销自动充值通知状态失败: %v", e)
+			}
+
+			return fmt.Errorf("send auto topup notice failed: %w", err)
+		}
+
+		item.NotifiedAt = &now
+	}
+
+	if time.Since(*item.NotifiedAt) < conf.AutoTopupNoticePeriod {
+		return nil
+	}
+
+	claimed, err := rep.AutoTopup.ClaimCharge(ctx, item.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	return chargeAutoTopup(ctx, conf, rep, pay, que, mailer, ding, item, user.Email, product)
+}
+
+// chargeAutoTopup 创建支付订单并发起协议扣款，扣款成功后与普通支付宝支付一样通过支付队列发放智慧果
+func chargeAutoTopup(ctx context.Context, conf *config.Config, rep *repo.Repository, pay alipay.Alipay, que *queue.Queue, mailer *mail.Sender, ding *dingding.Dingding, item repo.AutoTopup, email string, product *coins.Product) error {
+	paymentID, err := rep.Payment.CreateAliPayment(ctx, item.UserID, product.ID, repo.PaymentSourceAlipayAgreement)
+	if err != nil {
+		return fmt.Errorf("create payment failed: %w", err)
+	}
+
+	environment := ternary.If(conf.AlipaySandbox, "Sandbox", "Production")
+	res, err := pay.AgreementPay(ctx, alipay.AgreementPay{
+		AgreementNo: item.AgreementNo,
+		OutTradeNo:  paymentID,
+		TotalAmount: fmt.Sprintf("%.2f", float64(product.RetailPrice)/100.0),
+		Subject:     product.Name,
+	}, !conf.AlipaySandbox)
+	if err != nil {
+		if _, e := rep.Payment.CompleteAliPayment(ctx, item.UserID, paymentID, repo.AlipayPayment{
+			ProductID:   product.ID,
+			PurchaseAt:  time.Now(),
+			Status:      repo.PaymentStatusFailed,
+			Environment: environment,
+			Note:        "自动充值扣款失败",
+		}); e != nil {
+			log.F(log.M{"payment_id": paymentID}).Errorf("更新自动充值订单状态失败: %v", e)
+		}
+
+		suspended, e := rep.AutoTopup.ChargeFailed(ctx, item.ID, err.Error())
+		if e != nil {
+			log.F(log.M{"auto_topup_id": item.ID}).Errorf("记录自动充值扣款失败: %v", e)
+		}
+
+		if suspended {
+			notifyAutoTopupSuspended(conf, mailer, ding, item, email, err)
+		}
+
+		return fmt.Errorf("agreement pay failed: %w", err)
+	}
+
+	// 用户付款处理中，交易结果由支付宝异步通知（AlipayNotify）完成
+	if res.Pending {
+		log.F(log.M{"user_id": item.UserID, "payment_id": paymentID, "trade_no": res.TradeNo}).Info("auto topup payment pending")
+		return nil
+	}
+
+	eventID, err := rep.Payment.CompleteAliPayment(ctx, item.UserID, paymentID, repo.AlipayPayment{
+		ProductID:      product.ID,
+		BuyerID:        res.BuyerID,
+		InvoiceAmount:  product.RetailPrice,
+		ReceiptAmount:  product.RetailPrice,
+		BuyerPayAmount: product.RetailPrice,
+		TotalAmount:    product.RetailPrice,
+		TradeNo:        res.TradeNo,
+		BuyerLogonID:   res.BuyerLogonID,
+		PurchaseAt:     time.Now(),
+		Status:         repo.PaymentStatusSuccess,
+		Environment:    environment,
+		Note:           "自动充值",
+	})
+	if err != nil {
+		// 异步通知先到达时订单已经处理过
+		if errors.Is(err, repo.ErrPaymentHasBeenProcessed) {
+			return rep.AutoTopup.ChargeSucceed(ctx, item.ID)
+		}
+
+		return fmt.Errorf("complete payment failed: %w", err)
+	}
+
+	if eventID > 0 {
+		payload := queue.PaymentPayload{
+			UserID:    item.UserID,
+			ProductID: product.ID,
+			PaymentID: paymentID,
+			Note:      product.Name,
+			Source:    "alipay-agreement",
+			Env:       environment,
+			CreatedAt: time.Now(),
+			EventID:   eventID,
+		}
+
+		if _, err := que.EnqueueContext(ctx, &payload, queue.NewPaymentTask); err != nil {
+			log.F(log.M{"payment_id": paymentID}).Errorf("enqueue payment task failed: %v", err)
+		}
+	}
+
+	log.F(log.M{"user_id": item.UserID, "payment_id": paymentID, "product_id": product.ID}).Info("auto topup charged")
+
+	return rep.AutoTopup.ChargeSucceed(ctx, item.ID)
+}
+
+// notifyAutoTopupSuspended 连续扣款失败，自动充值已暂停，通知用户以及管理员
+func notifyAutoTopupSuspended(conf *config.Config, mailer *mail.Sender, ding *dingding.Dingding, item repo.AutoTopup, email string, reason error) {
+	if conf.EnableMail && email != "" {
+		body := fmt.Sprintf("您的智慧果自动充值连续 %d 次扣款失败，已暂停。请检查支付宝账户后在 App 中重新保存自动充值设置。", repo.AutoTopupMaxFailures)
+		if err := mailer.Send([]string{email}, "【AIdea】智慧果自动充值已暂停", body); err != nil {
+			log.F(log.M{"user_id": item.UserID}).Errorf("发送自动充值暂停邮件失败: %v", err)
+		}
+	}
+
+	content := fmt.Sprintf("用户 %d 的自动充值连续 %d 次扣款失败，已暂停\n\n最后一次失败原因：%v", item.UserID, repo.AutoTopupMaxFailures, reason)
+	if err := ding.Send(dingding.NewMarkdownMessage("自动充值已暂停", content, []string{})); err != nil {
+		log.Errorf("send dingding message failed: %s", err)
+	}
+}
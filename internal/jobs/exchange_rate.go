@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/asteria/log"
+)
+
+// ExchangeRateRefreshJob 刷新人民币汇率，用于计算海外用户看到的商品展示价格
+func ExchangeRateRefreshJob(ctx context.Context, srv *service.ExchangeRateService) error {
+	if err := srv.Refresh(ctx); err != nil {
+		log.Errorf("刷新汇率失败: %v", err)
+		return err
+	}
+
+	return nil
+}
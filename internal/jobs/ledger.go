@@ -0,0 +1,131 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/dingding"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// LedgerTrialBalanceJob 每天对智慧果记账分录做试算平衡：截止到今天零点所有科目的借贷合计必须相等，前一天写入的凭证必须各自平衡，否则通知管理员
+func LedgerTrialBalanceJob(ctx context.Context, rep *repo.Repository, ding *dingding.Dingding) error {
+	now := time.Now()
+	endAt := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	startAt := endAt.AddDate(0, 0, -1)
+
+	tb, err := rep.Ledger.TrialBalance(ctx, startAt, endAt)
+	if err != nil {
+		log.Errorf("智慧果记账试算平衡失败: %v", err)
+		return err
+	}
+
+	log.F(log.M{
+		"date":       startAt.Format("2006-01-02"),
+		"debit":      tb.Debit,
+		"credit":     tb.Credit,
+		"entries":    tb.Entries,
+		"unbalanced": len(tb.Unbalanced),
+	}).Info("ledger trial balance")
+
+	if tb.Balanced() {
+		return nil
+	}
+
+	lines := []string{
+		fmt.Sprintf("日期：%s", startAt.Format("2006-01-02")),
+		fmt.Sprintf("累计借方：%d，累计贷方：%d，差额：%d", tb.Debit, tb.Credit, tb.Debit-tb.Credit),
+	}
+	for _, account := range tb.Accounts {
+		lines = append(lines, fmt.Sprintf("- %s：借 %d / 贷 %d", account.Account, account.Debit, account.Credit))
+	}
+
+	if len(tb.Unbalanced) > 0 {
+		lines = append(lines, fmt.Sprintf("借贷不平衡的凭证：%s", strings.Join(tb.Unbalanced, ", ")))
+	}
+
+	if err := ding.Send(dingding.NewMarkdownMessage("智慧果记账试算不平衡", strings.Join(lines, "\n\n"), []string{})); err != nil {
+		log.Errorf("send dingding message failed: %s", err)
+	}
+
+	return nil
+}
+
+// balanceDriftReportLimit 通知中最多列出的钱包数量
+const balanceDriftReportLimit = 20
+
+// BalanceIntegrityJob 以记账分录为准校验每个用户和组织钱包的余额，发现配额记录与记账分录不一致时通知管理员，开启自动修正时同时修正配额记录
+func BalanceIntegrityJob(ctx context.Context, conf *config.Config, rep *repo.Repository, ding *dingding.Dingding) error {
+	drifts := make([]repo.WalletDrift, 0)
+	for _, account := range []string{repo.LedgerAccountUserWallet, repo.LedgerAccountOrgWallet} {
+		candidates, err := rep.Ledger.WalletDrifts(ctx, account)
+		if err != nil {
+			log.F(log.M{"account": account}).Errorf("校验钱包余额失败: %v", err)
+			return err
+		}
+
+		for _, candidate := range candidates {
+			repair := conf.BalanceAutoRepair && (conf.BalanceRepairMaxDrift <= 0 || abs64(candidate.Drift()) <= int64(conf.BalanceRepairMaxDrift))
+
+			drift, err := rep.Ledger.ReconcileWallet(ctx, account, candidate.OwnerID, repair)
+			if err != nil {
+				log.F(log.M{"account": account, "owner_id": candidate.OwnerID}).Errorf("确认钱包余额失败: %v", err)
+				continue
+			}
+
+			// 批量比较期间发生了余额变动，锁定后重新比较一致
+			if drift == nil {
+				continue
+			}
+
+			log.F(log.M{
+				"account":  drift.Account,
+				"owner_id": drift.OwnerID,
+				"ledger":   drift.Ledger,
+				"cached":   drift.Cached,
+				"repaired": drift.Repaired,
+			}).Warning("wallet balance drift detected")
+
+			drifts = append(drifts, *drift)
+		}
+	}
+
+	if len(drifts) == 0 {
+		return nil
+	}
+
+	var repaired int
+	lines := make([]string, 0, len(drifts)+1)
+	for i, drift := range drifts {
+		if drift.Repaired {
+			repaired++
+		}
+
+		if i < balanceDriftReportLimit {
+			lines = append(lines, fmt.Sprintf(
+				"- %s %d：记账余额 %d，配额余额 %d，差额 %d%s",
+				drift.Account, drift.OwnerID, drift.Ledger, drift.Cached, drift.Drift(), ternary.If(drift.Repaired, "（已修正）", ""),
+			))
+		}
+	}
+
+	lines = append([]string{fmt.Sprintf("共有 %d 个钱包的余额与记账分录不一致，已修正 %d 个", len(drifts), repaired)}, lines...)
+	if err := ding.Send(dingding.NewMarkdownMessage("钱包余额校验异常", strings.Join(lines, "\n\n"), []string{})); err != nil {
+		log.Errorf("send dingding message failed: %s", err)
+	}
+
+	return nil
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/mail"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+)
+
+// OrgUsageAggregateJob 每天汇总前一天组织成员的使用记录，用于组织使用统计导出
+func OrgUsageAggregateJob(ctx context.Context, rep *repo.Repository) error {
+	date := time.Now().AddDate(0, 0, -1)
+	if err := rep.Org.AggregateUsage(ctx, date); err != nil {
+		log.Errorf("汇总组织使用统计失败: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// OrgUsageReportJob 向订阅了使用报告的组织发送邮件，周报在每周一发送上周的数据，月报在每月 1 日发送上个月的数据
+func OrgUsageReportJob(ctx context.Context, rep *repo.Repository, conf *config.Config, mailer *mail.Sender) error {
+	if !conf.EnableMail {
+		log.Debugf("mail is disabled, skip org usage report")
+		return nil
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	endDate := today.AddDate(0, 0, -1)
+
+	if now.Weekday() == time.Monday {
+		sendOrgUsageReports(ctx, rep, mailer, repo.OrgUsageFrequencyWeekly, today.AddDate(0, 0, -7), endDate)
+	}
+
+	if now.Day() == 1 {
+		sendOrgUsageReports(ctx, rep, mailer, repo.OrgUsageFrequencyMonthly, today.AddDate(0, -1, 0), endDate)
+	}
+
+	return nil
+}
+
+func sendOrgUsageReports(ctx context.Context, rep *repo.Repository, mailer *mail.Sender, frequency string, startDate, endDate time.Time) {
+	subs, err := rep.Org.UsageSubscriptions(ctx, frequency)
+	if err != nil {
+		log.F(log.M{"frequency": frequency}).Errorf("查询组织使用报告订阅失败: %v", err)
+		return
+	}
+
+	for _, sub := range subs {
+		if err := sendOrgUsageReport(ctx, rep, mailer, sub, startDate, endDate); err != nil {
+			log.F(log.M{"org_id": sub.OrgID, "frequency": frequency}).Errorf("发送组织使用报告失败: %v", err)
+		}
+	}
+}
+
+func sendOrgUsageReport(ctx context.Context, rep *repo.Repository, mailer *mail.Sender, sub repo.OrgUsageSubscription, startDate, endDate time.Time) error {
+	org, err := rep.Org.Org(ctx, sub.OrgID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := rep.Org.UsageExport(ctx, sub.OrgID, startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	data, err := repo.OrgUsageCSV(rows)
+	if err != nil {
+		return err
+	}
+
+	var coins int64
+	for _, row := range rows {
+		coins += row.Coins
+	}
+
+	period := fmt.Sprintf("%s ~ %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	subject := fmt.Sprintf("【AIdea】%s 使用报告（%s）", org.Name, period)
+	body := fmt.Sprintf("<p>%s 在 %s 期间共使用 %d 个智慧果，按成员与模型分类的明细见附件。</p>", org.Name, period, coins)
+	filename := fmt.Sprintf("org-%d-usage-%s-%s.csv", sub.OrgID, startDate.Format("20060102"), endDate.Format("20060102"))
+
+	if err := mailer.SendWithAttachment(sub.Emails, subject, body, filename, data); err != nil {
+		return err
+	}
+
+	return rep.Org.MarkUsageSubscriptionSent(ctx, sub.OrgID, time.Now())
+}
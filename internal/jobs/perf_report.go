@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/dingding"
+	"github.com/mylxsw/aidea-server/pkg/profiler"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+)
+
+const (
+	// perfReportDays 性能周报统计的天数
+	perfReportDays = 7
+	// perfReportLimit 性能周报中每类统计展示的条数
+	perfReportLimit = 10
+	// perfReportMinCount 参与统计的最少请求次数，过滤偶发的慢请求
+	perfReportMinCount = 10
+	// perfReportNameLength 周报中 SQL 的最大展示长度
+	perfReportNameLength = 200
+)
+
+// PerfWeeklyReportJob 每周汇总平均耗时最高的接口与数据库查询，用于指导索引优化
+func PerfWeeklyReportJob(ctx context.Context, rep *repo.Repository, ding *dingding.Dingding) error {
+	endDate := time.Now().AddDate(0, 0, -1)
+	startDate := endDate.AddDate(0, 0, -(perfReportDays - 1))
+
+	endpoints, err := rep.PerfStat.Slowest(ctx, profiler.KindEndpoint, startDate, endDate, perfReportMinCount, perfReportLimit)
+	if err != nil {
+		log.Errorf("查询慢接口统计失败: %v", err)
+		return err
+	}
+
+	queries, err := rep.PerfStat.Slowest(ctx, profiler.KindQuery, startDate, endDate, perfReportMinCount, perfReportLimit)
+	if err != nil {
+		log.Errorf("查询慢查询统计失败: %v", err)
+		return err
+	}
+
+	title := fmt.Sprintf("性能周报（%s ~ %s）", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	content := buildPerfReport(title, endpoints, queries)
+
+	log.WithFields(log.Fields{"endpoints": endpoints, "queries": queries}).Infof("perf weekly report generated")
+
+	if ding != nil {
+		if err := ding.Send(dingding.NewMarkdownMessage(title, content, []string{})); err != nil {
+			log.WithFields(log.Fields{"content": content}).Errorf("send dingding message failed: %s", err)
+		}
+	} else {
+		log.WithFields(log.Fields{"content": content}).Debugf("dingding client is nil, skip send message")
+	}
+
+	return nil
+}
+
+func buildPerfReport(title string, endpoints, queries []profiler.Stat) string {
+	var sb strings.Builder
+	sb.WriteString("### " + title + "\n\n")
+
+	for _, section := range []struct {
+		title string
+		stats []profiler.Stat
+	}{
+		{"平均耗时最高的接口", endpoints},
+		{"平均耗时最高的查询", queries},
+	} {
+		sb.WriteString("#### " + section.title + "\n\n")
+		if len(section.stats) == 0 {
+			sb.WriteString("无\n\n")
+			continue
+		}
+
+		for i, st := range section.stats {
+			name := st.Name
+			if len([]rune(name)) > perfReportNameLength {
+				name = string([]rune(name)[:perfReportNameLength]) + "..."
+			}
+
+			sb.WriteString(fmt.Sprintf(
+				"%d. `%s` 平均 %dms，最大 %dms，%d 次请求（慢请求 %d 次）\n",
+				i+1, name, st.AvgElapse(), st.MaxElapse, st.Count, st.SlowCount,
+			))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -48,6 +48,33 @@ func (Provider) Jobs(cc infra.Resolver, creator scheduler.JobCreator) {
 		log.Errorf("注册定时任务 quota-usage-statistics 失败: %v", err)
 	}
 
+	// 每天凌晨 0:20 汇总用户个人统计数据
+	if err := creator.Add(
+		"user-stats",
+		"0 20 0 * * *",
+		scheduler.WithoutOverlap(UserStatsJob),
+	); err != nil {
+		log.Errorf("注册定时任务 user-stats 失败: %v", err)
+	}
+
+	// 每天凌晨 0:40 汇总组织成员的使用统计
+	if err := creator.Add(
+		"org-usage-aggregate",
+		"0 40 0 * * *",
+		scheduler.WithoutOverlap(OrgUsageAggregateJob),
+	); err != nil {
+		log.Errorf("注册定时任务 org-usage-aggregate 失败: %v", err)
+	}
+
+	// 每天 8:00 发送到期的组织使用报告
+	if err := creator.Add(
+		"org-usage-report",
+		"0 0 8 * * *",
+		scheduler.WithoutOverlap(OrgUsageReportJob),
+	); err != nil {
+		log.Errorf("注册定时任务 org-usage-report 失败: %v", err)
+	}
+
 	// 每 5s 执行一次 PendingTask 任务
 	if err := creator.Add(
 		"pending-task",
@@ -95,6 +122,122 @@ func (Provider) Jobs(cc infra.Resolver, creator scheduler.JobCreator) {
 		log.Errorf("注册定时任务 clear-expired-cache 失败: %v", err)
 	}
 
+	// 清理超过保留期限的已删除群聊消息
+	if err := creator.Add(
+		"purge-deleted-group-messages",
+		"0 30 3 * * *",
+		scheduler.WithoutOverlap(queue.PurgeDeletedGroupMessagesJob),
+	); err != nil {
+		log.Errorf("注册定时任务 purge-deleted-group-messages 失败: %v", err)
+	}
+
+	// 清理回收站中超过保留期限的会话
+	if err := creator.Add(
+		"purge-trashed-rooms",
+		"0 45 3 * * *",
+		scheduler.WithoutOverlap(queue.PurgeTrashedRoomsJob),
+	); err != nil {
+		log.Errorf("注册定时任务 purge-trashed-rooms 失败: %v", err)
+	}
+
+	if err := creator.Add(
+		"purge-avatar-pack-selfies",
+		"0 */10 * * * *",
+		scheduler.WithoutOverlap(queue.PurgeAvatarPackSelfiesJob),
+	); err != nil {
+		log.Errorf("注册定时任务 purge-avatar-pack-selfies 失败: %v", err)
+	}
+
+	// 每 30 分钟为最近活跃的会话标记话题
+	if err := creator.Add(
+		"conversation-topic-tagging",
+		"0 */30 * * * *",
+		scheduler.WithoutOverlap(TopicTaggingJob),
+	); err != nil {
+		log.Errorf("注册定时任务 conversation-topic-tagging 失败: %v", err)
+	}
+
+	// 每小时检查一次活跃用户的成就解锁情况
+	if err := creator.Add(
+		"achievement-check",
+		"0 5 * * * *",
+		scheduler.WithoutOverlap(AchievementCheckJob),
+	); err != nil {
+		log.Errorf("注册定时任务 achievement-check 失败: %v", err)
+	}
+
+	// 每天凌晨 0:40 计算用户的智慧果消耗速度（依赖配额使用统计）
+	if err := creator.Add(
+		"quota-forecast",
+		"0 40 0 * * *",
+		scheduler.WithoutOverlap(QuotaForecastJob),
+	); err != nil {
+		log.Errorf("注册定时任务 quota-forecast 失败: %v", err)
+	}
+
+	// 每 6 小时刷新一次汇率
+	if err := creator.Add(
+		"exchange-rate-refresh",
+		"0 15 */6 * * *",
+		scheduler.WithoutOverlap(ExchangeRateRefreshJob),
+	); err != nil {
+		log.Errorf("注册定时任务 exchange-rate-refresh 失败: %v", err)
+	}
+
+	// 每周一 9:00 发送性能周报
+	if err := creator.Add(
+		"perf-weekly-report",
+		"0 0 9 * * 1",
+		scheduler.WithoutOverlap(PerfWeeklyReportJob),
+	); err != nil {
+		log.Errorf("注册定时任务 perf-weekly-report 失败: %v", err)
+	}
+
+	// 每天 1:10 对前一天的智慧果记账分录做试算平衡
+	if err := creator.Add(
+		"ledger-trial-balance",
+		"0 10 1 * * *",
+		scheduler.WithoutOverlap(LedgerTrialBalanceJob),
+	); err != nil {
+		log.Errorf("注册定时任务 ledger-trial-balance 失败: %v", err)
+	}
+
+	// 每天 2:30 以记账分录为准校验钱包余额
+	if err := creator.Add(
+		"balance-integrity",
+		"0 30 2 * * *",
+		scheduler.WithoutOverlap(BalanceIntegrityJob),
+	); err != nil {
+		log.Errorf("注册定时任务 balance-integrity 失败: %v", err)
+	}
+
+	// 每 5 分钟检查一次余额不足的用户，执行自动充值
+	if err := creator.Add(
+		"auto-topup",
+		"0 */5 * * * *",
+		scheduler.WithoutOverlap(AutoTopupJob),
+	); err != nil {
+		log.Errorf("注册定时任务 auto-topup 失败: %v", err)
+	}
+
+	// 每小时整点提醒设置在该时间复习的用户
+	if err := creator.Add(
+		"study-reminder",
+		"0 0 * * * *",
+		scheduler.WithoutOverlap(StudyReminderJob),
+	); err != nil {
+		log.Errorf("注册定时任务 study-reminder 失败: %v", err)
+	}
+
+	// 每周一 9:00 向家长发送作业辅导学习周报
+	if err := creator.Add(
+		"tutor-weekly-report",
+		"0 0 9 * * 1",
+		scheduler.WithoutOverlap(TutorWeeklyReportJob),
+	); err != nil {
+		log.Errorf("注册定时任务 tutor-weekly-report 失败: %v", err)
+	}
+
 	// 用户注册通知（管理）
 	if err := creator.Add(
 		"user-signup-notification",
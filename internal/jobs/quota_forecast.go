@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/asteria/log"
+)
+
+// QuotaForecastJob 根据前一天的配额使用统计，重新计算近期活跃用户的智慧果消耗速度
+func QuotaForecastJob(ctx context.Context, rep *repo.Repository, srv *service.QuotaForecastService) error {
+	userIDs, err := rep.Quota.GetQuotaStatisticsActiveUsers(ctx, 30)
+	if err != nil {
+		log.Errorf("查询近期活跃用户失败: %v", err)
+		return err
+	}
+
+	var failed int
+	for _, userID := range userIDs {
+		if _, err := srv.RefreshUsageRate(ctx, userID); err != nil {
+			failed++
+			log.F(log.M{"user_id": userID}).Warningf("计算用户智慧果消耗速度失败: %v", err)
+		}
+	}
+
+	log.Infof("计算用户智慧果消耗速度完成，共 %d 个用户，失败 %d 个", len(userIDs), failed)
+	return nil
+}
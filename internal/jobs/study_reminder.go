@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/mail"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+)
+
+// StudyReminderJob 在用户设置的时间提醒有到期卡片的用户复习，每天最多提醒一次
+func StudyReminderJob(ctx context.Context, conf *config.Config, rep *repo.Repository, mailer *mail.Sender) error {
+	// 目前只支持通过邮件提醒
+	if !conf.EnableMail {
+		return nil
+	}
+
+	dayStart := repo.NowInDate()
+	reminders, err := rep.Flashcard.ReminderCandidates(ctx, time.Now().Hour(), dayStart)
+	if err != nil {
+		log.Errorf("查询需要发送复习提醒的用户失败: %v", err)
+		return err
+	}
+
+	var sent int
+	for _, item := range reminders {
+		marked, err := rep.Flashcard.MarkReminded(ctx, item.UserID, dayStart)
+		if err != nil {
+			log.F(log.M{"user_id": item.UserID}).Errorf("记录复习提醒失败: %v", err)
+			continue
+		}
+
+		if !marked {
+			continue
+		}
+
+		user, err := rep.User.GetUserByID(ctx, item.UserID)
+		if err != nil {
+			log.F(log.M{"user_id": item.UserID}).Errorf("查询用户信息失败: %v", err)
+			continue
+		}
+
+		if user.Email == "" {
+			continue
+		}
+
+		body := fmt.Sprintf("您今天有 %d 张记忆卡片需要复习，按时复习可以记得更牢。前往 App 的学习模式开始复习吧！", item.DueCount)
+		if err := mailer.Send([]string{user.Email}, "【AIdea】今日复习提醒", body); err != nil {
+			log.F(log.M{"user_id": item.UserID}).Errorf("发送复习提醒邮件失败: %v", err)
+			continue
+		}
+
+		sent++
+	}
+
+	log.Infof("复习提醒发送完成，共 %d 个用户，发送 %d 封", len(reminders), sent)
+	return nil
+}
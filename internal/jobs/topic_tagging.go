@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/topic"
+	"github.com/mylxsw/asteria/log"
+)
+
+// topicTaggingBatchSize 每次执行最多处理的房间数量
+const topicTaggingBatchSize = 500
+
+// TopicTaggingJob 为最近有活动的会话（包括群聊）自动标记话题
+func TopicTaggingJob(ctx context.Context, rep *repo.Repository) error {
+	rooms, err := rep.Topic.UntaggedRooms(ctx, time.Now().AddDate(0, 0, -30), topicTaggingBatchSize)
+	if err != nil {
+		log.Errorf("查询待标记话题的会话失败: %v", err)
+		return err
+	}
+
+	for _, room := range rooms {
+		messages, err := rep.Topic.RecentUserMessages(ctx, room, 20)
+		if err != nil {
+			log.F(log.M{"room_id": room.Id}).Errorf("查询会话消息失败: %v", err)
+			continue
+		}
+
+		text := strings.Join(append([]string{room.Name, room.Description}, messages...), "\n")
+		if err := rep.Topic.SetRoomTopics(ctx, room.UserId, room.Id, room.RoomType, topic.Classify(text)); err != nil {
+			log.F(log.M{"room_id": room.Id}).Errorf("更新会话话题失败: %v", err)
+		}
+	}
+
+	if len(rooms) > 0 {
+		log.Debugf("会话话题标记完成，共处理 %d 个会话", len(rooms))
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/mail"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/asteria/log"
+)
+
+// TutorWeeklyReportJob 向家长发送孩子上周的作业辅导学习周报，每周最多发送一次
+func TutorWeeklyReportJob(ctx context.Context, conf *config.Config, rep *repo.Repository, mailer *mail.Sender) error {
+	// 目前只支持通过邮件发送周报
+	if !conf.EnableMail {
+		return nil
+	}
+
+	weekStart := service.TutorWeekStart()
+	lastWeek := weekStart.AddDate(0, 0, -7)
+
+	targets, err := rep.Tutor.ReportCandidates(ctx, weekStart)
+	if err != nil {
+		log.Errorf("查询需要发送学习周报的用户失败: %v", err)
+		return err
+	}
+
+	var sent int
+	for _, target := range targets {
+		if err := sendTutorWeeklyReport(ctx, rep, mailer, target, lastWeek, weekStart); err != nil {
+			log.F(log.M{"user_id": target.UserID}).Errorf("发送学习周报失败: %v", err)
+			continue
+		}
+
+		sent++
+	}
+
+	log.Infof("学习周报发送完成，共 %d 个用户，发送 %d 封", len(targets), sent)
+	return nil
+}
+
+func sendTutorWeeklyReport(ctx context.Context, rep *repo.Repository, mailer *mail.Sender, target repo.TutorReportTarget, startDate, endDate time.Time) error {
+	marked, err := rep.Tutor.MarkReported(ctx, target.UserID, endDate)
+	if err != nil || !marked {
+		return err
+	}
+
+	user, err := rep.User.GetUserByID(ctx, target.UserID)
+	if err != nil {
+		return err
+	}
+
+	progress, err := rep.Tutor.Progress(ctx, target.UserID, startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	name := user.Realname
+	if name == "" {
+		name = "您的孩子"
+	}
+
+	return mailer.Send([]string{target.ParentEmail}, "【AIdea】作业辅导学习周报", service.TutorWeeklyReport(name, startDate, progress))
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+)
+
+// UserStatsJob 汇总前一天所有用户的聊天统计数据，用于个人统计接口快速查询
+func UserStatsJob(ctx context.Context, rep *repo.Repository) error {
+	date := time.Now().AddDate(0, 0, -1)
+	if err := rep.UserStats.Aggregate(ctx, date); err != nil {
+		log.Errorf("执行用户统计任务(%s)失败: %v", date.Format("2006-01-02"), err)
+		return err
+	}
+
+	log.Infof("执行用户统计任务(%s)成功", date.Format("2006-01-02"))
+	return nil
+}
@@ -11,6 +11,13 @@ type Alipay interface {
 	TradeWebPay(ctx context.Context, tradeAppPay TradePay, isProd bool) (string, error)
 	VerifyCallbackSign(notifyBean any) (bool, error)
 	Enabled() bool
+
+	// AgreementSign 生成商家扣款协议的签约链接，source 为 app 时返回唤起支付宝 App 的链接
+	AgreementSign(ctx context.Context, source string, sign AgreementSign, isProd bool) (string, error)
+	// AgreementUnsign 解除商家扣款协议
+	AgreementUnsign(ctx context.Context, agreementNo string, isProd bool) error
+	// AgreementPay 使用商家扣款协议直接扣款，不需要用户确认
+	AgreementPay(ctx context.Context, pay AgreementPay, isProd bool) (*AgreementPayResult, error)
 }
 
 type TradePay struct {
@@ -46,3 +53,32 @@ type TradePay struct {
 	// Web/Wap 专用
 	ReturnURL string `json:"return_url,omitempty"`
 }
+
+// AgreementSign 商家扣款协议签约 https://opendocs.alipay.com/open/08bg92
+type AgreementSign struct {
+	// ExternalAgreementNo 商户签约号，由商家自定义，签约结果通知中原样返回
+	ExternalAgreementNo string `json:"external_agreement_no"`
+	// NotifyURL 签约、解约结果通知地址
+	NotifyURL string `json:"notify_url,omitempty"`
+	// ReturnURL 签约完成后跳转的页面，仅 Web/Wap 有效
+	ReturnURL string `json:"return_url,omitempty"`
+}
+
+// AgreementPay 协议扣款
+type AgreementPay struct {
+	AgreementNo string `json:"agreement_no"`
+	OutTradeNo  string `json:"out_trade_no"`
+	// TotalAmount 扣款金额，单位为元，精确到小数点后两位
+	TotalAmount string `json:"total_amount"`
+	Subject     string `json:"subject"`
+}
+
+// AgreementPayResult 协议扣款结果
+type AgreementPayResult struct {
+	TradeNo      string `json:"trade_no"`
+	BuyerID      string `json:"buyer_id"`
+	BuyerLogonID string `json:"buyer_logon_id"`
+	TotalAmount  string `json:"total_amount"`
+	// Pending 支付宝处理中（返回码 10003），最终结果以异步通知为准
+	Pending bool `json:"pending"`
+}
@@ -28,3 +28,15 @@ func (pay *AlipayFake) VerifyCallbackSign(notifyBean any) (bool, error) {
 func (pay *AlipayFake) Enabled() bool {
 	return false
 }
+
+func (pay *AlipayFake) AgreementSign(ctx context.Context, source string, sign AgreementSign, isProd bool) (string, error) {
+	panic("implement me")
+}
+
+func (pay *AlipayFake) AgreementUnsign(ctx context.Context, agreementNo string, isProd bool) error {
+	panic("implement me")
+}
+
+func (pay *AlipayFake) AgreementPay(ctx context.Context, agreementPay AgreementPay, isProd bool) (*AgreementPayResult, error) {
+	panic("implement me")
+}
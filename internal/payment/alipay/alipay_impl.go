@@ -138,3 +138,75 @@ func (pay *AlipayImpl) VerifyCallbackSign(notifyBean any) (bool, error) {
 func (pay *AlipayImpl) Enabled() bool {
 	return true
 }
+
+func (pay *AlipayImpl) client(isProd bool) *alipay.Client {
+	if isProd {
+		return pay.prodClient
+	}
+
+	return pay.devClient
+}
+
+// agreementProductCode 商家扣款的个人签约产品码
+const agreementProductCode = "GENERAL_WITHHOLDING_P1"
+
+func (pay *AlipayImpl) AgreementSign(ctx context.Context, source string, sign AgreementSign, isProd bool) (string, error) {
+	bm := gopay.BodyMap{
+		"personal_product_code": agreementProductCode,
+		"product_code":          "GENERAL_WITHHOLDING",
+		"sign_scene":            "INDUSTRY|DIGITAL_MEDIA",
+		"external_agreement_no": sign.ExternalAgreementNo,
+		"access_params":         map[string]string{"channel": "ALIPAYAPP"},
+	}
+
+	if sign.NotifyURL != "" {
+		bm.Set("notify_url", sign.NotifyURL)
+	}
+
+	switch source {
+	case "app":
+		return pay.client(isProd).UserAgreementPageSignInApp(ctx, bm)
+	case "wap", "web":
+		if sign.ReturnURL != "" {
+			bm.Set("return_url", sign.ReturnURL)
+		}
+
+		return pay.client(isProd).UserAgreementPageSign(ctx, bm)
+	default:
+		return "", fmt.Errorf("unknown alipay source: %s", source)
+	}
+}
+
+func (pay *AlipayImpl) AgreementUnsign(ctx context.Context, agreementNo string, isProd bool) error {
+	_, err := pay.client(isProd).UserAgreementPageUnSign(ctx, gopay.BodyMap{
+		"personal_product_code": agreementProductCode,
+		"agreement_no":          agreementNo,
+	})
+
+	return err
+}
+
+func (pay *AlipayImpl) AgreementPay(ctx context.Context, agreementPay AgreementPay, isProd bool) (*AgreementPayResult, error) {
+	resp, err := pay.client(isProd).TradePay(ctx, gopay.BodyMap{
+		"out_trade_no":     agreementPay.OutTradeNo,
+		"total_amount":     agreementPay.TotalAmount,
+		"subject":          agreementPay.Subject,
+		"product_code":     "GENERAL_WITHHOLDING",
+		"agreement_params": map[string]string{"agreement_no": agreementPay.AgreementNo},
+	})
+	if err != nil {
+		// 10003 表示支付宝正在处理，最终结果以异步通知为准
+		if resp != nil && resp.Response != nil && resp.Response.Code == "10003" {
+			return &AgreementPayResult{TradeNo: resp.Response.TradeNo, Pending: true}, nil
+		}
+
+		return nil, err
+	}
+
+	return &AgreementPayResult{
+		TradeNo:      resp.Response.TradeNo,
+		BuyerID:      resp.Response.BuyerUserId,
+		BuyerLogonID: resp.Response.BuyerLogonId,
+		TotalAmount:  resp.Response.TotalAmount,
+	}, nil
+}
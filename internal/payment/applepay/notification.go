@@ -0,0 +1,158 @@
+package applepay
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/awa/go-iap/appstore"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// appleRootCAG3 Apple Root CA - G3，App Store 服务器通知的签名证书链必须由该根证书签发
+// https://www.apple.com/certificateauthority/AppleRootCA-G3.cer
+const appleRootCAG3 = `
+-----BEGIN CERTIFICATE-----
+MIICQzCCAcmgAwIBAgIILcX8iNLFS5UwCgYIKoZIzj0EAwMwZzEbMBkGA1UEAwwS
+QXBwbGUgUm9vdCBDQSAtIEczMSYwJAYDVQQLDB1BcHBsZSBDZXJ0aWZpY2F0aW9u
+IEF1dGhvcml0eTETMBEGA1UECgwKQXBwbGUgSW5jLjELMAkGA1UEBhMCVVMwHhcN
+MTQwNDMwMTgxOTA2WhcNMzkwNDMwMTgxOTA2WjBnMRswGQYDVQQDDBJBcHBsZSBS
+b290IENBIC0gRzMxJjAkBgNVBAsMHUFwcGxlIENlcnRpZmljYXRpb24gQXV0aG9y
+aXR5MRMwEQYDVQQKDApBcHBsZSBJbmMuMQswCQYDVQQGEwJVUzB2MBAGByqGSM49
+AgEGBSuBBAAiA2IABJjpLz1AcqTtkyJygRMc3RCV8cWjTnHcFBbZDuWmBSp3ZHtf
+TjjTuxxEtX/1H7YyYl3J6YRbTzBPEVoA/VhYDKX1DyxNB0cTddqXl5dvMVztK517
+IDvYuVTZXpmkOlEKMaNCMEAwHQYDVR0OBBYEFLuw3qFYM4iapIqZ3r6966/ayySr
+MA8GA1UdEwEB/wQFMAMBAf8wDgYDVR0PAQH/BAQDAgEGMAoGCCqGSM49BAMDA2gA
+MGUCMQCD6cHEFl4aXTQY2e3v9GwOAEZLuN+yRhHFD/3meoyhpmvOwgPUnPWTxnS4
+at+qIxUCMG1mihDK1A3UT82NQz60imOlM27jbdoXt2QfyFMm+YhidDkLF1vLUagM
+6BgD56KyKA==
+-----END CERTIFICATE-----
+`
+
+var (
+	ErrInvalidSignature = errors.New("invalid apple notification signature")
+	ErrBundleIDMismatch = errors.New("apple notification bundle id mismatch")
+)
+
+// Notification 解析并验证签名后的 App Store 服务器通知（V2）
+type Notification struct {
+	Type        appstore.NotificationTypeV2 `json:"type"`
+	Subtype     appstore.SubtypeV2          `json:"subtype"`
+	UUID        string                      `json:"uuid"`
+	Environment string                      `json:"environment"`
+	BundleID    string                      `json:"bundle_id"`
+	// Transaction 通知关联的交易信息，部分通知类型（例如 TEST）没有交易信息
+	Transaction *appstore.JWSTransactionDecodedPayload `json:"transaction,omitempty"`
+}
+
+// IsRefund 是否为退款或者撤销（家庭共享被取消）通知，需要扣回已发放的智慧果
+func (n *Notification) IsRefund() bool {
+	return n.Type == appstore.NotificationTypeV2Refund || n.Type == appstore.NotificationTypeV2Revoke
+}
+
+type notificationClaims struct {
+	jwt.RegisteredClaims
+	appstore.SubscriptionNotificationV2DecodedPayload
+}
+
+type transactionClaims struct {
+	jwt.RegisteredClaims
+	appstore.JWSTransactionDecodedPayload
+}
+
+// ParseNotification 验证 App Store 服务器通知的 JWS 签名并解析内容，bundleID 不为空时校验通知所属的应用
+func ParseNotification(signedPayload string, bundleID string) (*Notification, error) {
+	var payload notificationClaims
+	if err := parseJWS(signedPayload, &payload); err != nil {
+		return nil, err
+	}
+
+	notification := Notification{
+		Type:        payload.NotificationType,
+		Subtype:     payload.Subtype,
+		UUID:        payload.NotificationUUID,
+		Environment: payload.Data.Environment,
+		BundleID:    payload.Data.BundleID,
+	}
+
+	if bundleID != "" && notification.BundleID != bundleID {
+		return nil, ErrBundleIDMismatch
+	}
+
+	if payload.Data.SignedTransactionInfo != "" {
+		var trans transactionClaims
+		if err := parseJWS(string(payload.Data.SignedTransactionInfo), &trans); err != nil {
+			return nil, fmt.Errorf("parse signed transaction info failed: %w", err)
+		}
+
+		notification.Transaction = &trans.JWSTransactionDecodedPayload
+	}
+
+	return &notification, nil
+}
+
+// parseJWS 使用 JWS 头部 x5c 中的证书链验证签名，证书链必须由 Apple Root CA - G3 签发
+func parseJWS(token string, claims jwt.Claims) error {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
+	if _, err := parser.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return verifiedPublicKey(token.Header["x5c"])
+	}); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	return nil
+}
+
+func verifiedPublicKey(x5c interface{}) (*ecdsa.PublicKey, error) {
+	chain, ok := x5c.([]interface{})
+	if !ok || len(chain) < 2 {
+		return nil, errors.New("x5c certificate chain is missing")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(chain))
+	for _, item := range chain {
+		encoded, ok := item.(string)
+		if !ok {
+			return nil, errors.New("invalid x5c certificate")
+		}
+
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode x5c certificate failed: %w", err)
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse x5c certificate failed: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(appleRootCAG3)) {
+		return nil, errors.New("load apple root certificate failed")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("verify certificate chain failed: %w", err)
+	}
+
+	key, ok := certs[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("leaf certificate is not an ecdsa key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,60 @@
+package applepay_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/mylxsw/aidea-server/internal/payment/applepay"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestParseNotification_Invalid(t *testing.T) {
+	_, err := applepay.ParseNotification("not-a-jws", "")
+	assert.True(t, errors.Is(err, applepay.ErrInvalidSignature))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	// 没有 x5c 证书链
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{"notificationType": "REFUND"}).SignedString(key)
+	assert.NoError(t, err)
+
+	_, err = applepay.ParseNotification(token, "")
+	assert.True(t, errors.Is(err, applepay.ErrInvalidSignature))
+
+	// 自签名的证书链，不是由 Apple 根证书签发
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fake apple"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	cert := base64.StdEncoding.EncodeToString(der)
+	fake := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{"notificationType": "REFUND"})
+	fake.Header["x5c"] = []string{cert, cert, cert}
+	token, err = fake.SignedString(key)
+	assert.NoError(t, err)
+
+	_, err = applepay.ParseNotification(token, "")
+	assert.True(t, errors.Is(err, applepay.ErrInvalidSignature))
+}
+
+func TestNotification_IsRefund(t *testing.T) {
+	assert.True(t, (&applepay.Notification{Type: "REFUND"}).IsRefund())
+	assert.True(t, (&applepay.Notification{Type: "REVOKE"}).IsRefund())
+	assert.False(t, (&applepay.Notification{Type: "REFUND_DECLINED"}).IsRefund())
+}
@@ -0,0 +1,314 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/replicate"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/asteria/log"
+)
+
+// AvatarPackConsentVersion 数字分身写真授权协议的版本，协议内容变更时需要更新版本号，用户需要重新同意
+const AvatarPackConsentVersion = "2024-01"
+
+// avatarPackNegativePrompt 生成数字分身写真时默认排除的内容
+const avatarPackNegativePrompt = "nsfw, nude, lowres, bad anatomy, bad hands, deformed face, disfigured, extra fingers, blurry, watermark, text, signature, worst quality, low quality"
+
+// AvatarPackTheme 数字分身写真的主题，每个主题包含一组提示语，每个提示语生成一张图片
+type AvatarPackTheme struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	PreviewImage string   `json:"preview_image,omitempty"`
+	Prompts      []string `json:"-"`
+}
+
+// AvatarPackThemes 支持的写真主题
+var AvatarPackThemes = []AvatarPackTheme{
+	{
+		ID:          "business",
+		Name:        "职业形象照",
+		Description: "适用于简历、职场社交平台的专业形象照",
+		Prompts: []string{
+			"professional corporate headshot, wearing a tailored navy suit, soft studio lighting, neutral grey background, sharp focus, 85mm lens",
+			"professional portrait in a modern glass office, business casual shirt, natural window light, shallow depth of field",
+			"confident half-body portrait with arms crossed, dark blazer, clean white background, high-end studio photography",
+			"linkedin profile photo, friendly smile, light blue shirt, blurred city skyline background, golden hour light",
+		},
+	},
+	{
+		ID:          "guofeng",
+		Name:        "国风古装",
+		Description: "身着传统汉服的古风写真",
+		Prompts: []string{
+			"portrait wearing traditional chinese hanfu, ancient pavilion, plum blossoms, soft mist, cinematic lighting, ultra detailed",
+			"portrait in elegant tang dynasty costume, holding a paper fan, red lanterns at night, warm light",
+			"portrait in flowing white hanfu by a mountain lake, ink painting atmosphere, gentle breeze, ethereal",
+			"portrait as an ancient chinese scholar, bamboo forest, morning sunlight, traditional hair ornaments",
+		},
+	},
+	{
+		ID:          "cyberpunk",
+		Name:        "赛博朋克",
+		Description: "霓虹灯下的未来都市风格",
+		Prompts: []string{
+			"cyberpunk portrait, neon lights, rainy night city street, reflective leather jacket, cinematic, blade runner style",
+			"futuristic portrait with glowing holographic visor, pink and cyan neon, high contrast, ultra detailed",
+			"portrait in a futuristic megacity rooftop, flying cars in background, dramatic rim light",
+			"cyberpunk hacker portrait, dark room lit by monitors, techwear hoodie, moody atmosphere",
+		},
+	},
+	{
+		ID:          "anime",
+		Name:        "动漫角色",
+		Description: "日系动漫风格的角色形象",
+		Prompts: []string{
+			"anime style portrait, makoto shinkai style sky, vibrant colors, detailed eyes, school uniform",
+			"anime style portrait in a cherry blossom park, spring, soft pastel colors, studio ghibli style",
+			"anime style portrait as a fantasy adventurer, magical forest, glowing particles",
+			"anime style portrait at a summer festival, yukata, fireworks in the night sky",
+		},
+	},
+}
+
+// AvatarPackThemeByID 查询写真主题
+func AvatarPackThemeByID(id string) *AvatarPackTheme {
+	for _, theme := range AvatarPackThemes {
+		if theme.ID == id {
+			return &theme
+		}
+	}
+
+	return nil
+}
+
+type AvatarPackPayload struct {
+	ID     string `json:"id,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID     int64     `json:"org_id,omitempty"`
+	Theme     string    `json:"theme,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	Selfies   []string  `json:"selfies,omitempty"`
+	Quota     int64     `json:"quota,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func (payload *AvatarPackPayload) GetTitle() string {
+	return "数字分身写真"
+}
+
+func (payload *AvatarPackPayload) SetID(id string) {
+	payload.ID = id
+}
+
+func (payload *AvatarPackPayload) GetID() string {
+	return payload.ID
+}
+
+func (payload *AvatarPackPayload) GetUID() int64 {
+	return payload.UserID
+}
+
+func (payload *AvatarPackPayload) GetQuota() int64 {
+	return payload.Quota
+}
+
+func NewAvatarPackTask(payload any) *asynq.Task {
+	data, _ := json.Marshal(payload)
+	return asynq.NewTask(TypeAvatarPack, data)
+}
+
+// avatarPackImageTimeout 生成单张写真的超时时间，包含模型冷启动的时间
+const avatarPackImageTimeout = 5 * time.Minute
+
+func BuildAvatarPackHandler(conf *config.Config, client *replicate.Replicate, up *uploader.Uploader, rep *repo2.Repository) TaskHandler {
+	return func(ctx context.Context, task *asynq.Task) (err error) {
+		var payload AvatarPackPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return err
+		}
+
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
+		// 无论生成成功与否，自拍照都不再保留
+		defer PurgeAvatarPackSelfies(context.Background(), conf, up, rep, payload.GetUID(), payload.GetID())
+
+		defer func() {
+			if err2 := recover(); err2 != nil {
+				log.With(task).Errorf("panic: %v", err2)
+				err = fmt.Errorf("%v", err2)
+			}
+
+			if err != nil {
+				if err := rep.Creative.UpdateRecordByTaskID(ctx, payload.GetUID(), payload.GetID(), repo2.CreativeRecordUpdateRequest{
+					Answer: err.Error(),
+					Status: repo2.CreativeStatusFailed,
+				}); err != nil {
+					log.WithFields(log.Fields{"payload": payload}).Errorf("update creative failed: %s", err)
+				}
+
+				if err := rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{err.Error()}}); err != nil {
+					log.With(task).Errorf("update queue status failed: %s", err)
+				}
+			}
+		}()
+
+		// 任务积压超过 1 小时不再处理，自拍照同样会被删除
+		if payload.CreatedAt.Add(time.Hour).Before(time.Now()) {
+			return errors.New("任务处理超时")
+		}
+
+		theme := AvatarPackThemeByID(payload.Theme)
+		if theme == nil || len(payload.Selfies) == 0 {
+			return errors.New("无效的写真任务")
+		}
+
+		resources := make([]string, 0, len(theme.Prompts))
+		var lastErr error
+		for i, prompt := range theme.Prompts {
+			// 多张自拍照轮流作为人脸参考，提升生成结果的多样性
+			res, err := generateAvatar(ctx, client, up, payload, payload.Selfies[i%len(payload.Selfies)], prompt)
+			if err != nil {
+				log.F(log.M{"payload": payload, "index": i}).Errorf("生成数字分身写真失败: %v", err)
+				if err := rep.Queue.AddLog(ctx, payload.GetID(), repo2.QueueTaskStepProvider, fmt.Sprintf("第 %d 张图片生成失败：%v", i+1, err)); err != nil {
+					log.With(payload).Errorf("记录任务日志失败: %v", err)
+				}
+
+				lastErr = err
+				continue
+			}
+
+			resources = append(resources, res)
+		}
+
+		if len(resources) == 0 {
+			return fmt.Errorf("写真生成失败: %w", lastErr)
+		}
+
+		if err := rep.Queue.AddLog(ctx, payload.GetID(), repo2.QueueTaskStepUploaded, fmt.Sprintf("已保存 %d/%d 张图片", len(resources), len(theme.Prompts))); err != nil {
+			log.With(payload).Errorf("记录任务日志失败: %v", err)
+		}
+
+		// 部分图片生成失败时，只按照成功生成的数量计费
+		quota := coins.GetAvatarPackCoins(len(resources))
+		if quota > payload.GetQuota() {
+			quota = payload.GetQuota()
+		}
+
+		retJson, _ := json.Marshal(resources)
+		if err := rep.Creative.UpdateRecordByTaskID(ctx, payload.GetUID(), payload.GetID(), repo2.CreativeRecordUpdateRequest{
+			Status:    repo2.CreativeStatusSuccess,
+			Answer:    string(retJson),
+			QuotaUsed: quota,
+		}); err != nil {
+			log.WithFields(log.Fields{"payload": payload}).Errorf("update creative failed: %s", err)
+			return err
+		}
+
+		if err := rep.Quota.QuotaConsume(ctx, payload.GetUID(), quota, repo2.NewQuotaUsedMeta("avatar-pack", payload.Model)); err != nil {
+			log.With(payload).Errorf("used quota add failed: %s", err)
+		}
+
+		return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, CompletionResult{
+			Resources:   resources,
+			ValidBefore: time.Now().Add(7 * 24 * time.Hour),
+		})
+	}
+}
+
+func generateAvatar(ctx context.Context, client *replicate.Replicate, up *uploader.Uploader, payload AvatarPackPayload, selfie, prompt string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, avatarPackImageTimeout)
+	defer cancel()
+
+	prediction, err := client.CreatePrediction(ctx, payload.Model, map[string]any{
+		"image":           selfie,
+		"prompt":          prompt,
+		"negative_prompt": avatarPackNegativePrompt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if !prediction.IsFinished() {
+		if prediction, err = client.Wait(ctx, prediction.ID, 3*time.Second); err != nil {
+			return "", err
+		}
+	}
+
+	images := prediction.Images()
+	if prediction.Status != replicate.StatusSucceeded || len(images) == 0 {
+		return "", fmt.Errorf("prediction %s: %s", prediction.ID, prediction.ErrorMessage())
+	}
+
+	return up.UploadRemoteFile(ctx, images[0], int(payload.GetUID()), uploader.DefaultUploadExpireAfterDays, "png", false)
+}
+
+// PurgeAvatarPackSelfies 从存储中删除用户上传的自拍照，并清空授权记录中的自拍照地址
+func PurgeAvatarPackSelfies(ctx context.Context, conf *config.Config, up *uploader.Uploader, rep *repo2.Repository, userID int64, taskID string) {
+	consent, err := rep.AvatarPack.Consent(ctx, userID, taskID)
+	if err != nil {
+		if !errors.Is(err, repo2.ErrNotFound) {
+			log.F(log.M{"user_id": userID, "task_id": taskID}).Errorf("查询数字分身写真授权记录失败: %v", err)
+		}
+		return
+	}
+
+	purgeAvatarPackConsent(ctx, conf, up, rep, *consent)
+}
+
+func purgeAvatarPackConsent(ctx context.Context, conf *config.Config, up *uploader.Uploader, rep *repo2.Repository, consent repo2.AvatarPackConsent) {
+	if consent.PurgedAt != nil {
+		return
+	}
+
+	for _, selfie := range consent.Selfies {
+		key := strings.TrimPrefix(strings.TrimPrefix(selfie, conf.StorageDomain), "/")
+		if key == selfie {
+			continue
+		}
+
+		// 文件已经不存在时同样认为删除成功
+		if err := up.RemoveFile(ctx, key); err != nil && !strings.Contains(err.Error(), "no such file") {
+			log.F(log.M{"user_id": consent.UserID, "task_id": consent.TaskID, "key": key}).Errorf("删除数字分身写真自拍照失败: %v", err)
+			return
+		}
+	}
+
+	if err := rep.AvatarPack.MarkSelfiesPurged(ctx, consent.ID); err != nil {
+		log.F(log.M{"user_id": consent.UserID, "task_id": consent.TaskID}).Errorf("更新数字分身写真授权记录失败: %v", err)
+	}
+}
+
+// PurgeAvatarPackSelfiesJob 清理超过保留期限仍未删除的自拍照，如任务未被执行或删除失败的情况
+func PurgeAvatarPackSelfiesJob(ctx context.Context, conf *config.Config, up *uploader.Uploader, rep *repo2.Repository) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	retention := conf.AvatarPackSelfieRetention
+	if retention <= 0 {
+		retention = 24 * time.Hour
+	}
+
+	consents, err := rep.AvatarPack.UnpurgedConsents(ctx, time.Now().Add(-retention), 500)
+	if err != nil {
+		log.Errorf("查询待清理的数字分身写真自拍照失败: %v", err)
+		return err
+	}
+
+	for _, consent := range consents {
+		purgeAvatarPackConsent(ctx, conf, up, rep, consent)
+	}
+
+	return nil
+}
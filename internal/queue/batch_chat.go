@@ -0,0 +1,189 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/asteria/log"
+)
+
+// BatchQueueName 批量任务使用的低优先级队列
+const BatchQueueName = "batch"
+
+type BatchChatPayload struct {
+	ID      string `json:"id,omitempty"`
+	BatchID int64  `json:"batch_id,omitempty"`
+	UserID  int64  `json:"user_id,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID        int64     `json:"org_id,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	FreezedCoins int64     `json:"freezed_coins,omitempty"`
+}
+
+func (payload *BatchChatPayload) GetTitle() string {
+	return "批量聊天"
+}
+
+func (payload *BatchChatPayload) SetID(id string) {
+	payload.ID = id
+}
+
+func (payload *BatchChatPayload) GetID() string {
+	return payload.ID
+}
+
+func (payload *BatchChatPayload) GetUID() int64 {
+	return payload.UserID
+}
+
+func (payload *BatchChatPayload) GetQuotaID() int64 {
+	return 0
+}
+
+func (payload *BatchChatPayload) GetQuota() int64 {
+	return 0
+}
+
+func NewBatchChatTask(payload any) *asynq.Task {
+	data, _ := json.Marshal(payload)
+	return asynq.NewTask(TypeBatchChat, data)
+}
+
+func BuildBatchChatHandler(conf *config.Config, ct chat.Chat, rep *repo2.Repository, userSrv *service.UserService) TaskHandler {
+	return func(ctx context.Context, task *asynq.Task) (err error) {
+		var payload BatchChatPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return err
+		}
+
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
+		defer func() {
+			if err2 := recover(); err2 != nil {
+				log.With(task).Errorf("panic: %v", err2)
+				err = fmt.Errorf("%v", err2)
+			}
+
+			if err != nil {
+				if err := rep.Batch.UpdateBatchStatus(ctx, payload.BatchID, repo2.BatchStatusFailed); err != nil {
+					log.With(task).Errorf("update batch status failed: %s", err)
+				}
+
+				if err := rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{err.Error()}}); err != nil {
+					log.With(task).Errorf("update queue status failed: %s", err)
+				}
+			}
+
+			// 无论如何，都要释放用户被冻结的智慧果
+			if payload.FreezedCoins > 0 {
+				if err := userSrv.UnfreezeUserQuota(ctx, payload.UserID, payload.FreezedCoins); err != nil {
+					log.F(log.M{"payload": payload}).Errorf("批量聊天任务执行完毕，释放用户冻结的智慧果失败: %s", err)
+				}
+			}
+		}()
+
+		batch, err := rep.Batch.GetBatch(ctx, 0, payload.BatchID)
+		if err != nil {
+			return fmt.Errorf("query batch failed: %w", err)
+		}
+
+		if batch.Status != repo2.BatchStatusPending {
+			return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, EmptyResult{})
+		}
+
+		if err := rep.Batch.UpdateBatchStatus(ctx, payload.BatchID, repo2.BatchStatusRunning); err != nil {
+			return fmt.Errorf("update batch status failed: %w", err)
+		}
+
+		items, err := rep.Batch.GetBatchItems(ctx, payload.BatchID)
+		if err != nil {
+			return fmt.Errorf("query batch items failed: %w", err)
+		}
+
+		for _, item := range items {
+			if item.Status != repo2.BatchStatusPending {
+				continue
+			}
+
+			// 每个条目处理前检查批次是否已被取消
+			if current, err := rep.Batch.GetBatch(ctx, 0, payload.BatchID); err == nil && current.Status == repo2.BatchStatusCancelled {
+				log.F(log.M{"batch_id": payload.BatchID}).Infof("批量聊天任务已取消")
+				return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, EmptyResult{})
+			}
+
+			res := processBatchChatItem(ctx, conf, ct, rep, payload, item.Messages)
+			if err := rep.Batch.UpdateBatchItemResult(ctx, payload.BatchID, item.Id, res); err != nil {
+				log.F(log.M{"batch_id": payload.BatchID, "item_id": item.Id}).Errorf("update batch item failed: %s", err)
+			}
+		}
+
+		if err := rep.Batch.UpdateBatchStatus(ctx, payload.BatchID, repo2.BatchStatusSucceed); err != nil {
+			log.F(log.M{"batch_id": payload.BatchID}).Errorf("update batch status failed: %s", err)
+		}
+
+		return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, EmptyResult{})
+	}
+}
+
+// processBatchChatItem 处理单个批量聊天条目，条目失败不影响整个批次
+func processBatchChatItem(ctx context.Context, conf *config.Config, ct chat.Chat, rep *repo2.Repository, payload BatchChatPayload, rawMessages string) repo2.BatchItemResult {
+	var messages chat.Messages
+	if err := json.Unmarshal([]byte(rawMessages), &messages); err != nil {
+		return repo2.BatchItemResult{Status: repo2.BatchStatusFailed, Error: "invalid messages"}
+	}
+
+	req, _, err := (chat.Request{Model: payload.Model, Messages: messages}).Init().Fix(ct, 100)
+	if err != nil {
+		return repo2.BatchItemResult{Status: repo2.BatchStatusFailed, Error: err.Error()}
+	}
+
+	chatCtx, cancel := context.WithTimeout(ctx, 180*time.Second)
+	defer cancel()
+
+	resp, err := ct.Chat(chatCtx, *req)
+	if err != nil {
+		if errors.Is(err, chat.ErrContentFilter) {
+			return repo2.BatchItemResult{Status: repo2.BatchStatusFailed, Error: "content filtered"}
+		}
+
+		log.F(log.M{"batch_id": payload.BatchID, "model": payload.Model}).Errorf("batch chat failed: %s", err)
+		return repo2.BatchItemResult{Status: repo2.BatchStatusFailed, Error: "upstream error"}
+	}
+
+	if resp.ErrorCode != "" {
+		return repo2.BatchItemResult{Status: repo2.BatchStatusFailed, Error: fmt.Sprintf("%s %s", resp.ErrorCode, resp.Error)}
+	}
+
+	inputTokens, outputTokens := int64(resp.InputTokens), int64(resp.OutputTokens)
+	if inputTokens+outputTokens == 0 {
+		realTokens, _ := chat.MessageTokenCount(append(req.Messages, chat.Message{Role: "assistant", Content: resp.Text}), req.Model)
+		promptTokens, _ := chat.MessageTokenCount(req.Messages, req.Model)
+		inputTokens, outputTokens = int64(promptTokens), int64(realTokens-promptTokens)
+	}
+
+	quotaConsumed := coins.GetBatchChatCoins(req.ResolveCalFeeModel(conf), inputTokens+outputTokens)
+	if quotaConsumed > 0 {
+		if err := rep.Quota.QuotaConsume(ctx, payload.UserID, quotaConsumed, repo2.NewQuotaUsedMeta("batch_chat", req.Model)); err != nil {
+			log.Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	return repo2.BatchItemResult{
+		Status:       repo2.BatchStatusSucceed,
+		Output:       resp.Text,
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		Coins:        quotaConsumed,
+	}
+}
@@ -0,0 +1,142 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/pkg/chatimport"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
+)
+
+type ChatImportPayload struct {
+	ID        string    `json:"id,omitempty"`
+	ImportID  int64     `json:"import_id,omitempty"`
+	UserID    int64     `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+func (payload *ChatImportPayload) GetTitle() string {
+	return "聊天记录导入"
+}
+
+func (payload *ChatImportPayload) SetID(id string) {
+	payload.ID = id
+}
+
+func (payload *ChatImportPayload) GetID() string {
+	return payload.ID
+}
+
+func (payload *ChatImportPayload) GetUID() int64 {
+	return payload.UserID
+}
+
+func (payload *ChatImportPayload) GetQuotaID() int64 {
+	return 0
+}
+
+func (payload *ChatImportPayload) GetQuota() int64 {
+	return 0
+}
+
+func NewChatImportTask(payload any) *asynq.Task {
+	data, _ := json.Marshal(payload)
+	return asynq.NewTask(TypeChatImport, data)
+}
+
+func BuildChatImportHandler(rep *repo2.Repository) TaskHandler {
+	return func(ctx context.Context, task *asynq.Task) (err error) {
+		var payload ChatImportPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return err
+		}
+
+		defer func() {
+			if err2 := recover(); err2 != nil {
+				log.With(task).Errorf("panic: %v", err2)
+				err = fmt.Errorf("%v", err2)
+			}
+
+			if err != nil {
+				if err := rep.ChatImport.UpdateImportStatus(ctx, payload.ImportID, repo2.ChatImportStatusFailed); err != nil {
+					log.With(task).Errorf("update chat import status failed: %s", err)
+				}
+
+				if err := rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{err.Error()}}); err != nil {
+					log.With(task).Errorf("update queue status failed: %s", err)
+				}
+			}
+		}()
+
+		imp, err := rep.ChatImport.GetImport(ctx, 0, payload.ImportID)
+		if err != nil {
+			return fmt.Errorf("query chat import failed: %w", err)
+		}
+
+		if imp.Status != repo2.ChatImportStatusPending {
+			return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, EmptyResult{})
+		}
+
+		if err := rep.ChatImport.UpdateImportStatus(ctx, payload.ImportID, repo2.ChatImportStatusRunning); err != nil {
+			return fmt.Errorf("update chat import status failed: %w", err)
+		}
+
+		items, err := rep.ChatImport.GetImportItems(ctx, payload.ImportID)
+		if err != nil {
+			return fmt.Errorf("query chat import items failed: %w", err)
+		}
+
+		for _, item := range items {
+			if item.Status != repo2.ChatImportStatusPending {
+				continue
+			}
+
+			res := importConversation(ctx, rep, payload, item)
+			if err := rep.ChatImport.UpdateImportItemResult(ctx, payload.ImportID, item.Id, res); err != nil {
+				log.F(log.M{"import_id": payload.ImportID, "item_id": item.Id}).Errorf("update chat import item failed: %s", err)
+			}
+		}
+
+		if err := rep.ChatImport.UpdateImportStatus(ctx, payload.ImportID, repo2.ChatImportStatusSucceed); err != nil {
+			log.F(log.M{"import_id": payload.ImportID}).Errorf("update chat import status failed: %s", err)
+		}
+
+		return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, EmptyResult{})
+	}
+}
+
+// importConversation 导入单个会话，已经导入过的会话只追加新增的消息，会话导入失败不影响其它会话
+func importConversation(ctx context.Context, rep *repo2.Repository, payload ChatImportPayload, item model.ChatImportItem) repo2.ChatImportItemResult {
+	var conv chatimport.Conversation
+	if err := json.Unmarshal([]byte(item.Conversation), &conv); err != nil {
+		return repo2.ChatImportItemResult{Status: repo2.ChatImportStatusFailed, Error: "invalid conversation"}
+	}
+
+	prev, err := rep.ChatImport.ImportedConversation(ctx, payload.UserID, item.Source, item.ExternalId)
+	if err != nil {
+		log.F(log.M{"import_id": payload.ImportID, "item_id": item.Id}).Errorf("query imported conversation failed: %s", err)
+		return repo2.ChatImportItemResult{Status: repo2.ChatImportStatusFailed, Error: "internal error"}
+	}
+
+	var roomID int64
+	var skip int
+	if prev != nil {
+		roomID, skip = prev.RoomId, int(prev.MessageCount)
+		if skip >= len(conv.Messages) {
+			return repo2.ChatImportItemResult{Status: repo2.ChatImportStatusSkipped, RoomID: roomID, MessageCount: prev.MessageCount}
+		}
+	}
+
+	roomID, err = rep.ChatImport.SaveImportedConversation(ctx, payload.UserID, roomID, chatimport.ChatGPTModel(conv.Model), conv, skip)
+	if err != nil {
+		log.F(log.M{"import_id": payload.ImportID, "item_id": item.Id}).Errorf("save imported conversation failed: %s", err)
+		return repo2.ChatImportItemResult{Status: repo2.ChatImportStatusFailed, Error: "internal error"}
+	}
+
+	return repo2.ChatImportItemResult{Status: repo2.ChatImportStatusSucceed, RoomID: roomID, MessageCount: int64(len(conv.Messages))}
+}
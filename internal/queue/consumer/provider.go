@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/ai/chat"
 	"github.com/mylxsw/aidea-server/pkg/ai/dashscope"
 	"github.com/mylxsw/aidea-server/pkg/ai/deepai"
@@ -10,12 +11,16 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/leap"
 	"github.com/mylxsw/aidea-server/pkg/ai/lepton"
 	"github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/pkg/ai/replicate"
 	"github.com/mylxsw/aidea-server/pkg/ai/stabilityai"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
 	"github.com/mylxsw/aidea-server/pkg/dingding"
 	"github.com/mylxsw/aidea-server/pkg/mail"
+	"github.com/mylxsw/aidea-server/pkg/ocr"
 	"github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/aidea-server/pkg/sms"
+	"github.com/mylxsw/aidea-server/pkg/trace"
 	"github.com/mylxsw/aidea-server/pkg/uploader"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"time"
@@ -42,6 +47,8 @@ func (Provider) Register(binder infra.Binder) {
 					"mail":    conf.QueueWorkers / 5 * 1,
 					"user":    conf.QueueWorkers / 5 * 1,
 					"default": conf.QueueWorkers - conf.QueueWorkers/5*2,
+					// 批量离线任务，优先级最低
+					queue.BatchQueueName: 1,
 					//"text":  conf.QueueWorkers / 3 * 2,
 					//"image": conf.QueueWorkers - conf.QueueWorkers/3*2,
 				},
@@ -59,20 +66,53 @@ func (Provider) Register(binder infra.Binder) {
 
 func loggingMiddleware(h asynq.Handler) asynq.Handler {
 	return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		// 发起任务的请求 ID，用于关联请求与任务的日志
+		if requestID := queue.ExtractRequestID(t.Payload()); requestID != "" {
+			ctx = trace.WithRequestID(ctx, requestID)
+		}
+
+		taskID, _ := asynq.GetTaskID(ctx)
 		start := time.Now()
-		log.Debugf("Start processing %q", t.Type())
+		trace.F(ctx, log.M{"task_type": t.Type(), "task_id": taskID}).Debugf("Start processing %q", t.Type())
 		err := h.ProcessTask(ctx, t)
 		if err != nil {
-			log.Warningf("task process failed: %q, %v", t.Type(), err)
+			trace.F(ctx, log.M{"task_type": t.Type(), "task_id": taskID, "elapse": time.Since(start).Milliseconds()}).
+				Warningf("task process failed: %q, %v", t.Type(), err)
 			// 失败后不再进行重试
 			return asynq.SkipRetry
 		}
 
-		log.Debugf("finished processing %q: elapsed time = %v", t.Type(), time.Since(start))
+		trace.F(ctx, log.M{"task_type": t.Type(), "task_id": taskID, "elapse": time.Since(start).Milliseconds()}).
+			Debugf("finished processing %q", t.Type())
 		return nil
 	})
 }
 
+// taskLogMiddleware 任务开始处理时记录任务日志，排队时间与处理时间可以从日志的时间中看出
+func taskLogMiddleware(queueRepo *repo.QueueRepo) asynq.MiddlewareFunc {
+	return func(h asynq.Handler) asynq.Handler {
+		return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+			meta := queue.ExtractTaskMeta(t.Payload())
+			if meta.ID != "" {
+				step, message := repo.QueueTaskStepStarted, "开始处理"
+				if retried, _ := asynq.GetRetryCount(ctx); retried > 0 {
+					step, message = repo.QueueTaskStepRetried, fmt.Sprintf("第 %d 次重试", retried)
+				}
+
+				if meta.Vendor != "" {
+					message += fmt.Sprintf("，服务提供商：%s，模型：%s", meta.Vendor, meta.Model)
+				}
+
+				if err := queueRepo.AddLog(ctx, meta.ID, step, message); err != nil {
+					trace.F(ctx, log.M{"task_type": t.Type(), "task_id": meta.ID}).Errorf("记录任务日志失败: %v", err)
+				}
+			}
+
+			return h.ProcessTask(ctx, t)
+		})
+	}
+}
+
 func (p Provider) Boot(resolver infra.Resolver) {
 	resolver.MustResolve(func(
 		mux *asynq.ServeMux,
@@ -95,7 +135,14 @@ func (p Provider) Boot(resolver infra.Resolver) {
 		userSvc *service.UserService,
 		dalleClient *openai.DalleImageClient,
 		leptonClient *lepton.Lepton,
+		ocrClient *ocr.OCR,
+		judgeSvc *service.GroupJudgeService,
+		consensusSvc *service.GroupConsensusService,
+		zhipuClient *zhipu.Zhipu,
+		replicateClient *replicate.Replicate,
 	) {
+		mux.Use(taskLogMiddleware(rep.Queue))
+
 		log.Debugf("register all queue handlers")
 		mux.HandleFunc(queue.TypeOpenAICompletion, queue.BuildOpenAICompletionHandler(openaiClient, rep))
 		mux.HandleFunc(queue.TypeDeepAICompletion, queue.BuildDeepAICompletionHandler(deepaiClient, translater, uploader, rep, openaiClient))
@@ -106,16 +153,22 @@ func (p Provider) Boot(resolver infra.Resolver) {
 		mux.HandleFunc(queue.TypeSignup, queue.BuildSignupHandler(rep, mailer, ding))
 		mux.HandleFunc(queue.TypePayment, queue.BuildPaymentHandler(rep, mailer, que, ding))
 		mux.HandleFunc(queue.TypeBindPhone, queue.BuildBindPhoneHandler(rep, mailer))
-		mux.HandleFunc(queue.TypeImageGenCompletion, queue.BuildImageCompletionHandler(leapClient, stabaiClient, deepaiClient, fromstonClient, dashscopeClient, getimgaiClient, translater, uploader, rep, openaiClient, dalleClient))
+		mux.HandleFunc(queue.TypeImageGenCompletion, queue.BuildImageCompletionHandler(leapClient, stabaiClient, deepaiClient, fromstonClient, dashscopeClient, getimgaiClient, translater, uploader, rep, openaiClient, dalleClient, zhipuClient))
 		mux.HandleFunc(queue.TypeFromStonCompletion, queue.BuildFromStonCompletionHandler(fromstonClient, uploader, rep))
 		mux.HandleFunc(queue.TypeDashscopeImageCompletion, queue.BuildDashscopeImageCompletionHandler(dashscopeClient, uploader, rep, translater, openaiClient))
 		mux.HandleFunc(queue.TypeGetimgAICompletion, queue.BuildGetimgAICompletionHandler(getimgaiClient, translater, uploader, rep, openaiClient))
 		mux.HandleFunc(queue.TypeImageDownloader, queue.BuildImageDownloaderHandler(uploader, rep))
 		mux.HandleFunc(queue.TypeImageUpscale, queue.BuildImageUpscaleHandler(deepaiClient, stabaiClient, uploader, rep))
 		mux.HandleFunc(queue.TypeImageColorization, queue.BuildImageColorizationHandler(deepaiClient, uploader, rep))
-		mux.HandleFunc(queue.TypeGroupChat, queue.BuildGroupChatHandler(conf, ct, rep, userSvc))
+		mux.HandleFunc(queue.TypeGroupChat, queue.BuildGroupChatHandler(conf, ct, rep, userSvc, judgeSvc, consensusSvc))
+		mux.HandleFunc(queue.TypeGroupDebate, queue.BuildGroupDebateHandler(conf, ct, rep, userSvc))
 		mux.HandleFunc(queue.TypeDalleCompletion, queue.BuildDalleCompletionHandler(dalleClient, uploader, rep))
+		mux.HandleFunc(queue.TypeZhipuImageCompletion, queue.BuildZhipuImageCompletionHandler(zhipuClient, uploader, rep))
 		mux.HandleFunc(queue.TypeArtisticTextCompletion, queue.BuildArtisticTextCompletionHandler(leptonClient, translater, uploader, rep, openaiClient))
+		mux.HandleFunc(queue.TypeBatchChat, queue.BuildBatchChatHandler(conf, ct, rep, userSvc))
+		mux.HandleFunc(queue.TypeEssayGrading, queue.BuildEssayGradingHandler(ct, ocrClient, rep))
+		mux.HandleFunc(queue.TypeChatImport, queue.BuildChatImportHandler(rep))
+		mux.HandleFunc(queue.TypeAvatarPack, queue.BuildAvatarPackHandler(conf, replicateClient, uploader, rep))
 	})
 }
 
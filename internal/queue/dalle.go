@@ -19,6 +19,8 @@ type DalleCompletionPayload struct {
 	Model string `json:"model,omitempty"`
 	Quota int64  `json:"quota,omitempty"`
 	UID   int64  `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID int64 `json:"org_id,omitempty"`
 
 	Prompt      string   `json:"prompt,omitempty"`
 	PromptTags  []string `json:"prompt_tags,omitempty"`
@@ -64,6 +66,9 @@ func BuildDalleCompletionHandler(client *openai.DalleImageClient, up *uploader.U
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		if payload.CreatedAt.Add(5 * time.Minute).Before(time.Now()) {
 			rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{"任务处理超时"}})
 			log.WithFields(log.Fields{"payload": payload}).Errorf("task expired")
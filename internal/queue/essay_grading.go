@@ -0,0 +1,222 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/ocr"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+)
+
+type EssayGradingPayload struct {
+	ID     string `json:"id,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID       int64     `json:"org_id,omitempty"`
+	Model       string    `json:"model,omitempty"`
+	Text        string    `json:"text,omitempty"`
+	Images      []string  `json:"images,omitempty"`
+	Grade       string    `json:"grade,omitempty"`
+	Requirement string    `json:"requirement,omitempty"`
+	Quota       int64     `json:"quota,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+func (payload *EssayGradingPayload) GetTitle() string {
+	return "作文批改"
+}
+
+func (payload *EssayGradingPayload) SetID(id string) {
+	payload.ID = id
+}
+
+func (payload *EssayGradingPayload) GetID() string {
+	return payload.ID
+}
+
+func (payload *EssayGradingPayload) GetUID() int64 {
+	return payload.UserID
+}
+
+func (payload *EssayGradingPayload) GetQuotaID() int64 {
+	return 0
+}
+
+func (payload *EssayGradingPayload) GetQuota() int64 {
+	return payload.Quota
+}
+
+func NewEssayGradingTask(payload any) *asynq.Task {
+	data, _ := json.Marshal(payload)
+	return asynq.NewTask(TypeEssayGrading, data)
+}
+
+// EssayGradingDimension 评分维度
+type EssayGradingDimension struct {
+	Name     string `json:"name"`
+	Score    int    `json:"score"`
+	MaxScore int    `json:"max_score"`
+	Comment  string `json:"comment"`
+}
+
+// EssayGradingResult 作文批改结果，以 JSON 格式保存在创作岛历史记录中
+type EssayGradingResult struct {
+	// Essay 批改的作文原文，拍照上传时为文字识别结果
+	Essay       string                  `json:"essay"`
+	Score       int                     `json:"score"`
+	MaxScore    int                     `json:"max_score"`
+	Level       string                  `json:"level"`
+	Dimensions  []EssayGradingDimension `json:"dimensions"`
+	Strengths   []string                `json:"strengths"`
+	Weaknesses  []string                `json:"weaknesses"`
+	Suggestions []string                `json:"suggestions"`
+	Comment     string                  `json:"comment"`
+}
+
+const essayGradingPrompt = `你是一位经验丰富的语文/英语作文阅卷老师，请按照评分标准批改学生的作文。
+%s
+评分标准（满分 100 分）：
+1. 内容与立意（30 分）：是否切题，中心是否明确，内容是否充实
+2. 结构与条理（20 分）：结构是否完整，段落层次是否清晰，过渡是否自然
+3. 语言表达（30 分）：用词是否准确，句式是否丰富，是否有语法或错别字问题
+4. 文采与创新（20 分）：是否有亮点，表达是否生动，观点是否新颖
+
+请只输出如下格式的 JSON，不要输出其它内容，评语使用作文所用的语言：
+{"score": 总分, "max_score": 100, "level": "优秀/良好/中等/及格/不及格", "dimensions": [{"name": "维度名称", "score": 得分, "max_score": 满分, "comment": "评语"}], "strengths": ["优点"], "weaknesses": ["不足"], "suggestions": ["修改建议"], "comment": "总评"}`
+
+func BuildEssayGradingHandler(ct chat.Chat, ocrClient *ocr.OCR, rep *repo2.Repository) TaskHandler {
+	return func(ctx context.Context, task *asynq.Task) (err error) {
+		var payload EssayGradingPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return err
+		}
+
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
+		// 如果任务是 30 分钟前创建的，不再处理
+		if payload.CreatedAt.Add(30 * time.Minute).Before(time.Now()) {
+			return nil
+		}
+
+		defer func() {
+			if err2 := recover(); err2 != nil {
+				log.With(task).Errorf("panic: %v", err2)
+				err = fmt.Errorf("%v", err2)
+			}
+
+			if err != nil {
+				// 更新创作岛历史记录
+				if err := rep.Creative.UpdateRecordByTaskID(ctx, payload.GetUID(), payload.GetID(), repo2.CreativeRecordUpdateRequest{
+					Answer: err.Error(),
+					Status: repo2.CreativeStatusFailed,
+				}); err != nil {
+					log.WithFields(log.Fields{"payload": payload}).Errorf("update creative failed: %s", err)
+				}
+
+				if err := rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{err.Error()}}); err != nil {
+					log.With(task).Errorf("update queue status failed: %s", err)
+				}
+			}
+		}()
+
+		essay := strings.TrimSpace(payload.Text)
+		if len(payload.Images) > 0 {
+			pages := make([]string, 0, len(payload.Images))
+			for _, img := range payload.Images {
+				page, err := ocrClient.RecognizeURL(ctx, img)
+				if err != nil {
+					return fmt.Errorf("作文图片文字识别失败: %w", err)
+				}
+
+				pages = append(pages, page.Text())
+			}
+
+			essay = strings.TrimSpace(strings.Join(pages, "\n"))
+		}
+
+		if essay == "" {
+			return errors.New("没有识别到作文内容")
+		}
+
+		var extra string
+		if payload.Grade != "" {
+			extra += fmt.Sprintf("学生年级/考试类型：%s\n", payload.Grade)
+		}
+		if payload.Requirement != "" {
+			extra += fmt.Sprintf("作文题目要求：%s\n", payload.Requirement)
+		}
+
+		chatCtx, cancel := context.WithTimeout(ctx, 180*time.Second)
+		defer cancel()
+
+		resp, err := ct.Chat(chatCtx, chat.Request{
+			Model: payload.Model,
+			Messages: chat.Messages{
+				{Role: "system", Content: fmt.Sprintf(essayGradingPrompt, extra)},
+				{Role: "user", Content: essay},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("作文批改失败: %w", err)
+		}
+
+		if resp.ErrorCode != "" {
+			return fmt.Errorf("作文批改失败: %s %s", resp.ErrorCode, resp.Error)
+		}
+
+		result, err := parseEssayGradingResult(resp.Text)
+		if err != nil {
+			return fmt.Errorf("作文批改结果解析失败: %w", err)
+		}
+
+		result.Essay = essay
+		retJson, _ := json.Marshal(result)
+
+		if err := rep.Creative.UpdateRecordByTaskID(ctx, payload.GetUID(), payload.GetID(), repo2.CreativeRecordUpdateRequest{
+			Status:    repo2.CreativeStatusSuccess,
+			Answer:    string(retJson),
+			QuotaUsed: payload.GetQuota(),
+		}); err != nil {
+			log.WithFields(log.Fields{"payload": payload}).Errorf("update creative failed: %s", err)
+			return err
+		}
+
+		// 记录消耗
+		if err := rep.Quota.QuotaConsume(ctx, payload.GetUID(), payload.GetQuota(), repo2.NewQuotaUsedMeta("essay-grading", payload.Model)); err != nil {
+			log.With(payload).Errorf("used quota add failed: %s", err)
+		}
+
+		return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, EmptyResult{})
+	}
+}
+
+// parseEssayGradingResult 解析模型输出的批改结果，忽略 JSON 之外的多余内容
+func parseEssayGradingResult(text string) (*EssayGradingResult, error) {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, errors.New("no json object found")
+	}
+
+	var result EssayGradingResult
+	if err := json.Unmarshal([]byte(text[start:end+1]), &result); err != nil {
+		return nil, err
+	}
+
+	if result.MaxScore <= 0 {
+		result.MaxScore = 100
+	}
+
+	if result.Score < 0 || result.Score > result.MaxScore {
+		return nil, fmt.Errorf("invalid score %d", result.Score)
+	}
+
+	return &result, nil
+}
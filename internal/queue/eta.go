@@ -0,0 +1,108 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+)
+
+const (
+	// throughputWindow 统计任务处理速度的时间窗口
+	throughputWindow = 30 * time.Minute
+	// throughputCacheTTL 任务处理速度的缓存时间，避免每次查询排队位置都统计一次
+	throughputCacheTTL = 30 * time.Second
+	// defaultTaskDuration 时间窗口内没有完成的任务时，假定每个任务的处理时间
+	defaultTaskDuration = time.Minute
+)
+
+// TaskETA 排队中任务的位置与预计开始时间
+type TaskETA struct {
+	// Position 任务前面还有多少个任务在排队
+	Position int64 `json:"position"`
+	// Wait 预计还需要等待的时间（秒）
+	Wait int64 `json:"wait"`
+	// EstimatedStartAt 预计开始处理的时间
+	EstimatedStartAt time.Time `json:"estimated_start_at"`
+}
+
+// EstimateQueueWait 根据时间窗口内完成的任务数量估算排在 position 位置的任务的等待时间
+func EstimateQueueWait(position, completed int64, window time.Duration) time.Duration {
+	if position <= 0 {
+		return 0
+	}
+
+	if completed <= 0 || window <= 0 {
+		return time.Duration(position) * defaultTaskDuration
+	}
+
+	return time.Duration(float64(window) / float64(completed) * float64(position))
+}
+
+// PositionChangedSignificantly 排队位置的变化是否需要通知用户：轮到该任务、前进至少 5 位或者前进超过 20%
+func PositionChangedSignificantly(prev, cur int64) bool {
+	if prev == cur {
+		return false
+	}
+
+	if cur == 0 {
+		return true
+	}
+
+	diff := prev - cur
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff >= 5 || diff*5 >= prev
+}
+
+type throughput struct {
+	completed int64
+	at        time.Time
+}
+
+var throughputCache sync.Map
+
+// completedInWindow 时间窗口内完成的同类型任务数量
+func (q *Queue) completedInWindow(ctx context.Context, taskType string) (int64, error) {
+	if val, ok := throughputCache.Load(taskType); ok {
+		if tp := val.(throughput); time.Since(tp.at) < throughputCacheTTL {
+			return tp.completed, nil
+		}
+	}
+
+	completed, err := q.queueRepo.CompletedCount(ctx, taskType, time.Now().Add(-throughputWindow))
+	if err != nil {
+		return 0, err
+	}
+
+	throughputCache.Store(taskType, throughput{completed: completed, at: time.Now()})
+	return completed, nil
+}
+
+// ETA 查询排队中任务的位置与预计开始时间，任务已经开始处理时返回 nil
+func (q *Queue) ETA(ctx context.Context, task model.QueueTasks) (*TaskETA, error) {
+	if repo2.QueueTaskStatus(task.Status) != repo2.QueueTaskStatusPending {
+		return nil, nil
+	}
+
+	position, err := q.queueRepo.QueuePosition(ctx, task.TaskType, task.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := q.completedInWindow(ctx, task.TaskType)
+	if err != nil {
+		return nil, err
+	}
+
+	wait := EstimateQueueWait(position, completed, throughputWindow)
+	return &TaskETA{
+		Position:         position,
+		Wait:             int64(wait.Seconds()),
+		EstimatedStartAt: time.Now().Add(wait),
+	}, nil
+}
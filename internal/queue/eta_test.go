@@ -0,0 +1,24 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestEstimateQueueWait(t *testing.T) {
+	assert.EqualValues(t, 0, queue.EstimateQueueWait(0, 10, 30*time.Minute))
+	assert.EqualValues(t, 3*time.Minute, queue.EstimateQueueWait(3, 0, 30*time.Minute))
+	assert.EqualValues(t, 15*time.Minute, queue.EstimateQueueWait(5, 10, 30*time.Minute))
+}
+
+func TestPositionChangedSignificantly(t *testing.T) {
+	assert.False(t, queue.PositionChangedSignificantly(10, 10))
+	assert.True(t, queue.PositionChangedSignificantly(1, 0))
+	assert.False(t, queue.PositionChangedSignificantly(100, 97))
+	assert.True(t, queue.PositionChangedSignificantly(100, 95))
+	assert.True(t, queue.PositionChangedSignificantly(10, 8))
+	assert.False(t, queue.PositionChangedSignificantly(30, 29))
+}
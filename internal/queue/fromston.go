@@ -27,6 +27,8 @@ type FromStonCompletionPayload struct {
 	Model string `json:"model,omitempty"`
 	Quota int64  `json:"quota,omitempty"`
 	UID   int64  `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID int64 `json:"org_id,omitempty"`
 
 	Prompt     string   `json:"prompt,omitempty"`
 	PromptTags []string `json:"prompt_tags,omitempty"`
@@ -96,6 +98,10 @@ func (p FromStonPendingTaskPayload) GetQuota() int64 {
 	return p.Payload.Quota
 }
 
+func (p FromStonPendingTaskPayload) GetOrgID() int64 {
+	return p.Payload.OrgID
+}
+
 func (p FromStonPendingTaskPayload) GetModel() string {
 	return p.Payload.Model
 }
@@ -116,6 +122,9 @@ func BuildFromStonCompletionHandler(client *fromston2.Fromston, up *uploader2.Up
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		if payload.CreatedAt.Add(5 * time.Minute).Before(time.Now()) {
 			rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{"任务处理超时"}})
 			log.WithFields(log.Fields{"payload": payload}).Errorf("task expired")
@@ -411,6 +420,7 @@ func fromStonAsyncJobProcesser(que *Queue, client *fromston2.Fromston, up *uploa
 type FromstonTaskPayload interface {
 	GetID() string
 	GetUID() int64
+	GetOrgID() int64
 	GetQuota() int64
 	GetModel() string
 	GetImage() string
@@ -459,7 +469,7 @@ func handleFromstonTask(
 	// 更新用户配额
 	modelUsed := []string{payload.GetModel(), "upload"}
 	if err := rep.Quota.QuotaConsume(
-		context.TODO(),
+		repo2.WithBillingOrg(context.TODO(), payload.GetOrgID()),
 		payload.GetUID(),
 		payload.GetQuota(),
 		repo2.NewQuotaUsedMeta("fromston", modelUsed...),
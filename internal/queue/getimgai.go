@@ -27,6 +27,8 @@ type GetimgAICompletionPayload struct {
 	Model string `json:"model,omitempty"`
 	Quota int64  `json:"quota,omitempty"`
 	UID   int64  `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID int64 `json:"org_id,omitempty"`
 
 	Prompt         string   `json:"prompt,omitempty"`
 	PromptTags     []string `json:"prompt_tags,omitempty"`
@@ -77,6 +79,9 @@ func BuildGetimgAICompletionHandler(client *getimgai.GetimgAI, translator youdao
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		if payload.CreatedAt.Add(5 * time.Minute).Before(time.Now()) {
 			rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{"任务处理超时"}})
 			log.WithFields(log.Fields{"payload": payload}).Errorf("task expired")
@@ -282,12 +287,14 @@ func resolvePrompts(ctx context.Context, payload PromptResolverPayload, creative
 	negativePrompt := payload.NegativePrompt
 
 	// 查询 Filter 信息
+	var styleFilter *repo2.ImageFilter
 	if payload.FilterID > 0 {
 		filter, err := creativeRepo.Filter(ctx, payload.FilterID)
 		if err != nil {
 			log.WithFields(log.Fields{"payload": payload}).Errorf("invalid filter: %s", err)
 		}
 
+		styleFilter = filter
 		if filter != nil {
 			if filter.ImageMeta.ShouldUseTemplate(prompt) {
 				prompt = filter.ImageMeta.ApplyTemplate(prompt)
@@ -379,6 +386,10 @@ func resolvePrompts(ctx context.Context, payload PromptResolverPayload, creative
 					}
 				}
 			}
+
+			if styleFilter != nil && modelInDB.ImageMeta.SupportLora {
+				prompt = applyStyleLoras(ctx, creativeRepo, prompt, styleFilter.ImageMeta.Loras)
+			}
 		}
 	}
 
@@ -388,3 +399,24 @@ func resolvePrompts(ctx context.Context, payload PromptResolverPayload, creative
 
 	return prompt, negativePrompt, payload.AIRewrite
 }
+
+// applyStyleLoras 在提示语中追加风格使用的 LoRA 及其触发词，已禁用的 LoRA 会被忽略
+func applyStyleLoras(ctx context.Context, creativeRepo *repo2.CreativeRepo, prompt string, styleLoras []repo2.ImageFilterLora) string {
+	if len(styleLoras) == 0 {
+		return prompt
+	}
+
+	loras, err := creativeRepo.LorasByIDs(ctx, array.Map(styleLoras, func(item repo2.ImageFilterLora, _ int) int64 { return item.ID }))
+	if err != nil {
+		log.Errorf("query style loras failed: %v", err)
+		return prompt
+	}
+
+	for _, item := range styleLoras {
+		if lora, ok := loras[item.ID]; ok {
+			prompt = strings.Trim(prompt, ",") + "," + lora.PromptTag(item.Weight)
+		}
+	}
+
+	return prompt
+}
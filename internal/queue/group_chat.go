@@ -3,6 +3,7 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/ai/chat"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
@@ -17,9 +18,11 @@ import (
 )
 
 type GroupChatPayload struct {
-	ID              string        `json:"id,omitempty"`
-	GroupID         int64         `json:"group_id,omitempty"`
-	UserID          int64         `json:"user_id,omitempty"`
+	ID      string `json:"id,omitempty"`
+	GroupID int64  `json:"group_id,omitempty"`
+	UserID  int64  `json:"user_id,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID           int64         `json:"org_id,omitempty"`
 	MemberID        int64         `json:"member_id,omitempty"`
 	QuestionID      int64         `json:"question_id,omitempty"`
 	MessageID       int64         `json:"message_id,omitempty"`
@@ -27,6 +30,10 @@ type GroupChatPayload struct {
 	ContextMessages chat.Messages `json:"context_messages,omitempty"`
 	CreatedAt       time.Time     `json:"created_at,omitempty"`
 	FreezedCoins    int64         `json:"freezed_coins,omitempty"`
+	// AutoJudge 所有成员回答完成后，自动由评审模型选出最佳回答
+	AutoJudge bool `json:"auto_judge,omitempty"`
+	// AutoMerge 所有成员回答完成后，自动合并生成综合回答
+	AutoMerge bool `json:"auto_merge,omitempty"`
 }
 
 func (payload *GroupChatPayload) GetTitle() string {
@@ -58,13 +65,16 @@ func NewGroupChatTask(payload any) *asynq.Task {
 	return asynq.NewTask(TypeGroupChat, data)
 }
 
-func BuildGroupChatHandler(conf *config.Config, ct chat.Chat, rep *repo2.Repository, userSrv *service.UserService) TaskHandler {
+func BuildGroupChatHandler(conf *config.Config, ct chat.Chat, rep *repo2.Repository, userSrv *service.UserService, judgeSrv *service.GroupJudgeService, consensusSrv *service.GroupConsensusService) TaskHandler {
 	return func(ctx context.Context, task *asynq.Task) (err error) {
 		var payload GroupChatPayload
 		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		// 如果任务是 15 分钟前创建的，不再处理
 		if payload.CreatedAt.Add(15 * time.Minute).Before(time.Now()) {
 			return nil
@@ -157,6 +167,10 @@ func BuildGroupChatHandler(conf *config.Config, ct chat.Chat, rep *repo2.Reposit
 			}
 		}
 
+		if payload.AutoJudge || payload.AutoMerge {
+			postProcessGroupAnswers(ctx, rep, judgeSrv, consensusSrv, payload)
+		}
+
 		return rep.Queue.Update(
 			context.TODO(),
 			payload.GetID(),
@@ -165,3 +179,32 @@ func BuildGroupChatHandler(conf *config.Config, ct chat.Chat, rep *repo2.Reposit
 		)
 	}
 }
+
+// postProcessGroupAnswers 最后一个完成的成员回答触发评审和综合回答，处理失败不影响当前任务
+func postProcessGroupAnswers(ctx context.Context, rep *repo2.Repository, judgeSrv *service.GroupJudgeService, consensusSrv *service.GroupConsensusService, payload GroupChatPayload) {
+	answers, err := rep.ChatGroup.GetAnswers(ctx, payload.GroupID, payload.UserID, payload.QuestionID)
+	if err != nil {
+		log.With(payload).Errorf("query group answers failed: %s", err)
+		return
+	}
+
+	for _, ans := range answers {
+		if ans.Status == repo2.MessageStatusWaiting {
+			return
+		}
+	}
+
+	if payload.AutoJudge {
+		if _, err := judgeSrv.Judge(ctx, payload.GroupID, payload.UserID, payload.QuestionID); err != nil &&
+			!errors.Is(err, service.ErrJudgeInProgress) && !errors.Is(err, service.ErrJudgeNotEnough) {
+			log.With(payload).Errorf("group answers judge failed: %s", err)
+		}
+	}
+
+	if payload.AutoMerge {
+		if _, err := consensusSrv.Merge(ctx, payload.GroupID, payload.UserID, payload.QuestionID); err != nil &&
+			!errors.Is(err, service.ErrConsensusExists) && !errors.Is(err, service.ErrConsensusNotEnough) {
+			log.With(payload).Errorf("group answers merge failed: %s", err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// GroupDebateMember 参与辩论的群组成员
+type GroupDebateMember struct {
+	ID        int64  `json:"id"`
+	ModelID   string `json:"model_id"`
+	ModelName string `json:"model_name"`
+}
+
+type GroupDebatePayload struct {
+	ID      string `json:"id,omitempty"`
+	GroupID int64  `json:"group_id,omitempty"`
+	UserID  int64  `json:"user_id,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID      int64 `json:"org_id,omitempty"`
+	QuestionID int64 `json:"question_id,omitempty"`
+	// Topic 辩论主题（用户的问题）
+	Topic string `json:"topic,omitempty"`
+	// Rounds 辩论轮数，每一轮所有成员按顺序各发言一次
+	Rounds       int                 `json:"rounds,omitempty"`
+	Members      []GroupDebateMember `json:"members,omitempty"`
+	CreatedAt    time.Time           `json:"created_at,omitempty"`
+	FreezedCoins int64               `json:"freezed_coins,omitempty"`
+}
+
+func (payload *GroupDebatePayload) GetTitle() string {
+	return "群聊辩论"
+}
+
+func (payload *GroupDebatePayload) SetID(id string) {
+	payload.ID = id
+}
+
+func (payload *GroupDebatePayload) GetID() string {
+	return payload.ID
+}
+
+func (payload *GroupDebatePayload) GetUID() int64 {
+	return payload.UserID
+}
+
+func (payload *GroupDebatePayload) GetQuotaID() int64 {
+	return 0
+}
+
+func (payload *GroupDebatePayload) GetQuota() int64 {
+	return 0
+}
+
+func NewGroupDebateTask(payload any) *asynq.Task {
+	data, _ := json.Marshal(payload)
+	return asynq.NewTask(TypeGroupDebate, data)
+}
+
+// debateTurn 辩论中的一次发言
+type debateTurn struct {
+	Member  GroupDebateMember
+	Content string
+}
+
+func BuildGroupDebateHandler(conf *config.Config, ct chat.Chat, rep *repo2.Repository, userSrv *service.UserService) TaskHandler {
+	return func(ctx context.Context, task *asynq.Task) (err error) {
+		var payload GroupDebatePayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return err
+		}
+
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
+		// 如果任务是 15 分钟前创建的，不再处理
+		if payload.CreatedAt.Add(15 * time.Minute).Before(time.Now()) {
+			return nil
+		}
+
+		defer func() {
+			if err2 := recover(); err2 != nil {
+				log.With(task).Errorf("panic: %v", err2)
+				err = fmt.Errorf("%v", err2)
+			}
+
+			if err != nil {
+				if err := rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{err.Error()}}); err != nil {
+					log.With(task).Errorf("update queue status failed: %s", err)
+				}
+			}
+
+			// 无论如何，都要释放用户被冻结的智慧果
+			if payload.FreezedCoins > 0 {
+				if err := userSrv.UnfreezeUserQuota(ctx, payload.UserID, payload.FreezedCoins); err != nil {
+					log.F(log.M{"payload": payload}).Errorf("群聊辩论任务执行完毕，释放用户冻结的智慧果失败: %s", err)
+				}
+			}
+		}()
+
+		turns := make([]debateTurn, 0)
+		for round := 1; round <= payload.Rounds; round++ {
+			for _, member := range payload.Members {
+				messages := buildDebateMessages(payload, member, round, turns)
+				reply, ok := processGroupDebateMessage(ctx, conf, ct, rep, payload, member, repo2.MessageRoleAssistant, messages)
+				if ok {
+					turns = append(turns, debateTurn{Member: member, Content: reply})
+				}
+			}
+		}
+
+		// 由第一个成员对辩论进行总结
+		if len(turns) > 0 {
+			processGroupDebateMessage(ctx, conf, ct, rep, payload, payload.Members[0], repo2.GroupMessageRoleSummary, buildDebateSummaryMessages(payload, turns))
+		}
+
+		return rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusSuccess, EmptyResult{})
+	}
+}
+
+// processGroupDebateMessage 创建成员的发言消息并调用模型生成内容，失败时只标记当前消息失败，不中断整个辩论
+func processGroupDebateMessage(
+	ctx context.Context,
+	conf *config.Config,
+	ct chat.Chat,
+	rep *repo2.Repository,
+	payload GroupDebatePayload,
+	member GroupDebateMember,
+	role repo2.MessageRole,
+	messages chat.Messages,
+) (string, bool) {
+	messageID, err := rep.ChatGroup.AddChatMessage(ctx, payload.GroupID, payload.UserID, repo2.ChatGroupMessage{
+		Role:     int64(role),
+		Pid:      payload.QuestionID,
+		MemberId: member.ID,
+		Status:   repo2.MessageStatusWaiting,
+	})
+	if err != nil {
+		log.F(log.M{"payload": payload, "member_id": member.ID}).Errorf("add chat message failed: %s", err)
+		return "", false
+	}
+
+	failed := func(err error) (string, bool) {
+		log.F(log.M{"group_id": payload.GroupID, "member_id": member.ID, "model": member.ModelID}).Errorf("群聊辩论发言失败: %s", err)
+		if err := rep.ChatGroup.UpdateChatMessage(ctx, payload.GroupID, payload.UserID, messageID, repo2.ChatGroupMessageUpdate{
+			Message: err.Error(),
+			Status:  repo2.MessageStatusFailed,
+			Error:   err.Error(),
+		}); err != nil {
+			log.F(log.M{"message_id": messageID}).Errorf("update chat message failed: %s", err)
+		}
+
+		return "", false
+	}
+
+	req, _, err := (chat.Request{Model: member.ModelID, Messages: messages}).Init().Fix(ct, 5)
+	if err != nil {
+		return failed(fmt.Errorf("fix chat request failed: %w", err))
+	}
+
+	chatCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+
+	resp, err := ct.Chat(chatCtx, *req)
+	if err != nil {
+		return failed(fmt.Errorf("chat failed: %w", err))
+	}
+
+	if resp.ErrorCode != "" {
+		return failed(fmt.Errorf("chat failed: %s %s", resp.ErrorCode, resp.Error))
+	}
+
+	tokenConsumed := int64(resp.InputTokens + resp.OutputTokens)
+	if tokenConsumed == 0 {
+		realTokens, _ := chat.MessageTokenCount(append(req.Messages, chat.Message{Role: "assistant", Content: resp.Text}), req.Model)
+		tokenConsumed = int64(realTokens)
+	}
+
+	quotaConsumed := coins.GetOpenAITextCoins(req.ResolveCalFeeModel(conf), tokenConsumed)
+	if err := rep.ChatGroup.UpdateChatMessage(ctx, payload.GroupID, payload.UserID, messageID, repo2.ChatGroupMessageUpdate{
+		Message:       resp.Text,
+		TokenConsumed: tokenConsumed,
+		QuotaConsumed: quotaConsumed,
+		Status:        repo2.MessageStatusSucceed,
+	}); err != nil {
+		log.F(log.M{"message_id": messageID}).Errorf("update chat message failed: %s", err)
+	}
+
+	if quotaConsumed > 0 {
+		if err := rep.Quota.QuotaConsume(ctx, payload.UserID, quotaConsumed, repo2.NewQuotaUsedMeta("group_debate", req.Model)); err != nil {
+			log.Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	return resp.Text, true
+}
+
+// buildDebateTranscript 将之前的发言整理为辩论记录
+func buildDebateTranscript(turns []debateTurn) string {
+	if len(turns) == 0 {
+		return "(no one has spoken yet)"
+	}
+
+	return strings.Join(array.Map(turns, func(turn debateTurn, _ int) string {
+		return fmt.Sprintf("[%s]\n%s", turn.Member.ModelName, turn.Content)
+	}), "\n\n")
+}
+
+// buildDebateMessages 构建成员发言的上下文，其它成员之前的发言作为上下文提供给当前成员
+func buildDebateMessages(payload GroupDebatePayload, member GroupDebateMember, round int, turns []debateTurn) chat.Messages {
+	participants := strings.Join(array.Map(payload.Members, func(m GroupDebateMember, _ int) string { return m.ModelName }), ", ")
+	system := fmt.Sprintf(
+		"You are %s, taking part in a round-table debate with other AI participants (%s). "+
+			"This is round %d of %d. Respond directly to the points made by the other participants: agree, rebut or add new evidence, and give your reasons. "+
+			"Do not repeat what has already been said, keep your reply under 200 words, and reply in the same language as the topic.",
+		member.ModelName, participants, round, payload.Rounds,
+	)
+
+	return chat.Messages{
+		{Role: "system", Content: system},
+		{Role: "user", Content: fmt.Sprintf("Topic:\n%s\n\nDebate so far:\n%s\n\nIt is your turn, %s.", payload.Topic, buildDebateTranscript(turns), member.ModelName)},
+	}
+}
+
+// buildDebateSummaryMessages 构建辩论总结的上下文
+func buildDebateSummaryMessages(payload GroupDebatePayload, turns []debateTurn) chat.Messages {
+	return chat.Messages{
+		{Role: "system", Content: "You are the moderator of a debate. Summarize the debate objectively: list the main viewpoints of each participant, the points of consensus and disagreement, and give a balanced conclusion. Reply in the same language as the topic."},
+		{Role: "user", Content: fmt.Sprintf("Topic:\n%s\n\nDebate transcript:\n%s", payload.Topic, buildDebateTranscript(turns))},
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/leap"
 	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
 	"github.com/mylxsw/aidea-server/pkg/ai/stabilityai"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
+	"github.com/mylxsw/aidea-server/pkg/provenance"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/uploader"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
@@ -24,6 +26,8 @@ type ImageCompletionPayload struct {
 
 	Quota int64 `json:"quota,omitempty"`
 	UID   int64 `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID int64 `json:"org_id,omitempty"`
 
 	Prompt         string    `json:"prompt,omitempty"`
 	NegativePrompt string    `json:"negative_prompt,omitempty"`
@@ -48,6 +52,13 @@ type ImageCompletionPayload struct {
 	FilterName     string    `json:"filter_name,omitempty"`
 	GalleryCopyID  int64     `json:"gallery_copy_id,omitempty"`
 
+	// OriginalPrompt 使用提示语优化时，用户输入的原始提示语
+	OriginalPrompt string `json:"original_prompt,omitempty"`
+	// PromptEnhancementID 使用的提示语优化记录
+	PromptEnhancementID int64 `json:"prompt_enhancement_id,omitempty"`
+	// PresetID 使用的参数预设
+	PresetID int64 `json:"preset_id,omitempty"`
+
 	FreezedCoins int64 `json:"freezed_coins,omitempty"`
 }
 
@@ -106,6 +117,7 @@ func BuildImageCompletionHandler(
 	rep *repo2.Repository,
 	oai openai2.Client,
 	dalleClient *openai2.DalleImageClient,
+	zhipuClient *zhipu.Zhipu,
 ) TaskHandler {
 	return func(ctx context.Context, task *asynq.Task) (err error) {
 		var payload ImageCompletionPayload
@@ -113,12 +125,18 @@ func BuildImageCompletionHandler(
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		if payload.CreatedAt.Add(5 * time.Minute).Before(time.Now()) {
 			rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{"任务处理超时"}})
 			log.WithFields(log.Fields{"payload": payload}).Errorf("task expired")
 			return nil
 		}
 
+		// 生成的图片上传时嵌入 AI 生成内容水印
+		ctx = provenance.WithWatermark(ctx, provenance.New(payload.Model, payload.Vendor, nil, time.Now()).Watermark())
+
 		switch payload.Vendor {
 		case "leapai":
 			return BuildLeapAICompletionHandler(leapClient, translator, up, rep, oai)(ctx, task)
@@ -134,6 +152,8 @@ func BuildImageCompletionHandler(
 			return BuildDashscopeImageCompletionHandler(dashscopeClient, up, rep, translator, oai)(ctx, task)
 		case "dalle":
 			return BuildDalleCompletionHandler(dalleClient, up, rep)(ctx, task)
+		case "zhipu":
+			return BuildZhipuImageCompletionHandler(zhipuClient, up, rep)(ctx, task)
 		default:
 			return nil
 		}
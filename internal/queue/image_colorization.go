@@ -20,9 +20,11 @@ import (
 )
 
 type ImageColorizationPayload struct {
-	ID           string    `json:"id,omitempty"`
-	Image        string    `json:"image,omitempty"`
-	UserID       int64     `json:"user_id,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Image  string `json:"image,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID        int64     `json:"org_id,omitempty"`
 	Quota        int64     `json:"quota,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 	FreezedCoins int64     `json:"freezed_coins,omitempty"`
@@ -64,6 +66,9 @@ func BuildImageColorizationHandler(deepClient *deepai.DeepAI, up *uploader.Uploa
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		log.With(payload).Debugf("开始处理图片上色任务")
 
 		// 如果任务是 30 分钟前创建的，不再处理
@@ -102,6 +102,7 @@ func BuildImageDownloaderHandler(up *uploader.Uploader, rep *repo2.Repository) T
 			panic(fmt.Errorf("创作岛历史记录中没有图片: %s", payload.ID))
 		}
 
+		var uploaded int
 		for i, res := range resources {
 			ret, err := up.UploadRemoteFile(ctx, res, int(payload.UserID), uploader.DefaultUploadExpireAfterDays, "png", false)
 			if err != nil {
@@ -110,9 +111,14 @@ func BuildImageDownloaderHandler(up *uploader.Uploader, rep *repo2.Repository) T
 				}).Errorf("图片上传失败: %s", err)
 			} else {
 				resources[i] = ret
+				uploaded++
 			}
 		}
 
+		if err := rep.Queue.AddLog(ctx, payload.CreativeHistoryTaskID, repo2.QueueTaskStepUploaded, fmt.Sprintf("已保存 %d/%d 张图片", uploaded, len(resources))); err != nil {
+			log.With(payload).Errorf("记录任务日志失败: %v", err)
+		}
+
 		answer, _ := json.Marshal(resources)
 		if err := rep.Creative.UpdateRecordAnswerByTaskID(ctx, payload.UserID, payload.CreativeHistoryTaskID, string(answer)); err != nil {
 			log.WithFields(log.Fields{
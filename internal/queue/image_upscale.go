@@ -23,14 +23,16 @@ import (
 )
 
 type ImageUpscalePayload struct {
-	ID                string    `json:"id,omitempty"`
-	CreativeHistoryID int64     `json:"creative_history_id,omitempty"`
-	Image             string    `json:"image,omitempty"`
-	UserID            int64     `json:"user_id,omitempty"`
-	UpscaleBy         string    `json:"upscale_by,omitempty"`
-	Quota             int64     `json:"quota,omitempty"`
-	CreatedAt         time.Time `json:"created_at,omitempty"`
-	FreezedCoins      int64     `json:"freezed_coins,omitempty"`
+	ID                string `json:"id,omitempty"`
+	CreativeHistoryID int64  `json:"creative_history_id,omitempty"`
+	Image             string `json:"image,omitempty"`
+	UserID            int64  `json:"user_id,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID        int64     `json:"org_id,omitempty"`
+	UpscaleBy    string    `json:"upscale_by,omitempty"`
+	Quota        int64     `json:"quota,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	FreezedCoins int64     `json:"freezed_coins,omitempty"`
 }
 
 func (payload *ImageUpscalePayload) GetTitle() string {
@@ -69,6 +71,9 @@ func BuildImageUpscaleHandler(deepClient *deepai.DeepAI, client *stabilityai.Sta
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		log.With(payload).Debugf("开始处理图片超分辨率任务")
 
 		// 如果任务是 30 分钟前创建的，不再处理
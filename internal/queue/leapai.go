@@ -25,6 +25,8 @@ type LeapAICompletionPayload struct {
 	Model string `json:"model,omitempty"`
 	Quota int64  `json:"quota,omitempty"`
 	UID   int64  `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID int64 `json:"org_id,omitempty"`
 
 	Prompt         string   `json:"prompt,omitempty"`
 	PromptTags     []string `json:"prompt_tags,omitempty"`
@@ -65,6 +67,10 @@ func (payload *LeapAICompletionPayload) GetQuota() int64 {
 	return payload.Quota
 }
 
+func (payload *LeapAICompletionPayload) GetOrgID() int64 {
+	return payload.OrgID
+}
+
 func (payload *LeapAICompletionPayload) GetModel() string {
 	return payload.Model
 }
@@ -99,6 +105,10 @@ func (p LeapAIPendingTaskPayload) GetQuota() int64 {
 	return p.Payload.Quota
 }
 
+func (p LeapAIPendingTaskPayload) GetOrgID() int64 {
+	return p.Payload.OrgID
+}
+
 func (p LeapAIPendingTaskPayload) GetModel() string {
 	return p.Payload.Model
 }
@@ -119,6 +129,9 @@ func BuildLeapAICompletionHandler(client *leap.LeapAI, translator youdao.Transla
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		if payload.CreatedAt.Add(5 * time.Minute).Before(time.Now()) {
 			rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{"任务处理超时"}})
 			log.WithFields(log.Fields{"payload": payload}).Errorf("task expired")
@@ -349,6 +362,7 @@ func leapAsyncJobProcesser(client *leap.LeapAI, up *uploader2.Uploader, rep *rep
 type LeapTaskPayload interface {
 	GetID() string
 	GetUID() int64
+	GetOrgID() int64
 	GetQuota() int64
 	GetModel() string
 	GetImage() string
@@ -399,7 +413,7 @@ func handleLeapTask(
 	// 更新用户配额
 	modelUsed := []string{payload.GetModel(), "upload"}
 	if err := rep.Quota.QuotaConsume(
-		context.TODO(),
+		repo2.WithBillingOrg(context.TODO(), payload.GetOrgID()),
 		payload.GetUID(),
 		payload.GetQuota(),
 		repo2.NewQuotaUsedMeta("leapai", modelUsed...),
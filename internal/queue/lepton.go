@@ -18,10 +18,12 @@ import (
 )
 
 type ArtisticTextCompletionPayload struct {
-	ID             string    `json:"id,omitempty"`
-	ArtisticType   string    `json:"artistic_type,omitempty"`
-	Quota          int64     `json:"quota,omitempty"`
-	UID            int64     `json:"uid,omitempty"`
+	ID           string `json:"id,omitempty"`
+	ArtisticType string `json:"artistic_type,omitempty"`
+	Quota        int64  `json:"quota,omitempty"`
+	UID          int64  `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID          int64     `json:"org_id,omitempty"`
 	Prompt         string    `json:"prompt,omitempty"`
 	NegativePrompt string    `json:"negative_prompt,omitempty"`
 	CreatedAt      time.Time `json:"created_at,omitempty"`
@@ -75,6 +77,9 @@ func BuildArtisticTextCompletionHandler(client *lepton.Lepton, translator youdao
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		if err := rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusRunning, nil); err != nil {
 			log.WithFields(log.Fields{"payload": payload}).Errorf("set task status to running failed: %s", err)
 			return err
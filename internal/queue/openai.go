@@ -15,10 +15,12 @@ import (
 )
 
 type OpenAICompletionPayload struct {
-	ID           string                         `json:"id,omitempty"`
-	Model        string                         `json:"model,omitempty"`
-	Quota        int64                          `json:"quota,omitempty"`
-	UID          int64                          `json:"uid,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Model string `json:"model,omitempty"`
+	Quota int64  `json:"quota,omitempty"`
+	UID   int64  `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID        int64                          `json:"org_id,omitempty"`
 	Prompts      []openai.ChatCompletionMessage `json:"prompts,omitempty"`
 	WordCount    int64                          `json:"word_count,omitempty"`
 	CreatedAt    time.Time                      `json:"created_at,omitempty"`
@@ -62,6 +64,9 @@ func BuildOpenAICompletionHandler(client openai2.Client, rep *repo2.Repository)
 			return err
 		}
 
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
 		// 如果任务是 15 分钟前创建的，不再处理
 		if payload.CreatedAt.Add(15 * time.Minute).Before(time.Now()) {
 			return nil
@@ -3,6 +3,7 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/dingding"
 	"github.com/mylxsw/aidea-server/pkg/mail"
@@ -122,8 +123,52 @@ func BuildPaymentHandler(
 		}
 
 		expiredAt := product.ExpiredAt()
-		if _, err := rep.Quota.AddUserQuota(ctx, payload.UserID, product.Quota, expiredAt, payload.Note, payload.PaymentID); err != nil {
-			log.With(payload).Errorf("用户充值增加配额失败: %s", err)
+
+		// 为组织充值时，智慧果充值到组织的共享钱包
+		orgID, err := rep.Org.PaymentOrg(ctx, payload.PaymentID)
+		if err != nil && !errors.Is(err, repo2.ErrNotFound) {
+			log.With(payload).Errorf("查询支付订单关联的组织失败: %s", err)
+			return err
+		}
+
+		// 同一个支付订单只发放一次智慧果，重复投递的任务直接忽略，并通知管理员
+		if err := rep.Payment.GrantPayment(ctx, repo2.PaymentGrant{
+			UserID:    payload.UserID,
+			OrgID:     orgID,
+			PaymentID: payload.PaymentID,
+			Quota:     product.Quota,
+			EndAt:     expiredAt,
+			Note:      payload.Note,
+		}); err != nil {
+			if errors.Is(err, repo2.ErrPaymentHasBeenGranted) {
+				log.With(payload).Warningf("支付订单已经发放过智慧果，忽略重复的充值任务")
+
+				if err := rep.Event.UpdateEvent(ctx, payload.EventID, repo2.EventStatusFailed); err != nil {
+					log.WithFields(log.Fields{"event_id": payload.EventID}).Errorf("update event status failed: %s", err)
+				}
+
+				go func() {
+					content := fmt.Sprintf(
+						`用户（ID：%d）的充值订单 %s 已经发放过智慧果，已拦截重复的充值任务（事件 ID：%d），充值来源为 %s。`,
+						payload.UserID,
+						payload.PaymentID,
+						payload.EventID,
+						payload.Source,
+					)
+					if err := ding.Send(dingding.NewMarkdownMessage(payload.Env+": 拦截重复充值", content, []string{})); err != nil {
+						log.Errorf("发送钉钉通知失败: %s", err)
+					}
+				}()
+
+				return rep.Queue.Update(
+					context.TODO(),
+					payload.GetID(),
+					repo2.QueueTaskStatusFailed,
+					ErrorResult{Errors: []string{"支付订单已经发放过智慧果"}},
+				)
+			}
+
+			log.With(payload).Errorf("充值增加配额失败: %s", err)
 			return err
 		}
 
@@ -140,7 +185,7 @@ func BuildPaymentHandler(
 				CreatedAt: time.Now(),
 			}
 
-			if _, err := que.Enqueue(mailPayload, NewMailTask, asynq.Queue("mail")); err != nil {
+			if _, err := que.EnqueueContext(ctx, mailPayload, NewMailTask, asynq.Queue("mail")); err != nil {
 				log.With(mailPayload).Errorf("failed to enqueue mail task: %s", err)
 			}
 		}
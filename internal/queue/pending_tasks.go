@@ -86,6 +86,10 @@ func handlePendingTask(ctx context.Context, queueRepo *repo.QueueRepo, manager *
 			return err
 		}
 
+		if err := queueRepo.AddLog(ctx, task.TaskId, repo.QueueTaskStepTimeout, "等待服务提供商返回结果超时"); err != nil {
+			log.WithFields(log.Fields{"task_id": task.TaskId}).Errorf("记录任务日志失败: %v", err)
+		}
+
 		return nil
 	}
 
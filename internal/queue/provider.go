@@ -2,12 +2,14 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/ai/dashscope"
 	"github.com/mylxsw/aidea-server/pkg/ai/fromston"
 	"github.com/mylxsw/aidea-server/pkg/ai/leap"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/trace"
 	"github.com/mylxsw/aidea-server/pkg/uploader"
 	"time"
 
@@ -82,6 +84,7 @@ const (
 	TypeGetimgAICompletion       = "getimgai:completion"
 	TypeDalleCompletion          = "dalle:completion"
 	TypeDashscopeImageCompletion = "dashscope-image:completion"
+	TypeZhipuImageCompletion     = "zhipu-image:completion"
 	TypeMailSend                 = "mail:send"
 	TypeImageDownloader          = "image:downloader"
 	TypeImageUpscale             = "image:upscale"
@@ -92,6 +95,11 @@ const (
 	TypeBindPhone                = "bind_phone"
 	TypeGroupChat                = "group_chat"
 	TypeArtisticTextCompletion   = "artistic_text:completion"
+	TypeBatchChat                = "batch_chat"
+	TypeEssayGrading             = "essay_grading"
+	TypeGroupDebate              = "group_debate"
+	TypeChatImport               = "chat_import"
+	TypeAvatarPack               = "avatar_pack"
 )
 
 func ResolveTaskType(category, model string) string {
@@ -110,6 +118,8 @@ func ResolveTaskType(category, model string) string {
 		return TypeDashscopeImageCompletion
 	case "dalle":
 		return TypeDalleCompletion
+	case "zhipu":
+		return TypeZhipuImageCompletion
 	}
 
 	return ""
@@ -158,21 +168,81 @@ func NewQueue(client *asynq.Client, queueRepo *repo2.QueueRepo) *Queue {
 
 // Enqueue 将任务加入队列
 func (q *Queue) Enqueue(payload Payload, taskBuilder TaskBuilder, opts ...asynq.Option) (string, error) {
+	return q.EnqueueContext(context.TODO(), payload, taskBuilder, opts...)
+}
+
+// EnqueueContext 将任务加入队列，context 中的请求 ID 会传递给任务，任务执行过程中的日志可以与发起请求关联
+func (q *Queue) EnqueueContext(ctx context.Context, payload Payload, taskBuilder TaskBuilder, opts ...asynq.Option) (string, error) {
 	payload.SetID(must.Must(uuid.GenerateUUID()))
 
 	task := taskBuilder(payload)
+	if requestID := trace.RequestID(ctx); requestID != "" {
+		task = asynq.NewTask(task.Type(), InjectRequestID(task.Payload(), requestID))
+	}
+
 	info, err := q.client.Enqueue(task, opts...)
 	if err != nil {
 		return "", err
 	}
 
-	return payload.GetID(), q.queueRepo.Add(
-		context.TODO(),
+	if err := q.queueRepo.Add(
+		ctx,
 		payload.GetUID(),
 		payload.GetID(),
 		task.Type(),
 		info.Queue,
 		payload.GetTitle(),
 		task.Payload(),
-	)
+	); err != nil {
+		return payload.GetID(), err
+	}
+
+	if err := q.queueRepo.AddLog(ctx, payload.GetID(), repo2.QueueTaskStepQueued, "任务已加入队列"); err != nil {
+		log.F(log.M{"task_id": payload.GetID()}).Errorf("记录任务日志失败: %v", err)
+	}
+
+	return payload.GetID(), nil
+}
+
+// requestIDField 任务载荷中保存请求 ID 的字段，不与任何载荷的字段重名
+const requestIDField = "_request_id"
+
+// InjectRequestID 在 JSON 格式的任务载荷中写入请求 ID，载荷不是 JSON 对象时原样返回
+func InjectRequestID(payload []byte, requestID string) []byte {
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &data); err != nil || data == nil {
+		return payload
+	}
+
+	data[requestIDField], _ = json.Marshal(requestID)
+	res, err := json.Marshal(data)
+	if err != nil {
+		return payload
+	}
+
+	return res
+}
+
+// TaskMeta 任务载荷中的任务 ID 以及服务提供商信息，用于记录任务日志
+type TaskMeta struct {
+	ID     string `json:"id"`
+	Vendor string `json:"vendor"`
+	Model  string `json:"model"`
+}
+
+// ExtractTaskMeta 读取任务载荷中的任务 ID 以及服务提供商信息
+func ExtractTaskMeta(payload []byte) TaskMeta {
+	var meta TaskMeta
+	_ = json.Unmarshal(payload, &meta)
+	return meta
+}
+
+// ExtractRequestID 读取任务载荷中的请求 ID，不存在时返回空
+func ExtractRequestID(payload []byte) string {
+	var data struct {
+		RequestID string `json:"_request_id"`
+	}
+
+	_ = json.Unmarshal(payload, &data)
+	return data.RequestID
 }
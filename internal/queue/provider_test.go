@@ -0,0 +1,24 @@
+package queue_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestRequestIDInjection(t *testing.T) {
+	payload, _ := json.Marshal(queue.ChatImportPayload{ID: "task-1", ImportID: 3})
+
+	injected := queue.InjectRequestID(payload, "req-1")
+	assert.Equal(t, "req-1", queue.ExtractRequestID(injected))
+
+	var decoded queue.ChatImportPayload
+	assert.NoError(t, json.Unmarshal(injected, &decoded))
+	assert.Equal(t, "task-1", decoded.ID)
+	assert.EqualValues(t, 3, decoded.ImportID)
+
+	assert.Equal(t, "", queue.ExtractRequestID(payload))
+	assert.Equal(t, "not-json", string(queue.InjectRequestID([]byte("not-json"), "req-1")))
+}
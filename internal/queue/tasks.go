@@ -2,6 +2,7 @@ package queue
 
 import (
 	"context"
+	"github.com/mylxsw/aidea-server/config"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	"time"
 
@@ -22,6 +23,11 @@ func ClearExpiredTaskJob(ctx context.Context, queueRepo *repo2.QueueRepo) error
 		log.Errorf("清理过期的 QueueTasks 失败: %v", err)
 	}
 
+	// 清理过期的任务日志，失败的任务不会被清理，日志保留时间长一些方便客服排查
+	if err := queueRepo.RemoveLogs(ctx, time.Now().AddDate(0, 0, -30)); err != nil {
+		log.Errorf("清理过期的任务日志失败: %v", err)
+	}
+
 	return nil
 }
 
@@ -35,3 +41,47 @@ func ClearExpiredCacheJob(ctx context.Context, cacheRepo *repo2.CacheRepo) error
 
 	return nil
 }
+
+// PurgeDeletedGroupMessagesJob 物理删除超过保留期限的已删除群聊消息
+func PurgeDeletedGroupMessagesJob(ctx context.Context, conf *config.Config, groupRepo *repo2.ChatGroupRepo) error {
+	if conf.DeletedMessageRetention <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	purged, err := groupRepo.PurgeDeletedMessages(ctx, time.Now().Add(-conf.DeletedMessageRetention))
+	if err != nil {
+		log.Errorf("清理已删除的群聊消息失败: %v", err)
+		return err
+	}
+
+	if purged > 0 {
+		log.Infof("清理已删除的群聊消息 %d 条", purged)
+	}
+
+	return nil
+}
+
+// PurgeTrashedRoomsJob 物理删除回收站中超过保留期限的会话以及会话中的消息
+func PurgeTrashedRoomsJob(ctx context.Context, conf *config.Config, roomRepo *repo2.RoomRepo) error {
+	if conf.RoomTrashRetention <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	purged, err := roomRepo.PurgeTrashedRooms(ctx, time.Now().Add(-conf.RoomTrashRetention))
+	if err != nil {
+		log.Errorf("清理回收站中的会话失败: %v", err)
+		return err
+	}
+
+	if purged > 0 {
+		log.Infof("清理回收站中的会话 %d 个", purged)
+	}
+
+	return nil
+}
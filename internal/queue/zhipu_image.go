@@ -0,0 +1,183 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/asteria/log"
+)
+
+type ZhipuImageCompletionPayload struct {
+	ID    string `json:"id,omitempty"`
+	Model string `json:"model,omitempty"`
+	Quota int64  `json:"quota,omitempty"`
+	UID   int64  `json:"uid,omitempty"`
+	// OrgID 使用组织钱包计费时的组织 ID，为 0 时使用个人钱包
+	OrgID int64 `json:"org_id,omitempty"`
+
+	Prompt     string   `json:"prompt,omitempty"`
+	PromptTags []string `json:"prompt_tags,omitempty"`
+	ImageCount int64    `json:"image_count,omitempty"`
+	FilterID   int64    `json:"filter_id,omitempty"`
+
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	FreezedCoins int64     `json:"freezed_coins,omitempty"`
+}
+
+func (payload *ZhipuImageCompletionPayload) GetTitle() string {
+	return payload.Prompt
+}
+
+func (payload *ZhipuImageCompletionPayload) GetID() string {
+	return payload.ID
+}
+
+func (payload *ZhipuImageCompletionPayload) SetID(id string) {
+	payload.ID = id
+}
+
+func (payload *ZhipuImageCompletionPayload) GetUID() int64 {
+	return payload.UID
+}
+
+func (payload *ZhipuImageCompletionPayload) GetQuota() int64 {
+	return payload.Quota
+}
+
+func BuildZhipuImageCompletionHandler(client *zhipu.Zhipu, up *uploader.Uploader, rep *repo2.Repository) TaskHandler {
+	return func(ctx context.Context, task *asynq.Task) (err error) {
+		var payload ZhipuImageCompletionPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return err
+		}
+
+		// 任务执行过程中的扣费使用发起请求时的计费钱包
+		ctx = repo2.WithBillingOrg(ctx, payload.OrgID)
+
+		if payload.CreatedAt.Add(5 * time.Minute).Before(time.Now()) {
+			rep.Queue.Update(context.TODO(), payload.GetID(), repo2.QueueTaskStatusFailed, ErrorResult{Errors: []string{"任务处理超时"}})
+			log.WithFields(log.Fields{"payload": payload}).Errorf("task expired")
+			return nil
+		}
+
+		defer func() {
+			if err2 := recover(); err2 != nil {
+				log.With(task).Errorf("panic: %v", err2)
+				err = err2.(error)
+
+				// 更新创作岛历史记录
+				if err := rep.Creative.UpdateRecordByTaskID(ctx, payload.GetUID(), payload.GetID(), repo2.CreativeRecordUpdateRequest{
+					Status: repo2.CreativeStatusFailed,
+					Answer: err.Error(),
+				}); err != nil {
+					log.WithFields(log.Fields{"payload": payload}).Errorf("update creative failed: %s", err)
+				}
+			}
+
+			if err != nil {
+				if err := rep.Queue.Update(
+					context.TODO(),
+					payload.GetID(),
+					repo2.QueueTaskStatusFailed,
+					ErrorResult{
+						Errors: []string{err.Error()},
+					},
+				); err != nil {
+					log.With(task).Errorf("update queue status failed: %s", err)
+				}
+			}
+		}()
+
+		// CogView 支持中文提示语，不需要翻译
+		var prompt string
+		prompt, _, _ = resolvePrompts(
+			ctx,
+			PromptResolverPayload{
+				Prompt:     payload.Prompt,
+				PromptTags: payload.PromptTags,
+				FilterID:   payload.FilterID,
+				AIRewrite:  false,
+				Vendor:     "zhipu",
+				Model:      payload.Model,
+			},
+			rep.Creative,
+			nil, nil,
+		)
+
+		// CogView 每次请求只生成一张图片
+		resources := make([]string, 0, payload.ImageCount)
+		for i := int64(0); i < payload.ImageCount; i++ {
+			resp, err := client.CreateImage(ctx, zhipu.ImageRequest{Model: payload.Model, Prompt: prompt})
+			if err != nil {
+				log.With(payload).Errorf("[Zhipu] 图片生成失败: %v", err)
+				panic(err)
+			}
+
+			for _, item := range resp.Data {
+				res, err := up.UploadRemoteFile(ctx, item.URL, int(payload.GetUID()), uploader.DefaultUploadExpireAfterDays, "png", false)
+				if err != nil {
+					log.WithFields(log.Fields{"payload": payload}).Errorf("upload image failed: %s", err)
+					panic(err)
+				}
+
+				resources = append(resources, res)
+			}
+		}
+
+		if len(resources) == 0 {
+			log.WithFields(log.Fields{
+				"payload": payload,
+			}).Errorf("没有生成任何图片")
+			panic(errors.New("没有生成任何图片"))
+		}
+
+		modelUsed := []string{payload.Model, "upload"}
+
+		// 更新创作岛历史记录
+		retJson, err := json.Marshal(resources)
+		if err != nil {
+			log.WithFields(log.Fields{"payload": payload}).Errorf("update creative failed: %s", err)
+			panic(err)
+		}
+
+		updateReq := repo2.CreativeRecordUpdateRequest{
+			Status:    repo2.CreativeStatusSuccess,
+			Answer:    string(retJson),
+			QuotaUsed: payload.GetQuota(),
+		}
+
+		if prompt != payload.Prompt {
+			updateReq.ExtArguments = &repo2.CreativeRecordUpdateExtArgs{RealPrompt: prompt}
+		}
+
+		if err := rep.Creative.UpdateRecordByTaskID(ctx, payload.GetUID(), payload.GetID(), updateReq); err != nil {
+			log.WithFields(log.Fields{"payload": payload}).Errorf("update creative failed: %s", err)
+			return err
+		}
+
+		if err := rep.Quota.QuotaConsume(
+			ctx,
+			payload.GetUID(),
+			payload.GetQuota(),
+			repo2.NewQuotaUsedMeta("zhipu", modelUsed...),
+		); err != nil {
+			log.Errorf("used quota add failed: %s", err)
+		}
+
+		return rep.Queue.Update(
+			context.TODO(),
+			payload.GetID(),
+			repo2.QueueTaskStatusSuccess,
+			CompletionResult{
+				Resources:   resources,
+				ValidBefore: time.Now().Add(7 * 24 * time.Hour),
+			},
+		)
+	}
+}
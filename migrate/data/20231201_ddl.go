@@ -0,0 +1,20 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231201DDL(m *migrate.Manager) {
+	m.Schema("20231201-ddl").Create("user_api_key_usage", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Integer("api_key_id", false, true).Nullable(false).Comment("API Key ID")
+		builder.String("endpoint", 50).Nullable(false).Comment("接口名称")
+		builder.Date("stat_date").Nullable(false).Comment("统计日期")
+		builder.Integer("request_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("请求次数")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Unique("uk_api_key_endpoint_date", "api_key_id", "endpoint", "stat_date")
+		builder.Index("idx_user_date", "user_id", "stat_date")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
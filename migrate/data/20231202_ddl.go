@@ -0,0 +1,40 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231202DDL(m *migrate.Manager) {
+	m.Schema("20231202-ddl").Create("chat_batch", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("model", 100).Nullable(false).Comment("模型")
+		builder.String("task_id", 64).Nullable(true).Comment("队列任务 ID")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-等待中 2-处理中 3-已完成 4-失败 5-已取消")
+		builder.Integer("total", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("总条目数")
+		builder.Integer("succeed", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("成功条目数")
+		builder.Integer("failed", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("失败条目数")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamp("completed_at", 0).Nullable(true).Comment("完成时间")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231202-ddl").Create("chat_batch_item", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("batch_id", false, true).Nullable(false).Comment("批次 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("custom_id", 100).Nullable(true).Comment("调用方自定义 ID")
+		builder.Text("messages").Nullable(false).Comment("请求消息（JSON）")
+		builder.Text("output").Nullable(true).Comment("输出内容")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-等待中 2-处理中 3-已完成 4-失败 5-已取消")
+		builder.Integer("input_tokens", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("输入 Token")
+		builder.Integer("output_tokens", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("输出 Token")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.String("error", 255).Nullable(true).Comment("错误信息")
+		builder.Timestamps(0)
+		builder.Index("idx_batch_id", "batch_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,10 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231203DDL(m *migrate.Manager) {
+	m.Schema("20231203-ddl").Table("chat_messages", func(builder *migrate.Builder) {
+		builder.TinyInteger("rating", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("用户评价：0-未评价 1-赞 -1-踩")
+		builder.Index("idx_rating_created", "rating", "created_at")
+	})
+}
@@ -0,0 +1,32 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231204DDL(m *migrate.Manager) {
+	m.Schema("20231204-ddl").Create("vector_collection", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("name", 100).Nullable(false).Comment("集合名称")
+		builder.String("description", 255).Nullable(true).Comment("集合描述")
+		builder.String("model", 100).Nullable(false).Comment("Embedding 模型")
+		builder.Integer("dimension", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("向量维度")
+		builder.Integer("doc_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("文档数量")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_name", "user_id", "name")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231204-ddl").Create("vector_document", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("collection_id", false, true).Nullable(false).Comment("集合 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Text("content").Nullable(false).Comment("文档内容")
+		builder.Text("metadata").Nullable(true).Comment("文档元数据（JSON）")
+		builder.MediumText("vector").Nullable(false).Comment("向量（JSON）")
+		builder.Timestamps(0)
+		builder.Index("idx_collection_id", "collection_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,22 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231205DDL(m *migrate.Manager) {
+	m.Schema("20231205-ddl").Create("chat_messages_code_execution", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("message_id", false, true).Nullable(false).Comment("消息 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("language", 20).Nullable(false).Default(migrate.StringExpr("python")).Comment("代码语言")
+		builder.Text("code").Nullable(false).Comment("执行的代码")
+		builder.Text("stdout").Nullable(true).Comment("标准输出")
+		builder.Text("stderr").Nullable(true).Comment("错误输出")
+		builder.Integer("exit_code", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("退出码，-1 表示执行超时")
+		builder.Integer("duration_ms", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("执行耗时（毫秒）")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Index("idx_message_id", "message_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,21 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231206DDL(m *migrate.Manager) {
+	m.Schema("20231206-ddl").Create("ocr_result", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("engine", 20).Nullable(false).Comment("识别引擎")
+		builder.Integer("pages", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("页数")
+		builder.Text("images").Nullable(false).Comment("图片地址（JSON）")
+		builder.MediumText("text").Nullable(true).Comment("识别出的文本")
+		builder.MediumText("result").Nullable(true).Comment("逐页识别结果，包含每行的置信度（JSON）")
+		builder.Integer("confidence", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("平均置信度（百分比）")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,36 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231207DDL(m *migrate.Manager) {
+	m.Schema("20231207-ddl").Create("pdf_document", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("name", 255).Nullable(false).Comment("文档名称")
+		builder.String("url", 1024).Nullable(false).Comment("文档地址")
+		builder.String("model", 100).Nullable(false).Comment("向量化使用的模型")
+		builder.Integer("pages", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("页数")
+		builder.Integer("chunks", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("切分的片段数")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("向量化消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231207-ddl").Create("pdf_chunk", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("document_id", false, true).Nullable(false).Comment("文档 ID")
+		builder.Integer("page", false, true).Nullable(false).Comment("所在页码，从 1 开始")
+		builder.Text("content").Nullable(false).Comment("片段内容")
+		builder.MediumText("vector").Nullable(false).Comment("向量（JSON 数组）")
+		builder.Timestamps(0)
+		builder.Index("idx_document_id", "document_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231207-ddl").Table("chat_messages", func(builder *migrate.Builder) {
+		builder.Text("citations").Nullable(true).Comment("回答引用的来源（JSON），如 PDF 的页码")
+	})
+}
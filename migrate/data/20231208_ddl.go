@@ -0,0 +1,19 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231208DDL(m *migrate.Manager) {
+	m.Schema("20231208-ddl").Create("table_file", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("name", 255).Nullable(false).Comment("文件名称")
+		builder.String("url", 1024).Nullable(false).Comment("文件地址")
+		builder.Integer("row_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("行数")
+		builder.Text("profile").Nullable(false).Comment("列统计信息（JSON）")
+		builder.MediumText("data").Nullable(false).Comment("表格数据（JSON）")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,39 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231209DDL(m *migrate.Manager) {
+	m.Schema("20231209-ddl").Create("prompt_template", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("scene", 50).Nullable(false).Comment("使用场景，如 resume")
+		builder.String("name", 100).Nullable(false).Comment("模板名称")
+		builder.String("description", 255).Nullable(true).Comment("模板描述")
+		builder.Text("system_prompt").Nullable(false).Comment("系统提示语")
+		builder.Text("user_prompt").Nullable(false).Comment("用户提示语，支持 {{变量}} 占位符")
+		builder.Integer("sort", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("排序，越小越靠前")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 0-禁用")
+		builder.Timestamps(0)
+		builder.Index("idx_scene_status", "scene", "status")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231209-ddl").Create("resume_polish", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Integer("thread_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("同一份简历的迭代线程 ID，为第一个版本的 ID")
+		builder.Integer("version", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("版本号")
+		builder.Integer("template_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("使用的模板 ID")
+		builder.String("model", 100).Nullable(false).Comment("使用的模型")
+		builder.MediumText("resume").Nullable(false).Comment("简历原文")
+		builder.Text("job_description").Nullable(true).Comment("目标岗位描述")
+		builder.Text("feedback").Nullable(true).Comment("用户对上一个版本的修改意见")
+		builder.Text("bullet_points").Nullable(true).Comment("优化后的经历要点（JSON）")
+		builder.Text("cover_letter").Nullable(true).Comment("求职信")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Index("idx_user_thread", "user_id", "thread_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,34 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231210DDL(m *migrate.Manager) {
+	m.Schema("20231210-ddl").Create("role_play_session", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("title", 255).Nullable(false).Comment("标题")
+		builder.String("model", 100).Nullable(false).Comment("使用的模型")
+		builder.Text("setting").Nullable(false).Comment("故事设定")
+		builder.Text("state").Nullable(true).Comment("当前状态（JSON）")
+		builder.Integer("turns", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("对话轮数")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("累计消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231210-ddl").Create("role_play_message", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("session_id", false, true).Nullable(false).Comment("会话 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("role", 20).Nullable(false).Comment("消息角色：user/assistant")
+		builder.Text("content").Nullable(false).Comment("消息内容")
+		builder.Text("state").Nullable(true).Comment("本轮对话后的状态快照（JSON），仅 assistant 消息有值")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Index("idx_session_id", "session_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
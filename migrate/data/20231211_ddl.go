@@ -0,0 +1,11 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231211DDL(m *migrate.Manager) {
+	m.Schema("20231211-ddl").Table("chat_group_message", func(builder *migrate.Builder) {
+		builder.Integer("score", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("评审模型给出的得分（0-10）")
+		builder.TinyInteger("best", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("最佳回答：0-否 1-评审模型选择 2-用户投票")
+		builder.TinyInteger("judge_status", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("评审状态（仅用户问题）：0-未评审 1-评审中 2-已评审 3-评审失败")
+	})
+}
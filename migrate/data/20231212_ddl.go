@@ -0,0 +1,18 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231212DDL(m *migrate.Manager) {
+	m.Schema("20231212-ddl").Create("room_topic", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Integer("room_id", false, true).Nullable(false).Comment("房间 ID（群聊为群组 ID）")
+		builder.TinyInteger("room_type", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("房间类型")
+		builder.String("topic", 30).Nullable(false).Comment("话题")
+		builder.Timestamps(0)
+		builder.Index("idx_user_topic", "user_id", "topic")
+		builder.Unique("uk_room_topic", "room_id", "topic")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
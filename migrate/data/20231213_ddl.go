@@ -0,0 +1,20 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231213DDL(m *migrate.Manager) {
+	m.Schema("20231213-ddl").Create("user_stats", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Date("stat_date").Nullable(false).Comment("统计日期")
+		builder.Integer("room_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("房间 ID（群聊为群组 ID）")
+		builder.String("model", 100).Nullable(false).Default(migrate.StringExpr("")).Comment("模型")
+		builder.Integer("messages", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("用户发送的消息数量")
+		builder.Integer("tokens", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的 Token 数量")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_date_room_model", "user_id", "stat_date", "room_id", "model")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,27 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231214DDL(m *migrate.Manager) {
+	m.Schema("20231214-ddl").Create("user_achievement", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("achievement", 50).Nullable(false).Comment("成就标识")
+		builder.Integer("reward", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("奖励的智慧果")
+		builder.Integer("points", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("成就积分")
+		builder.TinyInteger("notified", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("是否已通知用户：0-否 1-是")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_achievement", "user_id", "achievement")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231214-ddl").Create("achievement_leaderboard", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("参与排行榜的用户 ID")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,28 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231215DDL(m *migrate.Manager) {
+	m.Schema("20231215-ddl").Create("check_in_reward", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("streak_day", false, true).Nullable(false).Comment("连续签到天数，超过最大天数时使用最大天数的奖励")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("奖励的智慧果")
+		builder.Timestamps(0)
+		builder.Unique("uk_streak_day", "streak_day")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231215-ddl").Create("user_check_in", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Date("check_in_date").Nullable(false).Comment("签到日期")
+		builder.Integer("streak", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("连续签到天数")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("奖励的智慧果")
+		builder.Integer("quota_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("对应的配额记录 ID")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_date", "user_id", "check_in_date")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,51 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231216DDL(m *migrate.Manager) {
+	m.Schema("20231216-ddl").Create("promo_event", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 100).Nullable(false).Comment("活动名称")
+		builder.String("description", 255).Nullable(true).Comment("活动描述")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.DateTime("start_at", 0).Nullable(false).Comment("活动开始时间")
+		builder.DateTime("end_at", 0).Nullable(false).Comment("活动结束时间")
+		builder.Integer("daily_limit", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("每个用户每天最多抽奖次数，0 表示不限制")
+		builder.Integer("total_limit", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("每个用户活动期间最多抽奖次数，0 表示不限制")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231216-ddl").Create("promo_prize", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("event_id", false, true).Nullable(false).Comment("活动 ID")
+		builder.String("name", 100).Nullable(false).Comment("奖品名称")
+		builder.TinyInteger("prize_type", false, true).Nullable(false).Comment("奖品类型：1-智慧果 2-兑换券")
+		builder.Integer("value", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("奖品价值（智慧果数量）")
+		builder.Integer("probability", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("中奖概率（万分比）")
+		builder.Integer("stock", false, false).Nullable(false).Default(migrate.RawExpr("-1")).Comment("库存，-1 表示不限制")
+		builder.Integer("issued", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("已发放数量")
+		builder.Timestamps(0)
+		builder.Index("idx_event_id", "event_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231216-ddl").Create("promo_draw", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("event_id", false, true).Nullable(false).Comment("活动 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Integer("prize_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("奖品 ID，0 表示未中奖")
+		builder.TinyInteger("prize_type", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("奖品类型：0-未中奖 1-智慧果 2-兑换券")
+		builder.Integer("value", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("奖品价值")
+		builder.String("coupon_code", 32).Nullable(true).Comment("兑换券码")
+		builder.Integer("quota_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("发放智慧果对应的配额记录 ID")
+		builder.Timestamp("redeemed_at", 0).Nullable(true).Comment("兑换券兑换时间")
+		builder.Timestamps(0)
+		builder.Index("idx_event_user", "event_id", "user_id")
+		builder.Unique("uk_coupon_code", "coupon_code")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,71 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231217DDL(m *migrate.Manager) {
+	m.Schema("20231217-ddl").Create("org", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 100).Nullable(false).Comment("组织名称")
+		builder.Integer("owner_id", false, true).Nullable(false).Comment("组织所有者用户 ID")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-正常 2-禁用")
+		builder.Timestamps(0)
+		builder.Index("idx_owner_id", "owner_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231217-ddl").Create("org_member", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false).Comment("组织 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("成员用户 ID")
+		builder.TinyInteger("role", false, true).Nullable(false).Default(migrate.RawExpr("2")).Comment("角色：1-所有者 2-成员")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-已邀请 2-已加入")
+		builder.Integer("monthly_limit", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("每月可使用组织智慧果上限，0 表示不限制")
+		builder.Integer("invited_by", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("邀请人用户 ID")
+		builder.Timestamps(0)
+		builder.Unique("uk_org_user", "org_id", "user_id")
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231217-ddl").Create("org_quota", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false).Comment("组织 ID")
+		builder.Integer("quota", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("配额")
+		builder.Integer("rest", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("剩余配额")
+		builder.String("note", 255).Nullable(true).Comment("备注")
+		builder.String("payment_id", 255).Nullable(true).Comment("支付 ID")
+		builder.DateTime("period_start_at", 0).Nullable(false).Comment("有效期开始时间")
+		builder.DateTime("period_end_at", 0).Nullable(false).Comment("有效期结束时间")
+		builder.Timestamps(0)
+		builder.Index("idx_org_id", "org_id", "period_end_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231217-ddl").Create("org_quota_usage", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false).Comment("组织 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("使用者用户 ID")
+		builder.Integer("used", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("使用的智慧果")
+		builder.Integer("debt", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("组织配额不足时的欠费")
+		builder.String("quota_ids", 255).Nullable(true).Comment("关联的组织配额 ID 及使用量")
+		builder.String("meta", 255).Nullable(true).Comment("使用场景")
+		builder.Timestamps(0)
+		builder.Index("idx_org_user", "org_id", "user_id", "created_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231217-ddl").Create("org_payment", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("payment_id", 255).Nullable(false).Comment("支付 ID")
+		builder.Integer("org_id", false, true).Nullable(false).Comment("组织 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("付款用户 ID")
+		builder.Timestamps(0)
+		builder.Unique("uk_payment_id", "payment_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
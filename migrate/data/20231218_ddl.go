@@ -0,0 +1,29 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231218DDL(m *migrate.Manager) {
+	m.Schema("20231218-ddl").Create("org_policy", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false).Comment("组织 ID")
+		builder.Text("allowed_models").Nullable(true).Comment("允许成员使用的模型列表（JSON），为空表示不限制")
+		builder.Text("blocked_words").Nullable(true).Comment("组织自定义的敏感词列表（JSON）")
+		builder.TinyInteger("strict_safety", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("是否启用严格的内容安全策略：0-否 1-是")
+		builder.Timestamps(0)
+		builder.Unique("uk_org_id", "org_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231218-ddl").Create("org_audit_log", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false).Comment("组织 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("操作用户 ID")
+		builder.String("action", 50).Nullable(false).Comment("操作类型")
+		builder.String("detail", 1024).Nullable(true).Comment("操作详情")
+		builder.Timestamps(0)
+		builder.Index("idx_org_id", "org_id", "created_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,16 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231219DDL(m *migrate.Manager) {
+	m.Schema("20231219-ddl").Create("user_restricted_mode", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("pin", 255).Nullable(false).Comment("受限模式 PIN（加密存储）")
+		builder.Integer("daily_limit", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("每日智慧果使用上限")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
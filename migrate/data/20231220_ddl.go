@@ -0,0 +1,35 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231220DDL(m *migrate.Manager) {
+	m.Schema("20231220-ddl").Create("keyword_filter", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("keyword", 255).Nullable(false).Comment("关键词或正则表达式")
+		builder.TinyInteger("is_regex", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("是否为正则表达式：0-否 1-是")
+		builder.TinyInteger("action", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("命中后的动作：1-拦截 2-打码 3-标记待审核")
+		builder.TinyInteger("scope", false, true).Nullable(false).Default(migrate.RawExpr("3")).Comment("生效范围：1-用户输入 2-模型输出 3-全部")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.Integer("hit_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("命中次数")
+		builder.Timestamp("last_hit_at", 0).Nullable(true).Comment("最后命中时间")
+		builder.String("note", 255).Nullable(true).Comment("备注")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20231220-ddl").Create("keyword_filter_hit", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("filter_id", false, true).Nullable(false).Comment("关键词规则 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.TinyInteger("action", false, true).Nullable(false).Comment("命中后的动作：1-拦截 2-打码 3-标记待审核")
+		builder.TinyInteger("scope", false, true).Nullable(false).Comment("命中范围：1-用户输入 2-模型输出")
+		builder.Text("content").Nullable(true).Comment("命中的内容")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("审核状态：1-待审核 2-已审核")
+		builder.Timestamps(0)
+		builder.Index("idx_filter_id", "filter_id", "created_at")
+		builder.Index("idx_status", "status", "created_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
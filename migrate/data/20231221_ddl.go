@@ -0,0 +1,19 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231221DDL(m *migrate.Manager) {
+	m.Schema("20231221-ddl").Create("abuse_flag", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("signals", 255).Nullable(false).Comment("命中的异常行为类型，多个使用逗号分隔")
+		builder.Text("evidence").Nullable(true).Comment("异常行为证据（JSON）")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("审核状态：1-待审核 2-确认异常 3-误报")
+		builder.String("note", 255).Nullable(true).Comment("审核备注")
+		builder.Timestamps(0)
+		builder.Index("idx_status", "status", "created_at")
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
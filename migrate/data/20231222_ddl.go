@@ -0,0 +1,17 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231222DDL(m *migrate.Manager) {
+	m.Schema("20231222-ddl").Create("geo_policy", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("region", 16).Nullable(false).Comment("地区（ISO 3166-1 国家代码），* 表示其它所有地区")
+		builder.Text("blocked_features").Nullable(true).Comment("禁止使用的功能列表（JSON）")
+		builder.Text("model_routes").Nullable(true).Comment("模型路由（JSON），将请求的模型替换为该地区合规的模型")
+		builder.String("note", 255).Nullable(true).Comment("备注")
+		builder.Timestamps(0)
+		builder.Unique("uk_region", "region")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
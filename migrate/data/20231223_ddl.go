@@ -0,0 +1,18 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231223DDL(m *migrate.Manager) {
+	m.Schema("20231223-ddl").Create("chat_group_composition", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 100).Nullable(false).Comment("组合名称")
+		builder.String("description", 255).Nullable(true).Comment("组合描述")
+		builder.String("avatar_url", 255).Nullable(true).Comment("群组头像")
+		builder.Text("members").Nullable(false).Comment("群组成员（JSON）")
+		builder.Integer("sort", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("排序，值越大越靠前")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,17 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231224DML(m *migrate.Manager) {
+	m.Schema("20231224-dml").Raw("image_model", func() []string {
+		return []string{
+			"INSERT INTO `image_model` (`model_id`, `model_name`, `vendor`, `real_model`, `meta`, `preview_image`, `description`, `status`, `created_at`, `updated_at`, `star_level`) VALUES ('zhipu:cogview-3','CogView-3','zhipu','cogview-3','{\\\"ratio_dimensions\\\": {\\\"1:1\\\": {\\\"width\\\": 1024, \\\"height\\\": 1024}}}',NULL,'智谱 AI 文生图模型，支持中文提示语',1,'2023-12-24 10:00:00','2023-12-24 10:00:00',NULL);",
+		}
+	})
+
+	m.Schema("20231224-dml").Raw("image_filter", func() []string {
+		return []string{
+			"INSERT INTO `image_filter` (`name`, `model_id`, `meta`, `preview_image`, `description`, `status`, `created_at`, `updated_at`, `star`) VALUES ('CogView-3','zhipu:cogview-3','{\\\"supports\\\": [\\\"text-to-image\\\"]}','https://ssl.aicode.cc/ai-server/assets/filters/cogview-3.png-square_500',NULL,1,'2023-12-24 10:00:00','2023-12-24 10:00:00',5);",
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231225DDL(m *migrate.Manager) {
+	m.Schema("20231225-ddl").Create("channel", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 100).Nullable(false).Comment("渠道名称")
+		builder.String("type", 20).Nullable(false).Default(migrate.StringExpr("openai")).Comment("渠道类型，目前只支持 openai（OpenAI 兼容接口）")
+		builder.String("server", 255).Nullable(false).Comment("接口地址")
+		builder.String("secret", 255).Nullable(true).Comment("接口密钥")
+		builder.Text("models").Nullable(false).Comment("渠道提供的模型（JSON），包括模型映射和价格")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,18 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231226DDL(m *migrate.Manager) {
+	m.Schema("20231226-ddl").Create("routing_rule", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 100).Nullable(false).Comment("规则名称")
+		builder.Integer("priority", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("优先级，值越大越先匹配")
+		builder.Text("conditions").Nullable(false).Comment("匹配条件（JSON），所有条件同时满足时规则生效")
+		builder.String("target_model", 100).Nullable(false).Comment("规则生效时实际使用的模型")
+		builder.String("note", 255).Nullable(true).Comment("备注")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,22 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231227DDL(m *migrate.Manager) {
+	m.Schema("20231227-ddl").Create("model_cost_stats", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Date("stat_date").Nullable(false).Comment("统计日期")
+		builder.String("model", 100).Nullable(false).Comment("模型")
+		builder.String("provider", 50).Nullable(false).Comment("服务商")
+		builder.Integer("request_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("请求次数")
+		builder.BigInteger("input_tokens", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("输入 Token 数量")
+		builder.BigInteger("output_tokens", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("输出 Token 数量")
+		builder.Decimal("cost", 16, 6).Nullable(false).Default(migrate.RawExpr("0")).Comment("按照服务商价格计算的上游成本（元）")
+		builder.Integer("unpriced_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("没有配置服务商价格的请求次数")
+		builder.BigInteger("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("向用户收取的智慧果")
+		builder.Timestamps(0)
+		builder.Unique("uk_date_model_provider", "stat_date", "model", "provider")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,9 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231228DDL(m *migrate.Manager) {
+	m.Schema("20231228-ddl").Table("channel", func(builder *migrate.Builder) {
+		builder.Timestamp("verified_at", 0).Nullable(true).Comment("通过测试验证的时间，未验证的渠道不参与模型路由")
+	})
+}
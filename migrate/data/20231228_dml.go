@@ -0,0 +1,12 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231228DML(m *migrate.Manager) {
+	// 已经在使用的渠道视为已验证，避免升级后被移出路由
+	m.Schema("20231228-dml").Raw("channel", func() []string {
+		return []string{
+			"UPDATE `channel` SET `verified_at` = NOW() WHERE `verified_at` IS NULL;",
+		}
+	})
+}
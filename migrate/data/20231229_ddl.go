@@ -0,0 +1,9 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231229DDL(m *migrate.Manager) {
+	m.Schema("20231229-ddl").Table("chat_messages", func(builder *migrate.Builder) {
+		builder.Text("structured").Nullable(true).Comment("结构化输出时解析并校验后的 JSON")
+	})
+}
@@ -0,0 +1,16 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231230DDL(m *migrate.Manager) {
+	m.Schema("20231230-ddl").Create("user_prompt_variable", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("name", 64).Nullable(false).Comment("变量名称，在提示语中使用 {{name}} 引用")
+		builder.Text("value").Nullable(true).Comment("变量的值")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_name", "user_id", "name")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,21 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20231231DDL(m *migrate.Manager) {
+	m.Schema("20231231-ddl").Create("system_notice", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("type", 20).Nullable(false).Comment("类型：banner-公告横幅，maintenance-维护窗口")
+		builder.String("level", 20).Nullable(false).Default(migrate.StringExpr("info")).Comment("级别：info/warning/error")
+		builder.String("title", 255).Nullable(true).Comment("标题")
+		builder.Text("content").Nullable(true).Comment("内容，支持 Markdown")
+		builder.Text("gated_features").Nullable(true).Comment("维护期间暂停使用的功能（JSON 数组）")
+		builder.DateTime("start_at", 0).Nullable(false).Comment("开始时间")
+		builder.DateTime("end_at", 0).Nullable(false).Comment("结束时间")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用，2-禁用")
+		builder.Timestamps(0)
+		builder.Index("idx_end_at", "end_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
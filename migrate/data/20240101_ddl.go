@@ -0,0 +1,19 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240101DDL(m *migrate.Manager) {
+	m.Schema("20240101-ddl").Create("client_version_policy", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("platform", 32).Nullable(false).Comment("客户端平台：ios/android/macos/windows/linux/web，* 表示其它所有平台")
+		builder.String("min_version", 32).Nullable(true).Comment("最低支持版本，低于该版本的客户端必须升级")
+		builder.String("recommended_version", 32).Nullable(true).Comment("推荐版本，低于该版本的客户端提示升级")
+		builder.Text("blocked_versions").Nullable(true).Comment("禁止使用的版本列表（JSON 数组）")
+		builder.String("upgrade_url", 255).Nullable(true).Comment("升级地址")
+		builder.String("message", 255).Nullable(true).Comment("升级提示")
+		builder.Timestamps(0)
+		builder.Unique("uk_platform", "platform")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,22 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240102DDL(m *migrate.Manager) {
+	m.Schema("20240102-ddl").Create("quota_refund", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.Integer("org_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("计费组织 ID，从个人钱包扣费时为 0")
+		builder.String("model", 100).Nullable(false).Comment("模型")
+		builder.String("provider", 50).Nullable(false).Comment("服务商")
+		builder.Integer("charged", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("本次请求扣除的智慧果")
+		builder.Integer("refunded", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("退还的智慧果")
+		builder.Integer("quota_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("退款对应的配额记录（quota/org_quota）")
+		builder.String("reason", 255).Nullable(true).Comment("上游失败原因")
+		builder.Timestamps(0)
+		builder.Index("idx_created_at_provider", "created_at", "provider")
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,41 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240103DDL(m *migrate.Manager) {
+	m.Schema("20240103-ddl").Create("chat_import", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("source", 20).Nullable(false).Comment("来源：chatgpt")
+		builder.String("task_id", 64).Nullable(true).Comment("队列任务 ID")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-等待中 2-处理中 3-已完成 4-失败")
+		builder.Integer("total", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("会话总数")
+		builder.Integer("imported", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("导入成功的会话数")
+		builder.Integer("skipped", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("已导入过且没有新消息而跳过的会话数")
+		builder.Integer("failed", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("导入失败的会话数")
+		builder.Timestamp("completed_at", 0).Nullable(true).Comment("完成时间")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20240103-ddl").Create("chat_import_item", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("import_id", false, true).Nullable(false).Comment("导入任务 ID")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("source", 20).Nullable(false).Comment("来源：chatgpt")
+		builder.String("external_id", 100).Nullable(false).Comment("会话在来源平台中的 ID")
+		builder.String("title", 255).Nullable(true).Comment("会话标题")
+		builder.MediumText("conversation").Nullable(false).Comment("会话内容（JSON）")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-等待中 3-已导入 4-失败 5-已跳过")
+		builder.Integer("room_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("导入后的聊天室 ID")
+		builder.Integer("message_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("聊天室中已导入的消息数")
+		builder.String("error", 255).Nullable(true).Comment("错误信息")
+		builder.Timestamps(0)
+		builder.Index("idx_import_id", "import_id")
+		builder.Index("idx_user_external_id", "user_id", "source", "external_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,15 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240104DDL(m *migrate.Manager) {
+	m.Schema("20240104-ddl").Create("disabled_model", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("model_id", 100).Nullable(false).Comment("模型 ID")
+		builder.String("reason", 255).Nullable(true).Comment("禁用原因")
+		builder.Timestamps(0)
+		builder.Unique("uk_model_id", "model_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,22 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240105DDL(m *migrate.Manager) {
+	m.Schema("20240105-ddl").Create("perf_stat", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("kind", 20).Nullable(false).Comment("统计类型：query-数据库查询 endpoint-接口")
+		builder.String("name", 1000).Nullable(false).Comment("SQL 或接口")
+		builder.String("name_hash", 40).Nullable(false).Comment("SQL 或接口的 SHA1，用于唯一索引")
+		builder.Date("stat_date").Nullable(false).Comment("统计日期")
+		builder.Integer("count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("请求次数")
+		builder.BigInteger("total_elapse", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("总耗时（毫秒）")
+		builder.Integer("max_elapse", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("最大耗时（毫秒）")
+		builder.Integer("slow_count", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("超过阈值的次数")
+		builder.Timestamps(0)
+		builder.Unique("uk_kind_name_date", "kind", "name_hash", "stat_date")
+		builder.Index("idx_date", "stat_date")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
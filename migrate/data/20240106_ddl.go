@@ -0,0 +1,17 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240106DDL(m *migrate.Manager) {
+	// 消息列表按 group_id + user_id 过滤后按 id 倒序分页，原 (group_id, user_id) 索引被新索引覆盖
+	m.Schema("20240106-ddl").Table("chat_group_message", func(builder *migrate.Builder) {
+		builder.Index("idx_group_user_id", "group_id", "user_id", "id")
+		builder.DropIndex("chat_group_message_group_user_idx")
+	})
+
+	// 成员查询按 group_id + user_id + status 过滤，原 (group_id, user_id) 索引被新索引覆盖
+	m.Schema("20240106-ddl").Table("chat_group_member", func(builder *migrate.Builder) {
+		builder.Index("idx_group_user_status", "group_id", "user_id", "status")
+		builder.DropIndex("chat_group_member_group_user_idx")
+	})
+}
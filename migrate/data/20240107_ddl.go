@@ -0,0 +1,10 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240107DDL(m *migrate.Manager) {
+	m.Schema("20240107-ddl").Table("chat_group_message", func(builder *migrate.Builder) {
+		builder.Timestamp("deleted_at", 0).Nullable(true).Comment("删除时间，超过保留期限后物理删除")
+		builder.Index("idx_deleted_at", "deleted_at")
+	})
+}
@@ -0,0 +1,14 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240108DDL(m *migrate.Manager) {
+	// 加密后的消息内容长度约为明文的 4/3，TEXT 无法保存接近长度上限的消息
+	m.Schema("20240108-ddl").Table("chat_messages", func(builder *migrate.Builder) {
+		builder.MediumText("message").Nullable(true).Comment("消息内容").Change()
+	})
+
+	m.Schema("20240108-ddl").Table("chat_group_message", func(builder *migrate.Builder) {
+		builder.MediumText("message").Nullable(true).Comment("消息内容").Change()
+	})
+}
@@ -0,0 +1,25 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240109DDL(m *migrate.Manager) {
+	m.Schema("20240109-ddl").Table("rooms", func(builder *migrate.Builder) {
+		builder.TinyInteger("incognito", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("无痕模式：0-否 1-是，无痕模式下聊天消息不写入历史记录")
+	})
+
+	m.Schema("20240109-ddl").Create("incognito_usage", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.Date("stat_date").Nullable(false).Comment("统计日期")
+		builder.Integer("room_id", false, true).Nullable(false)
+		builder.String("model", 100).Nullable(false).Default(migrate.StringExpr("")).Comment("模型")
+		builder.Integer("messages", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("提问次数")
+		builder.Integer("tokens", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的 Token")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_date_room_model", "user_id", "stat_date", "room_id", "model")
+		builder.Index("idx_date", "stat_date")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,19 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240110DDL(m *migrate.Manager) {
+	m.Schema("20240110-ddl").Create("user_identity", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("provider", 20).Nullable(false).Comment("身份源：ldap/oidc")
+		builder.String("subject", 255).Nullable(false).Comment("用户在身份源中的唯一标识，LDAP 为用户 DN，OIDC 为 sub")
+		builder.String("email", 255).Nullable(true).Comment("身份源中的用户邮箱")
+		builder.Timestamp("last_login_at", 0).Nullable(true).Comment("最后登录时间")
+		builder.Timestamps(0)
+		builder.Unique("uk_provider_subject", "provider", "subject")
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,20 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240111DDL(m *migrate.Manager) {
+	m.Schema("20240111-ddl").Create("org_provision_token", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false)
+		builder.String("name", 100).Nullable(true).Comment("Token 名称，例如对接的 HR/IdP 系统")
+		builder.String("token", 255).Nullable(false).Comment("成员同步接口的访问 Token")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.Integer("created_by", false, true).Nullable(false)
+		builder.Timestamp("last_used_at", 0).Nullable(true).Comment("最后使用时间")
+		builder.Timestamps(0)
+		builder.Unique("uk_token", "token")
+		builder.Index("idx_org_id", "org_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,33 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240112DDL(m *migrate.Manager) {
+	m.Schema("20240112-ddl").Create("org_usage_daily", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false)
+		builder.Date("stat_date").Nullable(false).Comment("统计日期")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("model", 100).Nullable(false).Default(migrate.StringExpr("")).Comment("模型")
+		builder.Integer("requests", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("请求次数")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("消耗的智慧果")
+		builder.Integer("debt", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("组织钱包余额不足时产生的欠费")
+		builder.Timestamps(0)
+		builder.Unique("uk_org_date_user_model", "org_id", "stat_date", "user_id", "model")
+		builder.Index("idx_stat_date", "stat_date")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20240112-ddl").Create("org_usage_subscription", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false)
+		builder.Text("emails").Nullable(true).Comment("接收使用报告的邮箱，多个邮箱使用英文逗号分隔")
+		builder.String("frequency", 10).Nullable(false).Comment("发送周期：weekly/monthly")
+		builder.Timestamp("last_sent_at", 0).Nullable(true).Comment("最后发送时间")
+		builder.Timestamps(0)
+		builder.Unique("uk_org_id", "org_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,35 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240113DDL(m *migrate.Manager) {
+	m.Schema("20240113-ddl").Create("image_prompt_style", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 50).Nullable(false).Comment("风格名称")
+		builder.String("description", 255).Nullable(true).Comment("风格描述")
+		builder.Text("instruction").Nullable(true).Comment("提供给模型的风格要求")
+		builder.String("preview", 255).Nullable(true).Comment("预览图")
+		builder.Integer("sort_order", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("排序，值越小越靠前")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20240113-ddl").Create("image_prompt_enhancement", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.Integer("style_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("使用的风格，0 表示不指定风格")
+		builder.String("target", 20).Nullable(false).Default(migrate.StringExpr("")).Comment("目标模型类型：sd/dalle")
+		builder.Text("prompt").Nullable(true).Comment("用户输入的提示语")
+		builder.Text("enhanced_prompt").Nullable(true).Comment("优化后的提示语")
+		builder.Text("negative_prompt").Nullable(true).Comment("优化后的排除内容")
+		builder.String("model", 100).Nullable(false).Default(migrate.StringExpr("")).Comment("用于优化提示语的模型")
+		builder.Integer("token_consumed", false, true).Nullable(false).Default(migrate.RawExpr("0"))
+		builder.Integer("quota_consumed", false, true).Nullable(false).Default(migrate.RawExpr("0"))
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,20 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240114DDL(m *migrate.Manager) {
+	m.Schema("20240114-ddl").Create("image_lora", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 100).Nullable(false).Comment("LoRA 名称")
+		builder.String("file", 255).Nullable(false).Comment("Stable Diffusion 服务端的 LoRA 文件名（不含扩展名）")
+		builder.String("source_url", 255).Nullable(true).Comment("LoRA 模型的来源地址，如 Civitai、HuggingFace 下载地址")
+		builder.String("base_model", 50).Nullable(true).Comment("适用的基础模型，如 sd15、sdxl")
+		builder.String("trigger_words", 255).Nullable(true).Comment("触发词，多个使用英文逗号分隔")
+		builder.Decimal("default_weight", 4, 2).Nullable(false).Default(migrate.RawExpr("0.8")).Comment("默认权重")
+		builder.String("preview_image", 255).Nullable(true).Comment("预览图")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用 2-禁用")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,22 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240115DDL(m *migrate.Manager) {
+	m.Schema("20240115-ddl").Create("avatar_pack_consent", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("task_id", 64).Nullable(false).Comment("数字分身写真任务 ID")
+		builder.String("consent_version", 20).Nullable(false).Comment("用户同意的授权协议版本")
+		builder.String("client_ip", 64).Nullable(true).Comment("用户同意授权时的 IP 地址")
+		builder.Text("selfies").Nullable(true).Comment("用户上传的自拍照地址，JSON 数组，删除后清空")
+		builder.Timestamp("consented_at", 0).Nullable(false).Comment("用户同意授权的时间")
+		builder.Timestamp("purged_at", 0).Nullable(true).Comment("自拍照删除时间")
+		builder.Timestamps(0)
+		builder.Unique("uk_task_id", "task_id")
+		builder.Index("idx_user_id", "user_id")
+		builder.Index("idx_purged_at", "purged_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
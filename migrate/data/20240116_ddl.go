@@ -0,0 +1,16 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240116DDL(m *migrate.Manager) {
+	m.Schema("20240116-ddl").Create("image_preset", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("name", 50).Nullable(false).Comment("预设名称")
+		builder.Text("params").Nullable(true).Comment("生成参数")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_name", "user_id", "name")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,11 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240117DDL(m *migrate.Manager) {
+	// 用于计算任务的排队位置以及各任务类型的处理速度
+	m.Schema("20240117-ddl").Table("queue_tasks", func(builder *migrate.Builder) {
+		builder.Index("idx_type_status_created", "task_type", "status", "created_at")
+		builder.Index("idx_type_status_updated", "task_type", "status", "updated_at")
+	})
+}
@@ -0,0 +1,17 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240118DDL(m *migrate.Manager) {
+	m.Schema("20240118-ddl").Create("queue_task_log", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("task_id", 64).Nullable(false).Comment("任务 ID")
+		builder.String("step", 30).Nullable(false).Comment("任务步骤")
+		builder.String("message", 500).Nullable(true).Comment("步骤说明，已脱敏")
+		builder.Timestamps(0)
+		builder.Index("idx_task_id", "task_id")
+		builder.Index("idx_created_at", "created_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,36 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240119DDL(m *migrate.Manager) {
+	m.Schema("20240119-ddl").Create("support_ticket", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("subject", 255).Nullable(false).Comment("工单标题")
+		builder.String("category", 30).Nullable(false).Default(migrate.StringExpr("")).Comment("问题分类")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-待处理 2-已回复 3-已关闭")
+		builder.String("attachment_type", 20).Nullable(false).Default(migrate.StringExpr("")).Comment("关联的对象类型：room/message/task")
+		builder.String("attachment_id", 64).Nullable(false).Default(migrate.StringExpr("")).Comment("关联的对象 ID")
+		builder.TinyInteger("user_unread", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("用户是否有未读回复")
+		builder.TinyInteger("admin_unread", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("客服是否有未读消息")
+		builder.Timestamp("last_reply_at", 0).Nullable(true).Comment("最后回复时间")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Index("idx_status_last_reply", "status", "last_reply_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20240119-ddl").Create("support_ticket_message", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("ticket_id", false, true).Nullable(false)
+		builder.Integer("sender_id", false, true).Nullable(false).Comment("发送者用户 ID")
+		builder.TinyInteger("is_admin", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("是否为客服回复")
+		builder.Text("content").Nullable(true).Comment("消息内容")
+		builder.Text("images").Nullable(true).Comment("图片地址，JSON 数组")
+		builder.Timestamps(0)
+		builder.Index("idx_ticket_id", "ticket_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
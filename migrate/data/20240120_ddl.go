@@ -0,0 +1,21 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240120DDL(m *migrate.Manager) {
+	m.Schema("20240120-ddl").Create("bug_report", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("app_version", 30).Nullable(false).Default(migrate.StringExpr("")).Comment("客户端版本")
+		builder.String("platform", 30).Nullable(false).Default(migrate.StringExpr("")).Comment("客户端平台")
+		builder.String("platform_version", 100).Nullable(false).Default(migrate.StringExpr("")).Comment("操作系统版本")
+		builder.Text("device").Nullable(true).Comment("设备信息，JSON 格式")
+		builder.Text("client_error").Nullable(true).Comment("客户端错误信息")
+		builder.Text("request_ids").Nullable(true).Comment("客户端上报的请求 ID，JSON 数组")
+		builder.Text("correlated").Nullable(true).Comment("服务端关联的请求与任务信息，JSON 格式")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,22 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240121DDL(m *migrate.Manager) {
+	m.Schema("20240121-ddl").Create("room_folder", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("name", 50).Nullable(false).Comment("文件夹名称")
+		builder.Integer("sort", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("排序，值越小越靠前")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_name", "user_id", "name")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	// 会话（包括群聊）所属的文件夹以及置顶时间，用于在多个设备之间同步会话列表的整理结果
+	m.Schema("20240121-ddl").Table("rooms", func(builder *migrate.Builder) {
+		builder.Integer("folder_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("所属文件夹 ID，0 表示不属于任何文件夹")
+		builder.Timestamp("pinned_at", 0).Nullable(true).Comment("置顶时间，为空表示未置顶")
+	})
+}
@@ -0,0 +1,11 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240122DDL(m *migrate.Manager) {
+	// 删除的会话（包括群聊）先放入回收站，超过保留期限后由定时任务物理删除
+	m.Schema("20240122-ddl").Table("rooms", func(builder *migrate.Builder) {
+		builder.Timestamp("deleted_at", 0).Nullable(true).Comment("删除时间，不为空表示在回收站中")
+		builder.Index("idx_deleted_at", "deleted_at")
+	})
+}
@@ -0,0 +1,12 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240123DDL(m *migrate.Manager) {
+	// 支付回调去重：同一笔 Apple 交易或者支付宝交易只能对应一个支付订单，每个支付订单只发放一次智慧果
+	m.Schema("20240123-ddl").Table("payment_history", func(builder *migrate.Builder) {
+		builder.String("transaction_key", 128).Nullable(true).Comment("第三方交易标识，例如 apple:交易 ID、alipay:交易号")
+		builder.Timestamp("granted_at", 0).Nullable(true).Comment("智慧果发放时间")
+		builder.Unique("uk_transaction_key", "transaction_key")
+	})
+}
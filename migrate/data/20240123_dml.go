@@ -0,0 +1,12 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240123DML(m *migrate.Manager) {
+	// 已经支付成功的订单视为已发放智慧果，避免重复投递的事件再次充值
+	m.Schema("20240123-dml").Raw("payment_history", func() []string {
+		return []string{
+			"UPDATE `payment_history` SET `granted_at` = `updated_at` WHERE `status` = 1 AND `granted_at` IS NULL;",
+		}
+	})
+}
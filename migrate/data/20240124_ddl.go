@@ -0,0 +1,34 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240124DDL(m *migrate.Manager) {
+	// 第三方支付平台的退款、撤销通知，记录扣回智慧果的结果，供财务复核
+	m.Schema("20240124-ddl").Create("payment_refund", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Default(migrate.RawExpr("0"))
+		builder.Integer("org_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("为组织充值时的组织 ID")
+		builder.String("payment_id", 255).Nullable(true).Comment("关联的支付订单，没有匹配到订单时为空")
+		builder.String("transaction_key", 128).Nullable(false).Comment("第三方交易标识，例如 apple:交易 ID")
+		builder.String("notification_id", 64).Nullable(false).Comment("第三方通知 ID，用于通知去重")
+		builder.String("notification_type", 64).Nullable(false).Comment("通知类型，例如 REFUND、REVOKE")
+		builder.String("environment", 20).Nullable(true)
+		builder.Integer("quantity", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("需要扣回的智慧果数量")
+		builder.Integer("clawed_back", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("从余额中扣回的智慧果数量")
+		builder.Integer("debt", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("余额不足时产生的欠费（负余额）")
+		builder.Text("payload").Nullable(true).Comment("通知内容")
+		builder.Timestamp("reviewed_at", 0).Nullable(true).Comment("财务复核时间")
+		builder.String("review_note", 255).Nullable(true).Comment("财务复核备注")
+		builder.Timestamps(0)
+		builder.Unique("uk_notification_id", "notification_id")
+		builder.Index("idx_transaction_key", "transaction_key")
+		builder.Index("idx_created_at", "created_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	// 退款扣回智慧果时，余额不足的部分记为负余额
+	m.Schema("20240124-ddl").Table("org_quota", func(builder *migrate.Builder) {
+		builder.Integer("rest", false, false).Nullable(false).Default(migrate.RawExpr("0")).Comment("剩余配额，负数表示欠费").Change()
+	})
+}
@@ -0,0 +1,22 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240125DDL(m *migrate.Manager) {
+	// 用户之间转赠智慧果的记录，转出方的扣费记录在 quota_usage 中，转入方的配额记录在 quota 中
+	m.Schema("20240125-ddl").Create("coin_transfer", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("from_user_id", false, true).Nullable(false).Comment("转出用户 ID")
+		builder.Integer("to_user_id", false, true).Nullable(false).Comment("转入用户 ID")
+		builder.Integer("amount", false, true).Nullable(false).Comment("转赠的智慧果数量")
+		builder.String("note", 255).Nullable(true).Comment("转赠留言")
+		builder.String("from_quota_ids", 1024).Nullable(true).Comment("从转出用户的哪些配额中扣除，格式为 {配额 ID: 扣除数量}")
+		builder.String("to_quota_ids", 1024).Nullable(true).Comment("为转入用户创建的配额 ID 列表")
+		builder.String("client_ip", 64).Nullable(true)
+		builder.Timestamps(0)
+		builder.Index("idx_from_user_created", "from_user_id", "created_at")
+		builder.Index("idx_to_user_created", "to_user_id", "created_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
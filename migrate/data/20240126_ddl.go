@@ -0,0 +1,29 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240126DDL(m *migrate.Manager) {
+	// 余额不足时自动充值的设置，通过支付宝商家扣款协议扣款
+	m.Schema("20240126-ddl").Create("auto_topup", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("状态：0-待签约 1-已生效 2-已取消 3-扣款失败已暂停")
+		builder.String("product_id", 100).Nullable(false).Comment("自动充值的产品")
+		builder.Integer("threshold", false, true).Nullable(false).Comment("余额低于该值时自动充值")
+		builder.Integer("monthly_cap", false, true).Nullable(false).Comment("每月自动充值的最大金额，单位为分")
+		builder.String("external_agreement_no", 64).Nullable(false).Comment("商户签约号")
+		builder.String("agreement_no", 64).Nullable(true).Comment("支付宝协议号")
+		builder.String("alipay_user_id", 64).Nullable(true)
+		builder.Timestamp("signed_at", 0).Nullable(true)
+		builder.Timestamp("notified_at", 0).Nullable(true).Comment("扣款前通知用户的时间，为空表示没有待扣款")
+		builder.Timestamp("last_charged_at", 0).Nullable(true)
+		builder.Integer("failures", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("连续扣款失败次数")
+		builder.String("last_error", 255).Nullable(true)
+		builder.Timestamps(0)
+		builder.Unique("uk_user_id", "user_id")
+		builder.Unique("uk_external_agreement_no", "external_agreement_no")
+		builder.Index("idx_status", "status")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
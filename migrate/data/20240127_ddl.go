@@ -0,0 +1,24 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240127DDL(m *migrate.Manager) {
+	// 智慧果复式记账分录，同一个 txn_id 下所有分录的借方合计等于贷方合计
+	m.Schema("20240127-ddl").Create("coin_ledger", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("txn_id", 64).Nullable(false).Comment("记账凭证号，同一笔业务的分录使用相同的凭证号")
+		builder.String("account", 32).Nullable(false).Comment("科目：user_wallet, org_wallet, user_debt, org_debt, revenue, gift, refund, consumption, opening")
+		builder.Integer("owner_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("钱包、欠费科目所属的用户或者组织 ID，系统科目为 0")
+		builder.Integer("debit", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("借方金额")
+		builder.Integer("credit", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("贷方金额")
+		builder.String("kind", 32).Nullable(false).Comment("业务类型：purchase, gift, consume, refund, transfer, clawback, opening")
+		builder.String("ref", 100).Nullable(true).Comment("关联的业务单号，例如支付订单 ID、配额 ID")
+		builder.String("note", 255).Nullable(true)
+		builder.Timestamps(0)
+		builder.Index("idx_txn_id", "txn_id")
+		builder.Index("idx_account_owner", "account", "owner_id")
+		builder.Index("idx_created_at", "created_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,17 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240127DML(m *migrate.Manager) {
+	// 启用复式记账前的余额记为期初余额，对方科目为 opening，此后的余额变动都通过记账分录完成
+	m.Schema("20240127-dml").Raw("coin_ledger", func() []string {
+		return []string{
+			"INSERT INTO `coin_ledger` (`txn_id`, `account`, `owner_id`, `debit`, `credit`, `kind`, `note`, `created_at`, `updated_at`) SELECT CONCAT('opening:user:', `user_id`), 'user_wallet', `user_id`, GREATEST(-SUM(`rest`), 0), GREATEST(SUM(`rest`), 0), 'opening', '期初余额', NOW(), NOW() FROM `quota` GROUP BY `user_id` HAVING SUM(`rest`) != 0;",
+			"INSERT INTO `coin_ledger` (`txn_id`, `account`, `owner_id`, `debit`, `credit`, `kind`, `note`, `created_at`, `updated_at`) SELECT CONCAT('opening:user:', `user_id`), 'opening', 0, GREATEST(SUM(`rest`), 0), GREATEST(-SUM(`rest`), 0), 'opening', '期初余额', NOW(), NOW() FROM `quota` GROUP BY `user_id` HAVING SUM(`rest`) != 0;",
+			"INSERT INTO `coin_ledger` (`txn_id`, `account`, `owner_id`, `debit`, `credit`, `kind`, `note`, `created_at`, `updated_at`) SELECT CONCAT('opening:org:', `org_id`), 'org_wallet', `org_id`, GREATEST(-SUM(`rest`), 0), GREATEST(SUM(`rest`), 0), 'opening', '期初余额', NOW(), NOW() FROM `org_quota` GROUP BY `org_id` HAVING SUM(`rest`) != 0;",
+			"INSERT INTO `coin_ledger` (`txn_id`, `account`, `owner_id`, `debit`, `credit`, `kind`, `note`, `created_at`, `updated_at`) SELECT CONCAT('opening:org:', `org_id`), 'opening', 0, GREATEST(SUM(`rest`), 0), GREATEST(-SUM(`rest`), 0), 'opening', '期初余额', NOW(), NOW() FROM `org_quota` GROUP BY `org_id` HAVING SUM(`rest`) != 0;",
+			"INSERT INTO `coin_ledger` (`txn_id`, `account`, `owner_id`, `debit`, `credit`, `kind`, `note`, `created_at`, `updated_at`) SELECT CONCAT('opening:debt:', `user_id`), 'user_debt', `user_id`, SUM(`used`), 0, 'opening', '期初欠费', NOW(), NOW() FROM `debt` GROUP BY `user_id` HAVING SUM(`used`) > 0;",
+			"INSERT INTO `coin_ledger` (`txn_id`, `account`, `owner_id`, `debit`, `credit`, `kind`, `note`, `created_at`, `updated_at`) SELECT CONCAT('opening:debt:', `user_id`), 'opening', 0, 0, SUM(`used`), 'opening', '期初欠费', NOW(), NOW() FROM `debt` GROUP BY `user_id` HAVING SUM(`used`) > 0;",
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240128DDL(m *migrate.Manager) {
+	// 高级模型免费试用次数，每个用户每个模型一条记录
+	m.Schema("20240128-ddl").Create("model_trial", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("model", 100).Nullable(false)
+		builder.Integer("used", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("已经使用的试用次数")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_model", "user_id", "model")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,19 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240129DDL(m *migrate.Manager) {
+	// 模型对比：同一个问题同时发送给多个模型，保存各个模型的回答用于对比查看
+	m.Schema("20240129-ddl").Create("model_comparison", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.Text("prompt").Nullable(false)
+		builder.String("models", 255).Nullable(false).Comment("参与对比的模型，逗号分隔")
+		builder.Text("results").Nullable(true).Comment("各个模型的回答、耗时以及消耗的智慧果，JSON 数组")
+		builder.Integer("coins", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("总计消耗的智慧果")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
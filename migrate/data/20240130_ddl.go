@@ -0,0 +1,10 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240130DDL(m *migrate.Manager) {
+	m.Schema("20240130-ddl").Table("rooms", func(builder *migrate.Builder) {
+		builder.String("reply_language", 20).Nullable(true).Comment("用户设置的回复语言，为空时跟随检测到的语言，off 表示不指定回复语言")
+		builder.String("detected_language", 20).Nullable(true).Comment("根据用户最近的提问检测到的语言")
+	})
+}
@@ -0,0 +1,17 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240131DDL(m *migrate.Manager) {
+	m.Schema("20240131-ddl").Create("user_glossary", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false).Comment("用户 ID")
+		builder.String("term", 100).Nullable(false).Comment("术语原文")
+		builder.String("replacement", 255).Nullable(false).Comment("首选的译法或者写法")
+		builder.String("note", 255).Nullable(true).Comment("备注")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_term", "user_id", "term")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
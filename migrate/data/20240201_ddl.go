@@ -0,0 +1,21 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240201DDL(m *migrate.Manager) {
+	// 写作工具操作记录：改写、扩写、缩写、调整语气等
+	m.Schema("20240201-ddl").Create("writing_tool_history", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("operation", 20).Nullable(false).Comment("操作类型：rewrite/expand/shorten/tone")
+		builder.String("options", 255).Nullable(true).Comment("操作选项，JSON")
+		builder.String("model", 50).Nullable(false)
+		builder.Text("input").Nullable(false)
+		builder.Text("output").Nullable(true)
+		builder.Integer("quota_consumed", false, true).Nullable(false).Default(migrate.RawExpr("0"))
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
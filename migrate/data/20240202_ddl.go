@@ -0,0 +1,46 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240202DDL(m *migrate.Manager) {
+	// 学习模式：记忆卡片以及间隔重复复习
+	m.Schema("20240202-ddl").Create("study_deck", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("name", 100).Nullable(false)
+		builder.String("source", 20).Nullable(false).Comment("卡片来源：conversation-会话 notes-笔记")
+		builder.Integer("room_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("从会话生成时的会话 ID")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20240202-ddl").Create("study_card", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.Integer("deck_id", false, true).Nullable(false)
+		builder.Text("front").Nullable(false).Comment("正面：问题")
+		builder.Text("back").Nullable(false).Comment("背面：答案")
+		builder.Integer("repetitions", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("连续答对的次数")
+		builder.Integer("interval_days", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("复习间隔（天）")
+		builder.Integer("ease_factor", false, true).Nullable(false).Default(migrate.RawExpr("2500")).Comment("SM-2 难度系数 EF 的千倍，初始为 2.5")
+		builder.Timestamp("due_at", 0).Nullable(false).Comment("下次复习时间")
+		builder.Timestamp("reviewed_at", 0).Nullable(true).Comment("最后复习时间")
+		builder.Timestamps(0)
+		builder.Index("idx_deck_id", "deck_id")
+		builder.Index("idx_user_due", "user_id", "due_at")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	m.Schema("20240202-ddl").Create("study_setting", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.TinyInteger("reminder_enabled", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("是否发送复习提醒")
+		builder.TinyInteger("reminder_hour", false, true).Nullable(false).Default(migrate.RawExpr("20")).Comment("每天发送复习提醒的时间（小时）")
+		builder.Timestamp("reminded_at", 0).Nullable(true).Comment("最后发送复习提醒的时间")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_id", "user_id")
+	})
+}
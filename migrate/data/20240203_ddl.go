@@ -0,0 +1,30 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240203DDL(m *migrate.Manager) {
+	// 作业辅导模式：辅导策略设置以及分学科的学习进度
+	m.Schema("20240203-ddl").Create("tutor_setting", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("answer_policy", 20).Nullable(false).Default(migrate.StringExpr("")).Comment("答案策略：guide-只引导不给出最终答案 answer-允许给出答案，留空使用系统默认策略")
+		builder.String("parent_email", 255).Nullable(false).Default(migrate.StringExpr("")).Comment("接收学习周报的家长邮箱")
+		builder.TinyInteger("weekly_report", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("是否发送学习周报")
+		builder.Timestamp("reported_at", 0).Nullable(true).Comment("最后发送学习周报的时间")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_id", "user_id")
+	})
+
+	m.Schema("20240203-ddl").Create("tutor_progress", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("subject", 20).Nullable(false).Comment("学科")
+		builder.Date("stat_date").Nullable(false).Comment("统计日期")
+		builder.Integer("steps", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("辅导的步骤数（提问次数）")
+		builder.Integer("solved", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("独立解决的题目数")
+		builder.Integer("blocked", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("被受限模式拦截的提问数")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_subject_date", "user_id", "subject", "stat_date")
+		builder.Index("idx_user_date", "user_id", "stat_date")
+	})
+}
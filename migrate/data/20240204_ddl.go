@@ -0,0 +1,24 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240204DDL(m *migrate.Manager) {
+	// 模拟面试：面试过程记录，评分报告保存在创作岛历史记录中
+	m.Schema("20240204-ddl").Create("mock_interview", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.Integer("history_id", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("对应的创作岛历史记录 ID")
+		builder.String("role", 100).Nullable(false).Comment("面试岗位")
+		builder.String("level", 20).Nullable(false).Default(migrate.StringExpr("")).Comment("岗位级别：junior/middle/senior")
+		builder.String("model", 50).Nullable(false)
+		builder.TinyInteger("question_count", false, true).Nullable(false).Comment("计划的题目数量")
+		builder.TinyInteger("answered", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("已经回答的题目数量")
+		builder.Text("transcript").Nullable(true).Comment("面试过程：问题与回答，JSON 格式")
+		builder.Integer("quota_used", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("已经消耗的智慧果")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-进行中 2-已结束")
+		builder.Timestamps(0)
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,34 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240205DDL(m *migrate.Manager) {
+	// 新用户引导：引导步骤、示例提示语、推荐模型，由管理员维护，按照语言区分
+	m.Schema("20240205-ddl").Create("onboarding_item", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("kind", 20).Nullable(false).Comment("类型：step-引导步骤，prompt-示例提示语，model-推荐模型")
+		builder.String("language", 20).Nullable(false).Default(migrate.StringExpr("zh-CHS")).Comment("语言，例如 zh-CHS/en")
+		builder.String("persona", 50).Nullable(false).Default(migrate.StringExpr("")).Comment("适用的用户角色，为空时适用于全部用户")
+		builder.String("title", 255).Nullable(false).Comment("标题")
+		builder.Text("content").Nullable(true).Comment("内容：引导说明或者提示语")
+		builder.String("payload", 255).Nullable(false).Default(migrate.StringExpr("")).Comment("附加数据：推荐模型 ID 或者跳转地址")
+		builder.Integer("sort", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("排序，值越小越靠前")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用，2-禁用")
+		builder.Timestamps(0)
+		builder.Index("idx_kind_language", "kind", "language")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	// 新用户引导：用户完成的引导步骤
+	m.Schema("20240205-ddl").Create("onboarding_progress", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.Integer("item_id", false, true).Nullable(false).Comment("完成的引导步骤 ID")
+		builder.Timestamps(0)
+		builder.Unique("uk_user_item", "user_id", "item_id")
+		builder.Index("idx_item_id", "item_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -0,0 +1,49 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240206DDL(m *migrate.Manager) {
+	// 内测计划：内测分组，分组成员优先使用分组中的新功能与新模型
+	m.Schema("20240206-ddl").Create("beta_cohort", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.String("name", 100).Nullable(false).Comment("分组名称")
+		builder.Text("description").Nullable(true).Comment("分组说明")
+		builder.Text("features").Nullable(true).Comment("分组成员可以使用的内测功能（JSON 数组）")
+		builder.Text("models").Nullable(true).Comment("分组成员可以使用的内测模型（JSON 数组）")
+		builder.TinyInteger("open_enroll", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("是否允许用户自行加入：0-否，1-是")
+		builder.Integer("capacity", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("成员数量上限，0 表示不限制")
+		builder.TinyInteger("status", false, true).Nullable(false).Default(migrate.RawExpr("1")).Comment("状态：1-启用，2-禁用")
+		builder.Timestamps(0)
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	// 内测计划：分组成员
+	m.Schema("20240206-ddl").Create("beta_member", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("cohort_id", false, true).Nullable(false)
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("source", 20).Nullable(false).Default(migrate.StringExpr("self")).Comment("加入方式：self-用户自行加入，admin-管理员添加")
+		builder.Timestamps(0)
+		builder.Unique("uk_cohort_user", "cohort_id", "user_id")
+		builder.Index("idx_user_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	// 内测计划：分组成员提交的反馈
+	m.Schema("20240206-ddl").Create("beta_feedback", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("cohort_id", false, true).Nullable(false)
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.String("feature", 50).Nullable(false).Default(migrate.StringExpr("")).Comment("反馈的内测功能或者模型，为空时表示整体反馈")
+		builder.TinyInteger("rating", false, true).Nullable(false).Default(migrate.RawExpr("0")).Comment("评分：1-5，0 表示未评分")
+		builder.Text("content").Nullable(true).Comment("反馈内容")
+		builder.String("platform", 20).Nullable(false).Default(migrate.StringExpr(""))
+		builder.String("client_version", 20).Nullable(false).Default(migrate.StringExpr(""))
+		builder.Timestamps(0)
+		builder.Index("idx_cohort_id", "cohort_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
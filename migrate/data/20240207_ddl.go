@@ -0,0 +1,30 @@
+package data
+
+import "github.com/mylxsw/eloquent/migrate"
+
+func Migrate20240207DDL(m *migrate.Manager) {
+	// 组织的邮箱域名，验证域名所有权后，成员同步接口才能为该域名下的邮箱创建并启用账号
+	m.Schema("20240207-ddl").Create("org_domain", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false)
+		builder.String("domain", 255).Nullable(false).Comment("邮箱域名，例如 example.com")
+		builder.String("token", 64).Nullable(false).Comment("域名验证使用的 DNS TXT 记录值")
+		builder.Timestamp("verified_at", 0).Nullable(true).Comment("验证通过时间，为空表示未验证")
+		builder.Timestamps(0)
+		builder.Unique("uk_org_domain", "org_id", "domain")
+		builder.Index("idx_domain", "domain")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+
+	// 成员同步接口创建的账号，这些账号可以由该组织的成员同步接口直接启用
+	m.Schema("20240207-ddl").Create("org_provisioned_account", func(builder *migrate.Builder) {
+		builder.Increments("id")
+		builder.Integer("org_id", false, true).Nullable(false)
+		builder.Integer("user_id", false, true).Nullable(false)
+		builder.Timestamps(0)
+		builder.Unique("uk_org_user", "org_id", "user_id")
+		builder.Charset("utf8mb4")
+		builder.Collation("utf8mb4_general_ci")
+	})
+}
@@ -20,6 +20,78 @@ func Migrate(ctx context.Context, db *sql.DB) error {
 
 	data.Migrate20231129DDL(m)
 	data.Migrate20231129DML(m)
+	data.Migrate20231201DDL(m)
+	data.Migrate20231202DDL(m)
+	data.Migrate20231203DDL(m)
+	data.Migrate20231204DDL(m)
+	data.Migrate20231205DDL(m)
+	data.Migrate20231206DDL(m)
+	data.Migrate20231207DDL(m)
+	data.Migrate20231208DDL(m)
+	data.Migrate20231209DDL(m)
+	data.Migrate20231210DDL(m)
+	data.Migrate20231211DDL(m)
+	data.Migrate20231212DDL(m)
+	data.Migrate20231213DDL(m)
+	data.Migrate20231214DDL(m)
+	data.Migrate20231215DDL(m)
+	data.Migrate20231216DDL(m)
+	data.Migrate20231217DDL(m)
+	data.Migrate20231218DDL(m)
+	data.Migrate20231219DDL(m)
+	data.Migrate20231220DDL(m)
+	data.Migrate20231221DDL(m)
+	data.Migrate20231222DDL(m)
+	data.Migrate20231223DDL(m)
+	data.Migrate20231224DML(m)
+	data.Migrate20231225DDL(m)
+	data.Migrate20231226DDL(m)
+	data.Migrate20231227DDL(m)
+	data.Migrate20231228DDL(m)
+	data.Migrate20231228DML(m)
+	data.Migrate20231229DDL(m)
+	data.Migrate20231230DDL(m)
+	data.Migrate20231231DDL(m)
+	data.Migrate20240101DDL(m)
+	data.Migrate20240102DDL(m)
+	data.Migrate20240103DDL(m)
+	data.Migrate20240104DDL(m)
+	data.Migrate20240105DDL(m)
+	data.Migrate20240106DDL(m)
+	data.Migrate20240107DDL(m)
+	data.Migrate20240108DDL(m)
+	data.Migrate20240109DDL(m)
+	data.Migrate20240110DDL(m)
+	data.Migrate20240111DDL(m)
+	data.Migrate20240112DDL(m)
+	data.Migrate20240113DDL(m)
+	data.Migrate20240114DDL(m)
+	data.Migrate20240115DDL(m)
+	data.Migrate20240116DDL(m)
+	data.Migrate20240117DDL(m)
+	data.Migrate20240118DDL(m)
+	data.Migrate20240119DDL(m)
+	data.Migrate20240120DDL(m)
+	data.Migrate20240121DDL(m)
+	data.Migrate20240122DDL(m)
+	data.Migrate20240123DDL(m)
+	data.Migrate20240123DML(m)
+	data.Migrate20240124DDL(m)
+	data.Migrate20240125DDL(m)
+	data.Migrate20240126DDL(m)
+	data.Migrate20240127DDL(m)
+	data.Migrate20240127DML(m)
+	data.Migrate20240128DDL(m)
+	data.Migrate20240129DDL(m)
+	data.Migrate20240130DDL(m)
+	data.Migrate20240131DDL(m)
+	data.Migrate20240201DDL(m)
+	data.Migrate20240202DDL(m)
+	data.Migrate20240203DDL(m)
+	data.Migrate20240204DDL(m)
+	data.Migrate20240205DDL(m)
+	data.Migrate20240206DDL(m)
+	data.Migrate20240207DDL(m)
 
 	return m.Run(ctx)
 }
@@ -0,0 +1,115 @@
+package achievement
+
+// Metrics 用户的成就统计指标
+type Metrics struct {
+	// Chats 用户累计发送的聊天消息数量（包括群聊）
+	Chats int64
+	// Images 用户累计成功创作的图片数量
+	Images int64
+	// CurrentStreak 当前连续使用天数
+	CurrentStreak int64
+}
+
+// Achievement 成就定义
+type Achievement struct {
+	Key         string `json:"key"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Reward 解锁成就时奖励的智慧果数量
+	Reward int64 `json:"reward"`
+	// Points 成就积分，用于排行榜排序
+	Points   int64 `json:"points"`
+	unlocked func(m Metrics) bool
+}
+
+// Unlocked 判断用户是否满足成就的解锁条件
+func (a Achievement) Unlocked(m Metrics) bool {
+	return a.unlocked(m)
+}
+
+var achievements = []Achievement{
+	{
+		Key:         "first-chat",
+		Title:       "初次见面",
+		Description: "发送第一条聊天消息",
+		Reward:      5,
+		Points:      1,
+		unlocked:    func(m Metrics) bool { return m.Chats >= 1 },
+	},
+	{
+		Key:         "first-image",
+		Title:       "初试丹青",
+		Description: "成功创作第一张图片",
+		Reward:      10,
+		Points:      2,
+		unlocked:    func(m Metrics) bool { return m.Images >= 1 },
+	},
+	{
+		Key:         "chats-100",
+		Title:       "畅所欲言",
+		Description: "累计发送 100 条聊天消息",
+		Reward:      20,
+		Points:      5,
+		unlocked:    func(m Metrics) bool { return m.Chats >= 100 },
+	},
+	{
+		Key:         "chats-1000",
+		Title:       "知无不言",
+		Description: "累计发送 1000 条聊天消息",
+		Reward:      50,
+		Points:      10,
+		unlocked:    func(m Metrics) bool { return m.Chats >= 1000 },
+	},
+	{
+		Key:         "images-100",
+		Title:       "妙笔生花",
+		Description: "累计成功创作 100 张图片",
+		Reward:      50,
+		Points:      10,
+		unlocked:    func(m Metrics) bool { return m.Images >= 100 },
+	},
+	{
+		Key:         "streak-7",
+		Title:       "持之以恒",
+		Description: "连续使用 7 天",
+		Reward:      20,
+		Points:      5,
+		unlocked:    func(m Metrics) bool { return m.CurrentStreak >= 7 },
+	},
+	{
+		Key:         "streak-30",
+		Title:       "日积月累",
+		Description: "连续使用 30 天",
+		Reward:      100,
+		Points:      20,
+		unlocked:    func(m Metrics) bool { return m.CurrentStreak >= 30 },
+	},
+}
+
+// All 返回所有成就
+func All() []Achievement {
+	return achievements
+}
+
+// Get 根据 Key 查询成就
+func Get(key string) (Achievement, bool) {
+	for _, a := range achievements {
+		if a.Key == key {
+			return a, true
+		}
+	}
+
+	return Achievement{}, false
+}
+
+// Evaluate 返回用户满足解锁条件的所有成就
+func Evaluate(m Metrics) []Achievement {
+	res := make([]Achievement, 0)
+	for _, a := range achievements {
+		if a.Unlocked(m) {
+			res = append(res, a)
+		}
+	}
+
+	return res
+}
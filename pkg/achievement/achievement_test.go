@@ -0,0 +1,28 @@
+package achievement_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/achievement"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	assert.Equal(t, 0, len(achievement.Evaluate(achievement.Metrics{})))
+
+	keys := array.Map(
+		achievement.Evaluate(achievement.Metrics{Chats: 120, Images: 1, CurrentStreak: 7}),
+		func(item achievement.Achievement, _ int) string { return item.Key },
+	)
+	assert.Equal(t, []string{"first-chat", "first-image", "chats-100", "streak-7"}, keys)
+}
+
+func TestGet(t *testing.T) {
+	a, ok := achievement.Get("first-image")
+	assert.True(t, ok)
+	assert.True(t, a.Reward > 0)
+
+	_, ok = achievement.Get("unknown")
+	assert.False(t, ok)
+}
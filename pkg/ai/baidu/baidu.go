@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/go-utils/array"
@@ -25,7 +26,32 @@ type BaiduAIImpl struct {
 	APIKey      string
 	APISecret   string
 	accessToken string
+	expiresAt   time.Time
 	lock        sync.RWMutex
+	// refreshLock 保证同一时间只有一个请求在刷新 AccessToken
+	refreshLock sync.Mutex
+}
+
+// accessTokenRefreshAhead AccessToken 在过期前提前刷新的时间，AccessToken 有效期为 30 天
+const accessTokenRefreshAhead = 24 * time.Hour
+
+// 千帆平台错误码 https://cloud.baidu.com/doc/WENXINWORKSHOP/s/tlmyncueh
+const (
+	// ErrorCodeAccessTokenInvalid Access token invalid or no longer valid
+	ErrorCodeAccessTokenInvalid = 110
+	// ErrorCodeAccessTokenExpired Access token expired
+	ErrorCodeAccessTokenExpired = 111
+	// ErrorCodeQPSLimit Open api qps request limit reached
+	ErrorCodeQPSLimit = 18
+	// ErrorCodeRPMLimit 超过每分钟请求数限制
+	ErrorCodeRPMLimit = 336501
+	// ErrorCodeTPMLimit 超过每分钟 tokens 数限制
+	ErrorCodeTPMLimit = 336502
+)
+
+// IsAccessTokenError 错误码是否表示 AccessToken 无效或已过期，需要刷新后重试
+func IsAccessTokenError(code int) bool {
+	return code == ErrorCodeAccessTokenInvalid || code == ErrorCodeAccessTokenExpired
 }
 
 func NewBaiduAI(apiKey, apiSecret string) *BaiduAIImpl {
@@ -70,19 +96,55 @@ func (ai *BaiduAIImpl) RefreshAccessToken() error {
 		return err
 	}
 
-	if accessTokenResponse.AccessToken != "" {
-		ai.lock.Lock()
-		ai.accessToken = accessTokenResponse.AccessToken
-		ai.lock.Unlock()
+	if accessTokenResponse.AccessToken == "" {
+		return fmt.Errorf("refresh access token failed: %s", string(resp.Body()))
 	}
 
+	ai.lock.Lock()
+	ai.accessToken = accessTokenResponse.AccessToken
+	ai.expiresAt = time.Now().Add(time.Duration(accessTokenResponse.ExpiresIn) * time.Second)
+	ai.lock.Unlock()
+
 	return nil
 }
 
+// refreshAccessTokenIfNeeded AccessToken 为空或即将过期时刷新，force 为 true 时强制刷新，
+// expired 为调用方已知失效的 AccessToken，如果其它请求已经刷新过则不再重复刷新
+func (ai *BaiduAIImpl) refreshAccessTokenIfNeeded(force bool, expired string) {
+	ai.refreshLock.Lock()
+	defer ai.refreshLock.Unlock()
+
+	ai.lock.RLock()
+	token, expiresAt := ai.accessToken, ai.expiresAt
+	ai.lock.RUnlock()
+
+	if force {
+		if token != expired {
+			return
+		}
+	} else if token != "" && time.Until(expiresAt) > accessTokenRefreshAhead {
+		return
+	}
+
+	if err := ai.RefreshAccessToken(); err != nil {
+		log.Errorf("refresh baidu ai access token failed: %s", err)
+	}
+}
+
 func (ai *BaiduAIImpl) getAccessToken() string {
 	ai.lock.RLock()
-	defer ai.lock.RUnlock()
-	return ai.accessToken
+	token, expiresAt := ai.accessToken, ai.expiresAt
+	ai.lock.RUnlock()
+
+	if token == "" || time.Until(expiresAt) <= accessTokenRefreshAhead {
+		ai.refreshAccessTokenIfNeeded(false, "")
+
+		ai.lock.RLock()
+		token = ai.accessToken
+		ai.lock.RUnlock()
+	}
+
+	return token
 }
 
 type ChatRequest struct {
@@ -206,7 +268,7 @@ type Usage struct {
 
 // SupportSystemMessage 是否支持系统消息
 func SupportSystemMessage(model Model) bool {
-	return model == ModelErnieBot || model == ModelErnieBotTurbo || model == ModelErnieBot4
+	return model == ModelErnieBot || model == ModelErnieBotTurbo || model == ModelErnieBot4 || model == ModelErnieBot8K
 }
 
 type Model string
@@ -218,6 +280,9 @@ const (
 	// ModelErnieBotTurbo ERNIE-Bot-turbo是百度自行研发的大语言模型，覆盖海量中文数据，具有更强的对话问答、内容创作生成等能力，响应速度更快。
 	// ¥0.008元/千tokens
 	ModelErnieBotTurbo = "model_ernie_bot_turbo"
+	// ModelErnieBot8K ERNIE-Bot-8K 是百度自行研发的大语言模型，在 ERNIE-Bot 的基础上增加了对 8K 上下文的支持
+	// ¥0.024元/千tokens
+	ModelErnieBot8K = "model_ernie_bot_8k"
 	// ModelErnieBot4 文心一言 4.0
 	// ¥0.12元/千tokens
 	ModelErnieBot4 = "model_ernie_bot_4"
@@ -248,55 +313,63 @@ func (ai *BaiduAIImpl) Chat(ctx context.Context, model Model, req ChatRequest) (
 		return nil, err
 	}
 
-	url := ai.modelURL(model)
-
-	resp, err := resty.R().SetQueryParam("access_token", ai.getAccessToken()).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		SetContext(ctx).
-		Post(url)
+	url, err := ModelURL(model)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("chat failed, status code: %d", resp.StatusCode())
-	}
+	for i := 0; ; i++ {
+		accessToken := ai.getAccessToken()
+		resp, err := resty.R().SetQueryParam("access_token", accessToken).
+			SetHeader("Content-Type", "application/json").
+			SetBody(body).
+			SetContext(ctx).
+			Post(url)
+		if err != nil {
+			return nil, err
+		}
 
-	var chatResponse ChatResponse
-	if err := json.Unmarshal(resp.Body(), &chatResponse); err != nil {
-		return nil, err
+		if resp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("chat failed, status code: %d", resp.StatusCode())
+		}
+
+		var chatResponse ChatResponse
+		if err := json.Unmarshal(resp.Body(), &chatResponse); err != nil {
+			return nil, err
+		}
+
+		// AccessToken 失效时刷新后重试一次
+		if IsAccessTokenError(chatResponse.ErrorCode) && i == 0 {
+			ai.refreshAccessTokenIfNeeded(true, accessToken)
+			continue
+		}
+
+		return &chatResponse, nil
 	}
+}
 
-	return &chatResponse, nil
+// modelEndpoints 模型与千帆平台接口地址的映射
+var modelEndpoints = map[Model]string{
+	ModelErnieBot:        "completions",
+	ModelErnieBotTurbo:   "eb-instant",
+	ModelErnieBot8K:      "ernie_bot_8k",
+	ModelErnieBot4:       "completions_pro",
+	ModelLlama2_70b:      "llama_2_70b",
+	ModelLlama2_13b:      "llama_2_13b",
+	ModelLlama2_7b_CN:    "qianfan_chinese_llama_2_7b",
+	ModelChatGLM2_6B_32K: "chatglm2_6b_32k",
+	ModelAquilaChat7B:    "aquilachat_7b",
+	ModelBloomz7B:        "bloomz_7b1",
 }
 
-func (ai *BaiduAIImpl) modelURL(model Model) string {
-	var url string
-	switch model {
-	case ModelErnieBot:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/completions"
-	case ModelErnieBotTurbo:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/eb-instant"
-	case ModelErnieBot4:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/completions_pro"
-	case ModelLlama2_70b:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/llama_2_70b"
-	case ModelLlama2_13b:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/llama_2_13b"
-	case ModelLlama2_7b_CN:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/qianfan_chinese_llama_2_7b"
-	case ModelChatGLM2_6B_32K:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/chatglm2_6b_32k"
-	case ModelAquilaChat7B:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/aquilachat_7b"
-	case ModelBloomz7B:
-		url = "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/bloomz_7b1"
-	default:
-		panic("invalid model")
+// ModelURL 返回模型对应的千帆平台接口地址
+func ModelURL(model Model) (string, error) {
+	endpoint, ok := modelEndpoints[model]
+	if !ok {
+		return "", fmt.Errorf("invalid model: %s", model)
 	}
 
-	return url
+	return "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/" + endpoint, nil
 }
 
 func (ai *BaiduAIImpl) ChatStream(ctx context.Context, model Model, req ChatRequest) (<-chan ChatResponse, error) {
@@ -306,28 +379,43 @@ func (ai *BaiduAIImpl) ChatStream(ctx context.Context, model Model, req ChatRequ
 		return nil, err
 	}
 
-	url := ai.modelURL(model)
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url+"?access_token="+ai.getAccessToken(), bytes.NewReader(body))
+	url, err := ModelURL(model)
 	if err != nil {
 		return nil, err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "text/event-stream")
-	httpReq.Header.Set("Cache-Control", "no-cache")
-	httpReq.Header.Set("Connection", "keep-alive")
+	var httpResp *http.Response
+	var errResp *ChatResponse
+	for i := 0; ; i++ {
+		accessToken := ai.getAccessToken()
+		httpResp, errResp, err = ai.requestStream(ctx, url, accessToken, body)
+		if err != nil {
+			return nil, err
+		}
 
-	httpResp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return nil, err
-	}
+		// AccessToken 失效时刷新后重试一次
+		if errResp != nil && IsAccessTokenError(errResp.ErrorCode) && i == 0 {
+			ai.refreshAccessTokenIfNeeded(true, accessToken)
+			continue
+		}
 
-	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("chat failed, status code: %d", httpResp.StatusCode)
+		break
 	}
 
 	res := make(chan ChatResponse)
+	if errResp != nil {
+		go func() {
+			defer close(res)
+
+			select {
+			case <-ctx.Done():
+			case res <- *errResp:
+			}
+		}()
+
+		return res, nil
+	}
+
 	go func() {
 		defer func() {
 			_ = httpResp.Body.Close()
@@ -383,3 +471,43 @@ func (ai *BaiduAIImpl) ChatStream(ctx context.Context, model Model, req ChatRequ
 
 	return res, nil
 }
+
+// requestStream 发起流式请求，请求失败时千帆平台返回的是普通的 JSON 响应而不是事件流，此时返回错误响应
+func (ai *BaiduAIImpl) requestStream(ctx context.Context, url, accessToken string, body []byte) (*http.Response, *ChatResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url+"?access_token="+accessToken, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+	httpReq.Header.Set("Cache-Control", "no-cache")
+	httpReq.Header.Set("Connection", "keep-alive")
+
+	httpResp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusBadRequest {
+		_ = httpResp.Body.Close()
+		return nil, nil, fmt.Errorf("chat failed, status code: %d", httpResp.StatusCode)
+	}
+
+	if strings.HasPrefix(httpResp.Header.Get("Content-Type"), "application/json") {
+		defer httpResp.Body.Close()
+
+		var chatResponse ChatResponse
+		if err := json.NewDecoder(httpResp.Body).Decode(&chatResponse); err != nil {
+			return nil, nil, err
+		}
+
+		if chatResponse.ErrorCode == 0 && chatResponse.ErrorMessage == "" {
+			chatResponse.ErrorCode, chatResponse.ErrorMessage = 10, "unexpected non-stream response"
+		}
+
+		return nil, &chatResponse, nil
+	}
+
+	return httpResp, nil, nil
+}
@@ -127,3 +127,15 @@ func TestChatMessageFix(t *testing.T) {
 		log.Debugf("%s: %s", msg.Role, msg.Content)
 	}
 }
+
+func TestModelURL(t *testing.T) {
+	url, err := baidu.ModelURL(baidu.ModelErnieBot8K)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://aip.baidubce.com/rpc/2.0/ai_custom/v1/wenxinworkshop/chat/ernie_bot_8k", url)
+
+	_, err = baidu.ModelURL("model_not_exist")
+	assert.True(t, err != nil)
+
+	assert.True(t, baidu.IsAccessTokenError(baidu.ErrorCodeAccessTokenExpired))
+	assert.False(t, baidu.IsAccessTokenError(baidu.ErrorCodeQPSLimit))
+}
@@ -0,0 +1,199 @@
+package channel
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/sashabaranov/go-openai"
+)
+
+// Prefix 自定义渠道模型在模型列表中的 ID 前缀
+const Prefix = "channel:"
+
+// Model 自定义渠道提供的模型
+type Model struct {
+	repo.ChannelModel
+	// ChannelID 模型所属的渠道
+	ChannelID   int64  `json:"channel_id"`
+	ChannelName string `json:"channel_name"`
+
+	client openai2.Client
+}
+
+// ChatStream 使用模型所属渠道的上游接口发起流式请求，请求中的模型名称会被替换为上游的模型名称，
+// 首个响应的延迟以及请求是否失败会被记录到渠道的请求统计中
+func (m Model) ChatStream(ctx context.Context, request openai.ChatCompletionRequest) (<-chan openai2.ChatStreamResponse, error) {
+	request.Model = m.UpstreamModel()
+
+	startTime := time.Now()
+	stream, err := m.client.ChatStream(ctx, request)
+	if err != nil {
+		Record(m.ChannelID, m.ID, time.Since(startTime), ctx.Err() == nil)
+		return nil, err
+	}
+
+	res := make(chan openai2.ChatStreamResponse)
+	go func() {
+		defer close(res)
+
+		first := true
+		for data := range stream {
+			if first {
+				first = false
+				Record(m.ChannelID, m.ID, time.Since(startTime), data.Code != "")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case res <- data:
+			}
+		}
+
+		// 上游没有返回任何数据就结束了，客户端主动取消的请求不计入失败
+		if first && ctx.Err() == nil {
+			Record(m.ChannelID, m.ID, time.Since(startTime), true)
+		}
+	}()
+
+	return res, nil
+}
+
+// Chat 使用模型所属渠道的上游接口发起请求，请求中的模型名称会被替换为上游的模型名称
+func (m Model) Chat(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	request.Model = m.UpstreamModel()
+
+	startTime := time.Now()
+	res, err := m.client.CreateChatCompletion(ctx, request)
+	if err == nil || ctx.Err() == nil {
+		Record(m.ChannelID, m.ID, time.Since(startTime), err != nil)
+	}
+
+	return res, err
+}
+
+var (
+	registryLock sync.RWMutex
+	// registry 模型 ID 到提供该模型的所有渠道的映射
+	registry = map[string][]Model{}
+	ordered  []Model
+)
+
+// Load 使用渠道配置重建模型注册表，只有启用的 OpenAI 兼容渠道会被加载，多个渠道提供同一个模型时，请求时根据渠道的延迟和错误率选择
+func Load(channels []repo.Channel) {
+	models := make(map[string][]Model)
+	results := make([]Model, 0)
+
+	for _, ch := range channels {
+		if ch.Status != repo.ChannelStatusEnabled || ch.Type != repo.ChannelTypeOpenAI || ch.Server == "" {
+			continue
+		}
+
+		client := openai2.NewOpenAIClient(&openai2.Config{
+			Enable:        true,
+			OpenAIServers: []string{strings.TrimSuffix(ch.Server, "/")},
+			OpenAIKeys:    []string{ch.Secret},
+		}, nil)
+
+		for _, item := range ch.Models {
+			if item.ID == "" {
+				continue
+			}
+
+			m := Model{ChannelModel: item, ChannelID: ch.ID, ChannelName: ch.Name, client: client}
+			if _, ok := models[item.ID]; !ok {
+				results = append(results, m)
+			}
+
+			models[item.ID] = append(models[item.ID], m)
+		}
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registry, ordered = models, results
+}
+
+// Lookup 查询自定义渠道提供的模型，支持带 channel: 前缀的模型 ID，多个渠道提供该模型时按照 Select 的规则选择渠道
+func Lookup(model string) (Model, bool) {
+	candidates := Candidates(model)
+	if len(candidates) == 0 {
+		return Model{}, false
+	}
+
+	return Select(candidates), true
+}
+
+// Candidates 返回提供该模型的所有渠道
+func Candidates(model string) []Model {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return append([]Model{}, registry[strings.TrimPrefix(model, Prefix)]...)
+}
+
+// Select 从提供同一模型的多个渠道中选择一个：存在手动固定的渠道时总是使用该渠道，
+// 否则选择滚动窗口内 p95 延迟与错误率综合得分最低的渠道，得分相同时按照渠道加载顺序选择
+func Select(candidates []Model) Model {
+	for _, m := range candidates {
+		if m.Pinned {
+			return m
+		}
+	}
+
+	selected, best := candidates[0], math.MaxFloat64
+	for _, m := range candidates {
+		if score := StatsOf(m.ChannelID, m.ID).Score(); score < best {
+			selected, best = m, score
+		}
+	}
+
+	return selected
+}
+
+// Models 返回所有自定义渠道提供的模型，提供同一模型的多个渠道只返回首个渠道
+func Models() []Model {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return append([]Model{}, ordered...)
+}
+
+// Loader 从数据库加载自定义渠道
+type Loader struct {
+	rep *repo.Repository
+}
+
+func NewLoader(rep *repo.Repository) *Loader {
+	return &Loader{rep: rep}
+}
+
+// Reload 重新加载自定义渠道，并设置渠道模型的计费价格，未通过测试验证的渠道不会被加载
+func (l *Loader) Reload(ctx context.Context) error {
+	channels, err := l.rep.Channel.Channels(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	Load(array.Filter(channels, func(ch repo.Channel, _ int) bool { return ch.Verified() }))
+
+	for _, m := range Models() {
+		if m.Price > 0 {
+			coins.SetDynamicTextPrice(m.ID, m.Price)
+		}
+
+		if m.UpstreamPrice != nil {
+			coins.SetDynamicUpstreamPrice(m.ID, *m.UpstreamPrice)
+		}
+	}
+
+	return nil
+}
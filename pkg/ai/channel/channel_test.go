@@ -0,0 +1,197 @@
+package channel_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/channel"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestLoad(t *testing.T) {
+	channel.Load([]repo.Channel{
+		{
+			ID:     1,
+			Name:   "primary",
+			Type:   repo.ChannelTypeOpenAI,
+			Server: "https://llm.example.com/v1/",
+			Models: []repo.ChannelModel{
+				{ID: "llama-3-70b", RealModel: "meta-llama/Llama-3-70b-chat-hf", Price: 3},
+				{ID: "qwen-72b"},
+			},
+			Status: repo.ChannelStatusEnabled,
+		},
+		{
+			ID:     2,
+			Name:   "secondary",
+			Type:   repo.ChannelTypeOpenAI,
+			Server: "https://backup.example.com/v1",
+			Models: []repo.ChannelModel{{ID: "llama-3-70b"}, {ID: "mixtral"}},
+			Status: repo.ChannelStatusEnabled,
+		},
+		{
+			ID:     3,
+			Name:   "disabled",
+			Type:   repo.ChannelTypeOpenAI,
+			Server: "https://disabled.example.com/v1",
+			Models: []repo.ChannelModel{{ID: "yi-34b"}},
+			Status: repo.ChannelStatusDisabled,
+		},
+	})
+
+	assert.Equal(t, 3, len(channel.Models()))
+
+	m, ok := channel.Lookup("llama-3-70b")
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, m.ChannelID)
+	assert.Equal(t, "meta-llama/Llama-3-70b-chat-hf", m.UpstreamModel())
+
+	m, ok = channel.Lookup(channel.Prefix + "qwen-72b")
+	assert.True(t, ok)
+	assert.Equal(t, "qwen-72b", m.UpstreamModel())
+
+	m, ok = channel.Lookup("mixtral")
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, m.ChannelID)
+
+	_, ok = channel.Lookup("yi-34b")
+	assert.False(t, ok)
+
+	channel.Load(nil)
+	_, ok = channel.Lookup("llama-3-70b")
+	assert.False(t, ok)
+}
+
+func TestSelect(t *testing.T) {
+	channels := []repo.Channel{
+		{ID: 11, Name: "slow", Type: repo.ChannelTypeOpenAI, Server: "https://slow.example.com/v1", Models: []repo.ChannelModel{{ID: "gpt-4-mirror"}}, Status: repo.ChannelStatusEnabled},
+		{ID: 12, Name: "fast", Type: repo.ChannelTypeOpenAI, Server: "https://fast.example.com/v1", Models: []repo.ChannelModel{{ID: "gpt-4-mirror"}}, Status: repo.ChannelStatusEnabled},
+		{ID: 13, Name: "broken", Type: repo.ChannelTypeOpenAI, Server: "https://broken.example.com/v1", Models: []repo.ChannelModel{{ID: "gpt-4-mirror"}}, Status: repo.ChannelStatusEnabled},
+	}
+	channel.Load(channels)
+	defer channel.Load(nil)
+
+	assert.Equal(t, 1, len(channel.Models()))
+	assert.Equal(t, 3, len(channel.Candidates("gpt-4-mirror")))
+
+	for i := 0; i < 20; i++ {
+		channel.Record(11, "gpt-4-mirror", 3*time.Second, false)
+		channel.Record(12, "gpt-4-mirror", 800*time.Millisecond, i%10 == 0)
+		channel.Record(13, "gpt-4-mirror", 100*time.Millisecond, i%2 == 0)
+	}
+
+	stats := channel.StatsOf(12, "gpt-4-mirror")
+	assert.Equal(t, 20, stats.Samples)
+	assert.Equal(t, 800*time.Millisecond, stats.P95Latency)
+	assert.True(t, stats.Healthy())
+	assert.False(t, channel.StatsOf(13, "gpt-4-mirror").Healthy())
+
+	m, ok := channel.Lookup("gpt-4-mirror")
+	assert.True(t, ok)
+	assert.EqualValues(t, 12, m.ChannelID)
+
+	// 手动固定渠道时总是使用固定的渠道
+	channels[0].Models[0].Pinned = true
+	channel.Load(channels)
+
+	m, ok = channel.Lookup("gpt-4-mirror")
+	assert.True(t, ok)
+	assert.EqualValues(t, 11, m.ChannelID)
+}
+
+func TestTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id":"chatcmpl-1","model":"meta-llama/Llama-3-70b-chat-hf","choices":[{"index":0,"message":{"role":"assistant","content":"hello"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1200,"completion_tokens":800,"total_tokens":2000}}`)
+	}))
+	defer server.Close()
+
+	ch := repo.Channel{
+		ID:     1,
+		Type:   repo.ChannelTypeOpenAI,
+		Server: server.URL + "/v1/",
+		Secret: "sk-secret",
+		Models: []repo.ChannelModel{
+			{ID: "llama-3-70b", RealModel: "meta-llama/Llama-3-70b-chat-hf", Price: 3, UpstreamPrice: &coins.UpstreamPrice{Input: 0.01, Output: 0.02}},
+		},
+	}
+
+	result, err := channel.Test(context.Background(), ch, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", result.Error)
+	assert.Equal(t, "hello", result.Reply)
+	assert.Equal(t, "meta-llama/Llama-3-70b-chat-hf", result.UpstreamModel)
+	assert.True(t, strings.Contains(result.Request.Body, channel.DefaultTestPrompt))
+	assert.Equal(t, "******", result.Request.Headers["Authorization"])
+	assert.Equal(t, http.StatusOK, result.Response.StatusCode)
+	assert.True(t, strings.Contains(result.Response.Body, "chatcmpl-1"))
+
+	assert.Equal(t, int64(6), result.Billing.Coins)
+	assert.Equal(t, 0.06, result.Billing.Revenue)
+	assert.Equal(t, 0.028, *result.Billing.Cost)
+
+	_, err = channel.Test(context.Background(), ch, "not-exist", "")
+	assert.True(t, err != nil)
+}
+
+func TestEnsureLoaded(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		// 模拟模型加载耗时
+		time.Sleep(200 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id":"chatcmpl-1","model":"llama3","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"length"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`)
+	}))
+	defer server.Close()
+
+	channel.Load([]repo.Channel{
+		{
+			ID:     21,
+			Name:   "ollama",
+			Type:   repo.ChannelTypeOpenAI,
+			Server: server.URL + "/v1",
+			Models: []repo.ChannelModel{{ID: "llama3", KeepAlive: true}, {ID: "qwen2"}},
+			Status: repo.ChannelStatusEnabled,
+		},
+	})
+	defer channel.Load(nil)
+
+	m, ok := channel.Lookup("llama3")
+	assert.True(t, ok)
+	assert.False(t, m.Warm())
+
+	// 加载期间的请求排队等待同一个预热请求
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, m.EnsureLoaded(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+	assert.True(t, m.Warm())
+
+	assert.NoError(t, m.EnsureLoaded(context.Background()))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+
+	// 未开启保持加载的模型不预热
+	m, ok = channel.Lookup("qwen2")
+	assert.True(t, ok)
+	assert.True(t, m.Warm())
+	channel.KeepAlive(context.Background(), time.Minute)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,64 @@
+package channel
+
+import (
+	"context"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// reloadInterval 自定义渠道的重新加载周期，多实例部署时其它实例通过定时加载感知渠道的变更
+const reloadInterval = time.Minute
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(NewLoader)
+
+	for _, c := range Collectors() {
+		if err := prometheus.Register(c); err != nil {
+			log.Errorf("register prometheus metric failed: %v", err)
+		}
+	}
+}
+
+// Daemon 定时从数据库加载自定义渠道，并为开启了保持加载的模型定时发送预热请求
+func (Provider) Daemon(ctx context.Context, resolver infra.Resolver) {
+	resolver.MustResolve(func(loader *Loader, conf *config.Config) {
+		if conf.ChannelKeepAliveInterval > 0 {
+			go keepAliveLoop(ctx, conf.ChannelKeepAliveInterval)
+		}
+
+		ticker := time.NewTicker(reloadInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := loader.Reload(ctx); err != nil {
+				log.Errorf("reload channels failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+func keepAliveLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			KeepAlive(ctx, interval)
+		}
+	}
+}
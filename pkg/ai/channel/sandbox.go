@@ -0,0 +1,225 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/sashabaranov/go-openai"
+)
+
+// DefaultTestPrompt 渠道测试默认使用的提示语
+const DefaultTestPrompt = "你好，请用一句话介绍你自己。"
+
+const (
+	// testTimeout 渠道测试请求的超时时间
+	testTimeout = 60 * time.Second
+	// testMaxBodySize 测试结果中保留的原始请求、响应内容的最大长度
+	testMaxBodySize = 64 * 1024
+)
+
+// TestResult 渠道测试结果
+type TestResult struct {
+	Model         string `json:"model"`
+	UpstreamModel string `json:"upstream_model"`
+	// Request 发送到上游的原始请求
+	Request TestHTTPMessage `json:"request"`
+	// Response 上游返回的原始响应，请求未能发出时为空
+	Response *TestHTTPMessage `json:"response,omitempty"`
+	// Latency 请求耗时（毫秒）
+	Latency int64 `json:"latency"`
+	// Reply 模型回复的内容
+	Reply string `json:"reply,omitempty"`
+	// Error 请求失败的原因
+	Error   string      `json:"error,omitempty"`
+	Billing TestBilling `json:"billing"`
+}
+
+// TestHTTPMessage 原始的 HTTP 请求或响应
+type TestHTTPMessage struct {
+	Method     string            `json:"method,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	StatusCode int               `json:"status_code,omitempty"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
+// TestBilling 按照渠道当前配置计算的计费结果
+type TestBilling struct {
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+	// Coins 用户需要支付的智慧果数量
+	Coins int64 `json:"coins"`
+	// DefaultPrice 模型没有配置价格，按照默认价格计费
+	DefaultPrice bool `json:"default_price,omitempty"`
+	// Revenue 用户支付的智慧果对应的金额（人民币）
+	Revenue float64 `json:"revenue"`
+	// Cost 上游成本（人民币），模型没有配置上游价格时为空
+	Cost *float64 `json:"cost,omitempty"`
+}
+
+// Billing 按照渠道模型的配置计算请求的计费结果
+func Billing(m repo.ChannelModel, inputTokens, outputTokens int64) TestBilling {
+	billing := TestBilling{InputTokens: inputTokens, OutputTokens: outputTokens}
+	if m.Price > 0 {
+		billing.Coins = int64(math.Ceil(float64(m.Price) * float64(inputTokens+outputTokens) / 1000.0))
+	} else {
+		billing.Coins, billing.DefaultPrice = coins.GetOpenAITextCoins(m.ID, inputTokens+outputTokens), true
+	}
+
+	billing.Revenue = coins.CoinsToPrice(billing.Coins)
+	if m.UpstreamPrice != nil {
+		cost := (m.UpstreamPrice.Input*float64(inputTokens) + m.UpstreamPrice.Output*float64(outputTokens)) / 1000
+		billing.Cost = &cost
+	}
+
+	return billing
+}
+
+// recorder 记录经过的原始 HTTP 请求和响应
+type recorder struct {
+	transport http.RoundTripper
+	request   *TestHTTPMessage
+	response  *TestHTTPMessage
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.request = &TestHTTPMessage{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: recordHeaders(req.Header),
+		Body:    truncateBody(reqBody),
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.response = &TestHTTPMessage{
+		StatusCode: resp.StatusCode,
+		Headers:    recordHeaders(resp.Header),
+		Body:       truncateBody(respBody),
+	}
+
+	return resp, nil
+}
+
+// readBody 读取完整的请求体或响应体，并替换为可以重复读取的内容
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func truncateBody(body []byte) string {
+	if len(body) > testMaxBodySize {
+		return string(body[:testMaxBodySize]) + "...(truncated)"
+	}
+
+	return string(body)
+}
+
+// recordHeaders 记录 HTTP 头，认证相关的头会被隐藏
+func recordHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for k := range header {
+		switch strings.ToLower(k) {
+		case "authorization", "api-key", "x-api-key", "cookie", "set-cookie":
+			headers[k] = "******"
+		default:
+			headers[k] = header.Get(k)
+		}
+	}
+
+	return headers
+}
+
+// Test 使用测试提示语通过渠道发起一次非流式请求，返回原始的请求、响应以及按照渠道配置计算的计费结果，
+// 测试请求不经过模型路由，也不计入渠道的请求统计，因此可以在渠道验证之前使用
+func Test(ctx context.Context, ch repo.Channel, modelID, prompt string) (*TestResult, error) {
+	if ch.Type != repo.ChannelTypeOpenAI {
+		return nil, errors.New("unsupported channel type")
+	}
+
+	var m *repo.ChannelModel
+	for i := range ch.Models {
+		if ch.Models[i].ID == modelID || modelID == "" {
+			m = &ch.Models[i]
+			break
+		}
+	}
+
+	if m == nil {
+		return nil, fmt.Errorf("model %s not found in channel %d", modelID, ch.ID)
+	}
+
+	if prompt == "" {
+		prompt = DefaultTestPrompt
+	}
+
+	rec := &recorder{transport: http.DefaultTransport}
+	conf := openai.DefaultConfig(ch.Secret)
+	conf.BaseURL = strings.TrimSuffix(ch.Server, "/")
+	conf.HTTPClient = &http.Client{Timeout: testTimeout, Transport: rec}
+
+	ctx, cancel := context.WithTimeout(ctx, testTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	resp, err := openai.NewClientWithConfig(conf).CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:    m.UpstreamModel(),
+		Messages: []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: prompt}},
+	})
+
+	result := &TestResult{
+		Model:         m.ID,
+		UpstreamModel: m.UpstreamModel(),
+		Response:      rec.response,
+		Latency:       time.Since(startTime).Milliseconds(),
+	}
+
+	if rec.request != nil {
+		result.Request = *rec.request
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		result.Billing = Billing(*m, 0, 0)
+		return result, nil
+	}
+
+	if len(resp.Choices) > 0 {
+		result.Reply = resp.Choices[0].Message.Content
+	}
+
+	result.Billing = Billing(*m, int64(resp.Usage.PromptTokens), int64(resp.Usage.CompletionTokens))
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package channel
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// statsWindow 统计延迟和错误率的滚动时间窗口，窗口外的样本不再参与路由决策
+	statsWindow = 5 * time.Minute
+	// statsMaxSamples 每个渠道模型最多保留的样本数量
+	statsMaxSamples = 200
+	// statsMinSamples 样本数量少于该值时优先选择该渠道，以便尽快获得足够的样本
+	statsMinSamples = 5
+	// unhealthyErrorRate 错误率达到该值时认为渠道不可用，只有所有渠道都不可用时才会被选择
+	unhealthyErrorRate = 0.5
+	// errorRatePenalty 错误率对路由得分的放大系数
+	errorRatePenalty = 4
+)
+
+var (
+	latencyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "aidea",
+		Name:      "channel_latency_p95_seconds",
+		Help:      "自定义渠道模型最近 5 分钟的 p95 延迟（流式请求为首个响应的延迟）",
+	}, []string{"channel", "model"})
+	errorRateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "aidea",
+		Name:      "channel_error_rate",
+		Help:      "自定义渠道模型最近 5 分钟的错误率",
+	}, []string{"channel", "model"})
+	requestCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "aidea",
+		Name:      "channel_requests_total",
+		Help:      "自定义渠道模型的请求次数",
+	}, []string{"channel", "model", "status"})
+)
+
+// Collectors 渠道路由相关的监控指标
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{latencyGauge, errorRateGauge, requestCounter}
+}
+
+type sample struct {
+	at      time.Time
+	latency time.Duration
+	failed  bool
+}
+
+type modelStats struct {
+	lock    sync.Mutex
+	samples []sample
+}
+
+// Stats 渠道模型在滚动时间窗口内的请求统计
+type Stats struct {
+	Samples    int           `json:"samples"`
+	P95Latency time.Duration `json:"p95_latency"`
+	ErrorRate  float64       `json:"error_rate"`
+}
+
+// Healthy 渠道错误率是否低于不可用阈值
+func (s Stats) Healthy() bool {
+	return s.ErrorRate < unhealthyErrorRate
+}
+
+// Score 路由得分，得分越低越优先，样本不足时得分为 0
+func (s Stats) Score() float64 {
+	if s.Samples < statsMinSamples {
+		return 0
+	}
+
+	score := float64(s.P95Latency.Milliseconds()) * (1 + errorRatePenalty*s.ErrorRate)
+	if !s.Healthy() {
+		score += math.MaxInt32
+	}
+
+	return score
+}
+
+func (st *modelStats) record(now time.Time, latency time.Duration, failed bool) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.samples = append(st.samples, sample{at: now, latency: latency, failed: failed})
+	if len(st.samples) > statsMaxSamples {
+		st.samples = st.samples[len(st.samples)-statsMaxSamples:]
+	}
+}
+
+func (st *modelStats) snapshot(now time.Time) Stats {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	// 清理滚动窗口之外的样本
+	idx := sort.Search(len(st.samples), func(i int) bool { return now.Sub(st.samples[i].at) < statsWindow })
+	st.samples = st.samples[idx:]
+
+	if len(st.samples) == 0 {
+		return Stats{}
+	}
+
+	latencies := make([]time.Duration, 0, len(st.samples))
+	var failed int
+	for _, s := range st.samples {
+		if s.failed {
+			failed++
+			continue
+		}
+
+		latencies = append(latencies, s.latency)
+	}
+
+	res := Stats{Samples: len(st.samples), ErrorRate: float64(failed) / float64(len(st.samples))}
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		res.P95Latency = latencies[int(math.Ceil(float64(len(latencies))*0.95))-1]
+	}
+
+	return res
+}
+
+var statsRegistry sync.Map
+
+func statsKey(channelID int64, model string) string {
+	return fmt.Sprintf("%d:%s", channelID, model)
+}
+
+func statsOf(channelID int64, model string) *modelStats {
+	st, _ := statsRegistry.LoadOrStore(statsKey(channelID, model), &modelStats{})
+	return st.(*modelStats)
+}
+
+// Record 记录渠道模型的一次请求结果，同时更新监控指标
+func Record(channelID int64, model string, latency time.Duration, failed bool) {
+	now := time.Now()
+	st := statsOf(channelID, model)
+	st.record(now, latency, failed)
+
+	snapshot := st.snapshot(now)
+	channel := strconv.Itoa(int(channelID))
+	latencyGauge.WithLabelValues(channel, model).Set(snapshot.P95Latency.Seconds())
+	errorRateGauge.WithLabelValues(channel, model).Set(snapshot.ErrorRate)
+
+	status := "success"
+	if failed {
+		status = "failed"
+	} else {
+		markActive(channelID, model)
+	}
+	requestCounter.WithLabelValues(channel, model, status).Inc()
+}
+
+// StatsOf 查询渠道模型当前的请求统计
+func StatsOf(channelID int64, model string) Stats {
+	return statsOf(channelID, model).snapshot(time.Now())
+}
@@ -0,0 +1,169 @@
+package channel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/asteria/log"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	// warmTTL 模型最近一次成功响应后，在该时间内认为模型仍处于加载状态，与 Ollama 默认的 keep_alive 一致
+	warmTTL = 5 * time.Minute
+	// defaultLoadTimeout 等待模型加载完成的默认超时时间
+	defaultLoadTimeout = 2 * time.Minute
+	// warmupPrompt 预热请求使用的提示语，只要求模型输出 1 个 Token
+	warmupPrompt = "hi"
+)
+
+// warmState 自托管模型的加载状态
+type warmState struct {
+	lock       sync.Mutex
+	lastActive time.Time
+	// loading 正在加载时不为空，加载完成后关闭
+	loading chan struct{}
+	loadErr error
+}
+
+var warmRegistry sync.Map
+
+func warmStateOf(channelID int64, model string) *warmState {
+	st, _ := warmRegistry.LoadOrStore(statsKey(channelID, model), &warmState{})
+	return st.(*warmState)
+}
+
+// markActive 记录模型成功响应的时间
+func markActive(channelID int64, model string) {
+	st := warmStateOf(channelID, model)
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.lastActive = time.Now()
+}
+
+// LoadTimeout 等待模型加载完成的最长时间
+func (m Model) LoadTimeout() time.Duration {
+	if m.ChannelModel.LoadTimeout > 0 {
+		return time.Duration(m.ChannelModel.LoadTimeout) * time.Second
+	}
+
+	return defaultLoadTimeout
+}
+
+// Warm 模型是否处于加载状态，未开启保持加载的模型总是认为已加载
+func (m Model) Warm() bool {
+	if !m.KeepAlive {
+		return true
+	}
+
+	st := warmStateOf(m.ChannelID, m.ID)
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return time.Since(st.lastActive) < warmTTL
+}
+
+// EnsureLoaded 模型未加载时发送预热请求并等待加载完成，同一模型同时只会发送一个预热请求，
+// 加载期间的其它请求排队等待同一个加载结果，避免每个请求都因为等待模型加载而超时
+func (m Model) EnsureLoaded(ctx context.Context) error {
+	if !m.KeepAlive {
+		return nil
+	}
+
+	return m.warmup(ctx, warmTTL)
+}
+
+// warmup 模型在 staleAfter 时间内没有成功响应时发送预热请求
+func (m Model) warmup(ctx context.Context, staleAfter time.Duration) error {
+	st := warmStateOf(m.ChannelID, m.ID)
+
+	st.lock.Lock()
+	if time.Since(st.lastActive) < staleAfter {
+		st.lock.Unlock()
+		return nil
+	}
+
+	loading := st.loading
+	if loading == nil {
+		loading = make(chan struct{})
+		st.loading = loading
+
+		// 预热请求不使用调用方的上下文，调用方取消请求时，排队等待的其它请求仍然可以使用加载结果
+		go m.load(st, loading)
+	}
+	st.lock.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-loading:
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return st.loadErr
+}
+
+func (m Model) load(st *warmState, loading chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.LoadTimeout())
+	defer cancel()
+
+	startTime := time.Now()
+	_, err := m.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:     m.UpstreamModel(),
+		Messages:  []openai.ChatCompletionMessage{{Role: openai.ChatMessageRoleUser, Content: warmupPrompt}},
+		MaxTokens: 1,
+	})
+	if err != nil {
+		log.F(log.M{"channel": m.ChannelName, "model": m.ID}).Errorf("预热自托管模型失败: %v", err)
+	} else {
+		log.F(log.M{"channel": m.ChannelName, "model": m.ID, "elapse": time.Since(startTime).String()}).Debugf("预热自托管模型完成")
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if err == nil {
+		st.lastActive = time.Now()
+	}
+
+	st.loadErr = err
+	st.loading = nil
+	close(loading)
+}
+
+// KeepAlive 为所有开启了保持加载的模型发送预热请求，interval 时间内有成功响应的模型不再预热
+func KeepAlive(ctx context.Context, interval time.Duration) {
+	var wg sync.WaitGroup
+	for _, m := range allModels() {
+		if !m.KeepAlive {
+			continue
+		}
+
+		wg.Add(1)
+		go func(m Model) {
+			defer wg.Done()
+			_ = m.warmup(ctx, interval)
+		}(m)
+	}
+
+	wg.Wait()
+}
+
+// allModels 返回所有渠道提供的模型，提供同一模型的多个渠道全部返回
+func allModels() []Model {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	models := make([]Model, 0, len(registry))
+	for _, items := range registry {
+		models = append(models, items...)
+	}
+
+	return models
+}
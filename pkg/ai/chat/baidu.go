@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/ai/baidu"
+	"github.com/mylxsw/asteria/log"
 	"strings"
 )
 
@@ -71,6 +72,11 @@ func (chat *BaiduAIChat) Chat(ctx context.Context, req Request) (*Response, erro
 		return nil, err
 	}
 
+	if res.NeedClearHistory {
+		log.WithFields(log.Fields{"model": req.Model, "ban_round": res.BanRound}).Errorf("违反文心千帆内容安全策略")
+		return nil, ErrContentFilter
+	}
+
 	if res.ErrorCode != 0 {
 		return nil, fmt.Errorf("baidu ai chat error: [%d] %s", res.ErrorCode, res.ErrorMessage)
 	}
@@ -104,10 +110,25 @@ func (chat *BaiduAIChat) ChatStream(ctx context.Context, req Request) (<-chan Re
 					return
 				}
 
+				// 输入或输出内容存在安全风险
+				if data.NeedClearHistory {
+					log.WithFields(log.Fields{"model": req.Model, "room_id": req.RoomID, "ban_round": data.BanRound}).Errorf("违反文心千帆内容安全策略")
+					select {
+					case <-ctx.Done():
+					case res <- Response{ErrorCode: ErrorCodeContentFilter}:
+					}
+					return
+				}
+
 				if data.ErrorCode != 0 {
+					errMsg := data.ErrorMessage
+					if data.ErrorCode == baidu.ErrorCodeQPSLimit || data.ErrorCode == baidu.ErrorCodeRPMLimit || data.ErrorCode == baidu.ErrorCodeTPMLimit {
+						errMsg = "请求频率过高，请稍后再试"
+					}
+
 					select {
 					case <-ctx.Done():
-					case res <- Response{Error: data.ErrorMessage, ErrorCode: fmt.Sprintf("ERR%d", data.ErrorCode)}:
+					case res <- Response{Error: errMsg, ErrorCode: fmt.Sprintf("ERR%d", data.ErrorCode)}:
 					}
 					return
 				}
@@ -133,7 +154,7 @@ func (chat *BaiduAIChat) MaxContextLength(model string) int {
 	case baidu.ModelErnieBot:
 		// https://cloud.baidu.com/doc/WENXINWORKSHOP/s/jlil56u11
 		return 3000
-	case baidu.ModelErnieBotTurbo:
+	case baidu.ModelErnieBotTurbo, baidu.ModelErnieBot8K:
 		// https://cloud.baidu.com/doc/WENXINWORKSHOP/s/4lilb2lpf
 		return 7000
 	case baidu.ModelLlama2_70b, baidu.ModelLlama2_13b:
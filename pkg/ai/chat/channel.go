@@ -0,0 +1,222 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/channel"
+	oai "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/sashabaranov/go-openai"
+)
+
+// ErrChannelModelNotFound 自定义渠道中不存在请求的模型，通常是渠道已被禁用或删除
+var ErrChannelModelNotFound = errors.New("channel model not found")
+
+// ChannelChat 管理员自定义的 OpenAI 兼容渠道
+type ChannelChat struct{}
+
+func NewChannelChat() *ChannelChat {
+	return &ChannelChat{}
+}
+
+func (chat *ChannelChat) initRequest(req Request) (channel.Model, *openai.ChatCompletionRequest, error) {
+	m, ok := channel.Lookup(req.Model)
+	if !ok {
+		return m, nil, ErrChannelModelNotFound
+	}
+
+	var systemMessages []openai.ChatCompletionMessage
+	var contextMessages []openai.ChatCompletionMessage
+
+	for _, msg := range req.Messages {
+		m := openai.ChatCompletionMessage{
+			Role:    msg.Role,
+			Content: msg.Content,
+		}
+
+		if msg.Role == "system" {
+			systemMessages = append(systemMessages, m)
+		} else {
+			contextMessages = append(contextMessages, m)
+		}
+	}
+
+	msgs, _, err := oai.ReduceChatCompletionMessages(
+		contextMessages,
+		m.UpstreamModel(),
+		chat.MaxContextLength(req.Model),
+	)
+	if err != nil {
+		return m, nil, err
+	}
+
+	return m, &openai.ChatCompletionRequest{
+		Model:          m.UpstreamModel(),
+		Messages:       append(systemMessages, msgs...),
+		MaxTokens:      req.MaxTokens,
+		ResponseFormat: req.openAIResponseFormat(),
+	}, nil
+}
+
+func (chat *ChannelChat) Chat(ctx context.Context, req Request) (*Response, error) {
+	m, openaiReq, err := chat.initRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.EnsureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	res, err := m.Chat(ctx, *openaiReq)
+	if err != nil {
+		if strings.Contains(err.Error(), "content management policy") {
+			log.With(err).Errorf("违反上游渠道 %s 的内容管理策略", m.ChannelName)
+			return nil, ErrContentFilter
+		}
+
+		return nil, err
+	}
+
+	return &Response{
+		Text: array.Reduce(
+			res.Choices,
+			func(carry string, item openai.ChatCompletionChoice) string {
+				return carry + "\n" + item.Message.Content
+			},
+			"",
+		),
+		InputTokens:  res.Usage.PromptTokens,
+		OutputTokens: res.Usage.CompletionTokens,
+	}, nil
+}
+
+func (chat *ChannelChat) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	m, openaiReq, err := chat.initRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	openaiReq.Stream = true
+
+	// 自托管模型未加载时，先通知调用方模型正在加载，加载完成后再发起请求
+	if !m.Warm() {
+		return chat.chatStreamAfterLoaded(ctx, m, req, *openaiReq), nil
+	}
+
+	return chat.chatStream(ctx, m, req, *openaiReq)
+}
+
+func (chat *ChannelChat) chatStreamAfterLoaded(ctx context.Context, m channel.Model, req Request, openaiReq openai.ChatCompletionRequest) <-chan Response {
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+
+		select {
+		case <-ctx.Done():
+			return
+		case res <- Response{Loading: true}:
+		}
+
+		stream, err := func() (<-chan Response, error) {
+			if err := m.EnsureLoaded(ctx); err != nil {
+				return nil, err
+			}
+
+			return chat.chatStream(ctx, m, req, openaiReq)
+		}()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.F(log.M{"channel": m.ChannelName, "model": m.ID}).Errorf("自托管模型加载后请求失败: %v", err)
+
+			errRes := Response{ErrorCode: ErrorCodeModelLoadFailed, Error: "模型加载失败，请稍后再试"}
+			if errors.Is(err, ErrContentFilter) {
+				errRes = Response{ErrorCode: ErrorCodeContentFilter}
+			}
+
+			select {
+			case <-ctx.Done():
+			case res <- errRes:
+			}
+			return
+		}
+
+		for data := range stream {
+			select {
+			case <-ctx.Done():
+				return
+			case res <- data:
+			}
+		}
+	}()
+
+	return res
+}
+
+func (chat *ChannelChat) chatStream(ctx context.Context, m channel.Model, req Request, openaiReq openai.ChatCompletionRequest) (<-chan Response, error) {
+	stream, err := m.ChatStream(ctx, openaiReq)
+	if err != nil {
+		if strings.Contains(err.Error(), "content management policy") {
+			log.WithFields(log.Fields{
+				"error":   err,
+				"message": req.assembleMessage(),
+				"model":   req.Model,
+				"channel": m.ChannelName,
+				"room_id": req.RoomID,
+			}).Errorf("违反上游渠道的内容管理策略")
+			return nil, ErrContentFilter
+		}
+
+		return nil, err
+	}
+
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok := <-stream:
+				if !ok {
+					return
+				}
+
+				if data.Code != "" {
+					res <- Response{
+						Error:     data.ErrorMessage,
+						ErrorCode: data.Code,
+					}
+					return
+				}
+
+				res <- Response{
+					Text: array.Reduce(
+						data.ChatResponse.Choices,
+						func(carry string, item openai.ChatCompletionStreamChoice) string {
+							return carry + item.Delta.Content
+						},
+						"",
+					),
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (chat *ChannelChat) MaxContextLength(model string) int {
+	if m, ok := channel.Lookup(model); ok && m.MaxContext > 0 {
+		return m.MaxContext
+	}
+
+	return 4000
+}
@@ -0,0 +1,176 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/sashabaranov/go-openai"
+)
+
+// 故障注入类型
+const (
+	// ChaosFaultTimeout 服务商长时间无响应
+	ChaosFaultTimeout = "timeout"
+	// ChaosFaultRateLimit 服务商返回 429 请求过于频繁
+	ChaosFaultRateLimit = "rate_limit"
+	// ChaosFaultMalformed 服务商返回无法解析的 SSE 数据
+	ChaosFaultMalformed = "malformed_sse"
+	// ChaosFaultTruncated 服务商在输出过程中断开连接，没有返回结束标识
+	ChaosFaultTruncated = "truncated"
+)
+
+// ChaosFaults 支持注入的全部故障类型
+var ChaosFaults = []string{ChaosFaultTimeout, ChaosFaultRateLimit, ChaosFaultMalformed, ChaosFaultTruncated}
+
+// chaosTimeoutWait 注入超时故障时的最长等待时间，请求的 context 先结束时提前返回
+const chaosTimeoutWait = 2 * time.Minute
+
+// ChaosChat 故障注入，按照指定的概率让服务商随机返回超时、429、异常的 SSE 数据以及被截断的响应，
+// 用于在测试环境中验证重试、计费以及多模型并发等流程的容错能力，禁止在生产环境中启用
+type ChaosChat struct {
+	next   Chat
+	rate   float64
+	faults []string
+}
+
+// NewChaosChat 创建故障注入的聊天实现，rate 为注入故障的概率，faults 为空时注入全部类型的故障
+func NewChaosChat(next Chat, rate float64, faults []string) *ChaosChat {
+	faults = array.Filter(faults, func(f string, _ int) bool { return array.In(f, ChaosFaults) })
+	if len(faults) == 0 {
+		faults = ChaosFaults
+	}
+
+	return &ChaosChat{next: next, rate: rate, faults: faults}
+}
+
+// pick 随机选择一个要注入的故障，返回空表示本次请求不注入故障
+func (ai *ChaosChat) pick(req Request, candidates []string) string {
+	if ai.rate <= 0 || rand.Float64() >= ai.rate {
+		return ""
+	}
+
+	faults := array.Filter(ai.faults, func(f string, _ int) bool { return array.In(f, candidates) })
+	if len(faults) == 0 {
+		return ""
+	}
+
+	fault := faults[rand.Intn(len(faults))]
+	log.F(log.M{"model": req.Model, "room_id": req.RoomID}).Warningf("chaos: inject fault %s", fault)
+
+	return fault
+}
+
+func chaosRateLimitError() error {
+	return &openai.APIError{
+		Code:           "rate_limit_exceeded",
+		Type:           "requests",
+		Message:        "chaos: Rate limit reached, please try again later",
+		HTTPStatusCode: http.StatusTooManyRequests,
+	}
+}
+
+func chaosWait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(chaosTimeoutWait):
+		return fmt.Errorf("chaos: %w", context.DeadlineExceeded)
+	}
+}
+
+func (ai *ChaosChat) Chat(ctx context.Context, req Request) (*Response, error) {
+	switch ai.pick(req, []string{ChaosFaultTimeout, ChaosFaultRateLimit, ChaosFaultMalformed}) {
+	case ChaosFaultTimeout:
+		return nil, chaosWait(ctx)
+	case ChaosFaultRateLimit:
+		return nil, chaosRateLimitError()
+	case ChaosFaultMalformed:
+		return nil, fmt.Errorf("chaos: invalid character '<' looking for beginning of value")
+	}
+
+	return ai.next.Chat(ctx, req)
+}
+
+func (ai *ChaosChat) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	fault := ai.pick(req, ChaosFaults)
+	switch fault {
+	case ChaosFaultRateLimit:
+		return nil, chaosRateLimitError()
+	case ChaosFaultTimeout:
+		// 不返回任何数据，直到请求结束
+		res := make(chan Response)
+		go func() {
+			defer close(res)
+			_ = chaosWait(ctx)
+		}()
+
+		return res, nil
+	case "":
+		return ai.next.ChatStream(ctx, req)
+	}
+
+	upstreamCtx, cancel := context.WithCancel(ctx)
+	stream, err := ai.next.ChatStream(upstreamCtx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// 正常输出若干个分片后，再返回异常的数据或者直接断开连接
+	keep := 1 + rand.Intn(5)
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+		defer func() {
+			cancel()
+			// 部分服务商的实现在发送数据时不检查 context，需要读完剩余的数据，避免 goroutine 泄露
+			go func() {
+				for range stream {
+				}
+			}()
+		}()
+
+		for i := 0; i < keep; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok := <-stream:
+				if !ok {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case res <- data:
+				}
+
+				// 上游已经结束时不再注入故障
+				if data.FinishReason != "" || data.ErrorCode != "" {
+					return
+				}
+			}
+		}
+
+		if fault == ChaosFaultMalformed {
+			select {
+			case <-ctx.Done():
+			case res <- Response{
+				ErrorCode: "READ_STREAM_FAILED",
+				Error:     "read stream failed: chaos: invalid character 'd' looking for beginning of value",
+			}:
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (ai *ChaosChat) MaxContextLength(model string) int {
+	return ai.next.MaxContextLength(model)
+}
@@ -0,0 +1,80 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/go-utils/assert"
+	"github.com/sashabaranov/go-openai"
+)
+
+type chaosTestClient struct {
+	ChatTestClient
+	chunks int
+}
+
+func (c chaosTestClient) Chat(ctx context.Context, req Request) (*Response, error) {
+	return &Response{Text: "ok"}, nil
+}
+
+func (c chaosTestClient) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+		for i := 0; i < c.chunks; i++ {
+			res <- Response{Text: "x"}
+		}
+		res <- Response{FinishReason: "stop"}
+	}()
+
+	return res, nil
+}
+
+func collectChaosStream(t *testing.T, ai Chat) []Response {
+	stream, err := ai.ChatStream(context.TODO(), Request{Model: "gpt-3.5-turbo"})
+	assert.NoError(t, err)
+
+	var items []Response
+	for item := range stream {
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func TestChaosChat(t *testing.T) {
+	next := chaosTestClient{chunks: 20}
+
+	// 概率为 0 时不注入故障
+	items := collectChaosStream(t, NewChaosChat(next, 0, nil))
+	assert.Equal(t, 21, len(items))
+	assert.Equal(t, "stop", items[20].FinishReason)
+
+	_, err := NewChaosChat(next, 1, []string{ChaosFaultRateLimit}).Chat(context.TODO(), Request{})
+	var apiErr *openai.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.HTTPStatusCode)
+
+	items = collectChaosStream(t, NewChaosChat(next, 1, []string{ChaosFaultTruncated}))
+	assert.True(t, len(items) >= 1 && len(items) <= 5)
+	assert.Equal(t, "", items[len(items)-1].FinishReason)
+
+	items = collectChaosStream(t, NewChaosChat(next, 1, []string{ChaosFaultMalformed}))
+	assert.Equal(t, "READ_STREAM_FAILED", items[len(items)-1].ErrorCode)
+
+	// 上游在截断之前已经结束时，原样返回
+	items = collectChaosStream(t, NewChaosChat(chaosTestClient{}, 1, []string{ChaosFaultTruncated}))
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "stop", items[0].FinishReason)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+
+	stream, err := NewChaosChat(next, 1, []string{ChaosFaultTimeout}).ChatStream(ctx, Request{})
+	assert.NoError(t, err)
+	_, ok := <-stream
+	assert.False(t, ok)
+}
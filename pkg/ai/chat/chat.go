@@ -7,14 +7,18 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/anthropic"
 	"github.com/mylxsw/aidea-server/pkg/ai/baichuan"
 	"github.com/mylxsw/aidea-server/pkg/ai/baidu"
+	"github.com/mylxsw/aidea-server/pkg/ai/channel"
 	"github.com/mylxsw/aidea-server/pkg/ai/dashscope"
+	"github.com/mylxsw/aidea-server/pkg/ai/deepseek"
 	"github.com/mylxsw/aidea-server/pkg/ai/google"
 	"github.com/mylxsw/aidea-server/pkg/ai/gpt360"
+	"github.com/mylxsw/aidea-server/pkg/ai/moonshot"
 	"github.com/mylxsw/aidea-server/pkg/ai/openrouter"
 	"github.com/mylxsw/aidea-server/pkg/ai/sensenova"
 	"github.com/mylxsw/aidea-server/pkg/ai/sky"
 	"github.com/mylxsw/aidea-server/pkg/ai/tencentai"
 	"github.com/mylxsw/aidea-server/pkg/ai/xfyun"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
 	"strings"
 
 	"github.com/mylxsw/aidea-server/config"
@@ -26,6 +30,12 @@ var (
 	ErrContentFilter      = errors.New("请求或响应内容包含敏感词")
 )
 
+// ErrorCodeContentFilter 流式响应过程中内容违反服务商的安全策略时使用的错误码
+const ErrorCodeContentFilter = "CONTENT_FILTER"
+
+// ErrorCodeModelLoadFailed 自托管模型加载失败时使用的错误码
+const ErrorCodeModelLoadFailed = "MODEL_LOAD_FAILED"
+
 type Message struct {
 	Role              string              `json:"role"`
 	Content           string              `json:"content"`
@@ -37,6 +47,8 @@ type MultipartContent struct {
 	Type     string    `json:"type"`
 	ImageURL *ImageURL `json:"image_url,omitempty"`
 	Text     string    `json:"text,omitempty"`
+	// FileID 文件 ID，type 为 file 时有效，目前只有 Moonshot 支持
+	FileID string `json:"file_id,omitempty"`
 }
 
 type ImageURL struct {
@@ -114,6 +126,8 @@ type Request struct {
 	Messages  Messages `json:"messages"`
 	MaxTokens int      `json:"max_tokens,omitempty"`
 	N         int      `json:"n,omitempty"` // 复用作为 room_id
+	// ResponseFormat 结构化输出格式，为空时输出普通文本
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 
 	// 业务定制字段
 	RoomID    int64 `json:"-"`
@@ -213,6 +227,8 @@ type Response struct {
 	FinishReason string `json:"finish_reason,omitempty"`
 	InputTokens  int    `json:"input_tokens,omitempty"`
 	OutputTokens int    `json:"output_tokens,omitempty"`
+	// Loading 模型正在加载，加载完成前不会有输出，调用方应该延长等待时间并提示用户
+	Loading bool `json:"loading,omitempty"`
 }
 
 type Chat interface {
@@ -239,6 +255,10 @@ type Imp struct {
 	gai         *GoogleChat
 	openrouter  *OpenRouterChat
 	sky         *SkyChat
+	deepSeek    *DeepSeekChat
+	moonshot    *MoonshotChat
+	zhipu       *ZhipuChat
+	channel     *ChannelChat
 }
 
 func NewChat(
@@ -256,6 +276,10 @@ func NewChat(
 	gai *GoogleChat,
 	openr *OpenRouterChat,
 	sky *SkyChat,
+	deepSeek *DeepSeekChat,
+	moonshot *MoonshotChat,
+	zhipuAI *ZhipuChat,
+	ch *ChannelChat,
 ) Chat {
 	var virtualImpl Chat
 	impLowercase := strings.ToLower(conf.VirtualModel.Implementation)
@@ -284,6 +308,12 @@ func NewChat(
 		virtualImpl = gai
 	case "sky":
 		virtualImpl = sky
+	case "deepseek":
+		virtualImpl = deepSeek
+	case "moonshot", "kimi":
+		virtualImpl = moonshot
+	case "zhipu", "智谱":
+		virtualImpl = zhipuAI
 	default:
 		if openrouter.SupportModel(impLowercase) {
 			virtualImpl = openr
@@ -307,6 +337,10 @@ func NewChat(
 		gai:         gai,
 		openrouter:  openr,
 		sky:         sky,
+		deepSeek:    deepSeek,
+		moonshot:    moonshot,
+		zhipu:       zhipuAI,
+		channel:     ch,
 	}
 }
 
@@ -363,11 +397,28 @@ func (ai *Imp) selectImp(model string) Chat {
 		return ai.sky
 	}
 
+	if strings.HasPrefix(model, "deepseek:") {
+		return ai.deepSeek
+	}
+
+	if strings.HasPrefix(model, "moonshot:") {
+		return ai.moonshot
+	}
+
+	if strings.HasPrefix(model, "zhipu:") {
+		return ai.zhipu
+	}
+
+	if strings.HasPrefix(model, channel.Prefix) {
+		return ai.channel
+	}
+
 	// TODO 根据模型名称判断使用哪个 AI
 	switch model {
 	case string(baidu.ModelErnieBot),
 		baidu.ModelErnieBotTurbo,
 		baidu.ModelErnieBot4,
+		baidu.ModelErnieBot8K,
 		baidu.ModelAquilaChat7B,
 		baidu.ModelChatGLM2_6B_32K,
 		baidu.ModelBloomz7B,
@@ -378,7 +429,7 @@ func (ai *Imp) selectImp(model string) Chat {
 		return ai.baiduAI
 	case dashscope.ModelQWenV1, dashscope.ModelQWenPlusV1,
 		dashscope.ModelQWen7BV1, dashscope.ModelQWen7BChatV1,
-		dashscope.ModelQWenMax, dashscope.ModelQWenMaxLongContext, dashscope.ModelQWenVLPlus,
+		dashscope.ModelQWenMax, dashscope.ModelQWenMaxLongContext, dashscope.ModelQWenVLPlus, dashscope.ModelQWenVLMax,
 		dashscope.ModelQWenTurbo, dashscope.ModelQWenPlus, dashscope.ModelBaiChuan7BChatV1,
 		dashscope.ModelQWen7BChat, dashscope.ModelQWen14BChat:
 		// 阿里灵积平台
@@ -389,7 +440,7 @@ func (ai *Imp) selectImp(model string) Chat {
 	case string(sensenova.ModelNovaPtcXLV1), string(sensenova.ModelNovaPtcXSV1):
 		// 商汤日日新
 		return ai.snAI
-	case tencentai.ModelHyllm:
+	case tencentai.ModelHyllm, tencentai.ModelHunyuanStd, tencentai.ModelHunyuanPro:
 		// 腾讯混元大模型
 		return ai.tencentAI
 	case string(anthropic.ModelClaude2), string(anthropic.ModelClaudeInstant):
@@ -411,7 +462,18 @@ func (ai *Imp) selectImp(model string) Chat {
 		return ai.gai
 	case sky.ModelSkyChatMegaVerse:
 		return ai.sky
+	case deepseek.ModelDeepSeekChat, deepseek.ModelDeepSeekCoder:
+		return ai.deepSeek
+	case moonshot.ModelMoonshotV1_8K, moonshot.ModelMoonshotV1_32K, moonshot.ModelMoonshotV1_128K:
+		return ai.moonshot
+	case zhipu.ModelGLM4, zhipu.ModelGLM4V, zhipu.ModelGLM3Turbo:
+		return ai.zhipu
 	default:
+		// 自定义渠道的模型优先于 OpenRouter，管理员可以使用自定义渠道覆盖同名模型
+		if _, ok := channel.Lookup(model); ok {
+			return ai.channel
+		}
+
 		if openrouter.SupportModel(model) {
 			return ai.openrouter
 		}
@@ -421,10 +483,18 @@ func (ai *Imp) selectImp(model string) Chat {
 }
 
 func (ai *Imp) Chat(ctx context.Context, req Request) (*Response, error) {
-	return ai.selectImp(req.Model).Chat(ctx, req)
+	if ModelDisabled(req.Model) {
+		return nil, ErrModelDisabled
+	}
+
+	return ai.selectImp(req.Model).Chat(ctx, req.withJSONModeInstruction())
 }
 
 func (ai *Imp) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	if ModelDisabled(req.Model) {
+		return nil, ErrModelDisabled
+	}
+
 	// TODO 这里是临时解决方案
 	// 使用微软的 Azure OpenAI 接口时，聊天内容只有“继续”两个字时，会触发风控，导致无法继续对话
 	req.Messages = array.Map(req.Messages, func(item Message, _ int) Message {
@@ -436,7 +506,7 @@ func (ai *Imp) ChatStream(ctx context.Context, req Request) (<-chan Response, er
 		return item
 	})
 
-	return ai.selectImp(req.Model).ChatStream(ctx, req)
+	return ai.selectImp(req.Model).ChatStream(ctx, req.withJSONModeInstruction())
 }
 
 func (ai *Imp) MaxContextLength(model string) int {
@@ -2,6 +2,7 @@ package chat
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/mylxsw/asteria/log"
@@ -280,3 +281,24 @@ func TestBaiduAIChat_InitRequest(t *testing.T) {
 	}
 
 }
+
+func TestExtractJSON(t *testing.T) {
+	schema, err := Request{ResponseFormat: &ResponseFormat{
+		Type:       ResponseFormatJSONSchema,
+		JSONSchema: &JSONSchema{Schema: []byte(`{"type": "object", "required": ["answer"]}`)},
+	}}.ParseResponseFormat()
+	assert.NoError(t, err)
+
+	data, err := ExtractJSON("```json\n{\"answer\": 42}\n```", schema)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"answer":42}`, string(data))
+
+	_, err = ExtractJSON(`{"result": 42}`, schema)
+	assert.True(t, errors.Is(err, ErrInvalidJSONResponse))
+
+	_, err = ExtractJSON(`答案是 42`, schema)
+	assert.True(t, errors.Is(err, ErrInvalidJSONResponse))
+
+	_, err = Request{ResponseFormat: &ResponseFormat{Type: ResponseFormatJSONSchema}}.ParseResponseFormat()
+	assert.True(t, err != nil)
+}
@@ -0,0 +1,148 @@
+package chat
+
+import (
+	"context"
+)
+
+// ContentFilter 部署方自定义的内容过滤规则，例如关键词过滤
+type ContentFilter interface {
+	// Prompt 过滤用户输入，返回打码后的内容，blocked 表示命中了拦截规则
+	//
+	// 用户输入的命中统计在请求入口处记录，这里不再重复记录
+	Prompt(ctx context.Context, content string) (masked string, blocked bool)
+	// Output 过滤模型输出，返回打码后的内容，blocked 表示命中了拦截规则，record 为 true 时记录命中统计
+	Output(ctx context.Context, content string, record bool) (masked string, blocked bool)
+}
+
+// FilteredChat 对所有模型请求的输入与输出执行内容过滤，覆盖聊天、群聊、角色扮演、写作工具、异步任务以及开放 API 等所有调用模型的功能
+type FilteredChat struct {
+	next   Chat
+	filter ContentFilter
+}
+
+// NewFilteredChat 创建执行内容过滤的聊天实现
+func NewFilteredChat(next Chat, filter ContentFilter) *FilteredChat {
+	return &FilteredChat{next: next, filter: filter}
+}
+
+// filterPrompt 过滤最后一条用户消息，命中拦截规则时返回 ErrContentFilter
+func (ai *FilteredChat) filterPrompt(ctx context.Context, req Request) (Request, error) {
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role != "user" {
+			continue
+		}
+
+		masked, blocked := ai.filter.Prompt(ctx, req.Messages[i].Content)
+		if blocked {
+			return req, ErrContentFilter
+		}
+
+		if masked != req.Messages[i].Content {
+			// 复制消息列表，避免修改调用方持有的请求
+			messages := make(Messages, len(req.Messages))
+			copy(messages, req.Messages)
+			messages[i].Content = masked
+			req.Messages = messages
+		}
+
+		break
+	}
+
+	return req, nil
+}
+
+func (ai *FilteredChat) Chat(ctx context.Context, req Request) (*Response, error) {
+	req, err := ai.filterPrompt(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ai.next.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	masked, blocked := ai.filter.Output(ctx, res.Text, true)
+	if blocked {
+		return nil, ErrContentFilter
+	}
+
+	res.Text = masked
+	return res, nil
+}
+
+func (ai *FilteredChat) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	req, err := ai.filterPrompt(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	upstreamCtx, cancel := context.WithCancel(ctx)
+	stream, err := ai.next.ChatStream(upstreamCtx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+		defer func() {
+			cancel()
+			// 部分服务商的实现在发送数据时不检查 context，需要读完剩余的数据，避免 goroutine 泄露
+			go func() {
+				for range stream {
+				}
+			}()
+		}()
+
+		var replyText string
+		// 输出结束时记录完整输出的命中统计
+		defer func() {
+			if replyText != "" {
+				ai.filter.Output(ctx, replyText, true)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok := <-stream:
+				if !ok {
+					return
+				}
+
+				if data.ErrorCode == "" && data.Text != "" {
+					data.Text, _ = ai.filter.Output(ctx, data.Text, false)
+
+					// 关键词可能跨越多个分片，需要检查已经输出的全部内容，命中拦截规则时中断输出
+					if _, blocked := ai.filter.Output(ctx, replyText+data.Text, false); blocked {
+						replyText += data.Text
+
+						select {
+						case <-ctx.Done():
+						case res <- Response{ErrorCode: ErrorCodeContentFilter, Error: ErrContentFilter.Error()}:
+						}
+
+						return
+					}
+
+					replyText += data.Text
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case res <- data:
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (ai *FilteredChat) MaxContextLength(model string) int {
+	return ai.next.MaxContextLength(model)
+}
@@ -0,0 +1,111 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+type filterTestClient struct {
+	ChatTestClient
+	chunks []string
+	req    *Request
+}
+
+func (c filterTestClient) Chat(ctx context.Context, req Request) (*Response, error) {
+	*c.req = req
+	return &Response{Text: strings.Join(c.chunks, "")}, nil
+}
+
+func (c filterTestClient) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	*c.req = req
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+		for _, chunk := range c.chunks {
+			select {
+			case <-ctx.Done():
+				return
+			case res <- Response{Text: chunk}:
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+// keywordTestFilter 包含 secret 的内容打码，包含 forbidden 的内容拦截
+type keywordTestFilter struct {
+	lock     sync.Mutex
+	recorded []string
+}
+
+func (f *keywordTestFilter) apply(content string) (string, bool) {
+	return strings.ReplaceAll(content, "secret", "******"), strings.Contains(content, "forbidden")
+}
+
+func (f *keywordTestFilter) Prompt(ctx context.Context, content string) (string, bool) {
+	return f.apply(content)
+}
+
+func (f *keywordTestFilter) Output(ctx context.Context, content string, record bool) (string, bool) {
+	if record {
+		f.lock.Lock()
+		f.recorded = append(f.recorded, content)
+		f.lock.Unlock()
+	}
+
+	return f.apply(content)
+}
+
+func TestFilteredChat(t *testing.T) {
+	var req Request
+	filter := &keywordTestFilter{}
+	messages := Messages{{Role: "user", Content: "tell me the secret"}, {Role: "assistant", Content: "secret"}}
+
+	ai := NewFilteredChat(filterTestClient{chunks: []string{"the ", "secret"}, req: &req}, filter)
+	res, err := ai.Chat(context.TODO(), Request{Messages: messages})
+	assert.NoError(t, err)
+	assert.Equal(t, "the ******", res.Text)
+	assert.Equal(t, "tell me the ******", req.Messages[0].Content)
+	assert.Equal(t, "secret", req.Messages[1].Content)
+	// 不修改调用方持有的消息列表
+	assert.Equal(t, "tell me the secret", messages[0].Content)
+
+	_, err = ai.Chat(context.TODO(), Request{Messages: Messages{{Role: "user", Content: "forbidden"}}})
+	assert.True(t, errors.Is(err, ErrContentFilter))
+
+	_, err = NewFilteredChat(filterTestClient{chunks: []string{"forbidden"}, req: &req}, filter).Chat(context.TODO(), Request{Messages: messages})
+	assert.True(t, errors.Is(err, ErrContentFilter))
+
+	_, err = ai.ChatStream(context.TODO(), Request{Messages: Messages{{Role: "user", Content: "forbidden"}}})
+	assert.True(t, errors.Is(err, ErrContentFilter))
+}
+
+func TestFilteredChatStream(t *testing.T) {
+	var req Request
+	filter := &keywordTestFilter{}
+
+	stream, err := NewFilteredChat(filterTestClient{chunks: []string{"a secret ", "for", "bidden", " word"}, req: &req}, filter).
+		ChatStream(context.TODO(), Request{Messages: Messages{{Role: "user", Content: "hello"}}})
+	assert.NoError(t, err)
+
+	var items []Response
+	for item := range stream {
+		items = append(items, item)
+	}
+
+	// 拦截规则跨越多个分片时，在命中的分片处中断输出
+	assert.Equal(t, 3, len(items))
+	assert.Equal(t, "a ****** ", items[0].Text)
+	assert.Equal(t, "for", items[1].Text)
+	assert.Equal(t, ErrorCodeContentFilter, items[2].ErrorCode)
+
+	filter.lock.Lock()
+	defer filter.lock.Unlock()
+	assert.Equal(t, []string{"a ****** forbidden"}, filter.recorded)
+}
@@ -63,7 +63,7 @@ func (ds *DashScopeChat) initRequest(req Request) dashscope.ChatRequest {
 
 	input := dashscope.ChatInput{}
 
-	if req.Model == dashscope.ModelQWenVLPlus {
+	if dashscope.IsVisionModel(req.Model) {
 		input.Messages = array.Map(contextMessages, func(msg Message, _ int) dashscope.Message {
 			contents := make([]dashscope.MessageContent, 0)
 			if len(msg.MultipartContents) == 0 {
@@ -149,6 +149,10 @@ func (ds *DashScopeChat) Chat(ctx context.Context, req Request) (*Response, erro
 		return nil, err
 	}
 
+	if resp.Code == "DataInspectionFailed" {
+		return nil, ErrContentFilter
+	}
+
 	if resp.Code != "" {
 		return nil, fmt.Errorf("dashscope chat error: [%s] %s", resp.Code, resp.Message)
 	}
@@ -208,7 +212,7 @@ func (ds *DashScopeChat) ChatStream(ctx context.Context, req Request) (<-chan Re
 
 func (ds *DashScopeChat) MaxContextLength(model string) int {
 	switch model {
-	case dashscope.ModelQWenV1, dashscope.ModelQWenTurbo, dashscope.ModelQWenVLPlus,
+	case dashscope.ModelQWenV1, dashscope.ModelQWenTurbo, dashscope.ModelQWenVLPlus, dashscope.ModelQWenVLMax,
 		dashscope.ModelQWenPlusV1, dashscope.ModelQWenPlus, dashscope.ModelQWenMax,
 		dashscope.ModelQWen7BChat, dashscope.ModelQWen14BChat:
 		// https://help.aliyun.com/zh/dashscope/developer-reference/api-details?disableWebsiteRedirect=true
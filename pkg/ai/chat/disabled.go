@@ -0,0 +1,40 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrModelDisabled 模型已被管理员禁用
+var ErrModelDisabled = errors.New("当前模型暂不可用，请选择其它模型")
+
+var (
+	disabledLock   sync.RWMutex
+	disabledModels = map[string]bool{}
+)
+
+// SetDisabledModels 设置被管理员禁用的模型，模型 ID 格式与 Model.ID 相同，例如 openai:gpt-4
+func SetDisabledModels(ids []string) {
+	models := make(map[string]bool, len(ids)*2)
+	for _, id := range ids {
+		models[id] = true
+		// 调用服务商接口时使用的是不带分类前缀的模型 ID
+		if segs := strings.SplitN(id, ":", 2); len(segs) == 2 {
+			models[segs[1]] = true
+		}
+	}
+
+	disabledLock.Lock()
+	defer disabledLock.Unlock()
+
+	disabledModels = models
+}
+
+// ModelDisabled 模型是否已被管理员禁用，支持 Model.ID 与不带分类前缀的模型 ID
+func ModelDisabled(id string) bool {
+	disabledLock.RLock()
+	defer disabledLock.RUnlock()
+
+	return disabledModels[id]
+}
@@ -0,0 +1,128 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/jsonschema"
+	"github.com/sashabaranov/go-openai"
+)
+
+// 结构化输出的响应格式
+const (
+	// ResponseFormatText 普通文本输出
+	ResponseFormatText = "text"
+	// ResponseFormatJSONObject 输出任意 JSON 对象
+	ResponseFormatJSONObject = "json_object"
+	// ResponseFormatJSONSchema 输出符合 JSON Schema 的 JSON
+	ResponseFormatJSONSchema = "json_schema"
+)
+
+// ErrInvalidJSONResponse 模型的输出不是合法的 JSON 或不符合 JSON Schema
+var ErrInvalidJSONResponse = errors.New("模型输出的内容不符合要求的 JSON 格式")
+
+// ResponseFormat 结构化输出的格式，与 OpenAI 的 response_format 参数保持一致
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema 结构化输出需要满足的 JSON Schema
+type JSONSchema struct {
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+// JSONMode 请求是否要求模型输出 JSON
+func (req Request) JSONMode() bool {
+	return req.ResponseFormat != nil && (req.ResponseFormat.Type == ResponseFormatJSONObject || req.ResponseFormat.Type == ResponseFormatJSONSchema)
+}
+
+// ParseResponseFormat 检查请求的输出格式，返回需要满足的 JSON Schema，json_object 格式返回的 Schema 只要求输出为对象
+func (req Request) ParseResponseFormat() (*jsonschema.Schema, error) {
+	if req.ResponseFormat == nil {
+		return nil, nil
+	}
+
+	switch req.ResponseFormat.Type {
+	case "", ResponseFormatText:
+		return nil, nil
+	case ResponseFormatJSONObject:
+		return &jsonschema.Schema{Type: jsonschema.Types{"object"}}, nil
+	case ResponseFormatJSONSchema:
+		if req.ResponseFormat.JSONSchema == nil || len(req.ResponseFormat.JSONSchema.Schema) == 0 {
+			return nil, errors.New("json_schema 格式必须提供 schema")
+		}
+
+		return jsonschema.Parse(req.ResponseFormat.JSONSchema.Schema)
+	}
+
+	return nil, fmt.Errorf("不支持的输出格式：%s", req.ResponseFormat.Type)
+}
+
+// jsonModeInstruction 对于不支持结构化输出参数的服务商，通过系统提示语约束输出格式
+func (req Request) jsonModeInstruction() string {
+	if req.ResponseFormat.Type == ResponseFormatJSONSchema && req.ResponseFormat.JSONSchema != nil {
+		var schema bytes.Buffer
+		if err := json.Compact(&schema, req.ResponseFormat.JSONSchema.Schema); err != nil {
+			schema.Write(req.ResponseFormat.JSONSchema.Schema)
+		}
+
+		return "你必须只输出一个符合下面 JSON Schema 的 JSON，不要使用 Markdown 代码块，也不要输出任何解释说明。\nJSON Schema：" + schema.String()
+	}
+
+	return "你必须只输出一个 JSON 对象，不要使用 Markdown 代码块，也不要输出任何解释说明。"
+}
+
+// withJSONModeInstruction 在系统消息中追加结构化输出的约束
+func (req Request) withJSONModeInstruction() Request {
+	if !req.JSONMode() {
+		return req
+	}
+
+	instruction := req.jsonModeInstruction()
+	messages := make(Messages, 0, len(req.Messages)+1)
+	messages = append(messages, Message{Role: "system", Content: instruction})
+	messages = append(messages, req.Messages...)
+	req.Messages = messages
+
+	return req
+}
+
+// openAIResponseFormat OpenAI 兼容接口的 response_format 参数，json_schema 格式由系统提示语约束，这里统一使用 json_object
+func (req Request) openAIResponseFormat() *openai.ChatCompletionResponseFormat {
+	if !req.JSONMode() {
+		return nil
+	}
+
+	return &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+}
+
+// ExtractJSON 从模型的输出中解析 JSON 并使用 Schema 校验，兼容模型使用 Markdown 代码块包裹输出的情况，返回紧凑格式的 JSON
+func ExtractJSON(text string, schema *jsonschema.Schema) (json.RawMessage, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "```"))
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(text)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSONResponse, err)
+	}
+
+	if schema != nil {
+		var value any
+		_ = json.Unmarshal(compacted.Bytes(), &value)
+		if err := schema.Validate(value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJSONResponse, err)
+		}
+	}
+
+	return compacted.Bytes(), nil
+}
@@ -4,12 +4,17 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/anthropic"
 	"github.com/mylxsw/aidea-server/pkg/ai/baichuan"
 	"github.com/mylxsw/aidea-server/pkg/ai/baidu"
+	"github.com/mylxsw/aidea-server/pkg/ai/channel"
 	"github.com/mylxsw/aidea-server/pkg/ai/dashscope"
+	"github.com/mylxsw/aidea-server/pkg/ai/deepseek"
 	"github.com/mylxsw/aidea-server/pkg/ai/google"
 	"github.com/mylxsw/aidea-server/pkg/ai/gpt360"
+	"github.com/mylxsw/aidea-server/pkg/ai/moonshot"
+	"github.com/mylxsw/aidea-server/pkg/ai/openrouter"
 	"github.com/mylxsw/aidea-server/pkg/ai/sensenova"
 	"github.com/mylxsw/aidea-server/pkg/ai/tencentai"
 	"github.com/mylxsw/aidea-server/pkg/ai/xfyun"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
 	"github.com/mylxsw/go-utils/str"
 	"strings"
 
@@ -32,6 +37,9 @@ type Model struct {
 
 	IsChat        bool `json:"is_chat"`
 	SupportVision bool `json:"support_vision,omitempty"`
+
+	// TrialLeft 当前用户剩余的免费试用次数
+	TrialLeft int64 `json:"trial_left,omitempty"`
 }
 
 func (m Model) RealID() string {
@@ -54,6 +62,7 @@ func Models(conf *config.Config, returnAll bool) []Model {
 	models = append(models, googleModels(conf)...)
 	models = append(models, chinaModels(conf)...)
 	models = append(models, aideaModels(conf)...)
+	models = append(models, channelModels(models)...)
 
 	return array.Filter(
 		array.Map(models, func(item Model, _ int) Model {
@@ -61,6 +70,10 @@ func Models(conf *config.Config, returnAll bool) []Model {
 				item.ShortName = item.Name
 			}
 
+			if ModelDisabled(item.ID) {
+				item.Disabled = true
+			}
+
 			return item
 		}),
 		func(item Model, _ int) bool {
@@ -73,6 +86,57 @@ func Models(conf *config.Config, returnAll bool) []Model {
 	)
 }
 
+// openRouterCatalogModels 从 OpenRouter 同步的模型，已经存在的模型不重复添加
+func openRouterCatalogModels(existing []Model) []Model {
+	ids := array.ToMap(existing, func(m Model, _ int) string { return m.ID })
+
+	results := make([]Model, 0)
+	for _, item := range openrouter.CatalogModels() {
+		id := "openrouter:" + item.CatalogID()
+		if _, ok := ids[id]; ok {
+			continue
+		}
+
+		results = append(results, Model{
+			ID:            id,
+			Name:          item.Name,
+			Description:   item.Description,
+			Category:      "openrouter",
+			IsChat:        true,
+			VersionMin:    "1.0.5",
+			SupportVision: item.SupportVision(),
+		})
+	}
+
+	return results
+}
+
+// channelModels 管理员自定义渠道提供的模型，已经存在的模型不重复添加
+func channelModels(existing []Model) []Model {
+	ids := array.ToMap(existing, func(m Model, _ int) string { return m.ID })
+
+	results := make([]Model, 0)
+	for _, item := range channel.Models() {
+		id := channel.Prefix + item.ID
+		if _, ok := ids[id]; ok {
+			continue
+		}
+
+		results = append(results, Model{
+			ID:            id,
+			Name:          item.Name,
+			Description:   item.Description,
+			Category:      "channel",
+			IsChat:        true,
+			VersionMin:    "1.0.5",
+			AvatarURL:     item.AvatarURL,
+			SupportVision: item.SupportVision,
+		})
+	}
+
+	return results
+}
+
 func openAIModels(conf *config.Config) []Model {
 	return []Model{
 		{
@@ -196,6 +260,17 @@ func chinaModels(conf *config.Config) []Model {
 		VersionMin:  "1.0.3",
 		AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/creative/wenxinyiyan.png",
 	})
+	models = append(models, Model{
+		ID:          "文心千帆:" + baidu.ModelErnieBot8K,
+		Name:        "文心一言 8K",
+		ShortName:   "文心 8K",
+		Description: "ERNIE-Bot-8K 在文心一言的基础上支持 8K 上下文，适合长文本的问答、总结和创作",
+		Category:    "文心千帆",
+		IsChat:      true,
+		Disabled:    !conf.EnableBaiduWXAI,
+		VersionMin:  "1.0.3",
+		AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/creative/wenxinyiyan.png",
+	})
 	models = append(models, Model{
 		ID:          "文心千帆:" + string(baidu.ModelErnieBot4),
 		Name:        "文心一言 4.0",
@@ -331,6 +406,18 @@ func chinaModels(conf *config.Config) []Model {
 			SupportVision: true,
 			AvatarURL:     "https://ssl.aicode.cc/ai-server/assets/avatar/qwen-vlplus.jpeg",
 		})
+		models = append(models, Model{
+			ID:            "灵积:" + dashscope.ModelQWenVLMax,
+			Name:          "通义千问（视觉增强）",
+			ShortName:     "千问 VL Max",
+			Description:   "通义千问超大规模视觉语言模型，相比 VL Plus 进一步提升视觉推理和指令遵循能力，能够识别更多图像细节",
+			Category:      "灵积",
+			IsChat:        true,
+			Disabled:      !conf.EnableDashScopeAI,
+			VersionMin:    "1.0.8",
+			SupportVision: true,
+			AvatarURL:     "https://ssl.aicode.cc/ai-server/assets/avatar/qwen-vlplus.jpeg",
+		})
 		models = append(models, Model{
 			ID:          "灵积:" + dashscope.ModelQWen7BChat,
 			Name:        "通义千问 7B",
@@ -400,6 +487,28 @@ func chinaModels(conf *config.Config) []Model {
 		VersionMin:  "1.0.5",
 		AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/hunyuan.png",
 	})
+	models = append(models, Model{
+		ID:          "腾讯:" + tencentai.ModelHunyuanStd,
+		Name:        "混元大模型标准版",
+		ShortName:   "混元标准版",
+		Description: "腾讯混元大模型标准版，通过腾讯云 API 调用，响应速度更快，性价比更高",
+		Category:    "腾讯",
+		IsChat:      true,
+		Disabled:    !conf.EnableTencentAI,
+		VersionMin:  "1.0.5",
+		AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/hunyuan.png",
+	})
+	models = append(models, Model{
+		ID:          "腾讯:" + tencentai.ModelHunyuanPro,
+		Name:        "混元大模型高级版",
+		ShortName:   "混元高级版",
+		Description: "腾讯混元大模型高级版，通过腾讯云 API 调用，具备更强的中文创作、逻辑推理和任务执行能力",
+		Category:    "腾讯",
+		IsChat:      true,
+		Disabled:    !conf.EnableTencentAI,
+		VersionMin:  "1.0.5",
+		AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/hunyuan.png",
+	})
 
 	models = append(models, Model{
 		ID:          "百川:" + baichuan.ModelBaichuan2_53B,
@@ -494,6 +603,10 @@ func chinaModels(conf *config.Config) []Model {
 			VersionMin:  "1.0.5",
 			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/yi-01.png",
 		})
+
+		if conf.OpenRouterSyncModels {
+			models = append(models, openRouterCatalogModels(models)...)
+		}
 	}
 
 	if conf.EnableSky {
@@ -509,6 +622,96 @@ func chinaModels(conf *config.Config) []Model {
 		})
 	}
 
+	if conf.EnableDeepSeek {
+		models = append(models, Model{
+			ID:          "deepseek:" + deepseek.ModelDeepSeekChat,
+			Name:        "DeepSeek Chat",
+			ShortName:   "DeepSeek",
+			Description: "深度求索研发的通用大语言模型，支持 32K 上下文，中英文能力出色",
+			Category:    "deepseek",
+			IsChat:      true,
+			VersionMin:  "1.0.5",
+			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/deepseek.png",
+		})
+		models = append(models, Model{
+			ID:          "deepseek:" + deepseek.ModelDeepSeekCoder,
+			Name:        "DeepSeek Coder",
+			ShortName:   "DeepSeek Coder",
+			Description: "深度求索研发的代码大模型，擅长代码生成、补全与解释，支持 16K 上下文",
+			Category:    "deepseek",
+			IsChat:      true,
+			VersionMin:  "1.0.5",
+			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/deepseek.png",
+		})
+	}
+
+	if conf.EnableMoonshot {
+		models = append(models, Model{
+			ID:          "moonshot:" + moonshot.ModelMoonshotV1_8K,
+			Name:        "Kimi 8K",
+			ShortName:   "Kimi",
+			Description: "月之暗面研发的大语言模型，支持 8K 上下文，适合日常对话",
+			Category:    "moonshot",
+			IsChat:      true,
+			VersionMin:  "1.0.5",
+			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/moonshot.png",
+		})
+		models = append(models, Model{
+			ID:          "moonshot:" + moonshot.ModelMoonshotV1_32K,
+			Name:        "Kimi 32K",
+			ShortName:   "Kimi 32K",
+			Description: "月之暗面研发的大语言模型，支持 32K 上下文，按实际使用的上下文长度分档计费",
+			Category:    "moonshot",
+			IsChat:      true,
+			VersionMin:  "1.0.5",
+			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/moonshot.png",
+		})
+		models = append(models, Model{
+			ID:          "moonshot:" + moonshot.ModelMoonshotV1_128K,
+			Name:        "Kimi 128K",
+			ShortName:   "Kimi 128K",
+			Description: "月之暗面研发的超长上下文大语言模型，支持 128K 上下文和文档问答，按实际使用的上下文长度分档计费",
+			Category:    "moonshot",
+			IsChat:      true,
+			VersionMin:  "1.0.5",
+			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/moonshot.png",
+		})
+	}
+
+	if conf.EnableZhipu {
+		models = append(models, Model{
+			ID:          "zhipu:" + zhipu.ModelGLM4,
+			Name:        "智谱 GLM-4",
+			ShortName:   "GLM-4",
+			Description: "智谱 AI 新一代基座大模型，支持 128K 上下文和工具调用，整体性能相比上一代大幅提升",
+			Category:    "zhipu",
+			IsChat:      true,
+			VersionMin:  "1.0.5",
+			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/zhipu.png",
+		})
+		models = append(models, Model{
+			ID:            "zhipu:" + zhipu.ModelGLM4V,
+			Name:          "智谱 GLM-4V（视觉）",
+			ShortName:     "GLM-4V",
+			Description:   "智谱 AI 的多模态大模型，支持图片理解",
+			Category:      "zhipu",
+			IsChat:        true,
+			SupportVision: true,
+			VersionMin:    "1.0.8",
+			AvatarURL:     "https://ssl.aicode.cc/ai-server/assets/avatar/zhipu.png",
+		})
+		models = append(models, Model{
+			ID:          "zhipu:" + zhipu.ModelGLM3Turbo,
+			Name:        "智谱 GLM-3 Turbo",
+			ShortName:   "GLM-3",
+			Description: "智谱 AI 的高性价比大模型，适合日常对话",
+			Category:    "zhipu",
+			IsChat:      true,
+			VersionMin:  "1.0.5",
+			AvatarURL:   "https://ssl.aicode.cc/ai-server/assets/avatar/zhipu.png",
+		})
+	}
+
 	return models
 }
 
@@ -0,0 +1,181 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/moonshot"
+	oai "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+	"github.com/sashabaranov/go-openai"
+)
+
+// moonshotOutputReserve 选择模型档位时为输出预留的 Token 数
+const moonshotOutputReserve = 1024
+
+type MoonshotChat struct {
+	ms *moonshot.Moonshot
+}
+
+func NewMoonshotChat(ms *moonshot.Moonshot) *MoonshotChat {
+	return &MoonshotChat{ms: ms}
+}
+
+// fileMessages 将消息中引用的文件转换为 system 消息，Moonshot 使用 system 消息承载文件内容
+func (chat *MoonshotChat) fileMessages(ctx context.Context, messages Messages) ([]openai.ChatCompletionMessage, error) {
+	fileMessages := make([]openai.ChatCompletionMessage, 0)
+	for _, msg := range messages {
+		for _, part := range msg.MultipartContents {
+			if part.Type != "file" || part.FileID == "" {
+				continue
+			}
+
+			content, err := chat.ms.FileContent(ctx, part.FileID)
+			if err != nil {
+				return nil, fmt.Errorf("query moonshot file content failed: %w", err)
+			}
+
+			fileMessages = append(fileMessages, openai.ChatCompletionMessage{
+				Role:    "system",
+				Content: content.Content,
+			})
+		}
+	}
+
+	return fileMessages, nil
+}
+
+func (chat *MoonshotChat) initRequest(ctx context.Context, req Request) (*openai.ChatCompletionRequest, error) {
+	req.Model = strings.TrimPrefix(req.Model, "moonshot:")
+
+	systemMessages, err := chat.fileMessages(ctx, req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	var contextMessages []openai.ChatCompletionMessage
+	for _, msg := range req.Messages {
+		content := msg.Content
+		if content == "" {
+			content = array.Reduce(msg.MultipartContents, func(carry string, item *MultipartContent) string {
+				return carry + item.Text
+			}, "")
+		}
+
+		m := openai.ChatCompletionMessage{Role: msg.Role, Content: content}
+		if msg.Role == "system" {
+			systemMessages = append(systemMessages, m)
+		} else {
+			contextMessages = append(contextMessages, m)
+		}
+	}
+
+	systemTokens, err := oai.NumTokensFromMessages(systemMessages, req.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, tokenCount, err := oai.ReduceChatCompletionMessages(
+		contextMessages,
+		req.Model,
+		chat.MaxContextLength(req.Model)-systemTokens,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 长上下文模型按档位计费，根据实际上下文长度选择能够容纳的最小档位
+	reserve := ternary.If(req.MaxTokens > 0, req.MaxTokens, moonshotOutputReserve)
+	model := moonshot.SelectModel(req.Model, systemTokens+tokenCount+reserve)
+	if model != req.Model {
+		log.F(log.M{"model": req.Model, "selected": model, "tokens": systemTokens + tokenCount}).Debugf("moonshot model tier selected")
+	}
+
+	return &openai.ChatCompletionRequest{
+		Model:          model,
+		Messages:       append(systemMessages, msgs...),
+		MaxTokens:      req.MaxTokens,
+		ResponseFormat: req.openAIResponseFormat(),
+	}, nil
+}
+
+func (chat *MoonshotChat) Chat(ctx context.Context, req Request) (*Response, error) {
+	openaiReq, err := chat.initRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := chat.ms.Chat(ctx, *openaiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		Text: array.Reduce(
+			res.Choices,
+			func(carry string, item openai.ChatCompletionChoice) string {
+				return carry + "\n" + item.Message.Content
+			},
+			"",
+		),
+		InputTokens:  res.Usage.PromptTokens,
+		OutputTokens: res.Usage.CompletionTokens,
+	}, nil
+}
+
+func (chat *MoonshotChat) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	openaiReq, err := chat.initRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	openaiReq.Stream = true
+
+	stream, err := chat.ms.ChatStream(ctx, *openaiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok := <-stream:
+				if !ok {
+					return
+				}
+
+				if data.Code != "" {
+					res <- Response{
+						Error:     data.ErrorMessage,
+						ErrorCode: data.Code,
+					}
+					return
+				}
+
+				res <- Response{
+					Text: array.Reduce(
+						data.ChatResponse.Choices,
+						func(carry string, item openai.ChatCompletionStreamChoice) string {
+							return carry + item.Delta.Content
+						},
+						"",
+					),
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (chat *MoonshotChat) MaxContextLength(model string) int {
+	return moonshot.MaxContextSize(strings.TrimPrefix(model, "moonshot:")) - moonshotOutputReserve
+}
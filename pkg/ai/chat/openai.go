@@ -84,9 +84,10 @@ func (chat *OpenAIChat) initRequest(req Request) (*openai.ChatCompletionRequest,
 	req.Model = openai2.SelectBestModel(req.Model, tokenCount)
 
 	return &openai.ChatCompletionRequest{
-		Model:     req.Model,
-		Messages:  messages,
-		MaxTokens: req.MaxTokens,
+		Model:          req.Model,
+		Messages:       messages,
+		MaxTokens:      req.MaxTokens,
+		ResponseFormat: req.openAIResponseFormat(),
 	}, nil
 }
 
@@ -50,9 +50,10 @@ func (chat *OpenRouterChat) initRequest(req Request) (*openai.ChatCompletionRequ
 	req.Model = oai.SelectBestModel(req.Model, tokenCount)
 
 	return &openai.ChatCompletionRequest{
-		Model:     req.Model,
-		Messages:  messages,
-		MaxTokens: req.MaxTokens,
+		Model:          req.Model,
+		Messages:       messages,
+		MaxTokens:      req.MaxTokens,
+		ResponseFormat: req.openAIResponseFormat(),
 	}, nil
 }
 
@@ -147,5 +148,10 @@ func (chat *OpenRouterChat) ChatStream(ctx context.Context, req Request) (<-chan
 }
 
 func (chat *OpenRouterChat) MaxContextLength(model string) int {
+	// 同步的模型使用 OpenRouter 返回的上下文长度，预留部分 Token 给模型输出
+	if m, ok := openrouter.CatalogModel(model); ok && m.ContextLength > 8000 {
+		return m.ContextLength - 4000
+	}
+
 	return 4000
 }
@@ -1,13 +1,18 @@
 package chat
 
 import (
+	"context"
+	"time"
+
 	"github.com/mylxsw/aidea-server/config"
 	"github.com/mylxsw/aidea-server/pkg/ai/anthropic"
 	"github.com/mylxsw/aidea-server/pkg/ai/baichuan"
 	"github.com/mylxsw/aidea-server/pkg/ai/baidu"
 	"github.com/mylxsw/aidea-server/pkg/ai/dashscope"
+	"github.com/mylxsw/aidea-server/pkg/ai/deepseek"
 	"github.com/mylxsw/aidea-server/pkg/ai/google"
 	"github.com/mylxsw/aidea-server/pkg/ai/gpt360"
+	"github.com/mylxsw/aidea-server/pkg/ai/moonshot"
 	"github.com/mylxsw/aidea-server/pkg/ai/oneapi"
 	"github.com/mylxsw/aidea-server/pkg/ai/openai"
 	"github.com/mylxsw/aidea-server/pkg/ai/openrouter"
@@ -15,10 +20,17 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/sky"
 	"github.com/mylxsw/aidea-server/pkg/ai/tencentai"
 	"github.com/mylxsw/aidea-server/pkg/ai/xfyun"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
 	"github.com/mylxsw/aidea-server/pkg/file"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
 )
 
+// disabledReloadInterval 禁用模型列表的重新加载周期，多实例部署时其它实例通过定时加载感知变更
+const disabledReloadInterval = time.Minute
+
 type Provider struct{}
 
 func (Provider) Register(binder infra.Binder) {
@@ -37,9 +49,14 @@ func (Provider) Register(binder infra.Binder) {
 		gai *google.GoogleAI,
 		openRouter *openrouter.OpenRouter,
 		skyChat *sky.Sky,
+		ds2 *deepseek.DeepSeek,
+		ms *moonshot.Moonshot,
+		zp *zhipu.Zhipu,
 		file *file.File,
+		filter ContentFilter,
 	) Chat {
-		return NewChat(
+		var imp Chat
+		imp = NewChat(
 			conf,
 			NewOpenAIChat(oai),
 			NewBaiduAIChat(bai),
@@ -54,6 +71,49 @@ func (Provider) Register(binder infra.Binder) {
 			NewGoogleChat(gai),
 			NewOpenRouterChat(openRouter),
 			NewSkyChat(skyChat),
+			NewDeepSeekChat(ds2),
+			NewMoonshotChat(ms),
+			NewZhipuChat(zp),
+			NewChannelChat(),
 		)
+
+		if conf.EnableChaos {
+			log.Warningf("chaos: fault injection for chat providers enabled, rate=%.2f, faults=%v", conf.ChaosRate, conf.ChaosFaults)
+			imp = NewChaosChat(imp, conf.ChaosRate, conf.ChaosFaults)
+		}
+
+		// 部署方自定义的关键词过滤，所有调用模型的功能都需要经过过滤
+		return NewFilteredChat(imp, filter)
+	})
+}
+
+// Daemon 定时从数据库加载被管理员禁用的模型
+func (Provider) Daemon(ctx context.Context, resolver infra.Resolver) {
+	resolver.MustResolve(func(rep *repo.Repository) {
+		ticker := time.NewTicker(disabledReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := ReloadDisabledModels(ctx, rep); err != nil {
+				log.Errorf("reload disabled models failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
 	})
 }
+
+// ReloadDisabledModels 从数据库重新加载被禁用的模型
+func ReloadDisabledModels(ctx context.Context, rep *repo.Repository) error {
+	models, err := rep.DisabledModel.DisabledModels(ctx)
+	if err != nil {
+		return err
+	}
+
+	SetDisabledModels(array.Map(models, func(m repo.DisabledModel, _ int) string { return m.ModelID }))
+	return nil
+}
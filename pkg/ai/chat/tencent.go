@@ -2,9 +2,13 @@ package chat
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/ai/tencentai"
+	"github.com/mylxsw/asteria/log"
 	"strings"
+
+	"github.com/mylxsw/go-utils/array"
 )
 
 type TencentAIChat struct {
@@ -16,7 +20,10 @@ func NewTencentAIChat(ai *tencentai.TencentAI) *TencentAIChat {
 }
 
 func (chat *TencentAIChat) initRequest(req Request) tencentai.Request {
-	req.Model = strings.TrimPrefix(req.Model, "腾讯:")
+	return tencentai.NewRequest(chat.contextMessages(req))
+}
+
+func (chat *TencentAIChat) contextMessages(req Request) tencentai.Messages {
 
 	var systemMessages tencentai.Messages
 	var contextMessages tencentai.Messages
@@ -52,10 +59,22 @@ func (chat *TencentAIChat) initRequest(req Request) tencentai.Request {
 		contextMessages = append(finalSystemMessages, contextMessages...)
 	}
 
-	return tencentai.NewRequest(contextMessages)
+	return contextMessages
+}
+
+func (chat *TencentAIChat) initHunyuanRequest(req Request) tencentai.HunyuanRequest {
+	return tencentai.HunyuanRequest{
+		Messages: array.Map(chat.contextMessages(req), func(msg tencentai.Message, _ int) tencentai.HunyuanMessage {
+			return tencentai.HunyuanMessage{Role: msg.Role, Content: msg.Content}
+		}),
+	}
 }
 
 func (chat *TencentAIChat) Chat(ctx context.Context, req Request) (*Response, error) {
+	if tencentai.IsHunyuanModel(strings.TrimPrefix(req.Model, "腾讯:")) {
+		return chat.hunyuanChat(ctx, req)
+	}
+
 	res, err := chat.ai.Chat(ctx, chat.initRequest(req))
 	if err != nil {
 		return nil, err
@@ -73,6 +92,10 @@ func (chat *TencentAIChat) Chat(ctx context.Context, req Request) (*Response, er
 }
 
 func (chat *TencentAIChat) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	if model := strings.TrimPrefix(req.Model, "腾讯:"); tencentai.IsHunyuanModel(model) {
+		return chat.hunyuanChatStream(ctx, model, req)
+	}
+
 	tencentReq := chat.initRequest(req)
 	stream, err := chat.ai.ChatStream(ctx, tencentReq)
 	if err != nil {
@@ -118,6 +141,100 @@ func (chat *TencentAIChat) ChatStream(ctx context.Context, req Request) (<-chan
 	return res, nil
 }
 
+// hunyuanChat 混元接口只支持流式输出，同步请求时合并流式响应的结果
+func (chat *TencentAIChat) hunyuanChat(ctx context.Context, req Request) (*Response, error) {
+	stream, err := chat.ChatStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := Response{}
+	for msg := range stream {
+		if msg.ErrorCode == ErrorCodeContentFilter {
+			return nil, ErrContentFilter
+		}
+
+		if msg.ErrorCode != "" {
+			return nil, fmt.Errorf("tencent hunyuan chat error: [%s] %s", msg.ErrorCode, msg.Error)
+		}
+
+		resp.Text += msg.Text
+		resp.InputTokens, resp.OutputTokens = msg.InputTokens, msg.OutputTokens
+	}
+
+	return &resp, nil
+}
+
+func (chat *TencentAIChat) hunyuanChatStream(ctx context.Context, model string, req Request) (<-chan Response, error) {
+	stream, err := chat.ai.HunyuanChatStream(ctx, model, chat.initHunyuanRequest(req))
+	if err != nil {
+		var apiErr *tencentai.HunyuanAPIError
+		if errors.As(err, &apiErr) {
+			if apiErr.IsRateLimit() {
+				return nil, fmt.Errorf("混元大模型请求频率过高，请稍后再试: %w", err)
+			}
+
+			if apiErr.IsResourceExhausted() {
+				log.With(apiErr).Errorf("腾讯混元大模型资源包已用尽或服务未开通")
+			}
+		}
+
+		return nil, err
+	}
+
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok := <-stream:
+				if !ok {
+					return
+				}
+
+				if data.ErrorMsg != nil && data.ErrorMsg.Code != 0 {
+					select {
+					case <-ctx.Done():
+					case res <- Response{
+						Error:     data.ErrorMsg.Msg,
+						ErrorCode: fmt.Sprintf("ERR%d", data.ErrorMsg.Code),
+					}:
+					}
+					return
+				}
+
+				if len(data.Choices) == 0 {
+					continue
+				}
+
+				if data.Choices[0].FinishReason == tencentai.FinishReasonSensitive {
+					log.WithFields(log.Fields{"model": model, "room_id": req.RoomID, "id": data.Id}).Errorf("违反腾讯混元内容安全策略")
+					select {
+					case <-ctx.Done():
+					case res <- Response{ErrorCode: ErrorCodeContentFilter}:
+					}
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case res <- Response{
+					Text:         data.Choices[0].Delta.Content,
+					InputTokens:  int(data.Usage.PromptTokens),
+					OutputTokens: int(data.Usage.CompletionTokens),
+				}:
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
 func (chat *TencentAIChat) MaxContextLength(model string) int {
 	// https://cloud.tencent.com/document/product/1729/97732
 	return 3000
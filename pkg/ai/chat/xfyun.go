@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/ai/xfyun"
+	"github.com/mylxsw/asteria/log"
 	"strings"
 
 	"github.com/mylxsw/go-utils/array"
@@ -67,16 +68,25 @@ func (chat *XFYunChat) Chat(ctx context.Context, req Request) (*Response, error)
 		return nil, err
 	}
 
-	var content string
+	resp := Response{}
 	for msg := range stream {
+		if msg.ErrorCode == ErrorCodeContentFilter {
+			return nil, ErrContentFilter
+		}
+
 		if msg.ErrorCode != "" {
 			return nil, fmt.Errorf("%s %s", msg.ErrorCode, msg.Error)
 		}
 
-		content += msg.Text
+		resp.Text += msg.Text
+
+		// 只有最后一帧包含 token 用量
+		if msg.InputTokens > 0 || msg.OutputTokens > 0 {
+			resp.InputTokens, resp.OutputTokens = msg.InputTokens, msg.OutputTokens
+		}
 	}
 
-	return &Response{Text: content}, nil
+	return &resp, nil
 }
 
 func (chat *XFYunChat) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
@@ -97,11 +107,21 @@ func (chat *XFYunChat) ChatStream(ctx context.Context, req Request) (<-chan Resp
 		for {
 			select {
 			case <-ctx.Done():
+				return
 			case data, ok := <-stream:
 				if !ok {
 					return
 				}
 
+				if xfyun.IsContentFilterCode(data.Header.Code) {
+					log.WithFields(log.Fields{"model": model, "sid": data.Header.SID, "code": data.Header.Code}).Errorf("违反讯飞星火内容安全策略")
+					select {
+					case <-ctx.Done():
+					case res <- Response{ErrorCode: ErrorCodeContentFilter}:
+					}
+					return
+				}
+
 				if data.Header.Code != 0 {
 					select {
 					case <-ctx.Done():
@@ -113,10 +133,15 @@ func (chat *XFYunChat) ChatStream(ctx context.Context, req Request) (<-chan Resp
 					return
 				}
 
+				text := array.Reduce(data.Payload.Choices.Text, func(carry string, item xfyun.PayloadChoiceText) string {
+					return carry + item.Content
+				}, "")
+
 				select {
 				case <-ctx.Done():
+					return
 				case res <- Response{
-					Text:         data.Payload.Choices.Text[0].Content,
+					Text:         text,
 					InputTokens:  data.Payload.Usage.Text.PromptTokens,
 					OutputTokens: data.Payload.Usage.Text.CompletionTokens,
 				}:
@@ -131,7 +156,7 @@ func (chat *XFYunChat) ChatStream(ctx context.Context, req Request) (<-chan Resp
 func (chat *XFYunChat) MaxContextLength(model string) int {
 	// https://www.xfyun.cn/doc/spark/Web.html#_1-%E6%8E%A5%E5%8F%A3%E8%AF%B4%E6%98%8E
 	switch xfyun.Model(model) {
-	case xfyun.ModelGeneralV2:
+	case xfyun.ModelGeneralV2, xfyun.ModelGeneralV3:
 		return 8000
 	case xfyun.ModelGeneralV1_5:
 		return 4000
@@ -0,0 +1,163 @@
+package chat
+
+import (
+	"context"
+	oai "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/sashabaranov/go-openai"
+	"strings"
+)
+
+type ZhipuChat struct {
+	oai *zhipu.Zhipu
+}
+
+func NewZhipuChat(oai *zhipu.Zhipu) *ZhipuChat {
+	return &ZhipuChat{oai: oai}
+}
+
+func (chat *ZhipuChat) initRequest(req Request) (*openai.ChatCompletionRequest, error) {
+	req.Model = strings.TrimPrefix(req.Model, "zhipu:")
+
+	var systemMessages []openai.ChatCompletionMessage
+	var contextMessages []openai.ChatCompletionMessage
+
+	for _, msg := range req.Messages {
+		m := openai.ChatCompletionMessage{
+			Role:    msg.Role,
+			Content: msg.Content,
+		}
+
+		// GLM-4V 支持图片输入，图片地址直接传递给智谱 AI
+		if len(msg.MultipartContents) > 0 {
+			m.Content = ""
+			m.MultiContent = array.Map(msg.MultipartContents, func(item *MultipartContent, _ int) openai.ChatMessagePart {
+				ret := openai.ChatMessagePart{Text: item.Text, Type: item.Type}
+				if item.Type == "image_url" && item.ImageURL != nil {
+					ret.ImageURL = &openai.ChatMessageImageURL{URL: item.ImageURL.URL}
+				}
+
+				return ret
+			})
+		}
+
+		if msg.Role == "system" {
+			systemMessages = append(systemMessages, m)
+		} else {
+			contextMessages = append(contextMessages, m)
+		}
+	}
+
+	msgs, _, err := oai.ReduceChatCompletionMessages(
+		contextMessages,
+		req.Model,
+		zhipu.MaxContextSize(req.Model),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := append(systemMessages, msgs...)
+
+	return &openai.ChatCompletionRequest{
+		Model:     req.Model,
+		Messages:  messages,
+		MaxTokens: req.MaxTokens,
+	}, nil
+}
+
+func (chat *ZhipuChat) Chat(ctx context.Context, req Request) (*Response, error) {
+	openaiReq, err := chat.initRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := chat.oai.Chat(ctx, *openaiReq)
+	if err != nil {
+		if strings.Contains(err.Error(), "不安全或敏感内容") {
+			log.With(err).Errorf("违反智谱 AI 内容安全策略")
+			return nil, ErrContentFilter
+		}
+
+		return nil, err
+	}
+
+	return &Response{
+		Text: array.Reduce(
+			res.Choices,
+			func(carry string, item openai.ChatCompletionChoice) string {
+				return carry + "\n" + item.Message.Content
+			},
+			"",
+		),
+		InputTokens:  res.Usage.PromptTokens,
+		OutputTokens: res.Usage.CompletionTokens,
+	}, nil
+}
+
+func (chat *ZhipuChat) ChatStream(ctx context.Context, req Request) (<-chan Response, error) {
+	openaiReq, err := chat.initRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	openaiReq.Stream = true
+
+	stream, err := chat.oai.ChatStream(ctx, *openaiReq)
+	if err != nil {
+		if strings.Contains(err.Error(), "不安全或敏感内容") {
+			log.WithFields(log.Fields{
+				"error":   err,
+				"message": req.assembleMessage(),
+				"model":   req.Model,
+				"room_id": req.RoomID,
+			}).Errorf("违反智谱 AI 内容安全策略")
+			return nil, ErrContentFilter
+		}
+
+		return nil, err
+	}
+
+	res := make(chan Response)
+	go func() {
+		defer close(res)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok := <-stream:
+				if !ok {
+					return
+				}
+
+				if data.Code != "" {
+					res <- Response{
+						Error:     data.ErrorMessage,
+						ErrorCode: data.Code,
+					}
+					return
+				}
+
+				res <- Response{
+					Text: array.Reduce(
+						data.ChatResponse.Choices,
+						func(carry string, item openai.ChatCompletionStreamChoice) string {
+							return carry + item.Delta.Content
+						},
+						"",
+					),
+				}
+			}
+		}
+
+	}()
+
+	return res, nil
+}
+
+func (chat *ZhipuChat) MaxContextLength(model string) int {
+	return zhipu.MaxContextSize(strings.TrimPrefix(model, "zhipu:"))
+}
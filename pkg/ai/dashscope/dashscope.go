@@ -6,22 +6,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/mylxsw/go-utils/ternary"
+	"github.com/mylxsw/go-utils/array"
 	"io"
 	"math/rand"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type DashScope struct {
 	apiKeys    []string
 	serviceURL string
+
+	lock sync.Mutex
+	// throttled 被限流的 API Key 及其恢复时间，限流期间负载均衡时跳过这些 Key
+	throttled map[string]time.Time
 }
 
 func New(apiKeys ...string) *DashScope {
 	return &DashScope{
 		apiKeys:    apiKeys,
 		serviceURL: "https://dashscope.aliyuncs.com",
+		throttled:  map[string]time.Time{},
 	}
 }
 
@@ -39,6 +47,8 @@ const (
 	ModelQWenMaxLongContext = "qwen-max-longcontext"
 	// ModelQWenVLPlus 通义千问VL plus支持灵活的交互方式，包括多图、多轮问答、创作等能力的模型，大幅提升了图片文字处理能力，增加可处理分辨率范围，增强视觉推理和决策能力
 	ModelQWenVLPlus = "qwen-vl-plus"
+	// ModelQWenVLMax 通义千问超大规模视觉语言模型，相比 plus 版本进一步提升了视觉推理能力和指令遵循能力
+	ModelQWenVLMax = "qwen-vl-max"
 
 	// 通义千问7B
 	ModelQWen7BV1     = "qwen-7b-v1"
@@ -76,7 +86,7 @@ type ChatInput struct {
 	// History 用户与模型的对话历史，list中的每个元素是形式为{"user":"用户输入","bot":"模型输出"}的一轮对话，多轮对话按时间正序排列。
 	History []ChatHistory `json:"history,omitempty"`
 
-	// 以下为新版本格式，目前只适配了 qwen-vl-plus 模型
+	// 以下为新版本格式，目前只适配了视觉模型（qwen-vl-plus/qwen-vl-max）
 	Messages []Message `json:"messages,omitempty"`
 }
 
@@ -133,7 +143,7 @@ type ChatOutput struct {
 	//	生成结束时如果因为生成长度过长导致则为 length
 	FinishReason string `json:"finish_reason,omitempty"`
 
-	// 以下为新版本格式，目前只适配了 qwen-vl-plus 模型
+	// 以下为新版本格式，目前只适配了视觉模型（qwen-vl-plus/qwen-vl-max）
 	Choices []Choice `json:"choices,omitempty"`
 }
 
@@ -154,21 +164,115 @@ type ChatUsage struct {
 	InputTokens int `json:"input_tokens,omitempty"`
 }
 
-func (ds *DashScope) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+// IsVisionModel 判断模型是否为视觉模型，视觉模型使用多模态接口和新版本的消息格式
+func IsVisionModel(model string) bool {
+	return array.In(model, []string{ModelQWenVLPlus, ModelQWenVLMax})
+}
+
+func endpoint(model string) string {
+	if IsVisionModel(model) {
+		return "/api/v1/services/aigc/multimodal-generation/generation"
+	}
+
+	return "/api/v1/services/aigc/text-generation/generation"
+}
+
+// RateLimitError 请求被灵积平台限流
+type RateLimitError struct {
+	// RetryAfter 建议的重试等待时间，平台没有返回时为 0
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("dashscope rate limited, retry after %s: %s", e.RetryAfter, e.Message)
+	}
+
+	return fmt.Sprintf("dashscope rate limited: %s", e.Message)
+}
+
+// ParseRetryAfter 从响应头中解析限流恢复时间，支持 Retry-After（秒数或 HTTP 时间）以及 X-RateLimit-Reset（秒数）
+func ParseRetryAfter(header http.Header, now time.Time) time.Duration {
+	for _, key := range []string{"Retry-After", "X-RateLimit-Reset-Requests", "X-RateLimit-Reset"} {
+		val := strings.TrimSpace(header.Get(key))
+		if val == "" {
+			continue
+		}
+
+		if seconds, err := strconv.ParseFloat(strings.TrimSuffix(val, "s"), 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds * float64(time.Second))
+		}
+
+		if t, err := http.ParseTime(val); err == nil && t.After(now) {
+			return t.Sub(now)
+		}
+	}
+
+	return 0
+}
+
+// responseError 将请求失败的响应转换为错误，限流时返回 *RateLimitError
+func (ds *DashScope) responseError(apiKey string, resp *http.Response) error {
+	data, _ := io.ReadAll(resp.Body)
+
+	var errResp ChatResponse
+	message := string(data)
+	if err := json.Unmarshal(data, &errResp); err == nil && errResp.Message != "" {
+		message = fmt.Sprintf("[%s] %s", errResp.Code, errResp.Message)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || errResp.Code == "Throttling" || strings.HasPrefix(errResp.Code, "Throttling.") {
+		retryAfter := ParseRetryAfter(resp.Header, time.Now())
+		ds.throttle(apiKey, retryAfter)
+
+		return &RateLimitError{RetryAfter: retryAfter, Message: message}
+	}
+
+	return fmt.Errorf("chat failed [%d]: %s", resp.StatusCode, message)
+}
+
+func (ds *DashScope) newChatRequest(ctx context.Context, req ChatRequest, stream bool) (*http.Request, string, error) {
 	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", ds.serviceURL+"/api/v1/services/aigc/text-generation/generation", bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", ds.serviceURL+endpoint(req.Model), bytes.NewReader(body))
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+ds.apiKeyLoadBalanced())
+	apiKey := ds.apiKeyLoadBalanced()
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("X-DashScope-DataInspection", "enable")
 
+	if stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+		httpReq.Header.Set("Cache-Control", "no-cache")
+		httpReq.Header.Set("Connection", "keep-alive")
+		httpReq.Header.Set("X-DashScope-SSE", "enable")
+	}
+
+	return httpReq, apiKey, nil
+}
+
+// fillText 新版本格式的响应内容在 choices 中，统一填充到 Output.Text
+func (res *ChatResponse) fillText() {
+	if len(res.Output.Choices) > 0 && res.Output.Text == "" {
+		for _, ct := range res.Output.Choices[0].Message.Content {
+			res.Output.Text += ct.Text
+		}
+	}
+}
+
+func (ds *DashScope) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	httpReq, apiKey, err := ds.newChatRequest(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+
 	httpResp, err := http.DefaultClient.Do(httpReq)
 	if err != nil {
 		return nil, err
@@ -177,7 +281,7 @@ func (ds *DashScope) Chat(ctx context.Context, req ChatRequest) (*ChatResponse,
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("chat failed [%d]: %s", httpResp.StatusCode, httpResp.Status)
+		return nil, ds.responseError(apiKey, httpResp)
 	}
 
 	var chatResp ChatResponse
@@ -185,42 +289,79 @@ func (ds *DashScope) Chat(ctx context.Context, req ChatRequest) (*ChatResponse,
 		return nil, err
 	}
 
+	chatResp.fillText()
+
 	return &chatResp, nil
 }
 
-func (ds *DashScope) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatResponse, error) {
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
+// Event SSE 事件
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// ReadEvent 从 SSE 流中读取一个完整的事件，事件之间以空行分隔，多行 data 使用换行符拼接
+func ReadEvent(reader *bufio.Reader) (*Event, error) {
+	var evt Event
+	var dataLines []string
+	hasField := false
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF && hasField {
+				evt.Data = strings.Join(dataLines, "\n")
+				return &evt, nil
+			}
+
+			return nil, err
+		}
 
-	endpoint := ternary.IfElse(
-		req.Model == ModelQWenVLPlus,
-		"/api/v1/services/aigc/multimodal-generation/generation",
-		"/api/v1/services/aigc/text-generation/generation",
-	)
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if hasField {
+				evt.Data = strings.Join(dataLines, "\n")
+				return &evt, nil
+			}
+
+			continue
+		}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", ds.serviceURL+endpoint, bytes.NewReader(body))
+		// 以冒号开头的行为注释
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		hasField = true
+
+		switch field {
+		case "id":
+			evt.ID = value
+		case "event":
+			evt.Event = value
+		case "data":
+			dataLines = append(dataLines, value)
+		}
+	}
+}
+
+func (ds *DashScope) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatResponse, error) {
+	httpReq, apiKey, err := ds.newChatRequest(ctx, req, true)
 	if err != nil {
 		return nil, err
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+ds.apiKeyLoadBalanced())
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "text/event-stream")
-	httpReq.Header.Set("Cache-Control", "no-cache")
-	httpReq.Header.Set("Connection", "keep-alive")
-	httpReq.Header.Set("X-DashScope-SSE", "enable")
-	httpReq.Header.Set("X-DashScope-DataInspection", "enable")
-
 	httpResp, err := http.DefaultClient.Do(httpReq)
 	if err != nil {
 		return nil, err
 	}
 
 	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusBadRequest {
-		_ = httpResp.Body.Close()
-		return nil, fmt.Errorf("chat failed [%d]: %s", httpResp.StatusCode, httpResp.Status)
+		defer httpResp.Body.Close()
+		return nil, ds.responseError(apiKey, httpResp)
 	}
 
 	res := make(chan ChatResponse)
@@ -232,7 +373,11 @@ func (ds *DashScope) ChatStream(ctx context.Context, req ChatRequest) (<-chan Ch
 
 		reader := bufio.NewReader(httpResp.Body)
 		for {
-			data, err := reader.ReadBytes('\n')
+			//id:1
+			//event:result
+			//:HTTP_STATUS/200
+			//data:...
+			evt, err := ReadEvent(reader)
 			if err != nil {
 				if err == io.EOF {
 					return
@@ -245,20 +390,12 @@ func (ds *DashScope) ChatStream(ctx context.Context, req ChatRequest) (<-chan Ch
 				return
 			}
 
-			dataStr := strings.TrimSpace(string(data))
-			if dataStr == "" {
-				continue
-			}
-
-			if !strings.HasPrefix(dataStr, "data:") {
-				//id:1
-				//event:result
-				//data:...
+			if evt.Data == "" {
 				continue
 			}
 
 			var chatResponse ChatResponse
-			if err := json.Unmarshal([]byte(dataStr[5:]), &chatResponse); err != nil {
+			if err := json.Unmarshal([]byte(evt.Data), &chatResponse); err != nil {
 				select {
 				case <-ctx.Done():
 				case res <- ChatResponse{Message: fmt.Sprintf("unmarshal stream data failed: %v", err), Code: "UNMARSHAL_STREAM_DATA_FAILED"}:
@@ -266,17 +403,22 @@ func (ds *DashScope) ChatStream(ctx context.Context, req ChatRequest) (<-chan Ch
 				return
 			}
 
-			if len(chatResponse.Output.Choices) > 0 && chatResponse.Output.Text == "" {
-				if len(chatResponse.Output.Choices[0].Message.Content) > 0 {
-					chatResponse.Output.Text = chatResponse.Output.Choices[0].Message.Content[0].Text
-				}
+			// 流式响应过程中出现错误时，事件类型为 error
+			if evt.Event == "error" && chatResponse.Code == "" {
+				chatResponse.Code = "STREAM_ERROR"
 			}
 
+			if chatResponse.Code == "Throttling" || strings.HasPrefix(chatResponse.Code, "Throttling.") {
+				ds.throttle(apiKey, 0)
+			}
+
+			chatResponse.fillText()
+
 			select {
 			case <-ctx.Done():
 				return
 			case res <- chatResponse:
-				if chatResponse.Output.FinishReason != "" && chatResponse.Output.FinishReason != "null" {
+				if chatResponse.Code != "" || (chatResponse.Output.FinishReason != "" && chatResponse.Output.FinishReason != "null") {
 					return
 				}
 			}
@@ -357,6 +499,34 @@ func (ds *DashScope) ImageTaskStatus(ctx context.Context, taskID string) (*Image
 	return &chatResp, nil
 }
 
+// defaultThrottleDuration 平台没有返回限流恢复时间时，API Key 的默认冷却时间
+const defaultThrottleDuration = 10 * time.Second
+
+// throttle 标记 API Key 被限流，冷却期间优先使用其它 Key
+func (ds *DashScope) throttle(apiKey string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = defaultThrottleDuration
+	}
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	ds.throttled[apiKey] = time.Now().Add(retryAfter)
+}
+
 func (ds *DashScope) apiKeyLoadBalanced() string {
-	return ds.apiKeys[rand.Intn(len(ds.apiKeys))]
+	ds.lock.Lock()
+	now := time.Now()
+	available := array.Filter(ds.apiKeys, func(key string, _ int) bool {
+		until, ok := ds.throttled[key]
+		return !ok || now.After(until)
+	})
+	ds.lock.Unlock()
+
+	// 所有 Key 都被限流时，仍然随机选择一个，由平台返回限流错误
+	if len(available) == 0 {
+		available = ds.apiKeys
+	}
+
+	return available[rand.Intn(len(available))]
 }
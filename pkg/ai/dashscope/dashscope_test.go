@@ -1,10 +1,15 @@
 package dashscope_test
 
 import (
+	"bufio"
 	"context"
 	"github.com/mylxsw/aidea-server/pkg/ai/dashscope"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/go-utils/assert"
@@ -55,3 +60,30 @@ func TestImageTaskStatus(t *testing.T) {
 
 	log.With(resp).Debug("resp")
 }
+
+func TestReadEvent(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("id:1\nevent:result\n:HTTP_STATUS/200\ndata:{\"output\":\ndata:{}}\n\nid:2\nevent:error\ndata:{\"code\":\"Throttling\"}"))
+
+	evt, err := dashscope.ReadEvent(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", evt.ID)
+	assert.Equal(t, "result", evt.Event)
+	assert.Equal(t, "{\"output\":\n{}}", evt.Data)
+
+	evt, err = dashscope.ReadEvent(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", evt.Event)
+	assert.Equal(t, `{"code":"Throttling"}`, evt.Data)
+
+	_, err = dashscope.ReadEvent(reader)
+	assert.True(t, err == io.EOF)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Duration(0), dashscope.ParseRetryAfter(http.Header{}, now))
+	assert.Equal(t, 3*time.Second, dashscope.ParseRetryAfter(http.Header{"Retry-After": []string{"3"}}, now))
+	assert.Equal(t, 1500*time.Millisecond, dashscope.ParseRetryAfter(http.Header{"X-Ratelimit-Reset": []string{"1.5s"}}, now))
+	assert.Equal(t, 10*time.Second, dashscope.ParseRetryAfter(http.Header{"Retry-After": []string{now.Add(10 * time.Second).Format(http.TimeFormat)}}, now))
+}
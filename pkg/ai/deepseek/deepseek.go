@@ -0,0 +1,42 @@
+package deepseek
+
+import (
+	"context"
+
+	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/sashabaranov/go-openai"
+)
+
+// https://platform.deepseek.com/api-docs/
+const (
+	ModelDeepSeekChat  = "deepseek-chat"
+	ModelDeepSeekCoder = "deepseek-coder"
+)
+
+type DeepSeek struct {
+	client openai2.Client
+}
+
+func NewDeepSeek(client openai2.Client) *DeepSeek {
+	return &DeepSeek{client: client}
+}
+
+func (ds *DeepSeek) ChatStream(ctx context.Context, request openai.ChatCompletionRequest) (<-chan openai2.ChatStreamResponse, error) {
+	return ds.client.ChatStream(ctx, request)
+}
+
+func (ds *DeepSeek) Chat(ctx context.Context, request openai.ChatCompletionRequest) (response openai.ChatCompletionResponse, err error) {
+	return ds.client.CreateChatCompletion(ctx, request)
+}
+
+// MaxContextSize 模型最大上下文长度，预留 4K 给模型输出
+func MaxContextSize(model string) int {
+	switch model {
+	case ModelDeepSeekCoder:
+		return 16000 - 4096
+	case ModelDeepSeekChat:
+		return 32000 - 4096
+	}
+
+	return 4000
+}
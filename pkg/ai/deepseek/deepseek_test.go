@@ -0,0 +1,64 @@
+package deepseek_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/deepseek"
+	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/assert"
+	"github.com/sashabaranov/go-openai"
+)
+
+func createClient() *deepseek.DeepSeek {
+	conf := &openai2.Config{
+		Enable:        true,
+		OpenAIServers: []string{"https://api.deepseek.com/v1"},
+		OpenAIKeys:    []string{os.Getenv("DEEPSEEK_API_KEY")},
+	}
+	return deepseek.NewDeepSeek(openai2.NewOpenAIClient(conf, nil))
+}
+
+func TestDeepSeek_ChatStream(t *testing.T) {
+	if os.Getenv("DEEPSEEK_API_KEY") == "" {
+		t.Skip("DEEPSEEK_API_KEY not set")
+	}
+
+	client := createClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	response, err := client.ChatStream(ctx, openai.ChatCompletionRequest{
+		Model: deepseek.ModelDeepSeekChat,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    "user",
+				Content: "用 Go 写一个快速排序",
+			},
+		},
+		MaxTokens: 500,
+	})
+
+	assert.NoError(t, err)
+
+	var finalText string
+	for res := range response {
+		if res.Code != "" {
+			log.With(res).Errorf("-> %s", res.ErrorMessage)
+			continue
+		}
+
+		finalText += res.ChatResponse.Choices[0].Delta.Content
+	}
+
+	log.Debugf("final text: %s", finalText)
+}
+
+func TestMaxContextSize(t *testing.T) {
+	assert.True(t, deepseek.MaxContextSize(deepseek.ModelDeepSeekChat) > deepseek.MaxContextSize(deepseek.ModelDeepSeekCoder))
+	assert.Equal(t, 4000, deepseek.MaxContextSize("unknown"))
+}
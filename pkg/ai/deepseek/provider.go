@@ -0,0 +1,25 @@
+package deepseek
+
+import (
+	"github.com/mylxsw/aidea-server/config"
+	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/glacier/infra"
+)
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(func(conf *config.Config) *DeepSeek {
+		if conf.DeepSeekServer == "" {
+			conf.DeepSeekServer = "https://api.deepseek.com/v1"
+		}
+
+		client := openai2.NewOpenAIClient(&openai2.Config{
+			Enable:        conf.EnableDeepSeek,
+			OpenAIServers: []string{conf.DeepSeekServer},
+			OpenAIKeys:    []string{conf.DeepSeekKey},
+		}, nil)
+
+		return NewDeepSeek(client)
+	})
+}
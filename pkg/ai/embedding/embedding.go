@@ -0,0 +1,63 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mylxsw/go-utils/array"
+)
+
+var ErrModelNotSupported = errors.New("embedding model not supported")
+
+// Request Embedding 请求
+type Request struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// Response Embedding 响应，Data 与请求中的 Input 一一对应
+type Response struct {
+	Model  string      `json:"model"`
+	Data   [][]float32 `json:"data"`
+	Tokens int64       `json:"tokens"`
+}
+
+// Embedding 文本向量化接口
+type Embedding interface {
+	// Embedding 将文本转换为向量
+	Embedding(ctx context.Context, req Request) (*Response, error)
+	// Support 是否支持指定的模型
+	Support(model string) bool
+}
+
+// Imp Embedding 实现，根据模型自动选择对应的服务
+type Imp struct {
+	backends []Embedding
+}
+
+func NewEmbedding(backends ...Embedding) Embedding {
+	return &Imp{backends: array.Filter(backends, func(item Embedding, _ int) bool { return item != nil })}
+}
+
+func (imp *Imp) selectBackend(model string) Embedding {
+	for _, backend := range imp.backends {
+		if backend.Support(model) {
+			return backend
+		}
+	}
+
+	return nil
+}
+
+func (imp *Imp) Support(model string) bool {
+	return imp.selectBackend(model) != nil
+}
+
+func (imp *Imp) Embedding(ctx context.Context, req Request) (*Response, error) {
+	backend := imp.selectBackend(req.Model)
+	if backend == nil {
+		return nil, ErrModelNotSupported
+	}
+
+	return backend.Embedding(ctx, req)
+}
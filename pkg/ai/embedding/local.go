@@ -0,0 +1,82 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/resty.v1"
+)
+
+// LocalEmbedding 本地部署的 Embedding 服务，服务端需要兼容 OpenAI 的 /v1/embeddings 接口
+type LocalEmbedding struct {
+	serverURL string
+	key       string
+	models    []string
+	resty     *resty.Client
+}
+
+func NewLocalEmbedding(conf *config.Config) *LocalEmbedding {
+	return &LocalEmbedding{
+		serverURL: conf.LocalEmbeddingServer,
+		key:       conf.LocalEmbeddingKey,
+		models:    conf.LocalEmbeddingModels,
+		resty:     misc.RestyClient(2).SetTimeout(60 * time.Second),
+	}
+}
+
+func (e *LocalEmbedding) Support(model string) bool {
+	return array.In(model, e.models)
+}
+
+type localEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Usage struct {
+		PromptTokens int64 `json:"prompt_tokens"`
+		TotalTokens  int64 `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func (e *LocalEmbedding) Embedding(ctx context.Context, req Request) (*Response, error) {
+	r := e.resty.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(req)
+	if e.key != "" {
+		r.SetHeader("Authorization", "Bearer "+e.key)
+	}
+
+	var ret localEmbeddingResponse
+	resp, err := r.SetResult(&ret).Post(e.serverURL + "/embeddings")
+	if err != nil {
+		return nil, fmt.Errorf("local embedding failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("local embedding failed: [%d] %s", resp.StatusCode(), resp.String())
+	}
+
+	data := make([][]float32, len(req.Input))
+	for _, item := range ret.Data {
+		if item.Index >= 0 && item.Index < len(data) {
+			data[item.Index] = item.Embedding
+		}
+	}
+
+	tokens := ret.Usage.TotalTokens
+	if tokens == 0 {
+		// 部分本地服务不返回 Token 用量，按照字数估算
+		tokens = array.Reduce(req.Input, func(carry int64, item string) int64 {
+			return carry + misc.WordCount(item)
+		}, 0)
+	}
+
+	return &Response{Model: req.Model, Data: data, Tokens: tokens}, nil
+}
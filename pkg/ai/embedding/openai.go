@@ -0,0 +1,41 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/openai"
+	openailib "github.com/sashabaranov/go-openai"
+)
+
+// OpenAIEmbedding 基于 OpenAI 的 Embedding 服务
+type OpenAIEmbedding struct {
+	client openai.Client
+}
+
+func NewOpenAIEmbedding(client openai.Client) *OpenAIEmbedding {
+	return &OpenAIEmbedding{client: client}
+}
+
+func (e *OpenAIEmbedding) Support(model string) bool {
+	return model == openailib.AdaEmbeddingV2.String()
+}
+
+func (e *OpenAIEmbedding) Embedding(ctx context.Context, req Request) (*Response, error) {
+	resp, err := e.client.CreateEmbeddings(ctx, openailib.EmbeddingRequest{
+		Input: req.Input,
+		Model: openailib.AdaEmbeddingV2,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("openai embedding failed: %w", err)
+	}
+
+	data := make([][]float32, len(req.Input))
+	for _, item := range resp.Data {
+		if item.Index >= 0 && item.Index < len(data) {
+			data[item.Index] = item.Embedding
+		}
+	}
+
+	return &Response{Model: req.Model, Data: data, Tokens: int64(resp.Usage.TotalTokens)}, nil
+}
@@ -0,0 +1,24 @@
+package embedding
+
+import (
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/glacier/infra"
+)
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(func(conf *config.Config, client openai.Client) Embedding {
+		var openaiBackend, localBackend Embedding
+		if conf.EnableOpenAI || conf.EnableFallbackOpenAI {
+			openaiBackend = NewOpenAIEmbedding(client)
+		}
+
+		if conf.EnableLocalEmbedding {
+			localBackend = NewLocalEmbedding(conf)
+		}
+
+		return NewEmbedding(openaiBackend, localBackend)
+	})
+}
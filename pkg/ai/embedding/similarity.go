@@ -0,0 +1,55 @@
+package embedding
+
+import (
+	"math"
+	"sort"
+)
+
+// CosineSimilarity 计算两个向量的余弦相似度，向量长度不一致或为零向量时返回 0
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// Scored 相似度检索结果
+type Scored struct {
+	Index int
+	Score float64
+}
+
+// TopK 返回与 query 最相似的 k 个向量（按照相似度降序），相似度低于 minScore 的结果会被忽略
+func TopK(query []float32, vectors [][]float32, k int, minScore float64) []Scored {
+	results := make([]Scored, 0, len(vectors))
+	for i, vec := range vectors {
+		score := CosineSimilarity(query, vec)
+		if score < minScore {
+			continue
+		}
+
+		results = append(results, Scored{Index: i, Score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+
+	return results
+}
@@ -0,0 +1,29 @@
+package embedding_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/embedding"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.True(t, math.Abs(embedding.CosineSimilarity([]float32{1, 2, 3}, []float32{2, 4, 6})-1) < 1e-6)
+	assert.True(t, math.Abs(embedding.CosineSimilarity([]float32{1, 0}, []float32{0, 1})) < 1e-6)
+	assert.True(t, math.Abs(embedding.CosineSimilarity([]float32{1, 1}, []float32{-1, -1})+1) < 1e-6)
+	assert.Equal(t, 0.0, embedding.CosineSimilarity([]float32{1, 2}, []float32{1, 2, 3}))
+	assert.Equal(t, 0.0, embedding.CosineSimilarity([]float32{0, 0}, []float32{1, 2}))
+}
+
+func TestTopK(t *testing.T) {
+	vectors := [][]float32{{0, 1}, {1, 0}, {1, 1}, {-1, 0}}
+
+	res := embedding.TopK([]float32{1, 0}, vectors, 2, 0)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, 1, res[0].Index)
+	assert.Equal(t, 2, res[1].Index)
+
+	res = embedding.TopK([]float32{1, 0}, vectors, 10, 0.5)
+	assert.Equal(t, 2, len(res))
+}
@@ -29,9 +29,9 @@ func NewGPT360(apiKey string) *GPT360 {
 }
 
 type ChatRequest struct {
-	Model    string   `json:"model"`
-	Messages Messages `json:"messages"`
-	Stream   bool     `json:"stream"`
+	Model       string   `json:"model"`
+	Messages    Messages `json:"messages"`
+	Stream      bool     `json:"stream"`
 	Temperature float64  `json:"temperature,omitempty"`
 	// MaxTokens 大于等于1小于等于2048，默认值是2048，代表输出结果的最大token数
 	MaxTokens int `json:"max_tokens,omitempty"`
@@ -0,0 +1,176 @@
+package moonshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/sashabaranov/go-openai"
+)
+
+// https://platform.moonshot.cn/docs/api-reference
+const (
+	ModelMoonshotV1_8K   = "moonshot-v1-8k"
+	ModelMoonshotV1_32K  = "moonshot-v1-32k"
+	ModelMoonshotV1_128K = "moonshot-v1-128k"
+)
+
+// contextTiers 按照上下文长度从小到大排列的模型档位
+var contextTiers = []struct {
+	model string
+	size  int
+}{
+	{model: ModelMoonshotV1_8K, size: 8 * 1024},
+	{model: ModelMoonshotV1_32K, size: 32 * 1024},
+	{model: ModelMoonshotV1_128K, size: 128 * 1024},
+}
+
+// MaxContextSize 模型最大上下文长度（包含输出）
+func MaxContextSize(model string) int {
+	for _, tier := range contextTiers {
+		if tier.model == model {
+			return tier.size
+		}
+	}
+
+	return 8 * 1024
+}
+
+// SelectModel 根据实际需要的上下文长度选择能够容纳的最小档位，不会超过用户选择的模型档位
+func SelectModel(model string, tokenCount int) string {
+	maxSize := MaxContextSize(model)
+	for _, tier := range contextTiers {
+		if tier.size > maxSize {
+			break
+		}
+
+		if tokenCount <= tier.size {
+			return tier.model
+		}
+	}
+
+	return model
+}
+
+type Moonshot struct {
+	client openai2.Client
+	server string
+	key    string
+}
+
+func NewMoonshot(client openai2.Client, server, key string) *Moonshot {
+	return &Moonshot{client: client, server: strings.TrimSuffix(server, "/"), key: key}
+}
+
+func (ms *Moonshot) ChatStream(ctx context.Context, request openai.ChatCompletionRequest) (<-chan openai2.ChatStreamResponse, error) {
+	return ms.client.ChatStream(ctx, request)
+}
+
+func (ms *Moonshot) Chat(ctx context.Context, request openai.ChatCompletionRequest) (response openai.ChatCompletionResponse, err error) {
+	return ms.client.CreateChatCompletion(ctx, request)
+}
+
+// File Moonshot 文件信息
+type File struct {
+	ID       string `json:"id"`
+	Bytes    int64  `json:"bytes"`
+	Filename string `json:"filename"`
+	Purpose  string `json:"purpose"`
+	Status   string `json:"status"`
+}
+
+// FileContent Moonshot 文件抽取后的内容
+type FileContent struct {
+	Content  string `json:"content"`
+	Filename string `json:"filename"`
+	Type     string `json:"type"`
+}
+
+func (ms *Moonshot) do(req *http.Request, result any) error {
+	req.Header.Set("Authorization", "Bearer "+ms.key)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("moonshot request failed [%s]: %s", resp.Status, string(data))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decode response failed: %w", err)
+	}
+
+	return nil
+}
+
+// UploadFile 上传文件用于文档问答，Moonshot 会抽取文件中的文字内容
+func (ms *Moonshot) UploadFile(ctx context.Context, filename string, data []byte) (*File, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("purpose", "file-extract")
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := part.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ms.server+"/files", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var file File
+	if err := ms.do(req, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// FileContent 查询文件抽取后的内容
+func (ms *Moonshot) FileContent(ctx context.Context, fileID string) (*FileContent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ms.server+"/files/"+fileID+"/content", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var content FileContent
+	if err := ms.do(req, &content); err != nil {
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+// DeleteFile 删除已上传的文件
+func (ms *Moonshot) DeleteFile(ctx context.Context, fileID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, ms.server+"/files/"+fileID, nil)
+	if err != nil {
+		return err
+	}
+
+	return ms.do(req, nil)
+}
@@ -0,0 +1,16 @@
+package moonshot_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/moonshot"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestSelectModel(t *testing.T) {
+	assert.Equal(t, moonshot.ModelMoonshotV1_8K, moonshot.SelectModel(moonshot.ModelMoonshotV1_128K, 2000))
+	assert.Equal(t, moonshot.ModelMoonshotV1_32K, moonshot.SelectModel(moonshot.ModelMoonshotV1_128K, 20000))
+	assert.Equal(t, moonshot.ModelMoonshotV1_128K, moonshot.SelectModel(moonshot.ModelMoonshotV1_128K, 100000))
+	assert.Equal(t, moonshot.ModelMoonshotV1_8K, moonshot.SelectModel(moonshot.ModelMoonshotV1_8K, 20000))
+	assert.Equal(t, moonshot.ModelMoonshotV1_8K, moonshot.SelectModel(moonshot.ModelMoonshotV1_32K, 100))
+}
@@ -0,0 +1,25 @@
+package moonshot
+
+import (
+	"github.com/mylxsw/aidea-server/config"
+	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/glacier/infra"
+)
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(func(conf *config.Config) *Moonshot {
+		if conf.MoonshotServer == "" {
+			conf.MoonshotServer = "https://api.moonshot.cn/v1"
+		}
+
+		client := openai2.NewOpenAIClient(&openai2.Config{
+			Enable:        conf.EnableMoonshot,
+			OpenAIServers: []string{conf.MoonshotServer},
+			OpenAIKeys:    []string{conf.MoonshotKey},
+		}, nil)
+
+		return NewMoonshot(client, conf.MoonshotServer, conf.MoonshotKey)
+	})
+}
@@ -16,6 +16,7 @@ type Client interface {
 	CreateTranscription(ctx context.Context, request openai.AudioRequest) (response openai.AudioResponse, err error)
 	CreateSpeech(ctx context.Context, request openai.CreateSpeechRequest) (response io.ReadCloser, err error)
 	QuickAsk(ctx context.Context, prompt string, question string, maxTokenCount int) (string, error)
+	CreateEmbeddings(ctx context.Context, request openai.EmbeddingRequest) (response openai.EmbeddingResponse, err error)
 }
 
 type ClientImpl struct {
@@ -149,6 +150,30 @@ func (proxy *ClientImpl) CreateSpeech(ctx context.Context, request openai.Create
 	panic("no openai client available")
 }
 
+func (proxy *ClientImpl) CreateEmbeddings(ctx context.Context, request openai.EmbeddingRequest) (response openai.EmbeddingResponse, err error) {
+	ctl := control.FromContext(ctx)
+	if ctl.PreferBackup && proxy.backup != nil {
+		return proxy.backup.CreateEmbeddings(ctx, request)
+	}
+
+	if proxy.main != nil {
+		response, err = proxy.main.CreateEmbeddings(ctx, request)
+		if err == nil {
+			return response, nil
+		}
+	}
+
+	if proxy.backup != nil {
+		log.WithFields(log.Fields{
+			"model": request.Model.String(),
+			"error": err.Error(),
+		}).Warningf("use control openai client")
+		return proxy.backup.CreateEmbeddings(ctx, request)
+	}
+
+	return response, err
+}
+
 func (proxy *ClientImpl) QuickAsk(ctx context.Context, prompt string, question string, maxTokenCount int) (string, error) {
 	var res string
 	var err error
@@ -213,6 +213,10 @@ func (client *realClientImpl) CreateSpeech(ctx context.Context, request openai.C
 	return client.client("audio").CreateSpeech(ctx, request)
 }
 
+func (client *realClientImpl) CreateEmbeddings(ctx context.Context, request openai.EmbeddingRequest) (response openai.EmbeddingResponse, err error) {
+	return client.client("embedding").CreateEmbeddings(ctx, request)
+}
+
 func (client *realClientImpl) QuickAsk(ctx context.Context, prompt string, question string, maxTokenCount int) (string, error) {
 	if client.conf != nil && !client.conf.Enable {
 		return question, nil
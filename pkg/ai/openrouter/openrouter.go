@@ -2,18 +2,81 @@ package openrouter
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
 	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
 	"github.com/mylxsw/go-utils/array"
 	"github.com/sashabaranov/go-openai"
-	"strings"
 )
 
+// USDToCNY 美元兑人民币汇率，用于将 OpenRouter 的美元价格换算为智慧果
+const USDToCNY = 7.2
+
 type OpenRouter struct {
-	client openai2.Client
+	client     openai2.Client
+	server     string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// Options OpenRouter 的附加请求头，用于在 OpenRouter 的排行榜中展示应用信息
+// https://openrouter.ai/docs#requests
+type Options struct {
+	// SiteURL 通过 HTTP-Referer 请求头传递
+	SiteURL string
+	// SiteName 通过 X-Title 请求头传递
+	SiteName string
+}
+
+// headerTransport 为每个请求附加 OpenRouter 的应用信息请求头
+type headerTransport struct {
+	opt  Options
+	base http.RoundTripper
 }
 
-func NewOpenRouter(client openai2.Client) *OpenRouter {
-	return &OpenRouter{client: client}
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.opt.SiteURL != "" {
+		req.Header.Set("HTTP-Referer", t.opt.SiteURL)
+	}
+
+	if t.opt.SiteName != "" {
+		req.Header.Set("X-Title", t.opt.SiteName)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// NewOpenRouter 创建 OpenRouter 客户端，transport 为空时使用默认的 http.Transport
+func NewOpenRouter(server, apiKey string, opt Options, transport http.RoundTripper) *OpenRouter {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	httpClient := &http.Client{
+		Timeout:   180 * time.Second,
+		Transport: &headerTransport{opt: opt, base: transport},
+	}
+
+	conf := openai.DefaultConfig(apiKey)
+	conf.BaseURL = strings.TrimSuffix(server, "/")
+	conf.HTTPClient = httpClient
+
+	return &OpenRouter{
+		client:     openai2.New(&openai2.Config{Enable: true}, []*openai.Client{openai.NewClientWithConfig(conf)}),
+		server:     strings.TrimSuffix(server, "/"),
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
 }
 
 func (oa *OpenRouter) ChatStream(ctx context.Context, request openai.ChatCompletionRequest) (<-chan openai2.ChatStreamResponse, error) {
@@ -102,7 +165,165 @@ var supportModels = []string{
 	"gryphe/mythomax-l2-13b",
 }
 
-// SupportModel 判断是否支持某个模型
+// SupportModel 判断是否支持某个模型，包括从 OpenRouter 同步的模型
 func SupportModel(model string) bool {
-	return array.In(strings.ReplaceAll(model, ".", "/"), supportModels)
+	model = strings.ReplaceAll(model, ".", "/")
+	if array.In(model, supportModels) {
+		return true
+	}
+
+	_, ok := CatalogModel(model)
+	return ok
+}
+
+// ModelInfo OpenRouter 模型列表接口返回的模型信息
+type ModelInfo struct {
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description"`
+	ContextLength int          `json:"context_length"`
+	Pricing       ModelPricing `json:"pricing"`
+	Architecture  struct {
+		// Modality 模型支持的输入输出类型，例如 text->text、text+image->text
+		Modality string `json:"modality"`
+	} `json:"architecture"`
+}
+
+// ModelPricing 模型价格，单位为美元/Token
+type ModelPricing struct {
+	Prompt     string `json:"prompt"`
+	Completion string `json:"completion"`
+}
+
+// SupportVision 模型是否支持图片输入
+func (m ModelInfo) SupportVision() bool {
+	input, _, _ := strings.Cut(m.Architecture.Modality, "->")
+	return strings.Contains(input, "image")
+}
+
+// CatalogID 模型在本系统中的 ID，OpenRouter 的模型 ID 中包含 /，需要替换为 .
+func (m ModelInfo) CatalogID() string {
+	return strings.ReplaceAll(m.ID, "/", ".")
+}
+
+// UpstreamPrice 将 OpenRouter 的价格（美元/Token）转换为 人民币/1K Token，价格无法解析时返回 false
+func (m ModelInfo) UpstreamPrice() (coins.UpstreamPrice, bool) {
+	prompt, err := strconv.ParseFloat(m.Pricing.Prompt, 64)
+	if err != nil || prompt < 0 {
+		return coins.UpstreamPrice{}, false
+	}
+
+	completion, err := strconv.ParseFloat(m.Pricing.Completion, 64)
+	if err != nil || completion < 0 {
+		return coins.UpstreamPrice{}, false
+	}
+
+	return coins.UpstreamPrice{Input: prompt * 1000 * USDToCNY, Output: completion * 1000 * USDToCNY}, true
+}
+
+// Coins 按照 OpenRouter 的价格计算每 1K Token 消耗的智慧果数量，输入和输出价格取平均值，markup 为加价系数。
+// 价格无法解析时返回 false，免费模型最少收取 1 个智慧果
+func (m ModelInfo) Coins(markup float64) (int64, bool) {
+	price, ok := m.UpstreamPrice()
+	if !ok {
+		return 0, false
+	}
+
+	if markup <= 0 {
+		markup = 1
+	}
+
+	// 人民币/1K Token -> 智慧果（1 元 = 100 智慧果）
+	unit := int64(math.Ceil((price.Input + price.Output) / 2 * markup * 100))
+	if unit < 1 {
+		unit = 1
+	}
+
+	return unit, true
+}
+
+var catalog = struct {
+	lock   sync.RWMutex
+	models map[string]ModelInfo
+}{models: map[string]ModelInfo{}}
+
+// CatalogModels 返回从 OpenRouter 同步的模型列表，按照模型 ID 排序
+func CatalogModels() []ModelInfo {
+	catalog.lock.RLock()
+	defer catalog.lock.RUnlock()
+
+	models := make([]ModelInfo, 0, len(catalog.models))
+	for _, m := range catalog.models {
+		models = append(models, m)
+	}
+
+	return array.Sort(models, func(a, b ModelInfo) bool { return a.ID < b.ID })
+}
+
+// CatalogModel 查询从 OpenRouter 同步的模型信息，model 可以是 OpenRouter 的模型 ID，也可以是本系统的模型 ID
+func CatalogModel(model string) (ModelInfo, bool) {
+	catalog.lock.RLock()
+	defer catalog.lock.RUnlock()
+
+	m, ok := catalog.models[strings.ReplaceAll(model, ".", "/")]
+	return m, ok
+}
+
+// SetCatalogModels 替换同步的模型列表
+func SetCatalogModels(models []ModelInfo) {
+	items := make(map[string]ModelInfo, len(models))
+	for _, m := range models {
+		items[m.ID] = m
+	}
+
+	catalog.lock.Lock()
+	defer catalog.lock.Unlock()
+
+	catalog.models = items
+}
+
+// ListModels 查询 OpenRouter 支持的所有模型
+func (oa *OpenRouter) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, oa.server+"/models", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+oa.apiKey)
+
+	resp, err := oa.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("list models failed [%d]: %s", resp.StatusCode, string(data))
+	}
+
+	var ret struct {
+		Data []ModelInfo `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ret); err != nil {
+		return nil, fmt.Errorf("decode models failed: %w", err)
+	}
+
+	return ret.Data, nil
+}
+
+// SyncModels 从 OpenRouter 同步模型列表到模型目录
+func (oa *OpenRouter) SyncModels(ctx context.Context) ([]ModelInfo, error) {
+	models, err := oa.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同步结果为空时保留上次的模型列表，避免接口异常导致模型全部下线
+	if len(models) == 0 {
+		return nil, fmt.Errorf("no models returned from openrouter")
+	}
+
+	SetCatalogModels(models)
+	return models, nil
 }
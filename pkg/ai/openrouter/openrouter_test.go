@@ -2,7 +2,6 @@ package openrouter_test
 
 import (
 	"context"
-	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
 	"github.com/mylxsw/aidea-server/pkg/ai/openrouter"
 	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/go-utils/assert"
@@ -50,12 +49,38 @@ func TestOpenRouter_ChatStream(t *testing.T) {
 }
 
 func createClient() *openrouter.OpenRouter {
-	conf := &openai2.Config{
-		Enable:        true,
-		OpenAIServers: []string{"https://openrouter.ai/api/v1"},
-		OpenAIKeys:    []string{os.Getenv("OPEN_ROUTER_API_KEY")},
-	}
-	return openrouter.NewOpenRouter(openai2.NewOpenAIClient(conf, nil))
+	return openrouter.NewOpenRouter(
+		"https://openrouter.ai/api/v1",
+		os.Getenv("OPEN_ROUTER_API_KEY"),
+		openrouter.Options{SiteURL: "https://aidea.aicode.cc", SiteName: "AIdea"},
+		nil,
+	)
+}
+
+func TestModelInfo_Coins(t *testing.T) {
+	m := openrouter.ModelInfo{ID: "mistralai/mixtral-8x7b-instruct"}
+	m.Pricing.Prompt, m.Pricing.Completion = "0.0000006", "0.0000006"
+	m.Architecture.Modality = "text->text"
+
+	// $0.0006/1K tokens -> ¥0.00432/1K tokens，1.5 倍加价后为 ¥0.00648 -> 1 智慧果
+	unit, ok := m.Coins(1.5)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), unit)
+
+	// $0.03/1K tokens -> ¥0.216/1K tokens，2 倍加价后为 ¥0.432 -> 44 智慧果
+	m.Pricing.Prompt, m.Pricing.Completion = "0.00003", "0.00003"
+	unit, ok = m.Coins(2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(44), unit)
+
+	m.Pricing.Prompt = "-"
+	_, ok = m.Coins(1)
+	assert.False(t, ok)
+
+	assert.Equal(t, "mistralai.mixtral-8x7b-instruct", m.CatalogID())
+	assert.False(t, m.SupportVision())
+	m.Architecture.Modality = "text+image->text"
+	assert.True(t, m.SupportVision())
 }
 
 func TestOpenRouter_Chat(t *testing.T) {
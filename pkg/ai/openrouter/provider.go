@@ -1,9 +1,15 @@
 package openrouter
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
 	"github.com/mylxsw/aidea-server/config"
-	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/internal/coins"
 	"github.com/mylxsw/aidea-server/pkg/proxy"
+	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/glacier/infra"
 )
 
@@ -11,10 +17,12 @@ type Provider struct{}
 
 func (Provider) Register(binder infra.Binder) {
 	binder.MustSingleton(func(conf *config.Config, resolver infra.Resolver) *OpenRouter {
-		var pp *proxy.Proxy
+		var transport http.RoundTripper = &http.Transport{
+			DialContext: (&net.Dialer{Timeout: 120 * time.Second}).DialContext,
+		}
 		if conf.SupportProxy() && conf.OpenRouterAutoProxy {
 			resolver.MustResolve(func(p *proxy.Proxy) {
-				pp = p
+				transport = p.BuildTransport()
 			})
 		}
 
@@ -22,12 +30,59 @@ func (Provider) Register(binder infra.Binder) {
 			conf.OpenRouterServer = "https://openrouter.ai/api/v1"
 		}
 
-		client := openai2.NewOpenAIClient(&openai2.Config{
-			Enable:        conf.EnableOpenRouter,
-			OpenAIServers: []string{conf.OpenRouterServer},
-			OpenAIKeys:    []string{conf.OpenRouterKey},
-		}, pp)
+		return NewOpenRouter(conf.OpenRouterServer, conf.OpenRouterKey, Options{
+			SiteURL:  conf.OpenRouterSiteURL,
+			SiteName: conf.OpenRouterSiteName,
+		}, transport)
+	})
+}
+
+// Daemon 定时从 OpenRouter 同步模型列表，并按照上游价格加价后设置模型的计费价格
+func (Provider) Daemon(ctx context.Context, resolver infra.Resolver) {
+	resolver.MustResolve(func(conf *config.Config, oa *OpenRouter) {
+		if !conf.EnableOpenRouter || !conf.OpenRouterSyncModels {
+			return
+		}
+
+		interval := conf.OpenRouterSyncInterval
+		if interval <= 0 {
+			interval = 6 * time.Hour
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			syncModels(ctx, conf, oa)
 
-		return NewOpenRouter(client)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
 	})
 }
+
+func syncModels(ctx context.Context, conf *config.Config, oa *OpenRouter) {
+	syncCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	models, err := oa.SyncModels(syncCtx)
+	if err != nil {
+		log.Errorf("sync openrouter models failed: %v", err)
+		return
+	}
+
+	for _, m := range models {
+		if unit, ok := m.Coins(conf.OpenRouterPriceMarkup); ok {
+			coins.SetDynamicTextPrice(m.CatalogID(), unit)
+		}
+
+		if price, ok := m.UpstreamPrice(); ok {
+			coins.SetDynamicUpstreamPrice(m.CatalogID(), price)
+		}
+	}
+
+	log.Debugf("sync openrouter models success, %d models", len(models))
+}
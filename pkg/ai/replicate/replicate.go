@@ -0,0 +1,147 @@
+package replicate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"gopkg.in/resty.v1"
+)
+
+// 预测任务的状态
+const (
+	StatusStarting   = "starting"
+	StatusProcessing = "processing"
+	StatusSucceeded  = "succeeded"
+	StatusFailed     = "failed"
+	StatusCanceled   = "canceled"
+)
+
+// Replicate replicate.com 模型推理服务
+type Replicate struct {
+	conf  *config.Config
+	resty *resty.Client
+}
+
+func NewReplicate(conf *config.Config) *Replicate {
+	return &Replicate{conf: conf, resty: misc.RestyClient(2).SetTimeout(60 * time.Second)}
+}
+
+// Prediction 预测任务
+type Prediction struct {
+	ID      string          `json:"id"`
+	Version string          `json:"version,omitempty"`
+	Status  string          `json:"status"`
+	Output  json.RawMessage `json:"output,omitempty"`
+	Error   any             `json:"error,omitempty"`
+}
+
+// IsFinished 任务是否已经结束（成功、失败或取消）
+func (p *Prediction) IsFinished() bool {
+	return p.Status == StatusSucceeded || p.Status == StatusFailed || p.Status == StatusCanceled
+}
+
+// Images 任务输出的图片地址，模型输出可能是单个地址或地址数组
+func (p *Prediction) Images() []string {
+	if len(p.Output) == 0 {
+		return nil
+	}
+
+	var images []string
+	if err := json.Unmarshal(p.Output, &images); err == nil {
+		return images
+	}
+
+	var image string
+	if err := json.Unmarshal(p.Output, &image); err == nil && image != "" {
+		return []string{image}
+	}
+
+	return nil
+}
+
+// ErrorMessage 任务失败的原因
+func (p *Prediction) ErrorMessage() string {
+	if p.Error == nil {
+		return p.Status
+	}
+
+	return fmt.Sprintf("%v", p.Error)
+}
+
+func (r *Replicate) url(path string) string {
+	return strings.TrimRight(r.conf.ReplicateServer, "/") + path
+}
+
+// CreatePrediction 创建预测任务
+func (r *Replicate) CreatePrediction(ctx context.Context, version string, input map[string]any) (*Prediction, error) {
+	resp, err := r.resty.R().
+		SetContext(ctx).
+		SetHeader("Authorization", "Token "+r.conf.ReplicateKey).
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]any{"version": version, "input": input}).
+		Post(r.url("/v1/predictions"))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("create prediction failed: [%d] %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	var prediction Prediction
+	if err := json.Unmarshal(resp.Body(), &prediction); err != nil {
+		return nil, fmt.Errorf("decode prediction failed: %w", err)
+	}
+
+	return &prediction, nil
+}
+
+// GetPrediction 查询预测任务
+func (r *Replicate) GetPrediction(ctx context.Context, id string) (*Prediction, error) {
+	resp, err := r.resty.R().
+		SetContext(ctx).
+		SetHeader("Authorization", "Token "+r.conf.ReplicateKey).
+		Get(r.url("/v1/predictions/" + id))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("get prediction failed: [%d] %s", resp.StatusCode(), string(resp.Body()))
+	}
+
+	var prediction Prediction
+	if err := json.Unmarshal(resp.Body(), &prediction); err != nil {
+		return nil, fmt.Errorf("decode prediction failed: %w", err)
+	}
+
+	return &prediction, nil
+}
+
+// Wait 等待预测任务结束，interval 为查询间隔
+func (r *Replicate) Wait(ctx context.Context, id string, interval time.Duration) (*Prediction, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		prediction, err := r.GetPrediction(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if prediction.IsFinished() {
+			return prediction, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package replicate_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/replicate"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestPredictionImages(t *testing.T) {
+	var p replicate.Prediction
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"p1","status":"succeeded","output":["https://a.png","https://b.png"]}`), &p))
+	assert.True(t, p.IsFinished())
+	assert.EqualValues(t, []string{"https://a.png", "https://b.png"}, p.Images())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"p2","status":"succeeded","output":"https://c.png"}`), &p))
+	assert.EqualValues(t, []string{"https://c.png"}, p.Images())
+
+	p = replicate.Prediction{Status: replicate.StatusProcessing}
+	assert.False(t, p.IsFinished())
+	assert.Equal(t, 0, len(p.Images()))
+	assert.Equal(t, replicate.StatusProcessing, p.ErrorMessage())
+}
@@ -0,0 +1,270 @@
+package tencentai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/go-utils/array"
+)
+
+// 腾讯云混元大模型 API https://cloud.tencent.com/document/product/1729/101836
+const (
+	// ModelHunyuanStd 混元标准版
+	ModelHunyuanStd = "hunyuan-std"
+	// ModelHunyuanPro 混元高级版
+	ModelHunyuanPro = "hunyuan-pro"
+)
+
+const (
+	hunyuanHost    = "hunyuan.tencentcloudapi.com"
+	hunyuanService = "hunyuan"
+	hunyuanVersion = "2023-09-01"
+)
+
+// FinishReasonSensitive 输出内容命中混元的内容安全策略
+const FinishReasonSensitive = "sensitive"
+
+// IsHunyuanModel 是否为通过腾讯云 API 调用的混元模型
+func IsHunyuanModel(model string) bool {
+	return array.In(model, []string{ModelHunyuanStd, ModelHunyuanPro})
+}
+
+func hunyuanAction(model string) string {
+	if model == ModelHunyuanPro {
+		return "ChatPro"
+	}
+
+	return "ChatStd"
+}
+
+type HunyuanMessage struct {
+	// Role 当前支持 user 和 assistant，必须交替出现，最后一条为 user
+	Role string `json:"Role"`
+	// Content 消息的内容
+	Content string `json:"Content"`
+}
+
+type HunyuanRequest struct {
+	// Messages 会话内容，长度最多为 40，按对话时间从旧到新排列
+	Messages []HunyuanMessage `json:"Messages"`
+	// TopP 影响输出文本的多样性，取值区间为 [0.0, 1.0]
+	TopP float64 `json:"TopP,omitempty"`
+	// Temperature 较高的数值会使输出更加随机，取值区间为 [0.0, 2.0]
+	Temperature float64 `json:"Temperature,omitempty"`
+}
+
+// HunyuanResponse 流式响应的数据帧，每一帧为 `data: {...}` 格式
+type HunyuanResponse struct {
+	// Note 免责声明
+	Note string `json:"Note,omitempty"`
+	// Choices 回复内容
+	Choices []HunyuanChoice `json:"Choices,omitempty"`
+	// Created unix 时间戳
+	Created int64 `json:"Created,omitempty"`
+	// Id 会话 id
+	Id string `json:"Id,omitempty"`
+	// Usage token 数量，每一帧都是截至当前的累计值
+	Usage HunyuanUsage `json:"Usage,omitempty"`
+	// ErrorMsg 流式输出过程中出现的错误，正常时为 null
+	ErrorMsg *HunyuanErrorMsg `json:"ErrorMsg,omitempty"`
+}
+
+type HunyuanChoice struct {
+	// FinishReason 结束标志位，stop 表示尾包，sensitive 表示内容安全审核未通过
+	FinishReason string         `json:"FinishReason,omitempty"`
+	Delta        HunyuanMessage `json:"Delta,omitempty"`
+}
+
+type HunyuanUsage struct {
+	PromptTokens     int64 `json:"PromptTokens,omitempty"`
+	CompletionTokens int64 `json:"CompletionTokens,omitempty"`
+	TotalTokens      int64 `json:"TotalTokens,omitempty"`
+}
+
+type HunyuanErrorMsg struct {
+	Msg  string `json:"Msg,omitempty"`
+	Code int    `json:"Code,omitempty"`
+}
+
+// HunyuanAPIError 请求失败时腾讯云 API 返回的公共错误，此时响应为普通 JSON 而不是事件流
+type HunyuanAPIError struct {
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+	RequestID string `json:"-"`
+}
+
+func (e *HunyuanAPIError) Error() string {
+	return fmt.Sprintf("hunyuan api error: [%s] %s (request_id: %s)", e.Code, e.Message, e.RequestID)
+}
+
+// IsRateLimit 是否为请求频率或并发超限错误
+func (e *HunyuanAPIError) IsRateLimit() bool {
+	return strings.HasPrefix(e.Code, "LimitExceeded") ||
+		e.Code == "RequestLimitExceeded" ||
+		e.Code == "FailedOperation.EngineServerLimitExceeded"
+}
+
+// IsResourceExhausted 是否为资源包用尽或账号欠费错误
+func (e *HunyuanAPIError) IsResourceExhausted() bool {
+	return strings.HasPrefix(e.Code, "ResourceInsufficient") ||
+		strings.HasPrefix(e.Code, "FailedOperation.FreeResourcePackExhausted") ||
+		e.Code == "FailedOperation.ServiceNotActivated" ||
+		e.Code == "FailedOperation.ServiceStop"
+}
+
+// SignTC3 使用 TC3-HMAC-SHA256 签名方法计算请求的 Authorization 头
+// https://cloud.tencent.com/document/api/213/30654
+func SignTC3(secretID, secretKey, service, host string, payload []byte, timestamp int64) string {
+	hashedPayload := sha256.Sum256(payload)
+	canonicalRequest := strings.Join([]string{
+		"POST",
+		"/",
+		"",
+		"content-type:application/json\nhost:" + host + "\n",
+		"content-type;host",
+		hex.EncodeToString(hashedPayload[:]),
+	}, "\n")
+
+	date := time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+	credentialScope := date + "/" + service + "/tc3_request"
+	hashedCanonicalRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		strconv.FormatInt(timestamp, 10),
+		credentialScope,
+		hex.EncodeToString(hashedCanonicalRequest[:]),
+	}, "\n")
+
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, service)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	return fmt.Sprintf(
+		"TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		secretID, credentialScope, signature,
+	)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// HunyuanChatStream 调用混元大模型，混元的 ChatStd/ChatPro 接口只支持流式输出
+func (ai *TencentAI) HunyuanChatStream(ctx context.Context, model string, req HunyuanRequest) (<-chan HunyuanResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", "https://"+hunyuanHost, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Authorization", SignTC3(ai.secretID, ai.secretKey, hunyuanService, hunyuanHost, body, timestamp))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Host", hunyuanHost)
+	httpReq.Header.Set("X-TC-Action", hunyuanAction(model))
+	httpReq.Header.Set("X-TC-Timestamp", strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set("X-TC-Version", hunyuanVersion)
+
+	httpResp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusBadRequest {
+		_ = httpResp.Body.Close()
+		return nil, fmt.Errorf("chat failed [%d]: %s", httpResp.StatusCode, httpResp.Status)
+	}
+
+	// 请求失败时返回的是腾讯云 API 的公共错误结构
+	if strings.HasPrefix(httpResp.Header.Get("Content-Type"), "application/json") {
+		defer httpResp.Body.Close()
+
+		var errResp struct {
+			Response struct {
+				Error     *HunyuanAPIError `json:"Error"`
+				RequestID string           `json:"RequestId"`
+			} `json:"Response"`
+		}
+		if err := json.NewDecoder(httpResp.Body).Decode(&errResp); err != nil {
+			return nil, fmt.Errorf("decode response failed: %w", err)
+		}
+
+		if errResp.Response.Error == nil {
+			return nil, fmt.Errorf("unexpected non-stream response (request_id: %s)", errResp.Response.RequestID)
+		}
+
+		errResp.Response.Error.RequestID = errResp.Response.RequestID
+		return nil, errResp.Response.Error
+	}
+
+	res := make(chan HunyuanResponse)
+	go func() {
+		defer func() {
+			_ = httpResp.Body.Close()
+			close(res)
+		}()
+
+		reader := bufio.NewReader(httpResp.Body)
+		for {
+			data, err := reader.ReadBytes('\n')
+			if err != nil {
+				if err == io.EOF {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+				case res <- HunyuanResponse{ErrorMsg: &HunyuanErrorMsg{Msg: fmt.Sprintf("read stream data failed: %v", err), Code: 500}}:
+				}
+				return
+			}
+
+			dataStr := strings.TrimSpace(string(data))
+			if !strings.HasPrefix(dataStr, "data:") {
+				continue
+			}
+
+			var chatResponse HunyuanResponse
+			if err := json.Unmarshal([]byte(strings.TrimSpace(dataStr[5:])), &chatResponse); err != nil {
+				select {
+				case <-ctx.Done():
+				case res <- HunyuanResponse{ErrorMsg: &HunyuanErrorMsg{Msg: fmt.Sprintf("decode response failed: %v", err), Code: 500}}:
+				}
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case res <- chatResponse:
+				if chatResponse.ErrorMsg != nil && chatResponse.ErrorMsg.Code != 0 {
+					return
+				}
+
+				if len(chatResponse.Choices) > 0 && chatResponse.Choices[0].FinishReason != "" {
+					return
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
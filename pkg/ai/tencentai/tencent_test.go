@@ -6,6 +6,7 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/tencentai"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/mylxsw/asteria/log"
@@ -95,3 +96,15 @@ func TestTencentMessageFix(t *testing.T) {
 
 	log.With(messages.Fix()).Debug("messages")
 }
+
+func TestSignTC3(t *testing.T) {
+	payload := []byte(`{"Messages":[{"Role":"user","Content":"你好"}]}`)
+	sign := tencentai.SignTC3("AKID", "secret", "hunyuan", "hunyuan.tencentcloudapi.com", payload, 1703491200)
+
+	assert.True(t, strings.HasPrefix(sign, "TC3-HMAC-SHA256 Credential=AKID/2023-12-25/hunyuan/tc3_request, SignedHeaders=content-type;host, Signature="))
+	assert.Equal(t, 64, len(sign[strings.LastIndex(sign, "=")+1:]))
+
+	// 签名与请求内容相关，请求内容变化时签名随之变化
+	assert.Equal(t, sign, tencentai.SignTC3("AKID", "secret", "hunyuan", "hunyuan.tencentcloudapi.com", payload, 1703491200))
+	assert.True(t, sign != tencentai.SignTC3("AKID", "secret", "hunyuan", "hunyuan.tencentcloudapi.com", []byte(`{}`), 1703491200))
+}
@@ -23,6 +23,31 @@ const (
 	ModelGeneralV3   Model = "generalv3"
 )
 
+// 星火平台错误码 https://www.xfyun.cn/doc/spark/Web.html#_6-%E9%94%99%E8%AF%AF%E7%A0%81
+const (
+	// ErrorCodeInputAuditFailed 输入内容审核不通过，涉嫌违规
+	ErrorCodeInputAuditFailed = 10013
+	// ErrorCodeOutputAuditFailed 输出内容涉及敏感信息，审核不通过
+	ErrorCodeOutputAuditFailed = 10014
+	// ErrorCodeAuditSuspected 本次会话内容有涉及违规信息的倾向
+	ErrorCodeAuditSuspected = 10019
+)
+
+// IsContentFilterCode 错误码是否表示内容违反了星火平台的内容安全策略
+func IsContentFilterCode(code int) bool {
+	return code == ErrorCodeInputAuditFailed || code == ErrorCodeOutputAuditFailed || code == ErrorCodeAuditSuspected
+}
+
+// 帧状态，0 代表首个结果，1 代表中间结果，2 代表最后一个结果
+const (
+	StatusFirst  = 0
+	StatusMiddle = 1
+	StatusLast   = 2
+)
+
+// frameReadTimeout 等待下一帧数据的最长时间
+const frameReadTimeout = 60 * time.Second
+
 type XFYunAI struct {
 	appID     string
 	apiKey    string
@@ -89,7 +114,7 @@ func (ai *XFYunAI) ChatStream(ctx context.Context, model Model, messages []Messa
 	ws := websocket.DefaultDialer
 
 	host := ai.resolveHostForModel(model)
-	urlStr := ai.assembleAuthURL(host, ai.apiKey, ai.apiSecret)
+	urlStr := AssembleAuthURL(host, ai.apiKey, ai.apiSecret, time.Now())
 	conn, resp, err := ws.DialContext(ctx, urlStr, nil)
 	if err != nil {
 		detail := fmt.Sprintf(" 模型：%s", model)
@@ -110,13 +135,26 @@ func (ai *XFYunAI) ChatStream(ctx context.Context, model Model, messages []Messa
 	}
 
 	respChan := make(chan Response)
+	done := make(chan struct{})
+
+	// 请求取消时关闭连接，中断阻塞的读操作
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
 	go func() {
 		defer func() {
+			close(done)
 			close(respChan)
 			_ = conn.Close()
 		}()
 
 		for {
+			_ = conn.SetReadDeadline(time.Now().Add(frameReadTimeout))
 			_, msg, err := conn.ReadMessage()
 			if err != nil {
 				if err == io.EOF || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
@@ -151,6 +189,10 @@ func (ai *XFYunAI) ChatStream(ctx context.Context, model Model, messages []Messa
 			case <-ctx.Done():
 				return
 			case respChan <- ret:
+				// 最后一帧包含本次会话的 token 用量，收到后即可结束，不必等待服务端关闭连接
+				if ret.Header.Status == StatusLast {
+					return
+				}
 			}
 		}
 	}()
@@ -158,17 +200,18 @@ func (ai *XFYunAI) ChatStream(ctx context.Context, model Model, messages []Messa
 	return respChan, nil
 }
 
-// assembleAuthURL 组装鉴权 URL
-func (ai *XFYunAI) assembleAuthURL(host, apiKey, secret string) string {
+// AssembleAuthURL 组装鉴权 URL，使用 hmac-sha256 对 host、date 和 request-line 签名，签名有效期为 5 分钟
+func AssembleAuthURL(host, apiKey, secret string, now time.Time) string {
 	ul, err := url.Parse(host)
 	if err != nil {
 		panic(err)
 	}
 
-	date := time.Now().UTC().Format(time.RFC1123)
+	// 星火平台要求 date 为 GMT 时区的 RFC1123 格式
+	date := now.UTC().Format(http.TimeFormat)
 	signString := []string{"host: " + ul.Host, "date: " + date, "GET " + ul.Path + " HTTP/1.1"}
 	sign := strings.Join(signString, "\n")
-	sha := ai.hmacWithShaToBase64(sign, secret)
+	sha := hmacWithShaToBase64(sign, secret)
 
 	authUrl := fmt.Sprintf(`hmac username="%s", algorithm="%s", headers="%s", signature="%s"`,
 		apiKey,
@@ -186,7 +229,7 @@ func (ai *XFYunAI) assembleAuthURL(host, apiKey, secret string) string {
 	return host + "?" + v.Encode()
 }
 
-func (ai *XFYunAI) hmacWithShaToBase64(data, key string) string {
+func hmacWithShaToBase64(data, key string) string {
 	mac := hmac.New(sha256.New, []byte(key))
 	mac.Write([]byte(data))
 	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
@@ -2,10 +2,16 @@ package xfyun_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/ai/xfyun"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mylxsw/go-utils/assert"
 )
@@ -26,3 +32,23 @@ func TestXFYunAI_ChatStream(t *testing.T) {
 
 	fmt.Println()
 }
+
+func TestAssembleAuthURL(t *testing.T) {
+	now := time.Date(2023, 12, 25, 8, 0, 0, 0, time.UTC)
+	authURL := xfyun.AssembleAuthURL("wss://spark-api.xf-yun.com/v3.1/chat", "key", "secret", now)
+
+	ul, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "spark-api.xf-yun.com", ul.Query().Get("host"))
+	assert.Equal(t, "Mon, 25 Dec 2023 08:00:00 GMT", ul.Query().Get("date"))
+
+	authorization, err := base64.StdEncoding.DecodeString(ul.Query().Get("authorization"))
+	assert.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("host: spark-api.xf-yun.com\ndate: Mon, 25 Dec 2023 08:00:00 GMT\nGET /v3.1/chat HTTP/1.1"))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, strings.HasPrefix(string(authorization), `hmac username="key", algorithm="hmac-sha256"`))
+	assert.True(t, strings.Contains(string(authorization), `signature="`+signature+`"`))
+}
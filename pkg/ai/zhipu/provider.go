@@ -0,0 +1,18 @@
+package zhipu
+
+import (
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/glacier/infra"
+)
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(func(conf *config.Config) *Zhipu {
+		if conf.ZhipuServer == "" {
+			conf.ZhipuServer = "https://open.bigmodel.cn/api/paas/v4"
+		}
+
+		return NewZhipu(conf.ZhipuServer, conf.ZhipuKey)
+	})
+}
@@ -0,0 +1,193 @@
+package zhipu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	openai2 "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/sashabaranov/go-openai"
+)
+
+// https://open.bigmodel.cn/dev/api
+const (
+	ModelGLM4      = "glm-4"
+	ModelGLM4V     = "glm-4v"
+	ModelGLM3Turbo = "glm-3-turbo"
+	ModelCogView3  = "cogview-3"
+)
+
+// tokenTTL 鉴权 Token 的有效期
+const tokenTTL = 30 * time.Minute
+
+// GenerateToken 生成智谱 AI 的鉴权 Token，apiKey 格式为 {id}.{secret}
+func GenerateToken(apiKey string, ttl time.Duration, now time.Time) (string, error) {
+	segs := strings.SplitN(apiKey, ".", 2)
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		return "", errors.New("invalid zhipu api key")
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"api_key":   segs[0],
+		"exp":       now.Add(ttl).UnixMilli(),
+		"timestamp": now.UnixMilli(),
+	})
+	token.Header["sign_type"] = "SIGN"
+
+	return token.SignedString([]byte(segs[1]))
+}
+
+// tokenSource 缓存鉴权 Token，过期前重新生成
+type tokenSource struct {
+	apiKey string
+
+	lock      sync.Mutex
+	token     string
+	expiredAt time.Time
+}
+
+func (ts *tokenSource) Token() (string, error) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	now := time.Now()
+	if ts.token != "" && now.Add(time.Minute).Before(ts.expiredAt) {
+		return ts.token, nil
+	}
+
+	token, err := GenerateToken(ts.apiKey, tokenTTL, now)
+	if err != nil {
+		return "", err
+	}
+
+	ts.token, ts.expiredAt = token, now.Add(tokenTTL)
+	return token, nil
+}
+
+// authTransport 为每个请求设置智谱 AI 的鉴权 Token
+type authTransport struct {
+	ts   *tokenSource
+	base http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
+
+type Zhipu struct {
+	server     string
+	client     openai2.Client
+	httpClient *http.Client
+}
+
+func NewZhipu(server, apiKey string) *Zhipu {
+	httpClient := &http.Client{
+		Timeout: 180 * time.Second,
+		Transport: &authTransport{
+			ts: &tokenSource{apiKey: apiKey},
+			base: &http.Transport{
+				DialContext: (&net.Dialer{Timeout: 120 * time.Second}).DialContext,
+			},
+		},
+	}
+
+	// 智谱 AI 的对话接口与 OpenAI 兼容，只是鉴权方式不同
+	conf := openai.DefaultConfig("")
+	conf.BaseURL = strings.TrimSuffix(server, "/")
+	conf.HTTPClient = httpClient
+
+	return &Zhipu{
+		server:     strings.TrimSuffix(server, "/"),
+		client:     openai2.New(&openai2.Config{Enable: true}, []*openai.Client{openai.NewClientWithConfig(conf)}),
+		httpClient: httpClient,
+	}
+}
+
+func (ai *Zhipu) ChatStream(ctx context.Context, request openai.ChatCompletionRequest) (<-chan openai2.ChatStreamResponse, error) {
+	return ai.client.ChatStream(ctx, request)
+}
+
+// Chat 以请求-响应的方式对话，支持工具调用
+func (ai *Zhipu) Chat(ctx context.Context, request openai.ChatCompletionRequest) (response openai.ChatCompletionResponse, err error) {
+	return ai.client.CreateChatCompletion(ctx, request)
+}
+
+// SupportModel 判断是否为智谱 AI 的对话模型
+func SupportModel(model string) bool {
+	model = strings.TrimPrefix(model, "zhipu:")
+	return model == ModelGLM4 || model == ModelGLM4V || model == ModelGLM3Turbo
+}
+
+// MaxContextSize 模型最大上下文长度，预留 4K 给模型输出
+func MaxContextSize(model string) int {
+	switch model {
+	case ModelGLM4, ModelGLM3Turbo:
+		return 128000 - 4096
+	case ModelGLM4V:
+		return 2000
+	}
+
+	return 4000
+}
+
+type ImageRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type ImageResponse struct {
+	Created int64       `json:"created"`
+	Data    []ImageData `json:"data"`
+}
+
+type ImageData struct {
+	URL string `json:"url"`
+}
+
+// CreateImage 使用 CogView 生成图片，每次请求生成一张图片
+func (ai *Zhipu) CreateImage(ctx context.Context, req ImageRequest) (*ImageResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, ai.server+"/images/generations", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := ai.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("create image failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("create image failed [%s]: %s", resp.Status, string(data))
+	}
+
+	var ret ImageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ret); err != nil {
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+
+	return &ret, nil
+}
@@ -0,0 +1,30 @@
+package zhipu_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestGenerateToken(t *testing.T) {
+	now := time.Now()
+	token, err := zhipu.GenerateToken("my-id.my-secret", time.Minute, now)
+	assert.NoError(t, err)
+
+	parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		return []byte("my-secret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "SIGN", parsed.Header["sign_type"])
+	assert.Equal(t, "HS256", parsed.Header["alg"])
+
+	claims := parsed.Claims.(jwt.MapClaims)
+	assert.Equal(t, "my-id", claims["api_key"])
+	assert.Equal(t, float64(now.UnixMilli()), claims["timestamp"])
+
+	_, err = zhipu.GenerateToken("invalid", time.Minute, now)
+	assert.True(t, err != nil)
+}
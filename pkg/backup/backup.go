@@ -0,0 +1,429 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// ManifestVersion 备份格式版本
+const ManifestVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	assetsFile   = "assets.jsonl.gz"
+	tablesDir    = "tables"
+)
+
+// excludedTables 不参与备份与恢复的数据表，迁移记录由新部署自己维护
+var excludedTables = []string{"migrations"}
+
+var (
+	ErrInvalidBackup   = errors.New("备份不存在或者已损坏")
+	ErrChecksumFailed  = errors.New("备份文件校验失败")
+	ErrTargetNotEmpty  = errors.New("目标数据库中已存在用户数据")
+	ErrTableNotMigrate = errors.New("目标数据库中缺少数据表，请先完成数据库迁移")
+)
+
+var namePattern = regexp.MustCompile(`^[0-9]{8}-[0-9]{6}$`)
+
+// Manifest 备份清单，记录备份时的数据表与对象存储文件，恢复前用于校验备份的完整性
+type Manifest struct {
+	Version       int       `json:"version"`
+	Name          string    `json:"name"`
+	ServerVersion string    `json:"server_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// StorageDomain 备份时对象存储的访问域名，恢复时用于替换数据中的文件地址
+	StorageDomain string  `json:"storage_domain"`
+	Tables        []Table `json:"tables"`
+	Assets        File    `json:"assets"`
+}
+
+// Table 数据表备份
+type Table struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	// CreateSQL 备份时的建表语句，仅用于排查问题，恢复时使用新部署迁移后的表结构
+	CreateSQL string `json:"create_sql"`
+	File
+}
+
+// File 备份文件及其校验信息
+type File struct {
+	Path   string `json:"path"`
+	Rows   int64  `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// Asset 对象存储中的文件
+type Asset struct {
+	Key  string `json:"key"`
+	Hash string `json:"hash,omitempty"`
+	Size int64  `json:"size"`
+}
+
+// ValidName 备份名称是否合法，备份名称会被拼接到路径中，必须校验
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Backups 备份目录下的所有备份，按照创建时间倒序排列
+func Backups(dir string) ([]Manifest, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []Manifest{}, nil
+		}
+
+		return nil, err
+	}
+
+	manifests := make([]Manifest, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() || !ValidName(entry.Name()) {
+			continue
+		}
+
+		// 没有清单文件的备份尚未完成或者已经失败
+		manifest, err := LoadManifest(dir, entry.Name())
+		if err != nil {
+			continue
+		}
+
+		manifests = append(manifests, *manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Name > manifests[j].Name })
+	return manifests, nil
+}
+
+// LoadManifest 读取备份清单
+func LoadManifest(dir, name string) (*Manifest, error) {
+	if !ValidName(name) {
+		return nil, ErrInvalidBackup
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name, manifestFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrInvalidBackup
+		}
+
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+
+	return &manifest, nil
+}
+
+// Dumper 生成一致性备份
+type Dumper struct {
+	DB            *sql.DB
+	Dir           string
+	ServerVersion string
+	StorageDomain string
+}
+
+// Dump 在同一个只读事务（一致性快照）中导出所有数据表，同时导出对象存储中的文件清单，清单文件最后写入，存在清单文件即表示备份完成
+func (d Dumper) Dump(ctx context.Context) (*Manifest, error) {
+	manifest := Manifest{
+		Version:       ManifestVersion,
+		Name:          time.Now().Format("20060102-150405"),
+		ServerVersion: d.ServerVersion,
+		CreatedAt:     time.Now(),
+		StorageDomain: d.StorageDomain,
+	}
+
+	root := filepath.Join(d.Dir, manifest.Name)
+	if err := os.MkdirAll(filepath.Join(root, tablesDir), 0700); err != nil {
+		return nil, err
+	}
+
+	tx, err := d.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// InnoDB 在事务的第一次读取时建立快照，之后所有表的读取都基于同一个快照
+	if _, err := tx.ExecContext(ctx, "SELECT 1"); err != nil {
+		return nil, err
+	}
+
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range tables {
+		table, err := dumpTable(ctx, tx, root, name)
+		if err != nil {
+			return nil, fmt.Errorf("dump table %s failed: %w", name, err)
+		}
+
+		manifest.Tables = append(manifest.Tables, *table)
+		log.F(log.M{"table": name, "rows": table.Rows}).Debugf("backup: table dumped")
+	}
+
+	assets, err := dumpAssets(ctx, tx, root)
+	if err != nil {
+		return nil, fmt.Errorf("dump assets failed: %w", err)
+	}
+	manifest.Assets = *assets
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(filepath.Join(root, manifestFile), data, 0600); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func listTables(ctx context.Context, db queryer) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SHOW FULL TABLES WHERE Table_type = 'BASE TABLE'")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make([]string, 0)
+	for rows.Next() {
+		var name, typ string
+		if err := rows.Scan(&name, &typ); err != nil {
+			return nil, err
+		}
+
+		if !array.In(name, excludedTables) {
+			tables = append(tables, name)
+		}
+	}
+
+	return tables, rows.Err()
+}
+
+// writer 写入 gzip 压缩的 JSON Lines 文件，同时计算压缩后文件的 SHA256
+type writer struct {
+	f    *os.File
+	gz   *gzip.Writer
+	enc  *json.Encoder
+	file File
+	sum  func() string
+}
+
+func newWriter(root, path string) (*writer, error) {
+	f, err := os.OpenFile(filepath.Join(root, path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(f, h))
+	return &writer{
+		f:    f,
+		gz:   gz,
+		enc:  json.NewEncoder(gz),
+		file: File{Path: path},
+		sum:  func() string { return hex.EncodeToString(h.Sum(nil)) },
+	}, nil
+}
+
+func (w *writer) Write(v any) error {
+	w.file.Rows++
+	return w.enc.Encode(v)
+}
+
+func (w *writer) Close() (*File, error) {
+	if err := w.gz.Close(); err != nil {
+		_ = w.f.Close()
+		return nil, err
+	}
+
+	if err := w.f.Close(); err != nil {
+		return nil, err
+	}
+
+	w.file.SHA256 = w.sum()
+	return &w.file, nil
+}
+
+func dumpTable(ctx context.Context, tx *sql.Tx, root, name string) (*Table, error) {
+	table := Table{Name: name}
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf("SHOW CREATE TABLE `%s`", name)).Scan(new(string), &table.CreateSQL); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `%s`", name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if table.Columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+
+	w, err := newWriter(root, filepath.Join(tablesDir, name+".jsonl.gz"))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(table.Columns))
+	ptrs := make([]any, len(table.Columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			_, _ = w.Close()
+			return nil, err
+		}
+
+		row := make([]any, len(values))
+		for i, v := range values {
+			row[i] = encodeValue(v)
+		}
+
+		if err := w.Write(row); err != nil {
+			_, _ = w.Close()
+			return nil, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		_, _ = w.Close()
+		return nil, err
+	}
+
+	file, err := w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	table.File = *file
+	return &table, nil
+}
+
+// encodeValue 将数据库中读取的值转换为可以原样写回的 JSON 值
+func encodeValue(v any) any {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case time.Time:
+		// 数据库连接使用 parseTime，时间已经转换为连接配置的时区，按照原格式写回即可保持原值
+		return val.Format("2006-01-02 15:04:05.999999")
+	default:
+		return val
+	}
+}
+
+// dumpAssets 导出对象存储中由本系统上传的文件清单，用于在新部署中校验文件是否完整
+func dumpAssets(ctx context.Context, tx *sql.Tx, root string) (*File, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT file_key, hash, file_size FROM storage_file WHERE file_key IS NOT NULL ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	w, err := newWriter(root, assetsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var key, hash sql.NullString
+		var size sql.NullInt64
+		if err := rows.Scan(&key, &hash, &size); err != nil {
+			_, _ = w.Close()
+			return nil, err
+		}
+
+		if err := w.Write(Asset{Key: key.String, Hash: hash.String, Size: size.Int64}); err != nil {
+			_, _ = w.Close()
+			return nil, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		_, _ = w.Close()
+		return nil, err
+	}
+
+	return w.Close()
+}
+
+// readLines 逐行读取备份文件，读取前校验文件的 SHA256
+func readLines(root string, file File, fn func(line []byte) error) error {
+	if err := verifyFile(root, file); err != nil {
+		return err
+	}
+
+	f, err := os.Open(filepath.Join(root, file.Path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func verifyFile(root string, file File) error {
+	f, err := os.Open(filepath.Join(root, file.Path))
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrChecksumFailed, file.Path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(h.Sum(nil)) != file.SHA256 {
+		return fmt.Errorf("%w: %s", ErrChecksumFailed, file.Path)
+	}
+
+	return nil
+}
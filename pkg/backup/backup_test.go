@@ -0,0 +1,88 @@
+package backup_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/backup"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestURLRekeyer(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rekeyer := backup.NewURLRekeyer("https://old.example.com/", "https://new.example.com", func(key string, ttl time.Duration) string {
+		return fmt.Sprintf("https://new.example.com/%s?signed=%d", key, int64(ttl.Hours()))
+	})
+
+	s, n := rekeyer.Rewrite(`{"url":"https://old.example.com/ai-server/a.png","preview":"https://old.example.com/b.png?e=1700360000&token=ak:sig="}`, now)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, `{"url":"https://new.example.com/ai-server/a.png","preview":"https://new.example.com/b.png?signed=100"}`, s)
+
+	// 已过期的签名地址重新签名后至少有效 24 小时
+	s, _ = rekeyer.Rewrite("https://old.example.com/c.png?e=1600000000&token=ak:sig", now)
+	assert.Equal(t, "https://new.example.com/c.png?signed=24", s)
+
+	s, n = rekeyer.Rewrite("https://other.example.com/a.png", now)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, "https://other.example.com/a.png", s)
+
+	s, n = backup.NewURLRekeyer("", "https://new.example.com", nil).Rewrite("https://old.example.com/a.png", now)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, "https://old.example.com/a.png", s)
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	name := "20240103-120000"
+	assert.True(t, backup.ValidName(name))
+	assert.False(t, backup.ValidName("../20240103-120000"))
+
+	root := filepath.Join(dir, name)
+	assert.NoError(t, os.MkdirAll(root, 0700))
+
+	data := []byte("not really gzip")
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "users.jsonl.gz"), data, 0600))
+	sum := sha256.Sum256(data)
+
+	manifest := backup.Manifest{
+		Version: backup.ManifestVersion,
+		Name:    name,
+		Tables: []backup.Table{
+			{Name: "users", File: backup.File{Path: "users.jsonl.gz", Rows: 3, SHA256: hex.EncodeToString(sum[:])}},
+		},
+		Assets: backup.File{Path: "assets.jsonl.gz", SHA256: "invalid"},
+	}
+
+	writeManifest := func() {
+		raw, _ := json.Marshal(manifest)
+		assert.NoError(t, os.WriteFile(filepath.Join(root, "manifest.json"), raw, 0600))
+	}
+
+	writeManifest()
+	_, err := backup.Verify(context.TODO(), dir, name, nil)
+	assert.True(t, errors.Is(err, backup.ErrChecksumFailed))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "assets.jsonl.gz"), data, 0600))
+	manifest.Assets.SHA256 = hex.EncodeToString(sum[:])
+	writeManifest()
+
+	res, err := backup.Verify(context.TODO(), dir, name, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Tables)
+	assert.Equal(t, int64(3), res.Rows)
+
+	manifests, err := backup.Backups(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(manifests))
+
+	_, err = backup.LoadManifest(dir, "../etc")
+	assert.True(t, errors.Is(err, backup.ErrInvalidBackup))
+}
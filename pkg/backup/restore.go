@@ -0,0 +1,372 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// restoreBatchSize 每次批量写入的行数
+const restoreBatchSize = 200
+
+// resignMinTTL 重新签名的私有文件地址的最短有效期
+const resignMinTTL = 24 * time.Hour
+
+// Storage 新部署的对象存储
+type Storage interface {
+	// Stat 查询文件信息，文件不存在时返回 nil
+	Stat(ctx context.Context, key string) (*Asset, error)
+	// MakePrivateURL 使用新部署的密钥生成私有文件访问地址
+	MakePrivateURL(key string, ttl time.Duration) string
+}
+
+// URLRekeyer 将数据中旧部署的文件地址替换为新部署的地址，带签名的私有文件地址使用新部署的密钥重新签名
+type URLRekeyer struct {
+	pattern   *regexp.Regexp
+	newDomain string
+	sign      func(key string, ttl time.Duration) string
+}
+
+// NewURLRekeyer 创建文件地址替换器，旧域名为空时不替换
+func NewURLRekeyer(oldDomain, newDomain string, sign func(key string, ttl time.Duration) string) *URLRekeyer {
+	oldDomain = strings.TrimRight(oldDomain, "/")
+	if oldDomain == "" {
+		return &URLRekeyer{}
+	}
+
+	return &URLRekeyer{
+		pattern:   regexp.MustCompile(regexp.QuoteMeta(oldDomain) + `/([^\s"'?#<>()\\]+)(\?e=(\d+)&token=[A-Za-z0-9_\-=:]+)?`),
+		newDomain: strings.TrimRight(ternary.If(newDomain == "", oldDomain, newDomain), "/"),
+		sign:      sign,
+	}
+}
+
+// Rewrite 替换字符串中的文件地址，返回替换后的字符串与替换的地址数量
+func (r *URLRekeyer) Rewrite(s string, now time.Time) (string, int) {
+	if r.pattern == nil {
+		return s, 0
+	}
+
+	var count int
+	rewritten := r.pattern.ReplaceAllStringFunc(s, func(u string) string {
+		match := r.pattern.FindStringSubmatch(u)
+		count++
+
+		if match[2] == "" || r.sign == nil {
+			return r.newDomain + "/" + match[1]
+		}
+
+		ttl := resignMinTTL
+		if deadline, err := strconv.ParseInt(match[3], 10, 64); err == nil && time.Unix(deadline, 0).Sub(now) > ttl {
+			ttl = time.Unix(deadline, 0).Sub(now)
+		}
+
+		return r.sign(match[1], ttl)
+	})
+
+	return rewritten, count
+}
+
+// Restorer 将备份恢复到新部署的数据库中
+type Restorer struct {
+	DB            *sql.DB
+	Dir           string
+	StorageDomain string
+	Storage       Storage
+	// Force 目标数据库中已存在用户数据时是否仍然恢复
+	Force bool
+}
+
+// RestoreResult 恢复结果
+type RestoreResult struct {
+	Tables int   `json:"tables"`
+	Rows   int64 `json:"rows"`
+	// RekeyedURLs 替换或者重新签名的文件地址数量
+	RekeyedURLs int64 `json:"rekeyed_urls"`
+	// SkippedColumns 新部署中已不存在，恢复时被忽略的字段
+	SkippedColumns map[string][]string `json:"skipped_columns,omitempty"`
+}
+
+// Restore 校验备份文件后，在同一个事务中使用备份数据替换目标数据库中对应数据表的全部数据
+// 目标数据库需要先由新版本完成迁移，恢复时以新部署的表结构为准
+func (r Restorer) Restore(ctx context.Context, name string) (*RestoreResult, error) {
+	manifest, err := LoadManifest(r.Dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackup, manifest.Version)
+	}
+
+	root := filepath.Join(r.Dir, name)
+	for _, table := range manifest.Tables {
+		if err := verifyFile(root, table.File); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := listTables(ctx, r.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range manifest.Tables {
+		if !array.In(table.Name, existing) {
+			return nil, fmt.Errorf("%w: %s", ErrTableNotMigrate, table.Name)
+		}
+	}
+
+	if !r.Force {
+		var users int64
+		if err := r.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&users); err != nil {
+			return nil, err
+		}
+
+		if users > 0 {
+			return nil, ErrTargetNotEmpty
+		}
+	}
+
+	var sign func(key string, ttl time.Duration) string
+	if r.Storage != nil {
+		sign = r.Storage.MakePrivateURL
+	}
+
+	rekeyer := NewURLRekeyer(manifest.StorageDomain, r.StorageDomain, sign)
+	result := RestoreResult{SkippedColumns: make(map[string][]string)}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, table := range manifest.Tables {
+		rows, rekeyed, skipped, err := restoreTable(ctx, tx, root, table, rekeyer, now)
+		if err != nil {
+			return nil, fmt.Errorf("restore table %s failed: %w", table.Name, err)
+		}
+
+		result.Tables++
+		result.Rows += rows
+		result.RekeyedURLs += rekeyed
+		if len(skipped) > 0 {
+			result.SkippedColumns[table.Name] = skipped
+		}
+
+		log.F(log.M{"table": table.Name, "rows": rows, "rekeyed": rekeyed}).Debugf("backup: table restored")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func targetColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SHOW COLUMNS FROM `%s`", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0)
+	for rows.Next() {
+		values := make([]any, len(cols))
+		var field string
+		values[0] = &field
+		for i := 1; i < len(values); i++ {
+			values[i] = new(sql.RawBytes)
+		}
+
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, field)
+	}
+
+	return columns, rows.Err()
+}
+
+func restoreTable(ctx context.Context, tx *sql.Tx, root string, table Table, rekeyer *URLRekeyer, now time.Time) (int64, int64, []string, error) {
+	target, err := targetColumns(ctx, tx, table.Name)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	// 只写入新部署中仍然存在的字段，新增的字段使用默认值
+	indexes, columns, skipped := make([]int, 0), make([]string, 0), make([]string, 0)
+	for i, col := range table.Columns {
+		if array.In(col, target) {
+			indexes, columns = append(indexes, i), append(columns, "`"+col+"`")
+		} else {
+			skipped = append(skipped, col)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM `%s`", table.Name)); err != nil {
+		return 0, 0, nil, err
+	}
+
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+	var rows, rekeyed int64
+	batch := make([]any, 0, restoreBatchSize*len(columns))
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		n := len(batch) / len(columns)
+		sqlStr := fmt.Sprintf(
+			"INSERT INTO `%s` (%s) VALUES %s",
+			table.Name,
+			strings.Join(columns, ","),
+			strings.TrimSuffix(strings.Repeat(placeholder+",", n), ","),
+		)
+
+		_, err := tx.ExecContext(ctx, sqlStr, batch...)
+		batch = batch[:0]
+		return err
+	}
+
+	err = readLines(root, table.File, func(line []byte) error {
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.UseNumber()
+
+		var row []any
+		if err := dec.Decode(&row); err != nil {
+			return err
+		}
+
+		if len(row) != len(table.Columns) {
+			return fmt.Errorf("%w: column count mismatch", ErrInvalidBackup)
+		}
+
+		for _, i := range indexes {
+			switch v := row[i].(type) {
+			case string:
+				s, n := rekeyer.Rewrite(v, now)
+				rekeyed += int64(n)
+				batch = append(batch, s)
+			case json.Number:
+				batch = append(batch, v.String())
+			default:
+				batch = append(batch, v)
+			}
+		}
+
+		rows++
+		if len(batch) >= restoreBatchSize*len(columns) {
+			return flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	if err := flush(); err != nil {
+		return 0, 0, nil, err
+	}
+
+	return rows, rekeyed, skipped, nil
+}
+
+// VerifyResult 备份校验结果
+type VerifyResult struct {
+	Tables int   `json:"tables"`
+	Rows   int64 `json:"rows"`
+	Assets int64 `json:"assets"`
+	// MissingAssets 对象存储中不存在的文件，最多返回 100 个
+	MissingAssets []string `json:"missing_assets"`
+	// CorruptedAssets 对象存储中大小或者哈希与备份时不一致的文件，最多返回 100 个
+	CorruptedAssets []string `json:"corrupted_assets"`
+	MissingCount    int64    `json:"missing_count"`
+	CorruptedCount  int64    `json:"corrupted_count"`
+}
+
+const maxReportedAssets = 100
+
+// Verify 校验备份文件的完整性，storage 不为空时同时校验对象存储中的文件是否完整
+func Verify(ctx context.Context, dir, name string, storage Storage) (*VerifyResult, error) {
+	manifest, err := LoadManifest(dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	root := filepath.Join(dir, name)
+	result := VerifyResult{MissingAssets: []string{}, CorruptedAssets: []string{}}
+	for _, table := range manifest.Tables {
+		if err := verifyFile(root, table.File); err != nil {
+			return nil, err
+		}
+
+		result.Tables++
+		result.Rows += table.Rows
+	}
+
+	if err := verifyFile(root, manifest.Assets); err != nil {
+		return nil, err
+	}
+
+	if storage == nil {
+		result.Assets = manifest.Assets.Rows
+		return &result, nil
+	}
+
+	err = readLines(root, manifest.Assets, func(line []byte) error {
+		var asset Asset
+		if err := json.Unmarshal(line, &asset); err != nil {
+			return err
+		}
+
+		result.Assets++
+		current, err := storage.Stat(ctx, asset.Key)
+		if err != nil {
+			return err
+		}
+
+		if current == nil {
+			result.MissingCount++
+			if len(result.MissingAssets) < maxReportedAssets {
+				result.MissingAssets = append(result.MissingAssets, asset.Key)
+			}
+			return nil
+		}
+
+		if (asset.Size > 0 && current.Size != asset.Size) || (asset.Hash != "" && current.Hash != asset.Hash) {
+			result.CorruptedCount++
+			if len(result.CorruptedAssets) < maxReportedAssets {
+				result.CorruptedAssets = append(result.CorruptedAssets, asset.Key)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,278 @@
+package chatimport
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SourceChatGPT ChatGPT 官方导出的聊天记录（conversations.json）
+const SourceChatGPT = "chatgpt"
+
+var ErrInvalidExport = errors.New("无法识别的聊天记录导出文件")
+
+// 消息角色
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message 导入的单条消息
+type Message struct {
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	Model     string    `json:"model,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Conversation 导入的单个会话
+type Conversation struct {
+	// ID 会话在来源平台中的 ID，用于重复导入时去重
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Model     string    `json:"model,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Messages  []Message `json:"messages"`
+}
+
+type chatGPTConversation struct {
+	ID               string                 `json:"id"`
+	ConversationID   string                 `json:"conversation_id"`
+	Title            string                 `json:"title"`
+	CreateTime       float64                `json:"create_time"`
+	UpdateTime       float64                `json:"update_time"`
+	CurrentNode      string                 `json:"current_node"`
+	DefaultModelSlug string                 `json:"default_model_slug"`
+	Mapping          map[string]chatGPTNode `json:"mapping"`
+}
+
+type chatGPTNode struct {
+	ID       string          `json:"id"`
+	Parent   string          `json:"parent"`
+	Children []string        `json:"children"`
+	Message  *chatGPTMessage `json:"message"`
+}
+
+type chatGPTMessage struct {
+	Author struct {
+		Role string `json:"role"`
+	} `json:"author"`
+	CreateTime float64 `json:"create_time"`
+	Content    struct {
+		ContentType string            `json:"content_type"`
+		Parts       []json.RawMessage `json:"parts"`
+	} `json:"content"`
+	Metadata struct {
+		ModelSlug      string `json:"model_slug"`
+		VisuallyHidden bool   `json:"is_visually_hidden_from_conversation"`
+	} `json:"metadata"`
+}
+
+func unixTime(ts float64) time.Time {
+	if ts <= 0 {
+		return time.Time{}
+	}
+
+	sec, frac := math.Modf(ts)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// text 消息中的文本内容，图片、文件等非文本内容会被忽略
+func (msg *chatGPTMessage) text() string {
+	if msg.Content.ContentType != "text" && msg.Content.ContentType != "multimodal_text" {
+		return ""
+	}
+
+	parts := make([]string, 0, len(msg.Content.Parts))
+	for _, raw := range msg.Content.Parts {
+		var part string
+		if err := json.Unmarshal(raw, &part); err != nil {
+			continue
+		}
+
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// ParseChatGPT 解析 ChatGPT 导出的 conversations.json，会话中存在多个分支（重新生成、编辑问题）时，只保留最终展示的分支
+func ParseChatGPT(r io.Reader) ([]Conversation, error) {
+	var items []chatGPTConversation
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+
+	conversations := make([]Conversation, 0, len(items))
+	for _, item := range items {
+		conv := Conversation{
+			ID:        item.ConversationID,
+			Title:     strings.TrimSpace(item.Title),
+			CreatedAt: unixTime(item.CreateTime),
+			UpdatedAt: unixTime(item.UpdateTime),
+			Model:     item.DefaultModelSlug,
+		}
+		if conv.ID == "" {
+			conv.ID = item.ID
+		}
+
+		if conv.ID == "" || len(item.Mapping) == 0 {
+			return nil, ErrInvalidExport
+		}
+
+		conv.Messages = chatGPTThread(item)
+		if len(conv.Messages) == 0 {
+			continue
+		}
+
+		if conv.Title == "" {
+			conv.Title = conv.Messages[0].Content
+		}
+
+		conversations = append(conversations, conv)
+	}
+
+	sort.SliceStable(conversations, func(i, j int) bool {
+		return conversations[i].CreatedAt.Before(conversations[j].CreatedAt)
+	})
+
+	return conversations, nil
+}
+
+// ParseChatGPTFile 解析 ChatGPT 导出的文件，支持导出的 zip 压缩包以及其中的 conversations.json
+func ParseChatGPTFile(path string) ([]Conversation, error) {
+	if zr, err := zip.OpenReader(path); err == nil {
+		defer zr.Close()
+
+		for _, f := range zr.File {
+			if filepath.Base(f.Name) != "conversations.json" {
+				continue
+			}
+
+			r, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+
+			return ParseChatGPT(r)
+		}
+
+		return nil, fmt.Errorf("%w: conversations.json not found", ErrInvalidExport)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseChatGPT(f)
+}
+
+// chatGPTThread 从当前节点回溯到根节点，得到最终展示的消息列表
+func chatGPTThread(conv chatGPTConversation) []Message {
+	current := conv.CurrentNode
+	if _, ok := conv.Mapping[current]; !ok {
+		current = latestLeaf(conv.Mapping)
+	}
+
+	messages := make([]Message, 0)
+	visited := make(map[string]bool)
+	for current != "" && !visited[current] {
+		visited[current] = true
+
+		node, ok := conv.Mapping[current]
+		if !ok {
+			break
+		}
+
+		current = node.Parent
+		if node.Message == nil || node.Message.Metadata.VisuallyHidden {
+			continue
+		}
+
+		role := node.Message.Author.Role
+		if role != RoleUser && role != RoleAssistant {
+			continue
+		}
+
+		content := node.Message.text()
+		if content == "" {
+			continue
+		}
+
+		messages = append(messages, Message{
+			Role:      role,
+			Content:   content,
+			Model:     node.Message.Metadata.ModelSlug,
+			CreatedAt: unixTime(node.Message.CreateTime),
+		})
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+
+	return mergeConsecutive(messages)
+}
+
+// latestLeaf 未指定当前节点时，选择最后创建的叶子节点
+func latestLeaf(mapping map[string]chatGPTNode) string {
+	var leaf string
+	var latest float64 = -1
+	for id, node := range mapping {
+		if len(node.Children) > 0 {
+			continue
+		}
+
+		var ts float64
+		if node.Message != nil {
+			ts = node.Message.CreateTime
+		}
+
+		if ts > latest || (ts == latest && id > leaf) {
+			leaf, latest = id, ts
+		}
+	}
+
+	return leaf
+}
+
+// mergeConsecutive 合并同一角色的连续消息（例如调用插件时拆分的多段回复），保持问答交替
+func mergeConsecutive(messages []Message) []Message {
+	merged := make([]Message, 0, len(messages))
+	for _, msg := range messages {
+		if n := len(merged); n > 0 && merged[n-1].Role == msg.Role {
+			merged[n-1].Content += "\n\n" + msg.Content
+			if merged[n-1].Model == "" {
+				merged[n-1].Model = msg.Model
+			}
+			continue
+		}
+
+		merged = append(merged, msg)
+	}
+
+	return merged
+}
+
+// ChatGPTModel 将 ChatGPT 的模型标识转换为本系统中对应的模型，用于导入后继续对话
+func ChatGPTModel(slug string) string {
+	if strings.HasPrefix(slug, "gpt-4") {
+		return "gpt-4"
+	}
+
+	return "gpt-3.5-turbo"
+}
@@ -0,0 +1,78 @@
+package chatimport_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/chatimport"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+const chatGPTExport = `[
+  {
+    "title": "Go 泛型",
+    "create_time": 1700000000.5,
+    "update_time": 1700000100.0,
+    "conversation_id": "conv-1",
+    "current_node": "a2",
+    "default_model_slug": "gpt-4",
+    "mapping": {
+      "root": {"id": "root", "parent": null, "children": ["sys"], "message": null},
+      "sys": {"id": "sys", "parent": "root", "children": ["u1"], "message": {"author": {"role": "system"}, "content": {"content_type": "text", "parts": [""]}, "metadata": {"is_visually_hidden_from_conversation": true}}},
+      "u1": {"id": "u1", "parent": "sys", "children": ["a1", "a2"], "message": {"author": {"role": "user"}, "create_time": 1700000001, "content": {"content_type": "text", "parts": ["什么是泛型？"]}, "metadata": {}}},
+      "a1": {"id": "a1", "parent": "u1", "children": [], "message": {"author": {"role": "assistant"}, "create_time": 1700000002, "content": {"content_type": "text", "parts": ["旧的回答"]}, "metadata": {"model_slug": "gpt-4"}}},
+      "a2": {"id": "a2", "parent": "u1", "children": [], "message": {"author": {"role": "assistant"}, "create_time": 1700000003, "content": {"content_type": "multimodal_text", "parts": ["重新生成的回答", {"asset_pointer": "file-service://x"}]}, "metadata": {"model_slug": "gpt-4"}}}
+    }
+  },
+  {
+    "title": "",
+    "create_time": 1600000000,
+    "update_time": 1600000000,
+    "id": "conv-0",
+    "current_node": "missing",
+    "mapping": {
+      "u1": {"id": "u1", "parent": null, "children": ["t1"], "message": {"author": {"role": "user"}, "create_time": 1600000001, "content": {"content_type": "text", "parts": ["你好"]}, "metadata": {}}},
+      "t1": {"id": "t1", "parent": "u1", "children": ["a1"], "message": {"author": {"role": "tool"}, "create_time": 1600000002, "content": {"content_type": "text", "parts": ["tool output"]}, "metadata": {}}},
+      "a1": {"id": "a1", "parent": "t1", "children": ["a2"], "message": {"author": {"role": "assistant"}, "create_time": 1600000003, "content": {"content_type": "text", "parts": ["第一段"]}, "metadata": {}}},
+      "a2": {"id": "a2", "parent": "a1", "children": [], "message": {"author": {"role": "assistant"}, "create_time": 1600000004, "content": {"content_type": "text", "parts": ["第二段"]}, "metadata": {"model_slug": "text-davinci-002-render-sha"}}}
+    }
+  },
+  {
+    "title": "空会话",
+    "create_time": 1650000000,
+    "conversation_id": "conv-empty",
+    "mapping": {"root": {"id": "root", "children": [], "message": null}}
+  }
+]`
+
+func TestParseChatGPT(t *testing.T) {
+	conversations, err := chatimport.ParseChatGPT(strings.NewReader(chatGPTExport))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(conversations))
+
+	// 按照创建时间排序，没有标题时使用第一条消息作为标题
+	first := conversations[0]
+	assert.Equal(t, "conv-0", first.ID)
+	assert.Equal(t, "你好", first.Title)
+	assert.Equal(t, 2, len(first.Messages))
+	assert.Equal(t, "第一段\n\n第二段", first.Messages[1].Content)
+	assert.Equal(t, "text-davinci-002-render-sha", first.Messages[1].Model)
+
+	// 只保留当前展示的分支
+	second := conversations[1]
+	assert.Equal(t, "conv-1", second.ID)
+	assert.Equal(t, "gpt-4", second.Model)
+	assert.Equal(t, 2, len(second.Messages))
+	assert.Equal(t, chatimport.RoleUser, second.Messages[0].Role)
+	assert.Equal(t, "重新生成的回答", second.Messages[1].Content)
+	assert.Equal(t, int64(1700000000), second.CreatedAt.Unix())
+
+	assert.Equal(t, "gpt-4", chatimport.ChatGPTModel("gpt-4o"))
+	assert.Equal(t, "gpt-3.5-turbo", chatimport.ChatGPTModel("text-davinci-002-render-sha"))
+
+	_, err = chatimport.ParseChatGPT(strings.NewReader(`{"foo": "bar"}`))
+	assert.True(t, err != nil)
+
+	_, err = chatimport.ParseChatGPT(strings.NewReader(`[{"title": "x"}]`))
+	assert.True(t, err != nil)
+}
@@ -0,0 +1,423 @@
+package image
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+	"github.com/mylxsw/aidea-server/pkg/markdown"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	// cardWidth 卡片宽度
+	cardWidth = 1080
+	// cardMargin 卡片面板与图片边缘的距离
+	cardMargin = 48
+	// cardPadding 卡片面板的内边距
+	cardPadding = 56
+	// cardMaxContentHeight 卡片内容的最大高度，超出部分截断，避免生成过大的图片
+	cardMaxContentHeight = 9000
+
+	cardTextSize   = 32
+	cardCodeSize   = 26
+	cardLabelSize  = 26
+	cardFooterSize = 24
+	cardLineHeight = 1.6
+)
+
+// cardPalette 卡片配色，代码块使用 One Dark 配色
+var cardPalette = struct {
+	Background, Panel, Text, Muted, Quote, Rule, Accent            string
+	CodeBackground, CodePlain, CodeKeyword, CodeString, CodeNumber string
+	CodeComment                                                    string
+}{
+	Background:     "#EEF1F5",
+	Panel:          "#FFFFFF",
+	Text:           "#1F2937",
+	Muted:          "#6B7280",
+	Quote:          "#CBD5E1",
+	Rule:           "#E5E7EB",
+	Accent:         "#4F6BED",
+	CodeBackground: "#282C34",
+	CodePlain:      "#ABB2BF",
+	CodeKeyword:    "#C678DD",
+	CodeString:     "#98C379",
+	CodeNumber:     "#D19A66",
+	CodeComment:    "#7F848E",
+}
+
+// CardSection 卡片中的一段内容，例如用户的提问或者 AI 的回复
+type CardSection struct {
+	// Label 内容标签，例如「问」「答」
+	Label string
+	// Content Markdown 格式的内容
+	Content string
+}
+
+// MessageCard 消息分享卡片
+type MessageCard struct {
+	Sections []CardSection
+	// Brand 底部左侧的品牌信息
+	Brand string
+	// Subtitle 底部右侧的附加信息，例如模型名称与日期
+	Subtitle string
+}
+
+var fontCache sync.Map
+
+// loadFonts 加载卡片使用的字体，未配置字体文件时使用内置的 Go 字体（不支持中文）
+func (builder *Imager) loadFonts() (text *truetype.Font, code *truetype.Font, err error) {
+	if builder.fontPath == "" {
+		text, err = truetype.Parse(goregular.TTF)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		code, err = truetype.Parse(gomono.TTF)
+		return text, code, err
+	}
+
+	if cached, ok := fontCache.Load(builder.fontPath); ok {
+		return cached.(*truetype.Font), cached.(*truetype.Font), nil
+	}
+
+	data, err := os.ReadFile(builder.fontPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取字体文件失败: %w", err)
+	}
+
+	f, err := truetype.Parse(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析字体文件失败: %w", err)
+	}
+
+	fontCache.Store(builder.fontPath, f)
+	// 内置的等宽字体不支持中文，配置了字体文件时代码块同样使用该字体
+	return f, f, nil
+}
+
+// cardRenderer 卡片渲染器，先在不绘制的情况下计算高度，然后使用相同的逻辑绘制
+type cardRenderer struct {
+	dc       *gg.Context
+	draw     bool
+	text     *truetype.Font
+	code     *truetype.Font
+	faces    map[string]font.Face
+	left     float64
+	width    float64
+	y        float64
+	truncate bool
+}
+
+func (r *cardRenderer) face(f *truetype.Font, size float64) font.Face {
+	key := fmt.Sprintf("%p:%v", f, size)
+	if face, ok := r.faces[key]; ok {
+		return face
+	}
+
+	face := truetype.NewFace(f, &truetype.Options{Size: size})
+	r.faces[key] = face
+	return face
+}
+
+// MessageCard 将消息渲染为 PNG 格式的分享卡片，Markdown 内容会被排版，代码块进行语法着色
+func (builder *Imager) MessageCard(card MessageCard) ([]byte, error) {
+	textFont, codeFont, err := builder.loadFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &cardRenderer{
+		dc:    gg.NewContext(1, 1),
+		text:  textFont,
+		code:  codeFont,
+		faces: make(map[string]font.Face),
+		left:  cardMargin + cardPadding,
+		width: cardWidth - 2*(cardMargin+cardPadding),
+	}
+
+	// 第一遍只计算布局高度
+	contentHeight := r.render(card)
+
+	height := int(contentHeight + cardMargin*2 + cardPadding*2 + cardFooterSize*3)
+	r.dc = gg.NewContext(cardWidth, height)
+	r.draw = true
+	r.y = 0
+	r.truncate = false
+
+	r.dc.SetHexColor(cardPalette.Background)
+	r.dc.Clear()
+
+	panelHeight := contentHeight + cardPadding*2
+	r.dc.SetHexColor(cardPalette.Panel)
+	r.dc.DrawRoundedRectangle(cardMargin, cardMargin, cardWidth-2*cardMargin, panelHeight, 24)
+	r.dc.Fill()
+
+	r.render(card)
+
+	// 底部品牌信息
+	footerY := cardMargin + panelHeight + cardFooterSize*2
+	r.dc.SetFontFace(r.face(r.text, cardFooterSize))
+	r.dc.SetHexColor(cardPalette.Muted)
+	r.dc.DrawStringAnchored(card.Brand, cardMargin+8, footerY, 0, 0)
+	r.dc.DrawStringAnchored(card.Subtitle, cardWidth-cardMargin-8, footerY, 1, 0)
+
+	buf := bytes.NewBuffer(nil)
+	if err := r.dc.EncodePNG(buf); err != nil {
+		return nil, fmt.Errorf("编码 PNG 数据失败: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// render 排版卡片内容，返回内容的高度，draw 为 true 时同时绘制
+func (r *cardRenderer) render(card MessageCard) float64 {
+	top := float64(cardMargin + cardPadding)
+	r.y = top
+
+	for i, section := range card.Sections {
+		if r.truncate {
+			break
+		}
+
+		if i > 0 {
+			r.y += 24
+			r.rule()
+			r.y += 24
+		}
+
+		if section.Label != "" {
+			r.label(section.Label)
+		}
+
+		for _, block := range ParseMarkdown(section.Content) {
+			if r.y-top > cardMaxContentHeight {
+				r.truncate = true
+				break
+			}
+
+			r.block(block)
+		}
+	}
+
+	if r.truncate {
+		r.y += 16
+		r.paragraph("……（内容过长，完整内容请在 App 中查看）", cardTextSize*0.8, cardPalette.Muted, 0)
+	}
+
+	return r.y - top
+}
+
+func (r *cardRenderer) label(text string) {
+	face := r.face(r.text, cardLabelSize)
+	r.dc.SetFontFace(face)
+	w, _ := r.dc.MeasureString(text)
+	h := float64(cardLabelSize) * 1.6
+
+	if r.draw {
+		r.dc.SetHexColor(cardPalette.Accent)
+		r.dc.DrawRoundedRectangle(r.left, r.y, w+32, h, h/2)
+		r.dc.Fill()
+		r.dc.SetHexColor("#FFFFFF")
+		r.dc.DrawStringAnchored(text, r.left+16, r.y+h/2, 0, 0.35)
+	}
+
+	r.y += h + 20
+}
+
+func (r *cardRenderer) rule() {
+	if r.draw {
+		r.dc.SetHexColor(cardPalette.Rule)
+		r.dc.SetLineWidth(2)
+		r.dc.DrawLine(r.left, r.y, r.left+r.width, r.y)
+		r.dc.Stroke()
+	}
+}
+
+func (r *cardRenderer) block(block Block) {
+	switch block.Type {
+	case BlockHeading:
+		size := map[int]float64{1: 44, 2: 40, 3: 36}[block.Level]
+		if size == 0 {
+			size = 34
+		}
+
+		r.y += 8
+		r.paragraph(block.Text, size, cardPalette.Text, 0)
+	case BlockListItem:
+		indent := float64(block.Level)*32 + 8
+		r.dc.SetFontFace(r.face(r.text, cardTextSize))
+		if r.draw {
+			r.dc.SetHexColor(cardPalette.Accent)
+			r.dc.DrawStringAnchored(block.Marker, r.left+indent, r.y+cardTextSize, 0, 0)
+		}
+
+		markerWidth, _ := r.dc.MeasureString(block.Marker)
+		r.paragraph(block.Text, cardTextSize, cardPalette.Text, indent+markerWidth+14)
+	case BlockQuote:
+		start := r.y
+		r.paragraph(block.Text, cardTextSize, cardPalette.Muted, 28)
+		if r.draw {
+			r.dc.SetHexColor(cardPalette.Quote)
+			r.dc.DrawRectangle(r.left+4, start, 6, r.y-start-12)
+			r.dc.Fill()
+		}
+	case BlockCode:
+		r.codeBlock(block)
+	case BlockRule:
+		r.y += 12
+		r.rule()
+		r.y += 24
+	default:
+		r.paragraph(block.Text, cardTextSize, cardPalette.Text, 0)
+	}
+}
+
+// paragraph 绘制自动换行的文本，indent 为相对于内容左侧的缩进
+func (r *cardRenderer) paragraph(text string, size float64, color string, indent float64) {
+	r.dc.SetFontFace(r.face(r.text, size))
+	lineHeight := size * cardLineHeight
+
+	for _, line := range wrapText(r.dc, text, r.width-indent) {
+		if r.draw && line != "" {
+			r.dc.SetHexColor(color)
+			r.dc.DrawString(line, r.left+indent, r.y+size)
+		}
+
+		r.y += lineHeight
+	}
+
+	r.y += size * 0.5
+}
+
+func (r *cardRenderer) codeBlock(block Block) {
+	const padding = 24
+	face := r.face(r.code, cardCodeSize)
+	r.dc.SetFontFace(face)
+	lineHeight := float64(cardCodeSize) * 1.5
+
+	colors := map[markdown.TokenKind]string{
+		markdown.TokenPlain:   cardPalette.CodePlain,
+		markdown.TokenKeyword: cardPalette.CodeKeyword,
+		markdown.TokenString:  cardPalette.CodeString,
+		markdown.TokenNumber:  cardPalette.CodeNumber,
+		markdown.TokenComment: cardPalette.CodeComment,
+	}
+
+	// 先计算代码块的高度，用于绘制背景
+	maxWidth := r.width - padding*2
+	lines := 0
+	for _, line := range block.Lines {
+		lines += len(wrapCode(r.dc, markdown.HighlightLine(line, block.Lang), maxWidth))
+	}
+
+	if lines == 0 {
+		lines = 1
+	}
+
+	header := 0.0
+	if block.Lang != "" {
+		header = cardFooterSize * 1.4
+	}
+
+	height := float64(lines)*lineHeight + padding*2 + header
+	if r.draw {
+		r.dc.SetHexColor(cardPalette.CodeBackground)
+		r.dc.DrawRoundedRectangle(r.left, r.y, r.width, height, 12)
+		r.dc.Fill()
+
+		if block.Lang != "" {
+			r.dc.SetFontFace(r.face(r.text, cardFooterSize))
+			r.dc.SetHexColor(cardPalette.CodeComment)
+			r.dc.DrawStringAnchored(block.Lang, r.left+r.width-padding, r.y+padding+cardFooterSize*0.8, 1, 0)
+			r.dc.SetFontFace(face)
+		}
+	}
+
+	y := r.y + padding + header
+	for _, line := range block.Lines {
+		for _, segments := range wrapCode(r.dc, markdown.HighlightLine(line, block.Lang), maxWidth) {
+			if r.draw {
+				x := r.left + padding
+				for _, seg := range segments {
+					r.dc.SetHexColor(colors[seg.Kind])
+					r.dc.DrawString(seg.Text, x, y+cardCodeSize)
+					w, _ := r.dc.MeasureString(seg.Text)
+					x += w
+				}
+			}
+
+			y += lineHeight
+		}
+	}
+
+	r.y += height + cardTextSize*0.6
+}
+
+// wrapText 按照宽度对文本进行换行，优先在空格处断开英文单词，中文按字符断开
+func wrapText(dc *gg.Context, text string, maxWidth float64) []string {
+	lines := make([]string, 0)
+	for _, para := range strings.Split(text, "\n") {
+		runes := []rune(para)
+		start, lastSpace := 0, -1
+		for i := 0; i < len(runes); i++ {
+			if runes[i] == ' ' {
+				lastSpace = i
+			}
+
+			if w, _ := dc.MeasureString(string(runes[start : i+1])); w <= maxWidth || i == start {
+				continue
+			}
+
+			end := i
+			if lastSpace > start {
+				end = lastSpace
+			}
+
+			lines = append(lines, strings.TrimRight(string(runes[start:end]), " "))
+			for start = end; start < len(runes) && runes[start] == ' '; start++ {
+			}
+
+			lastSpace = -1
+			i = start - 1
+		}
+
+		lines = append(lines, string(runes[start:]))
+	}
+
+	return lines
+}
+
+// wrapCode 按照宽度对已着色的代码行进行换行，每一行由多个着色片段组成
+func wrapCode(dc *gg.Context, tokens []markdown.Token, maxWidth float64) [][]markdown.Token {
+	lines := [][]markdown.Token{{}}
+	width := 0.0
+	for _, token := range tokens {
+		for _, ch := range token.Text {
+			w, _ := dc.MeasureString(string(ch))
+			if width+w > maxWidth && width > 0 {
+				lines = append(lines, []markdown.Token{})
+				width = 0
+			}
+
+			current := lines[len(lines)-1]
+			if n := len(current); n > 0 && current[n-1].Kind == token.Kind {
+				current[n-1].Text += string(ch)
+			} else {
+				current = append(current, markdown.Token{Kind: token.Kind, Text: string(ch)})
+			}
+
+			lines[len(lines)-1] = current
+			width += w
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,60 @@
+package image_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/image"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	blocks := image.ParseMarkdown("# Title\n\nSome **bold** and [link](https://example.com).\n\n- item\n  1. nested\n> quote\n\n---\n| a | b |\n|---|---|\n| 1 | 2 |\n\n```go\nfunc main() {}\n```")
+
+	types := make([]image.BlockType, 0, len(blocks))
+	for _, b := range blocks {
+		types = append(types, b.Type)
+	}
+
+	assert.EqualValues(t, []image.BlockType{
+		image.BlockHeading,
+		image.BlockParagraph,
+		image.BlockListItem,
+		image.BlockListItem,
+		image.BlockQuote,
+		image.BlockRule,
+		image.BlockParagraph,
+		image.BlockCode,
+	}, types)
+
+	assert.Equal(t, "Some bold and link.", blocks[1].Text)
+	assert.Equal(t, 1, blocks[3].Level)
+	assert.Equal(t, "1.", blocks[3].Marker)
+	assert.Equal(t, "a  │  b\n1  │  2", blocks[6].Text)
+	assert.Equal(t, "go", blocks[7].Lang)
+	assert.EqualValues(t, []string{"func main() {}"}, blocks[7].Lines)
+
+	// 未闭合的代码块持续到文本结束
+	blocks = image.ParseMarkdown("```\nline1\nline2")
+	assert.Equal(t, 1, len(blocks))
+	assert.EqualValues(t, []string{"line1", "line2"}, blocks[0].Lines)
+}
+
+func TestImager_MessageCard(t *testing.T) {
+	data, err := image.New("").MessageCard(image.MessageCard{
+		Sections: []image.CardSection{
+			{Label: "Q", Content: "How to print in Go?"},
+			{Label: "A", Content: "Use `fmt`:\n\n```go\n" + strings.Repeat("fmt.Println(\"hello world\") ", 10) + "\n```\n\n- done"},
+		},
+		Brand:    "AIdea",
+		Subtitle: "gpt-4 · 2024-01-22",
+	})
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 1080, img.Bounds().Dx())
+	assert.True(t, img.Bounds().Dy() > 400)
+}
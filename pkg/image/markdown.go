@@ -0,0 +1,148 @@
+package image
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BlockType Markdown 块类型
+type BlockType int
+
+const (
+	BlockParagraph BlockType = iota
+	BlockHeading
+	BlockListItem
+	BlockQuote
+	BlockCode
+	BlockRule
+)
+
+// Block 渲染卡片使用的 Markdown 块，只支持聊天回复中常见的语法
+type Block struct {
+	Type BlockType
+	// Level 标题级别（1-6）或者列表的缩进级别（从 0 开始）
+	Level int
+	// Marker 列表项的标记，例如 • 或者 1.
+	Marker string
+	Text   string
+	// Lang 代码块的语言
+	Lang string
+	// Lines 代码块的内容，按行保存，不做换行处理
+	Lines []string
+}
+
+var (
+	headingRegexp     = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	orderedListRegexp = regexp.MustCompile(`^(\d+)[.)]\s+(.*)$`)
+	ruleRegexp        = regexp.MustCompile(`^([-*_])(\s*[-*_]){2,}$`)
+	tableSepRegexp    = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?$`)
+
+	inlineImageRegexp  = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	inlineLinkRegexp   = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	inlineStrongRegexp = regexp.MustCompile(`(\*\*|__)(.+?)(\*\*|__)`)
+	inlineStrikeRegexp = regexp.MustCompile(`~~(.+?)~~`)
+	inlineCodeRegexp   = regexp.MustCompile("`([^`]+)`")
+)
+
+// ParseMarkdown 将 Markdown 文本解析为块，行内语法（加粗、链接等）只保留文本内容
+func ParseMarkdown(text string) []Block {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	blocks := make([]Block, 0)
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, Block{Type: BlockParagraph, Text: strings.Join(paragraph, "\n")})
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		// 代码块，未闭合的代码块一直持续到文本结束
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+
+			fence := trimmed[:3]
+			code := Block{Type: BlockCode, Lang: strings.ToLower(strings.TrimSpace(trimmed[3:]))}
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+
+				code.Lines = append(code.Lines, strings.ReplaceAll(strings.TrimRight(lines[i], " \t\r"), "\t", "    "))
+			}
+
+			blocks = append(blocks, code)
+			continue
+		}
+
+		if trimmed == "" {
+			flush()
+			continue
+		}
+
+		if ruleRegexp.MatchString(trimmed) {
+			flush()
+			blocks = append(blocks, Block{Type: BlockRule})
+			continue
+		}
+
+		if matches := headingRegexp.FindStringSubmatch(trimmed); matches != nil {
+			flush()
+			blocks = append(blocks, Block{Type: BlockHeading, Level: len(matches[1]), Text: inlineText(matches[2])})
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, ">") {
+			flush()
+			blocks = append(blocks, Block{Type: BlockQuote, Text: inlineText(strings.TrimSpace(strings.TrimLeft(trimmed, ">")))})
+			continue
+		}
+
+		level := (len(line) - len(strings.TrimLeft(line, " \t"))) / 2
+		if len(trimmed) > 2 && strings.Contains("-*+", trimmed[:1]) && trimmed[1] == ' ' {
+			flush()
+			blocks = append(blocks, Block{Type: BlockListItem, Level: level, Marker: "•", Text: inlineText(strings.TrimSpace(trimmed[2:]))})
+			continue
+		}
+
+		if matches := orderedListRegexp.FindStringSubmatch(trimmed); matches != nil {
+			flush()
+			blocks = append(blocks, Block{Type: BlockListItem, Level: level, Marker: matches[1] + ".", Text: inlineText(matches[2])})
+			continue
+		}
+
+		// 表格按行展示，忽略表头与内容之间的分隔行
+		if strings.HasPrefix(trimmed, "|") {
+			if tableSepRegexp.MatchString(trimmed) {
+				continue
+			}
+
+			cells := strings.Split(strings.Trim(trimmed, "|"), "|")
+			for j := range cells {
+				cells[j] = inlineText(strings.TrimSpace(cells[j]))
+			}
+
+			paragraph = append(paragraph, strings.Join(cells, "  │  "))
+			continue
+		}
+
+		paragraph = append(paragraph, inlineText(trimmed))
+	}
+
+	flush()
+	return blocks
+}
+
+// inlineText 去除行内语法的标记，只保留文本内容
+func inlineText(text string) string {
+	text = inlineImageRegexp.ReplaceAllString(text, "[$1]")
+	text = inlineLinkRegexp.ReplaceAllString(text, "$1")
+	text = inlineStrongRegexp.ReplaceAllString(text, "$2")
+	text = inlineStrikeRegexp.ReplaceAllString(text, "$1")
+	text = inlineCodeRegexp.ReplaceAllString(text, "$1")
+	return text
+}
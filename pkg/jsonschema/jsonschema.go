@@ -0,0 +1,314 @@
+// Package jsonschema 实现了 JSON Schema 的常用子集，用于校验模型输出的结构化数据
+//
+// 支持的关键字：type、properties、required、additionalProperties（布尔值或 Schema）、items、enum、const、
+// minimum、maximum、minLength、maxLength、minItems、maxItems、anyOf、oneOf，其它关键字会被忽略
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema JSON Schema 定义
+type Schema struct {
+	Type                 Types              `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Additional        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// Types type 关键字，可以是单个类型，也可以是类型数组
+type Types []string
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return errors.New("type must be a string or an array of strings")
+	}
+
+	*t = multi
+	return nil
+}
+
+// Additional additionalProperties 关键字，可以是布尔值或 Schema
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *Additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Allowed = allowed
+		return nil
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return err
+	}
+
+	a.Allowed, a.Schema = true, &schema
+	return nil
+}
+
+var supportedTypes = []string{"object", "array", "string", "number", "integer", "boolean", "null"}
+
+// Parse 解析 JSON Schema
+func Parse(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+
+	if err := schema.check(); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+
+	return &schema, nil
+}
+
+func (s *Schema) check() error {
+	for _, t := range s.Type {
+		found := false
+		for _, st := range supportedTypes {
+			if t == st {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("unsupported type %q", t)
+		}
+	}
+
+	children := make([]*Schema, 0, len(s.Properties)+len(s.AnyOf)+len(s.OneOf)+2)
+	for _, p := range s.Properties {
+		children = append(children, p)
+	}
+
+	children = append(children, s.AnyOf...)
+	children = append(children, s.OneOf...)
+	children = append(children, s.Items)
+	if s.AdditionalProperties != nil {
+		children = append(children, s.AdditionalProperties.Schema)
+	}
+
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+
+		if err := child.check(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidationError 校验失败的原因，Path 为出错字段的路径，如 $.items[0].name
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Validate 校验使用 encoding/json 解析得到的数据是否符合 Schema
+func (s *Schema) Validate(value any) error {
+	return s.validate("$", value)
+}
+
+func (s *Schema) validate(path string, value any) error {
+	fail := func(format string, args ...any) error {
+		return &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if len(s.Type) > 0 {
+		actual := typeOf(value)
+		matched := false
+		for _, t := range s.Type {
+			if t == actual || (t == "number" && actual == "integer") {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fail("expected %s, got %s", strings.Join(s.Type, " or "), actual)
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		matched := false
+		for _, e := range s.Enum {
+			if equal(e, value) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fail("value is not one of the allowed values")
+		}
+	}
+
+	if s.Const != nil && !equal(s.Const, value) {
+		return fail("value does not match const")
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fail("missing required property %q", name)
+			}
+		}
+
+		// 按照字段名称排序，保证多个字段出错时返回的错误是稳定的
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if prop, ok := s.Properties[name]; ok {
+				if err := prop.validate(path+"."+name, v[name]); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if s.AdditionalProperties == nil {
+				continue
+			}
+
+			if !s.AdditionalProperties.Allowed {
+				return fail("unexpected property %q", name)
+			}
+
+			if s.AdditionalProperties.Schema != nil {
+				if err := s.AdditionalProperties.Schema.validate(path+"."+name, v[name]); err != nil {
+					return err
+				}
+			}
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			return fail("expected at least %d items, got %d", *s.MinItems, len(v))
+		}
+
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			return fail("expected at most %d items, got %d", *s.MaxItems, len(v))
+		}
+
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			return fail("expected at least %d characters, got %d", *s.MinLength, length)
+		}
+
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fail("expected at most %d characters, got %d", *s.MaxLength, length)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fail("expected a value >= %v, got %v", *s.Minimum, v)
+		}
+
+		if s.Maximum != nil && v > *s.Maximum {
+			return fail("expected a value <= %v, got %v", *s.Maximum, v)
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, sub := range s.AnyOf {
+			if sub.validate(path, value) == nil {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fail("value does not match any of the schemas in anyOf")
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, sub := range s.OneOf {
+			if sub.validate(path, value) == nil {
+				matched++
+			}
+		}
+
+		if matched != 1 {
+			return fail("value must match exactly one schema in oneOf, matched %d", matched)
+		}
+	}
+
+	return nil
+}
+
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func equal(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
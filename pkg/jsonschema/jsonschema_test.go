@@ -0,0 +1,42 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/jsonschema"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := jsonschema.Parse([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"age": {"type": "integer", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+			"level": {"enum": ["low", "high"]}
+		},
+		"required": ["name", "age"],
+		"additionalProperties": false
+	}`))
+	assert.NoError(t, err)
+
+	validate := func(data string) error {
+		var value any
+		assert.NoError(t, json.Unmarshal([]byte(data), &value))
+		return schema.Validate(value)
+	}
+
+	assert.NoError(t, validate(`{"name": "Tom", "age": 3, "tags": ["a"], "level": "low"}`))
+	assert.Equal(t, `$: missing required property "age"`, validate(`{"name": "Tom"}`).Error())
+	assert.Equal(t, "$.age: expected integer, got number", validate(`{"name": "Tom", "age": 1.5}`).Error())
+	assert.Equal(t, "$.name: expected at least 1 characters, got 0", validate(`{"name": "", "age": 1}`).Error())
+	assert.Equal(t, "$.tags[1]: expected string, got integer", validate(`{"name": "Tom", "age": 1, "tags": ["a", 1]}`).Error())
+	assert.Equal(t, "$.level: value is not one of the allowed values", validate(`{"name": "Tom", "age": 1, "level": "mid"}`).Error())
+	assert.Equal(t, `$: unexpected property "extra"`, validate(`{"name": "Tom", "age": 1, "extra": true}`).Error())
+	assert.Equal(t, "$: expected object, got array", validate(`[]`).Error())
+
+	_, err = jsonschema.Parse([]byte(`{"type": "date"}`))
+	assert.True(t, err != nil)
+}
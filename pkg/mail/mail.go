@@ -1,6 +1,8 @@
 package mail
 
 import (
+	"io"
+
 	"github.com/mylxsw/aidea-server/config"
 	"gopkg.in/gomail.v2"
 )
@@ -26,3 +28,18 @@ func (m *Sender) Send(to []string, subject, body string) error {
 
 	return m.dailer.DialAndSend(msg)
 }
+
+// SendWithAttachment 发送带附件的邮件
+func (m *Sender) SendWithAttachment(to []string, subject, body string, filename string, content []byte) error {
+	msg := gomail.NewMessage()
+	msg.SetAddressHeader("From", m.conf.SMTPUsername, m.conf.From)
+	msg.SetHeader("To", to...)
+	msg.SetHeader("Subject", subject)
+	msg.SetBody("text/plain", body)
+	msg.Attach(filename, gomail.SetCopyFunc(func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	}))
+
+	return m.dailer.DialAndSend(msg)
+}
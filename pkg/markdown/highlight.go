@@ -0,0 +1,139 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// TokenKind 代码高亮的词法类型
+type TokenKind int
+
+const (
+	TokenPlain TokenKind = iota
+	TokenKeyword
+	TokenString
+	TokenComment
+	TokenNumber
+)
+
+// Token 代码高亮的词法单元
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// highlightKeywords 常见编程语言的关键字，不区分语言，只用于简单的代码着色
+var highlightKeywords = map[string]bool{
+	"func": true, "function": true, "def": true, "fn": true, "class": true, "struct": true, "interface": true,
+	"type": true, "enum": true, "return": true, "if": true, "else": true, "elif": true, "for": true,
+	"while": true, "do": true, "switch": true, "case": true, "default": true, "break": true, "continue": true,
+	"go": true, "defer": true, "select": true, "chan": true, "map": true, "range": true, "package": true,
+	"import": true, "from": true, "as": true, "export": true, "var": true, "let": true, "const": true,
+	"new": true, "delete": true, "try": true, "catch": true, "except": true, "finally": true, "throw": true,
+	"raise": true, "with": true, "yield": true, "async": true, "await": true, "public": true, "private": true,
+	"protected": true, "static": true, "final": true, "void": true, "this": true, "self": true, "super": true,
+	"extends": true, "implements": true, "in": true, "of": true, "is": true, "not": true, "and": true,
+	"or": true, "lambda": true, "pass": true, "nil": true, "null": true, "None": true, "true": true,
+	"false": true, "True": true, "False": true, "use": true, "mut": true, "impl": true, "pub": true,
+	"match": true, "SELECT": true, "FROM": true, "WHERE": true, "INSERT": true,
+	"UPDATE": true, "DELETE": true, "JOIN": true, "GROUP": true, "ORDER": true, "BY": true, "LIMIT": true,
+}
+
+// commentPrefixes 根据语言确定单行注释的前缀，未知语言同时支持 // 和 #
+func commentPrefixes(lang string) []string {
+	switch lang {
+	case "python", "py", "shell", "sh", "bash", "zsh", "ruby", "rb", "yaml", "yml", "toml", "ini", "r", "perl", "dockerfile", "makefile":
+		return []string{"#"}
+	case "sql", "lua", "haskell":
+		return []string{"--"}
+	case "go", "golang", "c", "cpp", "c++", "java", "javascript", "js", "typescript", "ts", "rust", "rs", "swift", "kotlin", "kt", "dart", "php", "scala", "cs", "csharp":
+		return []string{"//"}
+	case "json", "html", "xml", "markdown", "md", "text", "txt", "plaintext":
+		return nil
+	}
+
+	return []string{"//", "#"}
+}
+
+// HighlightLine 对单行代码进行简单的词法着色，多行注释与多行字符串按普通文本处理
+func HighlightLine(line, lang string) []Token {
+	prefixes := commentPrefixes(lang)
+	runes := []rune(line)
+	tokens := make([]Token, 0)
+
+	appendToken := func(kind TokenKind, text string) {
+		if text == "" {
+			return
+		}
+
+		if n := len(tokens); n > 0 && tokens[n-1].Kind == kind {
+			tokens[n-1].Text += text
+			return
+		}
+
+		tokens = append(tokens, Token{Kind: kind, Text: text})
+	}
+
+	for i := 0; i < len(runes); {
+		rest := string(runes[i:])
+
+		isComment := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(rest, prefix) {
+				isComment = true
+				break
+			}
+		}
+
+		if isComment {
+			appendToken(TokenComment, rest)
+			break
+		}
+
+		r := runes[i]
+		switch {
+		case r == '"' || r == '\'' || r == '`':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == '\\' {
+					j++
+					continue
+				}
+
+				if runes[j] == r {
+					j++
+					break
+				}
+			}
+
+			if j > len(runes) {
+				j = len(runes)
+			}
+
+			appendToken(TokenString, string(runes[i:j]))
+			i = j
+		case unicode.IsDigit(r):
+			j := i + 1
+			for ; j < len(runes) && (unicode.IsDigit(runes[j]) || strings.ContainsRune("xXabcdefABCDEF._", runes[j])); j++ {
+			}
+
+			appendToken(TokenNumber, string(runes[i:j]))
+			i = j
+		case r == '_' || unicode.IsLetter(r):
+			j := i + 1
+			for ; j < len(runes) && (runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])); j++ {
+			}
+
+			word := string(runes[i:j])
+			appendToken(ternary.If(highlightKeywords[word], TokenKeyword, TokenPlain), word)
+			i = j
+		default:
+			appendToken(TokenPlain, string(r))
+			i++
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,253 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/mylxsw/go-utils/ternary"
+	"github.com/russross/blackfriday/v2"
+)
+
+// RendererVersion 渲染规则的版本，修改渲染逻辑后需要递增，使已缓存的渲染结果失效
+const RendererVersion = 1
+
+const (
+	htmlExtensions = blackfriday.CommonExtensions | blackfriday.HardLineBreak
+	// htmlFlags 跳过 Markdown 中的原始 HTML，只允许安全协议的链接，外部链接在新窗口中打开
+	htmlFlags = blackfriday.SkipHTML | blackfriday.Safelink | blackfriday.NofollowLinks |
+		blackfriday.NoreferrerLinks | blackfriday.HrefTargetBlank
+)
+
+// mathPlaceholder 数学公式的占位符，只包含字母和数字，不会被 Markdown 解析
+const mathPlaceholder = "KATEXMATH%dEND"
+
+var mathPlaceholderRegexp = regexp.MustCompile(`(<p>)?KATEXMATH(\d+)END(</p>)?`)
+
+type mathFormula struct {
+	tex     string
+	display bool
+}
+
+// RenderHTML 将 Markdown 渲染为 HTML，结果中不包含原始 HTML 与不安全的链接，可以直接嵌入页面
+//
+// 代码块按照 HighlightLine 的结果输出 hl-* 样式的 span；数学公式（$...$、$$...$$、\(...\)、\[...\]）
+// 输出为带有 math 样式的元素并保留 KaTeX 定界符，由页面中的 KaTeX auto-render 渲染
+func RenderHTML(content string) string {
+	content, formulas := extractMath(strings.ReplaceAll(content, "\r\n", "\n"))
+
+	renderer := &htmlRenderer{HTMLRenderer: blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{Flags: htmlFlags})}
+	output := string(blackfriday.Run([]byte(content), blackfriday.WithExtensions(htmlExtensions), blackfriday.WithRenderer(renderer)))
+
+	return mathPlaceholderRegexp.ReplaceAllStringFunc(output, func(match string) string {
+		sub := mathPlaceholderRegexp.FindStringSubmatch(match)
+		var idx int
+		_, _ = fmt.Sscanf(sub[2], "%d", &idx)
+		if idx >= len(formulas) {
+			return match
+		}
+
+		formula := formulas[idx]
+		tex := html.EscapeString(formula.tex)
+		if formula.display && sub[1] != "" && sub[3] != "" {
+			return `<div class="math math-display">\[` + tex + `\]</div>`
+		}
+
+		var res string
+		if formula.display {
+			res = `<span class="math math-display">\[` + tex + `\]</span>`
+		} else {
+			res = `<span class="math math-inline">\(` + tex + `\)</span>`
+		}
+
+		return sub[1] + res + sub[3]
+	})
+}
+
+// htmlRenderer 在 blackfriday 的基础上增加代码着色，并过滤不安全的图片地址
+type htmlRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+func (r *htmlRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	switch node.Type {
+	case blackfriday.CodeBlock:
+		lang := strings.ToLower(strings.TrimSpace(strings.SplitN(string(node.Info), " ", 2)[0]))
+		_, _ = io.WriteString(w, "<pre><code")
+		if lang != "" {
+			_, _ = io.WriteString(w, ` class="language-`+html.EscapeString(lang)+`"`)
+		}
+		_, _ = io.WriteString(w, ">")
+
+		lines := strings.Split(strings.TrimSuffix(string(node.Literal), "\n"), "\n")
+		for i, line := range lines {
+			if i > 0 {
+				_, _ = io.WriteString(w, "\n")
+			}
+
+			for _, token := range HighlightLine(line, lang) {
+				text := html.EscapeString(token.Text)
+				if class := tokenClass(token.Kind); class != "" {
+					text = `<span class="` + class + `">` + text + `</span>`
+				}
+
+				_, _ = io.WriteString(w, text)
+			}
+		}
+
+		_, _ = io.WriteString(w, "</code></pre>\n")
+		return blackfriday.GoToNext
+	case blackfriday.Image:
+		// 只允许 http(s) 图片，其它地址只保留图片的描述文本
+		dest := strings.ToLower(string(node.LinkData.Destination))
+		if !strings.HasPrefix(dest, "https://") && !strings.HasPrefix(dest, "http://") {
+			return blackfriday.GoToNext
+		}
+	}
+
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
+func tokenClass(kind TokenKind) string {
+	switch kind {
+	case TokenKeyword:
+		return "hl-keyword"
+	case TokenString:
+		return "hl-string"
+	case TokenNumber:
+		return "hl-number"
+	case TokenComment:
+		return "hl-comment"
+	}
+
+	return ""
+}
+
+// extractMath 将数学公式替换为占位符，避免公式中的 _、* 等字符被当作 Markdown 语法解析，代码中的内容不处理
+func extractMath(content string) (string, []mathFormula) {
+	formulas := make([]mathFormula, 0)
+	var buf bytes.Buffer
+
+	inFence := false
+	lines := strings.SplitAfter(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			buf.WriteString(line)
+			continue
+		}
+
+		if inFence {
+			buf.WriteString(line)
+			continue
+		}
+
+		// 跨行的块级公式，例如单独一行的 $$ 或者 \[
+		if trimmed == "$$" || trimmed == `\[` {
+			closing := ternary.If(trimmed == "$$", "$$", `\]`)
+			var tex []string
+			j := i + 1
+			for ; j < len(lines) && strings.TrimSpace(lines[j]) != closing; j++ {
+				tex = append(tex, strings.TrimRight(lines[j], "\n"))
+			}
+
+			if j < len(lines) {
+				buf.WriteString(fmt.Sprintf(mathPlaceholder, len(formulas)) + "\n")
+				formulas = append(formulas, mathFormula{tex: strings.Join(tex, "\n"), display: true})
+				i = j
+				continue
+			}
+		}
+
+		buf.WriteString(extractInlineMath(line, &formulas))
+	}
+
+	return buf.String(), formulas
+}
+
+// extractInlineMath 处理单行中的公式，行内代码中的内容不处理
+func extractInlineMath(line string, formulas *[]mathFormula) string {
+	var buf strings.Builder
+	for i := 0; i < len(line); {
+		switch {
+		case line[i] == '`':
+			end := strings.Index(line[i+1:], "`")
+			if end < 0 {
+				buf.WriteString(line[i:])
+				return buf.String()
+			}
+
+			buf.WriteString(line[i : i+end+2])
+			i += end + 2
+			continue
+		case strings.HasPrefix(line[i:], "$$"):
+			if end := strings.Index(line[i+2:], "$$"); end > 0 {
+				buf.WriteString(addFormula(formulas, line[i+2:i+2+end], true))
+				i += end + 4
+				continue
+			}
+		case strings.HasPrefix(line[i:], `\[`), strings.HasPrefix(line[i:], `\(`):
+			closing := ternary.If(line[i+1] == '[', `\]`, `\)`)
+			if end := strings.Index(line[i+2:], closing); end > 0 {
+				buf.WriteString(addFormula(formulas, line[i+2:i+2+end], line[i+1] == '['))
+				i += end + 4
+				continue
+			}
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '$':
+			buf.WriteString(`\$`)
+			i += 2
+			continue
+		case line[i] == '$':
+			// 与 Pandoc 的规则一致：开始的 $ 后面不能是空白，结束的 $ 前面不能是空白且后面不能是数字，避免误判金额
+			if end := closingDollar(line, i); end > 0 {
+				buf.WriteString(addFormula(formulas, line[i+1:end], false))
+				i = end + 1
+				continue
+			}
+		}
+
+		buf.WriteByte(line[i])
+		i++
+	}
+
+	return buf.String()
+}
+
+func closingDollar(line string, start int) int {
+	if start+1 >= len(line) || line[start+1] == ' ' || line[start+1] == '\t' || line[start+1] == '\n' {
+		return -1
+	}
+
+	for j := start + 1; j < len(line); j++ {
+		if line[j] == '\\' {
+			j++
+			continue
+		}
+
+		if line[j] != '$' {
+			continue
+		}
+
+		if line[j-1] == ' ' || line[j-1] == '\t' || j == start+1 {
+			return -1
+		}
+
+		if j+1 < len(line) && line[j+1] >= '0' && line[j+1] <= '9' {
+			return -1
+		}
+
+		return j
+	}
+
+	return -1
+}
+
+func addFormula(formulas *[]mathFormula, tex string, display bool) string {
+	*formulas = append(*formulas, mathFormula{tex: strings.TrimSpace(tex), display: display})
+	return fmt.Sprintf(mathPlaceholder, len(*formulas)-1)
+}
@@ -0,0 +1,55 @@
+package markdown_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/markdown"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestHighlightLine(t *testing.T) {
+	tokens := markdown.HighlightLine(`return "a//b", 42 // done`, "go")
+	assert.EqualValues(t, []markdown.Token{
+		{Kind: markdown.TokenKeyword, Text: "return"},
+		{Kind: markdown.TokenPlain, Text: " "},
+		{Kind: markdown.TokenString, Text: `"a//b"`},
+		{Kind: markdown.TokenPlain, Text: ", "},
+		{Kind: markdown.TokenNumber, Text: "42"},
+		{Kind: markdown.TokenPlain, Text: " "},
+		{Kind: markdown.TokenComment, Text: "// done"},
+	}, tokens)
+
+	// 未闭合的字符串不会越界
+	tokens = markdown.HighlightLine(`x = 'abc`, "python")
+	assert.Equal(t, markdown.TokenString, tokens[len(tokens)-1].Kind)
+}
+
+func TestRenderHTML_Sanitize(t *testing.T) {
+	res := markdown.RenderHTML("hello <script>alert(1)</script>\n\n<div onclick=\"x()\">block</div>\n\n[click](javascript:alert(1)) ![img](javascript:alert(1)) ![ok](https://example.com/a.png)")
+
+	assert.False(t, strings.Contains(res, "<script"))
+	assert.False(t, strings.Contains(res, "onclick"))
+	assert.False(t, strings.Contains(res, `href="javascript:`))
+	assert.False(t, strings.Contains(res, `src="javascript:`))
+	assert.True(t, strings.Contains(res, `<img src="https://example.com/a.png" alt="ok" />`))
+}
+
+func TestRenderHTML_Code(t *testing.T) {
+	res := markdown.RenderHTML("```go\nreturn \"<b>\"\n```")
+	assert.Equal(t, `<pre><code class="language-go"><span class="hl-keyword">return</span> <span class="hl-string">&#34;&lt;b&gt;&#34;</span></code></pre>`+"\n", res)
+
+	// 代码中的 $ 不作为公式处理
+	res = markdown.RenderHTML("`$a$` and\n```\n$b$\n```")
+	assert.False(t, strings.Contains(res, "math"))
+}
+
+func TestRenderHTML_Math(t *testing.T) {
+	res := markdown.RenderHTML("Euler: $e^{i\\pi} + 1 = 0$, costs $5 and $10.\n\n$$\nx_1 < x_2\n$$\n\n\\(a_b\\) and \\[c^2\\]")
+
+	assert.True(t, strings.Contains(res, `<span class="math math-inline">\(e^{i\pi} + 1 = 0\)</span>`))
+	assert.True(t, strings.Contains(res, "costs $5 and $10."))
+	assert.True(t, strings.Contains(res, `<div class="math math-display">\[x_1 &lt; x_2\]</div>`))
+	assert.True(t, strings.Contains(res, `<span class="math math-inline">\(a_b\)</span>`))
+	assert.True(t, strings.Contains(res, `<span class="math math-display">\[c^2\]</span>`))
+}
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -327,3 +328,20 @@ func Md5(data []byte) string {
 	h.Write(data)
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+var (
+	piiEmailRegexp  = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	piiIDCardRegexp = regexp.MustCompile(`\b\d{17}[\dXx]\b`)
+	piiPhoneRegexp  = regexp.MustCompile(`(?:\+?86[- ]?)?\b1[3-9]\d{9}\b`)
+	piiBankRegexp   = regexp.MustCompile(`\b\d{16,19}\b`)
+)
+
+// ScrubPII 移除文本中的个人敏感信息（邮箱、身份证号、手机号、银行卡号）
+func ScrubPII(text string) string {
+	text = piiEmailRegexp.ReplaceAllString(text, "[EMAIL]")
+	text = piiIDCardRegexp.ReplaceAllString(text, "[ID_CARD]")
+	text = piiPhoneRegexp.ReplaceAllString(text, "[PHONE]")
+	text = piiBankRegexp.ReplaceAllString(text, "[BANK_CARD]")
+
+	return text
+}
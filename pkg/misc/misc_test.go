@@ -140,3 +140,12 @@ func TestUUID(t *testing.T) {
 func TestFileExt(t *testing.T) {
 	fmt.Println(misc.FileExt("abc.jpg"))
 }
+
+func TestScrubPII(t *testing.T) {
+	assert.EqualValues(t, "我的邮箱是 [EMAIL]，请联系", misc.ScrubPII("我的邮箱是 someone.test@example.com，请联系"))
+	assert.EqualValues(t, "手机号：[PHONE]", misc.ScrubPII("手机号：13812345678"))
+	assert.EqualValues(t, "call [PHONE] now", misc.ScrubPII("call +86 13812345678 now"))
+	assert.EqualValues(t, "身份证 [ID_CARD] 已登记", misc.ScrubPII("身份证 11010519491231002X 已登记"))
+	assert.EqualValues(t, "卡号 [BANK_CARD]", misc.ScrubPII("卡号 6222021234567890123"))
+	assert.EqualValues(t, "订单数量 12345", misc.ScrubPII("订单数量 12345"))
+}
@@ -0,0 +1,33 @@
+package msgcrypt
+
+import (
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+)
+
+// NewFromConfig 根据配置创建消息加密器，未配置主密钥时只能读取明文消息
+func NewFromConfig(conf *config.Config) (*Cipher, error) {
+	if conf.MessageEncryptionVaultServer != "" {
+		return New(conf.EnableMessageEncryption, conf.MessageEncryptionActiveKey, NewVaultKeyWrapper(conf.MessageEncryptionVaultServer, conf.MessageEncryptionVaultToken)), nil
+	}
+
+	if len(conf.MessageEncryptionKeys) == 0 {
+		if conf.EnableMessageEncryption {
+			return nil, fmt.Errorf("message encryption enabled but no key configured")
+		}
+
+		return New(false, "", nil), nil
+	}
+
+	wrapper, err := NewLocalKeyWrapper(conf.MessageEncryptionKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.EnableMessageEncryption && !wrapper.Has(conf.MessageEncryptionActiveKey) {
+		return nil, fmt.Errorf("message encryption active key %q not found", conf.MessageEncryptionActiveKey)
+	}
+
+	return New(conf.EnableMessageEncryption, conf.MessageEncryptionActiveKey, wrapper), nil
+}
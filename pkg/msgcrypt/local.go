@@ -0,0 +1,78 @@
+package msgcrypt
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// LocalKeyWrapper 使用配置文件中提供的主密钥加密数据密钥
+type LocalKeyWrapper struct {
+	keys map[string][]byte
+}
+
+// NewLocalKeyWrapper 创建本地主密钥，keys 的格式为 <主密钥 ID>:<base64 编码的 32 字节密钥>，
+// 轮换密钥时新增主密钥并修改当前使用的主密钥 ID，旧的主密钥需要保留用于解密历史消息
+func NewLocalKeyWrapper(keys []string) (*LocalKeyWrapper, error) {
+	wrapper := &LocalKeyWrapper{keys: make(map[string][]byte)}
+	for _, item := range keys {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid message encryption key %q, format should be <key-id>:<base64-key>", id)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("invalid message encryption key %s, key should be 32 bytes encoded with base64", id)
+		}
+
+		wrapper.keys[id] = key
+	}
+
+	return wrapper, nil
+}
+
+// Has 是否存在指定的主密钥
+func (w *LocalKeyWrapper) Has(keyID string) bool {
+	_, ok := w.keys[keyID]
+	return ok
+}
+
+func (w *LocalKeyWrapper) Wrap(_ context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	key, ok := w.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("message encryption key %s not found", keyID)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+func (w *LocalKeyWrapper) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := w.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("message encryption key %s not found", keyID)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], nil)
+}
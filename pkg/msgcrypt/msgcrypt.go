@@ -0,0 +1,239 @@
+// Package msgcrypt 聊天消息的信封加密
+//
+// 每条消息使用数据密钥（DEK）通过 AES-GCM 加密，数据密钥再由主密钥（KEK）加密后与密文一起保存，
+// 主密钥可以由配置文件提供，也可以托管在 KMS（Vault Transit）中。
+// 加密后的消息格式为 enc:v1:<主密钥 ID>:<加密后的数据密钥>:<nonce + 密文>
+package msgcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// prefix 加密消息的前缀，不带前缀的消息视为明文
+	prefix = "enc:v1:"
+	// dataKeyTTL 数据密钥的有效期，有效期内的消息共用同一个数据密钥，减少 KMS 调用
+	dataKeyTTL = time.Hour
+	// maxCachedDataKeys 解密时缓存的数据密钥的最大数量
+	maxCachedDataKeys = 1024
+)
+
+var (
+	// ErrNoKeyWrapper 未配置主密钥，无法解密已加密的消息
+	ErrNoKeyWrapper = errors.New("message encryption key is not configured")
+	// ErrInvalidCiphertext 加密消息的格式错误
+	ErrInvalidCiphertext = errors.New("invalid encrypted message")
+)
+
+// KeyWrapper 使用主密钥加密、解密数据密钥
+type KeyWrapper interface {
+	Wrap(ctx context.Context, keyID string, dataKey []byte) ([]byte, error)
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+type dataKey struct {
+	keyID     string
+	wrapped   string
+	aead      cipher.AEAD
+	createdAt time.Time
+}
+
+// Cipher 加密、解密聊天消息，未启用加密时新消息以明文保存，已加密的消息仍然可以解密
+type Cipher struct {
+	enabled   bool
+	activeKey string
+	wrapper   KeyWrapper
+
+	lock    sync.Mutex
+	current *dataKey
+	cache   map[string]cipher.AEAD
+}
+
+// New 创建消息加密器，enabled 为 false 或 wrapper 为 nil 时新消息不加密，activeKey 为加密新消息使用的主密钥 ID
+func New(enabled bool, activeKey string, wrapper KeyWrapper) *Cipher {
+	return &Cipher{
+		enabled:   enabled && wrapper != nil && activeKey != "",
+		activeKey: activeKey,
+		wrapper:   wrapper,
+		cache:     make(map[string]cipher.AEAD),
+	}
+}
+
+// Enabled 新消息是否加密保存
+func (c *Cipher) Enabled() bool {
+	return c != nil && c.enabled
+}
+
+// IsEncrypted 消息是否已加密
+func IsEncrypted(msg string) bool {
+	return strings.HasPrefix(msg, prefix)
+}
+
+// NeedsEncrypt 消息是否需要（重新）加密：未加密的消息，或者使用的不是当前主密钥加密的消息，用于密钥轮换
+func (c *Cipher) NeedsEncrypt(msg string) bool {
+	if !c.Enabled() || msg == "" {
+		return false
+	}
+
+	if !IsEncrypted(msg) {
+		return true
+	}
+
+	keyID, _, _, err := parse(msg)
+	return err != nil || keyID != c.activeKey
+}
+
+// Encrypt 加密消息，未启用加密时原样返回
+func (c *Cipher) Encrypt(ctx context.Context, msg string) (string, error) {
+	if !c.Enabled() || msg == "" || IsEncrypted(msg) {
+		return msg, nil
+	}
+
+	dk, err := c.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, dk.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce failed: %w", err)
+	}
+
+	sealed := dk.aead.Seal(nonce, nonce, []byte(msg), []byte(dk.keyID))
+	return prefix + dk.keyID + ":" + dk.wrapped + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密消息，明文消息原样返回
+func (c *Cipher) Decrypt(ctx context.Context, msg string) (string, error) {
+	if !IsEncrypted(msg) {
+		return msg, nil
+	}
+
+	if c == nil || c.wrapper == nil {
+		return "", ErrNoKeyWrapper
+	}
+
+	keyID, wrapped, sealed, err := parse(msg)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := c.unwrap(ctx, keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("decrypt message failed: %w", err)
+	}
+
+	return string(plain), nil
+}
+
+func parse(msg string) (keyID string, wrapped string, sealed []byte, err error) {
+	segs := strings.Split(strings.TrimPrefix(msg, prefix), ":")
+	if len(segs) != 3 || segs[0] == "" || segs[1] == "" {
+		return "", "", nil, ErrInvalidCiphertext
+	}
+
+	sealed, err = base64.RawStdEncoding.DecodeString(segs[2])
+	if err != nil {
+		return "", "", nil, ErrInvalidCiphertext
+	}
+
+	return segs[0], segs[1], sealed, nil
+}
+
+// dataKey 返回当前用于加密的数据密钥，超过有效期或主密钥变更后重新生成
+func (c *Cipher) dataKey(ctx context.Context) (*dataKey, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.current != nil && c.current.keyID == c.activeKey && time.Since(c.current.createdAt) < dataKeyTTL {
+		return c.current, nil
+	}
+
+	plain := make([]byte, 32)
+	if _, err := rand.Read(plain); err != nil {
+		return nil, fmt.Errorf("generate data key failed: %w", err)
+	}
+
+	wrapped, err := c.wrapper.Wrap(ctx, c.activeKey, plain)
+	if err != nil {
+		return nil, fmt.Errorf("wrap data key failed: %w", err)
+	}
+
+	aead, err := newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	c.current = &dataKey{
+		keyID:     c.activeKey,
+		wrapped:   base64.RawStdEncoding.EncodeToString(wrapped),
+		aead:      aead,
+		createdAt: time.Now(),
+	}
+
+	return c.current, nil
+}
+
+// unwrap 解密数据密钥，同一个数据密钥加密了一段时间内的所有消息，解密结果会被缓存
+func (c *Cipher) unwrap(ctx context.Context, keyID, wrapped string) (cipher.AEAD, error) {
+	cacheKey := keyID + ":" + wrapped
+
+	c.lock.Lock()
+	aead, ok := c.cache[cacheKey]
+	c.lock.Unlock()
+	if ok {
+		return aead, nil
+	}
+
+	raw, err := base64.RawStdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+
+	plain, err := c.wrapper.Unwrap(ctx, keyID, raw)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key failed: %w", err)
+	}
+
+	aead, err = newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.cache) >= maxCachedDataKeys {
+		c.cache = make(map[string]cipher.AEAD)
+	}
+	c.cache[cacheKey] = aead
+
+	return aead, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher failed: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,81 @@
+package msgcrypt_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/msgcrypt"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func newKey(t *testing.T, id string) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	assert.NoError(t, err)
+
+	return id + ":" + base64.StdEncoding.EncodeToString(key)
+}
+
+func TestCipher(t *testing.T) {
+	ctx := context.Background()
+	keys := []string{newKey(t, "k1"), newKey(t, "k2")}
+
+	wrapper, err := msgcrypt.NewLocalKeyWrapper(keys)
+	assert.NoError(t, err)
+
+	c1 := msgcrypt.New(true, "k1", wrapper)
+	encrypted, err := c1.Encrypt(ctx, "你好，世界")
+	assert.NoError(t, err)
+	assert.True(t, msgcrypt.IsEncrypted(encrypted))
+	assert.False(t, c1.NeedsEncrypt(encrypted))
+
+	// 同一个数据密钥加密的消息，密文也不相同
+	encrypted2, err := c1.Encrypt(ctx, "你好，世界")
+	assert.NoError(t, err)
+	assert.False(t, encrypted == encrypted2)
+
+	plain, err := c1.Decrypt(ctx, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "你好，世界", plain)
+
+	// 明文消息原样返回
+	plain, err = c1.Decrypt(ctx, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", plain)
+	assert.True(t, c1.NeedsEncrypt("hello"))
+
+	// 轮换主密钥后，旧主密钥加密的消息仍然可以解密，并且需要重新加密
+	c2 := msgcrypt.New(true, "k2", wrapper)
+	assert.True(t, c2.NeedsEncrypt(encrypted))
+	plain, err = c2.Decrypt(ctx, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "你好，世界", plain)
+
+	// 关闭加密后，新消息以明文保存，已加密的消息仍然可以解密
+	disabled := msgcrypt.New(false, "k1", wrapper)
+	msg, err := disabled.Encrypt(ctx, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", msg)
+	plain, err = disabled.Decrypt(ctx, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "你好，世界", plain)
+
+	// 缺少主密钥时无法解密
+	other, err := msgcrypt.NewLocalKeyWrapper([]string{newKey(t, "k2")})
+	assert.NoError(t, err)
+	_, err = msgcrypt.New(true, "k2", other).Decrypt(ctx, encrypted)
+	assert.True(t, err != nil)
+
+	_, err = msgcrypt.New(false, "", nil).Decrypt(ctx, encrypted)
+	assert.True(t, err != nil)
+}
+
+func TestNewLocalKeyWrapper(t *testing.T) {
+	_, err := msgcrypt.NewLocalKeyWrapper([]string{"k1"})
+	assert.True(t, err != nil)
+
+	_, err = msgcrypt.NewLocalKeyWrapper([]string{"k1:" + base64.StdEncoding.EncodeToString([]byte("short"))})
+	assert.True(t, err != nil)
+}
@@ -0,0 +1,87 @@
+package msgcrypt
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VaultKeyWrapper 使用 Vault Transit 托管的主密钥加密数据密钥，主密钥 ID 为 Transit 中的密钥名称，
+// 主密钥不离开 Vault，Transit 中轮换的密钥版本信息包含在加密后的数据密钥中
+type VaultKeyWrapper struct {
+	server string
+	token  string
+	client *http.Client
+}
+
+// NewVaultKeyWrapper 创建 Vault Transit 主密钥，server 为 Vault 服务地址，例如 https://vault.example.com:8200
+func NewVaultKeyWrapper(server, token string) *VaultKeyWrapper {
+	return &VaultKeyWrapper{
+		server: strings.TrimSuffix(server, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *VaultKeyWrapper) Wrap(ctx context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	var res struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+
+	if err := w.call(ctx, "encrypt", keyID, map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}, &res); err != nil {
+		return nil, err
+	}
+
+	return []byte(res.Data.Ciphertext), nil
+}
+
+func (w *VaultKeyWrapper) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	var res struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+
+	if err := w.call(ctx, "decrypt", keyID, map[string]string{"ciphertext": string(wrapped)}, &res); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(res.Data.Plaintext)
+}
+
+func (w *VaultKeyWrapper) call(ctx context.Context, action, keyID string, body any, res any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.server+"/v1/transit/"+action+"/"+url.PathEscape(keyID), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", w.token)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("vault %s failed: [%d] %s", action, resp.StatusCode, string(msg))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(res)
+}
@@ -0,0 +1,116 @@
+package ocr
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mylxsw/go-utils/array"
+)
+
+var ErrEngineNotAvailable = errors.New("ocr engine not available")
+
+// maxImageSize 单张图片最大尺寸
+const maxImageSize = 10 * 1024 * 1024
+
+// Line 识别出的单行文本
+type Line struct {
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Page 单页（单张图片）识别结果
+type Page struct {
+	Lines []Line `json:"lines"`
+}
+
+// Text 页面的完整文本
+func (p Page) Text() string {
+	return strings.Join(array.Map(p.Lines, func(item Line, _ int) string { return item.Text }), "\n")
+}
+
+// Confidence 页面的平均置信度（0-1）
+func (p Page) Confidence() float64 {
+	if len(p.Lines) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, line := range p.Lines {
+		total += line.Confidence
+	}
+
+	return total / float64(len(p.Lines))
+}
+
+// Engine OCR 识别引擎
+type Engine interface {
+	// Name 引擎名称
+	Name() string
+	// Recognize 识别图片中的文字，imageBase64 为图片内容的 base64 编码
+	Recognize(ctx context.Context, imageBase64 string) (*Page, error)
+}
+
+// OCR 文字识别服务
+type OCR struct {
+	engine Engine
+}
+
+func NewOCR(engine Engine) *OCR {
+	return &OCR{engine: engine}
+}
+
+// Engine 当前使用的识别引擎名称
+func (o *OCR) Engine() string {
+	if o.engine == nil {
+		return ""
+	}
+
+	return o.engine.Name()
+}
+
+// RecognizeURL 下载远程图片并识别其中的文字
+func (o *OCR) RecognizeURL(ctx context.Context, imageURL string) (*Page, error) {
+	if o.engine == nil {
+		return nil, ErrEngineNotAvailable
+	}
+
+	data, err := downloadImage(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.engine.Recognize(ctx, base64.StdEncoding.EncodeToString(data))
+}
+
+func downloadImage(ctx context.Context, imageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download image failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("download image failed: [%d] %s", resp.StatusCode, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image failed: %w", err)
+	}
+
+	if len(data) > maxImageSize {
+		return nil, errors.New("image too large")
+	}
+
+	return data, nil
+}
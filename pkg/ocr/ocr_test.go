@@ -0,0 +1,20 @@
+package ocr_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/ocr"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestPage(t *testing.T) {
+	page := ocr.Page{Lines: []ocr.Line{
+		{Text: "第一行", Confidence: 0.9},
+		{Text: "second line", Confidence: 0.7},
+	}}
+
+	assert.Equal(t, "第一行\nsecond line", page.Text())
+	assert.True(t, math.Abs(page.Confidence()-0.8) < 1e-9)
+	assert.Equal(t, 0.0, ocr.Page{}.Confidence())
+}
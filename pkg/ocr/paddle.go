@@ -0,0 +1,64 @@
+package ocr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"gopkg.in/resty.v1"
+)
+
+// PaddleOCR 本地部署的 PaddleOCR（PaddleHub Serving）服务
+type PaddleOCR struct {
+	server string
+	resty  *resty.Client
+}
+
+func NewPaddleOCR(server string) *PaddleOCR {
+	return &PaddleOCR{server: server, resty: misc.RestyClient(2).SetTimeout(60 * time.Second)}
+}
+
+func (p *PaddleOCR) Name() string {
+	return "paddle"
+}
+
+type paddleResponse struct {
+	Msg     string `json:"msg"`
+	Status  string `json:"status"`
+	Results [][]struct {
+		Text       string  `json:"text"`
+		Confidence float64 `json:"confidence"`
+	} `json:"results"`
+}
+
+func (p *PaddleOCR) Recognize(ctx context.Context, imageBase64 string) (*Page, error) {
+	var ret paddleResponse
+	resp, err := p.resty.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]any{"images": []string{imageBase64}}).
+		SetResult(&ret).
+		Post(p.server + "/predict/ocr_system")
+	if err != nil {
+		return nil, fmt.Errorf("paddle ocr failed: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("paddle ocr failed: [%d] %s", resp.StatusCode(), resp.String())
+	}
+
+	if ret.Status != "000" {
+		return nil, fmt.Errorf("paddle ocr failed: [%s] %s", ret.Status, ret.Msg)
+	}
+
+	page := Page{Lines: make([]Line, 0)}
+	for _, res := range ret.Results {
+		for _, line := range res {
+			page.Lines = append(page.Lines, Line{Text: line.Text, Confidence: line.Confidence})
+		}
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,27 @@
+package ocr
+
+import (
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(func(conf *config.Config) *OCR {
+		switch conf.OCREngine {
+		case "paddle":
+			return NewOCR(NewPaddleOCR(conf.PaddleOCRServer))
+		case "tencent":
+			if conf.TencentSecretID == "" || conf.TencentSecretKey == "" {
+				log.Errorf("腾讯云 OCR 需要配置 tencent-id 和 tencent-key")
+				return NewOCR(nil)
+			}
+
+			return NewOCR(NewTencentOCR(conf.TencentSecretID, conf.TencentSecretKey))
+		}
+
+		return NewOCR(nil)
+	})
+}
@@ -0,0 +1,68 @@
+package ocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+)
+
+// TencentOCR 腾讯云通用文字识别（高精度版）
+type TencentOCR struct {
+	client *common.Client
+}
+
+func NewTencentOCR(secretID, secretKey string) *TencentOCR {
+	credential := common.NewCredential(secretID, secretKey)
+	return &TencentOCR{client: common.NewCommonClient(credential, "ap-shanghai", profile.NewClientProfile())}
+}
+
+func (t *TencentOCR) Name() string {
+	return "tencent"
+}
+
+type tencentOCRResponse struct {
+	Response struct {
+		TextDetections []struct {
+			DetectedText string  `json:"DetectedText"`
+			Confidence   float64 `json:"Confidence"`
+		} `json:"TextDetections"`
+		Error *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error,omitempty"`
+	} `json:"Response"`
+}
+
+func (t *TencentOCR) Recognize(ctx context.Context, imageBase64 string) (*Page, error) {
+	req := tchttp.NewCommonRequest("ocr", "2018-11-19", "GeneralAccurateOCR")
+	req.SetContext(ctx)
+	if err := req.SetActionParameters(map[string]any{"ImageBase64": imageBase64}); err != nil {
+		return nil, err
+	}
+
+	resp := tchttp.NewCommonResponse()
+	if err := t.client.Send(req, resp); err != nil {
+		return nil, fmt.Errorf("tencent ocr failed: %w", err)
+	}
+
+	var ret tencentOCRResponse
+	if err := json.Unmarshal(resp.GetBody(), &ret); err != nil {
+		return nil, fmt.Errorf("decode tencent ocr response failed: %w", err)
+	}
+
+	if ret.Response.Error != nil {
+		return nil, fmt.Errorf("tencent ocr failed: [%s] %s", ret.Response.Error.Code, ret.Response.Error.Message)
+	}
+
+	page := Page{Lines: make([]Line, 0, len(ret.Response.TextDetections))}
+	for _, item := range ret.Response.TextDetections {
+		// 腾讯云返回的置信度范围为 0-100
+		page.Lines = append(page.Lines, Line{Text: item.DetectedText, Confidence: item.Confidence / 100})
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,170 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+)
+
+var (
+	ErrFileTooLarge  = errors.New("pdf file too large")
+	ErrTooManyPages  = errors.New("pdf has too many pages")
+	ErrNoTextContent = errors.New("pdf has no text content")
+)
+
+// maxFileSize PDF 文件最大支持 20MB
+const maxFileSize = 20 * 1024 * 1024
+
+// Extractor 基于 poppler-utils 中的 pdftotext 命令提取 PDF 的逐页文本
+type Extractor struct {
+	bin      string
+	maxPages int
+}
+
+func NewExtractor(conf *config.Config) *Extractor {
+	return &Extractor{bin: conf.PDFToTextBin, maxPages: conf.PDFMaxPages}
+}
+
+// ExtractURL 下载远程 PDF 文件并提取逐页文本
+func (e *Extractor) ExtractURL(ctx context.Context, fileURL string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download pdf failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("download pdf failed: [%d] %s", resp.StatusCode, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read pdf failed: %w", err)
+	}
+
+	if len(data) > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	return e.Extract(ctx, data)
+}
+
+// Extract 提取 PDF 的逐页文本，返回结果中第 i 个元素为第 i+1 页的内容
+func (e *Extractor) Extract(ctx context.Context, data []byte) ([]string, error) {
+	tmp, err := os.CreateTemp("", "aidea-pdf-*.pdf")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return nil, err
+	}
+	_ = tmp.Close()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.bin, "-enc", "UTF-8", "-layout", tmp.Name(), "-")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("pdftotext failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	pages := SplitPages(stdout.String())
+	if e.maxPages > 0 && len(pages) > e.maxPages {
+		return nil, ErrTooManyPages
+	}
+
+	empty := true
+	for _, p := range pages {
+		if strings.TrimSpace(p) != "" {
+			empty = false
+			break
+		}
+	}
+
+	if empty {
+		return nil, ErrNoTextContent
+	}
+
+	return pages, nil
+}
+
+// SplitPages pdftotext 使用换页符（\f）分隔每一页，最后一页之后也会输出一个换页符
+func SplitPages(text string) []string {
+	pages := strings.Split(text, "\f")
+	if len(pages) > 0 && strings.TrimSpace(pages[len(pages)-1]) == "" {
+		pages = pages[:len(pages)-1]
+	}
+
+	return pages
+}
+
+// Chunk 用于向量化的文本片段，Page 从 1 开始
+type Chunk struct {
+	Page    int
+	Content string
+}
+
+// ChunkPages 将每一页的文本按照 size 个字符切分为片段，相邻片段之间重叠 overlap 个字符，片段不会跨页，以便引用时定位到页码
+func ChunkPages(pages []string, size, overlap int) []Chunk {
+	if overlap >= size {
+		overlap = 0
+	}
+
+	chunks := make([]Chunk, 0)
+	for i, page := range pages {
+		runes := []rune(strings.Join(strings.Fields(page), " "))
+		for start := 0; start < len(runes); start += size - overlap {
+			end := start + size
+			if end > len(runes) {
+				end = len(runes)
+			}
+
+			chunks = append(chunks, Chunk{Page: i + 1, Content: string(runes[start:end])})
+			if end == len(runes) {
+				break
+			}
+		}
+	}
+
+	return chunks
+}
+
+var citationRegexp = regexp.MustCompile(`\[P(\d+)]`)
+
+// ParseCitations 解析回答中形如 [P3] 的页码引用标记，返回去重后升序排列的页码
+func ParseCitations(text string) []int {
+	seen := make(map[int]bool)
+	pages := make([]int, 0)
+	for _, match := range citationRegexp.FindAllStringSubmatch(text, -1) {
+		page, err := strconv.Atoi(match[1])
+		if err != nil || page <= 0 || seen[page] {
+			continue
+		}
+
+		seen[page] = true
+		pages = append(pages, page)
+	}
+
+	sort.Ints(pages)
+	return pages
+}
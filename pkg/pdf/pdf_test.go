@@ -0,0 +1,30 @@
+package pdf_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/pdf"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestSplitPages(t *testing.T) {
+	pages := pdf.SplitPages("page one\fpage two\n\f")
+	assert.Equal(t, 2, len(pages))
+	assert.Equal(t, "page one", pages[0])
+	assert.Equal(t, "page two\n", pages[1])
+}
+
+func TestChunkPages(t *testing.T) {
+	chunks := pdf.ChunkPages([]string{"abcdefghij", "", "  xy\n z  "}, 4, 1)
+
+	assert.Equal(t, 4, len(chunks))
+	assert.Equal(t, pdf.Chunk{Page: 1, Content: "abcd"}, chunks[0])
+	assert.Equal(t, pdf.Chunk{Page: 1, Content: "defg"}, chunks[1])
+	assert.Equal(t, pdf.Chunk{Page: 1, Content: "ghij"}, chunks[2])
+	assert.Equal(t, pdf.Chunk{Page: 3, Content: "xy z"}, chunks[3])
+}
+
+func TestParseCitations(t *testing.T) {
+	assert.Equal(t, []int{2, 5}, pdf.ParseCitations("见第五页 [P5]，另外 [P2] 和 [P5] 也提到了，[P0] [Px] 无效"))
+	assert.Equal(t, 0, len(pdf.ParseCitations("没有引用")))
+}
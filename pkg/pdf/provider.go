@@ -0,0 +1,9 @@
+package pdf
+
+import "github.com/mylxsw/glacier/infra"
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(NewExtractor)
+}
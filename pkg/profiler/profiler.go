@@ -0,0 +1,112 @@
+// Package profiler 记录数据库查询与接口的耗时，定期汇总到数据库，用于发现慢查询与热点接口
+package profiler
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 统计类型
+const (
+	KindQuery    = "query"
+	KindEndpoint = "endpoint"
+)
+
+// maxNameLength 统计名称（SQL 或接口）的最大长度
+const maxNameLength = 1000
+
+// Stat 一段时间内某条 SQL 或某个接口的耗时统计
+type Stat struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Count 请求次数
+	Count int64 `json:"count"`
+	// TotalElapse 总耗时（毫秒）
+	TotalElapse int64 `json:"total_elapse"`
+	// MaxElapse 最大耗时（毫秒）
+	MaxElapse int64 `json:"max_elapse"`
+	// SlowCount 超过慢查询（慢接口）阈值的次数
+	SlowCount int64 `json:"slow_count"`
+}
+
+// AvgElapse 平均耗时（毫秒）
+func (s Stat) AvgElapse() int64 {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return s.TotalElapse / s.Count
+}
+
+type statKey struct {
+	kind string
+	name string
+}
+
+var (
+	lock  sync.Mutex
+	stats = map[statKey]*Stat{}
+)
+
+// Record 记录一次查询或接口请求的耗时，返回本次是否超过阈值，threshold 为 0 时不判断是否超过阈值
+func Record(kind, name string, elapse, threshold time.Duration) bool {
+	slow := threshold > 0 && elapse >= threshold
+	key := statKey{kind: kind, name: name}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	st, ok := stats[key]
+	if !ok {
+		st = &Stat{Kind: kind, Name: name}
+		stats[key] = st
+	}
+
+	ms := elapse.Milliseconds()
+	st.Count++
+	st.TotalElapse += ms
+	if ms > st.MaxElapse {
+		st.MaxElapse = ms
+	}
+
+	if slow {
+		st.SlowCount++
+	}
+
+	return slow
+}
+
+// Flush 返回上次 Flush 以来的所有统计，并清空当前统计
+func Flush() []Stat {
+	lock.Lock()
+	current := stats
+	stats = map[statKey]*Stat{}
+	lock.Unlock()
+
+	res := make([]Stat, 0, len(current))
+	for _, st := range current {
+		res = append(res, *st)
+	}
+
+	return res
+}
+
+var (
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	// placeholdersPattern IN 查询中参数数量不同的 SQL 视为同一条 SQL
+	placeholdersPattern = regexp.MustCompile(`\(\s*\?(\s*,\s*\?)+\s*\)`)
+)
+
+// NormalizeSQL 合并空白字符与 IN 查询的参数占位符，参数不同的同一条 SQL 合并统计
+func NormalizeSQL(sql string) string {
+	sql = whitespacePattern.ReplaceAllString(strings.TrimSpace(sql), " ")
+	sql = placeholdersPattern.ReplaceAllString(sql, "(?...)")
+
+	if len(sql) > maxNameLength {
+		sql = strings.ToValidUTF8(sql[:maxNameLength], "")
+	}
+
+	return sql
+}
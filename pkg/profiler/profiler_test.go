@@ -0,0 +1,43 @@
+package profiler_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/profiler"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestRecord(t *testing.T) {
+	profiler.Flush()
+
+	assert.False(t, profiler.Record(profiler.KindQuery, "SELECT 1", 100*time.Millisecond, 200*time.Millisecond))
+	assert.True(t, profiler.Record(profiler.KindQuery, "SELECT 1", 300*time.Millisecond, 200*time.Millisecond))
+	assert.False(t, profiler.Record(profiler.KindEndpoint, "GET /v1/models", 5*time.Second, 0))
+
+	stats := profiler.Flush()
+	assert.EqualValues(t, 2, len(stats))
+
+	for _, st := range stats {
+		switch st.Kind {
+		case profiler.KindQuery:
+			assert.EqualValues(t, 2, st.Count)
+			assert.EqualValues(t, 400, st.TotalElapse)
+			assert.EqualValues(t, 300, st.MaxElapse)
+			assert.EqualValues(t, 1, st.SlowCount)
+			assert.EqualValues(t, 200, st.AvgElapse())
+		case profiler.KindEndpoint:
+			assert.EqualValues(t, 1, st.Count)
+			assert.EqualValues(t, 0, st.SlowCount)
+		}
+	}
+
+	assert.EqualValues(t, 0, len(profiler.Flush()))
+}
+
+func TestNormalizeSQL(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM users WHERE id IN (?...) AND status = ?", profiler.NormalizeSQL("SELECT *\n\tFROM users  WHERE id IN (?, ?,?) AND status = ?"))
+	assert.Equal(t, "SELECT * FROM users WHERE id IN (?)", profiler.NormalizeSQL(" SELECT * FROM users WHERE id IN (?)"))
+	assert.EqualValues(t, 1000, len(profiler.NormalizeSQL("SELECT "+strings.Repeat("a", 2000))))
+}
@@ -0,0 +1,59 @@
+package provenance
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Generator 内容生成平台名称
+const Generator = "AIdea"
+
+// Label AI 生成内容的标识文字
+const Label = "本内容由人工智能生成"
+
+// Metadata AI 生成内容的来源信息，用于满足 AI 生成内容标识的要求
+type Metadata struct {
+	AIGenerated bool   `json:"ai_generated"`
+	Label       string `json:"label"`
+	Generator   string `json:"generator"`
+	// Model 生成内容使用的模型
+	Model string `json:"model,omitempty"`
+	// Provider 模型服务商
+	Provider string `json:"provider,omitempty"`
+	// Params 生成参数
+	Params map[string]any `json:"params,omitempty"`
+	// GeneratedAt 生成时间
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// New 创建 AI 生成内容的来源信息
+func New(model, provider string, params map[string]any, generatedAt time.Time) Metadata {
+	return Metadata{
+		AIGenerated: true,
+		Label:       Label,
+		Generator:   Generator,
+		Model:       model,
+		Provider:    provider,
+		Params:      params,
+		GeneratedAt: generatedAt,
+	}
+}
+
+// Watermark 嵌入到图片中的水印内容，只包含必要的信息以控制长度
+func (m Metadata) Watermark() string {
+	return fmt.Sprintf("AIGC;%s;%s;%s;%d", m.Generator, m.Provider, m.Model, m.GeneratedAt.Unix())
+}
+
+type watermarkKey struct{}
+
+// WithWatermark 在上下文中设置图片水印内容，上传图片时会将其嵌入到图片中（需要启用图片水印）
+func WithWatermark(ctx context.Context, watermark string) context.Context {
+	return context.WithValue(ctx, watermarkKey{}, watermark)
+}
+
+// WatermarkFromContext 从上下文中读取图片水印内容
+func WatermarkFromContext(ctx context.Context) (string, bool) {
+	watermark, ok := ctx.Value(watermarkKey{}).(string)
+	return watermark, ok && watermark != ""
+}
@@ -0,0 +1,93 @@
+package provenance
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+)
+
+// watermarkMagic 水印数据的起始标识
+var watermarkMagic = []byte("AW")
+
+var (
+	ErrWatermarkTooLarge = errors.New("watermark is too large for the image")
+	ErrWatermarkNotFound = errors.New("watermark not found")
+)
+
+// Embed 将水印以不可见的方式嵌入到图片中（写入每个像素蓝色通道的最低位）
+// 格式为：2 字节标识 + 2 字节长度 + 水印内容
+func Embed(img image.Image, watermark string) (*image.NRGBA, error) {
+	payload := append(append([]byte{}, watermarkMagic...), byte(len(watermark)>>8), byte(len(watermark)))
+	payload = append(payload, watermark...)
+
+	bounds := img.Bounds()
+	if len(watermark) > 0xFFFF || len(payload)*8 > bounds.Dx()*bounds.Dy() {
+		return nil, ErrWatermarkTooLarge
+	}
+
+	dst := image.NewNRGBA(bounds)
+	draw.Draw(dst, bounds, img, bounds.Min, draw.Src)
+
+	for i := 0; i < len(payload)*8; i++ {
+		bit := (payload[i/8] >> (7 - uint(i%8))) & 1
+		offset := i*4 + 2
+		dst.Pix[offset] = dst.Pix[offset]&0xFE | bit
+	}
+
+	return dst, nil
+}
+
+// Extract 从图片中读取水印
+func Extract(img image.Image) (string, error) {
+	bounds := img.Bounds()
+	src := image.NewNRGBA(bounds)
+	draw.Draw(src, bounds, img, bounds.Min, draw.Src)
+
+	capacity := bounds.Dx() * bounds.Dy() / 8
+	readByte := func(index int) byte {
+		var b byte
+		for i := 0; i < 8; i++ {
+			b = b<<1 | src.Pix[(index*8+i)*4+2]&1
+		}
+		return b
+	}
+
+	if capacity < 4 || readByte(0) != watermarkMagic[0] || readByte(1) != watermarkMagic[1] {
+		return "", ErrWatermarkNotFound
+	}
+
+	length := int(readByte(2))<<8 | int(readByte(3))
+	if 4+length > capacity {
+		return "", ErrWatermarkNotFound
+	}
+
+	data := make([]byte, length)
+	for i := range data {
+		data[i] = readByte(4 + i)
+	}
+
+	return string(data), nil
+}
+
+// EmbedPNG 将水印嵌入到图片数据中，输出为 PNG 格式（有损压缩格式会破坏水印）
+func EmbedPNG(data []byte, watermark string) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := Embed(img, watermark)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,45 @@
+package provenance_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/provenance"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestWatermark(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 64; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 4), G: uint8(y * 4), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+
+	_, err := provenance.Extract(img)
+	assert.Equal(t, provenance.ErrWatermarkNotFound, err)
+
+	watermark := provenance.New("dall-e-3", "dalle", nil, time.Unix(1700000000, 0)).Watermark()
+	assert.Equal(t, "AIGC;AIdea;dalle;dall-e-3;1700000000", watermark)
+
+	data, err := provenance.EmbedPNG(buf.Bytes(), watermark)
+	assert.NoError(t, err)
+
+	decoded, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	extracted, err := provenance.Extract(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, watermark, extracted)
+
+	// 图片太小，无法容纳水印
+	_, err = provenance.Embed(image.NewRGBA(image.Rect(0, 0, 4, 4)), watermark)
+	assert.Equal(t, provenance.ErrWatermarkTooLarge, err)
+}
@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// 异常行为标记的审核状态
+const (
+	AbuseFlagStatusPending   = 1
+	AbuseFlagStatusConfirmed = 2
+	AbuseFlagStatusDismissed = 3
+)
+
+// AbuseRepo 异常使用行为标记
+type AbuseRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewAbuseRepo create a new AbuseRepo
+func NewAbuseRepo(db *sql.DB, conf *config.Config) *AbuseRepo {
+	return &AbuseRepo{db: db, conf: conf}
+}
+
+// AddFlag 将用户标记为疑似异常，进入人工审核队列
+func (repo *AbuseRepo) AddFlag(ctx context.Context, userID int64, signals []string, evidence string) (int64, error) {
+	return model.NewAbuseFlagModel(repo.db).Create(ctx, query.KV{
+		model.FieldAbuseFlagUserId:   userID,
+		model.FieldAbuseFlagSignals:  strings.Join(signals, ","),
+		model.FieldAbuseFlagEvidence: evidence,
+		model.FieldAbuseFlagStatus:   AbuseFlagStatusPending,
+	})
+}
+
+// Flag 查询异常行为标记
+func (repo *AbuseRepo) Flag(ctx context.Context, id int64) (*model.AbuseFlag, error) {
+	item, err := model.NewAbuseFlagModel(repo.db).First(ctx, query.Builder().Where(model.FieldAbuseFlagId, id))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := item.ToAbuseFlag()
+	return &ret, nil
+}
+
+// Flags 查询异常行为标记列表，status 为 0 时查询所有状态
+func (repo *AbuseRepo) Flags(ctx context.Context, status, limit int64) ([]model.AbuseFlag, error) {
+	q := query.Builder().OrderBy(model.FieldAbuseFlagId, "DESC").Limit(limit)
+	if status > 0 {
+		q = q.Where(model.FieldAbuseFlagStatus, status)
+	}
+
+	items, err := model.NewAbuseFlagModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.AbuseFlagN, _ int) model.AbuseFlag {
+		return item.ToAbuseFlag()
+	}), nil
+}
+
+// Review 审核异常行为标记，只能审核待审核状态的标记
+func (repo *AbuseRepo) Review(ctx context.Context, id int64, status int64, note string) error {
+	affected, err := model.NewAbuseFlagModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{
+			model.FieldAbuseFlagStatus: status,
+			model.FieldAbuseFlagNote:   note,
+		},
+		query.Builder().Where(model.FieldAbuseFlagId, id).Where(model.FieldAbuseFlagStatus, AbuseFlagStatusPending),
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
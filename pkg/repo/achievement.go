@@ -0,0 +1,208 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/achievement"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+type AchievementRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewAchievementRepo create a new AchievementRepo
+func NewAchievementRepo(db *sql.DB, conf *config.Config) *AchievementRepo {
+	return &AchievementRepo{db: db, conf: conf}
+}
+
+// Metrics 查询用户的成就统计指标
+func (repo *AchievementRepo) Metrics(ctx context.Context, userID int64) (*achievement.Metrics, error) {
+	var m achievement.Metrics
+
+	row := repo.db.QueryRowContext(
+		ctx,
+		"SELECT (SELECT COUNT(*) FROM chat_messages WHERE user_id = ? AND role = ?) + (SELECT COUNT(*) FROM chat_group_message WHERE user_id = ? AND role = ? AND deleted_at IS NULL)",
+		userID, int64(MessageRoleUser), userID, int64(MessageRoleUser),
+	)
+	if err := row.Scan(&m.Chats); err != nil {
+		return nil, fmt.Errorf("query chat count failed: %w", err)
+	}
+
+	images, err := model.NewCreativeHistoryModel(repo.db).Count(ctx, query.Builder().
+		Where(model.FieldCreativeHistoryUserId, userID).
+		Where(model.FieldCreativeHistoryIslandType, int64(IslandTypeImage)).
+		Where(model.FieldCreativeHistoryStatus, int64(CreativeStatusSuccess)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query image count failed: %w", err)
+	}
+	m.Images = images
+
+	// 连续使用天数基于每日汇总的用户统计数据计算
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT DISTINCT DATE_FORMAT(stat_date, '%Y-%m-%d') FROM user_stats WHERE user_id = ? AND stat_date >= ? AND messages > 0",
+		userID, time.Now().AddDate(-1, 0, 0).Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query active dates failed: %w", err)
+	}
+	defer rows.Close()
+
+	dates := make([]string, 0)
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+
+		dates = append(dates, date)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	m.CurrentStreak, _ = Streaks(dates, time.Now())
+	return &m, nil
+}
+
+// Unlock 为用户解锁成就，返回 false 表示用户之前已经解锁过该成就
+func (repo *AchievementRepo) Unlock(ctx context.Context, userID int64, a achievement.Achievement) (bool, error) {
+	res, err := repo.db.ExecContext(
+		ctx,
+		"INSERT IGNORE INTO user_achievement (user_id, achievement, reward, points, notified, created_at, updated_at) VALUES (?, ?, ?, ?, 0, NOW(), NOW())",
+		userID, a.Key, a.Reward, a.Points,
+	)
+	if err != nil {
+		return false, fmt.Errorf("unlock achievement failed: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// UserAchievements 查询用户已经解锁的成就
+func (repo *AchievementRepo) UserAchievements(ctx context.Context, userID int64) ([]model.UserAchievement, error) {
+	items, err := model.NewUserAchievementModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldUserAchievementUserId, userID).
+		OrderBy(model.FieldUserAchievementId, "ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.UserAchievementN, _ int) model.UserAchievement {
+		return item.ToUserAchievement()
+	}), nil
+}
+
+// PopUnnotified 查询用户新解锁但还未通知的成就，并将其标记为已通知
+func (repo *AchievementRepo) PopUnnotified(ctx context.Context, userID int64) ([]model.UserAchievement, error) {
+	q := query.Builder().
+		Where(model.FieldUserAchievementUserId, userID).
+		Where(model.FieldUserAchievementNotified, 0)
+
+	items, err := model.NewUserAchievementModel(repo.db).Get(ctx, q.OrderBy(model.FieldUserAchievementId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return []model.UserAchievement{}, nil
+	}
+
+	ids := array.Map(items, func(item model.UserAchievementN, _ int) int64 { return item.Id.ValueOrZero() })
+	if _, err := model.NewUserAchievementModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldUserAchievementNotified: 1},
+		query.Builder().WhereIn(model.FieldUserAchievementId, ids),
+	); err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.UserAchievementN, _ int) model.UserAchievement {
+		return item.ToUserAchievement()
+	}), nil
+}
+
+// IsLeaderboardMember 用户是否已加入排行榜
+func (repo *AchievementRepo) IsLeaderboardMember(ctx context.Context, userID int64) (bool, error) {
+	return model.NewAchievementLeaderboardModel(repo.db).Exists(ctx, query.Builder().Where(model.FieldAchievementLeaderboardUserId, userID))
+}
+
+// SetLeaderboardMember 加入或退出排行榜
+func (repo *AchievementRepo) SetLeaderboardMember(ctx context.Context, userID int64, join bool) error {
+	if !join {
+		_, err := model.NewAchievementLeaderboardModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldAchievementLeaderboardUserId, userID))
+		return err
+	}
+
+	exist, err := repo.IsLeaderboardMember(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		return nil
+	}
+
+	if _, err := model.NewAchievementLeaderboardModel(repo.db).Create(ctx, query.KV{
+		model.FieldAchievementLeaderboardUserId: userID,
+	}); err != nil {
+		return fmt.Errorf("join leaderboard failed: %w", err)
+	}
+
+	return nil
+}
+
+// LeaderboardItem 排行榜条目
+type LeaderboardItem struct {
+	UserID       int64  `json:"user_id"`
+	Name         string `json:"name"`
+	Avatar       string `json:"avatar,omitempty"`
+	Achievements int64  `json:"achievements"`
+	Points       int64  `json:"points"`
+}
+
+// Leaderboard 查询成就排行榜，只包含主动加入排行榜的用户
+func (repo *AchievementRepo) Leaderboard(ctx context.Context, limit int64) ([]LeaderboardItem, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT u.id, COALESCE(u.realname, ''), COALESCE(u.avatar, ''), COUNT(ua.id), COALESCE(SUM(ua.points), 0)
+FROM achievement_leaderboard lb
+INNER JOIN users u ON u.id = lb.user_id
+INNER JOIN user_achievement ua ON ua.user_id = lb.user_id
+WHERE u.status = ?
+GROUP BY u.id, u.realname, u.avatar
+ORDER BY SUM(ua.points) DESC, MAX(ua.id) ASC
+LIMIT ?`,
+		UserStatusActive, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query leaderboard failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]LeaderboardItem, 0)
+	for rows.Next() {
+		var item LeaderboardItem
+		if err := rows.Scan(&item.UserID, &item.Name, &item.Avatar, &item.Achievements, &item.Points); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
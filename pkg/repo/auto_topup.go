@@ -0,0 +1,309 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/guregu/null.v3"
+)
+
+const (
+	AutoTopupStatusPending   = 0
+	AutoTopupStatusActive    = 1
+	AutoTopupStatusCancelled = 2
+	// AutoTopupStatusSuspended 连续扣款失败，自动充值已暂停，用户重新保存设置后恢复
+	AutoTopupStatusSuspended = 3
+)
+
+// AutoTopupMaxFailures 连续扣款失败多少次后暂停自动充值
+const AutoTopupMaxFailures = 3
+
+// PaymentSourceAlipayAgreement 支付宝商家扣款的支付来源，对应 payment_history.source 为 alipay-agreement
+const PaymentSourceAlipayAgreement = "agreement"
+
+// AutoTopup 自动充值设置
+type AutoTopup struct {
+	ID                  int64      `json:"id"`
+	UserID              int64      `json:"user_id"`
+	Status              int64      `json:"status"`
+	ProductID           string     `json:"product_id"`
+	Threshold           int64      `json:"threshold"`
+	MonthlyCap          int64      `json:"monthly_cap"`
+	ExternalAgreementNo string     `json:"-"`
+	AgreementNo         string     `json:"-"`
+	SignedAt            *time.Time `json:"signed_at,omitempty"`
+	NotifiedAt          *time.Time `json:"notified_at,omitempty"`
+	LastChargedAt       *time.Time `json:"last_charged_at,omitempty"`
+	Failures            int64      `json:"failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+func newAutoTopup(m model.AutoTopupN) AutoTopup {
+	ret := AutoTopup{
+		ID:                  m.Id.ValueOrZero(),
+		UserID:              m.UserId.ValueOrZero(),
+		Status:              m.Status.ValueOrZero(),
+		ProductID:           m.ProductId.ValueOrZero(),
+		Threshold:           m.Threshold.ValueOrZero(),
+		MonthlyCap:          m.MonthlyCap.ValueOrZero(),
+		ExternalAgreementNo: m.ExternalAgreementNo.ValueOrZero(),
+		AgreementNo:         m.AgreementNo.ValueOrZero(),
+		Failures:            m.Failures.ValueOrZero(),
+		LastError:           m.LastError.ValueOrZero(),
+		CreatedAt:           m.CreatedAt.ValueOrZero(),
+	}
+
+	if m.SignedAt.Valid {
+		ret.SignedAt = &m.SignedAt.Time
+	}
+	if m.NotifiedAt.Valid {
+		ret.NotifiedAt = &m.NotifiedAt.Time
+	}
+	if m.LastChargedAt.Valid {
+		ret.LastChargedAt = &m.LastChargedAt.Time
+	}
+
+	return ret
+}
+
+// AutoTopupSettings 用户的自动充值设置
+type AutoTopupSettings struct {
+	ProductID string
+	// Threshold 余额低于该值时自动充值
+	Threshold int64
+	// MonthlyCap 每月自动充值的最大金额，单位为分
+	MonthlyCap int64
+}
+
+// AutoTopupRepo 余额不足时自动充值
+type AutoTopupRepo struct {
+	db *sql.DB
+}
+
+// NewAutoTopupRepo create a new AutoTopupRepo
+func NewAutoTopupRepo(db *sql.DB) *AutoTopupRepo {
+	return &AutoTopupRepo{db: db}
+}
+
+// Get 查询用户的自动充值设置
+func (repo *AutoTopupRepo) Get(ctx context.Context, userID int64) (*AutoTopup, error) {
+	item, err := model.NewAutoTopupModel(repo.db).First(ctx, query.Builder().Where(model.FieldAutoTopupUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := newAutoTopup(*item)
+	return &ret, nil
+}
+
+// Save 保存用户的自动充值设置
+//
+// 已经签约的设置直接生效（暂停的设置同时恢复），未签约或者已取消时重新生成商户签约号，需要用户完成签约后才会生效
+func (repo *AutoTopupRepo) Save(ctx context.Context, userID int64, settings AutoTopupSettings) (*AutoTopup, error) {
+	existing, err := repo.Get(ctx, userID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if existing != nil && (existing.Status == AutoTopupStatusActive || existing.Status == AutoTopupStatusSuspended) {
+		if _, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+			model.FieldAutoTopupStatus:     AutoTopupStatusActive,
+			model.FieldAutoTopupProductId:  settings.ProductID,
+			model.FieldAutoTopupThreshold:  settings.Threshold,
+			model.FieldAutoTopupMonthlyCap: settings.MonthlyCap,
+			model.FieldAutoTopupFailures:   0,
+			model.FieldAutoTopupNotifiedAt: null.Time{},
+		}, query.Builder().Where(model.FieldAutoTopupId, existing.ID)); err != nil {
+			return nil, err
+		}
+
+		return repo.Get(ctx, userID)
+	}
+
+	externalNo, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("generate agreement no failed: %w", err)
+	}
+
+	kv := query.KV{
+		model.FieldAutoTopupStatus:              AutoTopupStatusPending,
+		model.FieldAutoTopupProductId:           settings.ProductID,
+		model.FieldAutoTopupThreshold:           settings.Threshold,
+		model.FieldAutoTopupMonthlyCap:          settings.MonthlyCap,
+		model.FieldAutoTopupExternalAgreementNo: fmt.Sprintf("topup-%d-%s", userID, externalNo[:8]),
+		model.FieldAutoTopupAgreementNo:         null.String{},
+		model.FieldAutoTopupFailures:            0,
+		model.FieldAutoTopupNotifiedAt:          null.Time{},
+	}
+
+	if existing != nil {
+		if _, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, kv, query.Builder().Where(model.FieldAutoTopupId, existing.ID)); err != nil {
+			return nil, err
+		}
+	} else {
+		kv[model.FieldAutoTopupUserId] = userID
+		if _, err := model.NewAutoTopupModel(repo.db).Create(ctx, kv); err != nil {
+			return nil, err
+		}
+	}
+
+	return repo.Get(ctx, userID)
+}
+
+// Activate 签约成功，自动充值生效，只有待签约的设置可以生效
+func (repo *AutoTopupRepo) Activate(ctx context.Context, externalAgreementNo, agreementNo, alipayUserID string) (*AutoTopup, error) {
+	q := query.Builder().
+		Where(model.FieldAutoTopupExternalAgreementNo, externalAgreementNo).
+		Where(model.FieldAutoTopupStatus, AutoTopupStatusPending)
+
+	affected, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupStatus:       AutoTopupStatusActive,
+		model.FieldAutoTopupAgreementNo:  agreementNo,
+		model.FieldAutoTopupAlipayUserId: alipayUserID,
+		model.FieldAutoTopupSignedAt:     time.Now(),
+	}, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, ErrNotFound
+	}
+
+	item, err := model.NewAutoTopupModel(repo.db).First(ctx, query.Builder().Where(model.FieldAutoTopupExternalAgreementNo, externalAgreementNo))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := newAutoTopup(*item)
+	return &ret, nil
+}
+
+// Cancel 取消用户的自动充值，返回取消前的设置，用于解除支付宝协议
+func (repo *AutoTopupRepo) Cancel(ctx context.Context, userID int64) (*AutoTopup, error) {
+	existing, err := repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupStatus:     AutoTopupStatusCancelled,
+		model.FieldAutoTopupNotifiedAt: null.Time{},
+	}, query.Builder().Where(model.FieldAutoTopupId, existing.ID)); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// CancelByAgreement 用户在支付宝中解约后，取消对应的自动充值
+func (repo *AutoTopupRepo) CancelByAgreement(ctx context.Context, agreementNo string) error {
+	_, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupStatus:     AutoTopupStatusCancelled,
+		model.FieldAutoTopupNotifiedAt: null.Time{},
+	}, query.Builder().Where(model.FieldAutoTopupAgreementNo, agreementNo))
+
+	return err
+}
+
+// ActiveItems 所有已生效的自动充值设置
+func (repo *AutoTopupRepo) ActiveItems(ctx context.Context) ([]AutoTopup, error) {
+	items, err := model.NewAutoTopupModel(repo.db).Get(ctx, query.Builder().Where(model.FieldAutoTopupStatus, AutoTopupStatusActive))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.AutoTopupN, _ int) AutoTopup {
+		return newAutoTopup(item)
+	}), nil
+}
+
+// MarkNotified 记录已经发送扣款前通知，返回 false 表示已经通知过（其它实例已经处理）
+func (repo *AutoTopupRepo) MarkNotified(ctx context.Context, id int64) (bool, error) {
+	affected, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupNotifiedAt: time.Now(),
+	}, query.Builder().
+		Where(model.FieldAutoTopupId, id).
+		Where(model.FieldAutoTopupStatus, AutoTopupStatusActive).
+		WhereNull(model.FieldAutoTopupNotifiedAt))
+
+	return affected > 0, err
+}
+
+// ClearNotified 余额已经充足，取消待扣款
+func (repo *AutoTopupRepo) ClearNotified(ctx context.Context, id int64) error {
+	_, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupNotifiedAt: null.Time{},
+	}, query.Builder().Where(model.FieldAutoTopupId, id))
+
+	return err
+}
+
+// ClaimCharge 开始扣款，清空待扣款状态，返回 false 表示用户已经取消或者其它实例已经扣款
+func (repo *AutoTopupRepo) ClaimCharge(ctx context.Context, id int64) (bool, error) {
+	affected, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupNotifiedAt:    null.Time{},
+		model.FieldAutoTopupLastChargedAt: time.Now(),
+	}, query.Builder().
+		Where(model.FieldAutoTopupId, id).
+		Where(model.FieldAutoTopupStatus, AutoTopupStatusActive).
+		WhereNotNull(model.FieldAutoTopupNotifiedAt))
+
+	return affected > 0, err
+}
+
+// ChargeSucceed 扣款成功，重置连续失败次数
+func (repo *AutoTopupRepo) ChargeSucceed(ctx context.Context, id int64) error {
+	_, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupFailures:  0,
+		model.FieldAutoTopupLastError: "",
+	}, query.Builder().Where(model.FieldAutoTopupId, id))
+
+	return err
+}
+
+// ChargeFailed 记录扣款失败，连续失败 AutoTopupMaxFailures 次后暂停自动充值，返回是否已暂停
+func (repo *AutoTopupRepo) ChargeFailed(ctx context.Context, id int64, reason string) (bool, error) {
+	if len([]rune(reason)) > 255 {
+		reason = string([]rune(reason)[:255])
+	}
+
+	if _, err := repo.db.ExecContext(ctx, "UPDATE auto_topup SET failures = failures + 1, last_error = ?, updated_at = ? WHERE id = ?", reason, time.Now(), id); err != nil {
+		return false, err
+	}
+
+	affected, err := model.NewAutoTopupModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAutoTopupStatus: AutoTopupStatusSuspended,
+	}, query.Builder().
+		Where(model.FieldAutoTopupId, id).
+		Where(model.FieldAutoTopupStatus, AutoTopupStatusActive).
+		Where(model.FieldAutoTopupFailures, ">=", AutoTopupMaxFailures))
+
+	return affected > 0, err
+}
+
+// MonthSpent 用户本月自动充值的金额（包括处理中的订单），单位为分
+func (repo *AutoTopupRepo) MonthSpent(ctx context.Context, userID int64) (int64, error) {
+	start, end := monthRange(time.Now())
+
+	var spent null.Int
+	err := repo.db.QueryRowContext(
+		ctx,
+		"SELECT SUM(retail_price) FROM payment_history WHERE user_id = ? AND source = ? AND status IN (?, ?) AND created_at >= ? AND created_at < ?",
+		userID, "alipay-"+PaymentSourceAlipayAgreement, PaymentStatusWaiting, PaymentStatusSuccess, start, end,
+	).Scan(&spent)
+
+	return spent.ValueOrZero(), err
+}
@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// AvatarPackConsent 用户上传自拍照生成数字分身写真时的授权记录
+type AvatarPackConsent struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	TaskID         string     `json:"task_id"`
+	ConsentVersion string     `json:"consent_version"`
+	ClientIP       string     `json:"client_ip,omitempty"`
+	Selfies        []string   `json:"selfies,omitempty"`
+	ConsentedAt    time.Time  `json:"consented_at"`
+	PurgedAt       *time.Time `json:"purged_at,omitempty"`
+}
+
+// AvatarPackRepo 数字分身写真
+type AvatarPackRepo struct {
+	db *sql.DB
+}
+
+// NewAvatarPackRepo create a new AvatarPackRepo
+func NewAvatarPackRepo(db *sql.DB) *AvatarPackRepo {
+	return &AvatarPackRepo{db: db}
+}
+
+// AddConsent 保存用户的授权记录
+func (repo *AvatarPackRepo) AddConsent(ctx context.Context, consent AvatarPackConsent) (int64, error) {
+	selfies, _ := json.Marshal(consent.Selfies)
+	return model.NewAvatarPackConsentModel(repo.db).Create(ctx, query.KV{
+		model.FieldAvatarPackConsentUserId:         consent.UserID,
+		model.FieldAvatarPackConsentTaskId:         consent.TaskID,
+		model.FieldAvatarPackConsentConsentVersion: consent.ConsentVersion,
+		model.FieldAvatarPackConsentClientIp:       consent.ClientIP,
+		model.FieldAvatarPackConsentSelfies:        string(selfies),
+		model.FieldAvatarPackConsentConsentedAt:    consent.ConsentedAt,
+	})
+}
+
+func avatarPackConsentFromModel(item model.AvatarPackConsentN) AvatarPackConsent {
+	consent := AvatarPackConsent{
+		ID:             item.Id.ValueOrZero(),
+		UserID:         item.UserId.ValueOrZero(),
+		TaskID:         item.TaskId.ValueOrZero(),
+		ConsentVersion: item.ConsentVersion.ValueOrZero(),
+		ClientIP:       item.ClientIp.ValueOrZero(),
+		ConsentedAt:    item.ConsentedAt.ValueOrZero(),
+	}
+
+	if v := item.Selfies.ValueOrZero(); v != "" {
+		_ = json.Unmarshal([]byte(v), &consent.Selfies)
+	}
+
+	if item.PurgedAt.Valid {
+		consent.PurgedAt = &item.PurgedAt.Time
+	}
+
+	return consent
+}
+
+// Consent 查询用户指定任务的授权记录
+func (repo *AvatarPackRepo) Consent(ctx context.Context, userID int64, taskID string) (*AvatarPackConsent, error) {
+	item, err := model.NewAvatarPackConsentModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldAvatarPackConsentUserId, userID).
+		Where(model.FieldAvatarPackConsentTaskId, taskID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	consent := avatarPackConsentFromModel(*item)
+	return &consent, nil
+}
+
+// UnpurgedConsents 查询授权时间早于 before，且自拍照还没有删除的授权记录
+func (repo *AvatarPackRepo) UnpurgedConsents(ctx context.Context, before time.Time, limit int64) ([]AvatarPackConsent, error) {
+	items, err := model.NewAvatarPackConsentModel(repo.db).Get(ctx, query.Builder().
+		WhereNull(model.FieldAvatarPackConsentPurgedAt).
+		Where(model.FieldAvatarPackConsentConsentedAt, "<", before).
+		OrderBy(model.FieldAvatarPackConsentId, "ASC").
+		Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.AvatarPackConsentN, _ int) AvatarPackConsent {
+		return avatarPackConsentFromModel(item)
+	}), nil
+}
+
+// MarkSelfiesPurged 自拍照已从存储中删除，清空授权记录中的自拍照地址，授权记录本身保留用于审计
+func (repo *AvatarPackRepo) MarkSelfiesPurged(ctx context.Context, id int64) error {
+	_, err := model.NewAvatarPackConsentModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldAvatarPackConsentSelfies:  "",
+		model.FieldAvatarPackConsentPurgedAt: time.Now(),
+	}, query.Builder().Where(model.FieldAvatarPackConsentId, id))
+	return err
+}
@@ -0,0 +1,218 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/guregu/null.v3"
+)
+
+const (
+	BatchStatusPending   int64 = 1
+	BatchStatusRunning   int64 = 2
+	BatchStatusSucceed   int64 = 3
+	BatchStatusFailed    int64 = 4
+	BatchStatusCancelled int64 = 5
+)
+
+type BatchRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewBatchRepo create a new BatchRepo
+func NewBatchRepo(db *sql.DB, conf *config.Config) *BatchRepo {
+	return &BatchRepo{db: db, conf: conf}
+}
+
+// BatchItemAddReq 批量请求条目
+type BatchItemAddReq struct {
+	CustomID string
+	// Messages 请求消息列表，JSON 格式
+	Messages string
+}
+
+// CreateBatch 创建一个批量聊天任务
+func (repo *BatchRepo) CreateBatch(ctx context.Context, userID int64, modelID string, items []BatchItemAddReq) (int64, error) {
+	var batchID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewChatBatchModel(tx).Create(ctx, query.KV{
+			model.FieldChatBatchUserId: userID,
+			model.FieldChatBatchModel:  modelID,
+			model.FieldChatBatchStatus: BatchStatusPending,
+			model.FieldChatBatchTotal:  len(items),
+		})
+		if err != nil {
+			return fmt.Errorf("create batch failed: %w", err)
+		}
+
+		batchID = id
+
+		for _, item := range items {
+			if _, err := model.NewChatBatchItemModel(tx).Create(ctx, query.KV{
+				model.FieldChatBatchItemBatchId:  batchID,
+				model.FieldChatBatchItemUserId:   userID,
+				model.FieldChatBatchItemCustomId: misc.SubString(item.CustomID, 100),
+				model.FieldChatBatchItemMessages: item.Messages,
+				model.FieldChatBatchItemStatus:   BatchStatusPending,
+			}); err != nil {
+				return fmt.Errorf("create batch item failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return batchID, err
+}
+
+// UpdateBatchTaskID 更新批次关联的队列任务 ID
+func (repo *BatchRepo) UpdateBatchTaskID(ctx context.Context, batchID int64, taskID string) error {
+	_, err := model.NewChatBatchModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldChatBatchTaskId: taskID},
+		query.Builder().Where(model.FieldChatBatchId, batchID),
+	)
+	return err
+}
+
+// GetBatch 获取批次信息，userID 为 0 时不校验所属用户
+func (repo *BatchRepo) GetBatch(ctx context.Context, userID, batchID int64) (*model.ChatBatch, error) {
+	q := query.Builder().Where(model.FieldChatBatchId, batchID)
+	if userID > 0 {
+		q = q.Where(model.FieldChatBatchUserId, userID)
+	}
+
+	batch, err := model.NewChatBatchModel(repo.db).First(ctx, q)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := batch.ToChatBatch()
+	return &ret, nil
+}
+
+// GetBatches 获取用户最近的批次列表
+func (repo *BatchRepo) GetBatches(ctx context.Context, userID int64, limit int64) ([]model.ChatBatch, error) {
+	q := query.Builder().
+		Where(model.FieldChatBatchUserId, userID).
+		OrderBy(model.FieldChatBatchId, "DESC").
+		Limit(limit)
+
+	batches, err := model.NewChatBatchModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(batches, func(item model.ChatBatchN, _ int) model.ChatBatch {
+		return item.ToChatBatch()
+	}), nil
+}
+
+// GetBatchItems 获取批次下的所有条目
+func (repo *BatchRepo) GetBatchItems(ctx context.Context, batchID int64) ([]model.ChatBatchItem, error) {
+	q := query.Builder().
+		Where(model.FieldChatBatchItemBatchId, batchID).
+		OrderBy(model.FieldChatBatchItemId, "ASC")
+
+	items, err := model.NewChatBatchItemModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.ChatBatchItemN, _ int) model.ChatBatchItem {
+		return item.ToChatBatchItem()
+	}), nil
+}
+
+// UpdateBatchStatus 更新批次状态
+func (repo *BatchRepo) UpdateBatchStatus(ctx context.Context, batchID int64, status int64) error {
+	kv := query.KV{model.FieldChatBatchStatus: status}
+	if status == BatchStatusSucceed || status == BatchStatusFailed || status == BatchStatusCancelled {
+		kv[model.FieldChatBatchCompletedAt] = time.Now()
+	}
+
+	_, err := model.NewChatBatchModel(repo.db).UpdateFields(ctx, kv, query.Builder().Where(model.FieldChatBatchId, batchID))
+	return err
+}
+
+// CancelBatch 取消批次，只有等待中或处理中的批次可以取消
+func (repo *BatchRepo) CancelBatch(ctx context.Context, userID, batchID int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		affected, err := model.NewChatBatchModel(tx).UpdateFields(
+			ctx,
+			query.KV{model.FieldChatBatchStatus: BatchStatusCancelled, model.FieldChatBatchCompletedAt: time.Now()},
+			query.Builder().
+				Where(model.FieldChatBatchId, batchID).
+				Where(model.FieldChatBatchUserId, userID).
+				WhereIn(model.FieldChatBatchStatus, BatchStatusPending, BatchStatusRunning),
+		)
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return ErrNotFound
+		}
+
+		_, err = model.NewChatBatchItemModel(tx).UpdateFields(
+			ctx,
+			query.KV{model.FieldChatBatchItemStatus: BatchStatusCancelled},
+			query.Builder().
+				Where(model.FieldChatBatchItemBatchId, batchID).
+				Where(model.FieldChatBatchItemStatus, BatchStatusPending),
+		)
+		return err
+	})
+}
+
+// BatchItemResult 批次条目处理结果
+type BatchItemResult struct {
+	Status       int64
+	Output       string
+	InputTokens  int64
+	OutputTokens int64
+	Coins        int64
+	Error        string
+}
+
+// UpdateBatchItemResult 更新批次条目的处理结果，同时累加批次的统计数据
+func (repo *BatchRepo) UpdateBatchItemResult(ctx context.Context, batchID, itemID int64, res BatchItemResult) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewChatBatchItemModel(tx).UpdateFields(
+			ctx,
+			query.KV{
+				model.FieldChatBatchItemStatus:       res.Status,
+				model.FieldChatBatchItemOutput:       null.StringFrom(res.Output),
+				model.FieldChatBatchItemInputTokens:  res.InputTokens,
+				model.FieldChatBatchItemOutputTokens: res.OutputTokens,
+				model.FieldChatBatchItemCoins:        res.Coins,
+				model.FieldChatBatchItemError:        misc.SubString(res.Error, 255),
+			},
+			query.Builder().Where(model.FieldChatBatchItemId, itemID),
+		); err != nil {
+			return err
+		}
+
+		if res.Status == BatchStatusSucceed {
+			_, err := tx.ExecContext(ctx, "UPDATE chat_batch SET succeed = succeed + 1, coins = coins + ? WHERE id = ?", res.Coins, batchID)
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "UPDATE chat_batch SET failed = failed + 1 WHERE id = ?", batchID)
+		return err
+	})
+}
@@ -0,0 +1,366 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// ErrBetaCohortFull 内测分组成员数量已达上限
+var ErrBetaCohortFull = errors.New("beta cohort is full")
+
+// 内测分组中可以分阶段开放的功能
+const (
+	BetaFeatureStudy           = "study"
+	BetaFeatureTutor           = "tutor"
+	BetaFeatureMockInterview   = "mock-interview"
+	BetaFeatureWritingTools    = "writing-tools"
+	BetaFeatureModelComparison = "model-comparison"
+)
+
+// BetaFeatures 内测分组中可以分阶段开放的功能
+var BetaFeatures = []string{BetaFeatureStudy, BetaFeatureTutor, BetaFeatureMockInterview, BetaFeatureWritingTools, BetaFeatureModelComparison}
+
+// 内测分组状态
+const (
+	BetaCohortStatusEnabled  = 1
+	BetaCohortStatusDisabled = 2
+)
+
+// 内测分组成员的加入方式
+const (
+	BetaMemberSourceSelf  = "self"
+	BetaMemberSourceAdmin = "admin"
+)
+
+// BetaCohort 内测分组，分组启用时，分组中的功能与模型只对分组成员开放
+type BetaCohort struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Features 分组成员可以使用的内测功能，可选值为 BetaFeatures
+	Features []string `json:"features"`
+	// Models 分组成员可以使用的内测模型，模型 ID 格式与模型列表相同，例如 openai:gpt-4
+	Models []string `json:"models"`
+	// OpenEnroll 是否允许用户自行加入，否则只能由管理员添加
+	OpenEnroll bool `json:"open_enroll"`
+	// Capacity 成员数量上限，0 表示不限制
+	Capacity int64 `json:"capacity"`
+	Status   int64 `json:"status"`
+}
+
+// BetaMember 内测分组成员
+type BetaMember struct {
+	CohortID  int64     `json:"cohort_id"`
+	UserID    int64     `json:"user_id"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BetaFeedback 内测分组成员提交的反馈
+type BetaFeedback struct {
+	ID       int64 `json:"id"`
+	CohortID int64 `json:"cohort_id"`
+	UserID   int64 `json:"user_id"`
+	// Feature 反馈的内测功能或者模型，为空时表示对分组的整体反馈
+	Feature string `json:"feature,omitempty"`
+	// Rating 评分 1-5，0 表示未评分
+	Rating        int64     `json:"rating"`
+	Content       string    `json:"content"`
+	Platform      string    `json:"platform,omitempty"`
+	ClientVersion string    `json:"client_version,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BetaRepo 内测计划：内测分组、分组成员以及成员反馈
+type BetaRepo struct {
+	db *sql.DB
+}
+
+// NewBetaRepo create a new BetaRepo
+func NewBetaRepo(db *sql.DB) *BetaRepo {
+	return &BetaRepo{db: db}
+}
+
+// Cohorts 查询内测分组，onlyEnabled 为 true 时只返回启用的分组
+func (repo *BetaRepo) Cohorts(ctx context.Context, onlyEnabled bool) ([]BetaCohort, error) {
+	q := query.Builder().OrderBy(model.FieldBetaCohortId, "ASC")
+	if onlyEnabled {
+		q = q.Where(model.FieldBetaCohortStatus, BetaCohortStatusEnabled)
+	}
+
+	items, err := model.NewBetaCohortModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts := make([]BetaCohort, 0, len(items))
+	for _, item := range items {
+		cohort := BetaCohort{
+			ID:          item.Id.ValueOrZero(),
+			Name:        item.Name.ValueOrZero(),
+			Description: item.Description.ValueOrZero(),
+			Features:    []string{},
+			Models:      []string{},
+			OpenEnroll:  item.OpenEnroll.ValueOrZero() == 1,
+			Capacity:    item.Capacity.ValueOrZero(),
+			Status:      item.Status.ValueOrZero(),
+		}
+
+		if v := item.Features.ValueOrZero(); v != "" {
+			if err := json.Unmarshal([]byte(v), &cohort.Features); err != nil {
+				return nil, fmt.Errorf("unmarshal features of beta cohort %d failed: %w", cohort.ID, err)
+			}
+		}
+
+		if v := item.Models.ValueOrZero(); v != "" {
+			if err := json.Unmarshal([]byte(v), &cohort.Models); err != nil {
+				return nil, fmt.Errorf("unmarshal models of beta cohort %d failed: %w", cohort.ID, err)
+			}
+		}
+
+		cohorts = append(cohorts, cohort)
+	}
+
+	return cohorts, nil
+}
+
+func betaCohortKV(cohort BetaCohort) query.KV {
+	features, _ := json.Marshal(ternary.If(cohort.Features == nil, []string{}, cohort.Features))
+	models, _ := json.Marshal(ternary.If(cohort.Models == nil, []string{}, cohort.Models))
+	return query.KV{
+		model.FieldBetaCohortName:        cohort.Name,
+		model.FieldBetaCohortDescription: cohort.Description,
+		model.FieldBetaCohortFeatures:    string(features),
+		model.FieldBetaCohortModels:      string(models),
+		model.FieldBetaCohortOpenEnroll:  ternary.If(cohort.OpenEnroll, 1, 0),
+		model.FieldBetaCohortCapacity:    cohort.Capacity,
+		model.FieldBetaCohortStatus:      cohort.Status,
+	}
+}
+
+// CreateCohort 新增内测分组
+func (repo *BetaRepo) CreateCohort(ctx context.Context, cohort BetaCohort) (int64, error) {
+	return model.NewBetaCohortModel(repo.db).Create(ctx, betaCohortKV(cohort))
+}
+
+// UpdateCohort 更新内测分组
+func (repo *BetaRepo) UpdateCohort(ctx context.Context, id int64, cohort BetaCohort) error {
+	_, err := model.NewBetaCohortModel(repo.db).UpdateFields(ctx, betaCohortKV(cohort), query.Builder().Where(model.FieldBetaCohortId, id))
+	return err
+}
+
+// DeleteCohort 删除内测分组以及分组成员，成员提交的反馈保留
+func (repo *BetaRepo) DeleteCohort(ctx context.Context, id int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewBetaCohortModel(tx).Delete(ctx, query.Builder().Where(model.FieldBetaCohortId, id)); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "DELETE FROM beta_member WHERE cohort_id = ?", id)
+		return err
+	})
+}
+
+// CohortIDs 用户加入的内测分组 ID
+func (repo *BetaRepo) CohortIDs(ctx context.Context, userID int64) ([]int64, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT cohort_id FROM beta_member WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		res = append(res, id)
+	}
+
+	return res, rows.Err()
+}
+
+// Join 用户加入内测分组，已经是分组成员时直接返回；capacity 大于 0 时成员数量达到上限返回 ErrBetaCohortFull
+func (repo *BetaRepo) Join(ctx context.Context, cohortID, userID int64, source string, capacity int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		// 锁定分组，避免并发加入时超出成员数量上限
+		rows, err := tx.QueryContext(ctx, "SELECT id FROM beta_cohort WHERE id = ? FOR UPDATE", cohortID)
+		if err != nil {
+			return err
+		}
+		found := rows.Next()
+		if err := rows.Close(); err != nil {
+			return err
+		}
+
+		if !found {
+			return ErrNotFound
+		}
+
+		rows, err = tx.QueryContext(ctx, "SELECT COUNT(*), COALESCE(SUM(user_id = ?), 0) FROM beta_member WHERE cohort_id = ?", userID, cohortID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var count, joined int64
+		if rows.Next() {
+			if err := rows.Scan(&count, &joined); err != nil {
+				return err
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if joined > 0 {
+			return nil
+		}
+
+		if capacity > 0 && count >= capacity {
+			return ErrBetaCohortFull
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT IGNORE INTO beta_member (cohort_id, user_id, source, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
+			cohortID, userID, source, time.Now(), time.Now(),
+		)
+		return err
+	})
+}
+
+// Leave 用户退出内测分组
+func (repo *BetaRepo) Leave(ctx context.Context, cohortID, userID int64) error {
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM beta_member WHERE cohort_id = ? AND user_id = ?", cohortID, userID)
+	return err
+}
+
+// Members 内测分组的成员，最近加入的在前
+func (repo *BetaRepo) Members(ctx context.Context, cohortID int64) ([]BetaMember, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT cohort_id, user_id, source, created_at FROM beta_member WHERE cohort_id = ? ORDER BY id DESC", cohortID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]BetaMember, 0)
+	for rows.Next() {
+		var item BetaMember
+		if err := rows.Scan(&item.CohortID, &item.UserID, &item.Source, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
+
+// MemberCounts 各个内测分组的成员数量
+func (repo *BetaRepo) MemberCounts(ctx context.Context) (map[int64]int64, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT cohort_id, COUNT(*) FROM beta_member GROUP BY cohort_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int64]int64)
+	for rows.Next() {
+		var id, count int64
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+
+		res[id] = count
+	}
+
+	return res, rows.Err()
+}
+
+// CreateFeedback 保存内测分组成员提交的反馈
+func (repo *BetaRepo) CreateFeedback(ctx context.Context, feedback BetaFeedback) (int64, error) {
+	return model.NewBetaFeedbackModel(repo.db).Create(ctx, query.KV{
+		model.FieldBetaFeedbackCohortId:      feedback.CohortID,
+		model.FieldBetaFeedbackUserId:        feedback.UserID,
+		model.FieldBetaFeedbackFeature:       feedback.Feature,
+		model.FieldBetaFeedbackRating:        feedback.Rating,
+		model.FieldBetaFeedbackContent:       feedback.Content,
+		model.FieldBetaFeedbackPlatform:      feedback.Platform,
+		model.FieldBetaFeedbackClientVersion: feedback.ClientVersion,
+	})
+}
+
+// Feedbacks 查询内测分组的反馈，feature 不为空时只查询该功能的反馈，最新的在前
+func (repo *BetaRepo) Feedbacks(ctx context.Context, cohortID int64, feature string, page, perPage int64) ([]BetaFeedback, query.PaginateMeta, error) {
+	q := query.Builder().Where(model.FieldBetaFeedbackCohortId, cohortID).OrderBy(model.FieldBetaFeedbackId, "DESC")
+	if feature != "" {
+		q = q.Where(model.FieldBetaFeedbackFeature, feature)
+	}
+
+	items, meta, err := model.NewBetaFeedbackModel(repo.db).Paginate(ctx, page, perPage, q)
+	if err != nil {
+		return nil, query.PaginateMeta{}, err
+	}
+
+	res := make([]BetaFeedback, 0, len(items))
+	for _, item := range items {
+		res = append(res, BetaFeedback{
+			ID:            item.Id.ValueOrZero(),
+			CohortID:      item.CohortId.ValueOrZero(),
+			UserID:        item.UserId.ValueOrZero(),
+			Feature:       item.Feature.ValueOrZero(),
+			Rating:        item.Rating.ValueOrZero(),
+			Content:       item.Content.ValueOrZero(),
+			Platform:      item.Platform.ValueOrZero(),
+			ClientVersion: item.ClientVersion.ValueOrZero(),
+			CreatedAt:     item.CreatedAt.ValueOrZero(),
+		})
+	}
+
+	return res, meta, nil
+}
+
+// BetaFeedbackStat 内测功能的反馈统计
+type BetaFeedbackStat struct {
+	Feature string  `json:"feature"`
+	Count   int64   `json:"count"`
+	Rating  float64 `json:"rating"`
+}
+
+// FeedbackStats 内测分组各个功能的反馈数量以及平均评分（不含未评分的反馈）
+func (repo *BetaRepo) FeedbackStats(ctx context.Context, cohortID int64) ([]BetaFeedbackStat, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT feature, COUNT(*), COALESCE(AVG(NULLIF(rating, 0)), 0) FROM beta_feedback WHERE cohort_id = ? GROUP BY feature ORDER BY COUNT(*) DESC",
+		cohortID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]BetaFeedbackStat, 0)
+	for rows.Next() {
+		var item BetaFeedbackStat
+		if err := rows.Scan(&item.Feature, &item.Count, &item.Rating); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
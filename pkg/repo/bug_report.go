@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// SupportTicketAttachmentBugReport 工单关联的问题报告
+const SupportTicketAttachmentBugReport = "bug_report"
+
+// BugReportRequest 问题报告中客户端上报的请求，以及服务端关联到的请求记录与异步任务
+type BugReportRequest struct {
+	RequestID string `json:"request_id"`
+	// Found 服务端是否找到了该请求的记录，请求记录只保留一段时间
+	Found     bool            `json:"found"`
+	Method    string          `json:"method,omitempty"`
+	Route     string          `json:"route,omitempty"`
+	Code      int             `json:"code,omitempty"`
+	Elapse    int64           `json:"elapse,omitempty"`
+	Model     string          `json:"model,omitempty"`
+	CreatedAt *time.Time      `json:"created_at,omitempty"`
+	Tasks     []BugReportTask `json:"tasks,omitempty"`
+}
+
+// BugReportTask 请求创建的异步任务及其处理步骤
+type BugReportTask struct {
+	TaskID    string         `json:"task_id"`
+	TaskType  string         `json:"task_type"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	Logs      []QueueTaskLog `json:"logs,omitempty"`
+}
+
+// BugReport 客户端出错时提交的问题报告
+type BugReport struct {
+	ID              int64              `json:"id"`
+	UserID          int64              `json:"user_id"`
+	AppVersion      string             `json:"app_version"`
+	Platform        string             `json:"platform"`
+	PlatformVersion string             `json:"platform_version"`
+	Device          map[string]string  `json:"device,omitempty"`
+	ClientError     string             `json:"client_error,omitempty"`
+	RequestIDs      []string           `json:"request_ids"`
+	Requests        []BugReportRequest `json:"requests"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// BugReportRepo 问题报告
+type BugReportRepo struct {
+	db *sql.DB
+}
+
+// NewBugReportRepo create a new BugReportRepo
+func NewBugReportRepo(db *sql.DB) *BugReportRepo {
+	return &BugReportRepo{db: db}
+}
+
+// CreateReport 保存问题报告
+func (repo *BugReportRepo) CreateReport(ctx context.Context, report BugReport) (int64, error) {
+	device, _ := json.Marshal(report.Device)
+	requestIDs, _ := json.Marshal(report.RequestIDs)
+	correlated, _ := json.Marshal(report.Requests)
+
+	return model.NewBugReportModel(repo.db).Create(ctx, query.KV{
+		model.FieldBugReportUserId:          report.UserID,
+		model.FieldBugReportAppVersion:      report.AppVersion,
+		model.FieldBugReportPlatform:        report.Platform,
+		model.FieldBugReportPlatformVersion: report.PlatformVersion,
+		model.FieldBugReportDevice:          string(device),
+		model.FieldBugReportClientError:     report.ClientError,
+		model.FieldBugReportRequestIds:      string(requestIDs),
+		model.FieldBugReportCorrelated:      string(correlated),
+	})
+}
+
+// Report 查询问题报告，不存在时返回 ErrNotFound
+func (repo *BugReportRepo) Report(ctx context.Context, id int64) (*BugReport, error) {
+	item, err := model.NewBugReportModel(repo.db).First(ctx, query.Builder().Where(model.FieldBugReportId, id))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	report := BugReport{
+		ID:              item.Id.ValueOrZero(),
+		UserID:          item.UserId.ValueOrZero(),
+		AppVersion:      item.AppVersion.ValueOrZero(),
+		Platform:        item.Platform.ValueOrZero(),
+		PlatformVersion: item.PlatformVersion.ValueOrZero(),
+		ClientError:     item.ClientError.ValueOrZero(),
+		CreatedAt:       item.CreatedAt.ValueOrZero(),
+	}
+
+	_ = json.Unmarshal([]byte(item.Device.ValueOrZero()), &report.Device)
+	_ = json.Unmarshal([]byte(item.RequestIds.ValueOrZero()), &report.RequestIDs)
+	_ = json.Unmarshal([]byte(item.Correlated.ValueOrZero()), &report.Requests)
+
+	return &report, nil
+}
@@ -0,0 +1,200 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+)
+
+// 自定义渠道状态
+const (
+	ChannelStatusEnabled  = 1
+	ChannelStatusDisabled = 2
+)
+
+// ChannelTypeOpenAI OpenAI 兼容接口
+const ChannelTypeOpenAI = "openai"
+
+// Channel 管理员自定义的上游渠道
+type Channel struct {
+	ID     int64          `json:"id"`
+	Name   string         `json:"name"`
+	Type   string         `json:"type"`
+	Server string         `json:"server"`
+	Secret string         `json:"secret,omitempty"`
+	Models []ChannelModel `json:"models"`
+	Status int64          `json:"status"`
+	// VerifiedAt 渠道通过测试验证的时间，未验证的渠道不会进入模型路由
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// Verified 渠道是否已通过测试验证
+func (ch Channel) Verified() bool {
+	return ch.VerifiedAt != nil
+}
+
+// ChannelModel 渠道提供的模型
+type ChannelModel struct {
+	// ID 模型在本系统中的 ID，客户端使用该 ID 发起请求
+	ID string `json:"id"`
+	// RealModel 上游接口实际使用的模型名称，为空时与 ID 相同
+	RealModel   string `json:"real_model,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+	// Price 每 1K Token 消耗的智慧果数量
+	Price int64 `json:"price"`
+	// UpstreamPrice 上游渠道的价格，用于计算上游成本
+	UpstreamPrice *coins.UpstreamPrice `json:"upstream_price,omitempty"`
+	// MaxContext 最大上下文长度（Token），为 0 时使用默认值
+	MaxContext    int  `json:"max_context,omitempty"`
+	SupportVision bool `json:"support_vision,omitempty"`
+	// Pinned 手动固定路由，多个渠道提供同一模型时总是使用固定的渠道
+	Pinned bool `json:"pinned,omitempty"`
+	// KeepAlive 保持模型加载，自托管模型（Ollama、vLLM 等）空闲一段时间后会被卸载，开启后定时发送预热请求
+	KeepAlive bool `json:"keep_alive,omitempty"`
+	// LoadTimeout 模型未加载时等待加载完成的最长时间（秒），为 0 时使用默认值
+	LoadTimeout int `json:"load_timeout,omitempty"`
+}
+
+// UpstreamModel 上游接口实际使用的模型名称
+func (m ChannelModel) UpstreamModel() string {
+	if m.RealModel != "" {
+		return m.RealModel
+	}
+
+	return m.ID
+}
+
+// ChannelRepo 自定义渠道
+type ChannelRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewChannelRepo create a new ChannelRepo
+func NewChannelRepo(db *sql.DB, conf *config.Config) *ChannelRepo {
+	return &ChannelRepo{db: db, conf: conf}
+}
+
+func channelFromModel(item model.ChannelN) (Channel, error) {
+	ch := Channel{
+		ID:     item.Id.ValueOrZero(),
+		Name:   item.Name.ValueOrZero(),
+		Type:   item.Type.ValueOrZero(),
+		Server: item.Server.ValueOrZero(),
+		Secret: item.Secret.ValueOrZero(),
+		Models: []ChannelModel{},
+		Status: item.Status.ValueOrZero(),
+	}
+
+	if item.VerifiedAt.Valid {
+		verifiedAt := item.VerifiedAt.Time
+		ch.VerifiedAt = &verifiedAt
+	}
+
+	if v := item.Models.ValueOrZero(); v != "" {
+		if err := json.Unmarshal([]byte(v), &ch.Models); err != nil {
+			return ch, fmt.Errorf("unmarshal models of channel %d failed: %w", ch.ID, err)
+		}
+	}
+
+	return ch, nil
+}
+
+// Channels 查询自定义渠道，enabledOnly 为 true 时只返回启用的渠道
+func (repo *ChannelRepo) Channels(ctx context.Context, enabledOnly bool) ([]Channel, error) {
+	q := query.Builder().OrderBy(model.FieldChannelId, "ASC")
+	if enabledOnly {
+		q = q.Where(model.FieldChannelStatus, ChannelStatusEnabled)
+	}
+
+	items, err := model.NewChannelModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]Channel, 0, len(items))
+	for _, item := range items {
+		ch, err := channelFromModel(item)
+		if err != nil {
+			return nil, err
+		}
+
+		channels = append(channels, ch)
+	}
+
+	return channels, nil
+}
+
+// Channel 查询自定义渠道
+func (repo *ChannelRepo) Channel(ctx context.Context, id int64) (*Channel, error) {
+	item, err := model.NewChannelModel(repo.db).First(ctx, query.Builder().Where(model.FieldChannelId, id))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ch, err := channelFromModel(*item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ch, nil
+}
+
+func channelKV(ch Channel) query.KV {
+	models, _ := json.Marshal(ch.Models)
+	return query.KV{
+		model.FieldChannelName:   ch.Name,
+		model.FieldChannelType:   ch.Type,
+		model.FieldChannelServer: ch.Server,
+		model.FieldChannelSecret: ch.Secret,
+		model.FieldChannelModels: string(models),
+		model.FieldChannelStatus: ch.Status,
+	}
+}
+
+// CreateChannel 新增自定义渠道
+func (repo *ChannelRepo) CreateChannel(ctx context.Context, ch Channel) (int64, error) {
+	return model.NewChannelModel(repo.db).Create(ctx, channelKV(ch))
+}
+
+// UpdateChannel 更新自定义渠道
+func (repo *ChannelRepo) UpdateChannel(ctx context.Context, id int64, ch Channel) error {
+	_, err := model.NewChannelModel(repo.db).UpdateFields(ctx, channelKV(ch), query.Builder().Where(model.FieldChannelId, id))
+	return err
+}
+
+// SetVerified 设置渠道的验证状态
+func (repo *ChannelRepo) SetVerified(ctx context.Context, id int64, verified bool) error {
+	verifiedAt := null.Time{}
+	if verified {
+		verifiedAt = null.TimeFrom(time.Now())
+	}
+
+	_, err := model.NewChannelModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldChannelVerifiedAt: verifiedAt},
+		query.Builder().Where(model.FieldChannelId, id),
+	)
+	return err
+}
+
+// DeleteChannel 删除自定义渠道
+func (repo *ChannelRepo) DeleteChannel(ctx context.Context, id int64) error {
+	_, err := model.NewChannelModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldChannelId, id))
+	return err
+}
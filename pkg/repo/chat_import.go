@@ -0,0 +1,305 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/chatimport"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/msgcrypt"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// 聊天记录导入状态，导入任务与会话共用
+const (
+	ChatImportStatusPending int64 = 1
+	ChatImportStatusRunning int64 = 2
+	ChatImportStatusSucceed int64 = 3
+	ChatImportStatusFailed  int64 = 4
+	// ChatImportStatusSkipped 会话已经导入过，并且没有新的消息
+	ChatImportStatusSkipped int64 = 5
+)
+
+type ChatImportRepo struct {
+	db     *sql.DB
+	conf   *config.Config
+	cipher *msgcrypt.Cipher
+}
+
+// NewChatImportRepo create a new ChatImportRepo
+func NewChatImportRepo(db *sql.DB, conf *config.Config, cipher *msgcrypt.Cipher) *ChatImportRepo {
+	return &ChatImportRepo{db: db, conf: conf, cipher: cipher}
+}
+
+// CreateImport 创建聊天记录导入任务，每个会话保存为一个待导入的条目
+func (repo *ChatImportRepo) CreateImport(ctx context.Context, userID int64, source string, conversations []chatimport.Conversation) (int64, error) {
+	var importID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewChatImportModel(tx).Create(ctx, query.KV{
+			model.FieldChatImportUserId: userID,
+			model.FieldChatImportSource: source,
+			model.FieldChatImportStatus: ChatImportStatusPending,
+			model.FieldChatImportTotal:  len(conversations),
+		})
+		if err != nil {
+			return fmt.Errorf("create chat import failed: %w", err)
+		}
+
+		importID = id
+
+		for _, conv := range conversations {
+			data, err := json.Marshal(conv)
+			if err != nil {
+				return err
+			}
+
+			if _, err := model.NewChatImportItemModel(tx).Create(ctx, query.KV{
+				model.FieldChatImportItemImportId:     importID,
+				model.FieldChatImportItemUserId:       userID,
+				model.FieldChatImportItemSource:       source,
+				model.FieldChatImportItemExternalId:   misc.SubString(conv.ID, 100),
+				model.FieldChatImportItemTitle:        misc.SubString(conv.Title, 255),
+				model.FieldChatImportItemConversation: string(data),
+				model.FieldChatImportItemStatus:       ChatImportStatusPending,
+			}); err != nil {
+				return fmt.Errorf("create chat import item failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return importID, err
+}
+
+// UpdateImportTaskID 更新导入任务关联的队列任务 ID
+func (repo *ChatImportRepo) UpdateImportTaskID(ctx context.Context, importID int64, taskID string) error {
+	_, err := model.NewChatImportModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldChatImportTaskId: taskID},
+		query.Builder().Where(model.FieldChatImportId, importID),
+	)
+	return err
+}
+
+// GetImport 获取导入任务，userID 为 0 时不校验所属用户
+func (repo *ChatImportRepo) GetImport(ctx context.Context, userID, importID int64) (*model.ChatImport, error) {
+	q := query.Builder().Where(model.FieldChatImportId, importID)
+	if userID > 0 {
+		q = q.Where(model.FieldChatImportUserId, userID)
+	}
+
+	item, err := model.NewChatImportModel(repo.db).First(ctx, q)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := item.ToChatImport()
+	return &ret, nil
+}
+
+// GetImports 获取用户最近的导入任务
+func (repo *ChatImportRepo) GetImports(ctx context.Context, userID int64, limit int64) ([]model.ChatImport, error) {
+	q := query.Builder().
+		Where(model.FieldChatImportUserId, userID).
+		OrderBy(model.FieldChatImportId, "DESC").
+		Limit(limit)
+
+	items, err := model.NewChatImportModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.ChatImportN, _ int) model.ChatImport {
+		return item.ToChatImport()
+	}), nil
+}
+
+// UpdateImportStatus 更新导入任务状态
+func (repo *ChatImportRepo) UpdateImportStatus(ctx context.Context, importID int64, status int64) error {
+	kv := query.KV{model.FieldChatImportStatus: status}
+	if status == ChatImportStatusSucceed || status == ChatImportStatusFailed {
+		kv[model.FieldChatImportCompletedAt] = time.Now()
+	}
+
+	_, err := model.NewChatImportModel(repo.db).UpdateFields(ctx, kv, query.Builder().Where(model.FieldChatImportId, importID))
+	return err
+}
+
+// GetImportItems 获取导入任务中待导入的会话
+func (repo *ChatImportRepo) GetImportItems(ctx context.Context, importID int64) ([]model.ChatImportItem, error) {
+	q := query.Builder().
+		Where(model.FieldChatImportItemImportId, importID).
+		OrderBy(model.FieldChatImportItemId, "ASC")
+
+	items, err := model.NewChatImportItemModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.ChatImportItemN, _ int) model.ChatImportItem {
+		return item.ToChatImportItem()
+	}), nil
+}
+
+// ImportedConversation 查询会话最近一次导入的结果，用于重复导入时去重，会话没有导入过或者导入的聊天室已被删除时返回 nil
+func (repo *ChatImportRepo) ImportedConversation(ctx context.Context, userID int64, source, externalID string) (*model.ChatImportItem, error) {
+	q := query.Builder().
+		Where(model.FieldChatImportItemUserId, userID).
+		Where(model.FieldChatImportItemSource, source).
+		Where(model.FieldChatImportItemExternalId, misc.SubString(externalID, 100)).
+		WhereIn(model.FieldChatImportItemStatus, ChatImportStatusSucceed, ChatImportStatusSkipped).
+		Where(model.FieldChatImportItemRoomId, ">", 0).
+		OrderBy(model.FieldChatImportItemId, "DESC")
+
+	item, err := model.NewChatImportItemModel(repo.db).First(ctx, q)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	exist, err := model.NewRoomsModel(repo.db).Exists(ctx, query.Builder().
+		Where(model.FieldRoomsId, item.RoomId.ValueOrZero()).
+		Where(model.FieldRoomsUserId, userID))
+	if err != nil {
+		return nil, err
+	}
+
+	if !exist {
+		return nil, nil
+	}
+
+	ret := item.ToChatImportItem()
+	return &ret, nil
+}
+
+// SaveImportedConversation 保存导入的会话，roomID 为 0 时创建新的聊天室，否则只追加 skip 之后的消息
+func (repo *ChatImportRepo) SaveImportedConversation(ctx context.Context, userID, roomID int64, roomModel string, conv chatimport.Conversation, skip int) (int64, error) {
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		lastActive := conv.UpdatedAt
+		if lastActive.IsZero() {
+			lastActive = time.Now()
+		}
+
+		if roomID == 0 {
+			id, err := model.NewRoomsModel(tx).Create(ctx, query.KV{
+				model.FieldRoomsUserId:         userID,
+				model.FieldRoomsName:           misc.SubString(strings.TrimSpace(conv.Title), 50),
+				model.FieldRoomsModel:          roomModel,
+				model.FieldRoomsVendor:         "openai",
+				model.FieldRoomsMaxContext:     5,
+				model.FieldRoomsRoomType:       RoomTypeCustom,
+				model.FieldRoomsLastActiveTime: lastActive,
+			})
+			if err != nil {
+				return fmt.Errorf("create room failed: %w", err)
+			}
+
+			roomID = id
+		}
+
+		var questionID int64
+		var lastQuestion string
+		for _, msg := range conv.Messages[skip:] {
+			content, err := repo.cipher.Encrypt(ctx, msg.Content)
+			if err != nil {
+				return fmt.Errorf("encrypt message failed: %w", err)
+			}
+
+			kv := query.KV{
+				model.FieldChatMessagesUserId:  userID,
+				model.FieldChatMessagesRoomId:  roomID,
+				model.FieldChatMessagesMessage: content,
+				model.FieldChatMessagesModel:   roomModel,
+				model.FieldChatMessagesStatus:  MessageStatusSucceed,
+			}
+
+			if !msg.CreatedAt.IsZero() {
+				kv[model.FieldChatMessagesCreatedAt] = msg.CreatedAt
+			}
+
+			if msg.Role == chatimport.RoleUser {
+				kv[model.FieldChatMessagesRole] = MessageRoleUser
+			} else {
+				kv[model.FieldChatMessagesRole] = MessageRoleAssistant
+				if questionID > 0 {
+					kv[model.FieldChatMessagesPid] = questionID
+				}
+			}
+
+			id, err := model.NewChatMessagesModel(tx).Create(ctx, kv)
+			if err != nil {
+				return fmt.Errorf("create message failed: %w", err)
+			}
+
+			if msg.Role == chatimport.RoleUser {
+				questionID, lastQuestion = id, msg.Content
+			}
+		}
+
+		kv := query.KV{model.FieldRoomsLastActiveTime: lastActive}
+		if lastQuestion != "" && !repo.cipher.Enabled() {
+			kv[model.FieldRoomsDescription] = misc.SubString(lastQuestion, 70)
+		}
+
+		_, err := model.NewRoomsModel(tx).UpdateFields(ctx, kv, query.Builder().
+			Where(model.FieldRoomsId, roomID).
+			Where(model.FieldRoomsUserId, userID))
+		return err
+	})
+
+	return roomID, err
+}
+
+// ChatImportItemResult 单个会话的导入结果
+type ChatImportItemResult struct {
+	Status       int64
+	RoomID       int64
+	MessageCount int64
+	Error        string
+}
+
+// UpdateImportItemResult 更新会话的导入结果，同时累加导入任务的进度
+func (repo *ChatImportRepo) UpdateImportItemResult(ctx context.Context, importID, itemID int64, res ChatImportItemResult) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewChatImportItemModel(tx).UpdateFields(
+			ctx,
+			query.KV{
+				model.FieldChatImportItemStatus:       res.Status,
+				model.FieldChatImportItemRoomId:       res.RoomID,
+				model.FieldChatImportItemMessageCount: res.MessageCount,
+				model.FieldChatImportItemError:        misc.SubString(res.Error, 255),
+			},
+			query.Builder().Where(model.FieldChatImportItemId, itemID),
+		); err != nil {
+			return err
+		}
+
+		field := "failed"
+		switch res.Status {
+		case ChatImportStatusSucceed:
+			field = "imported"
+		case ChatImportStatusSkipped:
+			field = "skipped"
+		}
+
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE chat_import SET %s = %s + 1 WHERE id = ?", field, field), importID)
+		return err
+	})
+}
@@ -0,0 +1,226 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+var (
+	ErrAlreadyCheckedIn = errors.New("already checked in today")
+)
+
+// CheckInQuotaTag 签到奖励的配额记录标识，记录在配额的 payment_id 字段中
+const CheckInQuotaTag = "check-in"
+
+// CheckInReward 连续签到奖励
+type CheckInReward struct {
+	StreakDay int64 `json:"streak_day"`
+	Coins     int64 `json:"coins"`
+}
+
+// DefaultCheckInRewards 数据库中没有配置奖励曲线时使用的默认奖励曲线
+var DefaultCheckInRewards = []CheckInReward{
+	{StreakDay: 1, Coins: 5},
+	{StreakDay: 2, Coins: 5},
+	{StreakDay: 3, Coins: 10},
+	{StreakDay: 4, Coins: 10},
+	{StreakDay: 5, Coins: 15},
+	{StreakDay: 6, Coins: 15},
+	{StreakDay: 7, Coins: 30},
+}
+
+// CheckInRewardForStreak 根据奖励曲线计算连续签到第 streak 天的奖励，
+// 奖励曲线中没有对应天数时，使用不超过该天数的最大天数的奖励
+func CheckInRewardForStreak(rewards []CheckInReward, streak int64) int64 {
+	sorted := make([]CheckInReward, len(rewards))
+	copy(sorted, rewards)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StreakDay < sorted[j].StreakDay })
+
+	var coins int64
+	for _, r := range sorted {
+		if r.StreakDay > streak {
+			break
+		}
+
+		coins = r.Coins
+	}
+
+	return coins
+}
+
+type CheckInRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewCheckInRepo create a new CheckInRepo
+func NewCheckInRepo(db *sql.DB, conf *config.Config) *CheckInRepo {
+	return &CheckInRepo{db: db, conf: conf}
+}
+
+// Rewards 查询签到奖励曲线，数据库中没有配置时返回默认奖励曲线
+func (repo *CheckInRepo) Rewards(ctx context.Context) ([]CheckInReward, error) {
+	items, err := model.NewCheckInRewardModel(repo.db).Get(ctx, query.Builder().OrderBy(model.FieldCheckInRewardStreakDay, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return DefaultCheckInRewards, nil
+	}
+
+	return array.Map(items, func(item model.CheckInRewardN, _ int) CheckInReward {
+		return CheckInReward{StreakDay: item.StreakDay.ValueOrZero(), Coins: item.Coins.ValueOrZero()}
+	}), nil
+}
+
+// UpdateRewards 替换签到奖励曲线
+func (repo *CheckInRepo) UpdateRewards(ctx context.Context, rewards []CheckInReward) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewCheckInRewardModel(tx).Delete(ctx, query.Builder()); err != nil {
+			return err
+		}
+
+		for _, r := range rewards {
+			if _, err := model.NewCheckInRewardModel(tx).Create(ctx, query.KV{
+				model.FieldCheckInRewardStreakDay: r.StreakDay,
+				model.FieldCheckInRewardCoins:     r.Coins,
+			}); err != nil {
+				return fmt.Errorf("create check-in reward failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// LastCheckIn 查询用户最近一次签到记录，没有签到记录时返回 ErrNotFound
+func (repo *CheckInRepo) LastCheckIn(ctx context.Context, userID int64) (*model.UserCheckIn, error) {
+	item, err := model.NewUserCheckInModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldUserCheckInUserId, userID).
+		OrderBy(model.FieldUserCheckInCheckInDate, "DESC"),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := item.ToUserCheckIn()
+	return &ret, nil
+}
+
+// CheckIn 用户签到，同一个用户每天只能签到一次，签到记录与奖励的配额记录在同一个事务中创建
+func (repo *CheckInRepo) CheckIn(ctx context.Context, userID int64, now time.Time) (*model.UserCheckIn, error) {
+	rewards, err := repo.Rewards(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query check-in rewards failed: %w", err)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var result model.UserCheckIn
+	err = eloquent.Transaction(repo.db, func(tx query.Database) error {
+		// 昨天签到过则连续签到天数加 1，否则重新开始计算
+		var streak int64 = 1
+		last, err := model.NewUserCheckInModel(tx).First(ctx, query.Builder().
+			Where(model.FieldUserCheckInUserId, userID).
+			Where(model.FieldUserCheckInCheckInDate, today.AddDate(0, 0, -1).Format("2006-01-02")),
+		)
+		if err != nil && !errors.Is(err, query.ErrNoResult) {
+			return err
+		}
+
+		if err == nil {
+			streak = last.Streak.ValueOrZero() + 1
+		}
+
+		coins := CheckInRewardForStreak(rewards, streak)
+
+		res, err := tx.ExecContext(
+			ctx,
+			"INSERT IGNORE INTO user_check_in (user_id, check_in_date, streak, coins, quota_id, created_at, updated_at) VALUES (?, ?, ?, ?, 0, NOW(), NOW())",
+			userID, today.Format("2006-01-02"), streak, coins,
+		)
+		if err != nil {
+			return fmt.Errorf("create check-in failed: %w", err)
+		}
+
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return ErrAlreadyCheckedIn
+		}
+
+		checkInID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		var quotaID int64
+		if coins > 0 {
+			quota := model.Quota{
+				UserId:        userID,
+				Quota:         coins,
+				Rest:          coins,
+				Note:          fmt.Sprintf("每日签到（连续 %d 天）", streak),
+				PaymentId:     CheckInQuotaTag,
+				PeriodStartAt: NowInDate(),
+				PeriodEndAt:   TimeInDate(now.AddDate(0, 1, 0)),
+			}
+
+			quotaID, err = model.NewQuotaModel(tx).Save(ctx, quota.ToQuotaN(
+				model.FieldQuotaUserId,
+				model.FieldQuotaQuota,
+				model.FieldQuotaRest,
+				model.FieldQuotaNote,
+				model.FieldQuotaPaymentId,
+				model.FieldQuotaPeriodStartAt,
+				model.FieldQuotaPeriodEndAt,
+			))
+			if err != nil {
+				return fmt.Errorf("create check-in quota failed: %w", err)
+			}
+
+			if err := postLedger(ctx, tx, issueLedger(LedgerKindGift, LedgerAccountGift, LedgerAccountUserWallet, userID, coins, fmt.Sprintf("quota:%d", quotaID), quota.Note)); err != nil {
+				return err
+			}
+
+			if _, err := model.NewUserCheckInModel(tx).UpdateFields(
+				ctx,
+				query.KV{model.FieldUserCheckInQuotaId: quotaID},
+				query.Builder().Where(model.FieldUserCheckInId, checkInID),
+			); err != nil {
+				return err
+			}
+		}
+
+		result = model.UserCheckIn{
+			Id:          checkInID,
+			UserId:      userID,
+			CheckInDate: today,
+			Streak:      streak,
+			Coins:       coins,
+			QuotaId:     quotaID,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
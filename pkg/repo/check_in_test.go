@@ -0,0 +1,23 @@
+package repo_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestCheckInRewardForStreak(t *testing.T) {
+	rewards := []repo.CheckInReward{
+		{StreakDay: 7, Coins: 30},
+		{StreakDay: 1, Coins: 5},
+		{StreakDay: 3, Coins: 10},
+	}
+
+	assert.Equal(t, int64(0), repo.CheckInRewardForStreak(rewards, 0))
+	assert.Equal(t, int64(5), repo.CheckInRewardForStreak(rewards, 1))
+	assert.Equal(t, int64(5), repo.CheckInRewardForStreak(rewards, 2))
+	assert.Equal(t, int64(10), repo.CheckInRewardForStreak(rewards, 6))
+	assert.Equal(t, int64(30), repo.CheckInRewardForStreak(rewards, 100))
+	assert.Equal(t, int64(0), repo.CheckInRewardForStreak(nil, 3))
+}
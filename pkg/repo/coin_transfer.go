@@ -0,0 +1,320 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/guregu/null.v3"
+)
+
+var (
+	// ErrCoinTransferInvalid 转赠数量必须大于 0，并且不能转赠给自己
+	ErrCoinTransferInvalid = errors.New("invalid coin transfer")
+	// ErrCoinTransferBalanceNotEnough 可用的智慧果不足（转赠不允许产生欠费）
+	ErrCoinTransferBalanceNotEnough = errors.New("coin transfer balance not enough")
+	// ErrCoinTransferDailyLimit 超过每日转出额度
+	ErrCoinTransferDailyLimit = errors.New("coin transfer daily limit exceeded")
+	// ErrCoinTransferMonthlyLimit 超过每月转出额度
+	ErrCoinTransferMonthlyLimit = errors.New("coin transfer monthly limit exceeded")
+	// ErrCoinTransferRecipientLimit 超过每日转赠对象数量限制
+	ErrCoinTransferRecipientLimit = errors.New("coin transfer recipient limit exceeded")
+)
+
+// QuotaUsageTagCoinTransfer 转出智慧果时 quota_usage 记录的标签
+const QuotaUsageTagCoinTransfer = "coin-transfer"
+
+// CoinTransferLimits 转赠限制，值为 0 表示不限制
+type CoinTransferLimits struct {
+	Daily           int64 `json:"daily"`
+	Monthly         int64 `json:"monthly"`
+	DailyRecipients int64 `json:"daily_recipients"`
+}
+
+// CoinTransferUsage 用户已经转出的智慧果统计
+type CoinTransferUsage struct {
+	TodayAmount int64 `json:"today_amount"`
+	MonthAmount int64 `json:"month_amount"`
+	// TodayRecipients 今天已经转赠过的用户
+	TodayRecipients []int64 `json:"-"`
+}
+
+// Check 检查本次转赠是否超过限制
+func (limits CoinTransferLimits) Check(usage CoinTransferUsage, toUserID, amount int64) error {
+	if limits.Daily > 0 && usage.TodayAmount+amount > limits.Daily {
+		return ErrCoinTransferDailyLimit
+	}
+
+	if limits.Monthly > 0 && usage.MonthAmount+amount > limits.Monthly {
+		return ErrCoinTransferMonthlyLimit
+	}
+
+	if limits.DailyRecipients > 0 && !array.In(toUserID, usage.TodayRecipients) && int64(len(usage.TodayRecipients)) >= limits.DailyRecipients {
+		return ErrCoinTransferRecipientLimit
+	}
+
+	return nil
+}
+
+// CoinTransferRequest 转赠请求
+type CoinTransferRequest struct {
+	FromUserID int64
+	ToUserID   int64
+	Amount     int64
+	Note       string
+	ClientIP   string
+}
+
+// CoinTransfer 转赠记录
+type CoinTransfer struct {
+	ID         int64     `json:"id"`
+	FromUserID int64     `json:"from_user_id"`
+	ToUserID   int64     `json:"to_user_id"`
+	Amount     int64     `json:"amount"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CoinTransferRepo 用户之间转赠智慧果
+type CoinTransferRepo struct {
+	db *sql.DB
+}
+
+// NewCoinTransferRepo create a new CoinTransferRepo
+func NewCoinTransferRepo(db *sql.DB) *CoinTransferRepo {
+	return &CoinTransferRepo{db: db}
+}
+
+// Transfer 从转出用户的个人钱包中扣除智慧果并转入到接收用户的个人钱包
+//
+// 转出方按照有效期从早到晚扣除配额，并写入一条 quota_usage 记录；转入方按照被扣除配额的有效期创建新的配额，
+// 转赠不会延长智慧果的有效期。额度检查与扣除在同一个事务中完成，转出方的配额行被锁定，并发转赠不会超过限额
+func (repo *CoinTransferRepo) Transfer(ctx context.Context, req CoinTransferRequest, limits CoinTransferLimits) (*CoinTransfer, error) {
+	if req.Amount <= 0 || req.FromUserID == req.ToUserID {
+		return nil, ErrCoinTransferInvalid
+	}
+
+	var transferID int64
+	fromQuotaIDs := make(map[int64]int64)
+
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		rows, err := tx.QueryContext(ctx, "SELECT id, rest, period_end_at FROM quota WHERE user_id = ? AND rest != 0 AND period_end_at > ? ORDER BY period_end_at ASC FOR UPDATE", req.FromUserID, time.Now())
+		if err != nil {
+			return err
+		}
+
+		type item struct {
+			id, rest int64
+			endAt    time.Time
+		}
+		items := make([]item, 0)
+		var balance int64
+		for rows.Next() {
+			var it item
+			if err := rows.Scan(&it.id, &it.rest, &it.endAt); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			balance += it.rest
+			items = append(items, it)
+		}
+		_ = rows.Close()
+
+		// 余额包含负数的欠费记录，欠费未补足时不允许转出
+		if balance < req.Amount {
+			return ErrCoinTransferBalanceNotEnough
+		}
+
+		usage, err := coinTransferUsage(ctx, tx, req.FromUserID)
+		if err != nil {
+			return err
+		}
+
+		if err := limits.Check(usage, req.ToUserID, req.Amount); err != nil {
+			return err
+		}
+
+		// 转入方的配额按照有效期合并
+		received := make(map[time.Time]int64)
+		endAts := make([]time.Time, 0)
+
+		var transferred int64
+		for _, it := range items {
+			if transferred >= req.Amount {
+				break
+			}
+
+			if it.rest <= 0 {
+				continue
+			}
+
+			deduct := it.rest
+			if deduct > req.Amount-transferred {
+				deduct = req.Amount - transferred
+			}
+
+			if _, err := tx.ExecContext(ctx, "UPDATE quota SET rest = rest - ? WHERE id = ?", deduct, it.id); err != nil {
+				return err
+			}
+
+			if _, ok := received[it.endAt]; !ok {
+				endAts = append(endAts, it.endAt)
+			}
+
+			received[it.endAt] += deduct
+			fromQuotaIDs[it.id] = deduct
+			transferred += deduct
+		}
+
+		if transferred < req.Amount {
+			return ErrCoinTransferBalanceNotEnough
+		}
+
+		fromQuotaIDsBytes, _ := json.Marshal(fromQuotaIDs)
+		transferID, err = model.NewCoinTransferModel(tx).Save(ctx, model.CoinTransferN{
+			FromUserId:   null.IntFrom(req.FromUserID),
+			ToUserId:     null.IntFrom(req.ToUserID),
+			Amount:       null.IntFrom(req.Amount),
+			Note:         null.StringFrom(req.Note),
+			FromQuotaIds: null.StringFrom(string(fromQuotaIDsBytes)),
+			ClientIp:     null.StringFrom(req.ClientIP),
+		})
+		if err != nil {
+			return fmt.Errorf("create coin transfer failed: %w", err)
+		}
+
+		toQuotaIDs := make([]int64, 0, len(endAts))
+		for _, endAt := range endAts {
+			quotaID, err := model.NewQuotaModel(tx).Create(ctx, query.KV{
+				model.FieldQuotaUserId:        req.ToUserID,
+				model.FieldQuotaQuota:         received[endAt],
+				model.FieldQuotaRest:          received[endAt],
+				model.FieldQuotaNote:          fmt.Sprintf("用户 %d 转赠", req.FromUserID),
+				model.FieldQuotaPaymentId:     fmt.Sprintf("transfer-%d", transferID),
+				model.FieldQuotaPeriodStartAt: NowInDate(),
+				model.FieldQuotaPeriodEndAt:   endAt,
+			})
+			if err != nil {
+				return fmt.Errorf("create receiver quota failed: %w", err)
+			}
+
+			toQuotaIDs = append(toQuotaIDs, quotaID)
+		}
+
+		toQuotaIDsBytes, _ := json.Marshal(toQuotaIDs)
+		if _, err := model.NewCoinTransferModel(tx).UpdateFields(ctx, query.KV{
+			model.FieldCoinTransferToQuotaIds: string(toQuotaIDsBytes),
+		}, query.Builder().Where(model.FieldCoinTransferId, transferID)); err != nil {
+			return fmt.Errorf("update coin transfer failed: %w", err)
+		}
+
+		// 转出方的扣费记录与转赠记录在同一个事务中写入，保证两边的账目一致
+		metaBytes, _ := json.Marshal(NewQuotaUsedMeta(QuotaUsageTagCoinTransfer))
+		if _, err := model.NewQuotaUsageModel(tx).Save(ctx, model.QuotaUsageN{
+			UserId:   null.IntFrom(req.FromUserID),
+			Used:     null.IntFrom(req.Amount),
+			QuotaIds: null.StringFrom(string(fromQuotaIDsBytes)),
+			Meta:     null.StringFrom(string(metaBytes)),
+		}); err != nil {
+			return fmt.Errorf("save quota usage failed: %w", err)
+		}
+
+		return postLedger(ctx, tx, LedgerTxn{
+			Kind: LedgerKindTransfer,
+			Ref:  fmt.Sprintf("transfer-%d", transferID),
+			Note: req.Note,
+			Entries: []LedgerEntry{
+				Debit(LedgerAccountUserWallet, req.FromUserID, req.Amount),
+				Credit(LedgerAccountUserWallet, req.ToUserID, req.Amount),
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.F(log.M{
+		"transfer_id":  transferID,
+		"from_user_id": req.FromUserID,
+		"to_user_id":   req.ToUserID,
+		"amount":       req.Amount,
+		"quota_ids":    fromQuotaIDs,
+	}).Info("coins transferred")
+
+	return &CoinTransfer{
+		ID:         transferID,
+		FromUserID: req.FromUserID,
+		ToUserID:   req.ToUserID,
+		Amount:     req.Amount,
+		Note:       req.Note,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// Usage 查询用户今天和本月已经转出的智慧果
+func (repo *CoinTransferRepo) Usage(ctx context.Context, userID int64) (CoinTransferUsage, error) {
+	return coinTransferUsage(ctx, repo.db, userID)
+}
+
+func coinTransferUsage(ctx context.Context, db query.Database, userID int64) (CoinTransferUsage, error) {
+	usage := CoinTransferUsage{TodayRecipients: make([]int64, 0)}
+	monthStart, _ := monthRange(time.Now())
+	today := NowInDate()
+
+	rows, err := db.QueryContext(ctx, "SELECT to_user_id, amount, created_at FROM coin_transfer WHERE from_user_id = ? AND created_at >= ?", userID, monthStart)
+	if err != nil {
+		return usage, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var toUserID, amount int64
+		var createdAt time.Time
+		if err := rows.Scan(&toUserID, &amount, &createdAt); err != nil {
+			return usage, err
+		}
+
+		usage.MonthAmount += amount
+		if !createdAt.Before(today) {
+			usage.TodayAmount += amount
+			if !array.In(toUserID, usage.TodayRecipients) {
+				usage.TodayRecipients = append(usage.TodayRecipients, toUserID)
+			}
+		}
+	}
+
+	return usage, rows.Err()
+}
+
+// Transfers 用户转出和收到的转赠记录
+func (repo *CoinTransferRepo) Transfers(ctx context.Context, userID int64, page, perPage int64) ([]CoinTransfer, query.PaginateMeta, error) {
+	q := query.Builder().
+		WhereGroup(func(builder query.Condition) {
+			builder.Where(model.FieldCoinTransferFromUserId, userID).OrWhere(model.FieldCoinTransferToUserId, userID)
+		}).
+		OrderBy(model.FieldCoinTransferId, "DESC")
+
+	items, meta, err := model.NewCoinTransferModel(repo.db).Paginate(ctx, page, perPage, q)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	return array.Map(items, func(item model.CoinTransferN, _ int) CoinTransfer {
+		return CoinTransfer{
+			ID:         item.Id.ValueOrZero(),
+			FromUserID: item.FromUserId.ValueOrZero(),
+			ToUserID:   item.ToUserId.ValueOrZero(),
+			Amount:     item.Amount.ValueOrZero(),
+			Note:       item.Note.ValueOrZero(),
+			CreatedAt:  item.CreatedAt.ValueOrZero(),
+		}
+	}), meta, nil
+}
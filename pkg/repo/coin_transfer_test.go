@@ -0,0 +1,23 @@
+package repo_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestCoinTransferLimits_Check(t *testing.T) {
+	unlimited := repo.CoinTransferLimits{}
+	assert.NoError(t, unlimited.Check(repo.CoinTransferUsage{TodayAmount: 1000000, MonthAmount: 1000000, TodayRecipients: []int64{1, 2, 3}}, 4, 1000))
+
+	limits := repo.CoinTransferLimits{Daily: 100, Monthly: 500, DailyRecipients: 2}
+	usage := repo.CoinTransferUsage{TodayAmount: 60, MonthAmount: 450, TodayRecipients: []int64{1, 2}}
+
+	assert.NoError(t, limits.Check(usage, 1, 40))
+	assert.Equal(t, repo.ErrCoinTransferDailyLimit, limits.Check(usage, 1, 41))
+	assert.Equal(t, repo.ErrCoinTransferMonthlyLimit, limits.Check(repo.CoinTransferUsage{MonthAmount: 480}, 1, 30))
+	// 今天已经转赠过的用户不受转赠对象数量的限制
+	assert.Equal(t, repo.ErrCoinTransferRecipientLimit, limits.Check(usage, 3, 10))
+	assert.NoError(t, limits.Check(usage, 2, 10))
+}
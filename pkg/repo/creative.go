@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/provenance"
 	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/mylxsw/eloquent"
 	"github.com/mylxsw/eloquent/query"
 	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
 	"gopkg.in/guregu/null.v3"
 )
 
@@ -28,6 +30,9 @@ const (
 	IslandTypeUpscale           IslandType = 5
 	IslandTypeImageColorization IslandType = 6
 	IslandTypeArtisticText      IslandType = 7
+	IslandTypeEssayGrading      IslandType = 8
+	IslandTypeAvatarPack        IslandType = 9
+	IslandTypeMockInterview     IslandType = 10
 )
 
 type IslandHistorySharedStatus int64
@@ -521,6 +526,8 @@ func (r *CreativeRepo) HistoryRecordPaginate(ctx context.Context, userId int64,
 		q = q.Where(model2.FieldCreativeHistoryIslandType, int64(IslandTypeText))
 	case "image-draw":
 		q = q.Where(model2.FieldCreativeHistoryIslandType, int64(IslandTypeImage))
+	case "essay-grading":
+		q = q.Where(model2.FieldCreativeHistoryIslandType, int64(IslandTypeEssayGrading))
 	default:
 	}
 
@@ -667,6 +674,24 @@ type CreativeRecordArguments struct {
 	Seed               int64    `json:"seed,omitempty"`
 	Text               string   `json:"text,omitempty"`
 	ArtisticType       string   `json:"artistic_type,omitempty"`
+	// Images 作文批改时拍照上传的图片
+	Images []string `json:"images,omitempty"`
+	// Grade 作文批改时的年级或考试类型，如 初二、高考、雅思
+	Grade string `json:"grade,omitempty"`
+	// Requirement 作文批改时的题目要求
+	Requirement string `json:"requirement,omitempty"`
+	// Theme 数字分身写真的主题
+	Theme string `json:"theme,omitempty"`
+	// OriginalPrompt 使用提示语优化时，用户输入的原始提示语
+	OriginalPrompt string `json:"original_prompt,omitempty"`
+	// PromptEnhancementID 使用的提示语优化记录
+	PromptEnhancementID int64 `json:"prompt_enhancement_id,omitempty"`
+	// PresetID 使用的参数预设
+	PresetID int64 `json:"preset_id,omitempty"`
+	// InterviewID 模拟面试的面试 ID
+	InterviewID int64 `json:"interview_id,omitempty"`
+	// InterviewLevel 模拟面试的岗位级别
+	InterviewLevel string `json:"interview_level,omitempty"`
 }
 
 func (arg CreativeRecordArguments) ToGalleryMeta() GalleryMeta {
@@ -897,6 +922,8 @@ type ImageModelMeta struct {
 	IntroURL          string               `json:"intro_url,omitempty"`
 	ArtistStyle       string               `json:"artist_style,omitempty"`
 	RatioDimensions   map[string]Dimension `json:"ratio_dimensions,omitempty"`
+	// SupportLora 模型（Checkpoint）是否支持在提示语中使用 <lora:name:weight> 语法加载 LoRA
+	SupportLora bool `json:"support_lora,omitempty"`
 }
 
 type Dimension struct {
@@ -969,6 +996,26 @@ type ImageFilterMeta struct {
 	// Mode 用于图生图（ControlNet）
 	// 可选值："canny", "mlsd", "pose", "scribble"
 	Mode string `json:"mode,omitempty"`
+	// Loras 风格使用的 LoRA，只对支持 LoRA 的模型生效
+	Loras []ImageFilterLora `json:"loras,omitempty"`
+	// Defaults 风格的默认生成参数，用户没有指定时使用
+	Defaults *ImageFilterDefaults `json:"defaults,omitempty"`
+	// ExtraCoins 使用该风格时，每张图片在模型价格基础上调整的智慧果数量，负数表示优惠
+	ExtraCoins int64 `json:"extra_coins,omitempty"`
+}
+
+// ImageFilterLora 风格使用的 LoRA 及其权重
+type ImageFilterLora struct {
+	ID int64 `json:"id"`
+	// Weight 权重，为 0 时使用 LoRA 的默认权重
+	Weight float64 `json:"weight,omitempty"`
+}
+
+// ImageFilterDefaults 风格的默认生成参数
+type ImageFilterDefaults struct {
+	Steps         int64   `json:"steps,omitempty"`
+	ImageRatio    string  `json:"image_ratio,omitempty"`
+	ImageStrength float64 `json:"image_strength,omitempty"`
 }
 
 func (meta ImageFilterMeta) ApplyTemplate(prompt string) string {
@@ -1080,3 +1127,44 @@ func (r *CreativeRepo) Filter(ctx context.Context, id int64) (*ImageFilter, erro
 		ImageMeta:   meta,
 	}, nil
 }
+
+// Provenance 创作岛生成内容的来源信息
+func (item CreativeHistoryItem) Provenance() provenance.Metadata {
+	var arg CreativeRecordArguments
+	_ = json.Unmarshal([]byte(item.Arguments), &arg)
+
+	return provenance.New(
+		ternary.If(arg.ModelID != "", arg.ModelID, item.IslandModel),
+		"",
+		creativeProvenanceParams(arg.ImageRatio, arg.StylePreset, arg.Mode, arg.Steps),
+		item.CreatedAt,
+	)
+}
+
+// GalleryProvenance 分享到发现页的作品的来源信息
+func GalleryProvenance(item model2.CreativeGallery) provenance.Metadata {
+	var meta GalleryMeta
+	_ = json.Unmarshal([]byte(item.Meta), &meta)
+
+	return provenance.New(
+		meta.ModelID,
+		"",
+		creativeProvenanceParams(meta.ImageRatio, meta.StylePreset, meta.Mode, meta.Steps),
+		item.CreatedAt,
+	)
+}
+
+func creativeProvenanceParams(imageRatio, stylePreset, mode string, steps int64) map[string]any {
+	params := make(map[string]any)
+	for k, v := range map[string]string{"image_ratio": imageRatio, "style_preset": stylePreset, "mode": mode} {
+		if v != "" {
+			params[k] = v
+		}
+	}
+
+	if steps > 0 {
+		params["steps"] = steps
+	}
+
+	return params
+}
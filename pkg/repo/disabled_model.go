@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// DisabledModel 被管理员禁用的模型
+type DisabledModel struct {
+	ModelID   string    `json:"model_id"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DisabledModelRepo 运行时禁用的模型，无需修改配置文件和重启服务
+type DisabledModelRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewDisabledModelRepo create a new DisabledModelRepo
+func NewDisabledModelRepo(db *sql.DB, conf *config.Config) *DisabledModelRepo {
+	return &DisabledModelRepo{db: db, conf: conf}
+}
+
+// DisabledModels 查询所有被禁用的模型
+func (repo *DisabledModelRepo) DisabledModels(ctx context.Context) ([]DisabledModel, error) {
+	items, err := model.NewDisabledModelModel(repo.db).Get(ctx, query.Builder().OrderBy(model.FieldDisabledModelId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.DisabledModelN, _ int) DisabledModel {
+		return DisabledModel{
+			ModelID:   item.ModelId.ValueOrZero(),
+			Reason:    item.Reason.ValueOrZero(),
+			CreatedAt: item.CreatedAt.ValueOrZero(),
+		}
+	}), nil
+}
+
+// Disable 禁用模型，模型已经被禁用时更新禁用原因
+func (repo *DisabledModelRepo) Disable(ctx context.Context, modelID string, reason string) error {
+	q := query.Builder().Where(model.FieldDisabledModelModelId, modelID)
+	exist, err := model.NewDisabledModelModel(repo.db).Exists(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		_, err = model.NewDisabledModelModel(repo.db).UpdateFields(ctx, query.KV{model.FieldDisabledModelReason: reason}, q)
+		return err
+	}
+
+	_, err = model.NewDisabledModelModel(repo.db).Create(ctx, query.KV{
+		model.FieldDisabledModelModelId: modelID,
+		model.FieldDisabledModelReason:  reason,
+	})
+	return err
+}
+
+// Enable 取消禁用模型
+func (repo *DisabledModelRepo) Enable(ctx context.Context, modelID string) error {
+	_, err := model.NewDisabledModelModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldDisabledModelModelId, modelID))
+	return err
+}
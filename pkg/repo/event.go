@@ -26,6 +26,8 @@ type UserCreatedEventSource string
 const (
 	UserCreatedEventSourceEmail UserCreatedEventSource = "email"
 	UserCreatedEventSourcePhone UserCreatedEventSource = "phone"
+	// UserCreatedEventSourceSSO 企业身份源（LDAP/OIDC）首次登录时自动创建的用户
+	UserCreatedEventSourceSSO UserCreatedEventSource = "sso"
 )
 
 type UserBindEvent struct {
@@ -0,0 +1,380 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// FlashcardSourceConversation 从会话生成的卡片
+	FlashcardSourceConversation = "conversation"
+	// FlashcardSourceNotes 从用户上传的笔记生成的卡片
+	FlashcardSourceNotes = "notes"
+)
+
+// DefaultFlashcardEaseFactor SM-2 算法中新卡片的难度系数（千倍）
+const DefaultFlashcardEaseFactor = 2500
+
+// FlashcardRepo 学习模式：记忆卡片以及复习计划
+type FlashcardRepo struct {
+	db *sql.DB
+}
+
+// NewFlashcardRepo create a new FlashcardRepo
+func NewFlashcardRepo(db *sql.DB) *FlashcardRepo {
+	return &FlashcardRepo{db: db}
+}
+
+// FlashcardDeck 卡片组
+type FlashcardDeck struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	RoomID int64  `json:"room_id,omitempty"`
+	// CardCount 卡片数量
+	CardCount int64 `json:"card_count"`
+	// DueCount 当前需要复习的卡片数量
+	DueCount  int64     `json:"due_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FlashcardContent 卡片内容
+type FlashcardContent struct {
+	Front string `json:"front"`
+	Back  string `json:"back"`
+}
+
+// FlashcardSchedule 卡片的复习计划
+type FlashcardSchedule struct {
+	// Repetitions 连续答对的次数
+	Repetitions int64 `json:"repetitions"`
+	// IntervalDays 复习间隔（天）
+	IntervalDays int64 `json:"interval_days"`
+	// EaseFactor 难度系数的千倍
+	EaseFactor int64     `json:"ease_factor"`
+	DueAt      time.Time `json:"due_at"`
+}
+
+// Flashcard 记忆卡片
+type Flashcard struct {
+	ID     int64 `json:"id"`
+	DeckID int64 `json:"deck_id"`
+	FlashcardContent
+	FlashcardSchedule
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+func flashcardFromModel(item model.StudyCardN) Flashcard {
+	ret := Flashcard{
+		ID:     item.Id.ValueOrZero(),
+		DeckID: item.DeckId.ValueOrZero(),
+		FlashcardContent: FlashcardContent{
+			Front: item.Front.ValueOrZero(),
+			Back:  item.Back.ValueOrZero(),
+		},
+		FlashcardSchedule: FlashcardSchedule{
+			Repetitions:  item.Repetitions.ValueOrZero(),
+			IntervalDays: item.IntervalDays.ValueOrZero(),
+			EaseFactor:   item.EaseFactor.ValueOrZero(),
+			DueAt:        item.DueAt.ValueOrZero(),
+		},
+	}
+
+	if item.ReviewedAt.Valid {
+		reviewedAt := item.ReviewedAt.ValueOrZero()
+		ret.ReviewedAt = &reviewedAt
+	}
+
+	return ret
+}
+
+// CreateDeck 创建卡片组，新卡片立即可以复习
+func (repo *FlashcardRepo) CreateDeck(ctx context.Context, userID int64, name, source string, roomID int64, cards []FlashcardContent) (int64, error) {
+	var deckID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewStudyDeckModel(tx).Create(ctx, query.KV{
+			model.FieldStudyDeckUserId: userID,
+			model.FieldStudyDeckName:   name,
+			model.FieldStudyDeckSource: source,
+			model.FieldStudyDeckRoomId: roomID,
+		})
+		if err != nil {
+			return err
+		}
+
+		deckID = id
+		now := time.Now()
+		for _, card := range cards {
+			if _, err := model.NewStudyCardModel(tx).Create(ctx, query.KV{
+				model.FieldStudyCardUserId:     userID,
+				model.FieldStudyDeckId:         deckID,
+				model.FieldStudyCardFront:      card.Front,
+				model.FieldStudyCardBack:       card.Back,
+				model.FieldStudyCardEaseFactor: DefaultFlashcardEaseFactor,
+				model.FieldStudyCardDueAt:      now,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return deckID, err
+}
+
+// Decks 用户的所有卡片组，dueBefore 之前到期的卡片计入需要复习的数量
+func (repo *FlashcardRepo) Decks(ctx context.Context, userID int64, dueBefore time.Time) ([]FlashcardDeck, error) {
+	decks, err := model.NewStudyDeckModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldStudyDeckUserId, userID).
+		OrderBy(model.FieldStudyDeckId, "DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT deck_id, COUNT(*), COALESCE(SUM(due_at <= ?), 0) FROM study_card WHERE user_id = ? GROUP BY deck_id",
+		dueBefore, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int64][2]int64)
+	for rows.Next() {
+		var deckID, cardCount, dueCount int64
+		if err := rows.Scan(&deckID, &cardCount, &dueCount); err != nil {
+			return nil, err
+		}
+
+		counts[deckID] = [2]int64{cardCount, dueCount}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return array.Map(decks, func(item model.StudyDeckN, _ int) FlashcardDeck {
+		count := counts[item.Id.ValueOrZero()]
+		return FlashcardDeck{
+			ID:        item.Id.ValueOrZero(),
+			Name:      item.Name.ValueOrZero(),
+			Source:    item.Source.ValueOrZero(),
+			RoomID:    item.RoomId.ValueOrZero(),
+			CardCount: count[0],
+			DueCount:  count[1],
+			CreatedAt: item.CreatedAt.ValueOrZero(),
+		}
+	}), nil
+}
+
+// DeleteDeck 删除卡片组及其中的所有卡片
+func (repo *FlashcardRepo) DeleteDeck(ctx context.Context, userID, deckID int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		affected, err := model.NewStudyDeckModel(tx).Delete(ctx, query.Builder().
+			Where(model.FieldStudyDeckId, deckID).
+			Where(model.FieldStudyDeckUserId, userID))
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return ErrNotFound
+		}
+
+		_, err = model.NewStudyCardModel(tx).Delete(ctx, query.Builder().
+			Where(model.FieldStudyDeckId, deckID).
+			Where(model.FieldStudyCardUserId, userID))
+		return err
+	})
+}
+
+// DeckCards 卡片组中的所有卡片
+func (repo *FlashcardRepo) DeckCards(ctx context.Context, userID, deckID int64) ([]Flashcard, error) {
+	cards, err := model.NewStudyCardModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldStudyDeckId, deckID).
+		Where(model.FieldStudyCardUserId, userID).
+		OrderBy(model.FieldStudyCardId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(cards, func(item model.StudyCardN, _ int) Flashcard { return flashcardFromModel(item) }), nil
+}
+
+// DueCards 到期需要复习的卡片，按照到期时间排序，deckID 为 0 时查询所有卡片组
+func (repo *FlashcardRepo) DueCards(ctx context.Context, userID, deckID int64, dueBefore time.Time, limit int64) ([]Flashcard, error) {
+	q := query.Builder().
+		Where(model.FieldStudyCardUserId, userID).
+		Where(model.FieldStudyCardDueAt, "<=", dueBefore)
+	if deckID > 0 {
+		q = q.Where(model.FieldStudyDeckId, deckID)
+	}
+
+	cards, err := model.NewStudyCardModel(repo.db).Get(ctx, q.OrderBy(model.FieldStudyCardDueAt, "ASC").Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(cards, func(item model.StudyCardN, _ int) Flashcard { return flashcardFromModel(item) }), nil
+}
+
+// Card 查询用户的卡片
+func (repo *FlashcardRepo) Card(ctx context.Context, userID, id int64) (*Flashcard, error) {
+	card, err := model.NewStudyCardModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldStudyCardId, id).
+		Where(model.FieldStudyCardUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := flashcardFromModel(*card)
+	return &ret, nil
+}
+
+// UpdateCard 修改卡片内容，不影响复习计划
+func (repo *FlashcardRepo) UpdateCard(ctx context.Context, userID, id int64, content FlashcardContent) error {
+	return repo.updateCardFields(ctx, userID, id, query.KV{
+		model.FieldStudyCardFront: content.Front,
+		model.FieldStudyCardBack:  content.Back,
+	})
+}
+
+// UpdateSchedule 保存复习结果
+func (repo *FlashcardRepo) UpdateSchedule(ctx context.Context, userID, id int64, schedule FlashcardSchedule, reviewedAt time.Time) error {
+	return repo.updateCardFields(ctx, userID, id, query.KV{
+		model.FieldStudyCardRepetitions:  schedule.Repetitions,
+		model.FieldStudyCardIntervalDays: schedule.IntervalDays,
+		model.FieldStudyCardEaseFactor:   schedule.EaseFactor,
+		model.FieldStudyCardDueAt:        schedule.DueAt,
+		model.FieldStudyCardReviewedAt:   reviewedAt,
+	})
+}
+
+func (repo *FlashcardRepo) updateCardFields(ctx context.Context, userID, id int64, kv query.KV) error {
+	affected, err := model.NewStudyCardModel(repo.db).UpdateFields(ctx, kv, query.Builder().
+		Where(model.FieldStudyCardId, id).
+		Where(model.FieldStudyCardUserId, userID))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteCard 删除卡片
+func (repo *FlashcardRepo) DeleteCard(ctx context.Context, userID, id int64) error {
+	affected, err := model.NewStudyCardModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldStudyCardId, id).
+		Where(model.FieldStudyCardUserId, userID))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// StudySetting 学习模式设置
+type StudySetting struct {
+	// ReminderEnabled 是否在有到期卡片时发送复习提醒
+	ReminderEnabled bool `json:"reminder_enabled"`
+	// ReminderHour 每天发送复习提醒的时间（0-23 点）
+	ReminderHour int64 `json:"reminder_hour"`
+}
+
+// StudySetting 查询用户的学习模式设置，没有设置时返回默认值
+func (repo *FlashcardRepo) StudySetting(ctx context.Context, userID int64) (*StudySetting, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT reminder_enabled, reminder_hour FROM study_setting WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	setting := StudySetting{ReminderHour: 20}
+	if rows.Next() {
+		if err := rows.Scan(&setting.ReminderEnabled, &setting.ReminderHour); err != nil {
+			return nil, err
+		}
+	}
+
+	return &setting, rows.Err()
+}
+
+// SetStudySetting 保存用户的学习模式设置
+func (repo *FlashcardRepo) SetStudySetting(ctx context.Context, userID int64, setting StudySetting) error {
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO study_setting (user_id, reminder_enabled, reminder_hour, created_at, updated_at) VALUES (?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE reminder_enabled = VALUES(reminder_enabled), reminder_hour = VALUES(reminder_hour), updated_at = VALUES(updated_at)",
+		userID, setting.ReminderEnabled, setting.ReminderHour, time.Now(), time.Now(),
+	)
+	return err
+}
+
+// StudyReminder 需要发送复习提醒的用户
+type StudyReminder struct {
+	UserID   int64
+	DueCount int64
+}
+
+// ReminderCandidates 设置在 hour 点提醒、dayStart 之后还没有提醒过，并且当前有到期卡片的用户
+func (repo *FlashcardRepo) ReminderCandidates(ctx context.Context, hour int, dayStart time.Time) ([]StudyReminder, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT s.user_id, COUNT(f.id) FROM study_setting s INNER JOIN study_card f ON f.user_id = s.user_id AND f.due_at <= ? "+
+			"WHERE s.reminder_enabled = 1 AND s.reminder_hour = ? AND (s.reminded_at IS NULL OR s.reminded_at < ?) GROUP BY s.user_id",
+		time.Now(), hour, dayStart,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]StudyReminder, 0)
+	for rows.Next() {
+		var item StudyReminder
+		if err := rows.Scan(&item.UserID, &item.DueCount); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
+
+// MarkReminded 记录当天已经发送复习提醒，返回 false 表示已经提醒过（其它实例已经处理）
+func (repo *FlashcardRepo) MarkReminded(ctx context.Context, userID int64, dayStart time.Time) (bool, error) {
+	res, err := repo.db.ExecContext(
+		ctx,
+		"UPDATE study_setting SET reminded_at = ? WHERE user_id = ? AND (reminded_at IS NULL OR reminded_at < ?)",
+		time.Now(), userID, dayStart,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// GeoRegionDefault 默认地区策略，对没有单独配置策略的地区生效
+const GeoRegionDefault = "*"
+
+// 地区策略可以限制的功能
+const (
+	GeoFeatureChat     = "chat"
+	GeoFeatureCreative = "creative"
+	GeoFeatureVoice    = "voice"
+	GeoFeatureImage    = "image"
+)
+
+// GeoPolicy 地区访问策略
+type GeoPolicy struct {
+	Region string `json:"region"`
+	// BlockedFeatures 该地区禁止使用的功能
+	BlockedFeatures []string `json:"blocked_features"`
+	// ModelRoutes 模型路由，key 为请求的模型，value 为该地区实际使用的模型，例如国内流量使用国产模型
+	ModelRoutes map[string]string `json:"model_routes"`
+	Note        string            `json:"note,omitempty"`
+}
+
+// GeoPolicyRepo 地区访问策略
+type GeoPolicyRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewGeoPolicyRepo create a new GeoPolicyRepo
+func NewGeoPolicyRepo(db *sql.DB, conf *config.Config) *GeoPolicyRepo {
+	return &GeoPolicyRepo{db: db, conf: conf}
+}
+
+// Policies 查询所有地区策略
+func (repo *GeoPolicyRepo) Policies(ctx context.Context) ([]GeoPolicy, error) {
+	items, err := model.NewGeoPolicyModel(repo.db).Get(ctx, query.Builder().OrderBy(model.FieldGeoPolicyRegion, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]GeoPolicy, 0, len(items))
+	for _, item := range items {
+		policy := GeoPolicy{
+			Region:          item.Region.ValueOrZero(),
+			BlockedFeatures: []string{},
+			ModelRoutes:     map[string]string{},
+			Note:            item.Note.ValueOrZero(),
+		}
+
+		if v := item.BlockedFeatures.ValueOrZero(); v != "" {
+			if err := json.Unmarshal([]byte(v), &policy.BlockedFeatures); err != nil {
+				return nil, fmt.Errorf("unmarshal blocked features of region %s failed: %w", policy.Region, err)
+			}
+		}
+
+		if v := item.ModelRoutes.ValueOrZero(); v != "" {
+			if err := json.Unmarshal([]byte(v), &policy.ModelRoutes); err != nil {
+				return nil, fmt.Errorf("unmarshal model routes of region %s failed: %w", policy.Region, err)
+			}
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// UpdatePolicy 新增或更新地区策略
+func (repo *GeoPolicyRepo) UpdatePolicy(ctx context.Context, policy GeoPolicy) error {
+	blockedFeatures, _ := json.Marshal(ternary.If(policy.BlockedFeatures == nil, []string{}, policy.BlockedFeatures))
+	modelRoutes, _ := json.Marshal(ternary.If(policy.ModelRoutes == nil, map[string]string{}, policy.ModelRoutes))
+
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO geo_policy (region, blocked_features, model_routes, note, created_at, updated_at) VALUES (?, ?, ?, ?, NOW(), NOW()) ON DUPLICATE KEY UPDATE blocked_features = VALUES(blocked_features), model_routes = VALUES(model_routes), note = VALUES(note), updated_at = NOW()",
+		policy.Region, string(blockedFeatures), string(modelRoutes), policy.Note,
+	)
+	return err
+}
+
+// DeletePolicy 删除地区策略
+func (repo *GeoPolicyRepo) DeletePolicy(ctx context.Context, region string) error {
+	_, err := model.NewGeoPolicyModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldGeoPolicyRegion, region))
+	return err
+}
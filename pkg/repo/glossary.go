@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// GlossaryEntry 用户术语表中的一条术语
+type GlossaryEntry struct {
+	ID          int64  `json:"id"`
+	Term        string `json:"term"`
+	Replacement string `json:"replacement"`
+	Note        string `json:"note,omitempty"`
+}
+
+// GlossaryRepo 用户术语表，翻译以及写作请求中要求模型使用指定的译法或者写法
+type GlossaryRepo struct {
+	db *sql.DB
+}
+
+// NewGlossaryRepo create a new GlossaryRepo
+func NewGlossaryRepo(db *sql.DB) *GlossaryRepo {
+	return &GlossaryRepo{db: db}
+}
+
+// Entries 查询用户的所有术语
+func (repo *GlossaryRepo) Entries(ctx context.Context, userID int64) ([]GlossaryEntry, error) {
+	items, err := model.NewUserGlossaryModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldUserGlossaryUserId, userID).
+		OrderBy(model.FieldUserGlossaryTerm, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]GlossaryEntry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, GlossaryEntry{
+			ID:          item.Id.ValueOrZero(),
+			Term:        item.Term.ValueOrZero(),
+			Replacement: item.Replacement.ValueOrZero(),
+			Note:        item.Note.ValueOrZero(),
+		})
+	}
+
+	return entries, nil
+}
+
+// SetEntry 新增术语，术语已经存在时（不区分大小写）更新译法和备注
+func (repo *GlossaryRepo) SetEntry(ctx context.Context, userID int64, entry GlossaryEntry) error {
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO user_glossary (user_id, term, replacement, note, created_at, updated_at) VALUES (?, ?, ?, ?, NOW(), NOW()) ON DUPLICATE KEY UPDATE term = VALUES(term), replacement = VALUES(replacement), note = VALUES(note), updated_at = NOW()",
+		userID, entry.Term, entry.Replacement, entry.Note,
+	)
+	return err
+}
+
+// UpdateEntry 修改术语，术语不存在时返回 ErrNotFound
+func (repo *GlossaryRepo) UpdateEntry(ctx context.Context, userID int64, entry GlossaryEntry) error {
+	q := query.Builder().
+		Where(model.FieldUserGlossaryId, entry.ID).
+		Where(model.FieldUserGlossaryUserId, userID)
+
+	exist, err := model.NewUserGlossaryModel(repo.db).Exists(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	if !exist {
+		return ErrNotFound
+	}
+
+	_, err = model.NewUserGlossaryModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldUserGlossaryTerm:        entry.Term,
+		model.FieldUserGlossaryReplacement: entry.Replacement,
+		model.FieldUserGlossaryNote:        entry.Note,
+	}, q)
+	return err
+}
+
+// DeleteEntry 删除术语
+func (repo *GlossaryRepo) DeleteEntry(ctx context.Context, userID, id int64) error {
+	_, err := model.NewUserGlossaryModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldUserGlossaryUserId, userID).
+		Where(model.FieldUserGlossaryId, id))
+	return err
+}
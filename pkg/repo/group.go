@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/msgcrypt"
 	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
 	"time"
 
@@ -16,11 +17,30 @@ import (
 )
 
 type ChatGroupRepo struct {
-	db *sql.DB
+	db     *sql.DB
+	cipher *msgcrypt.Cipher
 }
 
-func NewChatGroupRepo(db *sql.DB) *ChatGroupRepo {
-	return &ChatGroupRepo{db: db}
+func NewChatGroupRepo(db *sql.DB, cipher *msgcrypt.Cipher) *ChatGroupRepo {
+	return &ChatGroupRepo{db: db, cipher: cipher}
+}
+
+// decryptMessages 解密查询到的消息内容
+func (repo *ChatGroupRepo) decryptMessages(ctx context.Context, messages []model2.ChatGroupMessageN) ([]model2.ChatGroupMessageN, error) {
+	for i, msg := range messages {
+		if !msg.Message.Valid {
+			continue
+		}
+
+		plain, err := repo.cipher.Decrypt(ctx, msg.Message.String)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt chat message %d failed: %w", msg.Id.ValueOrZero(), err)
+		}
+
+		messages[i].Message = null.StringFrom(plain)
+	}
+
+	return messages, nil
 }
 
 type Member struct {
@@ -257,15 +277,23 @@ type ChatGroupMessage struct {
 
 // AddChatMessage 添加聊天消息
 func (repo *ChatGroupRepo) AddChatMessage(ctx context.Context, groupID, userID int64, msg ChatGroupMessage) (int64, error) {
+	encrypted, err := repo.cipher.Encrypt(ctx, msg.Message)
+	if err != nil {
+		return 0, fmt.Errorf("encrypt chat message failed: %w", err)
+	}
+
 	var messageID int64
-	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+	err = eloquent.Transaction(repo.db, func(tx query.Database) error {
 		if MessageRole(msg.Role) == MessageRoleUser {
+			kv := query.KV{model2.FieldRoomsLastActiveTime: null.TimeFrom(time.Now())}
+			// 启用消息加密后，群组描述中不再保存消息内容的明文摘要
+			if !repo.cipher.Enabled() {
+				kv[model2.FieldRoomsDescription] = null.StringFrom(misc.SubString(msg.Message, 70))
+			}
+
 			if _, err := model2.NewRoomsModel(tx).UpdateFields(
 				ctx,
-				query.KV{
-					model2.FieldRoomsLastActiveTime: null.TimeFrom(time.Now()),
-					model2.FieldRoomsDescription:    null.StringFrom(misc.SubString(msg.Message, 70)),
-				},
+				kv,
 				query.Builder().Where(model2.FieldRoomsId, groupID),
 			); err != nil {
 				return fmt.Errorf("update group last active time failed: %w", err)
@@ -275,7 +303,7 @@ func (repo *ChatGroupRepo) AddChatMessage(ctx context.Context, groupID, userID i
 		chatMsg := model2.ChatGroupMessage{
 			GroupId:       groupID,
 			UserId:        userID,
-			Message:       msg.Message,
+			Message:       encrypted,
 			Role:          msg.Role,
 			TokenConsumed: msg.TokenConsumed,
 			QuotaConsumed: msg.QuotaConsumed,
@@ -325,6 +353,15 @@ func (repo *ChatGroupRepo) GetChatMessage(ctx context.Context, groupID, userID,
 
 	}
 
+	if msg.Message.Valid {
+		plain, err := repo.cipher.Decrypt(ctx, msg.Message.String)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt chat message failed: %w", err)
+		}
+
+		msg.Message = null.StringFrom(plain)
+	}
+
 	ret := msg.ToChatGroupMessage()
 	return &ret, err
 }
@@ -351,11 +388,15 @@ func (repo *ChatGroupRepo) GetChatMessages(ctx context.Context, groupID, userID
 		return nil, 0, fmt.Errorf("query chat messages failed: %w", err)
 	}
 
+	if messages, err = repo.decryptMessages(ctx, messages); err != nil {
+		return nil, 0, err
+	}
+
 	if len(messages) == 0 {
 		return []ChatGroupMessageRes{}, startID, nil
 	}
 
-	return array.Map(messages, func(message model2.ChatGroupMessageN, _ int) ChatGroupMessageRes {
+	return SurfaceConsensusMessages(array.Map(messages, func(message model2.ChatGroupMessageN, _ int) ChatGroupMessageRes {
 		ret := message.ToChatGroupMessage()
 		if ret.Status == MessageStatusWaiting && ret.CreatedAt.Add(3*time.Minute).Before(time.Now()) {
 			// 3 分钟未完成的消息，标记为失败
@@ -365,9 +406,52 @@ func (repo *ChatGroupRepo) GetChatMessages(ctx context.Context, groupID, userID
 			ChatGroupMessage: ret,
 			Type:             ResolveGroupMessageType(ret.Role),
 		}
-	}), messages[len(messages)-1].Id.ValueOrZero(), nil
+	})), messages[len(messages)-1].Id.ValueOrZero(), nil
+}
+
+// SurfaceConsensusMessages 将综合回答移动到所属问题之后、其它成员回答之前，使其显示在本轮对话的最上方
+// messages 按照 ID 倒序排列
+func SurfaceConsensusMessages(messages []ChatGroupMessageRes) []ChatGroupMessageRes {
+	consensus := make(map[int64]ChatGroupMessageRes)
+	rest := make([]ChatGroupMessageRes, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Role == int64(GroupMessageRoleConsensus) && msg.Pid > 0 {
+			consensus[msg.Pid] = msg
+			continue
+		}
+
+		rest = append(rest, msg)
+	}
+
+	if len(consensus) == 0 {
+		return messages
+	}
+
+	ret := make([]ChatGroupMessageRes, 0, len(messages))
+	for _, msg := range rest {
+		if c, ok := consensus[msg.Id]; ok && msg.Role == int64(MessageRoleUser) {
+			ret = append(ret, c)
+			delete(consensus, msg.Id)
+		}
+
+		ret = append(ret, msg)
+	}
+
+	// 所属问题不在当前页时，放在列表的最后（即最早的位置）
+	for _, c := range consensus {
+		ret = append(ret, c)
+	}
+
+	return ret
 }
 
+const (
+	// GroupMessageRoleSummary 群聊辩论结束后的总结消息
+	GroupMessageRoleSummary MessageRole = 5
+	// GroupMessageRoleConsensus 合并多个成员回答生成的综合回答
+	GroupMessageRoleConsensus MessageRole = 6
+)
+
 func ResolveGroupMessageType(role int64) string {
 	switch role {
 	case 1, 2:
@@ -376,6 +460,10 @@ func ResolveGroupMessageType(role int64) string {
 		return "contextBreak"
 	case 4:
 		return "timeline"
+	case int64(GroupMessageRoleSummary):
+		return "summary"
+	case int64(GroupMessageRoleConsensus):
+		return "consensus"
 	}
 
 	return "text"
@@ -392,7 +480,7 @@ func ResolveGroupMessageTypeToRole(messageType string) int64 {
 	return 0
 }
 
-// DeleteChatMessage 删除聊天消息
+// DeleteChatMessage 删除聊天消息，消息标记为已删除，保留期限内仍保留在数据库中，避免子消息的 Pid 失效以及计费无法核对
 func (repo *ChatGroupRepo) DeleteChatMessage(ctx context.Context, groupID, userID, messageID int64) error {
 	return eloquent.Transaction(repo.db, func(tx query.Database) error {
 		q := query.Builder().
@@ -405,7 +493,7 @@ func (repo *ChatGroupRepo) DeleteChatMessage(ctx context.Context, groupID, userI
 	})
 }
 
-// DeleteAllChatMessage 清空聊天消息
+// DeleteAllChatMessage 清空聊天消息（标记为已删除）
 func (repo *ChatGroupRepo) DeleteAllChatMessage(ctx context.Context, groupID, userID int64) error {
 	return eloquent.Transaction(repo.db, func(tx query.Database) error {
 		q := query.Builder().
@@ -417,6 +505,34 @@ func (repo *ChatGroupRepo) DeleteAllChatMessage(ctx context.Context, groupID, us
 	})
 }
 
+// purgeBatchSize 每次物理删除的消息数量，避免长时间锁表
+const purgeBatchSize = 1000
+
+// PurgeDeletedMessages 物理删除 before 之前标记为已删除的消息，返回删除的消息数量，扣费记录（quota_usage）不受影响
+func (repo *ChatGroupRepo) PurgeDeletedMessages(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for {
+		res, err := repo.db.ExecContext(
+			ctx,
+			"DELETE FROM chat_group_message WHERE deleted_at IS NOT NULL AND deleted_at < ? LIMIT ?",
+			before, purgeBatchSize,
+		)
+		if err != nil {
+			return total, fmt.Errorf("purge deleted chat messages failed: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+
+		total += affected
+		if affected < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
 // GetChatMessagesStatus 获取聊天消息状态
 func (repo *ChatGroupRepo) GetChatMessagesStatus(ctx context.Context, groupID, userID int64, messageIDs []int64) ([]ChatGroupMessageRes, error) {
 	messages, err := model2.NewChatGroupMessageModel(repo.db).Get(ctx, query.Builder().
@@ -427,6 +543,10 @@ func (repo *ChatGroupRepo) GetChatMessagesStatus(ctx context.Context, groupID, u
 		return nil, fmt.Errorf("query chat messages failed: %w", err)
 	}
 
+	if messages, err = repo.decryptMessages(ctx, messages); err != nil {
+		return nil, err
+	}
+
 	return array.Map(messages, func(message model2.ChatGroupMessageN, _ int) ChatGroupMessageRes {
 		ret := message.ToChatGroupMessage()
 		if ret.Status == MessageStatusWaiting && ret.CreatedAt.Add(3*time.Minute).Before(time.Now()) {
@@ -450,6 +570,11 @@ type ChatGroupMessageUpdate struct {
 
 // UpdateChatMessage 更新聊天消息
 func (repo *ChatGroupRepo) UpdateChatMessage(ctx context.Context, groupID, userID, messageID int64, msg ChatGroupMessageUpdate) error {
+	encrypted, err := repo.cipher.Encrypt(ctx, msg.Message)
+	if err != nil {
+		return fmt.Errorf("encrypt chat message failed: %w", err)
+	}
+
 	return eloquent.Transaction(repo.db, func(tx query.Database) error {
 		q := query.Builder().
 			Where(model2.FieldChatGroupMessageGroupId, groupID).
@@ -457,7 +582,7 @@ func (repo *ChatGroupRepo) UpdateChatMessage(ctx context.Context, groupID, userI
 			Where(model2.FieldChatGroupMessageId, messageID)
 
 		_, err := model2.NewChatGroupMessageModel(tx).UpdateFields(ctx, query.KV{
-			model2.FieldChatGroupMessageMessage:       msg.Message,
+			model2.FieldChatGroupMessageMessage:       encrypted,
 			model2.FieldChatGroupMessageTokenConsumed: msg.TokenConsumed,
 			model2.FieldChatGroupMessageQuotaConsumed: msg.QuotaConsumed,
 			model2.FieldChatGroupMessageStatus:        msg.Status,
@@ -468,7 +593,7 @@ func (repo *ChatGroupRepo) UpdateChatMessage(ctx context.Context, groupID, userI
 	})
 }
 
-// DeleteGroup 删除群组
+// DeleteGroup 删除群组，群组放入回收站，成员保留用于恢复，超过保留期限后由 RoomRepo.PurgeTrashedRooms 物理删除
 func (repo *ChatGroupRepo) DeleteGroup(ctx context.Context, groupID, userID int64, deleteMessages bool) error {
 	return eloquent.Transaction(repo.db, func(tx query.Database) error {
 		// 删除历史记录
@@ -481,13 +606,6 @@ func (repo *ChatGroupRepo) DeleteGroup(ctx context.Context, groupID, userID int6
 			}
 		}
 
-		// 删除成员
-		if _, err := model2.NewChatGroupMemberModel(tx).Delete(ctx, query.Builder().
-			Where(model2.FieldChatGroupMemberGroupId, groupID).
-			Where(model2.FieldChatGroupMemberUserId, userID)); err != nil {
-			return fmt.Errorf("delete chat group members failed: %w", err)
-		}
-
 		// 删除组
 		if _, err := model2.NewRoomsModel(tx).Delete(ctx, query.Builder().
 			Where(model2.FieldRoomsId, groupID).
@@ -498,3 +616,23 @@ func (repo *ChatGroupRepo) DeleteGroup(ctx context.Context, groupID, userID int6
 		return nil
 	})
 }
+
+// GetMessagesByRole 获取问题下指定类型的消息，例如综合回答
+func (repo *ChatGroupRepo) GetMessagesByRole(ctx context.Context, groupID, userID, questionID int64, role MessageRole) ([]model2.ChatGroupMessage, error) {
+	messages, err := model2.NewChatGroupMessageModel(repo.db).Get(ctx, query.Builder().
+		Where(model2.FieldChatGroupMessageGroupId, groupID).
+		Where(model2.FieldChatGroupMessageUserId, userID).
+		Where(model2.FieldChatGroupMessagePid, questionID).
+		Where(model2.FieldChatGroupMessageRole, int64(role)))
+	if err != nil {
+		return nil, fmt.Errorf("query messages failed: %w", err)
+	}
+
+	if messages, err = repo.decryptMessages(ctx, messages); err != nil {
+		return nil, err
+	}
+
+	return array.Map(messages, func(msg model2.ChatGroupMessageN, _ int) model2.ChatGroupMessage {
+		return msg.ToChatGroupMessage()
+	}), nil
+}
@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 推荐群组组合状态
+const (
+	GroupCompositionStatusEnabled  = 1
+	GroupCompositionStatusDisabled = 2
+)
+
+// GroupComposition 推荐的群组组合，用户可以直接使用组合创建群组
+type GroupComposition struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	AvatarURL   string   `json:"avatar_url,omitempty"`
+	Members     []Member `json:"members"`
+	Sort        int64    `json:"sort"`
+	Status      int64    `json:"status"`
+}
+
+func groupCompositionFromModel(item model.ChatGroupCompositionN) (GroupComposition, error) {
+	comp := GroupComposition{
+		ID:          item.Id.ValueOrZero(),
+		Name:        item.Name.ValueOrZero(),
+		Description: item.Description.ValueOrZero(),
+		AvatarURL:   item.AvatarUrl.ValueOrZero(),
+		Members:     []Member{},
+		Sort:        item.Sort.ValueOrZero(),
+		Status:      item.Status.ValueOrZero(),
+	}
+
+	if v := item.Members.ValueOrZero(); v != "" {
+		if err := json.Unmarshal([]byte(v), &comp.Members); err != nil {
+			return comp, fmt.Errorf("unmarshal group composition members failed: %w", err)
+		}
+	}
+
+	return comp, nil
+}
+
+// Compositions 查询推荐的群组组合，enabledOnly 为 true 时只返回启用的组合
+func (repo *ChatGroupRepo) Compositions(ctx context.Context, enabledOnly bool) ([]GroupComposition, error) {
+	q := query.Builder().
+		OrderBy(model.FieldChatGroupCompositionSort, "DESC").
+		OrderBy(model.FieldChatGroupCompositionId, "ASC")
+	if enabledOnly {
+		q = q.Where(model.FieldChatGroupCompositionStatus, GroupCompositionStatusEnabled)
+	}
+
+	items, err := model.NewChatGroupCompositionModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	comps := make([]GroupComposition, 0, len(items))
+	for _, item := range items {
+		comp, err := groupCompositionFromModel(item)
+		if err != nil {
+			return nil, err
+		}
+
+		comps = append(comps, comp)
+	}
+
+	return comps, nil
+}
+
+// Composition 查询推荐的群组组合，组合不存在或已禁用时返回 ErrNotFound
+func (repo *ChatGroupRepo) Composition(ctx context.Context, id int64) (*GroupComposition, error) {
+	item, err := model.NewChatGroupCompositionModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldChatGroupCompositionId, id).
+		Where(model.FieldChatGroupCompositionStatus, GroupCompositionStatusEnabled))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	comp, err := groupCompositionFromModel(*item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comp, nil
+}
+
+func groupCompositionKV(comp GroupComposition) query.KV {
+	members, _ := json.Marshal(comp.Members)
+	return query.KV{
+		model.FieldChatGroupCompositionName:        comp.Name,
+		model.FieldChatGroupCompositionDescription: comp.Description,
+		model.FieldChatGroupCompositionAvatarUrl:   comp.AvatarURL,
+		model.FieldChatGroupCompositionMembers:     string(members),
+		model.FieldChatGroupCompositionSort:        comp.Sort,
+		model.FieldChatGroupCompositionStatus:      comp.Status,
+	}
+}
+
+// CreateComposition 新增推荐的群组组合
+func (repo *ChatGroupRepo) CreateComposition(ctx context.Context, comp GroupComposition) (int64, error) {
+	return model.NewChatGroupCompositionModel(repo.db).Create(ctx, groupCompositionKV(comp))
+}
+
+// UpdateComposition 更新推荐的群组组合
+func (repo *ChatGroupRepo) UpdateComposition(ctx context.Context, id int64, comp GroupComposition) error {
+	_, err := model.NewChatGroupCompositionModel(repo.db).UpdateFields(
+		ctx,
+		groupCompositionKV(comp),
+		query.Builder().Where(model.FieldChatGroupCompositionId, id),
+	)
+	return err
+}
+
+// DeleteComposition 删除推荐的群组组合
+func (repo *ChatGroupRepo) DeleteComposition(ctx context.Context, id int64) error {
+	_, err := model.NewChatGroupCompositionModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldChatGroupCompositionId, id))
+	return err
+}
@@ -0,0 +1,204 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// GroupMessageBestNone 非最佳回答
+	GroupMessageBestNone int64 = 0
+	// GroupMessageBestJudge 评审模型选择的最佳回答
+	GroupMessageBestJudge int64 = 1
+	// GroupMessageBestUser 用户投票选择的最佳回答
+	GroupMessageBestUser int64 = 2
+)
+
+const (
+	GroupJudgeStatusNone    int64 = 0
+	GroupJudgeStatusRunning int64 = 1
+	GroupJudgeStatusDone    int64 = 2
+	GroupJudgeStatusFailed  int64 = 3
+)
+
+// StartJudge 将问题标记为评审中，返回 false 表示问题已经在评审中或已评审完成
+func (repo *ChatGroupRepo) StartJudge(ctx context.Context, groupID, userID, questionID int64) (bool, error) {
+	affected, err := model2.NewChatGroupMessageModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model2.FieldChatGroupMessageJudgeStatus: GroupJudgeStatusRunning},
+		query.Builder().
+			Where(model2.FieldChatGroupMessageId, questionID).
+			Where(model2.FieldChatGroupMessageGroupId, groupID).
+			Where(model2.FieldChatGroupMessageUserId, userID).
+			Where(model2.FieldChatGroupMessageRole, int64(MessageRoleUser)).
+			WhereIn(model2.FieldChatGroupMessageJudgeStatus, GroupJudgeStatusNone, GroupJudgeStatusFailed),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// GetAnswers 获取问题下所有成员的回答
+func (repo *ChatGroupRepo) GetAnswers(ctx context.Context, groupID, userID, questionID int64) ([]model2.ChatGroupMessage, error) {
+	messages, err := model2.NewChatGroupMessageModel(repo.db).Get(ctx, query.Builder().
+		Where(model2.FieldChatGroupMessageGroupId, groupID).
+		Where(model2.FieldChatGroupMessageUserId, userID).
+		Where(model2.FieldChatGroupMessagePid, questionID).
+		Where(model2.FieldChatGroupMessageRole, int64(MessageRoleAssistant)).
+		OrderBy(model2.FieldChatGroupMessageId, "ASC"))
+	if err != nil {
+		return nil, fmt.Errorf("query answers failed: %w", err)
+	}
+
+	if messages, err = repo.decryptMessages(ctx, messages); err != nil {
+		return nil, err
+	}
+
+	return array.Map(messages, func(msg model2.ChatGroupMessageN, _ int) model2.ChatGroupMessage {
+		return msg.ToChatGroupMessage()
+	}), nil
+}
+
+// FinishJudge 保存评审结果，scores 为回答 ID 到得分的映射，bestID 为 0 时表示评审失败
+func (repo *ChatGroupRepo) FinishJudge(ctx context.Context, questionID int64, scores map[int64]int64, bestID int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if bestID > 0 {
+			for answerID, score := range scores {
+				if _, err := model2.NewChatGroupMessageModel(tx).UpdateFields(
+					ctx,
+					query.KV{model2.FieldChatGroupMessageScore: score},
+					query.Builder().
+						Where(model2.FieldChatGroupMessageId, answerID).
+						Where(model2.FieldChatGroupMessagePid, questionID),
+				); err != nil {
+					return err
+				}
+			}
+
+			// 用户已经投票选择了最佳回答时，不覆盖用户的选择
+			voted, err := model2.NewChatGroupMessageModel(tx).Count(ctx, query.Builder().
+				Where(model2.FieldChatGroupMessagePid, questionID).
+				Where(model2.FieldChatGroupMessageBest, GroupMessageBestUser))
+			if err != nil {
+				return err
+			}
+
+			if voted == 0 {
+				if _, err := model2.NewChatGroupMessageModel(tx).UpdateFields(
+					ctx,
+					query.KV{model2.FieldChatGroupMessageBest: GroupMessageBestJudge},
+					query.Builder().
+						Where(model2.FieldChatGroupMessageId, bestID).
+						Where(model2.FieldChatGroupMessagePid, questionID),
+				); err != nil {
+					return err
+				}
+			}
+		}
+
+		status := GroupJudgeStatusDone
+		if bestID == 0 {
+			status = GroupJudgeStatusFailed
+		}
+
+		_, err := model2.NewChatGroupMessageModel(tx).UpdateFields(
+			ctx,
+			query.KV{model2.FieldChatGroupMessageJudgeStatus: status},
+			query.Builder().Where(model2.FieldChatGroupMessageId, questionID),
+		)
+		return err
+	})
+}
+
+// VoteBest 用户投票选择最佳回答，会覆盖评审模型的选择
+func (repo *ChatGroupRepo) VoteBest(ctx context.Context, groupID, userID, questionID, answerID int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		exist, err := model2.NewChatGroupMessageModel(tx).Exists(ctx, query.Builder().
+			Where(model2.FieldChatGroupMessageId, answerID).
+			Where(model2.FieldChatGroupMessageGroupId, groupID).
+			Where(model2.FieldChatGroupMessageUserId, userID).
+			Where(model2.FieldChatGroupMessagePid, questionID).
+			Where(model2.FieldChatGroupMessageRole, int64(MessageRoleAssistant)).
+			Where(model2.FieldChatGroupMessageStatus, MessageStatusSucceed))
+		if err != nil {
+			return err
+		}
+
+		if !exist {
+			return ErrNotFound
+		}
+
+		if _, err := model2.NewChatGroupMessageModel(tx).UpdateFields(
+			ctx,
+			query.KV{model2.FieldChatGroupMessageBest: GroupMessageBestNone},
+			query.Builder().
+				Where(model2.FieldChatGroupMessagePid, questionID).
+				Where(model2.FieldChatGroupMessageUserId, userID),
+		); err != nil {
+			return err
+		}
+
+		_, err = model2.NewChatGroupMessageModel(tx).UpdateFields(
+			ctx,
+			query.KV{model2.FieldChatGroupMessageBest: GroupMessageBestUser},
+			query.Builder().Where(model2.FieldChatGroupMessageId, answerID),
+		)
+		return err
+	})
+}
+
+// ModelWinRate 模型在群聊中被选为最佳回答的统计
+type ModelWinRate struct {
+	ModelID string `json:"model_id"`
+	// Total 参与评选的回答数量
+	Total int64 `json:"total"`
+	// Wins 被选为最佳回答的数量
+	Wins int64 `json:"wins"`
+	// UserWins 被用户投票选为最佳回答的数量
+	UserWins int64   `json:"user_wins"`
+	WinRate  float64 `json:"win_rate"`
+}
+
+// ModelWinRates 统计每个模型在群聊中的胜率，只统计已经选出最佳回答的问题，userID 为 0 时统计所有用户
+func (repo *ChatGroupRepo) ModelWinRates(ctx context.Context, userID int64) ([]ModelWinRate, error) {
+	sqlStr := `SELECT mem.model_id, COUNT(*), SUM(IF(msg.best > 0, 1, 0)), SUM(IF(msg.best = ?, 1, 0))
+FROM chat_group_message msg
+INNER JOIN chat_group_member mem ON mem.id = msg.member_id
+WHERE msg.role = ? AND msg.status = ? AND msg.deleted_at IS NULL
+  AND msg.pid IN (SELECT DISTINCT pid FROM chat_group_message WHERE best > 0 AND deleted_at IS NULL)`
+	args := []any{GroupMessageBestUser, int64(MessageRoleAssistant), MessageStatusSucceed}
+	if userID > 0 {
+		sqlStr += " AND msg.user_id = ?"
+		args = append(args, userID)
+	}
+	sqlStr += " GROUP BY mem.model_id ORDER BY COUNT(*) DESC"
+
+	rows, err := repo.db.QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query model win rates failed: %w", err)
+	}
+	defer rows.Close()
+
+	rates := make([]ModelWinRate, 0)
+	for rows.Next() {
+		var rate ModelWinRate
+		if err := rows.Scan(&rate.ModelID, &rate.Total, &rate.Wins, &rate.UserWins); err != nil {
+			return nil, err
+		}
+
+		if rate.Total > 0 {
+			rate.WinRate = float64(rate.Wins) / float64(rate.Total)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, rows.Err()
+}
@@ -0,0 +1,27 @@
+package repo_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestSurfaceConsensusMessages(t *testing.T) {
+	msg := func(id, pid int64, role repo.MessageRole) repo.ChatGroupMessageRes {
+		return repo.ChatGroupMessageRes{ChatGroupMessage: model.ChatGroupMessage{Id: id, Pid: pid, Role: int64(role)}}
+	}
+
+	messages := []repo.ChatGroupMessageRes{
+		msg(10, 7, repo.GroupMessageRoleConsensus),
+		msg(9, 7, repo.MessageRoleAssistant),
+		msg(8, 7, repo.MessageRoleAssistant),
+		msg(7, 0, repo.MessageRoleUser),
+		msg(6, 5, repo.MessageRoleAssistant),
+	}
+
+	ids := array.Map(repo.SurfaceConsensusMessages(messages), func(m repo.ChatGroupMessageRes, _ int) int64 { return m.Id })
+	assert.Equal(t, []int64{9, 8, 10, 7, 6}, ids)
+}
@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// ImagePresetMaxPerUser 每个用户最多可以保存的参数预设数量
+const ImagePresetMaxPerUser = 50
+
+var (
+	// ErrImagePresetExists 同名的参数预设已经存在
+	ErrImagePresetExists = errors.New("image preset already exists")
+	// ErrImagePresetLimitExceeded 参数预设数量超过限制
+	ErrImagePresetLimitExceeded = errors.New("image preset limit exceeded")
+)
+
+// ImagePresetParams 图片生成参数预设，值为空的参数不覆盖默认值
+type ImagePresetParams struct {
+	Model          string  `json:"model,omitempty"`
+	FilterID       int64   `json:"filter_id,omitempty"`
+	ImageRatio     string  `json:"image_ratio,omitempty"`
+	Width          int64   `json:"width,omitempty"`
+	Height         int64   `json:"height,omitempty"`
+	Steps          int64   `json:"steps,omitempty"`
+	ImageCount     int64   `json:"image_count,omitempty"`
+	ImageStrength  float64 `json:"image_strength,omitempty"`
+	NegativePrompt string  `json:"negative_prompt,omitempty"`
+	StylePreset    string  `json:"style_preset,omitempty"`
+}
+
+// ImagePreset 用户保存的图片生成参数预设
+type ImagePreset struct {
+	ID        int64             `json:"id"`
+	UserID    int64             `json:"user_id"`
+	Name      string            `json:"name"`
+	Params    ImagePresetParams `json:"params"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ImagePresetRepo 图片生成参数预设
+type ImagePresetRepo struct {
+	db *sql.DB
+}
+
+// NewImagePresetRepo create a new ImagePresetRepo
+func NewImagePresetRepo(db *sql.DB) *ImagePresetRepo {
+	return &ImagePresetRepo{db: db}
+}
+
+func imagePresetFromModel(item model.ImagePresetN) ImagePreset {
+	preset := ImagePreset{
+		ID:        item.Id.ValueOrZero(),
+		UserID:    item.UserId.ValueOrZero(),
+		Name:      item.Name.ValueOrZero(),
+		CreatedAt: item.CreatedAt.ValueOrZero(),
+		UpdatedAt: item.UpdatedAt.ValueOrZero(),
+	}
+
+	_ = json.Unmarshal([]byte(item.Params.ValueOrZero()), &preset.Params)
+	return preset
+}
+
+// Presets 查询用户的所有参数预设，最近更新的在前
+func (repo *ImagePresetRepo) Presets(ctx context.Context, userID int64) ([]ImagePreset, error) {
+	items, err := model.NewImagePresetModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldImagePresetUserId, userID).
+		OrderBy(model.FieldImagePresetUpdatedAt, "DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	presets := make([]ImagePreset, 0, len(items))
+	for _, item := range items {
+		presets = append(presets, imagePresetFromModel(item))
+	}
+
+	return presets, nil
+}
+
+// Preset 查询用户的参数预设，不存在时返回 ErrNotFound
+func (repo *ImagePresetRepo) Preset(ctx context.Context, userID, id int64) (*ImagePreset, error) {
+	item, err := model.NewImagePresetModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldImagePresetId, id).
+		Where(model.FieldImagePresetUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	preset := imagePresetFromModel(*item)
+	return &preset, nil
+}
+
+func (repo *ImagePresetRepo) nameExists(ctx context.Context, userID int64, name string, excludeID int64) (bool, error) {
+	q := query.Builder().
+		Where(model.FieldImagePresetUserId, userID).
+		Where(model.FieldImagePresetName, name)
+	if excludeID > 0 {
+		q = q.Where(model.FieldImagePresetId, "!=", excludeID)
+	}
+
+	return model.NewImagePresetModel(repo.db).Exists(ctx, q)
+}
+
+// CreatePreset 新增参数预设
+func (repo *ImagePresetRepo) CreatePreset(ctx context.Context, userID int64, name string, params ImagePresetParams) (int64, error) {
+	count, err := model.NewImagePresetModel(repo.db).Count(ctx, query.Builder().Where(model.FieldImagePresetUserId, userID))
+	if err != nil {
+		return 0, err
+	}
+
+	if count >= ImagePresetMaxPerUser {
+		return 0, ErrImagePresetLimitExceeded
+	}
+
+	exist, err := repo.nameExists(ctx, userID, name, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if exist {
+		return 0, ErrImagePresetExists
+	}
+
+	data, _ := json.Marshal(params)
+	return model.NewImagePresetModel(repo.db).Create(ctx, query.KV{
+		model.FieldImagePresetUserId: userID,
+		model.FieldImagePresetName:   name,
+		model.FieldImagePresetParams: string(data),
+	})
+}
+
+// UpdatePreset 更新参数预设，预设不存在时返回 ErrNotFound
+func (repo *ImagePresetRepo) UpdatePreset(ctx context.Context, userID, id int64, name string, params ImagePresetParams) error {
+	if _, err := repo.Preset(ctx, userID, id); err != nil {
+		return err
+	}
+
+	exist, err := repo.nameExists(ctx, userID, name, id)
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		return ErrImagePresetExists
+	}
+
+	data, _ := json.Marshal(params)
+	_, err = model.NewImagePresetModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldImagePresetName:   name,
+		model.FieldImagePresetParams: string(data),
+	}, query.Builder().Where(model.FieldImagePresetId, id).Where(model.FieldImagePresetUserId, userID))
+	return err
+}
+
+// DeletePreset 删除参数预设
+func (repo *ImagePresetRepo) DeletePreset(ctx context.Context, userID, id int64) error {
+	_, err := model.NewImagePresetModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldImagePresetId, id).
+		Where(model.FieldImagePresetUserId, userID))
+	return err
+}
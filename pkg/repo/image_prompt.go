@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 提示语风格状态
+const (
+	ImagePromptStyleStatusEnabled  = 1
+	ImagePromptStyleStatusDisabled = 2
+)
+
+// ImagePromptStyle 提示语优化使用的风格预设
+type ImagePromptStyle struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Instruction 提供给模型的风格要求，不返回给普通用户
+	Instruction string `json:"instruction,omitempty"`
+	Preview     string `json:"preview,omitempty"`
+	SortOrder   int64  `json:"sort_order"`
+	Status      int64  `json:"status"`
+}
+
+// ImagePromptEnhancement 提示语优化记录
+type ImagePromptEnhancement struct {
+	ID             int64  `json:"id"`
+	UserID         int64  `json:"user_id"`
+	StyleID        int64  `json:"style_id,omitempty"`
+	Target         string `json:"target"`
+	Prompt         string `json:"prompt"`
+	EnhancedPrompt string `json:"enhanced_prompt"`
+	NegativePrompt string `json:"negative_prompt,omitempty"`
+	Model          string `json:"model"`
+	TokenConsumed  int64  `json:"token_consumed"`
+	QuotaConsumed  int64  `json:"quota_consumed"`
+}
+
+// ImagePromptRepo 图片提示语优化
+type ImagePromptRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewImagePromptRepo create a new ImagePromptRepo
+func NewImagePromptRepo(db *sql.DB, conf *config.Config) *ImagePromptRepo {
+	return &ImagePromptRepo{db: db, conf: conf}
+}
+
+func imagePromptStyleFromModel(item model.ImagePromptStyleN) ImagePromptStyle {
+	return ImagePromptStyle{
+		ID:          item.Id.ValueOrZero(),
+		Name:        item.Name.ValueOrZero(),
+		Description: item.Description.ValueOrZero(),
+		Instruction: item.Instruction.ValueOrZero(),
+		Preview:     item.Preview.ValueOrZero(),
+		SortOrder:   item.SortOrder.ValueOrZero(),
+		Status:      item.Status.ValueOrZero(),
+	}
+}
+
+// Styles 查询提示语风格，enabledOnly 为 true 时只返回启用的风格
+func (repo *ImagePromptRepo) Styles(ctx context.Context, enabledOnly bool) ([]ImagePromptStyle, error) {
+	q := query.Builder().
+		OrderBy(model.FieldImagePromptStyleSortOrder, "ASC").
+		OrderBy(model.FieldImagePromptStyleId, "ASC")
+	if enabledOnly {
+		q = q.Where(model.FieldImagePromptStyleStatus, ImagePromptStyleStatusEnabled)
+	}
+
+	items, err := model.NewImagePromptStyleModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	styles := make([]ImagePromptStyle, 0, len(items))
+	for _, item := range items {
+		styles = append(styles, imagePromptStyleFromModel(item))
+	}
+
+	return styles, nil
+}
+
+// Style 查询已启用的提示语风格
+func (repo *ImagePromptRepo) Style(ctx context.Context, id int64) (*ImagePromptStyle, error) {
+	item, err := model.NewImagePromptStyleModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldImagePromptStyleId, id).
+		Where(model.FieldImagePromptStyleStatus, ImagePromptStyleStatusEnabled))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	style := imagePromptStyleFromModel(*item)
+	return &style, nil
+}
+
+func imagePromptStyleKV(style ImagePromptStyle) query.KV {
+	return query.KV{
+		model.FieldImagePromptStyleName:        style.Name,
+		model.FieldImagePromptStyleDescription: style.Description,
+		model.FieldImagePromptStyleInstruction: style.Instruction,
+		model.FieldImagePromptStylePreview:     style.Preview,
+		model.FieldImagePromptStyleSortOrder:   style.SortOrder,
+		model.FieldImagePromptStyleStatus:      style.Status,
+	}
+}
+
+// CreateStyle 新增提示语风格
+func (repo *ImagePromptRepo) CreateStyle(ctx context.Context, style ImagePromptStyle) (int64, error) {
+	return model.NewImagePromptStyleModel(repo.db).Create(ctx, imagePromptStyleKV(style))
+}
+
+// UpdateStyle 更新提示语风格
+func (repo *ImagePromptRepo) UpdateStyle(ctx context.Context, id int64, style ImagePromptStyle) error {
+	_, err := model.NewImagePromptStyleModel(repo.db).UpdateFields(ctx, imagePromptStyleKV(style), query.Builder().Where(model.FieldImagePromptStyleId, id))
+	return err
+}
+
+// DeleteStyle 删除提示语风格
+func (repo *ImagePromptRepo) DeleteStyle(ctx context.Context, id int64) error {
+	_, err := model.NewImagePromptStyleModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldImagePromptStyleId, id))
+	return err
+}
+
+// AddEnhancement 保存提示语优化记录
+func (repo *ImagePromptRepo) AddEnhancement(ctx context.Context, item ImagePromptEnhancement) (int64, error) {
+	return model.NewImagePromptEnhancementModel(repo.db).Create(ctx, query.KV{
+		model.FieldImagePromptEnhancementUserId:         item.UserID,
+		model.FieldImagePromptEnhancementStyleId:        item.StyleID,
+		model.FieldImagePromptEnhancementTarget:         item.Target,
+		model.FieldImagePromptEnhancementPrompt:         item.Prompt,
+		model.FieldImagePromptEnhancementEnhancedPrompt: item.EnhancedPrompt,
+		model.FieldImagePromptEnhancementNegativePrompt: item.NegativePrompt,
+		model.FieldImagePromptEnhancementModel:          item.Model,
+		model.FieldImagePromptEnhancementTokenConsumed:  item.TokenConsumed,
+		model.FieldImagePromptEnhancementQuotaConsumed:  item.QuotaConsumed,
+	})
+}
+
+// Enhancement 查询用户的提示语优化记录
+func (repo *ImagePromptRepo) Enhancement(ctx context.Context, userID, id int64) (*ImagePromptEnhancement, error) {
+	item, err := model.NewImagePromptEnhancementModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldImagePromptEnhancementId, id).
+		Where(model.FieldImagePromptEnhancementUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &ImagePromptEnhancement{
+		ID:             item.Id.ValueOrZero(),
+		UserID:         item.UserId.ValueOrZero(),
+		StyleID:        item.StyleId.ValueOrZero(),
+		Target:         item.Target.ValueOrZero(),
+		Prompt:         item.Prompt.ValueOrZero(),
+		EnhancedPrompt: item.EnhancedPrompt.ValueOrZero(),
+		NegativePrompt: item.NegativePrompt.ValueOrZero(),
+		Model:          item.Model.ValueOrZero(),
+		TokenConsumed:  item.TokenConsumed.ValueOrZero(),
+		QuotaConsumed:  item.QuotaConsumed.ValueOrZero(),
+	}, nil
+}
@@ -0,0 +1,263 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 图片模型（Checkpoint）、风格与 LoRA 的状态
+const (
+	ImageStyleStatusEnabled  = 1
+	ImageStyleStatusDisabled = 2
+)
+
+// ImageLora Stable Diffusion 的 LoRA 模型
+type ImageLora struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// File Stable Diffusion 服务端的 LoRA 文件名（不含扩展名）
+	File string `json:"file"`
+	// SourceURL LoRA 模型的来源地址
+	SourceURL string `json:"source_url,omitempty"`
+	// BaseModel 适用的基础模型，如 sd15、sdxl
+	BaseModel string `json:"base_model,omitempty"`
+	// TriggerWords 触发词，多个使用英文逗号分隔
+	TriggerWords  string  `json:"trigger_words,omitempty"`
+	DefaultWeight float64 `json:"default_weight"`
+	PreviewImage  string  `json:"preview_image,omitempty"`
+	Status        int64   `json:"status"`
+}
+
+// PromptTag LoRA 在提示语中的加载语法，weight 为 0 时使用默认权重
+func (lora ImageLora) PromptTag(weight float64) string {
+	if weight == 0 {
+		weight = lora.DefaultWeight
+	}
+
+	tag := fmt.Sprintf("<lora:%s:%s>", lora.File, strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", weight), "0"), "."))
+	if words := strings.TrimSpace(strings.Trim(strings.TrimSpace(lora.TriggerWords), ",")); words != "" {
+		tag += "," + words
+	}
+
+	return tag
+}
+
+func imageLoraFromModel(item model.ImageLoraN) ImageLora {
+	return ImageLora{
+		ID:            item.Id.ValueOrZero(),
+		Name:          item.Name.ValueOrZero(),
+		File:          item.File.ValueOrZero(),
+		SourceURL:     item.SourceUrl.ValueOrZero(),
+		BaseModel:     item.BaseModel.ValueOrZero(),
+		TriggerWords:  item.TriggerWords.ValueOrZero(),
+		DefaultWeight: item.DefaultWeight.ValueOrZero(),
+		PreviewImage:  item.PreviewImage.ValueOrZero(),
+		Status:        item.Status.ValueOrZero(),
+	}
+}
+
+// Loras 查询 LoRA 列表，enabledOnly 为 true 时只返回启用的 LoRA
+func (r *CreativeRepo) Loras(ctx context.Context, enabledOnly bool) ([]ImageLora, error) {
+	q := query.Builder().OrderBy(model.FieldImageLoraId, "DESC")
+	if enabledOnly {
+		q = q.Where(model.FieldImageLoraStatus, ImageStyleStatusEnabled)
+	}
+
+	items, err := model.NewImageLoraModel(r.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	loras := make([]ImageLora, 0, len(items))
+	for _, item := range items {
+		loras = append(loras, imageLoraFromModel(item))
+	}
+
+	return loras, nil
+}
+
+// LorasByIDs 查询指定的 LoRA，只返回启用的 LoRA
+func (r *CreativeRepo) LorasByIDs(ctx context.Context, ids []int64) (map[int64]ImageLora, error) {
+	ret := make(map[int64]ImageLora)
+	if len(ids) == 0 {
+		return ret, nil
+	}
+
+	items, err := model.NewImageLoraModel(r.db).Get(ctx, query.Builder().
+		WhereIn(model.FieldImageLoraId, ids).
+		Where(model.FieldImageLoraStatus, ImageStyleStatusEnabled))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		ret[item.Id.ValueOrZero()] = imageLoraFromModel(item)
+	}
+
+	return ret, nil
+}
+
+func imageLoraKV(lora ImageLora) query.KV {
+	return query.KV{
+		model.FieldImageLoraName:          lora.Name,
+		model.FieldImageLoraFile:          lora.File,
+		model.FieldImageLoraSourceUrl:     lora.SourceURL,
+		model.FieldImageLoraBaseModel:     lora.BaseModel,
+		model.FieldImageLoraTriggerWords:  lora.TriggerWords,
+		model.FieldImageLoraDefaultWeight: lora.DefaultWeight,
+		model.FieldImageLoraPreviewImage:  lora.PreviewImage,
+		model.FieldImageLoraStatus:        lora.Status,
+	}
+}
+
+// CreateLora 新增 LoRA
+func (r *CreativeRepo) CreateLora(ctx context.Context, lora ImageLora) (int64, error) {
+	return model.NewImageLoraModel(r.db).Create(ctx, imageLoraKV(lora))
+}
+
+// UpdateLora 更新 LoRA
+func (r *CreativeRepo) UpdateLora(ctx context.Context, id int64, lora ImageLora) error {
+	_, err := model.NewImageLoraModel(r.db).UpdateFields(ctx, imageLoraKV(lora), query.Builder().Where(model.FieldImageLoraId, id))
+	return err
+}
+
+// DeleteLora 删除 LoRA
+func (r *CreativeRepo) DeleteLora(ctx context.Context, id int64) error {
+	_, err := model.NewImageLoraModel(r.db).Delete(ctx, query.Builder().Where(model.FieldImageLoraId, id))
+	return err
+}
+
+// AllModels 查询所有的图片模型（Checkpoint），包含已禁用的模型，用于管理后台
+func (r *CreativeRepo) AllModels(ctx context.Context) ([]ImageModel, error) {
+	items, err := model.NewImageModelModel(r.db).Get(ctx, query.Builder().
+		OrderBy(model.FieldImageModelVendor, "ASC").
+		OrderBy(model.FieldImageModelModelName, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]ImageModel, 0, len(items))
+	for _, item := range items {
+		m := ImageModel{ImageModel: item.ToImageModel()}
+		if m.Meta != "" {
+			if err := json.Unmarshal([]byte(m.Meta), &m.ImageMeta); err != nil {
+				return nil, fmt.Errorf("unmarshal meta of image model %d failed: %w", m.Id, err)
+			}
+		}
+
+		ret = append(ret, m)
+	}
+
+	return ret, nil
+}
+
+func imageModelKV(m ImageModel) query.KV {
+	meta, _ := json.Marshal(m.ImageMeta)
+	return query.KV{
+		model.FieldImageModelModelId:      m.ModelId,
+		model.FieldImageModelModelName:    m.ModelName,
+		model.FieldImageModelVendor:       m.Vendor,
+		model.FieldImageModelRealModel:    m.RealModel,
+		model.FieldImageModelMeta:         string(meta),
+		model.FieldImageModelPreviewImage: m.PreviewImage,
+		model.FieldImageModelDescription:  m.Description,
+		model.FieldImageModelStatus:       m.Status,
+	}
+}
+
+// CreateModel 新增图片模型（Checkpoint）
+func (r *CreativeRepo) CreateModel(ctx context.Context, m ImageModel) (int64, error) {
+	exist, err := model.NewImageModelModel(r.db).Exists(ctx, query.Builder().Where(model.FieldImageModelModelId, m.ModelId))
+	if err != nil {
+		return 0, err
+	}
+
+	if exist {
+		return 0, ErrImageModelExists
+	}
+
+	return model.NewImageModelModel(r.db).Create(ctx, imageModelKV(m))
+}
+
+// ErrImageModelExists 模型 ID 已经存在
+var ErrImageModelExists = errors.New("image model already exists")
+
+// UpdateModel 更新图片模型（Checkpoint）
+func (r *CreativeRepo) UpdateModel(ctx context.Context, id int64, m ImageModel) error {
+	exist, err := model.NewImageModelModel(r.db).Exists(ctx, query.Builder().
+		Where(model.FieldImageModelModelId, m.ModelId).
+		Where(model.FieldImageModelId, "!=", id))
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		return ErrImageModelExists
+	}
+
+	_, err = model.NewImageModelModel(r.db).UpdateFields(ctx, imageModelKV(m), query.Builder().Where(model.FieldImageModelId, id))
+	return err
+}
+
+// DeleteModel 删除图片模型（Checkpoint）
+func (r *CreativeRepo) DeleteModel(ctx context.Context, id int64) error {
+	_, err := model.NewImageModelModel(r.db).Delete(ctx, query.Builder().Where(model.FieldImageModelId, id))
+	return err
+}
+
+// AllFilters 查询所有的风格，包含已禁用的风格，用于管理后台
+func (r *CreativeRepo) AllFilters(ctx context.Context) ([]ImageFilter, error) {
+	items, err := model.NewImageFilterModel(r.db).Get(ctx, query.Builder().OrderBy(model.FieldImageFilterId, "DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]ImageFilter, 0, len(items))
+	for _, item := range items {
+		f := ImageFilter{ImageFilter: item.ToImageFilter()}
+		if f.Meta != "" {
+			if err := json.Unmarshal([]byte(f.Meta), &f.ImageMeta); err != nil {
+				return nil, fmt.Errorf("unmarshal meta of image filter %d failed: %w", f.Id, err)
+			}
+		}
+
+		ret = append(ret, f)
+	}
+
+	return ret, nil
+}
+
+func imageFilterKV(f ImageFilter) query.KV {
+	meta, _ := json.Marshal(f.ImageMeta)
+	return query.KV{
+		model.FieldImageFilterName:         f.Name,
+		model.FieldImageFilterModelId:      f.ModelId,
+		model.FieldImageFilterMeta:         string(meta),
+		model.FieldImageFilterPreviewImage: f.PreviewImage,
+		model.FieldImageFilterDescription:  f.Description,
+		model.FieldImageFilterStatus:       f.Status,
+	}
+}
+
+// CreateFilter 新增风格
+func (r *CreativeRepo) CreateFilter(ctx context.Context, f ImageFilter) (int64, error) {
+	return model.NewImageFilterModel(r.db).Create(ctx, imageFilterKV(f))
+}
+
+// UpdateFilter 更新风格
+func (r *CreativeRepo) UpdateFilter(ctx context.Context, id int64, f ImageFilter) error {
+	_, err := model.NewImageFilterModel(r.db).UpdateFields(ctx, imageFilterKV(f), query.Builder().Where(model.FieldImageFilterId, id))
+	return err
+}
+
+// DeleteFilter 删除风格
+func (r *CreativeRepo) DeleteFilter(ctx context.Context, id int64) error {
+	_, err := model.NewImageFilterModel(r.db).Delete(ctx, query.Builder().Where(model.FieldImageFilterId, id))
+	return err
+}
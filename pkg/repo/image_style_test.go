@@ -0,0 +1,16 @@
+package repo_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestImageLoraPromptTag(t *testing.T) {
+	lora := repo.ImageLora{File: "ghibli_style_v2", TriggerWords: " ghibli, anime ,", DefaultWeight: 0.8}
+
+	assert.Equal(t, "<lora:ghibli_style_v2:0.8>,ghibli, anime", lora.PromptTag(0))
+	assert.Equal(t, "<lora:ghibli_style_v2:1.25>,ghibli, anime", lora.PromptTag(1.25))
+	assert.Equal(t, "<lora:ghibli_style_v2:1>", repo.ImageLora{File: "ghibli_style_v2", DefaultWeight: 1}.PromptTag(0))
+}
@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// IndexSpec 期望存在的索引，只要表中存在以 Columns 为前缀的索引即视为满足
+type IndexSpec struct {
+	Table   string
+	Columns []string
+}
+
+func (spec IndexSpec) String() string {
+	return fmt.Sprintf("%s(%s)", spec.Table, strings.Join(spec.Columns, ", "))
+}
+
+// expectedIndexes 高频查询依赖的索引，缺失时查询会退化为全表扫描
+var expectedIndexes = []IndexSpec{
+	// GetChatMessages：按 group_id + user_id 过滤后按 id 倒序分页
+	{Table: "chat_group_message", Columns: []string{"group_id", "user_id", "id"}},
+	// 群组成员查询：按 group_id + user_id + status 过滤
+	{Table: "chat_group_member", Columns: []string{"group_id", "user_id", "status"}},
+}
+
+// AuditIndexes 检查当前数据库中缺失的索引，迁移未执行或索引被手动删除时返回缺失的索引
+func AuditIndexes(ctx context.Context, db *sql.DB) ([]IndexSpec, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		"SELECT TABLE_NAME, INDEX_NAME, COLUMN_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query index statistics failed: %w", err)
+	}
+	defer rows.Close()
+
+	// 表名 -> 索引名 -> 索引列
+	existing := make(map[string]map[string][]string)
+	for rows.Next() {
+		var table, index, column string
+		if err := rows.Scan(&table, &index, &column); err != nil {
+			return nil, fmt.Errorf("scan index statistics failed: %w", err)
+		}
+
+		if existing[table] == nil {
+			existing[table] = make(map[string][]string)
+		}
+
+		existing[table][index] = append(existing[table][index], strings.ToLower(column))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query index statistics failed: %w", err)
+	}
+
+	return missingIndexes(expectedIndexes, existing), nil
+}
+
+func missingIndexes(expected []IndexSpec, existing map[string]map[string][]string) []IndexSpec {
+	missing := make([]IndexSpec, 0)
+	for _, spec := range expected {
+		satisfied := false
+		for _, columns := range existing[spec.Table] {
+			if hasColumnPrefix(columns, spec.Columns) {
+				satisfied = true
+				break
+			}
+		}
+
+		if !satisfied {
+			missing = append(missing, spec)
+		}
+	}
+
+	return missing
+}
+
+func hasColumnPrefix(columns, prefix []string) bool {
+	if len(columns) < len(prefix) {
+		return false
+	}
+
+	for i, col := range prefix {
+		if columns[i] != col {
+			return false
+		}
+	}
+
+	return true
+}
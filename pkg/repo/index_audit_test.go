@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestMissingIndexes(t *testing.T) {
+	expected := []IndexSpec{
+		{Table: "chat_group_message", Columns: []string{"group_id", "user_id", "id"}},
+		{Table: "chat_group_member", Columns: []string{"group_id", "user_id", "status"}},
+	}
+
+	missing := missingIndexes(expected, map[string]map[string][]string{
+		"chat_group_message": {
+			"PRIMARY":           {"id"},
+			"idx_group_user_id": {"group_id", "user_id", "id", "role"},
+		},
+		"chat_group_member": {
+			"chat_group_member_group_user_idx": {"group_id", "user_id"},
+			"idx_status_group_user":            {"status", "group_id", "user_id"},
+		},
+	})
+
+	assert.EqualValues(t, 1, len(missing))
+	assert.Equal(t, "chat_group_member(group_id, user_id, status)", missing[0].String())
+
+	assert.EqualValues(t, 2, len(missingIndexes(expected, map[string]map[string][]string{})))
+}
@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// 关键词命中后的动作
+const (
+	KeywordFilterActionBlock  = 1
+	KeywordFilterActionMask   = 2
+	KeywordFilterActionReview = 3
+)
+
+// 关键词生效范围
+const (
+	KeywordFilterScopePrompt = 1
+	KeywordFilterScopeOutput = 2
+	KeywordFilterScopeAll    = KeywordFilterScopePrompt | KeywordFilterScopeOutput
+)
+
+// 关键词规则状态
+const (
+	KeywordFilterStatusEnabled  = 1
+	KeywordFilterStatusDisabled = 2
+)
+
+// 关键词命中记录审核状态
+const (
+	KeywordFilterHitStatusPending  = 1
+	KeywordFilterHitStatusReviewed = 2
+)
+
+// KeywordFilterRepo 部署方自定义的关键词过滤规则
+type KeywordFilterRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewKeywordFilterRepo create a new KeywordFilterRepo
+func NewKeywordFilterRepo(db *sql.DB, conf *config.Config) *KeywordFilterRepo {
+	return &KeywordFilterRepo{db: db, conf: conf}
+}
+
+// KeywordFilterRule 关键词规则
+type KeywordFilterRule struct {
+	Keyword string `json:"keyword"`
+	IsRegex bool   `json:"is_regex"`
+	Action  int64  `json:"action"`
+	Scope   int64  `json:"scope"`
+	Note    string `json:"note,omitempty"`
+}
+
+// Rules 查询所有关键词规则，enabledOnly 为 true 时只返回启用的规则
+func (repo *KeywordFilterRepo) Rules(ctx context.Context, enabledOnly bool) ([]model.KeywordFilter, error) {
+	q := query.Builder().OrderBy(model.FieldKeywordFilterId, "ASC")
+	if enabledOnly {
+		q = q.Where(model.FieldKeywordFilterStatus, KeywordFilterStatusEnabled)
+	}
+
+	items, err := model.NewKeywordFilterModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.KeywordFilterN, _ int) model.KeywordFilter {
+		return item.ToKeywordFilter()
+	}), nil
+}
+
+// Import 批量导入关键词规则
+func (repo *KeywordFilterRepo) Import(ctx context.Context, rules []KeywordFilterRule) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		for _, rule := range rules {
+			if _, err := model.NewKeywordFilterModel(tx).Create(ctx, query.KV{
+				model.FieldKeywordFilterKeyword: rule.Keyword,
+				model.FieldKeywordFilterIsRegex: ternary.If(rule.IsRegex, 1, 0),
+				model.FieldKeywordFilterAction:  rule.Action,
+				model.FieldKeywordFilterScope:   rule.Scope,
+				model.FieldKeywordFilterStatus:  KeywordFilterStatusEnabled,
+				model.FieldKeywordFilterNote:    rule.Note,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Update 更新关键词规则
+func (repo *KeywordFilterRepo) Update(ctx context.Context, id int64, rule KeywordFilterRule, status int64) error {
+	_, err := model.NewKeywordFilterModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{
+			model.FieldKeywordFilterKeyword: rule.Keyword,
+			model.FieldKeywordFilterIsRegex: ternary.If(rule.IsRegex, 1, 0),
+			model.FieldKeywordFilterAction:  rule.Action,
+			model.FieldKeywordFilterScope:   rule.Scope,
+			model.FieldKeywordFilterStatus:  status,
+			model.FieldKeywordFilterNote:    rule.Note,
+		},
+		query.Builder().Where(model.FieldKeywordFilterId, id),
+	)
+	return err
+}
+
+// Delete 删除关键词规则
+func (repo *KeywordFilterRepo) Delete(ctx context.Context, id int64) error {
+	_, err := model.NewKeywordFilterModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldKeywordFilterId, id))
+	return err
+}
+
+// AddHit 记录关键词命中，同时更新规则的命中统计
+func (repo *KeywordFilterRepo) AddHit(ctx context.Context, filterID, userID int64, action, scope int64, content string) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewKeywordFilterHitModel(tx).Create(ctx, query.KV{
+			model.FieldKeywordFilterHitFilterId: filterID,
+			model.FieldKeywordFilterHitUserId:   userID,
+			model.FieldKeywordFilterHitAction:   action,
+			model.FieldKeywordFilterHitScope:    scope,
+			model.FieldKeywordFilterHitContent:  content,
+			model.FieldKeywordFilterHitStatus: ternary.If(
+				action == KeywordFilterActionReview,
+				KeywordFilterHitStatusPending,
+				KeywordFilterHitStatusReviewed,
+			),
+		}); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "UPDATE keyword_filter SET hit_count = hit_count + 1, last_hit_at = NOW() WHERE id = ?", filterID)
+		return err
+	})
+}
+
+// Hits 查询关键词命中记录，status 为 0 时查询所有状态，filterID 为 0 时查询所有规则
+func (repo *KeywordFilterRepo) Hits(ctx context.Context, filterID, status, limit int64) ([]model.KeywordFilterHit, error) {
+	q := query.Builder().OrderBy(model.FieldKeywordFilterHitId, "DESC").Limit(limit)
+	if filterID > 0 {
+		q = q.Where(model.FieldKeywordFilterHitFilterId, filterID)
+	}
+
+	if status > 0 {
+		q = q.Where(model.FieldKeywordFilterHitStatus, status)
+	}
+
+	items, err := model.NewKeywordFilterHitModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.KeywordFilterHitN, _ int) model.KeywordFilterHit {
+		return item.ToKeywordFilterHit()
+	}), nil
+}
+
+// MarkHitReviewed 将命中记录标记为已审核
+func (repo *KeywordFilterRepo) MarkHitReviewed(ctx context.Context, id int64) error {
+	affected, err := model.NewKeywordFilterHitModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldKeywordFilterHitStatus: KeywordFilterHitStatusReviewed},
+		query.Builder().Where(model.FieldKeywordFilterHitId, id).Where(model.FieldKeywordFilterHitStatus, KeywordFilterHitStatusPending),
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 智慧果复式记账科目，钱包和欠费科目通过 owner_id 区分所属的用户或者组织
+const (
+	// LedgerAccountUserWallet 用户钱包，贷方余额，等于用户所有配额记录的剩余数量（rest）之和
+	LedgerAccountUserWallet = "user_wallet"
+	// LedgerAccountOrgWallet 组织共享钱包，贷方余额，等于组织所有配额记录的剩余数量之和
+	LedgerAccountOrgWallet = "org_wallet"
+	// LedgerAccountUserDebt 用户余额不足时产生的欠费，借方余额
+	LedgerAccountUserDebt = "user_debt"
+	// LedgerAccountOrgDebt 组织共享钱包余额不足时产生的欠费，借方余额
+	LedgerAccountOrgDebt = "org_debt"
+	// LedgerAccountRevenue 购买发放的智慧果
+	LedgerAccountRevenue = "revenue"
+	// LedgerAccountGift 赠送的智慧果，包括注册、绑定手机、邀请、签到、成就、活动奖励以及管理员发放
+	LedgerAccountGift = "gift"
+	// LedgerAccountRefund 退款，上游服务异常退还的智慧果记借方，支付退款扣回的智慧果记贷方
+	LedgerAccountRefund = "refund"
+	// LedgerAccountConsumption 用户使用消耗的智慧果
+	LedgerAccountConsumption = "consumption"
+	// LedgerAccountOpening 启用复式记账之前的期初余额
+	LedgerAccountOpening = "opening"
+)
+
+// 记账凭证的业务类型
+const (
+	LedgerKindPurchase = "purchase"
+	LedgerKindGift     = "gift"
+	LedgerKindConsume  = "consume"
+	LedgerKindRefund   = "refund"
+	LedgerKindTransfer = "transfer"
+	LedgerKindClawback = "clawback"
+)
+
+// ErrLedgerUnbalanced 记账凭证借贷不平衡
+var ErrLedgerUnbalanced = errors.New("ledger transaction is unbalanced")
+
+// LedgerEntry 记账分录，Debit 和 Credit 最多只有一个大于 0
+type LedgerEntry struct {
+	Account string `json:"account"`
+	OwnerID int64  `json:"owner_id"`
+	Debit   int64  `json:"debit"`
+	Credit  int64  `json:"credit"`
+}
+
+// Debit 借记分录
+func Debit(account string, ownerID, amount int64) LedgerEntry {
+	return LedgerEntry{Account: account, OwnerID: ownerID, Debit: amount}
+}
+
+// Credit 贷记分录
+func Credit(account string, ownerID, amount int64) LedgerEntry {
+	return LedgerEntry{Account: account, OwnerID: ownerID, Credit: amount}
+}
+
+// LedgerTxn 一笔余额变动对应的记账凭证
+type LedgerTxn struct {
+	Kind    string
+	Ref     string
+	Note    string
+	Entries []LedgerEntry
+}
+
+// Validate 检查凭证中的分录金额合法并且借贷平衡
+func (txn LedgerTxn) Validate() error {
+	var debit, credit int64
+	for _, entry := range txn.Entries {
+		if entry.Debit < 0 || entry.Credit < 0 || (entry.Debit > 0 && entry.Credit > 0) {
+			return fmt.Errorf("%w: invalid entry %s(%d) debit=%d credit=%d", ErrLedgerUnbalanced, entry.Account, entry.OwnerID, entry.Debit, entry.Credit)
+		}
+
+		debit += entry.Debit
+		credit += entry.Credit
+	}
+
+	if debit != credit {
+		return fmt.Errorf("%w: %s debit=%d credit=%d", ErrLedgerUnbalanced, txn.Kind, debit, credit)
+	}
+
+	return nil
+}
+
+// walletAccount 配额所属的钱包科目，orgID 大于 0 时为组织共享钱包
+func walletAccount(orgID, userID int64) (string, int64) {
+	if orgID > 0 {
+		return LedgerAccountOrgWallet, orgID
+	}
+
+	return LedgerAccountUserWallet, userID
+}
+
+// issueLedger 向钱包发放智慧果：借记来源科目，贷记钱包
+func issueLedger(kind, source, wallet string, ownerID, amount int64, ref, note string) LedgerTxn {
+	return LedgerTxn{
+		Kind:    kind,
+		Ref:     ref,
+		Note:    note,
+		Entries: []LedgerEntry{Debit(source, 0, amount), Credit(wallet, ownerID, amount)},
+	}
+}
+
+// consumeLedger 使用消耗智慧果：借记钱包中实际扣除的部分以及欠费，贷记消耗
+func consumeLedger(wallet, debtAccount string, ownerID, used, debt int64, tag string) LedgerTxn {
+	return LedgerTxn{
+		Kind: LedgerKindConsume,
+		Ref:  tag,
+		Entries: []LedgerEntry{
+			Debit(wallet, ownerID, used-debt),
+			Debit(debtAccount, ownerID, debt),
+			Credit(LedgerAccountConsumption, 0, used),
+		},
+	}
+}
+
+// postLedger 在余额变动所在的事务中写入记账凭证，借贷不平衡时返回错误，整个事务随之回滚
+func postLedger(ctx context.Context, tx query.Database, txn LedgerTxn) error {
+	if err := txn.Validate(); err != nil {
+		return err
+	}
+
+	txnID, err := uuid.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("generate ledger txn id failed: %w", err)
+	}
+
+	for _, entry := range txn.Entries {
+		if entry.Debit == 0 && entry.Credit == 0 {
+			continue
+		}
+
+		if _, err := model.NewCoinLedgerModel(tx).Create(ctx, query.KV{
+			model.FieldCoinLedgerTxnId:   txnID,
+			model.FieldCoinLedgerAccount: entry.Account,
+			model.FieldCoinLedgerOwnerId: entry.OwnerID,
+			model.FieldCoinLedgerDebit:   entry.Debit,
+			model.FieldCoinLedgerCredit:  entry.Credit,
+			model.FieldCoinLedgerKind:    txn.Kind,
+			model.FieldCoinLedgerRef:     txn.Ref,
+			model.FieldCoinLedgerNote:    txn.Note,
+		}); err != nil {
+			return fmt.Errorf("create ledger entry failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LedgerRepo 智慧果复式记账
+type LedgerRepo struct {
+	db *sql.DB
+}
+
+// NewLedgerRepo create a new LedgerRepo
+func NewLedgerRepo(db *sql.DB) *LedgerRepo {
+	return &LedgerRepo{db: db}
+}
+
+// LedgerAccountTotal 科目的借方、贷方发生额合计
+type LedgerAccountTotal struct {
+	Account string `json:"account"`
+	Debit   int64  `json:"debit"`
+	Credit  int64  `json:"credit"`
+}
+
+// TrialBalance 试算平衡结果
+type TrialBalance struct {
+	// Accounts 截止到结束时间各科目的累计发生额
+	Accounts []LedgerAccountTotal `json:"accounts"`
+	Debit    int64                `json:"debit"`
+	Credit   int64                `json:"credit"`
+	// Entries 检查期间内的分录数量
+	Entries int64 `json:"entries"`
+	// Unbalanced 检查期间内借贷不平衡的凭证号（最多 100 个）
+	Unbalanced []string `json:"unbalanced"`
+}
+
+// Balanced 累计借贷平衡，并且检查期间内没有不平衡的凭证
+func (tb TrialBalance) Balanced() bool {
+	return tb.Debit == tb.Credit && len(tb.Unbalanced) == 0
+}
+
+// TrialBalance 试算平衡：汇总截止到 endAt 所有科目的借贷发生额，并找出 [startAt, endAt) 期间借贷不平衡的凭证
+func (repo *LedgerRepo) TrialBalance(ctx context.Context, startAt, endAt time.Time) (*TrialBalance, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT account, SUM(debit), SUM(credit) FROM coin_ledger WHERE created_at < ? GROUP BY account ORDER BY account", endAt)
+	if err != nil {
+		return nil, err
+	}
+
+	tb := TrialBalance{Accounts: make([]LedgerAccountTotal, 0), Unbalanced: make([]string, 0)}
+	for rows.Next() {
+		var item LedgerAccountTotal
+		if err := rows.Scan(&item.Account, &item.Debit, &item.Credit); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+
+		tb.Debit += item.Debit
+		tb.Credit += item.Credit
+		tb.Accounts = append(tb.Accounts, item)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM coin_ledger WHERE created_at >= ? AND created_at < ?", startAt, endAt).Scan(&tb.Entries); err != nil {
+		return nil, err
+	}
+
+	// 同一个凭证的分录可能跨越检查期间的边界，按照凭证的全部分录检查
+	rows, err = repo.db.QueryContext(
+		ctx,
+		"SELECT txn_id FROM coin_ledger WHERE txn_id IN (SELECT DISTINCT txn_id FROM coin_ledger WHERE created_at >= ? AND created_at < ?) GROUP BY txn_id HAVING SUM(debit) != SUM(credit) LIMIT 100",
+		startAt, endAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var txnID string
+		if err := rows.Scan(&txnID); err != nil {
+			return nil, err
+		}
+
+		tb.Unbalanced = append(tb.Unbalanced, txnID)
+	}
+
+	return &tb, rows.Err()
+}
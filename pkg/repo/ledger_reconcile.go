@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// ledgerRepairNote 以记账分录为准修正余额时创建的配额备注
+const ledgerRepairNote = "余额校正"
+
+// ledgerRepairValidity 修正时补回的智慧果有效期
+const ledgerRepairValidity = 365 * 24 * time.Hour
+
+// WalletDrift 钱包在配额记录中的余额与记账分录计算出的余额不一致
+type WalletDrift struct {
+	Account string `json:"account"`
+	OwnerID int64  `json:"owner_id"`
+	// Ledger 记账分录计算出的余额（贷方 - 借方）
+	Ledger int64 `json:"ledger"`
+	// Cached 配额记录的剩余数量之和（包括已经过期的配额）
+	Cached   int64 `json:"cached"`
+	Repaired bool  `json:"repaired"`
+}
+
+// Drift 配额记录比记账分录多出的数量，负数表示配额记录少于记账分录
+func (d WalletDrift) Drift() int64 {
+	return d.Cached - d.Ledger
+}
+
+// CompareWalletBalances 比较每个钱包的记账余额与配额余额，返回不一致的钱包，差额大的排在前面
+func CompareWalletBalances(account string, ledger, cached map[int64]int64) []WalletDrift {
+	drifts := make([]WalletDrift, 0)
+	for ownerID, balance := range ledger {
+		if cached[ownerID] != balance {
+			drifts = append(drifts, WalletDrift{Account: account, OwnerID: ownerID, Ledger: balance, Cached: cached[ownerID]})
+		}
+	}
+
+	for ownerID, balance := range cached {
+		if _, ok := ledger[ownerID]; !ok && balance != 0 {
+			drifts = append(drifts, WalletDrift{Account: account, OwnerID: ownerID, Cached: balance})
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		di, dj := abs(drifts[i].Drift()), abs(drifts[j].Drift())
+		if di != dj {
+			return di > dj
+		}
+
+		return drifts[i].OwnerID < drifts[j].OwnerID
+	})
+
+	return drifts
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+// walletTable 钱包科目对应的配额表以及所属字段
+func walletTable(account string) (table, owner string, err error) {
+	switch account {
+	case LedgerAccountUserWallet:
+		return "quota", "user_id", nil
+	case LedgerAccountOrgWallet:
+		return "org_quota", "org_id", nil
+	}
+
+	return "", "", fmt.Errorf("unsupported wallet account: %s", account)
+}
+
+func sumByOwner(ctx context.Context, db query.Database, sqlStr string, args ...any) (map[int64]int64, error) {
+	rows, err := db.QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(map[int64]int64)
+	for rows.Next() {
+		var ownerID, value int64
+		if err := rows.Scan(&ownerID, &value); err != nil {
+			return nil, err
+		}
+
+		ret[ownerID] = value
+	}
+
+	return ret, rows.Err()
+}
+
+func queryInt64(ctx context.Context, db query.Database, sqlStr string, args ...any) (int64, error) {
+	rows, err := db.QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var value int64
+	if rows.Next() {
+		if err := rows.Scan(&value); err != nil {
+			return 0, err
+		}
+	}
+
+	return value, rows.Err()
+}
+
+// WalletDrifts 批量比较所有钱包的余额，两次查询之间发生的余额变动可能导致误报，需要通过 ReconcileWallet 逐个确认
+func (repo *LedgerRepo) WalletDrifts(ctx context.Context, account string) ([]WalletDrift, error) {
+	table, owner, err := walletTable(account)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := sumByOwner(ctx, repo.db, "SELECT owner_id, SUM(credit) - SUM(debit) FROM coin_ledger WHERE account = ? GROUP BY owner_id", account)
+	if err != nil {
+		return nil, fmt.Errorf("sum ledger balances failed: %w", err)
+	}
+
+	cached, err := sumByOwner(ctx, repo.db, fmt.Sprintf("SELECT %s, SUM(rest) FROM %s GROUP BY %s", owner, table, owner))
+	if err != nil {
+		return nil, fmt.Errorf("sum quota balances failed: %w", err)
+	}
+
+	return CompareWalletBalances(account, ledger, cached), nil
+}
+
+// ReconcileWallet 锁定钱包的配额记录后重新比较余额，余额一致时返回 nil
+//
+// repair 为 true 时以记账分录为准修正：创建一条剩余数量为差额的配额记录（差额为负时作为欠费，与退款扣回的处理方式一致），不写入记账分录
+func (repo *LedgerRepo) ReconcileWallet(ctx context.Context, account string, ownerID int64, repair bool) (*WalletDrift, error) {
+	table, owner, err := walletTable(account)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift *WalletDrift
+	err = eloquent.Transaction(repo.db, func(tx query.Database) error {
+		// 锁定配额记录，期间其它余额变动（同时写入配额记录和记账分录）需要等待，保证两边读到的是同一时刻的数据
+		cached, err := queryInt64(ctx, tx, fmt.Sprintf("SELECT COALESCE(SUM(rest), 0) FROM %s WHERE %s = ? FOR UPDATE", table, owner), ownerID)
+		if err != nil {
+			return err
+		}
+
+		ledger, err := queryInt64(ctx, tx, "SELECT COALESCE(SUM(credit) - SUM(debit), 0) FROM coin_ledger WHERE account = ? AND owner_id = ? LOCK IN SHARE MODE", account, ownerID)
+		if err != nil {
+			return err
+		}
+
+		if cached == ledger {
+			return nil
+		}
+
+		drift = &WalletDrift{Account: account, OwnerID: ownerID, Ledger: ledger, Cached: cached}
+		if !repair {
+			return nil
+		}
+
+		diff := ledger - cached
+		granted, endAt := int64(0), refundDebtEndAt
+		if diff > 0 {
+			granted, endAt = diff, TimeInDate(time.Now().Add(ledgerRepairValidity))
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			fmt.Sprintf("INSERT INTO %s (%s, quota, rest, note, payment_id, period_start_at, period_end_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", table, owner),
+			ownerID, granted, diff, ledgerRepairNote, "ledger-repair", NowInDate(), endAt, time.Now(), time.Now(),
+		); err != nil {
+			return fmt.Errorf("create repair quota failed: %w", err)
+		}
+
+		drift.Repaired = true
+		return nil
+	})
+
+	return drift, err
+}
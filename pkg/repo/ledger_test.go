@@ -0,0 +1,67 @@
+package repo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestLedgerTxn_Validate(t *testing.T) {
+	// 消耗 100，钱包中只有 70，其余 30 记为欠费
+	consume := repo.LedgerTxn{
+		Kind: repo.LedgerKindConsume,
+		Entries: []repo.LedgerEntry{
+			repo.Debit(repo.LedgerAccountUserWallet, 1, 70),
+			repo.Debit(repo.LedgerAccountUserDebt, 1, 30),
+			repo.Credit(repo.LedgerAccountConsumption, 0, 100),
+		},
+	}
+	assert.NoError(t, consume.Validate())
+
+	transfer := repo.LedgerTxn{
+		Kind: repo.LedgerKindTransfer,
+		Entries: []repo.LedgerEntry{
+			repo.Debit(repo.LedgerAccountUserWallet, 1, 50),
+			repo.Credit(repo.LedgerAccountUserWallet, 2, 49),
+		},
+	}
+	assert.True(t, errors.Is(transfer.Validate(), repo.ErrLedgerUnbalanced))
+
+	// 负数金额会让借贷看起来平衡，但不是合法的分录
+	negative := repo.LedgerTxn{
+		Kind: repo.LedgerKindGift,
+		Entries: []repo.LedgerEntry{
+			repo.Debit(repo.LedgerAccountGift, 0, -10),
+			repo.Credit(repo.LedgerAccountUserWallet, 1, -10),
+		},
+	}
+	assert.True(t, errors.Is(negative.Validate(), repo.ErrLedgerUnbalanced))
+
+	// 空凭证不产生分录
+	assert.NoError(t, repo.LedgerTxn{Kind: repo.LedgerKindConsume}.Validate())
+}
+
+func TestTrialBalance_Balanced(t *testing.T) {
+	assert.True(t, repo.TrialBalance{Debit: 100, Credit: 100}.Balanced())
+	assert.False(t, repo.TrialBalance{Debit: 100, Credit: 90}.Balanced())
+	assert.False(t, repo.TrialBalance{Debit: 100, Credit: 100, Unbalanced: []string{"txn"}}.Balanced())
+}
+
+func TestCompareWalletBalances(t *testing.T) {
+	ledger := map[int64]int64{1: 100, 2: 50, 3: 0, 4: 30}
+	cached := map[int64]int64{1: 100, 2: 80, 4: 10, 5: -5}
+
+	drifts := repo.CompareWalletBalances(repo.LedgerAccountUserWallet, ledger, cached)
+	assert.Equal(t, 3, len(drifts))
+
+	// 差额大的排在前面，只存在于配额记录中的钱包记账余额为 0
+	assert.Equal(t, int64(2), drifts[0].OwnerID)
+	assert.Equal(t, int64(30), drifts[0].Drift())
+	assert.Equal(t, int64(4), drifts[1].OwnerID)
+	assert.Equal(t, int64(-20), drifts[1].Drift())
+	assert.Equal(t, int64(5), drifts[2].OwnerID)
+	assert.Equal(t, int64(0), drifts[2].Ledger)
+	assert.Equal(t, repo.LedgerAccountUserWallet, drifts[2].Account)
+}
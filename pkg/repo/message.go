@@ -3,21 +3,27 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/msgcrypt"
 	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
 	"time"
 
 	"github.com/mylxsw/eloquent"
 	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
 	"gopkg.in/guregu/null.v3"
 )
 
 type MessageRepo struct {
-	db *sql.DB
+	db     *sql.DB
+	cipher *msgcrypt.Cipher
 }
 
-func NewMessageRepo(db *sql.DB) *MessageRepo {
-	return &MessageRepo{db: db}
+func NewMessageRepo(db *sql.DB, cipher *msgcrypt.Cipher) *MessageRepo {
+	return &MessageRepo{db: db, cipher: cipher}
 }
 
 type MessageRole int64
@@ -38,19 +44,35 @@ type MessageAddReq struct {
 	Model         string
 	Status        int64
 	Error         string
+	// Citations 回答引用的来源，JSON 格式
+	Citations string
+	// Structured 结构化输出时解析并校验后的 JSON
+	Structured string
+	// Incognito 房间是否开启了无痕模式，由调用方根据房间设置传入
+	Incognito bool
 }
 
+// Add 保存聊天消息，无痕模式的房间中消息不写入历史记录，只记录汇总的用量统计，此时返回的消息 ID 为 0
 func (r *MessageRepo) Add(ctx context.Context, req MessageAddReq) (int64, error) {
 	if req.Status == 0 {
 		req.Status = MessageStatusSucceed
 	}
 
+	if req.Incognito {
+		return 0, r.addIncognitoUsage(ctx, req)
+	}
+
+	encrypted, err := r.cipher.Encrypt(ctx, req.Message)
+	if err != nil {
+		return 0, fmt.Errorf("encrypt message failed: %w", err)
+	}
+
 	var id int64
 	kvs := query.KV{
 		model2.FieldChatMessagesUserId:        req.UserID,
 		model2.FieldChatMessagesRoomId:        req.RoomID,
 		model2.FieldChatMessagesRole:          req.Role,
-		model2.FieldChatMessagesMessage:       req.Message,
+		model2.FieldChatMessagesMessage:       encrypted,
 		model2.FieldChatMessagesQuotaConsumed: req.QuotaConsumed,
 		model2.FieldChatMessagesTokenConsumed: req.TokenConsumed,
 		model2.FieldChatMessagesStatus:        req.Status,
@@ -68,6 +90,14 @@ func (r *MessageRepo) Add(ctx context.Context, req MessageAddReq) (int64, error)
 		kvs[model2.FieldChatMessagesError] = req.Error
 	}
 
+	if req.Citations != "" {
+		kvs[model2.FieldChatMessagesCitations] = req.Citations
+	}
+
+	if req.Structured != "" {
+		kvs[model2.FieldChatMessagesStructured] = req.Structured
+	}
+
 	return id, eloquent.Transaction(r.db, func(tx query.Database) error {
 		var err error
 		id, err = model2.NewChatMessagesModel(tx).Create(ctx, kvs)
@@ -81,10 +111,13 @@ func (r *MessageRepo) Add(ctx context.Context, req MessageAddReq) (int64, error)
 				Where(model2.FieldRoomsUserId, req.UserID).
 				Where(model2.FieldRoomsId, req.RoomID)
 
-			_, err = model2.NewRoomsModel(r.db).Update(ctx, q, model2.RoomsN{
-				LastActiveTime: null.TimeFrom(time.Now()),
-				Description:    null.StringFrom(misc.SubString(req.Message, 70)),
-			})
+			room := model2.RoomsN{LastActiveTime: null.TimeFrom(time.Now())}
+			// 启用消息加密后，房间描述中不再保存消息内容的明文摘要
+			if !r.cipher.Enabled() {
+				room.Description = null.StringFrom(misc.SubString(req.Message, 70))
+			}
+
+			_, err = model2.NewRoomsModel(r.db).Update(ctx, q, room)
 		}
 
 		return err
@@ -92,6 +125,30 @@ func (r *MessageRepo) Add(ctx context.Context, req MessageAddReq) (int64, error)
 
 }
 
+// addIncognitoUsage 记录无痕模式下的用量统计，不包含消息内容，同时更新房间的最后活跃时间
+func (r *MessageRepo) addIncognitoUsage(ctx context.Context, req MessageAddReq) error {
+	return eloquent.Transaction(r.db, func(tx query.Database) error {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO incognito_usage (user_id, stat_date, room_id, model, messages, tokens, coins, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+ON DUPLICATE KEY UPDATE messages = messages + VALUES(messages), tokens = tokens + VALUES(tokens), coins = coins + VALUES(coins), updated_at = NOW()`,
+			req.UserID, time.Now().Format("2006-01-02"), req.RoomID, req.Model,
+			ternary.If(req.Role == MessageRoleUser, 1, 0), req.TokenConsumed, req.QuotaConsumed,
+		); err != nil {
+			return fmt.Errorf("add incognito usage failed: %w", err)
+		}
+
+		if req.Role == MessageRoleUser {
+			if _, err := model2.NewRoomsModel(tx).Update(ctx, query.Builder().
+				Where(model2.FieldRoomsUserId, req.UserID).
+				Where(model2.FieldRoomsId, req.RoomID), model2.RoomsN{LastActiveTime: null.TimeFrom(time.Now())}); err != nil {
+				return fmt.Errorf("update room last active time failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
 type MessageUpdateReq struct {
 	Status int64
 	Error  string
@@ -115,3 +172,258 @@ func (r *MessageRepo) UpdateMessageStatus(ctx context.Context, id int64, req Mes
 	_, err := model2.NewChatMessagesModel(r.db).UpdateFields(ctx, kv, query.Builder().Where(model2.FieldChatMessagesId, id))
 	return err
 }
+
+const (
+	// MessageRatingNone 消息未评价
+	MessageRatingNone int64 = 0
+	// MessageRatingLike 消息评价：赞
+	MessageRatingLike int64 = 1
+	// MessageRatingDislike 消息评价：踩
+	MessageRatingDislike int64 = -1
+)
+
+// RateMessage 用户对 AI 回复的消息进行评价
+func (r *MessageRepo) RateMessage(ctx context.Context, userID, messageID int64, rating int64) error {
+	q := query.Builder().
+		Where(model2.FieldChatMessagesId, messageID).
+		Where(model2.FieldChatMessagesUserId, userID).
+		Where(model2.FieldChatMessagesRole, MessageRoleAssistant)
+
+	cnt, err := model2.NewChatMessagesModel(r.db).Count(ctx, q)
+	if err != nil {
+		return fmt.Errorf("query message failed: %w", err)
+	}
+
+	if cnt == 0 {
+		return ErrNotFound
+	}
+
+	_, err = model2.NewChatMessagesModel(r.db).UpdateFields(ctx, query.KV{model2.FieldChatMessagesRating: rating}, q)
+	return err
+}
+
+// Message 查询用户的单条消息，返回的消息内容已解密
+func (r *MessageRepo) Message(ctx context.Context, userID, messageID int64) (*model2.ChatMessages, error) {
+	msg, err := model2.NewChatMessagesModel(r.db).First(ctx, query.Builder().
+		Where(model2.FieldChatMessagesId, messageID).
+		Where(model2.FieldChatMessagesUserId, userID),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("query message failed: %w", err)
+	}
+
+	ret := msg.ToChatMessages()
+	if ret.Message, err = r.cipher.Decrypt(ctx, ret.Message); err != nil {
+		return nil, fmt.Errorf("decrypt message failed: %w", err)
+	}
+
+	return &ret, nil
+}
+
+// QuestionAnswer 查询 AI 回复及其对应的用户提问，返回的第一个值为提问，第二个值为回复
+func (r *MessageRepo) QuestionAnswer(ctx context.Context, userID, answerID int64) (string, string, error) {
+	answer, err := model2.NewChatMessagesModel(r.db).First(ctx, query.Builder().
+		Where(model2.FieldChatMessagesId, answerID).
+		Where(model2.FieldChatMessagesUserId, userID).
+		Where(model2.FieldChatMessagesRole, MessageRoleAssistant),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return "", "", ErrNotFound
+		}
+
+		return "", "", fmt.Errorf("query answer failed: %w", err)
+	}
+
+	answerMsg, err := r.cipher.Decrypt(ctx, answer.Message.ValueOrZero())
+	if err != nil {
+		return "", "", fmt.Errorf("decrypt answer failed: %w", err)
+	}
+
+	if answer.Pid.ValueOrZero() <= 0 {
+		return "", answerMsg, nil
+	}
+
+	question, err := model2.NewChatMessagesModel(r.db).First(ctx, query.Builder().
+		Where(model2.FieldChatMessagesId, answer.Pid.ValueOrZero()).
+		Where(model2.FieldChatMessagesUserId, userID),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return "", answerMsg, nil
+		}
+
+		return "", "", fmt.Errorf("query question failed: %w", err)
+	}
+
+	questionMsg, err := r.cipher.Decrypt(ctx, question.Message.ValueOrZero())
+	if err != nil {
+		return "", "", fmt.Errorf("decrypt question failed: %w", err)
+	}
+
+	return questionMsg, answerMsg, nil
+}
+
+// RoomMessage 会话中的一条消息
+type RoomMessage struct {
+	Role    MessageRole
+	Message string
+}
+
+// RecentRoomMessages 查询会话中最近的 limit 条消息，按照时间顺序返回，返回的消息内容已解密
+func (r *MessageRepo) RecentRoomMessages(ctx context.Context, userID, roomID, limit int64) ([]RoomMessage, error) {
+	messages, err := model2.NewChatMessagesModel(r.db).Get(ctx, query.Builder().
+		Select(model2.FieldChatMessagesRole, model2.FieldChatMessagesMessage).
+		Where(model2.FieldChatMessagesRoomId, roomID).
+		Where(model2.FieldChatMessagesUserId, userID).
+		OrderBy(model2.FieldChatMessagesId, "DESC").
+		Limit(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query room messages failed: %w", err)
+	}
+
+	res := make([]RoomMessage, 0, len(messages))
+	for _, msg := range array.Reverse(messages) {
+		plain, err := r.cipher.Decrypt(ctx, msg.Message.ValueOrZero())
+		if err != nil {
+			return nil, fmt.Errorf("decrypt message failed: %w", err)
+		}
+
+		res = append(res, RoomMessage{Role: MessageRole(msg.Role.ValueOrZero()), Message: plain})
+	}
+
+	return res, nil
+}
+
+// FineTuneFilter 微调数据集导出过滤条件
+type FineTuneFilter struct {
+	Rating    int64
+	Model     string
+	StartTime time.Time
+	EndTime   time.Time
+	// StartID 从该消息 ID 之后开始查询，用于分页导出
+	StartID int64
+	Limit   int64
+}
+
+// FineTuneSample 微调数据集样本，由用户的提问与 AI 的回复组成
+type FineTuneSample struct {
+	AnswerID  int64     `json:"answer_id"`
+	UserID    int64     `json:"user_id"`
+	Model     string    `json:"model"`
+	Rating    int64     `json:"rating"`
+	Question  string    `json:"question"`
+	Answer    string    `json:"answer"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FineTuneSamples 查询符合条件的问答对，用于导出微调数据集
+func (r *MessageRepo) FineTuneSamples(ctx context.Context, filter FineTuneFilter) ([]FineTuneSample, error) {
+	sqlStr := `SELECT a.id, a.user_id, a.model, a.rating, q.message, a.message, a.created_at
+FROM chat_messages a
+INNER JOIN chat_messages q ON q.id = a.pid
+WHERE a.role = ? AND a.status = ? AND a.rating = ? AND a.id > ?`
+	args := []any{MessageRoleAssistant, MessageStatusSucceed, filter.Rating, filter.StartID}
+
+	if filter.Model != "" {
+		sqlStr += " AND a.model = ?"
+		args = append(args, filter.Model)
+	}
+
+	if !filter.StartTime.IsZero() {
+		sqlStr += " AND a.created_at >= ?"
+		args = append(args, filter.StartTime)
+	}
+
+	if !filter.EndTime.IsZero() {
+		sqlStr += " AND a.created_at < ?"
+		args = append(args, filter.EndTime)
+	}
+
+	sqlStr += " ORDER BY a.id ASC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query fine-tune samples failed: %w", err)
+	}
+	defer rows.Close()
+
+	samples := make([]FineTuneSample, 0)
+	for rows.Next() {
+		var sample FineTuneSample
+		var modelName sql.NullString
+		if err := rows.Scan(&sample.AnswerID, &sample.UserID, &modelName, &sample.Rating, &sample.Question, &sample.Answer, &sample.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan fine-tune sample failed: %w", err)
+		}
+
+		if sample.Question, err = r.cipher.Decrypt(ctx, sample.Question); err != nil {
+			return nil, fmt.Errorf("decrypt fine-tune sample %d failed: %w", sample.AnswerID, err)
+		}
+
+		if sample.Answer, err = r.cipher.Decrypt(ctx, sample.Answer); err != nil {
+			return nil, fmt.Errorf("decrypt fine-tune sample %d failed: %w", sample.AnswerID, err)
+		}
+
+		sample.Model = modelName.String
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
+
+// CodeExecutionAddReq 代码执行记录
+type CodeExecutionAddReq struct {
+	MessageID  int64
+	UserID     int64
+	Language   string
+	Code       string
+	Stdout     string
+	Stderr     string
+	ExitCode   int64
+	DurationMs int64
+	Coins      int64
+}
+
+// AddCodeExecutions 保存消息关联的代码执行记录
+func (r *MessageRepo) AddCodeExecutions(ctx context.Context, executions []CodeExecutionAddReq) error {
+	return eloquent.Transaction(r.db, func(tx query.Database) error {
+		for _, exec := range executions {
+			if _, err := model2.NewChatMessagesCodeExecutionModel(tx).Create(ctx, query.KV{
+				model2.FieldChatMessagesCodeExecutionMessageId:  exec.MessageID,
+				model2.FieldChatMessagesCodeExecutionUserId:     exec.UserID,
+				model2.FieldChatMessagesCodeExecutionLanguage:   exec.Language,
+				model2.FieldChatMessagesCodeExecutionCode:       exec.Code,
+				model2.FieldChatMessagesCodeExecutionStdout:     exec.Stdout,
+				model2.FieldChatMessagesCodeExecutionStderr:     exec.Stderr,
+				model2.FieldChatMessagesCodeExecutionExitCode:   exec.ExitCode,
+				model2.FieldChatMessagesCodeExecutionDurationMs: exec.DurationMs,
+				model2.FieldChatMessagesCodeExecutionCoins:      exec.Coins,
+			}); err != nil {
+				return fmt.Errorf("add code execution failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetCodeExecutions 查询消息关联的代码执行记录
+func (r *MessageRepo) GetCodeExecutions(ctx context.Context, userID, messageID int64) ([]model2.ChatMessagesCodeExecution, error) {
+	items, err := model2.NewChatMessagesCodeExecutionModel(r.db).Get(ctx, query.Builder().
+		Where(model2.FieldChatMessagesCodeExecutionMessageId, messageID).
+		Where(model2.FieldChatMessagesCodeExecutionUserId, userID).
+		OrderBy(model2.FieldChatMessagesCodeExecutionId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model2.ChatMessagesCodeExecutionN, _ int) model2.ChatMessagesCodeExecution {
+		return item.ToChatMessagesCodeExecution()
+	}), nil
+}
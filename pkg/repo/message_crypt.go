@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mylxsw/go-utils/array"
+)
+
+// EncryptedMessageTables 保存聊天消息内容的表
+var EncryptedMessageTables = []string{"chat_messages", "chat_group_message"}
+
+// EncryptHistory 加密表中的历史消息，已使用其它主密钥加密的消息会使用当前主密钥重新加密，用于密钥轮换，
+// 从 startID 之后开始处理，每处理一批调用一次 progress，返回处理到的最大消息 ID 与加密的消息数量
+func (r *MessageRepo) EncryptHistory(ctx context.Context, table string, startID, batchSize int64, progress func(lastID, encrypted int64)) (int64, int64, error) {
+	if !array.In(table, EncryptedMessageTables) {
+		return startID, 0, fmt.Errorf("unsupported table %s", table)
+	}
+
+	if !r.cipher.Enabled() {
+		return startID, 0, fmt.Errorf("message encryption is not enabled")
+	}
+
+	lastID, total := startID, int64(0)
+	for {
+		rows, err := r.db.QueryContext(ctx, fmt.Sprintf("SELECT id, message FROM %s WHERE id > ? AND message IS NOT NULL ORDER BY id ASC LIMIT ?", table), lastID, batchSize)
+		if err != nil {
+			return lastID, total, fmt.Errorf("query messages failed: %w", err)
+		}
+
+		type item struct {
+			id      int64
+			message string
+		}
+
+		items := make([]item, 0, batchSize)
+		for rows.Next() {
+			var it item
+			if err := rows.Scan(&it.id, &it.message); err != nil {
+				_ = rows.Close()
+				return lastID, total, fmt.Errorf("scan message failed: %w", err)
+			}
+
+			items = append(items, it)
+		}
+		_ = rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return lastID, total, fmt.Errorf("query messages failed: %w", err)
+		}
+
+		for _, it := range items {
+			lastID = it.id
+			if !r.cipher.NeedsEncrypt(it.message) {
+				continue
+			}
+
+			plain, err := r.cipher.Decrypt(ctx, it.message)
+			if err != nil {
+				return lastID, total, fmt.Errorf("decrypt message %d failed: %w", it.id, err)
+			}
+
+			encrypted, err := r.cipher.Encrypt(ctx, plain)
+			if err != nil {
+				return lastID, total, fmt.Errorf("encrypt message %d failed: %w", it.id, err)
+			}
+
+			// 消息内容在此期间被修改（例如仍在生成中的回复）时跳过，保持原有的更新时间
+			res, err := r.db.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET message = ?, updated_at = updated_at WHERE id = ? AND message = ?", table), encrypted, it.id, it.message)
+			if err != nil {
+				return lastID, total, fmt.Errorf("update message %d failed: %w", it.id, err)
+			}
+
+			if affected, _ := res.RowsAffected(); affected > 0 {
+				total++
+			}
+		}
+
+		if progress != nil {
+			progress(lastID, total)
+		}
+
+		if int64(len(items)) < batchSize {
+			return lastID, total, nil
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// ErrMockInterviewChanged 面试记录已经被其它请求修改（例如重复提交回答）
+var ErrMockInterviewChanged = errors.New("mock interview has been changed")
+
+const (
+	// MockInterviewStatusOngoing 面试进行中
+	MockInterviewStatusOngoing = 1
+	// MockInterviewStatusFinished 面试已结束，已经生成评分报告
+	MockInterviewStatusFinished = 2
+)
+
+// MockInterviewRepo 模拟面试
+type MockInterviewRepo struct {
+	db *sql.DB
+}
+
+// NewMockInterviewRepo create a new MockInterviewRepo
+func NewMockInterviewRepo(db *sql.DB) *MockInterviewRepo {
+	return &MockInterviewRepo{db: db}
+}
+
+// MockInterviewTurn 面试中的一轮问答
+type MockInterviewTurn struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer,omitempty"`
+}
+
+// MockInterview 一次模拟面试
+type MockInterview struct {
+	ID        int64  `json:"id"`
+	HistoryID int64  `json:"history_id"`
+	Role      string `json:"role"`
+	Level     string `json:"level,omitempty"`
+	Model     string `json:"-"`
+	// QuestionCount 计划的题目数量
+	QuestionCount int64 `json:"question_count"`
+	// Answered 已经回答的题目数量
+	Answered   int64               `json:"answered"`
+	Transcript []MockInterviewTurn `json:"transcript"`
+	QuotaUsed  int64               `json:"quota_used"`
+	Status     int64               `json:"status"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+func mockInterviewFromModel(item model.MockInterviewN) MockInterview {
+	ret := MockInterview{
+		ID:            item.Id.ValueOrZero(),
+		HistoryID:     item.HistoryId.ValueOrZero(),
+		Role:          item.Role.ValueOrZero(),
+		Level:         item.Level.ValueOrZero(),
+		Model:         item.Model.ValueOrZero(),
+		QuestionCount: item.QuestionCount.ValueOrZero(),
+		Answered:      item.Answered.ValueOrZero(),
+		QuotaUsed:     item.QuotaUsed.ValueOrZero(),
+		Status:        item.Status.ValueOrZero(),
+		CreatedAt:     item.CreatedAt.ValueOrZero(),
+	}
+
+	_ = json.Unmarshal([]byte(item.Transcript.ValueOrZero()), &ret.Transcript)
+	if ret.Transcript == nil {
+		ret.Transcript = make([]MockInterviewTurn, 0)
+	}
+
+	return ret
+}
+
+// Create 创建模拟面试，同时创建进行中的创作岛历史记录，面试结束后评分报告写入该历史记录
+func (repo *MockInterviewRepo) Create(ctx context.Context, userID int64, islandID string, item MockInterview) (*MockInterview, error) {
+	transcript, _ := json.Marshal(item.Transcript)
+
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewMockInterviewModel(tx).Create(ctx, query.KV{
+			model.FieldMockInterviewUserId:        userID,
+			model.FieldMockInterviewRole:          item.Role,
+			model.FieldMockInterviewLevel:         item.Level,
+			model.FieldMockInterviewModel:         item.Model,
+			model.FieldMockInterviewQuestionCount: item.QuestionCount,
+			model.FieldMockInterviewAnswered:      item.Answered,
+			model.FieldMockInterviewTranscript:    string(transcript),
+			model.FieldMockInterviewQuotaUsed:     item.QuotaUsed,
+			model.FieldMockInterviewStatus:        MockInterviewStatusOngoing,
+		})
+		if err != nil {
+			return err
+		}
+
+		arguments, _ := json.Marshal(CreativeRecordArguments{InterviewID: id, InterviewLevel: item.Level})
+		historyID, err := model.NewCreativeHistoryModel(tx).Create(ctx, query.KV{
+			model.FieldCreativeHistoryUserId:      userID,
+			model.FieldCreativeHistoryIslandId:    islandID,
+			model.FieldCreativeHistoryIslandType:  int64(IslandTypeMockInterview),
+			model.FieldCreativeHistoryIslandModel: item.Model,
+			model.FieldCreativeHistoryArguments:   string(arguments),
+			model.FieldCreativeHistoryPrompt:      item.Role,
+			model.FieldCreativeHistoryStatus:      int64(CreativeStatusProcessing),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = model.NewMockInterviewModel(tx).UpdateFields(ctx, query.KV{model.FieldMockInterviewHistoryId: historyID}, query.Builder().Where(model.FieldMockInterviewId, id))
+
+		item.ID, item.HistoryID, item.Status = id, historyID, MockInterviewStatusOngoing
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// Get 查询用户的模拟面试
+func (repo *MockInterviewRepo) Get(ctx context.Context, userID, id int64) (*MockInterview, error) {
+	item, err := model.NewMockInterviewModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldMockInterviewId, id).
+		Where(model.FieldMockInterviewUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := mockInterviewFromModel(*item)
+	return &ret, nil
+}
+
+// SaveTurn 保存面试进度，prevAnswered 为读取面试记录时已经回答的题目数量，面试记录已经被修改时返回 ErrMockInterviewChanged
+func (repo *MockInterviewRepo) SaveTurn(ctx context.Context, userID int64, item MockInterview, prevAnswered int64) error {
+	return repo.update(ctx, repo.db, userID, item, prevAnswered)
+}
+
+// Finish 结束面试，评分报告写入创作岛历史记录
+func (repo *MockInterviewRepo) Finish(ctx context.Context, userID int64, item MockInterview, prevAnswered int64, report string) error {
+	item.Status = MockInterviewStatusFinished
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if err := repo.update(ctx, tx, userID, item, prevAnswered); err != nil {
+			return err
+		}
+
+		_, err := model.NewCreativeHistoryModel(tx).UpdateFields(ctx, query.KV{
+			model.FieldCreativeHistoryAnswer:    report,
+			model.FieldCreativeHistoryQuotaUsed: item.QuotaUsed,
+			model.FieldCreativeHistoryStatus:    int64(CreativeStatusSuccess),
+		}, query.Builder().Where(model.FieldCreativeHistoryId, item.HistoryID).Where(model.FieldCreativeHistoryUserId, userID))
+		return err
+	})
+}
+
+func (repo *MockInterviewRepo) update(ctx context.Context, db query.Database, userID int64, item MockInterview, prevAnswered int64) error {
+	transcript, _ := json.Marshal(item.Transcript)
+
+	affected, err := model.NewMockInterviewModel(db).UpdateFields(ctx, query.KV{
+		model.FieldMockInterviewAnswered:   item.Answered,
+		model.FieldMockInterviewTranscript: string(transcript),
+		model.FieldMockInterviewQuotaUsed:  item.QuotaUsed,
+		model.FieldMockInterviewStatus:     item.Status,
+	}, query.Builder().
+		Where(model.FieldMockInterviewId, item.ID).
+		Where(model.FieldMockInterviewUserId, userID).
+		Where(model.FieldMockInterviewStatus, MockInterviewStatusOngoing).
+		Where(model.FieldMockInterviewAnswered, prevAnswered))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrMockInterviewChanged
+	}
+
+	return nil
+}
@@ -0,0 +1,747 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// AbuseFlagN is a AbuseFlag object, all fields are nullable
+type AbuseFlagN struct {
+	original       *abuseFlagOriginal
+	abuseFlagModel *AbuseFlagModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Signals   null.String `json:"signals"`
+	Evidence  null.String `json:"evidence"`
+	Status    null.Int    `json:"status"`
+	Note      null.String `json:"note,omitempty"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *AbuseFlagN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for AbuseFlag
+func (inst *AbuseFlagN) SetModel(abuseFlagModel *AbuseFlagModel) {
+	inst.abuseFlagModel = abuseFlagModel
+}
+
+// abuseFlagOriginal is an object which stores original AbuseFlag from database
+type abuseFlagOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Signals   null.String
+	Evidence  null.String
+	Status    null.Int
+	Note      null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *AbuseFlagN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &abuseFlagOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Signals != inst.original.Signals {
+			return true
+		}
+		if inst.Evidence != inst.original.Evidence {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "signals":
+				if inst.Signals != inst.original.Signals {
+					return true
+				}
+			case "evidence":
+				if inst.Evidence != inst.original.Evidence {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *AbuseFlagN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &abuseFlagOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Signals != inst.original.Signals {
+			kv["signals"] = inst.Signals
+		}
+		if inst.Evidence != inst.original.Evidence {
+			kv["evidence"] = inst.Evidence
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "signals":
+				if inst.Signals != inst.original.Signals {
+					kv["signals"] = inst.Signals
+				}
+			case "evidence":
+				if inst.Evidence != inst.original.Evidence {
+					kv["evidence"] = inst.Evidence
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *AbuseFlagN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.abuseFlagModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.abuseFlagModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a abuse_flag
+func (inst *AbuseFlagN) Delete(ctx context.Context) error {
+	if inst.abuseFlagModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.abuseFlagModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *AbuseFlagN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type abuseFlagScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var abuseFlagGlobalScopes = make([]abuseFlagScope, 0)
+var abuseFlagLocalScopes = make([]abuseFlagScope, 0)
+
+// AddGlobalScopeForAbuseFlag assign a global scope to a model
+func AddGlobalScopeForAbuseFlag(name string, apply func(builder query.Condition)) {
+	abuseFlagGlobalScopes = append(abuseFlagGlobalScopes, abuseFlagScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForAbuseFlag assign a local scope to a model
+func AddLocalScopeForAbuseFlag(name string, apply func(builder query.Condition)) {
+	abuseFlagLocalScopes = append(abuseFlagLocalScopes, abuseFlagScope{name: name, apply: apply})
+}
+
+func (m *AbuseFlagModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range abuseFlagGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range abuseFlagLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *AbuseFlagModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *AbuseFlagModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type AbuseFlag struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Signals   string `json:"signals"`
+	Evidence  string `json:"evidence"`
+	Status    int64  `json:"status"`
+	Note      string `json:"note,omitempty"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w AbuseFlag) ToAbuseFlagN(allows ...string) AbuseFlagN {
+	if len(allows) == 0 {
+		return AbuseFlagN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Signals:   null.StringFrom(w.Signals),
+			Evidence:  null.StringFrom(w.Evidence),
+			Status:    null.IntFrom(int64(w.Status)),
+			Note:      null.StringFrom(w.Note),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := AbuseFlagN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "signals":
+			res.Signals = null.StringFrom(w.Signals)
+		case "evidence":
+			res.Evidence = null.StringFrom(w.Evidence)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w AbuseFlag) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *AbuseFlagN) ToAbuseFlag() AbuseFlag {
+	return AbuseFlag{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Signals:   w.Signals.String,
+		Evidence:  w.Evidence.String,
+		Status:    w.Status.Int64,
+		Note:      w.Note.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// AbuseFlagModel is a model which encapsulates the operations of the object
+type AbuseFlagModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var abuseFlagTableName = "abuse_flag"
+
+// AbuseFlagTable return table name for AbuseFlag
+func AbuseFlagTable() string {
+	return abuseFlagTableName
+}
+
+const (
+	FieldAbuseFlagId        = "id"
+	FieldAbuseFlagUserId    = "user_id"
+	FieldAbuseFlagSignals   = "signals"
+	FieldAbuseFlagEvidence  = "evidence"
+	FieldAbuseFlagStatus    = "status"
+	FieldAbuseFlagNote      = "note"
+	FieldAbuseFlagCreatedAt = "created_at"
+	FieldAbuseFlagUpdatedAt = "updated_at"
+)
+
+// AbuseFlagFields return all fields in AbuseFlag model
+func AbuseFlagFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"signals",
+		"evidence",
+		"status",
+		"note",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetAbuseFlagTable(tableName string) {
+	abuseFlagTableName = tableName
+}
+
+// NewAbuseFlagModel create a AbuseFlagModel
+func NewAbuseFlagModel(db query.Database) *AbuseFlagModel {
+	return &AbuseFlagModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           abuseFlagTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *AbuseFlagModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *AbuseFlagModel) clone() *AbuseFlagModel {
+	return &AbuseFlagModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *AbuseFlagModel) WithoutGlobalScopes(names ...string) *AbuseFlagModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *AbuseFlagModel) WithLocalScopes(names ...string) *AbuseFlagModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *AbuseFlagModel) Condition(builder query.SQLBuilder) *AbuseFlagModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *AbuseFlagModel) Find(ctx context.Context, id int64) (*AbuseFlagN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *AbuseFlagModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *AbuseFlagModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *AbuseFlagModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]AbuseFlagN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *AbuseFlagModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]AbuseFlagN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"signals",
+			"evidence",
+			"status",
+			"note",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "signals":
+			selectFields = append(selectFields, f)
+		case "evidence":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*AbuseFlagN, []interface{}) {
+		var abuseFlagVar AbuseFlagN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &abuseFlagVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &abuseFlagVar.UserId)
+			case "signals":
+				scanFields = append(scanFields, &abuseFlagVar.Signals)
+			case "evidence":
+				scanFields = append(scanFields, &abuseFlagVar.Evidence)
+			case "status":
+				scanFields = append(scanFields, &abuseFlagVar.Status)
+			case "note":
+				scanFields = append(scanFields, &abuseFlagVar.Note)
+			case "created_at":
+				scanFields = append(scanFields, &abuseFlagVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &abuseFlagVar.UpdatedAt)
+			}
+		}
+
+		return &abuseFlagVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	abuseFlags := make([]AbuseFlagN, 0)
+	for rows.Next() {
+		abuseFlagReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		abuseFlagReal.original = &abuseFlagOriginal{}
+		_ = query.Copy(abuseFlagReal, abuseFlagReal.original)
+
+		abuseFlagReal.SetModel(m)
+		abuseFlags = append(abuseFlags, *abuseFlagReal)
+	}
+
+	return abuseFlags, nil
+}
+
+// First return first result for given query
+func (m *AbuseFlagModel) First(ctx context.Context, builders ...query.SQLBuilder) (*AbuseFlagN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new abuse_flag to database
+func (m *AbuseFlagModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all abuse_flags to database
+func (m *AbuseFlagModel) SaveAll(ctx context.Context, abuseFlags []AbuseFlagN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, abuseFlag := range abuseFlags {
+		id, err := m.Save(ctx, abuseFlag)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a abuse_flag to database
+func (m *AbuseFlagModel) Save(ctx context.Context, abuseFlag AbuseFlagN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, abuseFlag.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new abuse_flag or update it when it has a id > 0
+func (m *AbuseFlagModel) SaveOrUpdate(ctx context.Context, abuseFlag AbuseFlagN, onlyFields ...string) (id int64, updated bool, err error) {
+	if abuseFlag.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, abuseFlag.Id.Int64, abuseFlag, onlyFields...)
+		return abuseFlag.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, abuseFlag, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *AbuseFlagModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *AbuseFlagModel) Update(ctx context.Context, builder query.SQLBuilder, abuseFlag AbuseFlagN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, abuseFlag.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *AbuseFlagModel) UpdateById(ctx context.Context, id int64, abuseFlag AbuseFlagN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, abuseFlag.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *AbuseFlagModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *AbuseFlagModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,1366 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// UserAchievementN is a UserAchievement object, all fields are nullable
+type UserAchievementN struct {
+	original             *userAchievementOriginal
+	userAchievementModel *UserAchievementModel
+
+	Id          null.Int    `json:"id"`
+	UserId      null.Int    `json:"user_id"`
+	Achievement null.String `json:"achievement"`
+	Reward      null.Int    `json:"reward"`
+	Points      null.Int    `json:"points"`
+	Notified    null.Int    `json:"notified"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *UserAchievementN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for UserAchievement
+func (inst *UserAchievementN) SetModel(userAchievementModel *UserAchievementModel) {
+	inst.userAchievementModel = userAchievementModel
+}
+
+// userAchievementOriginal is an object which stores original UserAchievement from database
+type userAchievementOriginal struct {
+	Id          null.Int
+	UserId      null.Int
+	Achievement null.String
+	Reward      null.Int
+	Points      null.Int
+	Notified    null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *UserAchievementN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &userAchievementOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Achievement != inst.original.Achievement {
+			return true
+		}
+		if inst.Reward != inst.original.Reward {
+			return true
+		}
+		if inst.Points != inst.original.Points {
+			return true
+		}
+		if inst.Notified != inst.original.Notified {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "achievement":
+				if inst.Achievement != inst.original.Achievement {
+					return true
+				}
+			case "reward":
+				if inst.Reward != inst.original.Reward {
+					return true
+				}
+			case "points":
+				if inst.Points != inst.original.Points {
+					return true
+				}
+			case "notified":
+				if inst.Notified != inst.original.Notified {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *UserAchievementN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &userAchievementOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Achievement != inst.original.Achievement {
+			kv["achievement"] = inst.Achievement
+		}
+		if inst.Reward != inst.original.Reward {
+			kv["reward"] = inst.Reward
+		}
+		if inst.Points != inst.original.Points {
+			kv["points"] = inst.Points
+		}
+		if inst.Notified != inst.original.Notified {
+			kv["notified"] = inst.Notified
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "achievement":
+				if inst.Achievement != inst.original.Achievement {
+					kv["achievement"] = inst.Achievement
+				}
+			case "reward":
+				if inst.Reward != inst.original.Reward {
+					kv["reward"] = inst.Reward
+				}
+			case "points":
+				if inst.Points != inst.original.Points {
+					kv["points"] = inst.Points
+				}
+			case "notified":
+				if inst.Notified != inst.original.Notified {
+					kv["notified"] = inst.Notified
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *UserAchievementN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.userAchievementModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.userAchievementModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a user_achievement
+func (inst *UserAchievementN) Delete(ctx context.Context) error {
+	if inst.userAchievementModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.userAchievementModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *UserAchievementN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type userAchievementScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var userAchievementGlobalScopes = make([]userAchievementScope, 0)
+var userAchievementLocalScopes = make([]userAchievementScope, 0)
+
+// AddGlobalScopeForUserAchievement assign a global scope to a model
+func AddGlobalScopeForUserAchievement(name string, apply func(builder query.Condition)) {
+	userAchievementGlobalScopes = append(userAchievementGlobalScopes, userAchievementScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForUserAchievement assign a local scope to a model
+func AddLocalScopeForUserAchievement(name string, apply func(builder query.Condition)) {
+	userAchievementLocalScopes = append(userAchievementLocalScopes, userAchievementScope{name: name, apply: apply})
+}
+
+func (m *UserAchievementModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range userAchievementGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range userAchievementLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *UserAchievementModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *UserAchievementModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UserAchievement struct {
+	Id          int64  `json:"id"`
+	UserId      int64  `json:"user_id"`
+	Achievement string `json:"achievement"`
+	Reward      int64  `json:"reward"`
+	Points      int64  `json:"points"`
+	Notified    int64  `json:"notified"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w UserAchievement) ToUserAchievementN(allows ...string) UserAchievementN {
+	if len(allows) == 0 {
+		return UserAchievementN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			UserId:      null.IntFrom(int64(w.UserId)),
+			Achievement: null.StringFrom(w.Achievement),
+			Reward:      null.IntFrom(int64(w.Reward)),
+			Points:      null.IntFrom(int64(w.Points)),
+			Notified:    null.IntFrom(int64(w.Notified)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := UserAchievementN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "achievement":
+			res.Achievement = null.StringFrom(w.Achievement)
+		case "reward":
+			res.Reward = null.IntFrom(int64(w.Reward))
+		case "points":
+			res.Points = null.IntFrom(int64(w.Points))
+		case "notified":
+			res.Notified = null.IntFrom(int64(w.Notified))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w UserAchievement) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *UserAchievementN) ToUserAchievement() UserAchievement {
+	return UserAchievement{
+
+		Id:          w.Id.Int64,
+		UserId:      w.UserId.Int64,
+		Achievement: w.Achievement.String,
+		Reward:      w.Reward.Int64,
+		Points:      w.Points.Int64,
+		Notified:    w.Notified.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// UserAchievementModel is a model which encapsulates the operations of the object
+type UserAchievementModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var userAchievementTableName = "user_achievement"
+
+// UserAchievementTable return table name for UserAchievement
+func UserAchievementTable() string {
+	return userAchievementTableName
+}
+
+const (
+	FieldUserAchievementId          = "id"
+	FieldUserAchievementUserId      = "user_id"
+	FieldUserAchievementAchievement = "achievement"
+	FieldUserAchievementReward      = "reward"
+	FieldUserAchievementPoints      = "points"
+	FieldUserAchievementNotified    = "notified"
+	FieldUserAchievementCreatedAt   = "created_at"
+	FieldUserAchievementUpdatedAt   = "updated_at"
+)
+
+// UserAchievementFields return all fields in UserAchievement model
+func UserAchievementFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"achievement",
+		"reward",
+		"points",
+		"notified",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetUserAchievementTable(tableName string) {
+	userAchievementTableName = tableName
+}
+
+// NewUserAchievementModel create a UserAchievementModel
+func NewUserAchievementModel(db query.Database) *UserAchievementModel {
+	return &UserAchievementModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           userAchievementTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *UserAchievementModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *UserAchievementModel) clone() *UserAchievementModel {
+	return &UserAchievementModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *UserAchievementModel) WithoutGlobalScopes(names ...string) *UserAchievementModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *UserAchievementModel) WithLocalScopes(names ...string) *UserAchievementModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *UserAchievementModel) Condition(builder query.SQLBuilder) *UserAchievementModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *UserAchievementModel) Find(ctx context.Context, id int64) (*UserAchievementN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *UserAchievementModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *UserAchievementModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *UserAchievementModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]UserAchievementN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *UserAchievementModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]UserAchievementN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"achievement",
+			"reward",
+			"points",
+			"notified",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "achievement":
+			selectFields = append(selectFields, f)
+		case "reward":
+			selectFields = append(selectFields, f)
+		case "points":
+			selectFields = append(selectFields, f)
+		case "notified":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*UserAchievementN, []interface{}) {
+		var userAchievementVar UserAchievementN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &userAchievementVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &userAchievementVar.UserId)
+			case "achievement":
+				scanFields = append(scanFields, &userAchievementVar.Achievement)
+			case "reward":
+				scanFields = append(scanFields, &userAchievementVar.Reward)
+			case "points":
+				scanFields = append(scanFields, &userAchievementVar.Points)
+			case "notified":
+				scanFields = append(scanFields, &userAchievementVar.Notified)
+			case "created_at":
+				scanFields = append(scanFields, &userAchievementVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &userAchievementVar.UpdatedAt)
+			}
+		}
+
+		return &userAchievementVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userAchievements := make([]UserAchievementN, 0)
+	for rows.Next() {
+		userAchievementReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		userAchievementReal.original = &userAchievementOriginal{}
+		_ = query.Copy(userAchievementReal, userAchievementReal.original)
+
+		userAchievementReal.SetModel(m)
+		userAchievements = append(userAchievements, *userAchievementReal)
+	}
+
+	return userAchievements, nil
+}
+
+// First return first result for given query
+func (m *UserAchievementModel) First(ctx context.Context, builders ...query.SQLBuilder) (*UserAchievementN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new user_achievement to database
+func (m *UserAchievementModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all user_achievements to database
+func (m *UserAchievementModel) SaveAll(ctx context.Context, userAchievements []UserAchievementN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, userAchievement := range userAchievements {
+		id, err := m.Save(ctx, userAchievement)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a user_achievement to database
+func (m *UserAchievementModel) Save(ctx context.Context, userAchievement UserAchievementN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, userAchievement.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new user_achievement or update it when it has a id > 0
+func (m *UserAchievementModel) SaveOrUpdate(ctx context.Context, userAchievement UserAchievementN, onlyFields ...string) (id int64, updated bool, err error) {
+	if userAchievement.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, userAchievement.Id.Int64, userAchievement, onlyFields...)
+		return userAchievement.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, userAchievement, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *UserAchievementModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *UserAchievementModel) Update(ctx context.Context, builder query.SQLBuilder, userAchievement UserAchievementN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, userAchievement.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *UserAchievementModel) UpdateById(ctx context.Context, id int64, userAchievement UserAchievementN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, userAchievement.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *UserAchievementModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *UserAchievementModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// AchievementLeaderboardN is a AchievementLeaderboard object, all fields are nullable
+type AchievementLeaderboardN struct {
+	original                    *achievementLeaderboardOriginal
+	achievementLeaderboardModel *AchievementLeaderboardModel
+
+	Id        null.Int `json:"id"`
+	UserId    null.Int `json:"user_id"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *AchievementLeaderboardN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for AchievementLeaderboard
+func (inst *AchievementLeaderboardN) SetModel(achievementLeaderboardModel *AchievementLeaderboardModel) {
+	inst.achievementLeaderboardModel = achievementLeaderboardModel
+}
+
+// achievementLeaderboardOriginal is an object which stores original AchievementLeaderboard from database
+type achievementLeaderboardOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *AchievementLeaderboardN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &achievementLeaderboardOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *AchievementLeaderboardN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &achievementLeaderboardOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *AchievementLeaderboardN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.achievementLeaderboardModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.achievementLeaderboardModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a achievement_leaderboard
+func (inst *AchievementLeaderboardN) Delete(ctx context.Context) error {
+	if inst.achievementLeaderboardModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.achievementLeaderboardModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *AchievementLeaderboardN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type achievementLeaderboardScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var achievementLeaderboardGlobalScopes = make([]achievementLeaderboardScope, 0)
+var achievementLeaderboardLocalScopes = make([]achievementLeaderboardScope, 0)
+
+// AddGlobalScopeForAchievementLeaderboard assign a global scope to a model
+func AddGlobalScopeForAchievementLeaderboard(name string, apply func(builder query.Condition)) {
+	achievementLeaderboardGlobalScopes = append(achievementLeaderboardGlobalScopes, achievementLeaderboardScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForAchievementLeaderboard assign a local scope to a model
+func AddLocalScopeForAchievementLeaderboard(name string, apply func(builder query.Condition)) {
+	achievementLeaderboardLocalScopes = append(achievementLeaderboardLocalScopes, achievementLeaderboardScope{name: name, apply: apply})
+}
+
+func (m *AchievementLeaderboardModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range achievementLeaderboardGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range achievementLeaderboardLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *AchievementLeaderboardModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *AchievementLeaderboardModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type AchievementLeaderboard struct {
+	Id        int64 `json:"id"`
+	UserId    int64 `json:"user_id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w AchievementLeaderboard) ToAchievementLeaderboardN(allows ...string) AchievementLeaderboardN {
+	if len(allows) == 0 {
+		return AchievementLeaderboardN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := AchievementLeaderboardN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w AchievementLeaderboard) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *AchievementLeaderboardN) ToAchievementLeaderboard() AchievementLeaderboard {
+	return AchievementLeaderboard{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// AchievementLeaderboardModel is a model which encapsulates the operations of the object
+type AchievementLeaderboardModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var achievementLeaderboardTableName = "achievement_leaderboard"
+
+// AchievementLeaderboardTable return table name for AchievementLeaderboard
+func AchievementLeaderboardTable() string {
+	return achievementLeaderboardTableName
+}
+
+const (
+	FieldAchievementLeaderboardId        = "id"
+	FieldAchievementLeaderboardUserId    = "user_id"
+	FieldAchievementLeaderboardCreatedAt = "created_at"
+	FieldAchievementLeaderboardUpdatedAt = "updated_at"
+)
+
+// AchievementLeaderboardFields return all fields in AchievementLeaderboard model
+func AchievementLeaderboardFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetAchievementLeaderboardTable(tableName string) {
+	achievementLeaderboardTableName = tableName
+}
+
+// NewAchievementLeaderboardModel create a AchievementLeaderboardModel
+func NewAchievementLeaderboardModel(db query.Database) *AchievementLeaderboardModel {
+	return &AchievementLeaderboardModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           achievementLeaderboardTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *AchievementLeaderboardModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *AchievementLeaderboardModel) clone() *AchievementLeaderboardModel {
+	return &AchievementLeaderboardModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *AchievementLeaderboardModel) WithoutGlobalScopes(names ...string) *AchievementLeaderboardModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *AchievementLeaderboardModel) WithLocalScopes(names ...string) *AchievementLeaderboardModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *AchievementLeaderboardModel) Condition(builder query.SQLBuilder) *AchievementLeaderboardModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *AchievementLeaderboardModel) Find(ctx context.Context, id int64) (*AchievementLeaderboardN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *AchievementLeaderboardModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *AchievementLeaderboardModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *AchievementLeaderboardModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]AchievementLeaderboardN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *AchievementLeaderboardModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]AchievementLeaderboardN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*AchievementLeaderboardN, []interface{}) {
+		var achievementLeaderboardVar AchievementLeaderboardN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &achievementLeaderboardVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &achievementLeaderboardVar.UserId)
+			case "created_at":
+				scanFields = append(scanFields, &achievementLeaderboardVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &achievementLeaderboardVar.UpdatedAt)
+			}
+		}
+
+		return &achievementLeaderboardVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	achievementLeaderboards := make([]AchievementLeaderboardN, 0)
+	for rows.Next() {
+		achievementLeaderboardReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		achievementLeaderboardReal.original = &achievementLeaderboardOriginal{}
+		_ = query.Copy(achievementLeaderboardReal, achievementLeaderboardReal.original)
+
+		achievementLeaderboardReal.SetModel(m)
+		achievementLeaderboards = append(achievementLeaderboards, *achievementLeaderboardReal)
+	}
+
+	return achievementLeaderboards, nil
+}
+
+// First return first result for given query
+func (m *AchievementLeaderboardModel) First(ctx context.Context, builders ...query.SQLBuilder) (*AchievementLeaderboardN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new achievement_leaderboard to database
+func (m *AchievementLeaderboardModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all achievement_leaderboards to database
+func (m *AchievementLeaderboardModel) SaveAll(ctx context.Context, achievementLeaderboards []AchievementLeaderboardN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, achievementLeaderboard := range achievementLeaderboards {
+		id, err := m.Save(ctx, achievementLeaderboard)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a achievement_leaderboard to database
+func (m *AchievementLeaderboardModel) Save(ctx context.Context, achievementLeaderboard AchievementLeaderboardN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, achievementLeaderboard.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new achievement_leaderboard or update it when it has a id > 0
+func (m *AchievementLeaderboardModel) SaveOrUpdate(ctx context.Context, achievementLeaderboard AchievementLeaderboardN, onlyFields ...string) (id int64, updated bool, err error) {
+	if achievementLeaderboard.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, achievementLeaderboard.Id.Int64, achievementLeaderboard, onlyFields...)
+		return achievementLeaderboard.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, achievementLeaderboard, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *AchievementLeaderboardModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *AchievementLeaderboardModel) Update(ctx context.Context, builder query.SQLBuilder, achievementLeaderboard AchievementLeaderboardN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, achievementLeaderboard.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *AchievementLeaderboardModel) UpdateById(ctx context.Context, id int64, achievementLeaderboard AchievementLeaderboardN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, achievementLeaderboard.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *AchievementLeaderboardModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *AchievementLeaderboardModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
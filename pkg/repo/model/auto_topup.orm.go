@@ -0,0 +1,971 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// AutoTopupN is a AutoTopup object, all fields are nullable
+type AutoTopupN struct {
+	original       *autoTopupOriginal
+	autoTopupModel *AutoTopupModel
+
+	Id                  null.Int    `json:"id"`
+	UserId              null.Int    `json:"user_id"`
+	Status              null.Int    `json:"status"`
+	ProductId           null.String `json:"product_id"`
+	Threshold           null.Int    `json:"threshold"`
+	MonthlyCap          null.Int    `json:"monthly_cap"`
+	ExternalAgreementNo null.String `json:"external_agreement_no"`
+	AgreementNo         null.String `json:"agreement_no"`
+	AlipayUserId        null.String `json:"alipay_user_id"`
+	SignedAt            null.Time   `json:"signed_at"`
+	NotifiedAt          null.Time   `json:"notified_at"`
+	LastChargedAt       null.Time   `json:"last_charged_at"`
+	Failures            null.Int    `json:"failures"`
+	LastError           null.String `json:"last_error"`
+	CreatedAt           null.Time
+	UpdatedAt           null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *AutoTopupN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for AutoTopup
+func (inst *AutoTopupN) SetModel(autoTopupModel *AutoTopupModel) {
+	inst.autoTopupModel = autoTopupModel
+}
+
+// autoTopupOriginal is an object which stores original AutoTopup from database
+type autoTopupOriginal struct {
+	Id                  null.Int
+	UserId              null.Int
+	Status              null.Int
+	ProductId           null.String
+	Threshold           null.Int
+	MonthlyCap          null.Int
+	ExternalAgreementNo null.String
+	AgreementNo         null.String
+	AlipayUserId        null.String
+	SignedAt            null.Time
+	NotifiedAt          null.Time
+	LastChargedAt       null.Time
+	Failures            null.Int
+	LastError           null.String
+	CreatedAt           null.Time
+	UpdatedAt           null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *AutoTopupN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &autoTopupOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.ProductId != inst.original.ProductId {
+			return true
+		}
+		if inst.Threshold != inst.original.Threshold {
+			return true
+		}
+		if inst.MonthlyCap != inst.original.MonthlyCap {
+			return true
+		}
+		if inst.ExternalAgreementNo != inst.original.ExternalAgreementNo {
+			return true
+		}
+		if inst.AgreementNo != inst.original.AgreementNo {
+			return true
+		}
+		if inst.AlipayUserId != inst.original.AlipayUserId {
+			return true
+		}
+		if inst.SignedAt != inst.original.SignedAt {
+			return true
+		}
+		if inst.NotifiedAt != inst.original.NotifiedAt {
+			return true
+		}
+		if inst.LastChargedAt != inst.original.LastChargedAt {
+			return true
+		}
+		if inst.Failures != inst.original.Failures {
+			return true
+		}
+		if inst.LastError != inst.original.LastError {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "product_id":
+				if inst.ProductId != inst.original.ProductId {
+					return true
+				}
+			case "threshold":
+				if inst.Threshold != inst.original.Threshold {
+					return true
+				}
+			case "monthly_cap":
+				if inst.MonthlyCap != inst.original.MonthlyCap {
+					return true
+				}
+			case "external_agreement_no":
+				if inst.ExternalAgreementNo != inst.original.ExternalAgreementNo {
+					return true
+				}
+			case "agreement_no":
+				if inst.AgreementNo != inst.original.AgreementNo {
+					return true
+				}
+			case "alipay_user_id":
+				if inst.AlipayUserId != inst.original.AlipayUserId {
+					return true
+				}
+			case "signed_at":
+				if inst.SignedAt != inst.original.SignedAt {
+					return true
+				}
+			case "notified_at":
+				if inst.NotifiedAt != inst.original.NotifiedAt {
+					return true
+				}
+			case "last_charged_at":
+				if inst.LastChargedAt != inst.original.LastChargedAt {
+					return true
+				}
+			case "failures":
+				if inst.Failures != inst.original.Failures {
+					return true
+				}
+			case "last_error":
+				if inst.LastError != inst.original.LastError {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *AutoTopupN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &autoTopupOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.ProductId != inst.original.ProductId {
+			kv["product_id"] = inst.ProductId
+		}
+		if inst.Threshold != inst.original.Threshold {
+			kv["threshold"] = inst.Threshold
+		}
+		if inst.MonthlyCap != inst.original.MonthlyCap {
+			kv["monthly_cap"] = inst.MonthlyCap
+		}
+		if inst.ExternalAgreementNo != inst.original.ExternalAgreementNo {
+			kv["external_agreement_no"] = inst.ExternalAgreementNo
+		}
+		if inst.AgreementNo != inst.original.AgreementNo {
+			kv["agreement_no"] = inst.AgreementNo
+		}
+		if inst.AlipayUserId != inst.original.AlipayUserId {
+			kv["alipay_user_id"] = inst.AlipayUserId
+		}
+		if inst.SignedAt != inst.original.SignedAt {
+			kv["signed_at"] = inst.SignedAt
+		}
+		if inst.NotifiedAt != inst.original.NotifiedAt {
+			kv["notified_at"] = inst.NotifiedAt
+		}
+		if inst.LastChargedAt != inst.original.LastChargedAt {
+			kv["last_charged_at"] = inst.LastChargedAt
+		}
+		if inst.Failures != inst.original.Failures {
+			kv["failures"] = inst.Failures
+		}
+		if inst.LastError != inst.original.LastError {
+			kv["last_error"] = inst.LastError
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "product_id":
+				if inst.ProductId != inst.original.ProductId {
+					kv["product_id"] = inst.ProductId
+				}
+			case "threshold":
+				if inst.Threshold != inst.original.Threshold {
+					kv["threshold"] = inst.Threshold
+				}
+			case "monthly_cap":
+				if inst.MonthlyCap != inst.original.MonthlyCap {
+					kv["monthly_cap"] = inst.MonthlyCap
+				}
+			case "external_agreement_no":
+				if inst.ExternalAgreementNo != inst.original.ExternalAgreementNo {
+					kv["external_agreement_no"] = inst.ExternalAgreementNo
+				}
+			case "agreement_no":
+				if inst.AgreementNo != inst.original.AgreementNo {
+					kv["agreement_no"] = inst.AgreementNo
+				}
+			case "alipay_user_id":
+				if inst.AlipayUserId != inst.original.AlipayUserId {
+					kv["alipay_user_id"] = inst.AlipayUserId
+				}
+			case "signed_at":
+				if inst.SignedAt != inst.original.SignedAt {
+					kv["signed_at"] = inst.SignedAt
+				}
+			case "notified_at":
+				if inst.NotifiedAt != inst.original.NotifiedAt {
+					kv["notified_at"] = inst.NotifiedAt
+				}
+			case "last_charged_at":
+				if inst.LastChargedAt != inst.original.LastChargedAt {
+					kv["last_charged_at"] = inst.LastChargedAt
+				}
+			case "failures":
+				if inst.Failures != inst.original.Failures {
+					kv["failures"] = inst.Failures
+				}
+			case "last_error":
+				if inst.LastError != inst.original.LastError {
+					kv["last_error"] = inst.LastError
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *AutoTopupN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.autoTopupModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.autoTopupModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a auto_topup
+func (inst *AutoTopupN) Delete(ctx context.Context) error {
+	if inst.autoTopupModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.autoTopupModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *AutoTopupN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type autoTopupScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var autoTopupGlobalScopes = make([]autoTopupScope, 0)
+var autoTopupLocalScopes = make([]autoTopupScope, 0)
+
+// AddGlobalScopeForAutoTopup assign a global scope to a model
+func AddGlobalScopeForAutoTopup(name string, apply func(builder query.Condition)) {
+	autoTopupGlobalScopes = append(autoTopupGlobalScopes, autoTopupScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForAutoTopup assign a local scope to a model
+func AddLocalScopeForAutoTopup(name string, apply func(builder query.Condition)) {
+	autoTopupLocalScopes = append(autoTopupLocalScopes, autoTopupScope{name: name, apply: apply})
+}
+
+func (m *AutoTopupModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range autoTopupGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range autoTopupLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *AutoTopupModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *AutoTopupModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type AutoTopup struct {
+	Id                  int64     `json:"id"`
+	UserId              int64     `json:"user_id"`
+	Status              int64     `json:"status"`
+	ProductId           string    `json:"product_id"`
+	Threshold           int64     `json:"threshold"`
+	MonthlyCap          int64     `json:"monthly_cap"`
+	ExternalAgreementNo string    `json:"external_agreement_no"`
+	AgreementNo         string    `json:"agreement_no"`
+	AlipayUserId        string    `json:"alipay_user_id"`
+	SignedAt            time.Time `json:"signed_at"`
+	NotifiedAt          time.Time `json:"notified_at"`
+	LastChargedAt       time.Time `json:"last_charged_at"`
+	Failures            int64     `json:"failures"`
+	LastError           string    `json:"last_error"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (w AutoTopup) ToAutoTopupN(allows ...string) AutoTopupN {
+	if len(allows) == 0 {
+		return AutoTopupN{
+
+			Id:                  null.IntFrom(int64(w.Id)),
+			UserId:              null.IntFrom(int64(w.UserId)),
+			Status:              null.IntFrom(int64(w.Status)),
+			ProductId:           null.StringFrom(w.ProductId),
+			Threshold:           null.IntFrom(int64(w.Threshold)),
+			MonthlyCap:          null.IntFrom(int64(w.MonthlyCap)),
+			ExternalAgreementNo: null.StringFrom(w.ExternalAgreementNo),
+			AgreementNo:         null.StringFrom(w.AgreementNo),
+			AlipayUserId:        null.StringFrom(w.AlipayUserId),
+			SignedAt:            null.TimeFrom(w.SignedAt),
+			NotifiedAt:          null.TimeFrom(w.NotifiedAt),
+			LastChargedAt:       null.TimeFrom(w.LastChargedAt),
+			Failures:            null.IntFrom(int64(w.Failures)),
+			LastError:           null.StringFrom(w.LastError),
+			CreatedAt:           null.TimeFrom(w.CreatedAt),
+			UpdatedAt:           null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := AutoTopupN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "product_id":
+			res.ProductId = null.StringFrom(w.ProductId)
+		case "threshold":
+			res.Threshold = null.IntFrom(int64(w.Threshold))
+		case "monthly_cap":
+			res.MonthlyCap = null.IntFrom(int64(w.MonthlyCap))
+		case "external_agreement_no":
+			res.ExternalAgreementNo = null.StringFrom(w.ExternalAgreementNo)
+		case "agreement_no":
+			res.AgreementNo = null.StringFrom(w.AgreementNo)
+		case "alipay_user_id":
+			res.AlipayUserId = null.StringFrom(w.AlipayUserId)
+		case "signed_at":
+			res.SignedAt = null.TimeFrom(w.SignedAt)
+		case "notified_at":
+			res.NotifiedAt = null.TimeFrom(w.NotifiedAt)
+		case "last_charged_at":
+			res.LastChargedAt = null.TimeFrom(w.LastChargedAt)
+		case "failures":
+			res.Failures = null.IntFrom(int64(w.Failures))
+		case "last_error":
+			res.LastError = null.StringFrom(w.LastError)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w AutoTopup) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *AutoTopupN) ToAutoTopup() AutoTopup {
+	return AutoTopup{
+
+		Id:                  w.Id.Int64,
+		UserId:              w.UserId.Int64,
+		Status:              w.Status.Int64,
+		ProductId:           w.ProductId.String,
+		Threshold:           w.Threshold.Int64,
+		MonthlyCap:          w.MonthlyCap.Int64,
+		ExternalAgreementNo: w.ExternalAgreementNo.String,
+		AgreementNo:         w.AgreementNo.String,
+		AlipayUserId:        w.AlipayUserId.String,
+		SignedAt:            w.SignedAt.Time,
+		NotifiedAt:          w.NotifiedAt.Time,
+		LastChargedAt:       w.LastChargedAt.Time,
+		Failures:            w.Failures.Int64,
+		LastError:           w.LastError.String,
+		CreatedAt:           w.CreatedAt.Time,
+		UpdatedAt:           w.UpdatedAt.Time,
+	}
+}
+
+// AutoTopupModel is a model which encapsulates the operations of the object
+type AutoTopupModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var autoTopupTableName = "auto_topup"
+
+// AutoTopupTable return table name for AutoTopup
+func AutoTopupTable() string {
+	return autoTopupTableName
+}
+
+const (
+	FieldAutoTopupId                  = "id"
+	FieldAutoTopupUserId              = "user_id"
+	FieldAutoTopupStatus              = "status"
+	FieldAutoTopupProductId           = "product_id"
+	FieldAutoTopupThreshold           = "threshold"
+	FieldAutoTopupMonthlyCap          = "monthly_cap"
+	FieldAutoTopupExternalAgreementNo = "external_agreement_no"
+	FieldAutoTopupAgreementNo         = "agreement_no"
+	FieldAutoTopupAlipayUserId        = "alipay_user_id"
+	FieldAutoTopupSignedAt            = "signed_at"
+	FieldAutoTopupNotifiedAt          = "notified_at"
+	FieldAutoTopupLastChargedAt       = "last_charged_at"
+	FieldAutoTopupFailures            = "failures"
+	FieldAutoTopupLastError           = "last_error"
+	FieldAutoTopupCreatedAt           = "created_at"
+	FieldAutoTopupUpdatedAt           = "updated_at"
+)
+
+// AutoTopupFields return all fields in AutoTopup model
+func AutoTopupFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"status",
+		"product_id",
+		"threshold",
+		"monthly_cap",
+		"external_agreement_no",
+		"agreement_no",
+		"alipay_user_id",
+		"signed_at",
+		"notified_at",
+		"last_charged_at",
+		"failures",
+		"last_error",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetAutoTopupTable(tableName string) {
+	autoTopupTableName = tableName
+}
+
+// NewAutoTopupModel create a AutoTopupModel
+func NewAutoTopupModel(db query.Database) *AutoTopupModel {
+	return &AutoTopupModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           autoTopupTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *AutoTopupModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *AutoTopupModel) clone() *AutoTopupModel {
+	return &AutoTopupModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *AutoTopupModel) WithoutGlobalScopes(names ...string) *AutoTopupModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *AutoTopupModel) WithLocalScopes(names ...string) *AutoTopupModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *AutoTopupModel) Condition(builder query.SQLBuilder) *AutoTopupModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *AutoTopupModel) Find(ctx context.Context, id int64) (*AutoTopupN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *AutoTopupModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *AutoTopupModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *AutoTopupModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]AutoTopupN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *AutoTopupModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]AutoTopupN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"status",
+			"product_id",
+			"threshold",
+			"monthly_cap",
+			"external_agreement_no",
+			"agreement_no",
+			"alipay_user_id",
+			"signed_at",
+			"notified_at",
+			"last_charged_at",
+			"failures",
+			"last_error",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "product_id":
+			selectFields = append(selectFields, f)
+		case "threshold":
+			selectFields = append(selectFields, f)
+		case "monthly_cap":
+			selectFields = append(selectFields, f)
+		case "external_agreement_no":
+			selectFields = append(selectFields, f)
+		case "agreement_no":
+			selectFields = append(selectFields, f)
+		case "alipay_user_id":
+			selectFields = append(selectFields, f)
+		case "signed_at":
+			selectFields = append(selectFields, f)
+		case "notified_at":
+			selectFields = append(selectFields, f)
+		case "last_charged_at":
+			selectFields = append(selectFields, f)
+		case "failures":
+			selectFields = append(selectFields, f)
+		case "last_error":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*AutoTopupN, []interface{}) {
+		var autoTopupVar AutoTopupN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &autoTopupVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &autoTopupVar.UserId)
+			case "status":
+				scanFields = append(scanFields, &autoTopupVar.Status)
+			case "product_id":
+				scanFields = append(scanFields, &autoTopupVar.ProductId)
+			case "threshold":
+				scanFields = append(scanFields, &autoTopupVar.Threshold)
+			case "monthly_cap":
+				scanFields = append(scanFields, &autoTopupVar.MonthlyCap)
+			case "external_agreement_no":
+				scanFields = append(scanFields, &autoTopupVar.ExternalAgreementNo)
+			case "agreement_no":
+				scanFields = append(scanFields, &autoTopupVar.AgreementNo)
+			case "alipay_user_id":
+				scanFields = append(scanFields, &autoTopupVar.AlipayUserId)
+			case "signed_at":
+				scanFields = append(scanFields, &autoTopupVar.SignedAt)
+			case "notified_at":
+				scanFields = append(scanFields, &autoTopupVar.NotifiedAt)
+			case "last_charged_at":
+				scanFields = append(scanFields, &autoTopupVar.LastChargedAt)
+			case "failures":
+				scanFields = append(scanFields, &autoTopupVar.Failures)
+			case "last_error":
+				scanFields = append(scanFields, &autoTopupVar.LastError)
+			case "created_at":
+				scanFields = append(scanFields, &autoTopupVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &autoTopupVar.UpdatedAt)
+			}
+		}
+
+		return &autoTopupVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	autoTopups := make([]AutoTopupN, 0)
+	for rows.Next() {
+		autoTopupReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		autoTopupReal.original = &autoTopupOriginal{}
+		_ = query.Copy(autoTopupReal, autoTopupReal.original)
+
+		autoTopupReal.SetModel(m)
+		autoTopups = append(autoTopups, *autoTopupReal)
+	}
+
+	return autoTopups, nil
+}
+
+// First return first result for given query
+func (m *AutoTopupModel) First(ctx context.Context, builders ...query.SQLBuilder) (*AutoTopupN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new auto_topup to database
+func (m *AutoTopupModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all auto_topups to database
+func (m *AutoTopupModel) SaveAll(ctx context.Context, autoTopups []AutoTopupN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, autoTopup := range autoTopups {
+		id, err := m.Save(ctx, autoTopup)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a auto_topup to database
+func (m *AutoTopupModel) Save(ctx context.Context, autoTopup AutoTopupN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, autoTopup.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new auto_topup or update it when it has a id > 0
+func (m *AutoTopupModel) SaveOrUpdate(ctx context.Context, autoTopup AutoTopupN, onlyFields ...string) (id int64, updated bool, err error) {
+	if autoTopup.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, autoTopup.Id.Int64, autoTopup, onlyFields...)
+		return autoTopup.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, autoTopup, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *AutoTopupModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *AutoTopupModel) Update(ctx context.Context, builder query.SQLBuilder, autoTopup AutoTopupN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, autoTopup.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *AutoTopupModel) UpdateById(ctx context.Context, id int64, autoTopup AutoTopupN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, autoTopup.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *AutoTopupModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *AutoTopupModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,803 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// AvatarPackConsentN is a AvatarPackConsent object, all fields are nullable
+type AvatarPackConsentN struct {
+	original               *avatarPackConsentOriginal
+	avatarPackConsentModel *AvatarPackConsentModel
+
+	Id             null.Int    `json:"id"`
+	UserId         null.Int    `json:"user_id"`
+	TaskId         null.String `json:"task_id"`
+	ConsentVersion null.String `json:"consent_version"`
+	ClientIp       null.String `json:"client_ip"`
+	Selfies        null.String `json:"selfies"`
+	ConsentedAt    null.Time   `json:"consented_at"`
+	PurgedAt       null.Time   `json:"purged_at"`
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *AvatarPackConsentN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for AvatarPackConsent
+func (inst *AvatarPackConsentN) SetModel(avatarPackConsentModel *AvatarPackConsentModel) {
+	inst.avatarPackConsentModel = avatarPackConsentModel
+}
+
+// avatarPackConsentOriginal is an object which stores original AvatarPackConsent from database
+type avatarPackConsentOriginal struct {
+	Id             null.Int
+	UserId         null.Int
+	TaskId         null.String
+	ConsentVersion null.String
+	ClientIp       null.String
+	Selfies        null.String
+	ConsentedAt    null.Time
+	PurgedAt       null.Time
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *AvatarPackConsentN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &avatarPackConsentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.TaskId != inst.original.TaskId {
+			return true
+		}
+		if inst.ConsentVersion != inst.original.ConsentVersion {
+			return true
+		}
+		if inst.ClientIp != inst.original.ClientIp {
+			return true
+		}
+		if inst.Selfies != inst.original.Selfies {
+			return true
+		}
+		if inst.ConsentedAt != inst.original.ConsentedAt {
+			return true
+		}
+		if inst.PurgedAt != inst.original.PurgedAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					return true
+				}
+			case "consent_version":
+				if inst.ConsentVersion != inst.original.ConsentVersion {
+					return true
+				}
+			case "client_ip":
+				if inst.ClientIp != inst.original.ClientIp {
+					return true
+				}
+			case "selfies":
+				if inst.Selfies != inst.original.Selfies {
+					return true
+				}
+			case "consented_at":
+				if inst.ConsentedAt != inst.original.ConsentedAt {
+					return true
+				}
+			case "purged_at":
+				if inst.PurgedAt != inst.original.PurgedAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *AvatarPackConsentN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &avatarPackConsentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.TaskId != inst.original.TaskId {
+			kv["task_id"] = inst.TaskId
+		}
+		if inst.ConsentVersion != inst.original.ConsentVersion {
+			kv["consent_version"] = inst.ConsentVersion
+		}
+		if inst.ClientIp != inst.original.ClientIp {
+			kv["client_ip"] = inst.ClientIp
+		}
+		if inst.Selfies != inst.original.Selfies {
+			kv["selfies"] = inst.Selfies
+		}
+		if inst.ConsentedAt != inst.original.ConsentedAt {
+			kv["consented_at"] = inst.ConsentedAt
+		}
+		if inst.PurgedAt != inst.original.PurgedAt {
+			kv["purged_at"] = inst.PurgedAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					kv["task_id"] = inst.TaskId
+				}
+			case "consent_version":
+				if inst.ConsentVersion != inst.original.ConsentVersion {
+					kv["consent_version"] = inst.ConsentVersion
+				}
+			case "client_ip":
+				if inst.ClientIp != inst.original.ClientIp {
+					kv["client_ip"] = inst.ClientIp
+				}
+			case "selfies":
+				if inst.Selfies != inst.original.Selfies {
+					kv["selfies"] = inst.Selfies
+				}
+			case "consented_at":
+				if inst.ConsentedAt != inst.original.ConsentedAt {
+					kv["consented_at"] = inst.ConsentedAt
+				}
+			case "purged_at":
+				if inst.PurgedAt != inst.original.PurgedAt {
+					kv["purged_at"] = inst.PurgedAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *AvatarPackConsentN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.avatarPackConsentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.avatarPackConsentModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a avatar_pack_consent
+func (inst *AvatarPackConsentN) Delete(ctx context.Context) error {
+	if inst.avatarPackConsentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.avatarPackConsentModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *AvatarPackConsentN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type avatarPackConsentScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var avatarPackConsentGlobalScopes = make([]avatarPackConsentScope, 0)
+var avatarPackConsentLocalScopes = make([]avatarPackConsentScope, 0)
+
+// AddGlobalScopeForAvatarPackConsent assign a global scope to a model
+func AddGlobalScopeForAvatarPackConsent(name string, apply func(builder query.Condition)) {
+	avatarPackConsentGlobalScopes = append(avatarPackConsentGlobalScopes, avatarPackConsentScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForAvatarPackConsent assign a local scope to a model
+func AddLocalScopeForAvatarPackConsent(name string, apply func(builder query.Condition)) {
+	avatarPackConsentLocalScopes = append(avatarPackConsentLocalScopes, avatarPackConsentScope{name: name, apply: apply})
+}
+
+func (m *AvatarPackConsentModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range avatarPackConsentGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range avatarPackConsentLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *AvatarPackConsentModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *AvatarPackConsentModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type AvatarPackConsent struct {
+	Id             int64     `json:"id"`
+	UserId         int64     `json:"user_id"`
+	TaskId         string    `json:"task_id"`
+	ConsentVersion string    `json:"consent_version"`
+	ClientIp       string    `json:"client_ip"`
+	Selfies        string    `json:"selfies"`
+	ConsentedAt    time.Time `json:"consented_at"`
+	PurgedAt       time.Time `json:"purged_at"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (w AvatarPackConsent) ToAvatarPackConsentN(allows ...string) AvatarPackConsentN {
+	if len(allows) == 0 {
+		return AvatarPackConsentN{
+
+			Id:             null.IntFrom(int64(w.Id)),
+			UserId:         null.IntFrom(int64(w.UserId)),
+			TaskId:         null.StringFrom(w.TaskId),
+			ConsentVersion: null.StringFrom(w.ConsentVersion),
+			ClientIp:       null.StringFrom(w.ClientIp),
+			Selfies:        null.StringFrom(w.Selfies),
+			ConsentedAt:    null.TimeFrom(w.ConsentedAt),
+			PurgedAt:       null.TimeFrom(w.PurgedAt),
+			CreatedAt:      null.TimeFrom(w.CreatedAt),
+			UpdatedAt:      null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := AvatarPackConsentN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "task_id":
+			res.TaskId = null.StringFrom(w.TaskId)
+		case "consent_version":
+			res.ConsentVersion = null.StringFrom(w.ConsentVersion)
+		case "client_ip":
+			res.ClientIp = null.StringFrom(w.ClientIp)
+		case "selfies":
+			res.Selfies = null.StringFrom(w.Selfies)
+		case "consented_at":
+			res.ConsentedAt = null.TimeFrom(w.ConsentedAt)
+		case "purged_at":
+			res.PurgedAt = null.TimeFrom(w.PurgedAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w AvatarPackConsent) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *AvatarPackConsentN) ToAvatarPackConsent() AvatarPackConsent {
+	return AvatarPackConsent{
+
+		Id:             w.Id.Int64,
+		UserId:         w.UserId.Int64,
+		TaskId:         w.TaskId.String,
+		ConsentVersion: w.ConsentVersion.String,
+		ClientIp:       w.ClientIp.String,
+		Selfies:        w.Selfies.String,
+		ConsentedAt:    w.ConsentedAt.Time,
+		PurgedAt:       w.PurgedAt.Time,
+		CreatedAt:      w.CreatedAt.Time,
+		UpdatedAt:      w.UpdatedAt.Time,
+	}
+}
+
+// AvatarPackConsentModel is a model which encapsulates the operations of the object
+type AvatarPackConsentModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var avatarPackConsentTableName = "avatar_pack_consent"
+
+// AvatarPackConsentTable return table name for AvatarPackConsent
+func AvatarPackConsentTable() string {
+	return avatarPackConsentTableName
+}
+
+const (
+	FieldAvatarPackConsentId             = "id"
+	FieldAvatarPackConsentUserId         = "user_id"
+	FieldAvatarPackConsentTaskId         = "task_id"
+	FieldAvatarPackConsentConsentVersion = "consent_version"
+	FieldAvatarPackConsentClientIp       = "client_ip"
+	FieldAvatarPackConsentSelfies        = "selfies"
+	FieldAvatarPackConsentConsentedAt    = "consented_at"
+	FieldAvatarPackConsentPurgedAt       = "purged_at"
+	FieldAvatarPackConsentCreatedAt      = "created_at"
+	FieldAvatarPackConsentUpdatedAt      = "updated_at"
+)
+
+// AvatarPackConsentFields return all fields in AvatarPackConsent model
+func AvatarPackConsentFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"task_id",
+		"consent_version",
+		"client_ip",
+		"selfies",
+		"consented_at",
+		"purged_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetAvatarPackConsentTable(tableName string) {
+	avatarPackConsentTableName = tableName
+}
+
+// NewAvatarPackConsentModel create a AvatarPackConsentModel
+func NewAvatarPackConsentModel(db query.Database) *AvatarPackConsentModel {
+	return &AvatarPackConsentModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           avatarPackConsentTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *AvatarPackConsentModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *AvatarPackConsentModel) clone() *AvatarPackConsentModel {
+	return &AvatarPackConsentModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *AvatarPackConsentModel) WithoutGlobalScopes(names ...string) *AvatarPackConsentModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *AvatarPackConsentModel) WithLocalScopes(names ...string) *AvatarPackConsentModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *AvatarPackConsentModel) Condition(builder query.SQLBuilder) *AvatarPackConsentModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *AvatarPackConsentModel) Find(ctx context.Context, id int64) (*AvatarPackConsentN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *AvatarPackConsentModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *AvatarPackConsentModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *AvatarPackConsentModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]AvatarPackConsentN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *AvatarPackConsentModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]AvatarPackConsentN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"task_id",
+			"consent_version",
+			"client_ip",
+			"selfies",
+			"consented_at",
+			"purged_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "task_id":
+			selectFields = append(selectFields, f)
+		case "consent_version":
+			selectFields = append(selectFields, f)
+		case "client_ip":
+			selectFields = append(selectFields, f)
+		case "selfies":
+			selectFields = append(selectFields, f)
+		case "consented_at":
+			selectFields = append(selectFields, f)
+		case "purged_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*AvatarPackConsentN, []interface{}) {
+		var avatarPackConsentVar AvatarPackConsentN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &avatarPackConsentVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &avatarPackConsentVar.UserId)
+			case "task_id":
+				scanFields = append(scanFields, &avatarPackConsentVar.TaskId)
+			case "consent_version":
+				scanFields = append(scanFields, &avatarPackConsentVar.ConsentVersion)
+			case "client_ip":
+				scanFields = append(scanFields, &avatarPackConsentVar.ClientIp)
+			case "selfies":
+				scanFields = append(scanFields, &avatarPackConsentVar.Selfies)
+			case "consented_at":
+				scanFields = append(scanFields, &avatarPackConsentVar.ConsentedAt)
+			case "purged_at":
+				scanFields = append(scanFields, &avatarPackConsentVar.PurgedAt)
+			case "created_at":
+				scanFields = append(scanFields, &avatarPackConsentVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &avatarPackConsentVar.UpdatedAt)
+			}
+		}
+
+		return &avatarPackConsentVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	avatarPackConsents := make([]AvatarPackConsentN, 0)
+	for rows.Next() {
+		avatarPackConsentReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		avatarPackConsentReal.original = &avatarPackConsentOriginal{}
+		_ = query.Copy(avatarPackConsentReal, avatarPackConsentReal.original)
+
+		avatarPackConsentReal.SetModel(m)
+		avatarPackConsents = append(avatarPackConsents, *avatarPackConsentReal)
+	}
+
+	return avatarPackConsents, nil
+}
+
+// First return first result for given query
+func (m *AvatarPackConsentModel) First(ctx context.Context, builders ...query.SQLBuilder) (*AvatarPackConsentN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new avatar_pack_consent to database
+func (m *AvatarPackConsentModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all avatar_pack_consents to database
+func (m *AvatarPackConsentModel) SaveAll(ctx context.Context, avatarPackConsents []AvatarPackConsentN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, avatarPackConsent := range avatarPackConsents {
+		id, err := m.Save(ctx, avatarPackConsent)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a avatar_pack_consent to database
+func (m *AvatarPackConsentModel) Save(ctx context.Context, avatarPackConsent AvatarPackConsentN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, avatarPackConsent.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new avatar_pack_consent or update it when it has a id > 0
+func (m *AvatarPackConsentModel) SaveOrUpdate(ctx context.Context, avatarPackConsent AvatarPackConsentN, onlyFields ...string) (id int64, updated bool, err error) {
+	if avatarPackConsent.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, avatarPackConsent.Id.Int64, avatarPackConsent, onlyFields...)
+		return avatarPackConsent.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, avatarPackConsent, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *AvatarPackConsentModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *AvatarPackConsentModel) Update(ctx context.Context, builder query.SQLBuilder, avatarPackConsent AvatarPackConsentN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, avatarPackConsent.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *AvatarPackConsentModel) UpdateById(ctx context.Context, id int64, avatarPackConsent AvatarPackConsentN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, avatarPackConsent.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *AvatarPackConsentModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *AvatarPackConsentModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,1590 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// BetaCohortN is a BetaCohort object, all fields are nullable
+type BetaCohortN struct {
+	original        *betaCohortOriginal
+	betaCohortModel *BetaCohortModel
+
+	Id          null.Int    `json:"id"`
+	Name        null.String `json:"name"`
+	Description null.String `json:"description"`
+	Features    null.String `json:"features"`
+	Models      null.String `json:"models"`
+	OpenEnroll  null.Int    `json:"open_enroll"`
+	Capacity    null.Int    `json:"capacity"`
+	Status      null.Int    `json:"status"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *BetaCohortN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for BetaCohort
+func (inst *BetaCohortN) SetModel(betaCohortModel *BetaCohortModel) {
+	inst.betaCohortModel = betaCohortModel
+}
+
+// betaCohortOriginal is an object which stores original BetaCohort from database
+type betaCohortOriginal struct {
+	Id          null.Int
+	Name        null.String
+	Description null.String
+	Features    null.String
+	Models      null.String
+	OpenEnroll  null.Int
+	Capacity    null.Int
+	Status      null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *BetaCohortN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &betaCohortOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Description != inst.original.Description {
+			return true
+		}
+		if inst.Features != inst.original.Features {
+			return true
+		}
+		if inst.Models != inst.original.Models {
+			return true
+		}
+		if inst.OpenEnroll != inst.original.OpenEnroll {
+			return true
+		}
+		if inst.Capacity != inst.original.Capacity {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					return true
+				}
+			case "features":
+				if inst.Features != inst.original.Features {
+					return true
+				}
+			case "models":
+				if inst.Models != inst.original.Models {
+					return true
+				}
+			case "open_enroll":
+				if inst.OpenEnroll != inst.original.OpenEnroll {
+					return true
+				}
+			case "capacity":
+				if inst.Capacity != inst.original.Capacity {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *BetaCohortN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &betaCohortOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Description != inst.original.Description {
+			kv["description"] = inst.Description
+		}
+		if inst.Features != inst.original.Features {
+			kv["features"] = inst.Features
+		}
+		if inst.Models != inst.original.Models {
+			kv["models"] = inst.Models
+		}
+		if inst.OpenEnroll != inst.original.OpenEnroll {
+			kv["open_enroll"] = inst.OpenEnroll
+		}
+		if inst.Capacity != inst.original.Capacity {
+			kv["capacity"] = inst.Capacity
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					kv["description"] = inst.Description
+				}
+			case "features":
+				if inst.Features != inst.original.Features {
+					kv["features"] = inst.Features
+				}
+			case "models":
+				if inst.Models != inst.original.Models {
+					kv["models"] = inst.Models
+				}
+			case "open_enroll":
+				if inst.OpenEnroll != inst.original.OpenEnroll {
+					kv["open_enroll"] = inst.OpenEnroll
+				}
+			case "capacity":
+				if inst.Capacity != inst.original.Capacity {
+					kv["capacity"] = inst.Capacity
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *BetaCohortN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.betaCohortModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.betaCohortModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a beta_cohort
+func (inst *BetaCohortN) Delete(ctx context.Context) error {
+	if inst.betaCohortModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.betaCohortModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *BetaCohortN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type betaCohortScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var betaCohortGlobalScopes = make([]betaCohortScope, 0)
+var betaCohortLocalScopes = make([]betaCohortScope, 0)
+
+// AddGlobalScopeForBetaCohort assign a global scope to a model
+func AddGlobalScopeForBetaCohort(name string, apply func(builder query.Condition)) {
+	betaCohortGlobalScopes = append(betaCohortGlobalScopes, betaCohortScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForBetaCohort assign a local scope to a model
+func AddLocalScopeForBetaCohort(name string, apply func(builder query.Condition)) {
+	betaCohortLocalScopes = append(betaCohortLocalScopes, betaCohortScope{name: name, apply: apply})
+}
+
+func (m *BetaCohortModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range betaCohortGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range betaCohortLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *BetaCohortModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *BetaCohortModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type BetaCohort struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Features    string `json:"features"`
+	Models      string `json:"models"`
+	OpenEnroll  int64  `json:"open_enroll"`
+	Capacity    int64  `json:"capacity"`
+	Status      int64  `json:"status"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w BetaCohort) ToBetaCohortN(allows ...string) BetaCohortN {
+	if len(allows) == 0 {
+		return BetaCohortN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			Name:        null.StringFrom(w.Name),
+			Description: null.StringFrom(w.Description),
+			Features:    null.StringFrom(w.Features),
+			Models:      null.StringFrom(w.Models),
+			OpenEnroll:  null.IntFrom(int64(w.OpenEnroll)),
+			Capacity:    null.IntFrom(int64(w.Capacity)),
+			Status:      null.IntFrom(int64(w.Status)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := BetaCohortN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "description":
+			res.Description = null.StringFrom(w.Description)
+		case "features":
+			res.Features = null.StringFrom(w.Features)
+		case "models":
+			res.Models = null.StringFrom(w.Models)
+		case "open_enroll":
+			res.OpenEnroll = null.IntFrom(int64(w.OpenEnroll))
+		case "capacity":
+			res.Capacity = null.IntFrom(int64(w.Capacity))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w BetaCohort) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *BetaCohortN) ToBetaCohort() BetaCohort {
+	return BetaCohort{
+
+		Id:          w.Id.Int64,
+		Name:        w.Name.String,
+		Description: w.Description.String,
+		Features:    w.Features.String,
+		Models:      w.Models.String,
+		OpenEnroll:  w.OpenEnroll.Int64,
+		Capacity:    w.Capacity.Int64,
+		Status:      w.Status.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// BetaCohortModel is a model which encapsulates the operations of the object
+type BetaCohortModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var betaCohortTableName = "beta_cohort"
+
+// BetaCohortTable return table name for BetaCohort
+func BetaCohortTable() string {
+	return betaCohortTableName
+}
+
+const (
+	FieldBetaCohortId          = "id"
+	FieldBetaCohortName        = "name"
+	FieldBetaCohortDescription = "description"
+	FieldBetaCohortFeatures    = "features"
+	FieldBetaCohortModels      = "models"
+	FieldBetaCohortOpenEnroll  = "open_enroll"
+	FieldBetaCohortCapacity    = "capacity"
+	FieldBetaCohortStatus      = "status"
+	FieldBetaCohortCreatedAt   = "created_at"
+	FieldBetaCohortUpdatedAt   = "updated_at"
+)
+
+// BetaCohortFields return all fields in BetaCohort model
+func BetaCohortFields() []string {
+	return []string{
+		"id",
+		"name",
+		"description",
+		"features",
+		"models",
+		"open_enroll",
+		"capacity",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetBetaCohortTable(tableName string) {
+	betaCohortTableName = tableName
+}
+
+// NewBetaCohortModel create a BetaCohortModel
+func NewBetaCohortModel(db query.Database) *BetaCohortModel {
+	return &BetaCohortModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           betaCohortTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *BetaCohortModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *BetaCohortModel) clone() *BetaCohortModel {
+	return &BetaCohortModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *BetaCohortModel) WithoutGlobalScopes(names ...string) *BetaCohortModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *BetaCohortModel) WithLocalScopes(names ...string) *BetaCohortModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *BetaCohortModel) Condition(builder query.SQLBuilder) *BetaCohortModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *BetaCohortModel) Find(ctx context.Context, id int64) (*BetaCohortN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *BetaCohortModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *BetaCohortModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *BetaCohortModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]BetaCohortN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *BetaCohortModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]BetaCohortN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"description",
+			"features",
+			"models",
+			"open_enroll",
+			"capacity",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "description":
+			selectFields = append(selectFields, f)
+		case "features":
+			selectFields = append(selectFields, f)
+		case "models":
+			selectFields = append(selectFields, f)
+		case "open_enroll":
+			selectFields = append(selectFields, f)
+		case "capacity":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*BetaCohortN, []interface{}) {
+		var betaCohortVar BetaCohortN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &betaCohortVar.Id)
+			case "name":
+				scanFields = append(scanFields, &betaCohortVar.Name)
+			case "description":
+				scanFields = append(scanFields, &betaCohortVar.Description)
+			case "features":
+				scanFields = append(scanFields, &betaCohortVar.Features)
+			case "models":
+				scanFields = append(scanFields, &betaCohortVar.Models)
+			case "open_enroll":
+				scanFields = append(scanFields, &betaCohortVar.OpenEnroll)
+			case "capacity":
+				scanFields = append(scanFields, &betaCohortVar.Capacity)
+			case "status":
+				scanFields = append(scanFields, &betaCohortVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &betaCohortVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &betaCohortVar.UpdatedAt)
+			}
+		}
+
+		return &betaCohortVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	betaCohorts := make([]BetaCohortN, 0)
+	for rows.Next() {
+		betaCohortReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		betaCohortReal.original = &betaCohortOriginal{}
+		_ = query.Copy(betaCohortReal, betaCohortReal.original)
+
+		betaCohortReal.SetModel(m)
+		betaCohorts = append(betaCohorts, *betaCohortReal)
+	}
+
+	return betaCohorts, nil
+}
+
+// First return first result for given query
+func (m *BetaCohortModel) First(ctx context.Context, builders ...query.SQLBuilder) (*BetaCohortN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new beta_cohort to database
+func (m *BetaCohortModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all beta_cohorts to database
+func (m *BetaCohortModel) SaveAll(ctx context.Context, betaCohorts []BetaCohortN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, betaCohort := range betaCohorts {
+		id, err := m.Save(ctx, betaCohort)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a beta_cohort to database
+func (m *BetaCohortModel) Save(ctx context.Context, betaCohort BetaCohortN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, betaCohort.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new beta_cohort or update it when it has a id > 0
+func (m *BetaCohortModel) SaveOrUpdate(ctx context.Context, betaCohort BetaCohortN, onlyFields ...string) (id int64, updated bool, err error) {
+	if betaCohort.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, betaCohort.Id.Int64, betaCohort, onlyFields...)
+		return betaCohort.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, betaCohort, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *BetaCohortModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *BetaCohortModel) Update(ctx context.Context, builder query.SQLBuilder, betaCohort BetaCohortN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, betaCohort.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *BetaCohortModel) UpdateById(ctx context.Context, id int64, betaCohort BetaCohortN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, betaCohort.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *BetaCohortModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *BetaCohortModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// BetaFeedbackN is a BetaFeedback object, all fields are nullable
+type BetaFeedbackN struct {
+	original          *betaFeedbackOriginal
+	betaFeedbackModel *BetaFeedbackModel
+
+	Id            null.Int    `json:"id"`
+	CohortId      null.Int    `json:"cohort_id"`
+	UserId        null.Int    `json:"user_id"`
+	Feature       null.String `json:"feature"`
+	Rating        null.Int    `json:"rating"`
+	Content       null.String `json:"content"`
+	Platform      null.String `json:"platform"`
+	ClientVersion null.String `json:"client_version"`
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *BetaFeedbackN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for BetaFeedback
+func (inst *BetaFeedbackN) SetModel(betaFeedbackModel *BetaFeedbackModel) {
+	inst.betaFeedbackModel = betaFeedbackModel
+}
+
+// betaFeedbackOriginal is an object which stores original BetaFeedback from database
+type betaFeedbackOriginal struct {
+	Id            null.Int
+	CohortId      null.Int
+	UserId        null.Int
+	Feature       null.String
+	Rating        null.Int
+	Content       null.String
+	Platform      null.String
+	ClientVersion null.String
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *BetaFeedbackN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &betaFeedbackOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.CohortId != inst.original.CohortId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Feature != inst.original.Feature {
+			return true
+		}
+		if inst.Rating != inst.original.Rating {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.Platform != inst.original.Platform {
+			return true
+		}
+		if inst.ClientVersion != inst.original.ClientVersion {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "cohort_id":
+				if inst.CohortId != inst.original.CohortId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "feature":
+				if inst.Feature != inst.original.Feature {
+					return true
+				}
+			case "rating":
+				if inst.Rating != inst.original.Rating {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "platform":
+				if inst.Platform != inst.original.Platform {
+					return true
+				}
+			case "client_version":
+				if inst.ClientVersion != inst.original.ClientVersion {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *BetaFeedbackN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &betaFeedbackOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.CohortId != inst.original.CohortId {
+			kv["cohort_id"] = inst.CohortId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Feature != inst.original.Feature {
+			kv["feature"] = inst.Feature
+		}
+		if inst.Rating != inst.original.Rating {
+			kv["rating"] = inst.Rating
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.Platform != inst.original.Platform {
+			kv["platform"] = inst.Platform
+		}
+		if inst.ClientVersion != inst.original.ClientVersion {
+			kv["client_version"] = inst.ClientVersion
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "cohort_id":
+				if inst.CohortId != inst.original.CohortId {
+					kv["cohort_id"] = inst.CohortId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "feature":
+				if inst.Feature != inst.original.Feature {
+					kv["feature"] = inst.Feature
+				}
+			case "rating":
+				if inst.Rating != inst.original.Rating {
+					kv["rating"] = inst.Rating
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "platform":
+				if inst.Platform != inst.original.Platform {
+					kv["platform"] = inst.Platform
+				}
+			case "client_version":
+				if inst.ClientVersion != inst.original.ClientVersion {
+					kv["client_version"] = inst.ClientVersion
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *BetaFeedbackN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.betaFeedbackModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.betaFeedbackModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a beta_feedback
+func (inst *BetaFeedbackN) Delete(ctx context.Context) error {
+	if inst.betaFeedbackModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.betaFeedbackModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *BetaFeedbackN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type betaFeedbackScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var betaFeedbackGlobalScopes = make([]betaFeedbackScope, 0)
+var betaFeedbackLocalScopes = make([]betaFeedbackScope, 0)
+
+// AddGlobalScopeForBetaFeedback assign a global scope to a model
+func AddGlobalScopeForBetaFeedback(name string, apply func(builder query.Condition)) {
+	betaFeedbackGlobalScopes = append(betaFeedbackGlobalScopes, betaFeedbackScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForBetaFeedback assign a local scope to a model
+func AddLocalScopeForBetaFeedback(name string, apply func(builder query.Condition)) {
+	betaFeedbackLocalScopes = append(betaFeedbackLocalScopes, betaFeedbackScope{name: name, apply: apply})
+}
+
+func (m *BetaFeedbackModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range betaFeedbackGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range betaFeedbackLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *BetaFeedbackModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *BetaFeedbackModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type BetaFeedback struct {
+	Id            int64  `json:"id"`
+	CohortId      int64  `json:"cohort_id"`
+	UserId        int64  `json:"user_id"`
+	Feature       string `json:"feature"`
+	Rating        int64  `json:"rating"`
+	Content       string `json:"content"`
+	Platform      string `json:"platform"`
+	ClientVersion string `json:"client_version"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (w BetaFeedback) ToBetaFeedbackN(allows ...string) BetaFeedbackN {
+	if len(allows) == 0 {
+		return BetaFeedbackN{
+
+			Id:            null.IntFrom(int64(w.Id)),
+			CohortId:      null.IntFrom(int64(w.CohortId)),
+			UserId:        null.IntFrom(int64(w.UserId)),
+			Feature:       null.StringFrom(w.Feature),
+			Rating:        null.IntFrom(int64(w.Rating)),
+			Content:       null.StringFrom(w.Content),
+			Platform:      null.StringFrom(w.Platform),
+			ClientVersion: null.StringFrom(w.ClientVersion),
+			CreatedAt:     null.TimeFrom(w.CreatedAt),
+			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := BetaFeedbackN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "cohort_id":
+			res.CohortId = null.IntFrom(int64(w.CohortId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "feature":
+			res.Feature = null.StringFrom(w.Feature)
+		case "rating":
+			res.Rating = null.IntFrom(int64(w.Rating))
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "platform":
+			res.Platform = null.StringFrom(w.Platform)
+		case "client_version":
+			res.ClientVersion = null.StringFrom(w.ClientVersion)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w BetaFeedback) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *BetaFeedbackN) ToBetaFeedback() BetaFeedback {
+	return BetaFeedback{
+
+		Id:            w.Id.Int64,
+		CohortId:      w.CohortId.Int64,
+		UserId:        w.UserId.Int64,
+		Feature:       w.Feature.String,
+		Rating:        w.Rating.Int64,
+		Content:       w.Content.String,
+		Platform:      w.Platform.String,
+		ClientVersion: w.ClientVersion.String,
+		CreatedAt:     w.CreatedAt.Time,
+		UpdatedAt:     w.UpdatedAt.Time,
+	}
+}
+
+// BetaFeedbackModel is a model which encapsulates the operations of the object
+type BetaFeedbackModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var betaFeedbackTableName = "beta_feedback"
+
+// BetaFeedbackTable return table name for BetaFeedback
+func BetaFeedbackTable() string {
+	return betaFeedbackTableName
+}
+
+const (
+	FieldBetaFeedbackId            = "id"
+	FieldBetaFeedbackCohortId      = "cohort_id"
+	FieldBetaFeedbackUserId        = "user_id"
+	FieldBetaFeedbackFeature       = "feature"
+	FieldBetaFeedbackRating        = "rating"
+	FieldBetaFeedbackContent       = "content"
+	FieldBetaFeedbackPlatform      = "platform"
+	FieldBetaFeedbackClientVersion = "client_version"
+	FieldBetaFeedbackCreatedAt     = "created_at"
+	FieldBetaFeedbackUpdatedAt     = "updated_at"
+)
+
+// BetaFeedbackFields return all fields in BetaFeedback model
+func BetaFeedbackFields() []string {
+	return []string{
+		"id",
+		"cohort_id",
+		"user_id",
+		"feature",
+		"rating",
+		"content",
+		"platform",
+		"client_version",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetBetaFeedbackTable(tableName string) {
+	betaFeedbackTableName = tableName
+}
+
+// NewBetaFeedbackModel create a BetaFeedbackModel
+func NewBetaFeedbackModel(db query.Database) *BetaFeedbackModel {
+	return &BetaFeedbackModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           betaFeedbackTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *BetaFeedbackModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *BetaFeedbackModel) clone() *BetaFeedbackModel {
+	return &BetaFeedbackModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *BetaFeedbackModel) WithoutGlobalScopes(names ...string) *BetaFeedbackModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *BetaFeedbackModel) WithLocalScopes(names ...string) *BetaFeedbackModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *BetaFeedbackModel) Condition(builder query.SQLBuilder) *BetaFeedbackModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *BetaFeedbackModel) Find(ctx context.Context, id int64) (*BetaFeedbackN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *BetaFeedbackModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *BetaFeedbackModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *BetaFeedbackModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]BetaFeedbackN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *BetaFeedbackModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]BetaFeedbackN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"cohort_id",
+			"user_id",
+			"feature",
+			"rating",
+			"content",
+			"platform",
+			"client_version",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "cohort_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "feature":
+			selectFields = append(selectFields, f)
+		case "rating":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "platform":
+			selectFields = append(selectFields, f)
+		case "client_version":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*BetaFeedbackN, []interface{}) {
+		var betaFeedbackVar BetaFeedbackN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &betaFeedbackVar.Id)
+			case "cohort_id":
+				scanFields = append(scanFields, &betaFeedbackVar.CohortId)
+			case "user_id":
+				scanFields = append(scanFields, &betaFeedbackVar.UserId)
+			case "feature":
+				scanFields = append(scanFields, &betaFeedbackVar.Feature)
+			case "rating":
+				scanFields = append(scanFields, &betaFeedbackVar.Rating)
+			case "content":
+				scanFields = append(scanFields, &betaFeedbackVar.Content)
+			case "platform":
+				scanFields = append(scanFields, &betaFeedbackVar.Platform)
+			case "client_version":
+				scanFields = append(scanFields, &betaFeedbackVar.ClientVersion)
+			case "created_at":
+				scanFields = append(scanFields, &betaFeedbackVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &betaFeedbackVar.UpdatedAt)
+			}
+		}
+
+		return &betaFeedbackVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	betaFeedbacks := make([]BetaFeedbackN, 0)
+	for rows.Next() {
+		betaFeedbackReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		betaFeedbackReal.original = &betaFeedbackOriginal{}
+		_ = query.Copy(betaFeedbackReal, betaFeedbackReal.original)
+
+		betaFeedbackReal.SetModel(m)
+		betaFeedbacks = append(betaFeedbacks, *betaFeedbackReal)
+	}
+
+	return betaFeedbacks, nil
+}
+
+// First return first result for given query
+func (m *BetaFeedbackModel) First(ctx context.Context, builders ...query.SQLBuilder) (*BetaFeedbackN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new beta_feedback to database
+func (m *BetaFeedbackModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all beta_feedbacks to database
+func (m *BetaFeedbackModel) SaveAll(ctx context.Context, betaFeedbacks []BetaFeedbackN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, betaFeedback := range betaFeedbacks {
+		id, err := m.Save(ctx, betaFeedback)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a beta_feedback to database
+func (m *BetaFeedbackModel) Save(ctx context.Context, betaFeedback BetaFeedbackN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, betaFeedback.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new beta_feedback or update it when it has a id > 0
+func (m *BetaFeedbackModel) SaveOrUpdate(ctx context.Context, betaFeedback BetaFeedbackN, onlyFields ...string) (id int64, updated bool, err error) {
+	if betaFeedback.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, betaFeedback.Id.Int64, betaFeedback, onlyFields...)
+		return betaFeedback.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, betaFeedback, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *BetaFeedbackModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *BetaFeedbackModel) Update(ctx context.Context, builder query.SQLBuilder, betaFeedback BetaFeedbackN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, betaFeedback.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *BetaFeedbackModel) UpdateById(ctx context.Context, id int64, betaFeedback BetaFeedbackN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, betaFeedback.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *BetaFeedbackModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *BetaFeedbackModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
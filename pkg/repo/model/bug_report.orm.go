@@ -0,0 +1,831 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// BugReportN is a BugReport object, all fields are nullable
+type BugReportN struct {
+	original       *bugReportOriginal
+	bugReportModel *BugReportModel
+
+	Id              null.Int    `json:"id"`
+	UserId          null.Int    `json:"user_id"`
+	AppVersion      null.String `json:"app_version"`
+	Platform        null.String `json:"platform"`
+	PlatformVersion null.String `json:"platform_version"`
+	Device          null.String `json:"device"`
+	ClientError     null.String `json:"client_error"`
+	RequestIds      null.String `json:"request_ids"`
+	Correlated      null.String `json:"correlated"`
+	CreatedAt       null.Time
+	UpdatedAt       null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *BugReportN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for BugReport
+func (inst *BugReportN) SetModel(bugReportModel *BugReportModel) {
+	inst.bugReportModel = bugReportModel
+}
+
+// bugReportOriginal is an object which stores original BugReport from database
+type bugReportOriginal struct {
+	Id              null.Int
+	UserId          null.Int
+	AppVersion      null.String
+	Platform        null.String
+	PlatformVersion null.String
+	Device          null.String
+	ClientError     null.String
+	RequestIds      null.String
+	Correlated      null.String
+	CreatedAt       null.Time
+	UpdatedAt       null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *BugReportN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &bugReportOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.AppVersion != inst.original.AppVersion {
+			return true
+		}
+		if inst.Platform != inst.original.Platform {
+			return true
+		}
+		if inst.PlatformVersion != inst.original.PlatformVersion {
+			return true
+		}
+		if inst.Device != inst.original.Device {
+			return true
+		}
+		if inst.ClientError != inst.original.ClientError {
+			return true
+		}
+		if inst.RequestIds != inst.original.RequestIds {
+			return true
+		}
+		if inst.Correlated != inst.original.Correlated {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "app_version":
+				if inst.AppVersion != inst.original.AppVersion {
+					return true
+				}
+			case "platform":
+				if inst.Platform != inst.original.Platform {
+					return true
+				}
+			case "platform_version":
+				if inst.PlatformVersion != inst.original.PlatformVersion {
+					return true
+				}
+			case "device":
+				if inst.Device != inst.original.Device {
+					return true
+				}
+			case "client_error":
+				if inst.ClientError != inst.original.ClientError {
+					return true
+				}
+			case "request_ids":
+				if inst.RequestIds != inst.original.RequestIds {
+					return true
+				}
+			case "correlated":
+				if inst.Correlated != inst.original.Correlated {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *BugReportN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &bugReportOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.AppVersion != inst.original.AppVersion {
+			kv["app_version"] = inst.AppVersion
+		}
+		if inst.Platform != inst.original.Platform {
+			kv["platform"] = inst.Platform
+		}
+		if inst.PlatformVersion != inst.original.PlatformVersion {
+			kv["platform_version"] = inst.PlatformVersion
+		}
+		if inst.Device != inst.original.Device {
+			kv["device"] = inst.Device
+		}
+		if inst.ClientError != inst.original.ClientError {
+			kv["client_error"] = inst.ClientError
+		}
+		if inst.RequestIds != inst.original.RequestIds {
+			kv["request_ids"] = inst.RequestIds
+		}
+		if inst.Correlated != inst.original.Correlated {
+			kv["correlated"] = inst.Correlated
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "app_version":
+				if inst.AppVersion != inst.original.AppVersion {
+					kv["app_version"] = inst.AppVersion
+				}
+			case "platform":
+				if inst.Platform != inst.original.Platform {
+					kv["platform"] = inst.Platform
+				}
+			case "platform_version":
+				if inst.PlatformVersion != inst.original.PlatformVersion {
+					kv["platform_version"] = inst.PlatformVersion
+				}
+			case "device":
+				if inst.Device != inst.original.Device {
+					kv["device"] = inst.Device
+				}
+			case "client_error":
+				if inst.ClientError != inst.original.ClientError {
+					kv["client_error"] = inst.ClientError
+				}
+			case "request_ids":
+				if inst.RequestIds != inst.original.RequestIds {
+					kv["request_ids"] = inst.RequestIds
+				}
+			case "correlated":
+				if inst.Correlated != inst.original.Correlated {
+					kv["correlated"] = inst.Correlated
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *BugReportN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.bugReportModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.bugReportModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a bug_report
+func (inst *BugReportN) Delete(ctx context.Context) error {
+	if inst.bugReportModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.bugReportModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *BugReportN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type bugReportScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var bugReportGlobalScopes = make([]bugReportScope, 0)
+var bugReportLocalScopes = make([]bugReportScope, 0)
+
+// AddGlobalScopeForBugReport assign a global scope to a model
+func AddGlobalScopeForBugReport(name string, apply func(builder query.Condition)) {
+	bugReportGlobalScopes = append(bugReportGlobalScopes, bugReportScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForBugReport assign a local scope to a model
+func AddLocalScopeForBugReport(name string, apply func(builder query.Condition)) {
+	bugReportLocalScopes = append(bugReportLocalScopes, bugReportScope{name: name, apply: apply})
+}
+
+func (m *BugReportModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range bugReportGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range bugReportLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *BugReportModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *BugReportModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type BugReport struct {
+	Id              int64  `json:"id"`
+	UserId          int64  `json:"user_id"`
+	AppVersion      string `json:"app_version"`
+	Platform        string `json:"platform"`
+	PlatformVersion string `json:"platform_version"`
+	Device          string `json:"device"`
+	ClientError     string `json:"client_error"`
+	RequestIds      string `json:"request_ids"`
+	Correlated      string `json:"correlated"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (w BugReport) ToBugReportN(allows ...string) BugReportN {
+	if len(allows) == 0 {
+		return BugReportN{
+
+			Id:              null.IntFrom(int64(w.Id)),
+			UserId:          null.IntFrom(int64(w.UserId)),
+			AppVersion:      null.StringFrom(w.AppVersion),
+			Platform:        null.StringFrom(w.Platform),
+			PlatformVersion: null.StringFrom(w.PlatformVersion),
+			Device:          null.StringFrom(w.Device),
+			ClientError:     null.StringFrom(w.ClientError),
+			RequestIds:      null.StringFrom(w.RequestIds),
+			Correlated:      null.StringFrom(w.Correlated),
+			CreatedAt:       null.TimeFrom(w.CreatedAt),
+			UpdatedAt:       null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := BugReportN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "app_version":
+			res.AppVersion = null.StringFrom(w.AppVersion)
+		case "platform":
+			res.Platform = null.StringFrom(w.Platform)
+		case "platform_version":
+			res.PlatformVersion = null.StringFrom(w.PlatformVersion)
+		case "device":
+			res.Device = null.StringFrom(w.Device)
+		case "client_error":
+			res.ClientError = null.StringFrom(w.ClientError)
+		case "request_ids":
+			res.RequestIds = null.StringFrom(w.RequestIds)
+		case "correlated":
+			res.Correlated = null.StringFrom(w.Correlated)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w BugReport) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *BugReportN) ToBugReport() BugReport {
+	return BugReport{
+
+		Id:              w.Id.Int64,
+		UserId:          w.UserId.Int64,
+		AppVersion:      w.AppVersion.String,
+		Platform:        w.Platform.String,
+		PlatformVersion: w.PlatformVersion.String,
+		Device:          w.Device.String,
+		ClientError:     w.ClientError.String,
+		RequestIds:      w.RequestIds.String,
+		Correlated:      w.Correlated.String,
+		CreatedAt:       w.CreatedAt.Time,
+		UpdatedAt:       w.UpdatedAt.Time,
+	}
+}
+
+// BugReportModel is a model which encapsulates the operations of the object
+type BugReportModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var bugReportTableName = "bug_report"
+
+// BugReportTable return table name for BugReport
+func BugReportTable() string {
+	return bugReportTableName
+}
+
+const (
+	FieldBugReportId              = "id"
+	FieldBugReportUserId          = "user_id"
+	FieldBugReportAppVersion      = "app_version"
+	FieldBugReportPlatform        = "platform"
+	FieldBugReportPlatformVersion = "platform_version"
+	FieldBugReportDevice          = "device"
+	FieldBugReportClientError     = "client_error"
+	FieldBugReportRequestIds      = "request_ids"
+	FieldBugReportCorrelated      = "correlated"
+	FieldBugReportCreatedAt       = "created_at"
+	FieldBugReportUpdatedAt       = "updated_at"
+)
+
+// BugReportFields return all fields in BugReport model
+func BugReportFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"app_version",
+		"platform",
+		"platform_version",
+		"device",
+		"client_error",
+		"request_ids",
+		"correlated",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetBugReportTable(tableName string) {
+	bugReportTableName = tableName
+}
+
+// NewBugReportModel create a BugReportModel
+func NewBugReportModel(db query.Database) *BugReportModel {
+	return &BugReportModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           bugReportTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *BugReportModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *BugReportModel) clone() *BugReportModel {
+	return &BugReportModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *BugReportModel) WithoutGlobalScopes(names ...string) *BugReportModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *BugReportModel) WithLocalScopes(names ...string) *BugReportModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *BugReportModel) Condition(builder query.SQLBuilder) *BugReportModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *BugReportModel) Find(ctx context.Context, id int64) (*BugReportN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *BugReportModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *BugReportModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *BugReportModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]BugReportN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *BugReportModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]BugReportN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"app_version",
+			"platform",
+			"platform_version",
+			"device",
+			"client_error",
+			"request_ids",
+			"correlated",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "app_version":
+			selectFields = append(selectFields, f)
+		case "platform":
+			selectFields = append(selectFields, f)
+		case "platform_version":
+			selectFields = append(selectFields, f)
+		case "device":
+			selectFields = append(selectFields, f)
+		case "client_error":
+			selectFields = append(selectFields, f)
+		case "request_ids":
+			selectFields = append(selectFields, f)
+		case "correlated":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*BugReportN, []interface{}) {
+		var bugReportVar BugReportN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &bugReportVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &bugReportVar.UserId)
+			case "app_version":
+				scanFields = append(scanFields, &bugReportVar.AppVersion)
+			case "platform":
+				scanFields = append(scanFields, &bugReportVar.Platform)
+			case "platform_version":
+				scanFields = append(scanFields, &bugReportVar.PlatformVersion)
+			case "device":
+				scanFields = append(scanFields, &bugReportVar.Device)
+			case "client_error":
+				scanFields = append(scanFields, &bugReportVar.ClientError)
+			case "request_ids":
+				scanFields = append(scanFields, &bugReportVar.RequestIds)
+			case "correlated":
+				scanFields = append(scanFields, &bugReportVar.Correlated)
+			case "created_at":
+				scanFields = append(scanFields, &bugReportVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &bugReportVar.UpdatedAt)
+			}
+		}
+
+		return &bugReportVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bugReports := make([]BugReportN, 0)
+	for rows.Next() {
+		bugReportReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		bugReportReal.original = &bugReportOriginal{}
+		_ = query.Copy(bugReportReal, bugReportReal.original)
+
+		bugReportReal.SetModel(m)
+		bugReports = append(bugReports, *bugReportReal)
+	}
+
+	return bugReports, nil
+}
+
+// First return first result for given query
+func (m *BugReportModel) First(ctx context.Context, builders ...query.SQLBuilder) (*BugReportN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new bug_report to database
+func (m *BugReportModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all bug_reports to database
+func (m *BugReportModel) SaveAll(ctx context.Context, bugReports []BugReportN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, bugReport := range bugReports {
+		id, err := m.Save(ctx, bugReport)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a bug_report to database
+func (m *BugReportModel) Save(ctx context.Context, bugReport BugReportN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, bugReport.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new bug_report or update it when it has a id > 0
+func (m *BugReportModel) SaveOrUpdate(ctx context.Context, bugReport BugReportN, onlyFields ...string) (id int64, updated bool, err error) {
+	if bugReport.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, bugReport.Id.Int64, bugReport, onlyFields...)
+		return bugReport.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, bugReport, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *BugReportModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *BugReportModel) Update(ctx context.Context, builder query.SQLBuilder, bugReport BugReportN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, bugReport.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *BugReportModel) UpdateById(ctx context.Context, id int64, bugReport BugReportN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, bugReport.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *BugReportModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *BugReportModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,803 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ChannelN is a Channel object, all fields are nullable
+type ChannelN struct {
+	original     *channelOriginal
+	channelModel *ChannelModel
+
+	Id         null.Int    `json:"id"`
+	Name       null.String `json:"name"`
+	Type       null.String `json:"type"`
+	Server     null.String `json:"server"`
+	Secret     null.String `json:"-"`
+	Models     null.String `json:"models"`
+	Status     null.Int    `json:"status"`
+	VerifiedAt null.Time   `json:"verified_at,omitempty"`
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ChannelN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for Channel
+func (inst *ChannelN) SetModel(channelModel *ChannelModel) {
+	inst.channelModel = channelModel
+}
+
+// channelOriginal is an object which stores original Channel from database
+type channelOriginal struct {
+	Id         null.Int
+	Name       null.String
+	Type       null.String
+	Server     null.String
+	Secret     null.String
+	Models     null.String
+	Status     null.Int
+	VerifiedAt null.Time
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ChannelN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &channelOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Type != inst.original.Type {
+			return true
+		}
+		if inst.Server != inst.original.Server {
+			return true
+		}
+		if inst.Secret != inst.original.Secret {
+			return true
+		}
+		if inst.Models != inst.original.Models {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.VerifiedAt != inst.original.VerifiedAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "type":
+				if inst.Type != inst.original.Type {
+					return true
+				}
+			case "server":
+				if inst.Server != inst.original.Server {
+					return true
+				}
+			case "secret":
+				if inst.Secret != inst.original.Secret {
+					return true
+				}
+			case "models":
+				if inst.Models != inst.original.Models {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "verified_at":
+				if inst.VerifiedAt != inst.original.VerifiedAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ChannelN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &channelOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Type != inst.original.Type {
+			kv["type"] = inst.Type
+		}
+		if inst.Server != inst.original.Server {
+			kv["server"] = inst.Server
+		}
+		if inst.Secret != inst.original.Secret {
+			kv["secret"] = inst.Secret
+		}
+		if inst.Models != inst.original.Models {
+			kv["models"] = inst.Models
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.VerifiedAt != inst.original.VerifiedAt {
+			kv["verified_at"] = inst.VerifiedAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "type":
+				if inst.Type != inst.original.Type {
+					kv["type"] = inst.Type
+				}
+			case "server":
+				if inst.Server != inst.original.Server {
+					kv["server"] = inst.Server
+				}
+			case "secret":
+				if inst.Secret != inst.original.Secret {
+					kv["secret"] = inst.Secret
+				}
+			case "models":
+				if inst.Models != inst.original.Models {
+					kv["models"] = inst.Models
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "verified_at":
+				if inst.VerifiedAt != inst.original.VerifiedAt {
+					kv["verified_at"] = inst.VerifiedAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ChannelN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.channelModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.channelModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a channel
+func (inst *ChannelN) Delete(ctx context.Context) error {
+	if inst.channelModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.channelModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ChannelN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type channelScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var channelGlobalScopes = make([]channelScope, 0)
+var channelLocalScopes = make([]channelScope, 0)
+
+// AddGlobalScopeForChannel assign a global scope to a model
+func AddGlobalScopeForChannel(name string, apply func(builder query.Condition)) {
+	channelGlobalScopes = append(channelGlobalScopes, channelScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForChannel assign a local scope to a model
+func AddLocalScopeForChannel(name string, apply func(builder query.Condition)) {
+	channelLocalScopes = append(channelLocalScopes, channelScope{name: name, apply: apply})
+}
+
+func (m *ChannelModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range channelGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range channelLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ChannelModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ChannelModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type Channel struct {
+	Id         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Server     string    `json:"server"`
+	Secret     string    `json:"-"`
+	Models     string    `json:"models"`
+	Status     int64     `json:"status"`
+	VerifiedAt time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w Channel) ToChannelN(allows ...string) ChannelN {
+	if len(allows) == 0 {
+		return ChannelN{
+
+			Id:         null.IntFrom(int64(w.Id)),
+			Name:       null.StringFrom(w.Name),
+			Type:       null.StringFrom(w.Type),
+			Server:     null.StringFrom(w.Server),
+			Secret:     null.StringFrom(w.Secret),
+			Models:     null.StringFrom(w.Models),
+			Status:     null.IntFrom(int64(w.Status)),
+			VerifiedAt: null.TimeFrom(w.VerifiedAt),
+			CreatedAt:  null.TimeFrom(w.CreatedAt),
+			UpdatedAt:  null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ChannelN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "type":
+			res.Type = null.StringFrom(w.Type)
+		case "server":
+			res.Server = null.StringFrom(w.Server)
+		case "secret":
+			res.Secret = null.StringFrom(w.Secret)
+		case "models":
+			res.Models = null.StringFrom(w.Models)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "verified_at":
+			res.VerifiedAt = null.TimeFrom(w.VerifiedAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w Channel) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ChannelN) ToChannel() Channel {
+	return Channel{
+
+		Id:         w.Id.Int64,
+		Name:       w.Name.String,
+		Type:       w.Type.String,
+		Server:     w.Server.String,
+		Secret:     w.Secret.String,
+		Models:     w.Models.String,
+		Status:     w.Status.Int64,
+		VerifiedAt: w.VerifiedAt.Time,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+	}
+}
+
+// ChannelModel is a model which encapsulates the operations of the object
+type ChannelModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var channelTableName = "channel"
+
+// ChannelTable return table name for Channel
+func ChannelTable() string {
+	return channelTableName
+}
+
+const (
+	FieldChannelId         = "id"
+	FieldChannelName       = "name"
+	FieldChannelType       = "type"
+	FieldChannelServer     = "server"
+	FieldChannelSecret     = "secret"
+	FieldChannelModels     = "models"
+	FieldChannelStatus     = "status"
+	FieldChannelVerifiedAt = "verified_at"
+	FieldChannelCreatedAt  = "created_at"
+	FieldChannelUpdatedAt  = "updated_at"
+)
+
+// ChannelFields return all fields in Channel model
+func ChannelFields() []string {
+	return []string{
+		"id",
+		"name",
+		"type",
+		"server",
+		"secret",
+		"models",
+		"status",
+		"verified_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetChannelTable(tableName string) {
+	channelTableName = tableName
+}
+
+// NewChannelModel create a ChannelModel
+func NewChannelModel(db query.Database) *ChannelModel {
+	return &ChannelModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           channelTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ChannelModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ChannelModel) clone() *ChannelModel {
+	return &ChannelModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ChannelModel) WithoutGlobalScopes(names ...string) *ChannelModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ChannelModel) WithLocalScopes(names ...string) *ChannelModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ChannelModel) Condition(builder query.SQLBuilder) *ChannelModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ChannelModel) Find(ctx context.Context, id int64) (*ChannelN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ChannelModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ChannelModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ChannelModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ChannelN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ChannelModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ChannelN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"type",
+			"server",
+			"secret",
+			"models",
+			"status",
+			"verified_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "type":
+			selectFields = append(selectFields, f)
+		case "server":
+			selectFields = append(selectFields, f)
+		case "secret":
+			selectFields = append(selectFields, f)
+		case "models":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "verified_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ChannelN, []interface{}) {
+		var channelVar ChannelN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &channelVar.Id)
+			case "name":
+				scanFields = append(scanFields, &channelVar.Name)
+			case "type":
+				scanFields = append(scanFields, &channelVar.Type)
+			case "server":
+				scanFields = append(scanFields, &channelVar.Server)
+			case "secret":
+				scanFields = append(scanFields, &channelVar.Secret)
+			case "models":
+				scanFields = append(scanFields, &channelVar.Models)
+			case "status":
+				scanFields = append(scanFields, &channelVar.Status)
+			case "verified_at":
+				scanFields = append(scanFields, &channelVar.VerifiedAt)
+			case "created_at":
+				scanFields = append(scanFields, &channelVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &channelVar.UpdatedAt)
+			}
+		}
+
+		return &channelVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	channels := make([]ChannelN, 0)
+	for rows.Next() {
+		channelReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		channelReal.original = &channelOriginal{}
+		_ = query.Copy(channelReal, channelReal.original)
+
+		channelReal.SetModel(m)
+		channels = append(channels, *channelReal)
+	}
+
+	return channels, nil
+}
+
+// First return first result for given query
+func (m *ChannelModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ChannelN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new channel to database
+func (m *ChannelModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all channels to database
+func (m *ChannelModel) SaveAll(ctx context.Context, channels []ChannelN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, channel := range channels {
+		id, err := m.Save(ctx, channel)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a channel to database
+func (m *ChannelModel) Save(ctx context.Context, channel ChannelN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, channel.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new channel or update it when it has a id > 0
+func (m *ChannelModel) SaveOrUpdate(ctx context.Context, channel ChannelN, onlyFields ...string) (id int64, updated bool, err error) {
+	if channel.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, channel.Id.Int64, channel, onlyFields...)
+		return channel.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, channel, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ChannelModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ChannelModel) Update(ctx context.Context, builder query.SQLBuilder, channel ChannelN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, channel.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ChannelModel) UpdateById(ctx context.Context, id int64, channel ChannelN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, channel.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ChannelModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ChannelModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
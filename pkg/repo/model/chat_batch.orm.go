@@ -0,0 +1,1730 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ChatBatchN is a ChatBatch object, all fields are nullable
+type ChatBatchN struct {
+	original       *chatBatchOriginal
+	chatBatchModel *ChatBatchModel
+
+	Id          null.Int    `json:"id"`
+	UserId      null.Int    `json:"user_id"`
+	Model       null.String `json:"model"`
+	TaskId      null.String `json:"task_id,omitempty"`
+	Status      null.Int    `json:"status"`
+	Total       null.Int    `json:"total"`
+	Succeed     null.Int    `json:"succeed"`
+	Failed      null.Int    `json:"failed"`
+	Coins       null.Int    `json:"coins"`
+	CompletedAt null.Time   `json:"completed_at,omitempty"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ChatBatchN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ChatBatch
+func (inst *ChatBatchN) SetModel(chatBatchModel *ChatBatchModel) {
+	inst.chatBatchModel = chatBatchModel
+}
+
+// chatBatchOriginal is an object which stores original ChatBatch from database
+type chatBatchOriginal struct {
+	Id          null.Int
+	UserId      null.Int
+	Model       null.String
+	TaskId      null.String
+	Status      null.Int
+	Total       null.Int
+	Succeed     null.Int
+	Failed      null.Int
+	Coins       null.Int
+	CompletedAt null.Time
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ChatBatchN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &chatBatchOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.TaskId != inst.original.TaskId {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.Total != inst.original.Total {
+			return true
+		}
+		if inst.Succeed != inst.original.Succeed {
+			return true
+		}
+		if inst.Failed != inst.original.Failed {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CompletedAt != inst.original.CompletedAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "total":
+				if inst.Total != inst.original.Total {
+					return true
+				}
+			case "succeed":
+				if inst.Succeed != inst.original.Succeed {
+					return true
+				}
+			case "failed":
+				if inst.Failed != inst.original.Failed {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "completed_at":
+				if inst.CompletedAt != inst.original.CompletedAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ChatBatchN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &chatBatchOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.TaskId != inst.original.TaskId {
+			kv["task_id"] = inst.TaskId
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.Total != inst.original.Total {
+			kv["total"] = inst.Total
+		}
+		if inst.Succeed != inst.original.Succeed {
+			kv["succeed"] = inst.Succeed
+		}
+		if inst.Failed != inst.original.Failed {
+			kv["failed"] = inst.Failed
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CompletedAt != inst.original.CompletedAt {
+			kv["completed_at"] = inst.CompletedAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					kv["task_id"] = inst.TaskId
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "total":
+				if inst.Total != inst.original.Total {
+					kv["total"] = inst.Total
+				}
+			case "succeed":
+				if inst.Succeed != inst.original.Succeed {
+					kv["succeed"] = inst.Succeed
+				}
+			case "failed":
+				if inst.Failed != inst.original.Failed {
+					kv["failed"] = inst.Failed
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "completed_at":
+				if inst.CompletedAt != inst.original.CompletedAt {
+					kv["completed_at"] = inst.CompletedAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ChatBatchN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.chatBatchModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.chatBatchModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a chat_batch
+func (inst *ChatBatchN) Delete(ctx context.Context) error {
+	if inst.chatBatchModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.chatBatchModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ChatBatchN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type chatBatchScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var chatBatchGlobalScopes = make([]chatBatchScope, 0)
+var chatBatchLocalScopes = make([]chatBatchScope, 0)
+
+// AddGlobalScopeForChatBatch assign a global scope to a model
+func AddGlobalScopeForChatBatch(name string, apply func(builder query.Condition)) {
+	chatBatchGlobalScopes = append(chatBatchGlobalScopes, chatBatchScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForChatBatch assign a local scope to a model
+func AddLocalScopeForChatBatch(name string, apply func(builder query.Condition)) {
+	chatBatchLocalScopes = append(chatBatchLocalScopes, chatBatchScope{name: name, apply: apply})
+}
+
+func (m *ChatBatchModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range chatBatchGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range chatBatchLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ChatBatchModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ChatBatchModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ChatBatch struct {
+	Id          int64     `json:"id"`
+	UserId      int64     `json:"user_id"`
+	Model       string    `json:"model"`
+	TaskId      string    `json:"task_id,omitempty"`
+	Status      int64     `json:"status"`
+	Total       int64     `json:"total"`
+	Succeed     int64     `json:"succeed"`
+	Failed      int64     `json:"failed"`
+	Coins       int64     `json:"coins"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w ChatBatch) ToChatBatchN(allows ...string) ChatBatchN {
+	if len(allows) == 0 {
+		return ChatBatchN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			UserId:      null.IntFrom(int64(w.UserId)),
+			Model:       null.StringFrom(w.Model),
+			TaskId:      null.StringFrom(w.TaskId),
+			Status:      null.IntFrom(int64(w.Status)),
+			Total:       null.IntFrom(int64(w.Total)),
+			Succeed:     null.IntFrom(int64(w.Succeed)),
+			Failed:      null.IntFrom(int64(w.Failed)),
+			Coins:       null.IntFrom(int64(w.Coins)),
+			CompletedAt: null.TimeFrom(w.CompletedAt),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ChatBatchN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "task_id":
+			res.TaskId = null.StringFrom(w.TaskId)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "total":
+			res.Total = null.IntFrom(int64(w.Total))
+		case "succeed":
+			res.Succeed = null.IntFrom(int64(w.Succeed))
+		case "failed":
+			res.Failed = null.IntFrom(int64(w.Failed))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "completed_at":
+			res.CompletedAt = null.TimeFrom(w.CompletedAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ChatBatch) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ChatBatchN) ToChatBatch() ChatBatch {
+	return ChatBatch{
+
+		Id:          w.Id.Int64,
+		UserId:      w.UserId.Int64,
+		Model:       w.Model.String,
+		TaskId:      w.TaskId.String,
+		Status:      w.Status.Int64,
+		Total:       w.Total.Int64,
+		Succeed:     w.Succeed.Int64,
+		Failed:      w.Failed.Int64,
+		Coins:       w.Coins.Int64,
+		CompletedAt: w.CompletedAt.Time,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// ChatBatchModel is a model which encapsulates the operations of the object
+type ChatBatchModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var chatBatchTableName = "chat_batch"
+
+// ChatBatchTable return table name for ChatBatch
+func ChatBatchTable() string {
+	return chatBatchTableName
+}
+
+const (
+	FieldChatBatchId          = "id"
+	FieldChatBatchUserId      = "user_id"
+	FieldChatBatchModel       = "model"
+	FieldChatBatchTaskId      = "task_id"
+	FieldChatBatchStatus      = "status"
+	FieldChatBatchTotal       = "total"
+	FieldChatBatchSucceed     = "succeed"
+	FieldChatBatchFailed      = "failed"
+	FieldChatBatchCoins       = "coins"
+	FieldChatBatchCompletedAt = "completed_at"
+	FieldChatBatchCreatedAt   = "created_at"
+	FieldChatBatchUpdatedAt   = "updated_at"
+)
+
+// ChatBatchFields return all fields in ChatBatch model
+func ChatBatchFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"model",
+		"task_id",
+		"status",
+		"total",
+		"succeed",
+		"failed",
+		"coins",
+		"completed_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetChatBatchTable(tableName string) {
+	chatBatchTableName = tableName
+}
+
+// NewChatBatchModel create a ChatBatchModel
+func NewChatBatchModel(db query.Database) *ChatBatchModel {
+	return &ChatBatchModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           chatBatchTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ChatBatchModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ChatBatchModel) clone() *ChatBatchModel {
+	return &ChatBatchModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ChatBatchModel) WithoutGlobalScopes(names ...string) *ChatBatchModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ChatBatchModel) WithLocalScopes(names ...string) *ChatBatchModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ChatBatchModel) Condition(builder query.SQLBuilder) *ChatBatchModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ChatBatchModel) Find(ctx context.Context, id int64) (*ChatBatchN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ChatBatchModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ChatBatchModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ChatBatchModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ChatBatchN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ChatBatchModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ChatBatchN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"model",
+			"task_id",
+			"status",
+			"total",
+			"succeed",
+			"failed",
+			"coins",
+			"completed_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "task_id":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "total":
+			selectFields = append(selectFields, f)
+		case "succeed":
+			selectFields = append(selectFields, f)
+		case "failed":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "completed_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ChatBatchN, []interface{}) {
+		var chatBatchVar ChatBatchN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &chatBatchVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &chatBatchVar.UserId)
+			case "model":
+				scanFields = append(scanFields, &chatBatchVar.Model)
+			case "task_id":
+				scanFields = append(scanFields, &chatBatchVar.TaskId)
+			case "status":
+				scanFields = append(scanFields, &chatBatchVar.Status)
+			case "total":
+				scanFields = append(scanFields, &chatBatchVar.Total)
+			case "succeed":
+				scanFields = append(scanFields, &chatBatchVar.Succeed)
+			case "failed":
+				scanFields = append(scanFields, &chatBatchVar.Failed)
+			case "coins":
+				scanFields = append(scanFields, &chatBatchVar.Coins)
+			case "completed_at":
+				scanFields = append(scanFields, &chatBatchVar.CompletedAt)
+			case "created_at":
+				scanFields = append(scanFields, &chatBatchVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &chatBatchVar.UpdatedAt)
+			}
+		}
+
+		return &chatBatchVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	chatBatchs := make([]ChatBatchN, 0)
+	for rows.Next() {
+		chatBatchReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		chatBatchReal.original = &chatBatchOriginal{}
+		_ = query.Copy(chatBatchReal, chatBatchReal.original)
+
+		chatBatchReal.SetModel(m)
+		chatBatchs = append(chatBatchs, *chatBatchReal)
+	}
+
+	return chatBatchs, nil
+}
+
+// First return first result for given query
+func (m *ChatBatchModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ChatBatchN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new chat_batch to database
+func (m *ChatBatchModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all chat_batchs to database
+func (m *ChatBatchModel) SaveAll(ctx context.Context, chatBatchs []ChatBatchN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, chatBatch := range chatBatchs {
+		id, err := m.Save(ctx, chatBatch)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a chat_batch to database
+func (m *ChatBatchModel) Save(ctx context.Context, chatBatch ChatBatchN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, chatBatch.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new chat_batch or update it when it has a id > 0
+func (m *ChatBatchModel) SaveOrUpdate(ctx context.Context, chatBatch ChatBatchN, onlyFields ...string) (id int64, updated bool, err error) {
+	if chatBatch.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, chatBatch.Id.Int64, chatBatch, onlyFields...)
+		return chatBatch.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, chatBatch, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ChatBatchModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ChatBatchModel) Update(ctx context.Context, builder query.SQLBuilder, chatBatch ChatBatchN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, chatBatch.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ChatBatchModel) UpdateById(ctx context.Context, id int64, chatBatch ChatBatchN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, chatBatch.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ChatBatchModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ChatBatchModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// ChatBatchItemN is a ChatBatchItem object, all fields are nullable
+type ChatBatchItemN struct {
+	original           *chatBatchItemOriginal
+	chatBatchItemModel *ChatBatchItemModel
+
+	Id           null.Int    `json:"id"`
+	BatchId      null.Int    `json:"batch_id"`
+	UserId       null.Int    `json:"user_id"`
+	CustomId     null.String `json:"custom_id,omitempty"`
+	Messages     null.String `json:"messages"`
+	Output       null.String `json:"output,omitempty"`
+	Status       null.Int    `json:"status"`
+	InputTokens  null.Int    `json:"input_tokens"`
+	OutputTokens null.Int    `json:"output_tokens"`
+	Coins        null.Int    `json:"coins"`
+	Error        null.String `json:"error,omitempty"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ChatBatchItemN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ChatBatchItem
+func (inst *ChatBatchItemN) SetModel(chatBatchItemModel *ChatBatchItemModel) {
+	inst.chatBatchItemModel = chatBatchItemModel
+}
+
+// chatBatchItemOriginal is an object which stores original ChatBatchItem from database
+type chatBatchItemOriginal struct {
+	Id           null.Int
+	BatchId      null.Int
+	UserId       null.Int
+	CustomId     null.String
+	Messages     null.String
+	Output       null.String
+	Status       null.Int
+	InputTokens  null.Int
+	OutputTokens null.Int
+	Coins        null.Int
+	Error        null.String
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ChatBatchItemN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &chatBatchItemOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.BatchId != inst.original.BatchId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.CustomId != inst.original.CustomId {
+			return true
+		}
+		if inst.Messages != inst.original.Messages {
+			return true
+		}
+		if inst.Output != inst.original.Output {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.InputTokens != inst.original.InputTokens {
+			return true
+		}
+		if inst.OutputTokens != inst.original.OutputTokens {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.Error != inst.original.Error {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "batch_id":
+				if inst.BatchId != inst.original.BatchId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "custom_id":
+				if inst.CustomId != inst.original.CustomId {
+					return true
+				}
+			case "messages":
+				if inst.Messages != inst.original.Messages {
+					return true
+				}
+			case "output":
+				if inst.Output != inst.original.Output {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "input_tokens":
+				if inst.InputTokens != inst.original.InputTokens {
+					return true
+				}
+			case "output_tokens":
+				if inst.OutputTokens != inst.original.OutputTokens {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "error":
+				if inst.Error != inst.original.Error {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ChatBatchItemN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &chatBatchItemOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.BatchId != inst.original.BatchId {
+			kv["batch_id"] = inst.BatchId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.CustomId != inst.original.CustomId {
+			kv["custom_id"] = inst.CustomId
+		}
+		if inst.Messages != inst.original.Messages {
+			kv["messages"] = inst.Messages
+		}
+		if inst.Output != inst.original.Output {
+			kv["output"] = inst.Output
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.InputTokens != inst.original.InputTokens {
+			kv["input_tokens"] = inst.InputTokens
+		}
+		if inst.OutputTokens != inst.original.OutputTokens {
+			kv["output_tokens"] = inst.OutputTokens
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.Error != inst.original.Error {
+			kv["error"] = inst.Error
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "batch_id":
+				if inst.BatchId != inst.original.BatchId {
+					kv["batch_id"] = inst.BatchId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "custom_id":
+				if inst.CustomId != inst.original.CustomId {
+					kv["custom_id"] = inst.CustomId
+				}
+			case "messages":
+				if inst.Messages != inst.original.Messages {
+					kv["messages"] = inst.Messages
+				}
+			case "output":
+				if inst.Output != inst.original.Output {
+					kv["output"] = inst.Output
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "input_tokens":
+				if inst.InputTokens != inst.original.InputTokens {
+					kv["input_tokens"] = inst.InputTokens
+				}
+			case "output_tokens":
+				if inst.OutputTokens != inst.original.OutputTokens {
+					kv["output_tokens"] = inst.OutputTokens
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "error":
+				if inst.Error != inst.original.Error {
+					kv["error"] = inst.Error
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ChatBatchItemN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.chatBatchItemModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.chatBatchItemModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a chat_batch_item
+func (inst *ChatBatchItemN) Delete(ctx context.Context) error {
+	if inst.chatBatchItemModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.chatBatchItemModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ChatBatchItemN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type chatBatchItemScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var chatBatchItemGlobalScopes = make([]chatBatchItemScope, 0)
+var chatBatchItemLocalScopes = make([]chatBatchItemScope, 0)
+
+// AddGlobalScopeForChatBatchItem assign a global scope to a model
+func AddGlobalScopeForChatBatchItem(name string, apply func(builder query.Condition)) {
+	chatBatchItemGlobalScopes = append(chatBatchItemGlobalScopes, chatBatchItemScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForChatBatchItem assign a local scope to a model
+func AddLocalScopeForChatBatchItem(name string, apply func(builder query.Condition)) {
+	chatBatchItemLocalScopes = append(chatBatchItemLocalScopes, chatBatchItemScope{name: name, apply: apply})
+}
+
+func (m *ChatBatchItemModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range chatBatchItemGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range chatBatchItemLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ChatBatchItemModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ChatBatchItemModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ChatBatchItem struct {
+	Id           int64  `json:"id"`
+	BatchId      int64  `json:"batch_id"`
+	UserId       int64  `json:"user_id"`
+	CustomId     string `json:"custom_id,omitempty"`
+	Messages     string `json:"messages"`
+	Output       string `json:"output,omitempty"`
+	Status       int64  `json:"status"`
+	InputTokens  int64  `json:"input_tokens"`
+	OutputTokens int64  `json:"output_tokens"`
+	Coins        int64  `json:"coins"`
+	Error        string `json:"error,omitempty"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w ChatBatchItem) ToChatBatchItemN(allows ...string) ChatBatchItemN {
+	if len(allows) == 0 {
+		return ChatBatchItemN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			BatchId:      null.IntFrom(int64(w.BatchId)),
+			UserId:       null.IntFrom(int64(w.UserId)),
+			CustomId:     null.StringFrom(w.CustomId),
+			Messages:     null.StringFrom(w.Messages),
+			Output:       null.StringFrom(w.Output),
+			Status:       null.IntFrom(int64(w.Status)),
+			InputTokens:  null.IntFrom(int64(w.InputTokens)),
+			OutputTokens: null.IntFrom(int64(w.OutputTokens)),
+			Coins:        null.IntFrom(int64(w.Coins)),
+			Error:        null.StringFrom(w.Error),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ChatBatchItemN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "batch_id":
+			res.BatchId = null.IntFrom(int64(w.BatchId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "custom_id":
+			res.CustomId = null.StringFrom(w.CustomId)
+		case "messages":
+			res.Messages = null.StringFrom(w.Messages)
+		case "output":
+			res.Output = null.StringFrom(w.Output)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "input_tokens":
+			res.InputTokens = null.IntFrom(int64(w.InputTokens))
+		case "output_tokens":
+			res.OutputTokens = null.IntFrom(int64(w.OutputTokens))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "error":
+			res.Error = null.StringFrom(w.Error)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ChatBatchItem) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ChatBatchItemN) ToChatBatchItem() ChatBatchItem {
+	return ChatBatchItem{
+
+		Id:           w.Id.Int64,
+		BatchId:      w.BatchId.Int64,
+		UserId:       w.UserId.Int64,
+		CustomId:     w.CustomId.String,
+		Messages:     w.Messages.String,
+		Output:       w.Output.String,
+		Status:       w.Status.Int64,
+		InputTokens:  w.InputTokens.Int64,
+		OutputTokens: w.OutputTokens.Int64,
+		Coins:        w.Coins.Int64,
+		Error:        w.Error.String,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// ChatBatchItemModel is a model which encapsulates the operations of the object
+type ChatBatchItemModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var chatBatchItemTableName = "chat_batch_item"
+
+// ChatBatchItemTable return table name for ChatBatchItem
+func ChatBatchItemTable() string {
+	return chatBatchItemTableName
+}
+
+const (
+	FieldChatBatchItemId           = "id"
+	FieldChatBatchItemBatchId      = "batch_id"
+	FieldChatBatchItemUserId       = "user_id"
+	FieldChatBatchItemCustomId     = "custom_id"
+	FieldChatBatchItemMessages     = "messages"
+	FieldChatBatchItemOutput       = "output"
+	FieldChatBatchItemStatus       = "status"
+	FieldChatBatchItemInputTokens  = "input_tokens"
+	FieldChatBatchItemOutputTokens = "output_tokens"
+	FieldChatBatchItemCoins        = "coins"
+	FieldChatBatchItemError        = "error"
+	FieldChatBatchItemCreatedAt    = "created_at"
+	FieldChatBatchItemUpdatedAt    = "updated_at"
+)
+
+// ChatBatchItemFields return all fields in ChatBatchItem model
+func ChatBatchItemFields() []string {
+	return []string{
+		"id",
+		"batch_id",
+		"user_id",
+		"custom_id",
+		"messages",
+		"output",
+		"status",
+		"input_tokens",
+		"output_tokens",
+		"coins",
+		"error",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetChatBatchItemTable(tableName string) {
+	chatBatchItemTableName = tableName
+}
+
+// NewChatBatchItemModel create a ChatBatchItemModel
+func NewChatBatchItemModel(db query.Database) *ChatBatchItemModel {
+	return &ChatBatchItemModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           chatBatchItemTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ChatBatchItemModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ChatBatchItemModel) clone() *ChatBatchItemModel {
+	return &ChatBatchItemModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ChatBatchItemModel) WithoutGlobalScopes(names ...string) *ChatBatchItemModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ChatBatchItemModel) WithLocalScopes(names ...string) *ChatBatchItemModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ChatBatchItemModel) Condition(builder query.SQLBuilder) *ChatBatchItemModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ChatBatchItemModel) Find(ctx context.Context, id int64) (*ChatBatchItemN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ChatBatchItemModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ChatBatchItemModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ChatBatchItemModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ChatBatchItemN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ChatBatchItemModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ChatBatchItemN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"batch_id",
+			"user_id",
+			"custom_id",
+			"messages",
+			"output",
+			"status",
+			"input_tokens",
+			"output_tokens",
+			"coins",
+			"error",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "batch_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "custom_id":
+			selectFields = append(selectFields, f)
+		case "messages":
+			selectFields = append(selectFields, f)
+		case "output":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "input_tokens":
+			selectFields = append(selectFields, f)
+		case "output_tokens":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "error":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ChatBatchItemN, []interface{}) {
+		var chatBatchItemVar ChatBatchItemN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &chatBatchItemVar.Id)
+			case "batch_id":
+				scanFields = append(scanFields, &chatBatchItemVar.BatchId)
+			case "user_id":
+				scanFields = append(scanFields, &chatBatchItemVar.UserId)
+			case "custom_id":
+				scanFields = append(scanFields, &chatBatchItemVar.CustomId)
+			case "messages":
+				scanFields = append(scanFields, &chatBatchItemVar.Messages)
+			case "output":
+				scanFields = append(scanFields, &chatBatchItemVar.Output)
+			case "status":
+				scanFields = append(scanFields, &chatBatchItemVar.Status)
+			case "input_tokens":
+				scanFields = append(scanFields, &chatBatchItemVar.InputTokens)
+			case "output_tokens":
+				scanFields = append(scanFields, &chatBatchItemVar.OutputTokens)
+			case "coins":
+				scanFields = append(scanFields, &chatBatchItemVar.Coins)
+			case "error":
+				scanFields = append(scanFields, &chatBatchItemVar.Error)
+			case "created_at":
+				scanFields = append(scanFields, &chatBatchItemVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &chatBatchItemVar.UpdatedAt)
+			}
+		}
+
+		return &chatBatchItemVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	chatBatchItems := make([]ChatBatchItemN, 0)
+	for rows.Next() {
+		chatBatchItemReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		chatBatchItemReal.original = &chatBatchItemOriginal{}
+		_ = query.Copy(chatBatchItemReal, chatBatchItemReal.original)
+
+		chatBatchItemReal.SetModel(m)
+		chatBatchItems = append(chatBatchItems, *chatBatchItemReal)
+	}
+
+	return chatBatchItems, nil
+}
+
+// First return first result for given query
+func (m *ChatBatchItemModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ChatBatchItemN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new chat_batch_item to database
+func (m *ChatBatchItemModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all chat_batch_items to database
+func (m *ChatBatchItemModel) SaveAll(ctx context.Context, chatBatchItems []ChatBatchItemN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, chatBatchItem := range chatBatchItems {
+		id, err := m.Save(ctx, chatBatchItem)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a chat_batch_item to database
+func (m *ChatBatchItemModel) Save(ctx context.Context, chatBatchItem ChatBatchItemN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, chatBatchItem.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new chat_batch_item or update it when it has a id > 0
+func (m *ChatBatchItemModel) SaveOrUpdate(ctx context.Context, chatBatchItem ChatBatchItemN, onlyFields ...string) (id int64, updated bool, err error) {
+	if chatBatchItem.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, chatBatchItem.Id.Int64, chatBatchItem, onlyFields...)
+		return chatBatchItem.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, chatBatchItem, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ChatBatchItemModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ChatBatchItemModel) Update(ctx context.Context, builder query.SQLBuilder, chatBatchItem ChatBatchItemN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, chatBatchItem.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ChatBatchItemModel) UpdateById(ctx context.Context, id int64, chatBatchItem ChatBatchItemN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, chatBatchItem.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ChatBatchItemModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ChatBatchItemModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
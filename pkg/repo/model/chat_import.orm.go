@@ -0,0 +1,1730 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ChatImportN is a ChatImport object, all fields are nullable
+type ChatImportN struct {
+	original        *chatImportOriginal
+	chatImportModel *ChatImportModel
+
+	Id          null.Int    `json:"id"`
+	UserId      null.Int    `json:"user_id"`
+	Source      null.String `json:"source"`
+	TaskId      null.String `json:"task_id,omitempty"`
+	Status      null.Int    `json:"status"`
+	Total       null.Int    `json:"total"`
+	Imported    null.Int    `json:"imported"`
+	Skipped     null.Int    `json:"skipped"`
+	Failed      null.Int    `json:"failed"`
+	CompletedAt null.Time   `json:"completed_at,omitempty"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ChatImportN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ChatImport
+func (inst *ChatImportN) SetModel(chatImportModel *ChatImportModel) {
+	inst.chatImportModel = chatImportModel
+}
+
+// chatImportOriginal is an object which stores original ChatImport from database
+type chatImportOriginal struct {
+	Id          null.Int
+	UserId      null.Int
+	Source      null.String
+	TaskId      null.String
+	Status      null.Int
+	Total       null.Int
+	Imported    null.Int
+	Skipped     null.Int
+	Failed      null.Int
+	CompletedAt null.Time
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ChatImportN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &chatImportOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Source != inst.original.Source {
+			return true
+		}
+		if inst.TaskId != inst.original.TaskId {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.Total != inst.original.Total {
+			return true
+		}
+		if inst.Imported != inst.original.Imported {
+			return true
+		}
+		if inst.Skipped != inst.original.Skipped {
+			return true
+		}
+		if inst.Failed != inst.original.Failed {
+			return true
+		}
+		if inst.CompletedAt != inst.original.CompletedAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "source":
+				if inst.Source != inst.original.Source {
+					return true
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "total":
+				if inst.Total != inst.original.Total {
+					return true
+				}
+			case "imported":
+				if inst.Imported != inst.original.Imported {
+					return true
+				}
+			case "skipped":
+				if inst.Skipped != inst.original.Skipped {
+					return true
+				}
+			case "failed":
+				if inst.Failed != inst.original.Failed {
+					return true
+				}
+			case "completed_at":
+				if inst.CompletedAt != inst.original.CompletedAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ChatImportN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &chatImportOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Source != inst.original.Source {
+			kv["source"] = inst.Source
+		}
+		if inst.TaskId != inst.original.TaskId {
+			kv["task_id"] = inst.TaskId
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.Total != inst.original.Total {
+			kv["total"] = inst.Total
+		}
+		if inst.Imported != inst.original.Imported {
+			kv["imported"] = inst.Imported
+		}
+		if inst.Skipped != inst.original.Skipped {
+			kv["skipped"] = inst.Skipped
+		}
+		if inst.Failed != inst.original.Failed {
+			kv["failed"] = inst.Failed
+		}
+		if inst.CompletedAt != inst.original.CompletedAt {
+			kv["completed_at"] = inst.CompletedAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "source":
+				if inst.Source != inst.original.Source {
+					kv["source"] = inst.Source
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					kv["task_id"] = inst.TaskId
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "total":
+				if inst.Total != inst.original.Total {
+					kv["total"] = inst.Total
+				}
+			case "imported":
+				if inst.Imported != inst.original.Imported {
+					kv["imported"] = inst.Imported
+				}
+			case "skipped":
+				if inst.Skipped != inst.original.Skipped {
+					kv["skipped"] = inst.Skipped
+				}
+			case "failed":
+				if inst.Failed != inst.original.Failed {
+					kv["failed"] = inst.Failed
+				}
+			case "completed_at":
+				if inst.CompletedAt != inst.original.CompletedAt {
+					kv["completed_at"] = inst.CompletedAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ChatImportN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.chatImportModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.chatImportModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a chat_import
+func (inst *ChatImportN) Delete(ctx context.Context) error {
+	if inst.chatImportModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.chatImportModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ChatImportN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type chatImportScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var chatImportGlobalScopes = make([]chatImportScope, 0)
+var chatImportLocalScopes = make([]chatImportScope, 0)
+
+// AddGlobalScopeForChatImport assign a global scope to a model
+func AddGlobalScopeForChatImport(name string, apply func(builder query.Condition)) {
+	chatImportGlobalScopes = append(chatImportGlobalScopes, chatImportScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForChatImport assign a local scope to a model
+func AddLocalScopeForChatImport(name string, apply func(builder query.Condition)) {
+	chatImportLocalScopes = append(chatImportLocalScopes, chatImportScope{name: name, apply: apply})
+}
+
+func (m *ChatImportModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range chatImportGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range chatImportLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ChatImportModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ChatImportModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ChatImport struct {
+	Id          int64     `json:"id"`
+	UserId      int64     `json:"user_id"`
+	Source      string    `json:"source"`
+	TaskId      string    `json:"task_id,omitempty"`
+	Status      int64     `json:"status"`
+	Total       int64     `json:"total"`
+	Imported    int64     `json:"imported"`
+	Skipped     int64     `json:"skipped"`
+	Failed      int64     `json:"failed"`
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w ChatImport) ToChatImportN(allows ...string) ChatImportN {
+	if len(allows) == 0 {
+		return ChatImportN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			UserId:      null.IntFrom(int64(w.UserId)),
+			Source:      null.StringFrom(w.Source),
+			TaskId:      null.StringFrom(w.TaskId),
+			Status:      null.IntFrom(int64(w.Status)),
+			Total:       null.IntFrom(int64(w.Total)),
+			Imported:    null.IntFrom(int64(w.Imported)),
+			Skipped:     null.IntFrom(int64(w.Skipped)),
+			Failed:      null.IntFrom(int64(w.Failed)),
+			CompletedAt: null.TimeFrom(w.CompletedAt),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ChatImportN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "source":
+			res.Source = null.StringFrom(w.Source)
+		case "task_id":
+			res.TaskId = null.StringFrom(w.TaskId)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "total":
+			res.Total = null.IntFrom(int64(w.Total))
+		case "imported":
+			res.Imported = null.IntFrom(int64(w.Imported))
+		case "skipped":
+			res.Skipped = null.IntFrom(int64(w.Skipped))
+		case "failed":
+			res.Failed = null.IntFrom(int64(w.Failed))
+		case "completed_at":
+			res.CompletedAt = null.TimeFrom(w.CompletedAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ChatImport) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ChatImportN) ToChatImport() ChatImport {
+	return ChatImport{
+
+		Id:          w.Id.Int64,
+		UserId:      w.UserId.Int64,
+		Source:      w.Source.String,
+		TaskId:      w.TaskId.String,
+		Status:      w.Status.Int64,
+		Total:       w.Total.Int64,
+		Imported:    w.Imported.Int64,
+		Skipped:     w.Skipped.Int64,
+		Failed:      w.Failed.Int64,
+		CompletedAt: w.CompletedAt.Time,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// ChatImportModel is a model which encapsulates the operations of the object
+type ChatImportModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var chatImportTableName = "chat_import"
+
+// ChatImportTable return table name for ChatImport
+func ChatImportTable() string {
+	return chatImportTableName
+}
+
+const (
+	FieldChatImportId          = "id"
+	FieldChatImportUserId      = "user_id"
+	FieldChatImportSource      = "source"
+	FieldChatImportTaskId      = "task_id"
+	FieldChatImportStatus      = "status"
+	FieldChatImportTotal       = "total"
+	FieldChatImportImported    = "imported"
+	FieldChatImportSkipped     = "skipped"
+	FieldChatImportFailed      = "failed"
+	FieldChatImportCompletedAt = "completed_at"
+	FieldChatImportCreatedAt   = "created_at"
+	FieldChatImportUpdatedAt   = "updated_at"
+)
+
+// ChatImportFields return all fields in ChatImport model
+func ChatImportFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"source",
+		"task_id",
+		"status",
+		"total",
+		"imported",
+		"skipped",
+		"failed",
+		"completed_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetChatImportTable(tableName string) {
+	chatImportTableName = tableName
+}
+
+// NewChatImportModel create a ChatImportModel
+func NewChatImportModel(db query.Database) *ChatImportModel {
+	return &ChatImportModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           chatImportTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ChatImportModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ChatImportModel) clone() *ChatImportModel {
+	return &ChatImportModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ChatImportModel) WithoutGlobalScopes(names ...string) *ChatImportModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ChatImportModel) WithLocalScopes(names ...string) *ChatImportModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ChatImportModel) Condition(builder query.SQLBuilder) *ChatImportModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ChatImportModel) Find(ctx context.Context, id int64) (*ChatImportN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ChatImportModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ChatImportModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ChatImportModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ChatImportN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ChatImportModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ChatImportN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"source",
+			"task_id",
+			"status",
+			"total",
+			"imported",
+			"skipped",
+			"failed",
+			"completed_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "source":
+			selectFields = append(selectFields, f)
+		case "task_id":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "total":
+			selectFields = append(selectFields, f)
+		case "imported":
+			selectFields = append(selectFields, f)
+		case "skipped":
+			selectFields = append(selectFields, f)
+		case "failed":
+			selectFields = append(selectFields, f)
+		case "completed_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ChatImportN, []interface{}) {
+		var chatImportVar ChatImportN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &chatImportVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &chatImportVar.UserId)
+			case "source":
+				scanFields = append(scanFields, &chatImportVar.Source)
+			case "task_id":
+				scanFields = append(scanFields, &chatImportVar.TaskId)
+			case "status":
+				scanFields = append(scanFields, &chatImportVar.Status)
+			case "total":
+				scanFields = append(scanFields, &chatImportVar.Total)
+			case "imported":
+				scanFields = append(scanFields, &chatImportVar.Imported)
+			case "skipped":
+				scanFields = append(scanFields, &chatImportVar.Skipped)
+			case "failed":
+				scanFields = append(scanFields, &chatImportVar.Failed)
+			case "completed_at":
+				scanFields = append(scanFields, &chatImportVar.CompletedAt)
+			case "created_at":
+				scanFields = append(scanFields, &chatImportVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &chatImportVar.UpdatedAt)
+			}
+		}
+
+		return &chatImportVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	chatImports := make([]ChatImportN, 0)
+	for rows.Next() {
+		chatImportReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		chatImportReal.original = &chatImportOriginal{}
+		_ = query.Copy(chatImportReal, chatImportReal.original)
+
+		chatImportReal.SetModel(m)
+		chatImports = append(chatImports, *chatImportReal)
+	}
+
+	return chatImports, nil
+}
+
+// First return first result for given query
+func (m *ChatImportModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ChatImportN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new chat_import to database
+func (m *ChatImportModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all chat_imports to database
+func (m *ChatImportModel) SaveAll(ctx context.Context, chatImports []ChatImportN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, chatImport := range chatImports {
+		id, err := m.Save(ctx, chatImport)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a chat_import to database
+func (m *ChatImportModel) Save(ctx context.Context, chatImport ChatImportN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, chatImport.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new chat_import or update it when it has a id > 0
+func (m *ChatImportModel) SaveOrUpdate(ctx context.Context, chatImport ChatImportN, onlyFields ...string) (id int64, updated bool, err error) {
+	if chatImport.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, chatImport.Id.Int64, chatImport, onlyFields...)
+		return chatImport.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, chatImport, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ChatImportModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ChatImportModel) Update(ctx context.Context, builder query.SQLBuilder, chatImport ChatImportN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, chatImport.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ChatImportModel) UpdateById(ctx context.Context, id int64, chatImport ChatImportN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, chatImport.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ChatImportModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ChatImportModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// ChatImportItemN is a ChatImportItem object, all fields are nullable
+type ChatImportItemN struct {
+	original            *chatImportItemOriginal
+	chatImportItemModel *ChatImportItemModel
+
+	Id           null.Int    `json:"id"`
+	ImportId     null.Int    `json:"import_id"`
+	UserId       null.Int    `json:"user_id"`
+	Source       null.String `json:"source"`
+	ExternalId   null.String `json:"external_id"`
+	Title        null.String `json:"title,omitempty"`
+	Conversation null.String `json:"conversation"`
+	Status       null.Int    `json:"status"`
+	RoomId       null.Int    `json:"room_id"`
+	MessageCount null.Int    `json:"message_count"`
+	Error        null.String `json:"error,omitempty"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ChatImportItemN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ChatImportItem
+func (inst *ChatImportItemN) SetModel(chatImportItemModel *ChatImportItemModel) {
+	inst.chatImportItemModel = chatImportItemModel
+}
+
+// chatImportItemOriginal is an object which stores original ChatImportItem from database
+type chatImportItemOriginal struct {
+	Id           null.Int
+	ImportId     null.Int
+	UserId       null.Int
+	Source       null.String
+	ExternalId   null.String
+	Title        null.String
+	Conversation null.String
+	Status       null.Int
+	RoomId       null.Int
+	MessageCount null.Int
+	Error        null.String
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ChatImportItemN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &chatImportItemOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.ImportId != inst.original.ImportId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Source != inst.original.Source {
+			return true
+		}
+		if inst.ExternalId != inst.original.ExternalId {
+			return true
+		}
+		if inst.Title != inst.original.Title {
+			return true
+		}
+		if inst.Conversation != inst.original.Conversation {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.RoomId != inst.original.RoomId {
+			return true
+		}
+		if inst.MessageCount != inst.original.MessageCount {
+			return true
+		}
+		if inst.Error != inst.original.Error {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "import_id":
+				if inst.ImportId != inst.original.ImportId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "source":
+				if inst.Source != inst.original.Source {
+					return true
+				}
+			case "external_id":
+				if inst.ExternalId != inst.original.ExternalId {
+					return true
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					return true
+				}
+			case "conversation":
+				if inst.Conversation != inst.original.Conversation {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "room_id":
+				if inst.RoomId != inst.original.RoomId {
+					return true
+				}
+			case "message_count":
+				if inst.MessageCount != inst.original.MessageCount {
+					return true
+				}
+			case "error":
+				if inst.Error != inst.original.Error {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ChatImportItemN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &chatImportItemOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.ImportId != inst.original.ImportId {
+			kv["import_id"] = inst.ImportId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Source != inst.original.Source {
+			kv["source"] = inst.Source
+		}
+		if inst.ExternalId != inst.original.ExternalId {
+			kv["external_id"] = inst.ExternalId
+		}
+		if inst.Title != inst.original.Title {
+			kv["title"] = inst.Title
+		}
+		if inst.Conversation != inst.original.Conversation {
+			kv["conversation"] = inst.Conversation
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.RoomId != inst.original.RoomId {
+			kv["room_id"] = inst.RoomId
+		}
+		if inst.MessageCount != inst.original.MessageCount {
+			kv["message_count"] = inst.MessageCount
+		}
+		if inst.Error != inst.original.Error {
+			kv["error"] = inst.Error
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "import_id":
+				if inst.ImportId != inst.original.ImportId {
+					kv["import_id"] = inst.ImportId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "source":
+				if inst.Source != inst.original.Source {
+					kv["source"] = inst.Source
+				}
+			case "external_id":
+				if inst.ExternalId != inst.original.ExternalId {
+					kv["external_id"] = inst.ExternalId
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					kv["title"] = inst.Title
+				}
+			case "conversation":
+				if inst.Conversation != inst.original.Conversation {
+					kv["conversation"] = inst.Conversation
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "room_id":
+				if inst.RoomId != inst.original.RoomId {
+					kv["room_id"] = inst.RoomId
+				}
+			case "message_count":
+				if inst.MessageCount != inst.original.MessageCount {
+					kv["message_count"] = inst.MessageCount
+				}
+			case "error":
+				if inst.Error != inst.original.Error {
+					kv["error"] = inst.Error
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ChatImportItemN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.chatImportItemModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.chatImportItemModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a chat_import_item
+func (inst *ChatImportItemN) Delete(ctx context.Context) error {
+	if inst.chatImportItemModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.chatImportItemModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ChatImportItemN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type chatImportItemScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var chatImportItemGlobalScopes = make([]chatImportItemScope, 0)
+var chatImportItemLocalScopes = make([]chatImportItemScope, 0)
+
+// AddGlobalScopeForChatImportItem assign a global scope to a model
+func AddGlobalScopeForChatImportItem(name string, apply func(builder query.Condition)) {
+	chatImportItemGlobalScopes = append(chatImportItemGlobalScopes, chatImportItemScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForChatImportItem assign a local scope to a model
+func AddLocalScopeForChatImportItem(name string, apply func(builder query.Condition)) {
+	chatImportItemLocalScopes = append(chatImportItemLocalScopes, chatImportItemScope{name: name, apply: apply})
+}
+
+func (m *ChatImportItemModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range chatImportItemGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range chatImportItemLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ChatImportItemModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ChatImportItemModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ChatImportItem struct {
+	Id           int64  `json:"id"`
+	ImportId     int64  `json:"import_id"`
+	UserId       int64  `json:"user_id"`
+	Source       string `json:"source"`
+	ExternalId   string `json:"external_id"`
+	Title        string `json:"title,omitempty"`
+	Conversation string `json:"conversation"`
+	Status       int64  `json:"status"`
+	RoomId       int64  `json:"room_id"`
+	MessageCount int64  `json:"message_count"`
+	Error        string `json:"error,omitempty"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w ChatImportItem) ToChatImportItemN(allows ...string) ChatImportItemN {
+	if len(allows) == 0 {
+		return ChatImportItemN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			ImportId:     null.IntFrom(int64(w.ImportId)),
+			UserId:       null.IntFrom(int64(w.UserId)),
+			Source:       null.StringFrom(w.Source),
+			ExternalId:   null.StringFrom(w.ExternalId),
+			Title:        null.StringFrom(w.Title),
+			Conversation: null.StringFrom(w.Conversation),
+			Status:       null.IntFrom(int64(w.Status)),
+			RoomId:       null.IntFrom(int64(w.RoomId)),
+			MessageCount: null.IntFrom(int64(w.MessageCount)),
+			Error:        null.StringFrom(w.Error),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ChatImportItemN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "import_id":
+			res.ImportId = null.IntFrom(int64(w.ImportId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "source":
+			res.Source = null.StringFrom(w.Source)
+		case "external_id":
+			res.ExternalId = null.StringFrom(w.ExternalId)
+		case "title":
+			res.Title = null.StringFrom(w.Title)
+		case "conversation":
+			res.Conversation = null.StringFrom(w.Conversation)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "room_id":
+			res.RoomId = null.IntFrom(int64(w.RoomId))
+		case "message_count":
+			res.MessageCount = null.IntFrom(int64(w.MessageCount))
+		case "error":
+			res.Error = null.StringFrom(w.Error)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ChatImportItem) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ChatImportItemN) ToChatImportItem() ChatImportItem {
+	return ChatImportItem{
+
+		Id:           w.Id.Int64,
+		ImportId:     w.ImportId.Int64,
+		UserId:       w.UserId.Int64,
+		Source:       w.Source.String,
+		ExternalId:   w.ExternalId.String,
+		Title:        w.Title.String,
+		Conversation: w.Conversation.String,
+		Status:       w.Status.Int64,
+		RoomId:       w.RoomId.Int64,
+		MessageCount: w.MessageCount.Int64,
+		Error:        w.Error.String,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// ChatImportItemModel is a model which encapsulates the operations of the object
+type ChatImportItemModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var chatImportItemTableName = "chat_import_item"
+
+// ChatImportItemTable return table name for ChatImportItem
+func ChatImportItemTable() string {
+	return chatImportItemTableName
+}
+
+const (
+	FieldChatImportItemId           = "id"
+	FieldChatImportItemImportId     = "import_id"
+	FieldChatImportItemUserId       = "user_id"
+	FieldChatImportItemSource       = "source"
+	FieldChatImportItemExternalId   = "external_id"
+	FieldChatImportItemTitle        = "title"
+	FieldChatImportItemConversation = "conversation"
+	FieldChatImportItemStatus       = "status"
+	FieldChatImportItemRoomId       = "room_id"
+	FieldChatImportItemMessageCount = "message_count"
+	FieldChatImportItemError        = "error"
+	FieldChatImportItemCreatedAt    = "created_at"
+	FieldChatImportItemUpdatedAt    = "updated_at"
+)
+
+// ChatImportItemFields return all fields in ChatImportItem model
+func ChatImportItemFields() []string {
+	return []string{
+		"id",
+		"import_id",
+		"user_id",
+		"source",
+		"external_id",
+		"title",
+		"conversation",
+		"status",
+		"room_id",
+		"message_count",
+		"error",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetChatImportItemTable(tableName string) {
+	chatImportItemTableName = tableName
+}
+
+// NewChatImportItemModel create a ChatImportItemModel
+func NewChatImportItemModel(db query.Database) *ChatImportItemModel {
+	return &ChatImportItemModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           chatImportItemTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ChatImportItemModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ChatImportItemModel) clone() *ChatImportItemModel {
+	return &ChatImportItemModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ChatImportItemModel) WithoutGlobalScopes(names ...string) *ChatImportItemModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ChatImportItemModel) WithLocalScopes(names ...string) *ChatImportItemModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ChatImportItemModel) Condition(builder query.SQLBuilder) *ChatImportItemModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ChatImportItemModel) Find(ctx context.Context, id int64) (*ChatImportItemN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ChatImportItemModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ChatImportItemModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ChatImportItemModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ChatImportItemN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ChatImportItemModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ChatImportItemN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"import_id",
+			"user_id",
+			"source",
+			"external_id",
+			"title",
+			"conversation",
+			"status",
+			"room_id",
+			"message_count",
+			"error",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "import_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "source":
+			selectFields = append(selectFields, f)
+		case "external_id":
+			selectFields = append(selectFields, f)
+		case "title":
+			selectFields = append(selectFields, f)
+		case "conversation":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "room_id":
+			selectFields = append(selectFields, f)
+		case "message_count":
+			selectFields = append(selectFields, f)
+		case "error":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ChatImportItemN, []interface{}) {
+		var chatImportItemVar ChatImportItemN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &chatImportItemVar.Id)
+			case "import_id":
+				scanFields = append(scanFields, &chatImportItemVar.ImportId)
+			case "user_id":
+				scanFields = append(scanFields, &chatImportItemVar.UserId)
+			case "source":
+				scanFields = append(scanFields, &chatImportItemVar.Source)
+			case "external_id":
+				scanFields = append(scanFields, &chatImportItemVar.ExternalId)
+			case "title":
+				scanFields = append(scanFields, &chatImportItemVar.Title)
+			case "conversation":
+				scanFields = append(scanFields, &chatImportItemVar.Conversation)
+			case "status":
+				scanFields = append(scanFields, &chatImportItemVar.Status)
+			case "room_id":
+				scanFields = append(scanFields, &chatImportItemVar.RoomId)
+			case "message_count":
+				scanFields = append(scanFields, &chatImportItemVar.MessageCount)
+			case "error":
+				scanFields = append(scanFields, &chatImportItemVar.Error)
+			case "created_at":
+				scanFields = append(scanFields, &chatImportItemVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &chatImportItemVar.UpdatedAt)
+			}
+		}
+
+		return &chatImportItemVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	chatImportItems := make([]ChatImportItemN, 0)
+	for rows.Next() {
+		chatImportItemReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		chatImportItemReal.original = &chatImportItemOriginal{}
+		_ = query.Copy(chatImportItemReal, chatImportItemReal.original)
+
+		chatImportItemReal.SetModel(m)
+		chatImportItems = append(chatImportItems, *chatImportItemReal)
+	}
+
+	return chatImportItems, nil
+}
+
+// First return first result for given query
+func (m *ChatImportItemModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ChatImportItemN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new chat_import_item to database
+func (m *ChatImportItemModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all chat_import_items to database
+func (m *ChatImportItemModel) SaveAll(ctx context.Context, chatImportItems []ChatImportItemN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, chatImportItem := range chatImportItems {
+		id, err := m.Save(ctx, chatImportItem)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a chat_import_item to database
+func (m *ChatImportItemModel) Save(ctx context.Context, chatImportItem ChatImportItemN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, chatImportItem.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new chat_import_item or update it when it has a id > 0
+func (m *ChatImportItemModel) SaveOrUpdate(ctx context.Context, chatImportItem ChatImportItemN, onlyFields ...string) (id int64, updated bool, err error) {
+	if chatImportItem.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, chatImportItem.Id.Int64, chatImportItem, onlyFields...)
+		return chatImportItem.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, chatImportItem, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ChatImportItemModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ChatImportItemModel) Update(ctx context.Context, builder query.SQLBuilder, chatImportItem ChatImportItemN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, chatImportItem.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ChatImportItemModel) UpdateById(ctx context.Context, id int64, chatImportItem ChatImportItemN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, chatImportItem.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ChatImportItemModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ChatImportItemModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
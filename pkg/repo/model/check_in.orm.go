@@ -0,0 +1,1394 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// CheckInRewardN is a CheckInReward object, all fields are nullable
+type CheckInRewardN struct {
+	original           *checkInRewardOriginal
+	checkInRewardModel *CheckInRewardModel
+
+	Id        null.Int `json:"id"`
+	StreakDay null.Int `json:"streak_day"`
+	Coins     null.Int `json:"coins"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *CheckInRewardN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for CheckInReward
+func (inst *CheckInRewardN) SetModel(checkInRewardModel *CheckInRewardModel) {
+	inst.checkInRewardModel = checkInRewardModel
+}
+
+// checkInRewardOriginal is an object which stores original CheckInReward from database
+type checkInRewardOriginal struct {
+	Id        null.Int
+	StreakDay null.Int
+	Coins     null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *CheckInRewardN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &checkInRewardOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.StreakDay != inst.original.StreakDay {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "streak_day":
+				if inst.StreakDay != inst.original.StreakDay {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *CheckInRewardN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &checkInRewardOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.StreakDay != inst.original.StreakDay {
+			kv["streak_day"] = inst.StreakDay
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "streak_day":
+				if inst.StreakDay != inst.original.StreakDay {
+					kv["streak_day"] = inst.StreakDay
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *CheckInRewardN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.checkInRewardModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.checkInRewardModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a check_in_reward
+func (inst *CheckInRewardN) Delete(ctx context.Context) error {
+	if inst.checkInRewardModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.checkInRewardModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *CheckInRewardN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type checkInRewardScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var checkInRewardGlobalScopes = make([]checkInRewardScope, 0)
+var checkInRewardLocalScopes = make([]checkInRewardScope, 0)
+
+// AddGlobalScopeForCheckInReward assign a global scope to a model
+func AddGlobalScopeForCheckInReward(name string, apply func(builder query.Condition)) {
+	checkInRewardGlobalScopes = append(checkInRewardGlobalScopes, checkInRewardScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForCheckInReward assign a local scope to a model
+func AddLocalScopeForCheckInReward(name string, apply func(builder query.Condition)) {
+	checkInRewardLocalScopes = append(checkInRewardLocalScopes, checkInRewardScope{name: name, apply: apply})
+}
+
+func (m *CheckInRewardModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range checkInRewardGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range checkInRewardLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *CheckInRewardModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *CheckInRewardModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type CheckInReward struct {
+	Id        int64 `json:"id"`
+	StreakDay int64 `json:"streak_day"`
+	Coins     int64 `json:"coins"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w CheckInReward) ToCheckInRewardN(allows ...string) CheckInRewardN {
+	if len(allows) == 0 {
+		return CheckInRewardN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			StreakDay: null.IntFrom(int64(w.StreakDay)),
+			Coins:     null.IntFrom(int64(w.Coins)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := CheckInRewardN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "streak_day":
+			res.StreakDay = null.IntFrom(int64(w.StreakDay))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w CheckInReward) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *CheckInRewardN) ToCheckInReward() CheckInReward {
+	return CheckInReward{
+
+		Id:        w.Id.Int64,
+		StreakDay: w.StreakDay.Int64,
+		Coins:     w.Coins.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// CheckInRewardModel is a model which encapsulates the operations of the object
+type CheckInRewardModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var checkInRewardTableName = "check_in_reward"
+
+// CheckInRewardTable return table name for CheckInReward
+func CheckInRewardTable() string {
+	return checkInRewardTableName
+}
+
+const (
+	FieldCheckInRewardId        = "id"
+	FieldCheckInRewardStreakDay = "streak_day"
+	FieldCheckInRewardCoins     = "coins"
+	FieldCheckInRewardCreatedAt = "created_at"
+	FieldCheckInRewardUpdatedAt = "updated_at"
+)
+
+// CheckInRewardFields return all fields in CheckInReward model
+func CheckInRewardFields() []string {
+	return []string{
+		"id",
+		"streak_day",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetCheckInRewardTable(tableName string) {
+	checkInRewardTableName = tableName
+}
+
+// NewCheckInRewardModel create a CheckInRewardModel
+func NewCheckInRewardModel(db query.Database) *CheckInRewardModel {
+	return &CheckInRewardModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           checkInRewardTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *CheckInRewardModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *CheckInRewardModel) clone() *CheckInRewardModel {
+	return &CheckInRewardModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *CheckInRewardModel) WithoutGlobalScopes(names ...string) *CheckInRewardModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *CheckInRewardModel) WithLocalScopes(names ...string) *CheckInRewardModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *CheckInRewardModel) Condition(builder query.SQLBuilder) *CheckInRewardModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *CheckInRewardModel) Find(ctx context.Context, id int64) (*CheckInRewardN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *CheckInRewardModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *CheckInRewardModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *CheckInRewardModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]CheckInRewardN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *CheckInRewardModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]CheckInRewardN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"streak_day",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "streak_day":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*CheckInRewardN, []interface{}) {
+		var checkInRewardVar CheckInRewardN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &checkInRewardVar.Id)
+			case "streak_day":
+				scanFields = append(scanFields, &checkInRewardVar.StreakDay)
+			case "coins":
+				scanFields = append(scanFields, &checkInRewardVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &checkInRewardVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &checkInRewardVar.UpdatedAt)
+			}
+		}
+
+		return &checkInRewardVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	checkInRewards := make([]CheckInRewardN, 0)
+	for rows.Next() {
+		checkInRewardReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		checkInRewardReal.original = &checkInRewardOriginal{}
+		_ = query.Copy(checkInRewardReal, checkInRewardReal.original)
+
+		checkInRewardReal.SetModel(m)
+		checkInRewards = append(checkInRewards, *checkInRewardReal)
+	}
+
+	return checkInRewards, nil
+}
+
+// First return first result for given query
+func (m *CheckInRewardModel) First(ctx context.Context, builders ...query.SQLBuilder) (*CheckInRewardN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new check_in_reward to database
+func (m *CheckInRewardModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all check_in_rewards to database
+func (m *CheckInRewardModel) SaveAll(ctx context.Context, checkInRewards []CheckInRewardN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, checkInReward := range checkInRewards {
+		id, err := m.Save(ctx, checkInReward)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a check_in_reward to database
+func (m *CheckInRewardModel) Save(ctx context.Context, checkInReward CheckInRewardN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, checkInReward.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new check_in_reward or update it when it has a id > 0
+func (m *CheckInRewardModel) SaveOrUpdate(ctx context.Context, checkInReward CheckInRewardN, onlyFields ...string) (id int64, updated bool, err error) {
+	if checkInReward.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, checkInReward.Id.Int64, checkInReward, onlyFields...)
+		return checkInReward.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, checkInReward, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *CheckInRewardModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *CheckInRewardModel) Update(ctx context.Context, builder query.SQLBuilder, checkInReward CheckInRewardN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, checkInReward.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *CheckInRewardModel) UpdateById(ctx context.Context, id int64, checkInReward CheckInRewardN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, checkInReward.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *CheckInRewardModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *CheckInRewardModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// UserCheckInN is a UserCheckIn object, all fields are nullable
+type UserCheckInN struct {
+	original         *userCheckInOriginal
+	userCheckInModel *UserCheckInModel
+
+	Id          null.Int  `json:"id"`
+	UserId      null.Int  `json:"user_id"`
+	CheckInDate null.Time `json:"check_in_date"`
+	Streak      null.Int  `json:"streak"`
+	Coins       null.Int  `json:"coins"`
+	QuotaId     null.Int  `json:"quota_id"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *UserCheckInN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for UserCheckIn
+func (inst *UserCheckInN) SetModel(userCheckInModel *UserCheckInModel) {
+	inst.userCheckInModel = userCheckInModel
+}
+
+// userCheckInOriginal is an object which stores original UserCheckIn from database
+type userCheckInOriginal struct {
+	Id          null.Int
+	UserId      null.Int
+	CheckInDate null.Time
+	Streak      null.Int
+	Coins       null.Int
+	QuotaId     null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *UserCheckInN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &userCheckInOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.CheckInDate != inst.original.CheckInDate {
+			return true
+		}
+		if inst.Streak != inst.original.Streak {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.QuotaId != inst.original.QuotaId {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "check_in_date":
+				if inst.CheckInDate != inst.original.CheckInDate {
+					return true
+				}
+			case "streak":
+				if inst.Streak != inst.original.Streak {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "quota_id":
+				if inst.QuotaId != inst.original.QuotaId {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *UserCheckInN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &userCheckInOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.CheckInDate != inst.original.CheckInDate {
+			kv["check_in_date"] = inst.CheckInDate
+		}
+		if inst.Streak != inst.original.Streak {
+			kv["streak"] = inst.Streak
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.QuotaId != inst.original.QuotaId {
+			kv["quota_id"] = inst.QuotaId
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "check_in_date":
+				if inst.CheckInDate != inst.original.CheckInDate {
+					kv["check_in_date"] = inst.CheckInDate
+				}
+			case "streak":
+				if inst.Streak != inst.original.Streak {
+					kv["streak"] = inst.Streak
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "quota_id":
+				if inst.QuotaId != inst.original.QuotaId {
+					kv["quota_id"] = inst.QuotaId
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *UserCheckInN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.userCheckInModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.userCheckInModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a user_check_in
+func (inst *UserCheckInN) Delete(ctx context.Context) error {
+	if inst.userCheckInModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.userCheckInModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *UserCheckInN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type userCheckInScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var userCheckInGlobalScopes = make([]userCheckInScope, 0)
+var userCheckInLocalScopes = make([]userCheckInScope, 0)
+
+// AddGlobalScopeForUserCheckIn assign a global scope to a model
+func AddGlobalScopeForUserCheckIn(name string, apply func(builder query.Condition)) {
+	userCheckInGlobalScopes = append(userCheckInGlobalScopes, userCheckInScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForUserCheckIn assign a local scope to a model
+func AddLocalScopeForUserCheckIn(name string, apply func(builder query.Condition)) {
+	userCheckInLocalScopes = append(userCheckInLocalScopes, userCheckInScope{name: name, apply: apply})
+}
+
+func (m *UserCheckInModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range userCheckInGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range userCheckInLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *UserCheckInModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *UserCheckInModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UserCheckIn struct {
+	Id          int64     `json:"id"`
+	UserId      int64     `json:"user_id"`
+	CheckInDate time.Time `json:"check_in_date"`
+	Streak      int64     `json:"streak"`
+	Coins       int64     `json:"coins"`
+	QuotaId     int64     `json:"quota_id"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w UserCheckIn) ToUserCheckInN(allows ...string) UserCheckInN {
+	if len(allows) == 0 {
+		return UserCheckInN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			UserId:      null.IntFrom(int64(w.UserId)),
+			CheckInDate: null.TimeFrom(w.CheckInDate),
+			Streak:      null.IntFrom(int64(w.Streak)),
+			Coins:       null.IntFrom(int64(w.Coins)),
+			QuotaId:     null.IntFrom(int64(w.QuotaId)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := UserCheckInN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "check_in_date":
+			res.CheckInDate = null.TimeFrom(w.CheckInDate)
+		case "streak":
+			res.Streak = null.IntFrom(int64(w.Streak))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "quota_id":
+			res.QuotaId = null.IntFrom(int64(w.QuotaId))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w UserCheckIn) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *UserCheckInN) ToUserCheckIn() UserCheckIn {
+	return UserCheckIn{
+
+		Id:          w.Id.Int64,
+		UserId:      w.UserId.Int64,
+		CheckInDate: w.CheckInDate.Time,
+		Streak:      w.Streak.Int64,
+		Coins:       w.Coins.Int64,
+		QuotaId:     w.QuotaId.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// UserCheckInModel is a model which encapsulates the operations of the object
+type UserCheckInModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var userCheckInTableName = "user_check_in"
+
+// UserCheckInTable return table name for UserCheckIn
+func UserCheckInTable() string {
+	return userCheckInTableName
+}
+
+const (
+	FieldUserCheckInId          = "id"
+	FieldUserCheckInUserId      = "user_id"
+	FieldUserCheckInCheckInDate = "check_in_date"
+	FieldUserCheckInStreak      = "streak"
+	FieldUserCheckInCoins       = "coins"
+	FieldUserCheckInQuotaId     = "quota_id"
+	FieldUserCheckInCreatedAt   = "created_at"
+	FieldUserCheckInUpdatedAt   = "updated_at"
+)
+
+// UserCheckInFields return all fields in UserCheckIn model
+func UserCheckInFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"check_in_date",
+		"streak",
+		"coins",
+		"quota_id",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetUserCheckInTable(tableName string) {
+	userCheckInTableName = tableName
+}
+
+// NewUserCheckInModel create a UserCheckInModel
+func NewUserCheckInModel(db query.Database) *UserCheckInModel {
+	return &UserCheckInModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           userCheckInTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *UserCheckInModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *UserCheckInModel) clone() *UserCheckInModel {
+	return &UserCheckInModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *UserCheckInModel) WithoutGlobalScopes(names ...string) *UserCheckInModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *UserCheckInModel) WithLocalScopes(names ...string) *UserCheckInModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *UserCheckInModel) Condition(builder query.SQLBuilder) *UserCheckInModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *UserCheckInModel) Find(ctx context.Context, id int64) (*UserCheckInN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *UserCheckInModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *UserCheckInModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *UserCheckInModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]UserCheckInN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *UserCheckInModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]UserCheckInN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"check_in_date",
+			"streak",
+			"coins",
+			"quota_id",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "check_in_date":
+			selectFields = append(selectFields, f)
+		case "streak":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "quota_id":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*UserCheckInN, []interface{}) {
+		var userCheckInVar UserCheckInN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &userCheckInVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &userCheckInVar.UserId)
+			case "check_in_date":
+				scanFields = append(scanFields, &userCheckInVar.CheckInDate)
+			case "streak":
+				scanFields = append(scanFields, &userCheckInVar.Streak)
+			case "coins":
+				scanFields = append(scanFields, &userCheckInVar.Coins)
+			case "quota_id":
+				scanFields = append(scanFields, &userCheckInVar.QuotaId)
+			case "created_at":
+				scanFields = append(scanFields, &userCheckInVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &userCheckInVar.UpdatedAt)
+			}
+		}
+
+		return &userCheckInVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userCheckIns := make([]UserCheckInN, 0)
+	for rows.Next() {
+		userCheckInReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		userCheckInReal.original = &userCheckInOriginal{}
+		_ = query.Copy(userCheckInReal, userCheckInReal.original)
+
+		userCheckInReal.SetModel(m)
+		userCheckIns = append(userCheckIns, *userCheckInReal)
+	}
+
+	return userCheckIns, nil
+}
+
+// First return first result for given query
+func (m *UserCheckInModel) First(ctx context.Context, builders ...query.SQLBuilder) (*UserCheckInN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new user_check_in to database
+func (m *UserCheckInModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all user_check_ins to database
+func (m *UserCheckInModel) SaveAll(ctx context.Context, userCheckIns []UserCheckInN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, userCheckIn := range userCheckIns {
+		id, err := m.Save(ctx, userCheckIn)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a user_check_in to database
+func (m *UserCheckInModel) Save(ctx context.Context, userCheckIn UserCheckInN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, userCheckIn.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new user_check_in or update it when it has a id > 0
+func (m *UserCheckInModel) SaveOrUpdate(ctx context.Context, userCheckIn UserCheckInN, onlyFields ...string) (id int64, updated bool, err error) {
+	if userCheckIn.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, userCheckIn.Id.Int64, userCheckIn, onlyFields...)
+		return userCheckIn.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, userCheckIn, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *UserCheckInModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *UserCheckInModel) Update(ctx context.Context, builder query.SQLBuilder, userCheckIn UserCheckInN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, userCheckIn.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *UserCheckInModel) UpdateById(ctx context.Context, id int64, userCheckIn UserCheckInN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, userCheckIn.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *UserCheckInModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *UserCheckInModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
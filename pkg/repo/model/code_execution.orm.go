@@ -0,0 +1,859 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ChatMessagesCodeExecutionN is a ChatMessagesCodeExecution object, all fields are nullable
+type ChatMessagesCodeExecutionN struct {
+	original                       *chatMessagesCodeExecutionOriginal
+	chatMessagesCodeExecutionModel *ChatMessagesCodeExecutionModel
+
+	Id         null.Int    `json:"id"`
+	MessageId  null.Int    `json:"message_id"`
+	UserId     null.Int    `json:"user_id"`
+	Language   null.String `json:"language"`
+	Code       null.String `json:"code"`
+	Stdout     null.String `json:"stdout,omitempty"`
+	Stderr     null.String `json:"stderr,omitempty"`
+	ExitCode   null.Int    `json:"exit_code"`
+	DurationMs null.Int    `json:"duration_ms"`
+	Coins      null.Int    `json:"coins"`
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ChatMessagesCodeExecutionN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ChatMessagesCodeExecution
+func (inst *ChatMessagesCodeExecutionN) SetModel(chatMessagesCodeExecutionModel *ChatMessagesCodeExecutionModel) {
+	inst.chatMessagesCodeExecutionModel = chatMessagesCodeExecutionModel
+}
+
+// chatMessagesCodeExecutionOriginal is an object which stores original ChatMessagesCodeExecution from database
+type chatMessagesCodeExecutionOriginal struct {
+	Id         null.Int
+	MessageId  null.Int
+	UserId     null.Int
+	Language   null.String
+	Code       null.String
+	Stdout     null.String
+	Stderr     null.String
+	ExitCode   null.Int
+	DurationMs null.Int
+	Coins      null.Int
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ChatMessagesCodeExecutionN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &chatMessagesCodeExecutionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.MessageId != inst.original.MessageId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Language != inst.original.Language {
+			return true
+		}
+		if inst.Code != inst.original.Code {
+			return true
+		}
+		if inst.Stdout != inst.original.Stdout {
+			return true
+		}
+		if inst.Stderr != inst.original.Stderr {
+			return true
+		}
+		if inst.ExitCode != inst.original.ExitCode {
+			return true
+		}
+		if inst.DurationMs != inst.original.DurationMs {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "message_id":
+				if inst.MessageId != inst.original.MessageId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "language":
+				if inst.Language != inst.original.Language {
+					return true
+				}
+			case "code":
+				if inst.Code != inst.original.Code {
+					return true
+				}
+			case "stdout":
+				if inst.Stdout != inst.original.Stdout {
+					return true
+				}
+			case "stderr":
+				if inst.Stderr != inst.original.Stderr {
+					return true
+				}
+			case "exit_code":
+				if inst.ExitCode != inst.original.ExitCode {
+					return true
+				}
+			case "duration_ms":
+				if inst.DurationMs != inst.original.DurationMs {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ChatMessagesCodeExecutionN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &chatMessagesCodeExecutionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.MessageId != inst.original.MessageId {
+			kv["message_id"] = inst.MessageId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Language != inst.original.Language {
+			kv["language"] = inst.Language
+		}
+		if inst.Code != inst.original.Code {
+			kv["code"] = inst.Code
+		}
+		if inst.Stdout != inst.original.Stdout {
+			kv["stdout"] = inst.Stdout
+		}
+		if inst.Stderr != inst.original.Stderr {
+			kv["stderr"] = inst.Stderr
+		}
+		if inst.ExitCode != inst.original.ExitCode {
+			kv["exit_code"] = inst.ExitCode
+		}
+		if inst.DurationMs != inst.original.DurationMs {
+			kv["duration_ms"] = inst.DurationMs
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "message_id":
+				if inst.MessageId != inst.original.MessageId {
+					kv["message_id"] = inst.MessageId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "language":
+				if inst.Language != inst.original.Language {
+					kv["language"] = inst.Language
+				}
+			case "code":
+				if inst.Code != inst.original.Code {
+					kv["code"] = inst.Code
+				}
+			case "stdout":
+				if inst.Stdout != inst.original.Stdout {
+					kv["stdout"] = inst.Stdout
+				}
+			case "stderr":
+				if inst.Stderr != inst.original.Stderr {
+					kv["stderr"] = inst.Stderr
+				}
+			case "exit_code":
+				if inst.ExitCode != inst.original.ExitCode {
+					kv["exit_code"] = inst.ExitCode
+				}
+			case "duration_ms":
+				if inst.DurationMs != inst.original.DurationMs {
+					kv["duration_ms"] = inst.DurationMs
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ChatMessagesCodeExecutionN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.chatMessagesCodeExecutionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.chatMessagesCodeExecutionModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a chat_messages_code_execution
+func (inst *ChatMessagesCodeExecutionN) Delete(ctx context.Context) error {
+	if inst.chatMessagesCodeExecutionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.chatMessagesCodeExecutionModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ChatMessagesCodeExecutionN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type chatMessagesCodeExecutionScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var chatMessagesCodeExecutionGlobalScopes = make([]chatMessagesCodeExecutionScope, 0)
+var chatMessagesCodeExecutionLocalScopes = make([]chatMessagesCodeExecutionScope, 0)
+
+// AddGlobalScopeForChatMessagesCodeExecution assign a global scope to a model
+func AddGlobalScopeForChatMessagesCodeExecution(name string, apply func(builder query.Condition)) {
+	chatMessagesCodeExecutionGlobalScopes = append(chatMessagesCodeExecutionGlobalScopes, chatMessagesCodeExecutionScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForChatMessagesCodeExecution assign a local scope to a model
+func AddLocalScopeForChatMessagesCodeExecution(name string, apply func(builder query.Condition)) {
+	chatMessagesCodeExecutionLocalScopes = append(chatMessagesCodeExecutionLocalScopes, chatMessagesCodeExecutionScope{name: name, apply: apply})
+}
+
+func (m *ChatMessagesCodeExecutionModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range chatMessagesCodeExecutionGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range chatMessagesCodeExecutionLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ChatMessagesCodeExecutionModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ChatMessagesCodeExecutionModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ChatMessagesCodeExecution struct {
+	Id         int64  `json:"id"`
+	MessageId  int64  `json:"message_id"`
+	UserId     int64  `json:"user_id"`
+	Language   string `json:"language"`
+	Code       string `json:"code"`
+	Stdout     string `json:"stdout,omitempty"`
+	Stderr     string `json:"stderr,omitempty"`
+	ExitCode   int64  `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
+	Coins      int64  `json:"coins"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w ChatMessagesCodeExecution) ToChatMessagesCodeExecutionN(allows ...string) ChatMessagesCodeExecutionN {
+	if len(allows) == 0 {
+		return ChatMessagesCodeExecutionN{
+
+			Id:         null.IntFrom(int64(w.Id)),
+			MessageId:  null.IntFrom(int64(w.MessageId)),
+			UserId:     null.IntFrom(int64(w.UserId)),
+			Language:   null.StringFrom(w.Language),
+			Code:       null.StringFrom(w.Code),
+			Stdout:     null.StringFrom(w.Stdout),
+			Stderr:     null.StringFrom(w.Stderr),
+			ExitCode:   null.IntFrom(int64(w.ExitCode)),
+			DurationMs: null.IntFrom(int64(w.DurationMs)),
+			Coins:      null.IntFrom(int64(w.Coins)),
+			CreatedAt:  null.TimeFrom(w.CreatedAt),
+			UpdatedAt:  null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ChatMessagesCodeExecutionN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "message_id":
+			res.MessageId = null.IntFrom(int64(w.MessageId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "language":
+			res.Language = null.StringFrom(w.Language)
+		case "code":
+			res.Code = null.StringFrom(w.Code)
+		case "stdout":
+			res.Stdout = null.StringFrom(w.Stdout)
+		case "stderr":
+			res.Stderr = null.StringFrom(w.Stderr)
+		case "exit_code":
+			res.ExitCode = null.IntFrom(int64(w.ExitCode))
+		case "duration_ms":
+			res.DurationMs = null.IntFrom(int64(w.DurationMs))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ChatMessagesCodeExecution) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ChatMessagesCodeExecutionN) ToChatMessagesCodeExecution() ChatMessagesCodeExecution {
+	return ChatMessagesCodeExecution{
+
+		Id:         w.Id.Int64,
+		MessageId:  w.MessageId.Int64,
+		UserId:     w.UserId.Int64,
+		Language:   w.Language.String,
+		Code:       w.Code.String,
+		Stdout:     w.Stdout.String,
+		Stderr:     w.Stderr.String,
+		ExitCode:   w.ExitCode.Int64,
+		DurationMs: w.DurationMs.Int64,
+		Coins:      w.Coins.Int64,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+	}
+}
+
+// ChatMessagesCodeExecutionModel is a model which encapsulates the operations of the object
+type ChatMessagesCodeExecutionModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var chatMessagesCodeExecutionTableName = "chat_messages_code_execution"
+
+// ChatMessagesCodeExecutionTable return table name for ChatMessagesCodeExecution
+func ChatMessagesCodeExecutionTable() string {
+	return chatMessagesCodeExecutionTableName
+}
+
+const (
+	FieldChatMessagesCodeExecutionId         = "id"
+	FieldChatMessagesCodeExecutionMessageId  = "message_id"
+	FieldChatMessagesCodeExecutionUserId     = "user_id"
+	FieldChatMessagesCodeExecutionLanguage   = "language"
+	FieldChatMessagesCodeExecutionCode       = "code"
+	FieldChatMessagesCodeExecutionStdout     = "stdout"
+	FieldChatMessagesCodeExecutionStderr     = "stderr"
+	FieldChatMessagesCodeExecutionExitCode   = "exit_code"
+	FieldChatMessagesCodeExecutionDurationMs = "duration_ms"
+	FieldChatMessagesCodeExecutionCoins      = "coins"
+	FieldChatMessagesCodeExecutionCreatedAt  = "created_at"
+	FieldChatMessagesCodeExecutionUpdatedAt  = "updated_at"
+)
+
+// ChatMessagesCodeExecutionFields return all fields in ChatMessagesCodeExecution model
+func ChatMessagesCodeExecutionFields() []string {
+	return []string{
+		"id",
+		"message_id",
+		"user_id",
+		"language",
+		"code",
+		"stdout",
+		"stderr",
+		"exit_code",
+		"duration_ms",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetChatMessagesCodeExecutionTable(tableName string) {
+	chatMessagesCodeExecutionTableName = tableName
+}
+
+// NewChatMessagesCodeExecutionModel create a ChatMessagesCodeExecutionModel
+func NewChatMessagesCodeExecutionModel(db query.Database) *ChatMessagesCodeExecutionModel {
+	return &ChatMessagesCodeExecutionModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           chatMessagesCodeExecutionTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ChatMessagesCodeExecutionModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ChatMessagesCodeExecutionModel) clone() *ChatMessagesCodeExecutionModel {
+	return &ChatMessagesCodeExecutionModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ChatMessagesCodeExecutionModel) WithoutGlobalScopes(names ...string) *ChatMessagesCodeExecutionModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ChatMessagesCodeExecutionModel) WithLocalScopes(names ...string) *ChatMessagesCodeExecutionModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ChatMessagesCodeExecutionModel) Condition(builder query.SQLBuilder) *ChatMessagesCodeExecutionModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ChatMessagesCodeExecutionModel) Find(ctx context.Context, id int64) (*ChatMessagesCodeExecutionN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ChatMessagesCodeExecutionModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ChatMessagesCodeExecutionModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ChatMessagesCodeExecutionModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ChatMessagesCodeExecutionN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ChatMessagesCodeExecutionModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ChatMessagesCodeExecutionN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"message_id",
+			"user_id",
+			"language",
+			"code",
+			"stdout",
+			"stderr",
+			"exit_code",
+			"duration_ms",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "message_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "language":
+			selectFields = append(selectFields, f)
+		case "code":
+			selectFields = append(selectFields, f)
+		case "stdout":
+			selectFields = append(selectFields, f)
+		case "stderr":
+			selectFields = append(selectFields, f)
+		case "exit_code":
+			selectFields = append(selectFields, f)
+		case "duration_ms":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ChatMessagesCodeExecutionN, []interface{}) {
+		var chatMessagesCodeExecutionVar ChatMessagesCodeExecutionN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.Id)
+			case "message_id":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.MessageId)
+			case "user_id":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.UserId)
+			case "language":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.Language)
+			case "code":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.Code)
+			case "stdout":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.Stdout)
+			case "stderr":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.Stderr)
+			case "exit_code":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.ExitCode)
+			case "duration_ms":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.DurationMs)
+			case "coins":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &chatMessagesCodeExecutionVar.UpdatedAt)
+			}
+		}
+
+		return &chatMessagesCodeExecutionVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	chatMessagesCodeExecutions := make([]ChatMessagesCodeExecutionN, 0)
+	for rows.Next() {
+		chatMessagesCodeExecutionReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		chatMessagesCodeExecutionReal.original = &chatMessagesCodeExecutionOriginal{}
+		_ = query.Copy(chatMessagesCodeExecutionReal, chatMessagesCodeExecutionReal.original)
+
+		chatMessagesCodeExecutionReal.SetModel(m)
+		chatMessagesCodeExecutions = append(chatMessagesCodeExecutions, *chatMessagesCodeExecutionReal)
+	}
+
+	return chatMessagesCodeExecutions, nil
+}
+
+// First return first result for given query
+func (m *ChatMessagesCodeExecutionModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ChatMessagesCodeExecutionN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new chat_messages_code_execution to database
+func (m *ChatMessagesCodeExecutionModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all chat_messages_code_executions to database
+func (m *ChatMessagesCodeExecutionModel) SaveAll(ctx context.Context, chatMessagesCodeExecutions []ChatMessagesCodeExecutionN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, chatMessagesCodeExecution := range chatMessagesCodeExecutions {
+		id, err := m.Save(ctx, chatMessagesCodeExecution)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a chat_messages_code_execution to database
+func (m *ChatMessagesCodeExecutionModel) Save(ctx context.Context, chatMessagesCodeExecution ChatMessagesCodeExecutionN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, chatMessagesCodeExecution.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new chat_messages_code_execution or update it when it has a id > 0
+func (m *ChatMessagesCodeExecutionModel) SaveOrUpdate(ctx context.Context, chatMessagesCodeExecution ChatMessagesCodeExecutionN, onlyFields ...string) (id int64, updated bool, err error) {
+	if chatMessagesCodeExecution.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, chatMessagesCodeExecution.Id.Int64, chatMessagesCodeExecution, onlyFields...)
+		return chatMessagesCodeExecution.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, chatMessagesCodeExecution, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ChatMessagesCodeExecutionModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ChatMessagesCodeExecutionModel) Update(ctx context.Context, builder query.SQLBuilder, chatMessagesCodeExecution ChatMessagesCodeExecutionN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, chatMessagesCodeExecution.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ChatMessagesCodeExecutionModel) UpdateById(ctx context.Context, id int64, chatMessagesCodeExecution ChatMessagesCodeExecutionN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, chatMessagesCodeExecution.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ChatMessagesCodeExecutionModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ChatMessagesCodeExecutionModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
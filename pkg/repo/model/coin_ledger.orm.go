@@ -0,0 +1,831 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// CoinLedgerN is a CoinLedger object, all fields are nullable
+type CoinLedgerN struct {
+	original        *coinLedgerOriginal
+	coinLedgerModel *CoinLedgerModel
+
+	Id        null.Int    `json:"id"`
+	TxnId     null.String `json:"txn_id"`
+	Account   null.String `json:"account"`
+	OwnerId   null.Int    `json:"owner_id"`
+	Debit     null.Int    `json:"debit"`
+	Credit    null.Int    `json:"credit"`
+	Kind      null.String `json:"kind"`
+	Ref       null.String `json:"ref"`
+	Note      null.String `json:"note"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *CoinLedgerN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for CoinLedger
+func (inst *CoinLedgerN) SetModel(coinLedgerModel *CoinLedgerModel) {
+	inst.coinLedgerModel = coinLedgerModel
+}
+
+// coinLedgerOriginal is an object which stores original CoinLedger from database
+type coinLedgerOriginal struct {
+	Id        null.Int
+	TxnId     null.String
+	Account   null.String
+	OwnerId   null.Int
+	Debit     null.Int
+	Credit    null.Int
+	Kind      null.String
+	Ref       null.String
+	Note      null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *CoinLedgerN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &coinLedgerOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.TxnId != inst.original.TxnId {
+			return true
+		}
+		if inst.Account != inst.original.Account {
+			return true
+		}
+		if inst.OwnerId != inst.original.OwnerId {
+			return true
+		}
+		if inst.Debit != inst.original.Debit {
+			return true
+		}
+		if inst.Credit != inst.original.Credit {
+			return true
+		}
+		if inst.Kind != inst.original.Kind {
+			return true
+		}
+		if inst.Ref != inst.original.Ref {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "txn_id":
+				if inst.TxnId != inst.original.TxnId {
+					return true
+				}
+			case "account":
+				if inst.Account != inst.original.Account {
+					return true
+				}
+			case "owner_id":
+				if inst.OwnerId != inst.original.OwnerId {
+					return true
+				}
+			case "debit":
+				if inst.Debit != inst.original.Debit {
+					return true
+				}
+			case "credit":
+				if inst.Credit != inst.original.Credit {
+					return true
+				}
+			case "kind":
+				if inst.Kind != inst.original.Kind {
+					return true
+				}
+			case "ref":
+				if inst.Ref != inst.original.Ref {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *CoinLedgerN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &coinLedgerOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.TxnId != inst.original.TxnId {
+			kv["txn_id"] = inst.TxnId
+		}
+		if inst.Account != inst.original.Account {
+			kv["account"] = inst.Account
+		}
+		if inst.OwnerId != inst.original.OwnerId {
+			kv["owner_id"] = inst.OwnerId
+		}
+		if inst.Debit != inst.original.Debit {
+			kv["debit"] = inst.Debit
+		}
+		if inst.Credit != inst.original.Credit {
+			kv["credit"] = inst.Credit
+		}
+		if inst.Kind != inst.original.Kind {
+			kv["kind"] = inst.Kind
+		}
+		if inst.Ref != inst.original.Ref {
+			kv["ref"] = inst.Ref
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "txn_id":
+				if inst.TxnId != inst.original.TxnId {
+					kv["txn_id"] = inst.TxnId
+				}
+			case "account":
+				if inst.Account != inst.original.Account {
+					kv["account"] = inst.Account
+				}
+			case "owner_id":
+				if inst.OwnerId != inst.original.OwnerId {
+					kv["owner_id"] = inst.OwnerId
+				}
+			case "debit":
+				if inst.Debit != inst.original.Debit {
+					kv["debit"] = inst.Debit
+				}
+			case "credit":
+				if inst.Credit != inst.original.Credit {
+					kv["credit"] = inst.Credit
+				}
+			case "kind":
+				if inst.Kind != inst.original.Kind {
+					kv["kind"] = inst.Kind
+				}
+			case "ref":
+				if inst.Ref != inst.original.Ref {
+					kv["ref"] = inst.Ref
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *CoinLedgerN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.coinLedgerModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.coinLedgerModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a coin_ledger
+func (inst *CoinLedgerN) Delete(ctx context.Context) error {
+	if inst.coinLedgerModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.coinLedgerModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *CoinLedgerN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type coinLedgerScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var coinLedgerGlobalScopes = make([]coinLedgerScope, 0)
+var coinLedgerLocalScopes = make([]coinLedgerScope, 0)
+
+// AddGlobalScopeForCoinLedger assign a global scope to a model
+func AddGlobalScopeForCoinLedger(name string, apply func(builder query.Condition)) {
+	coinLedgerGlobalScopes = append(coinLedgerGlobalScopes, coinLedgerScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForCoinLedger assign a local scope to a model
+func AddLocalScopeForCoinLedger(name string, apply func(builder query.Condition)) {
+	coinLedgerLocalScopes = append(coinLedgerLocalScopes, coinLedgerScope{name: name, apply: apply})
+}
+
+func (m *CoinLedgerModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range coinLedgerGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range coinLedgerLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *CoinLedgerModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *CoinLedgerModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type CoinLedger struct {
+	Id        int64  `json:"id"`
+	TxnId     string `json:"txn_id"`
+	Account   string `json:"account"`
+	OwnerId   int64  `json:"owner_id"`
+	Debit     int64  `json:"debit"`
+	Credit    int64  `json:"credit"`
+	Kind      string `json:"kind"`
+	Ref       string `json:"ref"`
+	Note      string `json:"note"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w CoinLedger) ToCoinLedgerN(allows ...string) CoinLedgerN {
+	if len(allows) == 0 {
+		return CoinLedgerN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			TxnId:     null.StringFrom(w.TxnId),
+			Account:   null.StringFrom(w.Account),
+			OwnerId:   null.IntFrom(int64(w.OwnerId)),
+			Debit:     null.IntFrom(int64(w.Debit)),
+			Credit:    null.IntFrom(int64(w.Credit)),
+			Kind:      null.StringFrom(w.Kind),
+			Ref:       null.StringFrom(w.Ref),
+			Note:      null.StringFrom(w.Note),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := CoinLedgerN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "txn_id":
+			res.TxnId = null.StringFrom(w.TxnId)
+		case "account":
+			res.Account = null.StringFrom(w.Account)
+		case "owner_id":
+			res.OwnerId = null.IntFrom(int64(w.OwnerId))
+		case "debit":
+			res.Debit = null.IntFrom(int64(w.Debit))
+		case "credit":
+			res.Credit = null.IntFrom(int64(w.Credit))
+		case "kind":
+			res.Kind = null.StringFrom(w.Kind)
+		case "ref":
+			res.Ref = null.StringFrom(w.Ref)
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w CoinLedger) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *CoinLedgerN) ToCoinLedger() CoinLedger {
+	return CoinLedger{
+
+		Id:        w.Id.Int64,
+		TxnId:     w.TxnId.String,
+		Account:   w.Account.String,
+		OwnerId:   w.OwnerId.Int64,
+		Debit:     w.Debit.Int64,
+		Credit:    w.Credit.Int64,
+		Kind:      w.Kind.String,
+		Ref:       w.Ref.String,
+		Note:      w.Note.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// CoinLedgerModel is a model which encapsulates the operations of the object
+type CoinLedgerModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var coinLedgerTableName = "coin_ledger"
+
+// CoinLedgerTable return table name for CoinLedger
+func CoinLedgerTable() string {
+	return coinLedgerTableName
+}
+
+const (
+	FieldCoinLedgerId        = "id"
+	FieldCoinLedgerTxnId     = "txn_id"
+	FieldCoinLedgerAccount   = "account"
+	FieldCoinLedgerOwnerId   = "owner_id"
+	FieldCoinLedgerDebit     = "debit"
+	FieldCoinLedgerCredit    = "credit"
+	FieldCoinLedgerKind      = "kind"
+	FieldCoinLedgerRef       = "ref"
+	FieldCoinLedgerNote      = "note"
+	FieldCoinLedgerCreatedAt = "created_at"
+	FieldCoinLedgerUpdatedAt = "updated_at"
+)
+
+// CoinLedgerFields return all fields in CoinLedger model
+func CoinLedgerFields() []string {
+	return []string{
+		"id",
+		"txn_id",
+		"account",
+		"owner_id",
+		"debit",
+		"credit",
+		"kind",
+		"ref",
+		"note",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetCoinLedgerTable(tableName string) {
+	coinLedgerTableName = tableName
+}
+
+// NewCoinLedgerModel create a CoinLedgerModel
+func NewCoinLedgerModel(db query.Database) *CoinLedgerModel {
+	return &CoinLedgerModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           coinLedgerTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *CoinLedgerModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *CoinLedgerModel) clone() *CoinLedgerModel {
+	return &CoinLedgerModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *CoinLedgerModel) WithoutGlobalScopes(names ...string) *CoinLedgerModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *CoinLedgerModel) WithLocalScopes(names ...string) *CoinLedgerModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *CoinLedgerModel) Condition(builder query.SQLBuilder) *CoinLedgerModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *CoinLedgerModel) Find(ctx context.Context, id int64) (*CoinLedgerN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *CoinLedgerModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *CoinLedgerModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *CoinLedgerModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]CoinLedgerN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *CoinLedgerModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]CoinLedgerN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"txn_id",
+			"account",
+			"owner_id",
+			"debit",
+			"credit",
+			"kind",
+			"ref",
+			"note",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "txn_id":
+			selectFields = append(selectFields, f)
+		case "account":
+			selectFields = append(selectFields, f)
+		case "owner_id":
+			selectFields = append(selectFields, f)
+		case "debit":
+			selectFields = append(selectFields, f)
+		case "credit":
+			selectFields = append(selectFields, f)
+		case "kind":
+			selectFields = append(selectFields, f)
+		case "ref":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*CoinLedgerN, []interface{}) {
+		var coinLedgerVar CoinLedgerN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &coinLedgerVar.Id)
+			case "txn_id":
+				scanFields = append(scanFields, &coinLedgerVar.TxnId)
+			case "account":
+				scanFields = append(scanFields, &coinLedgerVar.Account)
+			case "owner_id":
+				scanFields = append(scanFields, &coinLedgerVar.OwnerId)
+			case "debit":
+				scanFields = append(scanFields, &coinLedgerVar.Debit)
+			case "credit":
+				scanFields = append(scanFields, &coinLedgerVar.Credit)
+			case "kind":
+				scanFields = append(scanFields, &coinLedgerVar.Kind)
+			case "ref":
+				scanFields = append(scanFields, &coinLedgerVar.Ref)
+			case "note":
+				scanFields = append(scanFields, &coinLedgerVar.Note)
+			case "created_at":
+				scanFields = append(scanFields, &coinLedgerVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &coinLedgerVar.UpdatedAt)
+			}
+		}
+
+		return &coinLedgerVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	coinLedgers := make([]CoinLedgerN, 0)
+	for rows.Next() {
+		coinLedgerReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		coinLedgerReal.original = &coinLedgerOriginal{}
+		_ = query.Copy(coinLedgerReal, coinLedgerReal.original)
+
+		coinLedgerReal.SetModel(m)
+		coinLedgers = append(coinLedgers, *coinLedgerReal)
+	}
+
+	return coinLedgers, nil
+}
+
+// First return first result for given query
+func (m *CoinLedgerModel) First(ctx context.Context, builders ...query.SQLBuilder) (*CoinLedgerN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new coin_ledger to database
+func (m *CoinLedgerModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all coin_ledgers to database
+func (m *CoinLedgerModel) SaveAll(ctx context.Context, coinLedgers []CoinLedgerN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, coinLedger := range coinLedgers {
+		id, err := m.Save(ctx, coinLedger)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a coin_ledger to database
+func (m *CoinLedgerModel) Save(ctx context.Context, coinLedger CoinLedgerN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, coinLedger.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new coin_ledger or update it when it has a id > 0
+func (m *CoinLedgerModel) SaveOrUpdate(ctx context.Context, coinLedger CoinLedgerN, onlyFields ...string) (id int64, updated bool, err error) {
+	if coinLedger.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, coinLedger.Id.Int64, coinLedger, onlyFields...)
+		return coinLedger.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, coinLedger, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *CoinLedgerModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *CoinLedgerModel) Update(ctx context.Context, builder query.SQLBuilder, coinLedger CoinLedgerN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, coinLedger.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *CoinLedgerModel) UpdateById(ctx context.Context, id int64, coinLedger CoinLedgerN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, coinLedger.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *CoinLedgerModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *CoinLedgerModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
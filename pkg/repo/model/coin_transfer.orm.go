@@ -0,0 +1,803 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// CoinTransferN is a CoinTransfer object, all fields are nullable
+type CoinTransferN struct {
+	original          *coinTransferOriginal
+	coinTransferModel *CoinTransferModel
+
+	Id           null.Int    `json:"id"`
+	FromUserId   null.Int    `json:"from_user_id"`
+	ToUserId     null.Int    `json:"to_user_id"`
+	Amount       null.Int    `json:"amount"`
+	Note         null.String `json:"note"`
+	FromQuotaIds null.String `json:"from_quota_ids"`
+	ToQuotaIds   null.String `json:"to_quota_ids"`
+	ClientIp     null.String `json:"client_ip"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *CoinTransferN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for CoinTransfer
+func (inst *CoinTransferN) SetModel(coinTransferModel *CoinTransferModel) {
+	inst.coinTransferModel = coinTransferModel
+}
+
+// coinTransferOriginal is an object which stores original CoinTransfer from database
+type coinTransferOriginal struct {
+	Id           null.Int
+	FromUserId   null.Int
+	ToUserId     null.Int
+	Amount       null.Int
+	Note         null.String
+	FromQuotaIds null.String
+	ToQuotaIds   null.String
+	ClientIp     null.String
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *CoinTransferN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &coinTransferOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.FromUserId != inst.original.FromUserId {
+			return true
+		}
+		if inst.ToUserId != inst.original.ToUserId {
+			return true
+		}
+		if inst.Amount != inst.original.Amount {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.FromQuotaIds != inst.original.FromQuotaIds {
+			return true
+		}
+		if inst.ToQuotaIds != inst.original.ToQuotaIds {
+			return true
+		}
+		if inst.ClientIp != inst.original.ClientIp {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "from_user_id":
+				if inst.FromUserId != inst.original.FromUserId {
+					return true
+				}
+			case "to_user_id":
+				if inst.ToUserId != inst.original.ToUserId {
+					return true
+				}
+			case "amount":
+				if inst.Amount != inst.original.Amount {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "from_quota_ids":
+				if inst.FromQuotaIds != inst.original.FromQuotaIds {
+					return true
+				}
+			case "to_quota_ids":
+				if inst.ToQuotaIds != inst.original.ToQuotaIds {
+					return true
+				}
+			case "client_ip":
+				if inst.ClientIp != inst.original.ClientIp {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *CoinTransferN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &coinTransferOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.FromUserId != inst.original.FromUserId {
+			kv["from_user_id"] = inst.FromUserId
+		}
+		if inst.ToUserId != inst.original.ToUserId {
+			kv["to_user_id"] = inst.ToUserId
+		}
+		if inst.Amount != inst.original.Amount {
+			kv["amount"] = inst.Amount
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.FromQuotaIds != inst.original.FromQuotaIds {
+			kv["from_quota_ids"] = inst.FromQuotaIds
+		}
+		if inst.ToQuotaIds != inst.original.ToQuotaIds {
+			kv["to_quota_ids"] = inst.ToQuotaIds
+		}
+		if inst.ClientIp != inst.original.ClientIp {
+			kv["client_ip"] = inst.ClientIp
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "from_user_id":
+				if inst.FromUserId != inst.original.FromUserId {
+					kv["from_user_id"] = inst.FromUserId
+				}
+			case "to_user_id":
+				if inst.ToUserId != inst.original.ToUserId {
+					kv["to_user_id"] = inst.ToUserId
+				}
+			case "amount":
+				if inst.Amount != inst.original.Amount {
+					kv["amount"] = inst.Amount
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "from_quota_ids":
+				if inst.FromQuotaIds != inst.original.FromQuotaIds {
+					kv["from_quota_ids"] = inst.FromQuotaIds
+				}
+			case "to_quota_ids":
+				if inst.ToQuotaIds != inst.original.ToQuotaIds {
+					kv["to_quota_ids"] = inst.ToQuotaIds
+				}
+			case "client_ip":
+				if inst.ClientIp != inst.original.ClientIp {
+					kv["client_ip"] = inst.ClientIp
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *CoinTransferN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.coinTransferModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.coinTransferModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a coin_transfer
+func (inst *CoinTransferN) Delete(ctx context.Context) error {
+	if inst.coinTransferModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.coinTransferModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *CoinTransferN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type coinTransferScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var coinTransferGlobalScopes = make([]coinTransferScope, 0)
+var coinTransferLocalScopes = make([]coinTransferScope, 0)
+
+// AddGlobalScopeForCoinTransfer assign a global scope to a model
+func AddGlobalScopeForCoinTransfer(name string, apply func(builder query.Condition)) {
+	coinTransferGlobalScopes = append(coinTransferGlobalScopes, coinTransferScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForCoinTransfer assign a local scope to a model
+func AddLocalScopeForCoinTransfer(name string, apply func(builder query.Condition)) {
+	coinTransferLocalScopes = append(coinTransferLocalScopes, coinTransferScope{name: name, apply: apply})
+}
+
+func (m *CoinTransferModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range coinTransferGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range coinTransferLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *CoinTransferModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *CoinTransferModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type CoinTransfer struct {
+	Id           int64  `json:"id"`
+	FromUserId   int64  `json:"from_user_id"`
+	ToUserId     int64  `json:"to_user_id"`
+	Amount       int64  `json:"amount"`
+	Note         string `json:"note"`
+	FromQuotaIds string `json:"from_quota_ids"`
+	ToQuotaIds   string `json:"to_quota_ids"`
+	ClientIp     string `json:"client_ip"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w CoinTransfer) ToCoinTransferN(allows ...string) CoinTransferN {
+	if len(allows) == 0 {
+		return CoinTransferN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			FromUserId:   null.IntFrom(int64(w.FromUserId)),
+			ToUserId:     null.IntFrom(int64(w.ToUserId)),
+			Amount:       null.IntFrom(int64(w.Amount)),
+			Note:         null.StringFrom(w.Note),
+			FromQuotaIds: null.StringFrom(w.FromQuotaIds),
+			ToQuotaIds:   null.StringFrom(w.ToQuotaIds),
+			ClientIp:     null.StringFrom(w.ClientIp),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := CoinTransferN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "from_user_id":
+			res.FromUserId = null.IntFrom(int64(w.FromUserId))
+		case "to_user_id":
+			res.ToUserId = null.IntFrom(int64(w.ToUserId))
+		case "amount":
+			res.Amount = null.IntFrom(int64(w.Amount))
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "from_quota_ids":
+			res.FromQuotaIds = null.StringFrom(w.FromQuotaIds)
+		case "to_quota_ids":
+			res.ToQuotaIds = null.StringFrom(w.ToQuotaIds)
+		case "client_ip":
+			res.ClientIp = null.StringFrom(w.ClientIp)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w CoinTransfer) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *CoinTransferN) ToCoinTransfer() CoinTransfer {
+	return CoinTransfer{
+
+		Id:           w.Id.Int64,
+		FromUserId:   w.FromUserId.Int64,
+		ToUserId:     w.ToUserId.Int64,
+		Amount:       w.Amount.Int64,
+		Note:         w.Note.String,
+		FromQuotaIds: w.FromQuotaIds.String,
+		ToQuotaIds:   w.ToQuotaIds.String,
+		ClientIp:     w.ClientIp.String,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// CoinTransferModel is a model which encapsulates the operations of the object
+type CoinTransferModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var coinTransferTableName = "coin_transfer"
+
+// CoinTransferTable return table name for CoinTransfer
+func CoinTransferTable() string {
+	return coinTransferTableName
+}
+
+const (
+	FieldCoinTransferId           = "id"
+	FieldCoinTransferFromUserId   = "from_user_id"
+	FieldCoinTransferToUserId     = "to_user_id"
+	FieldCoinTransferAmount       = "amount"
+	FieldCoinTransferNote         = "note"
+	FieldCoinTransferFromQuotaIds = "from_quota_ids"
+	FieldCoinTransferToQuotaIds   = "to_quota_ids"
+	FieldCoinTransferClientIp     = "client_ip"
+	FieldCoinTransferCreatedAt    = "created_at"
+	FieldCoinTransferUpdatedAt    = "updated_at"
+)
+
+// CoinTransferFields return all fields in CoinTransfer model
+func CoinTransferFields() []string {
+	return []string{
+		"id",
+		"from_user_id",
+		"to_user_id",
+		"amount",
+		"note",
+		"from_quota_ids",
+		"to_quota_ids",
+		"client_ip",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetCoinTransferTable(tableName string) {
+	coinTransferTableName = tableName
+}
+
+// NewCoinTransferModel create a CoinTransferModel
+func NewCoinTransferModel(db query.Database) *CoinTransferModel {
+	return &CoinTransferModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           coinTransferTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *CoinTransferModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *CoinTransferModel) clone() *CoinTransferModel {
+	return &CoinTransferModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *CoinTransferModel) WithoutGlobalScopes(names ...string) *CoinTransferModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *CoinTransferModel) WithLocalScopes(names ...string) *CoinTransferModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *CoinTransferModel) Condition(builder query.SQLBuilder) *CoinTransferModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *CoinTransferModel) Find(ctx context.Context, id int64) (*CoinTransferN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *CoinTransferModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *CoinTransferModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *CoinTransferModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]CoinTransferN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *CoinTransferModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]CoinTransferN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"from_user_id",
+			"to_user_id",
+			"amount",
+			"note",
+			"from_quota_ids",
+			"to_quota_ids",
+			"client_ip",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "from_user_id":
+			selectFields = append(selectFields, f)
+		case "to_user_id":
+			selectFields = append(selectFields, f)
+		case "amount":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "from_quota_ids":
+			selectFields = append(selectFields, f)
+		case "to_quota_ids":
+			selectFields = append(selectFields, f)
+		case "client_ip":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*CoinTransferN, []interface{}) {
+		var coinTransferVar CoinTransferN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &coinTransferVar.Id)
+			case "from_user_id":
+				scanFields = append(scanFields, &coinTransferVar.FromUserId)
+			case "to_user_id":
+				scanFields = append(scanFields, &coinTransferVar.ToUserId)
+			case "amount":
+				scanFields = append(scanFields, &coinTransferVar.Amount)
+			case "note":
+				scanFields = append(scanFields, &coinTransferVar.Note)
+			case "from_quota_ids":
+				scanFields = append(scanFields, &coinTransferVar.FromQuotaIds)
+			case "to_quota_ids":
+				scanFields = append(scanFields, &coinTransferVar.ToQuotaIds)
+			case "client_ip":
+				scanFields = append(scanFields, &coinTransferVar.ClientIp)
+			case "created_at":
+				scanFields = append(scanFields, &coinTransferVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &coinTransferVar.UpdatedAt)
+			}
+		}
+
+		return &coinTransferVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	coinTransfers := make([]CoinTransferN, 0)
+	for rows.Next() {
+		coinTransferReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		coinTransferReal.original = &coinTransferOriginal{}
+		_ = query.Copy(coinTransferReal, coinTransferReal.original)
+
+		coinTransferReal.SetModel(m)
+		coinTransfers = append(coinTransfers, *coinTransferReal)
+	}
+
+	return coinTransfers, nil
+}
+
+// First return first result for given query
+func (m *CoinTransferModel) First(ctx context.Context, builders ...query.SQLBuilder) (*CoinTransferN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new coin_transfer to database
+func (m *CoinTransferModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all coin_transfers to database
+func (m *CoinTransferModel) SaveAll(ctx context.Context, coinTransfers []CoinTransferN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, coinTransfer := range coinTransfers {
+		id, err := m.Save(ctx, coinTransfer)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a coin_transfer to database
+func (m *CoinTransferModel) Save(ctx context.Context, coinTransfer CoinTransferN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, coinTransfer.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new coin_transfer or update it when it has a id > 0
+func (m *CoinTransferModel) SaveOrUpdate(ctx context.Context, coinTransfer CoinTransferN, onlyFields ...string) (id int64, updated bool, err error) {
+	if coinTransfer.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, coinTransfer.Id.Int64, coinTransfer, onlyFields...)
+		return coinTransfer.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, coinTransfer, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *CoinTransferModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *CoinTransferModel) Update(ctx context.Context, builder query.SQLBuilder, coinTransfer CoinTransferN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, coinTransfer.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *CoinTransferModel) UpdateById(ctx context.Context, id int64, coinTransfer CoinTransferN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, coinTransfer.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *CoinTransferModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *CoinTransferModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
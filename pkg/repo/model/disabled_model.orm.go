@@ -0,0 +1,663 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// DisabledModelN is a DisabledModel object, all fields are nullable
+type DisabledModelN struct {
+	original           *disabledModelOriginal
+	disabledModelModel *DisabledModelModel
+
+	Id        null.Int    `json:"id"`
+	ModelId   null.String `json:"model_id"`
+	Reason    null.String `json:"reason,omitempty"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *DisabledModelN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for DisabledModel
+func (inst *DisabledModelN) SetModel(disabledModelModel *DisabledModelModel) {
+	inst.disabledModelModel = disabledModelModel
+}
+
+// disabledModelOriginal is an object which stores original DisabledModel from database
+type disabledModelOriginal struct {
+	Id        null.Int
+	ModelId   null.String
+	Reason    null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *DisabledModelN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &disabledModelOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.ModelId != inst.original.ModelId {
+			return true
+		}
+		if inst.Reason != inst.original.Reason {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "model_id":
+				if inst.ModelId != inst.original.ModelId {
+					return true
+				}
+			case "reason":
+				if inst.Reason != inst.original.Reason {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *DisabledModelN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &disabledModelOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.ModelId != inst.original.ModelId {
+			kv["model_id"] = inst.ModelId
+		}
+		if inst.Reason != inst.original.Reason {
+			kv["reason"] = inst.Reason
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "model_id":
+				if inst.ModelId != inst.original.ModelId {
+					kv["model_id"] = inst.ModelId
+				}
+			case "reason":
+				if inst.Reason != inst.original.Reason {
+					kv["reason"] = inst.Reason
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *DisabledModelN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.disabledModelModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.disabledModelModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a disabled_model
+func (inst *DisabledModelN) Delete(ctx context.Context) error {
+	if inst.disabledModelModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.disabledModelModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *DisabledModelN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type disabledModelScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var disabledModelGlobalScopes = make([]disabledModelScope, 0)
+var disabledModelLocalScopes = make([]disabledModelScope, 0)
+
+// AddGlobalScopeForDisabledModel assign a global scope to a model
+func AddGlobalScopeForDisabledModel(name string, apply func(builder query.Condition)) {
+	disabledModelGlobalScopes = append(disabledModelGlobalScopes, disabledModelScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForDisabledModel assign a local scope to a model
+func AddLocalScopeForDisabledModel(name string, apply func(builder query.Condition)) {
+	disabledModelLocalScopes = append(disabledModelLocalScopes, disabledModelScope{name: name, apply: apply})
+}
+
+func (m *DisabledModelModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range disabledModelGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range disabledModelLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *DisabledModelModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *DisabledModelModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type DisabledModel struct {
+	Id        int64  `json:"id"`
+	ModelId   string `json:"model_id"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w DisabledModel) ToDisabledModelN(allows ...string) DisabledModelN {
+	if len(allows) == 0 {
+		return DisabledModelN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			ModelId:   null.StringFrom(w.ModelId),
+			Reason:    null.StringFrom(w.Reason),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := DisabledModelN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "model_id":
+			res.ModelId = null.StringFrom(w.ModelId)
+		case "reason":
+			res.Reason = null.StringFrom(w.Reason)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w DisabledModel) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *DisabledModelN) ToDisabledModel() DisabledModel {
+	return DisabledModel{
+
+		Id:        w.Id.Int64,
+		ModelId:   w.ModelId.String,
+		Reason:    w.Reason.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// DisabledModelModel is a model which encapsulates the operations of the object
+type DisabledModelModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var disabledModelTableName = "disabled_model"
+
+// DisabledModelTable return table name for DisabledModel
+func DisabledModelTable() string {
+	return disabledModelTableName
+}
+
+const (
+	FieldDisabledModelId        = "id"
+	FieldDisabledModelModelId   = "model_id"
+	FieldDisabledModelReason    = "reason"
+	FieldDisabledModelCreatedAt = "created_at"
+	FieldDisabledModelUpdatedAt = "updated_at"
+)
+
+// DisabledModelFields return all fields in DisabledModel model
+func DisabledModelFields() []string {
+	return []string{
+		"id",
+		"model_id",
+		"reason",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetDisabledModelTable(tableName string) {
+	disabledModelTableName = tableName
+}
+
+// NewDisabledModelModel create a DisabledModelModel
+func NewDisabledModelModel(db query.Database) *DisabledModelModel {
+	return &DisabledModelModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           disabledModelTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *DisabledModelModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *DisabledModelModel) clone() *DisabledModelModel {
+	return &DisabledModelModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *DisabledModelModel) WithoutGlobalScopes(names ...string) *DisabledModelModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *DisabledModelModel) WithLocalScopes(names ...string) *DisabledModelModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *DisabledModelModel) Condition(builder query.SQLBuilder) *DisabledModelModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *DisabledModelModel) Find(ctx context.Context, id int64) (*DisabledModelN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *DisabledModelModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *DisabledModelModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *DisabledModelModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]DisabledModelN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *DisabledModelModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]DisabledModelN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"model_id",
+			"reason",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "model_id":
+			selectFields = append(selectFields, f)
+		case "reason":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*DisabledModelN, []interface{}) {
+		var disabledModelVar DisabledModelN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &disabledModelVar.Id)
+			case "model_id":
+				scanFields = append(scanFields, &disabledModelVar.ModelId)
+			case "reason":
+				scanFields = append(scanFields, &disabledModelVar.Reason)
+			case "created_at":
+				scanFields = append(scanFields, &disabledModelVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &disabledModelVar.UpdatedAt)
+			}
+		}
+
+		return &disabledModelVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	disabledModels := make([]DisabledModelN, 0)
+	for rows.Next() {
+		disabledModelReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		disabledModelReal.original = &disabledModelOriginal{}
+		_ = query.Copy(disabledModelReal, disabledModelReal.original)
+
+		disabledModelReal.SetModel(m)
+		disabledModels = append(disabledModels, *disabledModelReal)
+	}
+
+	return disabledModels, nil
+}
+
+// First return first result for given query
+func (m *DisabledModelModel) First(ctx context.Context, builders ...query.SQLBuilder) (*DisabledModelN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new disabled_model to database
+func (m *DisabledModelModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all disabled_models to database
+func (m *DisabledModelModel) SaveAll(ctx context.Context, disabledModels []DisabledModelN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, disabledModel := range disabledModels {
+		id, err := m.Save(ctx, disabledModel)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a disabled_model to database
+func (m *DisabledModelModel) Save(ctx context.Context, disabledModel DisabledModelN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, disabledModel.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new disabled_model or update it when it has a id > 0
+func (m *DisabledModelModel) SaveOrUpdate(ctx context.Context, disabledModel DisabledModelN, onlyFields ...string) (id int64, updated bool, err error) {
+	if disabledModel.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, disabledModel.Id.Int64, disabledModel, onlyFields...)
+		return disabledModel.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, disabledModel, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *DisabledModelModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *DisabledModelModel) Update(ctx context.Context, builder query.SQLBuilder, disabledModel DisabledModelN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, disabledModel.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *DisabledModelModel) UpdateById(ctx context.Context, id int64, disabledModel DisabledModelN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, disabledModel.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *DisabledModelModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *DisabledModelModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
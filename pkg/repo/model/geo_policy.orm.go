@@ -0,0 +1,719 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// GeoPolicyN is a GeoPolicy object, all fields are nullable
+type GeoPolicyN struct {
+	original       *geoPolicyOriginal
+	geoPolicyModel *GeoPolicyModel
+
+	Id              null.Int    `json:"id"`
+	Region          null.String `json:"region"`
+	BlockedFeatures null.String `json:"blocked_features"`
+	ModelRoutes     null.String `json:"model_routes"`
+	Note            null.String `json:"note,omitempty"`
+	CreatedAt       null.Time
+	UpdatedAt       null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *GeoPolicyN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for GeoPolicy
+func (inst *GeoPolicyN) SetModel(geoPolicyModel *GeoPolicyModel) {
+	inst.geoPolicyModel = geoPolicyModel
+}
+
+// geoPolicyOriginal is an object which stores original GeoPolicy from database
+type geoPolicyOriginal struct {
+	Id              null.Int
+	Region          null.String
+	BlockedFeatures null.String
+	ModelRoutes     null.String
+	Note            null.String
+	CreatedAt       null.Time
+	UpdatedAt       null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *GeoPolicyN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &geoPolicyOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Region != inst.original.Region {
+			return true
+		}
+		if inst.BlockedFeatures != inst.original.BlockedFeatures {
+			return true
+		}
+		if inst.ModelRoutes != inst.original.ModelRoutes {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "region":
+				if inst.Region != inst.original.Region {
+					return true
+				}
+			case "blocked_features":
+				if inst.BlockedFeatures != inst.original.BlockedFeatures {
+					return true
+				}
+			case "model_routes":
+				if inst.ModelRoutes != inst.original.ModelRoutes {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *GeoPolicyN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &geoPolicyOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Region != inst.original.Region {
+			kv["region"] = inst.Region
+		}
+		if inst.BlockedFeatures != inst.original.BlockedFeatures {
+			kv["blocked_features"] = inst.BlockedFeatures
+		}
+		if inst.ModelRoutes != inst.original.ModelRoutes {
+			kv["model_routes"] = inst.ModelRoutes
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "region":
+				if inst.Region != inst.original.Region {
+					kv["region"] = inst.Region
+				}
+			case "blocked_features":
+				if inst.BlockedFeatures != inst.original.BlockedFeatures {
+					kv["blocked_features"] = inst.BlockedFeatures
+				}
+			case "model_routes":
+				if inst.ModelRoutes != inst.original.ModelRoutes {
+					kv["model_routes"] = inst.ModelRoutes
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *GeoPolicyN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.geoPolicyModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.geoPolicyModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a geo_policy
+func (inst *GeoPolicyN) Delete(ctx context.Context) error {
+	if inst.geoPolicyModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.geoPolicyModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *GeoPolicyN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type geoPolicyScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var geoPolicyGlobalScopes = make([]geoPolicyScope, 0)
+var geoPolicyLocalScopes = make([]geoPolicyScope, 0)
+
+// AddGlobalScopeForGeoPolicy assign a global scope to a model
+func AddGlobalScopeForGeoPolicy(name string, apply func(builder query.Condition)) {
+	geoPolicyGlobalScopes = append(geoPolicyGlobalScopes, geoPolicyScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForGeoPolicy assign a local scope to a model
+func AddLocalScopeForGeoPolicy(name string, apply func(builder query.Condition)) {
+	geoPolicyLocalScopes = append(geoPolicyLocalScopes, geoPolicyScope{name: name, apply: apply})
+}
+
+func (m *GeoPolicyModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range geoPolicyGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range geoPolicyLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *GeoPolicyModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *GeoPolicyModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type GeoPolicy struct {
+	Id              int64  `json:"id"`
+	Region          string `json:"region"`
+	BlockedFeatures string `json:"blocked_features"`
+	ModelRoutes     string `json:"model_routes"`
+	Note            string `json:"note,omitempty"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (w GeoPolicy) ToGeoPolicyN(allows ...string) GeoPolicyN {
+	if len(allows) == 0 {
+		return GeoPolicyN{
+
+			Id:              null.IntFrom(int64(w.Id)),
+			Region:          null.StringFrom(w.Region),
+			BlockedFeatures: null.StringFrom(w.BlockedFeatures),
+			ModelRoutes:     null.StringFrom(w.ModelRoutes),
+			Note:            null.StringFrom(w.Note),
+			CreatedAt:       null.TimeFrom(w.CreatedAt),
+			UpdatedAt:       null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := GeoPolicyN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "region":
+			res.Region = null.StringFrom(w.Region)
+		case "blocked_features":
+			res.BlockedFeatures = null.StringFrom(w.BlockedFeatures)
+		case "model_routes":
+			res.ModelRoutes = null.StringFrom(w.ModelRoutes)
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w GeoPolicy) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *GeoPolicyN) ToGeoPolicy() GeoPolicy {
+	return GeoPolicy{
+
+		Id:              w.Id.Int64,
+		Region:          w.Region.String,
+		BlockedFeatures: w.BlockedFeatures.String,
+		ModelRoutes:     w.ModelRoutes.String,
+		Note:            w.Note.String,
+		CreatedAt:       w.CreatedAt.Time,
+		UpdatedAt:       w.UpdatedAt.Time,
+	}
+}
+
+// GeoPolicyModel is a model which encapsulates the operations of the object
+type GeoPolicyModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var geoPolicyTableName = "geo_policy"
+
+// GeoPolicyTable return table name for GeoPolicy
+func GeoPolicyTable() string {
+	return geoPolicyTableName
+}
+
+const (
+	FieldGeoPolicyId              = "id"
+	FieldGeoPolicyRegion          = "region"
+	FieldGeoPolicyBlockedFeatures = "blocked_features"
+	FieldGeoPolicyModelRoutes     = "model_routes"
+	FieldGeoPolicyNote            = "note"
+	FieldGeoPolicyCreatedAt       = "created_at"
+	FieldGeoPolicyUpdatedAt       = "updated_at"
+)
+
+// GeoPolicyFields return all fields in GeoPolicy model
+func GeoPolicyFields() []string {
+	return []string{
+		"id",
+		"region",
+		"blocked_features",
+		"model_routes",
+		"note",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetGeoPolicyTable(tableName string) {
+	geoPolicyTableName = tableName
+}
+
+// NewGeoPolicyModel create a GeoPolicyModel
+func NewGeoPolicyModel(db query.Database) *GeoPolicyModel {
+	return &GeoPolicyModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           geoPolicyTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *GeoPolicyModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *GeoPolicyModel) clone() *GeoPolicyModel {
+	return &GeoPolicyModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *GeoPolicyModel) WithoutGlobalScopes(names ...string) *GeoPolicyModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *GeoPolicyModel) WithLocalScopes(names ...string) *GeoPolicyModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *GeoPolicyModel) Condition(builder query.SQLBuilder) *GeoPolicyModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *GeoPolicyModel) Find(ctx context.Context, id int64) (*GeoPolicyN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *GeoPolicyModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *GeoPolicyModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *GeoPolicyModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]GeoPolicyN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *GeoPolicyModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]GeoPolicyN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"region",
+			"blocked_features",
+			"model_routes",
+			"note",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "region":
+			selectFields = append(selectFields, f)
+		case "blocked_features":
+			selectFields = append(selectFields, f)
+		case "model_routes":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*GeoPolicyN, []interface{}) {
+		var geoPolicyVar GeoPolicyN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &geoPolicyVar.Id)
+			case "region":
+				scanFields = append(scanFields, &geoPolicyVar.Region)
+			case "blocked_features":
+				scanFields = append(scanFields, &geoPolicyVar.BlockedFeatures)
+			case "model_routes":
+				scanFields = append(scanFields, &geoPolicyVar.ModelRoutes)
+			case "note":
+				scanFields = append(scanFields, &geoPolicyVar.Note)
+			case "created_at":
+				scanFields = append(scanFields, &geoPolicyVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &geoPolicyVar.UpdatedAt)
+			}
+		}
+
+		return &geoPolicyVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	geoPolicys := make([]GeoPolicyN, 0)
+	for rows.Next() {
+		geoPolicyReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		geoPolicyReal.original = &geoPolicyOriginal{}
+		_ = query.Copy(geoPolicyReal, geoPolicyReal.original)
+
+		geoPolicyReal.SetModel(m)
+		geoPolicys = append(geoPolicys, *geoPolicyReal)
+	}
+
+	return geoPolicys, nil
+}
+
+// First return first result for given query
+func (m *GeoPolicyModel) First(ctx context.Context, builders ...query.SQLBuilder) (*GeoPolicyN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new geo_policy to database
+func (m *GeoPolicyModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all geo_policys to database
+func (m *GeoPolicyModel) SaveAll(ctx context.Context, geoPolicys []GeoPolicyN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, geoPolicy := range geoPolicys {
+		id, err := m.Save(ctx, geoPolicy)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a geo_policy to database
+func (m *GeoPolicyModel) Save(ctx context.Context, geoPolicy GeoPolicyN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, geoPolicy.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new geo_policy or update it when it has a id > 0
+func (m *GeoPolicyModel) SaveOrUpdate(ctx context.Context, geoPolicy GeoPolicyN, onlyFields ...string) (id int64, updated bool, err error) {
+	if geoPolicy.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, geoPolicy.Id.Int64, geoPolicy, onlyFields...)
+		return geoPolicy.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, geoPolicy, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *GeoPolicyModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *GeoPolicyModel) Update(ctx context.Context, builder query.SQLBuilder, geoPolicy GeoPolicyN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, geoPolicy.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *GeoPolicyModel) UpdateById(ctx context.Context, id int64, geoPolicy GeoPolicyN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, geoPolicy.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *GeoPolicyModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *GeoPolicyModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
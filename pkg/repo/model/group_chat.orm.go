@@ -13,6 +13,11 @@ import (
 
 func init() {
 
+	// AddChatGroupMessageGlobalScope assign a global scope to a model for soft delete
+	AddGlobalScopeForChatGroupMessage("soft_delete", func(builder query.Condition) {
+		builder.WhereNull("deleted_at")
+	})
+
 }
 
 // ChatGroupMemberN is a ChatGroupMember object, all fields are nullable
@@ -762,8 +767,12 @@ type ChatGroupMessageN struct {
 	MemberId      null.Int    `json:"member_id,omitempty"`
 	Status        null.Int    `json:"status,omitempty"`
 	Error         null.String `json:"error,omitempty"`
+	Score         null.Int    `json:"score,omitempty"`
+	Best          null.Int    `json:"best,omitempty"`
+	JudgeStatus   null.Int    `json:"judge_status,omitempty"`
 	CreatedAt     null.Time
 	UpdatedAt     null.Time
+	DeletedAt     null.Time
 }
 
 // As convert object to other type
@@ -790,8 +799,12 @@ type chatGroupMessageOriginal struct {
 	MemberId      null.Int
 	Status        null.Int
 	Error         null.String
+	Score         null.Int
+	Best          null.Int
+	JudgeStatus   null.Int
 	CreatedAt     null.Time
 	UpdatedAt     null.Time
+	DeletedAt     null.Time
 }
 
 // Staled identify whether the object has been modified
@@ -835,12 +848,24 @@ func (inst *ChatGroupMessageN) Staled(onlyFields ...string) bool {
 		if inst.Error != inst.original.Error {
 			return true
 		}
+		if inst.Score != inst.original.Score {
+			return true
+		}
+		if inst.Best != inst.original.Best {
+			return true
+		}
+		if inst.JudgeStatus != inst.original.JudgeStatus {
+			return true
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			return true
 		}
 		if inst.UpdatedAt != inst.original.UpdatedAt {
 			return true
 		}
+		if inst.DeletedAt != inst.original.DeletedAt {
+			return true
+		}
 	} else {
 		for _, f := range onlyFields {
 			switch strcase.ToSnake(f) {
@@ -889,6 +914,18 @@ func (inst *ChatGroupMessageN) Staled(onlyFields ...string) bool {
 				if inst.Error != inst.original.Error {
 					return true
 				}
+			case "score":
+				if inst.Score != inst.original.Score {
+					return true
+				}
+			case "best":
+				if inst.Best != inst.original.Best {
+					return true
+				}
+			case "judge_status":
+				if inst.JudgeStatus != inst.original.JudgeStatus {
+					return true
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					return true
@@ -897,6 +934,10 @@ func (inst *ChatGroupMessageN) Staled(onlyFields ...string) bool {
 				if inst.UpdatedAt != inst.original.UpdatedAt {
 					return true
 				}
+			case "deleted_at":
+				if inst.DeletedAt != inst.original.DeletedAt {
+					return true
+				}
 			default:
 			}
 		}
@@ -948,12 +989,24 @@ func (inst *ChatGroupMessageN) StaledKV(onlyFields ...string) query.KV {
 		if inst.Error != inst.original.Error {
 			kv["error"] = inst.Error
 		}
+		if inst.Score != inst.original.Score {
+			kv["score"] = inst.Score
+		}
+		if inst.Best != inst.original.Best {
+			kv["best"] = inst.Best
+		}
+		if inst.JudgeStatus != inst.original.JudgeStatus {
+			kv["judge_status"] = inst.JudgeStatus
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			kv["created_at"] = inst.CreatedAt
 		}
 		if inst.UpdatedAt != inst.original.UpdatedAt {
 			kv["updated_at"] = inst.UpdatedAt
 		}
+		if inst.DeletedAt != inst.original.DeletedAt {
+			kv["deleted_at"] = inst.DeletedAt
+		}
 	} else {
 		for _, f := range onlyFields {
 			switch strcase.ToSnake(f) {
@@ -1002,6 +1055,18 @@ func (inst *ChatGroupMessageN) StaledKV(onlyFields ...string) query.KV {
 				if inst.Error != inst.original.Error {
 					kv["error"] = inst.Error
 				}
+			case "score":
+				if inst.Score != inst.original.Score {
+					kv["score"] = inst.Score
+				}
+			case "best":
+				if inst.Best != inst.original.Best {
+					kv["best"] = inst.Best
+				}
+			case "judge_status":
+				if inst.JudgeStatus != inst.original.JudgeStatus {
+					kv["judge_status"] = inst.JudgeStatus
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					kv["created_at"] = inst.CreatedAt
@@ -1010,6 +1075,10 @@ func (inst *ChatGroupMessageN) StaledKV(onlyFields ...string) query.KV {
 				if inst.UpdatedAt != inst.original.UpdatedAt {
 					kv["updated_at"] = inst.UpdatedAt
 				}
+			case "deleted_at":
+				if inst.DeletedAt != inst.original.DeletedAt {
+					kv["deleted_at"] = inst.DeletedAt
+				}
 			default:
 			}
 		}
@@ -1120,8 +1189,12 @@ type ChatGroupMessage struct {
 	MemberId      int64  `json:"member_id,omitempty"`
 	Status        int64  `json:"status,omitempty"`
 	Error         string `json:"error,omitempty"`
+	Score         int64  `json:"score,omitempty"`
+	Best          int64  `json:"best,omitempty"`
+	JudgeStatus   int64  `json:"judge_status,omitempty"`
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	DeletedAt     time.Time
 }
 
 func (w ChatGroupMessage) ToChatGroupMessageN(allows ...string) ChatGroupMessageN {
@@ -1139,8 +1212,12 @@ func (w ChatGroupMessage) ToChatGroupMessageN(allows ...string) ChatGroupMessage
 			MemberId:      null.IntFrom(int64(w.MemberId)),
 			Status:        null.IntFrom(int64(w.Status)),
 			Error:         null.StringFrom(w.Error),
+			Score:         null.IntFrom(int64(w.Score)),
+			Best:          null.IntFrom(int64(w.Best)),
+			JudgeStatus:   null.IntFrom(int64(w.JudgeStatus)),
 			CreatedAt:     null.TimeFrom(w.CreatedAt),
 			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+			DeletedAt:     null.TimeFrom(w.DeletedAt),
 		}
 	}
 
@@ -1170,10 +1247,18 @@ func (w ChatGroupMessage) ToChatGroupMessageN(allows ...string) ChatGroupMessage
 			res.Status = null.IntFrom(int64(w.Status))
 		case "error":
 			res.Error = null.StringFrom(w.Error)
+		case "score":
+			res.Score = null.IntFrom(int64(w.Score))
+		case "best":
+			res.Best = null.IntFrom(int64(w.Best))
+		case "judge_status":
+			res.JudgeStatus = null.IntFrom(int64(w.JudgeStatus))
 		case "created_at":
 			res.CreatedAt = null.TimeFrom(w.CreatedAt)
 		case "updated_at":
 			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		case "deleted_at":
+			res.DeletedAt = null.TimeFrom(w.DeletedAt)
 		default:
 		}
 	}
@@ -1201,8 +1286,12 @@ func (w *ChatGroupMessageN) ToChatGroupMessage() ChatGroupMessage {
 		MemberId:      w.MemberId.Int64,
 		Status:        w.Status.Int64,
 		Error:         w.Error.String,
+		Score:         w.Score.Int64,
+		Best:          w.Best.Int64,
+		JudgeStatus:   w.JudgeStatus.Int64,
 		CreatedAt:     w.CreatedAt.Time,
 		UpdatedAt:     w.UpdatedAt.Time,
+		DeletedAt:     w.DeletedAt.Time,
 	}
 }
 
@@ -1236,8 +1325,12 @@ const (
 	FieldChatGroupMessageMemberId      = "member_id"
 	FieldChatGroupMessageStatus        = "status"
 	FieldChatGroupMessageError         = "error"
+	FieldChatGroupMessageScore         = "score"
+	FieldChatGroupMessageBest          = "best"
+	FieldChatGroupMessageJudgeStatus   = "judge_status"
 	FieldChatGroupMessageCreatedAt     = "created_at"
 	FieldChatGroupMessageUpdatedAt     = "updated_at"
+	FieldChatGroupMessageDeletedAt     = "deleted_at"
 )
 
 // ChatGroupMessageFields return all fields in ChatGroupMessage model
@@ -1254,8 +1347,12 @@ func ChatGroupMessageFields() []string {
 		"member_id",
 		"status",
 		"error",
+		"score",
+		"best",
+		"judge_status",
 		"created_at",
 		"updated_at",
+		"deleted_at",
 	}
 }
 
@@ -1279,6 +1376,11 @@ func (m *ChatGroupMessageModel) GetDB() query.Database {
 	return m.db.GetDB()
 }
 
+// WithTrashed force soft deleted models to appear in a result set
+func (m *ChatGroupMessageModel) WithTrashed() *ChatGroupMessageModel {
+	return m.WithoutGlobalScopes("soft_delete")
+}
+
 func (m *ChatGroupMessageModel) clone() *ChatGroupMessageModel {
 	return &ChatGroupMessageModel{
 		db:                  m.db,
@@ -1397,8 +1499,12 @@ func (m *ChatGroupMessageModel) Get(ctx context.Context, builders ...query.SQLBu
 			"member_id",
 			"status",
 			"error",
+			"score",
+			"best",
+			"judge_status",
 			"created_at",
 			"updated_at",
+			"deleted_at",
 		)
 	}
 
@@ -1430,10 +1536,18 @@ func (m *ChatGroupMessageModel) Get(ctx context.Context, builders ...query.SQLBu
 			selectFields = append(selectFields, f)
 		case "error":
 			selectFields = append(selectFields, f)
+		case "score":
+			selectFields = append(selectFields, f)
+		case "best":
+			selectFields = append(selectFields, f)
+		case "judge_status":
+			selectFields = append(selectFields, f)
 		case "created_at":
 			selectFields = append(selectFields, f)
 		case "updated_at":
 			selectFields = append(selectFields, f)
+		case "deleted_at":
+			selectFields = append(selectFields, f)
 		}
 	}
 
@@ -1466,10 +1580,18 @@ func (m *ChatGroupMessageModel) Get(ctx context.Context, builders ...query.SQLBu
 				scanFields = append(scanFields, &chatGroupMessageVar.Status)
 			case "error":
 				scanFields = append(scanFields, &chatGroupMessageVar.Error)
+			case "score":
+				scanFields = append(scanFields, &chatGroupMessageVar.Score)
+			case "best":
+				scanFields = append(scanFields, &chatGroupMessageVar.Best)
+			case "judge_status":
+				scanFields = append(scanFields, &chatGroupMessageVar.JudgeStatus)
 			case "created_at":
 				scanFields = append(scanFields, &chatGroupMessageVar.CreatedAt)
 			case "updated_at":
 				scanFields = append(scanFields, &chatGroupMessageVar.UpdatedAt)
+			case "deleted_at":
+				scanFields = append(scanFields, &chatGroupMessageVar.DeletedAt)
 			}
 		}
 
@@ -1598,17 +1720,44 @@ func (m *ChatGroupMessageModel) UpdateById(ctx context.Context, id int64, chatGr
 	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, chatGroupMessage.StaledKV(onlyFields...))
 }
 
-// Delete remove a model
-func (m *ChatGroupMessageModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+// ForceDelete permanently remove a soft deleted model from the database
+func (m *ChatGroupMessageModel) ForceDelete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	m2 := m.WithTrashed()
 
-	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+	sqlStr, params := m2.query.Merge(builders...).AppendCondition(m2.applyScope()).Table(m2.tableName).ResolveDelete()
 
-	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	res, err := m2.db.ExecContext(ctx, sqlStr, params...)
 	if err != nil {
 		return 0, err
 	}
 
 	return res.RowsAffected()
+}
+
+// ForceDeleteById permanently remove a soft deleted model from the database by id
+func (m *ChatGroupMessageModel) ForceDeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).ForceDelete(ctx)
+}
+
+// Restore restore a soft deleted model into an active state
+func (m *ChatGroupMessageModel) Restore(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	m2 := m.WithTrashed()
+	return m2.UpdateFields(ctx, query.KV{
+		"deleted_at": nil,
+	}, builders...)
+}
+
+// RestoreById restore a soft deleted model into an active state by id
+func (m *ChatGroupMessageModel) RestoreById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Restore(ctx)
+}
+
+// Delete remove a model
+func (m *ChatGroupMessageModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	return m.UpdateFields(ctx, query.KV{
+		"deleted_at": time.Now(),
+	}, builders...)
 
 }
 
@@ -0,0 +1,775 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ChatGroupCompositionN is a ChatGroupComposition object, all fields are nullable
+type ChatGroupCompositionN struct {
+	original                  *chatGroupCompositionOriginal
+	chatGroupCompositionModel *ChatGroupCompositionModel
+
+	Id          null.Int    `json:"id"`
+	Name        null.String `json:"name"`
+	Description null.String `json:"description,omitempty"`
+	AvatarUrl   null.String `json:"avatar_url,omitempty"`
+	Members     null.String `json:"members"`
+	Sort        null.Int    `json:"sort"`
+	Status      null.Int    `json:"status"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ChatGroupCompositionN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ChatGroupComposition
+func (inst *ChatGroupCompositionN) SetModel(chatGroupCompositionModel *ChatGroupCompositionModel) {
+	inst.chatGroupCompositionModel = chatGroupCompositionModel
+}
+
+// chatGroupCompositionOriginal is an object which stores original ChatGroupComposition from database
+type chatGroupCompositionOriginal struct {
+	Id          null.Int
+	Name        null.String
+	Description null.String
+	AvatarUrl   null.String
+	Members     null.String
+	Sort        null.Int
+	Status      null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ChatGroupCompositionN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &chatGroupCompositionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Description != inst.original.Description {
+			return true
+		}
+		if inst.AvatarUrl != inst.original.AvatarUrl {
+			return true
+		}
+		if inst.Members != inst.original.Members {
+			return true
+		}
+		if inst.Sort != inst.original.Sort {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					return true
+				}
+			case "avatar_url":
+				if inst.AvatarUrl != inst.original.AvatarUrl {
+					return true
+				}
+			case "members":
+				if inst.Members != inst.original.Members {
+					return true
+				}
+			case "sort":
+				if inst.Sort != inst.original.Sort {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ChatGroupCompositionN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &chatGroupCompositionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Description != inst.original.Description {
+			kv["description"] = inst.Description
+		}
+		if inst.AvatarUrl != inst.original.AvatarUrl {
+			kv["avatar_url"] = inst.AvatarUrl
+		}
+		if inst.Members != inst.original.Members {
+			kv["members"] = inst.Members
+		}
+		if inst.Sort != inst.original.Sort {
+			kv["sort"] = inst.Sort
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					kv["description"] = inst.Description
+				}
+			case "avatar_url":
+				if inst.AvatarUrl != inst.original.AvatarUrl {
+					kv["avatar_url"] = inst.AvatarUrl
+				}
+			case "members":
+				if inst.Members != inst.original.Members {
+					kv["members"] = inst.Members
+				}
+			case "sort":
+				if inst.Sort != inst.original.Sort {
+					kv["sort"] = inst.Sort
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ChatGroupCompositionN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.chatGroupCompositionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.chatGroupCompositionModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a chat_group_composition
+func (inst *ChatGroupCompositionN) Delete(ctx context.Context) error {
+	if inst.chatGroupCompositionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.chatGroupCompositionModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ChatGroupCompositionN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type chatGroupCompositionScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var chatGroupCompositionGlobalScopes = make([]chatGroupCompositionScope, 0)
+var chatGroupCompositionLocalScopes = make([]chatGroupCompositionScope, 0)
+
+// AddGlobalScopeForChatGroupComposition assign a global scope to a model
+func AddGlobalScopeForChatGroupComposition(name string, apply func(builder query.Condition)) {
+	chatGroupCompositionGlobalScopes = append(chatGroupCompositionGlobalScopes, chatGroupCompositionScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForChatGroupComposition assign a local scope to a model
+func AddLocalScopeForChatGroupComposition(name string, apply func(builder query.Condition)) {
+	chatGroupCompositionLocalScopes = append(chatGroupCompositionLocalScopes, chatGroupCompositionScope{name: name, apply: apply})
+}
+
+func (m *ChatGroupCompositionModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range chatGroupCompositionGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range chatGroupCompositionLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ChatGroupCompositionModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ChatGroupCompositionModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ChatGroupComposition struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	AvatarUrl   string `json:"avatar_url,omitempty"`
+	Members     string `json:"members"`
+	Sort        int64  `json:"sort"`
+	Status      int64  `json:"status"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w ChatGroupComposition) ToChatGroupCompositionN(allows ...string) ChatGroupCompositionN {
+	if len(allows) == 0 {
+		return ChatGroupCompositionN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			Name:        null.StringFrom(w.Name),
+			Description: null.StringFrom(w.Description),
+			AvatarUrl:   null.StringFrom(w.AvatarUrl),
+			Members:     null.StringFrom(w.Members),
+			Sort:        null.IntFrom(int64(w.Sort)),
+			Status:      null.IntFrom(int64(w.Status)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ChatGroupCompositionN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "description":
+			res.Description = null.StringFrom(w.Description)
+		case "avatar_url":
+			res.AvatarUrl = null.StringFrom(w.AvatarUrl)
+		case "members":
+			res.Members = null.StringFrom(w.Members)
+		case "sort":
+			res.Sort = null.IntFrom(int64(w.Sort))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ChatGroupComposition) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ChatGroupCompositionN) ToChatGroupComposition() ChatGroupComposition {
+	return ChatGroupComposition{
+
+		Id:          w.Id.Int64,
+		Name:        w.Name.String,
+		Description: w.Description.String,
+		AvatarUrl:   w.AvatarUrl.String,
+		Members:     w.Members.String,
+		Sort:        w.Sort.Int64,
+		Status:      w.Status.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// ChatGroupCompositionModel is a model which encapsulates the operations of the object
+type ChatGroupCompositionModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var chatGroupCompositionTableName = "chat_group_composition"
+
+// ChatGroupCompositionTable return table name for ChatGroupComposition
+func ChatGroupCompositionTable() string {
+	return chatGroupCompositionTableName
+}
+
+const (
+	FieldChatGroupCompositionId          = "id"
+	FieldChatGroupCompositionName        = "name"
+	FieldChatGroupCompositionDescription = "description"
+	FieldChatGroupCompositionAvatarUrl   = "avatar_url"
+	FieldChatGroupCompositionMembers     = "members"
+	FieldChatGroupCompositionSort        = "sort"
+	FieldChatGroupCompositionStatus      = "status"
+	FieldChatGroupCompositionCreatedAt   = "created_at"
+	FieldChatGroupCompositionUpdatedAt   = "updated_at"
+)
+
+// ChatGroupCompositionFields return all fields in ChatGroupComposition model
+func ChatGroupCompositionFields() []string {
+	return []string{
+		"id",
+		"name",
+		"description",
+		"avatar_url",
+		"members",
+		"sort",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetChatGroupCompositionTable(tableName string) {
+	chatGroupCompositionTableName = tableName
+}
+
+// NewChatGroupCompositionModel create a ChatGroupCompositionModel
+func NewChatGroupCompositionModel(db query.Database) *ChatGroupCompositionModel {
+	return &ChatGroupCompositionModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           chatGroupCompositionTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ChatGroupCompositionModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ChatGroupCompositionModel) clone() *ChatGroupCompositionModel {
+	return &ChatGroupCompositionModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ChatGroupCompositionModel) WithoutGlobalScopes(names ...string) *ChatGroupCompositionModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ChatGroupCompositionModel) WithLocalScopes(names ...string) *ChatGroupCompositionModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ChatGroupCompositionModel) Condition(builder query.SQLBuilder) *ChatGroupCompositionModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ChatGroupCompositionModel) Find(ctx context.Context, id int64) (*ChatGroupCompositionN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ChatGroupCompositionModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ChatGroupCompositionModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ChatGroupCompositionModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ChatGroupCompositionN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ChatGroupCompositionModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ChatGroupCompositionN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"description",
+			"avatar_url",
+			"members",
+			"sort",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "description":
+			selectFields = append(selectFields, f)
+		case "avatar_url":
+			selectFields = append(selectFields, f)
+		case "members":
+			selectFields = append(selectFields, f)
+		case "sort":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ChatGroupCompositionN, []interface{}) {
+		var chatGroupCompositionVar ChatGroupCompositionN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &chatGroupCompositionVar.Id)
+			case "name":
+				scanFields = append(scanFields, &chatGroupCompositionVar.Name)
+			case "description":
+				scanFields = append(scanFields, &chatGroupCompositionVar.Description)
+			case "avatar_url":
+				scanFields = append(scanFields, &chatGroupCompositionVar.AvatarUrl)
+			case "members":
+				scanFields = append(scanFields, &chatGroupCompositionVar.Members)
+			case "sort":
+				scanFields = append(scanFields, &chatGroupCompositionVar.Sort)
+			case "status":
+				scanFields = append(scanFields, &chatGroupCompositionVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &chatGroupCompositionVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &chatGroupCompositionVar.UpdatedAt)
+			}
+		}
+
+		return &chatGroupCompositionVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	chatGroupCompositions := make([]ChatGroupCompositionN, 0)
+	for rows.Next() {
+		chatGroupCompositionReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		chatGroupCompositionReal.original = &chatGroupCompositionOriginal{}
+		_ = query.Copy(chatGroupCompositionReal, chatGroupCompositionReal.original)
+
+		chatGroupCompositionReal.SetModel(m)
+		chatGroupCompositions = append(chatGroupCompositions, *chatGroupCompositionReal)
+	}
+
+	return chatGroupCompositions, nil
+}
+
+// First return first result for given query
+func (m *ChatGroupCompositionModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ChatGroupCompositionN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new chat_group_composition to database
+func (m *ChatGroupCompositionModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all chat_group_compositions to database
+func (m *ChatGroupCompositionModel) SaveAll(ctx context.Context, chatGroupCompositions []ChatGroupCompositionN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, chatGroupComposition := range chatGroupCompositions {
+		id, err := m.Save(ctx, chatGroupComposition)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a chat_group_composition to database
+func (m *ChatGroupCompositionModel) Save(ctx context.Context, chatGroupComposition ChatGroupCompositionN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, chatGroupComposition.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new chat_group_composition or update it when it has a id > 0
+func (m *ChatGroupCompositionModel) SaveOrUpdate(ctx context.Context, chatGroupComposition ChatGroupCompositionN, onlyFields ...string) (id int64, updated bool, err error) {
+	if chatGroupComposition.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, chatGroupComposition.Id.Int64, chatGroupComposition, onlyFields...)
+		return chatGroupComposition.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, chatGroupComposition, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ChatGroupCompositionModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ChatGroupCompositionModel) Update(ctx context.Context, builder query.SQLBuilder, chatGroupComposition ChatGroupCompositionN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, chatGroupComposition.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ChatGroupCompositionModel) UpdateById(ctx context.Context, id int64, chatGroupComposition ChatGroupCompositionN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, chatGroupComposition.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ChatGroupCompositionModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ChatGroupCompositionModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
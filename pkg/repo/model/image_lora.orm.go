@@ -0,0 +1,831 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ImageLoraN is a ImageLora object, all fields are nullable
+type ImageLoraN struct {
+	original       *imageLoraOriginal
+	imageLoraModel *ImageLoraModel
+
+	Id            null.Int    `json:"id"`
+	Name          null.String `json:"name"`
+	File          null.String `json:"file"`
+	SourceUrl     null.String `json:"source_url"`
+	BaseModel     null.String `json:"base_model"`
+	TriggerWords  null.String `json:"trigger_words"`
+	DefaultWeight null.Float  `json:"default_weight"`
+	PreviewImage  null.String `json:"preview_image"`
+	Status        null.Int    `json:"status"`
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ImageLoraN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ImageLora
+func (inst *ImageLoraN) SetModel(imageLoraModel *ImageLoraModel) {
+	inst.imageLoraModel = imageLoraModel
+}
+
+// imageLoraOriginal is an object which stores original ImageLora from database
+type imageLoraOriginal struct {
+	Id            null.Int
+	Name          null.String
+	File          null.String
+	SourceUrl     null.String
+	BaseModel     null.String
+	TriggerWords  null.String
+	DefaultWeight null.Float
+	PreviewImage  null.String
+	Status        null.Int
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ImageLoraN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &imageLoraOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.File != inst.original.File {
+			return true
+		}
+		if inst.SourceUrl != inst.original.SourceUrl {
+			return true
+		}
+		if inst.BaseModel != inst.original.BaseModel {
+			return true
+		}
+		if inst.TriggerWords != inst.original.TriggerWords {
+			return true
+		}
+		if inst.DefaultWeight != inst.original.DefaultWeight {
+			return true
+		}
+		if inst.PreviewImage != inst.original.PreviewImage {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "file":
+				if inst.File != inst.original.File {
+					return true
+				}
+			case "source_url":
+				if inst.SourceUrl != inst.original.SourceUrl {
+					return true
+				}
+			case "base_model":
+				if inst.BaseModel != inst.original.BaseModel {
+					return true
+				}
+			case "trigger_words":
+				if inst.TriggerWords != inst.original.TriggerWords {
+					return true
+				}
+			case "default_weight":
+				if inst.DefaultWeight != inst.original.DefaultWeight {
+					return true
+				}
+			case "preview_image":
+				if inst.PreviewImage != inst.original.PreviewImage {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ImageLoraN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &imageLoraOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.File != inst.original.File {
+			kv["file"] = inst.File
+		}
+		if inst.SourceUrl != inst.original.SourceUrl {
+			kv["source_url"] = inst.SourceUrl
+		}
+		if inst.BaseModel != inst.original.BaseModel {
+			kv["base_model"] = inst.BaseModel
+		}
+		if inst.TriggerWords != inst.original.TriggerWords {
+			kv["trigger_words"] = inst.TriggerWords
+		}
+		if inst.DefaultWeight != inst.original.DefaultWeight {
+			kv["default_weight"] = inst.DefaultWeight
+		}
+		if inst.PreviewImage != inst.original.PreviewImage {
+			kv["preview_image"] = inst.PreviewImage
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "file":
+				if inst.File != inst.original.File {
+					kv["file"] = inst.File
+				}
+			case "source_url":
+				if inst.SourceUrl != inst.original.SourceUrl {
+					kv["source_url"] = inst.SourceUrl
+				}
+			case "base_model":
+				if inst.BaseModel != inst.original.BaseModel {
+					kv["base_model"] = inst.BaseModel
+				}
+			case "trigger_words":
+				if inst.TriggerWords != inst.original.TriggerWords {
+					kv["trigger_words"] = inst.TriggerWords
+				}
+			case "default_weight":
+				if inst.DefaultWeight != inst.original.DefaultWeight {
+					kv["default_weight"] = inst.DefaultWeight
+				}
+			case "preview_image":
+				if inst.PreviewImage != inst.original.PreviewImage {
+					kv["preview_image"] = inst.PreviewImage
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ImageLoraN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.imageLoraModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.imageLoraModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a image_lora
+func (inst *ImageLoraN) Delete(ctx context.Context) error {
+	if inst.imageLoraModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.imageLoraModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ImageLoraN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type imageLoraScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var imageLoraGlobalScopes = make([]imageLoraScope, 0)
+var imageLoraLocalScopes = make([]imageLoraScope, 0)
+
+// AddGlobalScopeForImageLora assign a global scope to a model
+func AddGlobalScopeForImageLora(name string, apply func(builder query.Condition)) {
+	imageLoraGlobalScopes = append(imageLoraGlobalScopes, imageLoraScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForImageLora assign a local scope to a model
+func AddLocalScopeForImageLora(name string, apply func(builder query.Condition)) {
+	imageLoraLocalScopes = append(imageLoraLocalScopes, imageLoraScope{name: name, apply: apply})
+}
+
+func (m *ImageLoraModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range imageLoraGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range imageLoraLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ImageLoraModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ImageLoraModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ImageLora struct {
+	Id            int64   `json:"id"`
+	Name          string  `json:"name"`
+	File          string  `json:"file"`
+	SourceUrl     string  `json:"source_url"`
+	BaseModel     string  `json:"base_model"`
+	TriggerWords  string  `json:"trigger_words"`
+	DefaultWeight float64 `json:"default_weight"`
+	PreviewImage  string  `json:"preview_image"`
+	Status        int64   `json:"status"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (w ImageLora) ToImageLoraN(allows ...string) ImageLoraN {
+	if len(allows) == 0 {
+		return ImageLoraN{
+
+			Id:            null.IntFrom(int64(w.Id)),
+			Name:          null.StringFrom(w.Name),
+			File:          null.StringFrom(w.File),
+			SourceUrl:     null.StringFrom(w.SourceUrl),
+			BaseModel:     null.StringFrom(w.BaseModel),
+			TriggerWords:  null.StringFrom(w.TriggerWords),
+			DefaultWeight: null.FloatFrom(float64(w.DefaultWeight)),
+			PreviewImage:  null.StringFrom(w.PreviewImage),
+			Status:        null.IntFrom(int64(w.Status)),
+			CreatedAt:     null.TimeFrom(w.CreatedAt),
+			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ImageLoraN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "file":
+			res.File = null.StringFrom(w.File)
+		case "source_url":
+			res.SourceUrl = null.StringFrom(w.SourceUrl)
+		case "base_model":
+			res.BaseModel = null.StringFrom(w.BaseModel)
+		case "trigger_words":
+			res.TriggerWords = null.StringFrom(w.TriggerWords)
+		case "default_weight":
+			res.DefaultWeight = null.FloatFrom(float64(w.DefaultWeight))
+		case "preview_image":
+			res.PreviewImage = null.StringFrom(w.PreviewImage)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ImageLora) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ImageLoraN) ToImageLora() ImageLora {
+	return ImageLora{
+
+		Id:            w.Id.Int64,
+		Name:          w.Name.String,
+		File:          w.File.String,
+		SourceUrl:     w.SourceUrl.String,
+		BaseModel:     w.BaseModel.String,
+		TriggerWords:  w.TriggerWords.String,
+		DefaultWeight: w.DefaultWeight.Float64,
+		PreviewImage:  w.PreviewImage.String,
+		Status:        w.Status.Int64,
+		CreatedAt:     w.CreatedAt.Time,
+		UpdatedAt:     w.UpdatedAt.Time,
+	}
+}
+
+// ImageLoraModel is a model which encapsulates the operations of the object
+type ImageLoraModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var imageLoraTableName = "image_lora"
+
+// ImageLoraTable return table name for ImageLora
+func ImageLoraTable() string {
+	return imageLoraTableName
+}
+
+const (
+	FieldImageLoraId            = "id"
+	FieldImageLoraName          = "name"
+	FieldImageLoraFile          = "file"
+	FieldImageLoraSourceUrl     = "source_url"
+	FieldImageLoraBaseModel     = "base_model"
+	FieldImageLoraTriggerWords  = "trigger_words"
+	FieldImageLoraDefaultWeight = "default_weight"
+	FieldImageLoraPreviewImage  = "preview_image"
+	FieldImageLoraStatus        = "status"
+	FieldImageLoraCreatedAt     = "created_at"
+	FieldImageLoraUpdatedAt     = "updated_at"
+)
+
+// ImageLoraFields return all fields in ImageLora model
+func ImageLoraFields() []string {
+	return []string{
+		"id",
+		"name",
+		"file",
+		"source_url",
+		"base_model",
+		"trigger_words",
+		"default_weight",
+		"preview_image",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetImageLoraTable(tableName string) {
+	imageLoraTableName = tableName
+}
+
+// NewImageLoraModel create a ImageLoraModel
+func NewImageLoraModel(db query.Database) *ImageLoraModel {
+	return &ImageLoraModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           imageLoraTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ImageLoraModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ImageLoraModel) clone() *ImageLoraModel {
+	return &ImageLoraModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ImageLoraModel) WithoutGlobalScopes(names ...string) *ImageLoraModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ImageLoraModel) WithLocalScopes(names ...string) *ImageLoraModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ImageLoraModel) Condition(builder query.SQLBuilder) *ImageLoraModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ImageLoraModel) Find(ctx context.Context, id int64) (*ImageLoraN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ImageLoraModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ImageLoraModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ImageLoraModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ImageLoraN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ImageLoraModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ImageLoraN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"file",
+			"source_url",
+			"base_model",
+			"trigger_words",
+			"default_weight",
+			"preview_image",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "file":
+			selectFields = append(selectFields, f)
+		case "source_url":
+			selectFields = append(selectFields, f)
+		case "base_model":
+			selectFields = append(selectFields, f)
+		case "trigger_words":
+			selectFields = append(selectFields, f)
+		case "default_weight":
+			selectFields = append(selectFields, f)
+		case "preview_image":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ImageLoraN, []interface{}) {
+		var imageLoraVar ImageLoraN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &imageLoraVar.Id)
+			case "name":
+				scanFields = append(scanFields, &imageLoraVar.Name)
+			case "file":
+				scanFields = append(scanFields, &imageLoraVar.File)
+			case "source_url":
+				scanFields = append(scanFields, &imageLoraVar.SourceUrl)
+			case "base_model":
+				scanFields = append(scanFields, &imageLoraVar.BaseModel)
+			case "trigger_words":
+				scanFields = append(scanFields, &imageLoraVar.TriggerWords)
+			case "default_weight":
+				scanFields = append(scanFields, &imageLoraVar.DefaultWeight)
+			case "preview_image":
+				scanFields = append(scanFields, &imageLoraVar.PreviewImage)
+			case "status":
+				scanFields = append(scanFields, &imageLoraVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &imageLoraVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &imageLoraVar.UpdatedAt)
+			}
+		}
+
+		return &imageLoraVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	imageLoras := make([]ImageLoraN, 0)
+	for rows.Next() {
+		imageLoraReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		imageLoraReal.original = &imageLoraOriginal{}
+		_ = query.Copy(imageLoraReal, imageLoraReal.original)
+
+		imageLoraReal.SetModel(m)
+		imageLoras = append(imageLoras, *imageLoraReal)
+	}
+
+	return imageLoras, nil
+}
+
+// First return first result for given query
+func (m *ImageLoraModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ImageLoraN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new image_lora to database
+func (m *ImageLoraModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all image_loras to database
+func (m *ImageLoraModel) SaveAll(ctx context.Context, imageLoras []ImageLoraN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, imageLora := range imageLoras {
+		id, err := m.Save(ctx, imageLora)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a image_lora to database
+func (m *ImageLoraModel) Save(ctx context.Context, imageLora ImageLoraN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, imageLora.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new image_lora or update it when it has a id > 0
+func (m *ImageLoraModel) SaveOrUpdate(ctx context.Context, imageLora ImageLoraN, onlyFields ...string) (id int64, updated bool, err error) {
+	if imageLora.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, imageLora.Id.Int64, imageLora, onlyFields...)
+		return imageLora.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, imageLora, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ImageLoraModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ImageLoraModel) Update(ctx context.Context, builder query.SQLBuilder, imageLora ImageLoraN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, imageLora.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ImageLoraModel) UpdateById(ctx context.Context, id int64, imageLora ImageLoraN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, imageLora.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ImageLoraModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ImageLoraModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
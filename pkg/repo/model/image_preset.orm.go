@@ -0,0 +1,691 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ImagePresetN is a ImagePreset object, all fields are nullable
+type ImagePresetN struct {
+	original         *imagePresetOriginal
+	imagePresetModel *ImagePresetModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Name      null.String `json:"name"`
+	Params    null.String `json:"params"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ImagePresetN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ImagePreset
+func (inst *ImagePresetN) SetModel(imagePresetModel *ImagePresetModel) {
+	inst.imagePresetModel = imagePresetModel
+}
+
+// imagePresetOriginal is an object which stores original ImagePreset from database
+type imagePresetOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Name      null.String
+	Params    null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ImagePresetN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &imagePresetOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Params != inst.original.Params {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "params":
+				if inst.Params != inst.original.Params {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ImagePresetN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &imagePresetOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Params != inst.original.Params {
+			kv["params"] = inst.Params
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "params":
+				if inst.Params != inst.original.Params {
+					kv["params"] = inst.Params
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ImagePresetN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.imagePresetModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.imagePresetModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a image_preset
+func (inst *ImagePresetN) Delete(ctx context.Context) error {
+	if inst.imagePresetModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.imagePresetModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ImagePresetN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type imagePresetScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var imagePresetGlobalScopes = make([]imagePresetScope, 0)
+var imagePresetLocalScopes = make([]imagePresetScope, 0)
+
+// AddGlobalScopeForImagePreset assign a global scope to a model
+func AddGlobalScopeForImagePreset(name string, apply func(builder query.Condition)) {
+	imagePresetGlobalScopes = append(imagePresetGlobalScopes, imagePresetScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForImagePreset assign a local scope to a model
+func AddLocalScopeForImagePreset(name string, apply func(builder query.Condition)) {
+	imagePresetLocalScopes = append(imagePresetLocalScopes, imagePresetScope{name: name, apply: apply})
+}
+
+func (m *ImagePresetModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range imagePresetGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range imagePresetLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ImagePresetModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ImagePresetModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ImagePreset struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	Params    string `json:"params"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w ImagePreset) ToImagePresetN(allows ...string) ImagePresetN {
+	if len(allows) == 0 {
+		return ImagePresetN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Name:      null.StringFrom(w.Name),
+			Params:    null.StringFrom(w.Params),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ImagePresetN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "params":
+			res.Params = null.StringFrom(w.Params)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ImagePreset) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ImagePresetN) ToImagePreset() ImagePreset {
+	return ImagePreset{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Name:      w.Name.String,
+		Params:    w.Params.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// ImagePresetModel is a model which encapsulates the operations of the object
+type ImagePresetModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var imagePresetTableName = "image_preset"
+
+// ImagePresetTable return table name for ImagePreset
+func ImagePresetTable() string {
+	return imagePresetTableName
+}
+
+const (
+	FieldImagePresetId        = "id"
+	FieldImagePresetUserId    = "user_id"
+	FieldImagePresetName      = "name"
+	FieldImagePresetParams    = "params"
+	FieldImagePresetCreatedAt = "created_at"
+	FieldImagePresetUpdatedAt = "updated_at"
+)
+
+// ImagePresetFields return all fields in ImagePreset model
+func ImagePresetFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"name",
+		"params",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetImagePresetTable(tableName string) {
+	imagePresetTableName = tableName
+}
+
+// NewImagePresetModel create a ImagePresetModel
+func NewImagePresetModel(db query.Database) *ImagePresetModel {
+	return &ImagePresetModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           imagePresetTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ImagePresetModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ImagePresetModel) clone() *ImagePresetModel {
+	return &ImagePresetModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ImagePresetModel) WithoutGlobalScopes(names ...string) *ImagePresetModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ImagePresetModel) WithLocalScopes(names ...string) *ImagePresetModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ImagePresetModel) Condition(builder query.SQLBuilder) *ImagePresetModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ImagePresetModel) Find(ctx context.Context, id int64) (*ImagePresetN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ImagePresetModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ImagePresetModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ImagePresetModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ImagePresetN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ImagePresetModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ImagePresetN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"name",
+			"params",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "params":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ImagePresetN, []interface{}) {
+		var imagePresetVar ImagePresetN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &imagePresetVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &imagePresetVar.UserId)
+			case "name":
+				scanFields = append(scanFields, &imagePresetVar.Name)
+			case "params":
+				scanFields = append(scanFields, &imagePresetVar.Params)
+			case "created_at":
+				scanFields = append(scanFields, &imagePresetVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &imagePresetVar.UpdatedAt)
+			}
+		}
+
+		return &imagePresetVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	imagePresets := make([]ImagePresetN, 0)
+	for rows.Next() {
+		imagePresetReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		imagePresetReal.original = &imagePresetOriginal{}
+		_ = query.Copy(imagePresetReal, imagePresetReal.original)
+
+		imagePresetReal.SetModel(m)
+		imagePresets = append(imagePresets, *imagePresetReal)
+	}
+
+	return imagePresets, nil
+}
+
+// First return first result for given query
+func (m *ImagePresetModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ImagePresetN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new image_preset to database
+func (m *ImagePresetModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all image_presets to database
+func (m *ImagePresetModel) SaveAll(ctx context.Context, imagePresets []ImagePresetN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, imagePreset := range imagePresets {
+		id, err := m.Save(ctx, imagePreset)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a image_preset to database
+func (m *ImagePresetModel) Save(ctx context.Context, imagePreset ImagePresetN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, imagePreset.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new image_preset or update it when it has a id > 0
+func (m *ImagePresetModel) SaveOrUpdate(ctx context.Context, imagePreset ImagePresetN, onlyFields ...string) (id int64, updated bool, err error) {
+	if imagePreset.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, imagePreset.Id.Int64, imagePreset, onlyFields...)
+		return imagePreset.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, imagePreset, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ImagePresetModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ImagePresetModel) Update(ctx context.Context, builder query.SQLBuilder, imagePreset ImagePresetN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, imagePreset.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ImagePresetModel) UpdateById(ctx context.Context, id int64, imagePreset ImagePresetN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, imagePreset.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ImagePresetModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ImagePresetModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
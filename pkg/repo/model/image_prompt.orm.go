@@ -0,0 +1,1618 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ImagePromptStyleN is a ImagePromptStyle object, all fields are nullable
+type ImagePromptStyleN struct {
+	original              *imagePromptStyleOriginal
+	imagePromptStyleModel *ImagePromptStyleModel
+
+	Id          null.Int    `json:"id"`
+	Name        null.String `json:"name"`
+	Description null.String `json:"description"`
+	Instruction null.String `json:"instruction"`
+	Preview     null.String `json:"preview"`
+	SortOrder   null.Int    `json:"sort_order"`
+	Status      null.Int    `json:"status"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ImagePromptStyleN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ImagePromptStyle
+func (inst *ImagePromptStyleN) SetModel(imagePromptStyleModel *ImagePromptStyleModel) {
+	inst.imagePromptStyleModel = imagePromptStyleModel
+}
+
+// imagePromptStyleOriginal is an object which stores original ImagePromptStyle from database
+type imagePromptStyleOriginal struct {
+	Id          null.Int
+	Name        null.String
+	Description null.String
+	Instruction null.String
+	Preview     null.String
+	SortOrder   null.Int
+	Status      null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ImagePromptStyleN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &imagePromptStyleOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Description != inst.original.Description {
+			return true
+		}
+		if inst.Instruction != inst.original.Instruction {
+			return true
+		}
+		if inst.Preview != inst.original.Preview {
+			return true
+		}
+		if inst.SortOrder != inst.original.SortOrder {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					return true
+				}
+			case "instruction":
+				if inst.Instruction != inst.original.Instruction {
+					return true
+				}
+			case "preview":
+				if inst.Preview != inst.original.Preview {
+					return true
+				}
+			case "sort_order":
+				if inst.SortOrder != inst.original.SortOrder {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ImagePromptStyleN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &imagePromptStyleOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Description != inst.original.Description {
+			kv["description"] = inst.Description
+		}
+		if inst.Instruction != inst.original.Instruction {
+			kv["instruction"] = inst.Instruction
+		}
+		if inst.Preview != inst.original.Preview {
+			kv["preview"] = inst.Preview
+		}
+		if inst.SortOrder != inst.original.SortOrder {
+			kv["sort_order"] = inst.SortOrder
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					kv["description"] = inst.Description
+				}
+			case "instruction":
+				if inst.Instruction != inst.original.Instruction {
+					kv["instruction"] = inst.Instruction
+				}
+			case "preview":
+				if inst.Preview != inst.original.Preview {
+					kv["preview"] = inst.Preview
+				}
+			case "sort_order":
+				if inst.SortOrder != inst.original.SortOrder {
+					kv["sort_order"] = inst.SortOrder
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ImagePromptStyleN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.imagePromptStyleModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.imagePromptStyleModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a image_prompt_style
+func (inst *ImagePromptStyleN) Delete(ctx context.Context) error {
+	if inst.imagePromptStyleModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.imagePromptStyleModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ImagePromptStyleN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type imagePromptStyleScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var imagePromptStyleGlobalScopes = make([]imagePromptStyleScope, 0)
+var imagePromptStyleLocalScopes = make([]imagePromptStyleScope, 0)
+
+// AddGlobalScopeForImagePromptStyle assign a global scope to a model
+func AddGlobalScopeForImagePromptStyle(name string, apply func(builder query.Condition)) {
+	imagePromptStyleGlobalScopes = append(imagePromptStyleGlobalScopes, imagePromptStyleScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForImagePromptStyle assign a local scope to a model
+func AddLocalScopeForImagePromptStyle(name string, apply func(builder query.Condition)) {
+	imagePromptStyleLocalScopes = append(imagePromptStyleLocalScopes, imagePromptStyleScope{name: name, apply: apply})
+}
+
+func (m *ImagePromptStyleModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range imagePromptStyleGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range imagePromptStyleLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ImagePromptStyleModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ImagePromptStyleModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ImagePromptStyle struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Instruction string `json:"instruction"`
+	Preview     string `json:"preview"`
+	SortOrder   int64  `json:"sort_order"`
+	Status      int64  `json:"status"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w ImagePromptStyle) ToImagePromptStyleN(allows ...string) ImagePromptStyleN {
+	if len(allows) == 0 {
+		return ImagePromptStyleN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			Name:        null.StringFrom(w.Name),
+			Description: null.StringFrom(w.Description),
+			Instruction: null.StringFrom(w.Instruction),
+			Preview:     null.StringFrom(w.Preview),
+			SortOrder:   null.IntFrom(int64(w.SortOrder)),
+			Status:      null.IntFrom(int64(w.Status)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ImagePromptStyleN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "description":
+			res.Description = null.StringFrom(w.Description)
+		case "instruction":
+			res.Instruction = null.StringFrom(w.Instruction)
+		case "preview":
+			res.Preview = null.StringFrom(w.Preview)
+		case "sort_order":
+			res.SortOrder = null.IntFrom(int64(w.SortOrder))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ImagePromptStyle) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ImagePromptStyleN) ToImagePromptStyle() ImagePromptStyle {
+	return ImagePromptStyle{
+
+		Id:          w.Id.Int64,
+		Name:        w.Name.String,
+		Description: w.Description.String,
+		Instruction: w.Instruction.String,
+		Preview:     w.Preview.String,
+		SortOrder:   w.SortOrder.Int64,
+		Status:      w.Status.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// ImagePromptStyleModel is a model which encapsulates the operations of the object
+type ImagePromptStyleModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var imagePromptStyleTableName = "image_prompt_style"
+
+// ImagePromptStyleTable return table name for ImagePromptStyle
+func ImagePromptStyleTable() string {
+	return imagePromptStyleTableName
+}
+
+const (
+	FieldImagePromptStyleId          = "id"
+	FieldImagePromptStyleName        = "name"
+	FieldImagePromptStyleDescription = "description"
+	FieldImagePromptStyleInstruction = "instruction"
+	FieldImagePromptStylePreview     = "preview"
+	FieldImagePromptStyleSortOrder   = "sort_order"
+	FieldImagePromptStyleStatus      = "status"
+	FieldImagePromptStyleCreatedAt   = "created_at"
+	FieldImagePromptStyleUpdatedAt   = "updated_at"
+)
+
+// ImagePromptStyleFields return all fields in ImagePromptStyle model
+func ImagePromptStyleFields() []string {
+	return []string{
+		"id",
+		"name",
+		"description",
+		"instruction",
+		"preview",
+		"sort_order",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetImagePromptStyleTable(tableName string) {
+	imagePromptStyleTableName = tableName
+}
+
+// NewImagePromptStyleModel create a ImagePromptStyleModel
+func NewImagePromptStyleModel(db query.Database) *ImagePromptStyleModel {
+	return &ImagePromptStyleModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           imagePromptStyleTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ImagePromptStyleModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ImagePromptStyleModel) clone() *ImagePromptStyleModel {
+	return &ImagePromptStyleModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ImagePromptStyleModel) WithoutGlobalScopes(names ...string) *ImagePromptStyleModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ImagePromptStyleModel) WithLocalScopes(names ...string) *ImagePromptStyleModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ImagePromptStyleModel) Condition(builder query.SQLBuilder) *ImagePromptStyleModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ImagePromptStyleModel) Find(ctx context.Context, id int64) (*ImagePromptStyleN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ImagePromptStyleModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ImagePromptStyleModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ImagePromptStyleModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ImagePromptStyleN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ImagePromptStyleModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ImagePromptStyleN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"description",
+			"instruction",
+			"preview",
+			"sort_order",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "description":
+			selectFields = append(selectFields, f)
+		case "instruction":
+			selectFields = append(selectFields, f)
+		case "preview":
+			selectFields = append(selectFields, f)
+		case "sort_order":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ImagePromptStyleN, []interface{}) {
+		var imagePromptStyleVar ImagePromptStyleN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &imagePromptStyleVar.Id)
+			case "name":
+				scanFields = append(scanFields, &imagePromptStyleVar.Name)
+			case "description":
+				scanFields = append(scanFields, &imagePromptStyleVar.Description)
+			case "instruction":
+				scanFields = append(scanFields, &imagePromptStyleVar.Instruction)
+			case "preview":
+				scanFields = append(scanFields, &imagePromptStyleVar.Preview)
+			case "sort_order":
+				scanFields = append(scanFields, &imagePromptStyleVar.SortOrder)
+			case "status":
+				scanFields = append(scanFields, &imagePromptStyleVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &imagePromptStyleVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &imagePromptStyleVar.UpdatedAt)
+			}
+		}
+
+		return &imagePromptStyleVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	imagePromptStyles := make([]ImagePromptStyleN, 0)
+	for rows.Next() {
+		imagePromptStyleReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		imagePromptStyleReal.original = &imagePromptStyleOriginal{}
+		_ = query.Copy(imagePromptStyleReal, imagePromptStyleReal.original)
+
+		imagePromptStyleReal.SetModel(m)
+		imagePromptStyles = append(imagePromptStyles, *imagePromptStyleReal)
+	}
+
+	return imagePromptStyles, nil
+}
+
+// First return first result for given query
+func (m *ImagePromptStyleModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ImagePromptStyleN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new image_prompt_style to database
+func (m *ImagePromptStyleModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all image_prompt_styles to database
+func (m *ImagePromptStyleModel) SaveAll(ctx context.Context, imagePromptStyles []ImagePromptStyleN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, imagePromptStyle := range imagePromptStyles {
+		id, err := m.Save(ctx, imagePromptStyle)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a image_prompt_style to database
+func (m *ImagePromptStyleModel) Save(ctx context.Context, imagePromptStyle ImagePromptStyleN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, imagePromptStyle.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new image_prompt_style or update it when it has a id > 0
+func (m *ImagePromptStyleModel) SaveOrUpdate(ctx context.Context, imagePromptStyle ImagePromptStyleN, onlyFields ...string) (id int64, updated bool, err error) {
+	if imagePromptStyle.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, imagePromptStyle.Id.Int64, imagePromptStyle, onlyFields...)
+		return imagePromptStyle.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, imagePromptStyle, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ImagePromptStyleModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ImagePromptStyleModel) Update(ctx context.Context, builder query.SQLBuilder, imagePromptStyle ImagePromptStyleN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, imagePromptStyle.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ImagePromptStyleModel) UpdateById(ctx context.Context, id int64, imagePromptStyle ImagePromptStyleN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, imagePromptStyle.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ImagePromptStyleModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ImagePromptStyleModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// ImagePromptEnhancementN is a ImagePromptEnhancement object, all fields are nullable
+type ImagePromptEnhancementN struct {
+	original                    *imagePromptEnhancementOriginal
+	imagePromptEnhancementModel *ImagePromptEnhancementModel
+
+	Id             null.Int    `json:"id"`
+	UserId         null.Int    `json:"user_id"`
+	StyleId        null.Int    `json:"style_id"`
+	Target         null.String `json:"target"`
+	Prompt         null.String `json:"prompt"`
+	EnhancedPrompt null.String `json:"enhanced_prompt"`
+	NegativePrompt null.String `json:"negative_prompt"`
+	Model          null.String `json:"model"`
+	TokenConsumed  null.Int    `json:"token_consumed"`
+	QuotaConsumed  null.Int    `json:"quota_consumed"`
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ImagePromptEnhancementN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ImagePromptEnhancement
+func (inst *ImagePromptEnhancementN) SetModel(imagePromptEnhancementModel *ImagePromptEnhancementModel) {
+	inst.imagePromptEnhancementModel = imagePromptEnhancementModel
+}
+
+// imagePromptEnhancementOriginal is an object which stores original ImagePromptEnhancement from database
+type imagePromptEnhancementOriginal struct {
+	Id             null.Int
+	UserId         null.Int
+	StyleId        null.Int
+	Target         null.String
+	Prompt         null.String
+	EnhancedPrompt null.String
+	NegativePrompt null.String
+	Model          null.String
+	TokenConsumed  null.Int
+	QuotaConsumed  null.Int
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ImagePromptEnhancementN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &imagePromptEnhancementOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.StyleId != inst.original.StyleId {
+			return true
+		}
+		if inst.Target != inst.original.Target {
+			return true
+		}
+		if inst.Prompt != inst.original.Prompt {
+			return true
+		}
+		if inst.EnhancedPrompt != inst.original.EnhancedPrompt {
+			return true
+		}
+		if inst.NegativePrompt != inst.original.NegativePrompt {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.TokenConsumed != inst.original.TokenConsumed {
+			return true
+		}
+		if inst.QuotaConsumed != inst.original.QuotaConsumed {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "style_id":
+				if inst.StyleId != inst.original.StyleId {
+					return true
+				}
+			case "target":
+				if inst.Target != inst.original.Target {
+					return true
+				}
+			case "prompt":
+				if inst.Prompt != inst.original.Prompt {
+					return true
+				}
+			case "enhanced_prompt":
+				if inst.EnhancedPrompt != inst.original.EnhancedPrompt {
+					return true
+				}
+			case "negative_prompt":
+				if inst.NegativePrompt != inst.original.NegativePrompt {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "token_consumed":
+				if inst.TokenConsumed != inst.original.TokenConsumed {
+					return true
+				}
+			case "quota_consumed":
+				if inst.QuotaConsumed != inst.original.QuotaConsumed {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ImagePromptEnhancementN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &imagePromptEnhancementOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.StyleId != inst.original.StyleId {
+			kv["style_id"] = inst.StyleId
+		}
+		if inst.Target != inst.original.Target {
+			kv["target"] = inst.Target
+		}
+		if inst.Prompt != inst.original.Prompt {
+			kv["prompt"] = inst.Prompt
+		}
+		if inst.EnhancedPrompt != inst.original.EnhancedPrompt {
+			kv["enhanced_prompt"] = inst.EnhancedPrompt
+		}
+		if inst.NegativePrompt != inst.original.NegativePrompt {
+			kv["negative_prompt"] = inst.NegativePrompt
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.TokenConsumed != inst.original.TokenConsumed {
+			kv["token_consumed"] = inst.TokenConsumed
+		}
+		if inst.QuotaConsumed != inst.original.QuotaConsumed {
+			kv["quota_consumed"] = inst.QuotaConsumed
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "style_id":
+				if inst.StyleId != inst.original.StyleId {
+					kv["style_id"] = inst.StyleId
+				}
+			case "target":
+				if inst.Target != inst.original.Target {
+					kv["target"] = inst.Target
+				}
+			case "prompt":
+				if inst.Prompt != inst.original.Prompt {
+					kv["prompt"] = inst.Prompt
+				}
+			case "enhanced_prompt":
+				if inst.EnhancedPrompt != inst.original.EnhancedPrompt {
+					kv["enhanced_prompt"] = inst.EnhancedPrompt
+				}
+			case "negative_prompt":
+				if inst.NegativePrompt != inst.original.NegativePrompt {
+					kv["negative_prompt"] = inst.NegativePrompt
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "token_consumed":
+				if inst.TokenConsumed != inst.original.TokenConsumed {
+					kv["token_consumed"] = inst.TokenConsumed
+				}
+			case "quota_consumed":
+				if inst.QuotaConsumed != inst.original.QuotaConsumed {
+					kv["quota_consumed"] = inst.QuotaConsumed
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ImagePromptEnhancementN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.imagePromptEnhancementModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.imagePromptEnhancementModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a image_prompt_enhancement
+func (inst *ImagePromptEnhancementN) Delete(ctx context.Context) error {
+	if inst.imagePromptEnhancementModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.imagePromptEnhancementModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ImagePromptEnhancementN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type imagePromptEnhancementScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var imagePromptEnhancementGlobalScopes = make([]imagePromptEnhancementScope, 0)
+var imagePromptEnhancementLocalScopes = make([]imagePromptEnhancementScope, 0)
+
+// AddGlobalScopeForImagePromptEnhancement assign a global scope to a model
+func AddGlobalScopeForImagePromptEnhancement(name string, apply func(builder query.Condition)) {
+	imagePromptEnhancementGlobalScopes = append(imagePromptEnhancementGlobalScopes, imagePromptEnhancementScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForImagePromptEnhancement assign a local scope to a model
+func AddLocalScopeForImagePromptEnhancement(name string, apply func(builder query.Condition)) {
+	imagePromptEnhancementLocalScopes = append(imagePromptEnhancementLocalScopes, imagePromptEnhancementScope{name: name, apply: apply})
+}
+
+func (m *ImagePromptEnhancementModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range imagePromptEnhancementGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range imagePromptEnhancementLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ImagePromptEnhancementModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ImagePromptEnhancementModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ImagePromptEnhancement struct {
+	Id             int64  `json:"id"`
+	UserId         int64  `json:"user_id"`
+	StyleId        int64  `json:"style_id"`
+	Target         string `json:"target"`
+	Prompt         string `json:"prompt"`
+	EnhancedPrompt string `json:"enhanced_prompt"`
+	NegativePrompt string `json:"negative_prompt"`
+	Model          string `json:"model"`
+	TokenConsumed  int64  `json:"token_consumed"`
+	QuotaConsumed  int64  `json:"quota_consumed"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (w ImagePromptEnhancement) ToImagePromptEnhancementN(allows ...string) ImagePromptEnhancementN {
+	if len(allows) == 0 {
+		return ImagePromptEnhancementN{
+
+			Id:             null.IntFrom(int64(w.Id)),
+			UserId:         null.IntFrom(int64(w.UserId)),
+			StyleId:        null.IntFrom(int64(w.StyleId)),
+			Target:         null.StringFrom(w.Target),
+			Prompt:         null.StringFrom(w.Prompt),
+			EnhancedPrompt: null.StringFrom(w.EnhancedPrompt),
+			NegativePrompt: null.StringFrom(w.NegativePrompt),
+			Model:          null.StringFrom(w.Model),
+			TokenConsumed:  null.IntFrom(int64(w.TokenConsumed)),
+			QuotaConsumed:  null.IntFrom(int64(w.QuotaConsumed)),
+			CreatedAt:      null.TimeFrom(w.CreatedAt),
+			UpdatedAt:      null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ImagePromptEnhancementN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "style_id":
+			res.StyleId = null.IntFrom(int64(w.StyleId))
+		case "target":
+			res.Target = null.StringFrom(w.Target)
+		case "prompt":
+			res.Prompt = null.StringFrom(w.Prompt)
+		case "enhanced_prompt":
+			res.EnhancedPrompt = null.StringFrom(w.EnhancedPrompt)
+		case "negative_prompt":
+			res.NegativePrompt = null.StringFrom(w.NegativePrompt)
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "token_consumed":
+			res.TokenConsumed = null.IntFrom(int64(w.TokenConsumed))
+		case "quota_consumed":
+			res.QuotaConsumed = null.IntFrom(int64(w.QuotaConsumed))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ImagePromptEnhancement) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ImagePromptEnhancementN) ToImagePromptEnhancement() ImagePromptEnhancement {
+	return ImagePromptEnhancement{
+
+		Id:             w.Id.Int64,
+		UserId:         w.UserId.Int64,
+		StyleId:        w.StyleId.Int64,
+		Target:         w.Target.String,
+		Prompt:         w.Prompt.String,
+		EnhancedPrompt: w.EnhancedPrompt.String,
+		NegativePrompt: w.NegativePrompt.String,
+		Model:          w.Model.String,
+		TokenConsumed:  w.TokenConsumed.Int64,
+		QuotaConsumed:  w.QuotaConsumed.Int64,
+		CreatedAt:      w.CreatedAt.Time,
+		UpdatedAt:      w.UpdatedAt.Time,
+	}
+}
+
+// ImagePromptEnhancementModel is a model which encapsulates the operations of the object
+type ImagePromptEnhancementModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var imagePromptEnhancementTableName = "image_prompt_enhancement"
+
+// ImagePromptEnhancementTable return table name for ImagePromptEnhancement
+func ImagePromptEnhancementTable() string {
+	return imagePromptEnhancementTableName
+}
+
+const (
+	FieldImagePromptEnhancementId             = "id"
+	FieldImagePromptEnhancementUserId         = "user_id"
+	FieldImagePromptEnhancementStyleId        = "style_id"
+	FieldImagePromptEnhancementTarget         = "target"
+	FieldImagePromptEnhancementPrompt         = "prompt"
+	FieldImagePromptEnhancementEnhancedPrompt = "enhanced_prompt"
+	FieldImagePromptEnhancementNegativePrompt = "negative_prompt"
+	FieldImagePromptEnhancementModel          = "model"
+	FieldImagePromptEnhancementTokenConsumed  = "token_consumed"
+	FieldImagePromptEnhancementQuotaConsumed  = "quota_consumed"
+	FieldImagePromptEnhancementCreatedAt      = "created_at"
+	FieldImagePromptEnhancementUpdatedAt      = "updated_at"
+)
+
+// ImagePromptEnhancementFields return all fields in ImagePromptEnhancement model
+func ImagePromptEnhancementFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"style_id",
+		"target",
+		"prompt",
+		"enhanced_prompt",
+		"negative_prompt",
+		"model",
+		"token_consumed",
+		"quota_consumed",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetImagePromptEnhancementTable(tableName string) {
+	imagePromptEnhancementTableName = tableName
+}
+
+// NewImagePromptEnhancementModel create a ImagePromptEnhancementModel
+func NewImagePromptEnhancementModel(db query.Database) *ImagePromptEnhancementModel {
+	return &ImagePromptEnhancementModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           imagePromptEnhancementTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ImagePromptEnhancementModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ImagePromptEnhancementModel) clone() *ImagePromptEnhancementModel {
+	return &ImagePromptEnhancementModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ImagePromptEnhancementModel) WithoutGlobalScopes(names ...string) *ImagePromptEnhancementModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ImagePromptEnhancementModel) WithLocalScopes(names ...string) *ImagePromptEnhancementModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ImagePromptEnhancementModel) Condition(builder query.SQLBuilder) *ImagePromptEnhancementModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ImagePromptEnhancementModel) Find(ctx context.Context, id int64) (*ImagePromptEnhancementN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ImagePromptEnhancementModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ImagePromptEnhancementModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ImagePromptEnhancementModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ImagePromptEnhancementN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ImagePromptEnhancementModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ImagePromptEnhancementN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"style_id",
+			"target",
+			"prompt",
+			"enhanced_prompt",
+			"negative_prompt",
+			"model",
+			"token_consumed",
+			"quota_consumed",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "style_id":
+			selectFields = append(selectFields, f)
+		case "target":
+			selectFields = append(selectFields, f)
+		case "prompt":
+			selectFields = append(selectFields, f)
+		case "enhanced_prompt":
+			selectFields = append(selectFields, f)
+		case "negative_prompt":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "token_consumed":
+			selectFields = append(selectFields, f)
+		case "quota_consumed":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ImagePromptEnhancementN, []interface{}) {
+		var imagePromptEnhancementVar ImagePromptEnhancementN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.UserId)
+			case "style_id":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.StyleId)
+			case "target":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.Target)
+			case "prompt":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.Prompt)
+			case "enhanced_prompt":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.EnhancedPrompt)
+			case "negative_prompt":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.NegativePrompt)
+			case "model":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.Model)
+			case "token_consumed":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.TokenConsumed)
+			case "quota_consumed":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.QuotaConsumed)
+			case "created_at":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &imagePromptEnhancementVar.UpdatedAt)
+			}
+		}
+
+		return &imagePromptEnhancementVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	imagePromptEnhancements := make([]ImagePromptEnhancementN, 0)
+	for rows.Next() {
+		imagePromptEnhancementReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		imagePromptEnhancementReal.original = &imagePromptEnhancementOriginal{}
+		_ = query.Copy(imagePromptEnhancementReal, imagePromptEnhancementReal.original)
+
+		imagePromptEnhancementReal.SetModel(m)
+		imagePromptEnhancements = append(imagePromptEnhancements, *imagePromptEnhancementReal)
+	}
+
+	return imagePromptEnhancements, nil
+}
+
+// First return first result for given query
+func (m *ImagePromptEnhancementModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ImagePromptEnhancementN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new image_prompt_enhancement to database
+func (m *ImagePromptEnhancementModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all image_prompt_enhancements to database
+func (m *ImagePromptEnhancementModel) SaveAll(ctx context.Context, imagePromptEnhancements []ImagePromptEnhancementN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, imagePromptEnhancement := range imagePromptEnhancements {
+		id, err := m.Save(ctx, imagePromptEnhancement)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a image_prompt_enhancement to database
+func (m *ImagePromptEnhancementModel) Save(ctx context.Context, imagePromptEnhancement ImagePromptEnhancementN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, imagePromptEnhancement.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new image_prompt_enhancement or update it when it has a id > 0
+func (m *ImagePromptEnhancementModel) SaveOrUpdate(ctx context.Context, imagePromptEnhancement ImagePromptEnhancementN, onlyFields ...string) (id int64, updated bool, err error) {
+	if imagePromptEnhancement.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, imagePromptEnhancement.Id.Int64, imagePromptEnhancement, onlyFields...)
+		return imagePromptEnhancement.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, imagePromptEnhancement, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ImagePromptEnhancementModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ImagePromptEnhancementModel) Update(ctx context.Context, builder query.SQLBuilder, imagePromptEnhancement ImagePromptEnhancementN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, imagePromptEnhancement.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ImagePromptEnhancementModel) UpdateById(ctx context.Context, id int64, imagePromptEnhancement ImagePromptEnhancementN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, imagePromptEnhancement.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ImagePromptEnhancementModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ImagePromptEnhancementModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
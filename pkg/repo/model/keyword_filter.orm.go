@@ -0,0 +1,1590 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// KeywordFilterN is a KeywordFilter object, all fields are nullable
+type KeywordFilterN struct {
+	original           *keywordFilterOriginal
+	keywordFilterModel *KeywordFilterModel
+
+	Id        null.Int    `json:"id"`
+	Keyword   null.String `json:"keyword"`
+	IsRegex   null.Int    `json:"is_regex"`
+	Action    null.Int    `json:"action"`
+	Scope     null.Int    `json:"scope"`
+	Status    null.Int    `json:"status"`
+	HitCount  null.Int    `json:"hit_count"`
+	LastHitAt null.Time   `json:"last_hit_at,omitempty"`
+	Note      null.String `json:"note,omitempty"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *KeywordFilterN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for KeywordFilter
+func (inst *KeywordFilterN) SetModel(keywordFilterModel *KeywordFilterModel) {
+	inst.keywordFilterModel = keywordFilterModel
+}
+
+// keywordFilterOriginal is an object which stores original KeywordFilter from database
+type keywordFilterOriginal struct {
+	Id        null.Int
+	Keyword   null.String
+	IsRegex   null.Int
+	Action    null.Int
+	Scope     null.Int
+	Status    null.Int
+	HitCount  null.Int
+	LastHitAt null.Time
+	Note      null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *KeywordFilterN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &keywordFilterOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Keyword != inst.original.Keyword {
+			return true
+		}
+		if inst.IsRegex != inst.original.IsRegex {
+			return true
+		}
+		if inst.Action != inst.original.Action {
+			return true
+		}
+		if inst.Scope != inst.original.Scope {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.HitCount != inst.original.HitCount {
+			return true
+		}
+		if inst.LastHitAt != inst.original.LastHitAt {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "keyword":
+				if inst.Keyword != inst.original.Keyword {
+					return true
+				}
+			case "is_regex":
+				if inst.IsRegex != inst.original.IsRegex {
+					return true
+				}
+			case "action":
+				if inst.Action != inst.original.Action {
+					return true
+				}
+			case "scope":
+				if inst.Scope != inst.original.Scope {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "hit_count":
+				if inst.HitCount != inst.original.HitCount {
+					return true
+				}
+			case "last_hit_at":
+				if inst.LastHitAt != inst.original.LastHitAt {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *KeywordFilterN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &keywordFilterOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Keyword != inst.original.Keyword {
+			kv["keyword"] = inst.Keyword
+		}
+		if inst.IsRegex != inst.original.IsRegex {
+			kv["is_regex"] = inst.IsRegex
+		}
+		if inst.Action != inst.original.Action {
+			kv["action"] = inst.Action
+		}
+		if inst.Scope != inst.original.Scope {
+			kv["scope"] = inst.Scope
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.HitCount != inst.original.HitCount {
+			kv["hit_count"] = inst.HitCount
+		}
+		if inst.LastHitAt != inst.original.LastHitAt {
+			kv["last_hit_at"] = inst.LastHitAt
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "keyword":
+				if inst.Keyword != inst.original.Keyword {
+					kv["keyword"] = inst.Keyword
+				}
+			case "is_regex":
+				if inst.IsRegex != inst.original.IsRegex {
+					kv["is_regex"] = inst.IsRegex
+				}
+			case "action":
+				if inst.Action != inst.original.Action {
+					kv["action"] = inst.Action
+				}
+			case "scope":
+				if inst.Scope != inst.original.Scope {
+					kv["scope"] = inst.Scope
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "hit_count":
+				if inst.HitCount != inst.original.HitCount {
+					kv["hit_count"] = inst.HitCount
+				}
+			case "last_hit_at":
+				if inst.LastHitAt != inst.original.LastHitAt {
+					kv["last_hit_at"] = inst.LastHitAt
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *KeywordFilterN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.keywordFilterModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.keywordFilterModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a keyword_filter
+func (inst *KeywordFilterN) Delete(ctx context.Context) error {
+	if inst.keywordFilterModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.keywordFilterModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *KeywordFilterN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type keywordFilterScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var keywordFilterGlobalScopes = make([]keywordFilterScope, 0)
+var keywordFilterLocalScopes = make([]keywordFilterScope, 0)
+
+// AddGlobalScopeForKeywordFilter assign a global scope to a model
+func AddGlobalScopeForKeywordFilter(name string, apply func(builder query.Condition)) {
+	keywordFilterGlobalScopes = append(keywordFilterGlobalScopes, keywordFilterScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForKeywordFilter assign a local scope to a model
+func AddLocalScopeForKeywordFilter(name string, apply func(builder query.Condition)) {
+	keywordFilterLocalScopes = append(keywordFilterLocalScopes, keywordFilterScope{name: name, apply: apply})
+}
+
+func (m *KeywordFilterModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range keywordFilterGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range keywordFilterLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *KeywordFilterModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *KeywordFilterModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type KeywordFilter struct {
+	Id        int64     `json:"id"`
+	Keyword   string    `json:"keyword"`
+	IsRegex   int64     `json:"is_regex"`
+	Action    int64     `json:"action"`
+	Scope     int64     `json:"scope"`
+	Status    int64     `json:"status"`
+	HitCount  int64     `json:"hit_count"`
+	LastHitAt time.Time `json:"last_hit_at,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w KeywordFilter) ToKeywordFilterN(allows ...string) KeywordFilterN {
+	if len(allows) == 0 {
+		return KeywordFilterN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			Keyword:   null.StringFrom(w.Keyword),
+			IsRegex:   null.IntFrom(int64(w.IsRegex)),
+			Action:    null.IntFrom(int64(w.Action)),
+			Scope:     null.IntFrom(int64(w.Scope)),
+			Status:    null.IntFrom(int64(w.Status)),
+			HitCount:  null.IntFrom(int64(w.HitCount)),
+			LastHitAt: null.TimeFrom(w.LastHitAt),
+			Note:      null.StringFrom(w.Note),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := KeywordFilterN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "keyword":
+			res.Keyword = null.StringFrom(w.Keyword)
+		case "is_regex":
+			res.IsRegex = null.IntFrom(int64(w.IsRegex))
+		case "action":
+			res.Action = null.IntFrom(int64(w.Action))
+		case "scope":
+			res.Scope = null.IntFrom(int64(w.Scope))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "hit_count":
+			res.HitCount = null.IntFrom(int64(w.HitCount))
+		case "last_hit_at":
+			res.LastHitAt = null.TimeFrom(w.LastHitAt)
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w KeywordFilter) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *KeywordFilterN) ToKeywordFilter() KeywordFilter {
+	return KeywordFilter{
+
+		Id:        w.Id.Int64,
+		Keyword:   w.Keyword.String,
+		IsRegex:   w.IsRegex.Int64,
+		Action:    w.Action.Int64,
+		Scope:     w.Scope.Int64,
+		Status:    w.Status.Int64,
+		HitCount:  w.HitCount.Int64,
+		LastHitAt: w.LastHitAt.Time,
+		Note:      w.Note.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// KeywordFilterModel is a model which encapsulates the operations of the object
+type KeywordFilterModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var keywordFilterTableName = "keyword_filter"
+
+// KeywordFilterTable return table name for KeywordFilter
+func KeywordFilterTable() string {
+	return keywordFilterTableName
+}
+
+const (
+	FieldKeywordFilterId        = "id"
+	FieldKeywordFilterKeyword   = "keyword"
+	FieldKeywordFilterIsRegex   = "is_regex"
+	FieldKeywordFilterAction    = "action"
+	FieldKeywordFilterScope     = "scope"
+	FieldKeywordFilterStatus    = "status"
+	FieldKeywordFilterHitCount  = "hit_count"
+	FieldKeywordFilterLastHitAt = "last_hit_at"
+	FieldKeywordFilterNote      = "note"
+	FieldKeywordFilterCreatedAt = "created_at"
+	FieldKeywordFilterUpdatedAt = "updated_at"
+)
+
+// KeywordFilterFields return all fields in KeywordFilter model
+func KeywordFilterFields() []string {
+	return []string{
+		"id",
+		"keyword",
+		"is_regex",
+		"action",
+		"scope",
+		"status",
+		"hit_count",
+		"last_hit_at",
+		"note",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetKeywordFilterTable(tableName string) {
+	keywordFilterTableName = tableName
+}
+
+// NewKeywordFilterModel create a KeywordFilterModel
+func NewKeywordFilterModel(db query.Database) *KeywordFilterModel {
+	return &KeywordFilterModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           keywordFilterTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *KeywordFilterModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *KeywordFilterModel) clone() *KeywordFilterModel {
+	return &KeywordFilterModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *KeywordFilterModel) WithoutGlobalScopes(names ...string) *KeywordFilterModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *KeywordFilterModel) WithLocalScopes(names ...string) *KeywordFilterModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *KeywordFilterModel) Condition(builder query.SQLBuilder) *KeywordFilterModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *KeywordFilterModel) Find(ctx context.Context, id int64) (*KeywordFilterN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *KeywordFilterModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *KeywordFilterModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *KeywordFilterModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]KeywordFilterN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *KeywordFilterModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]KeywordFilterN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"keyword",
+			"is_regex",
+			"action",
+			"scope",
+			"status",
+			"hit_count",
+			"last_hit_at",
+			"note",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "keyword":
+			selectFields = append(selectFields, f)
+		case "is_regex":
+			selectFields = append(selectFields, f)
+		case "action":
+			selectFields = append(selectFields, f)
+		case "scope":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "hit_count":
+			selectFields = append(selectFields, f)
+		case "last_hit_at":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*KeywordFilterN, []interface{}) {
+		var keywordFilterVar KeywordFilterN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &keywordFilterVar.Id)
+			case "keyword":
+				scanFields = append(scanFields, &keywordFilterVar.Keyword)
+			case "is_regex":
+				scanFields = append(scanFields, &keywordFilterVar.IsRegex)
+			case "action":
+				scanFields = append(scanFields, &keywordFilterVar.Action)
+			case "scope":
+				scanFields = append(scanFields, &keywordFilterVar.Scope)
+			case "status":
+				scanFields = append(scanFields, &keywordFilterVar.Status)
+			case "hit_count":
+				scanFields = append(scanFields, &keywordFilterVar.HitCount)
+			case "last_hit_at":
+				scanFields = append(scanFields, &keywordFilterVar.LastHitAt)
+			case "note":
+				scanFields = append(scanFields, &keywordFilterVar.Note)
+			case "created_at":
+				scanFields = append(scanFields, &keywordFilterVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &keywordFilterVar.UpdatedAt)
+			}
+		}
+
+		return &keywordFilterVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keywordFilters := make([]KeywordFilterN, 0)
+	for rows.Next() {
+		keywordFilterReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		keywordFilterReal.original = &keywordFilterOriginal{}
+		_ = query.Copy(keywordFilterReal, keywordFilterReal.original)
+
+		keywordFilterReal.SetModel(m)
+		keywordFilters = append(keywordFilters, *keywordFilterReal)
+	}
+
+	return keywordFilters, nil
+}
+
+// First return first result for given query
+func (m *KeywordFilterModel) First(ctx context.Context, builders ...query.SQLBuilder) (*KeywordFilterN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new keyword_filter to database
+func (m *KeywordFilterModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all keyword_filters to database
+func (m *KeywordFilterModel) SaveAll(ctx context.Context, keywordFilters []KeywordFilterN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, keywordFilter := range keywordFilters {
+		id, err := m.Save(ctx, keywordFilter)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a keyword_filter to database
+func (m *KeywordFilterModel) Save(ctx context.Context, keywordFilter KeywordFilterN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, keywordFilter.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new keyword_filter or update it when it has a id > 0
+func (m *KeywordFilterModel) SaveOrUpdate(ctx context.Context, keywordFilter KeywordFilterN, onlyFields ...string) (id int64, updated bool, err error) {
+	if keywordFilter.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, keywordFilter.Id.Int64, keywordFilter, onlyFields...)
+		return keywordFilter.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, keywordFilter, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *KeywordFilterModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *KeywordFilterModel) Update(ctx context.Context, builder query.SQLBuilder, keywordFilter KeywordFilterN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, keywordFilter.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *KeywordFilterModel) UpdateById(ctx context.Context, id int64, keywordFilter KeywordFilterN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, keywordFilter.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *KeywordFilterModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *KeywordFilterModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// KeywordFilterHitN is a KeywordFilterHit object, all fields are nullable
+type KeywordFilterHitN struct {
+	original              *keywordFilterHitOriginal
+	keywordFilterHitModel *KeywordFilterHitModel
+
+	Id        null.Int    `json:"id"`
+	FilterId  null.Int    `json:"filter_id"`
+	UserId    null.Int    `json:"user_id"`
+	Action    null.Int    `json:"action"`
+	Scope     null.Int    `json:"scope"`
+	Content   null.String `json:"content"`
+	Status    null.Int    `json:"status"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *KeywordFilterHitN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for KeywordFilterHit
+func (inst *KeywordFilterHitN) SetModel(keywordFilterHitModel *KeywordFilterHitModel) {
+	inst.keywordFilterHitModel = keywordFilterHitModel
+}
+
+// keywordFilterHitOriginal is an object which stores original KeywordFilterHit from database
+type keywordFilterHitOriginal struct {
+	Id        null.Int
+	FilterId  null.Int
+	UserId    null.Int
+	Action    null.Int
+	Scope     null.Int
+	Content   null.String
+	Status    null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *KeywordFilterHitN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &keywordFilterHitOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.FilterId != inst.original.FilterId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Action != inst.original.Action {
+			return true
+		}
+		if inst.Scope != inst.original.Scope {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "filter_id":
+				if inst.FilterId != inst.original.FilterId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "action":
+				if inst.Action != inst.original.Action {
+					return true
+				}
+			case "scope":
+				if inst.Scope != inst.original.Scope {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *KeywordFilterHitN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &keywordFilterHitOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.FilterId != inst.original.FilterId {
+			kv["filter_id"] = inst.FilterId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Action != inst.original.Action {
+			kv["action"] = inst.Action
+		}
+		if inst.Scope != inst.original.Scope {
+			kv["scope"] = inst.Scope
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "filter_id":
+				if inst.FilterId != inst.original.FilterId {
+					kv["filter_id"] = inst.FilterId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "action":
+				if inst.Action != inst.original.Action {
+					kv["action"] = inst.Action
+				}
+			case "scope":
+				if inst.Scope != inst.original.Scope {
+					kv["scope"] = inst.Scope
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *KeywordFilterHitN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.keywordFilterHitModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.keywordFilterHitModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a keyword_filter_hit
+func (inst *KeywordFilterHitN) Delete(ctx context.Context) error {
+	if inst.keywordFilterHitModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.keywordFilterHitModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *KeywordFilterHitN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type keywordFilterHitScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var keywordFilterHitGlobalScopes = make([]keywordFilterHitScope, 0)
+var keywordFilterHitLocalScopes = make([]keywordFilterHitScope, 0)
+
+// AddGlobalScopeForKeywordFilterHit assign a global scope to a model
+func AddGlobalScopeForKeywordFilterHit(name string, apply func(builder query.Condition)) {
+	keywordFilterHitGlobalScopes = append(keywordFilterHitGlobalScopes, keywordFilterHitScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForKeywordFilterHit assign a local scope to a model
+func AddLocalScopeForKeywordFilterHit(name string, apply func(builder query.Condition)) {
+	keywordFilterHitLocalScopes = append(keywordFilterHitLocalScopes, keywordFilterHitScope{name: name, apply: apply})
+}
+
+func (m *KeywordFilterHitModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range keywordFilterHitGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range keywordFilterHitLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *KeywordFilterHitModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *KeywordFilterHitModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type KeywordFilterHit struct {
+	Id        int64  `json:"id"`
+	FilterId  int64  `json:"filter_id"`
+	UserId    int64  `json:"user_id"`
+	Action    int64  `json:"action"`
+	Scope     int64  `json:"scope"`
+	Content   string `json:"content"`
+	Status    int64  `json:"status"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w KeywordFilterHit) ToKeywordFilterHitN(allows ...string) KeywordFilterHitN {
+	if len(allows) == 0 {
+		return KeywordFilterHitN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			FilterId:  null.IntFrom(int64(w.FilterId)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Action:    null.IntFrom(int64(w.Action)),
+			Scope:     null.IntFrom(int64(w.Scope)),
+			Content:   null.StringFrom(w.Content),
+			Status:    null.IntFrom(int64(w.Status)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := KeywordFilterHitN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "filter_id":
+			res.FilterId = null.IntFrom(int64(w.FilterId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "action":
+			res.Action = null.IntFrom(int64(w.Action))
+		case "scope":
+			res.Scope = null.IntFrom(int64(w.Scope))
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w KeywordFilterHit) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *KeywordFilterHitN) ToKeywordFilterHit() KeywordFilterHit {
+	return KeywordFilterHit{
+
+		Id:        w.Id.Int64,
+		FilterId:  w.FilterId.Int64,
+		UserId:    w.UserId.Int64,
+		Action:    w.Action.Int64,
+		Scope:     w.Scope.Int64,
+		Content:   w.Content.String,
+		Status:    w.Status.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// KeywordFilterHitModel is a model which encapsulates the operations of the object
+type KeywordFilterHitModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var keywordFilterHitTableName = "keyword_filter_hit"
+
+// KeywordFilterHitTable return table name for KeywordFilterHit
+func KeywordFilterHitTable() string {
+	return keywordFilterHitTableName
+}
+
+const (
+	FieldKeywordFilterHitId        = "id"
+	FieldKeywordFilterHitFilterId  = "filter_id"
+	FieldKeywordFilterHitUserId    = "user_id"
+	FieldKeywordFilterHitAction    = "action"
+	FieldKeywordFilterHitScope     = "scope"
+	FieldKeywordFilterHitContent   = "content"
+	FieldKeywordFilterHitStatus    = "status"
+	FieldKeywordFilterHitCreatedAt = "created_at"
+	FieldKeywordFilterHitUpdatedAt = "updated_at"
+)
+
+// KeywordFilterHitFields return all fields in KeywordFilterHit model
+func KeywordFilterHitFields() []string {
+	return []string{
+		"id",
+		"filter_id",
+		"user_id",
+		"action",
+		"scope",
+		"content",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetKeywordFilterHitTable(tableName string) {
+	keywordFilterHitTableName = tableName
+}
+
+// NewKeywordFilterHitModel create a KeywordFilterHitModel
+func NewKeywordFilterHitModel(db query.Database) *KeywordFilterHitModel {
+	return &KeywordFilterHitModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           keywordFilterHitTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *KeywordFilterHitModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *KeywordFilterHitModel) clone() *KeywordFilterHitModel {
+	return &KeywordFilterHitModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *KeywordFilterHitModel) WithoutGlobalScopes(names ...string) *KeywordFilterHitModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *KeywordFilterHitModel) WithLocalScopes(names ...string) *KeywordFilterHitModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *KeywordFilterHitModel) Condition(builder query.SQLBuilder) *KeywordFilterHitModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *KeywordFilterHitModel) Find(ctx context.Context, id int64) (*KeywordFilterHitN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *KeywordFilterHitModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *KeywordFilterHitModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *KeywordFilterHitModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]KeywordFilterHitN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *KeywordFilterHitModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]KeywordFilterHitN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"filter_id",
+			"user_id",
+			"action",
+			"scope",
+			"content",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "filter_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "action":
+			selectFields = append(selectFields, f)
+		case "scope":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*KeywordFilterHitN, []interface{}) {
+		var keywordFilterHitVar KeywordFilterHitN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &keywordFilterHitVar.Id)
+			case "filter_id":
+				scanFields = append(scanFields, &keywordFilterHitVar.FilterId)
+			case "user_id":
+				scanFields = append(scanFields, &keywordFilterHitVar.UserId)
+			case "action":
+				scanFields = append(scanFields, &keywordFilterHitVar.Action)
+			case "scope":
+				scanFields = append(scanFields, &keywordFilterHitVar.Scope)
+			case "content":
+				scanFields = append(scanFields, &keywordFilterHitVar.Content)
+			case "status":
+				scanFields = append(scanFields, &keywordFilterHitVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &keywordFilterHitVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &keywordFilterHitVar.UpdatedAt)
+			}
+		}
+
+		return &keywordFilterHitVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keywordFilterHits := make([]KeywordFilterHitN, 0)
+	for rows.Next() {
+		keywordFilterHitReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		keywordFilterHitReal.original = &keywordFilterHitOriginal{}
+		_ = query.Copy(keywordFilterHitReal, keywordFilterHitReal.original)
+
+		keywordFilterHitReal.SetModel(m)
+		keywordFilterHits = append(keywordFilterHits, *keywordFilterHitReal)
+	}
+
+	return keywordFilterHits, nil
+}
+
+// First return first result for given query
+func (m *KeywordFilterHitModel) First(ctx context.Context, builders ...query.SQLBuilder) (*KeywordFilterHitN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new keyword_filter_hit to database
+func (m *KeywordFilterHitModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all keyword_filter_hits to database
+func (m *KeywordFilterHitModel) SaveAll(ctx context.Context, keywordFilterHits []KeywordFilterHitN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, keywordFilterHit := range keywordFilterHits {
+		id, err := m.Save(ctx, keywordFilterHit)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a keyword_filter_hit to database
+func (m *KeywordFilterHitModel) Save(ctx context.Context, keywordFilterHit KeywordFilterHitN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, keywordFilterHit.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new keyword_filter_hit or update it when it has a id > 0
+func (m *KeywordFilterHitModel) SaveOrUpdate(ctx context.Context, keywordFilterHit KeywordFilterHitN, onlyFields ...string) (id int64, updated bool, err error) {
+	if keywordFilterHit.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, keywordFilterHit.Id.Int64, keywordFilterHit, onlyFields...)
+		return keywordFilterHit.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, keywordFilterHit, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *KeywordFilterHitModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *KeywordFilterHitModel) Update(ctx context.Context, builder query.SQLBuilder, keywordFilterHit KeywordFilterHitN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, keywordFilterHit.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *KeywordFilterHitModel) UpdateById(ctx context.Context, id int64, keywordFilterHit KeywordFilterHitN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, keywordFilterHit.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *KeywordFilterHitModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *KeywordFilterHitModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -31,6 +31,9 @@ type ChatMessagesN struct {
 	Model         null.String `json:"model,omitempty"`
 	Status        null.Int    `json:"status,omitempty"`
 	Error         null.String `json:"error,omitempty"`
+	Rating        null.Int    `json:"rating,omitempty"`
+	Citations     null.String `json:"citations,omitempty"`
+	Structured    null.String `json:"structured,omitempty"`
 	CreatedAt     null.Time
 	UpdatedAt     null.Time
 }
@@ -59,6 +62,9 @@ type chatMessagesOriginal struct {
 	Model         null.String
 	Status        null.Int
 	Error         null.String
+	Rating        null.Int
+	Citations     null.String
+	Structured    null.String
 	CreatedAt     null.Time
 	UpdatedAt     null.Time
 }
@@ -104,6 +110,15 @@ func (inst *ChatMessagesN) Staled(onlyFields ...string) bool {
 		if inst.Error != inst.original.Error {
 			return true
 		}
+		if inst.Rating != inst.original.Rating {
+			return true
+		}
+		if inst.Citations != inst.original.Citations {
+			return true
+		}
+		if inst.Structured != inst.original.Structured {
+			return true
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			return true
 		}
@@ -158,6 +173,18 @@ func (inst *ChatMessagesN) Staled(onlyFields ...string) bool {
 				if inst.Error != inst.original.Error {
 					return true
 				}
+			case "rating":
+				if inst.Rating != inst.original.Rating {
+					return true
+				}
+			case "citations":
+				if inst.Citations != inst.original.Citations {
+					return true
+				}
+			case "structured":
+				if inst.Structured != inst.original.Structured {
+					return true
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					return true
@@ -217,6 +244,15 @@ func (inst *ChatMessagesN) StaledKV(onlyFields ...string) query.KV {
 		if inst.Error != inst.original.Error {
 			kv["error"] = inst.Error
 		}
+		if inst.Rating != inst.original.Rating {
+			kv["rating"] = inst.Rating
+		}
+		if inst.Citations != inst.original.Citations {
+			kv["citations"] = inst.Citations
+		}
+		if inst.Structured != inst.original.Structured {
+			kv["structured"] = inst.Structured
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			kv["created_at"] = inst.CreatedAt
 		}
@@ -271,6 +307,18 @@ func (inst *ChatMessagesN) StaledKV(onlyFields ...string) query.KV {
 				if inst.Error != inst.original.Error {
 					kv["error"] = inst.Error
 				}
+			case "rating":
+				if inst.Rating != inst.original.Rating {
+					kv["rating"] = inst.Rating
+				}
+			case "citations":
+				if inst.Citations != inst.original.Citations {
+					kv["citations"] = inst.Citations
+				}
+			case "structured":
+				if inst.Structured != inst.original.Structured {
+					kv["structured"] = inst.Structured
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					kv["created_at"] = inst.CreatedAt
@@ -389,6 +437,9 @@ type ChatMessages struct {
 	Model         string `json:"model,omitempty"`
 	Status        int64  `json:"status,omitempty"`
 	Error         string `json:"error,omitempty"`
+	Rating        int64  `json:"rating,omitempty"`
+	Citations     string `json:"citations,omitempty"`
+	Structured    string `json:"structured,omitempty"`
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -408,6 +459,9 @@ func (w ChatMessages) ToChatMessagesN(allows ...string) ChatMessagesN {
 			Model:         null.StringFrom(w.Model),
 			Status:        null.IntFrom(int64(w.Status)),
 			Error:         null.StringFrom(w.Error),
+			Rating:        null.IntFrom(int64(w.Rating)),
+			Citations:     null.StringFrom(w.Citations),
+			Structured:    null.StringFrom(w.Structured),
 			CreatedAt:     null.TimeFrom(w.CreatedAt),
 			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
 		}
@@ -439,6 +493,12 @@ func (w ChatMessages) ToChatMessagesN(allows ...string) ChatMessagesN {
 			res.Status = null.IntFrom(int64(w.Status))
 		case "error":
 			res.Error = null.StringFrom(w.Error)
+		case "rating":
+			res.Rating = null.IntFrom(int64(w.Rating))
+		case "citations":
+			res.Citations = null.StringFrom(w.Citations)
+		case "structured":
+			res.Structured = null.StringFrom(w.Structured)
 		case "created_at":
 			res.CreatedAt = null.TimeFrom(w.CreatedAt)
 		case "updated_at":
@@ -470,6 +530,9 @@ func (w *ChatMessagesN) ToChatMessages() ChatMessages {
 		Model:         w.Model.String,
 		Status:        w.Status.Int64,
 		Error:         w.Error.String,
+		Rating:        w.Rating.Int64,
+		Citations:     w.Citations.String,
+		Structured:    w.Structured.String,
 		CreatedAt:     w.CreatedAt.Time,
 		UpdatedAt:     w.UpdatedAt.Time,
 	}
@@ -505,6 +568,9 @@ const (
 	FieldChatMessagesModel         = "model"
 	FieldChatMessagesStatus        = "status"
 	FieldChatMessagesError         = "error"
+	FieldChatMessagesRating        = "rating"
+	FieldChatMessagesCitations     = "citations"
+	FieldChatMessagesStructured    = "structured"
 	FieldChatMessagesCreatedAt     = "created_at"
 	FieldChatMessagesUpdatedAt     = "updated_at"
 )
@@ -523,6 +589,9 @@ func ChatMessagesFields() []string {
 		"model",
 		"status",
 		"error",
+		"rating",
+		"citations",
+		"structured",
 		"created_at",
 		"updated_at",
 	}
@@ -666,6 +735,9 @@ func (m *ChatMessagesModel) Get(ctx context.Context, builders ...query.SQLBuilde
 			"model",
 			"status",
 			"error",
+			"rating",
+			"citations",
+			"structured",
 			"created_at",
 			"updated_at",
 		)
@@ -699,6 +771,12 @@ func (m *ChatMessagesModel) Get(ctx context.Context, builders ...query.SQLBuilde
 			selectFields = append(selectFields, f)
 		case "error":
 			selectFields = append(selectFields, f)
+		case "rating":
+			selectFields = append(selectFields, f)
+		case "citations":
+			selectFields = append(selectFields, f)
+		case "structured":
+			selectFields = append(selectFields, f)
 		case "created_at":
 			selectFields = append(selectFields, f)
 		case "updated_at":
@@ -735,6 +813,12 @@ func (m *ChatMessagesModel) Get(ctx context.Context, builders ...query.SQLBuilde
 				scanFields = append(scanFields, &chatMessagesVar.Status)
 			case "error":
 				scanFields = append(scanFields, &chatMessagesVar.Error)
+			case "rating":
+				scanFields = append(scanFields, &chatMessagesVar.Rating)
+			case "citations":
+				scanFields = append(scanFields, &chatMessagesVar.Citations)
+			case "structured":
+				scanFields = append(scanFields, &chatMessagesVar.Structured)
 			case "created_at":
 				scanFields = append(scanFields, &chatMessagesVar.CreatedAt)
 			case "updated_at":
@@ -0,0 +1,887 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// MockInterviewN is a MockInterview object, all fields are nullable
+type MockInterviewN struct {
+	original           *mockInterviewOriginal
+	mockInterviewModel *MockInterviewModel
+
+	Id            null.Int    `json:"id"`
+	UserId        null.Int    `json:"user_id"`
+	HistoryId     null.Int    `json:"history_id"`
+	Role          null.String `json:"role"`
+	Level         null.String `json:"level"`
+	Model         null.String `json:"model"`
+	QuestionCount null.Int    `json:"question_count"`
+	Answered      null.Int    `json:"answered"`
+	Transcript    null.String `json:"transcript"`
+	QuotaUsed     null.Int    `json:"quota_used"`
+	Status        null.Int    `json:"status"`
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *MockInterviewN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for MockInterview
+func (inst *MockInterviewN) SetModel(mockInterviewModel *MockInterviewModel) {
+	inst.mockInterviewModel = mockInterviewModel
+}
+
+// mockInterviewOriginal is an object which stores original MockInterview from database
+type mockInterviewOriginal struct {
+	Id            null.Int
+	UserId        null.Int
+	HistoryId     null.Int
+	Role          null.String
+	Level         null.String
+	Model         null.String
+	QuestionCount null.Int
+	Answered      null.Int
+	Transcript    null.String
+	QuotaUsed     null.Int
+	Status        null.Int
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *MockInterviewN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &mockInterviewOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.HistoryId != inst.original.HistoryId {
+			return true
+		}
+		if inst.Role != inst.original.Role {
+			return true
+		}
+		if inst.Level != inst.original.Level {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.QuestionCount != inst.original.QuestionCount {
+			return true
+		}
+		if inst.Answered != inst.original.Answered {
+			return true
+		}
+		if inst.Transcript != inst.original.Transcript {
+			return true
+		}
+		if inst.QuotaUsed != inst.original.QuotaUsed {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "history_id":
+				if inst.HistoryId != inst.original.HistoryId {
+					return true
+				}
+			case "role":
+				if inst.Role != inst.original.Role {
+					return true
+				}
+			case "level":
+				if inst.Level != inst.original.Level {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "question_count":
+				if inst.QuestionCount != inst.original.QuestionCount {
+					return true
+				}
+			case "answered":
+				if inst.Answered != inst.original.Answered {
+					return true
+				}
+			case "transcript":
+				if inst.Transcript != inst.original.Transcript {
+					return true
+				}
+			case "quota_used":
+				if inst.QuotaUsed != inst.original.QuotaUsed {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *MockInterviewN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &mockInterviewOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.HistoryId != inst.original.HistoryId {
+			kv["history_id"] = inst.HistoryId
+		}
+		if inst.Role != inst.original.Role {
+			kv["role"] = inst.Role
+		}
+		if inst.Level != inst.original.Level {
+			kv["level"] = inst.Level
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.QuestionCount != inst.original.QuestionCount {
+			kv["question_count"] = inst.QuestionCount
+		}
+		if inst.Answered != inst.original.Answered {
+			kv["answered"] = inst.Answered
+		}
+		if inst.Transcript != inst.original.Transcript {
+			kv["transcript"] = inst.Transcript
+		}
+		if inst.QuotaUsed != inst.original.QuotaUsed {
+			kv["quota_used"] = inst.QuotaUsed
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "history_id":
+				if inst.HistoryId != inst.original.HistoryId {
+					kv["history_id"] = inst.HistoryId
+				}
+			case "role":
+				if inst.Role != inst.original.Role {
+					kv["role"] = inst.Role
+				}
+			case "level":
+				if inst.Level != inst.original.Level {
+					kv["level"] = inst.Level
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "question_count":
+				if inst.QuestionCount != inst.original.QuestionCount {
+					kv["question_count"] = inst.QuestionCount
+				}
+			case "answered":
+				if inst.Answered != inst.original.Answered {
+					kv["answered"] = inst.Answered
+				}
+			case "transcript":
+				if inst.Transcript != inst.original.Transcript {
+					kv["transcript"] = inst.Transcript
+				}
+			case "quota_used":
+				if inst.QuotaUsed != inst.original.QuotaUsed {
+					kv["quota_used"] = inst.QuotaUsed
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *MockInterviewN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.mockInterviewModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.mockInterviewModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a mock_interview
+func (inst *MockInterviewN) Delete(ctx context.Context) error {
+	if inst.mockInterviewModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.mockInterviewModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *MockInterviewN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type mockInterviewScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var mockInterviewGlobalScopes = make([]mockInterviewScope, 0)
+var mockInterviewLocalScopes = make([]mockInterviewScope, 0)
+
+// AddGlobalScopeForMockInterview assign a global scope to a model
+func AddGlobalScopeForMockInterview(name string, apply func(builder query.Condition)) {
+	mockInterviewGlobalScopes = append(mockInterviewGlobalScopes, mockInterviewScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForMockInterview assign a local scope to a model
+func AddLocalScopeForMockInterview(name string, apply func(builder query.Condition)) {
+	mockInterviewLocalScopes = append(mockInterviewLocalScopes, mockInterviewScope{name: name, apply: apply})
+}
+
+func (m *MockInterviewModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range mockInterviewGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range mockInterviewLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *MockInterviewModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *MockInterviewModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type MockInterview struct {
+	Id            int64  `json:"id"`
+	UserId        int64  `json:"user_id"`
+	HistoryId     int64  `json:"history_id"`
+	Role          string `json:"role"`
+	Level         string `json:"level"`
+	Model         string `json:"model"`
+	QuestionCount int64  `json:"question_count"`
+	Answered      int64  `json:"answered"`
+	Transcript    string `json:"transcript"`
+	QuotaUsed     int64  `json:"quota_used"`
+	Status        int64  `json:"status"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (w MockInterview) ToMockInterviewN(allows ...string) MockInterviewN {
+	if len(allows) == 0 {
+		return MockInterviewN{
+
+			Id:            null.IntFrom(int64(w.Id)),
+			UserId:        null.IntFrom(int64(w.UserId)),
+			HistoryId:     null.IntFrom(int64(w.HistoryId)),
+			Role:          null.StringFrom(w.Role),
+			Level:         null.StringFrom(w.Level),
+			Model:         null.StringFrom(w.Model),
+			QuestionCount: null.IntFrom(int64(w.QuestionCount)),
+			Answered:      null.IntFrom(int64(w.Answered)),
+			Transcript:    null.StringFrom(w.Transcript),
+			QuotaUsed:     null.IntFrom(int64(w.QuotaUsed)),
+			Status:        null.IntFrom(int64(w.Status)),
+			CreatedAt:     null.TimeFrom(w.CreatedAt),
+			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := MockInterviewN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "history_id":
+			res.HistoryId = null.IntFrom(int64(w.HistoryId))
+		case "role":
+			res.Role = null.StringFrom(w.Role)
+		case "level":
+			res.Level = null.StringFrom(w.Level)
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "question_count":
+			res.QuestionCount = null.IntFrom(int64(w.QuestionCount))
+		case "answered":
+			res.Answered = null.IntFrom(int64(w.Answered))
+		case "transcript":
+			res.Transcript = null.StringFrom(w.Transcript)
+		case "quota_used":
+			res.QuotaUsed = null.IntFrom(int64(w.QuotaUsed))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w MockInterview) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *MockInterviewN) ToMockInterview() MockInterview {
+	return MockInterview{
+
+		Id:            w.Id.Int64,
+		UserId:        w.UserId.Int64,
+		HistoryId:     w.HistoryId.Int64,
+		Role:          w.Role.String,
+		Level:         w.Level.String,
+		Model:         w.Model.String,
+		QuestionCount: w.QuestionCount.Int64,
+		Answered:      w.Answered.Int64,
+		Transcript:    w.Transcript.String,
+		QuotaUsed:     w.QuotaUsed.Int64,
+		Status:        w.Status.Int64,
+		CreatedAt:     w.CreatedAt.Time,
+		UpdatedAt:     w.UpdatedAt.Time,
+	}
+}
+
+// MockInterviewModel is a model which encapsulates the operations of the object
+type MockInterviewModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var mockInterviewTableName = "mock_interview"
+
+// MockInterviewTable return table name for MockInterview
+func MockInterviewTable() string {
+	return mockInterviewTableName
+}
+
+const (
+	FieldMockInterviewId            = "id"
+	FieldMockInterviewUserId        = "user_id"
+	FieldMockInterviewHistoryId     = "history_id"
+	FieldMockInterviewRole          = "role"
+	FieldMockInterviewLevel         = "level"
+	FieldMockInterviewModel         = "model"
+	FieldMockInterviewQuestionCount = "question_count"
+	FieldMockInterviewAnswered      = "answered"
+	FieldMockInterviewTranscript    = "transcript"
+	FieldMockInterviewQuotaUsed     = "quota_used"
+	FieldMockInterviewStatus        = "status"
+	FieldMockInterviewCreatedAt     = "created_at"
+	FieldMockInterviewUpdatedAt     = "updated_at"
+)
+
+// MockInterviewFields return all fields in MockInterview model
+func MockInterviewFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"history_id",
+		"role",
+		"level",
+		"model",
+		"question_count",
+		"answered",
+		"transcript",
+		"quota_used",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetMockInterviewTable(tableName string) {
+	mockInterviewTableName = tableName
+}
+
+// NewMockInterviewModel create a MockInterviewModel
+func NewMockInterviewModel(db query.Database) *MockInterviewModel {
+	return &MockInterviewModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           mockInterviewTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *MockInterviewModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *MockInterviewModel) clone() *MockInterviewModel {
+	return &MockInterviewModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *MockInterviewModel) WithoutGlobalScopes(names ...string) *MockInterviewModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *MockInterviewModel) WithLocalScopes(names ...string) *MockInterviewModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *MockInterviewModel) Condition(builder query.SQLBuilder) *MockInterviewModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *MockInterviewModel) Find(ctx context.Context, id int64) (*MockInterviewN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *MockInterviewModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *MockInterviewModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *MockInterviewModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]MockInterviewN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *MockInterviewModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]MockInterviewN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"history_id",
+			"role",
+			"level",
+			"model",
+			"question_count",
+			"answered",
+			"transcript",
+			"quota_used",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "history_id":
+			selectFields = append(selectFields, f)
+		case "role":
+			selectFields = append(selectFields, f)
+		case "level":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "question_count":
+			selectFields = append(selectFields, f)
+		case "answered":
+			selectFields = append(selectFields, f)
+		case "transcript":
+			selectFields = append(selectFields, f)
+		case "quota_used":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*MockInterviewN, []interface{}) {
+		var mockInterviewVar MockInterviewN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &mockInterviewVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &mockInterviewVar.UserId)
+			case "history_id":
+				scanFields = append(scanFields, &mockInterviewVar.HistoryId)
+			case "role":
+				scanFields = append(scanFields, &mockInterviewVar.Role)
+			case "level":
+				scanFields = append(scanFields, &mockInterviewVar.Level)
+			case "model":
+				scanFields = append(scanFields, &mockInterviewVar.Model)
+			case "question_count":
+				scanFields = append(scanFields, &mockInterviewVar.QuestionCount)
+			case "answered":
+				scanFields = append(scanFields, &mockInterviewVar.Answered)
+			case "transcript":
+				scanFields = append(scanFields, &mockInterviewVar.Transcript)
+			case "quota_used":
+				scanFields = append(scanFields, &mockInterviewVar.QuotaUsed)
+			case "status":
+				scanFields = append(scanFields, &mockInterviewVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &mockInterviewVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &mockInterviewVar.UpdatedAt)
+			}
+		}
+
+		return &mockInterviewVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	mockInterviews := make([]MockInterviewN, 0)
+	for rows.Next() {
+		mockInterviewReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		mockInterviewReal.original = &mockInterviewOriginal{}
+		_ = query.Copy(mockInterviewReal, mockInterviewReal.original)
+
+		mockInterviewReal.SetModel(m)
+		mockInterviews = append(mockInterviews, *mockInterviewReal)
+	}
+
+	return mockInterviews, nil
+}
+
+// First return first result for given query
+func (m *MockInterviewModel) First(ctx context.Context, builders ...query.SQLBuilder) (*MockInterviewN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new mock_interview to database
+func (m *MockInterviewModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all mock_interviews to database
+func (m *MockInterviewModel) SaveAll(ctx context.Context, mockInterviews []MockInterviewN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, mockInterview := range mockInterviews {
+		id, err := m.Save(ctx, mockInterview)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a mock_interview to database
+func (m *MockInterviewModel) Save(ctx context.Context, mockInterview MockInterviewN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, mockInterview.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new mock_interview or update it when it has a id > 0
+func (m *MockInterviewModel) SaveOrUpdate(ctx context.Context, mockInterview MockInterviewN, onlyFields ...string) (id int64, updated bool, err error) {
+	if mockInterview.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, mockInterview.Id.Int64, mockInterview, onlyFields...)
+		return mockInterview.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, mockInterview, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *MockInterviewModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *MockInterviewModel) Update(ctx context.Context, builder query.SQLBuilder, mockInterview MockInterviewN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, mockInterview.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *MockInterviewModel) UpdateById(ctx context.Context, id int64, mockInterview MockInterviewN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, mockInterview.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *MockInterviewModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *MockInterviewModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,747 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ModelComparisonN is a ModelComparison object, all fields are nullable
+type ModelComparisonN struct {
+	original             *modelComparisonOriginal
+	modelComparisonModel *ModelComparisonModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Prompt    null.String `json:"prompt"`
+	Models    null.String `json:"models"`
+	Results   null.String `json:"results"`
+	Coins     null.Int    `json:"coins"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ModelComparisonN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ModelComparison
+func (inst *ModelComparisonN) SetModel(modelComparisonModel *ModelComparisonModel) {
+	inst.modelComparisonModel = modelComparisonModel
+}
+
+// modelComparisonOriginal is an object which stores original ModelComparison from database
+type modelComparisonOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Prompt    null.String
+	Models    null.String
+	Results   null.String
+	Coins     null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ModelComparisonN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &modelComparisonOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Prompt != inst.original.Prompt {
+			return true
+		}
+		if inst.Models != inst.original.Models {
+			return true
+		}
+		if inst.Results != inst.original.Results {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "prompt":
+				if inst.Prompt != inst.original.Prompt {
+					return true
+				}
+			case "models":
+				if inst.Models != inst.original.Models {
+					return true
+				}
+			case "results":
+				if inst.Results != inst.original.Results {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ModelComparisonN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &modelComparisonOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Prompt != inst.original.Prompt {
+			kv["prompt"] = inst.Prompt
+		}
+		if inst.Models != inst.original.Models {
+			kv["models"] = inst.Models
+		}
+		if inst.Results != inst.original.Results {
+			kv["results"] = inst.Results
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "prompt":
+				if inst.Prompt != inst.original.Prompt {
+					kv["prompt"] = inst.Prompt
+				}
+			case "models":
+				if inst.Models != inst.original.Models {
+					kv["models"] = inst.Models
+				}
+			case "results":
+				if inst.Results != inst.original.Results {
+					kv["results"] = inst.Results
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ModelComparisonN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.modelComparisonModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.modelComparisonModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a model_comparison
+func (inst *ModelComparisonN) Delete(ctx context.Context) error {
+	if inst.modelComparisonModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.modelComparisonModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ModelComparisonN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type modelComparisonScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var modelComparisonGlobalScopes = make([]modelComparisonScope, 0)
+var modelComparisonLocalScopes = make([]modelComparisonScope, 0)
+
+// AddGlobalScopeForModelComparison assign a global scope to a model
+func AddGlobalScopeForModelComparison(name string, apply func(builder query.Condition)) {
+	modelComparisonGlobalScopes = append(modelComparisonGlobalScopes, modelComparisonScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForModelComparison assign a local scope to a model
+func AddLocalScopeForModelComparison(name string, apply func(builder query.Condition)) {
+	modelComparisonLocalScopes = append(modelComparisonLocalScopes, modelComparisonScope{name: name, apply: apply})
+}
+
+func (m *ModelComparisonModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range modelComparisonGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range modelComparisonLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ModelComparisonModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ModelComparisonModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ModelComparison struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Prompt    string `json:"prompt"`
+	Models    string `json:"models"`
+	Results   string `json:"results"`
+	Coins     int64  `json:"coins"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w ModelComparison) ToModelComparisonN(allows ...string) ModelComparisonN {
+	if len(allows) == 0 {
+		return ModelComparisonN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Prompt:    null.StringFrom(w.Prompt),
+			Models:    null.StringFrom(w.Models),
+			Results:   null.StringFrom(w.Results),
+			Coins:     null.IntFrom(int64(w.Coins)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ModelComparisonN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "prompt":
+			res.Prompt = null.StringFrom(w.Prompt)
+		case "models":
+			res.Models = null.StringFrom(w.Models)
+		case "results":
+			res.Results = null.StringFrom(w.Results)
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ModelComparison) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ModelComparisonN) ToModelComparison() ModelComparison {
+	return ModelComparison{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Prompt:    w.Prompt.String,
+		Models:    w.Models.String,
+		Results:   w.Results.String,
+		Coins:     w.Coins.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// ModelComparisonModel is a model which encapsulates the operations of the object
+type ModelComparisonModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var modelComparisonTableName = "model_comparison"
+
+// ModelComparisonTable return table name for ModelComparison
+func ModelComparisonTable() string {
+	return modelComparisonTableName
+}
+
+const (
+	FieldModelComparisonId        = "id"
+	FieldModelComparisonUserId    = "user_id"
+	FieldModelComparisonPrompt    = "prompt"
+	FieldModelComparisonModels    = "models"
+	FieldModelComparisonResults   = "results"
+	FieldModelComparisonCoins     = "coins"
+	FieldModelComparisonCreatedAt = "created_at"
+	FieldModelComparisonUpdatedAt = "updated_at"
+)
+
+// ModelComparisonFields return all fields in ModelComparison model
+func ModelComparisonFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"prompt",
+		"models",
+		"results",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetModelComparisonTable(tableName string) {
+	modelComparisonTableName = tableName
+}
+
+// NewModelComparisonModel create a ModelComparisonModel
+func NewModelComparisonModel(db query.Database) *ModelComparisonModel {
+	return &ModelComparisonModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           modelComparisonTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ModelComparisonModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ModelComparisonModel) clone() *ModelComparisonModel {
+	return &ModelComparisonModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ModelComparisonModel) WithoutGlobalScopes(names ...string) *ModelComparisonModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ModelComparisonModel) WithLocalScopes(names ...string) *ModelComparisonModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ModelComparisonModel) Condition(builder query.SQLBuilder) *ModelComparisonModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ModelComparisonModel) Find(ctx context.Context, id int64) (*ModelComparisonN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ModelComparisonModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ModelComparisonModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ModelComparisonModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ModelComparisonN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ModelComparisonModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ModelComparisonN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"prompt",
+			"models",
+			"results",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "prompt":
+			selectFields = append(selectFields, f)
+		case "models":
+			selectFields = append(selectFields, f)
+		case "results":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ModelComparisonN, []interface{}) {
+		var modelComparisonVar ModelComparisonN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &modelComparisonVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &modelComparisonVar.UserId)
+			case "prompt":
+				scanFields = append(scanFields, &modelComparisonVar.Prompt)
+			case "models":
+				scanFields = append(scanFields, &modelComparisonVar.Models)
+			case "results":
+				scanFields = append(scanFields, &modelComparisonVar.Results)
+			case "coins":
+				scanFields = append(scanFields, &modelComparisonVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &modelComparisonVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &modelComparisonVar.UpdatedAt)
+			}
+		}
+
+		return &modelComparisonVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	modelComparisons := make([]ModelComparisonN, 0)
+	for rows.Next() {
+		modelComparisonReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		modelComparisonReal.original = &modelComparisonOriginal{}
+		_ = query.Copy(modelComparisonReal, modelComparisonReal.original)
+
+		modelComparisonReal.SetModel(m)
+		modelComparisons = append(modelComparisons, *modelComparisonReal)
+	}
+
+	return modelComparisons, nil
+}
+
+// First return first result for given query
+func (m *ModelComparisonModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ModelComparisonN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new model_comparison to database
+func (m *ModelComparisonModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all model_comparisons to database
+func (m *ModelComparisonModel) SaveAll(ctx context.Context, modelComparisons []ModelComparisonN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, modelComparison := range modelComparisons {
+		id, err := m.Save(ctx, modelComparison)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a model_comparison to database
+func (m *ModelComparisonModel) Save(ctx context.Context, modelComparison ModelComparisonN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, modelComparison.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new model_comparison or update it when it has a id > 0
+func (m *ModelComparisonModel) SaveOrUpdate(ctx context.Context, modelComparison ModelComparisonN, onlyFields ...string) (id int64, updated bool, err error) {
+	if modelComparison.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, modelComparison.Id.Int64, modelComparison, onlyFields...)
+		return modelComparison.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, modelComparison, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ModelComparisonModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ModelComparisonModel) Update(ctx context.Context, builder query.SQLBuilder, modelComparison ModelComparisonN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, modelComparison.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ModelComparisonModel) UpdateById(ctx context.Context, id int64, modelComparison ModelComparisonN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, modelComparison.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ModelComparisonModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ModelComparisonModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,831 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// OcrResultN is a OcrResult object, all fields are nullable
+type OcrResultN struct {
+	original       *ocrResultOriginal
+	ocrResultModel *OcrResultModel
+
+	Id         null.Int    `json:"id"`
+	UserId     null.Int    `json:"user_id"`
+	Engine     null.String `json:"engine"`
+	Pages      null.Int    `json:"pages"`
+	Images     null.String `json:"images"`
+	Text       null.String `json:"text"`
+	Result     null.String `json:"result"`
+	Confidence null.Int    `json:"confidence"`
+	Coins      null.Int    `json:"coins"`
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OcrResultN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OcrResult
+func (inst *OcrResultN) SetModel(ocrResultModel *OcrResultModel) {
+	inst.ocrResultModel = ocrResultModel
+}
+
+// ocrResultOriginal is an object which stores original OcrResult from database
+type ocrResultOriginal struct {
+	Id         null.Int
+	UserId     null.Int
+	Engine     null.String
+	Pages      null.Int
+	Images     null.String
+	Text       null.String
+	Result     null.String
+	Confidence null.Int
+	Coins      null.Int
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OcrResultN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &ocrResultOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Engine != inst.original.Engine {
+			return true
+		}
+		if inst.Pages != inst.original.Pages {
+			return true
+		}
+		if inst.Images != inst.original.Images {
+			return true
+		}
+		if inst.Text != inst.original.Text {
+			return true
+		}
+		if inst.Result != inst.original.Result {
+			return true
+		}
+		if inst.Confidence != inst.original.Confidence {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "engine":
+				if inst.Engine != inst.original.Engine {
+					return true
+				}
+			case "pages":
+				if inst.Pages != inst.original.Pages {
+					return true
+				}
+			case "images":
+				if inst.Images != inst.original.Images {
+					return true
+				}
+			case "text":
+				if inst.Text != inst.original.Text {
+					return true
+				}
+			case "result":
+				if inst.Result != inst.original.Result {
+					return true
+				}
+			case "confidence":
+				if inst.Confidence != inst.original.Confidence {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OcrResultN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &ocrResultOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Engine != inst.original.Engine {
+			kv["engine"] = inst.Engine
+		}
+		if inst.Pages != inst.original.Pages {
+			kv["pages"] = inst.Pages
+		}
+		if inst.Images != inst.original.Images {
+			kv["images"] = inst.Images
+		}
+		if inst.Text != inst.original.Text {
+			kv["text"] = inst.Text
+		}
+		if inst.Result != inst.original.Result {
+			kv["result"] = inst.Result
+		}
+		if inst.Confidence != inst.original.Confidence {
+			kv["confidence"] = inst.Confidence
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "engine":
+				if inst.Engine != inst.original.Engine {
+					kv["engine"] = inst.Engine
+				}
+			case "pages":
+				if inst.Pages != inst.original.Pages {
+					kv["pages"] = inst.Pages
+				}
+			case "images":
+				if inst.Images != inst.original.Images {
+					kv["images"] = inst.Images
+				}
+			case "text":
+				if inst.Text != inst.original.Text {
+					kv["text"] = inst.Text
+				}
+			case "result":
+				if inst.Result != inst.original.Result {
+					kv["result"] = inst.Result
+				}
+			case "confidence":
+				if inst.Confidence != inst.original.Confidence {
+					kv["confidence"] = inst.Confidence
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OcrResultN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.ocrResultModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.ocrResultModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a ocr_result
+func (inst *OcrResultN) Delete(ctx context.Context) error {
+	if inst.ocrResultModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.ocrResultModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OcrResultN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type ocrResultScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var ocrResultGlobalScopes = make([]ocrResultScope, 0)
+var ocrResultLocalScopes = make([]ocrResultScope, 0)
+
+// AddGlobalScopeForOcrResult assign a global scope to a model
+func AddGlobalScopeForOcrResult(name string, apply func(builder query.Condition)) {
+	ocrResultGlobalScopes = append(ocrResultGlobalScopes, ocrResultScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOcrResult assign a local scope to a model
+func AddLocalScopeForOcrResult(name string, apply func(builder query.Condition)) {
+	ocrResultLocalScopes = append(ocrResultLocalScopes, ocrResultScope{name: name, apply: apply})
+}
+
+func (m *OcrResultModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range ocrResultGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range ocrResultLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OcrResultModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OcrResultModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OcrResult struct {
+	Id         int64  `json:"id"`
+	UserId     int64  `json:"user_id"`
+	Engine     string `json:"engine"`
+	Pages      int64  `json:"pages"`
+	Images     string `json:"images"`
+	Text       string `json:"text"`
+	Result     string `json:"result"`
+	Confidence int64  `json:"confidence"`
+	Coins      int64  `json:"coins"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w OcrResult) ToOcrResultN(allows ...string) OcrResultN {
+	if len(allows) == 0 {
+		return OcrResultN{
+
+			Id:         null.IntFrom(int64(w.Id)),
+			UserId:     null.IntFrom(int64(w.UserId)),
+			Engine:     null.StringFrom(w.Engine),
+			Pages:      null.IntFrom(int64(w.Pages)),
+			Images:     null.StringFrom(w.Images),
+			Text:       null.StringFrom(w.Text),
+			Result:     null.StringFrom(w.Result),
+			Confidence: null.IntFrom(int64(w.Confidence)),
+			Coins:      null.IntFrom(int64(w.Coins)),
+			CreatedAt:  null.TimeFrom(w.CreatedAt),
+			UpdatedAt:  null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OcrResultN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "engine":
+			res.Engine = null.StringFrom(w.Engine)
+		case "pages":
+			res.Pages = null.IntFrom(int64(w.Pages))
+		case "images":
+			res.Images = null.StringFrom(w.Images)
+		case "text":
+			res.Text = null.StringFrom(w.Text)
+		case "result":
+			res.Result = null.StringFrom(w.Result)
+		case "confidence":
+			res.Confidence = null.IntFrom(int64(w.Confidence))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OcrResult) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OcrResultN) ToOcrResult() OcrResult {
+	return OcrResult{
+
+		Id:         w.Id.Int64,
+		UserId:     w.UserId.Int64,
+		Engine:     w.Engine.String,
+		Pages:      w.Pages.Int64,
+		Images:     w.Images.String,
+		Text:       w.Text.String,
+		Result:     w.Result.String,
+		Confidence: w.Confidence.Int64,
+		Coins:      w.Coins.Int64,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+	}
+}
+
+// OcrResultModel is a model which encapsulates the operations of the object
+type OcrResultModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var ocrResultTableName = "ocr_result"
+
+// OcrResultTable return table name for OcrResult
+func OcrResultTable() string {
+	return ocrResultTableName
+}
+
+const (
+	FieldOcrResultId         = "id"
+	FieldOcrResultUserId     = "user_id"
+	FieldOcrResultEngine     = "engine"
+	FieldOcrResultPages      = "pages"
+	FieldOcrResultImages     = "images"
+	FieldOcrResultText       = "text"
+	FieldOcrResultResult     = "result"
+	FieldOcrResultConfidence = "confidence"
+	FieldOcrResultCoins      = "coins"
+	FieldOcrResultCreatedAt  = "created_at"
+	FieldOcrResultUpdatedAt  = "updated_at"
+)
+
+// OcrResultFields return all fields in OcrResult model
+func OcrResultFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"engine",
+		"pages",
+		"images",
+		"text",
+		"result",
+		"confidence",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOcrResultTable(tableName string) {
+	ocrResultTableName = tableName
+}
+
+// NewOcrResultModel create a OcrResultModel
+func NewOcrResultModel(db query.Database) *OcrResultModel {
+	return &OcrResultModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           ocrResultTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OcrResultModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OcrResultModel) clone() *OcrResultModel {
+	return &OcrResultModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OcrResultModel) WithoutGlobalScopes(names ...string) *OcrResultModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OcrResultModel) WithLocalScopes(names ...string) *OcrResultModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OcrResultModel) Condition(builder query.SQLBuilder) *OcrResultModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OcrResultModel) Find(ctx context.Context, id int64) (*OcrResultN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OcrResultModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OcrResultModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OcrResultModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OcrResultN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OcrResultModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OcrResultN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"engine",
+			"pages",
+			"images",
+			"text",
+			"result",
+			"confidence",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "engine":
+			selectFields = append(selectFields, f)
+		case "pages":
+			selectFields = append(selectFields, f)
+		case "images":
+			selectFields = append(selectFields, f)
+		case "text":
+			selectFields = append(selectFields, f)
+		case "result":
+			selectFields = append(selectFields, f)
+		case "confidence":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OcrResultN, []interface{}) {
+		var ocrResultVar OcrResultN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &ocrResultVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &ocrResultVar.UserId)
+			case "engine":
+				scanFields = append(scanFields, &ocrResultVar.Engine)
+			case "pages":
+				scanFields = append(scanFields, &ocrResultVar.Pages)
+			case "images":
+				scanFields = append(scanFields, &ocrResultVar.Images)
+			case "text":
+				scanFields = append(scanFields, &ocrResultVar.Text)
+			case "result":
+				scanFields = append(scanFields, &ocrResultVar.Result)
+			case "confidence":
+				scanFields = append(scanFields, &ocrResultVar.Confidence)
+			case "coins":
+				scanFields = append(scanFields, &ocrResultVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &ocrResultVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &ocrResultVar.UpdatedAt)
+			}
+		}
+
+		return &ocrResultVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ocrResults := make([]OcrResultN, 0)
+	for rows.Next() {
+		ocrResultReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		ocrResultReal.original = &ocrResultOriginal{}
+		_ = query.Copy(ocrResultReal, ocrResultReal.original)
+
+		ocrResultReal.SetModel(m)
+		ocrResults = append(ocrResults, *ocrResultReal)
+	}
+
+	return ocrResults, nil
+}
+
+// First return first result for given query
+func (m *OcrResultModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OcrResultN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new ocr_result to database
+func (m *OcrResultModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all ocr_results to database
+func (m *OcrResultModel) SaveAll(ctx context.Context, ocrResults []OcrResultN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, ocrResult := range ocrResults {
+		id, err := m.Save(ctx, ocrResult)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a ocr_result to database
+func (m *OcrResultModel) Save(ctx context.Context, ocrResult OcrResultN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, ocrResult.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new ocr_result or update it when it has a id > 0
+func (m *OcrResultModel) SaveOrUpdate(ctx context.Context, ocrResult OcrResultN, onlyFields ...string) (id int64, updated bool, err error) {
+	if ocrResult.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, ocrResult.Id.Int64, ocrResult, onlyFields...)
+		return ocrResult.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, ocrResult, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OcrResultModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OcrResultModel) Update(ctx context.Context, builder query.SQLBuilder, ocrResult OcrResultN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, ocrResult.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OcrResultModel) UpdateById(ctx context.Context, id int64, ocrResult OcrResultN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, ocrResult.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OcrResultModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OcrResultModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
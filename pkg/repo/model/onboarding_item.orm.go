@@ -0,0 +1,831 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// OnboardingItemN is a OnboardingItem object, all fields are nullable
+type OnboardingItemN struct {
+	original            *onboardingItemOriginal
+	onboardingItemModel *OnboardingItemModel
+
+	Id        null.Int    `json:"id"`
+	Kind      null.String `json:"kind"`
+	Language  null.String `json:"language"`
+	Persona   null.String `json:"persona"`
+	Title     null.String `json:"title"`
+	Content   null.String `json:"content"`
+	Payload   null.String `json:"payload"`
+	Sort      null.Int    `json:"sort"`
+	Status    null.Int    `json:"status"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OnboardingItemN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OnboardingItem
+func (inst *OnboardingItemN) SetModel(onboardingItemModel *OnboardingItemModel) {
+	inst.onboardingItemModel = onboardingItemModel
+}
+
+// onboardingItemOriginal is an object which stores original OnboardingItem from database
+type onboardingItemOriginal struct {
+	Id        null.Int
+	Kind      null.String
+	Language  null.String
+	Persona   null.String
+	Title     null.String
+	Content   null.String
+	Payload   null.String
+	Sort      null.Int
+	Status    null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OnboardingItemN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &onboardingItemOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Kind != inst.original.Kind {
+			return true
+		}
+		if inst.Language != inst.original.Language {
+			return true
+		}
+		if inst.Persona != inst.original.Persona {
+			return true
+		}
+		if inst.Title != inst.original.Title {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.Payload != inst.original.Payload {
+			return true
+		}
+		if inst.Sort != inst.original.Sort {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "kind":
+				if inst.Kind != inst.original.Kind {
+					return true
+				}
+			case "language":
+				if inst.Language != inst.original.Language {
+					return true
+				}
+			case "persona":
+				if inst.Persona != inst.original.Persona {
+					return true
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "payload":
+				if inst.Payload != inst.original.Payload {
+					return true
+				}
+			case "sort":
+				if inst.Sort != inst.original.Sort {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OnboardingItemN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &onboardingItemOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Kind != inst.original.Kind {
+			kv["kind"] = inst.Kind
+		}
+		if inst.Language != inst.original.Language {
+			kv["language"] = inst.Language
+		}
+		if inst.Persona != inst.original.Persona {
+			kv["persona"] = inst.Persona
+		}
+		if inst.Title != inst.original.Title {
+			kv["title"] = inst.Title
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.Payload != inst.original.Payload {
+			kv["payload"] = inst.Payload
+		}
+		if inst.Sort != inst.original.Sort {
+			kv["sort"] = inst.Sort
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "kind":
+				if inst.Kind != inst.original.Kind {
+					kv["kind"] = inst.Kind
+				}
+			case "language":
+				if inst.Language != inst.original.Language {
+					kv["language"] = inst.Language
+				}
+			case "persona":
+				if inst.Persona != inst.original.Persona {
+					kv["persona"] = inst.Persona
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					kv["title"] = inst.Title
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "payload":
+				if inst.Payload != inst.original.Payload {
+					kv["payload"] = inst.Payload
+				}
+			case "sort":
+				if inst.Sort != inst.original.Sort {
+					kv["sort"] = inst.Sort
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OnboardingItemN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.onboardingItemModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.onboardingItemModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a onboarding_item
+func (inst *OnboardingItemN) Delete(ctx context.Context) error {
+	if inst.onboardingItemModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.onboardingItemModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OnboardingItemN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type onboardingItemScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var onboardingItemGlobalScopes = make([]onboardingItemScope, 0)
+var onboardingItemLocalScopes = make([]onboardingItemScope, 0)
+
+// AddGlobalScopeForOnboardingItem assign a global scope to a model
+func AddGlobalScopeForOnboardingItem(name string, apply func(builder query.Condition)) {
+	onboardingItemGlobalScopes = append(onboardingItemGlobalScopes, onboardingItemScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOnboardingItem assign a local scope to a model
+func AddLocalScopeForOnboardingItem(name string, apply func(builder query.Condition)) {
+	onboardingItemLocalScopes = append(onboardingItemLocalScopes, onboardingItemScope{name: name, apply: apply})
+}
+
+func (m *OnboardingItemModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range onboardingItemGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range onboardingItemLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OnboardingItemModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OnboardingItemModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OnboardingItem struct {
+	Id        int64  `json:"id"`
+	Kind      string `json:"kind"`
+	Language  string `json:"language"`
+	Persona   string `json:"persona"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Payload   string `json:"payload"`
+	Sort      int64  `json:"sort"`
+	Status    int64  `json:"status"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w OnboardingItem) ToOnboardingItemN(allows ...string) OnboardingItemN {
+	if len(allows) == 0 {
+		return OnboardingItemN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			Kind:      null.StringFrom(w.Kind),
+			Language:  null.StringFrom(w.Language),
+			Persona:   null.StringFrom(w.Persona),
+			Title:     null.StringFrom(w.Title),
+			Content:   null.StringFrom(w.Content),
+			Payload:   null.StringFrom(w.Payload),
+			Sort:      null.IntFrom(int64(w.Sort)),
+			Status:    null.IntFrom(int64(w.Status)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OnboardingItemN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "kind":
+			res.Kind = null.StringFrom(w.Kind)
+		case "language":
+			res.Language = null.StringFrom(w.Language)
+		case "persona":
+			res.Persona = null.StringFrom(w.Persona)
+		case "title":
+			res.Title = null.StringFrom(w.Title)
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "payload":
+			res.Payload = null.StringFrom(w.Payload)
+		case "sort":
+			res.Sort = null.IntFrom(int64(w.Sort))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OnboardingItem) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OnboardingItemN) ToOnboardingItem() OnboardingItem {
+	return OnboardingItem{
+
+		Id:        w.Id.Int64,
+		Kind:      w.Kind.String,
+		Language:  w.Language.String,
+		Persona:   w.Persona.String,
+		Title:     w.Title.String,
+		Content:   w.Content.String,
+		Payload:   w.Payload.String,
+		Sort:      w.Sort.Int64,
+		Status:    w.Status.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// OnboardingItemModel is a model which encapsulates the operations of the object
+type OnboardingItemModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var onboardingItemTableName = "onboarding_item"
+
+// OnboardingItemTable return table name for OnboardingItem
+func OnboardingItemTable() string {
+	return onboardingItemTableName
+}
+
+const (
+	FieldOnboardingItemId        = "id"
+	FieldOnboardingItemKind      = "kind"
+	FieldOnboardingItemLanguage  = "language"
+	FieldOnboardingItemPersona   = "persona"
+	FieldOnboardingItemTitle     = "title"
+	FieldOnboardingItemContent   = "content"
+	FieldOnboardingItemPayload   = "payload"
+	FieldOnboardingItemSort      = "sort"
+	FieldOnboardingItemStatus    = "status"
+	FieldOnboardingItemCreatedAt = "created_at"
+	FieldOnboardingItemUpdatedAt = "updated_at"
+)
+
+// OnboardingItemFields return all fields in OnboardingItem model
+func OnboardingItemFields() []string {
+	return []string{
+		"id",
+		"kind",
+		"language",
+		"persona",
+		"title",
+		"content",
+		"payload",
+		"sort",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOnboardingItemTable(tableName string) {
+	onboardingItemTableName = tableName
+}
+
+// NewOnboardingItemModel create a OnboardingItemModel
+func NewOnboardingItemModel(db query.Database) *OnboardingItemModel {
+	return &OnboardingItemModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           onboardingItemTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OnboardingItemModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OnboardingItemModel) clone() *OnboardingItemModel {
+	return &OnboardingItemModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OnboardingItemModel) WithoutGlobalScopes(names ...string) *OnboardingItemModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OnboardingItemModel) WithLocalScopes(names ...string) *OnboardingItemModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OnboardingItemModel) Condition(builder query.SQLBuilder) *OnboardingItemModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OnboardingItemModel) Find(ctx context.Context, id int64) (*OnboardingItemN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OnboardingItemModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OnboardingItemModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OnboardingItemModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OnboardingItemN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OnboardingItemModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OnboardingItemN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"kind",
+			"language",
+			"persona",
+			"title",
+			"content",
+			"payload",
+			"sort",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "kind":
+			selectFields = append(selectFields, f)
+		case "language":
+			selectFields = append(selectFields, f)
+		case "persona":
+			selectFields = append(selectFields, f)
+		case "title":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "payload":
+			selectFields = append(selectFields, f)
+		case "sort":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OnboardingItemN, []interface{}) {
+		var onboardingItemVar OnboardingItemN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &onboardingItemVar.Id)
+			case "kind":
+				scanFields = append(scanFields, &onboardingItemVar.Kind)
+			case "language":
+				scanFields = append(scanFields, &onboardingItemVar.Language)
+			case "persona":
+				scanFields = append(scanFields, &onboardingItemVar.Persona)
+			case "title":
+				scanFields = append(scanFields, &onboardingItemVar.Title)
+			case "content":
+				scanFields = append(scanFields, &onboardingItemVar.Content)
+			case "payload":
+				scanFields = append(scanFields, &onboardingItemVar.Payload)
+			case "sort":
+				scanFields = append(scanFields, &onboardingItemVar.Sort)
+			case "status":
+				scanFields = append(scanFields, &onboardingItemVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &onboardingItemVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &onboardingItemVar.UpdatedAt)
+			}
+		}
+
+		return &onboardingItemVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	onboardingItems := make([]OnboardingItemN, 0)
+	for rows.Next() {
+		onboardingItemReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		onboardingItemReal.original = &onboardingItemOriginal{}
+		_ = query.Copy(onboardingItemReal, onboardingItemReal.original)
+
+		onboardingItemReal.SetModel(m)
+		onboardingItems = append(onboardingItems, *onboardingItemReal)
+	}
+
+	return onboardingItems, nil
+}
+
+// First return first result for given query
+func (m *OnboardingItemModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OnboardingItemN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new onboarding_item to database
+func (m *OnboardingItemModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all onboarding_items to database
+func (m *OnboardingItemModel) SaveAll(ctx context.Context, onboardingItems []OnboardingItemN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, onboardingItem := range onboardingItems {
+		id, err := m.Save(ctx, onboardingItem)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a onboarding_item to database
+func (m *OnboardingItemModel) Save(ctx context.Context, onboardingItem OnboardingItemN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, onboardingItem.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new onboarding_item or update it when it has a id > 0
+func (m *OnboardingItemModel) SaveOrUpdate(ctx context.Context, onboardingItem OnboardingItemN, onlyFields ...string) (id int64, updated bool, err error) {
+	if onboardingItem.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, onboardingItem.Id.Int64, onboardingItem, onlyFields...)
+		return onboardingItem.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, onboardingItem, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OnboardingItemModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OnboardingItemModel) Update(ctx context.Context, builder query.SQLBuilder, onboardingItem OnboardingItemN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, onboardingItem.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OnboardingItemModel) UpdateById(ctx context.Context, id int64, onboardingItem OnboardingItemN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, onboardingItem.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OnboardingItemModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OnboardingItemModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
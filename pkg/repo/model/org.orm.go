@@ -0,0 +1,3671 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// OrgN is a Org object, all fields are nullable
+type OrgN struct {
+	original *orgOriginal
+	orgModel *OrgModel
+
+	Id        null.Int    `json:"id"`
+	Name      null.String `json:"name"`
+	OwnerId   null.Int    `json:"owner_id"`
+	Status    null.Int    `json:"status"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for Org
+func (inst *OrgN) SetModel(orgModel *OrgModel) {
+	inst.orgModel = orgModel
+}
+
+// orgOriginal is an object which stores original Org from database
+type orgOriginal struct {
+	Id        null.Int
+	Name      null.String
+	OwnerId   null.Int
+	Status    null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.OwnerId != inst.original.OwnerId {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "owner_id":
+				if inst.OwnerId != inst.original.OwnerId {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.OwnerId != inst.original.OwnerId {
+			kv["owner_id"] = inst.OwnerId
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "owner_id":
+				if inst.OwnerId != inst.original.OwnerId {
+					kv["owner_id"] = inst.OwnerId
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org
+func (inst *OrgN) Delete(ctx context.Context) error {
+	if inst.orgModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgGlobalScopes = make([]orgScope, 0)
+var orgLocalScopes = make([]orgScope, 0)
+
+// AddGlobalScopeForOrg assign a global scope to a model
+func AddGlobalScopeForOrg(name string, apply func(builder query.Condition)) {
+	orgGlobalScopes = append(orgGlobalScopes, orgScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrg assign a local scope to a model
+func AddLocalScopeForOrg(name string, apply func(builder query.Condition)) {
+	orgLocalScopes = append(orgLocalScopes, orgScope{name: name, apply: apply})
+}
+
+func (m *OrgModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type Org struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	OwnerId   int64  `json:"owner_id"`
+	Status    int64  `json:"status"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w Org) ToOrgN(allows ...string) OrgN {
+	if len(allows) == 0 {
+		return OrgN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			Name:      null.StringFrom(w.Name),
+			OwnerId:   null.IntFrom(int64(w.OwnerId)),
+			Status:    null.IntFrom(int64(w.Status)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "owner_id":
+			res.OwnerId = null.IntFrom(int64(w.OwnerId))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w Org) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgN) ToOrg() Org {
+	return Org{
+
+		Id:        w.Id.Int64,
+		Name:      w.Name.String,
+		OwnerId:   w.OwnerId.Int64,
+		Status:    w.Status.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// OrgModel is a model which encapsulates the operations of the object
+type OrgModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgTableName = "org"
+
+// OrgTable return table name for Org
+func OrgTable() string {
+	return orgTableName
+}
+
+const (
+	FieldOrgId        = "id"
+	FieldOrgName      = "name"
+	FieldOrgOwnerId   = "owner_id"
+	FieldOrgStatus    = "status"
+	FieldOrgCreatedAt = "created_at"
+	FieldOrgUpdatedAt = "updated_at"
+)
+
+// OrgFields return all fields in Org model
+func OrgFields() []string {
+	return []string{
+		"id",
+		"name",
+		"owner_id",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgTable(tableName string) {
+	orgTableName = tableName
+}
+
+// NewOrgModel create a OrgModel
+func NewOrgModel(db query.Database) *OrgModel {
+	return &OrgModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgModel) clone() *OrgModel {
+	return &OrgModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgModel) WithoutGlobalScopes(names ...string) *OrgModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgModel) WithLocalScopes(names ...string) *OrgModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgModel) Condition(builder query.SQLBuilder) *OrgModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgModel) Find(ctx context.Context, id int64) (*OrgN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"owner_id",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "owner_id":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgN, []interface{}) {
+		var orgVar OrgN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgVar.Id)
+			case "name":
+				scanFields = append(scanFields, &orgVar.Name)
+			case "owner_id":
+				scanFields = append(scanFields, &orgVar.OwnerId)
+			case "status":
+				scanFields = append(scanFields, &orgVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &orgVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgVar.UpdatedAt)
+			}
+		}
+
+		return &orgVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgs := make([]OrgN, 0)
+	for rows.Next() {
+		orgReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgReal.original = &orgOriginal{}
+		_ = query.Copy(orgReal, orgReal.original)
+
+		orgReal.SetModel(m)
+		orgs = append(orgs, *orgReal)
+	}
+
+	return orgs, nil
+}
+
+// First return first result for given query
+func (m *OrgModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org to database
+func (m *OrgModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all orgs to database
+func (m *OrgModel) SaveAll(ctx context.Context, orgs []OrgN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, org := range orgs {
+		id, err := m.Save(ctx, org)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org to database
+func (m *OrgModel) Save(ctx context.Context, org OrgN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, org.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org or update it when it has a id > 0
+func (m *OrgModel) SaveOrUpdate(ctx context.Context, org OrgN, onlyFields ...string) (id int64, updated bool, err error) {
+	if org.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, org.Id.Int64, org, onlyFields...)
+		return org.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, org, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgModel) Update(ctx context.Context, builder query.SQLBuilder, org OrgN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, org.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgModel) UpdateById(ctx context.Context, id int64, org OrgN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, org.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// OrgMemberN is a OrgMember object, all fields are nullable
+type OrgMemberN struct {
+	original       *orgMemberOriginal
+	orgMemberModel *OrgMemberModel
+
+	Id           null.Int `json:"id"`
+	OrgId        null.Int `json:"org_id"`
+	UserId       null.Int `json:"user_id"`
+	Role         null.Int `json:"role"`
+	Status       null.Int `json:"status"`
+	MonthlyLimit null.Int `json:"monthly_limit"`
+	InvitedBy    null.Int `json:"invited_by"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgMemberN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OrgMember
+func (inst *OrgMemberN) SetModel(orgMemberModel *OrgMemberModel) {
+	inst.orgMemberModel = orgMemberModel
+}
+
+// orgMemberOriginal is an object which stores original OrgMember from database
+type orgMemberOriginal struct {
+	Id           null.Int
+	OrgId        null.Int
+	UserId       null.Int
+	Role         null.Int
+	Status       null.Int
+	MonthlyLimit null.Int
+	InvitedBy    null.Int
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgMemberN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgMemberOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Role != inst.original.Role {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.MonthlyLimit != inst.original.MonthlyLimit {
+			return true
+		}
+		if inst.InvitedBy != inst.original.InvitedBy {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "role":
+				if inst.Role != inst.original.Role {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "monthly_limit":
+				if inst.MonthlyLimit != inst.original.MonthlyLimit {
+					return true
+				}
+			case "invited_by":
+				if inst.InvitedBy != inst.original.InvitedBy {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgMemberN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgMemberOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Role != inst.original.Role {
+			kv["role"] = inst.Role
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.MonthlyLimit != inst.original.MonthlyLimit {
+			kv["monthly_limit"] = inst.MonthlyLimit
+		}
+		if inst.InvitedBy != inst.original.InvitedBy {
+			kv["invited_by"] = inst.InvitedBy
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "role":
+				if inst.Role != inst.original.Role {
+					kv["role"] = inst.Role
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "monthly_limit":
+				if inst.MonthlyLimit != inst.original.MonthlyLimit {
+					kv["monthly_limit"] = inst.MonthlyLimit
+				}
+			case "invited_by":
+				if inst.InvitedBy != inst.original.InvitedBy {
+					kv["invited_by"] = inst.InvitedBy
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgMemberN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgMemberModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgMemberModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org_member
+func (inst *OrgMemberN) Delete(ctx context.Context) error {
+	if inst.orgMemberModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgMemberModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgMemberN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgMemberScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgMemberGlobalScopes = make([]orgMemberScope, 0)
+var orgMemberLocalScopes = make([]orgMemberScope, 0)
+
+// AddGlobalScopeForOrgMember assign a global scope to a model
+func AddGlobalScopeForOrgMember(name string, apply func(builder query.Condition)) {
+	orgMemberGlobalScopes = append(orgMemberGlobalScopes, orgMemberScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrgMember assign a local scope to a model
+func AddLocalScopeForOrgMember(name string, apply func(builder query.Condition)) {
+	orgMemberLocalScopes = append(orgMemberLocalScopes, orgMemberScope{name: name, apply: apply})
+}
+
+func (m *OrgMemberModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgMemberGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgMemberLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgMemberModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgMemberModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OrgMember struct {
+	Id           int64 `json:"id"`
+	OrgId        int64 `json:"org_id"`
+	UserId       int64 `json:"user_id"`
+	Role         int64 `json:"role"`
+	Status       int64 `json:"status"`
+	MonthlyLimit int64 `json:"monthly_limit"`
+	InvitedBy    int64 `json:"invited_by"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w OrgMember) ToOrgMemberN(allows ...string) OrgMemberN {
+	if len(allows) == 0 {
+		return OrgMemberN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			OrgId:        null.IntFrom(int64(w.OrgId)),
+			UserId:       null.IntFrom(int64(w.UserId)),
+			Role:         null.IntFrom(int64(w.Role)),
+			Status:       null.IntFrom(int64(w.Status)),
+			MonthlyLimit: null.IntFrom(int64(w.MonthlyLimit)),
+			InvitedBy:    null.IntFrom(int64(w.InvitedBy)),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgMemberN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "role":
+			res.Role = null.IntFrom(int64(w.Role))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "monthly_limit":
+			res.MonthlyLimit = null.IntFrom(int64(w.MonthlyLimit))
+		case "invited_by":
+			res.InvitedBy = null.IntFrom(int64(w.InvitedBy))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OrgMember) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgMemberN) ToOrgMember() OrgMember {
+	return OrgMember{
+
+		Id:           w.Id.Int64,
+		OrgId:        w.OrgId.Int64,
+		UserId:       w.UserId.Int64,
+		Role:         w.Role.Int64,
+		Status:       w.Status.Int64,
+		MonthlyLimit: w.MonthlyLimit.Int64,
+		InvitedBy:    w.InvitedBy.Int64,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// OrgMemberModel is a model which encapsulates the operations of the object
+type OrgMemberModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgMemberTableName = "org_member"
+
+// OrgMemberTable return table name for OrgMember
+func OrgMemberTable() string {
+	return orgMemberTableName
+}
+
+const (
+	FieldOrgMemberId           = "id"
+	FieldOrgMemberOrgId        = "org_id"
+	FieldOrgMemberUserId       = "user_id"
+	FieldOrgMemberRole         = "role"
+	FieldOrgMemberStatus       = "status"
+	FieldOrgMemberMonthlyLimit = "monthly_limit"
+	FieldOrgMemberInvitedBy    = "invited_by"
+	FieldOrgMemberCreatedAt    = "created_at"
+	FieldOrgMemberUpdatedAt    = "updated_at"
+)
+
+// OrgMemberFields return all fields in OrgMember model
+func OrgMemberFields() []string {
+	return []string{
+		"id",
+		"org_id",
+		"user_id",
+		"role",
+		"status",
+		"monthly_limit",
+		"invited_by",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgMemberTable(tableName string) {
+	orgMemberTableName = tableName
+}
+
+// NewOrgMemberModel create a OrgMemberModel
+func NewOrgMemberModel(db query.Database) *OrgMemberModel {
+	return &OrgMemberModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgMemberTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgMemberModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgMemberModel) clone() *OrgMemberModel {
+	return &OrgMemberModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgMemberModel) WithoutGlobalScopes(names ...string) *OrgMemberModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgMemberModel) WithLocalScopes(names ...string) *OrgMemberModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgMemberModel) Condition(builder query.SQLBuilder) *OrgMemberModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgMemberModel) Find(ctx context.Context, id int64) (*OrgMemberN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgMemberModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgMemberModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgMemberModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgMemberN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgMemberModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgMemberN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"org_id",
+			"user_id",
+			"role",
+			"status",
+			"monthly_limit",
+			"invited_by",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "role":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "monthly_limit":
+			selectFields = append(selectFields, f)
+		case "invited_by":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgMemberN, []interface{}) {
+		var orgMemberVar OrgMemberN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgMemberVar.Id)
+			case "org_id":
+				scanFields = append(scanFields, &orgMemberVar.OrgId)
+			case "user_id":
+				scanFields = append(scanFields, &orgMemberVar.UserId)
+			case "role":
+				scanFields = append(scanFields, &orgMemberVar.Role)
+			case "status":
+				scanFields = append(scanFields, &orgMemberVar.Status)
+			case "monthly_limit":
+				scanFields = append(scanFields, &orgMemberVar.MonthlyLimit)
+			case "invited_by":
+				scanFields = append(scanFields, &orgMemberVar.InvitedBy)
+			case "created_at":
+				scanFields = append(scanFields, &orgMemberVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgMemberVar.UpdatedAt)
+			}
+		}
+
+		return &orgMemberVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgMembers := make([]OrgMemberN, 0)
+	for rows.Next() {
+		orgMemberReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgMemberReal.original = &orgMemberOriginal{}
+		_ = query.Copy(orgMemberReal, orgMemberReal.original)
+
+		orgMemberReal.SetModel(m)
+		orgMembers = append(orgMembers, *orgMemberReal)
+	}
+
+	return orgMembers, nil
+}
+
+// First return first result for given query
+func (m *OrgMemberModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgMemberN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org_member to database
+func (m *OrgMemberModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all org_members to database
+func (m *OrgMemberModel) SaveAll(ctx context.Context, orgMembers []OrgMemberN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, orgMember := range orgMembers {
+		id, err := m.Save(ctx, orgMember)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org_member to database
+func (m *OrgMemberModel) Save(ctx context.Context, orgMember OrgMemberN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, orgMember.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org_member or update it when it has a id > 0
+func (m *OrgMemberModel) SaveOrUpdate(ctx context.Context, orgMember OrgMemberN, onlyFields ...string) (id int64, updated bool, err error) {
+	if orgMember.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, orgMember.Id.Int64, orgMember, onlyFields...)
+		return orgMember.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, orgMember, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgMemberModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgMemberModel) Update(ctx context.Context, builder query.SQLBuilder, orgMember OrgMemberN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, orgMember.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgMemberModel) UpdateById(ctx context.Context, id int64, orgMember OrgMemberN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, orgMember.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgMemberModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgMemberModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// OrgQuotaN is a OrgQuota object, all fields are nullable
+type OrgQuotaN struct {
+	original      *orgQuotaOriginal
+	orgQuotaModel *OrgQuotaModel
+
+	Id            null.Int    `json:"id"`
+	OrgId         null.Int    `json:"org_id"`
+	Quota         null.Int    `json:"quota"`
+	Rest          null.Int    `json:"rest"`
+	Note          null.String `json:"note"`
+	PaymentId     null.String `json:"payment_id"`
+	PeriodStartAt null.Time   `json:"period_start_at"`
+	PeriodEndAt   null.Time   `json:"period_end_at"`
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgQuotaN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OrgQuota
+func (inst *OrgQuotaN) SetModel(orgQuotaModel *OrgQuotaModel) {
+	inst.orgQuotaModel = orgQuotaModel
+}
+
+// orgQuotaOriginal is an object which stores original OrgQuota from database
+type orgQuotaOriginal struct {
+	Id            null.Int
+	OrgId         null.Int
+	Quota         null.Int
+	Rest          null.Int
+	Note          null.String
+	PaymentId     null.String
+	PeriodStartAt null.Time
+	PeriodEndAt   null.Time
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgQuotaN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgQuotaOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.Quota != inst.original.Quota {
+			return true
+		}
+		if inst.Rest != inst.original.Rest {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.PaymentId != inst.original.PaymentId {
+			return true
+		}
+		if inst.PeriodStartAt != inst.original.PeriodStartAt {
+			return true
+		}
+		if inst.PeriodEndAt != inst.original.PeriodEndAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "quota":
+				if inst.Quota != inst.original.Quota {
+					return true
+				}
+			case "rest":
+				if inst.Rest != inst.original.Rest {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "payment_id":
+				if inst.PaymentId != inst.original.PaymentId {
+					return true
+				}
+			case "period_start_at":
+				if inst.PeriodStartAt != inst.original.PeriodStartAt {
+					return true
+				}
+			case "period_end_at":
+				if inst.PeriodEndAt != inst.original.PeriodEndAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgQuotaN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgQuotaOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.Quota != inst.original.Quota {
+			kv["quota"] = inst.Quota
+		}
+		if inst.Rest != inst.original.Rest {
+			kv["rest"] = inst.Rest
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.PaymentId != inst.original.PaymentId {
+			kv["payment_id"] = inst.PaymentId
+		}
+		if inst.PeriodStartAt != inst.original.PeriodStartAt {
+			kv["period_start_at"] = inst.PeriodStartAt
+		}
+		if inst.PeriodEndAt != inst.original.PeriodEndAt {
+			kv["period_end_at"] = inst.PeriodEndAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "quota":
+				if inst.Quota != inst.original.Quota {
+					kv["quota"] = inst.Quota
+				}
+			case "rest":
+				if inst.Rest != inst.original.Rest {
+					kv["rest"] = inst.Rest
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "payment_id":
+				if inst.PaymentId != inst.original.PaymentId {
+					kv["payment_id"] = inst.PaymentId
+				}
+			case "period_start_at":
+				if inst.PeriodStartAt != inst.original.PeriodStartAt {
+					kv["period_start_at"] = inst.PeriodStartAt
+				}
+			case "period_end_at":
+				if inst.PeriodEndAt != inst.original.PeriodEndAt {
+					kv["period_end_at"] = inst.PeriodEndAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgQuotaN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgQuotaModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgQuotaModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org_quota
+func (inst *OrgQuotaN) Delete(ctx context.Context) error {
+	if inst.orgQuotaModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgQuotaModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgQuotaN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgQuotaScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgQuotaGlobalScopes = make([]orgQuotaScope, 0)
+var orgQuotaLocalScopes = make([]orgQuotaScope, 0)
+
+// AddGlobalScopeForOrgQuota assign a global scope to a model
+func AddGlobalScopeForOrgQuota(name string, apply func(builder query.Condition)) {
+	orgQuotaGlobalScopes = append(orgQuotaGlobalScopes, orgQuotaScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrgQuota assign a local scope to a model
+func AddLocalScopeForOrgQuota(name string, apply func(builder query.Condition)) {
+	orgQuotaLocalScopes = append(orgQuotaLocalScopes, orgQuotaScope{name: name, apply: apply})
+}
+
+func (m *OrgQuotaModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgQuotaGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgQuotaLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgQuotaModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgQuotaModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OrgQuota struct {
+	Id            int64     `json:"id"`
+	OrgId         int64     `json:"org_id"`
+	Quota         int64     `json:"quota"`
+	Rest          int64     `json:"rest"`
+	Note          string    `json:"note"`
+	PaymentId     string    `json:"payment_id"`
+	PeriodStartAt time.Time `json:"period_start_at"`
+	PeriodEndAt   time.Time `json:"period_end_at"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (w OrgQuota) ToOrgQuotaN(allows ...string) OrgQuotaN {
+	if len(allows) == 0 {
+		return OrgQuotaN{
+
+			Id:            null.IntFrom(int64(w.Id)),
+			OrgId:         null.IntFrom(int64(w.OrgId)),
+			Quota:         null.IntFrom(int64(w.Quota)),
+			Rest:          null.IntFrom(int64(w.Rest)),
+			Note:          null.StringFrom(w.Note),
+			PaymentId:     null.StringFrom(w.PaymentId),
+			PeriodStartAt: null.TimeFrom(w.PeriodStartAt),
+			PeriodEndAt:   null.TimeFrom(w.PeriodEndAt),
+			CreatedAt:     null.TimeFrom(w.CreatedAt),
+			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgQuotaN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "quota":
+			res.Quota = null.IntFrom(int64(w.Quota))
+		case "rest":
+			res.Rest = null.IntFrom(int64(w.Rest))
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "payment_id":
+			res.PaymentId = null.StringFrom(w.PaymentId)
+		case "period_start_at":
+			res.PeriodStartAt = null.TimeFrom(w.PeriodStartAt)
+		case "period_end_at":
+			res.PeriodEndAt = null.TimeFrom(w.PeriodEndAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OrgQuota) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgQuotaN) ToOrgQuota() OrgQuota {
+	return OrgQuota{
+
+		Id:            w.Id.Int64,
+		OrgId:         w.OrgId.Int64,
+		Quota:         w.Quota.Int64,
+		Rest:          w.Rest.Int64,
+		Note:          w.Note.String,
+		PaymentId:     w.PaymentId.String,
+		PeriodStartAt: w.PeriodStartAt.Time,
+		PeriodEndAt:   w.PeriodEndAt.Time,
+		CreatedAt:     w.CreatedAt.Time,
+		UpdatedAt:     w.UpdatedAt.Time,
+	}
+}
+
+// OrgQuotaModel is a model which encapsulates the operations of the object
+type OrgQuotaModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgQuotaTableName = "org_quota"
+
+// OrgQuotaTable return table name for OrgQuota
+func OrgQuotaTable() string {
+	return orgQuotaTableName
+}
+
+const (
+	FieldOrgQuotaId            = "id"
+	FieldOrgQuotaOrgId         = "org_id"
+	FieldOrgQuotaQuota         = "quota"
+	FieldOrgQuotaRest          = "rest"
+	FieldOrgQuotaNote          = "note"
+	FieldOrgQuotaPaymentId     = "payment_id"
+	FieldOrgQuotaPeriodStartAt = "period_start_at"
+	FieldOrgQuotaPeriodEndAt   = "period_end_at"
+	FieldOrgQuotaCreatedAt     = "created_at"
+	FieldOrgQuotaUpdatedAt     = "updated_at"
+)
+
+// OrgQuotaFields return all fields in OrgQuota model
+func OrgQuotaFields() []string {
+	return []string{
+		"id",
+		"org_id",
+		"quota",
+		"rest",
+		"note",
+		"payment_id",
+		"period_start_at",
+		"period_end_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgQuotaTable(tableName string) {
+	orgQuotaTableName = tableName
+}
+
+// NewOrgQuotaModel create a OrgQuotaModel
+func NewOrgQuotaModel(db query.Database) *OrgQuotaModel {
+	return &OrgQuotaModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgQuotaTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgQuotaModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgQuotaModel) clone() *OrgQuotaModel {
+	return &OrgQuotaModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgQuotaModel) WithoutGlobalScopes(names ...string) *OrgQuotaModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgQuotaModel) WithLocalScopes(names ...string) *OrgQuotaModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgQuotaModel) Condition(builder query.SQLBuilder) *OrgQuotaModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgQuotaModel) Find(ctx context.Context, id int64) (*OrgQuotaN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgQuotaModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgQuotaModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgQuotaModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgQuotaN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgQuotaModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgQuotaN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"org_id",
+			"quota",
+			"rest",
+			"note",
+			"payment_id",
+			"period_start_at",
+			"period_end_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "quota":
+			selectFields = append(selectFields, f)
+		case "rest":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "payment_id":
+			selectFields = append(selectFields, f)
+		case "period_start_at":
+			selectFields = append(selectFields, f)
+		case "period_end_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgQuotaN, []interface{}) {
+		var orgQuotaVar OrgQuotaN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgQuotaVar.Id)
+			case "org_id":
+				scanFields = append(scanFields, &orgQuotaVar.OrgId)
+			case "quota":
+				scanFields = append(scanFields, &orgQuotaVar.Quota)
+			case "rest":
+				scanFields = append(scanFields, &orgQuotaVar.Rest)
+			case "note":
+				scanFields = append(scanFields, &orgQuotaVar.Note)
+			case "payment_id":
+				scanFields = append(scanFields, &orgQuotaVar.PaymentId)
+			case "period_start_at":
+				scanFields = append(scanFields, &orgQuotaVar.PeriodStartAt)
+			case "period_end_at":
+				scanFields = append(scanFields, &orgQuotaVar.PeriodEndAt)
+			case "created_at":
+				scanFields = append(scanFields, &orgQuotaVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgQuotaVar.UpdatedAt)
+			}
+		}
+
+		return &orgQuotaVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgQuotas := make([]OrgQuotaN, 0)
+	for rows.Next() {
+		orgQuotaReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgQuotaReal.original = &orgQuotaOriginal{}
+		_ = query.Copy(orgQuotaReal, orgQuotaReal.original)
+
+		orgQuotaReal.SetModel(m)
+		orgQuotas = append(orgQuotas, *orgQuotaReal)
+	}
+
+	return orgQuotas, nil
+}
+
+// First return first result for given query
+func (m *OrgQuotaModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgQuotaN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org_quota to database
+func (m *OrgQuotaModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all org_quotas to database
+func (m *OrgQuotaModel) SaveAll(ctx context.Context, orgQuotas []OrgQuotaN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, orgQuota := range orgQuotas {
+		id, err := m.Save(ctx, orgQuota)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org_quota to database
+func (m *OrgQuotaModel) Save(ctx context.Context, orgQuota OrgQuotaN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, orgQuota.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org_quota or update it when it has a id > 0
+func (m *OrgQuotaModel) SaveOrUpdate(ctx context.Context, orgQuota OrgQuotaN, onlyFields ...string) (id int64, updated bool, err error) {
+	if orgQuota.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, orgQuota.Id.Int64, orgQuota, onlyFields...)
+		return orgQuota.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, orgQuota, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgQuotaModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgQuotaModel) Update(ctx context.Context, builder query.SQLBuilder, orgQuota OrgQuotaN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, orgQuota.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgQuotaModel) UpdateById(ctx context.Context, id int64, orgQuota OrgQuotaN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, orgQuota.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgQuotaModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgQuotaModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// OrgQuotaUsageN is a OrgQuotaUsage object, all fields are nullable
+type OrgQuotaUsageN struct {
+	original           *orgQuotaUsageOriginal
+	orgQuotaUsageModel *OrgQuotaUsageModel
+
+	Id        null.Int    `json:"id"`
+	OrgId     null.Int    `json:"org_id"`
+	UserId    null.Int    `json:"user_id"`
+	Used      null.Int    `json:"used"`
+	Debt      null.Int    `json:"debt"`
+	QuotaIds  null.String `json:"quota_ids"`
+	Meta      null.String `json:"meta"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgQuotaUsageN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OrgQuotaUsage
+func (inst *OrgQuotaUsageN) SetModel(orgQuotaUsageModel *OrgQuotaUsageModel) {
+	inst.orgQuotaUsageModel = orgQuotaUsageModel
+}
+
+// orgQuotaUsageOriginal is an object which stores original OrgQuotaUsage from database
+type orgQuotaUsageOriginal struct {
+	Id        null.Int
+	OrgId     null.Int
+	UserId    null.Int
+	Used      null.Int
+	Debt      null.Int
+	QuotaIds  null.String
+	Meta      null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgQuotaUsageN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgQuotaUsageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Used != inst.original.Used {
+			return true
+		}
+		if inst.Debt != inst.original.Debt {
+			return true
+		}
+		if inst.QuotaIds != inst.original.QuotaIds {
+			return true
+		}
+		if inst.Meta != inst.original.Meta {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "used":
+				if inst.Used != inst.original.Used {
+					return true
+				}
+			case "debt":
+				if inst.Debt != inst.original.Debt {
+					return true
+				}
+			case "quota_ids":
+				if inst.QuotaIds != inst.original.QuotaIds {
+					return true
+				}
+			case "meta":
+				if inst.Meta != inst.original.Meta {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgQuotaUsageN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgQuotaUsageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Used != inst.original.Used {
+			kv["used"] = inst.Used
+		}
+		if inst.Debt != inst.original.Debt {
+			kv["debt"] = inst.Debt
+		}
+		if inst.QuotaIds != inst.original.QuotaIds {
+			kv["quota_ids"] = inst.QuotaIds
+		}
+		if inst.Meta != inst.original.Meta {
+			kv["meta"] = inst.Meta
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "used":
+				if inst.Used != inst.original.Used {
+					kv["used"] = inst.Used
+				}
+			case "debt":
+				if inst.Debt != inst.original.Debt {
+					kv["debt"] = inst.Debt
+				}
+			case "quota_ids":
+				if inst.QuotaIds != inst.original.QuotaIds {
+					kv["quota_ids"] = inst.QuotaIds
+				}
+			case "meta":
+				if inst.Meta != inst.original.Meta {
+					kv["meta"] = inst.Meta
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgQuotaUsageN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgQuotaUsageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgQuotaUsageModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org_quota_usage
+func (inst *OrgQuotaUsageN) Delete(ctx context.Context) error {
+	if inst.orgQuotaUsageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgQuotaUsageModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgQuotaUsageN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgQuotaUsageScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgQuotaUsageGlobalScopes = make([]orgQuotaUsageScope, 0)
+var orgQuotaUsageLocalScopes = make([]orgQuotaUsageScope, 0)
+
+// AddGlobalScopeForOrgQuotaUsage assign a global scope to a model
+func AddGlobalScopeForOrgQuotaUsage(name string, apply func(builder query.Condition)) {
+	orgQuotaUsageGlobalScopes = append(orgQuotaUsageGlobalScopes, orgQuotaUsageScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrgQuotaUsage assign a local scope to a model
+func AddLocalScopeForOrgQuotaUsage(name string, apply func(builder query.Condition)) {
+	orgQuotaUsageLocalScopes = append(orgQuotaUsageLocalScopes, orgQuotaUsageScope{name: name, apply: apply})
+}
+
+func (m *OrgQuotaUsageModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgQuotaUsageGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgQuotaUsageLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgQuotaUsageModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgQuotaUsageModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OrgQuotaUsage struct {
+	Id        int64  `json:"id"`
+	OrgId     int64  `json:"org_id"`
+	UserId    int64  `json:"user_id"`
+	Used      int64  `json:"used"`
+	Debt      int64  `json:"debt"`
+	QuotaIds  string `json:"quota_ids"`
+	Meta      string `json:"meta"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w OrgQuotaUsage) ToOrgQuotaUsageN(allows ...string) OrgQuotaUsageN {
+	if len(allows) == 0 {
+		return OrgQuotaUsageN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			OrgId:     null.IntFrom(int64(w.OrgId)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Used:      null.IntFrom(int64(w.Used)),
+			Debt:      null.IntFrom(int64(w.Debt)),
+			QuotaIds:  null.StringFrom(w.QuotaIds),
+			Meta:      null.StringFrom(w.Meta),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgQuotaUsageN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "used":
+			res.Used = null.IntFrom(int64(w.Used))
+		case "debt":
+			res.Debt = null.IntFrom(int64(w.Debt))
+		case "quota_ids":
+			res.QuotaIds = null.StringFrom(w.QuotaIds)
+		case "meta":
+			res.Meta = null.StringFrom(w.Meta)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OrgQuotaUsage) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgQuotaUsageN) ToOrgQuotaUsage() OrgQuotaUsage {
+	return OrgQuotaUsage{
+
+		Id:        w.Id.Int64,
+		OrgId:     w.OrgId.Int64,
+		UserId:    w.UserId.Int64,
+		Used:      w.Used.Int64,
+		Debt:      w.Debt.Int64,
+		QuotaIds:  w.QuotaIds.String,
+		Meta:      w.Meta.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// OrgQuotaUsageModel is a model which encapsulates the operations of the object
+type OrgQuotaUsageModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgQuotaUsageTableName = "org_quota_usage"
+
+// OrgQuotaUsageTable return table name for OrgQuotaUsage
+func OrgQuotaUsageTable() string {
+	return orgQuotaUsageTableName
+}
+
+const (
+	FieldOrgQuotaUsageId        = "id"
+	FieldOrgQuotaUsageOrgId     = "org_id"
+	FieldOrgQuotaUsageUserId    = "user_id"
+	FieldOrgQuotaUsageUsed      = "used"
+	FieldOrgQuotaUsageDebt      = "debt"
+	FieldOrgQuotaUsageQuotaIds  = "quota_ids"
+	FieldOrgQuotaUsageMeta      = "meta"
+	FieldOrgQuotaUsageCreatedAt = "created_at"
+	FieldOrgQuotaUsageUpdatedAt = "updated_at"
+)
+
+// OrgQuotaUsageFields return all fields in OrgQuotaUsage model
+func OrgQuotaUsageFields() []string {
+	return []string{
+		"id",
+		"org_id",
+		"user_id",
+		"used",
+		"debt",
+		"quota_ids",
+		"meta",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgQuotaUsageTable(tableName string) {
+	orgQuotaUsageTableName = tableName
+}
+
+// NewOrgQuotaUsageModel create a OrgQuotaUsageModel
+func NewOrgQuotaUsageModel(db query.Database) *OrgQuotaUsageModel {
+	return &OrgQuotaUsageModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgQuotaUsageTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgQuotaUsageModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgQuotaUsageModel) clone() *OrgQuotaUsageModel {
+	return &OrgQuotaUsageModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgQuotaUsageModel) WithoutGlobalScopes(names ...string) *OrgQuotaUsageModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgQuotaUsageModel) WithLocalScopes(names ...string) *OrgQuotaUsageModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgQuotaUsageModel) Condition(builder query.SQLBuilder) *OrgQuotaUsageModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgQuotaUsageModel) Find(ctx context.Context, id int64) (*OrgQuotaUsageN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgQuotaUsageModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgQuotaUsageModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgQuotaUsageModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgQuotaUsageN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgQuotaUsageModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgQuotaUsageN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"org_id",
+			"user_id",
+			"used",
+			"debt",
+			"quota_ids",
+			"meta",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "used":
+			selectFields = append(selectFields, f)
+		case "debt":
+			selectFields = append(selectFields, f)
+		case "quota_ids":
+			selectFields = append(selectFields, f)
+		case "meta":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgQuotaUsageN, []interface{}) {
+		var orgQuotaUsageVar OrgQuotaUsageN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgQuotaUsageVar.Id)
+			case "org_id":
+				scanFields = append(scanFields, &orgQuotaUsageVar.OrgId)
+			case "user_id":
+				scanFields = append(scanFields, &orgQuotaUsageVar.UserId)
+			case "used":
+				scanFields = append(scanFields, &orgQuotaUsageVar.Used)
+			case "debt":
+				scanFields = append(scanFields, &orgQuotaUsageVar.Debt)
+			case "quota_ids":
+				scanFields = append(scanFields, &orgQuotaUsageVar.QuotaIds)
+			case "meta":
+				scanFields = append(scanFields, &orgQuotaUsageVar.Meta)
+			case "created_at":
+				scanFields = append(scanFields, &orgQuotaUsageVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgQuotaUsageVar.UpdatedAt)
+			}
+		}
+
+		return &orgQuotaUsageVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgQuotaUsages := make([]OrgQuotaUsageN, 0)
+	for rows.Next() {
+		orgQuotaUsageReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgQuotaUsageReal.original = &orgQuotaUsageOriginal{}
+		_ = query.Copy(orgQuotaUsageReal, orgQuotaUsageReal.original)
+
+		orgQuotaUsageReal.SetModel(m)
+		orgQuotaUsages = append(orgQuotaUsages, *orgQuotaUsageReal)
+	}
+
+	return orgQuotaUsages, nil
+}
+
+// First return first result for given query
+func (m *OrgQuotaUsageModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgQuotaUsageN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org_quota_usage to database
+func (m *OrgQuotaUsageModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all org_quota_usages to database
+func (m *OrgQuotaUsageModel) SaveAll(ctx context.Context, orgQuotaUsages []OrgQuotaUsageN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, orgQuotaUsage := range orgQuotaUsages {
+		id, err := m.Save(ctx, orgQuotaUsage)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org_quota_usage to database
+func (m *OrgQuotaUsageModel) Save(ctx context.Context, orgQuotaUsage OrgQuotaUsageN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, orgQuotaUsage.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org_quota_usage or update it when it has a id > 0
+func (m *OrgQuotaUsageModel) SaveOrUpdate(ctx context.Context, orgQuotaUsage OrgQuotaUsageN, onlyFields ...string) (id int64, updated bool, err error) {
+	if orgQuotaUsage.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, orgQuotaUsage.Id.Int64, orgQuotaUsage, onlyFields...)
+		return orgQuotaUsage.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, orgQuotaUsage, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgQuotaUsageModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgQuotaUsageModel) Update(ctx context.Context, builder query.SQLBuilder, orgQuotaUsage OrgQuotaUsageN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, orgQuotaUsage.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgQuotaUsageModel) UpdateById(ctx context.Context, id int64, orgQuotaUsage OrgQuotaUsageN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, orgQuotaUsage.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgQuotaUsageModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgQuotaUsageModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// OrgPaymentN is a OrgPayment object, all fields are nullable
+type OrgPaymentN struct {
+	original        *orgPaymentOriginal
+	orgPaymentModel *OrgPaymentModel
+
+	Id        null.Int    `json:"id"`
+	PaymentId null.String `json:"payment_id"`
+	OrgId     null.Int    `json:"org_id"`
+	UserId    null.Int    `json:"user_id"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgPaymentN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OrgPayment
+func (inst *OrgPaymentN) SetModel(orgPaymentModel *OrgPaymentModel) {
+	inst.orgPaymentModel = orgPaymentModel
+}
+
+// orgPaymentOriginal is an object which stores original OrgPayment from database
+type orgPaymentOriginal struct {
+	Id        null.Int
+	PaymentId null.String
+	OrgId     null.Int
+	UserId    null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgPaymentN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgPaymentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.PaymentId != inst.original.PaymentId {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "payment_id":
+				if inst.PaymentId != inst.original.PaymentId {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgPaymentN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgPaymentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.PaymentId != inst.original.PaymentId {
+			kv["payment_id"] = inst.PaymentId
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "payment_id":
+				if inst.PaymentId != inst.original.PaymentId {
+					kv["payment_id"] = inst.PaymentId
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgPaymentN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgPaymentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgPaymentModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org_payment
+func (inst *OrgPaymentN) Delete(ctx context.Context) error {
+	if inst.orgPaymentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgPaymentModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgPaymentN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgPaymentScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgPaymentGlobalScopes = make([]orgPaymentScope, 0)
+var orgPaymentLocalScopes = make([]orgPaymentScope, 0)
+
+// AddGlobalScopeForOrgPayment assign a global scope to a model
+func AddGlobalScopeForOrgPayment(name string, apply func(builder query.Condition)) {
+	orgPaymentGlobalScopes = append(orgPaymentGlobalScopes, orgPaymentScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrgPayment assign a local scope to a model
+func AddLocalScopeForOrgPayment(name string, apply func(builder query.Condition)) {
+	orgPaymentLocalScopes = append(orgPaymentLocalScopes, orgPaymentScope{name: name, apply: apply})
+}
+
+func (m *OrgPaymentModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgPaymentGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgPaymentLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgPaymentModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgPaymentModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OrgPayment struct {
+	Id        int64  `json:"id"`
+	PaymentId string `json:"payment_id"`
+	OrgId     int64  `json:"org_id"`
+	UserId    int64  `json:"user_id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w OrgPayment) ToOrgPaymentN(allows ...string) OrgPaymentN {
+	if len(allows) == 0 {
+		return OrgPaymentN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			PaymentId: null.StringFrom(w.PaymentId),
+			OrgId:     null.IntFrom(int64(w.OrgId)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgPaymentN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "payment_id":
+			res.PaymentId = null.StringFrom(w.PaymentId)
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OrgPayment) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgPaymentN) ToOrgPayment() OrgPayment {
+	return OrgPayment{
+
+		Id:        w.Id.Int64,
+		PaymentId: w.PaymentId.String,
+		OrgId:     w.OrgId.Int64,
+		UserId:    w.UserId.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// OrgPaymentModel is a model which encapsulates the operations of the object
+type OrgPaymentModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgPaymentTableName = "org_payment"
+
+// OrgPaymentTable return table name for OrgPayment
+func OrgPaymentTable() string {
+	return orgPaymentTableName
+}
+
+const (
+	FieldOrgPaymentId        = "id"
+	FieldOrgPaymentPaymentId = "payment_id"
+	FieldOrgPaymentOrgId     = "org_id"
+	FieldOrgPaymentUserId    = "user_id"
+	FieldOrgPaymentCreatedAt = "created_at"
+	FieldOrgPaymentUpdatedAt = "updated_at"
+)
+
+// OrgPaymentFields return all fields in OrgPayment model
+func OrgPaymentFields() []string {
+	return []string{
+		"id",
+		"payment_id",
+		"org_id",
+		"user_id",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgPaymentTable(tableName string) {
+	orgPaymentTableName = tableName
+}
+
+// NewOrgPaymentModel create a OrgPaymentModel
+func NewOrgPaymentModel(db query.Database) *OrgPaymentModel {
+	return &OrgPaymentModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgPaymentTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgPaymentModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgPaymentModel) clone() *OrgPaymentModel {
+	return &OrgPaymentModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgPaymentModel) WithoutGlobalScopes(names ...string) *OrgPaymentModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgPaymentModel) WithLocalScopes(names ...string) *OrgPaymentModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgPaymentModel) Condition(builder query.SQLBuilder) *OrgPaymentModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgPaymentModel) Find(ctx context.Context, id int64) (*OrgPaymentN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgPaymentModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgPaymentModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgPaymentModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgPaymentN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgPaymentModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgPaymentN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"payment_id",
+			"org_id",
+			"user_id",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "payment_id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgPaymentN, []interface{}) {
+		var orgPaymentVar OrgPaymentN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgPaymentVar.Id)
+			case "payment_id":
+				scanFields = append(scanFields, &orgPaymentVar.PaymentId)
+			case "org_id":
+				scanFields = append(scanFields, &orgPaymentVar.OrgId)
+			case "user_id":
+				scanFields = append(scanFields, &orgPaymentVar.UserId)
+			case "created_at":
+				scanFields = append(scanFields, &orgPaymentVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgPaymentVar.UpdatedAt)
+			}
+		}
+
+		return &orgPaymentVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgPayments := make([]OrgPaymentN, 0)
+	for rows.Next() {
+		orgPaymentReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgPaymentReal.original = &orgPaymentOriginal{}
+		_ = query.Copy(orgPaymentReal, orgPaymentReal.original)
+
+		orgPaymentReal.SetModel(m)
+		orgPayments = append(orgPayments, *orgPaymentReal)
+	}
+
+	return orgPayments, nil
+}
+
+// First return first result for given query
+func (m *OrgPaymentModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgPaymentN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org_payment to database
+func (m *OrgPaymentModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all org_payments to database
+func (m *OrgPaymentModel) SaveAll(ctx context.Context, orgPayments []OrgPaymentN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, orgPayment := range orgPayments {
+		id, err := m.Save(ctx, orgPayment)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org_payment to database
+func (m *OrgPaymentModel) Save(ctx context.Context, orgPayment OrgPaymentN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, orgPayment.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org_payment or update it when it has a id > 0
+func (m *OrgPaymentModel) SaveOrUpdate(ctx context.Context, orgPayment OrgPaymentN, onlyFields ...string) (id int64, updated bool, err error) {
+	if orgPayment.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, orgPayment.Id.Int64, orgPayment, onlyFields...)
+		return orgPayment.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, orgPayment, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgPaymentModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgPaymentModel) Update(ctx context.Context, builder query.SQLBuilder, orgPayment OrgPaymentN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, orgPayment.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgPaymentModel) UpdateById(ctx context.Context, id int64, orgPayment OrgPaymentN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, orgPayment.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgPaymentModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgPaymentModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
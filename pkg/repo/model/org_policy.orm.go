@@ -0,0 +1,1422 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// OrgPolicyN is a OrgPolicy object, all fields are nullable
+type OrgPolicyN struct {
+	original       *orgPolicyOriginal
+	orgPolicyModel *OrgPolicyModel
+
+	Id            null.Int    `json:"id"`
+	OrgId         null.Int    `json:"org_id"`
+	AllowedModels null.String `json:"allowed_models"`
+	BlockedWords  null.String `json:"blocked_words"`
+	StrictSafety  null.Int    `json:"strict_safety"`
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgPolicyN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OrgPolicy
+func (inst *OrgPolicyN) SetModel(orgPolicyModel *OrgPolicyModel) {
+	inst.orgPolicyModel = orgPolicyModel
+}
+
+// orgPolicyOriginal is an object which stores original OrgPolicy from database
+type orgPolicyOriginal struct {
+	Id            null.Int
+	OrgId         null.Int
+	AllowedModels null.String
+	BlockedWords  null.String
+	StrictSafety  null.Int
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgPolicyN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgPolicyOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.AllowedModels != inst.original.AllowedModels {
+			return true
+		}
+		if inst.BlockedWords != inst.original.BlockedWords {
+			return true
+		}
+		if inst.StrictSafety != inst.original.StrictSafety {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "allowed_models":
+				if inst.AllowedModels != inst.original.AllowedModels {
+					return true
+				}
+			case "blocked_words":
+				if inst.BlockedWords != inst.original.BlockedWords {
+					return true
+				}
+			case "strict_safety":
+				if inst.StrictSafety != inst.original.StrictSafety {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgPolicyN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgPolicyOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.AllowedModels != inst.original.AllowedModels {
+			kv["allowed_models"] = inst.AllowedModels
+		}
+		if inst.BlockedWords != inst.original.BlockedWords {
+			kv["blocked_words"] = inst.BlockedWords
+		}
+		if inst.StrictSafety != inst.original.StrictSafety {
+			kv["strict_safety"] = inst.StrictSafety
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "allowed_models":
+				if inst.AllowedModels != inst.original.AllowedModels {
+					kv["allowed_models"] = inst.AllowedModels
+				}
+			case "blocked_words":
+				if inst.BlockedWords != inst.original.BlockedWords {
+					kv["blocked_words"] = inst.BlockedWords
+				}
+			case "strict_safety":
+				if inst.StrictSafety != inst.original.StrictSafety {
+					kv["strict_safety"] = inst.StrictSafety
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgPolicyN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgPolicyModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgPolicyModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org_policy
+func (inst *OrgPolicyN) Delete(ctx context.Context) error {
+	if inst.orgPolicyModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgPolicyModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgPolicyN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgPolicyScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgPolicyGlobalScopes = make([]orgPolicyScope, 0)
+var orgPolicyLocalScopes = make([]orgPolicyScope, 0)
+
+// AddGlobalScopeForOrgPolicy assign a global scope to a model
+func AddGlobalScopeForOrgPolicy(name string, apply func(builder query.Condition)) {
+	orgPolicyGlobalScopes = append(orgPolicyGlobalScopes, orgPolicyScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrgPolicy assign a local scope to a model
+func AddLocalScopeForOrgPolicy(name string, apply func(builder query.Condition)) {
+	orgPolicyLocalScopes = append(orgPolicyLocalScopes, orgPolicyScope{name: name, apply: apply})
+}
+
+func (m *OrgPolicyModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgPolicyGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgPolicyLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgPolicyModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgPolicyModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OrgPolicy struct {
+	Id            int64  `json:"id"`
+	OrgId         int64  `json:"org_id"`
+	AllowedModels string `json:"allowed_models"`
+	BlockedWords  string `json:"blocked_words"`
+	StrictSafety  int64  `json:"strict_safety"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (w OrgPolicy) ToOrgPolicyN(allows ...string) OrgPolicyN {
+	if len(allows) == 0 {
+		return OrgPolicyN{
+
+			Id:            null.IntFrom(int64(w.Id)),
+			OrgId:         null.IntFrom(int64(w.OrgId)),
+			AllowedModels: null.StringFrom(w.AllowedModels),
+			BlockedWords:  null.StringFrom(w.BlockedWords),
+			StrictSafety:  null.IntFrom(int64(w.StrictSafety)),
+			CreatedAt:     null.TimeFrom(w.CreatedAt),
+			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgPolicyN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "allowed_models":
+			res.AllowedModels = null.StringFrom(w.AllowedModels)
+		case "blocked_words":
+			res.BlockedWords = null.StringFrom(w.BlockedWords)
+		case "strict_safety":
+			res.StrictSafety = null.IntFrom(int64(w.StrictSafety))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OrgPolicy) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgPolicyN) ToOrgPolicy() OrgPolicy {
+	return OrgPolicy{
+
+		Id:            w.Id.Int64,
+		OrgId:         w.OrgId.Int64,
+		AllowedModels: w.AllowedModels.String,
+		BlockedWords:  w.BlockedWords.String,
+		StrictSafety:  w.StrictSafety.Int64,
+		CreatedAt:     w.CreatedAt.Time,
+		UpdatedAt:     w.UpdatedAt.Time,
+	}
+}
+
+// OrgPolicyModel is a model which encapsulates the operations of the object
+type OrgPolicyModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgPolicyTableName = "org_policy"
+
+// OrgPolicyTable return table name for OrgPolicy
+func OrgPolicyTable() string {
+	return orgPolicyTableName
+}
+
+const (
+	FieldOrgPolicyId            = "id"
+	FieldOrgPolicyOrgId         = "org_id"
+	FieldOrgPolicyAllowedModels = "allowed_models"
+	FieldOrgPolicyBlockedWords  = "blocked_words"
+	FieldOrgPolicyStrictSafety  = "strict_safety"
+	FieldOrgPolicyCreatedAt     = "created_at"
+	FieldOrgPolicyUpdatedAt     = "updated_at"
+)
+
+// OrgPolicyFields return all fields in OrgPolicy model
+func OrgPolicyFields() []string {
+	return []string{
+		"id",
+		"org_id",
+		"allowed_models",
+		"blocked_words",
+		"strict_safety",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgPolicyTable(tableName string) {
+	orgPolicyTableName = tableName
+}
+
+// NewOrgPolicyModel create a OrgPolicyModel
+func NewOrgPolicyModel(db query.Database) *OrgPolicyModel {
+	return &OrgPolicyModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgPolicyTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgPolicyModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgPolicyModel) clone() *OrgPolicyModel {
+	return &OrgPolicyModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgPolicyModel) WithoutGlobalScopes(names ...string) *OrgPolicyModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgPolicyModel) WithLocalScopes(names ...string) *OrgPolicyModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgPolicyModel) Condition(builder query.SQLBuilder) *OrgPolicyModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgPolicyModel) Find(ctx context.Context, id int64) (*OrgPolicyN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgPolicyModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgPolicyModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgPolicyModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgPolicyN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgPolicyModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgPolicyN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"org_id",
+			"allowed_models",
+			"blocked_words",
+			"strict_safety",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "allowed_models":
+			selectFields = append(selectFields, f)
+		case "blocked_words":
+			selectFields = append(selectFields, f)
+		case "strict_safety":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgPolicyN, []interface{}) {
+		var orgPolicyVar OrgPolicyN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgPolicyVar.Id)
+			case "org_id":
+				scanFields = append(scanFields, &orgPolicyVar.OrgId)
+			case "allowed_models":
+				scanFields = append(scanFields, &orgPolicyVar.AllowedModels)
+			case "blocked_words":
+				scanFields = append(scanFields, &orgPolicyVar.BlockedWords)
+			case "strict_safety":
+				scanFields = append(scanFields, &orgPolicyVar.StrictSafety)
+			case "created_at":
+				scanFields = append(scanFields, &orgPolicyVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgPolicyVar.UpdatedAt)
+			}
+		}
+
+		return &orgPolicyVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgPolicys := make([]OrgPolicyN, 0)
+	for rows.Next() {
+		orgPolicyReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgPolicyReal.original = &orgPolicyOriginal{}
+		_ = query.Copy(orgPolicyReal, orgPolicyReal.original)
+
+		orgPolicyReal.SetModel(m)
+		orgPolicys = append(orgPolicys, *orgPolicyReal)
+	}
+
+	return orgPolicys, nil
+}
+
+// First return first result for given query
+func (m *OrgPolicyModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgPolicyN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org_policy to database
+func (m *OrgPolicyModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all org_policys to database
+func (m *OrgPolicyModel) SaveAll(ctx context.Context, orgPolicys []OrgPolicyN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, orgPolicy := range orgPolicys {
+		id, err := m.Save(ctx, orgPolicy)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org_policy to database
+func (m *OrgPolicyModel) Save(ctx context.Context, orgPolicy OrgPolicyN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, orgPolicy.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org_policy or update it when it has a id > 0
+func (m *OrgPolicyModel) SaveOrUpdate(ctx context.Context, orgPolicy OrgPolicyN, onlyFields ...string) (id int64, updated bool, err error) {
+	if orgPolicy.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, orgPolicy.Id.Int64, orgPolicy, onlyFields...)
+		return orgPolicy.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, orgPolicy, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgPolicyModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgPolicyModel) Update(ctx context.Context, builder query.SQLBuilder, orgPolicy OrgPolicyN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, orgPolicy.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgPolicyModel) UpdateById(ctx context.Context, id int64, orgPolicy OrgPolicyN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, orgPolicy.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgPolicyModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgPolicyModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// OrgAuditLogN is a OrgAuditLog object, all fields are nullable
+type OrgAuditLogN struct {
+	original         *orgAuditLogOriginal
+	orgAuditLogModel *OrgAuditLogModel
+
+	Id        null.Int    `json:"id"`
+	OrgId     null.Int    `json:"org_id"`
+	UserId    null.Int    `json:"user_id"`
+	Action    null.String `json:"action"`
+	Detail    null.String `json:"detail"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgAuditLogN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OrgAuditLog
+func (inst *OrgAuditLogN) SetModel(orgAuditLogModel *OrgAuditLogModel) {
+	inst.orgAuditLogModel = orgAuditLogModel
+}
+
+// orgAuditLogOriginal is an object which stores original OrgAuditLog from database
+type orgAuditLogOriginal struct {
+	Id        null.Int
+	OrgId     null.Int
+	UserId    null.Int
+	Action    null.String
+	Detail    null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgAuditLogN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgAuditLogOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Action != inst.original.Action {
+			return true
+		}
+		if inst.Detail != inst.original.Detail {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "action":
+				if inst.Action != inst.original.Action {
+					return true
+				}
+			case "detail":
+				if inst.Detail != inst.original.Detail {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgAuditLogN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgAuditLogOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Action != inst.original.Action {
+			kv["action"] = inst.Action
+		}
+		if inst.Detail != inst.original.Detail {
+			kv["detail"] = inst.Detail
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "action":
+				if inst.Action != inst.original.Action {
+					kv["action"] = inst.Action
+				}
+			case "detail":
+				if inst.Detail != inst.original.Detail {
+					kv["detail"] = inst.Detail
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgAuditLogN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgAuditLogModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgAuditLogModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org_audit_log
+func (inst *OrgAuditLogN) Delete(ctx context.Context) error {
+	if inst.orgAuditLogModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgAuditLogModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgAuditLogN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgAuditLogScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgAuditLogGlobalScopes = make([]orgAuditLogScope, 0)
+var orgAuditLogLocalScopes = make([]orgAuditLogScope, 0)
+
+// AddGlobalScopeForOrgAuditLog assign a global scope to a model
+func AddGlobalScopeForOrgAuditLog(name string, apply func(builder query.Condition)) {
+	orgAuditLogGlobalScopes = append(orgAuditLogGlobalScopes, orgAuditLogScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrgAuditLog assign a local scope to a model
+func AddLocalScopeForOrgAuditLog(name string, apply func(builder query.Condition)) {
+	orgAuditLogLocalScopes = append(orgAuditLogLocalScopes, orgAuditLogScope{name: name, apply: apply})
+}
+
+func (m *OrgAuditLogModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgAuditLogGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgAuditLogLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgAuditLogModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgAuditLogModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OrgAuditLog struct {
+	Id        int64  `json:"id"`
+	OrgId     int64  `json:"org_id"`
+	UserId    int64  `json:"user_id"`
+	Action    string `json:"action"`
+	Detail    string `json:"detail"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w OrgAuditLog) ToOrgAuditLogN(allows ...string) OrgAuditLogN {
+	if len(allows) == 0 {
+		return OrgAuditLogN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			OrgId:     null.IntFrom(int64(w.OrgId)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Action:    null.StringFrom(w.Action),
+			Detail:    null.StringFrom(w.Detail),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgAuditLogN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "action":
+			res.Action = null.StringFrom(w.Action)
+		case "detail":
+			res.Detail = null.StringFrom(w.Detail)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OrgAuditLog) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgAuditLogN) ToOrgAuditLog() OrgAuditLog {
+	return OrgAuditLog{
+
+		Id:        w.Id.Int64,
+		OrgId:     w.OrgId.Int64,
+		UserId:    w.UserId.Int64,
+		Action:    w.Action.String,
+		Detail:    w.Detail.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// OrgAuditLogModel is a model which encapsulates the operations of the object
+type OrgAuditLogModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgAuditLogTableName = "org_audit_log"
+
+// OrgAuditLogTable return table name for OrgAuditLog
+func OrgAuditLogTable() string {
+	return orgAuditLogTableName
+}
+
+const (
+	FieldOrgAuditLogId        = "id"
+	FieldOrgAuditLogOrgId     = "org_id"
+	FieldOrgAuditLogUserId    = "user_id"
+	FieldOrgAuditLogAction    = "action"
+	FieldOrgAuditLogDetail    = "detail"
+	FieldOrgAuditLogCreatedAt = "created_at"
+	FieldOrgAuditLogUpdatedAt = "updated_at"
+)
+
+// OrgAuditLogFields return all fields in OrgAuditLog model
+func OrgAuditLogFields() []string {
+	return []string{
+		"id",
+		"org_id",
+		"user_id",
+		"action",
+		"detail",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgAuditLogTable(tableName string) {
+	orgAuditLogTableName = tableName
+}
+
+// NewOrgAuditLogModel create a OrgAuditLogModel
+func NewOrgAuditLogModel(db query.Database) *OrgAuditLogModel {
+	return &OrgAuditLogModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgAuditLogTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgAuditLogModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgAuditLogModel) clone() *OrgAuditLogModel {
+	return &OrgAuditLogModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgAuditLogModel) WithoutGlobalScopes(names ...string) *OrgAuditLogModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgAuditLogModel) WithLocalScopes(names ...string) *OrgAuditLogModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgAuditLogModel) Condition(builder query.SQLBuilder) *OrgAuditLogModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgAuditLogModel) Find(ctx context.Context, id int64) (*OrgAuditLogN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgAuditLogModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgAuditLogModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgAuditLogModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgAuditLogN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgAuditLogModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgAuditLogN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"org_id",
+			"user_id",
+			"action",
+			"detail",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "action":
+			selectFields = append(selectFields, f)
+		case "detail":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgAuditLogN, []interface{}) {
+		var orgAuditLogVar OrgAuditLogN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgAuditLogVar.Id)
+			case "org_id":
+				scanFields = append(scanFields, &orgAuditLogVar.OrgId)
+			case "user_id":
+				scanFields = append(scanFields, &orgAuditLogVar.UserId)
+			case "action":
+				scanFields = append(scanFields, &orgAuditLogVar.Action)
+			case "detail":
+				scanFields = append(scanFields, &orgAuditLogVar.Detail)
+			case "created_at":
+				scanFields = append(scanFields, &orgAuditLogVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgAuditLogVar.UpdatedAt)
+			}
+		}
+
+		return &orgAuditLogVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgAuditLogs := make([]OrgAuditLogN, 0)
+	for rows.Next() {
+		orgAuditLogReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgAuditLogReal.original = &orgAuditLogOriginal{}
+		_ = query.Copy(orgAuditLogReal, orgAuditLogReal.original)
+
+		orgAuditLogReal.SetModel(m)
+		orgAuditLogs = append(orgAuditLogs, *orgAuditLogReal)
+	}
+
+	return orgAuditLogs, nil
+}
+
+// First return first result for given query
+func (m *OrgAuditLogModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgAuditLogN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org_audit_log to database
+func (m *OrgAuditLogModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all org_audit_logs to database
+func (m *OrgAuditLogModel) SaveAll(ctx context.Context, orgAuditLogs []OrgAuditLogN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, orgAuditLog := range orgAuditLogs {
+		id, err := m.Save(ctx, orgAuditLog)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org_audit_log to database
+func (m *OrgAuditLogModel) Save(ctx context.Context, orgAuditLog OrgAuditLogN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, orgAuditLog.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org_audit_log or update it when it has a id > 0
+func (m *OrgAuditLogModel) SaveOrUpdate(ctx context.Context, orgAuditLog OrgAuditLogN, onlyFields ...string) (id int64, updated bool, err error) {
+	if orgAuditLog.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, orgAuditLog.Id.Int64, orgAuditLog, onlyFields...)
+		return orgAuditLog.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, orgAuditLog, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgAuditLogModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgAuditLogModel) Update(ctx context.Context, builder query.SQLBuilder, orgAuditLog OrgAuditLogN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, orgAuditLog.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgAuditLogModel) UpdateById(ctx context.Context, id int64, orgAuditLog OrgAuditLogN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, orgAuditLog.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgAuditLogModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgAuditLogModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,775 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// OrgProvisionTokenN is a OrgProvisionToken object, all fields are nullable
+type OrgProvisionTokenN struct {
+	original               *orgProvisionTokenOriginal
+	orgProvisionTokenModel *OrgProvisionTokenModel
+
+	Id         null.Int    `json:"id"`
+	OrgId      null.Int    `json:"org_id"`
+	Name       null.String `json:"name"`
+	Token      null.String `json:"token"`
+	Status     null.Int    `json:"status"`
+	CreatedBy  null.Int    `json:"created_by"`
+	LastUsedAt null.Time   `json:"last_used_at"`
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *OrgProvisionTokenN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for OrgProvisionToken
+func (inst *OrgProvisionTokenN) SetModel(orgProvisionTokenModel *OrgProvisionTokenModel) {
+	inst.orgProvisionTokenModel = orgProvisionTokenModel
+}
+
+// orgProvisionTokenOriginal is an object which stores original OrgProvisionToken from database
+type orgProvisionTokenOriginal struct {
+	Id         null.Int
+	OrgId      null.Int
+	Name       null.String
+	Token      null.String
+	Status     null.Int
+	CreatedBy  null.Int
+	LastUsedAt null.Time
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *OrgProvisionTokenN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &orgProvisionTokenOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Token != inst.original.Token {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedBy != inst.original.CreatedBy {
+			return true
+		}
+		if inst.LastUsedAt != inst.original.LastUsedAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "token":
+				if inst.Token != inst.original.Token {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_by":
+				if inst.CreatedBy != inst.original.CreatedBy {
+					return true
+				}
+			case "last_used_at":
+				if inst.LastUsedAt != inst.original.LastUsedAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *OrgProvisionTokenN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &orgProvisionTokenOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Token != inst.original.Token {
+			kv["token"] = inst.Token
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedBy != inst.original.CreatedBy {
+			kv["created_by"] = inst.CreatedBy
+		}
+		if inst.LastUsedAt != inst.original.LastUsedAt {
+			kv["last_used_at"] = inst.LastUsedAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "token":
+				if inst.Token != inst.original.Token {
+					kv["token"] = inst.Token
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_by":
+				if inst.CreatedBy != inst.original.CreatedBy {
+					kv["created_by"] = inst.CreatedBy
+				}
+			case "last_used_at":
+				if inst.LastUsedAt != inst.original.LastUsedAt {
+					kv["last_used_at"] = inst.LastUsedAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *OrgProvisionTokenN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.orgProvisionTokenModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.orgProvisionTokenModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a org_provision_token
+func (inst *OrgProvisionTokenN) Delete(ctx context.Context) error {
+	if inst.orgProvisionTokenModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.orgProvisionTokenModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *OrgProvisionTokenN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type orgProvisionTokenScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var orgProvisionTokenGlobalScopes = make([]orgProvisionTokenScope, 0)
+var orgProvisionTokenLocalScopes = make([]orgProvisionTokenScope, 0)
+
+// AddGlobalScopeForOrgProvisionToken assign a global scope to a model
+func AddGlobalScopeForOrgProvisionToken(name string, apply func(builder query.Condition)) {
+	orgProvisionTokenGlobalScopes = append(orgProvisionTokenGlobalScopes, orgProvisionTokenScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForOrgProvisionToken assign a local scope to a model
+func AddLocalScopeForOrgProvisionToken(name string, apply func(builder query.Condition)) {
+	orgProvisionTokenLocalScopes = append(orgProvisionTokenLocalScopes, orgProvisionTokenScope{name: name, apply: apply})
+}
+
+func (m *OrgProvisionTokenModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range orgProvisionTokenGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range orgProvisionTokenLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *OrgProvisionTokenModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *OrgProvisionTokenModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OrgProvisionToken struct {
+	Id         int64     `json:"id"`
+	OrgId      int64     `json:"org_id"`
+	Name       string    `json:"name"`
+	Token      string    `json:"token"`
+	Status     int64     `json:"status"`
+	CreatedBy  int64     `json:"created_by"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w OrgProvisionToken) ToOrgProvisionTokenN(allows ...string) OrgProvisionTokenN {
+	if len(allows) == 0 {
+		return OrgProvisionTokenN{
+
+			Id:         null.IntFrom(int64(w.Id)),
+			OrgId:      null.IntFrom(int64(w.OrgId)),
+			Name:       null.StringFrom(w.Name),
+			Token:      null.StringFrom(w.Token),
+			Status:     null.IntFrom(int64(w.Status)),
+			CreatedBy:  null.IntFrom(int64(w.CreatedBy)),
+			LastUsedAt: null.TimeFrom(w.LastUsedAt),
+			CreatedAt:  null.TimeFrom(w.CreatedAt),
+			UpdatedAt:  null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := OrgProvisionTokenN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "token":
+			res.Token = null.StringFrom(w.Token)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_by":
+			res.CreatedBy = null.IntFrom(int64(w.CreatedBy))
+		case "last_used_at":
+			res.LastUsedAt = null.TimeFrom(w.LastUsedAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w OrgProvisionToken) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *OrgProvisionTokenN) ToOrgProvisionToken() OrgProvisionToken {
+	return OrgProvisionToken{
+
+		Id:         w.Id.Int64,
+		OrgId:      w.OrgId.Int64,
+		Name:       w.Name.String,
+		Token:      w.Token.String,
+		Status:     w.Status.Int64,
+		CreatedBy:  w.CreatedBy.Int64,
+		LastUsedAt: w.LastUsedAt.Time,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+	}
+}
+
+// OrgProvisionTokenModel is a model which encapsulates the operations of the object
+type OrgProvisionTokenModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var orgProvisionTokenTableName = "org_provision_token"
+
+// OrgProvisionTokenTable return table name for OrgProvisionToken
+func OrgProvisionTokenTable() string {
+	return orgProvisionTokenTableName
+}
+
+const (
+	FieldOrgProvisionTokenId         = "id"
+	FieldOrgProvisionTokenOrgId      = "org_id"
+	FieldOrgProvisionTokenName       = "name"
+	FieldOrgProvisionTokenToken      = "token"
+	FieldOrgProvisionTokenStatus     = "status"
+	FieldOrgProvisionTokenCreatedBy  = "created_by"
+	FieldOrgProvisionTokenLastUsedAt = "last_used_at"
+	FieldOrgProvisionTokenCreatedAt  = "created_at"
+	FieldOrgProvisionTokenUpdatedAt  = "updated_at"
+)
+
+// OrgProvisionTokenFields return all fields in OrgProvisionToken model
+func OrgProvisionTokenFields() []string {
+	return []string{
+		"id",
+		"org_id",
+		"name",
+		"token",
+		"status",
+		"created_by",
+		"last_used_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetOrgProvisionTokenTable(tableName string) {
+	orgProvisionTokenTableName = tableName
+}
+
+// NewOrgProvisionTokenModel create a OrgProvisionTokenModel
+func NewOrgProvisionTokenModel(db query.Database) *OrgProvisionTokenModel {
+	return &OrgProvisionTokenModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           orgProvisionTokenTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *OrgProvisionTokenModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *OrgProvisionTokenModel) clone() *OrgProvisionTokenModel {
+	return &OrgProvisionTokenModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *OrgProvisionTokenModel) WithoutGlobalScopes(names ...string) *OrgProvisionTokenModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *OrgProvisionTokenModel) WithLocalScopes(names ...string) *OrgProvisionTokenModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *OrgProvisionTokenModel) Condition(builder query.SQLBuilder) *OrgProvisionTokenModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *OrgProvisionTokenModel) Find(ctx context.Context, id int64) (*OrgProvisionTokenN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *OrgProvisionTokenModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *OrgProvisionTokenModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *OrgProvisionTokenModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]OrgProvisionTokenN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *OrgProvisionTokenModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]OrgProvisionTokenN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"org_id",
+			"name",
+			"token",
+			"status",
+			"created_by",
+			"last_used_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "token":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_by":
+			selectFields = append(selectFields, f)
+		case "last_used_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*OrgProvisionTokenN, []interface{}) {
+		var orgProvisionTokenVar OrgProvisionTokenN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &orgProvisionTokenVar.Id)
+			case "org_id":
+				scanFields = append(scanFields, &orgProvisionTokenVar.OrgId)
+			case "name":
+				scanFields = append(scanFields, &orgProvisionTokenVar.Name)
+			case "token":
+				scanFields = append(scanFields, &orgProvisionTokenVar.Token)
+			case "status":
+				scanFields = append(scanFields, &orgProvisionTokenVar.Status)
+			case "created_by":
+				scanFields = append(scanFields, &orgProvisionTokenVar.CreatedBy)
+			case "last_used_at":
+				scanFields = append(scanFields, &orgProvisionTokenVar.LastUsedAt)
+			case "created_at":
+				scanFields = append(scanFields, &orgProvisionTokenVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &orgProvisionTokenVar.UpdatedAt)
+			}
+		}
+
+		return &orgProvisionTokenVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orgProvisionTokens := make([]OrgProvisionTokenN, 0)
+	for rows.Next() {
+		orgProvisionTokenReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		orgProvisionTokenReal.original = &orgProvisionTokenOriginal{}
+		_ = query.Copy(orgProvisionTokenReal, orgProvisionTokenReal.original)
+
+		orgProvisionTokenReal.SetModel(m)
+		orgProvisionTokens = append(orgProvisionTokens, *orgProvisionTokenReal)
+	}
+
+	return orgProvisionTokens, nil
+}
+
+// First return first result for given query
+func (m *OrgProvisionTokenModel) First(ctx context.Context, builders ...query.SQLBuilder) (*OrgProvisionTokenN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new org_provision_token to database
+func (m *OrgProvisionTokenModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all org_provision_tokens to database
+func (m *OrgProvisionTokenModel) SaveAll(ctx context.Context, orgProvisionTokens []OrgProvisionTokenN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, orgProvisionToken := range orgProvisionTokens {
+		id, err := m.Save(ctx, orgProvisionToken)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a org_provision_token to database
+func (m *OrgProvisionTokenModel) Save(ctx context.Context, orgProvisionToken OrgProvisionTokenN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, orgProvisionToken.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new org_provision_token or update it when it has a id > 0
+func (m *OrgProvisionTokenModel) SaveOrUpdate(ctx context.Context, orgProvisionToken OrgProvisionTokenN, onlyFields ...string) (id int64, updated bool, err error) {
+	if orgProvisionToken.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, orgProvisionToken.Id.Int64, orgProvisionToken, onlyFields...)
+		return orgProvisionToken.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, orgProvisionToken, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *OrgProvisionTokenModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *OrgProvisionTokenModel) Update(ctx context.Context, builder query.SQLBuilder, orgProvisionToken OrgProvisionTokenN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, orgProvisionToken.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *OrgProvisionTokenModel) UpdateById(ctx context.Context, id int64, orgProvisionToken OrgProvisionTokenN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, orgProvisionToken.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *OrgProvisionTokenModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *OrgProvisionTokenModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
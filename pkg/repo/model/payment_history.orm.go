@@ -20,19 +20,21 @@ type PaymentHistoryN struct {
 	original            *paymentHistoryOriginal
 	paymentHistoryModel *PaymentHistoryModel
 
-	Id          null.Int    `json:"id"`
-	UserId      null.Int    `json:"user_id"`
-	PaymentId   null.String `json:"payment_id"`
-	Source      null.String `json:"source"`
-	SourceId    null.String `json:"source_id"`
-	Quantity    null.Int    `json:"quantity"`
-	RetailPrice null.Int    `json:"retail_price"`
-	ValidUntil  null.Time   `json:"valid_until"`
-	Status      null.Int    `json:"status"`
-	Environment null.String `json:"environment"`
-	PurchaseAt  null.Time   `json:"purchase_at"`
-	CreatedAt   null.Time
-	UpdatedAt   null.Time
+	Id             null.Int    `json:"id"`
+	UserId         null.Int    `json:"user_id"`
+	PaymentId      null.String `json:"payment_id"`
+	Source         null.String `json:"source"`
+	SourceId       null.String `json:"source_id"`
+	Quantity       null.Int    `json:"quantity"`
+	RetailPrice    null.Int    `json:"retail_price"`
+	ValidUntil     null.Time   `json:"valid_until"`
+	Status         null.Int    `json:"status"`
+	Environment    null.String `json:"environment"`
+	PurchaseAt     null.Time   `json:"purchase_at"`
+	TransactionKey null.String `json:"transaction_key"`
+	GrantedAt      null.Time   `json:"granted_at"`
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
 }
 
 // As convert object to other type
@@ -48,19 +50,21 @@ func (inst *PaymentHistoryN) SetModel(paymentHistoryModel *PaymentHistoryModel)
 
 // paymentHistoryOriginal is an object which stores original PaymentHistory from database
 type paymentHistoryOriginal struct {
-	Id          null.Int
-	UserId      null.Int
-	PaymentId   null.String
-	Source      null.String
-	SourceId    null.String
-	Quantity    null.Int
-	RetailPrice null.Int
-	ValidUntil  null.Time
-	Status      null.Int
-	Environment null.String
-	PurchaseAt  null.Time
-	CreatedAt   null.Time
-	UpdatedAt   null.Time
+	Id             null.Int
+	UserId         null.Int
+	PaymentId      null.String
+	Source         null.String
+	SourceId       null.String
+	Quantity       null.Int
+	RetailPrice    null.Int
+	ValidUntil     null.Time
+	Status         null.Int
+	Environment    null.String
+	PurchaseAt     null.Time
+	TransactionKey null.String
+	GrantedAt      null.Time
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
 }
 
 // Staled identify whether the object has been modified
@@ -104,6 +108,12 @@ func (inst *PaymentHistoryN) Staled(onlyFields ...string) bool {
 		if inst.PurchaseAt != inst.original.PurchaseAt {
 			return true
 		}
+		if inst.TransactionKey != inst.original.TransactionKey {
+			return true
+		}
+		if inst.GrantedAt != inst.original.GrantedAt {
+			return true
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			return true
 		}
@@ -158,6 +168,14 @@ func (inst *PaymentHistoryN) Staled(onlyFields ...string) bool {
 				if inst.PurchaseAt != inst.original.PurchaseAt {
 					return true
 				}
+			case "transaction_key":
+				if inst.TransactionKey != inst.original.TransactionKey {
+					return true
+				}
+			case "granted_at":
+				if inst.GrantedAt != inst.original.GrantedAt {
+					return true
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					return true
@@ -217,6 +235,12 @@ func (inst *PaymentHistoryN) StaledKV(onlyFields ...string) query.KV {
 		if inst.PurchaseAt != inst.original.PurchaseAt {
 			kv["purchase_at"] = inst.PurchaseAt
 		}
+		if inst.TransactionKey != inst.original.TransactionKey {
+			kv["transaction_key"] = inst.TransactionKey
+		}
+		if inst.GrantedAt != inst.original.GrantedAt {
+			kv["granted_at"] = inst.GrantedAt
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			kv["created_at"] = inst.CreatedAt
 		}
@@ -271,6 +295,14 @@ func (inst *PaymentHistoryN) StaledKV(onlyFields ...string) query.KV {
 				if inst.PurchaseAt != inst.original.PurchaseAt {
 					kv["purchase_at"] = inst.PurchaseAt
 				}
+			case "transaction_key":
+				if inst.TransactionKey != inst.original.TransactionKey {
+					kv["transaction_key"] = inst.TransactionKey
+				}
+			case "granted_at":
+				if inst.GrantedAt != inst.original.GrantedAt {
+					kv["granted_at"] = inst.GrantedAt
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					kv["created_at"] = inst.CreatedAt
@@ -378,38 +410,42 @@ func (m *PaymentHistoryModel) globalScopeEnabled(name string) bool {
 }
 
 type PaymentHistory struct {
-	Id          int64     `json:"id"`
-	UserId      int64     `json:"user_id"`
-	PaymentId   string    `json:"payment_id"`
-	Source      string    `json:"source"`
-	SourceId    string    `json:"source_id"`
-	Quantity    int       `json:"quantity"`
-	RetailPrice int64     `json:"retail_price"`
-	ValidUntil  time.Time `json:"valid_until"`
-	Status      int       `json:"status"`
-	Environment string    `json:"environment"`
-	PurchaseAt  time.Time `json:"purchase_at"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Id             int64     `json:"id"`
+	UserId         int64     `json:"user_id"`
+	PaymentId      string    `json:"payment_id"`
+	Source         string    `json:"source"`
+	SourceId       string    `json:"source_id"`
+	Quantity       int       `json:"quantity"`
+	RetailPrice    int64     `json:"retail_price"`
+	ValidUntil     time.Time `json:"valid_until"`
+	Status         int       `json:"status"`
+	Environment    string    `json:"environment"`
+	PurchaseAt     time.Time `json:"purchase_at"`
+	TransactionKey string    `json:"transaction_key"`
+	GrantedAt      time.Time `json:"granted_at"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func (w PaymentHistory) ToPaymentHistoryN(allows ...string) PaymentHistoryN {
 	if len(allows) == 0 {
 		return PaymentHistoryN{
 
-			Id:          null.IntFrom(int64(w.Id)),
-			UserId:      null.IntFrom(int64(w.UserId)),
-			PaymentId:   null.StringFrom(w.PaymentId),
-			Source:      null.StringFrom(w.Source),
-			SourceId:    null.StringFrom(w.SourceId),
-			Quantity:    null.IntFrom(int64(w.Quantity)),
-			RetailPrice: null.IntFrom(int64(w.RetailPrice)),
-			ValidUntil:  null.TimeFrom(w.ValidUntil),
-			Status:      null.IntFrom(int64(w.Status)),
-			Environment: null.StringFrom(w.Environment),
-			PurchaseAt:  null.TimeFrom(w.PurchaseAt),
-			CreatedAt:   null.TimeFrom(w.CreatedAt),
-			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+			Id:             null.IntFrom(int64(w.Id)),
+			UserId:         null.IntFrom(int64(w.UserId)),
+			PaymentId:      null.StringFrom(w.PaymentId),
+			Source:         null.StringFrom(w.Source),
+			SourceId:       null.StringFrom(w.SourceId),
+			Quantity:       null.IntFrom(int64(w.Quantity)),
+			RetailPrice:    null.IntFrom(int64(w.RetailPrice)),
+			ValidUntil:     null.TimeFrom(w.ValidUntil),
+			Status:         null.IntFrom(int64(w.Status)),
+			Environment:    null.StringFrom(w.Environment),
+			PurchaseAt:     null.TimeFrom(w.PurchaseAt),
+			TransactionKey: null.StringFrom(w.TransactionKey),
+			GrantedAt:      null.TimeFrom(w.GrantedAt),
+			CreatedAt:      null.TimeFrom(w.CreatedAt),
+			UpdatedAt:      null.TimeFrom(w.UpdatedAt),
 		}
 	}
 
@@ -439,6 +475,10 @@ func (w PaymentHistory) ToPaymentHistoryN(allows ...string) PaymentHistoryN {
 			res.Environment = null.StringFrom(w.Environment)
 		case "purchase_at":
 			res.PurchaseAt = null.TimeFrom(w.PurchaseAt)
+		case "transaction_key":
+			res.TransactionKey = null.StringFrom(w.TransactionKey)
+		case "granted_at":
+			res.GrantedAt = null.TimeFrom(w.GrantedAt)
 		case "created_at":
 			res.CreatedAt = null.TimeFrom(w.CreatedAt)
 		case "updated_at":
@@ -459,19 +499,21 @@ func (w PaymentHistory) As(dst interface{}) error {
 func (w *PaymentHistoryN) ToPaymentHistory() PaymentHistory {
 	return PaymentHistory{
 
-		Id:          w.Id.Int64,
-		UserId:      w.UserId.Int64,
-		PaymentId:   w.PaymentId.String,
-		Source:      w.Source.String,
-		SourceId:    w.SourceId.String,
-		Quantity:    int(w.Quantity.Int64),
-		RetailPrice: w.RetailPrice.Int64,
-		ValidUntil:  w.ValidUntil.Time,
-		Status:      int(w.Status.Int64),
-		Environment: w.Environment.String,
-		PurchaseAt:  w.PurchaseAt.Time,
-		CreatedAt:   w.CreatedAt.Time,
-		UpdatedAt:   w.UpdatedAt.Time,
+		Id:             w.Id.Int64,
+		UserId:         w.UserId.Int64,
+		PaymentId:      w.PaymentId.String,
+		Source:         w.Source.String,
+		SourceId:       w.SourceId.String,
+		Quantity:       int(w.Quantity.Int64),
+		RetailPrice:    w.RetailPrice.Int64,
+		ValidUntil:     w.ValidUntil.Time,
+		Status:         int(w.Status.Int64),
+		Environment:    w.Environment.String,
+		PurchaseAt:     w.PurchaseAt.Time,
+		TransactionKey: w.TransactionKey.String,
+		GrantedAt:      w.GrantedAt.Time,
+		CreatedAt:      w.CreatedAt.Time,
+		UpdatedAt:      w.UpdatedAt.Time,
 	}
 }
 
@@ -494,19 +536,21 @@ func PaymentHistoryTable() string {
 }
 
 const (
-	FieldPaymentHistoryId          = "id"
-	FieldPaymentHistoryUserId      = "user_id"
-	FieldPaymentHistoryPaymentId   = "payment_id"
-	FieldPaymentHistorySource      = "source"
-	FieldPaymentHistorySourceId    = "source_id"
-	FieldPaymentHistoryQuantity    = "quantity"
-	FieldPaymentHistoryRetailPrice = "retail_price"
-	FieldPaymentHistoryValidUntil  = "valid_until"
-	FieldPaymentHistoryStatus      = "status"
-	FieldPaymentHistoryEnvironment = "environment"
-	FieldPaymentHistoryPurchaseAt  = "purchase_at"
-	FieldPaymentHistoryCreatedAt   = "created_at"
-	FieldPaymentHistoryUpdatedAt   = "updated_at"
+	FieldPaymentHistoryId             = "id"
+	FieldPaymentHistoryUserId         = "user_id"
+	FieldPaymentHistoryPaymentId      = "payment_id"
+	FieldPaymentHistorySource         = "source"
+	FieldPaymentHistorySourceId       = "source_id"
+	FieldPaymentHistoryQuantity       = "quantity"
+	FieldPaymentHistoryRetailPrice    = "retail_price"
+	FieldPaymentHistoryValidUntil     = "valid_until"
+	FieldPaymentHistoryStatus         = "status"
+	FieldPaymentHistoryEnvironment    = "environment"
+	FieldPaymentHistoryPurchaseAt     = "purchase_at"
+	FieldPaymentHistoryTransactionKey = "transaction_key"
+	FieldPaymentHistoryGrantedAt      = "granted_at"
+	FieldPaymentHistoryCreatedAt      = "created_at"
+	FieldPaymentHistoryUpdatedAt      = "updated_at"
 )
 
 // PaymentHistoryFields return all fields in PaymentHistory model
@@ -523,6 +567,8 @@ func PaymentHistoryFields() []string {
 		"status",
 		"environment",
 		"purchase_at",
+		"transaction_key",
+		"granted_at",
 		"created_at",
 		"updated_at",
 	}
@@ -666,6 +712,8 @@ func (m *PaymentHistoryModel) Get(ctx context.Context, builders ...query.SQLBuil
 			"status",
 			"environment",
 			"purchase_at",
+			"transaction_key",
+			"granted_at",
 			"created_at",
 			"updated_at",
 		)
@@ -699,6 +747,10 @@ func (m *PaymentHistoryModel) Get(ctx context.Context, builders ...query.SQLBuil
 			selectFields = append(selectFields, f)
 		case "purchase_at":
 			selectFields = append(selectFields, f)
+		case "transaction_key":
+			selectFields = append(selectFields, f)
+		case "granted_at":
+			selectFields = append(selectFields, f)
 		case "created_at":
 			selectFields = append(selectFields, f)
 		case "updated_at":
@@ -735,6 +787,10 @@ func (m *PaymentHistoryModel) Get(ctx context.Context, builders ...query.SQLBuil
 				scanFields = append(scanFields, &paymentHistoryVar.Environment)
 			case "purchase_at":
 				scanFields = append(scanFields, &paymentHistoryVar.PurchaseAt)
+			case "transaction_key":
+				scanFields = append(scanFields, &paymentHistoryVar.TransactionKey)
+			case "granted_at":
+				scanFields = append(scanFields, &paymentHistoryVar.GrantedAt)
 			case "created_at":
 				scanFields = append(scanFields, &paymentHistoryVar.CreatedAt)
 			case "updated_at":
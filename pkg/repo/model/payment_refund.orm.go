@@ -0,0 +1,971 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// PaymentRefundN is a PaymentRefund object, all fields are nullable
+type PaymentRefundN struct {
+	original           *paymentRefundOriginal
+	paymentRefundModel *PaymentRefundModel
+
+	Id               null.Int    `json:"id"`
+	UserId           null.Int    `json:"user_id"`
+	OrgId            null.Int    `json:"org_id"`
+	PaymentId        null.String `json:"payment_id"`
+	TransactionKey   null.String `json:"transaction_key"`
+	NotificationId   null.String `json:"notification_id"`
+	NotificationType null.String `json:"notification_type"`
+	Environment      null.String `json:"environment"`
+	Quantity         null.Int    `json:"quantity"`
+	ClawedBack       null.Int    `json:"clawed_back"`
+	Debt             null.Int    `json:"debt"`
+	Payload          null.String `json:"payload"`
+	ReviewedAt       null.Time   `json:"reviewed_at"`
+	ReviewNote       null.String `json:"review_note"`
+	CreatedAt        null.Time
+	UpdatedAt        null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *PaymentRefundN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for PaymentRefund
+func (inst *PaymentRefundN) SetModel(paymentRefundModel *PaymentRefundModel) {
+	inst.paymentRefundModel = paymentRefundModel
+}
+
+// paymentRefundOriginal is an object which stores original PaymentRefund from database
+type paymentRefundOriginal struct {
+	Id               null.Int
+	UserId           null.Int
+	OrgId            null.Int
+	PaymentId        null.String
+	TransactionKey   null.String
+	NotificationId   null.String
+	NotificationType null.String
+	Environment      null.String
+	Quantity         null.Int
+	ClawedBack       null.Int
+	Debt             null.Int
+	Payload          null.String
+	ReviewedAt       null.Time
+	ReviewNote       null.String
+	CreatedAt        null.Time
+	UpdatedAt        null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *PaymentRefundN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &paymentRefundOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.PaymentId != inst.original.PaymentId {
+			return true
+		}
+		if inst.TransactionKey != inst.original.TransactionKey {
+			return true
+		}
+		if inst.NotificationId != inst.original.NotificationId {
+			return true
+		}
+		if inst.NotificationType != inst.original.NotificationType {
+			return true
+		}
+		if inst.Environment != inst.original.Environment {
+			return true
+		}
+		if inst.Quantity != inst.original.Quantity {
+			return true
+		}
+		if inst.ClawedBack != inst.original.ClawedBack {
+			return true
+		}
+		if inst.Debt != inst.original.Debt {
+			return true
+		}
+		if inst.Payload != inst.original.Payload {
+			return true
+		}
+		if inst.ReviewedAt != inst.original.ReviewedAt {
+			return true
+		}
+		if inst.ReviewNote != inst.original.ReviewNote {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "payment_id":
+				if inst.PaymentId != inst.original.PaymentId {
+					return true
+				}
+			case "transaction_key":
+				if inst.TransactionKey != inst.original.TransactionKey {
+					return true
+				}
+			case "notification_id":
+				if inst.NotificationId != inst.original.NotificationId {
+					return true
+				}
+			case "notification_type":
+				if inst.NotificationType != inst.original.NotificationType {
+					return true
+				}
+			case "environment":
+				if inst.Environment != inst.original.Environment {
+					return true
+				}
+			case "quantity":
+				if inst.Quantity != inst.original.Quantity {
+					return true
+				}
+			case "clawed_back":
+				if inst.ClawedBack != inst.original.ClawedBack {
+					return true
+				}
+			case "debt":
+				if inst.Debt != inst.original.Debt {
+					return true
+				}
+			case "payload":
+				if inst.Payload != inst.original.Payload {
+					return true
+				}
+			case "reviewed_at":
+				if inst.ReviewedAt != inst.original.ReviewedAt {
+					return true
+				}
+			case "review_note":
+				if inst.ReviewNote != inst.original.ReviewNote {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *PaymentRefundN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &paymentRefundOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.PaymentId != inst.original.PaymentId {
+			kv["payment_id"] = inst.PaymentId
+		}
+		if inst.TransactionKey != inst.original.TransactionKey {
+			kv["transaction_key"] = inst.TransactionKey
+		}
+		if inst.NotificationId != inst.original.NotificationId {
+			kv["notification_id"] = inst.NotificationId
+		}
+		if inst.NotificationType != inst.original.NotificationType {
+			kv["notification_type"] = inst.NotificationType
+		}
+		if inst.Environment != inst.original.Environment {
+			kv["environment"] = inst.Environment
+		}
+		if inst.Quantity != inst.original.Quantity {
+			kv["quantity"] = inst.Quantity
+		}
+		if inst.ClawedBack != inst.original.ClawedBack {
+			kv["clawed_back"] = inst.ClawedBack
+		}
+		if inst.Debt != inst.original.Debt {
+			kv["debt"] = inst.Debt
+		}
+		if inst.Payload != inst.original.Payload {
+			kv["payload"] = inst.Payload
+		}
+		if inst.ReviewedAt != inst.original.ReviewedAt {
+			kv["reviewed_at"] = inst.ReviewedAt
+		}
+		if inst.ReviewNote != inst.original.ReviewNote {
+			kv["review_note"] = inst.ReviewNote
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "payment_id":
+				if inst.PaymentId != inst.original.PaymentId {
+					kv["payment_id"] = inst.PaymentId
+				}
+			case "transaction_key":
+				if inst.TransactionKey != inst.original.TransactionKey {
+					kv["transaction_key"] = inst.TransactionKey
+				}
+			case "notification_id":
+				if inst.NotificationId != inst.original.NotificationId {
+					kv["notification_id"] = inst.NotificationId
+				}
+			case "notification_type":
+				if inst.NotificationType != inst.original.NotificationType {
+					kv["notification_type"] = inst.NotificationType
+				}
+			case "environment":
+				if inst.Environment != inst.original.Environment {
+					kv["environment"] = inst.Environment
+				}
+			case "quantity":
+				if inst.Quantity != inst.original.Quantity {
+					kv["quantity"] = inst.Quantity
+				}
+			case "clawed_back":
+				if inst.ClawedBack != inst.original.ClawedBack {
+					kv["clawed_back"] = inst.ClawedBack
+				}
+			case "debt":
+				if inst.Debt != inst.original.Debt {
+					kv["debt"] = inst.Debt
+				}
+			case "payload":
+				if inst.Payload != inst.original.Payload {
+					kv["payload"] = inst.Payload
+				}
+			case "reviewed_at":
+				if inst.ReviewedAt != inst.original.ReviewedAt {
+					kv["reviewed_at"] = inst.ReviewedAt
+				}
+			case "review_note":
+				if inst.ReviewNote != inst.original.ReviewNote {
+					kv["review_note"] = inst.ReviewNote
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *PaymentRefundN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.paymentRefundModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.paymentRefundModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a payment_refund
+func (inst *PaymentRefundN) Delete(ctx context.Context) error {
+	if inst.paymentRefundModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.paymentRefundModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *PaymentRefundN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type paymentRefundScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var paymentRefundGlobalScopes = make([]paymentRefundScope, 0)
+var paymentRefundLocalScopes = make([]paymentRefundScope, 0)
+
+// AddGlobalScopeForPaymentRefund assign a global scope to a model
+func AddGlobalScopeForPaymentRefund(name string, apply func(builder query.Condition)) {
+	paymentRefundGlobalScopes = append(paymentRefundGlobalScopes, paymentRefundScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForPaymentRefund assign a local scope to a model
+func AddLocalScopeForPaymentRefund(name string, apply func(builder query.Condition)) {
+	paymentRefundLocalScopes = append(paymentRefundLocalScopes, paymentRefundScope{name: name, apply: apply})
+}
+
+func (m *PaymentRefundModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range paymentRefundGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range paymentRefundLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *PaymentRefundModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *PaymentRefundModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PaymentRefund struct {
+	Id               int64     `json:"id"`
+	UserId           int64     `json:"user_id"`
+	OrgId            int64     `json:"org_id"`
+	PaymentId        string    `json:"payment_id"`
+	TransactionKey   string    `json:"transaction_key"`
+	NotificationId   string    `json:"notification_id"`
+	NotificationType string    `json:"notification_type"`
+	Environment      string    `json:"environment"`
+	Quantity         int64     `json:"quantity"`
+	ClawedBack       int64     `json:"clawed_back"`
+	Debt             int64     `json:"debt"`
+	Payload          string    `json:"payload"`
+	ReviewedAt       time.Time `json:"reviewed_at"`
+	ReviewNote       string    `json:"review_note"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (w PaymentRefund) ToPaymentRefundN(allows ...string) PaymentRefundN {
+	if len(allows) == 0 {
+		return PaymentRefundN{
+
+			Id:               null.IntFrom(int64(w.Id)),
+			UserId:           null.IntFrom(int64(w.UserId)),
+			OrgId:            null.IntFrom(int64(w.OrgId)),
+			PaymentId:        null.StringFrom(w.PaymentId),
+			TransactionKey:   null.StringFrom(w.TransactionKey),
+			NotificationId:   null.StringFrom(w.NotificationId),
+			NotificationType: null.StringFrom(w.NotificationType),
+			Environment:      null.StringFrom(w.Environment),
+			Quantity:         null.IntFrom(int64(w.Quantity)),
+			ClawedBack:       null.IntFrom(int64(w.ClawedBack)),
+			Debt:             null.IntFrom(int64(w.Debt)),
+			Payload:          null.StringFrom(w.Payload),
+			ReviewedAt:       null.TimeFrom(w.ReviewedAt),
+			ReviewNote:       null.StringFrom(w.ReviewNote),
+			CreatedAt:        null.TimeFrom(w.CreatedAt),
+			UpdatedAt:        null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := PaymentRefundN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "payment_id":
+			res.PaymentId = null.StringFrom(w.PaymentId)
+		case "transaction_key":
+			res.TransactionKey = null.StringFrom(w.TransactionKey)
+		case "notification_id":
+			res.NotificationId = null.StringFrom(w.NotificationId)
+		case "notification_type":
+			res.NotificationType = null.StringFrom(w.NotificationType)
+		case "environment":
+			res.Environment = null.StringFrom(w.Environment)
+		case "quantity":
+			res.Quantity = null.IntFrom(int64(w.Quantity))
+		case "clawed_back":
+			res.ClawedBack = null.IntFrom(int64(w.ClawedBack))
+		case "debt":
+			res.Debt = null.IntFrom(int64(w.Debt))
+		case "payload":
+			res.Payload = null.StringFrom(w.Payload)
+		case "reviewed_at":
+			res.ReviewedAt = null.TimeFrom(w.ReviewedAt)
+		case "review_note":
+			res.ReviewNote = null.StringFrom(w.ReviewNote)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w PaymentRefund) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *PaymentRefundN) ToPaymentRefund() PaymentRefund {
+	return PaymentRefund{
+
+		Id:               w.Id.Int64,
+		UserId:           w.UserId.Int64,
+		OrgId:            w.OrgId.Int64,
+		PaymentId:        w.PaymentId.String,
+		TransactionKey:   w.TransactionKey.String,
+		NotificationId:   w.NotificationId.String,
+		NotificationType: w.NotificationType.String,
+		Environment:      w.Environment.String,
+		Quantity:         w.Quantity.Int64,
+		ClawedBack:       w.ClawedBack.Int64,
+		Debt:             w.Debt.Int64,
+		Payload:          w.Payload.String,
+		ReviewedAt:       w.ReviewedAt.Time,
+		ReviewNote:       w.ReviewNote.String,
+		CreatedAt:        w.CreatedAt.Time,
+		UpdatedAt:        w.UpdatedAt.Time,
+	}
+}
+
+// PaymentRefundModel is a model which encapsulates the operations of the object
+type PaymentRefundModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var paymentRefundTableName = "payment_refund"
+
+// PaymentRefundTable return table name for PaymentRefund
+func PaymentRefundTable() string {
+	return paymentRefundTableName
+}
+
+const (
+	FieldPaymentRefundId               = "id"
+	FieldPaymentRefundUserId           = "user_id"
+	FieldPaymentRefundOrgId            = "org_id"
+	FieldPaymentRefundPaymentId        = "payment_id"
+	FieldPaymentRefundTransactionKey   = "transaction_key"
+	FieldPaymentRefundNotificationId   = "notification_id"
+	FieldPaymentRefundNotificationType = "notification_type"
+	FieldPaymentRefundEnvironment      = "environment"
+	FieldPaymentRefundQuantity         = "quantity"
+	FieldPaymentRefundClawedBack       = "clawed_back"
+	FieldPaymentRefundDebt             = "debt"
+	FieldPaymentRefundPayload          = "payload"
+	FieldPaymentRefundReviewedAt       = "reviewed_at"
+	FieldPaymentRefundReviewNote       = "review_note"
+	FieldPaymentRefundCreatedAt        = "created_at"
+	FieldPaymentRefundUpdatedAt        = "updated_at"
+)
+
+// PaymentRefundFields return all fields in PaymentRefund model
+func PaymentRefundFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"org_id",
+		"payment_id",
+		"transaction_key",
+		"notification_id",
+		"notification_type",
+		"environment",
+		"quantity",
+		"clawed_back",
+		"debt",
+		"payload",
+		"reviewed_at",
+		"review_note",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetPaymentRefundTable(tableName string) {
+	paymentRefundTableName = tableName
+}
+
+// NewPaymentRefundModel create a PaymentRefundModel
+func NewPaymentRefundModel(db query.Database) *PaymentRefundModel {
+	return &PaymentRefundModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           paymentRefundTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *PaymentRefundModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *PaymentRefundModel) clone() *PaymentRefundModel {
+	return &PaymentRefundModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *PaymentRefundModel) WithoutGlobalScopes(names ...string) *PaymentRefundModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *PaymentRefundModel) WithLocalScopes(names ...string) *PaymentRefundModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *PaymentRefundModel) Condition(builder query.SQLBuilder) *PaymentRefundModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *PaymentRefundModel) Find(ctx context.Context, id int64) (*PaymentRefundN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *PaymentRefundModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *PaymentRefundModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *PaymentRefundModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]PaymentRefundN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *PaymentRefundModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]PaymentRefundN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"org_id",
+			"payment_id",
+			"transaction_key",
+			"notification_id",
+			"notification_type",
+			"environment",
+			"quantity",
+			"clawed_back",
+			"debt",
+			"payload",
+			"reviewed_at",
+			"review_note",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "payment_id":
+			selectFields = append(selectFields, f)
+		case "transaction_key":
+			selectFields = append(selectFields, f)
+		case "notification_id":
+			selectFields = append(selectFields, f)
+		case "notification_type":
+			selectFields = append(selectFields, f)
+		case "environment":
+			selectFields = append(selectFields, f)
+		case "quantity":
+			selectFields = append(selectFields, f)
+		case "clawed_back":
+			selectFields = append(selectFields, f)
+		case "debt":
+			selectFields = append(selectFields, f)
+		case "payload":
+			selectFields = append(selectFields, f)
+		case "reviewed_at":
+			selectFields = append(selectFields, f)
+		case "review_note":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*PaymentRefundN, []interface{}) {
+		var paymentRefundVar PaymentRefundN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &paymentRefundVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &paymentRefundVar.UserId)
+			case "org_id":
+				scanFields = append(scanFields, &paymentRefundVar.OrgId)
+			case "payment_id":
+				scanFields = append(scanFields, &paymentRefundVar.PaymentId)
+			case "transaction_key":
+				scanFields = append(scanFields, &paymentRefundVar.TransactionKey)
+			case "notification_id":
+				scanFields = append(scanFields, &paymentRefundVar.NotificationId)
+			case "notification_type":
+				scanFields = append(scanFields, &paymentRefundVar.NotificationType)
+			case "environment":
+				scanFields = append(scanFields, &paymentRefundVar.Environment)
+			case "quantity":
+				scanFields = append(scanFields, &paymentRefundVar.Quantity)
+			case "clawed_back":
+				scanFields = append(scanFields, &paymentRefundVar.ClawedBack)
+			case "debt":
+				scanFields = append(scanFields, &paymentRefundVar.Debt)
+			case "payload":
+				scanFields = append(scanFields, &paymentRefundVar.Payload)
+			case "reviewed_at":
+				scanFields = append(scanFields, &paymentRefundVar.ReviewedAt)
+			case "review_note":
+				scanFields = append(scanFields, &paymentRefundVar.ReviewNote)
+			case "created_at":
+				scanFields = append(scanFields, &paymentRefundVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &paymentRefundVar.UpdatedAt)
+			}
+		}
+
+		return &paymentRefundVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	paymentRefunds := make([]PaymentRefundN, 0)
+	for rows.Next() {
+		paymentRefundReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		paymentRefundReal.original = &paymentRefundOriginal{}
+		_ = query.Copy(paymentRefundReal, paymentRefundReal.original)
+
+		paymentRefundReal.SetModel(m)
+		paymentRefunds = append(paymentRefunds, *paymentRefundReal)
+	}
+
+	return paymentRefunds, nil
+}
+
+// First return first result for given query
+func (m *PaymentRefundModel) First(ctx context.Context, builders ...query.SQLBuilder) (*PaymentRefundN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new payment_refund to database
+func (m *PaymentRefundModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all payment_refunds to database
+func (m *PaymentRefundModel) SaveAll(ctx context.Context, paymentRefunds []PaymentRefundN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, paymentRefund := range paymentRefunds {
+		id, err := m.Save(ctx, paymentRefund)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a payment_refund to database
+func (m *PaymentRefundModel) Save(ctx context.Context, paymentRefund PaymentRefundN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, paymentRefund.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new payment_refund or update it when it has a id > 0
+func (m *PaymentRefundModel) SaveOrUpdate(ctx context.Context, paymentRefund PaymentRefundN, onlyFields ...string) (id int64, updated bool, err error) {
+	if paymentRefund.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, paymentRefund.Id.Int64, paymentRefund, onlyFields...)
+		return paymentRefund.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, paymentRefund, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *PaymentRefundModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *PaymentRefundModel) Update(ctx context.Context, builder query.SQLBuilder, paymentRefund PaymentRefundN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, paymentRefund.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *PaymentRefundModel) UpdateById(ctx context.Context, id int64, paymentRefund PaymentRefundN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, paymentRefund.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *PaymentRefundModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *PaymentRefundModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,1506 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// PdfDocumentN is a PdfDocument object, all fields are nullable
+type PdfDocumentN struct {
+	original         *pdfDocumentOriginal
+	pdfDocumentModel *PdfDocumentModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Name      null.String `json:"name"`
+	Url       null.String `json:"url"`
+	Model     null.String `json:"model"`
+	Pages     null.Int    `json:"pages"`
+	Chunks    null.Int    `json:"chunks"`
+	Coins     null.Int    `json:"coins"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *PdfDocumentN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for PdfDocument
+func (inst *PdfDocumentN) SetModel(pdfDocumentModel *PdfDocumentModel) {
+	inst.pdfDocumentModel = pdfDocumentModel
+}
+
+// pdfDocumentOriginal is an object which stores original PdfDocument from database
+type pdfDocumentOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Name      null.String
+	Url       null.String
+	Model     null.String
+	Pages     null.Int
+	Chunks    null.Int
+	Coins     null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *PdfDocumentN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &pdfDocumentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Url != inst.original.Url {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.Pages != inst.original.Pages {
+			return true
+		}
+		if inst.Chunks != inst.original.Chunks {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "url":
+				if inst.Url != inst.original.Url {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "pages":
+				if inst.Pages != inst.original.Pages {
+					return true
+				}
+			case "chunks":
+				if inst.Chunks != inst.original.Chunks {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *PdfDocumentN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &pdfDocumentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Url != inst.original.Url {
+			kv["url"] = inst.Url
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.Pages != inst.original.Pages {
+			kv["pages"] = inst.Pages
+		}
+		if inst.Chunks != inst.original.Chunks {
+			kv["chunks"] = inst.Chunks
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "url":
+				if inst.Url != inst.original.Url {
+					kv["url"] = inst.Url
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "pages":
+				if inst.Pages != inst.original.Pages {
+					kv["pages"] = inst.Pages
+				}
+			case "chunks":
+				if inst.Chunks != inst.original.Chunks {
+					kv["chunks"] = inst.Chunks
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *PdfDocumentN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.pdfDocumentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.pdfDocumentModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a pdf_document
+func (inst *PdfDocumentN) Delete(ctx context.Context) error {
+	if inst.pdfDocumentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.pdfDocumentModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *PdfDocumentN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type pdfDocumentScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var pdfDocumentGlobalScopes = make([]pdfDocumentScope, 0)
+var pdfDocumentLocalScopes = make([]pdfDocumentScope, 0)
+
+// AddGlobalScopeForPdfDocument assign a global scope to a model
+func AddGlobalScopeForPdfDocument(name string, apply func(builder query.Condition)) {
+	pdfDocumentGlobalScopes = append(pdfDocumentGlobalScopes, pdfDocumentScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForPdfDocument assign a local scope to a model
+func AddLocalScopeForPdfDocument(name string, apply func(builder query.Condition)) {
+	pdfDocumentLocalScopes = append(pdfDocumentLocalScopes, pdfDocumentScope{name: name, apply: apply})
+}
+
+func (m *PdfDocumentModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range pdfDocumentGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range pdfDocumentLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *PdfDocumentModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *PdfDocumentModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PdfDocument struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	Url       string `json:"url"`
+	Model     string `json:"model"`
+	Pages     int64  `json:"pages"`
+	Chunks    int64  `json:"chunks"`
+	Coins     int64  `json:"coins"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w PdfDocument) ToPdfDocumentN(allows ...string) PdfDocumentN {
+	if len(allows) == 0 {
+		return PdfDocumentN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Name:      null.StringFrom(w.Name),
+			Url:       null.StringFrom(w.Url),
+			Model:     null.StringFrom(w.Model),
+			Pages:     null.IntFrom(int64(w.Pages)),
+			Chunks:    null.IntFrom(int64(w.Chunks)),
+			Coins:     null.IntFrom(int64(w.Coins)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := PdfDocumentN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "url":
+			res.Url = null.StringFrom(w.Url)
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "pages":
+			res.Pages = null.IntFrom(int64(w.Pages))
+		case "chunks":
+			res.Chunks = null.IntFrom(int64(w.Chunks))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w PdfDocument) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *PdfDocumentN) ToPdfDocument() PdfDocument {
+	return PdfDocument{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Name:      w.Name.String,
+		Url:       w.Url.String,
+		Model:     w.Model.String,
+		Pages:     w.Pages.Int64,
+		Chunks:    w.Chunks.Int64,
+		Coins:     w.Coins.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// PdfDocumentModel is a model which encapsulates the operations of the object
+type PdfDocumentModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var pdfDocumentTableName = "pdf_document"
+
+// PdfDocumentTable return table name for PdfDocument
+func PdfDocumentTable() string {
+	return pdfDocumentTableName
+}
+
+const (
+	FieldPdfDocumentId        = "id"
+	FieldPdfDocumentUserId    = "user_id"
+	FieldPdfDocumentName      = "name"
+	FieldPdfDocumentUrl       = "url"
+	FieldPdfDocumentModel     = "model"
+	FieldPdfDocumentPages     = "pages"
+	FieldPdfDocumentChunks    = "chunks"
+	FieldPdfDocumentCoins     = "coins"
+	FieldPdfDocumentCreatedAt = "created_at"
+	FieldPdfDocumentUpdatedAt = "updated_at"
+)
+
+// PdfDocumentFields return all fields in PdfDocument model
+func PdfDocumentFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"name",
+		"url",
+		"model",
+		"pages",
+		"chunks",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetPdfDocumentTable(tableName string) {
+	pdfDocumentTableName = tableName
+}
+
+// NewPdfDocumentModel create a PdfDocumentModel
+func NewPdfDocumentModel(db query.Database) *PdfDocumentModel {
+	return &PdfDocumentModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           pdfDocumentTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *PdfDocumentModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *PdfDocumentModel) clone() *PdfDocumentModel {
+	return &PdfDocumentModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *PdfDocumentModel) WithoutGlobalScopes(names ...string) *PdfDocumentModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *PdfDocumentModel) WithLocalScopes(names ...string) *PdfDocumentModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *PdfDocumentModel) Condition(builder query.SQLBuilder) *PdfDocumentModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *PdfDocumentModel) Find(ctx context.Context, id int64) (*PdfDocumentN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *PdfDocumentModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *PdfDocumentModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *PdfDocumentModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]PdfDocumentN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *PdfDocumentModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]PdfDocumentN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"name",
+			"url",
+			"model",
+			"pages",
+			"chunks",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "url":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "pages":
+			selectFields = append(selectFields, f)
+		case "chunks":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*PdfDocumentN, []interface{}) {
+		var pdfDocumentVar PdfDocumentN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &pdfDocumentVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &pdfDocumentVar.UserId)
+			case "name":
+				scanFields = append(scanFields, &pdfDocumentVar.Name)
+			case "url":
+				scanFields = append(scanFields, &pdfDocumentVar.Url)
+			case "model":
+				scanFields = append(scanFields, &pdfDocumentVar.Model)
+			case "pages":
+				scanFields = append(scanFields, &pdfDocumentVar.Pages)
+			case "chunks":
+				scanFields = append(scanFields, &pdfDocumentVar.Chunks)
+			case "coins":
+				scanFields = append(scanFields, &pdfDocumentVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &pdfDocumentVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &pdfDocumentVar.UpdatedAt)
+			}
+		}
+
+		return &pdfDocumentVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pdfDocuments := make([]PdfDocumentN, 0)
+	for rows.Next() {
+		pdfDocumentReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		pdfDocumentReal.original = &pdfDocumentOriginal{}
+		_ = query.Copy(pdfDocumentReal, pdfDocumentReal.original)
+
+		pdfDocumentReal.SetModel(m)
+		pdfDocuments = append(pdfDocuments, *pdfDocumentReal)
+	}
+
+	return pdfDocuments, nil
+}
+
+// First return first result for given query
+func (m *PdfDocumentModel) First(ctx context.Context, builders ...query.SQLBuilder) (*PdfDocumentN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new pdf_document to database
+func (m *PdfDocumentModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all pdf_documents to database
+func (m *PdfDocumentModel) SaveAll(ctx context.Context, pdfDocuments []PdfDocumentN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, pdfDocument := range pdfDocuments {
+		id, err := m.Save(ctx, pdfDocument)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a pdf_document to database
+func (m *PdfDocumentModel) Save(ctx context.Context, pdfDocument PdfDocumentN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, pdfDocument.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new pdf_document or update it when it has a id > 0
+func (m *PdfDocumentModel) SaveOrUpdate(ctx context.Context, pdfDocument PdfDocumentN, onlyFields ...string) (id int64, updated bool, err error) {
+	if pdfDocument.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, pdfDocument.Id.Int64, pdfDocument, onlyFields...)
+		return pdfDocument.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, pdfDocument, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *PdfDocumentModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *PdfDocumentModel) Update(ctx context.Context, builder query.SQLBuilder, pdfDocument PdfDocumentN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, pdfDocument.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *PdfDocumentModel) UpdateById(ctx context.Context, id int64, pdfDocument PdfDocumentN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, pdfDocument.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *PdfDocumentModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *PdfDocumentModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// PdfChunkN is a PdfChunk object, all fields are nullable
+type PdfChunkN struct {
+	original      *pdfChunkOriginal
+	pdfChunkModel *PdfChunkModel
+
+	Id         null.Int    `json:"id"`
+	DocumentId null.Int    `json:"document_id"`
+	Page       null.Int    `json:"page"`
+	Content    null.String `json:"content"`
+	Vector     null.String `json:"-"`
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *PdfChunkN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for PdfChunk
+func (inst *PdfChunkN) SetModel(pdfChunkModel *PdfChunkModel) {
+	inst.pdfChunkModel = pdfChunkModel
+}
+
+// pdfChunkOriginal is an object which stores original PdfChunk from database
+type pdfChunkOriginal struct {
+	Id         null.Int
+	DocumentId null.Int
+	Page       null.Int
+	Content    null.String
+	Vector     null.String
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *PdfChunkN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &pdfChunkOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.DocumentId != inst.original.DocumentId {
+			return true
+		}
+		if inst.Page != inst.original.Page {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.Vector != inst.original.Vector {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "document_id":
+				if inst.DocumentId != inst.original.DocumentId {
+					return true
+				}
+			case "page":
+				if inst.Page != inst.original.Page {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "vector":
+				if inst.Vector != inst.original.Vector {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *PdfChunkN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &pdfChunkOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.DocumentId != inst.original.DocumentId {
+			kv["document_id"] = inst.DocumentId
+		}
+		if inst.Page != inst.original.Page {
+			kv["page"] = inst.Page
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.Vector != inst.original.Vector {
+			kv["vector"] = inst.Vector
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "document_id":
+				if inst.DocumentId != inst.original.DocumentId {
+					kv["document_id"] = inst.DocumentId
+				}
+			case "page":
+				if inst.Page != inst.original.Page {
+					kv["page"] = inst.Page
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "vector":
+				if inst.Vector != inst.original.Vector {
+					kv["vector"] = inst.Vector
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *PdfChunkN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.pdfChunkModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.pdfChunkModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a pdf_chunk
+func (inst *PdfChunkN) Delete(ctx context.Context) error {
+	if inst.pdfChunkModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.pdfChunkModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *PdfChunkN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type pdfChunkScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var pdfChunkGlobalScopes = make([]pdfChunkScope, 0)
+var pdfChunkLocalScopes = make([]pdfChunkScope, 0)
+
+// AddGlobalScopeForPdfChunk assign a global scope to a model
+func AddGlobalScopeForPdfChunk(name string, apply func(builder query.Condition)) {
+	pdfChunkGlobalScopes = append(pdfChunkGlobalScopes, pdfChunkScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForPdfChunk assign a local scope to a model
+func AddLocalScopeForPdfChunk(name string, apply func(builder query.Condition)) {
+	pdfChunkLocalScopes = append(pdfChunkLocalScopes, pdfChunkScope{name: name, apply: apply})
+}
+
+func (m *PdfChunkModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range pdfChunkGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range pdfChunkLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *PdfChunkModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *PdfChunkModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PdfChunk struct {
+	Id         int64  `json:"id"`
+	DocumentId int64  `json:"document_id"`
+	Page       int64  `json:"page"`
+	Content    string `json:"content"`
+	Vector     string `json:"-"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w PdfChunk) ToPdfChunkN(allows ...string) PdfChunkN {
+	if len(allows) == 0 {
+		return PdfChunkN{
+
+			Id:         null.IntFrom(int64(w.Id)),
+			DocumentId: null.IntFrom(int64(w.DocumentId)),
+			Page:       null.IntFrom(int64(w.Page)),
+			Content:    null.StringFrom(w.Content),
+			Vector:     null.StringFrom(w.Vector),
+			CreatedAt:  null.TimeFrom(w.CreatedAt),
+			UpdatedAt:  null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := PdfChunkN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "document_id":
+			res.DocumentId = null.IntFrom(int64(w.DocumentId))
+		case "page":
+			res.Page = null.IntFrom(int64(w.Page))
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "vector":
+			res.Vector = null.StringFrom(w.Vector)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w PdfChunk) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *PdfChunkN) ToPdfChunk() PdfChunk {
+	return PdfChunk{
+
+		Id:         w.Id.Int64,
+		DocumentId: w.DocumentId.Int64,
+		Page:       w.Page.Int64,
+		Content:    w.Content.String,
+		Vector:     w.Vector.String,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+	}
+}
+
+// PdfChunkModel is a model which encapsulates the operations of the object
+type PdfChunkModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var pdfChunkTableName = "pdf_chunk"
+
+// PdfChunkTable return table name for PdfChunk
+func PdfChunkTable() string {
+	return pdfChunkTableName
+}
+
+const (
+	FieldPdfChunkId         = "id"
+	FieldPdfChunkDocumentId = "document_id"
+	FieldPdfChunkPage       = "page"
+	FieldPdfChunkContent    = "content"
+	FieldPdfChunkVector     = "vector"
+	FieldPdfChunkCreatedAt  = "created_at"
+	FieldPdfChunkUpdatedAt  = "updated_at"
+)
+
+// PdfChunkFields return all fields in PdfChunk model
+func PdfChunkFields() []string {
+	return []string{
+		"id",
+		"document_id",
+		"page",
+		"content",
+		"vector",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetPdfChunkTable(tableName string) {
+	pdfChunkTableName = tableName
+}
+
+// NewPdfChunkModel create a PdfChunkModel
+func NewPdfChunkModel(db query.Database) *PdfChunkModel {
+	return &PdfChunkModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           pdfChunkTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *PdfChunkModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *PdfChunkModel) clone() *PdfChunkModel {
+	return &PdfChunkModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *PdfChunkModel) WithoutGlobalScopes(names ...string) *PdfChunkModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *PdfChunkModel) WithLocalScopes(names ...string) *PdfChunkModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *PdfChunkModel) Condition(builder query.SQLBuilder) *PdfChunkModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *PdfChunkModel) Find(ctx context.Context, id int64) (*PdfChunkN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *PdfChunkModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *PdfChunkModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *PdfChunkModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]PdfChunkN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *PdfChunkModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]PdfChunkN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"document_id",
+			"page",
+			"content",
+			"vector",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "document_id":
+			selectFields = append(selectFields, f)
+		case "page":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "vector":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*PdfChunkN, []interface{}) {
+		var pdfChunkVar PdfChunkN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &pdfChunkVar.Id)
+			case "document_id":
+				scanFields = append(scanFields, &pdfChunkVar.DocumentId)
+			case "page":
+				scanFields = append(scanFields, &pdfChunkVar.Page)
+			case "content":
+				scanFields = append(scanFields, &pdfChunkVar.Content)
+			case "vector":
+				scanFields = append(scanFields, &pdfChunkVar.Vector)
+			case "created_at":
+				scanFields = append(scanFields, &pdfChunkVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &pdfChunkVar.UpdatedAt)
+			}
+		}
+
+		return &pdfChunkVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pdfChunks := make([]PdfChunkN, 0)
+	for rows.Next() {
+		pdfChunkReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		pdfChunkReal.original = &pdfChunkOriginal{}
+		_ = query.Copy(pdfChunkReal, pdfChunkReal.original)
+
+		pdfChunkReal.SetModel(m)
+		pdfChunks = append(pdfChunks, *pdfChunkReal)
+	}
+
+	return pdfChunks, nil
+}
+
+// First return first result for given query
+func (m *PdfChunkModel) First(ctx context.Context, builders ...query.SQLBuilder) (*PdfChunkN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new pdf_chunk to database
+func (m *PdfChunkModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all pdf_chunks to database
+func (m *PdfChunkModel) SaveAll(ctx context.Context, pdfChunks []PdfChunkN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, pdfChunk := range pdfChunks {
+		id, err := m.Save(ctx, pdfChunk)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a pdf_chunk to database
+func (m *PdfChunkModel) Save(ctx context.Context, pdfChunk PdfChunkN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, pdfChunk.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new pdf_chunk or update it when it has a id > 0
+func (m *PdfChunkModel) SaveOrUpdate(ctx context.Context, pdfChunk PdfChunkN, onlyFields ...string) (id int64, updated bool, err error) {
+	if pdfChunk.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, pdfChunk.Id.Int64, pdfChunk, onlyFields...)
+		return pdfChunk.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, pdfChunk, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *PdfChunkModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *PdfChunkModel) Update(ctx context.Context, builder query.SQLBuilder, pdfChunk PdfChunkN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, pdfChunk.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *PdfChunkModel) UpdateById(ctx context.Context, id int64, pdfChunk PdfChunkN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, pdfChunk.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *PdfChunkModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *PdfChunkModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,2405 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// PromoEventN is a PromoEvent object, all fields are nullable
+type PromoEventN struct {
+	original        *promoEventOriginal
+	promoEventModel *PromoEventModel
+
+	Id          null.Int    `json:"id"`
+	Name        null.String `json:"name"`
+	Description null.String `json:"description,omitempty"`
+	Status      null.Int    `json:"status"`
+	StartAt     null.Time   `json:"start_at"`
+	EndAt       null.Time   `json:"end_at"`
+	DailyLimit  null.Int    `json:"daily_limit"`
+	TotalLimit  null.Int    `json:"total_limit"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *PromoEventN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for PromoEvent
+func (inst *PromoEventN) SetModel(promoEventModel *PromoEventModel) {
+	inst.promoEventModel = promoEventModel
+}
+
+// promoEventOriginal is an object which stores original PromoEvent from database
+type promoEventOriginal struct {
+	Id          null.Int
+	Name        null.String
+	Description null.String
+	Status      null.Int
+	StartAt     null.Time
+	EndAt       null.Time
+	DailyLimit  null.Int
+	TotalLimit  null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *PromoEventN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &promoEventOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Description != inst.original.Description {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.StartAt != inst.original.StartAt {
+			return true
+		}
+		if inst.EndAt != inst.original.EndAt {
+			return true
+		}
+		if inst.DailyLimit != inst.original.DailyLimit {
+			return true
+		}
+		if inst.TotalLimit != inst.original.TotalLimit {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "start_at":
+				if inst.StartAt != inst.original.StartAt {
+					return true
+				}
+			case "end_at":
+				if inst.EndAt != inst.original.EndAt {
+					return true
+				}
+			case "daily_limit":
+				if inst.DailyLimit != inst.original.DailyLimit {
+					return true
+				}
+			case "total_limit":
+				if inst.TotalLimit != inst.original.TotalLimit {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *PromoEventN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &promoEventOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Description != inst.original.Description {
+			kv["description"] = inst.Description
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.StartAt != inst.original.StartAt {
+			kv["start_at"] = inst.StartAt
+		}
+		if inst.EndAt != inst.original.EndAt {
+			kv["end_at"] = inst.EndAt
+		}
+		if inst.DailyLimit != inst.original.DailyLimit {
+			kv["daily_limit"] = inst.DailyLimit
+		}
+		if inst.TotalLimit != inst.original.TotalLimit {
+			kv["total_limit"] = inst.TotalLimit
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					kv["description"] = inst.Description
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "start_at":
+				if inst.StartAt != inst.original.StartAt {
+					kv["start_at"] = inst.StartAt
+				}
+			case "end_at":
+				if inst.EndAt != inst.original.EndAt {
+					kv["end_at"] = inst.EndAt
+				}
+			case "daily_limit":
+				if inst.DailyLimit != inst.original.DailyLimit {
+					kv["daily_limit"] = inst.DailyLimit
+				}
+			case "total_limit":
+				if inst.TotalLimit != inst.original.TotalLimit {
+					kv["total_limit"] = inst.TotalLimit
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *PromoEventN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.promoEventModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.promoEventModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a promo_event
+func (inst *PromoEventN) Delete(ctx context.Context) error {
+	if inst.promoEventModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.promoEventModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *PromoEventN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type promoEventScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var promoEventGlobalScopes = make([]promoEventScope, 0)
+var promoEventLocalScopes = make([]promoEventScope, 0)
+
+// AddGlobalScopeForPromoEvent assign a global scope to a model
+func AddGlobalScopeForPromoEvent(name string, apply func(builder query.Condition)) {
+	promoEventGlobalScopes = append(promoEventGlobalScopes, promoEventScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForPromoEvent assign a local scope to a model
+func AddLocalScopeForPromoEvent(name string, apply func(builder query.Condition)) {
+	promoEventLocalScopes = append(promoEventLocalScopes, promoEventScope{name: name, apply: apply})
+}
+
+func (m *PromoEventModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range promoEventGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range promoEventLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *PromoEventModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *PromoEventModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PromoEvent struct {
+	Id          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Status      int64     `json:"status"`
+	StartAt     time.Time `json:"start_at"`
+	EndAt       time.Time `json:"end_at"`
+	DailyLimit  int64     `json:"daily_limit"`
+	TotalLimit  int64     `json:"total_limit"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w PromoEvent) ToPromoEventN(allows ...string) PromoEventN {
+	if len(allows) == 0 {
+		return PromoEventN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			Name:        null.StringFrom(w.Name),
+			Description: null.StringFrom(w.Description),
+			Status:      null.IntFrom(int64(w.Status)),
+			StartAt:     null.TimeFrom(w.StartAt),
+			EndAt:       null.TimeFrom(w.EndAt),
+			DailyLimit:  null.IntFrom(int64(w.DailyLimit)),
+			TotalLimit:  null.IntFrom(int64(w.TotalLimit)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := PromoEventN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "description":
+			res.Description = null.StringFrom(w.Description)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "start_at":
+			res.StartAt = null.TimeFrom(w.StartAt)
+		case "end_at":
+			res.EndAt = null.TimeFrom(w.EndAt)
+		case "daily_limit":
+			res.DailyLimit = null.IntFrom(int64(w.DailyLimit))
+		case "total_limit":
+			res.TotalLimit = null.IntFrom(int64(w.TotalLimit))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w PromoEvent) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *PromoEventN) ToPromoEvent() PromoEvent {
+	return PromoEvent{
+
+		Id:          w.Id.Int64,
+		Name:        w.Name.String,
+		Description: w.Description.String,
+		Status:      w.Status.Int64,
+		StartAt:     w.StartAt.Time,
+		EndAt:       w.EndAt.Time,
+		DailyLimit:  w.DailyLimit.Int64,
+		TotalLimit:  w.TotalLimit.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// PromoEventModel is a model which encapsulates the operations of the object
+type PromoEventModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var promoEventTableName = "promo_event"
+
+// PromoEventTable return table name for PromoEvent
+func PromoEventTable() string {
+	return promoEventTableName
+}
+
+const (
+	FieldPromoEventId          = "id"
+	FieldPromoEventName        = "name"
+	FieldPromoEventDescription = "description"
+	FieldPromoEventStatus      = "status"
+	FieldPromoEventStartAt     = "start_at"
+	FieldPromoEventEndAt       = "end_at"
+	FieldPromoEventDailyLimit  = "daily_limit"
+	FieldPromoEventTotalLimit  = "total_limit"
+	FieldPromoEventCreatedAt   = "created_at"
+	FieldPromoEventUpdatedAt   = "updated_at"
+)
+
+// PromoEventFields return all fields in PromoEvent model
+func PromoEventFields() []string {
+	return []string{
+		"id",
+		"name",
+		"description",
+		"status",
+		"start_at",
+		"end_at",
+		"daily_limit",
+		"total_limit",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetPromoEventTable(tableName string) {
+	promoEventTableName = tableName
+}
+
+// NewPromoEventModel create a PromoEventModel
+func NewPromoEventModel(db query.Database) *PromoEventModel {
+	return &PromoEventModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           promoEventTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *PromoEventModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *PromoEventModel) clone() *PromoEventModel {
+	return &PromoEventModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *PromoEventModel) WithoutGlobalScopes(names ...string) *PromoEventModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *PromoEventModel) WithLocalScopes(names ...string) *PromoEventModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *PromoEventModel) Condition(builder query.SQLBuilder) *PromoEventModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *PromoEventModel) Find(ctx context.Context, id int64) (*PromoEventN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *PromoEventModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *PromoEventModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *PromoEventModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]PromoEventN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *PromoEventModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]PromoEventN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"description",
+			"status",
+			"start_at",
+			"end_at",
+			"daily_limit",
+			"total_limit",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "description":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "start_at":
+			selectFields = append(selectFields, f)
+		case "end_at":
+			selectFields = append(selectFields, f)
+		case "daily_limit":
+			selectFields = append(selectFields, f)
+		case "total_limit":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*PromoEventN, []interface{}) {
+		var promoEventVar PromoEventN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &promoEventVar.Id)
+			case "name":
+				scanFields = append(scanFields, &promoEventVar.Name)
+			case "description":
+				scanFields = append(scanFields, &promoEventVar.Description)
+			case "status":
+				scanFields = append(scanFields, &promoEventVar.Status)
+			case "start_at":
+				scanFields = append(scanFields, &promoEventVar.StartAt)
+			case "end_at":
+				scanFields = append(scanFields, &promoEventVar.EndAt)
+			case "daily_limit":
+				scanFields = append(scanFields, &promoEventVar.DailyLimit)
+			case "total_limit":
+				scanFields = append(scanFields, &promoEventVar.TotalLimit)
+			case "created_at":
+				scanFields = append(scanFields, &promoEventVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &promoEventVar.UpdatedAt)
+			}
+		}
+
+		return &promoEventVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	promoEvents := make([]PromoEventN, 0)
+	for rows.Next() {
+		promoEventReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		promoEventReal.original = &promoEventOriginal{}
+		_ = query.Copy(promoEventReal, promoEventReal.original)
+
+		promoEventReal.SetModel(m)
+		promoEvents = append(promoEvents, *promoEventReal)
+	}
+
+	return promoEvents, nil
+}
+
+// First return first result for given query
+func (m *PromoEventModel) First(ctx context.Context, builders ...query.SQLBuilder) (*PromoEventN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new promo_event to database
+func (m *PromoEventModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all promo_events to database
+func (m *PromoEventModel) SaveAll(ctx context.Context, promoEvents []PromoEventN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, promoEvent := range promoEvents {
+		id, err := m.Save(ctx, promoEvent)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a promo_event to database
+func (m *PromoEventModel) Save(ctx context.Context, promoEvent PromoEventN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, promoEvent.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new promo_event or update it when it has a id > 0
+func (m *PromoEventModel) SaveOrUpdate(ctx context.Context, promoEvent PromoEventN, onlyFields ...string) (id int64, updated bool, err error) {
+	if promoEvent.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, promoEvent.Id.Int64, promoEvent, onlyFields...)
+		return promoEvent.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, promoEvent, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *PromoEventModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *PromoEventModel) Update(ctx context.Context, builder query.SQLBuilder, promoEvent PromoEventN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, promoEvent.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *PromoEventModel) UpdateById(ctx context.Context, id int64, promoEvent PromoEventN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, promoEvent.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *PromoEventModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *PromoEventModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// PromoPrizeN is a PromoPrize object, all fields are nullable
+type PromoPrizeN struct {
+	original        *promoPrizeOriginal
+	promoPrizeModel *PromoPrizeModel
+
+	Id          null.Int    `json:"id"`
+	EventId     null.Int    `json:"event_id"`
+	Name        null.String `json:"name"`
+	PrizeType   null.Int    `json:"prize_type"`
+	Value       null.Int    `json:"value"`
+	Probability null.Int    `json:"probability"`
+	Stock       null.Int    `json:"stock"`
+	Issued      null.Int    `json:"issued"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *PromoPrizeN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for PromoPrize
+func (inst *PromoPrizeN) SetModel(promoPrizeModel *PromoPrizeModel) {
+	inst.promoPrizeModel = promoPrizeModel
+}
+
+// promoPrizeOriginal is an object which stores original PromoPrize from database
+type promoPrizeOriginal struct {
+	Id          null.Int
+	EventId     null.Int
+	Name        null.String
+	PrizeType   null.Int
+	Value       null.Int
+	Probability null.Int
+	Stock       null.Int
+	Issued      null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *PromoPrizeN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &promoPrizeOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.EventId != inst.original.EventId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.PrizeType != inst.original.PrizeType {
+			return true
+		}
+		if inst.Value != inst.original.Value {
+			return true
+		}
+		if inst.Probability != inst.original.Probability {
+			return true
+		}
+		if inst.Stock != inst.original.Stock {
+			return true
+		}
+		if inst.Issued != inst.original.Issued {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "event_id":
+				if inst.EventId != inst.original.EventId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "prize_type":
+				if inst.PrizeType != inst.original.PrizeType {
+					return true
+				}
+			case "value":
+				if inst.Value != inst.original.Value {
+					return true
+				}
+			case "probability":
+				if inst.Probability != inst.original.Probability {
+					return true
+				}
+			case "stock":
+				if inst.Stock != inst.original.Stock {
+					return true
+				}
+			case "issued":
+				if inst.Issued != inst.original.Issued {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *PromoPrizeN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &promoPrizeOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.EventId != inst.original.EventId {
+			kv["event_id"] = inst.EventId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.PrizeType != inst.original.PrizeType {
+			kv["prize_type"] = inst.PrizeType
+		}
+		if inst.Value != inst.original.Value {
+			kv["value"] = inst.Value
+		}
+		if inst.Probability != inst.original.Probability {
+			kv["probability"] = inst.Probability
+		}
+		if inst.Stock != inst.original.Stock {
+			kv["stock"] = inst.Stock
+		}
+		if inst.Issued != inst.original.Issued {
+			kv["issued"] = inst.Issued
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "event_id":
+				if inst.EventId != inst.original.EventId {
+					kv["event_id"] = inst.EventId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "prize_type":
+				if inst.PrizeType != inst.original.PrizeType {
+					kv["prize_type"] = inst.PrizeType
+				}
+			case "value":
+				if inst.Value != inst.original.Value {
+					kv["value"] = inst.Value
+				}
+			case "probability":
+				if inst.Probability != inst.original.Probability {
+					kv["probability"] = inst.Probability
+				}
+			case "stock":
+				if inst.Stock != inst.original.Stock {
+					kv["stock"] = inst.Stock
+				}
+			case "issued":
+				if inst.Issued != inst.original.Issued {
+					kv["issued"] = inst.Issued
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *PromoPrizeN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.promoPrizeModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.promoPrizeModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a promo_prize
+func (inst *PromoPrizeN) Delete(ctx context.Context) error {
+	if inst.promoPrizeModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.promoPrizeModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *PromoPrizeN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type promoPrizeScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var promoPrizeGlobalScopes = make([]promoPrizeScope, 0)
+var promoPrizeLocalScopes = make([]promoPrizeScope, 0)
+
+// AddGlobalScopeForPromoPrize assign a global scope to a model
+func AddGlobalScopeForPromoPrize(name string, apply func(builder query.Condition)) {
+	promoPrizeGlobalScopes = append(promoPrizeGlobalScopes, promoPrizeScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForPromoPrize assign a local scope to a model
+func AddLocalScopeForPromoPrize(name string, apply func(builder query.Condition)) {
+	promoPrizeLocalScopes = append(promoPrizeLocalScopes, promoPrizeScope{name: name, apply: apply})
+}
+
+func (m *PromoPrizeModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range promoPrizeGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range promoPrizeLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *PromoPrizeModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *PromoPrizeModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PromoPrize struct {
+	Id          int64  `json:"id"`
+	EventId     int64  `json:"event_id"`
+	Name        string `json:"name"`
+	PrizeType   int64  `json:"prize_type"`
+	Value       int64  `json:"value"`
+	Probability int64  `json:"probability"`
+	Stock       int64  `json:"stock"`
+	Issued      int64  `json:"issued"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w PromoPrize) ToPromoPrizeN(allows ...string) PromoPrizeN {
+	if len(allows) == 0 {
+		return PromoPrizeN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			EventId:     null.IntFrom(int64(w.EventId)),
+			Name:        null.StringFrom(w.Name),
+			PrizeType:   null.IntFrom(int64(w.PrizeType)),
+			Value:       null.IntFrom(int64(w.Value)),
+			Probability: null.IntFrom(int64(w.Probability)),
+			Stock:       null.IntFrom(int64(w.Stock)),
+			Issued:      null.IntFrom(int64(w.Issued)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := PromoPrizeN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "event_id":
+			res.EventId = null.IntFrom(int64(w.EventId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "prize_type":
+			res.PrizeType = null.IntFrom(int64(w.PrizeType))
+		case "value":
+			res.Value = null.IntFrom(int64(w.Value))
+		case "probability":
+			res.Probability = null.IntFrom(int64(w.Probability))
+		case "stock":
+			res.Stock = null.IntFrom(int64(w.Stock))
+		case "issued":
+			res.Issued = null.IntFrom(int64(w.Issued))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w PromoPrize) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *PromoPrizeN) ToPromoPrize() PromoPrize {
+	return PromoPrize{
+
+		Id:          w.Id.Int64,
+		EventId:     w.EventId.Int64,
+		Name:        w.Name.String,
+		PrizeType:   w.PrizeType.Int64,
+		Value:       w.Value.Int64,
+		Probability: w.Probability.Int64,
+		Stock:       w.Stock.Int64,
+		Issued:      w.Issued.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// PromoPrizeModel is a model which encapsulates the operations of the object
+type PromoPrizeModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var promoPrizeTableName = "promo_prize"
+
+// PromoPrizeTable return table name for PromoPrize
+func PromoPrizeTable() string {
+	return promoPrizeTableName
+}
+
+const (
+	FieldPromoPrizeId          = "id"
+	FieldPromoPrizeEventId     = "event_id"
+	FieldPromoPrizeName        = "name"
+	FieldPromoPrizePrizeType   = "prize_type"
+	FieldPromoPrizeValue       = "value"
+	FieldPromoPrizeProbability = "probability"
+	FieldPromoPrizeStock       = "stock"
+	FieldPromoPrizeIssued      = "issued"
+	FieldPromoPrizeCreatedAt   = "created_at"
+	FieldPromoPrizeUpdatedAt   = "updated_at"
+)
+
+// PromoPrizeFields return all fields in PromoPrize model
+func PromoPrizeFields() []string {
+	return []string{
+		"id",
+		"event_id",
+		"name",
+		"prize_type",
+		"value",
+		"probability",
+		"stock",
+		"issued",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetPromoPrizeTable(tableName string) {
+	promoPrizeTableName = tableName
+}
+
+// NewPromoPrizeModel create a PromoPrizeModel
+func NewPromoPrizeModel(db query.Database) *PromoPrizeModel {
+	return &PromoPrizeModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           promoPrizeTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *PromoPrizeModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *PromoPrizeModel) clone() *PromoPrizeModel {
+	return &PromoPrizeModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *PromoPrizeModel) WithoutGlobalScopes(names ...string) *PromoPrizeModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *PromoPrizeModel) WithLocalScopes(names ...string) *PromoPrizeModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *PromoPrizeModel) Condition(builder query.SQLBuilder) *PromoPrizeModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *PromoPrizeModel) Find(ctx context.Context, id int64) (*PromoPrizeN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *PromoPrizeModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *PromoPrizeModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *PromoPrizeModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]PromoPrizeN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *PromoPrizeModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]PromoPrizeN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"event_id",
+			"name",
+			"prize_type",
+			"value",
+			"probability",
+			"stock",
+			"issued",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "event_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "prize_type":
+			selectFields = append(selectFields, f)
+		case "value":
+			selectFields = append(selectFields, f)
+		case "probability":
+			selectFields = append(selectFields, f)
+		case "stock":
+			selectFields = append(selectFields, f)
+		case "issued":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*PromoPrizeN, []interface{}) {
+		var promoPrizeVar PromoPrizeN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &promoPrizeVar.Id)
+			case "event_id":
+				scanFields = append(scanFields, &promoPrizeVar.EventId)
+			case "name":
+				scanFields = append(scanFields, &promoPrizeVar.Name)
+			case "prize_type":
+				scanFields = append(scanFields, &promoPrizeVar.PrizeType)
+			case "value":
+				scanFields = append(scanFields, &promoPrizeVar.Value)
+			case "probability":
+				scanFields = append(scanFields, &promoPrizeVar.Probability)
+			case "stock":
+				scanFields = append(scanFields, &promoPrizeVar.Stock)
+			case "issued":
+				scanFields = append(scanFields, &promoPrizeVar.Issued)
+			case "created_at":
+				scanFields = append(scanFields, &promoPrizeVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &promoPrizeVar.UpdatedAt)
+			}
+		}
+
+		return &promoPrizeVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	promoPrizes := make([]PromoPrizeN, 0)
+	for rows.Next() {
+		promoPrizeReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		promoPrizeReal.original = &promoPrizeOriginal{}
+		_ = query.Copy(promoPrizeReal, promoPrizeReal.original)
+
+		promoPrizeReal.SetModel(m)
+		promoPrizes = append(promoPrizes, *promoPrizeReal)
+	}
+
+	return promoPrizes, nil
+}
+
+// First return first result for given query
+func (m *PromoPrizeModel) First(ctx context.Context, builders ...query.SQLBuilder) (*PromoPrizeN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new promo_prize to database
+func (m *PromoPrizeModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all promo_prizes to database
+func (m *PromoPrizeModel) SaveAll(ctx context.Context, promoPrizes []PromoPrizeN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, promoPrize := range promoPrizes {
+		id, err := m.Save(ctx, promoPrize)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a promo_prize to database
+func (m *PromoPrizeModel) Save(ctx context.Context, promoPrize PromoPrizeN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, promoPrize.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new promo_prize or update it when it has a id > 0
+func (m *PromoPrizeModel) SaveOrUpdate(ctx context.Context, promoPrize PromoPrizeN, onlyFields ...string) (id int64, updated bool, err error) {
+	if promoPrize.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, promoPrize.Id.Int64, promoPrize, onlyFields...)
+		return promoPrize.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, promoPrize, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *PromoPrizeModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *PromoPrizeModel) Update(ctx context.Context, builder query.SQLBuilder, promoPrize PromoPrizeN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, promoPrize.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *PromoPrizeModel) UpdateById(ctx context.Context, id int64, promoPrize PromoPrizeN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, promoPrize.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *PromoPrizeModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *PromoPrizeModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// PromoDrawN is a PromoDraw object, all fields are nullable
+type PromoDrawN struct {
+	original       *promoDrawOriginal
+	promoDrawModel *PromoDrawModel
+
+	Id         null.Int    `json:"id"`
+	EventId    null.Int    `json:"event_id"`
+	UserId     null.Int    `json:"user_id"`
+	PrizeId    null.Int    `json:"prize_id"`
+	PrizeType  null.Int    `json:"prize_type"`
+	Value      null.Int    `json:"value"`
+	CouponCode null.String `json:"coupon_code,omitempty"`
+	QuotaId    null.Int    `json:"quota_id"`
+	RedeemedAt null.Time   `json:"redeemed_at,omitempty"`
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *PromoDrawN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for PromoDraw
+func (inst *PromoDrawN) SetModel(promoDrawModel *PromoDrawModel) {
+	inst.promoDrawModel = promoDrawModel
+}
+
+// promoDrawOriginal is an object which stores original PromoDraw from database
+type promoDrawOriginal struct {
+	Id         null.Int
+	EventId    null.Int
+	UserId     null.Int
+	PrizeId    null.Int
+	PrizeType  null.Int
+	Value      null.Int
+	CouponCode null.String
+	QuotaId    null.Int
+	RedeemedAt null.Time
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *PromoDrawN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &promoDrawOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.EventId != inst.original.EventId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.PrizeId != inst.original.PrizeId {
+			return true
+		}
+		if inst.PrizeType != inst.original.PrizeType {
+			return true
+		}
+		if inst.Value != inst.original.Value {
+			return true
+		}
+		if inst.CouponCode != inst.original.CouponCode {
+			return true
+		}
+		if inst.QuotaId != inst.original.QuotaId {
+			return true
+		}
+		if inst.RedeemedAt != inst.original.RedeemedAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "event_id":
+				if inst.EventId != inst.original.EventId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "prize_id":
+				if inst.PrizeId != inst.original.PrizeId {
+					return true
+				}
+			case "prize_type":
+				if inst.PrizeType != inst.original.PrizeType {
+					return true
+				}
+			case "value":
+				if inst.Value != inst.original.Value {
+					return true
+				}
+			case "coupon_code":
+				if inst.CouponCode != inst.original.CouponCode {
+					return true
+				}
+			case "quota_id":
+				if inst.QuotaId != inst.original.QuotaId {
+					return true
+				}
+			case "redeemed_at":
+				if inst.RedeemedAt != inst.original.RedeemedAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *PromoDrawN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &promoDrawOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.EventId != inst.original.EventId {
+			kv["event_id"] = inst.EventId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.PrizeId != inst.original.PrizeId {
+			kv["prize_id"] = inst.PrizeId
+		}
+		if inst.PrizeType != inst.original.PrizeType {
+			kv["prize_type"] = inst.PrizeType
+		}
+		if inst.Value != inst.original.Value {
+			kv["value"] = inst.Value
+		}
+		if inst.CouponCode != inst.original.CouponCode {
+			kv["coupon_code"] = inst.CouponCode
+		}
+		if inst.QuotaId != inst.original.QuotaId {
+			kv["quota_id"] = inst.QuotaId
+		}
+		if inst.RedeemedAt != inst.original.RedeemedAt {
+			kv["redeemed_at"] = inst.RedeemedAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "event_id":
+				if inst.EventId != inst.original.EventId {
+					kv["event_id"] = inst.EventId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "prize_id":
+				if inst.PrizeId != inst.original.PrizeId {
+					kv["prize_id"] = inst.PrizeId
+				}
+			case "prize_type":
+				if inst.PrizeType != inst.original.PrizeType {
+					kv["prize_type"] = inst.PrizeType
+				}
+			case "value":
+				if inst.Value != inst.original.Value {
+					kv["value"] = inst.Value
+				}
+			case "coupon_code":
+				if inst.CouponCode != inst.original.CouponCode {
+					kv["coupon_code"] = inst.CouponCode
+				}
+			case "quota_id":
+				if inst.QuotaId != inst.original.QuotaId {
+					kv["quota_id"] = inst.QuotaId
+				}
+			case "redeemed_at":
+				if inst.RedeemedAt != inst.original.RedeemedAt {
+					kv["redeemed_at"] = inst.RedeemedAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *PromoDrawN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.promoDrawModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.promoDrawModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a promo_draw
+func (inst *PromoDrawN) Delete(ctx context.Context) error {
+	if inst.promoDrawModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.promoDrawModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *PromoDrawN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type promoDrawScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var promoDrawGlobalScopes = make([]promoDrawScope, 0)
+var promoDrawLocalScopes = make([]promoDrawScope, 0)
+
+// AddGlobalScopeForPromoDraw assign a global scope to a model
+func AddGlobalScopeForPromoDraw(name string, apply func(builder query.Condition)) {
+	promoDrawGlobalScopes = append(promoDrawGlobalScopes, promoDrawScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForPromoDraw assign a local scope to a model
+func AddLocalScopeForPromoDraw(name string, apply func(builder query.Condition)) {
+	promoDrawLocalScopes = append(promoDrawLocalScopes, promoDrawScope{name: name, apply: apply})
+}
+
+func (m *PromoDrawModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range promoDrawGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range promoDrawLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *PromoDrawModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *PromoDrawModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PromoDraw struct {
+	Id         int64     `json:"id"`
+	EventId    int64     `json:"event_id"`
+	UserId     int64     `json:"user_id"`
+	PrizeId    int64     `json:"prize_id"`
+	PrizeType  int64     `json:"prize_type"`
+	Value      int64     `json:"value"`
+	CouponCode string    `json:"coupon_code,omitempty"`
+	QuotaId    int64     `json:"quota_id"`
+	RedeemedAt time.Time `json:"redeemed_at,omitempty"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w PromoDraw) ToPromoDrawN(allows ...string) PromoDrawN {
+	if len(allows) == 0 {
+		return PromoDrawN{
+
+			Id:         null.IntFrom(int64(w.Id)),
+			EventId:    null.IntFrom(int64(w.EventId)),
+			UserId:     null.IntFrom(int64(w.UserId)),
+			PrizeId:    null.IntFrom(int64(w.PrizeId)),
+			PrizeType:  null.IntFrom(int64(w.PrizeType)),
+			Value:      null.IntFrom(int64(w.Value)),
+			CouponCode: null.StringFrom(w.CouponCode),
+			QuotaId:    null.IntFrom(int64(w.QuotaId)),
+			RedeemedAt: null.TimeFrom(w.RedeemedAt),
+			CreatedAt:  null.TimeFrom(w.CreatedAt),
+			UpdatedAt:  null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := PromoDrawN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "event_id":
+			res.EventId = null.IntFrom(int64(w.EventId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "prize_id":
+			res.PrizeId = null.IntFrom(int64(w.PrizeId))
+		case "prize_type":
+			res.PrizeType = null.IntFrom(int64(w.PrizeType))
+		case "value":
+			res.Value = null.IntFrom(int64(w.Value))
+		case "coupon_code":
+			res.CouponCode = null.StringFrom(w.CouponCode)
+		case "quota_id":
+			res.QuotaId = null.IntFrom(int64(w.QuotaId))
+		case "redeemed_at":
+			res.RedeemedAt = null.TimeFrom(w.RedeemedAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w PromoDraw) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *PromoDrawN) ToPromoDraw() PromoDraw {
+	return PromoDraw{
+
+		Id:         w.Id.Int64,
+		EventId:    w.EventId.Int64,
+		UserId:     w.UserId.Int64,
+		PrizeId:    w.PrizeId.Int64,
+		PrizeType:  w.PrizeType.Int64,
+		Value:      w.Value.Int64,
+		CouponCode: w.CouponCode.String,
+		QuotaId:    w.QuotaId.Int64,
+		RedeemedAt: w.RedeemedAt.Time,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+	}
+}
+
+// PromoDrawModel is a model which encapsulates the operations of the object
+type PromoDrawModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var promoDrawTableName = "promo_draw"
+
+// PromoDrawTable return table name for PromoDraw
+func PromoDrawTable() string {
+	return promoDrawTableName
+}
+
+const (
+	FieldPromoDrawId         = "id"
+	FieldPromoDrawEventId    = "event_id"
+	FieldPromoDrawUserId     = "user_id"
+	FieldPromoDrawPrizeId    = "prize_id"
+	FieldPromoDrawPrizeType  = "prize_type"
+	FieldPromoDrawValue      = "value"
+	FieldPromoDrawCouponCode = "coupon_code"
+	FieldPromoDrawQuotaId    = "quota_id"
+	FieldPromoDrawRedeemedAt = "redeemed_at"
+	FieldPromoDrawCreatedAt  = "created_at"
+	FieldPromoDrawUpdatedAt  = "updated_at"
+)
+
+// PromoDrawFields return all fields in PromoDraw model
+func PromoDrawFields() []string {
+	return []string{
+		"id",
+		"event_id",
+		"user_id",
+		"prize_id",
+		"prize_type",
+		"value",
+		"coupon_code",
+		"quota_id",
+		"redeemed_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetPromoDrawTable(tableName string) {
+	promoDrawTableName = tableName
+}
+
+// NewPromoDrawModel create a PromoDrawModel
+func NewPromoDrawModel(db query.Database) *PromoDrawModel {
+	return &PromoDrawModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           promoDrawTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *PromoDrawModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *PromoDrawModel) clone() *PromoDrawModel {
+	return &PromoDrawModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *PromoDrawModel) WithoutGlobalScopes(names ...string) *PromoDrawModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *PromoDrawModel) WithLocalScopes(names ...string) *PromoDrawModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *PromoDrawModel) Condition(builder query.SQLBuilder) *PromoDrawModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *PromoDrawModel) Find(ctx context.Context, id int64) (*PromoDrawN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *PromoDrawModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *PromoDrawModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *PromoDrawModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]PromoDrawN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *PromoDrawModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]PromoDrawN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"event_id",
+			"user_id",
+			"prize_id",
+			"prize_type",
+			"value",
+			"coupon_code",
+			"quota_id",
+			"redeemed_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "event_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "prize_id":
+			selectFields = append(selectFields, f)
+		case "prize_type":
+			selectFields = append(selectFields, f)
+		case "value":
+			selectFields = append(selectFields, f)
+		case "coupon_code":
+			selectFields = append(selectFields, f)
+		case "quota_id":
+			selectFields = append(selectFields, f)
+		case "redeemed_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*PromoDrawN, []interface{}) {
+		var promoDrawVar PromoDrawN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &promoDrawVar.Id)
+			case "event_id":
+				scanFields = append(scanFields, &promoDrawVar.EventId)
+			case "user_id":
+				scanFields = append(scanFields, &promoDrawVar.UserId)
+			case "prize_id":
+				scanFields = append(scanFields, &promoDrawVar.PrizeId)
+			case "prize_type":
+				scanFields = append(scanFields, &promoDrawVar.PrizeType)
+			case "value":
+				scanFields = append(scanFields, &promoDrawVar.Value)
+			case "coupon_code":
+				scanFields = append(scanFields, &promoDrawVar.CouponCode)
+			case "quota_id":
+				scanFields = append(scanFields, &promoDrawVar.QuotaId)
+			case "redeemed_at":
+				scanFields = append(scanFields, &promoDrawVar.RedeemedAt)
+			case "created_at":
+				scanFields = append(scanFields, &promoDrawVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &promoDrawVar.UpdatedAt)
+			}
+		}
+
+		return &promoDrawVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	promoDraws := make([]PromoDrawN, 0)
+	for rows.Next() {
+		promoDrawReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		promoDrawReal.original = &promoDrawOriginal{}
+		_ = query.Copy(promoDrawReal, promoDrawReal.original)
+
+		promoDrawReal.SetModel(m)
+		promoDraws = append(promoDraws, *promoDrawReal)
+	}
+
+	return promoDraws, nil
+}
+
+// First return first result for given query
+func (m *PromoDrawModel) First(ctx context.Context, builders ...query.SQLBuilder) (*PromoDrawN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new promo_draw to database
+func (m *PromoDrawModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all promo_draws to database
+func (m *PromoDrawModel) SaveAll(ctx context.Context, promoDraws []PromoDrawN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, promoDraw := range promoDraws {
+		id, err := m.Save(ctx, promoDraw)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a promo_draw to database
+func (m *PromoDrawModel) Save(ctx context.Context, promoDraw PromoDrawN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, promoDraw.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new promo_draw or update it when it has a id > 0
+func (m *PromoDrawModel) SaveOrUpdate(ctx context.Context, promoDraw PromoDrawN, onlyFields ...string) (id int64, updated bool, err error) {
+	if promoDraw.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, promoDraw.Id.Int64, promoDraw, onlyFields...)
+		return promoDraw.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, promoDraw, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *PromoDrawModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *PromoDrawModel) Update(ctx context.Context, builder query.SQLBuilder, promoDraw PromoDrawN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, promoDraw.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *PromoDrawModel) UpdateById(ctx context.Context, id int64, promoDraw PromoDrawN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, promoDraw.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *PromoDrawModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *PromoDrawModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
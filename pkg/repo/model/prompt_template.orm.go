@@ -0,0 +1,803 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// PromptTemplateN is a PromptTemplate object, all fields are nullable
+type PromptTemplateN struct {
+	original            *promptTemplateOriginal
+	promptTemplateModel *PromptTemplateModel
+
+	Id           null.Int    `json:"id"`
+	Scene        null.String `json:"scene"`
+	Name         null.String `json:"name"`
+	Description  null.String `json:"description,omitempty"`
+	SystemPrompt null.String `json:"system_prompt,omitempty"`
+	UserPrompt   null.String `json:"user_prompt,omitempty"`
+	Sort         null.Int    `json:"sort"`
+	Status       null.Int    `json:"status"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *PromptTemplateN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for PromptTemplate
+func (inst *PromptTemplateN) SetModel(promptTemplateModel *PromptTemplateModel) {
+	inst.promptTemplateModel = promptTemplateModel
+}
+
+// promptTemplateOriginal is an object which stores original PromptTemplate from database
+type promptTemplateOriginal struct {
+	Id           null.Int
+	Scene        null.String
+	Name         null.String
+	Description  null.String
+	SystemPrompt null.String
+	UserPrompt   null.String
+	Sort         null.Int
+	Status       null.Int
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *PromptTemplateN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &promptTemplateOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Scene != inst.original.Scene {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Description != inst.original.Description {
+			return true
+		}
+		if inst.SystemPrompt != inst.original.SystemPrompt {
+			return true
+		}
+		if inst.UserPrompt != inst.original.UserPrompt {
+			return true
+		}
+		if inst.Sort != inst.original.Sort {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "scene":
+				if inst.Scene != inst.original.Scene {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					return true
+				}
+			case "system_prompt":
+				if inst.SystemPrompt != inst.original.SystemPrompt {
+					return true
+				}
+			case "user_prompt":
+				if inst.UserPrompt != inst.original.UserPrompt {
+					return true
+				}
+			case "sort":
+				if inst.Sort != inst.original.Sort {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *PromptTemplateN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &promptTemplateOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Scene != inst.original.Scene {
+			kv["scene"] = inst.Scene
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Description != inst.original.Description {
+			kv["description"] = inst.Description
+		}
+		if inst.SystemPrompt != inst.original.SystemPrompt {
+			kv["system_prompt"] = inst.SystemPrompt
+		}
+		if inst.UserPrompt != inst.original.UserPrompt {
+			kv["user_prompt"] = inst.UserPrompt
+		}
+		if inst.Sort != inst.original.Sort {
+			kv["sort"] = inst.Sort
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "scene":
+				if inst.Scene != inst.original.Scene {
+					kv["scene"] = inst.Scene
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					kv["description"] = inst.Description
+				}
+			case "system_prompt":
+				if inst.SystemPrompt != inst.original.SystemPrompt {
+					kv["system_prompt"] = inst.SystemPrompt
+				}
+			case "user_prompt":
+				if inst.UserPrompt != inst.original.UserPrompt {
+					kv["user_prompt"] = inst.UserPrompt
+				}
+			case "sort":
+				if inst.Sort != inst.original.Sort {
+					kv["sort"] = inst.Sort
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *PromptTemplateN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.promptTemplateModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.promptTemplateModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a prompt_template
+func (inst *PromptTemplateN) Delete(ctx context.Context) error {
+	if inst.promptTemplateModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.promptTemplateModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *PromptTemplateN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type promptTemplateScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var promptTemplateGlobalScopes = make([]promptTemplateScope, 0)
+var promptTemplateLocalScopes = make([]promptTemplateScope, 0)
+
+// AddGlobalScopeForPromptTemplate assign a global scope to a model
+func AddGlobalScopeForPromptTemplate(name string, apply func(builder query.Condition)) {
+	promptTemplateGlobalScopes = append(promptTemplateGlobalScopes, promptTemplateScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForPromptTemplate assign a local scope to a model
+func AddLocalScopeForPromptTemplate(name string, apply func(builder query.Condition)) {
+	promptTemplateLocalScopes = append(promptTemplateLocalScopes, promptTemplateScope{name: name, apply: apply})
+}
+
+func (m *PromptTemplateModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range promptTemplateGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range promptTemplateLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *PromptTemplateModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *PromptTemplateModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PromptTemplate struct {
+	Id           int64  `json:"id"`
+	Scene        string `json:"scene"`
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	SystemPrompt string `json:"system_prompt,omitempty"`
+	UserPrompt   string `json:"user_prompt,omitempty"`
+	Sort         int64  `json:"sort"`
+	Status       int64  `json:"status"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w PromptTemplate) ToPromptTemplateN(allows ...string) PromptTemplateN {
+	if len(allows) == 0 {
+		return PromptTemplateN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			Scene:        null.StringFrom(w.Scene),
+			Name:         null.StringFrom(w.Name),
+			Description:  null.StringFrom(w.Description),
+			SystemPrompt: null.StringFrom(w.SystemPrompt),
+			UserPrompt:   null.StringFrom(w.UserPrompt),
+			Sort:         null.IntFrom(int64(w.Sort)),
+			Status:       null.IntFrom(int64(w.Status)),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := PromptTemplateN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "scene":
+			res.Scene = null.StringFrom(w.Scene)
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "description":
+			res.Description = null.StringFrom(w.Description)
+		case "system_prompt":
+			res.SystemPrompt = null.StringFrom(w.SystemPrompt)
+		case "user_prompt":
+			res.UserPrompt = null.StringFrom(w.UserPrompt)
+		case "sort":
+			res.Sort = null.IntFrom(int64(w.Sort))
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w PromptTemplate) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *PromptTemplateN) ToPromptTemplate() PromptTemplate {
+	return PromptTemplate{
+
+		Id:           w.Id.Int64,
+		Scene:        w.Scene.String,
+		Name:         w.Name.String,
+		Description:  w.Description.String,
+		SystemPrompt: w.SystemPrompt.String,
+		UserPrompt:   w.UserPrompt.String,
+		Sort:         w.Sort.Int64,
+		Status:       w.Status.Int64,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// PromptTemplateModel is a model which encapsulates the operations of the object
+type PromptTemplateModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var promptTemplateTableName = "prompt_template"
+
+// PromptTemplateTable return table name for PromptTemplate
+func PromptTemplateTable() string {
+	return promptTemplateTableName
+}
+
+const (
+	FieldPromptTemplateId           = "id"
+	FieldPromptTemplateScene        = "scene"
+	FieldPromptTemplateName         = "name"
+	FieldPromptTemplateDescription  = "description"
+	FieldPromptTemplateSystemPrompt = "system_prompt"
+	FieldPromptTemplateUserPrompt   = "user_prompt"
+	FieldPromptTemplateSort         = "sort"
+	FieldPromptTemplateStatus       = "status"
+	FieldPromptTemplateCreatedAt    = "created_at"
+	FieldPromptTemplateUpdatedAt    = "updated_at"
+)
+
+// PromptTemplateFields return all fields in PromptTemplate model
+func PromptTemplateFields() []string {
+	return []string{
+		"id",
+		"scene",
+		"name",
+		"description",
+		"system_prompt",
+		"user_prompt",
+		"sort",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetPromptTemplateTable(tableName string) {
+	promptTemplateTableName = tableName
+}
+
+// NewPromptTemplateModel create a PromptTemplateModel
+func NewPromptTemplateModel(db query.Database) *PromptTemplateModel {
+	return &PromptTemplateModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           promptTemplateTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *PromptTemplateModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *PromptTemplateModel) clone() *PromptTemplateModel {
+	return &PromptTemplateModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *PromptTemplateModel) WithoutGlobalScopes(names ...string) *PromptTemplateModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *PromptTemplateModel) WithLocalScopes(names ...string) *PromptTemplateModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *PromptTemplateModel) Condition(builder query.SQLBuilder) *PromptTemplateModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *PromptTemplateModel) Find(ctx context.Context, id int64) (*PromptTemplateN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *PromptTemplateModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *PromptTemplateModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *PromptTemplateModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]PromptTemplateN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *PromptTemplateModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]PromptTemplateN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"scene",
+			"name",
+			"description",
+			"system_prompt",
+			"user_prompt",
+			"sort",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "scene":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "description":
+			selectFields = append(selectFields, f)
+		case "system_prompt":
+			selectFields = append(selectFields, f)
+		case "user_prompt":
+			selectFields = append(selectFields, f)
+		case "sort":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*PromptTemplateN, []interface{}) {
+		var promptTemplateVar PromptTemplateN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &promptTemplateVar.Id)
+			case "scene":
+				scanFields = append(scanFields, &promptTemplateVar.Scene)
+			case "name":
+				scanFields = append(scanFields, &promptTemplateVar.Name)
+			case "description":
+				scanFields = append(scanFields, &promptTemplateVar.Description)
+			case "system_prompt":
+				scanFields = append(scanFields, &promptTemplateVar.SystemPrompt)
+			case "user_prompt":
+				scanFields = append(scanFields, &promptTemplateVar.UserPrompt)
+			case "sort":
+				scanFields = append(scanFields, &promptTemplateVar.Sort)
+			case "status":
+				scanFields = append(scanFields, &promptTemplateVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &promptTemplateVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &promptTemplateVar.UpdatedAt)
+			}
+		}
+
+		return &promptTemplateVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	promptTemplates := make([]PromptTemplateN, 0)
+	for rows.Next() {
+		promptTemplateReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		promptTemplateReal.original = &promptTemplateOriginal{}
+		_ = query.Copy(promptTemplateReal, promptTemplateReal.original)
+
+		promptTemplateReal.SetModel(m)
+		promptTemplates = append(promptTemplates, *promptTemplateReal)
+	}
+
+	return promptTemplates, nil
+}
+
+// First return first result for given query
+func (m *PromptTemplateModel) First(ctx context.Context, builders ...query.SQLBuilder) (*PromptTemplateN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new prompt_template to database
+func (m *PromptTemplateModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all prompt_templates to database
+func (m *PromptTemplateModel) SaveAll(ctx context.Context, promptTemplates []PromptTemplateN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, promptTemplate := range promptTemplates {
+		id, err := m.Save(ctx, promptTemplate)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a prompt_template to database
+func (m *PromptTemplateModel) Save(ctx context.Context, promptTemplate PromptTemplateN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, promptTemplate.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new prompt_template or update it when it has a id > 0
+func (m *PromptTemplateModel) SaveOrUpdate(ctx context.Context, promptTemplate PromptTemplateN, onlyFields ...string) (id int64, updated bool, err error) {
+	if promptTemplate.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, promptTemplate.Id.Int64, promptTemplate, onlyFields...)
+		return promptTemplate.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, promptTemplate, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *PromptTemplateModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *PromptTemplateModel) Update(ctx context.Context, builder query.SQLBuilder, promptTemplate PromptTemplateN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, promptTemplate.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *PromptTemplateModel) UpdateById(ctx context.Context, id int64, promptTemplate PromptTemplateN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, promptTemplate.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *PromptTemplateModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *PromptTemplateModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,691 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// UserPromptVariableN is a UserPromptVariable object, all fields are nullable
+type UserPromptVariableN struct {
+	original                *userPromptVariableOriginal
+	userPromptVariableModel *UserPromptVariableModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Name      null.String `json:"name"`
+	Value     null.String `json:"value"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *UserPromptVariableN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for UserPromptVariable
+func (inst *UserPromptVariableN) SetModel(userPromptVariableModel *UserPromptVariableModel) {
+	inst.userPromptVariableModel = userPromptVariableModel
+}
+
+// userPromptVariableOriginal is an object which stores original UserPromptVariable from database
+type userPromptVariableOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Name      null.String
+	Value     null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *UserPromptVariableN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &userPromptVariableOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Value != inst.original.Value {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "value":
+				if inst.Value != inst.original.Value {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *UserPromptVariableN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &userPromptVariableOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Value != inst.original.Value {
+			kv["value"] = inst.Value
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "value":
+				if inst.Value != inst.original.Value {
+					kv["value"] = inst.Value
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *UserPromptVariableN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.userPromptVariableModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.userPromptVariableModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a user_prompt_variable
+func (inst *UserPromptVariableN) Delete(ctx context.Context) error {
+	if inst.userPromptVariableModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.userPromptVariableModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *UserPromptVariableN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type userPromptVariableScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var userPromptVariableGlobalScopes = make([]userPromptVariableScope, 0)
+var userPromptVariableLocalScopes = make([]userPromptVariableScope, 0)
+
+// AddGlobalScopeForUserPromptVariable assign a global scope to a model
+func AddGlobalScopeForUserPromptVariable(name string, apply func(builder query.Condition)) {
+	userPromptVariableGlobalScopes = append(userPromptVariableGlobalScopes, userPromptVariableScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForUserPromptVariable assign a local scope to a model
+func AddLocalScopeForUserPromptVariable(name string, apply func(builder query.Condition)) {
+	userPromptVariableLocalScopes = append(userPromptVariableLocalScopes, userPromptVariableScope{name: name, apply: apply})
+}
+
+func (m *UserPromptVariableModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range userPromptVariableGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range userPromptVariableLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *UserPromptVariableModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *UserPromptVariableModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UserPromptVariable struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w UserPromptVariable) ToUserPromptVariableN(allows ...string) UserPromptVariableN {
+	if len(allows) == 0 {
+		return UserPromptVariableN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Name:      null.StringFrom(w.Name),
+			Value:     null.StringFrom(w.Value),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := UserPromptVariableN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "value":
+			res.Value = null.StringFrom(w.Value)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w UserPromptVariable) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *UserPromptVariableN) ToUserPromptVariable() UserPromptVariable {
+	return UserPromptVariable{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Name:      w.Name.String,
+		Value:     w.Value.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// UserPromptVariableModel is a model which encapsulates the operations of the object
+type UserPromptVariableModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var userPromptVariableTableName = "user_prompt_variable"
+
+// UserPromptVariableTable return table name for UserPromptVariable
+func UserPromptVariableTable() string {
+	return userPromptVariableTableName
+}
+
+const (
+	FieldUserPromptVariableId        = "id"
+	FieldUserPromptVariableUserId    = "user_id"
+	FieldUserPromptVariableName      = "name"
+	FieldUserPromptVariableValue     = "value"
+	FieldUserPromptVariableCreatedAt = "created_at"
+	FieldUserPromptVariableUpdatedAt = "updated_at"
+)
+
+// UserPromptVariableFields return all fields in UserPromptVariable model
+func UserPromptVariableFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"name",
+		"value",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetUserPromptVariableTable(tableName string) {
+	userPromptVariableTableName = tableName
+}
+
+// NewUserPromptVariableModel create a UserPromptVariableModel
+func NewUserPromptVariableModel(db query.Database) *UserPromptVariableModel {
+	return &UserPromptVariableModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           userPromptVariableTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *UserPromptVariableModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *UserPromptVariableModel) clone() *UserPromptVariableModel {
+	return &UserPromptVariableModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *UserPromptVariableModel) WithoutGlobalScopes(names ...string) *UserPromptVariableModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *UserPromptVariableModel) WithLocalScopes(names ...string) *UserPromptVariableModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *UserPromptVariableModel) Condition(builder query.SQLBuilder) *UserPromptVariableModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *UserPromptVariableModel) Find(ctx context.Context, id int64) (*UserPromptVariableN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *UserPromptVariableModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *UserPromptVariableModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *UserPromptVariableModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]UserPromptVariableN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *UserPromptVariableModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]UserPromptVariableN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"name",
+			"value",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "value":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*UserPromptVariableN, []interface{}) {
+		var userPromptVariableVar UserPromptVariableN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &userPromptVariableVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &userPromptVariableVar.UserId)
+			case "name":
+				scanFields = append(scanFields, &userPromptVariableVar.Name)
+			case "value":
+				scanFields = append(scanFields, &userPromptVariableVar.Value)
+			case "created_at":
+				scanFields = append(scanFields, &userPromptVariableVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &userPromptVariableVar.UpdatedAt)
+			}
+		}
+
+		return &userPromptVariableVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userPromptVariables := make([]UserPromptVariableN, 0)
+	for rows.Next() {
+		userPromptVariableReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		userPromptVariableReal.original = &userPromptVariableOriginal{}
+		_ = query.Copy(userPromptVariableReal, userPromptVariableReal.original)
+
+		userPromptVariableReal.SetModel(m)
+		userPromptVariables = append(userPromptVariables, *userPromptVariableReal)
+	}
+
+	return userPromptVariables, nil
+}
+
+// First return first result for given query
+func (m *UserPromptVariableModel) First(ctx context.Context, builders ...query.SQLBuilder) (*UserPromptVariableN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new user_prompt_variable to database
+func (m *UserPromptVariableModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all user_prompt_variables to database
+func (m *UserPromptVariableModel) SaveAll(ctx context.Context, userPromptVariables []UserPromptVariableN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, userPromptVariable := range userPromptVariables {
+		id, err := m.Save(ctx, userPromptVariable)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a user_prompt_variable to database
+func (m *UserPromptVariableModel) Save(ctx context.Context, userPromptVariable UserPromptVariableN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, userPromptVariable.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new user_prompt_variable or update it when it has a id > 0
+func (m *UserPromptVariableModel) SaveOrUpdate(ctx context.Context, userPromptVariable UserPromptVariableN, onlyFields ...string) (id int64, updated bool, err error) {
+	if userPromptVariable.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, userPromptVariable.Id.Int64, userPromptVariable, onlyFields...)
+		return userPromptVariable.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, userPromptVariable, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *UserPromptVariableModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *UserPromptVariableModel) Update(ctx context.Context, builder query.SQLBuilder, userPromptVariable UserPromptVariableN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, userPromptVariable.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *UserPromptVariableModel) UpdateById(ctx context.Context, id int64, userPromptVariable UserPromptVariableN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, userPromptVariable.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *UserPromptVariableModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *UserPromptVariableModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,691 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// QueueTaskLogN is a QueueTaskLog object, all fields are nullable
+type QueueTaskLogN struct {
+	original          *queueTaskLogOriginal
+	queueTaskLogModel *QueueTaskLogModel
+
+	Id        null.Int    `json:"id"`
+	TaskId    null.String `json:"task_id"`
+	Step      null.String `json:"step"`
+	Message   null.String `json:"message"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *QueueTaskLogN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for QueueTaskLog
+func (inst *QueueTaskLogN) SetModel(queueTaskLogModel *QueueTaskLogModel) {
+	inst.queueTaskLogModel = queueTaskLogModel
+}
+
+// queueTaskLogOriginal is an object which stores original QueueTaskLog from database
+type queueTaskLogOriginal struct {
+	Id        null.Int
+	TaskId    null.String
+	Step      null.String
+	Message   null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *QueueTaskLogN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &queueTaskLogOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.TaskId != inst.original.TaskId {
+			return true
+		}
+		if inst.Step != inst.original.Step {
+			return true
+		}
+		if inst.Message != inst.original.Message {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					return true
+				}
+			case "step":
+				if inst.Step != inst.original.Step {
+					return true
+				}
+			case "message":
+				if inst.Message != inst.original.Message {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *QueueTaskLogN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &queueTaskLogOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.TaskId != inst.original.TaskId {
+			kv["task_id"] = inst.TaskId
+		}
+		if inst.Step != inst.original.Step {
+			kv["step"] = inst.Step
+		}
+		if inst.Message != inst.original.Message {
+			kv["message"] = inst.Message
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "task_id":
+				if inst.TaskId != inst.original.TaskId {
+					kv["task_id"] = inst.TaskId
+				}
+			case "step":
+				if inst.Step != inst.original.Step {
+					kv["step"] = inst.Step
+				}
+			case "message":
+				if inst.Message != inst.original.Message {
+					kv["message"] = inst.Message
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *QueueTaskLogN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.queueTaskLogModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.queueTaskLogModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a queue_task_log
+func (inst *QueueTaskLogN) Delete(ctx context.Context) error {
+	if inst.queueTaskLogModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.queueTaskLogModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *QueueTaskLogN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type queueTaskLogScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var queueTaskLogGlobalScopes = make([]queueTaskLogScope, 0)
+var queueTaskLogLocalScopes = make([]queueTaskLogScope, 0)
+
+// AddGlobalScopeForQueueTaskLog assign a global scope to a model
+func AddGlobalScopeForQueueTaskLog(name string, apply func(builder query.Condition)) {
+	queueTaskLogGlobalScopes = append(queueTaskLogGlobalScopes, queueTaskLogScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForQueueTaskLog assign a local scope to a model
+func AddLocalScopeForQueueTaskLog(name string, apply func(builder query.Condition)) {
+	queueTaskLogLocalScopes = append(queueTaskLogLocalScopes, queueTaskLogScope{name: name, apply: apply})
+}
+
+func (m *QueueTaskLogModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range queueTaskLogGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range queueTaskLogLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *QueueTaskLogModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *QueueTaskLogModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type QueueTaskLog struct {
+	Id        int64  `json:"id"`
+	TaskId    string `json:"task_id"`
+	Step      string `json:"step"`
+	Message   string `json:"message"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w QueueTaskLog) ToQueueTaskLogN(allows ...string) QueueTaskLogN {
+	if len(allows) == 0 {
+		return QueueTaskLogN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			TaskId:    null.StringFrom(w.TaskId),
+			Step:      null.StringFrom(w.Step),
+			Message:   null.StringFrom(w.Message),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := QueueTaskLogN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "task_id":
+			res.TaskId = null.StringFrom(w.TaskId)
+		case "step":
+			res.Step = null.StringFrom(w.Step)
+		case "message":
+			res.Message = null.StringFrom(w.Message)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w QueueTaskLog) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *QueueTaskLogN) ToQueueTaskLog() QueueTaskLog {
+	return QueueTaskLog{
+
+		Id:        w.Id.Int64,
+		TaskId:    w.TaskId.String,
+		Step:      w.Step.String,
+		Message:   w.Message.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// QueueTaskLogModel is a model which encapsulates the operations of the object
+type QueueTaskLogModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var queueTaskLogTableName = "queue_task_log"
+
+// QueueTaskLogTable return table name for QueueTaskLog
+func QueueTaskLogTable() string {
+	return queueTaskLogTableName
+}
+
+const (
+	FieldQueueTaskLogId        = "id"
+	FieldQueueTaskLogTaskId    = "task_id"
+	FieldQueueTaskLogStep      = "step"
+	FieldQueueTaskLogMessage   = "message"
+	FieldQueueTaskLogCreatedAt = "created_at"
+	FieldQueueTaskLogUpdatedAt = "updated_at"
+)
+
+// QueueTaskLogFields return all fields in QueueTaskLog model
+func QueueTaskLogFields() []string {
+	return []string{
+		"id",
+		"task_id",
+		"step",
+		"message",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetQueueTaskLogTable(tableName string) {
+	queueTaskLogTableName = tableName
+}
+
+// NewQueueTaskLogModel create a QueueTaskLogModel
+func NewQueueTaskLogModel(db query.Database) *QueueTaskLogModel {
+	return &QueueTaskLogModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           queueTaskLogTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *QueueTaskLogModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *QueueTaskLogModel) clone() *QueueTaskLogModel {
+	return &QueueTaskLogModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *QueueTaskLogModel) WithoutGlobalScopes(names ...string) *QueueTaskLogModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *QueueTaskLogModel) WithLocalScopes(names ...string) *QueueTaskLogModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *QueueTaskLogModel) Condition(builder query.SQLBuilder) *QueueTaskLogModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *QueueTaskLogModel) Find(ctx context.Context, id int64) (*QueueTaskLogN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *QueueTaskLogModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *QueueTaskLogModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *QueueTaskLogModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]QueueTaskLogN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *QueueTaskLogModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]QueueTaskLogN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"task_id",
+			"step",
+			"message",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "task_id":
+			selectFields = append(selectFields, f)
+		case "step":
+			selectFields = append(selectFields, f)
+		case "message":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*QueueTaskLogN, []interface{}) {
+		var queueTaskLogVar QueueTaskLogN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &queueTaskLogVar.Id)
+			case "task_id":
+				scanFields = append(scanFields, &queueTaskLogVar.TaskId)
+			case "step":
+				scanFields = append(scanFields, &queueTaskLogVar.Step)
+			case "message":
+				scanFields = append(scanFields, &queueTaskLogVar.Message)
+			case "created_at":
+				scanFields = append(scanFields, &queueTaskLogVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &queueTaskLogVar.UpdatedAt)
+			}
+		}
+
+		return &queueTaskLogVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	queueTaskLogs := make([]QueueTaskLogN, 0)
+	for rows.Next() {
+		queueTaskLogReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		queueTaskLogReal.original = &queueTaskLogOriginal{}
+		_ = query.Copy(queueTaskLogReal, queueTaskLogReal.original)
+
+		queueTaskLogReal.SetModel(m)
+		queueTaskLogs = append(queueTaskLogs, *queueTaskLogReal)
+	}
+
+	return queueTaskLogs, nil
+}
+
+// First return first result for given query
+func (m *QueueTaskLogModel) First(ctx context.Context, builders ...query.SQLBuilder) (*QueueTaskLogN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new queue_task_log to database
+func (m *QueueTaskLogModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all queue_task_logs to database
+func (m *QueueTaskLogModel) SaveAll(ctx context.Context, queueTaskLogs []QueueTaskLogN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, queueTaskLog := range queueTaskLogs {
+		id, err := m.Save(ctx, queueTaskLog)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a queue_task_log to database
+func (m *QueueTaskLogModel) Save(ctx context.Context, queueTaskLog QueueTaskLogN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, queueTaskLog.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new queue_task_log or update it when it has a id > 0
+func (m *QueueTaskLogModel) SaveOrUpdate(ctx context.Context, queueTaskLog QueueTaskLogN, onlyFields ...string) (id int64, updated bool, err error) {
+	if queueTaskLog.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, queueTaskLog.Id.Int64, queueTaskLog, onlyFields...)
+		return queueTaskLog.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, queueTaskLog, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *QueueTaskLogModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *QueueTaskLogModel) Update(ctx context.Context, builder query.SQLBuilder, queueTaskLog QueueTaskLogN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, queueTaskLog.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *QueueTaskLogModel) UpdateById(ctx context.Context, id int64, queueTaskLog QueueTaskLogN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, queueTaskLog.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *QueueTaskLogModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *QueueTaskLogModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
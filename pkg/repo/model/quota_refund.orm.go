@@ -0,0 +1,831 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// QuotaRefundN is a QuotaRefund object, all fields are nullable
+type QuotaRefundN struct {
+	original         *quotaRefundOriginal
+	quotaRefundModel *QuotaRefundModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	OrgId     null.Int    `json:"org_id"`
+	Model     null.String `json:"model"`
+	Provider  null.String `json:"provider"`
+	Charged   null.Int    `json:"charged"`
+	Refunded  null.Int    `json:"refunded"`
+	QuotaId   null.Int    `json:"quota_id"`
+	Reason    null.String `json:"reason,omitempty"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *QuotaRefundN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for QuotaRefund
+func (inst *QuotaRefundN) SetModel(quotaRefundModel *QuotaRefundModel) {
+	inst.quotaRefundModel = quotaRefundModel
+}
+
+// quotaRefundOriginal is an object which stores original QuotaRefund from database
+type quotaRefundOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	OrgId     null.Int
+	Model     null.String
+	Provider  null.String
+	Charged   null.Int
+	Refunded  null.Int
+	QuotaId   null.Int
+	Reason    null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *QuotaRefundN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &quotaRefundOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.OrgId != inst.original.OrgId {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.Provider != inst.original.Provider {
+			return true
+		}
+		if inst.Charged != inst.original.Charged {
+			return true
+		}
+		if inst.Refunded != inst.original.Refunded {
+			return true
+		}
+		if inst.QuotaId != inst.original.QuotaId {
+			return true
+		}
+		if inst.Reason != inst.original.Reason {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "provider":
+				if inst.Provider != inst.original.Provider {
+					return true
+				}
+			case "charged":
+				if inst.Charged != inst.original.Charged {
+					return true
+				}
+			case "refunded":
+				if inst.Refunded != inst.original.Refunded {
+					return true
+				}
+			case "quota_id":
+				if inst.QuotaId != inst.original.QuotaId {
+					return true
+				}
+			case "reason":
+				if inst.Reason != inst.original.Reason {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *QuotaRefundN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &quotaRefundOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.OrgId != inst.original.OrgId {
+			kv["org_id"] = inst.OrgId
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.Provider != inst.original.Provider {
+			kv["provider"] = inst.Provider
+		}
+		if inst.Charged != inst.original.Charged {
+			kv["charged"] = inst.Charged
+		}
+		if inst.Refunded != inst.original.Refunded {
+			kv["refunded"] = inst.Refunded
+		}
+		if inst.QuotaId != inst.original.QuotaId {
+			kv["quota_id"] = inst.QuotaId
+		}
+		if inst.Reason != inst.original.Reason {
+			kv["reason"] = inst.Reason
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "org_id":
+				if inst.OrgId != inst.original.OrgId {
+					kv["org_id"] = inst.OrgId
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "provider":
+				if inst.Provider != inst.original.Provider {
+					kv["provider"] = inst.Provider
+				}
+			case "charged":
+				if inst.Charged != inst.original.Charged {
+					kv["charged"] = inst.Charged
+				}
+			case "refunded":
+				if inst.Refunded != inst.original.Refunded {
+					kv["refunded"] = inst.Refunded
+				}
+			case "quota_id":
+				if inst.QuotaId != inst.original.QuotaId {
+					kv["quota_id"] = inst.QuotaId
+				}
+			case "reason":
+				if inst.Reason != inst.original.Reason {
+					kv["reason"] = inst.Reason
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *QuotaRefundN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.quotaRefundModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.quotaRefundModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a quota_refund
+func (inst *QuotaRefundN) Delete(ctx context.Context) error {
+	if inst.quotaRefundModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.quotaRefundModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *QuotaRefundN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type quotaRefundScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var quotaRefundGlobalScopes = make([]quotaRefundScope, 0)
+var quotaRefundLocalScopes = make([]quotaRefundScope, 0)
+
+// AddGlobalScopeForQuotaRefund assign a global scope to a model
+func AddGlobalScopeForQuotaRefund(name string, apply func(builder query.Condition)) {
+	quotaRefundGlobalScopes = append(quotaRefundGlobalScopes, quotaRefundScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForQuotaRefund assign a local scope to a model
+func AddLocalScopeForQuotaRefund(name string, apply func(builder query.Condition)) {
+	quotaRefundLocalScopes = append(quotaRefundLocalScopes, quotaRefundScope{name: name, apply: apply})
+}
+
+func (m *QuotaRefundModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range quotaRefundGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range quotaRefundLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *QuotaRefundModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *QuotaRefundModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type QuotaRefund struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	OrgId     int64  `json:"org_id"`
+	Model     string `json:"model"`
+	Provider  string `json:"provider"`
+	Charged   int64  `json:"charged"`
+	Refunded  int64  `json:"refunded"`
+	QuotaId   int64  `json:"quota_id"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w QuotaRefund) ToQuotaRefundN(allows ...string) QuotaRefundN {
+	if len(allows) == 0 {
+		return QuotaRefundN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			OrgId:     null.IntFrom(int64(w.OrgId)),
+			Model:     null.StringFrom(w.Model),
+			Provider:  null.StringFrom(w.Provider),
+			Charged:   null.IntFrom(int64(w.Charged)),
+			Refunded:  null.IntFrom(int64(w.Refunded)),
+			QuotaId:   null.IntFrom(int64(w.QuotaId)),
+			Reason:    null.StringFrom(w.Reason),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := QuotaRefundN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "org_id":
+			res.OrgId = null.IntFrom(int64(w.OrgId))
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "provider":
+			res.Provider = null.StringFrom(w.Provider)
+		case "charged":
+			res.Charged = null.IntFrom(int64(w.Charged))
+		case "refunded":
+			res.Refunded = null.IntFrom(int64(w.Refunded))
+		case "quota_id":
+			res.QuotaId = null.IntFrom(int64(w.QuotaId))
+		case "reason":
+			res.Reason = null.StringFrom(w.Reason)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w QuotaRefund) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *QuotaRefundN) ToQuotaRefund() QuotaRefund {
+	return QuotaRefund{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		OrgId:     w.OrgId.Int64,
+		Model:     w.Model.String,
+		Provider:  w.Provider.String,
+		Charged:   w.Charged.Int64,
+		Refunded:  w.Refunded.Int64,
+		QuotaId:   w.QuotaId.Int64,
+		Reason:    w.Reason.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// QuotaRefundModel is a model which encapsulates the operations of the object
+type QuotaRefundModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var quotaRefundTableName = "quota_refund"
+
+// QuotaRefundTable return table name for QuotaRefund
+func QuotaRefundTable() string {
+	return quotaRefundTableName
+}
+
+const (
+	FieldQuotaRefundId        = "id"
+	FieldQuotaRefundUserId    = "user_id"
+	FieldQuotaRefundOrgId     = "org_id"
+	FieldQuotaRefundModel     = "model"
+	FieldQuotaRefundProvider  = "provider"
+	FieldQuotaRefundCharged   = "charged"
+	FieldQuotaRefundRefunded  = "refunded"
+	FieldQuotaRefundQuotaId   = "quota_id"
+	FieldQuotaRefundReason    = "reason"
+	FieldQuotaRefundCreatedAt = "created_at"
+	FieldQuotaRefundUpdatedAt = "updated_at"
+)
+
+// QuotaRefundFields return all fields in QuotaRefund model
+func QuotaRefundFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"org_id",
+		"model",
+		"provider",
+		"charged",
+		"refunded",
+		"quota_id",
+		"reason",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetQuotaRefundTable(tableName string) {
+	quotaRefundTableName = tableName
+}
+
+// NewQuotaRefundModel create a QuotaRefundModel
+func NewQuotaRefundModel(db query.Database) *QuotaRefundModel {
+	return &QuotaRefundModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           quotaRefundTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *QuotaRefundModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *QuotaRefundModel) clone() *QuotaRefundModel {
+	return &QuotaRefundModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *QuotaRefundModel) WithoutGlobalScopes(names ...string) *QuotaRefundModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *QuotaRefundModel) WithLocalScopes(names ...string) *QuotaRefundModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *QuotaRefundModel) Condition(builder query.SQLBuilder) *QuotaRefundModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *QuotaRefundModel) Find(ctx context.Context, id int64) (*QuotaRefundN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *QuotaRefundModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *QuotaRefundModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *QuotaRefundModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]QuotaRefundN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *QuotaRefundModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]QuotaRefundN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"org_id",
+			"model",
+			"provider",
+			"charged",
+			"refunded",
+			"quota_id",
+			"reason",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "org_id":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "provider":
+			selectFields = append(selectFields, f)
+		case "charged":
+			selectFields = append(selectFields, f)
+		case "refunded":
+			selectFields = append(selectFields, f)
+		case "quota_id":
+			selectFields = append(selectFields, f)
+		case "reason":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*QuotaRefundN, []interface{}) {
+		var quotaRefundVar QuotaRefundN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &quotaRefundVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &quotaRefundVar.UserId)
+			case "org_id":
+				scanFields = append(scanFields, &quotaRefundVar.OrgId)
+			case "model":
+				scanFields = append(scanFields, &quotaRefundVar.Model)
+			case "provider":
+				scanFields = append(scanFields, &quotaRefundVar.Provider)
+			case "charged":
+				scanFields = append(scanFields, &quotaRefundVar.Charged)
+			case "refunded":
+				scanFields = append(scanFields, &quotaRefundVar.Refunded)
+			case "quota_id":
+				scanFields = append(scanFields, &quotaRefundVar.QuotaId)
+			case "reason":
+				scanFields = append(scanFields, &quotaRefundVar.Reason)
+			case "created_at":
+				scanFields = append(scanFields, &quotaRefundVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &quotaRefundVar.UpdatedAt)
+			}
+		}
+
+		return &quotaRefundVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	quotaRefunds := make([]QuotaRefundN, 0)
+	for rows.Next() {
+		quotaRefundReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		quotaRefundReal.original = &quotaRefundOriginal{}
+		_ = query.Copy(quotaRefundReal, quotaRefundReal.original)
+
+		quotaRefundReal.SetModel(m)
+		quotaRefunds = append(quotaRefunds, *quotaRefundReal)
+	}
+
+	return quotaRefunds, nil
+}
+
+// First return first result for given query
+func (m *QuotaRefundModel) First(ctx context.Context, builders ...query.SQLBuilder) (*QuotaRefundN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new quota_refund to database
+func (m *QuotaRefundModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all quota_refunds to database
+func (m *QuotaRefundModel) SaveAll(ctx context.Context, quotaRefunds []QuotaRefundN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, quotaRefund := range quotaRefunds {
+		id, err := m.Save(ctx, quotaRefund)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a quota_refund to database
+func (m *QuotaRefundModel) Save(ctx context.Context, quotaRefund QuotaRefundN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, quotaRefund.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new quota_refund or update it when it has a id > 0
+func (m *QuotaRefundModel) SaveOrUpdate(ctx context.Context, quotaRefund QuotaRefundN, onlyFields ...string) (id int64, updated bool, err error) {
+	if quotaRefund.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, quotaRefund.Id.Int64, quotaRefund, onlyFields...)
+		return quotaRefund.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, quotaRefund, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *QuotaRefundModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *QuotaRefundModel) Update(ctx context.Context, builder query.SQLBuilder, quotaRefund QuotaRefundN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, quotaRefund.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *QuotaRefundModel) UpdateById(ctx context.Context, id int64, quotaRefund QuotaRefundN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, quotaRefund.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *QuotaRefundModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *QuotaRefundModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
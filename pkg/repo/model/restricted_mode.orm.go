@@ -0,0 +1,691 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// UserRestrictedModeN is a UserRestrictedMode object, all fields are nullable
+type UserRestrictedModeN struct {
+	original                *userRestrictedModeOriginal
+	userRestrictedModeModel *UserRestrictedModeModel
+
+	Id         null.Int    `json:"id"`
+	UserId     null.Int    `json:"user_id"`
+	Pin        null.String `json:"-"`
+	DailyLimit null.Int    `json:"daily_limit"`
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *UserRestrictedModeN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for UserRestrictedMode
+func (inst *UserRestrictedModeN) SetModel(userRestrictedModeModel *UserRestrictedModeModel) {
+	inst.userRestrictedModeModel = userRestrictedModeModel
+}
+
+// userRestrictedModeOriginal is an object which stores original UserRestrictedMode from database
+type userRestrictedModeOriginal struct {
+	Id         null.Int
+	UserId     null.Int
+	Pin        null.String
+	DailyLimit null.Int
+	CreatedAt  null.Time
+	UpdatedAt  null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *UserRestrictedModeN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &userRestrictedModeOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Pin != inst.original.Pin {
+			return true
+		}
+		if inst.DailyLimit != inst.original.DailyLimit {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "pin":
+				if inst.Pin != inst.original.Pin {
+					return true
+				}
+			case "daily_limit":
+				if inst.DailyLimit != inst.original.DailyLimit {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *UserRestrictedModeN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &userRestrictedModeOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Pin != inst.original.Pin {
+			kv["pin"] = inst.Pin
+		}
+		if inst.DailyLimit != inst.original.DailyLimit {
+			kv["daily_limit"] = inst.DailyLimit
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "pin":
+				if inst.Pin != inst.original.Pin {
+					kv["pin"] = inst.Pin
+				}
+			case "daily_limit":
+				if inst.DailyLimit != inst.original.DailyLimit {
+					kv["daily_limit"] = inst.DailyLimit
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *UserRestrictedModeN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.userRestrictedModeModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.userRestrictedModeModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a user_restricted_mode
+func (inst *UserRestrictedModeN) Delete(ctx context.Context) error {
+	if inst.userRestrictedModeModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.userRestrictedModeModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *UserRestrictedModeN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type userRestrictedModeScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var userRestrictedModeGlobalScopes = make([]userRestrictedModeScope, 0)
+var userRestrictedModeLocalScopes = make([]userRestrictedModeScope, 0)
+
+// AddGlobalScopeForUserRestrictedMode assign a global scope to a model
+func AddGlobalScopeForUserRestrictedMode(name string, apply func(builder query.Condition)) {
+	userRestrictedModeGlobalScopes = append(userRestrictedModeGlobalScopes, userRestrictedModeScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForUserRestrictedMode assign a local scope to a model
+func AddLocalScopeForUserRestrictedMode(name string, apply func(builder query.Condition)) {
+	userRestrictedModeLocalScopes = append(userRestrictedModeLocalScopes, userRestrictedModeScope{name: name, apply: apply})
+}
+
+func (m *UserRestrictedModeModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range userRestrictedModeGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range userRestrictedModeLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *UserRestrictedModeModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *UserRestrictedModeModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UserRestrictedMode struct {
+	Id         int64  `json:"id"`
+	UserId     int64  `json:"user_id"`
+	Pin        string `json:"-"`
+	DailyLimit int64  `json:"daily_limit"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w UserRestrictedMode) ToUserRestrictedModeN(allows ...string) UserRestrictedModeN {
+	if len(allows) == 0 {
+		return UserRestrictedModeN{
+
+			Id:         null.IntFrom(int64(w.Id)),
+			UserId:     null.IntFrom(int64(w.UserId)),
+			Pin:        null.StringFrom(w.Pin),
+			DailyLimit: null.IntFrom(int64(w.DailyLimit)),
+			CreatedAt:  null.TimeFrom(w.CreatedAt),
+			UpdatedAt:  null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := UserRestrictedModeN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "pin":
+			res.Pin = null.StringFrom(w.Pin)
+		case "daily_limit":
+			res.DailyLimit = null.IntFrom(int64(w.DailyLimit))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w UserRestrictedMode) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *UserRestrictedModeN) ToUserRestrictedMode() UserRestrictedMode {
+	return UserRestrictedMode{
+
+		Id:         w.Id.Int64,
+		UserId:     w.UserId.Int64,
+		Pin:        w.Pin.String,
+		DailyLimit: w.DailyLimit.Int64,
+		CreatedAt:  w.CreatedAt.Time,
+		UpdatedAt:  w.UpdatedAt.Time,
+	}
+}
+
+// UserRestrictedModeModel is a model which encapsulates the operations of the object
+type UserRestrictedModeModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var userRestrictedModeTableName = "user_restricted_mode"
+
+// UserRestrictedModeTable return table name for UserRestrictedMode
+func UserRestrictedModeTable() string {
+	return userRestrictedModeTableName
+}
+
+const (
+	FieldUserRestrictedModeId         = "id"
+	FieldUserRestrictedModeUserId     = "user_id"
+	FieldUserRestrictedModePin        = "pin"
+	FieldUserRestrictedModeDailyLimit = "daily_limit"
+	FieldUserRestrictedModeCreatedAt  = "created_at"
+	FieldUserRestrictedModeUpdatedAt  = "updated_at"
+)
+
+// UserRestrictedModeFields return all fields in UserRestrictedMode model
+func UserRestrictedModeFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"pin",
+		"daily_limit",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetUserRestrictedModeTable(tableName string) {
+	userRestrictedModeTableName = tableName
+}
+
+// NewUserRestrictedModeModel create a UserRestrictedModeModel
+func NewUserRestrictedModeModel(db query.Database) *UserRestrictedModeModel {
+	return &UserRestrictedModeModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           userRestrictedModeTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *UserRestrictedModeModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *UserRestrictedModeModel) clone() *UserRestrictedModeModel {
+	return &UserRestrictedModeModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *UserRestrictedModeModel) WithoutGlobalScopes(names ...string) *UserRestrictedModeModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *UserRestrictedModeModel) WithLocalScopes(names ...string) *UserRestrictedModeModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *UserRestrictedModeModel) Condition(builder query.SQLBuilder) *UserRestrictedModeModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *UserRestrictedModeModel) Find(ctx context.Context, id int64) (*UserRestrictedModeN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *UserRestrictedModeModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *UserRestrictedModeModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *UserRestrictedModeModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]UserRestrictedModeN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *UserRestrictedModeModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]UserRestrictedModeN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"pin",
+			"daily_limit",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "pin":
+			selectFields = append(selectFields, f)
+		case "daily_limit":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*UserRestrictedModeN, []interface{}) {
+		var userRestrictedModeVar UserRestrictedModeN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &userRestrictedModeVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &userRestrictedModeVar.UserId)
+			case "pin":
+				scanFields = append(scanFields, &userRestrictedModeVar.Pin)
+			case "daily_limit":
+				scanFields = append(scanFields, &userRestrictedModeVar.DailyLimit)
+			case "created_at":
+				scanFields = append(scanFields, &userRestrictedModeVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &userRestrictedModeVar.UpdatedAt)
+			}
+		}
+
+		return &userRestrictedModeVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userRestrictedModes := make([]UserRestrictedModeN, 0)
+	for rows.Next() {
+		userRestrictedModeReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		userRestrictedModeReal.original = &userRestrictedModeOriginal{}
+		_ = query.Copy(userRestrictedModeReal, userRestrictedModeReal.original)
+
+		userRestrictedModeReal.SetModel(m)
+		userRestrictedModes = append(userRestrictedModes, *userRestrictedModeReal)
+	}
+
+	return userRestrictedModes, nil
+}
+
+// First return first result for given query
+func (m *UserRestrictedModeModel) First(ctx context.Context, builders ...query.SQLBuilder) (*UserRestrictedModeN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new user_restricted_mode to database
+func (m *UserRestrictedModeModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all user_restricted_modes to database
+func (m *UserRestrictedModeModel) SaveAll(ctx context.Context, userRestrictedModes []UserRestrictedModeN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, userRestrictedMode := range userRestrictedModes {
+		id, err := m.Save(ctx, userRestrictedMode)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a user_restricted_mode to database
+func (m *UserRestrictedModeModel) Save(ctx context.Context, userRestrictedMode UserRestrictedModeN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, userRestrictedMode.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new user_restricted_mode or update it when it has a id > 0
+func (m *UserRestrictedModeModel) SaveOrUpdate(ctx context.Context, userRestrictedMode UserRestrictedModeN, onlyFields ...string) (id int64, updated bool, err error) {
+	if userRestrictedMode.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, userRestrictedMode.Id.Int64, userRestrictedMode, onlyFields...)
+		return userRestrictedMode.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, userRestrictedMode, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *UserRestrictedModeModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *UserRestrictedModeModel) Update(ctx context.Context, builder query.SQLBuilder, userRestrictedMode UserRestrictedModeN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, userRestrictedMode.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *UserRestrictedModeModel) UpdateById(ctx context.Context, id int64, userRestrictedMode UserRestrictedModeN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, userRestrictedMode.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *UserRestrictedModeModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *UserRestrictedModeModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
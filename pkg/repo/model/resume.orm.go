@@ -0,0 +1,915 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ResumePolishN is a ResumePolish object, all fields are nullable
+type ResumePolishN struct {
+	original          *resumePolishOriginal
+	resumePolishModel *ResumePolishModel
+
+	Id             null.Int    `json:"id"`
+	UserId         null.Int    `json:"user_id"`
+	ThreadId       null.Int    `json:"thread_id"`
+	Version        null.Int    `json:"version"`
+	TemplateId     null.Int    `json:"template_id"`
+	Model          null.String `json:"model"`
+	Resume         null.String `json:"resume,omitempty"`
+	JobDescription null.String `json:"job_description,omitempty"`
+	Feedback       null.String `json:"feedback,omitempty"`
+	BulletPoints   null.String `json:"bullet_points,omitempty"`
+	CoverLetter    null.String `json:"cover_letter,omitempty"`
+	Coins          null.Int    `json:"coins"`
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ResumePolishN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ResumePolish
+func (inst *ResumePolishN) SetModel(resumePolishModel *ResumePolishModel) {
+	inst.resumePolishModel = resumePolishModel
+}
+
+// resumePolishOriginal is an object which stores original ResumePolish from database
+type resumePolishOriginal struct {
+	Id             null.Int
+	UserId         null.Int
+	ThreadId       null.Int
+	Version        null.Int
+	TemplateId     null.Int
+	Model          null.String
+	Resume         null.String
+	JobDescription null.String
+	Feedback       null.String
+	BulletPoints   null.String
+	CoverLetter    null.String
+	Coins          null.Int
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ResumePolishN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &resumePolishOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.ThreadId != inst.original.ThreadId {
+			return true
+		}
+		if inst.Version != inst.original.Version {
+			return true
+		}
+		if inst.TemplateId != inst.original.TemplateId {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.Resume != inst.original.Resume {
+			return true
+		}
+		if inst.JobDescription != inst.original.JobDescription {
+			return true
+		}
+		if inst.Feedback != inst.original.Feedback {
+			return true
+		}
+		if inst.BulletPoints != inst.original.BulletPoints {
+			return true
+		}
+		if inst.CoverLetter != inst.original.CoverLetter {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "thread_id":
+				if inst.ThreadId != inst.original.ThreadId {
+					return true
+				}
+			case "version":
+				if inst.Version != inst.original.Version {
+					return true
+				}
+			case "template_id":
+				if inst.TemplateId != inst.original.TemplateId {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "resume":
+				if inst.Resume != inst.original.Resume {
+					return true
+				}
+			case "job_description":
+				if inst.JobDescription != inst.original.JobDescription {
+					return true
+				}
+			case "feedback":
+				if inst.Feedback != inst.original.Feedback {
+					return true
+				}
+			case "bullet_points":
+				if inst.BulletPoints != inst.original.BulletPoints {
+					return true
+				}
+			case "cover_letter":
+				if inst.CoverLetter != inst.original.CoverLetter {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ResumePolishN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &resumePolishOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.ThreadId != inst.original.ThreadId {
+			kv["thread_id"] = inst.ThreadId
+		}
+		if inst.Version != inst.original.Version {
+			kv["version"] = inst.Version
+		}
+		if inst.TemplateId != inst.original.TemplateId {
+			kv["template_id"] = inst.TemplateId
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.Resume != inst.original.Resume {
+			kv["resume"] = inst.Resume
+		}
+		if inst.JobDescription != inst.original.JobDescription {
+			kv["job_description"] = inst.JobDescription
+		}
+		if inst.Feedback != inst.original.Feedback {
+			kv["feedback"] = inst.Feedback
+		}
+		if inst.BulletPoints != inst.original.BulletPoints {
+			kv["bullet_points"] = inst.BulletPoints
+		}
+		if inst.CoverLetter != inst.original.CoverLetter {
+			kv["cover_letter"] = inst.CoverLetter
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "thread_id":
+				if inst.ThreadId != inst.original.ThreadId {
+					kv["thread_id"] = inst.ThreadId
+				}
+			case "version":
+				if inst.Version != inst.original.Version {
+					kv["version"] = inst.Version
+				}
+			case "template_id":
+				if inst.TemplateId != inst.original.TemplateId {
+					kv["template_id"] = inst.TemplateId
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "resume":
+				if inst.Resume != inst.original.Resume {
+					kv["resume"] = inst.Resume
+				}
+			case "job_description":
+				if inst.JobDescription != inst.original.JobDescription {
+					kv["job_description"] = inst.JobDescription
+				}
+			case "feedback":
+				if inst.Feedback != inst.original.Feedback {
+					kv["feedback"] = inst.Feedback
+				}
+			case "bullet_points":
+				if inst.BulletPoints != inst.original.BulletPoints {
+					kv["bullet_points"] = inst.BulletPoints
+				}
+			case "cover_letter":
+				if inst.CoverLetter != inst.original.CoverLetter {
+					kv["cover_letter"] = inst.CoverLetter
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ResumePolishN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.resumePolishModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.resumePolishModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a resume_polish
+func (inst *ResumePolishN) Delete(ctx context.Context) error {
+	if inst.resumePolishModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.resumePolishModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ResumePolishN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type resumePolishScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var resumePolishGlobalScopes = make([]resumePolishScope, 0)
+var resumePolishLocalScopes = make([]resumePolishScope, 0)
+
+// AddGlobalScopeForResumePolish assign a global scope to a model
+func AddGlobalScopeForResumePolish(name string, apply func(builder query.Condition)) {
+	resumePolishGlobalScopes = append(resumePolishGlobalScopes, resumePolishScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForResumePolish assign a local scope to a model
+func AddLocalScopeForResumePolish(name string, apply func(builder query.Condition)) {
+	resumePolishLocalScopes = append(resumePolishLocalScopes, resumePolishScope{name: name, apply: apply})
+}
+
+func (m *ResumePolishModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range resumePolishGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range resumePolishLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ResumePolishModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ResumePolishModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ResumePolish struct {
+	Id             int64  `json:"id"`
+	UserId         int64  `json:"user_id"`
+	ThreadId       int64  `json:"thread_id"`
+	Version        int64  `json:"version"`
+	TemplateId     int64  `json:"template_id"`
+	Model          string `json:"model"`
+	Resume         string `json:"resume,omitempty"`
+	JobDescription string `json:"job_description,omitempty"`
+	Feedback       string `json:"feedback,omitempty"`
+	BulletPoints   string `json:"bullet_points,omitempty"`
+	CoverLetter    string `json:"cover_letter,omitempty"`
+	Coins          int64  `json:"coins"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (w ResumePolish) ToResumePolishN(allows ...string) ResumePolishN {
+	if len(allows) == 0 {
+		return ResumePolishN{
+
+			Id:             null.IntFrom(int64(w.Id)),
+			UserId:         null.IntFrom(int64(w.UserId)),
+			ThreadId:       null.IntFrom(int64(w.ThreadId)),
+			Version:        null.IntFrom(int64(w.Version)),
+			TemplateId:     null.IntFrom(int64(w.TemplateId)),
+			Model:          null.StringFrom(w.Model),
+			Resume:         null.StringFrom(w.Resume),
+			JobDescription: null.StringFrom(w.JobDescription),
+			Feedback:       null.StringFrom(w.Feedback),
+			BulletPoints:   null.StringFrom(w.BulletPoints),
+			CoverLetter:    null.StringFrom(w.CoverLetter),
+			Coins:          null.IntFrom(int64(w.Coins)),
+			CreatedAt:      null.TimeFrom(w.CreatedAt),
+			UpdatedAt:      null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ResumePolishN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "thread_id":
+			res.ThreadId = null.IntFrom(int64(w.ThreadId))
+		case "version":
+			res.Version = null.IntFrom(int64(w.Version))
+		case "template_id":
+			res.TemplateId = null.IntFrom(int64(w.TemplateId))
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "resume":
+			res.Resume = null.StringFrom(w.Resume)
+		case "job_description":
+			res.JobDescription = null.StringFrom(w.JobDescription)
+		case "feedback":
+			res.Feedback = null.StringFrom(w.Feedback)
+		case "bullet_points":
+			res.BulletPoints = null.StringFrom(w.BulletPoints)
+		case "cover_letter":
+			res.CoverLetter = null.StringFrom(w.CoverLetter)
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ResumePolish) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ResumePolishN) ToResumePolish() ResumePolish {
+	return ResumePolish{
+
+		Id:             w.Id.Int64,
+		UserId:         w.UserId.Int64,
+		ThreadId:       w.ThreadId.Int64,
+		Version:        w.Version.Int64,
+		TemplateId:     w.TemplateId.Int64,
+		Model:          w.Model.String,
+		Resume:         w.Resume.String,
+		JobDescription: w.JobDescription.String,
+		Feedback:       w.Feedback.String,
+		BulletPoints:   w.BulletPoints.String,
+		CoverLetter:    w.CoverLetter.String,
+		Coins:          w.Coins.Int64,
+		CreatedAt:      w.CreatedAt.Time,
+		UpdatedAt:      w.UpdatedAt.Time,
+	}
+}
+
+// ResumePolishModel is a model which encapsulates the operations of the object
+type ResumePolishModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var resumePolishTableName = "resume_polish"
+
+// ResumePolishTable return table name for ResumePolish
+func ResumePolishTable() string {
+	return resumePolishTableName
+}
+
+const (
+	FieldResumePolishId             = "id"
+	FieldResumePolishUserId         = "user_id"
+	FieldResumePolishThreadId       = "thread_id"
+	FieldResumePolishVersion        = "version"
+	FieldResumePolishTemplateId     = "template_id"
+	FieldResumePolishModel          = "model"
+	FieldResumePolishResume         = "resume"
+	FieldResumePolishJobDescription = "job_description"
+	FieldResumePolishFeedback       = "feedback"
+	FieldResumePolishBulletPoints   = "bullet_points"
+	FieldResumePolishCoverLetter    = "cover_letter"
+	FieldResumePolishCoins          = "coins"
+	FieldResumePolishCreatedAt      = "created_at"
+	FieldResumePolishUpdatedAt      = "updated_at"
+)
+
+// ResumePolishFields return all fields in ResumePolish model
+func ResumePolishFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"thread_id",
+		"version",
+		"template_id",
+		"model",
+		"resume",
+		"job_description",
+		"feedback",
+		"bullet_points",
+		"cover_letter",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetResumePolishTable(tableName string) {
+	resumePolishTableName = tableName
+}
+
+// NewResumePolishModel create a ResumePolishModel
+func NewResumePolishModel(db query.Database) *ResumePolishModel {
+	return &ResumePolishModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           resumePolishTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ResumePolishModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ResumePolishModel) clone() *ResumePolishModel {
+	return &ResumePolishModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ResumePolishModel) WithoutGlobalScopes(names ...string) *ResumePolishModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ResumePolishModel) WithLocalScopes(names ...string) *ResumePolishModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ResumePolishModel) Condition(builder query.SQLBuilder) *ResumePolishModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ResumePolishModel) Find(ctx context.Context, id int64) (*ResumePolishN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ResumePolishModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ResumePolishModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ResumePolishModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ResumePolishN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ResumePolishModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ResumePolishN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"thread_id",
+			"version",
+			"template_id",
+			"model",
+			"resume",
+			"job_description",
+			"feedback",
+			"bullet_points",
+			"cover_letter",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "thread_id":
+			selectFields = append(selectFields, f)
+		case "version":
+			selectFields = append(selectFields, f)
+		case "template_id":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "resume":
+			selectFields = append(selectFields, f)
+		case "job_description":
+			selectFields = append(selectFields, f)
+		case "feedback":
+			selectFields = append(selectFields, f)
+		case "bullet_points":
+			selectFields = append(selectFields, f)
+		case "cover_letter":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ResumePolishN, []interface{}) {
+		var resumePolishVar ResumePolishN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &resumePolishVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &resumePolishVar.UserId)
+			case "thread_id":
+				scanFields = append(scanFields, &resumePolishVar.ThreadId)
+			case "version":
+				scanFields = append(scanFields, &resumePolishVar.Version)
+			case "template_id":
+				scanFields = append(scanFields, &resumePolishVar.TemplateId)
+			case "model":
+				scanFields = append(scanFields, &resumePolishVar.Model)
+			case "resume":
+				scanFields = append(scanFields, &resumePolishVar.Resume)
+			case "job_description":
+				scanFields = append(scanFields, &resumePolishVar.JobDescription)
+			case "feedback":
+				scanFields = append(scanFields, &resumePolishVar.Feedback)
+			case "bullet_points":
+				scanFields = append(scanFields, &resumePolishVar.BulletPoints)
+			case "cover_letter":
+				scanFields = append(scanFields, &resumePolishVar.CoverLetter)
+			case "coins":
+				scanFields = append(scanFields, &resumePolishVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &resumePolishVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &resumePolishVar.UpdatedAt)
+			}
+		}
+
+		return &resumePolishVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	resumePolishs := make([]ResumePolishN, 0)
+	for rows.Next() {
+		resumePolishReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		resumePolishReal.original = &resumePolishOriginal{}
+		_ = query.Copy(resumePolishReal, resumePolishReal.original)
+
+		resumePolishReal.SetModel(m)
+		resumePolishs = append(resumePolishs, *resumePolishReal)
+	}
+
+	return resumePolishs, nil
+}
+
+// First return first result for given query
+func (m *ResumePolishModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ResumePolishN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new resume_polish to database
+func (m *ResumePolishModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all resume_polishs to database
+func (m *ResumePolishModel) SaveAll(ctx context.Context, resumePolishs []ResumePolishN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, resumePolish := range resumePolishs {
+		id, err := m.Save(ctx, resumePolish)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a resume_polish to database
+func (m *ResumePolishModel) Save(ctx context.Context, resumePolish ResumePolishN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, resumePolish.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new resume_polish or update it when it has a id > 0
+func (m *ResumePolishModel) SaveOrUpdate(ctx context.Context, resumePolish ResumePolishN, onlyFields ...string) (id int64, updated bool, err error) {
+	if resumePolish.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, resumePolish.Id.Int64, resumePolish, onlyFields...)
+		return resumePolish.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, resumePolish, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ResumePolishModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ResumePolishModel) Update(ctx context.Context, builder query.SQLBuilder, resumePolish ResumePolishN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, resumePolish.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ResumePolishModel) UpdateById(ctx context.Context, id int64, resumePolish ResumePolishN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, resumePolish.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ResumePolishModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ResumePolishModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,1562 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// RolePlaySessionN is a RolePlaySession object, all fields are nullable
+type RolePlaySessionN struct {
+	original             *rolePlaySessionOriginal
+	rolePlaySessionModel *RolePlaySessionModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Title     null.String `json:"title"`
+	Model     null.String `json:"model"`
+	Setting   null.String `json:"setting"`
+	State     null.String `json:"state"`
+	Turns     null.Int    `json:"turns"`
+	Coins     null.Int    `json:"coins"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *RolePlaySessionN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for RolePlaySession
+func (inst *RolePlaySessionN) SetModel(rolePlaySessionModel *RolePlaySessionModel) {
+	inst.rolePlaySessionModel = rolePlaySessionModel
+}
+
+// rolePlaySessionOriginal is an object which stores original RolePlaySession from database
+type rolePlaySessionOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Title     null.String
+	Model     null.String
+	Setting   null.String
+	State     null.String
+	Turns     null.Int
+	Coins     null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *RolePlaySessionN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &rolePlaySessionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Title != inst.original.Title {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.Setting != inst.original.Setting {
+			return true
+		}
+		if inst.State != inst.original.State {
+			return true
+		}
+		if inst.Turns != inst.original.Turns {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "setting":
+				if inst.Setting != inst.original.Setting {
+					return true
+				}
+			case "state":
+				if inst.State != inst.original.State {
+					return true
+				}
+			case "turns":
+				if inst.Turns != inst.original.Turns {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *RolePlaySessionN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &rolePlaySessionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Title != inst.original.Title {
+			kv["title"] = inst.Title
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.Setting != inst.original.Setting {
+			kv["setting"] = inst.Setting
+		}
+		if inst.State != inst.original.State {
+			kv["state"] = inst.State
+		}
+		if inst.Turns != inst.original.Turns {
+			kv["turns"] = inst.Turns
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					kv["title"] = inst.Title
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "setting":
+				if inst.Setting != inst.original.Setting {
+					kv["setting"] = inst.Setting
+				}
+			case "state":
+				if inst.State != inst.original.State {
+					kv["state"] = inst.State
+				}
+			case "turns":
+				if inst.Turns != inst.original.Turns {
+					kv["turns"] = inst.Turns
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *RolePlaySessionN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.rolePlaySessionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.rolePlaySessionModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a role_play_session
+func (inst *RolePlaySessionN) Delete(ctx context.Context) error {
+	if inst.rolePlaySessionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.rolePlaySessionModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *RolePlaySessionN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type rolePlaySessionScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var rolePlaySessionGlobalScopes = make([]rolePlaySessionScope, 0)
+var rolePlaySessionLocalScopes = make([]rolePlaySessionScope, 0)
+
+// AddGlobalScopeForRolePlaySession assign a global scope to a model
+func AddGlobalScopeForRolePlaySession(name string, apply func(builder query.Condition)) {
+	rolePlaySessionGlobalScopes = append(rolePlaySessionGlobalScopes, rolePlaySessionScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForRolePlaySession assign a local scope to a model
+func AddLocalScopeForRolePlaySession(name string, apply func(builder query.Condition)) {
+	rolePlaySessionLocalScopes = append(rolePlaySessionLocalScopes, rolePlaySessionScope{name: name, apply: apply})
+}
+
+func (m *RolePlaySessionModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range rolePlaySessionGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range rolePlaySessionLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *RolePlaySessionModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *RolePlaySessionModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type RolePlaySession struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Title     string `json:"title"`
+	Model     string `json:"model"`
+	Setting   string `json:"setting"`
+	State     string `json:"state"`
+	Turns     int64  `json:"turns"`
+	Coins     int64  `json:"coins"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w RolePlaySession) ToRolePlaySessionN(allows ...string) RolePlaySessionN {
+	if len(allows) == 0 {
+		return RolePlaySessionN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Title:     null.StringFrom(w.Title),
+			Model:     null.StringFrom(w.Model),
+			Setting:   null.StringFrom(w.Setting),
+			State:     null.StringFrom(w.State),
+			Turns:     null.IntFrom(int64(w.Turns)),
+			Coins:     null.IntFrom(int64(w.Coins)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := RolePlaySessionN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "title":
+			res.Title = null.StringFrom(w.Title)
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "setting":
+			res.Setting = null.StringFrom(w.Setting)
+		case "state":
+			res.State = null.StringFrom(w.State)
+		case "turns":
+			res.Turns = null.IntFrom(int64(w.Turns))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w RolePlaySession) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *RolePlaySessionN) ToRolePlaySession() RolePlaySession {
+	return RolePlaySession{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Title:     w.Title.String,
+		Model:     w.Model.String,
+		Setting:   w.Setting.String,
+		State:     w.State.String,
+		Turns:     w.Turns.Int64,
+		Coins:     w.Coins.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// RolePlaySessionModel is a model which encapsulates the operations of the object
+type RolePlaySessionModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var rolePlaySessionTableName = "role_play_session"
+
+// RolePlaySessionTable return table name for RolePlaySession
+func RolePlaySessionTable() string {
+	return rolePlaySessionTableName
+}
+
+const (
+	FieldRolePlaySessionId        = "id"
+	FieldRolePlaySessionUserId    = "user_id"
+	FieldRolePlaySessionTitle     = "title"
+	FieldRolePlaySessionModel     = "model"
+	FieldRolePlaySessionSetting   = "setting"
+	FieldRolePlaySessionState     = "state"
+	FieldRolePlaySessionTurns     = "turns"
+	FieldRolePlaySessionCoins     = "coins"
+	FieldRolePlaySessionCreatedAt = "created_at"
+	FieldRolePlaySessionUpdatedAt = "updated_at"
+)
+
+// RolePlaySessionFields return all fields in RolePlaySession model
+func RolePlaySessionFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"title",
+		"model",
+		"setting",
+		"state",
+		"turns",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetRolePlaySessionTable(tableName string) {
+	rolePlaySessionTableName = tableName
+}
+
+// NewRolePlaySessionModel create a RolePlaySessionModel
+func NewRolePlaySessionModel(db query.Database) *RolePlaySessionModel {
+	return &RolePlaySessionModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           rolePlaySessionTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *RolePlaySessionModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *RolePlaySessionModel) clone() *RolePlaySessionModel {
+	return &RolePlaySessionModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *RolePlaySessionModel) WithoutGlobalScopes(names ...string) *RolePlaySessionModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *RolePlaySessionModel) WithLocalScopes(names ...string) *RolePlaySessionModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *RolePlaySessionModel) Condition(builder query.SQLBuilder) *RolePlaySessionModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *RolePlaySessionModel) Find(ctx context.Context, id int64) (*RolePlaySessionN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *RolePlaySessionModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *RolePlaySessionModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *RolePlaySessionModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]RolePlaySessionN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *RolePlaySessionModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]RolePlaySessionN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"title",
+			"model",
+			"setting",
+			"state",
+			"turns",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "title":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "setting":
+			selectFields = append(selectFields, f)
+		case "state":
+			selectFields = append(selectFields, f)
+		case "turns":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*RolePlaySessionN, []interface{}) {
+		var rolePlaySessionVar RolePlaySessionN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &rolePlaySessionVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &rolePlaySessionVar.UserId)
+			case "title":
+				scanFields = append(scanFields, &rolePlaySessionVar.Title)
+			case "model":
+				scanFields = append(scanFields, &rolePlaySessionVar.Model)
+			case "setting":
+				scanFields = append(scanFields, &rolePlaySessionVar.Setting)
+			case "state":
+				scanFields = append(scanFields, &rolePlaySessionVar.State)
+			case "turns":
+				scanFields = append(scanFields, &rolePlaySessionVar.Turns)
+			case "coins":
+				scanFields = append(scanFields, &rolePlaySessionVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &rolePlaySessionVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &rolePlaySessionVar.UpdatedAt)
+			}
+		}
+
+		return &rolePlaySessionVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rolePlaySessions := make([]RolePlaySessionN, 0)
+	for rows.Next() {
+		rolePlaySessionReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		rolePlaySessionReal.original = &rolePlaySessionOriginal{}
+		_ = query.Copy(rolePlaySessionReal, rolePlaySessionReal.original)
+
+		rolePlaySessionReal.SetModel(m)
+		rolePlaySessions = append(rolePlaySessions, *rolePlaySessionReal)
+	}
+
+	return rolePlaySessions, nil
+}
+
+// First return first result for given query
+func (m *RolePlaySessionModel) First(ctx context.Context, builders ...query.SQLBuilder) (*RolePlaySessionN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new role_play_session to database
+func (m *RolePlaySessionModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all role_play_sessions to database
+func (m *RolePlaySessionModel) SaveAll(ctx context.Context, rolePlaySessions []RolePlaySessionN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, rolePlaySession := range rolePlaySessions {
+		id, err := m.Save(ctx, rolePlaySession)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a role_play_session to database
+func (m *RolePlaySessionModel) Save(ctx context.Context, rolePlaySession RolePlaySessionN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, rolePlaySession.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new role_play_session or update it when it has a id > 0
+func (m *RolePlaySessionModel) SaveOrUpdate(ctx context.Context, rolePlaySession RolePlaySessionN, onlyFields ...string) (id int64, updated bool, err error) {
+	if rolePlaySession.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, rolePlaySession.Id.Int64, rolePlaySession, onlyFields...)
+		return rolePlaySession.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, rolePlaySession, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *RolePlaySessionModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *RolePlaySessionModel) Update(ctx context.Context, builder query.SQLBuilder, rolePlaySession RolePlaySessionN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, rolePlaySession.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *RolePlaySessionModel) UpdateById(ctx context.Context, id int64, rolePlaySession RolePlaySessionN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, rolePlaySession.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *RolePlaySessionModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *RolePlaySessionModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// RolePlayMessageN is a RolePlayMessage object, all fields are nullable
+type RolePlayMessageN struct {
+	original             *rolePlayMessageOriginal
+	rolePlayMessageModel *RolePlayMessageModel
+
+	Id        null.Int    `json:"id"`
+	SessionId null.Int    `json:"session_id"`
+	UserId    null.Int    `json:"user_id"`
+	Role      null.String `json:"role"`
+	Content   null.String `json:"content"`
+	State     null.String `json:"state,omitempty"`
+	Coins     null.Int    `json:"coins"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *RolePlayMessageN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for RolePlayMessage
+func (inst *RolePlayMessageN) SetModel(rolePlayMessageModel *RolePlayMessageModel) {
+	inst.rolePlayMessageModel = rolePlayMessageModel
+}
+
+// rolePlayMessageOriginal is an object which stores original RolePlayMessage from database
+type rolePlayMessageOriginal struct {
+	Id        null.Int
+	SessionId null.Int
+	UserId    null.Int
+	Role      null.String
+	Content   null.String
+	State     null.String
+	Coins     null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *RolePlayMessageN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &rolePlayMessageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.SessionId != inst.original.SessionId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Role != inst.original.Role {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.State != inst.original.State {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "session_id":
+				if inst.SessionId != inst.original.SessionId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "role":
+				if inst.Role != inst.original.Role {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "state":
+				if inst.State != inst.original.State {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *RolePlayMessageN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &rolePlayMessageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.SessionId != inst.original.SessionId {
+			kv["session_id"] = inst.SessionId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Role != inst.original.Role {
+			kv["role"] = inst.Role
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.State != inst.original.State {
+			kv["state"] = inst.State
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "session_id":
+				if inst.SessionId != inst.original.SessionId {
+					kv["session_id"] = inst.SessionId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "role":
+				if inst.Role != inst.original.Role {
+					kv["role"] = inst.Role
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "state":
+				if inst.State != inst.original.State {
+					kv["state"] = inst.State
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *RolePlayMessageN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.rolePlayMessageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.rolePlayMessageModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a role_play_message
+func (inst *RolePlayMessageN) Delete(ctx context.Context) error {
+	if inst.rolePlayMessageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.rolePlayMessageModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *RolePlayMessageN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type rolePlayMessageScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var rolePlayMessageGlobalScopes = make([]rolePlayMessageScope, 0)
+var rolePlayMessageLocalScopes = make([]rolePlayMessageScope, 0)
+
+// AddGlobalScopeForRolePlayMessage assign a global scope to a model
+func AddGlobalScopeForRolePlayMessage(name string, apply func(builder query.Condition)) {
+	rolePlayMessageGlobalScopes = append(rolePlayMessageGlobalScopes, rolePlayMessageScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForRolePlayMessage assign a local scope to a model
+func AddLocalScopeForRolePlayMessage(name string, apply func(builder query.Condition)) {
+	rolePlayMessageLocalScopes = append(rolePlayMessageLocalScopes, rolePlayMessageScope{name: name, apply: apply})
+}
+
+func (m *RolePlayMessageModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range rolePlayMessageGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range rolePlayMessageLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *RolePlayMessageModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *RolePlayMessageModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type RolePlayMessage struct {
+	Id        int64  `json:"id"`
+	SessionId int64  `json:"session_id"`
+	UserId    int64  `json:"user_id"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+	State     string `json:"state,omitempty"`
+	Coins     int64  `json:"coins"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w RolePlayMessage) ToRolePlayMessageN(allows ...string) RolePlayMessageN {
+	if len(allows) == 0 {
+		return RolePlayMessageN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			SessionId: null.IntFrom(int64(w.SessionId)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Role:      null.StringFrom(w.Role),
+			Content:   null.StringFrom(w.Content),
+			State:     null.StringFrom(w.State),
+			Coins:     null.IntFrom(int64(w.Coins)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := RolePlayMessageN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "session_id":
+			res.SessionId = null.IntFrom(int64(w.SessionId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "role":
+			res.Role = null.StringFrom(w.Role)
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "state":
+			res.State = null.StringFrom(w.State)
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w RolePlayMessage) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *RolePlayMessageN) ToRolePlayMessage() RolePlayMessage {
+	return RolePlayMessage{
+
+		Id:        w.Id.Int64,
+		SessionId: w.SessionId.Int64,
+		UserId:    w.UserId.Int64,
+		Role:      w.Role.String,
+		Content:   w.Content.String,
+		State:     w.State.String,
+		Coins:     w.Coins.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// RolePlayMessageModel is a model which encapsulates the operations of the object
+type RolePlayMessageModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var rolePlayMessageTableName = "role_play_message"
+
+// RolePlayMessageTable return table name for RolePlayMessage
+func RolePlayMessageTable() string {
+	return rolePlayMessageTableName
+}
+
+const (
+	FieldRolePlayMessageId        = "id"
+	FieldRolePlayMessageSessionId = "session_id"
+	FieldRolePlayMessageUserId    = "user_id"
+	FieldRolePlayMessageRole      = "role"
+	FieldRolePlayMessageContent   = "content"
+	FieldRolePlayMessageState     = "state"
+	FieldRolePlayMessageCoins     = "coins"
+	FieldRolePlayMessageCreatedAt = "created_at"
+	FieldRolePlayMessageUpdatedAt = "updated_at"
+)
+
+// RolePlayMessageFields return all fields in RolePlayMessage model
+func RolePlayMessageFields() []string {
+	return []string{
+		"id",
+		"session_id",
+		"user_id",
+		"role",
+		"content",
+		"state",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetRolePlayMessageTable(tableName string) {
+	rolePlayMessageTableName = tableName
+}
+
+// NewRolePlayMessageModel create a RolePlayMessageModel
+func NewRolePlayMessageModel(db query.Database) *RolePlayMessageModel {
+	return &RolePlayMessageModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           rolePlayMessageTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *RolePlayMessageModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *RolePlayMessageModel) clone() *RolePlayMessageModel {
+	return &RolePlayMessageModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *RolePlayMessageModel) WithoutGlobalScopes(names ...string) *RolePlayMessageModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *RolePlayMessageModel) WithLocalScopes(names ...string) *RolePlayMessageModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *RolePlayMessageModel) Condition(builder query.SQLBuilder) *RolePlayMessageModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *RolePlayMessageModel) Find(ctx context.Context, id int64) (*RolePlayMessageN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *RolePlayMessageModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *RolePlayMessageModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *RolePlayMessageModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]RolePlayMessageN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *RolePlayMessageModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]RolePlayMessageN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"session_id",
+			"user_id",
+			"role",
+			"content",
+			"state",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "session_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "role":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "state":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*RolePlayMessageN, []interface{}) {
+		var rolePlayMessageVar RolePlayMessageN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &rolePlayMessageVar.Id)
+			case "session_id":
+				scanFields = append(scanFields, &rolePlayMessageVar.SessionId)
+			case "user_id":
+				scanFields = append(scanFields, &rolePlayMessageVar.UserId)
+			case "role":
+				scanFields = append(scanFields, &rolePlayMessageVar.Role)
+			case "content":
+				scanFields = append(scanFields, &rolePlayMessageVar.Content)
+			case "state":
+				scanFields = append(scanFields, &rolePlayMessageVar.State)
+			case "coins":
+				scanFields = append(scanFields, &rolePlayMessageVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &rolePlayMessageVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &rolePlayMessageVar.UpdatedAt)
+			}
+		}
+
+		return &rolePlayMessageVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rolePlayMessages := make([]RolePlayMessageN, 0)
+	for rows.Next() {
+		rolePlayMessageReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		rolePlayMessageReal.original = &rolePlayMessageOriginal{}
+		_ = query.Copy(rolePlayMessageReal, rolePlayMessageReal.original)
+
+		rolePlayMessageReal.SetModel(m)
+		rolePlayMessages = append(rolePlayMessages, *rolePlayMessageReal)
+	}
+
+	return rolePlayMessages, nil
+}
+
+// First return first result for given query
+func (m *RolePlayMessageModel) First(ctx context.Context, builders ...query.SQLBuilder) (*RolePlayMessageN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new role_play_message to database
+func (m *RolePlayMessageModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all role_play_messages to database
+func (m *RolePlayMessageModel) SaveAll(ctx context.Context, rolePlayMessages []RolePlayMessageN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, rolePlayMessage := range rolePlayMessages {
+		id, err := m.Save(ctx, rolePlayMessage)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a role_play_message to database
+func (m *RolePlayMessageModel) Save(ctx context.Context, rolePlayMessage RolePlayMessageN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, rolePlayMessage.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new role_play_message or update it when it has a id > 0
+func (m *RolePlayMessageModel) SaveOrUpdate(ctx context.Context, rolePlayMessage RolePlayMessageN, onlyFields ...string) (id int64, updated bool, err error) {
+	if rolePlayMessage.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, rolePlayMessage.Id.Int64, rolePlayMessage, onlyFields...)
+		return rolePlayMessage.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, rolePlayMessage, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *RolePlayMessageModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *RolePlayMessageModel) Update(ctx context.Context, builder query.SQLBuilder, rolePlayMessage RolePlayMessageN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, rolePlayMessage.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *RolePlayMessageModel) UpdateById(ctx context.Context, id int64, rolePlayMessage RolePlayMessageN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, rolePlayMessage.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *RolePlayMessageModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *RolePlayMessageModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -13,6 +13,11 @@ import (
 
 func init() {
 
+	// AddRoomsGlobalScope assign a global scope to a model for soft delete
+	AddGlobalScopeForRooms("soft_delete", func(builder query.Condition) {
+		builder.WhereNull("deleted_at")
+	})
+
 }
 
 // RoomsN is a Rooms object, all fields are nullable
@@ -20,22 +25,28 @@ type RoomsN struct {
 	original   *roomsOriginal
 	roomsModel *RoomsModel
 
-	Id             null.Int    `json:"id"`
-	UserId         null.Int    `json:"user_id"`
-	AvatarId       null.Int    `json:"avatar_id,omitempty"`
-	AvatarUrl      null.String `json:"avatar_url,omitempty"`
-	Name           null.String `json:"name,omitempty"`
-	Description    null.String `json:"description,omitempty"`
-	Priority       null.Int    `json:"priority,omitempty"`
-	Model          null.String `json:"model,omitempty"`
-	Vendor         null.String `json:"vendor,omitempty"`
-	SystemPrompt   null.String `json:"system_prompt,omitempty"`
-	MaxContext     null.Int    `json:"max_context,omitempty"`
-	RoomType       null.Int    `json:"room_type,omitempty"`
-	InitMessage    null.String `json:"init_message,omitempty"`
-	LastActiveTime null.Time   `json:"last_active_time,omitempty"`
-	CreatedAt      null.Time
-	UpdatedAt      null.Time
+	Id               null.Int    `json:"id"`
+	UserId           null.Int    `json:"user_id"`
+	AvatarId         null.Int    `json:"avatar_id,omitempty"`
+	AvatarUrl        null.String `json:"avatar_url,omitempty"`
+	Name             null.String `json:"name,omitempty"`
+	Description      null.String `json:"description,omitempty"`
+	Priority         null.Int    `json:"priority,omitempty"`
+	Model            null.String `json:"model,omitempty"`
+	Vendor           null.String `json:"vendor,omitempty"`
+	SystemPrompt     null.String `json:"system_prompt,omitempty"`
+	MaxContext       null.Int    `json:"max_context,omitempty"`
+	RoomType         null.Int    `json:"room_type,omitempty"`
+	InitMessage      null.String `json:"init_message,omitempty"`
+	LastActiveTime   null.Time   `json:"last_active_time,omitempty"`
+	Incognito        null.Int    `json:"incognito,omitempty"`
+	FolderId         null.Int    `json:"folder_id,omitempty"`
+	PinnedAt         null.Time   `json:"-"`
+	ReplyLanguage    null.String `json:"reply_language,omitempty"`
+	DetectedLanguage null.String `json:"detected_language,omitempty"`
+	CreatedAt        null.Time
+	UpdatedAt        null.Time
+	DeletedAt        null.Time
 }
 
 // As convert object to other type
@@ -51,22 +62,28 @@ func (inst *RoomsN) SetModel(roomsModel *RoomsModel) {
 
 // roomsOriginal is an object which stores original Rooms from database
 type roomsOriginal struct {
-	Id             null.Int
-	UserId         null.Int
-	AvatarId       null.Int
-	AvatarUrl      null.String
-	Name           null.String
-	Description    null.String
-	Priority       null.Int
-	Model          null.String
-	Vendor         null.String
-	SystemPrompt   null.String
-	MaxContext     null.Int
-	RoomType       null.Int
-	InitMessage    null.String
-	LastActiveTime null.Time
-	CreatedAt      null.Time
-	UpdatedAt      null.Time
+	Id               null.Int
+	UserId           null.Int
+	AvatarId         null.Int
+	AvatarUrl        null.String
+	Name             null.String
+	Description      null.String
+	Priority         null.Int
+	Model            null.String
+	Vendor           null.String
+	SystemPrompt     null.String
+	MaxContext       null.Int
+	RoomType         null.Int
+	InitMessage      null.String
+	LastActiveTime   null.Time
+	Incognito        null.Int
+	FolderId         null.Int
+	PinnedAt         null.Time
+	ReplyLanguage    null.String
+	DetectedLanguage null.String
+	CreatedAt        null.Time
+	UpdatedAt        null.Time
+	DeletedAt        null.Time
 }
 
 // Staled identify whether the object has been modified
@@ -119,12 +136,30 @@ func (inst *RoomsN) Staled(onlyFields ...string) bool {
 		if inst.LastActiveTime != inst.original.LastActiveTime {
 			return true
 		}
+		if inst.Incognito != inst.original.Incognito {
+			return true
+		}
+		if inst.FolderId != inst.original.FolderId {
+			return true
+		}
+		if inst.PinnedAt != inst.original.PinnedAt {
+			return true
+		}
+		if inst.ReplyLanguage != inst.original.ReplyLanguage {
+			return true
+		}
+		if inst.DetectedLanguage != inst.original.DetectedLanguage {
+			return true
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			return true
 		}
 		if inst.UpdatedAt != inst.original.UpdatedAt {
 			return true
 		}
+		if inst.DeletedAt != inst.original.DeletedAt {
+			return true
+		}
 	} else {
 		for _, f := range onlyFields {
 			switch strcase.ToSnake(f) {
@@ -185,6 +220,26 @@ func (inst *RoomsN) Staled(onlyFields ...string) bool {
 				if inst.LastActiveTime != inst.original.LastActiveTime {
 					return true
 				}
+			case "incognito":
+				if inst.Incognito != inst.original.Incognito {
+					return true
+				}
+			case "folder_id":
+				if inst.FolderId != inst.original.FolderId {
+					return true
+				}
+			case "pinned_at":
+				if inst.PinnedAt != inst.original.PinnedAt {
+					return true
+				}
+			case "reply_language":
+				if inst.ReplyLanguage != inst.original.ReplyLanguage {
+					return true
+				}
+			case "detected_language":
+				if inst.DetectedLanguage != inst.original.DetectedLanguage {
+					return true
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					return true
@@ -193,6 +248,10 @@ func (inst *RoomsN) Staled(onlyFields ...string) bool {
 				if inst.UpdatedAt != inst.original.UpdatedAt {
 					return true
 				}
+			case "deleted_at":
+				if inst.DeletedAt != inst.original.DeletedAt {
+					return true
+				}
 			default:
 			}
 		}
@@ -253,12 +312,30 @@ func (inst *RoomsN) StaledKV(onlyFields ...string) query.KV {
 		if inst.LastActiveTime != inst.original.LastActiveTime {
 			kv["last_active_time"] = inst.LastActiveTime
 		}
+		if inst.Incognito != inst.original.Incognito {
+			kv["incognito"] = inst.Incognito
+		}
+		if inst.FolderId != inst.original.FolderId {
+			kv["folder_id"] = inst.FolderId
+		}
+		if inst.PinnedAt != inst.original.PinnedAt {
+			kv["pinned_at"] = inst.PinnedAt
+		}
+		if inst.ReplyLanguage != inst.original.ReplyLanguage {
+			kv["reply_language"] = inst.ReplyLanguage
+		}
+		if inst.DetectedLanguage != inst.original.DetectedLanguage {
+			kv["detected_language"] = inst.DetectedLanguage
+		}
 		if inst.CreatedAt != inst.original.CreatedAt {
 			kv["created_at"] = inst.CreatedAt
 		}
 		if inst.UpdatedAt != inst.original.UpdatedAt {
 			kv["updated_at"] = inst.UpdatedAt
 		}
+		if inst.DeletedAt != inst.original.DeletedAt {
+			kv["deleted_at"] = inst.DeletedAt
+		}
 	} else {
 		for _, f := range onlyFields {
 			switch strcase.ToSnake(f) {
@@ -319,6 +396,26 @@ func (inst *RoomsN) StaledKV(onlyFields ...string) query.KV {
 				if inst.LastActiveTime != inst.original.LastActiveTime {
 					kv["last_active_time"] = inst.LastActiveTime
 				}
+			case "incognito":
+				if inst.Incognito != inst.original.Incognito {
+					kv["incognito"] = inst.Incognito
+				}
+			case "folder_id":
+				if inst.FolderId != inst.original.FolderId {
+					kv["folder_id"] = inst.FolderId
+				}
+			case "pinned_at":
+				if inst.PinnedAt != inst.original.PinnedAt {
+					kv["pinned_at"] = inst.PinnedAt
+				}
+			case "reply_language":
+				if inst.ReplyLanguage != inst.original.ReplyLanguage {
+					kv["reply_language"] = inst.ReplyLanguage
+				}
+			case "detected_language":
+				if inst.DetectedLanguage != inst.original.DetectedLanguage {
+					kv["detected_language"] = inst.DetectedLanguage
+				}
 			case "created_at":
 				if inst.CreatedAt != inst.original.CreatedAt {
 					kv["created_at"] = inst.CreatedAt
@@ -327,6 +424,10 @@ func (inst *RoomsN) StaledKV(onlyFields ...string) query.KV {
 				if inst.UpdatedAt != inst.original.UpdatedAt {
 					kv["updated_at"] = inst.UpdatedAt
 				}
+			case "deleted_at":
+				if inst.DeletedAt != inst.original.DeletedAt {
+					kv["deleted_at"] = inst.DeletedAt
+				}
 			default:
 			}
 		}
@@ -426,44 +527,56 @@ func (m *RoomsModel) globalScopeEnabled(name string) bool {
 }
 
 type Rooms struct {
-	Id             int64     `json:"id"`
-	UserId         int64     `json:"user_id"`
-	AvatarId       int64     `json:"avatar_id,omitempty"`
-	AvatarUrl      string    `json:"avatar_url,omitempty"`
-	Name           string    `json:"name,omitempty"`
-	Description    string    `json:"description,omitempty"`
-	Priority       int64     `json:"priority,omitempty"`
-	Model          string    `json:"model,omitempty"`
-	Vendor         string    `json:"vendor,omitempty"`
-	SystemPrompt   string    `json:"system_prompt,omitempty"`
-	MaxContext     int64     `json:"max_context,omitempty"`
-	RoomType       int64     `json:"room_type,omitempty"`
-	InitMessage    string    `json:"init_message,omitempty"`
-	LastActiveTime time.Time `json:"last_active_time,omitempty"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	Id               int64     `json:"id"`
+	UserId           int64     `json:"user_id"`
+	AvatarId         int64     `json:"avatar_id,omitempty"`
+	AvatarUrl        string    `json:"avatar_url,omitempty"`
+	Name             string    `json:"name,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	Priority         int64     `json:"priority,omitempty"`
+	Model            string    `json:"model,omitempty"`
+	Vendor           string    `json:"vendor,omitempty"`
+	SystemPrompt     string    `json:"system_prompt,omitempty"`
+	MaxContext       int64     `json:"max_context,omitempty"`
+	RoomType         int64     `json:"room_type,omitempty"`
+	InitMessage      string    `json:"init_message,omitempty"`
+	LastActiveTime   time.Time `json:"last_active_time,omitempty"`
+	Incognito        int64     `json:"incognito,omitempty"`
+	FolderId         int64     `json:"folder_id,omitempty"`
+	PinnedAt         time.Time `json:"-"`
+	ReplyLanguage    string    `json:"reply_language,omitempty"`
+	DetectedLanguage string    `json:"detected_language,omitempty"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        time.Time
 }
 
 func (w Rooms) ToRoomsN(allows ...string) RoomsN {
 	if len(allows) == 0 {
 		return RoomsN{
 
-			Id:             null.IntFrom(int64(w.Id)),
-			UserId:         null.IntFrom(int64(w.UserId)),
-			AvatarId:       null.IntFrom(int64(w.AvatarId)),
-			AvatarUrl:      null.StringFrom(w.AvatarUrl),
-			Name:           null.StringFrom(w.Name),
-			Description:    null.StringFrom(w.Description),
-			Priority:       null.IntFrom(int64(w.Priority)),
-			Model:          null.StringFrom(w.Model),
-			Vendor:         null.StringFrom(w.Vendor),
-			SystemPrompt:   null.StringFrom(w.SystemPrompt),
-			MaxContext:     null.IntFrom(int64(w.MaxContext)),
-			RoomType:       null.IntFrom(int64(w.RoomType)),
-			InitMessage:    null.StringFrom(w.InitMessage),
-			LastActiveTime: null.TimeFrom(w.LastActiveTime),
-			CreatedAt:      null.TimeFrom(w.CreatedAt),
-			UpdatedAt:      null.TimeFrom(w.UpdatedAt),
+			Id:               null.IntFrom(int64(w.Id)),
+			UserId:           null.IntFrom(int64(w.UserId)),
+			AvatarId:         null.IntFrom(int64(w.AvatarId)),
+			AvatarUrl:        null.StringFrom(w.AvatarUrl),
+			Name:             null.StringFrom(w.Name),
+			Description:      null.StringFrom(w.Description),
+			Priority:         null.IntFrom(int64(w.Priority)),
+			Model:            null.StringFrom(w.Model),
+			Vendor:           null.StringFrom(w.Vendor),
+			SystemPrompt:     null.StringFrom(w.SystemPrompt),
+			MaxContext:       null.IntFrom(int64(w.MaxContext)),
+			RoomType:         null.IntFrom(int64(w.RoomType)),
+			InitMessage:      null.StringFrom(w.InitMessage),
+			LastActiveTime:   null.TimeFrom(w.LastActiveTime),
+			Incognito:        null.IntFrom(int64(w.Incognito)),
+			FolderId:         null.IntFrom(int64(w.FolderId)),
+			PinnedAt:         null.TimeFrom(w.PinnedAt),
+			ReplyLanguage:    null.StringFrom(w.ReplyLanguage),
+			DetectedLanguage: null.StringFrom(w.DetectedLanguage),
+			CreatedAt:        null.TimeFrom(w.CreatedAt),
+			UpdatedAt:        null.TimeFrom(w.UpdatedAt),
+			DeletedAt:        null.TimeFrom(w.DeletedAt),
 		}
 	}
 
@@ -499,10 +612,22 @@ func (w Rooms) ToRoomsN(allows ...string) RoomsN {
 			res.InitMessage = null.StringFrom(w.InitMessage)
 		case "last_active_time":
 			res.LastActiveTime = null.TimeFrom(w.LastActiveTime)
+		case "incognito":
+			res.Incognito = null.IntFrom(int64(w.Incognito))
+		case "folder_id":
+			res.FolderId = null.IntFrom(int64(w.FolderId))
+		case "pinned_at":
+			res.PinnedAt = null.TimeFrom(w.PinnedAt)
+		case "reply_language":
+			res.ReplyLanguage = null.StringFrom(w.ReplyLanguage)
+		case "detected_language":
+			res.DetectedLanguage = null.StringFrom(w.DetectedLanguage)
 		case "created_at":
 			res.CreatedAt = null.TimeFrom(w.CreatedAt)
 		case "updated_at":
 			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		case "deleted_at":
+			res.DeletedAt = null.TimeFrom(w.DeletedAt)
 		default:
 		}
 	}
@@ -519,22 +644,28 @@ func (w Rooms) As(dst interface{}) error {
 func (w *RoomsN) ToRooms() Rooms {
 	return Rooms{
 
-		Id:             w.Id.Int64,
-		UserId:         w.UserId.Int64,
-		AvatarId:       w.AvatarId.Int64,
-		AvatarUrl:      w.AvatarUrl.String,
-		Name:           w.Name.String,
-		Description:    w.Description.String,
-		Priority:       w.Priority.Int64,
-		Model:          w.Model.String,
-		Vendor:         w.Vendor.String,
-		SystemPrompt:   w.SystemPrompt.String,
-		MaxContext:     w.MaxContext.Int64,
-		RoomType:       w.RoomType.Int64,
-		InitMessage:    w.InitMessage.String,
-		LastActiveTime: w.LastActiveTime.Time,
-		CreatedAt:      w.CreatedAt.Time,
-		UpdatedAt:      w.UpdatedAt.Time,
+		Id:               w.Id.Int64,
+		UserId:           w.UserId.Int64,
+		AvatarId:         w.AvatarId.Int64,
+		AvatarUrl:        w.AvatarUrl.String,
+		Name:             w.Name.String,
+		Description:      w.Description.String,
+		Priority:         w.Priority.Int64,
+		Model:            w.Model.String,
+		Vendor:           w.Vendor.String,
+		SystemPrompt:     w.SystemPrompt.String,
+		MaxContext:       w.MaxContext.Int64,
+		RoomType:         w.RoomType.Int64,
+		InitMessage:      w.InitMessage.String,
+		LastActiveTime:   w.LastActiveTime.Time,
+		Incognito:        w.Incognito.Int64,
+		FolderId:         w.FolderId.Int64,
+		PinnedAt:         w.PinnedAt.Time,
+		ReplyLanguage:    w.ReplyLanguage.String,
+		DetectedLanguage: w.DetectedLanguage.String,
+		CreatedAt:        w.CreatedAt.Time,
+		UpdatedAt:        w.UpdatedAt.Time,
+		DeletedAt:        w.DeletedAt.Time,
 	}
 }
 
@@ -557,22 +688,28 @@ func RoomsTable() string {
 }
 
 const (
-	FieldRoomsId             = "id"
-	FieldRoomsUserId         = "user_id"
-	FieldRoomsAvatarId       = "avatar_id"
-	FieldRoomsAvatarUrl      = "avatar_url"
-	FieldRoomsName           = "name"
-	FieldRoomsDescription    = "description"
-	FieldRoomsPriority       = "priority"
-	FieldRoomsModel          = "model"
-	FieldRoomsVendor         = "vendor"
-	FieldRoomsSystemPrompt   = "system_prompt"
-	FieldRoomsMaxContext     = "max_context"
-	FieldRoomsRoomType       = "room_type"
-	FieldRoomsInitMessage    = "init_message"
-	FieldRoomsLastActiveTime = "last_active_time"
-	FieldRoomsCreatedAt      = "created_at"
-	FieldRoomsUpdatedAt      = "updated_at"
+	FieldRoomsId               = "id"
+	FieldRoomsUserId           = "user_id"
+	FieldRoomsAvatarId         = "avatar_id"
+	FieldRoomsAvatarUrl        = "avatar_url"
+	FieldRoomsName             = "name"
+	FieldRoomsDescription      = "description"
+	FieldRoomsPriority         = "priority"
+	FieldRoomsModel            = "model"
+	FieldRoomsVendor           = "vendor"
+	FieldRoomsSystemPrompt     = "system_prompt"
+	FieldRoomsMaxContext       = "max_context"
+	FieldRoomsRoomType         = "room_type"
+	FieldRoomsInitMessage      = "init_message"
+	FieldRoomsLastActiveTime   = "last_active_time"
+	FieldRoomsIncognito        = "incognito"
+	FieldRoomsFolderId         = "folder_id"
+	FieldRoomsPinnedAt         = "pinned_at"
+	FieldRoomsReplyLanguage    = "reply_language"
+	FieldRoomsDetectedLanguage = "detected_language"
+	FieldRoomsCreatedAt        = "created_at"
+	FieldRoomsUpdatedAt        = "updated_at"
+	FieldRoomsDeletedAt        = "deleted_at"
 )
 
 // RoomsFields return all fields in Rooms model
@@ -592,8 +729,14 @@ func RoomsFields() []string {
 		"room_type",
 		"init_message",
 		"last_active_time",
+		"incognito",
+		"folder_id",
+		"pinned_at",
+		"reply_language",
+		"detected_language",
 		"created_at",
 		"updated_at",
+		"deleted_at",
 	}
 }
 
@@ -617,6 +760,11 @@ func (m *RoomsModel) GetDB() query.Database {
 	return m.db.GetDB()
 }
 
+// WithTrashed force soft deleted models to appear in a result set
+func (m *RoomsModel) WithTrashed() *RoomsModel {
+	return m.WithoutGlobalScopes("soft_delete")
+}
+
 func (m *RoomsModel) clone() *RoomsModel {
 	return &RoomsModel{
 		db:                  m.db,
@@ -738,8 +886,14 @@ func (m *RoomsModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]R
 			"room_type",
 			"init_message",
 			"last_active_time",
+			"incognito",
+			"folder_id",
+			"pinned_at",
+			"reply_language",
+			"detected_language",
 			"created_at",
 			"updated_at",
+			"deleted_at",
 		)
 	}
 
@@ -777,10 +931,22 @@ func (m *RoomsModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]R
 			selectFields = append(selectFields, f)
 		case "last_active_time":
 			selectFields = append(selectFields, f)
+		case "incognito":
+			selectFields = append(selectFields, f)
+		case "folder_id":
+			selectFields = append(selectFields, f)
+		case "pinned_at":
+			selectFields = append(selectFields, f)
+		case "reply_language":
+			selectFields = append(selectFields, f)
+		case "detected_language":
+			selectFields = append(selectFields, f)
 		case "created_at":
 			selectFields = append(selectFields, f)
 		case "updated_at":
 			selectFields = append(selectFields, f)
+		case "deleted_at":
+			selectFields = append(selectFields, f)
 		}
 	}
 
@@ -819,10 +985,22 @@ func (m *RoomsModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]R
 				scanFields = append(scanFields, &roomsVar.InitMessage)
 			case "last_active_time":
 				scanFields = append(scanFields, &roomsVar.LastActiveTime)
+			case "incognito":
+				scanFields = append(scanFields, &roomsVar.Incognito)
+			case "folder_id":
+				scanFields = append(scanFields, &roomsVar.FolderId)
+			case "pinned_at":
+				scanFields = append(scanFields, &roomsVar.PinnedAt)
+			case "reply_language":
+				scanFields = append(scanFields, &roomsVar.ReplyLanguage)
+			case "detected_language":
+				scanFields = append(scanFields, &roomsVar.DetectedLanguage)
 			case "created_at":
 				scanFields = append(scanFields, &roomsVar.CreatedAt)
 			case "updated_at":
 				scanFields = append(scanFields, &roomsVar.UpdatedAt)
+			case "deleted_at":
+				scanFields = append(scanFields, &roomsVar.DeletedAt)
 			}
 		}
 
@@ -951,17 +1129,44 @@ func (m *RoomsModel) UpdateById(ctx context.Context, id int64, rooms RoomsN, onl
 	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, rooms.StaledKV(onlyFields...))
 }
 
-// Delete remove a model
-func (m *RoomsModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+// ForceDelete permanently remove a soft deleted model from the database
+func (m *RoomsModel) ForceDelete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	m2 := m.WithTrashed()
 
-	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+	sqlStr, params := m2.query.Merge(builders...).AppendCondition(m2.applyScope()).Table(m2.tableName).ResolveDelete()
 
-	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	res, err := m2.db.ExecContext(ctx, sqlStr, params...)
 	if err != nil {
 		return 0, err
 	}
 
 	return res.RowsAffected()
+}
+
+// ForceDeleteById permanently remove a soft deleted model from the database by id
+func (m *RoomsModel) ForceDeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).ForceDelete(ctx)
+}
+
+// Restore restore a soft deleted model into an active state
+func (m *RoomsModel) Restore(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	m2 := m.WithTrashed()
+	return m2.UpdateFields(ctx, query.KV{
+		"deleted_at": nil,
+	}, builders...)
+}
+
+// RestoreById restore a soft deleted model into an active state by id
+func (m *RoomsModel) RestoreById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Restore(ctx)
+}
+
+// Delete remove a model
+func (m *RoomsModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	return m.UpdateFields(ctx, query.KV{
+		"deleted_at": time.Now(),
+	}, builders...)
 
 }
 
@@ -0,0 +1,719 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// RoomTopicN is a RoomTopic object, all fields are nullable
+type RoomTopicN struct {
+	original       *roomTopicOriginal
+	roomTopicModel *RoomTopicModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	RoomId    null.Int    `json:"room_id"`
+	RoomType  null.Int    `json:"room_type"`
+	Topic     null.String `json:"topic"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *RoomTopicN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for RoomTopic
+func (inst *RoomTopicN) SetModel(roomTopicModel *RoomTopicModel) {
+	inst.roomTopicModel = roomTopicModel
+}
+
+// roomTopicOriginal is an object which stores original RoomTopic from database
+type roomTopicOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	RoomId    null.Int
+	RoomType  null.Int
+	Topic     null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *RoomTopicN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &roomTopicOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.RoomId != inst.original.RoomId {
+			return true
+		}
+		if inst.RoomType != inst.original.RoomType {
+			return true
+		}
+		if inst.Topic != inst.original.Topic {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "room_id":
+				if inst.RoomId != inst.original.RoomId {
+					return true
+				}
+			case "room_type":
+				if inst.RoomType != inst.original.RoomType {
+					return true
+				}
+			case "topic":
+				if inst.Topic != inst.original.Topic {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *RoomTopicN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &roomTopicOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.RoomId != inst.original.RoomId {
+			kv["room_id"] = inst.RoomId
+		}
+		if inst.RoomType != inst.original.RoomType {
+			kv["room_type"] = inst.RoomType
+		}
+		if inst.Topic != inst.original.Topic {
+			kv["topic"] = inst.Topic
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "room_id":
+				if inst.RoomId != inst.original.RoomId {
+					kv["room_id"] = inst.RoomId
+				}
+			case "room_type":
+				if inst.RoomType != inst.original.RoomType {
+					kv["room_type"] = inst.RoomType
+				}
+			case "topic":
+				if inst.Topic != inst.original.Topic {
+					kv["topic"] = inst.Topic
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *RoomTopicN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.roomTopicModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.roomTopicModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a room_topic
+func (inst *RoomTopicN) Delete(ctx context.Context) error {
+	if inst.roomTopicModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.roomTopicModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *RoomTopicN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type roomTopicScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var roomTopicGlobalScopes = make([]roomTopicScope, 0)
+var roomTopicLocalScopes = make([]roomTopicScope, 0)
+
+// AddGlobalScopeForRoomTopic assign a global scope to a model
+func AddGlobalScopeForRoomTopic(name string, apply func(builder query.Condition)) {
+	roomTopicGlobalScopes = append(roomTopicGlobalScopes, roomTopicScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForRoomTopic assign a local scope to a model
+func AddLocalScopeForRoomTopic(name string, apply func(builder query.Condition)) {
+	roomTopicLocalScopes = append(roomTopicLocalScopes, roomTopicScope{name: name, apply: apply})
+}
+
+func (m *RoomTopicModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range roomTopicGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range roomTopicLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *RoomTopicModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *RoomTopicModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type RoomTopic struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	RoomId    int64  `json:"room_id"`
+	RoomType  int64  `json:"room_type"`
+	Topic     string `json:"topic"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w RoomTopic) ToRoomTopicN(allows ...string) RoomTopicN {
+	if len(allows) == 0 {
+		return RoomTopicN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			RoomId:    null.IntFrom(int64(w.RoomId)),
+			RoomType:  null.IntFrom(int64(w.RoomType)),
+			Topic:     null.StringFrom(w.Topic),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := RoomTopicN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "room_id":
+			res.RoomId = null.IntFrom(int64(w.RoomId))
+		case "room_type":
+			res.RoomType = null.IntFrom(int64(w.RoomType))
+		case "topic":
+			res.Topic = null.StringFrom(w.Topic)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w RoomTopic) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *RoomTopicN) ToRoomTopic() RoomTopic {
+	return RoomTopic{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		RoomId:    w.RoomId.Int64,
+		RoomType:  w.RoomType.Int64,
+		Topic:     w.Topic.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// RoomTopicModel is a model which encapsulates the operations of the object
+type RoomTopicModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var roomTopicTableName = "room_topic"
+
+// RoomTopicTable return table name for RoomTopic
+func RoomTopicTable() string {
+	return roomTopicTableName
+}
+
+const (
+	FieldRoomTopicId        = "id"
+	FieldRoomTopicUserId    = "user_id"
+	FieldRoomTopicRoomId    = "room_id"
+	FieldRoomTopicRoomType  = "room_type"
+	FieldRoomTopicTopic     = "topic"
+	FieldRoomTopicCreatedAt = "created_at"
+	FieldRoomTopicUpdatedAt = "updated_at"
+)
+
+// RoomTopicFields return all fields in RoomTopic model
+func RoomTopicFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"room_id",
+		"room_type",
+		"topic",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetRoomTopicTable(tableName string) {
+	roomTopicTableName = tableName
+}
+
+// NewRoomTopicModel create a RoomTopicModel
+func NewRoomTopicModel(db query.Database) *RoomTopicModel {
+	return &RoomTopicModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           roomTopicTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *RoomTopicModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *RoomTopicModel) clone() *RoomTopicModel {
+	return &RoomTopicModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *RoomTopicModel) WithoutGlobalScopes(names ...string) *RoomTopicModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *RoomTopicModel) WithLocalScopes(names ...string) *RoomTopicModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *RoomTopicModel) Condition(builder query.SQLBuilder) *RoomTopicModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *RoomTopicModel) Find(ctx context.Context, id int64) (*RoomTopicN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *RoomTopicModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *RoomTopicModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *RoomTopicModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]RoomTopicN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *RoomTopicModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]RoomTopicN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"room_id",
+			"room_type",
+			"topic",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "room_id":
+			selectFields = append(selectFields, f)
+		case "room_type":
+			selectFields = append(selectFields, f)
+		case "topic":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*RoomTopicN, []interface{}) {
+		var roomTopicVar RoomTopicN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &roomTopicVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &roomTopicVar.UserId)
+			case "room_id":
+				scanFields = append(scanFields, &roomTopicVar.RoomId)
+			case "room_type":
+				scanFields = append(scanFields, &roomTopicVar.RoomType)
+			case "topic":
+				scanFields = append(scanFields, &roomTopicVar.Topic)
+			case "created_at":
+				scanFields = append(scanFields, &roomTopicVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &roomTopicVar.UpdatedAt)
+			}
+		}
+
+		return &roomTopicVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	roomTopics := make([]RoomTopicN, 0)
+	for rows.Next() {
+		roomTopicReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		roomTopicReal.original = &roomTopicOriginal{}
+		_ = query.Copy(roomTopicReal, roomTopicReal.original)
+
+		roomTopicReal.SetModel(m)
+		roomTopics = append(roomTopics, *roomTopicReal)
+	}
+
+	return roomTopics, nil
+}
+
+// First return first result for given query
+func (m *RoomTopicModel) First(ctx context.Context, builders ...query.SQLBuilder) (*RoomTopicN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new room_topic to database
+func (m *RoomTopicModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all room_topics to database
+func (m *RoomTopicModel) SaveAll(ctx context.Context, roomTopics []RoomTopicN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, roomTopic := range roomTopics {
+		id, err := m.Save(ctx, roomTopic)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a room_topic to database
+func (m *RoomTopicModel) Save(ctx context.Context, roomTopic RoomTopicN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, roomTopic.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new room_topic or update it when it has a id > 0
+func (m *RoomTopicModel) SaveOrUpdate(ctx context.Context, roomTopic RoomTopicN, onlyFields ...string) (id int64, updated bool, err error) {
+	if roomTopic.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, roomTopic.Id.Int64, roomTopic, onlyFields...)
+		return roomTopic.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, roomTopic, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *RoomTopicModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *RoomTopicModel) Update(ctx context.Context, builder query.SQLBuilder, roomTopic RoomTopicN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, roomTopic.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *RoomTopicModel) UpdateById(ctx context.Context, id int64, roomTopic RoomTopicN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, roomTopic.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *RoomTopicModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *RoomTopicModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
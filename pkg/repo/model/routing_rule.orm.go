@@ -0,0 +1,775 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// RoutingRuleN is a RoutingRule object, all fields are nullable
+type RoutingRuleN struct {
+	original         *routingRuleOriginal
+	routingRuleModel *RoutingRuleModel
+
+	Id          null.Int    `json:"id"`
+	Name        null.String `json:"name"`
+	Priority    null.Int    `json:"priority"`
+	Conditions  null.String `json:"conditions"`
+	TargetModel null.String `json:"target_model"`
+	Note        null.String `json:"note"`
+	Status      null.Int    `json:"status"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *RoutingRuleN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for RoutingRule
+func (inst *RoutingRuleN) SetModel(routingRuleModel *RoutingRuleModel) {
+	inst.routingRuleModel = routingRuleModel
+}
+
+// routingRuleOriginal is an object which stores original RoutingRule from database
+type routingRuleOriginal struct {
+	Id          null.Int
+	Name        null.String
+	Priority    null.Int
+	Conditions  null.String
+	TargetModel null.String
+	Note        null.String
+	Status      null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *RoutingRuleN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &routingRuleOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Priority != inst.original.Priority {
+			return true
+		}
+		if inst.Conditions != inst.original.Conditions {
+			return true
+		}
+		if inst.TargetModel != inst.original.TargetModel {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "priority":
+				if inst.Priority != inst.original.Priority {
+					return true
+				}
+			case "conditions":
+				if inst.Conditions != inst.original.Conditions {
+					return true
+				}
+			case "target_model":
+				if inst.TargetModel != inst.original.TargetModel {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *RoutingRuleN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &routingRuleOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Priority != inst.original.Priority {
+			kv["priority"] = inst.Priority
+		}
+		if inst.Conditions != inst.original.Conditions {
+			kv["conditions"] = inst.Conditions
+		}
+		if inst.TargetModel != inst.original.TargetModel {
+			kv["target_model"] = inst.TargetModel
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "priority":
+				if inst.Priority != inst.original.Priority {
+					kv["priority"] = inst.Priority
+				}
+			case "conditions":
+				if inst.Conditions != inst.original.Conditions {
+					kv["conditions"] = inst.Conditions
+				}
+			case "target_model":
+				if inst.TargetModel != inst.original.TargetModel {
+					kv["target_model"] = inst.TargetModel
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *RoutingRuleN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.routingRuleModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.routingRuleModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a routing_rule
+func (inst *RoutingRuleN) Delete(ctx context.Context) error {
+	if inst.routingRuleModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.routingRuleModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *RoutingRuleN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type routingRuleScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var routingRuleGlobalScopes = make([]routingRuleScope, 0)
+var routingRuleLocalScopes = make([]routingRuleScope, 0)
+
+// AddGlobalScopeForRoutingRule assign a global scope to a model
+func AddGlobalScopeForRoutingRule(name string, apply func(builder query.Condition)) {
+	routingRuleGlobalScopes = append(routingRuleGlobalScopes, routingRuleScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForRoutingRule assign a local scope to a model
+func AddLocalScopeForRoutingRule(name string, apply func(builder query.Condition)) {
+	routingRuleLocalScopes = append(routingRuleLocalScopes, routingRuleScope{name: name, apply: apply})
+}
+
+func (m *RoutingRuleModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range routingRuleGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range routingRuleLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *RoutingRuleModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *RoutingRuleModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type RoutingRule struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Priority    int64  `json:"priority"`
+	Conditions  string `json:"conditions"`
+	TargetModel string `json:"target_model"`
+	Note        string `json:"note"`
+	Status      int64  `json:"status"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w RoutingRule) ToRoutingRuleN(allows ...string) RoutingRuleN {
+	if len(allows) == 0 {
+		return RoutingRuleN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			Name:        null.StringFrom(w.Name),
+			Priority:    null.IntFrom(int64(w.Priority)),
+			Conditions:  null.StringFrom(w.Conditions),
+			TargetModel: null.StringFrom(w.TargetModel),
+			Note:        null.StringFrom(w.Note),
+			Status:      null.IntFrom(int64(w.Status)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := RoutingRuleN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "priority":
+			res.Priority = null.IntFrom(int64(w.Priority))
+		case "conditions":
+			res.Conditions = null.StringFrom(w.Conditions)
+		case "target_model":
+			res.TargetModel = null.StringFrom(w.TargetModel)
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w RoutingRule) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *RoutingRuleN) ToRoutingRule() RoutingRule {
+	return RoutingRule{
+
+		Id:          w.Id.Int64,
+		Name:        w.Name.String,
+		Priority:    w.Priority.Int64,
+		Conditions:  w.Conditions.String,
+		TargetModel: w.TargetModel.String,
+		Note:        w.Note.String,
+		Status:      w.Status.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// RoutingRuleModel is a model which encapsulates the operations of the object
+type RoutingRuleModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var routingRuleTableName = "routing_rule"
+
+// RoutingRuleTable return table name for RoutingRule
+func RoutingRuleTable() string {
+	return routingRuleTableName
+}
+
+const (
+	FieldRoutingRuleId          = "id"
+	FieldRoutingRuleName        = "name"
+	FieldRoutingRulePriority    = "priority"
+	FieldRoutingRuleConditions  = "conditions"
+	FieldRoutingRuleTargetModel = "target_model"
+	FieldRoutingRuleNote        = "note"
+	FieldRoutingRuleStatus      = "status"
+	FieldRoutingRuleCreatedAt   = "created_at"
+	FieldRoutingRuleUpdatedAt   = "updated_at"
+)
+
+// RoutingRuleFields return all fields in RoutingRule model
+func RoutingRuleFields() []string {
+	return []string{
+		"id",
+		"name",
+		"priority",
+		"conditions",
+		"target_model",
+		"note",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetRoutingRuleTable(tableName string) {
+	routingRuleTableName = tableName
+}
+
+// NewRoutingRuleModel create a RoutingRuleModel
+func NewRoutingRuleModel(db query.Database) *RoutingRuleModel {
+	return &RoutingRuleModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           routingRuleTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *RoutingRuleModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *RoutingRuleModel) clone() *RoutingRuleModel {
+	return &RoutingRuleModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *RoutingRuleModel) WithoutGlobalScopes(names ...string) *RoutingRuleModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *RoutingRuleModel) WithLocalScopes(names ...string) *RoutingRuleModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *RoutingRuleModel) Condition(builder query.SQLBuilder) *RoutingRuleModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *RoutingRuleModel) Find(ctx context.Context, id int64) (*RoutingRuleN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *RoutingRuleModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *RoutingRuleModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *RoutingRuleModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]RoutingRuleN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *RoutingRuleModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]RoutingRuleN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"name",
+			"priority",
+			"conditions",
+			"target_model",
+			"note",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "priority":
+			selectFields = append(selectFields, f)
+		case "conditions":
+			selectFields = append(selectFields, f)
+		case "target_model":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*RoutingRuleN, []interface{}) {
+		var routingRuleVar RoutingRuleN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &routingRuleVar.Id)
+			case "name":
+				scanFields = append(scanFields, &routingRuleVar.Name)
+			case "priority":
+				scanFields = append(scanFields, &routingRuleVar.Priority)
+			case "conditions":
+				scanFields = append(scanFields, &routingRuleVar.Conditions)
+			case "target_model":
+				scanFields = append(scanFields, &routingRuleVar.TargetModel)
+			case "note":
+				scanFields = append(scanFields, &routingRuleVar.Note)
+			case "status":
+				scanFields = append(scanFields, &routingRuleVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &routingRuleVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &routingRuleVar.UpdatedAt)
+			}
+		}
+
+		return &routingRuleVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	routingRules := make([]RoutingRuleN, 0)
+	for rows.Next() {
+		routingRuleReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		routingRuleReal.original = &routingRuleOriginal{}
+		_ = query.Copy(routingRuleReal, routingRuleReal.original)
+
+		routingRuleReal.SetModel(m)
+		routingRules = append(routingRules, *routingRuleReal)
+	}
+
+	return routingRules, nil
+}
+
+// First return first result for given query
+func (m *RoutingRuleModel) First(ctx context.Context, builders ...query.SQLBuilder) (*RoutingRuleN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new routing_rule to database
+func (m *RoutingRuleModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all routing_rules to database
+func (m *RoutingRuleModel) SaveAll(ctx context.Context, routingRules []RoutingRuleN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, routingRule := range routingRules {
+		id, err := m.Save(ctx, routingRule)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a routing_rule to database
+func (m *RoutingRuleModel) Save(ctx context.Context, routingRule RoutingRuleN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, routingRule.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new routing_rule or update it when it has a id > 0
+func (m *RoutingRuleModel) SaveOrUpdate(ctx context.Context, routingRule RoutingRuleN, onlyFields ...string) (id int64, updated bool, err error) {
+	if routingRule.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, routingRule.Id.Int64, routingRule, onlyFields...)
+		return routingRule.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, routingRule, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *RoutingRuleModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *RoutingRuleModel) Update(ctx context.Context, builder query.SQLBuilder, routingRule RoutingRuleN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, routingRule.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *RoutingRuleModel) UpdateById(ctx context.Context, id int64, routingRule RoutingRuleN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, routingRule.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *RoutingRuleModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *RoutingRuleModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
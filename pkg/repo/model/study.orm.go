@@ -0,0 +1,1562 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// StudyDeckN is a StudyDeck object, all fields are nullable
+type StudyDeckN struct {
+	original       *studyDeckOriginal
+	studyDeckModel *StudyDeckModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Name      null.String `json:"name"`
+	Source    null.String `json:"source"`
+	RoomId    null.Int    `json:"room_id"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *StudyDeckN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for StudyDeck
+func (inst *StudyDeckN) SetModel(studyDeckModel *StudyDeckModel) {
+	inst.studyDeckModel = studyDeckModel
+}
+
+// studyDeckOriginal is an object which stores original StudyDeck from database
+type studyDeckOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Name      null.String
+	Source    null.String
+	RoomId    null.Int
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *StudyDeckN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &studyDeckOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Source != inst.original.Source {
+			return true
+		}
+		if inst.RoomId != inst.original.RoomId {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "source":
+				if inst.Source != inst.original.Source {
+					return true
+				}
+			case "room_id":
+				if inst.RoomId != inst.original.RoomId {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *StudyDeckN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &studyDeckOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Source != inst.original.Source {
+			kv["source"] = inst.Source
+		}
+		if inst.RoomId != inst.original.RoomId {
+			kv["room_id"] = inst.RoomId
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "source":
+				if inst.Source != inst.original.Source {
+					kv["source"] = inst.Source
+				}
+			case "room_id":
+				if inst.RoomId != inst.original.RoomId {
+					kv["room_id"] = inst.RoomId
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *StudyDeckN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.studyDeckModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.studyDeckModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a study_deck
+func (inst *StudyDeckN) Delete(ctx context.Context) error {
+	if inst.studyDeckModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.studyDeckModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *StudyDeckN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type studyDeckScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var studyDeckGlobalScopes = make([]studyDeckScope, 0)
+var studyDeckLocalScopes = make([]studyDeckScope, 0)
+
+// AddGlobalScopeForStudyDeck assign a global scope to a model
+func AddGlobalScopeForStudyDeck(name string, apply func(builder query.Condition)) {
+	studyDeckGlobalScopes = append(studyDeckGlobalScopes, studyDeckScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForStudyDeck assign a local scope to a model
+func AddLocalScopeForStudyDeck(name string, apply func(builder query.Condition)) {
+	studyDeckLocalScopes = append(studyDeckLocalScopes, studyDeckScope{name: name, apply: apply})
+}
+
+func (m *StudyDeckModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range studyDeckGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range studyDeckLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *StudyDeckModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *StudyDeckModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type StudyDeck struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+	RoomId    int64  `json:"room_id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w StudyDeck) ToStudyDeckN(allows ...string) StudyDeckN {
+	if len(allows) == 0 {
+		return StudyDeckN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Name:      null.StringFrom(w.Name),
+			Source:    null.StringFrom(w.Source),
+			RoomId:    null.IntFrom(int64(w.RoomId)),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := StudyDeckN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "source":
+			res.Source = null.StringFrom(w.Source)
+		case "room_id":
+			res.RoomId = null.IntFrom(int64(w.RoomId))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w StudyDeck) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *StudyDeckN) ToStudyDeck() StudyDeck {
+	return StudyDeck{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Name:      w.Name.String,
+		Source:    w.Source.String,
+		RoomId:    w.RoomId.Int64,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// StudyDeckModel is a model which encapsulates the operations of the object
+type StudyDeckModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var studyDeckTableName = "study_deck"
+
+// StudyDeckTable return table name for StudyDeck
+func StudyDeckTable() string {
+	return studyDeckTableName
+}
+
+const (
+	FieldStudyDeckId        = "id"
+	FieldStudyDeckUserId    = "user_id"
+	FieldStudyDeckName      = "name"
+	FieldStudyDeckSource    = "source"
+	FieldStudyDeckRoomId    = "room_id"
+	FieldStudyDeckCreatedAt = "created_at"
+	FieldStudyDeckUpdatedAt = "updated_at"
+)
+
+// StudyDeckFields return all fields in StudyDeck model
+func StudyDeckFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"name",
+		"source",
+		"room_id",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetStudyDeckTable(tableName string) {
+	studyDeckTableName = tableName
+}
+
+// NewStudyDeckModel create a StudyDeckModel
+func NewStudyDeckModel(db query.Database) *StudyDeckModel {
+	return &StudyDeckModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           studyDeckTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *StudyDeckModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *StudyDeckModel) clone() *StudyDeckModel {
+	return &StudyDeckModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *StudyDeckModel) WithoutGlobalScopes(names ...string) *StudyDeckModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *StudyDeckModel) WithLocalScopes(names ...string) *StudyDeckModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *StudyDeckModel) Condition(builder query.SQLBuilder) *StudyDeckModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *StudyDeckModel) Find(ctx context.Context, id int64) (*StudyDeckN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *StudyDeckModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *StudyDeckModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *StudyDeckModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]StudyDeckN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *StudyDeckModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]StudyDeckN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"name",
+			"source",
+			"room_id",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "source":
+			selectFields = append(selectFields, f)
+		case "room_id":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*StudyDeckN, []interface{}) {
+		var studyDeckVar StudyDeckN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &studyDeckVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &studyDeckVar.UserId)
+			case "name":
+				scanFields = append(scanFields, &studyDeckVar.Name)
+			case "source":
+				scanFields = append(scanFields, &studyDeckVar.Source)
+			case "room_id":
+				scanFields = append(scanFields, &studyDeckVar.RoomId)
+			case "created_at":
+				scanFields = append(scanFields, &studyDeckVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &studyDeckVar.UpdatedAt)
+			}
+		}
+
+		return &studyDeckVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	studyDecks := make([]StudyDeckN, 0)
+	for rows.Next() {
+		studyDeckReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		studyDeckReal.original = &studyDeckOriginal{}
+		_ = query.Copy(studyDeckReal, studyDeckReal.original)
+
+		studyDeckReal.SetModel(m)
+		studyDecks = append(studyDecks, *studyDeckReal)
+	}
+
+	return studyDecks, nil
+}
+
+// First return first result for given query
+func (m *StudyDeckModel) First(ctx context.Context, builders ...query.SQLBuilder) (*StudyDeckN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new study_deck to database
+func (m *StudyDeckModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all study_decks to database
+func (m *StudyDeckModel) SaveAll(ctx context.Context, studyDecks []StudyDeckN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, studyDeck := range studyDecks {
+		id, err := m.Save(ctx, studyDeck)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a study_deck to database
+func (m *StudyDeckModel) Save(ctx context.Context, studyDeck StudyDeckN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, studyDeck.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new study_deck or update it when it has a id > 0
+func (m *StudyDeckModel) SaveOrUpdate(ctx context.Context, studyDeck StudyDeckN, onlyFields ...string) (id int64, updated bool, err error) {
+	if studyDeck.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, studyDeck.Id.Int64, studyDeck, onlyFields...)
+		return studyDeck.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, studyDeck, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *StudyDeckModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *StudyDeckModel) Update(ctx context.Context, builder query.SQLBuilder, studyDeck StudyDeckN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, studyDeck.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *StudyDeckModel) UpdateById(ctx context.Context, id int64, studyDeck StudyDeckN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, studyDeck.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *StudyDeckModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *StudyDeckModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// StudyCardN is a StudyCard object, all fields are nullable
+type StudyCardN struct {
+	original       *studyCardOriginal
+	studyCardModel *StudyCardModel
+
+	Id           null.Int    `json:"id"`
+	UserId       null.Int    `json:"user_id"`
+	DeckId       null.Int    `json:"deck_id"`
+	Front        null.String `json:"front"`
+	Back         null.String `json:"back"`
+	Repetitions  null.Int    `json:"repetitions"`
+	IntervalDays null.Int    `json:"interval_days"`
+	EaseFactor   null.Int    `json:"ease_factor"`
+	DueAt        null.Time   `json:"due_at"`
+	ReviewedAt   null.Time   `json:"reviewed_at"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *StudyCardN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for StudyCard
+func (inst *StudyCardN) SetModel(studyCardModel *StudyCardModel) {
+	inst.studyCardModel = studyCardModel
+}
+
+// studyCardOriginal is an object which stores original StudyCard from database
+type studyCardOriginal struct {
+	Id           null.Int
+	UserId       null.Int
+	DeckId       null.Int
+	Front        null.String
+	Back         null.String
+	Repetitions  null.Int
+	IntervalDays null.Int
+	EaseFactor   null.Int
+	DueAt        null.Time
+	ReviewedAt   null.Time
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *StudyCardN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &studyCardOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.DeckId != inst.original.DeckId {
+			return true
+		}
+		if inst.Front != inst.original.Front {
+			return true
+		}
+		if inst.Back != inst.original.Back {
+			return true
+		}
+		if inst.Repetitions != inst.original.Repetitions {
+			return true
+		}
+		if inst.IntervalDays != inst.original.IntervalDays {
+			return true
+		}
+		if inst.EaseFactor != inst.original.EaseFactor {
+			return true
+		}
+		if inst.DueAt != inst.original.DueAt {
+			return true
+		}
+		if inst.ReviewedAt != inst.original.ReviewedAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "deck_id":
+				if inst.DeckId != inst.original.DeckId {
+					return true
+				}
+			case "front":
+				if inst.Front != inst.original.Front {
+					return true
+				}
+			case "back":
+				if inst.Back != inst.original.Back {
+					return true
+				}
+			case "repetitions":
+				if inst.Repetitions != inst.original.Repetitions {
+					return true
+				}
+			case "interval_days":
+				if inst.IntervalDays != inst.original.IntervalDays {
+					return true
+				}
+			case "ease_factor":
+				if inst.EaseFactor != inst.original.EaseFactor {
+					return true
+				}
+			case "due_at":
+				if inst.DueAt != inst.original.DueAt {
+					return true
+				}
+			case "reviewed_at":
+				if inst.ReviewedAt != inst.original.ReviewedAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *StudyCardN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &studyCardOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.DeckId != inst.original.DeckId {
+			kv["deck_id"] = inst.DeckId
+		}
+		if inst.Front != inst.original.Front {
+			kv["front"] = inst.Front
+		}
+		if inst.Back != inst.original.Back {
+			kv["back"] = inst.Back
+		}
+		if inst.Repetitions != inst.original.Repetitions {
+			kv["repetitions"] = inst.Repetitions
+		}
+		if inst.IntervalDays != inst.original.IntervalDays {
+			kv["interval_days"] = inst.IntervalDays
+		}
+		if inst.EaseFactor != inst.original.EaseFactor {
+			kv["ease_factor"] = inst.EaseFactor
+		}
+		if inst.DueAt != inst.original.DueAt {
+			kv["due_at"] = inst.DueAt
+		}
+		if inst.ReviewedAt != inst.original.ReviewedAt {
+			kv["reviewed_at"] = inst.ReviewedAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "deck_id":
+				if inst.DeckId != inst.original.DeckId {
+					kv["deck_id"] = inst.DeckId
+				}
+			case "front":
+				if inst.Front != inst.original.Front {
+					kv["front"] = inst.Front
+				}
+			case "back":
+				if inst.Back != inst.original.Back {
+					kv["back"] = inst.Back
+				}
+			case "repetitions":
+				if inst.Repetitions != inst.original.Repetitions {
+					kv["repetitions"] = inst.Repetitions
+				}
+			case "interval_days":
+				if inst.IntervalDays != inst.original.IntervalDays {
+					kv["interval_days"] = inst.IntervalDays
+				}
+			case "ease_factor":
+				if inst.EaseFactor != inst.original.EaseFactor {
+					kv["ease_factor"] = inst.EaseFactor
+				}
+			case "due_at":
+				if inst.DueAt != inst.original.DueAt {
+					kv["due_at"] = inst.DueAt
+				}
+			case "reviewed_at":
+				if inst.ReviewedAt != inst.original.ReviewedAt {
+					kv["reviewed_at"] = inst.ReviewedAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *StudyCardN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.studyCardModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.studyCardModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a study_card
+func (inst *StudyCardN) Delete(ctx context.Context) error {
+	if inst.studyCardModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.studyCardModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *StudyCardN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type studyCardScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var studyCardGlobalScopes = make([]studyCardScope, 0)
+var studyCardLocalScopes = make([]studyCardScope, 0)
+
+// AddGlobalScopeForStudyCard assign a global scope to a model
+func AddGlobalScopeForStudyCard(name string, apply func(builder query.Condition)) {
+	studyCardGlobalScopes = append(studyCardGlobalScopes, studyCardScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForStudyCard assign a local scope to a model
+func AddLocalScopeForStudyCard(name string, apply func(builder query.Condition)) {
+	studyCardLocalScopes = append(studyCardLocalScopes, studyCardScope{name: name, apply: apply})
+}
+
+func (m *StudyCardModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range studyCardGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range studyCardLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *StudyCardModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *StudyCardModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type StudyCard struct {
+	Id           int64     `json:"id"`
+	UserId       int64     `json:"user_id"`
+	DeckId       int64     `json:"deck_id"`
+	Front        string    `json:"front"`
+	Back         string    `json:"back"`
+	Repetitions  int64     `json:"repetitions"`
+	IntervalDays int64     `json:"interval_days"`
+	EaseFactor   int64     `json:"ease_factor"`
+	DueAt        time.Time `json:"due_at"`
+	ReviewedAt   time.Time `json:"reviewed_at"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w StudyCard) ToStudyCardN(allows ...string) StudyCardN {
+	if len(allows) == 0 {
+		return StudyCardN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			UserId:       null.IntFrom(int64(w.UserId)),
+			DeckId:       null.IntFrom(int64(w.DeckId)),
+			Front:        null.StringFrom(w.Front),
+			Back:         null.StringFrom(w.Back),
+			Repetitions:  null.IntFrom(int64(w.Repetitions)),
+			IntervalDays: null.IntFrom(int64(w.IntervalDays)),
+			EaseFactor:   null.IntFrom(int64(w.EaseFactor)),
+			DueAt:        null.TimeFrom(w.DueAt),
+			ReviewedAt:   null.TimeFrom(w.ReviewedAt),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := StudyCardN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "deck_id":
+			res.DeckId = null.IntFrom(int64(w.DeckId))
+		case "front":
+			res.Front = null.StringFrom(w.Front)
+		case "back":
+			res.Back = null.StringFrom(w.Back)
+		case "repetitions":
+			res.Repetitions = null.IntFrom(int64(w.Repetitions))
+		case "interval_days":
+			res.IntervalDays = null.IntFrom(int64(w.IntervalDays))
+		case "ease_factor":
+			res.EaseFactor = null.IntFrom(int64(w.EaseFactor))
+		case "due_at":
+			res.DueAt = null.TimeFrom(w.DueAt)
+		case "reviewed_at":
+			res.ReviewedAt = null.TimeFrom(w.ReviewedAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w StudyCard) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *StudyCardN) ToStudyCard() StudyCard {
+	return StudyCard{
+
+		Id:           w.Id.Int64,
+		UserId:       w.UserId.Int64,
+		DeckId:       w.DeckId.Int64,
+		Front:        w.Front.String,
+		Back:         w.Back.String,
+		Repetitions:  w.Repetitions.Int64,
+		IntervalDays: w.IntervalDays.Int64,
+		EaseFactor:   w.EaseFactor.Int64,
+		DueAt:        w.DueAt.Time,
+		ReviewedAt:   w.ReviewedAt.Time,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// StudyCardModel is a model which encapsulates the operations of the object
+type StudyCardModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var studyCardTableName = "study_card"
+
+// StudyCardTable return table name for StudyCard
+func StudyCardTable() string {
+	return studyCardTableName
+}
+
+const (
+	FieldStudyCardId           = "id"
+	FieldStudyCardUserId       = "user_id"
+	FieldStudyCardDeckId       = "deck_id"
+	FieldStudyCardFront        = "front"
+	FieldStudyCardBack         = "back"
+	FieldStudyCardRepetitions  = "repetitions"
+	FieldStudyCardIntervalDays = "interval_days"
+	FieldStudyCardEaseFactor   = "ease_factor"
+	FieldStudyCardDueAt        = "due_at"
+	FieldStudyCardReviewedAt   = "reviewed_at"
+	FieldStudyCardCreatedAt    = "created_at"
+	FieldStudyCardUpdatedAt    = "updated_at"
+)
+
+// StudyCardFields return all fields in StudyCard model
+func StudyCardFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"deck_id",
+		"front",
+		"back",
+		"repetitions",
+		"interval_days",
+		"ease_factor",
+		"due_at",
+		"reviewed_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetStudyCardTable(tableName string) {
+	studyCardTableName = tableName
+}
+
+// NewStudyCardModel create a StudyCardModel
+func NewStudyCardModel(db query.Database) *StudyCardModel {
+	return &StudyCardModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           studyCardTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *StudyCardModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *StudyCardModel) clone() *StudyCardModel {
+	return &StudyCardModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *StudyCardModel) WithoutGlobalScopes(names ...string) *StudyCardModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *StudyCardModel) WithLocalScopes(names ...string) *StudyCardModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *StudyCardModel) Condition(builder query.SQLBuilder) *StudyCardModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *StudyCardModel) Find(ctx context.Context, id int64) (*StudyCardN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *StudyCardModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *StudyCardModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *StudyCardModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]StudyCardN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *StudyCardModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]StudyCardN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"deck_id",
+			"front",
+			"back",
+			"repetitions",
+			"interval_days",
+			"ease_factor",
+			"due_at",
+			"reviewed_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "deck_id":
+			selectFields = append(selectFields, f)
+		case "front":
+			selectFields = append(selectFields, f)
+		case "back":
+			selectFields = append(selectFields, f)
+		case "repetitions":
+			selectFields = append(selectFields, f)
+		case "interval_days":
+			selectFields = append(selectFields, f)
+		case "ease_factor":
+			selectFields = append(selectFields, f)
+		case "due_at":
+			selectFields = append(selectFields, f)
+		case "reviewed_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*StudyCardN, []interface{}) {
+		var studyCardVar StudyCardN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &studyCardVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &studyCardVar.UserId)
+			case "deck_id":
+				scanFields = append(scanFields, &studyCardVar.DeckId)
+			case "front":
+				scanFields = append(scanFields, &studyCardVar.Front)
+			case "back":
+				scanFields = append(scanFields, &studyCardVar.Back)
+			case "repetitions":
+				scanFields = append(scanFields, &studyCardVar.Repetitions)
+			case "interval_days":
+				scanFields = append(scanFields, &studyCardVar.IntervalDays)
+			case "ease_factor":
+				scanFields = append(scanFields, &studyCardVar.EaseFactor)
+			case "due_at":
+				scanFields = append(scanFields, &studyCardVar.DueAt)
+			case "reviewed_at":
+				scanFields = append(scanFields, &studyCardVar.ReviewedAt)
+			case "created_at":
+				scanFields = append(scanFields, &studyCardVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &studyCardVar.UpdatedAt)
+			}
+		}
+
+		return &studyCardVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	studyCards := make([]StudyCardN, 0)
+	for rows.Next() {
+		studyCardReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		studyCardReal.original = &studyCardOriginal{}
+		_ = query.Copy(studyCardReal, studyCardReal.original)
+
+		studyCardReal.SetModel(m)
+		studyCards = append(studyCards, *studyCardReal)
+	}
+
+	return studyCards, nil
+}
+
+// First return first result for given query
+func (m *StudyCardModel) First(ctx context.Context, builders ...query.SQLBuilder) (*StudyCardN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new study_card to database
+func (m *StudyCardModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all study_cards to database
+func (m *StudyCardModel) SaveAll(ctx context.Context, studyCards []StudyCardN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, studyCard := range studyCards {
+		id, err := m.Save(ctx, studyCard)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a study_card to database
+func (m *StudyCardModel) Save(ctx context.Context, studyCard StudyCardN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, studyCard.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new study_card or update it when it has a id > 0
+func (m *StudyCardModel) SaveOrUpdate(ctx context.Context, studyCard StudyCardN, onlyFields ...string) (id int64, updated bool, err error) {
+	if studyCard.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, studyCard.Id.Int64, studyCard, onlyFields...)
+		return studyCard.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, studyCard, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *StudyCardModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *StudyCardModel) Update(ctx context.Context, builder query.SQLBuilder, studyCard StudyCardN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, studyCard.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *StudyCardModel) UpdateById(ctx context.Context, id int64, studyCard StudyCardN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, studyCard.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *StudyCardModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *StudyCardModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,1590 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// SupportTicketN is a SupportTicket object, all fields are nullable
+type SupportTicketN struct {
+	original           *supportTicketOriginal
+	supportTicketModel *SupportTicketModel
+
+	Id             null.Int    `json:"id"`
+	UserId         null.Int    `json:"user_id"`
+	Subject        null.String `json:"subject"`
+	Category       null.String `json:"category"`
+	Status         null.Int    `json:"status"`
+	AttachmentType null.String `json:"attachment_type"`
+	AttachmentId   null.String `json:"attachment_id"`
+	UserUnread     null.Int    `json:"user_unread"`
+	AdminUnread    null.Int    `json:"admin_unread"`
+	LastReplyAt    null.Time   `json:"last_reply_at"`
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *SupportTicketN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for SupportTicket
+func (inst *SupportTicketN) SetModel(supportTicketModel *SupportTicketModel) {
+	inst.supportTicketModel = supportTicketModel
+}
+
+// supportTicketOriginal is an object which stores original SupportTicket from database
+type supportTicketOriginal struct {
+	Id             null.Int
+	UserId         null.Int
+	Subject        null.String
+	Category       null.String
+	Status         null.Int
+	AttachmentType null.String
+	AttachmentId   null.String
+	UserUnread     null.Int
+	AdminUnread    null.Int
+	LastReplyAt    null.Time
+	CreatedAt      null.Time
+	UpdatedAt      null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *SupportTicketN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &supportTicketOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Subject != inst.original.Subject {
+			return true
+		}
+		if inst.Category != inst.original.Category {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.AttachmentType != inst.original.AttachmentType {
+			return true
+		}
+		if inst.AttachmentId != inst.original.AttachmentId {
+			return true
+		}
+		if inst.UserUnread != inst.original.UserUnread {
+			return true
+		}
+		if inst.AdminUnread != inst.original.AdminUnread {
+			return true
+		}
+		if inst.LastReplyAt != inst.original.LastReplyAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "subject":
+				if inst.Subject != inst.original.Subject {
+					return true
+				}
+			case "category":
+				if inst.Category != inst.original.Category {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "attachment_type":
+				if inst.AttachmentType != inst.original.AttachmentType {
+					return true
+				}
+			case "attachment_id":
+				if inst.AttachmentId != inst.original.AttachmentId {
+					return true
+				}
+			case "user_unread":
+				if inst.UserUnread != inst.original.UserUnread {
+					return true
+				}
+			case "admin_unread":
+				if inst.AdminUnread != inst.original.AdminUnread {
+					return true
+				}
+			case "last_reply_at":
+				if inst.LastReplyAt != inst.original.LastReplyAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *SupportTicketN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &supportTicketOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Subject != inst.original.Subject {
+			kv["subject"] = inst.Subject
+		}
+		if inst.Category != inst.original.Category {
+			kv["category"] = inst.Category
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.AttachmentType != inst.original.AttachmentType {
+			kv["attachment_type"] = inst.AttachmentType
+		}
+		if inst.AttachmentId != inst.original.AttachmentId {
+			kv["attachment_id"] = inst.AttachmentId
+		}
+		if inst.UserUnread != inst.original.UserUnread {
+			kv["user_unread"] = inst.UserUnread
+		}
+		if inst.AdminUnread != inst.original.AdminUnread {
+			kv["admin_unread"] = inst.AdminUnread
+		}
+		if inst.LastReplyAt != inst.original.LastReplyAt {
+			kv["last_reply_at"] = inst.LastReplyAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "subject":
+				if inst.Subject != inst.original.Subject {
+					kv["subject"] = inst.Subject
+				}
+			case "category":
+				if inst.Category != inst.original.Category {
+					kv["category"] = inst.Category
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "attachment_type":
+				if inst.AttachmentType != inst.original.AttachmentType {
+					kv["attachment_type"] = inst.AttachmentType
+				}
+			case "attachment_id":
+				if inst.AttachmentId != inst.original.AttachmentId {
+					kv["attachment_id"] = inst.AttachmentId
+				}
+			case "user_unread":
+				if inst.UserUnread != inst.original.UserUnread {
+					kv["user_unread"] = inst.UserUnread
+				}
+			case "admin_unread":
+				if inst.AdminUnread != inst.original.AdminUnread {
+					kv["admin_unread"] = inst.AdminUnread
+				}
+			case "last_reply_at":
+				if inst.LastReplyAt != inst.original.LastReplyAt {
+					kv["last_reply_at"] = inst.LastReplyAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *SupportTicketN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.supportTicketModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.supportTicketModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a support_ticket
+func (inst *SupportTicketN) Delete(ctx context.Context) error {
+	if inst.supportTicketModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.supportTicketModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *SupportTicketN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type supportTicketScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var supportTicketGlobalScopes = make([]supportTicketScope, 0)
+var supportTicketLocalScopes = make([]supportTicketScope, 0)
+
+// AddGlobalScopeForSupportTicket assign a global scope to a model
+func AddGlobalScopeForSupportTicket(name string, apply func(builder query.Condition)) {
+	supportTicketGlobalScopes = append(supportTicketGlobalScopes, supportTicketScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForSupportTicket assign a local scope to a model
+func AddLocalScopeForSupportTicket(name string, apply func(builder query.Condition)) {
+	supportTicketLocalScopes = append(supportTicketLocalScopes, supportTicketScope{name: name, apply: apply})
+}
+
+func (m *SupportTicketModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range supportTicketGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range supportTicketLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *SupportTicketModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *SupportTicketModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type SupportTicket struct {
+	Id             int64     `json:"id"`
+	UserId         int64     `json:"user_id"`
+	Subject        string    `json:"subject"`
+	Category       string    `json:"category"`
+	Status         int64     `json:"status"`
+	AttachmentType string    `json:"attachment_type"`
+	AttachmentId   string    `json:"attachment_id"`
+	UserUnread     int64     `json:"user_unread"`
+	AdminUnread    int64     `json:"admin_unread"`
+	LastReplyAt    time.Time `json:"last_reply_at"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (w SupportTicket) ToSupportTicketN(allows ...string) SupportTicketN {
+	if len(allows) == 0 {
+		return SupportTicketN{
+
+			Id:             null.IntFrom(int64(w.Id)),
+			UserId:         null.IntFrom(int64(w.UserId)),
+			Subject:        null.StringFrom(w.Subject),
+			Category:       null.StringFrom(w.Category),
+			Status:         null.IntFrom(int64(w.Status)),
+			AttachmentType: null.StringFrom(w.AttachmentType),
+			AttachmentId:   null.StringFrom(w.AttachmentId),
+			UserUnread:     null.IntFrom(int64(w.UserUnread)),
+			AdminUnread:    null.IntFrom(int64(w.AdminUnread)),
+			LastReplyAt:    null.TimeFrom(w.LastReplyAt),
+			CreatedAt:      null.TimeFrom(w.CreatedAt),
+			UpdatedAt:      null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := SupportTicketN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "subject":
+			res.Subject = null.StringFrom(w.Subject)
+		case "category":
+			res.Category = null.StringFrom(w.Category)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "attachment_type":
+			res.AttachmentType = null.StringFrom(w.AttachmentType)
+		case "attachment_id":
+			res.AttachmentId = null.StringFrom(w.AttachmentId)
+		case "user_unread":
+			res.UserUnread = null.IntFrom(int64(w.UserUnread))
+		case "admin_unread":
+			res.AdminUnread = null.IntFrom(int64(w.AdminUnread))
+		case "last_reply_at":
+			res.LastReplyAt = null.TimeFrom(w.LastReplyAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w SupportTicket) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *SupportTicketN) ToSupportTicket() SupportTicket {
+	return SupportTicket{
+
+		Id:             w.Id.Int64,
+		UserId:         w.UserId.Int64,
+		Subject:        w.Subject.String,
+		Category:       w.Category.String,
+		Status:         w.Status.Int64,
+		AttachmentType: w.AttachmentType.String,
+		AttachmentId:   w.AttachmentId.String,
+		UserUnread:     w.UserUnread.Int64,
+		AdminUnread:    w.AdminUnread.Int64,
+		LastReplyAt:    w.LastReplyAt.Time,
+		CreatedAt:      w.CreatedAt.Time,
+		UpdatedAt:      w.UpdatedAt.Time,
+	}
+}
+
+// SupportTicketModel is a model which encapsulates the operations of the object
+type SupportTicketModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var supportTicketTableName = "support_ticket"
+
+// SupportTicketTable return table name for SupportTicket
+func SupportTicketTable() string {
+	return supportTicketTableName
+}
+
+const (
+	FieldSupportTicketId             = "id"
+	FieldSupportTicketUserId         = "user_id"
+	FieldSupportTicketSubject        = "subject"
+	FieldSupportTicketCategory       = "category"
+	FieldSupportTicketStatus         = "status"
+	FieldSupportTicketAttachmentType = "attachment_type"
+	FieldSupportTicketAttachmentId   = "attachment_id"
+	FieldSupportTicketUserUnread     = "user_unread"
+	FieldSupportTicketAdminUnread    = "admin_unread"
+	FieldSupportTicketLastReplyAt    = "last_reply_at"
+	FieldSupportTicketCreatedAt      = "created_at"
+	FieldSupportTicketUpdatedAt      = "updated_at"
+)
+
+// SupportTicketFields return all fields in SupportTicket model
+func SupportTicketFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"subject",
+		"category",
+		"status",
+		"attachment_type",
+		"attachment_id",
+		"user_unread",
+		"admin_unread",
+		"last_reply_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetSupportTicketTable(tableName string) {
+	supportTicketTableName = tableName
+}
+
+// NewSupportTicketModel create a SupportTicketModel
+func NewSupportTicketModel(db query.Database) *SupportTicketModel {
+	return &SupportTicketModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           supportTicketTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *SupportTicketModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *SupportTicketModel) clone() *SupportTicketModel {
+	return &SupportTicketModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *SupportTicketModel) WithoutGlobalScopes(names ...string) *SupportTicketModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *SupportTicketModel) WithLocalScopes(names ...string) *SupportTicketModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *SupportTicketModel) Condition(builder query.SQLBuilder) *SupportTicketModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *SupportTicketModel) Find(ctx context.Context, id int64) (*SupportTicketN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *SupportTicketModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *SupportTicketModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *SupportTicketModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]SupportTicketN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *SupportTicketModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]SupportTicketN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"subject",
+			"category",
+			"status",
+			"attachment_type",
+			"attachment_id",
+			"user_unread",
+			"admin_unread",
+			"last_reply_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "subject":
+			selectFields = append(selectFields, f)
+		case "category":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "attachment_type":
+			selectFields = append(selectFields, f)
+		case "attachment_id":
+			selectFields = append(selectFields, f)
+		case "user_unread":
+			selectFields = append(selectFields, f)
+		case "admin_unread":
+			selectFields = append(selectFields, f)
+		case "last_reply_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*SupportTicketN, []interface{}) {
+		var supportTicketVar SupportTicketN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &supportTicketVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &supportTicketVar.UserId)
+			case "subject":
+				scanFields = append(scanFields, &supportTicketVar.Subject)
+			case "category":
+				scanFields = append(scanFields, &supportTicketVar.Category)
+			case "status":
+				scanFields = append(scanFields, &supportTicketVar.Status)
+			case "attachment_type":
+				scanFields = append(scanFields, &supportTicketVar.AttachmentType)
+			case "attachment_id":
+				scanFields = append(scanFields, &supportTicketVar.AttachmentId)
+			case "user_unread":
+				scanFields = append(scanFields, &supportTicketVar.UserUnread)
+			case "admin_unread":
+				scanFields = append(scanFields, &supportTicketVar.AdminUnread)
+			case "last_reply_at":
+				scanFields = append(scanFields, &supportTicketVar.LastReplyAt)
+			case "created_at":
+				scanFields = append(scanFields, &supportTicketVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &supportTicketVar.UpdatedAt)
+			}
+		}
+
+		return &supportTicketVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	supportTickets := make([]SupportTicketN, 0)
+	for rows.Next() {
+		supportTicketReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		supportTicketReal.original = &supportTicketOriginal{}
+		_ = query.Copy(supportTicketReal, supportTicketReal.original)
+
+		supportTicketReal.SetModel(m)
+		supportTickets = append(supportTickets, *supportTicketReal)
+	}
+
+	return supportTickets, nil
+}
+
+// First return first result for given query
+func (m *SupportTicketModel) First(ctx context.Context, builders ...query.SQLBuilder) (*SupportTicketN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new support_ticket to database
+func (m *SupportTicketModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all support_tickets to database
+func (m *SupportTicketModel) SaveAll(ctx context.Context, supportTickets []SupportTicketN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, supportTicket := range supportTickets {
+		id, err := m.Save(ctx, supportTicket)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a support_ticket to database
+func (m *SupportTicketModel) Save(ctx context.Context, supportTicket SupportTicketN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, supportTicket.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new support_ticket or update it when it has a id > 0
+func (m *SupportTicketModel) SaveOrUpdate(ctx context.Context, supportTicket SupportTicketN, onlyFields ...string) (id int64, updated bool, err error) {
+	if supportTicket.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, supportTicket.Id.Int64, supportTicket, onlyFields...)
+		return supportTicket.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, supportTicket, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *SupportTicketModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *SupportTicketModel) Update(ctx context.Context, builder query.SQLBuilder, supportTicket SupportTicketN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, supportTicket.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *SupportTicketModel) UpdateById(ctx context.Context, id int64, supportTicket SupportTicketN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, supportTicket.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *SupportTicketModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *SupportTicketModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// SupportTicketMessageN is a SupportTicketMessage object, all fields are nullable
+type SupportTicketMessageN struct {
+	original                  *supportTicketMessageOriginal
+	supportTicketMessageModel *SupportTicketMessageModel
+
+	Id        null.Int    `json:"id"`
+	TicketId  null.Int    `json:"ticket_id"`
+	SenderId  null.Int    `json:"sender_id"`
+	IsAdmin   null.Int    `json:"is_admin"`
+	Content   null.String `json:"content"`
+	Images    null.String `json:"images"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *SupportTicketMessageN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for SupportTicketMessage
+func (inst *SupportTicketMessageN) SetModel(supportTicketMessageModel *SupportTicketMessageModel) {
+	inst.supportTicketMessageModel = supportTicketMessageModel
+}
+
+// supportTicketMessageOriginal is an object which stores original SupportTicketMessage from database
+type supportTicketMessageOriginal struct {
+	Id        null.Int
+	TicketId  null.Int
+	SenderId  null.Int
+	IsAdmin   null.Int
+	Content   null.String
+	Images    null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *SupportTicketMessageN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &supportTicketMessageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.TicketId != inst.original.TicketId {
+			return true
+		}
+		if inst.SenderId != inst.original.SenderId {
+			return true
+		}
+		if inst.IsAdmin != inst.original.IsAdmin {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.Images != inst.original.Images {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "ticket_id":
+				if inst.TicketId != inst.original.TicketId {
+					return true
+				}
+			case "sender_id":
+				if inst.SenderId != inst.original.SenderId {
+					return true
+				}
+			case "is_admin":
+				if inst.IsAdmin != inst.original.IsAdmin {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "images":
+				if inst.Images != inst.original.Images {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *SupportTicketMessageN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &supportTicketMessageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.TicketId != inst.original.TicketId {
+			kv["ticket_id"] = inst.TicketId
+		}
+		if inst.SenderId != inst.original.SenderId {
+			kv["sender_id"] = inst.SenderId
+		}
+		if inst.IsAdmin != inst.original.IsAdmin {
+			kv["is_admin"] = inst.IsAdmin
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.Images != inst.original.Images {
+			kv["images"] = inst.Images
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "ticket_id":
+				if inst.TicketId != inst.original.TicketId {
+					kv["ticket_id"] = inst.TicketId
+				}
+			case "sender_id":
+				if inst.SenderId != inst.original.SenderId {
+					kv["sender_id"] = inst.SenderId
+				}
+			case "is_admin":
+				if inst.IsAdmin != inst.original.IsAdmin {
+					kv["is_admin"] = inst.IsAdmin
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "images":
+				if inst.Images != inst.original.Images {
+					kv["images"] = inst.Images
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *SupportTicketMessageN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.supportTicketMessageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.supportTicketMessageModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a support_ticket_message
+func (inst *SupportTicketMessageN) Delete(ctx context.Context) error {
+	if inst.supportTicketMessageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.supportTicketMessageModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *SupportTicketMessageN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type supportTicketMessageScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var supportTicketMessageGlobalScopes = make([]supportTicketMessageScope, 0)
+var supportTicketMessageLocalScopes = make([]supportTicketMessageScope, 0)
+
+// AddGlobalScopeForSupportTicketMessage assign a global scope to a model
+func AddGlobalScopeForSupportTicketMessage(name string, apply func(builder query.Condition)) {
+	supportTicketMessageGlobalScopes = append(supportTicketMessageGlobalScopes, supportTicketMessageScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForSupportTicketMessage assign a local scope to a model
+func AddLocalScopeForSupportTicketMessage(name string, apply func(builder query.Condition)) {
+	supportTicketMessageLocalScopes = append(supportTicketMessageLocalScopes, supportTicketMessageScope{name: name, apply: apply})
+}
+
+func (m *SupportTicketMessageModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range supportTicketMessageGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range supportTicketMessageLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *SupportTicketMessageModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *SupportTicketMessageModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type SupportTicketMessage struct {
+	Id        int64  `json:"id"`
+	TicketId  int64  `json:"ticket_id"`
+	SenderId  int64  `json:"sender_id"`
+	IsAdmin   int64  `json:"is_admin"`
+	Content   string `json:"content"`
+	Images    string `json:"images"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w SupportTicketMessage) ToSupportTicketMessageN(allows ...string) SupportTicketMessageN {
+	if len(allows) == 0 {
+		return SupportTicketMessageN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			TicketId:  null.IntFrom(int64(w.TicketId)),
+			SenderId:  null.IntFrom(int64(w.SenderId)),
+			IsAdmin:   null.IntFrom(int64(w.IsAdmin)),
+			Content:   null.StringFrom(w.Content),
+			Images:    null.StringFrom(w.Images),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := SupportTicketMessageN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "ticket_id":
+			res.TicketId = null.IntFrom(int64(w.TicketId))
+		case "sender_id":
+			res.SenderId = null.IntFrom(int64(w.SenderId))
+		case "is_admin":
+			res.IsAdmin = null.IntFrom(int64(w.IsAdmin))
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "images":
+			res.Images = null.StringFrom(w.Images)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w SupportTicketMessage) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *SupportTicketMessageN) ToSupportTicketMessage() SupportTicketMessage {
+	return SupportTicketMessage{
+
+		Id:        w.Id.Int64,
+		TicketId:  w.TicketId.Int64,
+		SenderId:  w.SenderId.Int64,
+		IsAdmin:   w.IsAdmin.Int64,
+		Content:   w.Content.String,
+		Images:    w.Images.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// SupportTicketMessageModel is a model which encapsulates the operations of the object
+type SupportTicketMessageModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var supportTicketMessageTableName = "support_ticket_message"
+
+// SupportTicketMessageTable return table name for SupportTicketMessage
+func SupportTicketMessageTable() string {
+	return supportTicketMessageTableName
+}
+
+const (
+	FieldSupportTicketMessageId        = "id"
+	FieldSupportTicketMessageTicketId  = "ticket_id"
+	FieldSupportTicketMessageSenderId  = "sender_id"
+	FieldSupportTicketMessageIsAdmin   = "is_admin"
+	FieldSupportTicketMessageContent   = "content"
+	FieldSupportTicketMessageImages    = "images"
+	FieldSupportTicketMessageCreatedAt = "created_at"
+	FieldSupportTicketMessageUpdatedAt = "updated_at"
+)
+
+// SupportTicketMessageFields return all fields in SupportTicketMessage model
+func SupportTicketMessageFields() []string {
+	return []string{
+		"id",
+		"ticket_id",
+		"sender_id",
+		"is_admin",
+		"content",
+		"images",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetSupportTicketMessageTable(tableName string) {
+	supportTicketMessageTableName = tableName
+}
+
+// NewSupportTicketMessageModel create a SupportTicketMessageModel
+func NewSupportTicketMessageModel(db query.Database) *SupportTicketMessageModel {
+	return &SupportTicketMessageModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           supportTicketMessageTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *SupportTicketMessageModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *SupportTicketMessageModel) clone() *SupportTicketMessageModel {
+	return &SupportTicketMessageModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *SupportTicketMessageModel) WithoutGlobalScopes(names ...string) *SupportTicketMessageModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *SupportTicketMessageModel) WithLocalScopes(names ...string) *SupportTicketMessageModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *SupportTicketMessageModel) Condition(builder query.SQLBuilder) *SupportTicketMessageModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *SupportTicketMessageModel) Find(ctx context.Context, id int64) (*SupportTicketMessageN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *SupportTicketMessageModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *SupportTicketMessageModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *SupportTicketMessageModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]SupportTicketMessageN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *SupportTicketMessageModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]SupportTicketMessageN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"ticket_id",
+			"sender_id",
+			"is_admin",
+			"content",
+			"images",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "ticket_id":
+			selectFields = append(selectFields, f)
+		case "sender_id":
+			selectFields = append(selectFields, f)
+		case "is_admin":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "images":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*SupportTicketMessageN, []interface{}) {
+		var supportTicketMessageVar SupportTicketMessageN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &supportTicketMessageVar.Id)
+			case "ticket_id":
+				scanFields = append(scanFields, &supportTicketMessageVar.TicketId)
+			case "sender_id":
+				scanFields = append(scanFields, &supportTicketMessageVar.SenderId)
+			case "is_admin":
+				scanFields = append(scanFields, &supportTicketMessageVar.IsAdmin)
+			case "content":
+				scanFields = append(scanFields, &supportTicketMessageVar.Content)
+			case "images":
+				scanFields = append(scanFields, &supportTicketMessageVar.Images)
+			case "created_at":
+				scanFields = append(scanFields, &supportTicketMessageVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &supportTicketMessageVar.UpdatedAt)
+			}
+		}
+
+		return &supportTicketMessageVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	supportTicketMessages := make([]SupportTicketMessageN, 0)
+	for rows.Next() {
+		supportTicketMessageReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		supportTicketMessageReal.original = &supportTicketMessageOriginal{}
+		_ = query.Copy(supportTicketMessageReal, supportTicketMessageReal.original)
+
+		supportTicketMessageReal.SetModel(m)
+		supportTicketMessages = append(supportTicketMessages, *supportTicketMessageReal)
+	}
+
+	return supportTicketMessages, nil
+}
+
+// First return first result for given query
+func (m *SupportTicketMessageModel) First(ctx context.Context, builders ...query.SQLBuilder) (*SupportTicketMessageN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new support_ticket_message to database
+func (m *SupportTicketMessageModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all support_ticket_messages to database
+func (m *SupportTicketMessageModel) SaveAll(ctx context.Context, supportTicketMessages []SupportTicketMessageN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, supportTicketMessage := range supportTicketMessages {
+		id, err := m.Save(ctx, supportTicketMessage)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a support_ticket_message to database
+func (m *SupportTicketMessageModel) Save(ctx context.Context, supportTicketMessage SupportTicketMessageN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, supportTicketMessage.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new support_ticket_message or update it when it has a id > 0
+func (m *SupportTicketMessageModel) SaveOrUpdate(ctx context.Context, supportTicketMessage SupportTicketMessageN, onlyFields ...string) (id int64, updated bool, err error) {
+	if supportTicketMessage.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, supportTicketMessage.Id.Int64, supportTicketMessage, onlyFields...)
+		return supportTicketMessage.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, supportTicketMessage, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *SupportTicketMessageModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *SupportTicketMessageModel) Update(ctx context.Context, builder query.SQLBuilder, supportTicketMessage SupportTicketMessageN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, supportTicketMessage.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *SupportTicketMessageModel) UpdateById(ctx context.Context, id int64, supportTicketMessage SupportTicketMessageN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, supportTicketMessage.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *SupportTicketMessageModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *SupportTicketMessageModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
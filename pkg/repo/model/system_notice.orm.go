@@ -0,0 +1,831 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// SystemNoticeN is a SystemNotice object, all fields are nullable
+type SystemNoticeN struct {
+	original          *systemNoticeOriginal
+	systemNoticeModel *SystemNoticeModel
+
+	Id            null.Int    `json:"id"`
+	Type          null.String `json:"type"`
+	Level         null.String `json:"level"`
+	Title         null.String `json:"title,omitempty"`
+	Content       null.String `json:"content,omitempty"`
+	GatedFeatures null.String `json:"gated_features,omitempty"`
+	StartAt       null.Time   `json:"start_at"`
+	EndAt         null.Time   `json:"end_at"`
+	Status        null.Int    `json:"status"`
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *SystemNoticeN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for SystemNotice
+func (inst *SystemNoticeN) SetModel(systemNoticeModel *SystemNoticeModel) {
+	inst.systemNoticeModel = systemNoticeModel
+}
+
+// systemNoticeOriginal is an object which stores original SystemNotice from database
+type systemNoticeOriginal struct {
+	Id            null.Int
+	Type          null.String
+	Level         null.String
+	Title         null.String
+	Content       null.String
+	GatedFeatures null.String
+	StartAt       null.Time
+	EndAt         null.Time
+	Status        null.Int
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *SystemNoticeN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &systemNoticeOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Type != inst.original.Type {
+			return true
+		}
+		if inst.Level != inst.original.Level {
+			return true
+		}
+		if inst.Title != inst.original.Title {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.GatedFeatures != inst.original.GatedFeatures {
+			return true
+		}
+		if inst.StartAt != inst.original.StartAt {
+			return true
+		}
+		if inst.EndAt != inst.original.EndAt {
+			return true
+		}
+		if inst.Status != inst.original.Status {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "type":
+				if inst.Type != inst.original.Type {
+					return true
+				}
+			case "level":
+				if inst.Level != inst.original.Level {
+					return true
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "gated_features":
+				if inst.GatedFeatures != inst.original.GatedFeatures {
+					return true
+				}
+			case "start_at":
+				if inst.StartAt != inst.original.StartAt {
+					return true
+				}
+			case "end_at":
+				if inst.EndAt != inst.original.EndAt {
+					return true
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *SystemNoticeN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &systemNoticeOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Type != inst.original.Type {
+			kv["type"] = inst.Type
+		}
+		if inst.Level != inst.original.Level {
+			kv["level"] = inst.Level
+		}
+		if inst.Title != inst.original.Title {
+			kv["title"] = inst.Title
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.GatedFeatures != inst.original.GatedFeatures {
+			kv["gated_features"] = inst.GatedFeatures
+		}
+		if inst.StartAt != inst.original.StartAt {
+			kv["start_at"] = inst.StartAt
+		}
+		if inst.EndAt != inst.original.EndAt {
+			kv["end_at"] = inst.EndAt
+		}
+		if inst.Status != inst.original.Status {
+			kv["status"] = inst.Status
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "type":
+				if inst.Type != inst.original.Type {
+					kv["type"] = inst.Type
+				}
+			case "level":
+				if inst.Level != inst.original.Level {
+					kv["level"] = inst.Level
+				}
+			case "title":
+				if inst.Title != inst.original.Title {
+					kv["title"] = inst.Title
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "gated_features":
+				if inst.GatedFeatures != inst.original.GatedFeatures {
+					kv["gated_features"] = inst.GatedFeatures
+				}
+			case "start_at":
+				if inst.StartAt != inst.original.StartAt {
+					kv["start_at"] = inst.StartAt
+				}
+			case "end_at":
+				if inst.EndAt != inst.original.EndAt {
+					kv["end_at"] = inst.EndAt
+				}
+			case "status":
+				if inst.Status != inst.original.Status {
+					kv["status"] = inst.Status
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *SystemNoticeN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.systemNoticeModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.systemNoticeModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a system_notice
+func (inst *SystemNoticeN) Delete(ctx context.Context) error {
+	if inst.systemNoticeModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.systemNoticeModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *SystemNoticeN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type systemNoticeScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var systemNoticeGlobalScopes = make([]systemNoticeScope, 0)
+var systemNoticeLocalScopes = make([]systemNoticeScope, 0)
+
+// AddGlobalScopeForSystemNotice assign a global scope to a model
+func AddGlobalScopeForSystemNotice(name string, apply func(builder query.Condition)) {
+	systemNoticeGlobalScopes = append(systemNoticeGlobalScopes, systemNoticeScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForSystemNotice assign a local scope to a model
+func AddLocalScopeForSystemNotice(name string, apply func(builder query.Condition)) {
+	systemNoticeLocalScopes = append(systemNoticeLocalScopes, systemNoticeScope{name: name, apply: apply})
+}
+
+func (m *SystemNoticeModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range systemNoticeGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range systemNoticeLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *SystemNoticeModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *SystemNoticeModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type SystemNotice struct {
+	Id            int64     `json:"id"`
+	Type          string    `json:"type"`
+	Level         string    `json:"level"`
+	Title         string    `json:"title,omitempty"`
+	Content       string    `json:"content,omitempty"`
+	GatedFeatures string    `json:"gated_features,omitempty"`
+	StartAt       time.Time `json:"start_at"`
+	EndAt         time.Time `json:"end_at"`
+	Status        int64     `json:"status"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (w SystemNotice) ToSystemNoticeN(allows ...string) SystemNoticeN {
+	if len(allows) == 0 {
+		return SystemNoticeN{
+
+			Id:            null.IntFrom(int64(w.Id)),
+			Type:          null.StringFrom(w.Type),
+			Level:         null.StringFrom(w.Level),
+			Title:         null.StringFrom(w.Title),
+			Content:       null.StringFrom(w.Content),
+			GatedFeatures: null.StringFrom(w.GatedFeatures),
+			StartAt:       null.TimeFrom(w.StartAt),
+			EndAt:         null.TimeFrom(w.EndAt),
+			Status:        null.IntFrom(int64(w.Status)),
+			CreatedAt:     null.TimeFrom(w.CreatedAt),
+			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := SystemNoticeN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "type":
+			res.Type = null.StringFrom(w.Type)
+		case "level":
+			res.Level = null.StringFrom(w.Level)
+		case "title":
+			res.Title = null.StringFrom(w.Title)
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "gated_features":
+			res.GatedFeatures = null.StringFrom(w.GatedFeatures)
+		case "start_at":
+			res.StartAt = null.TimeFrom(w.StartAt)
+		case "end_at":
+			res.EndAt = null.TimeFrom(w.EndAt)
+		case "status":
+			res.Status = null.IntFrom(int64(w.Status))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w SystemNotice) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *SystemNoticeN) ToSystemNotice() SystemNotice {
+	return SystemNotice{
+
+		Id:            w.Id.Int64,
+		Type:          w.Type.String,
+		Level:         w.Level.String,
+		Title:         w.Title.String,
+		Content:       w.Content.String,
+		GatedFeatures: w.GatedFeatures.String,
+		StartAt:       w.StartAt.Time,
+		EndAt:         w.EndAt.Time,
+		Status:        w.Status.Int64,
+		CreatedAt:     w.CreatedAt.Time,
+		UpdatedAt:     w.UpdatedAt.Time,
+	}
+}
+
+// SystemNoticeModel is a model which encapsulates the operations of the object
+type SystemNoticeModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var systemNoticeTableName = "system_notice"
+
+// SystemNoticeTable return table name for SystemNotice
+func SystemNoticeTable() string {
+	return systemNoticeTableName
+}
+
+const (
+	FieldSystemNoticeId            = "id"
+	FieldSystemNoticeType          = "type"
+	FieldSystemNoticeLevel         = "level"
+	FieldSystemNoticeTitle         = "title"
+	FieldSystemNoticeContent       = "content"
+	FieldSystemNoticeGatedFeatures = "gated_features"
+	FieldSystemNoticeStartAt       = "start_at"
+	FieldSystemNoticeEndAt         = "end_at"
+	FieldSystemNoticeStatus        = "status"
+	FieldSystemNoticeCreatedAt     = "created_at"
+	FieldSystemNoticeUpdatedAt     = "updated_at"
+)
+
+// SystemNoticeFields return all fields in SystemNotice model
+func SystemNoticeFields() []string {
+	return []string{
+		"id",
+		"type",
+		"level",
+		"title",
+		"content",
+		"gated_features",
+		"start_at",
+		"end_at",
+		"status",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetSystemNoticeTable(tableName string) {
+	systemNoticeTableName = tableName
+}
+
+// NewSystemNoticeModel create a SystemNoticeModel
+func NewSystemNoticeModel(db query.Database) *SystemNoticeModel {
+	return &SystemNoticeModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           systemNoticeTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *SystemNoticeModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *SystemNoticeModel) clone() *SystemNoticeModel {
+	return &SystemNoticeModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *SystemNoticeModel) WithoutGlobalScopes(names ...string) *SystemNoticeModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *SystemNoticeModel) WithLocalScopes(names ...string) *SystemNoticeModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *SystemNoticeModel) Condition(builder query.SQLBuilder) *SystemNoticeModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *SystemNoticeModel) Find(ctx context.Context, id int64) (*SystemNoticeN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *SystemNoticeModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *SystemNoticeModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *SystemNoticeModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]SystemNoticeN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *SystemNoticeModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]SystemNoticeN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"type",
+			"level",
+			"title",
+			"content",
+			"gated_features",
+			"start_at",
+			"end_at",
+			"status",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "type":
+			selectFields = append(selectFields, f)
+		case "level":
+			selectFields = append(selectFields, f)
+		case "title":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "gated_features":
+			selectFields = append(selectFields, f)
+		case "start_at":
+			selectFields = append(selectFields, f)
+		case "end_at":
+			selectFields = append(selectFields, f)
+		case "status":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*SystemNoticeN, []interface{}) {
+		var systemNoticeVar SystemNoticeN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &systemNoticeVar.Id)
+			case "type":
+				scanFields = append(scanFields, &systemNoticeVar.Type)
+			case "level":
+				scanFields = append(scanFields, &systemNoticeVar.Level)
+			case "title":
+				scanFields = append(scanFields, &systemNoticeVar.Title)
+			case "content":
+				scanFields = append(scanFields, &systemNoticeVar.Content)
+			case "gated_features":
+				scanFields = append(scanFields, &systemNoticeVar.GatedFeatures)
+			case "start_at":
+				scanFields = append(scanFields, &systemNoticeVar.StartAt)
+			case "end_at":
+				scanFields = append(scanFields, &systemNoticeVar.EndAt)
+			case "status":
+				scanFields = append(scanFields, &systemNoticeVar.Status)
+			case "created_at":
+				scanFields = append(scanFields, &systemNoticeVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &systemNoticeVar.UpdatedAt)
+			}
+		}
+
+		return &systemNoticeVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	systemNotices := make([]SystemNoticeN, 0)
+	for rows.Next() {
+		systemNoticeReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		systemNoticeReal.original = &systemNoticeOriginal{}
+		_ = query.Copy(systemNoticeReal, systemNoticeReal.original)
+
+		systemNoticeReal.SetModel(m)
+		systemNotices = append(systemNotices, *systemNoticeReal)
+	}
+
+	return systemNotices, nil
+}
+
+// First return first result for given query
+func (m *SystemNoticeModel) First(ctx context.Context, builders ...query.SQLBuilder) (*SystemNoticeN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new system_notice to database
+func (m *SystemNoticeModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all system_notices to database
+func (m *SystemNoticeModel) SaveAll(ctx context.Context, systemNotices []SystemNoticeN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, systemNotice := range systemNotices {
+		id, err := m.Save(ctx, systemNotice)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a system_notice to database
+func (m *SystemNoticeModel) Save(ctx context.Context, systemNotice SystemNoticeN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, systemNotice.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new system_notice or update it when it has a id > 0
+func (m *SystemNoticeModel) SaveOrUpdate(ctx context.Context, systemNotice SystemNoticeN, onlyFields ...string) (id int64, updated bool, err error) {
+	if systemNotice.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, systemNotice.Id.Int64, systemNotice, onlyFields...)
+		return systemNotice.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, systemNotice, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *SystemNoticeModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *SystemNoticeModel) Update(ctx context.Context, builder query.SQLBuilder, systemNotice SystemNoticeN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, systemNotice.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *SystemNoticeModel) UpdateById(ctx context.Context, id int64, systemNotice SystemNoticeN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, systemNotice.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *SystemNoticeModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *SystemNoticeModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
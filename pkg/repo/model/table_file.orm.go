@@ -0,0 +1,775 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// TableFileN is a TableFile object, all fields are nullable
+type TableFileN struct {
+	original       *tableFileOriginal
+	tableFileModel *TableFileModel
+
+	Id        null.Int    `json:"id"`
+	UserId    null.Int    `json:"user_id"`
+	Name      null.String `json:"name"`
+	Url       null.String `json:"url"`
+	RowCount  null.Int    `json:"row_count"`
+	Profile   null.String `json:"profile"`
+	Data      null.String `json:"-"`
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *TableFileN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for TableFile
+func (inst *TableFileN) SetModel(tableFileModel *TableFileModel) {
+	inst.tableFileModel = tableFileModel
+}
+
+// tableFileOriginal is an object which stores original TableFile from database
+type tableFileOriginal struct {
+	Id        null.Int
+	UserId    null.Int
+	Name      null.String
+	Url       null.String
+	RowCount  null.Int
+	Profile   null.String
+	Data      null.String
+	CreatedAt null.Time
+	UpdatedAt null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *TableFileN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &tableFileOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Url != inst.original.Url {
+			return true
+		}
+		if inst.RowCount != inst.original.RowCount {
+			return true
+		}
+		if inst.Profile != inst.original.Profile {
+			return true
+		}
+		if inst.Data != inst.original.Data {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "url":
+				if inst.Url != inst.original.Url {
+					return true
+				}
+			case "row_count":
+				if inst.RowCount != inst.original.RowCount {
+					return true
+				}
+			case "profile":
+				if inst.Profile != inst.original.Profile {
+					return true
+				}
+			case "data":
+				if inst.Data != inst.original.Data {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *TableFileN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &tableFileOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Url != inst.original.Url {
+			kv["url"] = inst.Url
+		}
+		if inst.RowCount != inst.original.RowCount {
+			kv["row_count"] = inst.RowCount
+		}
+		if inst.Profile != inst.original.Profile {
+			kv["profile"] = inst.Profile
+		}
+		if inst.Data != inst.original.Data {
+			kv["data"] = inst.Data
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "url":
+				if inst.Url != inst.original.Url {
+					kv["url"] = inst.Url
+				}
+			case "row_count":
+				if inst.RowCount != inst.original.RowCount {
+					kv["row_count"] = inst.RowCount
+				}
+			case "profile":
+				if inst.Profile != inst.original.Profile {
+					kv["profile"] = inst.Profile
+				}
+			case "data":
+				if inst.Data != inst.original.Data {
+					kv["data"] = inst.Data
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *TableFileN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.tableFileModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.tableFileModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a table_file
+func (inst *TableFileN) Delete(ctx context.Context) error {
+	if inst.tableFileModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.tableFileModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *TableFileN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type tableFileScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var tableFileGlobalScopes = make([]tableFileScope, 0)
+var tableFileLocalScopes = make([]tableFileScope, 0)
+
+// AddGlobalScopeForTableFile assign a global scope to a model
+func AddGlobalScopeForTableFile(name string, apply func(builder query.Condition)) {
+	tableFileGlobalScopes = append(tableFileGlobalScopes, tableFileScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForTableFile assign a local scope to a model
+func AddLocalScopeForTableFile(name string, apply func(builder query.Condition)) {
+	tableFileLocalScopes = append(tableFileLocalScopes, tableFileScope{name: name, apply: apply})
+}
+
+func (m *TableFileModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range tableFileGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range tableFileLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *TableFileModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *TableFileModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type TableFile struct {
+	Id        int64  `json:"id"`
+	UserId    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	Url       string `json:"url"`
+	RowCount  int64  `json:"row_count"`
+	Profile   string `json:"profile"`
+	Data      string `json:"-"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w TableFile) ToTableFileN(allows ...string) TableFileN {
+	if len(allows) == 0 {
+		return TableFileN{
+
+			Id:        null.IntFrom(int64(w.Id)),
+			UserId:    null.IntFrom(int64(w.UserId)),
+			Name:      null.StringFrom(w.Name),
+			Url:       null.StringFrom(w.Url),
+			RowCount:  null.IntFrom(int64(w.RowCount)),
+			Profile:   null.StringFrom(w.Profile),
+			Data:      null.StringFrom(w.Data),
+			CreatedAt: null.TimeFrom(w.CreatedAt),
+			UpdatedAt: null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := TableFileN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "url":
+			res.Url = null.StringFrom(w.Url)
+		case "row_count":
+			res.RowCount = null.IntFrom(int64(w.RowCount))
+		case "profile":
+			res.Profile = null.StringFrom(w.Profile)
+		case "data":
+			res.Data = null.StringFrom(w.Data)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w TableFile) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *TableFileN) ToTableFile() TableFile {
+	return TableFile{
+
+		Id:        w.Id.Int64,
+		UserId:    w.UserId.Int64,
+		Name:      w.Name.String,
+		Url:       w.Url.String,
+		RowCount:  w.RowCount.Int64,
+		Profile:   w.Profile.String,
+		Data:      w.Data.String,
+		CreatedAt: w.CreatedAt.Time,
+		UpdatedAt: w.UpdatedAt.Time,
+	}
+}
+
+// TableFileModel is a model which encapsulates the operations of the object
+type TableFileModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var tableFileTableName = "table_file"
+
+// TableFileTable return table name for TableFile
+func TableFileTable() string {
+	return tableFileTableName
+}
+
+const (
+	FieldTableFileId        = "id"
+	FieldTableFileUserId    = "user_id"
+	FieldTableFileName      = "name"
+	FieldTableFileUrl       = "url"
+	FieldTableFileRowCount  = "row_count"
+	FieldTableFileProfile   = "profile"
+	FieldTableFileData      = "data"
+	FieldTableFileCreatedAt = "created_at"
+	FieldTableFileUpdatedAt = "updated_at"
+)
+
+// TableFileFields return all fields in TableFile model
+func TableFileFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"name",
+		"url",
+		"row_count",
+		"profile",
+		"data",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetTableFileTable(tableName string) {
+	tableFileTableName = tableName
+}
+
+// NewTableFileModel create a TableFileModel
+func NewTableFileModel(db query.Database) *TableFileModel {
+	return &TableFileModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           tableFileTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *TableFileModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *TableFileModel) clone() *TableFileModel {
+	return &TableFileModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *TableFileModel) WithoutGlobalScopes(names ...string) *TableFileModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *TableFileModel) WithLocalScopes(names ...string) *TableFileModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *TableFileModel) Condition(builder query.SQLBuilder) *TableFileModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *TableFileModel) Find(ctx context.Context, id int64) (*TableFileN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *TableFileModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *TableFileModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *TableFileModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]TableFileN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *TableFileModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]TableFileN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"name",
+			"url",
+			"row_count",
+			"profile",
+			"data",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "url":
+			selectFields = append(selectFields, f)
+		case "row_count":
+			selectFields = append(selectFields, f)
+		case "profile":
+			selectFields = append(selectFields, f)
+		case "data":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*TableFileN, []interface{}) {
+		var tableFileVar TableFileN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &tableFileVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &tableFileVar.UserId)
+			case "name":
+				scanFields = append(scanFields, &tableFileVar.Name)
+			case "url":
+				scanFields = append(scanFields, &tableFileVar.Url)
+			case "row_count":
+				scanFields = append(scanFields, &tableFileVar.RowCount)
+			case "profile":
+				scanFields = append(scanFields, &tableFileVar.Profile)
+			case "data":
+				scanFields = append(scanFields, &tableFileVar.Data)
+			case "created_at":
+				scanFields = append(scanFields, &tableFileVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &tableFileVar.UpdatedAt)
+			}
+		}
+
+		return &tableFileVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tableFiles := make([]TableFileN, 0)
+	for rows.Next() {
+		tableFileReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		tableFileReal.original = &tableFileOriginal{}
+		_ = query.Copy(tableFileReal, tableFileReal.original)
+
+		tableFileReal.SetModel(m)
+		tableFiles = append(tableFiles, *tableFileReal)
+	}
+
+	return tableFiles, nil
+}
+
+// First return first result for given query
+func (m *TableFileModel) First(ctx context.Context, builders ...query.SQLBuilder) (*TableFileN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new table_file to database
+func (m *TableFileModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all table_files to database
+func (m *TableFileModel) SaveAll(ctx context.Context, tableFiles []TableFileN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, tableFile := range tableFiles {
+		id, err := m.Save(ctx, tableFile)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a table_file to database
+func (m *TableFileModel) Save(ctx context.Context, tableFile TableFileN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, tableFile.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new table_file or update it when it has a id > 0
+func (m *TableFileModel) SaveOrUpdate(ctx context.Context, tableFile TableFileN, onlyFields ...string) (id int64, updated bool, err error) {
+	if tableFile.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, tableFile.Id.Int64, tableFile, onlyFields...)
+		return tableFile.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, tableFile, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *TableFileModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *TableFileModel) Update(ctx context.Context, builder query.SQLBuilder, tableFile TableFileN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, tableFile.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *TableFileModel) UpdateById(ctx context.Context, id int64, tableFile TableFileN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, tableFile.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *TableFileModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *TableFileModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,775 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// UserApiKeyUsageN is a UserApiKeyUsage object, all fields are nullable
+type UserApiKeyUsageN struct {
+	original             *userApiKeyUsageOriginal
+	userApiKeyUsageModel *UserApiKeyUsageModel
+
+	Id           null.Int    `json:"id"`
+	UserId       null.Int    `json:"user_id"`
+	ApiKeyId     null.Int    `json:"api_key_id"`
+	Endpoint     null.String `json:"endpoint"`
+	StatDate     null.Time   `json:"stat_date"`
+	RequestCount null.Int    `json:"request_count"`
+	Coins        null.Int    `json:"coins"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *UserApiKeyUsageN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for UserApiKeyUsage
+func (inst *UserApiKeyUsageN) SetModel(userApiKeyUsageModel *UserApiKeyUsageModel) {
+	inst.userApiKeyUsageModel = userApiKeyUsageModel
+}
+
+// userApiKeyUsageOriginal is an object which stores original UserApiKeyUsage from database
+type userApiKeyUsageOriginal struct {
+	Id           null.Int
+	UserId       null.Int
+	ApiKeyId     null.Int
+	Endpoint     null.String
+	StatDate     null.Time
+	RequestCount null.Int
+	Coins        null.Int
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *UserApiKeyUsageN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &userApiKeyUsageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.ApiKeyId != inst.original.ApiKeyId {
+			return true
+		}
+		if inst.Endpoint != inst.original.Endpoint {
+			return true
+		}
+		if inst.StatDate != inst.original.StatDate {
+			return true
+		}
+		if inst.RequestCount != inst.original.RequestCount {
+			return true
+		}
+		if inst.Coins != inst.original.Coins {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "api_key_id":
+				if inst.ApiKeyId != inst.original.ApiKeyId {
+					return true
+				}
+			case "endpoint":
+				if inst.Endpoint != inst.original.Endpoint {
+					return true
+				}
+			case "stat_date":
+				if inst.StatDate != inst.original.StatDate {
+					return true
+				}
+			case "request_count":
+				if inst.RequestCount != inst.original.RequestCount {
+					return true
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *UserApiKeyUsageN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &userApiKeyUsageOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.ApiKeyId != inst.original.ApiKeyId {
+			kv["api_key_id"] = inst.ApiKeyId
+		}
+		if inst.Endpoint != inst.original.Endpoint {
+			kv["endpoint"] = inst.Endpoint
+		}
+		if inst.StatDate != inst.original.StatDate {
+			kv["stat_date"] = inst.StatDate
+		}
+		if inst.RequestCount != inst.original.RequestCount {
+			kv["request_count"] = inst.RequestCount
+		}
+		if inst.Coins != inst.original.Coins {
+			kv["coins"] = inst.Coins
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "api_key_id":
+				if inst.ApiKeyId != inst.original.ApiKeyId {
+					kv["api_key_id"] = inst.ApiKeyId
+				}
+			case "endpoint":
+				if inst.Endpoint != inst.original.Endpoint {
+					kv["endpoint"] = inst.Endpoint
+				}
+			case "stat_date":
+				if inst.StatDate != inst.original.StatDate {
+					kv["stat_date"] = inst.StatDate
+				}
+			case "request_count":
+				if inst.RequestCount != inst.original.RequestCount {
+					kv["request_count"] = inst.RequestCount
+				}
+			case "coins":
+				if inst.Coins != inst.original.Coins {
+					kv["coins"] = inst.Coins
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *UserApiKeyUsageN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.userApiKeyUsageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.userApiKeyUsageModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a user_api_key_usage
+func (inst *UserApiKeyUsageN) Delete(ctx context.Context) error {
+	if inst.userApiKeyUsageModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.userApiKeyUsageModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *UserApiKeyUsageN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type userApiKeyUsageScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var userApiKeyUsageGlobalScopes = make([]userApiKeyUsageScope, 0)
+var userApiKeyUsageLocalScopes = make([]userApiKeyUsageScope, 0)
+
+// AddGlobalScopeForUserApiKeyUsage assign a global scope to a model
+func AddGlobalScopeForUserApiKeyUsage(name string, apply func(builder query.Condition)) {
+	userApiKeyUsageGlobalScopes = append(userApiKeyUsageGlobalScopes, userApiKeyUsageScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForUserApiKeyUsage assign a local scope to a model
+func AddLocalScopeForUserApiKeyUsage(name string, apply func(builder query.Condition)) {
+	userApiKeyUsageLocalScopes = append(userApiKeyUsageLocalScopes, userApiKeyUsageScope{name: name, apply: apply})
+}
+
+func (m *UserApiKeyUsageModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range userApiKeyUsageGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range userApiKeyUsageLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *UserApiKeyUsageModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *UserApiKeyUsageModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UserApiKeyUsage struct {
+	Id           int64     `json:"id"`
+	UserId       int64     `json:"user_id"`
+	ApiKeyId     int64     `json:"api_key_id"`
+	Endpoint     string    `json:"endpoint"`
+	StatDate     time.Time `json:"stat_date"`
+	RequestCount int64     `json:"request_count"`
+	Coins        int64     `json:"coins"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w UserApiKeyUsage) ToUserApiKeyUsageN(allows ...string) UserApiKeyUsageN {
+	if len(allows) == 0 {
+		return UserApiKeyUsageN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			UserId:       null.IntFrom(int64(w.UserId)),
+			ApiKeyId:     null.IntFrom(int64(w.ApiKeyId)),
+			Endpoint:     null.StringFrom(w.Endpoint),
+			StatDate:     null.TimeFrom(w.StatDate),
+			RequestCount: null.IntFrom(int64(w.RequestCount)),
+			Coins:        null.IntFrom(int64(w.Coins)),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := UserApiKeyUsageN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "api_key_id":
+			res.ApiKeyId = null.IntFrom(int64(w.ApiKeyId))
+		case "endpoint":
+			res.Endpoint = null.StringFrom(w.Endpoint)
+		case "stat_date":
+			res.StatDate = null.TimeFrom(w.StatDate)
+		case "request_count":
+			res.RequestCount = null.IntFrom(int64(w.RequestCount))
+		case "coins":
+			res.Coins = null.IntFrom(int64(w.Coins))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w UserApiKeyUsage) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *UserApiKeyUsageN) ToUserApiKeyUsage() UserApiKeyUsage {
+	return UserApiKeyUsage{
+
+		Id:           w.Id.Int64,
+		UserId:       w.UserId.Int64,
+		ApiKeyId:     w.ApiKeyId.Int64,
+		Endpoint:     w.Endpoint.String,
+		StatDate:     w.StatDate.Time,
+		RequestCount: w.RequestCount.Int64,
+		Coins:        w.Coins.Int64,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// UserApiKeyUsageModel is a model which encapsulates the operations of the object
+type UserApiKeyUsageModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var userApiKeyUsageTableName = "user_api_key_usage"
+
+// UserApiKeyUsageTable return table name for UserApiKeyUsage
+func UserApiKeyUsageTable() string {
+	return userApiKeyUsageTableName
+}
+
+const (
+	FieldUserApiKeyUsageId           = "id"
+	FieldUserApiKeyUsageUserId       = "user_id"
+	FieldUserApiKeyUsageApiKeyId     = "api_key_id"
+	FieldUserApiKeyUsageEndpoint     = "endpoint"
+	FieldUserApiKeyUsageStatDate     = "stat_date"
+	FieldUserApiKeyUsageRequestCount = "request_count"
+	FieldUserApiKeyUsageCoins        = "coins"
+	FieldUserApiKeyUsageCreatedAt    = "created_at"
+	FieldUserApiKeyUsageUpdatedAt    = "updated_at"
+)
+
+// UserApiKeyUsageFields return all fields in UserApiKeyUsage model
+func UserApiKeyUsageFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"api_key_id",
+		"endpoint",
+		"stat_date",
+		"request_count",
+		"coins",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetUserApiKeyUsageTable(tableName string) {
+	userApiKeyUsageTableName = tableName
+}
+
+// NewUserApiKeyUsageModel create a UserApiKeyUsageModel
+func NewUserApiKeyUsageModel(db query.Database) *UserApiKeyUsageModel {
+	return &UserApiKeyUsageModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           userApiKeyUsageTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *UserApiKeyUsageModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *UserApiKeyUsageModel) clone() *UserApiKeyUsageModel {
+	return &UserApiKeyUsageModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *UserApiKeyUsageModel) WithoutGlobalScopes(names ...string) *UserApiKeyUsageModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *UserApiKeyUsageModel) WithLocalScopes(names ...string) *UserApiKeyUsageModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *UserApiKeyUsageModel) Condition(builder query.SQLBuilder) *UserApiKeyUsageModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *UserApiKeyUsageModel) Find(ctx context.Context, id int64) (*UserApiKeyUsageN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *UserApiKeyUsageModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *UserApiKeyUsageModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *UserApiKeyUsageModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]UserApiKeyUsageN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *UserApiKeyUsageModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]UserApiKeyUsageN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"api_key_id",
+			"endpoint",
+			"stat_date",
+			"request_count",
+			"coins",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "api_key_id":
+			selectFields = append(selectFields, f)
+		case "endpoint":
+			selectFields = append(selectFields, f)
+		case "stat_date":
+			selectFields = append(selectFields, f)
+		case "request_count":
+			selectFields = append(selectFields, f)
+		case "coins":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*UserApiKeyUsageN, []interface{}) {
+		var userApiKeyUsageVar UserApiKeyUsageN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &userApiKeyUsageVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &userApiKeyUsageVar.UserId)
+			case "api_key_id":
+				scanFields = append(scanFields, &userApiKeyUsageVar.ApiKeyId)
+			case "endpoint":
+				scanFields = append(scanFields, &userApiKeyUsageVar.Endpoint)
+			case "stat_date":
+				scanFields = append(scanFields, &userApiKeyUsageVar.StatDate)
+			case "request_count":
+				scanFields = append(scanFields, &userApiKeyUsageVar.RequestCount)
+			case "coins":
+				scanFields = append(scanFields, &userApiKeyUsageVar.Coins)
+			case "created_at":
+				scanFields = append(scanFields, &userApiKeyUsageVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &userApiKeyUsageVar.UpdatedAt)
+			}
+		}
+
+		return &userApiKeyUsageVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userApiKeyUsages := make([]UserApiKeyUsageN, 0)
+	for rows.Next() {
+		userApiKeyUsageReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		userApiKeyUsageReal.original = &userApiKeyUsageOriginal{}
+		_ = query.Copy(userApiKeyUsageReal, userApiKeyUsageReal.original)
+
+		userApiKeyUsageReal.SetModel(m)
+		userApiKeyUsages = append(userApiKeyUsages, *userApiKeyUsageReal)
+	}
+
+	return userApiKeyUsages, nil
+}
+
+// First return first result for given query
+func (m *UserApiKeyUsageModel) First(ctx context.Context, builders ...query.SQLBuilder) (*UserApiKeyUsageN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new user_api_key_usage to database
+func (m *UserApiKeyUsageModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all user_api_key_usages to database
+func (m *UserApiKeyUsageModel) SaveAll(ctx context.Context, userApiKeyUsages []UserApiKeyUsageN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, userApiKeyUsage := range userApiKeyUsages {
+		id, err := m.Save(ctx, userApiKeyUsage)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a user_api_key_usage to database
+func (m *UserApiKeyUsageModel) Save(ctx context.Context, userApiKeyUsage UserApiKeyUsageN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, userApiKeyUsage.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new user_api_key_usage or update it when it has a id > 0
+func (m *UserApiKeyUsageModel) SaveOrUpdate(ctx context.Context, userApiKeyUsage UserApiKeyUsageN, onlyFields ...string) (id int64, updated bool, err error) {
+	if userApiKeyUsage.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, userApiKeyUsage.Id.Int64, userApiKeyUsage, onlyFields...)
+		return userApiKeyUsage.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, userApiKeyUsage, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *UserApiKeyUsageModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *UserApiKeyUsageModel) Update(ctx context.Context, builder query.SQLBuilder, userApiKeyUsage UserApiKeyUsageN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, userApiKeyUsage.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *UserApiKeyUsageModel) UpdateById(ctx context.Context, id int64, userApiKeyUsage UserApiKeyUsageN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, userApiKeyUsage.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *UserApiKeyUsageModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *UserApiKeyUsageModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
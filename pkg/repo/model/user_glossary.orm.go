@@ -0,0 +1,719 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// UserGlossaryN is a UserGlossary object, all fields are nullable
+type UserGlossaryN struct {
+	original          *userGlossaryOriginal
+	userGlossaryModel *UserGlossaryModel
+
+	Id          null.Int    `json:"id"`
+	UserId      null.Int    `json:"user_id"`
+	Term        null.String `json:"term"`
+	Replacement null.String `json:"replacement"`
+	Note        null.String `json:"note"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *UserGlossaryN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for UserGlossary
+func (inst *UserGlossaryN) SetModel(userGlossaryModel *UserGlossaryModel) {
+	inst.userGlossaryModel = userGlossaryModel
+}
+
+// userGlossaryOriginal is an object which stores original UserGlossary from database
+type userGlossaryOriginal struct {
+	Id          null.Int
+	UserId      null.Int
+	Term        null.String
+	Replacement null.String
+	Note        null.String
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *UserGlossaryN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &userGlossaryOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Term != inst.original.Term {
+			return true
+		}
+		if inst.Replacement != inst.original.Replacement {
+			return true
+		}
+		if inst.Note != inst.original.Note {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "term":
+				if inst.Term != inst.original.Term {
+					return true
+				}
+			case "replacement":
+				if inst.Replacement != inst.original.Replacement {
+					return true
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *UserGlossaryN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &userGlossaryOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Term != inst.original.Term {
+			kv["term"] = inst.Term
+		}
+		if inst.Replacement != inst.original.Replacement {
+			kv["replacement"] = inst.Replacement
+		}
+		if inst.Note != inst.original.Note {
+			kv["note"] = inst.Note
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "term":
+				if inst.Term != inst.original.Term {
+					kv["term"] = inst.Term
+				}
+			case "replacement":
+				if inst.Replacement != inst.original.Replacement {
+					kv["replacement"] = inst.Replacement
+				}
+			case "note":
+				if inst.Note != inst.original.Note {
+					kv["note"] = inst.Note
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *UserGlossaryN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.userGlossaryModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.userGlossaryModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a user_glossary
+func (inst *UserGlossaryN) Delete(ctx context.Context) error {
+	if inst.userGlossaryModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.userGlossaryModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *UserGlossaryN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type userGlossaryScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var userGlossaryGlobalScopes = make([]userGlossaryScope, 0)
+var userGlossaryLocalScopes = make([]userGlossaryScope, 0)
+
+// AddGlobalScopeForUserGlossary assign a global scope to a model
+func AddGlobalScopeForUserGlossary(name string, apply func(builder query.Condition)) {
+	userGlossaryGlobalScopes = append(userGlossaryGlobalScopes, userGlossaryScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForUserGlossary assign a local scope to a model
+func AddLocalScopeForUserGlossary(name string, apply func(builder query.Condition)) {
+	userGlossaryLocalScopes = append(userGlossaryLocalScopes, userGlossaryScope{name: name, apply: apply})
+}
+
+func (m *UserGlossaryModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range userGlossaryGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range userGlossaryLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *UserGlossaryModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *UserGlossaryModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UserGlossary struct {
+	Id          int64  `json:"id"`
+	UserId      int64  `json:"user_id"`
+	Term        string `json:"term"`
+	Replacement string `json:"replacement"`
+	Note        string `json:"note"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w UserGlossary) ToUserGlossaryN(allows ...string) UserGlossaryN {
+	if len(allows) == 0 {
+		return UserGlossaryN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			UserId:      null.IntFrom(int64(w.UserId)),
+			Term:        null.StringFrom(w.Term),
+			Replacement: null.StringFrom(w.Replacement),
+			Note:        null.StringFrom(w.Note),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := UserGlossaryN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "term":
+			res.Term = null.StringFrom(w.Term)
+		case "replacement":
+			res.Replacement = null.StringFrom(w.Replacement)
+		case "note":
+			res.Note = null.StringFrom(w.Note)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w UserGlossary) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *UserGlossaryN) ToUserGlossary() UserGlossary {
+	return UserGlossary{
+
+		Id:          w.Id.Int64,
+		UserId:      w.UserId.Int64,
+		Term:        w.Term.String,
+		Replacement: w.Replacement.String,
+		Note:        w.Note.String,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// UserGlossaryModel is a model which encapsulates the operations of the object
+type UserGlossaryModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var userGlossaryTableName = "user_glossary"
+
+// UserGlossaryTable return table name for UserGlossary
+func UserGlossaryTable() string {
+	return userGlossaryTableName
+}
+
+const (
+	FieldUserGlossaryId          = "id"
+	FieldUserGlossaryUserId      = "user_id"
+	FieldUserGlossaryTerm        = "term"
+	FieldUserGlossaryReplacement = "replacement"
+	FieldUserGlossaryNote        = "note"
+	FieldUserGlossaryCreatedAt   = "created_at"
+	FieldUserGlossaryUpdatedAt   = "updated_at"
+)
+
+// UserGlossaryFields return all fields in UserGlossary model
+func UserGlossaryFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"term",
+		"replacement",
+		"note",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetUserGlossaryTable(tableName string) {
+	userGlossaryTableName = tableName
+}
+
+// NewUserGlossaryModel create a UserGlossaryModel
+func NewUserGlossaryModel(db query.Database) *UserGlossaryModel {
+	return &UserGlossaryModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           userGlossaryTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *UserGlossaryModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *UserGlossaryModel) clone() *UserGlossaryModel {
+	return &UserGlossaryModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *UserGlossaryModel) WithoutGlobalScopes(names ...string) *UserGlossaryModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *UserGlossaryModel) WithLocalScopes(names ...string) *UserGlossaryModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *UserGlossaryModel) Condition(builder query.SQLBuilder) *UserGlossaryModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *UserGlossaryModel) Find(ctx context.Context, id int64) (*UserGlossaryN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *UserGlossaryModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *UserGlossaryModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *UserGlossaryModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]UserGlossaryN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *UserGlossaryModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]UserGlossaryN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"term",
+			"replacement",
+			"note",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "term":
+			selectFields = append(selectFields, f)
+		case "replacement":
+			selectFields = append(selectFields, f)
+		case "note":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*UserGlossaryN, []interface{}) {
+		var userGlossaryVar UserGlossaryN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &userGlossaryVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &userGlossaryVar.UserId)
+			case "term":
+				scanFields = append(scanFields, &userGlossaryVar.Term)
+			case "replacement":
+				scanFields = append(scanFields, &userGlossaryVar.Replacement)
+			case "note":
+				scanFields = append(scanFields, &userGlossaryVar.Note)
+			case "created_at":
+				scanFields = append(scanFields, &userGlossaryVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &userGlossaryVar.UpdatedAt)
+			}
+		}
+
+		return &userGlossaryVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userGlossarys := make([]UserGlossaryN, 0)
+	for rows.Next() {
+		userGlossaryReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		userGlossaryReal.original = &userGlossaryOriginal{}
+		_ = query.Copy(userGlossaryReal, userGlossaryReal.original)
+
+		userGlossaryReal.SetModel(m)
+		userGlossarys = append(userGlossarys, *userGlossaryReal)
+	}
+
+	return userGlossarys, nil
+}
+
+// First return first result for given query
+func (m *UserGlossaryModel) First(ctx context.Context, builders ...query.SQLBuilder) (*UserGlossaryN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new user_glossary to database
+func (m *UserGlossaryModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all user_glossarys to database
+func (m *UserGlossaryModel) SaveAll(ctx context.Context, userGlossarys []UserGlossaryN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, userGlossary := range userGlossarys {
+		id, err := m.Save(ctx, userGlossary)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a user_glossary to database
+func (m *UserGlossaryModel) Save(ctx context.Context, userGlossary UserGlossaryN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, userGlossary.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new user_glossary or update it when it has a id > 0
+func (m *UserGlossaryModel) SaveOrUpdate(ctx context.Context, userGlossary UserGlossaryN, onlyFields ...string) (id int64, updated bool, err error) {
+	if userGlossary.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, userGlossary.Id.Int64, userGlossary, onlyFields...)
+		return userGlossary.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, userGlossary, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *UserGlossaryModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *UserGlossaryModel) Update(ctx context.Context, builder query.SQLBuilder, userGlossary UserGlossaryN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, userGlossary.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *UserGlossaryModel) UpdateById(ctx context.Context, id int64, userGlossary UserGlossaryN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, userGlossary.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *UserGlossaryModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *UserGlossaryModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
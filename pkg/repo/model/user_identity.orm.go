@@ -0,0 +1,747 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// UserIdentityN is a UserIdentity object, all fields are nullable
+type UserIdentityN struct {
+	original          *userIdentityOriginal
+	userIdentityModel *UserIdentityModel
+
+	Id          null.Int    `json:"id"`
+	UserId      null.Int    `json:"user_id"`
+	Provider    null.String `json:"provider"`
+	Subject     null.String `json:"subject"`
+	Email       null.String `json:"email"`
+	LastLoginAt null.Time   `json:"last_login_at"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *UserIdentityN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for UserIdentity
+func (inst *UserIdentityN) SetModel(userIdentityModel *UserIdentityModel) {
+	inst.userIdentityModel = userIdentityModel
+}
+
+// userIdentityOriginal is an object which stores original UserIdentity from database
+type userIdentityOriginal struct {
+	Id          null.Int
+	UserId      null.Int
+	Provider    null.String
+	Subject     null.String
+	Email       null.String
+	LastLoginAt null.Time
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *UserIdentityN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &userIdentityOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Provider != inst.original.Provider {
+			return true
+		}
+		if inst.Subject != inst.original.Subject {
+			return true
+		}
+		if inst.Email != inst.original.Email {
+			return true
+		}
+		if inst.LastLoginAt != inst.original.LastLoginAt {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "provider":
+				if inst.Provider != inst.original.Provider {
+					return true
+				}
+			case "subject":
+				if inst.Subject != inst.original.Subject {
+					return true
+				}
+			case "email":
+				if inst.Email != inst.original.Email {
+					return true
+				}
+			case "last_login_at":
+				if inst.LastLoginAt != inst.original.LastLoginAt {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *UserIdentityN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &userIdentityOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Provider != inst.original.Provider {
+			kv["provider"] = inst.Provider
+		}
+		if inst.Subject != inst.original.Subject {
+			kv["subject"] = inst.Subject
+		}
+		if inst.Email != inst.original.Email {
+			kv["email"] = inst.Email
+		}
+		if inst.LastLoginAt != inst.original.LastLoginAt {
+			kv["last_login_at"] = inst.LastLoginAt
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "provider":
+				if inst.Provider != inst.original.Provider {
+					kv["provider"] = inst.Provider
+				}
+			case "subject":
+				if inst.Subject != inst.original.Subject {
+					kv["subject"] = inst.Subject
+				}
+			case "email":
+				if inst.Email != inst.original.Email {
+					kv["email"] = inst.Email
+				}
+			case "last_login_at":
+				if inst.LastLoginAt != inst.original.LastLoginAt {
+					kv["last_login_at"] = inst.LastLoginAt
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *UserIdentityN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.userIdentityModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.userIdentityModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a user_identity
+func (inst *UserIdentityN) Delete(ctx context.Context) error {
+	if inst.userIdentityModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.userIdentityModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *UserIdentityN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type userIdentityScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var userIdentityGlobalScopes = make([]userIdentityScope, 0)
+var userIdentityLocalScopes = make([]userIdentityScope, 0)
+
+// AddGlobalScopeForUserIdentity assign a global scope to a model
+func AddGlobalScopeForUserIdentity(name string, apply func(builder query.Condition)) {
+	userIdentityGlobalScopes = append(userIdentityGlobalScopes, userIdentityScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForUserIdentity assign a local scope to a model
+func AddLocalScopeForUserIdentity(name string, apply func(builder query.Condition)) {
+	userIdentityLocalScopes = append(userIdentityLocalScopes, userIdentityScope{name: name, apply: apply})
+}
+
+func (m *UserIdentityModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range userIdentityGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range userIdentityLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *UserIdentityModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *UserIdentityModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UserIdentity struct {
+	Id          int64     `json:"id"`
+	UserId      int64     `json:"user_id"`
+	Provider    string    `json:"provider"`
+	Subject     string    `json:"subject"`
+	Email       string    `json:"email"`
+	LastLoginAt time.Time `json:"last_login_at"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w UserIdentity) ToUserIdentityN(allows ...string) UserIdentityN {
+	if len(allows) == 0 {
+		return UserIdentityN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			UserId:      null.IntFrom(int64(w.UserId)),
+			Provider:    null.StringFrom(w.Provider),
+			Subject:     null.StringFrom(w.Subject),
+			Email:       null.StringFrom(w.Email),
+			LastLoginAt: null.TimeFrom(w.LastLoginAt),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := UserIdentityN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "provider":
+			res.Provider = null.StringFrom(w.Provider)
+		case "subject":
+			res.Subject = null.StringFrom(w.Subject)
+		case "email":
+			res.Email = null.StringFrom(w.Email)
+		case "last_login_at":
+			res.LastLoginAt = null.TimeFrom(w.LastLoginAt)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w UserIdentity) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *UserIdentityN) ToUserIdentity() UserIdentity {
+	return UserIdentity{
+
+		Id:          w.Id.Int64,
+		UserId:      w.UserId.Int64,
+		Provider:    w.Provider.String,
+		Subject:     w.Subject.String,
+		Email:       w.Email.String,
+		LastLoginAt: w.LastLoginAt.Time,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// UserIdentityModel is a model which encapsulates the operations of the object
+type UserIdentityModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var userIdentityTableName = "user_identity"
+
+// UserIdentityTable return table name for UserIdentity
+func UserIdentityTable() string {
+	return userIdentityTableName
+}
+
+const (
+	FieldUserIdentityId          = "id"
+	FieldUserIdentityUserId      = "user_id"
+	FieldUserIdentityProvider    = "provider"
+	FieldUserIdentitySubject     = "subject"
+	FieldUserIdentityEmail       = "email"
+	FieldUserIdentityLastLoginAt = "last_login_at"
+	FieldUserIdentityCreatedAt   = "created_at"
+	FieldUserIdentityUpdatedAt   = "updated_at"
+)
+
+// UserIdentityFields return all fields in UserIdentity model
+func UserIdentityFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"provider",
+		"subject",
+		"email",
+		"last_login_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetUserIdentityTable(tableName string) {
+	userIdentityTableName = tableName
+}
+
+// NewUserIdentityModel create a UserIdentityModel
+func NewUserIdentityModel(db query.Database) *UserIdentityModel {
+	return &UserIdentityModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           userIdentityTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *UserIdentityModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *UserIdentityModel) clone() *UserIdentityModel {
+	return &UserIdentityModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *UserIdentityModel) WithoutGlobalScopes(names ...string) *UserIdentityModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *UserIdentityModel) WithLocalScopes(names ...string) *UserIdentityModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *UserIdentityModel) Condition(builder query.SQLBuilder) *UserIdentityModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *UserIdentityModel) Find(ctx context.Context, id int64) (*UserIdentityN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *UserIdentityModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *UserIdentityModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *UserIdentityModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]UserIdentityN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *UserIdentityModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]UserIdentityN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"provider",
+			"subject",
+			"email",
+			"last_login_at",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "provider":
+			selectFields = append(selectFields, f)
+		case "subject":
+			selectFields = append(selectFields, f)
+		case "email":
+			selectFields = append(selectFields, f)
+		case "last_login_at":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*UserIdentityN, []interface{}) {
+		var userIdentityVar UserIdentityN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &userIdentityVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &userIdentityVar.UserId)
+			case "provider":
+				scanFields = append(scanFields, &userIdentityVar.Provider)
+			case "subject":
+				scanFields = append(scanFields, &userIdentityVar.Subject)
+			case "email":
+				scanFields = append(scanFields, &userIdentityVar.Email)
+			case "last_login_at":
+				scanFields = append(scanFields, &userIdentityVar.LastLoginAt)
+			case "created_at":
+				scanFields = append(scanFields, &userIdentityVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &userIdentityVar.UpdatedAt)
+			}
+		}
+
+		return &userIdentityVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userIdentitys := make([]UserIdentityN, 0)
+	for rows.Next() {
+		userIdentityReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		userIdentityReal.original = &userIdentityOriginal{}
+		_ = query.Copy(userIdentityReal, userIdentityReal.original)
+
+		userIdentityReal.SetModel(m)
+		userIdentitys = append(userIdentitys, *userIdentityReal)
+	}
+
+	return userIdentitys, nil
+}
+
+// First return first result for given query
+func (m *UserIdentityModel) First(ctx context.Context, builders ...query.SQLBuilder) (*UserIdentityN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new user_identity to database
+func (m *UserIdentityModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all user_identitys to database
+func (m *UserIdentityModel) SaveAll(ctx context.Context, userIdentitys []UserIdentityN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, userIdentity := range userIdentitys {
+		id, err := m.Save(ctx, userIdentity)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a user_identity to database
+func (m *UserIdentityModel) Save(ctx context.Context, userIdentity UserIdentityN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, userIdentity.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new user_identity or update it when it has a id > 0
+func (m *UserIdentityModel) SaveOrUpdate(ctx context.Context, userIdentity UserIdentityN, onlyFields ...string) (id int64, updated bool, err error) {
+	if userIdentity.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, userIdentity.Id.Int64, userIdentity, onlyFields...)
+		return userIdentity.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, userIdentity, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *UserIdentityModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *UserIdentityModel) Update(ctx context.Context, builder query.SQLBuilder, userIdentity UserIdentityN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, userIdentity.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *UserIdentityModel) UpdateById(ctx context.Context, id int64, userIdentity UserIdentityN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, userIdentity.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *UserIdentityModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *UserIdentityModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
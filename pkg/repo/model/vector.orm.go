@@ -0,0 +1,1506 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// VectorCollectionN is a VectorCollection object, all fields are nullable
+type VectorCollectionN struct {
+	original              *vectorCollectionOriginal
+	vectorCollectionModel *VectorCollectionModel
+
+	Id          null.Int    `json:"id"`
+	UserId      null.Int    `json:"user_id"`
+	Name        null.String `json:"name"`
+	Description null.String `json:"description,omitempty"`
+	Model       null.String `json:"model"`
+	Dimension   null.Int    `json:"dimension"`
+	DocCount    null.Int    `json:"doc_count"`
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *VectorCollectionN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for VectorCollection
+func (inst *VectorCollectionN) SetModel(vectorCollectionModel *VectorCollectionModel) {
+	inst.vectorCollectionModel = vectorCollectionModel
+}
+
+// vectorCollectionOriginal is an object which stores original VectorCollection from database
+type vectorCollectionOriginal struct {
+	Id          null.Int
+	UserId      null.Int
+	Name        null.String
+	Description null.String
+	Model       null.String
+	Dimension   null.Int
+	DocCount    null.Int
+	CreatedAt   null.Time
+	UpdatedAt   null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *VectorCollectionN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &vectorCollectionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Name != inst.original.Name {
+			return true
+		}
+		if inst.Description != inst.original.Description {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.Dimension != inst.original.Dimension {
+			return true
+		}
+		if inst.DocCount != inst.original.DocCount {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					return true
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "dimension":
+				if inst.Dimension != inst.original.Dimension {
+					return true
+				}
+			case "doc_count":
+				if inst.DocCount != inst.original.DocCount {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *VectorCollectionN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &vectorCollectionOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Name != inst.original.Name {
+			kv["name"] = inst.Name
+		}
+		if inst.Description != inst.original.Description {
+			kv["description"] = inst.Description
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.Dimension != inst.original.Dimension {
+			kv["dimension"] = inst.Dimension
+		}
+		if inst.DocCount != inst.original.DocCount {
+			kv["doc_count"] = inst.DocCount
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "name":
+				if inst.Name != inst.original.Name {
+					kv["name"] = inst.Name
+				}
+			case "description":
+				if inst.Description != inst.original.Description {
+					kv["description"] = inst.Description
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "dimension":
+				if inst.Dimension != inst.original.Dimension {
+					kv["dimension"] = inst.Dimension
+				}
+			case "doc_count":
+				if inst.DocCount != inst.original.DocCount {
+					kv["doc_count"] = inst.DocCount
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *VectorCollectionN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.vectorCollectionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.vectorCollectionModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a vector_collection
+func (inst *VectorCollectionN) Delete(ctx context.Context) error {
+	if inst.vectorCollectionModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.vectorCollectionModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *VectorCollectionN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type vectorCollectionScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var vectorCollectionGlobalScopes = make([]vectorCollectionScope, 0)
+var vectorCollectionLocalScopes = make([]vectorCollectionScope, 0)
+
+// AddGlobalScopeForVectorCollection assign a global scope to a model
+func AddGlobalScopeForVectorCollection(name string, apply func(builder query.Condition)) {
+	vectorCollectionGlobalScopes = append(vectorCollectionGlobalScopes, vectorCollectionScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForVectorCollection assign a local scope to a model
+func AddLocalScopeForVectorCollection(name string, apply func(builder query.Condition)) {
+	vectorCollectionLocalScopes = append(vectorCollectionLocalScopes, vectorCollectionScope{name: name, apply: apply})
+}
+
+func (m *VectorCollectionModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range vectorCollectionGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range vectorCollectionLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *VectorCollectionModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *VectorCollectionModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type VectorCollection struct {
+	Id          int64  `json:"id"`
+	UserId      int64  `json:"user_id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Model       string `json:"model"`
+	Dimension   int64  `json:"dimension"`
+	DocCount    int64  `json:"doc_count"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (w VectorCollection) ToVectorCollectionN(allows ...string) VectorCollectionN {
+	if len(allows) == 0 {
+		return VectorCollectionN{
+
+			Id:          null.IntFrom(int64(w.Id)),
+			UserId:      null.IntFrom(int64(w.UserId)),
+			Name:        null.StringFrom(w.Name),
+			Description: null.StringFrom(w.Description),
+			Model:       null.StringFrom(w.Model),
+			Dimension:   null.IntFrom(int64(w.Dimension)),
+			DocCount:    null.IntFrom(int64(w.DocCount)),
+			CreatedAt:   null.TimeFrom(w.CreatedAt),
+			UpdatedAt:   null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := VectorCollectionN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "name":
+			res.Name = null.StringFrom(w.Name)
+		case "description":
+			res.Description = null.StringFrom(w.Description)
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "dimension":
+			res.Dimension = null.IntFrom(int64(w.Dimension))
+		case "doc_count":
+			res.DocCount = null.IntFrom(int64(w.DocCount))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w VectorCollection) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *VectorCollectionN) ToVectorCollection() VectorCollection {
+	return VectorCollection{
+
+		Id:          w.Id.Int64,
+		UserId:      w.UserId.Int64,
+		Name:        w.Name.String,
+		Description: w.Description.String,
+		Model:       w.Model.String,
+		Dimension:   w.Dimension.Int64,
+		DocCount:    w.DocCount.Int64,
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+// VectorCollectionModel is a model which encapsulates the operations of the object
+type VectorCollectionModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var vectorCollectionTableName = "vector_collection"
+
+// VectorCollectionTable return table name for VectorCollection
+func VectorCollectionTable() string {
+	return vectorCollectionTableName
+}
+
+const (
+	FieldVectorCollectionId          = "id"
+	FieldVectorCollectionUserId      = "user_id"
+	FieldVectorCollectionName        = "name"
+	FieldVectorCollectionDescription = "description"
+	FieldVectorCollectionModel       = "model"
+	FieldVectorCollectionDimension   = "dimension"
+	FieldVectorCollectionDocCount    = "doc_count"
+	FieldVectorCollectionCreatedAt   = "created_at"
+	FieldVectorCollectionUpdatedAt   = "updated_at"
+)
+
+// VectorCollectionFields return all fields in VectorCollection model
+func VectorCollectionFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"name",
+		"description",
+		"model",
+		"dimension",
+		"doc_count",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetVectorCollectionTable(tableName string) {
+	vectorCollectionTableName = tableName
+}
+
+// NewVectorCollectionModel create a VectorCollectionModel
+func NewVectorCollectionModel(db query.Database) *VectorCollectionModel {
+	return &VectorCollectionModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           vectorCollectionTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *VectorCollectionModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *VectorCollectionModel) clone() *VectorCollectionModel {
+	return &VectorCollectionModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *VectorCollectionModel) WithoutGlobalScopes(names ...string) *VectorCollectionModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *VectorCollectionModel) WithLocalScopes(names ...string) *VectorCollectionModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *VectorCollectionModel) Condition(builder query.SQLBuilder) *VectorCollectionModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *VectorCollectionModel) Find(ctx context.Context, id int64) (*VectorCollectionN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *VectorCollectionModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *VectorCollectionModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *VectorCollectionModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]VectorCollectionN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *VectorCollectionModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]VectorCollectionN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"name",
+			"description",
+			"model",
+			"dimension",
+			"doc_count",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "name":
+			selectFields = append(selectFields, f)
+		case "description":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "dimension":
+			selectFields = append(selectFields, f)
+		case "doc_count":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*VectorCollectionN, []interface{}) {
+		var vectorCollectionVar VectorCollectionN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &vectorCollectionVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &vectorCollectionVar.UserId)
+			case "name":
+				scanFields = append(scanFields, &vectorCollectionVar.Name)
+			case "description":
+				scanFields = append(scanFields, &vectorCollectionVar.Description)
+			case "model":
+				scanFields = append(scanFields, &vectorCollectionVar.Model)
+			case "dimension":
+				scanFields = append(scanFields, &vectorCollectionVar.Dimension)
+			case "doc_count":
+				scanFields = append(scanFields, &vectorCollectionVar.DocCount)
+			case "created_at":
+				scanFields = append(scanFields, &vectorCollectionVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &vectorCollectionVar.UpdatedAt)
+			}
+		}
+
+		return &vectorCollectionVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	vectorCollections := make([]VectorCollectionN, 0)
+	for rows.Next() {
+		vectorCollectionReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		vectorCollectionReal.original = &vectorCollectionOriginal{}
+		_ = query.Copy(vectorCollectionReal, vectorCollectionReal.original)
+
+		vectorCollectionReal.SetModel(m)
+		vectorCollections = append(vectorCollections, *vectorCollectionReal)
+	}
+
+	return vectorCollections, nil
+}
+
+// First return first result for given query
+func (m *VectorCollectionModel) First(ctx context.Context, builders ...query.SQLBuilder) (*VectorCollectionN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new vector_collection to database
+func (m *VectorCollectionModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all vector_collections to database
+func (m *VectorCollectionModel) SaveAll(ctx context.Context, vectorCollections []VectorCollectionN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, vectorCollection := range vectorCollections {
+		id, err := m.Save(ctx, vectorCollection)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a vector_collection to database
+func (m *VectorCollectionModel) Save(ctx context.Context, vectorCollection VectorCollectionN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, vectorCollection.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new vector_collection or update it when it has a id > 0
+func (m *VectorCollectionModel) SaveOrUpdate(ctx context.Context, vectorCollection VectorCollectionN, onlyFields ...string) (id int64, updated bool, err error) {
+	if vectorCollection.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, vectorCollection.Id.Int64, vectorCollection, onlyFields...)
+		return vectorCollection.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, vectorCollection, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *VectorCollectionModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *VectorCollectionModel) Update(ctx context.Context, builder query.SQLBuilder, vectorCollection VectorCollectionN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, vectorCollection.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *VectorCollectionModel) UpdateById(ctx context.Context, id int64, vectorCollection VectorCollectionN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, vectorCollection.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *VectorCollectionModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *VectorCollectionModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
+
+// VectorDocumentN is a VectorDocument object, all fields are nullable
+type VectorDocumentN struct {
+	original            *vectorDocumentOriginal
+	vectorDocumentModel *VectorDocumentModel
+
+	Id           null.Int    `json:"id"`
+	CollectionId null.Int    `json:"collection_id"`
+	UserId       null.Int    `json:"user_id"`
+	Content      null.String `json:"content"`
+	Metadata     null.String `json:"metadata,omitempty"`
+	Vector       null.String `json:"-"`
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *VectorDocumentN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for VectorDocument
+func (inst *VectorDocumentN) SetModel(vectorDocumentModel *VectorDocumentModel) {
+	inst.vectorDocumentModel = vectorDocumentModel
+}
+
+// vectorDocumentOriginal is an object which stores original VectorDocument from database
+type vectorDocumentOriginal struct {
+	Id           null.Int
+	CollectionId null.Int
+	UserId       null.Int
+	Content      null.String
+	Metadata     null.String
+	Vector       null.String
+	CreatedAt    null.Time
+	UpdatedAt    null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *VectorDocumentN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &vectorDocumentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.CollectionId != inst.original.CollectionId {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Content != inst.original.Content {
+			return true
+		}
+		if inst.Metadata != inst.original.Metadata {
+			return true
+		}
+		if inst.Vector != inst.original.Vector {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "collection_id":
+				if inst.CollectionId != inst.original.CollectionId {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					return true
+				}
+			case "metadata":
+				if inst.Metadata != inst.original.Metadata {
+					return true
+				}
+			case "vector":
+				if inst.Vector != inst.original.Vector {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *VectorDocumentN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &vectorDocumentOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.CollectionId != inst.original.CollectionId {
+			kv["collection_id"] = inst.CollectionId
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Content != inst.original.Content {
+			kv["content"] = inst.Content
+		}
+		if inst.Metadata != inst.original.Metadata {
+			kv["metadata"] = inst.Metadata
+		}
+		if inst.Vector != inst.original.Vector {
+			kv["vector"] = inst.Vector
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "collection_id":
+				if inst.CollectionId != inst.original.CollectionId {
+					kv["collection_id"] = inst.CollectionId
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "content":
+				if inst.Content != inst.original.Content {
+					kv["content"] = inst.Content
+				}
+			case "metadata":
+				if inst.Metadata != inst.original.Metadata {
+					kv["metadata"] = inst.Metadata
+				}
+			case "vector":
+				if inst.Vector != inst.original.Vector {
+					kv["vector"] = inst.Vector
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *VectorDocumentN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.vectorDocumentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.vectorDocumentModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a vector_document
+func (inst *VectorDocumentN) Delete(ctx context.Context) error {
+	if inst.vectorDocumentModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.vectorDocumentModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *VectorDocumentN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type vectorDocumentScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var vectorDocumentGlobalScopes = make([]vectorDocumentScope, 0)
+var vectorDocumentLocalScopes = make([]vectorDocumentScope, 0)
+
+// AddGlobalScopeForVectorDocument assign a global scope to a model
+func AddGlobalScopeForVectorDocument(name string, apply func(builder query.Condition)) {
+	vectorDocumentGlobalScopes = append(vectorDocumentGlobalScopes, vectorDocumentScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForVectorDocument assign a local scope to a model
+func AddLocalScopeForVectorDocument(name string, apply func(builder query.Condition)) {
+	vectorDocumentLocalScopes = append(vectorDocumentLocalScopes, vectorDocumentScope{name: name, apply: apply})
+}
+
+func (m *VectorDocumentModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range vectorDocumentGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range vectorDocumentLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *VectorDocumentModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *VectorDocumentModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type VectorDocument struct {
+	Id           int64  `json:"id"`
+	CollectionId int64  `json:"collection_id"`
+	UserId       int64  `json:"user_id"`
+	Content      string `json:"content"`
+	Metadata     string `json:"metadata,omitempty"`
+	Vector       string `json:"-"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (w VectorDocument) ToVectorDocumentN(allows ...string) VectorDocumentN {
+	if len(allows) == 0 {
+		return VectorDocumentN{
+
+			Id:           null.IntFrom(int64(w.Id)),
+			CollectionId: null.IntFrom(int64(w.CollectionId)),
+			UserId:       null.IntFrom(int64(w.UserId)),
+			Content:      null.StringFrom(w.Content),
+			Metadata:     null.StringFrom(w.Metadata),
+			Vector:       null.StringFrom(w.Vector),
+			CreatedAt:    null.TimeFrom(w.CreatedAt),
+			UpdatedAt:    null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := VectorDocumentN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "collection_id":
+			res.CollectionId = null.IntFrom(int64(w.CollectionId))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "content":
+			res.Content = null.StringFrom(w.Content)
+		case "metadata":
+			res.Metadata = null.StringFrom(w.Metadata)
+		case "vector":
+			res.Vector = null.StringFrom(w.Vector)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w VectorDocument) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *VectorDocumentN) ToVectorDocument() VectorDocument {
+	return VectorDocument{
+
+		Id:           w.Id.Int64,
+		CollectionId: w.CollectionId.Int64,
+		UserId:       w.UserId.Int64,
+		Content:      w.Content.String,
+		Metadata:     w.Metadata.String,
+		Vector:       w.Vector.String,
+		CreatedAt:    w.CreatedAt.Time,
+		UpdatedAt:    w.UpdatedAt.Time,
+	}
+}
+
+// VectorDocumentModel is a model which encapsulates the operations of the object
+type VectorDocumentModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var vectorDocumentTableName = "vector_document"
+
+// VectorDocumentTable return table name for VectorDocument
+func VectorDocumentTable() string {
+	return vectorDocumentTableName
+}
+
+const (
+	FieldVectorDocumentId           = "id"
+	FieldVectorDocumentCollectionId = "collection_id"
+	FieldVectorDocumentUserId       = "user_id"
+	FieldVectorDocumentContent      = "content"
+	FieldVectorDocumentMetadata     = "metadata"
+	FieldVectorDocumentVector       = "vector"
+	FieldVectorDocumentCreatedAt    = "created_at"
+	FieldVectorDocumentUpdatedAt    = "updated_at"
+)
+
+// VectorDocumentFields return all fields in VectorDocument model
+func VectorDocumentFields() []string {
+	return []string{
+		"id",
+		"collection_id",
+		"user_id",
+		"content",
+		"metadata",
+		"vector",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetVectorDocumentTable(tableName string) {
+	vectorDocumentTableName = tableName
+}
+
+// NewVectorDocumentModel create a VectorDocumentModel
+func NewVectorDocumentModel(db query.Database) *VectorDocumentModel {
+	return &VectorDocumentModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           vectorDocumentTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *VectorDocumentModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *VectorDocumentModel) clone() *VectorDocumentModel {
+	return &VectorDocumentModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *VectorDocumentModel) WithoutGlobalScopes(names ...string) *VectorDocumentModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *VectorDocumentModel) WithLocalScopes(names ...string) *VectorDocumentModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *VectorDocumentModel) Condition(builder query.SQLBuilder) *VectorDocumentModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *VectorDocumentModel) Find(ctx context.Context, id int64) (*VectorDocumentN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *VectorDocumentModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *VectorDocumentModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *VectorDocumentModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]VectorDocumentN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *VectorDocumentModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]VectorDocumentN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"collection_id",
+			"user_id",
+			"content",
+			"metadata",
+			"vector",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "collection_id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "content":
+			selectFields = append(selectFields, f)
+		case "metadata":
+			selectFields = append(selectFields, f)
+		case "vector":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*VectorDocumentN, []interface{}) {
+		var vectorDocumentVar VectorDocumentN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &vectorDocumentVar.Id)
+			case "collection_id":
+				scanFields = append(scanFields, &vectorDocumentVar.CollectionId)
+			case "user_id":
+				scanFields = append(scanFields, &vectorDocumentVar.UserId)
+			case "content":
+				scanFields = append(scanFields, &vectorDocumentVar.Content)
+			case "metadata":
+				scanFields = append(scanFields, &vectorDocumentVar.Metadata)
+			case "vector":
+				scanFields = append(scanFields, &vectorDocumentVar.Vector)
+			case "created_at":
+				scanFields = append(scanFields, &vectorDocumentVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &vectorDocumentVar.UpdatedAt)
+			}
+		}
+
+		return &vectorDocumentVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	vectorDocuments := make([]VectorDocumentN, 0)
+	for rows.Next() {
+		vectorDocumentReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		vectorDocumentReal.original = &vectorDocumentOriginal{}
+		_ = query.Copy(vectorDocumentReal, vectorDocumentReal.original)
+
+		vectorDocumentReal.SetModel(m)
+		vectorDocuments = append(vectorDocuments, *vectorDocumentReal)
+	}
+
+	return vectorDocuments, nil
+}
+
+// First return first result for given query
+func (m *VectorDocumentModel) First(ctx context.Context, builders ...query.SQLBuilder) (*VectorDocumentN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new vector_document to database
+func (m *VectorDocumentModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all vector_documents to database
+func (m *VectorDocumentModel) SaveAll(ctx context.Context, vectorDocuments []VectorDocumentN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, vectorDocument := range vectorDocuments {
+		id, err := m.Save(ctx, vectorDocument)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a vector_document to database
+func (m *VectorDocumentModel) Save(ctx context.Context, vectorDocument VectorDocumentN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, vectorDocument.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new vector_document or update it when it has a id > 0
+func (m *VectorDocumentModel) SaveOrUpdate(ctx context.Context, vectorDocument VectorDocumentN, onlyFields ...string) (id int64, updated bool, err error) {
+	if vectorDocument.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, vectorDocument.Id.Int64, vectorDocument, onlyFields...)
+		return vectorDocument.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, vectorDocument, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *VectorDocumentModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *VectorDocumentModel) Update(ctx context.Context, builder query.SQLBuilder, vectorDocument VectorDocumentN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, vectorDocument.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *VectorDocumentModel) UpdateById(ctx context.Context, id int64, vectorDocument VectorDocumentN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, vectorDocument.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *VectorDocumentModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *VectorDocumentModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
@@ -0,0 +1,775 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// ClientVersionPolicyN is a ClientVersionPolicy object, all fields are nullable
+type ClientVersionPolicyN struct {
+	original                 *clientVersionPolicyOriginal
+	clientVersionPolicyModel *ClientVersionPolicyModel
+
+	Id                 null.Int    `json:"id"`
+	Platform           null.String `json:"platform"`
+	MinVersion         null.String `json:"min_version,omitempty"`
+	RecommendedVersion null.String `json:"recommended_version,omitempty"`
+	BlockedVersions    null.String `json:"blocked_versions"`
+	UpgradeUrl         null.String `json:"upgrade_url,omitempty"`
+	Message            null.String `json:"message,omitempty"`
+	CreatedAt          null.Time
+	UpdatedAt          null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *ClientVersionPolicyN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for ClientVersionPolicy
+func (inst *ClientVersionPolicyN) SetModel(clientVersionPolicyModel *ClientVersionPolicyModel) {
+	inst.clientVersionPolicyModel = clientVersionPolicyModel
+}
+
+// clientVersionPolicyOriginal is an object which stores original ClientVersionPolicy from database
+type clientVersionPolicyOriginal struct {
+	Id                 null.Int
+	Platform           null.String
+	MinVersion         null.String
+	RecommendedVersion null.String
+	BlockedVersions    null.String
+	UpgradeUrl         null.String
+	Message            null.String
+	CreatedAt          null.Time
+	UpdatedAt          null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *ClientVersionPolicyN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &clientVersionPolicyOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.Platform != inst.original.Platform {
+			return true
+		}
+		if inst.MinVersion != inst.original.MinVersion {
+			return true
+		}
+		if inst.RecommendedVersion != inst.original.RecommendedVersion {
+			return true
+		}
+		if inst.BlockedVersions != inst.original.BlockedVersions {
+			return true
+		}
+		if inst.UpgradeUrl != inst.original.UpgradeUrl {
+			return true
+		}
+		if inst.Message != inst.original.Message {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "platform":
+				if inst.Platform != inst.original.Platform {
+					return true
+				}
+			case "min_version":
+				if inst.MinVersion != inst.original.MinVersion {
+					return true
+				}
+			case "recommended_version":
+				if inst.RecommendedVersion != inst.original.RecommendedVersion {
+					return true
+				}
+			case "blocked_versions":
+				if inst.BlockedVersions != inst.original.BlockedVersions {
+					return true
+				}
+			case "upgrade_url":
+				if inst.UpgradeUrl != inst.original.UpgradeUrl {
+					return true
+				}
+			case "message":
+				if inst.Message != inst.original.Message {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *ClientVersionPolicyN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &clientVersionPolicyOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.Platform != inst.original.Platform {
+			kv["platform"] = inst.Platform
+		}
+		if inst.MinVersion != inst.original.MinVersion {
+			kv["min_version"] = inst.MinVersion
+		}
+		if inst.RecommendedVersion != inst.original.RecommendedVersion {
+			kv["recommended_version"] = inst.RecommendedVersion
+		}
+		if inst.BlockedVersions != inst.original.BlockedVersions {
+			kv["blocked_versions"] = inst.BlockedVersions
+		}
+		if inst.UpgradeUrl != inst.original.UpgradeUrl {
+			kv["upgrade_url"] = inst.UpgradeUrl
+		}
+		if inst.Message != inst.original.Message {
+			kv["message"] = inst.Message
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "platform":
+				if inst.Platform != inst.original.Platform {
+					kv["platform"] = inst.Platform
+				}
+			case "min_version":
+				if inst.MinVersion != inst.original.MinVersion {
+					kv["min_version"] = inst.MinVersion
+				}
+			case "recommended_version":
+				if inst.RecommendedVersion != inst.original.RecommendedVersion {
+					kv["recommended_version"] = inst.RecommendedVersion
+				}
+			case "blocked_versions":
+				if inst.BlockedVersions != inst.original.BlockedVersions {
+					kv["blocked_versions"] = inst.BlockedVersions
+				}
+			case "upgrade_url":
+				if inst.UpgradeUrl != inst.original.UpgradeUrl {
+					kv["upgrade_url"] = inst.UpgradeUrl
+				}
+			case "message":
+				if inst.Message != inst.original.Message {
+					kv["message"] = inst.Message
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *ClientVersionPolicyN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.clientVersionPolicyModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.clientVersionPolicyModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a client_version_policy
+func (inst *ClientVersionPolicyN) Delete(ctx context.Context) error {
+	if inst.clientVersionPolicyModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.clientVersionPolicyModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *ClientVersionPolicyN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type clientVersionPolicyScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var clientVersionPolicyGlobalScopes = make([]clientVersionPolicyScope, 0)
+var clientVersionPolicyLocalScopes = make([]clientVersionPolicyScope, 0)
+
+// AddGlobalScopeForClientVersionPolicy assign a global scope to a model
+func AddGlobalScopeForClientVersionPolicy(name string, apply func(builder query.Condition)) {
+	clientVersionPolicyGlobalScopes = append(clientVersionPolicyGlobalScopes, clientVersionPolicyScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForClientVersionPolicy assign a local scope to a model
+func AddLocalScopeForClientVersionPolicy(name string, apply func(builder query.Condition)) {
+	clientVersionPolicyLocalScopes = append(clientVersionPolicyLocalScopes, clientVersionPolicyScope{name: name, apply: apply})
+}
+
+func (m *ClientVersionPolicyModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range clientVersionPolicyGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range clientVersionPolicyLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *ClientVersionPolicyModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ClientVersionPolicyModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ClientVersionPolicy struct {
+	Id                 int64  `json:"id"`
+	Platform           string `json:"platform"`
+	MinVersion         string `json:"min_version,omitempty"`
+	RecommendedVersion string `json:"recommended_version,omitempty"`
+	BlockedVersions    string `json:"blocked_versions"`
+	UpgradeUrl         string `json:"upgrade_url,omitempty"`
+	Message            string `json:"message,omitempty"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (w ClientVersionPolicy) ToClientVersionPolicyN(allows ...string) ClientVersionPolicyN {
+	if len(allows) == 0 {
+		return ClientVersionPolicyN{
+
+			Id:                 null.IntFrom(int64(w.Id)),
+			Platform:           null.StringFrom(w.Platform),
+			MinVersion:         null.StringFrom(w.MinVersion),
+			RecommendedVersion: null.StringFrom(w.RecommendedVersion),
+			BlockedVersions:    null.StringFrom(w.BlockedVersions),
+			UpgradeUrl:         null.StringFrom(w.UpgradeUrl),
+			Message:            null.StringFrom(w.Message),
+			CreatedAt:          null.TimeFrom(w.CreatedAt),
+			UpdatedAt:          null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := ClientVersionPolicyN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "platform":
+			res.Platform = null.StringFrom(w.Platform)
+		case "min_version":
+			res.MinVersion = null.StringFrom(w.MinVersion)
+		case "recommended_version":
+			res.RecommendedVersion = null.StringFrom(w.RecommendedVersion)
+		case "blocked_versions":
+			res.BlockedVersions = null.StringFrom(w.BlockedVersions)
+		case "upgrade_url":
+			res.UpgradeUrl = null.StringFrom(w.UpgradeUrl)
+		case "message":
+			res.Message = null.StringFrom(w.Message)
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w ClientVersionPolicy) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *ClientVersionPolicyN) ToClientVersionPolicy() ClientVersionPolicy {
+	return ClientVersionPolicy{
+
+		Id:                 w.Id.Int64,
+		Platform:           w.Platform.String,
+		MinVersion:         w.MinVersion.String,
+		RecommendedVersion: w.RecommendedVersion.String,
+		BlockedVersions:    w.BlockedVersions.String,
+		UpgradeUrl:         w.UpgradeUrl.String,
+		Message:            w.Message.String,
+		CreatedAt:          w.CreatedAt.Time,
+		UpdatedAt:          w.UpdatedAt.Time,
+	}
+}
+
+// ClientVersionPolicyModel is a model which encapsulates the operations of the object
+type ClientVersionPolicyModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var clientVersionPolicyTableName = "client_version_policy"
+
+// ClientVersionPolicyTable return table name for ClientVersionPolicy
+func ClientVersionPolicyTable() string {
+	return clientVersionPolicyTableName
+}
+
+const (
+	FieldClientVersionPolicyId                 = "id"
+	FieldClientVersionPolicyPlatform           = "platform"
+	FieldClientVersionPolicyMinVersion         = "min_version"
+	FieldClientVersionPolicyRecommendedVersion = "recommended_version"
+	FieldClientVersionPolicyBlockedVersions    = "blocked_versions"
+	FieldClientVersionPolicyUpgradeUrl         = "upgrade_url"
+	FieldClientVersionPolicyMessage            = "message"
+	FieldClientVersionPolicyCreatedAt          = "created_at"
+	FieldClientVersionPolicyUpdatedAt          = "updated_at"
+)
+
+// ClientVersionPolicyFields return all fields in ClientVersionPolicy model
+func ClientVersionPolicyFields() []string {
+	return []string{
+		"id",
+		"platform",
+		"min_version",
+		"recommended_version",
+		"blocked_versions",
+		"upgrade_url",
+		"message",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetClientVersionPolicyTable(tableName string) {
+	clientVersionPolicyTableName = tableName
+}
+
+// NewClientVersionPolicyModel create a ClientVersionPolicyModel
+func NewClientVersionPolicyModel(db query.Database) *ClientVersionPolicyModel {
+	return &ClientVersionPolicyModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           clientVersionPolicyTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *ClientVersionPolicyModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *ClientVersionPolicyModel) clone() *ClientVersionPolicyModel {
+	return &ClientVersionPolicyModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *ClientVersionPolicyModel) WithoutGlobalScopes(names ...string) *ClientVersionPolicyModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *ClientVersionPolicyModel) WithLocalScopes(names ...string) *ClientVersionPolicyModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *ClientVersionPolicyModel) Condition(builder query.SQLBuilder) *ClientVersionPolicyModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *ClientVersionPolicyModel) Find(ctx context.Context, id int64) (*ClientVersionPolicyN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *ClientVersionPolicyModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *ClientVersionPolicyModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *ClientVersionPolicyModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]ClientVersionPolicyN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *ClientVersionPolicyModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]ClientVersionPolicyN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"platform",
+			"min_version",
+			"recommended_version",
+			"blocked_versions",
+			"upgrade_url",
+			"message",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "platform":
+			selectFields = append(selectFields, f)
+		case "min_version":
+			selectFields = append(selectFields, f)
+		case "recommended_version":
+			selectFields = append(selectFields, f)
+		case "blocked_versions":
+			selectFields = append(selectFields, f)
+		case "upgrade_url":
+			selectFields = append(selectFields, f)
+		case "message":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*ClientVersionPolicyN, []interface{}) {
+		var clientVersionPolicyVar ClientVersionPolicyN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &clientVersionPolicyVar.Id)
+			case "platform":
+				scanFields = append(scanFields, &clientVersionPolicyVar.Platform)
+			case "min_version":
+				scanFields = append(scanFields, &clientVersionPolicyVar.MinVersion)
+			case "recommended_version":
+				scanFields = append(scanFields, &clientVersionPolicyVar.RecommendedVersion)
+			case "blocked_versions":
+				scanFields = append(scanFields, &clientVersionPolicyVar.BlockedVersions)
+			case "upgrade_url":
+				scanFields = append(scanFields, &clientVersionPolicyVar.UpgradeUrl)
+			case "message":
+				scanFields = append(scanFields, &clientVersionPolicyVar.Message)
+			case "created_at":
+				scanFields = append(scanFields, &clientVersionPolicyVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &clientVersionPolicyVar.UpdatedAt)
+			}
+		}
+
+		return &clientVersionPolicyVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	clientVersionPolicys := make([]ClientVersionPolicyN, 0)
+	for rows.Next() {
+		clientVersionPolicyReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		clientVersionPolicyReal.original = &clientVersionPolicyOriginal{}
+		_ = query.Copy(clientVersionPolicyReal, clientVersionPolicyReal.original)
+
+		clientVersionPolicyReal.SetModel(m)
+		clientVersionPolicys = append(clientVersionPolicys, *clientVersionPolicyReal)
+	}
+
+	return clientVersionPolicys, nil
+}
+
+// First return first result for given query
+func (m *ClientVersionPolicyModel) First(ctx context.Context, builders ...query.SQLBuilder) (*ClientVersionPolicyN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new client_version_policy to database
+func (m *ClientVersionPolicyModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all client_version_policys to database
+func (m *ClientVersionPolicyModel) SaveAll(ctx context.Context, clientVersionPolicys []ClientVersionPolicyN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, clientVersionPolicy := range clientVersionPolicys {
+		id, err := m.Save(ctx, clientVersionPolicy)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a client_version_policy to database
+func (m *ClientVersionPolicyModel) Save(ctx context.Context, clientVersionPolicy ClientVersionPolicyN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, clientVersionPolicy.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new client_version_policy or update it when it has a id > 0
+func (m *ClientVersionPolicyModel) SaveOrUpdate(ctx context.Context, clientVersionPolicy ClientVersionPolicyN, onlyFields ...string) (id int64, updated bool, err error) {
+	if clientVersionPolicy.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, clientVersionPolicy.Id.Int64, clientVersionPolicy, onlyFields...)
+		return clientVersionPolicy.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, clientVersionPolicy, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *ClientVersionPolicyModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *ClientVersionPolicyModel) Update(ctx context.Context, builder query.SQLBuilder, clientVersionPolicy ClientVersionPolicyN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, clientVersionPolicy.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *ClientVersionPolicyModel) UpdateById(ctx context.Context, id int64, clientVersionPolicy ClientVersionPolicyN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, clientVersionPolicy.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *ClientVersionPolicyModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *ClientVersionPolicyModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
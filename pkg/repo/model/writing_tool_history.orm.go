@@ -0,0 +1,803 @@
+package model
+
+// !!! DO NOT EDIT THIS FILE
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/iancoleman/strcase"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+func init() {
+
+}
+
+// WritingToolHistoryN is a WritingToolHistory object, all fields are nullable
+type WritingToolHistoryN struct {
+	original                *writingToolHistoryOriginal
+	writingToolHistoryModel *WritingToolHistoryModel
+
+	Id            null.Int    `json:"id"`
+	UserId        null.Int    `json:"user_id"`
+	Operation     null.String `json:"operation"`
+	Options       null.String `json:"options"`
+	Model         null.String `json:"model"`
+	Input         null.String `json:"input"`
+	Output        null.String `json:"output"`
+	QuotaConsumed null.Int    `json:"quota_consumed"`
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (inst *WritingToolHistoryN) As(dst interface{}) error {
+	return query.Copy(inst, dst)
+}
+
+// SetModel set model for WritingToolHistory
+func (inst *WritingToolHistoryN) SetModel(writingToolHistoryModel *WritingToolHistoryModel) {
+	inst.writingToolHistoryModel = writingToolHistoryModel
+}
+
+// writingToolHistoryOriginal is an object which stores original WritingToolHistory from database
+type writingToolHistoryOriginal struct {
+	Id            null.Int
+	UserId        null.Int
+	Operation     null.String
+	Options       null.String
+	Model         null.String
+	Input         null.String
+	Output        null.String
+	QuotaConsumed null.Int
+	CreatedAt     null.Time
+	UpdatedAt     null.Time
+}
+
+// Staled identify whether the object has been modified
+func (inst *WritingToolHistoryN) Staled(onlyFields ...string) bool {
+	if inst.original == nil {
+		inst.original = &writingToolHistoryOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			return true
+		}
+		if inst.UserId != inst.original.UserId {
+			return true
+		}
+		if inst.Operation != inst.original.Operation {
+			return true
+		}
+		if inst.Options != inst.original.Options {
+			return true
+		}
+		if inst.Model != inst.original.Model {
+			return true
+		}
+		if inst.Input != inst.original.Input {
+			return true
+		}
+		if inst.Output != inst.original.Output {
+			return true
+		}
+		if inst.QuotaConsumed != inst.original.QuotaConsumed {
+			return true
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			return true
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			return true
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					return true
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					return true
+				}
+			case "operation":
+				if inst.Operation != inst.original.Operation {
+					return true
+				}
+			case "options":
+				if inst.Options != inst.original.Options {
+					return true
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					return true
+				}
+			case "input":
+				if inst.Input != inst.original.Input {
+					return true
+				}
+			case "output":
+				if inst.Output != inst.original.Output {
+					return true
+				}
+			case "quota_consumed":
+				if inst.QuotaConsumed != inst.original.QuotaConsumed {
+					return true
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					return true
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					return true
+				}
+			default:
+			}
+		}
+	}
+
+	return false
+}
+
+// StaledKV return all fields has been modified
+func (inst *WritingToolHistoryN) StaledKV(onlyFields ...string) query.KV {
+	kv := make(query.KV, 0)
+
+	if inst.original == nil {
+		inst.original = &writingToolHistoryOriginal{}
+	}
+
+	if len(onlyFields) == 0 {
+
+		if inst.Id != inst.original.Id {
+			kv["id"] = inst.Id
+		}
+		if inst.UserId != inst.original.UserId {
+			kv["user_id"] = inst.UserId
+		}
+		if inst.Operation != inst.original.Operation {
+			kv["operation"] = inst.Operation
+		}
+		if inst.Options != inst.original.Options {
+			kv["options"] = inst.Options
+		}
+		if inst.Model != inst.original.Model {
+			kv["model"] = inst.Model
+		}
+		if inst.Input != inst.original.Input {
+			kv["input"] = inst.Input
+		}
+		if inst.Output != inst.original.Output {
+			kv["output"] = inst.Output
+		}
+		if inst.QuotaConsumed != inst.original.QuotaConsumed {
+			kv["quota_consumed"] = inst.QuotaConsumed
+		}
+		if inst.CreatedAt != inst.original.CreatedAt {
+			kv["created_at"] = inst.CreatedAt
+		}
+		if inst.UpdatedAt != inst.original.UpdatedAt {
+			kv["updated_at"] = inst.UpdatedAt
+		}
+	} else {
+		for _, f := range onlyFields {
+			switch strcase.ToSnake(f) {
+
+			case "id":
+				if inst.Id != inst.original.Id {
+					kv["id"] = inst.Id
+				}
+			case "user_id":
+				if inst.UserId != inst.original.UserId {
+					kv["user_id"] = inst.UserId
+				}
+			case "operation":
+				if inst.Operation != inst.original.Operation {
+					kv["operation"] = inst.Operation
+				}
+			case "options":
+				if inst.Options != inst.original.Options {
+					kv["options"] = inst.Options
+				}
+			case "model":
+				if inst.Model != inst.original.Model {
+					kv["model"] = inst.Model
+				}
+			case "input":
+				if inst.Input != inst.original.Input {
+					kv["input"] = inst.Input
+				}
+			case "output":
+				if inst.Output != inst.original.Output {
+					kv["output"] = inst.Output
+				}
+			case "quota_consumed":
+				if inst.QuotaConsumed != inst.original.QuotaConsumed {
+					kv["quota_consumed"] = inst.QuotaConsumed
+				}
+			case "created_at":
+				if inst.CreatedAt != inst.original.CreatedAt {
+					kv["created_at"] = inst.CreatedAt
+				}
+			case "updated_at":
+				if inst.UpdatedAt != inst.original.UpdatedAt {
+					kv["updated_at"] = inst.UpdatedAt
+				}
+			default:
+			}
+		}
+	}
+
+	return kv
+}
+
+// Save create a new model or update it
+func (inst *WritingToolHistoryN) Save(ctx context.Context, onlyFields ...string) error {
+	if inst.writingToolHistoryModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	id, _, err := inst.writingToolHistoryModel.SaveOrUpdate(ctx, *inst, onlyFields...)
+	if err != nil {
+		return err
+	}
+
+	inst.Id = null.IntFrom(id)
+	return nil
+}
+
+// Delete remove a writing_tool_history
+func (inst *WritingToolHistoryN) Delete(ctx context.Context) error {
+	if inst.writingToolHistoryModel == nil {
+		return query.ErrModelNotSet
+	}
+
+	_, err := inst.writingToolHistoryModel.DeleteById(ctx, inst.Id.Int64)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String convert instance to json string
+func (inst *WritingToolHistoryN) String() string {
+	rs, _ := json.Marshal(inst)
+	return string(rs)
+}
+
+type writingToolHistoryScope struct {
+	name  string
+	apply func(builder query.Condition)
+}
+
+var writingToolHistoryGlobalScopes = make([]writingToolHistoryScope, 0)
+var writingToolHistoryLocalScopes = make([]writingToolHistoryScope, 0)
+
+// AddGlobalScopeForWritingToolHistory assign a global scope to a model
+func AddGlobalScopeForWritingToolHistory(name string, apply func(builder query.Condition)) {
+	writingToolHistoryGlobalScopes = append(writingToolHistoryGlobalScopes, writingToolHistoryScope{name: name, apply: apply})
+}
+
+// AddLocalScopeForWritingToolHistory assign a local scope to a model
+func AddLocalScopeForWritingToolHistory(name string, apply func(builder query.Condition)) {
+	writingToolHistoryLocalScopes = append(writingToolHistoryLocalScopes, writingToolHistoryScope{name: name, apply: apply})
+}
+
+func (m *WritingToolHistoryModel) applyScope() query.Condition {
+	scopeCond := query.ConditionBuilder()
+	for _, g := range writingToolHistoryGlobalScopes {
+		if m.globalScopeEnabled(g.name) {
+			g.apply(scopeCond)
+		}
+	}
+
+	for _, s := range writingToolHistoryLocalScopes {
+		if m.localScopeEnabled(s.name) {
+			s.apply(scopeCond)
+		}
+	}
+
+	return scopeCond
+}
+
+func (m *WritingToolHistoryModel) localScopeEnabled(name string) bool {
+	for _, n := range m.includeLocalScopes {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *WritingToolHistoryModel) globalScopeEnabled(name string) bool {
+	for _, n := range m.excludeGlobalScopes {
+		if name == n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type WritingToolHistory struct {
+	Id            int64  `json:"id"`
+	UserId        int64  `json:"user_id"`
+	Operation     string `json:"operation"`
+	Options       string `json:"options"`
+	Model         string `json:"model"`
+	Input         string `json:"input"`
+	Output        string `json:"output"`
+	QuotaConsumed int64  `json:"quota_consumed"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (w WritingToolHistory) ToWritingToolHistoryN(allows ...string) WritingToolHistoryN {
+	if len(allows) == 0 {
+		return WritingToolHistoryN{
+
+			Id:            null.IntFrom(int64(w.Id)),
+			UserId:        null.IntFrom(int64(w.UserId)),
+			Operation:     null.StringFrom(w.Operation),
+			Options:       null.StringFrom(w.Options),
+			Model:         null.StringFrom(w.Model),
+			Input:         null.StringFrom(w.Input),
+			Output:        null.StringFrom(w.Output),
+			QuotaConsumed: null.IntFrom(int64(w.QuotaConsumed)),
+			CreatedAt:     null.TimeFrom(w.CreatedAt),
+			UpdatedAt:     null.TimeFrom(w.UpdatedAt),
+		}
+	}
+
+	res := WritingToolHistoryN{}
+	for _, al := range allows {
+		switch strcase.ToSnake(al) {
+
+		case "id":
+			res.Id = null.IntFrom(int64(w.Id))
+		case "user_id":
+			res.UserId = null.IntFrom(int64(w.UserId))
+		case "operation":
+			res.Operation = null.StringFrom(w.Operation)
+		case "options":
+			res.Options = null.StringFrom(w.Options)
+		case "model":
+			res.Model = null.StringFrom(w.Model)
+		case "input":
+			res.Input = null.StringFrom(w.Input)
+		case "output":
+			res.Output = null.StringFrom(w.Output)
+		case "quota_consumed":
+			res.QuotaConsumed = null.IntFrom(int64(w.QuotaConsumed))
+		case "created_at":
+			res.CreatedAt = null.TimeFrom(w.CreatedAt)
+		case "updated_at":
+			res.UpdatedAt = null.TimeFrom(w.UpdatedAt)
+		default:
+		}
+	}
+
+	return res
+}
+
+// As convert object to other type
+// dst must be a pointer to struct
+func (w WritingToolHistory) As(dst interface{}) error {
+	return query.Copy(w, dst)
+}
+
+func (w *WritingToolHistoryN) ToWritingToolHistory() WritingToolHistory {
+	return WritingToolHistory{
+
+		Id:            w.Id.Int64,
+		UserId:        w.UserId.Int64,
+		Operation:     w.Operation.String,
+		Options:       w.Options.String,
+		Model:         w.Model.String,
+		Input:         w.Input.String,
+		Output:        w.Output.String,
+		QuotaConsumed: w.QuotaConsumed.Int64,
+		CreatedAt:     w.CreatedAt.Time,
+		UpdatedAt:     w.UpdatedAt.Time,
+	}
+}
+
+// WritingToolHistoryModel is a model which encapsulates the operations of the object
+type WritingToolHistoryModel struct {
+	db        *query.DatabaseWrap
+	tableName string
+
+	excludeGlobalScopes []string
+	includeLocalScopes  []string
+
+	query query.SQLBuilder
+}
+
+var writingToolHistoryTableName = "writing_tool_history"
+
+// WritingToolHistoryTable return table name for WritingToolHistory
+func WritingToolHistoryTable() string {
+	return writingToolHistoryTableName
+}
+
+const (
+	FieldWritingToolHistoryId            = "id"
+	FieldWritingToolHistoryUserId        = "user_id"
+	FieldWritingToolHistoryOperation     = "operation"
+	FieldWritingToolHistoryOptions       = "options"
+	FieldWritingToolHistoryModel         = "model"
+	FieldWritingToolHistoryInput         = "input"
+	FieldWritingToolHistoryOutput        = "output"
+	FieldWritingToolHistoryQuotaConsumed = "quota_consumed"
+	FieldWritingToolHistoryCreatedAt     = "created_at"
+	FieldWritingToolHistoryUpdatedAt     = "updated_at"
+)
+
+// WritingToolHistoryFields return all fields in WritingToolHistory model
+func WritingToolHistoryFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"operation",
+		"options",
+		"model",
+		"input",
+		"output",
+		"quota_consumed",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func SetWritingToolHistoryTable(tableName string) {
+	writingToolHistoryTableName = tableName
+}
+
+// NewWritingToolHistoryModel create a WritingToolHistoryModel
+func NewWritingToolHistoryModel(db query.Database) *WritingToolHistoryModel {
+	return &WritingToolHistoryModel{
+		db:                  query.NewDatabaseWrap(db),
+		tableName:           writingToolHistoryTableName,
+		excludeGlobalScopes: make([]string, 0),
+		includeLocalScopes:  make([]string, 0),
+		query:               query.Builder(),
+	}
+}
+
+// GetDB return database instance
+func (m *WritingToolHistoryModel) GetDB() query.Database {
+	return m.db.GetDB()
+}
+
+func (m *WritingToolHistoryModel) clone() *WritingToolHistoryModel {
+	return &WritingToolHistoryModel{
+		db:                  m.db,
+		tableName:           m.tableName,
+		excludeGlobalScopes: append([]string{}, m.excludeGlobalScopes...),
+		includeLocalScopes:  append([]string{}, m.includeLocalScopes...),
+		query:               m.query,
+	}
+}
+
+// WithoutGlobalScopes remove a global scope for given query
+func (m *WritingToolHistoryModel) WithoutGlobalScopes(names ...string) *WritingToolHistoryModel {
+	mc := m.clone()
+	mc.excludeGlobalScopes = append(mc.excludeGlobalScopes, names...)
+
+	return mc
+}
+
+// WithLocalScopes add a local scope for given query
+func (m *WritingToolHistoryModel) WithLocalScopes(names ...string) *WritingToolHistoryModel {
+	mc := m.clone()
+	mc.includeLocalScopes = append(mc.includeLocalScopes, names...)
+
+	return mc
+}
+
+// Condition add query builder to model
+func (m *WritingToolHistoryModel) Condition(builder query.SQLBuilder) *WritingToolHistoryModel {
+	mm := m.clone()
+	mm.query = mm.query.Merge(builder)
+
+	return mm
+}
+
+// Find retrieve a model by its primary key
+func (m *WritingToolHistoryModel) Find(ctx context.Context, id int64) (*WritingToolHistoryN, error) {
+	return m.First(ctx, m.query.Where("id", "=", id))
+}
+
+// Exists return whether the records exists for a given query
+func (m *WritingToolHistoryModel) Exists(ctx context.Context, builders ...query.SQLBuilder) (bool, error) {
+	count, err := m.Count(ctx, builders...)
+	return count > 0, err
+}
+
+// Count return model count for a given query
+func (m *WritingToolHistoryModel) Count(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+	sqlStr, params := m.query.
+		Merge(builders...).
+		Table(m.tableName).
+		AppendCondition(m.applyScope()).
+		ResolveCount()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	rows.Next()
+	var res int64
+	if err := rows.Scan(&res); err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+func (m *WritingToolHistoryModel) Paginate(ctx context.Context, page int64, perPage int64, builders ...query.SQLBuilder) ([]WritingToolHistoryN, query.PaginateMeta, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	if perPage <= 0 {
+		perPage = 15
+	}
+
+	meta := query.PaginateMeta{
+		PerPage: perPage,
+		Page:    page,
+	}
+
+	count, err := m.Count(ctx, builders...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	meta.Total = count
+	meta.LastPage = count / perPage
+	if count%perPage != 0 {
+		meta.LastPage += 1
+	}
+
+	res, err := m.Get(ctx, append([]query.SQLBuilder{query.Builder().Limit(perPage).Offset((page - 1) * perPage)}, builders...)...)
+	if err != nil {
+		return res, meta, err
+	}
+
+	return res, meta, nil
+}
+
+// Get retrieve all results for given query
+func (m *WritingToolHistoryModel) Get(ctx context.Context, builders ...query.SQLBuilder) ([]WritingToolHistoryN, error) {
+	b := m.query.Merge(builders...).Table(m.tableName).AppendCondition(m.applyScope())
+	if len(b.GetFields()) == 0 {
+		b = b.Select(
+			"id",
+			"user_id",
+			"operation",
+			"options",
+			"model",
+			"input",
+			"output",
+			"quota_consumed",
+			"created_at",
+			"updated_at",
+		)
+	}
+
+	fields := b.GetFields()
+	selectFields := make([]query.Expr, 0)
+
+	for _, f := range fields {
+		switch strcase.ToSnake(f.Value) {
+
+		case "id":
+			selectFields = append(selectFields, f)
+		case "user_id":
+			selectFields = append(selectFields, f)
+		case "operation":
+			selectFields = append(selectFields, f)
+		case "options":
+			selectFields = append(selectFields, f)
+		case "model":
+			selectFields = append(selectFields, f)
+		case "input":
+			selectFields = append(selectFields, f)
+		case "output":
+			selectFields = append(selectFields, f)
+		case "quota_consumed":
+			selectFields = append(selectFields, f)
+		case "created_at":
+			selectFields = append(selectFields, f)
+		case "updated_at":
+			selectFields = append(selectFields, f)
+		}
+	}
+
+	var createScanVar = func(fields []query.Expr) (*WritingToolHistoryN, []interface{}) {
+		var writingToolHistoryVar WritingToolHistoryN
+		scanFields := make([]interface{}, 0)
+
+		for _, f := range fields {
+			switch strcase.ToSnake(f.Value) {
+
+			case "id":
+				scanFields = append(scanFields, &writingToolHistoryVar.Id)
+			case "user_id":
+				scanFields = append(scanFields, &writingToolHistoryVar.UserId)
+			case "operation":
+				scanFields = append(scanFields, &writingToolHistoryVar.Operation)
+			case "options":
+				scanFields = append(scanFields, &writingToolHistoryVar.Options)
+			case "model":
+				scanFields = append(scanFields, &writingToolHistoryVar.Model)
+			case "input":
+				scanFields = append(scanFields, &writingToolHistoryVar.Input)
+			case "output":
+				scanFields = append(scanFields, &writingToolHistoryVar.Output)
+			case "quota_consumed":
+				scanFields = append(scanFields, &writingToolHistoryVar.QuotaConsumed)
+			case "created_at":
+				scanFields = append(scanFields, &writingToolHistoryVar.CreatedAt)
+			case "updated_at":
+				scanFields = append(scanFields, &writingToolHistoryVar.UpdatedAt)
+			}
+		}
+
+		return &writingToolHistoryVar, scanFields
+	}
+
+	sqlStr, params := b.Fields(selectFields...).ResolveQuery()
+
+	rows, err := m.db.QueryContext(ctx, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	writingToolHistorys := make([]WritingToolHistoryN, 0)
+	for rows.Next() {
+		writingToolHistoryReal, scanFields := createScanVar(fields)
+		if err := rows.Scan(scanFields...); err != nil {
+			return nil, err
+		}
+
+		writingToolHistoryReal.original = &writingToolHistoryOriginal{}
+		_ = query.Copy(writingToolHistoryReal, writingToolHistoryReal.original)
+
+		writingToolHistoryReal.SetModel(m)
+		writingToolHistorys = append(writingToolHistorys, *writingToolHistoryReal)
+	}
+
+	return writingToolHistorys, nil
+}
+
+// First return first result for given query
+func (m *WritingToolHistoryModel) First(ctx context.Context, builders ...query.SQLBuilder) (*WritingToolHistoryN, error) {
+	res, err := m.Get(ctx, append(builders, query.Builder().Limit(1))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, query.ErrNoResult
+	}
+
+	return &res[0], nil
+}
+
+// Create save a new writing_tool_history to database
+func (m *WritingToolHistoryModel) Create(ctx context.Context, kv query.KV) (int64, error) {
+
+	if _, ok := kv["created_at"]; !ok {
+		kv["created_at"] = time.Now()
+	}
+
+	if _, ok := kv["updated_at"]; !ok {
+		kv["updated_at"] = time.Now()
+	}
+
+	sqlStr, params := m.query.Table(m.tableName).ResolveInsert(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// SaveAll save all writing_tool_historys to database
+func (m *WritingToolHistoryModel) SaveAll(ctx context.Context, writingToolHistorys []WritingToolHistoryN) ([]int64, error) {
+	ids := make([]int64, 0)
+	for _, writingToolHistory := range writingToolHistorys {
+		id, err := m.Save(ctx, writingToolHistory)
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Save save a writing_tool_history to database
+func (m *WritingToolHistoryModel) Save(ctx context.Context, writingToolHistory WritingToolHistoryN, onlyFields ...string) (int64, error) {
+	return m.Create(ctx, writingToolHistory.StaledKV(onlyFields...))
+}
+
+// SaveOrUpdate save a new writing_tool_history or update it when it has a id > 0
+func (m *WritingToolHistoryModel) SaveOrUpdate(ctx context.Context, writingToolHistory WritingToolHistoryN, onlyFields ...string) (id int64, updated bool, err error) {
+	if writingToolHistory.Id.Int64 > 0 {
+		_, _err := m.UpdateById(ctx, writingToolHistory.Id.Int64, writingToolHistory, onlyFields...)
+		return writingToolHistory.Id.Int64, true, _err
+	}
+
+	_id, _err := m.Save(ctx, writingToolHistory, onlyFields...)
+	return _id, false, _err
+}
+
+// UpdateFields update kv for a given query
+func (m *WritingToolHistoryModel) UpdateFields(ctx context.Context, kv query.KV, builders ...query.SQLBuilder) (int64, error) {
+	if len(kv) == 0 {
+		return 0, nil
+	}
+
+	kv["updated_at"] = time.Now()
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).
+		Table(m.tableName).
+		ResolveUpdate(kv)
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// Update update a model for given query
+func (m *WritingToolHistoryModel) Update(ctx context.Context, builder query.SQLBuilder, writingToolHistory WritingToolHistoryN, onlyFields ...string) (int64, error) {
+	return m.UpdateFields(ctx, writingToolHistory.StaledKV(onlyFields...), builder)
+}
+
+// UpdateById update a model by id
+func (m *WritingToolHistoryModel) UpdateById(ctx context.Context, id int64, writingToolHistory WritingToolHistoryN, onlyFields ...string) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).UpdateFields(ctx, writingToolHistory.StaledKV(onlyFields...))
+}
+
+// Delete remove a model
+func (m *WritingToolHistoryModel) Delete(ctx context.Context, builders ...query.SQLBuilder) (int64, error) {
+
+	sqlStr, params := m.query.Merge(builders...).AppendCondition(m.applyScope()).Table(m.tableName).ResolveDelete()
+
+	res, err := m.db.ExecContext(ctx, sqlStr, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+
+}
+
+// DeleteById remove a model by id
+func (m *WritingToolHistoryModel) DeleteById(ctx context.Context, id int64) (int64, error) {
+	return m.Condition(query.Builder().Where("id", "=", id)).Delete(ctx)
+}
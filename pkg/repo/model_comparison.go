@@ -0,0 +1,133 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// ModelComparisonRepo 模型对比记录
+type ModelComparisonRepo struct {
+	db *sql.DB
+}
+
+// NewModelComparisonRepo create a new ModelComparisonRepo
+func NewModelComparisonRepo(db *sql.DB) *ModelComparisonRepo {
+	return &ModelComparisonRepo{db: db}
+}
+
+// ModelComparisonResult 单个模型的回答
+type ModelComparisonResult struct {
+	Model string `json:"model"`
+	Text  string `json:"text,omitempty"`
+	Error string `json:"error,omitempty"`
+	// Latency 模型响应耗时（毫秒）
+	Latency       int64 `json:"latency"`
+	TokenConsumed int64 `json:"token_consumed"`
+	QuotaConsumed int64 `json:"quota_consumed"`
+}
+
+// ModelComparison 一次模型对比
+type ModelComparison struct {
+	ID        int64                   `json:"id"`
+	UserID    int64                   `json:"user_id"`
+	Prompt    string                  `json:"prompt"`
+	Models    []string                `json:"models"`
+	Results   []ModelComparisonResult `json:"results"`
+	Coins     int64                   `json:"coins"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+func modelComparisonFromModel(item model.ModelComparisonN) ModelComparison {
+	ret := ModelComparison{
+		ID:        item.Id.ValueOrZero(),
+		UserID:    item.UserId.ValueOrZero(),
+		Prompt:    item.Prompt.ValueOrZero(),
+		Models:    strings.Split(item.Models.ValueOrZero(), ","),
+		Results:   make([]ModelComparisonResult, 0),
+		Coins:     item.Coins.ValueOrZero(),
+		CreatedAt: item.CreatedAt.ValueOrZero(),
+	}
+
+	if results := item.Results.ValueOrZero(); results != "" {
+		_ = json.Unmarshal([]byte(results), &ret.Results)
+	}
+
+	return ret
+}
+
+// Create 保存模型对比结果
+func (repo *ModelComparisonRepo) Create(ctx context.Context, userID int64, prompt string, results []ModelComparisonResult) (int64, error) {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return 0, fmt.Errorf("marshal comparison results failed: %w", err)
+	}
+
+	var coins int64
+	for _, res := range results {
+		coins += res.QuotaConsumed
+	}
+
+	return model.NewModelComparisonModel(repo.db).Create(ctx, query.KV{
+		model.FieldModelComparisonUserId:  userID,
+		model.FieldModelComparisonPrompt:  prompt,
+		model.FieldModelComparisonModels:  strings.Join(array.Map(results, func(item ModelComparisonResult, _ int) string { return item.Model }), ","),
+		model.FieldModelComparisonResults: string(data),
+		model.FieldModelComparisonCoins:   coins,
+	})
+}
+
+// Get 查询用户的模型对比记录
+func (repo *ModelComparisonRepo) Get(ctx context.Context, userID, id int64) (*ModelComparison, error) {
+	item, err := model.NewModelComparisonModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldModelComparisonId, id).
+		Where(model.FieldModelComparisonUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := modelComparisonFromModel(*item)
+	return &ret, nil
+}
+
+// Comparisons 分页查询用户的模型对比记录，按照创建时间倒序
+func (repo *ModelComparisonRepo) Comparisons(ctx context.Context, userID int64, page, perPage int64) ([]ModelComparison, query.PaginateMeta, error) {
+	items, meta, err := model.NewModelComparisonModel(repo.db).Paginate(ctx, page, perPage, query.Builder().
+		Where(model.FieldModelComparisonUserId, userID).
+		OrderBy(model.FieldModelComparisonId, "DESC"))
+	if err != nil {
+		return nil, meta, err
+	}
+
+	return array.Map(items, func(item model.ModelComparisonN, _ int) ModelComparison {
+		return modelComparisonFromModel(item)
+	}), meta, nil
+}
+
+// Delete 删除用户的模型对比记录
+func (repo *ModelComparisonRepo) Delete(ctx context.Context, userID, id int64) error {
+	affected, err := model.NewModelComparisonModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldModelComparisonId, id).
+		Where(model.FieldModelComparisonUserId, userID))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
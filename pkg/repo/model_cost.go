@@ -0,0 +1,146 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// ModelCostUsage 单次请求的用量
+type ModelCostUsage struct {
+	Model        string
+	Provider     string
+	InputTokens  int64
+	OutputTokens int64
+	// Cost 上游成本（元），Priced 为 false 时表示模型没有配置服务商价格
+	Cost   float64
+	Priced bool
+	// Coins 向用户收取的智慧果
+	Coins int64
+}
+
+// ModelCostReportItem 毛利报表中的一行，按照 日期 + 模型 + 服务商 聚合
+type ModelCostReportItem struct {
+	Date         string  `json:"date,omitempty"`
+	Model        string  `json:"model,omitempty"`
+	Provider     string  `json:"provider,omitempty"`
+	Requests     int64   `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	Unpriced     int64   `json:"unpriced"`
+	Coins        int64   `json:"coins"`
+	Revenue      float64 `json:"revenue"`
+	Cost         float64 `json:"cost"`
+	Margin       float64 `json:"margin"`
+	// MarginRate 毛利率，收入为 0 时为 0
+	MarginRate float64 `json:"margin_rate"`
+}
+
+// 毛利报表的聚合维度
+const (
+	ModelCostGroupByDate     = "date"
+	ModelCostGroupByModel    = "model"
+	ModelCostGroupByProvider = "provider"
+)
+
+// modelCostGroups 报表的聚合维度，顺序与查询结果的列顺序一致
+var modelCostGroups = []string{ModelCostGroupByDate, ModelCostGroupByModel, ModelCostGroupByProvider}
+
+var modelCostGroupColumns = map[string]string{
+	ModelCostGroupByDate:     "DATE_FORMAT(stat_date, '%Y-%m-%d')",
+	ModelCostGroupByModel:    "model",
+	ModelCostGroupByProvider: "provider",
+}
+
+// ModelCostRepo 模型上游成本统计
+type ModelCostRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewModelCostRepo create a new ModelCostRepo
+func NewModelCostRepo(db *sql.DB, conf *config.Config) *ModelCostRepo {
+	return &ModelCostRepo{db: db, conf: conf}
+}
+
+// Record 记录一次请求的上游成本与收取的智慧果，按照 日期 + 模型 + 服务商 聚合
+func (repo *ModelCostRepo) Record(ctx context.Context, usage ModelCostUsage) error {
+	unpriced := 0
+	if !usage.Priced {
+		unpriced = 1
+	}
+
+	_, err := repo.db.ExecContext(
+		ctx,
+		`INSERT INTO model_cost_stats (stat_date, model, provider, request_count, input_tokens, output_tokens, cost, unpriced_count, coins, created_at, updated_at)
+VALUES (?, ?, ?, 1, ?, ?, ?, ?, ?, NOW(), NOW())
+ON DUPLICATE KEY UPDATE request_count = request_count + 1, input_tokens = input_tokens + VALUES(input_tokens), output_tokens = output_tokens + VALUES(output_tokens),
+cost = cost + VALUES(cost), unpriced_count = unpriced_count + VALUES(unpriced_count), coins = coins + VALUES(coins), updated_at = NOW()`,
+		time.Now().Format("2006-01-02"), usage.Model, usage.Provider, usage.InputTokens, usage.OutputTokens, usage.Cost, unpriced, usage.Coins,
+	)
+	return err
+}
+
+// Report 查询 [startDate, endDate] 期间的毛利报表，groupBy 为聚合维度，按照毛利从低到高排序，便于发现亏损的模型
+func (repo *ModelCostRepo) Report(ctx context.Context, startDate, endDate time.Time, groupBy []string) ([]ModelCostReportItem, error) {
+	// 未参与聚合的维度返回空字符串，保证查询结果的列固定
+	selects := []string{"''", "''", "''"}
+	groups := make([]string, 0, len(groupBy))
+	for _, g := range groupBy {
+		if !array.In(g, modelCostGroups) {
+			return nil, fmt.Errorf("invalid group by: %s", g)
+		}
+	}
+
+	for i, g := range modelCostGroups {
+		if array.In(g, groupBy) {
+			selects[i] = modelCostGroupColumns[g]
+			groups = append(groups, modelCostGroupColumns[g])
+		}
+	}
+
+	sqlStr := "SELECT " + strings.Join(selects, ", ") + ", SUM(request_count), SUM(input_tokens), SUM(output_tokens), SUM(unpriced_count), SUM(coins), SUM(cost) FROM model_cost_stats WHERE stat_date >= ? AND stat_date <= ?"
+	if len(groups) > 0 {
+		sqlStr += " GROUP BY " + strings.Join(groups, ", ")
+	}
+
+	rows, err := repo.db.QueryContext(ctx, sqlStr, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]ModelCostReportItem, 0)
+	for rows.Next() {
+		var item ModelCostReportItem
+		var requests, inputTokens, outputTokens, unpriced, coinsCharged sql.NullInt64
+		var cost sql.NullFloat64
+		if err := rows.Scan(&item.Date, &item.Model, &item.Provider, &requests, &inputTokens, &outputTokens, &unpriced, &coinsCharged, &cost); err != nil {
+			return nil, err
+		}
+
+		item.Requests, item.InputTokens, item.OutputTokens = requests.Int64, inputTokens.Int64, outputTokens.Int64
+		item.Unpriced, item.Coins, item.Cost = unpriced.Int64, coinsCharged.Int64, cost.Float64
+		item.Revenue = coins.CoinsToPrice(item.Coins)
+		item.Margin = item.Revenue - item.Cost
+		if item.Revenue > 0 {
+			item.MarginRate = item.Margin / item.Revenue
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Margin < items[j].Margin })
+	return items, nil
+}
@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ModelTrialRepo 高级模型免费试用次数
+type ModelTrialRepo struct {
+	db *sql.DB
+}
+
+// NewModelTrialRepo create a new ModelTrialRepo
+func NewModelTrialRepo(db *sql.DB) *ModelTrialRepo {
+	return &ModelTrialRepo{db: db}
+}
+
+// Acquire 占用一次试用机会，已经使用的次数达到 limit 时返回 false
+func (repo *ModelTrialRepo) Acquire(ctx context.Context, userID int64, model string, limit int64) (bool, error) {
+	if _, err := repo.db.ExecContext(
+		ctx,
+		"INSERT IGNORE INTO model_trial (user_id, model, used, created_at, updated_at) VALUES (?, ?, 0, NOW(), NOW())",
+		userID, model,
+	); err != nil {
+		return false, fmt.Errorf("create model trial failed: %w", err)
+	}
+
+	res, err := repo.db.ExecContext(
+		ctx,
+		"UPDATE model_trial SET used = used + 1, updated_at = NOW() WHERE user_id = ? AND model = ? AND used < ?",
+		userID, model, limit,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Release 退还一次试用机会，用于请求失败没有产生有效回复的情况
+func (repo *ModelTrialRepo) Release(ctx context.Context, userID int64, model string) error {
+	_, err := repo.db.ExecContext(
+		ctx,
+		"UPDATE model_trial SET used = used - 1, updated_at = NOW() WHERE user_id = ? AND model = ? AND used > 0",
+		userID, model,
+	)
+	return err
+}
+
+// UsedByModels 用户在所有模型上已经使用的试用次数，key 为模型 ID
+func (repo *ModelTrialRepo) UsedByModels(ctx context.Context, userID int64) (map[string]int64, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT model, used FROM model_trial WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(map[string]int64)
+	for rows.Next() {
+		var model string
+		var used int64
+		if err := rows.Scan(&model, &used); err != nil {
+			return nil, err
+		}
+
+		ret[model] = used
+	}
+
+	return ret, rows.Err()
+}
@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// 系统通知类型
+const (
+	// NoticeTypeBanner 公告横幅，只展示不限制功能
+	NoticeTypeBanner = "banner"
+	// NoticeTypeMaintenance 维护窗口，维护期间暂停使用指定的功能
+	NoticeTypeMaintenance = "maintenance"
+)
+
+// 系统通知级别
+const (
+	NoticeLevelInfo    = "info"
+	NoticeLevelWarning = "warning"
+	NoticeLevelError   = "error"
+)
+
+// 系统通知状态
+const (
+	NoticeStatusEnabled  = 1
+	NoticeStatusDisabled = 2
+)
+
+// Notice 系统公告与维护窗口
+type Notice struct {
+	ID      int64  `json:"id"`
+	Type    string `json:"type"`
+	Level   string `json:"level"`
+	Title   string `json:"title,omitempty"`
+	Content string `json:"content,omitempty"`
+	// GatedFeatures 维护期间暂停使用的功能，可选值与地区策略限制的功能相同
+	GatedFeatures []string  `json:"gated_features,omitempty"`
+	StartAt       time.Time `json:"start_at"`
+	EndAt         time.Time `json:"end_at"`
+	Status        int64     `json:"status"`
+}
+
+// ActiveAt 通知在指定时间是否生效
+func (n Notice) ActiveAt(t time.Time) bool {
+	return n.Status == NoticeStatusEnabled && !t.Before(n.StartAt) && t.Before(n.EndAt)
+}
+
+// NoticeRepo 系统公告与维护窗口
+type NoticeRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewNoticeRepo create a new NoticeRepo
+func NewNoticeRepo(db *sql.DB, conf *config.Config) *NoticeRepo {
+	return &NoticeRepo{db: db, conf: conf}
+}
+
+// Notices 查询系统通知，onlyUnexpired 为 true 时只返回尚未结束的启用的通知
+func (repo *NoticeRepo) Notices(ctx context.Context, onlyUnexpired bool) ([]Notice, error) {
+	q := query.Builder().OrderBy(model.FieldSystemNoticeStartAt, "DESC")
+	if onlyUnexpired {
+		q = q.Where(model.FieldSystemNoticeStatus, NoticeStatusEnabled).
+			Where(model.FieldSystemNoticeEndAt, ">", time.Now())
+	}
+
+	items, err := model.NewSystemNoticeModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	notices := make([]Notice, 0, len(items))
+	for _, item := range items {
+		notice := Notice{
+			ID:            item.Id.ValueOrZero(),
+			Type:          item.Type.ValueOrZero(),
+			Level:         item.Level.ValueOrZero(),
+			Title:         item.Title.ValueOrZero(),
+			Content:       item.Content.ValueOrZero(),
+			GatedFeatures: []string{},
+			StartAt:       item.StartAt.ValueOrZero(),
+			EndAt:         item.EndAt.ValueOrZero(),
+			Status:        item.Status.ValueOrZero(),
+		}
+
+		if v := item.GatedFeatures.ValueOrZero(); v != "" {
+			if err := json.Unmarshal([]byte(v), &notice.GatedFeatures); err != nil {
+				return nil, fmt.Errorf("unmarshal gated features of notice %d failed: %w", notice.ID, err)
+			}
+		}
+
+		notices = append(notices, notice)
+	}
+
+	return notices, nil
+}
+
+func noticeKV(notice Notice) query.KV {
+	gatedFeatures, _ := json.Marshal(ternary.If(notice.GatedFeatures == nil, []string{}, notice.GatedFeatures))
+	return query.KV{
+		model.FieldSystemNoticeType:          notice.Type,
+		model.FieldSystemNoticeLevel:         notice.Level,
+		model.FieldSystemNoticeTitle:         notice.Title,
+		model.FieldSystemNoticeContent:       notice.Content,
+		model.FieldSystemNoticeGatedFeatures: string(gatedFeatures),
+		model.FieldSystemNoticeStartAt:       notice.StartAt,
+		model.FieldSystemNoticeEndAt:         notice.EndAt,
+		model.FieldSystemNoticeStatus:        notice.Status,
+	}
+}
+
+// CreateNotice 新增系统通知
+func (repo *NoticeRepo) CreateNotice(ctx context.Context, notice Notice) (int64, error) {
+	return model.NewSystemNoticeModel(repo.db).Create(ctx, noticeKV(notice))
+}
+
+// UpdateNotice 更新系统通知
+func (repo *NoticeRepo) UpdateNotice(ctx context.Context, id int64, notice Notice) error {
+	_, err := model.NewSystemNoticeModel(repo.db).UpdateFields(ctx, noticeKV(notice), query.Builder().Where(model.FieldSystemNoticeId, id))
+	return err
+}
+
+// DeleteNotice 删除系统通知
+func (repo *NoticeRepo) DeleteNotice(ctx context.Context, id int64) error {
+	_, err := model.NewSystemNoticeModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldSystemNoticeId, id))
+	return err
+}
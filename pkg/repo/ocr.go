@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+type OCRRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewOCRRepo create a new OCRRepo
+func NewOCRRepo(db *sql.DB, conf *config.Config) *OCRRepo {
+	return &OCRRepo{db: db, conf: conf}
+}
+
+// OCRResultAddReq 文字识别结果
+type OCRResultAddReq struct {
+	UserID int64
+	Engine string
+	Pages  int64
+	// Images 图片地址列表，JSON 格式
+	Images string
+	Text   string
+	// Result 逐页识别结果，JSON 格式
+	Result string
+	// Confidence 平均置信度（百分比）
+	Confidence int64
+	Coins      int64
+}
+
+// AddResult 保存文字识别结果
+func (repo *OCRRepo) AddResult(ctx context.Context, req OCRResultAddReq) (int64, error) {
+	return model.NewOcrResultModel(repo.db).Create(ctx, query.KV{
+		model.FieldOcrResultUserId:     req.UserID,
+		model.FieldOcrResultEngine:     req.Engine,
+		model.FieldOcrResultPages:      req.Pages,
+		model.FieldOcrResultImages:     req.Images,
+		model.FieldOcrResultText:       req.Text,
+		model.FieldOcrResultResult:     req.Result,
+		model.FieldOcrResultConfidence: req.Confidence,
+		model.FieldOcrResultCoins:      req.Coins,
+	})
+}
+
+// GetResult 查询用户的文字识别结果
+func (repo *OCRRepo) GetResult(ctx context.Context, userID, id int64) (*model.OcrResult, error) {
+	res, err := model.NewOcrResultModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldOcrResultId, id).
+		Where(model.FieldOcrResultUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := res.ToOcrResult()
+	return &ret, nil
+}
+
+// GetResults 查询用户最近的文字识别记录
+func (repo *OCRRepo) GetResults(ctx context.Context, userID int64, limit int64) ([]model.OcrResult, error) {
+	q := query.Builder().
+		Select(
+			model.FieldOcrResultId,
+			model.FieldOcrResultUserId,
+			model.FieldOcrResultEngine,
+			model.FieldOcrResultPages,
+			model.FieldOcrResultImages,
+			model.FieldOcrResultConfidence,
+			model.FieldOcrResultCoins,
+			model.FieldOcrResultCreatedAt,
+		).
+		Where(model.FieldOcrResultUserId, userID).
+		OrderBy(model.FieldOcrResultId, "DESC").
+		Limit(limit)
+
+	items, err := model.NewOcrResultModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.OcrResultN, _ int) model.OcrResult {
+		return item.ToOcrResult()
+	}), nil
+}
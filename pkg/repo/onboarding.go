@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 新用户引导内容类型
+const (
+	// OnboardingKindStep 引导步骤，用户完成后记录进度
+	OnboardingKindStep = "step"
+	// OnboardingKindPrompt 示例提示语，可以按照用户角色区分
+	OnboardingKindPrompt = "prompt"
+	// OnboardingKindModel 推荐模型，payload 为模型 ID
+	OnboardingKindModel = "model"
+)
+
+// 新用户引导内容状态
+const (
+	OnboardingStatusEnabled  = 1
+	OnboardingStatusDisabled = 2
+)
+
+// OnboardingItem 新用户引导内容
+type OnboardingItem struct {
+	ID       int64  `json:"id"`
+	Kind     string `json:"kind"`
+	Language string `json:"language"`
+	// Persona 适用的用户角色，为空时适用于全部用户
+	Persona string `json:"persona,omitempty"`
+	Title   string `json:"title"`
+	Content string `json:"content,omitempty"`
+	// Payload 附加数据：推荐模型的 ID，或者引导步骤的跳转地址
+	Payload string `json:"payload,omitempty"`
+	Sort    int64  `json:"sort"`
+	Status  int64  `json:"status"`
+}
+
+// OnboardingRepo 新用户引导内容以及用户的完成进度
+type OnboardingRepo struct {
+	db *sql.DB
+}
+
+// NewOnboardingRepo create a new OnboardingRepo
+func NewOnboardingRepo(db *sql.DB) *OnboardingRepo {
+	return &OnboardingRepo{db: db}
+}
+
+// Items 查询引导内容，按照排序值升序排列，onlyEnabled 为 true 时只返回启用的内容
+func (repo *OnboardingRepo) Items(ctx context.Context, onlyEnabled bool) ([]OnboardingItem, error) {
+	q := query.Builder().OrderBy(model.FieldOnboardingItemSort, "ASC").OrderBy(model.FieldOnboardingItemId, "ASC")
+	if onlyEnabled {
+		q = q.Where(model.FieldOnboardingItemStatus, OnboardingStatusEnabled)
+	}
+
+	items, err := model.NewOnboardingItemModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]OnboardingItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, OnboardingItem{
+			ID:       item.Id.ValueOrZero(),
+			Kind:     item.Kind.ValueOrZero(),
+			Language: item.Language.ValueOrZero(),
+			Persona:  item.Persona.ValueOrZero(),
+			Title:    item.Title.ValueOrZero(),
+			Content:  item.Content.ValueOrZero(),
+			Payload:  item.Payload.ValueOrZero(),
+			Sort:     item.Sort.ValueOrZero(),
+			Status:   item.Status.ValueOrZero(),
+		})
+	}
+
+	return res, nil
+}
+
+func onboardingItemKV(item OnboardingItem) query.KV {
+	return query.KV{
+		model.FieldOnboardingItemKind:     item.Kind,
+		model.FieldOnboardingItemLanguage: item.Language,
+		model.FieldOnboardingItemPersona:  item.Persona,
+		model.FieldOnboardingItemTitle:    item.Title,
+		model.FieldOnboardingItemContent:  item.Content,
+		model.FieldOnboardingItemPayload:  item.Payload,
+		model.FieldOnboardingItemSort:     item.Sort,
+		model.FieldOnboardingItemStatus:   item.Status,
+	}
+}
+
+// CreateItem 新增引导内容
+func (repo *OnboardingRepo) CreateItem(ctx context.Context, item OnboardingItem) (int64, error) {
+	return model.NewOnboardingItemModel(repo.db).Create(ctx, onboardingItemKV(item))
+}
+
+// UpdateItem 更新引导内容
+func (repo *OnboardingRepo) UpdateItem(ctx context.Context, id int64, item OnboardingItem) error {
+	_, err := model.NewOnboardingItemModel(repo.db).UpdateFields(ctx, onboardingItemKV(item), query.Builder().Where(model.FieldOnboardingItemId, id))
+	return err
+}
+
+// DeleteItem 删除引导内容，同时删除用户对该步骤的完成记录
+func (repo *OnboardingRepo) DeleteItem(ctx context.Context, id int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewOnboardingItemModel(tx).Delete(ctx, query.Builder().Where(model.FieldOnboardingItemId, id)); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "DELETE FROM onboarding_progress WHERE item_id = ?", id)
+		return err
+	})
+}
+
+// CompletedSteps 用户已经完成的引导步骤 ID
+func (repo *OnboardingRepo) CompletedSteps(ctx context.Context, userID int64) ([]int64, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT item_id FROM onboarding_progress WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		res = append(res, id)
+	}
+
+	return res, rows.Err()
+}
+
+// CompleteSteps 记录用户完成的引导步骤，重复完成时忽略
+func (repo *OnboardingRepo) CompleteSteps(ctx context.Context, userID int64, stepIDs []int64) error {
+	if len(stepIDs) == 0 {
+		return nil
+	}
+
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		for _, id := range stepIDs {
+			if _, err := tx.ExecContext(
+				ctx,
+				"INSERT IGNORE INTO onboarding_progress (user_id, item_id, created_at, updated_at) VALUES (?, ?, ?, ?)",
+				userID, id, time.Now(), time.Now(),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// CompletionCounts 各个引导步骤的完成人数
+func (repo *OnboardingRepo) CompletionCounts(ctx context.Context) (map[int64]int64, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT item_id, COUNT(*) FROM onboarding_progress GROUP BY item_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int64]int64)
+	for rows.Next() {
+		var id, count int64
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+
+		res[id] = count
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,630 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"gopkg.in/guregu/null.v3"
+)
+
+var (
+	ErrOrgMemberExists = errors.New("user is already a member of the organization")
+)
+
+const (
+	OrgStatusActive   int64 = 1
+	OrgStatusDisabled int64 = 2
+)
+
+const (
+	OrgRoleOwner  int64 = 1
+	OrgRoleMember int64 = 2
+	// OrgRoleAdmin 组织管理员，可以管理组织成员，但不能修改组织策略以及查看组织钱包
+	OrgRoleAdmin int64 = 3
+)
+
+const (
+	OrgMemberStatusInvited int64 = 1
+	OrgMemberStatusActive  int64 = 2
+)
+
+type billingOrgKey struct{}
+
+// WithBillingOrg 返回一个使用组织钱包计费的 context，QuotaRepo 在该 context 下查询和扣除的都是组织配额
+func WithBillingOrg(ctx context.Context, orgID int64) context.Context {
+	return context.WithValue(ctx, billingOrgKey{}, orgID)
+}
+
+// BillingOrgFromContext 返回 context 中使用的计费组织 ID，返回 0 表示使用个人钱包
+func BillingOrgFromContext(ctx context.Context) int64 {
+	if orgID, ok := ctx.Value(billingOrgKey{}).(int64); ok {
+		return orgID
+	}
+
+	return 0
+}
+
+// OrgMonthlyRemaining 计算成员本月还可以使用的组织智慧果，limit 为 0 表示不限制，此时返回 -1
+func OrgMonthlyRemaining(limit, used int64) int64 {
+	if limit <= 0 {
+		return -1
+	}
+
+	if used >= limit {
+		return 0
+	}
+
+	return limit - used
+}
+
+// monthRange 返回 t 所在月份的起止时间
+func monthRange(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 1, 0)
+}
+
+type OrgRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewOrgRepo create a new OrgRepo
+func NewOrgRepo(db *sql.DB, conf *config.Config) *OrgRepo {
+	return &OrgRepo{db: db, conf: conf}
+}
+
+// CreateOrg 创建组织，创建者成为组织的所有者
+func (repo *OrgRepo) CreateOrg(ctx context.Context, ownerID int64, name string) (int64, error) {
+	var orgID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewOrgModel(tx).Create(ctx, query.KV{
+			model.FieldOrgName:    name,
+			model.FieldOrgOwnerId: ownerID,
+			model.FieldOrgStatus:  OrgStatusActive,
+		})
+		if err != nil {
+			return fmt.Errorf("create org failed: %w", err)
+		}
+
+		if _, err := model.NewOrgMemberModel(tx).Create(ctx, query.KV{
+			model.FieldOrgMemberOrgId:  id,
+			model.FieldOrgMemberUserId: ownerID,
+			model.FieldOrgMemberRole:   OrgRoleOwner,
+			model.FieldOrgMemberStatus: OrgMemberStatusActive,
+		}); err != nil {
+			return fmt.Errorf("create org owner failed: %w", err)
+		}
+
+		orgID = id
+		return nil
+	})
+
+	return orgID, err
+}
+
+// Org 查询组织信息
+func (repo *OrgRepo) Org(ctx context.Context, orgID int64) (*model.Org, error) {
+	org, err := model.NewOrgModel(repo.db).First(ctx, query.Builder().Where(model.FieldOrgId, orgID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := org.ToOrg()
+	return &ret, nil
+}
+
+// UserOrg 用户加入的组织
+type UserOrg struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	OwnerID      int64  `json:"owner_id"`
+	Role         int64  `json:"role"`
+	MonthlyLimit int64  `json:"monthly_limit"`
+}
+
+// UserOrgs 查询用户已加入的组织
+func (repo *OrgRepo) UserOrgs(ctx context.Context, userID int64) ([]UserOrg, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT o.id, o.name, o.owner_id, m.role, m.monthly_limit
+FROM org_member m
+INNER JOIN org o ON o.id = m.org_id
+WHERE m.user_id = ? AND m.status = ? AND o.status = ?
+ORDER BY o.id ASC`,
+		userID, OrgMemberStatusActive, OrgStatusActive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query user orgs failed: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := make([]UserOrg, 0)
+	for rows.Next() {
+		var org UserOrg
+		if err := rows.Scan(&org.ID, &org.Name, &org.OwnerID, &org.Role, &org.MonthlyLimit); err != nil {
+			return nil, err
+		}
+
+		orgs = append(orgs, org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// ActiveMember 查询组织中已加入的成员，组织被禁用或者用户不是组织成员时返回 ErrNotFound
+func (repo *OrgRepo) ActiveMember(ctx context.Context, orgID, userID int64) (*model.OrgMember, error) {
+	return activeOrgMember(ctx, repo.db, orgID, userID)
+}
+
+func activeOrgMember(ctx context.Context, db query.Database, orgID, userID int64) (*model.OrgMember, error) {
+	exist, err := model.NewOrgModel(db).Exists(ctx, query.Builder().
+		Where(model.FieldOrgId, orgID).
+		Where(model.FieldOrgStatus, OrgStatusActive),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exist {
+		return nil, ErrNotFound
+	}
+
+	member, err := model.NewOrgMemberModel(db).First(ctx, query.Builder().
+		Where(model.FieldOrgMemberOrgId, orgID).
+		Where(model.FieldOrgMemberUserId, userID).
+		Where(model.FieldOrgMemberStatus, OrgMemberStatusActive),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := member.ToOrgMember()
+	return &ret, nil
+}
+
+// Invite 邀请用户加入组织，用户已经是组织成员或者已被邀请时返回 ErrOrgMemberExists
+func (repo *OrgRepo) Invite(ctx context.Context, orgID, inviterID, userID int64) error {
+	res, err := repo.db.ExecContext(
+		ctx,
+		"INSERT IGNORE INTO org_member (org_id, user_id, role, status, monthly_limit, invited_by, created_at, updated_at) VALUES (?, ?, ?, ?, 0, ?, NOW(), NOW())",
+		orgID, userID, OrgRoleMember, OrgMemberStatusInvited, inviterID,
+	)
+	if err != nil {
+		return fmt.Errorf("invite org member failed: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrOrgMemberExists
+	}
+
+	return nil
+}
+
+// OrgInvitation 用户收到的组织邀请
+type OrgInvitation struct {
+	OrgID     int64     `json:"org_id"`
+	Name      string    `json:"name"`
+	InvitedBy int64     `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Invitations 查询用户收到的还未处理的组织邀请
+func (repo *OrgRepo) Invitations(ctx context.Context, userID int64) ([]OrgInvitation, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT o.id, o.name, m.invited_by, m.created_at
+FROM org_member m
+INNER JOIN org o ON o.id = m.org_id
+WHERE m.user_id = ? AND m.status = ? AND o.status = ?
+ORDER BY m.id DESC`,
+		userID, OrgMemberStatusInvited, OrgStatusActive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query org invitations failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]OrgInvitation, 0)
+	for rows.Next() {
+		var item OrgInvitation
+		if err := rows.Scan(&item.OrgID, &item.Name, &item.InvitedBy, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// AcceptInvitation 接受组织邀请，没有对应的邀请时返回 ErrNotFound
+func (repo *OrgRepo) AcceptInvitation(ctx context.Context, orgID, userID int64) error {
+	affected, err := model.NewOrgMemberModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldOrgMemberStatus: OrgMemberStatusActive},
+		query.Builder().
+			Where(model.FieldOrgMemberOrgId, orgID).
+			Where(model.FieldOrgMemberUserId, userID).
+			Where(model.FieldOrgMemberStatus, OrgMemberStatusInvited),
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RemoveMember 移除组织成员（包括拒绝邀请、退出组织），组织所有者不能被移除
+func (repo *OrgRepo) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	_, err := model.NewOrgMemberModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldOrgMemberOrgId, orgID).
+		Where(model.FieldOrgMemberUserId, userID).
+		Where(model.FieldOrgMemberRole, "!=", OrgRoleOwner),
+	)
+	return err
+}
+
+// UpdateMemberLimit 设置成员每月可使用的组织智慧果上限，0 表示不限制
+func (repo *OrgRepo) UpdateMemberLimit(ctx context.Context, orgID, userID, limit int64) error {
+	affected, err := model.NewOrgMemberModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldOrgMemberMonthlyLimit: limit},
+		query.Builder().
+			Where(model.FieldOrgMemberOrgId, orgID).
+			Where(model.FieldOrgMemberUserId, userID),
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// OrgMemberItem 组织成员
+type OrgMemberItem struct {
+	UserID       int64     `json:"user_id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email,omitempty"`
+	Phone        string    `json:"phone,omitempty"`
+	Role         int64     `json:"role"`
+	Status       int64     `json:"status"`
+	MonthlyLimit int64     `json:"monthly_limit"`
+	MonthUsed    int64     `json:"month_used"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Members 查询组织的所有成员（包括已邀请但还未加入的用户），以及成员本月使用的组织智慧果
+func (repo *OrgRepo) Members(ctx context.Context, orgID int64) ([]OrgMemberItem, error) {
+	start, end := monthRange(time.Now())
+	rows, err := repo.db.QueryContext(ctx, `SELECT m.user_id, COALESCE(u.realname, ''), COALESCE(u.email, ''), COALESCE(u.phone, ''), m.role, m.status, m.monthly_limit, m.created_at,
+	(SELECT COALESCE(SUM(qu.used), 0) FROM org_quota_usage qu WHERE qu.org_id = m.org_id AND qu.user_id = m.user_id AND qu.created_at >= ? AND qu.created_at < ?)
+FROM org_member m
+LEFT JOIN users u ON u.id = m.user_id
+WHERE m.org_id = ?
+ORDER BY m.role ASC, m.id ASC`,
+		start, end, orgID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query org members failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]OrgMemberItem, 0)
+	for rows.Next() {
+		var item OrgMemberItem
+		if err := rows.Scan(&item.UserID, &item.Name, &item.Email, &item.Phone, &item.Role, &item.Status, &item.MonthlyLimit, &item.CreatedAt, &item.MonthUsed); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// MemberMonthUsed 查询成员本月已经使用的组织智慧果
+func (repo *OrgRepo) MemberMonthUsed(ctx context.Context, orgID, userID int64) (int64, error) {
+	return orgMemberMonthUsed(ctx, repo.db, orgID, userID)
+}
+
+func orgMemberMonthUsed(ctx context.Context, db query.Database, orgID, userID int64) (int64, error) {
+	start, end := monthRange(time.Now())
+
+	rows, err := db.QueryContext(
+		ctx,
+		"SELECT SUM(used) FROM org_quota_usage WHERE org_id = ? AND user_id = ? AND created_at >= ? AND created_at < ?",
+		orgID, userID, start, end,
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var used sql.NullInt64
+	if rows.Next() {
+		if err := rows.Scan(&used); err != nil {
+			return 0, err
+		}
+	}
+
+	return used.Int64, rows.Err()
+}
+
+// AddOrgQuota 为组织的共享钱包增加配额
+func (repo *OrgRepo) AddOrgQuota(ctx context.Context, orgID int64, quotaValue int64, endAt time.Time, note, paymentID string) (int64, error) {
+	var id int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		var err error
+		id, err = model.NewOrgQuotaModel(tx).Create(ctx, query.KV{
+			model.FieldOrgQuotaOrgId:         orgID,
+			model.FieldOrgQuotaQuota:         quotaValue,
+			model.FieldOrgQuotaRest:          quotaValue,
+			model.FieldOrgQuotaNote:          note,
+			model.FieldOrgQuotaPaymentId:     paymentID,
+			model.FieldOrgQuotaPeriodStartAt: NowInDate(),
+			model.FieldOrgQuotaPeriodEndAt:   TimeInDate(endAt),
+		})
+		if err != nil {
+			return err
+		}
+
+		return postLedger(ctx, tx, issueLedger(LedgerKindGift, LedgerAccountGift, LedgerAccountOrgWallet, orgID, quotaValue, fmt.Sprintf("org_quota:%d", id), note))
+	})
+
+	return id, err
+}
+
+// OrgQuota 查询组织共享钱包中未过期的配额汇总
+func (repo *OrgRepo) OrgQuota(ctx context.Context, orgID int64) (*QuotaSummary, error) {
+	return orgQuotaSummary(ctx, repo.db, orgID)
+}
+
+func orgQuotaSummary(ctx context.Context, db query.Database, orgID int64) (*QuotaSummary, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		"SELECT SUM(quota), SUM(rest) FROM org_quota WHERE org_id = ? AND period_end_at > ?",
+		orgID, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var quota, rest sql.NullInt64
+	if rows.Next() {
+		if err := rows.Scan(&quota, &rest); err != nil {
+			return nil, err
+		}
+	}
+
+	return &QuotaSummary{Quota: quota.Int64, Rest: rest.Int64, Used: quota.Int64 - rest.Int64}, rows.Err()
+}
+
+// orgMemberQuota 查询成员可以使用的组织配额，剩余配额为组织剩余配额与成员本月剩余额度中的较小值
+func orgMemberQuota(ctx context.Context, db query.Database, orgID, userID int64) (*QuotaSummary, error) {
+	member, err := activeOrgMember(ctx, db, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &QuotaSummary{}, nil
+		}
+
+		return nil, err
+	}
+
+	summary, err := orgQuotaSummary(ctx, db, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := orgMemberMonthUsed(ctx, db, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if remain := OrgMonthlyRemaining(member.MonthlyLimit, used); remain >= 0 && remain < summary.Rest {
+		summary.Rest = remain
+		summary.Used = summary.Quota - summary.Rest
+	}
+
+	return summary, nil
+}
+
+// orgQuotaConsume 从组织的共享钱包中扣除成员使用的配额，优先使用最早过期的配额，配额不足的部分记录为组织欠费
+func orgQuotaConsume(ctx context.Context, db *sql.DB, orgID, userID int64, used int64, meta QuotaUsedMeta) error {
+	relatedQuotaIds := make(map[int64]int64)
+	var debt int64
+
+	err := eloquent.Transaction(db, func(tx query.Database) error {
+		usedVar := used
+		quotas, err := model.NewOrgQuotaModel(tx).Get(ctx, query.Builder().
+			Where(model.FieldOrgQuotaOrgId, orgID).
+			Where(model.FieldOrgQuotaRest, ">", 0).
+			Where(model.FieldOrgQuotaPeriodEndAt, ">", time.Now()).
+			OrderBy(model.FieldOrgQuotaPeriodEndAt, "ASC"),
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, quota := range quotas {
+			quotaID := quota.Id.ValueOrZero()
+			rest := quota.Rest.ValueOrZero()
+			if rest >= usedVar {
+				relatedQuotaIds[quotaID] = usedVar
+				if _, err := tx.ExecContext(ctx, "UPDATE org_quota SET rest = rest - ? WHERE id = ?", usedVar, quotaID); err != nil {
+					return err
+				}
+
+				usedVar = 0
+				break
+			}
+
+			relatedQuotaIds[quotaID] = rest
+			if _, err := tx.ExecContext(ctx, "UPDATE org_quota SET rest = 0 WHERE id = ?", quotaID); err != nil {
+				return err
+			}
+
+			usedVar -= rest
+		}
+
+		debt = usedVar
+		return postLedger(ctx, tx, consumeLedger(LedgerAccountOrgWallet, LedgerAccountOrgDebt, orgID, used, debt, meta.Tag))
+	})
+	if err != nil {
+		return err
+	}
+
+	log.F(log.M{
+		"org_id":    orgID,
+		"user_id":   userID,
+		"used":      used,
+		"quota_ids": relatedQuotaIds,
+		"debt":      debt,
+		"meta":      meta,
+	}).Info("org quota consumed")
+
+	quotaIdsBytes, _ := json.Marshal(relatedQuotaIds)
+	metaBytes, _ := json.Marshal(meta)
+
+	if _, err := model.NewOrgQuotaUsageModel(db).Save(ctx, model.OrgQuotaUsageN{
+		OrgId:    null.IntFrom(orgID),
+		UserId:   null.IntFrom(userID),
+		Used:     null.IntFrom(used),
+		Debt:     null.IntFrom(debt),
+		QuotaIds: null.StringFrom(string(quotaIdsBytes)),
+		Meta:     null.StringFrom(string(metaBytes)),
+	}); err != nil {
+		log.F(log.M{"org_id": orgID, "user_id": userID, "err": err}).Error("save org quota usage failed")
+	}
+
+	return nil
+}
+
+// BindPayment 将支付订单关联到组织，支付完成后智慧果充值到组织的共享钱包
+func (repo *OrgRepo) BindPayment(ctx context.Context, orgID, userID int64, paymentID string) error {
+	_, err := model.NewOrgPaymentModel(repo.db).Create(ctx, query.KV{
+		model.FieldOrgPaymentPaymentId: paymentID,
+		model.FieldOrgPaymentOrgId:     orgID,
+		model.FieldOrgPaymentUserId:    userID,
+	})
+	return err
+}
+
+// PaymentOrg 查询支付订单关联的组织 ID，订单不是为组织充值时返回 ErrNotFound
+func (repo *OrgRepo) PaymentOrg(ctx context.Context, paymentID string) (int64, error) {
+	pay, err := model.NewOrgPaymentModel(repo.db).First(ctx, query.Builder().Where(model.FieldOrgPaymentPaymentId, paymentID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return 0, ErrNotFound
+		}
+
+		return 0, err
+	}
+
+	return pay.OrgId.ValueOrZero(), nil
+}
+
+// OrgMemberUsage 组织成员的使用统计
+type OrgMemberUsage struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+	Used   int64  `json:"used"`
+	Count  int64  `json:"count"`
+}
+
+// OrgDailyUsage 组织每日的使用统计
+type OrgDailyUsage struct {
+	Date string `json:"date"`
+	Used int64  `json:"used"`
+}
+
+// OrgUsageReport 组织的汇总使用报告
+type OrgUsageReport struct {
+	Total   int64            `json:"total"`
+	Debt    int64            `json:"debt"`
+	Members []OrgMemberUsage `json:"members"`
+	Daily   []OrgDailyUsage  `json:"daily"`
+}
+
+// UsageReport 查询组织在 [startAt, endAt) 时间范围内的汇总使用报告
+func (repo *OrgRepo) UsageReport(ctx context.Context, orgID int64, startAt, endAt time.Time) (*OrgUsageReport, error) {
+	report := OrgUsageReport{Members: make([]OrgMemberUsage, 0), Daily: make([]OrgDailyUsage, 0)}
+
+	rows, err := repo.db.QueryContext(ctx, `SELECT qu.user_id, COALESCE(u.realname, ''), SUM(qu.used), COUNT(*), SUM(qu.debt)
+FROM org_quota_usage qu
+LEFT JOIN users u ON u.id = qu.user_id
+WHERE qu.org_id = ? AND qu.created_at >= ? AND qu.created_at < ?
+GROUP BY qu.user_id, u.realname
+ORDER BY SUM(qu.used) DESC`,
+		orgID, startAt, endAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query org member usage failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item OrgMemberUsage
+		var debt int64
+		if err := rows.Scan(&item.UserID, &item.Name, &item.Used, &item.Count, &debt); err != nil {
+			return nil, err
+		}
+
+		report.Total += item.Used
+		report.Debt += debt
+		report.Members = append(report.Members, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	dailyRows, err := repo.db.QueryContext(ctx, `SELECT DATE_FORMAT(created_at, '%Y-%m-%d') AS d, SUM(used)
+FROM org_quota_usage
+WHERE org_id = ? AND created_at >= ? AND created_at < ?
+GROUP BY d
+ORDER BY d ASC`,
+		orgID, startAt, endAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query org daily usage failed: %w", err)
+	}
+	defer dailyRows.Close()
+
+	for dailyRows.Next() {
+		var item OrgDailyUsage
+		if err := dailyRows.Scan(&item.Date, &item.Used); err != nil {
+			return nil, err
+		}
+
+		report.Daily = append(report.Daily, item)
+	}
+
+	return &report, dailyRows.Err()
+}
@@ -0,0 +1,218 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+)
+
+// 组织域名的审计日志操作类型
+const (
+	OrgAuditDomainAdded    = "domain_added"
+	OrgAuditDomainVerified = "domain_verified"
+	OrgAuditDomainRemoved  = "domain_removed"
+)
+
+var (
+	ErrOrgDomainExists = errors.New("domain already exists in the organization")
+	// ErrOrgDomainVerifiedByOther 同一个域名只能被一个组织验证
+	ErrOrgDomainVerifiedByOther = errors.New("domain has been verified by another organization")
+)
+
+// OrgDomain 组织的邮箱域名，在域名的 DNS TXT 记录中添加验证值后完成所有权验证
+type OrgDomain struct {
+	ID         int64      `json:"id"`
+	OrgID      int64      `json:"org_id"`
+	Domain     string     `json:"domain"`
+	Token      string     `json:"token"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Verified 域名是否已经通过验证
+func (d OrgDomain) Verified() bool {
+	return d.VerifiedAt != nil
+}
+
+// AddDomain 为组织添加待验证的邮箱域名
+func (repo *OrgRepo) AddDomain(ctx context.Context, orgID int64, domain string) (*OrgDomain, error) {
+	token := strings.ReplaceAll(misc.UUID(), "-", "")
+	res, err := repo.db.ExecContext(
+		ctx,
+		"INSERT IGNORE INTO org_domain (org_id, domain, token, created_at, updated_at) VALUES (?, ?, ?, NOW(), NOW())",
+		orgID, domain, token,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("add org domain failed: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, ErrOrgDomainExists
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.Domain(ctx, orgID, id)
+}
+
+func scanOrgDomain(scanner interface{ Scan(dest ...any) error }) (*OrgDomain, error) {
+	var item OrgDomain
+	var verifiedAt sql.NullTime
+	if err := scanner.Scan(&item.ID, &item.OrgID, &item.Domain, &item.Token, &verifiedAt, &item.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if verifiedAt.Valid {
+		item.VerifiedAt = &verifiedAt.Time
+	}
+
+	return &item, nil
+}
+
+// Domains 查询组织的所有邮箱域名
+func (repo *OrgRepo) Domains(ctx context.Context, orgID int64) ([]OrgDomain, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT id, org_id, domain, token, verified_at, created_at FROM org_domain WHERE org_id = ? ORDER BY id", orgID)
+	if err != nil {
+		return nil, fmt.Errorf("query org domains failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]OrgDomain, 0)
+	for rows.Next() {
+		item, err := scanOrgDomain(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, *item)
+	}
+
+	return items, rows.Err()
+}
+
+// Domain 查询组织的邮箱域名
+func (repo *OrgRepo) Domain(ctx context.Context, orgID, domainID int64) (*OrgDomain, error) {
+	item, err := scanOrgDomain(repo.db.QueryRowContext(
+		ctx,
+		"SELECT id, org_id, domain, token, verified_at, created_at FROM org_domain WHERE org_id = ? AND id = ?",
+		orgID, domainID,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// MarkDomainVerified 将域名标记为已验证，域名已经被其它组织验证时返回 ErrOrgDomainVerifiedByOther
+func (repo *OrgRepo) MarkDomainVerified(ctx context.Context, orgID, domainID int64) error {
+	domain, err := repo.Domain(ctx, orgID, domainID)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := repo.db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM org_domain WHERE domain = ? AND org_id <> ? AND verified_at IS NOT NULL",
+		domain.Domain, orgID,
+	).Scan(&count); err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return ErrOrgDomainVerifiedByOther
+	}
+
+	if _, err := repo.db.ExecContext(
+		ctx,
+		"UPDATE org_domain SET verified_at = NOW(), updated_at = NOW() WHERE org_id = ? AND id = ? AND verified_at IS NULL",
+		orgID, domainID,
+	); err != nil {
+		return fmt.Errorf("verify org domain failed: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveDomain 删除组织的邮箱域名
+func (repo *OrgRepo) RemoveDomain(ctx context.Context, orgID, domainID int64) error {
+	res, err := repo.db.ExecContext(ctx, "DELETE FROM org_domain WHERE org_id = ? AND id = ?", orgID, domainID)
+	if err != nil {
+		return fmt.Errorf("remove org domain failed: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// EmailInVerifiedDomain 邮箱是否属于组织已经验证的域名
+func (repo *OrgRepo) EmailInVerifiedDomain(ctx context.Context, orgID int64, email string) (bool, error) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false, nil
+	}
+
+	var count int64
+	if err := repo.db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM org_domain WHERE org_id = ? AND domain = ? AND verified_at IS NOT NULL",
+		orgID, strings.ToLower(email[at+1:]),
+	).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// MarkProvisionedAccount 记录由组织的成员同步接口创建的账号
+func (repo *OrgRepo) MarkProvisionedAccount(ctx context.Context, orgID, userID int64) error {
+	if _, err := repo.db.ExecContext(
+		ctx,
+		"INSERT IGNORE INTO org_provisioned_account (org_id, user_id, created_at, updated_at) VALUES (?, ?, NOW(), NOW())",
+		orgID, userID,
+	); err != nil {
+		return fmt.Errorf("mark provisioned account failed: %w", err)
+	}
+
+	return nil
+}
+
+// ProvisionedAccount 账号是否由组织的成员同步接口创建
+func (repo *OrgRepo) ProvisionedAccount(ctx context.Context, orgID, userID int64) (bool, error) {
+	var count int64
+	if err := repo.db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM org_provisioned_account WHERE org_id = ? AND user_id = ?",
+		orgID, userID,
+	).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
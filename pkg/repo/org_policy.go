@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// 组织审计日志的操作类型
+const (
+	OrgAuditPolicyUpdated      = "policy_updated"
+	OrgAuditPolicyViolation    = "policy_violation"
+	OrgAuditMemberInvited      = "member_invited"
+	OrgAuditMemberRemoved      = "member_removed"
+	OrgAuditMemberLimitUpdated = "member_limit_updated"
+)
+
+// OrgPolicy 组织策略，对使用组织钱包计费的请求生效
+type OrgPolicy struct {
+	// AllowedModels 允许成员使用的模型，为空表示不限制
+	AllowedModels []string `json:"allowed_models"`
+	// BlockedWords 组织自定义的敏感词，请求内容包含任意一个时拒绝请求
+	BlockedWords []string `json:"blocked_words"`
+	// StrictSafety 严格的内容安全策略，内容安全检测结果存在任何风险时都拒绝请求
+	StrictSafety bool `json:"strict_safety"`
+}
+
+// ModelAllowed 模型是否允许组织成员使用
+func (p OrgPolicy) ModelAllowed(modelID string) bool {
+	if len(p.AllowedModels) == 0 {
+		return true
+	}
+
+	return array.In(modelID, p.AllowedModels)
+}
+
+// MatchBlockedWord 返回内容中包含的第一个组织敏感词（不区分大小写），不包含时返回空字符串
+func (p OrgPolicy) MatchBlockedWord(content string) string {
+	lower := strings.ToLower(content)
+	for _, word := range p.BlockedWords {
+		if word != "" && strings.Contains(lower, strings.ToLower(word)) {
+			return word
+		}
+	}
+
+	return ""
+}
+
+// Policy 查询组织策略，组织没有配置策略时返回空策略（不做任何限制）
+func (repo *OrgRepo) Policy(ctx context.Context, orgID int64) (*OrgPolicy, error) {
+	item, err := model.NewOrgPolicyModel(repo.db).First(ctx, query.Builder().Where(model.FieldOrgPolicyOrgId, orgID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return &OrgPolicy{AllowedModels: []string{}, BlockedWords: []string{}}, nil
+		}
+
+		return nil, err
+	}
+
+	policy := OrgPolicy{AllowedModels: []string{}, BlockedWords: []string{}, StrictSafety: item.StrictSafety.ValueOrZero() == 1}
+	if v := item.AllowedModels.ValueOrZero(); v != "" {
+		if err := json.Unmarshal([]byte(v), &policy.AllowedModels); err != nil {
+			return nil, fmt.Errorf("unmarshal allowed models failed: %w", err)
+		}
+	}
+
+	if v := item.BlockedWords.ValueOrZero(); v != "" {
+		if err := json.Unmarshal([]byte(v), &policy.BlockedWords); err != nil {
+			return nil, fmt.Errorf("unmarshal blocked words failed: %w", err)
+		}
+	}
+
+	return &policy, nil
+}
+
+// UpdatePolicy 更新组织策略
+func (repo *OrgRepo) UpdatePolicy(ctx context.Context, orgID int64, policy OrgPolicy) error {
+	allowedModels, _ := json.Marshal(ternary.If(policy.AllowedModels == nil, []string{}, policy.AllowedModels))
+	blockedWords, _ := json.Marshal(ternary.If(policy.BlockedWords == nil, []string{}, policy.BlockedWords))
+
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO org_policy (org_id, allowed_models, blocked_words, strict_safety, created_at, updated_at) VALUES (?, ?, ?, ?, NOW(), NOW()) ON DUPLICATE KEY UPDATE allowed_models = VALUES(allowed_models), blocked_words = VALUES(blocked_words), strict_safety = VALUES(strict_safety), updated_at = NOW()",
+		orgID, string(allowedModels), string(blockedWords), ternary.If(policy.StrictSafety, 1, 0),
+	)
+	return err
+}
+
+// AddAuditLog 记录组织审计日志
+func (repo *OrgRepo) AddAuditLog(ctx context.Context, orgID, userID int64, action, detail string) error {
+	_, err := model.NewOrgAuditLogModel(repo.db).Create(ctx, query.KV{
+		model.FieldOrgAuditLogOrgId:  orgID,
+		model.FieldOrgAuditLogUserId: userID,
+		model.FieldOrgAuditLogAction: action,
+		model.FieldOrgAuditLogDetail: detail,
+	})
+	return err
+}
+
+// AuditLogs 查询组织最近的审计日志，action 为空时查询所有类型
+func (repo *OrgRepo) AuditLogs(ctx context.Context, orgID int64, action string, limit int64) ([]model.OrgAuditLog, error) {
+	q := query.Builder().
+		Where(model.FieldOrgAuditLogOrgId, orgID).
+		OrderBy(model.FieldOrgAuditLogId, "DESC").
+		Limit(limit)
+	if action != "" {
+		q = q.Where(model.FieldOrgAuditLogAction, action)
+	}
+
+	items, err := model.NewOrgAuditLogModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.OrgAuditLogN, _ int) model.OrgAuditLog {
+		return item.ToOrgAuditLog()
+	}), nil
+}
@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/guregu/null.v3"
+)
+
+// OrgMemberStatusDisabled 被 HR/IdP 系统停用的成员，不能再使用组织钱包，重新启用后恢复
+const OrgMemberStatusDisabled int64 = 3
+
+const (
+	OrgProvisionTokenStatusActive   int64 = 1
+	OrgProvisionTokenStatusDisabled int64 = 2
+)
+
+// 成员同步接口的审计日志操作类型
+const (
+	OrgAuditMemberProvisioned      = "member_provisioned"
+	OrgAuditMemberDeprovisioned    = "member_deprovisioned"
+	OrgAuditProvisionTokenCreated  = "provision_token_created"
+	OrgAuditProvisionTokenDisabled = "provision_token_disabled"
+)
+
+var ErrOrgOwnerImmutable = errors.New("organization owner can not be modified by provisioning")
+
+// CreateProvisionToken 创建成员同步接口的访问 Token，返回 Token 明文
+func (repo *OrgRepo) CreateProvisionToken(ctx context.Context, orgID, userID int64, name string) (string, error) {
+	token := fmt.Sprintf("scim-%s", misc.GenerateAPIToken(name, orgID))
+	_, err := model.NewOrgProvisionTokenModel(repo.db).Create(ctx, query.KV{
+		model.FieldOrgProvisionTokenOrgId:     orgID,
+		model.FieldOrgProvisionTokenName:      name,
+		model.FieldOrgProvisionTokenToken:     token,
+		model.FieldOrgProvisionTokenStatus:    OrgProvisionTokenStatusActive,
+		model.FieldOrgProvisionTokenCreatedBy: userID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create provision token failed: %w", err)
+	}
+
+	return token, nil
+}
+
+// ProvisionTokens 查询组织所有启用的成员同步 Token，不返回 Token 明文
+func (repo *OrgRepo) ProvisionTokens(ctx context.Context, orgID int64) ([]model.OrgProvisionToken, error) {
+	items, err := model.NewOrgProvisionTokenModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldOrgProvisionTokenOrgId, orgID).
+		Where(model.FieldOrgProvisionTokenStatus, OrgProvisionTokenStatusActive).
+		OrderBy(model.FieldOrgProvisionTokenId, "DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.OrgProvisionTokenN, _ int) model.OrgProvisionToken {
+		ret := item.ToOrgProvisionToken()
+		ret.Token = ""
+		return ret
+	}), nil
+}
+
+// DisableProvisionToken 禁用成员同步 Token
+func (repo *OrgRepo) DisableProvisionToken(ctx context.Context, orgID, tokenID int64) error {
+	affected, err := model.NewOrgProvisionTokenModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldOrgProvisionTokenStatus: OrgProvisionTokenStatusDisabled},
+		query.Builder().
+			Where(model.FieldOrgProvisionTokenOrgId, orgID).
+			Where(model.FieldOrgProvisionTokenId, tokenID),
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ProvisionTokenOrg 查询成员同步 Token 所属的组织，Token 无效或者组织被禁用时返回 ErrNotFound
+func (repo *OrgRepo) ProvisionTokenOrg(ctx context.Context, token string) (*model.OrgProvisionToken, error) {
+	item, err := model.NewOrgProvisionTokenModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldOrgProvisionTokenToken, token).
+		Where(model.FieldOrgProvisionTokenStatus, OrgProvisionTokenStatusActive),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	org, err := repo.Org(ctx, item.OrgId.ValueOrZero())
+	if err != nil {
+		return nil, err
+	}
+
+	if org.Status != OrgStatusActive {
+		return nil, ErrNotFound
+	}
+
+	item.LastUsedAt = null.TimeFrom(time.Now())
+	if err := item.Save(ctx, model.FieldOrgProvisionTokenLastUsedAt); err != nil {
+		log.F(log.M{"org_id": org.Id, "token_id": item.Id.ValueOrZero()}).Warningf("update provision token last used time failed: %v", err)
+	}
+
+	ret := item.ToOrgProvisionToken()
+	return &ret, nil
+}
+
+// OrgMember 查询组织成员，包括已邀请、已停用的成员
+func (repo *OrgRepo) OrgMember(ctx context.Context, orgID, userID int64) (*model.OrgMember, error) {
+	member, err := model.NewOrgMemberModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldOrgMemberOrgId, orgID).
+		Where(model.FieldOrgMemberUserId, userID),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := member.ToOrgMember()
+	return &ret, nil
+}
+
+// ProvisionMember 由 HR/IdP 系统直接设置成员的角色、状态与每月额度，不需要用户接受邀请，组织所有者不能被修改
+func (repo *OrgRepo) ProvisionMember(ctx context.Context, orgID, userID, role, status, monthlyLimit int64) error {
+	if role == OrgRoleOwner {
+		return ErrOrgOwnerImmutable
+	}
+
+	member, err := repo.OrgMember(ctx, orgID, userID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if member != nil && member.Role == OrgRoleOwner {
+		return ErrOrgOwnerImmutable
+	}
+
+	_, err = repo.db.ExecContext(
+		ctx,
+		`INSERT INTO org_member (org_id, user_id, role, status, monthly_limit, invited_by, created_at, updated_at) VALUES (?, ?, ?, ?, ?, 0, NOW(), NOW())
+ON DUPLICATE KEY UPDATE role = VALUES(role), status = VALUES(status), monthly_limit = VALUES(monthly_limit), updated_at = NOW()`,
+		orgID, userID, role, status, monthlyLimit,
+	)
+	if err != nil {
+		return fmt.Errorf("provision org member failed: %w", err)
+	}
+
+	return nil
+}
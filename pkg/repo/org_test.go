@@ -0,0 +1,52 @@
+package repo_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestOrgMonthlyRemaining(t *testing.T) {
+	assert.Equal(t, int64(-1), repo.OrgMonthlyRemaining(0, 100))
+	assert.Equal(t, int64(70), repo.OrgMonthlyRemaining(100, 30))
+	assert.Equal(t, int64(0), repo.OrgMonthlyRemaining(100, 100))
+	assert.Equal(t, int64(0), repo.OrgMonthlyRemaining(100, 150))
+}
+
+func TestBillingOrgContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, int64(0), repo.BillingOrgFromContext(ctx))
+	assert.Equal(t, int64(12), repo.BillingOrgFromContext(repo.WithBillingOrg(ctx, 12)))
+}
+
+func TestOrgPolicy(t *testing.T) {
+	empty := repo.OrgPolicy{}
+	assert.True(t, empty.ModelAllowed("gpt-4"))
+	assert.Equal(t, "", empty.MatchBlockedWord("anything"))
+
+	policy := repo.OrgPolicy{
+		AllowedModels: []string{"gpt-3.5-turbo", "gpt-4"},
+		BlockedWords:  []string{"", "Secret"},
+	}
+	assert.True(t, policy.ModelAllowed("gpt-4"))
+	assert.False(t, policy.ModelAllowed("claude-2"))
+	assert.Equal(t, "Secret", policy.MatchBlockedWord("this is a top SECRET plan"))
+	assert.Equal(t, "", policy.MatchBlockedWord("nothing to see"))
+}
+
+func TestOrgUsageCSV(t *testing.T) {
+	data, err := repo.OrgUsageCSV([]repo.OrgUsageRow{
+		{Date: "2024-01-01", UserID: 1, Name: "张三", Email: "a@example.com", Model: "gpt-4", Requests: 3, Coins: 120},
+		{Date: "2024-01-01", UserID: 2, Name: "=HYPERLINK(\"x\")", Model: "gpt-3.5-turbo", Requests: 1, Coins: 5, Debt: 2},
+	})
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(string(data), "\xEF\xBB\xBF")), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.Equal(t, "date,user_id,name,email,model,requests,coins,debt", lines[0])
+	assert.Equal(t, "2024-01-01,1,张三,a@example.com,gpt-4,3,120,0", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], `2024-01-01,2,"'=HYPERLINK(""x"")"`))
+}
@@ -0,0 +1,285 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 组织使用报告的发送周期
+const (
+	OrgUsageFrequencyWeekly  = "weekly"
+	OrgUsageFrequencyMonthly = "monthly"
+)
+
+// OrgAuditUsageSubscriptionUpdated 修改使用报告订阅的审计日志操作类型
+const OrgAuditUsageSubscriptionUpdated = "usage_subscription_updated"
+
+// orgUsageAggregateBatch 汇总组织使用记录时，每次读取的记录数
+const orgUsageAggregateBatch = 1000
+
+// OrgUsageRow 组织成员每天每个模型的使用统计，用于内部成本分摊
+type OrgUsageRow struct {
+	Date     string `json:"date"`
+	UserID   int64  `json:"user_id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Model    string `json:"model"`
+	Requests int64  `json:"requests"`
+	Coins    int64  `json:"coins"`
+	Debt     int64  `json:"debt"`
+}
+
+type orgUsageKey struct {
+	orgID  int64
+	userID int64
+	model  string
+}
+
+type orgUsageValue struct {
+	requests int64
+	coins    int64
+	debt     int64
+}
+
+// AggregateUsage 汇总组织成员某一天的使用记录，按成员与模型分组，重复执行时覆盖当天的汇总结果
+func (repo *OrgRepo) AggregateUsage(ctx context.Context, date time.Time) error {
+	statDate := date.Format("2006-01-02")
+	startTime := statDate + " 00:00:00"
+	endTime := date.AddDate(0, 0, 1).Format("2006-01-02") + " 00:00:00"
+
+	// 使用的模型记录在 meta 中，一次请求使用了多个模型时计入第一个模型
+	stats := make(map[orgUsageKey]*orgUsageValue)
+	var lastID int64
+	for {
+		n, err := repo.scanUsage(ctx, startTime, endTime, lastID, func(id, orgID, userID, used, debt int64, meta string) {
+			lastID = id
+
+			var m QuotaUsedMeta
+			_ = json.Unmarshal([]byte(meta), &m)
+
+			key := orgUsageKey{orgID: orgID, userID: userID}
+			if len(m.Models) > 0 {
+				key.model = m.Models[0]
+			}
+
+			val, ok := stats[key]
+			if !ok {
+				val = &orgUsageValue{}
+				stats[key] = val
+			}
+
+			val.requests++
+			val.coins += used
+			val.debt += debt
+		})
+		if err != nil {
+			return err
+		}
+
+		if n < orgUsageAggregateBatch {
+			break
+		}
+	}
+
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM org_usage_daily WHERE stat_date = ?", statDate); err != nil {
+			return fmt.Errorf("delete org usage daily failed: %w", err)
+		}
+
+		for key, val := range stats {
+			if _, err := tx.ExecContext(
+				ctx,
+				"INSERT INTO org_usage_daily (org_id, stat_date, user_id, model, requests, coins, debt, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())",
+				key.orgID, statDate, key.userID, key.model, val.requests, val.coins, val.debt,
+			); err != nil {
+				return fmt.Errorf("insert org usage daily failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (repo *OrgRepo) scanUsage(ctx context.Context, startTime, endTime string, afterID int64, cb func(id, orgID, userID, used, debt int64, meta string)) (int, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT id, org_id, user_id, used, debt, COALESCE(meta, '') FROM org_quota_usage WHERE created_at >= ? AND created_at < ? AND id > ? ORDER BY id ASC LIMIT ?",
+		startTime, endTime, afterID, orgUsageAggregateBatch,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("query org quota usage failed: %w", err)
+	}
+	defer rows.Close()
+
+	var n int
+	for rows.Next() {
+		var id, orgID, userID, used, debt int64
+		var meta string
+		if err := rows.Scan(&id, &orgID, &userID, &used, &debt, &meta); err != nil {
+			return n, err
+		}
+
+		cb(id, orgID, userID, used, debt, meta)
+		n++
+	}
+
+	return n, rows.Err()
+}
+
+// UsageExport 查询组织在 [startDate, endDate] 日期范围内已汇总的使用统计，不包含当天的数据
+func (repo *OrgRepo) UsageExport(ctx context.Context, orgID int64, startDate, endDate time.Time) ([]OrgUsageRow, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT DATE_FORMAT(d.stat_date, '%Y-%m-%d'), d.user_id, COALESCE(u.realname, ''), COALESCE(u.email, ''), d.model, d.requests, d.coins, d.debt
+FROM org_usage_daily d
+LEFT JOIN users u ON u.id = d.user_id
+WHERE d.org_id = ? AND d.stat_date >= ? AND d.stat_date <= ?
+ORDER BY d.stat_date ASC, d.user_id ASC, d.model ASC`,
+		orgID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query org usage daily failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]OrgUsageRow, 0)
+	for rows.Next() {
+		var item OrgUsageRow
+		if err := rows.Scan(&item.Date, &item.UserID, &item.Name, &item.Email, &item.Model, &item.Requests, &item.Coins, &item.Debt); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// OrgUsageCSV 将使用统计转换为 CSV 格式，包含 UTF-8 BOM 以便 Excel 正确识别中文
+func OrgUsageCSV(rows []OrgUsageRow) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\xEF\xBB\xBF")
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"date", "user_id", "name", "email", "model", "requests", "coins", "debt"}); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if err := w.Write([]string{
+			row.Date,
+			strconv.Itoa(int(row.UserID)),
+			csvSafe(row.Name),
+			csvSafe(row.Email),
+			row.Model,
+			strconv.Itoa(int(row.Requests)),
+			strconv.Itoa(int(row.Coins)),
+			strconv.Itoa(int(row.Debt)),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvSafe 用户可以修改的字段以公式字符开头时，在 Excel 中打开会被当作公式执行
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// OrgUsageSubscription 组织使用报告的邮件订阅
+type OrgUsageSubscription struct {
+	OrgID      int64      `json:"org_id"`
+	Emails     []string   `json:"emails"`
+	Frequency  string     `json:"frequency"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+}
+
+// UsageSubscription 查询组织的使用报告订阅，没有订阅时返回 ErrNotFound
+func (repo *OrgRepo) UsageSubscription(ctx context.Context, orgID int64) (*OrgUsageSubscription, error) {
+	subs, err := repo.querySubscriptions(ctx, "WHERE s.org_id = ?", orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subs) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return &subs[0], nil
+}
+
+// UsageSubscriptions 查询指定发送周期的所有订阅，组织被禁用时不再发送
+func (repo *OrgRepo) UsageSubscriptions(ctx context.Context, frequency string) ([]OrgUsageSubscription, error) {
+	return repo.querySubscriptions(ctx, "INNER JOIN org o ON o.id = s.org_id WHERE s.frequency = ? AND o.status = ?", frequency, OrgStatusActive)
+}
+
+func (repo *OrgRepo) querySubscriptions(ctx context.Context, where string, args ...interface{}) ([]OrgUsageSubscription, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT s.org_id, COALESCE(s.emails, ''), s.frequency, s.last_sent_at FROM org_usage_subscription s "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query org usage subscriptions failed: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]OrgUsageSubscription, 0)
+	for rows.Next() {
+		var sub OrgUsageSubscription
+		var emails string
+		var lastSentAt sql.NullTime
+		if err := rows.Scan(&sub.OrgID, &emails, &sub.Frequency, &lastSentAt); err != nil {
+			return nil, err
+		}
+
+		if emails != "" {
+			sub.Emails = strings.Split(emails, ",")
+		}
+
+		if lastSentAt.Valid {
+			sub.LastSentAt = &lastSentAt.Time
+		}
+
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+// UpdateUsageSubscription 设置组织使用报告的邮件订阅，emails 为空时取消订阅
+func (repo *OrgRepo) UpdateUsageSubscription(ctx context.Context, orgID int64, emails []string, frequency string) error {
+	if frequency != OrgUsageFrequencyWeekly && frequency != OrgUsageFrequencyMonthly {
+		return errors.New("invalid frequency")
+	}
+
+	if len(emails) == 0 {
+		_, err := repo.db.ExecContext(ctx, "DELETE FROM org_usage_subscription WHERE org_id = ?", orgID)
+		return err
+	}
+
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO org_usage_subscription (org_id, emails, frequency, created_at, updated_at) VALUES (?, ?, ?, NOW(), NOW()) ON DUPLICATE KEY UPDATE emails = VALUES(emails), frequency = VALUES(frequency), updated_at = NOW()",
+		orgID, strings.Join(emails, ","), frequency,
+	)
+	return err
+}
+
+// MarkUsageSubscriptionSent 记录使用报告的发送时间
+func (repo *OrgRepo) MarkUsageSubscriptionSent(ctx context.Context, orgID int64, sentAt time.Time) error {
+	_, err := repo.db.ExecContext(ctx, "UPDATE org_usage_subscription SET last_sent_at = ? WHERE org_id = ?", sentAt, orgID)
+	return err
+}
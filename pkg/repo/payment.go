@@ -26,6 +26,10 @@ const (
 
 var (
 	ErrPaymentHasBeenProcessed = fmt.Errorf("payment has been processed")
+	// ErrPaymentDuplicated 第三方交易已经在其它支付订单中处理过，当前订单被标记为失败
+	ErrPaymentDuplicated = fmt.Errorf("payment transaction has been processed by another payment")
+	// ErrPaymentHasBeenGranted 支付订单对应的智慧果已经发放过
+	ErrPaymentHasBeenGranted = fmt.Errorf("payment has been granted")
 )
 
 type PaymentRepo struct {
@@ -108,10 +112,13 @@ type AlipayPayment struct {
 	Note           string    `json:"note"`
 }
 
+// CompleteAliPayment 完成支付宝支付，支付宝交易号已经在其它订单中处理过时，当前订单标记为失败并返回 ErrPaymentDuplicated
 func (repo *PaymentRepo) CompleteAliPayment(ctx context.Context, userId int64, paymentID string, pay AlipayPayment) (eventID int64, err error) {
 	q := query.Builder().
 		Where(model2.FieldPaymentHistoryPaymentId, paymentID).
 		Where(model2.FieldPaymentHistoryUserId, userId)
+
+	var duplicated bool
 	err = eloquent.Transaction(repo.db, func(tx query.Database) error {
 		his, err := model2.NewPaymentHistoryModel(tx).First(ctx, q)
 		if err != nil {
@@ -122,10 +129,24 @@ func (repo *PaymentRepo) CompleteAliPayment(ctx context.Context, userId int64, p
 			return ErrPaymentHasBeenProcessed
 		}
 
+		var transactionKey string
+		if pay.Status == PaymentStatusSuccess && pay.TradeNo != "" {
+			transactionKey = "alipay:" + pay.TradeNo
+			if original, err := repo.transactionProcessedBy(ctx, tx, transactionKey, paymentID); err != nil {
+				return err
+			} else if original != "" {
+				duplicated = true
+				transactionKey = ""
+				pay.Status = PaymentStatusFailed
+				pay.Note = fmt.Sprintf("重复的交易，该交易已在订单 %s 中处理", original)
+			}
+		}
+
 		if _, err := model2.NewPaymentHistoryModel(tx).Update(ctx, q, model2.PaymentHistoryN{
-			Status:      null.IntFrom(pay.Status),
-			Environment: null.StringFrom(pay.Environment),
-			PurchaseAt:  null.TimeFrom(pay.PurchaseAt),
+			Status:         null.IntFrom(pay.Status),
+			Environment:    null.StringFrom(pay.Environment),
+			PurchaseAt:     null.TimeFrom(pay.PurchaseAt),
+			TransactionKey: null.NewString(transactionKey, transactionKey != ""),
 		}); err != nil {
 			return fmt.Errorf("update payment history failed: %w", err)
 		}
@@ -163,6 +184,10 @@ func (repo *PaymentRepo) CompleteAliPayment(ctx context.Context, userId int64, p
 		return nil
 	})
 
+	if err == nil && duplicated {
+		err = ErrPaymentDuplicated
+	}
+
 	return eventID, err
 }
 
@@ -248,15 +273,49 @@ type ApplePayment struct {
 	Status        int64     `json:"status"`
 }
 
+// CompleteApplePayment 完成 Apple 应用内支付，订单已经支付成功时返回 ErrPaymentHasBeenProcessed，
+// Apple 交易 ID 已经在其它订单中处理过时，当前订单标记为失败并返回 ErrPaymentDuplicated
 func (repo *PaymentRepo) CompleteApplePayment(ctx context.Context, userId int64, paymentID string, applePayment *ApplePayment) (eventID int64, err error) {
 	q := query.Builder().
 		Where(model2.FieldPaymentHistoryPaymentId, paymentID).
 		Where(model2.FieldPaymentHistoryUserId, userId)
+
+	var duplicated bool
 	err = eloquent.Transaction(repo.db, func(tx query.Database) error {
+		his, err := model2.NewPaymentHistoryModel(tx).First(ctx, q)
+		if err != nil {
+			return fmt.Errorf("get payment history failed: %w", err)
+		}
+
+		// 客户端重试验证时，已经支付成功的订单不再重复处理
+		if his.Status.ValueOrZero() == PaymentStatusSuccess {
+			return ErrPaymentHasBeenProcessed
+		}
+
+		note := ternary.If(
+			applePayment.Status == PaymentStatusFailed,
+			null.StringFrom("验证失败，交易信息存在异常"),
+			null.NewString("", false),
+		)
+
+		var transactionKey string
+		if applePayment.Status == PaymentStatusSuccess && applePayment.TransactionID != "" {
+			transactionKey = "apple:" + applePayment.TransactionID
+			if original, err := repo.transactionProcessedBy(ctx, tx, transactionKey, paymentID); err != nil {
+				return err
+			} else if original != "" {
+				duplicated = true
+				transactionKey = ""
+				applePayment.Status = PaymentStatusFailed
+				note = null.StringFrom(fmt.Sprintf("重复的交易，该交易已在订单 %s 中处理", original))
+			}
+		}
+
 		if _, err := model2.NewPaymentHistoryModel(tx).Update(ctx, q, model2.PaymentHistoryN{
-			Status:      null.IntFrom(applePayment.Status),
-			Environment: null.StringFrom(applePayment.Environment),
-			PurchaseAt:  null.TimeFrom(applePayment.PurchaseAt),
+			Status:         null.IntFrom(applePayment.Status),
+			Environment:    null.StringFrom(applePayment.Environment),
+			PurchaseAt:     null.TimeFrom(applePayment.PurchaseAt),
+			TransactionKey: null.NewString(transactionKey, transactionKey != ""),
 		}); err != nil {
 			return fmt.Errorf("update payment history failed: %w", err)
 		}
@@ -267,11 +326,7 @@ func (repo *PaymentRepo) CompleteApplePayment(ctx context.Context, userId int64,
 			PurchaseAt:    null.TimeFrom(applePayment.PurchaseAt),
 			PurchaseId:    null.StringFrom(applePayment.PurchaseID),
 			TransactionId: null.StringFrom(applePayment.TransactionID),
-			Note: ternary.If(
-				applePayment.Status == PaymentStatusFailed,
-				null.StringFrom("验证失败，交易信息存在异常"),
-				null.NewString("", false),
-			),
+			Note:          note,
 		}); err != nil {
 			return fmt.Errorf("update apple pay history failed: %w", err)
 		}
@@ -293,6 +348,10 @@ func (repo *PaymentRepo) CompleteApplePayment(ctx context.Context, userId int64,
 		return nil
 	})
 
+	if err == nil && duplicated {
+		err = ErrPaymentDuplicated
+	}
+
 	return eventID, err
 }
 
@@ -318,3 +377,78 @@ func (repo *PaymentRepo) CancelApplePayment(ctx context.Context, userId int64, p
 		return nil
 	})
 }
+
+// transactionProcessedBy 查询第三方交易是否已经在其它支付订单中处理过，返回处理过该交易的支付订单 ID
+func (repo *PaymentRepo) transactionProcessedBy(ctx context.Context, tx query.Database, transactionKey, paymentID string) (string, error) {
+	his, err := model2.NewPaymentHistoryModel(tx).First(ctx, query.Builder().
+		Where(model2.FieldPaymentHistoryTransactionKey, transactionKey).
+		Where(model2.FieldPaymentHistoryPaymentId, "!=", paymentID))
+	if err != nil {
+		if err == query.ErrNoResult {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("query payment history by transaction failed: %w", err)
+	}
+
+	return his.PaymentId.ValueOrZero(), nil
+}
+
+// PaymentGrant 支付订单需要发放的智慧果
+type PaymentGrant struct {
+	UserID int64
+	// OrgID 为组织充值时，智慧果发放到组织的共享钱包
+	OrgID     int64
+	PaymentID string
+	Quota     int64
+	EndAt     time.Time
+	Note      string
+}
+
+// GrantPayment 为支付订单发放智慧果，每个支付订单只会发放一次，已经发放过时返回 ErrPaymentHasBeenGranted
+func (repo *PaymentRepo) GrantPayment(ctx context.Context, grant PaymentGrant) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		// 通过条件更新抢占发放标记，并发投递的重复任务只有一个能够成功
+		affected, err := model2.NewPaymentHistoryModel(tx).UpdateFields(ctx, query.KV{
+			model2.FieldPaymentHistoryGrantedAt: time.Now(),
+		}, query.Builder().
+			Where(model2.FieldPaymentHistoryPaymentId, grant.PaymentID).
+			Where(model2.FieldPaymentHistoryUserId, grant.UserID).
+			WhereNull(model2.FieldPaymentHistoryGrantedAt))
+		if err != nil {
+			return fmt.Errorf("mark payment granted failed: %w", err)
+		}
+
+		if affected == 0 {
+			return ErrPaymentHasBeenGranted
+		}
+
+		if grant.OrgID > 0 {
+			_, err = model2.NewOrgQuotaModel(tx).Create(ctx, query.KV{
+				model2.FieldOrgQuotaOrgId:         grant.OrgID,
+				model2.FieldOrgQuotaQuota:         grant.Quota,
+				model2.FieldOrgQuotaRest:          grant.Quota,
+				model2.FieldOrgQuotaNote:          grant.Note,
+				model2.FieldOrgQuotaPaymentId:     grant.PaymentID,
+				model2.FieldOrgQuotaPeriodStartAt: NowInDate(),
+				model2.FieldOrgQuotaPeriodEndAt:   TimeInDate(grant.EndAt),
+			})
+		} else {
+			_, err = model2.NewQuotaModel(tx).Create(ctx, query.KV{
+				model2.FieldQuotaUserId:        grant.UserID,
+				model2.FieldQuotaQuota:         grant.Quota,
+				model2.FieldQuotaRest:          grant.Quota,
+				model2.FieldQuotaNote:          grant.Note,
+				model2.FieldQuotaPaymentId:     grant.PaymentID,
+				model2.FieldQuotaPeriodStartAt: NowInDate(),
+				model2.FieldQuotaPeriodEndAt:   TimeInDate(grant.EndAt),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("add quota failed: %w", err)
+		}
+
+		wallet, ownerID := walletAccount(grant.OrgID, grant.UserID)
+		return postLedger(ctx, tx, issueLedger(LedgerKindPurchase, LedgerAccountRevenue, wallet, ownerID, grant.Quota, grant.PaymentID, grant.Note))
+	})
+}
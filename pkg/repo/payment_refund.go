@@ -0,0 +1,282 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/guregu/null.v3"
+)
+
+// PaymentStatusRefunded 支付订单已退款，对应的智慧果已扣回
+const PaymentStatusRefunded = 4
+
+// refundDebtNote 退款时余额不足，剩余部分记为负余额的配额备注
+const refundDebtNote = "退款扣回（欠费）"
+
+// refundDebtEndAt 负余额配额的有效期，欠费不会过期，只能通过充值抵扣（quota 表的有效期为 TIMESTAMP 类型，不能超过 2038 年）
+var refundDebtEndAt = time.Date(2037, 12, 31, 0, 0, 0, 0, time.Local)
+
+// PaymentRefundRequest 第三方支付平台的退款通知
+type PaymentRefundRequest struct {
+	TransactionKey   string
+	NotificationID   string
+	NotificationType string
+	Environment      string
+	Payload          string
+}
+
+// PaymentRefund 退款记录
+type PaymentRefund struct {
+	ID               int64      `json:"id"`
+	UserID           int64      `json:"user_id"`
+	OrgID            int64      `json:"org_id,omitempty"`
+	PaymentID        string     `json:"payment_id"`
+	TransactionKey   string     `json:"transaction_key"`
+	NotificationID   string     `json:"notification_id"`
+	NotificationType string     `json:"notification_type"`
+	Environment      string     `json:"environment"`
+	Quantity         int64      `json:"quantity"`
+	ClawedBack       int64      `json:"clawed_back"`
+	Debt             int64      `json:"debt"`
+	Payload          string     `json:"payload,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote       string     `json:"review_note,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func newPaymentRefund(r model.PaymentRefundN) PaymentRefund {
+	refund := PaymentRefund{
+		ID:               r.Id.ValueOrZero(),
+		UserID:           r.UserId.ValueOrZero(),
+		OrgID:            r.OrgId.ValueOrZero(),
+		PaymentID:        r.PaymentId.ValueOrZero(),
+		TransactionKey:   r.TransactionKey.ValueOrZero(),
+		NotificationID:   r.NotificationId.ValueOrZero(),
+		NotificationType: r.NotificationType.ValueOrZero(),
+		Environment:      r.Environment.ValueOrZero(),
+		Quantity:         r.Quantity.ValueOrZero(),
+		ClawedBack:       r.ClawedBack.ValueOrZero(),
+		Debt:             r.Debt.ValueOrZero(),
+		Payload:          r.Payload.ValueOrZero(),
+		ReviewNote:       r.ReviewNote.ValueOrZero(),
+		CreatedAt:        r.CreatedAt.ValueOrZero(),
+	}
+
+	if r.ReviewedAt.Valid {
+		refund.ReviewedAt = &r.ReviewedAt.Time
+	}
+
+	return refund
+}
+
+// RefundPayment 处理退款通知：支付订单标记为已退款，并扣回已经发放的智慧果
+//
+// 优先扣除该订单充值的剩余智慧果，不足的部分从其它未过期的智慧果中扣除，仍然不足时记为负余额，
+// 余额为负时无法使用任何需要智慧果的功能（相当于冻结钱包），直到充值补足欠费。
+// 同一个通知重复投递时返回 ErrPaymentHasBeenProcessed，没有匹配到支付订单时只记录通知，供财务复核
+func (repo *PaymentRepo) RefundPayment(ctx context.Context, req PaymentRefundRequest) (*PaymentRefund, error) {
+	if _, err := model.NewPaymentRefundModel(repo.db).First(ctx, query.Builder().Where(model.FieldPaymentRefundNotificationId, req.NotificationID)); err == nil {
+		return nil, ErrPaymentHasBeenProcessed
+	} else if err != query.ErrNoResult {
+		return nil, err
+	}
+
+	refund := model.PaymentRefundN{
+		TransactionKey:   null.StringFrom(req.TransactionKey),
+		NotificationId:   null.StringFrom(req.NotificationID),
+		NotificationType: null.StringFrom(req.NotificationType),
+		Environment:      null.StringFrom(req.Environment),
+		Payload:          null.StringFrom(req.Payload),
+	}
+
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		his, err := model.NewPaymentHistoryModel(tx).First(ctx, query.Builder().Where(model.FieldPaymentHistoryTransactionKey, req.TransactionKey))
+		if err != nil && err != query.ErrNoResult {
+			return fmt.Errorf("query payment history failed: %w", err)
+		}
+
+		if his != nil {
+			userID, paymentID := his.UserId.ValueOrZero(), his.PaymentId.ValueOrZero()
+			refund.UserId, refund.PaymentId = null.IntFrom(userID), null.StringFrom(paymentID)
+
+			// 条件更新保证同一笔交易的多个通知（例如先 REFUND 后 REVOKE）只扣回一次
+			q := query.Builder().Where(model.FieldPaymentHistoryId, his.Id.ValueOrZero()).Where(model.FieldPaymentHistoryStatus, "!=", PaymentStatusRefunded)
+			affected, err := model.NewPaymentHistoryModel(tx).UpdateFields(ctx, query.KV{model.FieldPaymentHistoryStatus: PaymentStatusRefunded}, q)
+			if err != nil {
+				return fmt.Errorf("update payment history failed: %w", err)
+			}
+
+			// 还没有发放过智慧果时，标记为已发放，避免退款后再充值
+			granted := his.GrantedAt.Valid
+			if !granted {
+				if _, err := model.NewPaymentHistoryModel(tx).UpdateFields(ctx, query.KV{model.FieldPaymentHistoryGrantedAt: time.Now()}, query.Builder().
+					Where(model.FieldPaymentHistoryId, his.Id.ValueOrZero()).
+					WhereNull(model.FieldPaymentHistoryGrantedAt)); err != nil {
+					return fmt.Errorf("update payment history failed: %w", err)
+				}
+			}
+
+			if affected > 0 && granted {
+				var orgID int64
+				if pay, err := model.NewOrgPaymentModel(tx).First(ctx, query.Builder().Where(model.FieldOrgPaymentPaymentId, paymentID)); err == nil {
+					orgID = pay.OrgId.ValueOrZero()
+				} else if err != query.ErrNoResult {
+					return fmt.Errorf("query payment org failed: %w", err)
+				}
+
+				quantity := his.Quantity.ValueOrZero()
+				table, owner, ownerID := "quota", "user_id", userID
+				if orgID > 0 {
+					table, owner, ownerID = "org_quota", "org_id", orgID
+				}
+
+				clawed, debt, err := clawBackQuota(ctx, tx, table, owner, ownerID, paymentID, quantity)
+				if err != nil {
+					return fmt.Errorf("claw back quota failed: %w", err)
+				}
+
+				// 欠费部分记为负余额的配额，钱包减少的数量等于订单发放的全部智慧果
+				wallet, walletOwner := walletAccount(orgID, userID)
+				if err := postLedger(ctx, tx, LedgerTxn{
+					Kind:    LedgerKindClawback,
+					Ref:     paymentID,
+					Note:    "支付退款扣回",
+					Entries: []LedgerEntry{Debit(wallet, walletOwner, clawed+debt), Credit(LedgerAccountRefund, 0, clawed+debt)},
+				}); err != nil {
+					return err
+				}
+
+				refund.OrgId = null.IntFrom(orgID)
+				refund.Quantity = null.IntFrom(quantity)
+				refund.ClawedBack = null.IntFrom(clawed)
+				refund.Debt = null.IntFrom(debt)
+			}
+		}
+
+		id, err := model.NewPaymentRefundModel(tx).Save(ctx, refund)
+		if err != nil {
+			return fmt.Errorf("create payment refund failed: %w", err)
+		}
+
+		refund.Id = null.IntFrom(id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := newPaymentRefund(refund)
+	ret.CreatedAt = time.Now()
+	return &ret, nil
+}
+
+// clawBackQuota 从钱包中扣回指定数量的智慧果，返回实际扣回的数量以及记为负余额的欠费
+func clawBackQuota(ctx context.Context, tx query.Database, table, owner string, ownerID int64, paymentID string, amount int64) (clawed int64, debt int64, err error) {
+	if amount <= 0 {
+		return 0, 0, nil
+	}
+
+	consume := func(where string, args ...any) error {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT id, rest FROM %s WHERE %s = ? AND rest > 0 AND %s ORDER BY period_end_at ASC FOR UPDATE", table, owner, where), append([]any{ownerID}, args...)...)
+		if err != nil {
+			return err
+		}
+
+		type item struct{ id, rest int64 }
+		items := make([]item, 0)
+		for rows.Next() {
+			var it item
+			if err := rows.Scan(&it.id, &it.rest); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			items = append(items, it)
+		}
+		_ = rows.Close()
+
+		for _, it := range items {
+			if clawed >= amount {
+				break
+			}
+
+			deduct := it.rest
+			if deduct > amount-clawed {
+				deduct = amount - clawed
+			}
+
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET rest = rest - ? WHERE id = ?", table), deduct, it.id); err != nil {
+				return err
+			}
+
+			clawed += deduct
+		}
+
+		return nil
+	}
+
+	// 优先扣除该订单充值的智慧果（包括已经过期的部分），再扣除其它未过期的智慧果
+	if err := consume("payment_id = ?", paymentID); err != nil {
+		return 0, 0, err
+	}
+
+	if clawed < amount {
+		if err := consume("period_end_at > ?", time.Now()); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if debt = amount - clawed; debt > 0 {
+		if _, err := tx.ExecContext(
+			ctx,
+			fmt.Sprintf("INSERT INTO %s (%s, quota, rest, note, payment_id, period_start_at, period_end_at, created_at, updated_at) VALUES (?, 0, ?, ?, ?, ?, ?, ?, ?)", table, owner),
+			ownerID, -debt, refundDebtNote, paymentID, NowInDate(), refundDebtEndAt, time.Now(), time.Now(),
+		); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return clawed, debt, nil
+}
+
+// PaymentRefunds 退款记录列表，reviewed 为 nil 时不按照复核状态筛选
+func (repo *PaymentRepo) PaymentRefunds(ctx context.Context, reviewed *bool, page, perPage int64) ([]PaymentRefund, query.PaginateMeta, error) {
+	q := query.Builder().OrderBy(model.FieldPaymentRefundId, "DESC")
+	if reviewed != nil {
+		if *reviewed {
+			q = q.WhereNotNull(model.FieldPaymentRefundReviewedAt)
+		} else {
+			q = q.WhereNull(model.FieldPaymentRefundReviewedAt)
+		}
+	}
+
+	items, meta, err := model.NewPaymentRefundModel(repo.db).Paginate(ctx, page, perPage, q)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	return array.Map(items, func(item model.PaymentRefundN, _ int) PaymentRefund {
+		return newPaymentRefund(item)
+	}), meta, nil
+}
+
+// ReviewPaymentRefund 财务复核退款记录
+func (repo *PaymentRepo) ReviewPaymentRefund(ctx context.Context, id int64, note string) error {
+	affected, err := model.NewPaymentRefundModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldPaymentRefundReviewedAt: time.Now(),
+		model.FieldPaymentRefundReviewNote: note,
+	}, query.Builder().Where(model.FieldPaymentRefundId, id))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+type PDFRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewPDFRepo create a new PDFRepo
+func NewPDFRepo(db *sql.DB, conf *config.Config) *PDFRepo {
+	return &PDFRepo{db: db, conf: conf}
+}
+
+// PDFDocumentAddReq PDF 文档
+type PDFDocumentAddReq struct {
+	UserID int64
+	Name   string
+	URL    string
+	Model  string
+	Pages  int64
+	Coins  int64
+	Chunks []PDFChunk
+}
+
+// PDFChunk PDF 文档片段
+type PDFChunk struct {
+	ID      int64     `json:"id"`
+	Page    int64     `json:"page"`
+	Content string    `json:"content"`
+	Vector  []float32 `json:"-"`
+}
+
+// AddDocument 保存 PDF 文档及其所有片段
+func (repo *PDFRepo) AddDocument(ctx context.Context, req PDFDocumentAddReq) (int64, error) {
+	var docID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewPdfDocumentModel(tx).Create(ctx, query.KV{
+			model.FieldPdfDocumentUserId: req.UserID,
+			model.FieldPdfDocumentName:   misc.SubString(req.Name, 255),
+			model.FieldPdfDocumentUrl:    req.URL,
+			model.FieldPdfDocumentModel:  req.Model,
+			model.FieldPdfDocumentPages:  req.Pages,
+			model.FieldPdfDocumentChunks: len(req.Chunks),
+			model.FieldPdfDocumentCoins:  req.Coins,
+		})
+		if err != nil {
+			return fmt.Errorf("create pdf document failed: %w", err)
+		}
+
+		docID = id
+
+		for _, chunk := range req.Chunks {
+			vec, err := json.Marshal(chunk.Vector)
+			if err != nil {
+				return fmt.Errorf("marshal vector failed: %w", err)
+			}
+
+			if _, err := model.NewPdfChunkModel(tx).Create(ctx, query.KV{
+				model.FieldPdfChunkDocumentId: docID,
+				model.FieldPdfChunkPage:       chunk.Page,
+				model.FieldPdfChunkContent:    chunk.Content,
+				model.FieldPdfChunkVector:     string(vec),
+			}); err != nil {
+				return fmt.Errorf("create pdf chunk failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return docID, err
+}
+
+// GetDocument 查询用户的 PDF 文档
+func (repo *PDFRepo) GetDocument(ctx context.Context, userID, id int64) (*model.PdfDocument, error) {
+	doc, err := model.NewPdfDocumentModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldPdfDocumentId, id).
+		Where(model.FieldPdfDocumentUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := doc.ToPdfDocument()
+	return &ret, nil
+}
+
+// GetDocuments 查询用户的所有 PDF 文档
+func (repo *PDFRepo) GetDocuments(ctx context.Context, userID int64) ([]model.PdfDocument, error) {
+	docs, err := model.NewPdfDocumentModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldPdfDocumentUserId, userID).
+		OrderBy(model.FieldPdfDocumentId, "DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(docs, func(item model.PdfDocumentN, _ int) model.PdfDocument {
+		return item.ToPdfDocument()
+	}), nil
+}
+
+// DeleteDocument 删除 PDF 文档及其所有片段
+func (repo *PDFRepo) DeleteDocument(ctx context.Context, userID, id int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		affected, err := model.NewPdfDocumentModel(tx).Delete(ctx, query.Builder().
+			Where(model.FieldPdfDocumentId, id).
+			Where(model.FieldPdfDocumentUserId, userID))
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return ErrNotFound
+		}
+
+		_, err = model.NewPdfChunkModel(tx).Delete(ctx, query.Builder().Where(model.FieldPdfChunkDocumentId, id))
+		return err
+	})
+}
+
+// GetChunks 获取文档的所有片段（包含向量），用于相似度检索
+func (repo *PDFRepo) GetChunks(ctx context.Context, documentID int64) ([]PDFChunk, error) {
+	chunks, err := model.NewPdfChunkModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldPdfChunkDocumentId, documentID).
+		OrderBy(model.FieldPdfChunkId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]PDFChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		item := PDFChunk{ID: chunk.Id.ValueOrZero(), Page: chunk.Page.ValueOrZero(), Content: chunk.Content.ValueOrZero()}
+		if err := json.Unmarshal([]byte(chunk.Vector.ValueOrZero()), &item.Vector); err != nil {
+			return nil, fmt.Errorf("unmarshal vector of chunk %d failed: %w", item.ID, err)
+		}
+
+		ret = append(ret, item)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/profiler"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// PerfStatRepo 数据库查询与接口耗时的按天汇总统计
+type PerfStatRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewPerfStatRepo create a new PerfStatRepo
+func NewPerfStatRepo(db *sql.DB, conf *config.Config) *PerfStatRepo {
+	return &PerfStatRepo{db: db, conf: conf}
+}
+
+// Save 将统计数据累加到指定日期的汇总统计中
+func (repo *PerfStatRepo) Save(ctx context.Context, date time.Time, stats []profiler.Stat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		for _, st := range stats {
+			hash := sha1.Sum([]byte(st.Name))
+			if _, err := tx.ExecContext(
+				ctx,
+				`INSERT INTO perf_stat (kind, name, name_hash, stat_date, count, total_elapse, max_elapse, slow_count, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+ON DUPLICATE KEY UPDATE count = count + VALUES(count), total_elapse = total_elapse + VALUES(total_elapse),
+max_elapse = GREATEST(max_elapse, VALUES(max_elapse)), slow_count = slow_count + VALUES(slow_count), updated_at = NOW()`,
+				st.Kind, st.Name, hex.EncodeToString(hash[:]), date.Format("2006-01-02"), st.Count, st.TotalElapse, st.MaxElapse, st.SlowCount,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Slowest 查询指定日期区间内平均耗时最长的 SQL 或接口，请求次数少于 minCount 的不参与排序
+func (repo *PerfStatRepo) Slowest(ctx context.Context, kind string, startDate, endDate time.Time, minCount int64, limit int64) ([]profiler.Stat, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		`SELECT name, SUM(count) AS cnt, SUM(total_elapse) AS total, MAX(max_elapse), SUM(slow_count)
+FROM perf_stat WHERE kind = ? AND stat_date >= ? AND stat_date <= ?
+GROUP BY name_hash, name HAVING cnt >= ? ORDER BY total / cnt DESC LIMIT ?`,
+		kind, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), minCount, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]profiler.Stat, 0)
+	for rows.Next() {
+		st := profiler.Stat{Kind: kind}
+		if err := rows.Scan(&st.Name, &st.Count, &st.TotalElapse, &st.MaxElapse, &st.SlowCount); err != nil {
+			return nil, err
+		}
+
+		res = append(res, st)
+	}
+
+	return res, rows.Err()
+}
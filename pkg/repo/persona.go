@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// PersonaMaxLength 全局指令的最大长度（字符）
+	PersonaMaxLength = 1500
+	// PersonaMaxHistory 全局指令最多保留的历史版本数量
+	PersonaMaxHistory = 10
+)
+
+// ErrPersonaVersionNotFound 全局指令的历史版本不存在
+var ErrPersonaVersionNotFound = errors.New("persona version not found")
+
+// UserPersona 用户自定义的全局指令，类似于 ChatGPT 的 Custom Instructions，自动添加到用户的所有对话中
+type UserPersona struct {
+	// Version 版本号，每次修改递增
+	Version int64 `json:"version"`
+	// Content 指令内容，为空表示未启用
+	Content string `json:"content"`
+	// SkipGroupChat 群聊中不使用全局指令
+	SkipGroupChat bool      `json:"skip_group_chat,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Enabled 全局指令是否已启用
+func (p *UserPersona) Enabled() bool {
+	return p != nil && p.Content != ""
+}
+
+// SetPersona 修改全局指令，修改前的版本加入历史记录，content 为空时停用全局指令
+func (conf *UserCustomConfig) SetPersona(content string, skipGroupChat bool, now time.Time) UserPersona {
+	var version int64
+	if conf.Persona != nil {
+		version = conf.Persona.Version
+		if conf.Persona.Enabled() {
+			conf.PersonaHistory = append([]UserPersona{*conf.Persona}, conf.PersonaHistory...)
+			if len(conf.PersonaHistory) > PersonaMaxHistory {
+				conf.PersonaHistory = conf.PersonaHistory[:PersonaMaxHistory]
+			}
+		}
+	}
+
+	conf.Persona = &UserPersona{
+		Version:       version + 1,
+		Content:       content,
+		SkipGroupChat: skipGroupChat,
+		UpdatedAt:     now,
+	}
+
+	return *conf.Persona
+}
+
+// RestorePersona 恢复全局指令的历史版本，恢复后作为新的版本保存
+func (conf *UserCustomConfig) RestorePersona(version int64, now time.Time) (UserPersona, error) {
+	for _, item := range conf.PersonaHistory {
+		if item.Version == version {
+			return conf.SetPersona(item.Content, item.SkipGroupChat, now), nil
+		}
+	}
+
+	return UserPersona{}, ErrPersonaVersionNotFound
+}
@@ -0,0 +1,52 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestUserCustomConfigSetPersona(t *testing.T) {
+	now := time.Now()
+	var cus repo.UserCustomConfig
+
+	p := cus.SetPersona("第一版", false, now)
+	assert.EqualValues(t, 1, p.Version)
+	assert.Equal(t, 0, len(cus.PersonaHistory))
+
+	p = cus.SetPersona("第二版", true, now)
+	assert.EqualValues(t, 2, p.Version)
+	assert.True(t, p.SkipGroupChat)
+	assert.Equal(t, 1, len(cus.PersonaHistory))
+	assert.Equal(t, "第一版", cus.PersonaHistory[0].Content)
+
+	// 停用后版本号继续递增，恢复历史版本作为新版本保存
+	p = cus.SetPersona("", false, now)
+	assert.False(t, p.Enabled())
+	assert.EqualValues(t, 3, p.Version)
+
+	p, err := cus.RestorePersona(1, now)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, p.Version)
+	assert.Equal(t, "第一版", p.Content)
+	assert.Equal(t, 2, len(cus.PersonaHistory))
+
+	_, err = cus.RestorePersona(3, now)
+	assert.True(t, err == repo.ErrPersonaVersionNotFound)
+
+	for i := 0; i < 20; i++ {
+		cus.SetPersona("内容", false, now)
+	}
+	assert.Equal(t, repo.PersonaMaxHistory, len(cus.PersonaHistory))
+}
+
+func TestUserCustomConfig_EffectiveSpendLimit(t *testing.T) {
+	assert.Equal(t, int64(1000), repo.UserCustomConfig{}.EffectiveSpendLimit(1000))
+	assert.Equal(t, int64(0), repo.UserCustomConfig{}.EffectiveSpendLimit(0))
+	// 用户设置优先于系统默认值，可以调高也可以调低
+	assert.Equal(t, int64(200), repo.UserCustomConfig{SpendLimit: 200}.EffectiveSpendLimit(1000))
+	assert.Equal(t, int64(5000), repo.UserCustomConfig{SpendLimit: 5000}.EffectiveSpendLimit(1000))
+	assert.Equal(t, int64(0), repo.UserCustomConfig{SpendLimit: -1}.EffectiveSpendLimit(1000))
+}
@@ -0,0 +1,429 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/guregu/null.v3"
+)
+
+var (
+	ErrPromoEventNotActive = errors.New("promo event is not active")
+	ErrPromoDrawLimit      = errors.New("promo draw limit exceeded")
+	ErrCouponRedeemed      = errors.New("coupon has been redeemed")
+)
+
+const (
+	PromoEventStatusEnabled  int64 = 1
+	PromoEventStatusDisabled int64 = 2
+)
+
+const (
+	// PromoPrizeTypeNone 未中奖
+	PromoPrizeTypeNone int64 = 0
+	// PromoPrizeTypeCoins 智慧果，抽中后直接发放
+	PromoPrizeTypeCoins int64 = 1
+	// PromoPrizeTypeCoupon 兑换券，用户兑换后发放对应数量的智慧果
+	PromoPrizeTypeCoupon int64 = 2
+)
+
+// PromoProbabilityBase 中奖概率的基数（万分比）
+const PromoProbabilityBase = 10000
+
+// PickPromoPrize 根据奖品的中奖概率（万分比）选择奖品，r 取值范围为 [0, PromoProbabilityBase)，
+// 所有奖品概率之和不足 PromoProbabilityBase 的部分为未中奖，返回 nil 表示未中奖
+func PickPromoPrize(prizes []model.PromoPrize, r int64) *model.PromoPrize {
+	var acc int64
+	for i, p := range prizes {
+		if p.Probability <= 0 {
+			continue
+		}
+
+		acc += p.Probability
+		if r < acc {
+			return &prizes[i]
+		}
+	}
+
+	return nil
+}
+
+type PromoRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewPromoRepo create a new PromoRepo
+func NewPromoRepo(db *sql.DB, conf *config.Config) *PromoRepo {
+	return &PromoRepo{db: db, conf: conf}
+}
+
+// PromoPrizeReq 创建活动时的奖品
+type PromoPrizeReq struct {
+	Name        string `json:"name"`
+	PrizeType   int64  `json:"prize_type"`
+	Value       int64  `json:"value"`
+	Probability int64  `json:"probability"`
+	Stock       int64  `json:"stock"`
+}
+
+// CreateEvent 创建活动及其奖品
+func (repo *PromoRepo) CreateEvent(ctx context.Context, event model.PromoEvent, prizes []PromoPrizeReq) (int64, error) {
+	var eventID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewPromoEventModel(tx).Create(ctx, query.KV{
+			model.FieldPromoEventName:        event.Name,
+			model.FieldPromoEventDescription: event.Description,
+			model.FieldPromoEventStatus:      event.Status,
+			model.FieldPromoEventStartAt:     event.StartAt,
+			model.FieldPromoEventEndAt:       event.EndAt,
+			model.FieldPromoEventDailyLimit:  event.DailyLimit,
+			model.FieldPromoEventTotalLimit:  event.TotalLimit,
+		})
+		if err != nil {
+			return fmt.Errorf("create promo event failed: %w", err)
+		}
+
+		eventID = id
+		for _, p := range prizes {
+			if _, err := model.NewPromoPrizeModel(tx).Create(ctx, query.KV{
+				model.FieldPromoPrizeEventId:     eventID,
+				model.FieldPromoPrizeName:        p.Name,
+				model.FieldPromoPrizePrizeType:   p.PrizeType,
+				model.FieldPromoPrizeValue:       p.Value,
+				model.FieldPromoPrizeProbability: p.Probability,
+				model.FieldPromoPrizeStock:       p.Stock,
+			}); err != nil {
+				return fmt.Errorf("create promo prize failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return eventID, err
+}
+
+// UpdateEventStatus 更新活动状态
+func (repo *PromoRepo) UpdateEventStatus(ctx context.Context, eventID int64, status int64) error {
+	_, err := model.NewPromoEventModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldPromoEventStatus: status},
+		query.Builder().Where(model.FieldPromoEventId, eventID),
+	)
+	return err
+}
+
+// Events 查询活动列表，activeOnly 为 true 时只返回当前正在进行中的活动
+func (repo *PromoRepo) Events(ctx context.Context, activeOnly bool) ([]model.PromoEvent, error) {
+	q := query.Builder().OrderBy(model.FieldPromoEventId, "DESC")
+	if activeOnly {
+		now := time.Now()
+		q = q.Where(model.FieldPromoEventStatus, PromoEventStatusEnabled).
+			Where(model.FieldPromoEventStartAt, "<=", now).
+			Where(model.FieldPromoEventEndAt, ">", now)
+	}
+
+	events, err := model.NewPromoEventModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(events, func(item model.PromoEventN, _ int) model.PromoEvent {
+		return item.ToPromoEvent()
+	}), nil
+}
+
+// Event 查询活动详情
+func (repo *PromoRepo) Event(ctx context.Context, eventID int64) (*model.PromoEvent, error) {
+	event, err := model.NewPromoEventModel(repo.db).First(ctx, query.Builder().Where(model.FieldPromoEventId, eventID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := event.ToPromoEvent()
+	return &ret, nil
+}
+
+// Prizes 查询活动的奖品列表
+func (repo *PromoRepo) Prizes(ctx context.Context, eventID int64) ([]model.PromoPrize, error) {
+	prizes, err := model.NewPromoPrizeModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldPromoPrizeEventId, eventID).
+		OrderBy(model.FieldPromoPrizeId, "ASC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(prizes, func(item model.PromoPrizeN, _ int) model.PromoPrize {
+		return item.ToPromoPrize()
+	}), nil
+}
+
+// UserDrawCount 查询用户在活动中今天的抽奖次数以及总抽奖次数
+func (repo *PromoRepo) UserDrawCount(ctx context.Context, eventID, userID int64) (today int64, total int64, err error) {
+	return userPromoDrawCount(ctx, repo.db, eventID, userID)
+}
+
+func userPromoDrawCount(ctx context.Context, db query.Database, eventID, userID int64) (today int64, total int64, err error) {
+	q := query.Builder().
+		Where(model.FieldPromoDrawEventId, eventID).
+		Where(model.FieldPromoDrawUserId, userID)
+
+	total, err = model.NewPromoDrawModel(db).Count(ctx, q)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	today, err = model.NewPromoDrawModel(db).Count(ctx, q.Where(model.FieldPromoDrawCreatedAt, ">=", NowInDate()))
+	return today, total, err
+}
+
+// Draw 用户抽奖，抽奖次数校验、库存扣减、抽奖记录以及智慧果发放在同一个事务中完成
+func (repo *PromoRepo) Draw(ctx context.Context, eventID, userID int64) (*model.PromoDraw, error) {
+	event, err := repo.Event(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if event.Status != PromoEventStatusEnabled || now.Before(event.StartAt) || !now.Before(event.EndAt) {
+		return nil, ErrPromoEventNotActive
+	}
+
+	prizes, err := repo.Prizes(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var draw model.PromoDraw
+	err = eloquent.Transaction(repo.db, func(tx query.Database) error {
+		// 锁定用户记录，避免同一个用户并发抽奖超出次数限制
+		if _, err := tx.ExecContext(ctx, "SELECT id FROM users WHERE id = ? FOR UPDATE", userID); err != nil {
+			return err
+		}
+
+		today, total, err := userPromoDrawCount(ctx, tx, eventID, userID)
+		if err != nil {
+			return err
+		}
+
+		if (event.DailyLimit > 0 && today >= event.DailyLimit) || (event.TotalLimit > 0 && total >= event.TotalLimit) {
+			return ErrPromoDrawLimit
+		}
+
+		draw = model.PromoDraw{EventId: eventID, UserId: userID, PrizeType: PromoPrizeTypeNone}
+
+		prize := PickPromoPrize(prizes, rand.Int63n(PromoProbabilityBase))
+		if prize != nil && prize.PrizeType != PromoPrizeTypeNone {
+			// 扣减库存，库存不足时视为未中奖
+			res, err := tx.ExecContext(
+				ctx,
+				"UPDATE promo_prize SET issued = issued + 1 WHERE id = ? AND (stock < 0 OR issued < stock)",
+				prize.Id,
+			)
+			if err != nil {
+				return err
+			}
+
+			if affected, err := res.RowsAffected(); err != nil {
+				return err
+			} else if affected > 0 {
+				draw.PrizeId, draw.PrizeType, draw.Value = prize.Id, prize.PrizeType, prize.Value
+			}
+		}
+
+		kv := query.KV{
+			model.FieldPromoDrawEventId:   draw.EventId,
+			model.FieldPromoDrawUserId:    draw.UserId,
+			model.FieldPromoDrawPrizeId:   draw.PrizeId,
+			model.FieldPromoDrawPrizeType: draw.PrizeType,
+			model.FieldPromoDrawValue:     draw.Value,
+		}
+
+		switch draw.PrizeType {
+		case PromoPrizeTypeCoins:
+			quotaID, err := addPromoQuota(ctx, tx, userID, draw.Value, "活动奖励："+event.Name, eventID)
+			if err != nil {
+				return err
+			}
+
+			draw.QuotaId = quotaID
+			kv[model.FieldPromoDrawQuotaId] = quotaID
+		case PromoPrizeTypeCoupon:
+			draw.CouponCode = strings.ToUpper(strings.ReplaceAll(misc.UUID(), "-", ""))[:16]
+			kv[model.FieldPromoDrawCouponCode] = draw.CouponCode
+		}
+
+		id, err := model.NewPromoDrawModel(tx).Create(ctx, kv)
+		if err != nil {
+			return fmt.Errorf("create promo draw failed: %w", err)
+		}
+
+		draw.Id = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &draw, nil
+}
+
+// addPromoQuota 发放活动奖励的智慧果
+func addPromoQuota(ctx context.Context, tx query.Database, userID, coins int64, note string, eventID int64) (int64, error) {
+	quota := model.Quota{
+		UserId:        userID,
+		Quota:         coins,
+		Rest:          coins,
+		Note:          note,
+		PaymentId:     fmt.Sprintf("promo:%d", eventID),
+		PeriodStartAt: NowInDate(),
+		PeriodEndAt:   TimeInDate(time.Now().AddDate(0, 1, 0)),
+	}
+
+	id, err := model.NewQuotaModel(tx).Save(ctx, quota.ToQuotaN(
+		model.FieldQuotaUserId,
+		model.FieldQuotaQuota,
+		model.FieldQuotaRest,
+		model.FieldQuotaNote,
+		model.FieldQuotaPaymentId,
+		model.FieldQuotaPeriodStartAt,
+		model.FieldQuotaPeriodEndAt,
+	))
+	if err != nil {
+		return 0, fmt.Errorf("create promo quota failed: %w", err)
+	}
+
+	if err := postLedger(ctx, tx, issueLedger(LedgerKindGift, LedgerAccountGift, LedgerAccountUserWallet, userID, coins, fmt.Sprintf("quota:%d", id), note)); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UserDraws 查询用户的抽奖记录
+func (repo *PromoRepo) UserDraws(ctx context.Context, userID int64, limit int64) ([]model.PromoDraw, error) {
+	draws, err := model.NewPromoDrawModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldPromoDrawUserId, userID).
+		OrderBy(model.FieldPromoDrawId, "DESC").
+		Limit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(draws, func(item model.PromoDrawN, _ int) model.PromoDraw {
+		return item.ToPromoDraw()
+	}), nil
+}
+
+// RedeemCoupon 兑换抽奖获得的兑换券，兑换券只能由获得者本人兑换一次
+func (repo *PromoRepo) RedeemCoupon(ctx context.Context, userID int64, code string) (*model.PromoDraw, error) {
+	var draw model.PromoDraw
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		item, err := model.NewPromoDrawModel(tx).First(ctx, query.Builder().
+			Where(model.FieldPromoDrawCouponCode, code).
+			Where(model.FieldPromoDrawUserId, userID).
+			Where(model.FieldPromoDrawPrizeType, PromoPrizeTypeCoupon),
+		)
+		if err != nil {
+			if errors.Is(err, query.ErrNoResult) {
+				return ErrNotFound
+			}
+
+			return err
+		}
+
+		draw = item.ToPromoDraw()
+
+		res, err := tx.ExecContext(ctx, "UPDATE promo_draw SET redeemed_at = NOW() WHERE id = ? AND redeemed_at IS NULL", draw.Id)
+		if err != nil {
+			return err
+		}
+
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return ErrCouponRedeemed
+		}
+
+		quotaID, err := addPromoQuota(ctx, tx, userID, draw.Value, "兑换券兑换", draw.EventId)
+		if err != nil {
+			return err
+		}
+
+		draw.QuotaId = quotaID
+		draw.RedeemedAt = time.Now()
+
+		_, err = model.NewPromoDrawModel(tx).UpdateFields(
+			ctx,
+			query.KV{model.FieldPromoDrawQuotaId: quotaID},
+			query.Builder().Where(model.FieldPromoDrawId, draw.Id),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &draw, nil
+}
+
+// PromoPrizeReport 活动奖品发放统计
+type PromoPrizeReport struct {
+	PrizeID   int64  `json:"prize_id"`
+	Name      string `json:"name"`
+	PrizeType int64  `json:"prize_type"`
+	// Draws 抽中次数
+	Draws int64 `json:"draws"`
+	// Users 抽中的用户数
+	Users int64 `json:"users"`
+	// Value 发放的总价值
+	Value int64 `json:"value"`
+	// Redeemed 已兑换的兑换券数量
+	Redeemed int64 `json:"redeemed"`
+}
+
+// Report 统计活动的奖品发放情况，未中奖的记录 PrizeID 为 0
+func (repo *PromoRepo) Report(ctx context.Context, eventID int64) ([]PromoPrizeReport, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT d.prize_id, COALESCE(p.name, ''), d.prize_type, COUNT(*), COUNT(DISTINCT d.user_id), COALESCE(SUM(d.value), 0), SUM(IF(d.redeemed_at IS NULL, 0, 1))
+FROM promo_draw d
+LEFT JOIN promo_prize p ON p.id = d.prize_id
+WHERE d.event_id = ?
+GROUP BY d.prize_id, p.name, d.prize_type
+ORDER BY d.prize_id ASC`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("query promo report failed: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]PromoPrizeReport, 0)
+	for rows.Next() {
+		var r PromoPrizeReport
+		var redeemed null.Int
+		if err := rows.Scan(&r.PrizeID, &r.Name, &r.PrizeType, &r.Draws, &r.Users, &r.Value, &redeemed); err != nil {
+			return nil, err
+		}
+
+		r.Redeemed = redeemed.ValueOrZero()
+		reports = append(reports, r)
+	}
+
+	return reports, rows.Err()
+}
@@ -0,0 +1,24 @@
+package repo_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestPickPromoPrize(t *testing.T) {
+	prizes := []model.PromoPrize{
+		{Id: 1, Probability: 100},
+		{Id: 2, Probability: 0},
+		{Id: 3, Probability: 900},
+	}
+
+	assert.Equal(t, int64(1), repo.PickPromoPrize(prizes, 0).Id)
+	assert.Equal(t, int64(1), repo.PickPromoPrize(prizes, 99).Id)
+	assert.Equal(t, int64(3), repo.PickPromoPrize(prizes, 100).Id)
+	assert.Equal(t, int64(3), repo.PickPromoPrize(prizes, 999).Id)
+	assert.True(t, repo.PickPromoPrize(prizes, 1000) == nil)
+	assert.True(t, repo.PickPromoPrize(nil, 0) == nil)
+}
@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	PromptTemplateStatusDisabled int64 = 0
+	PromptTemplateStatusEnabled  int64 = 1
+)
+
+const (
+	// PromptTemplateSceneResume 简历优化
+	PromptTemplateSceneResume = "resume"
+)
+
+type PromptTemplateRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewPromptTemplateRepo create a new PromptTemplateRepo
+func NewPromptTemplateRepo(db *sql.DB, conf *config.Config) *PromptTemplateRepo {
+	return &PromptTemplateRepo{db: db, conf: conf}
+}
+
+// Templates 查询指定场景的提示语模板，onlyEnabled 为 true 时只返回启用的模板
+func (repo *PromptTemplateRepo) Templates(ctx context.Context, scene string, onlyEnabled bool) ([]model.PromptTemplate, error) {
+	q := query.Builder().
+		OrderBy(model.FieldPromptTemplateSort, "ASC").
+		OrderBy(model.FieldPromptTemplateId, "ASC")
+	if scene != "" {
+		q = q.Where(model.FieldPromptTemplateScene, scene)
+	}
+
+	if onlyEnabled {
+		q = q.Where(model.FieldPromptTemplateStatus, PromptTemplateStatusEnabled)
+	}
+
+	items, err := model.NewPromptTemplateModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.PromptTemplateN, _ int) model.PromptTemplate {
+		return item.ToPromptTemplate()
+	}), nil
+}
+
+// Template 查询提示语模板
+func (repo *PromptTemplateRepo) Template(ctx context.Context, id int64) (*model.PromptTemplate, error) {
+	item, err := model.NewPromptTemplateModel(repo.db).First(ctx, query.Builder().Where(model.FieldPromptTemplateId, id))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := item.ToPromptTemplate()
+	return &ret, nil
+}
+
+// CreateTemplate 创建提示语模板
+func (repo *PromptTemplateRepo) CreateTemplate(ctx context.Context, tpl model.PromptTemplate) (int64, error) {
+	return model.NewPromptTemplateModel(repo.db).Create(ctx, query.KV{
+		model.FieldPromptTemplateScene:        tpl.Scene,
+		model.FieldPromptTemplateName:         tpl.Name,
+		model.FieldPromptTemplateDescription:  tpl.Description,
+		model.FieldPromptTemplateSystemPrompt: tpl.SystemPrompt,
+		model.FieldPromptTemplateUserPrompt:   tpl.UserPrompt,
+		model.FieldPromptTemplateSort:         tpl.Sort,
+		model.FieldPromptTemplateStatus:       tpl.Status,
+	})
+}
+
+// UpdateTemplate 更新提示语模板
+func (repo *PromptTemplateRepo) UpdateTemplate(ctx context.Context, id int64, tpl model.PromptTemplate) error {
+	_, err := model.NewPromptTemplateModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldPromptTemplateScene:        tpl.Scene,
+		model.FieldPromptTemplateName:         tpl.Name,
+		model.FieldPromptTemplateDescription:  tpl.Description,
+		model.FieldPromptTemplateSystemPrompt: tpl.SystemPrompt,
+		model.FieldPromptTemplateUserPrompt:   tpl.UserPrompt,
+		model.FieldPromptTemplateSort:         tpl.Sort,
+		model.FieldPromptTemplateStatus:       tpl.Status,
+	}, query.Builder().Where(model.FieldPromptTemplateId, id))
+	return err
+}
+
+// DeleteTemplate 删除提示语模板
+func (repo *PromptTemplateRepo) DeleteTemplate(ctx context.Context, id int64) error {
+	_, err := model.NewPromptTemplateModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldPromptTemplateId, id))
+	return err
+}
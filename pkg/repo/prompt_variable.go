@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// PromptVariable 用户自定义的提示语变量
+type PromptVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PromptVariableRepo 用户自定义的提示语变量，在聊天请求中使用 {{name}} 引用，由服务端在请求时展开
+type PromptVariableRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewPromptVariableRepo create a new PromptVariableRepo
+func NewPromptVariableRepo(db *sql.DB, conf *config.Config) *PromptVariableRepo {
+	return &PromptVariableRepo{db: db, conf: conf}
+}
+
+// Variables 查询用户的所有提示语变量
+func (repo *PromptVariableRepo) Variables(ctx context.Context, userID int64) ([]PromptVariable, error) {
+	items, err := model.NewUserPromptVariableModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldUserPromptVariableUserId, userID).
+		OrderBy(model.FieldUserPromptVariableName, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make([]PromptVariable, 0, len(items))
+	for _, item := range items {
+		vars = append(vars, PromptVariable{Name: item.Name.ValueOrZero(), Value: item.Value.ValueOrZero()})
+	}
+
+	return vars, nil
+}
+
+// SetVariable 新增或更新用户的提示语变量
+func (repo *PromptVariableRepo) SetVariable(ctx context.Context, userID int64, name, value string) error {
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO user_prompt_variable (user_id, name, value, created_at, updated_at) VALUES (?, ?, ?, NOW(), NOW()) ON DUPLICATE KEY UPDATE value = VALUES(value), updated_at = NOW()",
+		userID, name, value,
+	)
+	return err
+}
+
+// DeleteVariable 删除用户的提示语变量
+func (repo *PromptVariableRepo) DeleteVariable(ctx context.Context, userID int64, name string) error {
+	_, err := model.NewUserPromptVariableModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldUserPromptVariableUserId, userID).
+		Where(model.FieldUserPromptVariableName, name))
+	return err
+}
@@ -1,11 +1,14 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/msgcrypt"
+	"github.com/mylxsw/aidea-server/pkg/profiler"
 	"github.com/mylxsw/asteria/log"
 	"time"
 
@@ -40,8 +43,54 @@ func (Provider) Register(binder infra.Binder) {
 	binder.MustSingleton(NewFileStorageRepo)
 	binder.MustSingleton(NewArticleRepo)
 	binder.MustSingleton(NewNotificationRepo)
+	binder.MustSingleton(NewBatchRepo)
+	binder.MustSingleton(NewVectorRepo)
+	binder.MustSingleton(NewOCRRepo)
+	binder.MustSingleton(NewPDFRepo)
+	binder.MustSingleton(NewTableRepo)
+	binder.MustSingleton(NewPromptTemplateRepo)
+	binder.MustSingleton(NewResumeRepo)
+	binder.MustSingleton(NewRolePlayRepo)
+	binder.MustSingleton(NewTopicRepo)
+	binder.MustSingleton(NewUserStatsRepo)
+	binder.MustSingleton(NewAchievementRepo)
+	binder.MustSingleton(NewCheckInRepo)
+	binder.MustSingleton(NewPromoRepo)
+	binder.MustSingleton(NewOrgRepo)
+	binder.MustSingleton(NewRestrictedModeRepo)
+	binder.MustSingleton(NewKeywordFilterRepo)
+	binder.MustSingleton(NewAbuseRepo)
+	binder.MustSingleton(NewGeoPolicyRepo)
+	binder.MustSingleton(NewChannelRepo)
+	binder.MustSingleton(NewRoutingRuleRepo)
+	binder.MustSingleton(NewModelCostRepo)
+	binder.MustSingleton(NewPromptVariableRepo)
+	binder.MustSingleton(NewNoticeRepo)
+	binder.MustSingleton(NewVersionPolicyRepo)
+	binder.MustSingleton(NewQuotaRefundRepo)
+	binder.MustSingleton(NewChatImportRepo)
+	binder.MustSingleton(NewDisabledModelRepo)
+	binder.MustSingleton(NewPerfStatRepo)
+	binder.MustSingleton(NewImagePromptRepo)
+	binder.MustSingleton(NewAvatarPackRepo)
+	binder.MustSingleton(NewImagePresetRepo)
+	binder.MustSingleton(NewSupportTicketRepo)
+	binder.MustSingleton(NewBugReportRepo)
+	binder.MustSingleton(NewCoinTransferRepo)
+	binder.MustSingleton(NewAutoTopupRepo)
+	binder.MustSingleton(NewLedgerRepo)
+	binder.MustSingleton(NewModelTrialRepo)
+	binder.MustSingleton(NewModelComparisonRepo)
+	binder.MustSingleton(NewGlossaryRepo)
+	binder.MustSingleton(NewWritingToolRepo)
+	binder.MustSingleton(NewFlashcardRepo)
+	binder.MustSingleton(NewTutorRepo)
+	binder.MustSingleton(NewMockInterviewRepo)
+	binder.MustSingleton(NewOnboardingRepo)
+	binder.MustSingleton(NewBetaRepo)
 
 	// MySQL 数据库连接
+	binder.MustSingleton(msgcrypt.NewFromConfig)
 	binder.MustSingleton(func(conf *config.Config) (*sql.DB, error) {
 		conn, err := sql.Open("mysql", conf.DBURI)
 		if err != nil {
@@ -71,6 +120,14 @@ func (Provider) Boot(resolver infra.Resolver) {
 	event.SetDispatcher(eventManager)
 
 	resolver.MustResolve(func(conf *config.Config) {
+		// 记录查询耗时，超过阈值的慢查询写入日志，日志中不包含查询参数
+		eventManager.Listen(func(evt event.QueryExecutedEvent) {
+			sqlStr := profiler.NormalizeSQL(evt.SQL)
+			if profiler.Record(profiler.KindQuery, sqlStr, evt.Time, conf.SlowQueryThreshold) {
+				log.F(log.M{"sql": sqlStr, "elapse": evt.Time.Milliseconds()}).Warningf("slow query")
+			}
+		})
+
 		if !conf.DebugWithSQL {
 			return
 		}
@@ -85,19 +142,105 @@ func (Provider) Boot(resolver infra.Resolver) {
 	})
 }
 
+// Daemon 启动时检查缺失的索引，之后定时将查询与接口耗时统计写入数据库，多实例部署时每个实例分别写入
+func (Provider) Daemon(ctx context.Context, resolver infra.Resolver) {
+	resolver.MustResolve(func(db *sql.DB, rep *Repository) {
+		warnMissingIndexes(ctx, db)
+
+		ticker := time.NewTicker(perfStatFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				// 退出前写入剩余的统计
+				flushPerfStats(context.Background(), rep)
+				return
+			case <-ticker.C:
+				flushPerfStats(ctx, rep)
+			}
+		}
+	})
+}
+
+// perfStatFlushInterval 耗时统计写入数据库的周期
+const perfStatFlushInterval = time.Minute
+
+func warnMissingIndexes(ctx context.Context, db *sql.DB) {
+	missing, err := AuditIndexes(ctx, db)
+	if err != nil {
+		log.Errorf("audit database indexes failed: %v", err)
+		return
+	}
+
+	for _, spec := range missing {
+		log.F(log.M{"table": spec.Table, "columns": spec.Columns}).Warningf("missing database index %s, please run migrations", spec)
+	}
+}
+
+func flushPerfStats(ctx context.Context, rep *Repository) {
+	if err := rep.PerfStat.Save(ctx, time.Now(), profiler.Flush()); err != nil {
+		log.Errorf("save perf stats failed: %v", err)
+	}
+}
+
 type Repository struct {
-	Cache        *CacheRepo        `autowire:"@"`
-	Quota        *QuotaRepo        `autowire:"@"`
-	Queue        *QueueRepo        `autowire:"@"`
-	User         *UserRepo         `autowire:"@"`
-	Event        *EventRepo        `autowire:"@"`
-	Payment      *PaymentRepo      `autowire:"@"`
-	Room         *RoomRepo         `autowire:"@"`
-	Creative     *CreativeRepo     `autowire:"@"`
-	Message      *MessageRepo      `autowire:"@"`
-	Prompt       *PromptRepo       `autowire:"@"`
-	ChatGroup    *ChatGroupRepo    `autowire:"@"`
-	FileStorage  *FileStorageRepo  `autowire:"@"`
-	Notification *NotificationRepo `autowire:"@"`
-	Article      *ArticleRepo      `autowire:"@"`
+	Cache          *CacheRepo          `autowire:"@"`
+	Quota          *QuotaRepo          `autowire:"@"`
+	Queue          *QueueRepo          `autowire:"@"`
+	User           *UserRepo           `autowire:"@"`
+	Event          *EventRepo          `autowire:"@"`
+	Payment        *PaymentRepo        `autowire:"@"`
+	Room           *RoomRepo           `autowire:"@"`
+	Creative       *CreativeRepo       `autowire:"@"`
+	Message        *MessageRepo        `autowire:"@"`
+	Prompt         *PromptRepo         `autowire:"@"`
+	ChatGroup      *ChatGroupRepo      `autowire:"@"`
+	FileStorage    *FileStorageRepo    `autowire:"@"`
+	Notification   *NotificationRepo   `autowire:"@"`
+	Article        *ArticleRepo        `autowire:"@"`
+	Batch          *BatchRepo          `autowire:"@"`
+	Vector         *VectorRepo         `autowire:"@"`
+	OCR            *OCRRepo            `autowire:"@"`
+	PDF            *PDFRepo            `autowire:"@"`
+	Table          *TableRepo          `autowire:"@"`
+	PromptTemplate *PromptTemplateRepo `autowire:"@"`
+	Resume         *ResumeRepo         `autowire:"@"`
+	RolePlay       *RolePlayRepo       `autowire:"@"`
+	Topic          *TopicRepo          `autowire:"@"`
+	UserStats      *UserStatsRepo      `autowire:"@"`
+	Achievement    *AchievementRepo    `autowire:"@"`
+	CheckIn        *CheckInRepo        `autowire:"@"`
+	Promo          *PromoRepo          `autowire:"@"`
+	Org            *OrgRepo            `autowire:"@"`
+	RestrictedMode *RestrictedModeRepo `autowire:"@"`
+	KeywordFilter  *KeywordFilterRepo  `autowire:"@"`
+	Abuse          *AbuseRepo          `autowire:"@"`
+	GeoPolicy      *GeoPolicyRepo      `autowire:"@"`
+	Channel        *ChannelRepo        `autowire:"@"`
+	RoutingRule    *RoutingRuleRepo    `autowire:"@"`
+	ModelCost      *ModelCostRepo      `autowire:"@"`
+	PromptVariable *PromptVariableRepo `autowire:"@"`
+	Notice         *NoticeRepo         `autowire:"@"`
+	VersionPolicy  *VersionPolicyRepo  `autowire:"@"`
+	QuotaRefund    *QuotaRefundRepo    `autowire:"@"`
+	ChatImport     *ChatImportRepo     `autowire:"@"`
+	DisabledModel  *DisabledModelRepo  `autowire:"@"`
+	PerfStat       *PerfStatRepo       `autowire:"@"`
+	ImagePrompt    *ImagePromptRepo    `autowire:"@"`
+	AvatarPack     *AvatarPackRepo     `autowire:"@"`
+	ImagePreset    *ImagePresetRepo    `autowire:"@"`
+	SupportTicket  *SupportTicketRepo  `autowire:"@"`
+	BugReport      *BugReportRepo      `autowire:"@"`
+	CoinTransfer   *CoinTransferRepo   `autowire:"@"`
+	AutoTopup      *AutoTopupRepo      `autowire:"@"`
+	Ledger         *LedgerRepo         `autowire:"@"`
+	ModelTrial     *ModelTrialRepo     `autowire:"@"`
+	Glossary       *GlossaryRepo       `autowire:"@"`
+	WritingTool    *WritingToolRepo    `autowire:"@"`
+	Flashcard      *FlashcardRepo      `autowire:"@"`
+	Tutor          *TutorRepo          `autowire:"@"`
+	MockInterview  *MockInterviewRepo  `autowire:"@"`
+	Onboarding     *OnboardingRepo     `autowire:"@"`
+	Beta           *BetaRepo           `autowire:"@"`
 }
@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"github.com/mylxsw/aidea-server/pkg/misc"
 	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
+	"strings"
 	"time"
 
 	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/eloquent/query"
 	"gopkg.in/guregu/null.v3"
 )
@@ -53,15 +55,27 @@ func (repo *QueueRepo) Update(ctx context.Context, taskID string, status QueueTa
 
 	task.Status = null.StringFrom(string(status))
 
+	var data []byte
 	if result != nil {
-		data, err := json.Marshal(result)
+		data, err = json.Marshal(result)
 		if err != nil {
 			return err
 		}
 		task.Result = null.StringFrom(string(data))
 	}
 
-	return task.Save(ctx, model2.FieldQueueTasksStatus, model2.FieldQueueTasksResult)
+	if err := task.Save(ctx, model2.FieldQueueTasksStatus, model2.FieldQueueTasksResult); err != nil {
+		return err
+	}
+
+	// 任务状态变更时记录任务日志，日志写入失败不影响任务状态
+	if step, message := statusLog(status, data); step != "" {
+		if err := repo.AddLog(ctx, taskID, step, message); err != nil {
+			log.F(log.M{"task_id": taskID}).Errorf("记录任务日志失败: %v", err)
+		}
+	}
+
+	return nil
 }
 
 func (repo *QueueRepo) Tasks(ctx context.Context, userID int64, taskType string) ([]model2.QueueTasks, error) {
@@ -94,6 +108,42 @@ func (repo *QueueRepo) Task(ctx context.Context, taskID string) (*model2.QueueTa
 	return &res, nil
 }
 
+// QueuePosition 任务的排队位置，即同类型任务中比该任务更早创建且仍在排队的任务数量
+func (repo *QueueRepo) QueuePosition(ctx context.Context, taskType string, createdAt time.Time) (int64, error) {
+	return model2.NewQueueTasksModel(repo.db).Count(ctx, query.Builder().
+		Where(model2.FieldQueueTasksTaskType, taskType).
+		Where(model2.FieldQueueTasksStatus, QueueTaskStatusPending).
+		Where(model2.FieldQueueTasksCreatedAt, "<", createdAt))
+}
+
+// CompletedCount 指定时间之后处理完成（成功或失败）的同类型任务数量
+func (repo *QueueRepo) CompletedCount(ctx context.Context, taskType string, since time.Time) (int64, error) {
+	return model2.NewQueueTasksModel(repo.db).Count(ctx, query.Builder().
+		Where(model2.FieldQueueTasksTaskType, taskType).
+		WhereIn(model2.FieldQueueTasksStatus, QueueTaskStatusSuccess, QueueTaskStatusFailed).
+		Where(model2.FieldQueueTasksUpdatedAt, ">=", since))
+}
+
+// TasksByRequestID 查询用户在指定请求中创建的异步任务，请求 ID 在入队时写入任务载荷
+func (repo *QueueRepo) TasksByRequestID(ctx context.Context, uid int64, requestID string, since time.Time) ([]model2.QueueTasks, error) {
+	pattern := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(requestID)
+	tasks, err := model2.NewQueueTasksModel(repo.db).Get(ctx, query.Builder().
+		Where(model2.FieldQueueTasksUid, uid).
+		Where(model2.FieldQueueTasksCreatedAt, ">=", since).
+		Where(model2.FieldQueueTasksPayload, "LIKE", `%"\_request\_id":"`+pattern+`"%`).
+		OrderBy(model2.FieldQueueTasksId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]model2.QueueTasks, len(tasks))
+	for idx, task := range tasks {
+		res[idx] = task.ToQueueTasks()
+	}
+
+	return res, nil
+}
+
 func (repo *QueueRepo) Remove(ctx context.Context, taskID string) error {
 	_, err := model2.NewQueueTasksModel(repo.db).Delete(ctx, query.Builder().Where(model2.FieldQueueTasksTaskId, taskID))
 	return err
@@ -145,8 +195,15 @@ func (repo *QueueRepo) CreatePendingTask(ctx context.Context, task *PendingTask)
 		kv[model2.FieldQueueTasksPendingDeadlineAt] = time.Now().Add(24 * time.Hour)
 	}
 
-	_, err = model2.NewQueueTasksPendingModel(repo.db).Create(ctx, kv)
-	return err
+	if _, err = model2.NewQueueTasksPendingModel(repo.db).Create(ctx, kv); err != nil {
+		return err
+	}
+
+	if err := repo.AddLog(ctx, task.TaskID, QueueTaskStepProviderPending, "已提交给服务提供商，等待返回结果"); err != nil {
+		log.F(log.M{"task_id": task.TaskID}).Errorf("记录任务日志失败: %v", err)
+	}
+
+	return nil
 }
 
 func (repo *QueueRepo) PendingTasks(ctx context.Context) ([]model2.QueueTasksPending, error) {
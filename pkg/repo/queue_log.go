@@ -0,0 +1,128 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 异步任务的处理步骤
+const (
+	QueueTaskStepQueued          = "queued"
+	QueueTaskStepStarted         = "started"
+	QueueTaskStepRetried         = "retried"
+	QueueTaskStepProvider        = "provider"
+	QueueTaskStepProviderPending = "provider_pending"
+	QueueTaskStepUploaded        = "uploaded"
+	QueueTaskStepSucceeded       = "succeeded"
+	QueueTaskStepFailed          = "failed"
+	QueueTaskStepTimeout         = "timeout"
+)
+
+// queueTaskLogMaxLength 单条任务日志的最大长度（字符），与数据库字段长度一致
+const queueTaskLogMaxLength = 500
+
+// QueueTaskLog 异步任务的处理步骤日志，用户与客服可以查看任务耗时较长或失败的原因
+type QueueTaskLog struct {
+	Step      string    `json:"step"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+var (
+	// 签名地址的查询参数中包含访问凭证
+	taskLogURLQuery = regexp.MustCompile(`(https?://[^\s?#"'<>]+)[?#][^\s"'<>]*`)
+	taskLogSecrets  = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`\bsk-[A-Za-z0-9_\-]{8,}`), "sk-***"},
+		{regexp.MustCompile(`(?i)("?(?:api[_-]?key|access[_-]?key|secret|token|password|authorization)"?\s*[:=]\s*)("[^"]*"|(?:(?:bearer|basic)\s+)?[^\s,;&}]+)`), "$1***"},
+		{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9_\-.=:+/]+`), "$1 ***"},
+	}
+	// 本地文件路径可能暴露服务器的目录结构
+	taskLogFilePath = regexp.MustCompile(`(?:/tmp|/var|/home|/root|/Users)/[^\s"':]+`)
+)
+
+// SanitizeTaskLog 删除任务日志中的访问凭证、签名参数以及服务器路径等敏感信息，并限制日志长度
+func SanitizeTaskLog(message string) string {
+	message = taskLogURLQuery.ReplaceAllString(message, "$1")
+	for _, s := range taskLogSecrets {
+		message = s.re.ReplaceAllString(message, s.repl)
+	}
+
+	message = taskLogFilePath.ReplaceAllString(message, "***")
+	// 截断后会追加省略号，需要保证总长度不超过字段长度
+	return misc.SubString(strings.TrimSpace(message), queueTaskLogMaxLength-3)
+}
+
+// AddLog 记录任务的处理步骤，日志内容会先脱敏
+func (repo *QueueRepo) AddLog(ctx context.Context, taskID, step, message string) error {
+	if taskID == "" {
+		return nil
+	}
+
+	_, err := model2.NewQueueTaskLogModel(repo.db).Create(ctx, query.KV{
+		model2.FieldQueueTaskLogTaskId:  taskID,
+		model2.FieldQueueTaskLogStep:    step,
+		model2.FieldQueueTaskLogMessage: SanitizeTaskLog(message),
+	})
+	return err
+}
+
+// Logs 查询任务的处理步骤，按照时间先后排序
+func (repo *QueueRepo) Logs(ctx context.Context, taskID string) ([]QueueTaskLog, error) {
+	items, err := model2.NewQueueTaskLogModel(repo.db).Get(ctx, query.Builder().
+		Where(model2.FieldQueueTaskLogTaskId, taskID).
+		OrderBy(model2.FieldQueueTaskLogId, "ASC").
+		Limit(200))
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]QueueTaskLog, 0, len(items))
+	for _, item := range items {
+		logs = append(logs, QueueTaskLog{
+			Step:      item.Step.ValueOrZero(),
+			Message:   item.Message.ValueOrZero(),
+			CreatedAt: item.CreatedAt.ValueOrZero(),
+		})
+	}
+
+	return logs, nil
+}
+
+// RemoveLogs 清理指定时间之前的任务日志
+func (repo *QueueRepo) RemoveLogs(ctx context.Context, before time.Time) error {
+	_, err := model2.NewQueueTaskLogModel(repo.db).Delete(ctx, query.Builder().Where(model2.FieldQueueTaskLogCreatedAt, "<", before))
+	return err
+}
+
+// statusLog 任务状态变更时记录的日志
+func statusLog(status QueueTaskStatus, result []byte) (step, message string) {
+	switch status {
+	case QueueTaskStatusRunning:
+		return QueueTaskStepProvider, "服务提供商处理中"
+	case QueueTaskStatusSuccess:
+		return QueueTaskStepSucceeded, "任务处理完成"
+	case QueueTaskStatusFailed:
+		var res struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(result, &res)
+		if len(res.Errors) == 0 {
+			return QueueTaskStepFailed, "任务处理失败"
+		}
+
+		return QueueTaskStepFailed, fmt.Sprintf("任务处理失败：%s", strings.Join(res.Errors, "; "))
+	}
+
+	return "", ""
+}
@@ -0,0 +1,18 @@
+package repo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestSanitizeTaskLog(t *testing.T) {
+	assert.Equal(t, "下载图片失败: https://cdn.example.com/a.png", repo.SanitizeTaskLog("下载图片失败: https://cdn.example.com/a.png?token=abc&e=123"))
+	assert.Equal(t, "invalid key sk-***", repo.SanitizeTaskLog("invalid key sk-1234567890abcdef"))
+	assert.Equal(t, "Authorization: ***", repo.SanitizeTaskLog("Authorization: Bearer abc.def"))
+	assert.Equal(t, `request failed {"api_key":***}`, repo.SanitizeTaskLog(`request failed {"api_key":"xyz"}`))
+	assert.Equal(t, "open ***: no such file", repo.SanitizeTaskLog("open /tmp/upload-123.png: no such file"))
+	assert.Equal(t, 500, len([]rune(repo.SanitizeTaskLog(strings.Repeat("错", 600)))))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
 	"time"
 
@@ -38,15 +39,26 @@ func (repo *QuotaRepo) AddUserQuota(ctx context.Context, userID int64, quotaValu
 		PeriodEndAt:   TimeInDate(endAt),
 	}
 
-	return model2.NewQuotaModel(repo.db).Save(ctx, quota.ToQuotaN(
-		model2.FieldQuotaUserId,
-		model2.FieldQuotaQuota,
-		model2.FieldQuotaRest,
-		model2.FieldQuotaNote,
-		model2.FieldQuotaPaymentId,
-		model2.FieldQuotaPeriodStartAt,
-		model2.FieldQuotaPeriodEndAt,
-	))
+	var id int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		var err error
+		id, err = model2.NewQuotaModel(tx).Save(ctx, quota.ToQuotaN(
+			model2.FieldQuotaUserId,
+			model2.FieldQuotaQuota,
+			model2.FieldQuotaRest,
+			model2.FieldQuotaNote,
+			model2.FieldQuotaPaymentId,
+			model2.FieldQuotaPeriodStartAt,
+			model2.FieldQuotaPeriodEndAt,
+		))
+		if err != nil {
+			return err
+		}
+
+		return postLedger(ctx, tx, issueLedger(LedgerKindGift, LedgerAccountGift, LedgerAccountUserWallet, userID, quotaValue, fmt.Sprintf("quota:%d", id), note))
+	})
+
+	return id, err
 }
 
 // TimeInDate 获取时间的日期部分
@@ -113,8 +125,12 @@ type QuotaSummary struct {
 	Used  int64 `json:"used"`
 }
 
-// GetUserQuota 获取用户配额
+// GetUserQuota 获取用户配额，context 中指定了计费组织时，返回用户可以使用的组织配额
 func (repo *QuotaRepo) GetUserQuota(ctx context.Context, userID int64) (*QuotaSummary, error) {
+	if orgID := BillingOrgFromContext(ctx); orgID > 0 {
+		return orgMemberQuota(ctx, repo.db, orgID, userID)
+	}
+
 	q := query.Builder().
 		Table(model2.QuotaTable()).
 		Select(
@@ -156,8 +172,12 @@ func NewQuotaUsedMeta(tag string, models ...string) QuotaUsedMeta {
 	}
 }
 
-// QuotaConsume 更新用户配额已使用量
+// QuotaConsume 更新用户配额已使用量，context 中指定了计费组织时，从组织的共享钱包中扣除
 func (repo *QuotaRepo) QuotaConsume(ctx context.Context, userID int64, used int64, meta QuotaUsedMeta) error {
+	if orgID := BillingOrgFromContext(ctx); orgID > 0 {
+		return orgQuotaConsume(ctx, repo.db, orgID, userID, used, meta)
+	}
+
 	relatedQuotaIds := make(map[int64]int64)
 	var debt int64
 
@@ -180,8 +200,12 @@ func (repo *QuotaRepo) QuotaConsume(ctx context.Context, userID int64, used int6
 			if rest >= usedVar {
 				relatedQuotaIds[quotaID] = usedVar
 				// 当前配额足够，直接更新配额
-				_, err := tx.ExecContext(ctx, "UPDATE quota SET rest = rest - ? WHERE id = ?", usedVar, quotaID)
-				return err
+				if _, err := tx.ExecContext(ctx, "UPDATE quota SET rest = rest - ? WHERE id = ?", usedVar, quotaID); err != nil {
+					return err
+				}
+
+				usedVar = 0
+				break
 			}
 
 			relatedQuotaIds[quotaID] = rest
@@ -205,11 +229,9 @@ func (repo *QuotaRepo) QuotaConsume(ctx context.Context, userID int64, used int6
 			}); err != nil {
 				return err
 			}
-
-			return nil
 		}
 
-		return nil
+		return postLedger(ctx, tx, consumeLedger(LedgerAccountUserWallet, LedgerAccountUserDebt, userID, used, debt, meta.Tag))
 	})
 
 	if err == nil {
@@ -256,6 +278,23 @@ func (repo *QuotaRepo) GetQuotaStatisticsRecently(ctx context.Context, userId in
 	}), nil
 }
 
+// GetQuotaStatisticsActiveUsers 获取近期有配额使用统计的用户
+func (repo *QuotaRepo) GetQuotaStatisticsActiveUsers(ctx context.Context, days int64) ([]int64, error) {
+	q := query.Builder().
+		Table(model2.QuotaStatisticsTable()).
+		Select(query.Raw("DISTINCT user_id")).
+		Where(model2.FieldQuotaStatisticsCreatedAt, ">=", time.Now().AddDate(0, 0, -int(days)))
+
+	return eloquent.Query(ctx, repo.db, q, func(row eloquent.Scanner) (int64, error) {
+		var userID int64
+		if err := row.Scan(&userID); err != nil {
+			return 0, err
+		}
+
+		return userID, nil
+	})
+}
+
 type QuotaUsage struct {
 	model2.QuotaUsage
 	QuotaMeta QuotaUsedMeta `json:"quota_meta,omitempty"`
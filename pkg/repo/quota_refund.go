@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// refundQuotaValidity 退还的智慧果有效期
+const refundQuotaValidity = 365 * 24 * time.Hour
+
+// QuotaRefund 上游服务失败导致的智慧果退款
+type QuotaRefund struct {
+	UserID int64
+	// OrgID 计费组织，从个人钱包扣费时为 0，退款退回到扣费的钱包
+	OrgID    int64
+	Model    string
+	Provider string
+	// Charged 本次请求扣除的智慧果
+	Charged int64
+	// Refunded 退还的智慧果
+	Refunded int64
+	Reason   string
+}
+
+// QuotaRefundReportItem 退款报表中的一行，按照 服务商 + 模型 聚合
+type QuotaRefundReportItem struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Requests int64  `json:"requests"`
+	Charged  int64  `json:"charged"`
+	Refunded int64  `json:"refunded"`
+}
+
+// QuotaRefundRepo 智慧果退款
+type QuotaRefundRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewQuotaRefundRepo create a new QuotaRefundRepo
+func NewQuotaRefundRepo(db *sql.DB, conf *config.Config) *QuotaRefundRepo {
+	return &QuotaRefundRepo{db: db, conf: conf}
+}
+
+// Refund 将退款作为新的配额记录退还到扣费的钱包，同时写入退款记录，两者在同一个事务中完成
+func (repo *QuotaRefundRepo) Refund(ctx context.Context, refund QuotaRefund) (int64, error) {
+	note := "上游服务异常退款：" + refund.Model
+	endAt := TimeInDate(time.Now().Add(refundQuotaValidity))
+
+	var quotaID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		var err error
+		if refund.OrgID > 0 {
+			quotaID, err = model.NewOrgQuotaModel(tx).Create(ctx, query.KV{
+				model.FieldOrgQuotaOrgId:         refund.OrgID,
+				model.FieldOrgQuotaQuota:         refund.Refunded,
+				model.FieldOrgQuotaRest:          refund.Refunded,
+				model.FieldOrgQuotaNote:          note,
+				model.FieldOrgQuotaPeriodStartAt: NowInDate(),
+				model.FieldOrgQuotaPeriodEndAt:   endAt,
+			})
+		} else {
+			quotaID, err = model.NewQuotaModel(tx).Create(ctx, query.KV{
+				model.FieldQuotaUserId:        refund.UserID,
+				model.FieldQuotaQuota:         refund.Refunded,
+				model.FieldQuotaRest:          refund.Refunded,
+				model.FieldQuotaNote:          note,
+				model.FieldQuotaPeriodStartAt: NowInDate(),
+				model.FieldQuotaPeriodEndAt:   endAt,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		ref := fmt.Sprintf("quota:%d", quotaID)
+		if refund.OrgID > 0 {
+			ref = fmt.Sprintf("org_quota:%d", quotaID)
+		}
+
+		wallet, ownerID := walletAccount(refund.OrgID, refund.UserID)
+		if err := postLedger(ctx, tx, issueLedger(LedgerKindRefund, LedgerAccountRefund, wallet, ownerID, refund.Refunded, ref, note)); err != nil {
+			return err
+		}
+
+		_, err = model.NewQuotaRefundModel(tx).Create(ctx, query.KV{
+			model.FieldQuotaRefundUserId:   refund.UserID,
+			model.FieldQuotaRefundOrgId:    refund.OrgID,
+			model.FieldQuotaRefundModel:    refund.Model,
+			model.FieldQuotaRefundProvider: refund.Provider,
+			model.FieldQuotaRefundCharged:  refund.Charged,
+			model.FieldQuotaRefundRefunded: refund.Refunded,
+			model.FieldQuotaRefundQuotaId:  quotaID,
+			model.FieldQuotaRefundReason:   refund.Reason,
+		})
+		return err
+	})
+
+	return quotaID, err
+}
+
+// Report 查询 [startAt, endAt) 期间的退款报表，按照退款数量从高到低排序，便于追究服务商的责任
+func (repo *QuotaRefundRepo) Report(ctx context.Context, startAt, endAt time.Time) ([]QuotaRefundReportItem, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT provider, model, COUNT(*), SUM(charged), SUM(refunded) FROM quota_refund WHERE created_at >= ? AND created_at < ? GROUP BY provider, model ORDER BY SUM(refunded) DESC",
+		startAt, endAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]QuotaRefundReportItem, 0)
+	for rows.Next() {
+		var item QuotaRefundReportItem
+		if err := rows.Scan(&item.Provider, &item.Model, &item.Requests, &item.Charged, &item.Refunded); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
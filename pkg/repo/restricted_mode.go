@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrRestrictedModeEnabled    = errors.New("restricted mode has been enabled")
+	ErrRestrictedModeInvalidPIN = errors.New("invalid restricted mode pin")
+)
+
+// RestrictedModeRepo 受限模式（家长模式），开启后强制使用最严格的内容安全策略，并限制可用的模型与每日使用量
+type RestrictedModeRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewRestrictedModeRepo create a new RestrictedModeRepo
+func NewRestrictedModeRepo(db *sql.DB, conf *config.Config) *RestrictedModeRepo {
+	return &RestrictedModeRepo{db: db, conf: conf}
+}
+
+// Get 查询用户的受限模式设置，用户未开启受限模式时返回 ErrNotFound
+func (repo *RestrictedModeRepo) Get(ctx context.Context, userID int64) (*model.UserRestrictedMode, error) {
+	item, err := model.NewUserRestrictedModeModel(repo.db).First(ctx, query.Builder().Where(model.FieldUserRestrictedModeUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := item.ToUserRestrictedMode()
+	return &ret, nil
+}
+
+// Enable 开启受限模式，已经开启时返回 ErrRestrictedModeEnabled
+func (repo *RestrictedModeRepo) Enable(ctx context.Context, userID int64, pin string, dailyLimit int64) error {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	res, err := repo.db.ExecContext(
+		ctx,
+		"INSERT IGNORE INTO user_restricted_mode (user_id, pin, daily_limit, created_at, updated_at) VALUES (?, ?, ?, NOW(), NOW())",
+		userID, string(hashed), dailyLimit,
+	)
+	if err != nil {
+		return fmt.Errorf("enable restricted mode failed: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrRestrictedModeEnabled
+	}
+
+	return nil
+}
+
+// VerifyPIN 校验受限模式 PIN，用户未开启受限模式时返回 ErrNotFound，PIN 错误时返回 ErrRestrictedModeInvalidPIN
+func (repo *RestrictedModeRepo) VerifyPIN(ctx context.Context, userID int64, pin string) error {
+	item, err := repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(item.Pin), []byte(pin)); err != nil {
+		return ErrRestrictedModeInvalidPIN
+	}
+
+	return nil
+}
+
+// Disable 关闭受限模式，调用前需要先校验 PIN
+func (repo *RestrictedModeRepo) Disable(ctx context.Context, userID int64) error {
+	_, err := model.NewUserRestrictedModeModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldUserRestrictedModeUserId, userID))
+	return err
+}
+
+// UpdateDailyLimit 更新受限模式下的每日智慧果使用上限，调用前需要先校验 PIN
+func (repo *RestrictedModeRepo) UpdateDailyLimit(ctx context.Context, userID int64, dailyLimit int64) error {
+	_, err := model.NewUserRestrictedModeModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldUserRestrictedModeDailyLimit: dailyLimit},
+		query.Builder().Where(model.FieldUserRestrictedModeUserId, userID),
+	)
+	return err
+}
+
+// TodayUsed 查询用户今天已经使用的智慧果，包括个人钱包与组织钱包
+func (repo *RestrictedModeRepo) TodayUsed(ctx context.Context, userID int64) (int64, error) {
+	today := NowInDate()
+
+	var used sql.NullInt64
+	if err := repo.db.QueryRowContext(
+		ctx,
+		"SELECT (SELECT COALESCE(SUM(used), 0) FROM quota_usage WHERE user_id = ? AND created_at >= ?) + (SELECT COALESCE(SUM(used), 0) FROM org_quota_usage WHERE user_id = ? AND created_at >= ?)",
+		userID, today, userID, today,
+	).Scan(&used); err != nil {
+		return 0, err
+	}
+
+	return used.Int64, nil
+}
@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+type ResumeRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewResumeRepo create a new ResumeRepo
+func NewResumeRepo(db *sql.DB, conf *config.Config) *ResumeRepo {
+	return &ResumeRepo{db: db, conf: conf}
+}
+
+// ResumePolishAddReq 简历优化结果
+type ResumePolishAddReq struct {
+	UserID int64
+	// ThreadID 迭代的简历线程 ID，为 0 时创建新的线程
+	ThreadID       int64
+	TemplateID     int64
+	Model          string
+	Resume         string
+	JobDescription string
+	Feedback       string
+	// BulletPoints 优化后的经历要点，JSON 格式
+	BulletPoints string
+	CoverLetter  string
+	Coins        int64
+}
+
+// AddPolish 保存简历优化结果，同一线程中的版本号自动递增
+func (repo *ResumeRepo) AddPolish(ctx context.Context, req ResumePolishAddReq) (*model.ResumePolish, error) {
+	var id int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		version := int64(1)
+		if req.ThreadID > 0 {
+			latest, err := model.NewResumePolishModel(tx).First(ctx, query.Builder().
+				Where(model.FieldResumePolishUserId, req.UserID).
+				Where(model.FieldResumePolishThreadId, req.ThreadID).
+				OrderBy(model.FieldResumePolishVersion, "DESC"))
+			if err != nil {
+				if errors.Is(err, query.ErrNoResult) {
+					return ErrNotFound
+				}
+
+				return err
+			}
+
+			version = latest.Version.ValueOrZero() + 1
+		}
+
+		var err error
+		id, err = model.NewResumePolishModel(tx).Create(ctx, query.KV{
+			model.FieldResumePolishUserId:         req.UserID,
+			model.FieldResumePolishThreadId:       req.ThreadID,
+			model.FieldResumePolishVersion:        version,
+			model.FieldResumePolishTemplateId:     req.TemplateID,
+			model.FieldResumePolishModel:          req.Model,
+			model.FieldResumePolishResume:         req.Resume,
+			model.FieldResumePolishJobDescription: req.JobDescription,
+			model.FieldResumePolishFeedback:       req.Feedback,
+			model.FieldResumePolishBulletPoints:   req.BulletPoints,
+			model.FieldResumePolishCoverLetter:    req.CoverLetter,
+			model.FieldResumePolishCoins:          req.Coins,
+		})
+		if err != nil {
+			return fmt.Errorf("create resume polish failed: %w", err)
+		}
+
+		// 新线程以第一个版本的 ID 作为线程 ID
+		if req.ThreadID == 0 {
+			_, err = model.NewResumePolishModel(tx).UpdateFields(
+				ctx,
+				query.KV{model.FieldResumePolishThreadId: id},
+				query.Builder().Where(model.FieldResumePolishId, id),
+			)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.GetPolish(ctx, req.UserID, id)
+}
+
+// GetPolish 查询简历优化结果
+func (repo *ResumeRepo) GetPolish(ctx context.Context, userID, id int64) (*model.ResumePolish, error) {
+	item, err := model.NewResumePolishModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldResumePolishId, id).
+		Where(model.FieldResumePolishUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := item.ToResumePolish()
+	return &ret, nil
+}
+
+// GetThreadVersions 查询简历线程中的所有版本，按照版本号降序排列
+func (repo *ResumeRepo) GetThreadVersions(ctx context.Context, userID, threadID int64) ([]model.ResumePolish, error) {
+	items, err := model.NewResumePolishModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldResumePolishUserId, userID).
+		Where(model.FieldResumePolishThreadId, threadID).
+		OrderBy(model.FieldResumePolishVersion, "DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.ResumePolishN, _ int) model.ResumePolish {
+		return item.ToResumePolish()
+	}), nil
+}
+
+// GetThreads 查询用户的简历线程列表，每个线程只返回最新版本的概要信息
+func (repo *ResumeRepo) GetThreads(ctx context.Context, userID int64, limit int64) ([]model.ResumePolish, error) {
+	items, err := model.NewResumePolishModel(repo.db).Get(ctx, query.Builder().
+		Select(
+			model.FieldResumePolishId,
+			model.FieldResumePolishUserId,
+			model.FieldResumePolishThreadId,
+			model.FieldResumePolishVersion,
+			model.FieldResumePolishTemplateId,
+			model.FieldResumePolishModel,
+			model.FieldResumePolishCoins,
+			model.FieldResumePolishCreatedAt,
+			model.FieldResumePolishUpdatedAt,
+		).
+		WhereRaw("id IN (SELECT MAX(id) FROM resume_polish WHERE user_id = ? GROUP BY thread_id)", userID).
+		OrderBy(model.FieldResumePolishId, "DESC").
+		Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.ResumePolishN, _ int) model.ResumePolish {
+		return item.ToResumePolish()
+	}), nil
+}
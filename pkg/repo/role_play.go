@@ -0,0 +1,176 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+	"gopkg.in/guregu/null.v3"
+)
+
+type RolePlayRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewRolePlayRepo create a new RolePlayRepo
+func NewRolePlayRepo(db *sql.DB, conf *config.Config) *RolePlayRepo {
+	return &RolePlayRepo{db: db, conf: conf}
+}
+
+// RolePlaySessionAddReq 角色扮演会话
+type RolePlaySessionAddReq struct {
+	UserID  int64
+	Title   string
+	Model   string
+	Setting string
+	// State 初始状态，JSON 格式
+	State string
+}
+
+// CreateSession 创建角色扮演会话
+func (repo *RolePlayRepo) CreateSession(ctx context.Context, req RolePlaySessionAddReq) (int64, error) {
+	return model.NewRolePlaySessionModel(repo.db).Create(ctx, query.KV{
+		model.FieldRolePlaySessionUserId:  req.UserID,
+		model.FieldRolePlaySessionTitle:   misc.SubString(req.Title, 255),
+		model.FieldRolePlaySessionModel:   req.Model,
+		model.FieldRolePlaySessionSetting: req.Setting,
+		model.FieldRolePlaySessionState:   null.StringFrom(req.State),
+	})
+}
+
+// GetSession 查询用户的角色扮演会话
+func (repo *RolePlayRepo) GetSession(ctx context.Context, userID, id int64) (*model.RolePlaySession, error) {
+	sess, err := model.NewRolePlaySessionModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldRolePlaySessionId, id).
+		Where(model.FieldRolePlaySessionUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := sess.ToRolePlaySession()
+	return &ret, nil
+}
+
+// GetSessions 查询用户的角色扮演会话列表
+func (repo *RolePlayRepo) GetSessions(ctx context.Context, userID int64) ([]model.RolePlaySession, error) {
+	items, err := model.NewRolePlaySessionModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldRolePlaySessionUserId, userID).
+		OrderBy(model.FieldRolePlaySessionUpdatedAt, "DESC").
+		Limit(100))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(items, func(item model.RolePlaySessionN, _ int) model.RolePlaySession {
+		return item.ToRolePlaySession()
+	}), nil
+}
+
+// DeleteSession 删除角色扮演会话及其所有消息
+func (repo *RolePlayRepo) DeleteSession(ctx context.Context, userID, id int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		affected, err := model.NewRolePlaySessionModel(tx).Delete(ctx, query.Builder().
+			Where(model.FieldRolePlaySessionId, id).
+			Where(model.FieldRolePlaySessionUserId, userID))
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return ErrNotFound
+		}
+
+		_, err = model.NewRolePlayMessageModel(tx).Delete(ctx, query.Builder().Where(model.FieldRolePlayMessageSessionId, id))
+		return err
+	})
+}
+
+// UpdateState 更新会话状态，用于客户端手动修正状态
+func (repo *RolePlayRepo) UpdateState(ctx context.Context, userID, id int64, state string) error {
+	_, err := model.NewRolePlaySessionModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldRolePlaySessionState: null.StringFrom(state)},
+		query.Builder().
+			Where(model.FieldRolePlaySessionId, id).
+			Where(model.FieldRolePlaySessionUserId, userID),
+	)
+	return err
+}
+
+// RolePlayTurn 一轮角色扮演对话
+type RolePlayTurn struct {
+	UserID      int64
+	SessionID   int64
+	UserMessage string
+	Reply       string
+	// State 本轮对话后的新状态，JSON 格式
+	State string
+	Coins int64
+}
+
+// AddTurn 保存一轮对话，同时更新会话的状态和统计数据
+func (repo *RolePlayRepo) AddTurn(ctx context.Context, turn RolePlayTurn) (int64, error) {
+	var replyID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewRolePlayMessageModel(tx).Create(ctx, query.KV{
+			model.FieldRolePlayMessageSessionId: turn.SessionID,
+			model.FieldRolePlayMessageUserId:    turn.UserID,
+			model.FieldRolePlayMessageRole:      "user",
+			model.FieldRolePlayMessageContent:   turn.UserMessage,
+		}); err != nil {
+			return fmt.Errorf("create role-play message failed: %w", err)
+		}
+
+		id, err := model.NewRolePlayMessageModel(tx).Create(ctx, query.KV{
+			model.FieldRolePlayMessageSessionId: turn.SessionID,
+			model.FieldRolePlayMessageUserId:    turn.UserID,
+			model.FieldRolePlayMessageRole:      "assistant",
+			model.FieldRolePlayMessageContent:   turn.Reply,
+			model.FieldRolePlayMessageState:     null.StringFrom(turn.State),
+			model.FieldRolePlayMessageCoins:     turn.Coins,
+		})
+		if err != nil {
+			return fmt.Errorf("create role-play message failed: %w", err)
+		}
+
+		replyID = id
+
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE role_play_session SET state = ?, turns = turns + 1, coins = coins + ?, updated_at = NOW() WHERE id = ?",
+			turn.State, turn.Coins, turn.SessionID,
+		)
+		return err
+	})
+
+	return replyID, err
+}
+
+// GetMessages 查询会话最近的消息，按照时间正序排列
+func (repo *RolePlayRepo) GetMessages(ctx context.Context, sessionID int64, limit int64) ([]model.RolePlayMessage, error) {
+	items, err := model.NewRolePlayMessageModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldRolePlayMessageSessionId, sessionID).
+		OrderBy(model.FieldRolePlayMessageId, "DESC").
+		Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := array.Map(items, func(item model.RolePlayMessageN, _ int) model.RolePlayMessage {
+		return item.ToRolePlayMessage()
+	})
+
+	return array.Reverse(messages), nil
+}
@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// RoomFolderMaxPerUser 每个用户最多可以创建的文件夹数量
+const RoomFolderMaxPerUser = 50
+
+var (
+	// ErrRoomFolderExists 同名的文件夹已经存在
+	ErrRoomFolderExists = errors.New("room folder already exists")
+	// ErrRoomFolderLimitExceeded 文件夹数量超过限制
+	ErrRoomFolderLimitExceeded = errors.New("room folder limit exceeded")
+)
+
+// RoomFolder 会话文件夹，用于整理会话与群聊
+type RoomFolder struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Sort      int64     `json:"sort"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Folders 查询用户的所有文件夹
+func (r *RoomRepo) Folders(ctx context.Context, userID int64) ([]RoomFolder, error) {
+	items, err := model2.NewRoomFolderModel(r.db).Get(ctx, query.Builder().
+		Where(model2.FieldRoomFolderUserId, userID).
+		OrderBy(model2.FieldRoomFolderSort, "ASC").
+		OrderBy(model2.FieldRoomFolderId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make([]RoomFolder, 0, len(items))
+	for _, item := range items {
+		folders = append(folders, RoomFolder{
+			ID:        item.Id.ValueOrZero(),
+			Name:      item.Name.ValueOrZero(),
+			Sort:      item.Sort.ValueOrZero(),
+			CreatedAt: item.CreatedAt.ValueOrZero(),
+			UpdatedAt: item.UpdatedAt.ValueOrZero(),
+		})
+	}
+
+	return folders, nil
+}
+
+func (r *RoomRepo) folderNameExists(ctx context.Context, userID int64, name string, excludeID int64) (bool, error) {
+	q := query.Builder().
+		Where(model2.FieldRoomFolderUserId, userID).
+		Where(model2.FieldRoomFolderName, name)
+	if excludeID > 0 {
+		q = q.Where(model2.FieldRoomFolderId, "!=", excludeID)
+	}
+
+	return model2.NewRoomFolderModel(r.db).Exists(ctx, q)
+}
+
+// CreateFolder 创建文件夹
+func (r *RoomRepo) CreateFolder(ctx context.Context, userID int64, name string, sort int64) (int64, error) {
+	count, err := model2.NewRoomFolderModel(r.db).Count(ctx, query.Builder().Where(model2.FieldRoomFolderUserId, userID))
+	if err != nil {
+		return 0, err
+	}
+
+	if count >= RoomFolderMaxPerUser {
+		return 0, ErrRoomFolderLimitExceeded
+	}
+
+	exist, err := r.folderNameExists(ctx, userID, name, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if exist {
+		return 0, ErrRoomFolderExists
+	}
+
+	return model2.NewRoomFolderModel(r.db).Create(ctx, query.KV{
+		model2.FieldRoomFolderUserId: userID,
+		model2.FieldRoomFolderName:   name,
+		model2.FieldRoomFolderSort:   sort,
+	})
+}
+
+// UpdateFolder 修改文件夹名称与排序，文件夹不存在时返回 ErrNotFound
+func (r *RoomRepo) UpdateFolder(ctx context.Context, userID, folderID int64, name string, sort int64) error {
+	if err := r.checkFolder(ctx, userID, folderID); err != nil {
+		return err
+	}
+
+	exist, err := r.folderNameExists(ctx, userID, name, folderID)
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		return ErrRoomFolderExists
+	}
+
+	_, err = model2.NewRoomFolderModel(r.db).UpdateFields(ctx, query.KV{
+		model2.FieldRoomFolderName: name,
+		model2.FieldRoomFolderSort: sort,
+	}, query.Builder().Where(model2.FieldRoomFolderId, folderID).Where(model2.FieldRoomFolderUserId, userID))
+	return err
+}
+
+// DeleteFolder 删除文件夹，文件夹中的会话移出文件夹，不会被删除
+func (r *RoomRepo) DeleteFolder(ctx context.Context, userID, folderID int64) error {
+	return eloquent.Transaction(r.db, func(tx query.Database) error {
+		// 回收站中的会话同样需要移出文件夹，避免恢复后指向已删除的文件夹
+		if _, err := model2.NewRoomsModel(tx).WithTrashed().UpdateFields(ctx, query.KV{model2.FieldRoomsFolderId: 0}, query.Builder().
+			Where(model2.FieldRoomsUserId, userID).
+			Where(model2.FieldRoomsFolderId, folderID)); err != nil {
+			return err
+		}
+
+		_, err := model2.NewRoomFolderModel(tx).Delete(ctx, query.Builder().
+			Where(model2.FieldRoomFolderId, folderID).
+			Where(model2.FieldRoomFolderUserId, userID))
+		return err
+	})
+}
+
+func (r *RoomRepo) checkFolder(ctx context.Context, userID, folderID int64) error {
+	exist, err := model2.NewRoomFolderModel(r.db).Exists(ctx, query.Builder().
+		Where(model2.FieldRoomFolderId, folderID).
+		Where(model2.FieldRoomFolderUserId, userID))
+	if err != nil {
+		return err
+	}
+
+	if !exist {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// MoveToFolder 将会话移动到文件夹中，folderID 为 0 时移出文件夹，会话或文件夹不存在时返回 ErrNotFound
+func (r *RoomRepo) MoveToFolder(ctx context.Context, userID, roomID, folderID int64) error {
+	if folderID > 0 {
+		if err := r.checkFolder(ctx, userID, folderID); err != nil {
+			return err
+		}
+	}
+
+	return r.updateRoomFields(ctx, userID, roomID, query.KV{model2.FieldRoomsFolderId: folderID})
+}
+
+// Pin 置顶或者取消置顶会话，最近置顶的会话排在最前面，会话不存在时返回 ErrNotFound
+func (r *RoomRepo) Pin(ctx context.Context, userID, roomID int64, pinned bool) error {
+	var pinnedAt any
+	if pinned {
+		pinnedAt = time.Now()
+	}
+
+	return r.updateRoomFields(ctx, userID, roomID, query.KV{model2.FieldRoomsPinnedAt: pinnedAt})
+}
+
+func (r *RoomRepo) updateRoomFields(ctx context.Context, userID, roomID int64, kv query.KV) error {
+	q := query.Builder().
+		Where(model2.FieldRoomsUserId, userID).
+		Where(model2.FieldRoomsId, roomID)
+
+	exist, err := model2.NewRoomsModel(r.db).Exists(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	if !exist {
+		return ErrNotFound
+	}
+
+	_, err = model2.NewRoomsModel(r.db).UpdateFields(ctx, kv, q)
+	return err
+}
@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// roomPurgeBatchSize 每次物理删除的会话数量，会话中的消息一并删除，避免长时间锁表
+const roomPurgeBatchSize = 100
+
+// TrashRoom 回收站中的会话（包括群聊）
+type TrashRoom struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	AvatarURL string    `json:"avatar_url,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	RoomType  int64     `json:"room_type"`
+	DeletedAt time.Time `json:"deleted_at"`
+	// PurgeAt 超过该时间后会话将被物理删除，无法恢复
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// TrashRooms 查询用户回收站中的会话，已经超过保留期限等待清理的会话不再返回
+func (r *RoomRepo) TrashRooms(ctx context.Context, userID int64, retention time.Duration, limit int64) ([]TrashRoom, error) {
+	rooms, err := model2.NewRoomsModel(r.db).WithTrashed().Get(ctx, query.Builder().
+		Where(model2.FieldRoomsUserId, userID).
+		WhereNotNull(model2.FieldRoomsDeletedAt).
+		Where(model2.FieldRoomsDeletedAt, ">", time.Now().Add(-retention)).
+		OrderBy(model2.FieldRoomsDeletedAt, "DESC").
+		Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(rooms, func(room model2.RoomsN, _ int) TrashRoom {
+		deletedAt := room.DeletedAt.ValueOrZero()
+		return TrashRoom{
+			ID:        room.Id.ValueOrZero(),
+			Name:      room.Name.ValueOrZero(),
+			AvatarURL: room.AvatarUrl.ValueOrZero(),
+			Model:     room.Model.ValueOrZero(),
+			RoomType:  room.RoomType.ValueOrZero(),
+			DeletedAt: deletedAt,
+			PurgeAt:   deletedAt.Add(retention),
+		}
+	}), nil
+}
+
+// RestoreRoom 从回收站中恢复会话，会话不存在或者已经超过保留期限时返回 ErrNotFound
+func (r *RoomRepo) RestoreRoom(ctx context.Context, userID, roomID int64, retention time.Duration) error {
+	affected, err := model2.NewRoomsModel(r.db).Restore(ctx, query.Builder().
+		Where(model2.FieldRoomsUserId, userID).
+		Where(model2.FieldRoomsId, roomID).
+		WhereNotNull(model2.FieldRoomsDeletedAt).
+		Where(model2.FieldRoomsDeletedAt, ">", time.Now().Add(-retention)))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// PurgeTrashedRooms 物理删除 before 之前放入回收站的会话，以及会话中的消息、群聊成员和话题，返回删除的会话数量
+func (r *RoomRepo) PurgeTrashedRooms(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for {
+		rooms, err := model2.NewRoomsModel(r.db).WithTrashed().Get(ctx, query.Builder().
+			Select(model2.FieldRoomsId).
+			WhereNotNull(model2.FieldRoomsDeletedAt).
+			Where(model2.FieldRoomsDeletedAt, "<", before).
+			Limit(roomPurgeBatchSize))
+		if err != nil {
+			return total, fmt.Errorf("query trashed rooms failed: %w", err)
+		}
+
+		if len(rooms) == 0 {
+			return total, nil
+		}
+
+		ids := array.Map(rooms, func(room model2.RoomsN, _ int) int64 { return room.Id.ValueOrZero() })
+		err = eloquent.Transaction(r.db, func(tx query.Database) error {
+			if _, err := model2.NewChatMessagesModel(tx).Delete(ctx, query.Builder().WhereIn(model2.FieldChatMessagesRoomId, ids)); err != nil {
+				return fmt.Errorf("delete chat messages failed: %w", err)
+			}
+
+			if _, err := model2.NewChatGroupMessageModel(tx).ForceDelete(ctx, query.Builder().WhereIn(model2.FieldChatGroupMessageGroupId, ids)); err != nil {
+				return fmt.Errorf("delete chat group messages failed: %w", err)
+			}
+
+			if _, err := model2.NewChatGroupMemberModel(tx).Delete(ctx, query.Builder().WhereIn(model2.FieldChatGroupMemberGroupId, ids)); err != nil {
+				return fmt.Errorf("delete chat group members failed: %w", err)
+			}
+
+			if _, err := model2.NewRoomTopicModel(tx).Delete(ctx, query.Builder().WhereIn(model2.FieldRoomTopicRoomId, ids)); err != nil {
+				return fmt.Errorf("delete room topics failed: %w", err)
+			}
+
+			_, err := model2.NewRoomsModel(tx).ForceDelete(ctx, query.Builder().WhereIn(model2.FieldRoomsId, ids))
+			return err
+		})
+		if err != nil {
+			return total, fmt.Errorf("purge trashed rooms failed: %w", err)
+		}
+
+		total += int64(len(ids))
+		if len(ids) < roomPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// RoomStorageUsage 用户会话占用的存储，回收站中的会话在物理删除之前仍然计入
+type RoomStorageUsage struct {
+	// Rooms 会话数量（包括群聊）
+	Rooms int64 `json:"rooms"`
+	// Messages 会话中的消息数量
+	Messages int64 `json:"messages"`
+	// TrashedRooms 回收站中的会话数量
+	TrashedRooms int64 `json:"trashed_rooms"`
+	// TrashedMessages 回收站中的会话包含的消息数量
+	TrashedMessages int64 `json:"trashed_messages"`
+}
+
+// StorageUsage 统计用户会话占用的存储，已经物理删除的会话不再计入
+func (r *RoomRepo) StorageUsage(ctx context.Context, userID int64) (*RoomStorageUsage, error) {
+	queries := []string{
+		// 普通会话
+		`SELECT r.deleted_at IS NOT NULL, COUNT(DISTINCT r.id), COUNT(m.id)
+FROM rooms r
+LEFT JOIN chat_messages m ON m.room_id = r.id AND m.user_id = r.user_id
+WHERE r.user_id = ? AND r.room_type <> ?
+GROUP BY 1`,
+		// 群聊，群聊中已删除的消息不计入
+		`SELECT r.deleted_at IS NOT NULL, COUNT(DISTINCT r.id), COUNT(m.id)
+FROM rooms r
+LEFT JOIN chat_group_message m ON m.group_id = r.id AND m.user_id = r.user_id AND m.deleted_at IS NULL
+WHERE r.user_id = ? AND r.room_type = ?
+GROUP BY 1`,
+	}
+
+	var usage RoomStorageUsage
+	for _, sqlStr := range queries {
+		rows, err := r.db.QueryContext(ctx, sqlStr, userID, RoomTypeGroupChat)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var trashed bool
+			var rooms, messages int64
+			if err := rows.Scan(&trashed, &rooms, &messages); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+
+			if trashed {
+				usage.TrashedRooms += rooms
+				usage.TrashedMessages += messages
+			} else {
+				usage.Rooms += rooms
+				usage.Messages += messages
+			}
+		}
+
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &usage, nil
+}
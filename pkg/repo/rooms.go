@@ -42,12 +42,15 @@ func NewRoomRepo(db *sql.DB) *RoomRepo {
 type Room struct {
 	model2.Rooms
 	Members []string `json:"members,omitempty"`
+	// Pinned 会话是否已置顶
+	Pinned bool `json:"pinned,omitempty"`
 }
 
 func (r *RoomRepo) Rooms(ctx context.Context, userID int64, roomTypes []int, limit int64) ([]Room, error) {
 	q := query.Builder().
 		Where(model2.FieldRoomsUserId, userID).
 		WhereIn(model2.FieldRoomsRoomType, roomTypes).
+		OrderBy(model2.FieldRoomsPinnedAt, "DESC").
 		OrderBy(model2.FieldRoomsPriority, "DESC").
 		OrderBy(model2.FieldRoomsLastActiveTime, "DESC").
 		Limit(limit)
@@ -88,6 +91,7 @@ func (r *RoomRepo) Rooms(ctx context.Context, userID int64, roomTypes []int, lim
 		return Room{
 			Rooms:   room.ToRooms(),
 			Members: groupMembers[room.Id.ValueOrZero()],
+			Pinned:  room.PinnedAt.Valid,
 		}
 	}), nil
 }
@@ -156,6 +160,7 @@ func (r *RoomRepo) Create(ctx context.Context, userID int64, room *model2.Rooms,
 		model2.FieldRoomsMaxContext,
 		model2.FieldRoomsRoomType,
 		model2.FieldRoomsInitMessage,
+		model2.FieldRoomsIncognito,
 	)
 
 	id, err = model2.NewRoomsModel(r.db).Save(ctx, roomN)
@@ -189,6 +194,7 @@ func (r *RoomRepo) Update(ctx context.Context, userID, roomID int64, room *model
 		model2.FieldRoomsMaxContext,
 		model2.FieldRoomsRoomType,
 		model2.FieldRoomsInitMessage,
+		model2.FieldRoomsIncognito,
 	))
 
 	return err
@@ -206,6 +212,21 @@ func (r *RoomRepo) UpdateLastActiveTime(ctx context.Context, userID, roomID int6
 	return err
 }
 
+// UpdateReplyLanguage 设置会话的回复语言，为空时跟随检测到的语言，会话不存在时返回 ErrNotFound
+func (r *RoomRepo) UpdateReplyLanguage(ctx context.Context, userID, roomID int64, language string) error {
+	return r.updateRoomFields(ctx, userID, roomID, query.KV{model2.FieldRoomsReplyLanguage: language})
+}
+
+// UpdateDetectedLanguage 记录根据用户提问检测到的语言
+func (r *RoomRepo) UpdateDetectedLanguage(ctx context.Context, userID, roomID int64, language string) error {
+	q := query.Builder().
+		Where(model2.FieldRoomsUserId, userID).
+		Where(model2.FieldRoomsId, roomID)
+
+	_, err := model2.NewRoomsModel(r.db).UpdateFields(ctx, query.KV{model2.FieldRoomsDetectedLanguage: language}, q)
+	return err
+}
+
 type GalleryRoom struct {
 	Id          int64    `json:"id"`
 	Name        string   `json:"name,omitempty"`
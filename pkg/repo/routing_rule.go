@@ -0,0 +1,128 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 模型路由规则状态
+const (
+	RoutingRuleStatusEnabled  = 1
+	RoutingRuleStatusDisabled = 2
+)
+
+// 模型路由规则可以匹配的用户等级
+const (
+	// RoutingUserLevelFree 没有付费记录的用户
+	RoutingUserLevelFree = "free"
+	// RoutingUserLevelPaid 存在有效付费配额的用户
+	RoutingUserLevelPaid = "paid"
+)
+
+// RoutingConditions 模型路由规则的匹配条件，为空的条件不参与匹配，所有非空条件同时满足时规则生效
+type RoutingConditions struct {
+	// Models 请求的模型，为空时匹配所有模型
+	Models []string `json:"models,omitempty"`
+	// MinPromptTokens 输入 Token 数量大于该值时匹配
+	MinPromptTokens int64 `json:"min_prompt_tokens,omitempty"`
+	// MaxPromptTokens 输入 Token 数量不超过该值时匹配
+	MaxPromptTokens int64 `json:"max_prompt_tokens,omitempty"`
+	// UserLevels 用户等级，可选值为 free、paid
+	UserLevels []string `json:"user_levels,omitempty"`
+	// Regions 请求来源地区（ISO 3166-1 国家代码），EU 表示所有欧盟成员国
+	Regions []string `json:"regions,omitempty"`
+}
+
+// RoutingRule 模型路由规则
+type RoutingRule struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Priority    int64             `json:"priority"`
+	Conditions  RoutingConditions `json:"conditions"`
+	TargetModel string            `json:"target_model"`
+	Note        string            `json:"note,omitempty"`
+	Status      int64             `json:"status"`
+}
+
+// RoutingRuleRepo 模型路由规则
+type RoutingRuleRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewRoutingRuleRepo create a new RoutingRuleRepo
+func NewRoutingRuleRepo(db *sql.DB, conf *config.Config) *RoutingRuleRepo {
+	return &RoutingRuleRepo{db: db, conf: conf}
+}
+
+// Rules 查询模型路由规则，按照优先级从高到低排序，enabledOnly 为 true 时只返回启用的规则
+func (repo *RoutingRuleRepo) Rules(ctx context.Context, enabledOnly bool) ([]RoutingRule, error) {
+	q := query.Builder().
+		OrderBy(model.FieldRoutingRulePriority, "DESC").
+		OrderBy(model.FieldRoutingRuleId, "ASC")
+	if enabledOnly {
+		q = q.Where(model.FieldRoutingRuleStatus, RoutingRuleStatusEnabled)
+	}
+
+	items, err := model.NewRoutingRuleModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]RoutingRule, 0, len(items))
+	for _, item := range items {
+		rule := RoutingRule{
+			ID:          item.Id.ValueOrZero(),
+			Name:        item.Name.ValueOrZero(),
+			Priority:    item.Priority.ValueOrZero(),
+			TargetModel: item.TargetModel.ValueOrZero(),
+			Note:        item.Note.ValueOrZero(),
+			Status:      item.Status.ValueOrZero(),
+		}
+
+		if v := item.Conditions.ValueOrZero(); v != "" {
+			if err := json.Unmarshal([]byte(v), &rule.Conditions); err != nil {
+				return nil, fmt.Errorf("unmarshal conditions of routing rule %d failed: %w", rule.ID, err)
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func routingRuleKV(rule RoutingRule) query.KV {
+	conditions, _ := json.Marshal(rule.Conditions)
+	return query.KV{
+		model.FieldRoutingRuleName:        rule.Name,
+		model.FieldRoutingRulePriority:    rule.Priority,
+		model.FieldRoutingRuleConditions:  string(conditions),
+		model.FieldRoutingRuleTargetModel: rule.TargetModel,
+		model.FieldRoutingRuleNote:        rule.Note,
+		model.FieldRoutingRuleStatus:      rule.Status,
+	}
+}
+
+// CreateRule 新增模型路由规则
+func (repo *RoutingRuleRepo) CreateRule(ctx context.Context, rule RoutingRule) (int64, error) {
+	return model.NewRoutingRuleModel(repo.db).Create(ctx, routingRuleKV(rule))
+}
+
+// UpdateRule 更新模型路由规则
+func (repo *RoutingRuleRepo) UpdateRule(ctx context.Context, id int64, rule RoutingRule) error {
+	_, err := model.NewRoutingRuleModel(repo.db).UpdateFields(ctx, routingRuleKV(rule), query.Builder().Where(model.FieldRoutingRuleId, id))
+	return err
+}
+
+// DeleteRule 删除模型路由规则
+func (repo *RoutingRuleRepo) DeleteRule(ctx context.Context, id int64) error {
+	_, err := model.NewRoutingRuleModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldRoutingRuleId, id))
+	return err
+}
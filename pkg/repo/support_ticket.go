@@ -0,0 +1,300 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+// 工单状态
+const (
+	// SupportTicketStatusOpen 待客服处理
+	SupportTicketStatusOpen int64 = 1
+	// SupportTicketStatusReplied 客服已回复，等待用户反馈
+	SupportTicketStatusReplied int64 = 2
+	// SupportTicketStatusClosed 已关闭
+	SupportTicketStatusClosed int64 = 3
+)
+
+// 工单可以关联的对象类型
+const (
+	SupportTicketAttachmentRoom    = "room"
+	SupportTicketAttachmentMessage = "message"
+	SupportTicketAttachmentTask    = "task"
+)
+
+// ErrSupportTicketClosed 工单已关闭，不能继续回复
+var ErrSupportTicketClosed = errors.New("support ticket is closed")
+
+// SupportTicket 工单
+type SupportTicket struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	Subject        string     `json:"subject"`
+	Category       string     `json:"category,omitempty"`
+	Status         int64      `json:"status"`
+	AttachmentType string     `json:"attachment_type,omitempty"`
+	AttachmentID   string     `json:"attachment_id,omitempty"`
+	UserUnread     bool       `json:"user_unread"`
+	AdminUnread    bool       `json:"admin_unread"`
+	LastReplyAt    *time.Time `json:"last_reply_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// SupportTicketMessage 工单中的消息
+type SupportTicketMessage struct {
+	ID        int64     `json:"id"`
+	TicketID  int64     `json:"ticket_id"`
+	SenderID  int64     `json:"sender_id"`
+	IsAdmin   bool      `json:"is_admin"`
+	Content   string    `json:"content"`
+	Images    []string  `json:"images,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SupportTicketRepo 客服工单
+type SupportTicketRepo struct {
+	db *sql.DB
+}
+
+// NewSupportTicketRepo create a new SupportTicketRepo
+func NewSupportTicketRepo(db *sql.DB) *SupportTicketRepo {
+	return &SupportTicketRepo{db: db}
+}
+
+func supportTicketFromModel(item model.SupportTicketN) SupportTicket {
+	ticket := SupportTicket{
+		ID:             item.Id.ValueOrZero(),
+		UserID:         item.UserId.ValueOrZero(),
+		Subject:        item.Subject.ValueOrZero(),
+		Category:       item.Category.ValueOrZero(),
+		Status:         item.Status.ValueOrZero(),
+		AttachmentType: item.AttachmentType.ValueOrZero(),
+		AttachmentID:   item.AttachmentId.ValueOrZero(),
+		UserUnread:     item.UserUnread.ValueOrZero() == 1,
+		AdminUnread:    item.AdminUnread.ValueOrZero() == 1,
+		CreatedAt:      item.CreatedAt.ValueOrZero(),
+		UpdatedAt:      item.UpdatedAt.ValueOrZero(),
+	}
+
+	if item.LastReplyAt.Valid {
+		lastReplyAt := item.LastReplyAt.ValueOrZero()
+		ticket.LastReplyAt = &lastReplyAt
+	}
+
+	return ticket
+}
+
+func supportTicketMessageFromModel(item model.SupportTicketMessageN) SupportTicketMessage {
+	msg := SupportTicketMessage{
+		ID:        item.Id.ValueOrZero(),
+		TicketID:  item.TicketId.ValueOrZero(),
+		SenderID:  item.SenderId.ValueOrZero(),
+		IsAdmin:   item.IsAdmin.ValueOrZero() == 1,
+		Content:   item.Content.ValueOrZero(),
+		CreatedAt: item.CreatedAt.ValueOrZero(),
+	}
+
+	_ = json.Unmarshal([]byte(item.Images.ValueOrZero()), &msg.Images)
+	return msg
+}
+
+// SupportTicketCreate 创建工单的参数
+type SupportTicketCreate struct {
+	Subject        string
+	Category       string
+	Content        string
+	Images         []string
+	AttachmentType string
+	AttachmentID   string
+}
+
+// CreateTicket 创建工单，工单与第一条消息在同一个事务中写入
+func (repo *SupportTicketRepo) CreateTicket(ctx context.Context, userID int64, req SupportTicketCreate) (int64, error) {
+	var ticketID int64
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		id, err := model.NewSupportTicketModel(tx).Create(ctx, query.KV{
+			model.FieldSupportTicketUserId:         userID,
+			model.FieldSupportTicketSubject:        req.Subject,
+			model.FieldSupportTicketCategory:       req.Category,
+			model.FieldSupportTicketStatus:         SupportTicketStatusOpen,
+			model.FieldSupportTicketAttachmentType: req.AttachmentType,
+			model.FieldSupportTicketAttachmentId:   req.AttachmentID,
+			model.FieldSupportTicketUserUnread:     0,
+			model.FieldSupportTicketAdminUnread:    1,
+			model.FieldSupportTicketLastReplyAt:    time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		ticketID = id
+		return createSupportTicketMessage(ctx, tx, id, userID, false, req.Content, req.Images)
+	})
+
+	return ticketID, err
+}
+
+func createSupportTicketMessage(ctx context.Context, db query.Database, ticketID, senderID int64, isAdmin bool, content string, images []string) error {
+	var adminFlag int64
+	if isAdmin {
+		adminFlag = 1
+	}
+
+	var imagesData string
+	if len(images) > 0 {
+		data, _ := json.Marshal(images)
+		imagesData = string(data)
+	}
+
+	_, err := model.NewSupportTicketMessageModel(db).Create(ctx, query.KV{
+		model.FieldSupportTicketMessageTicketId: ticketID,
+		model.FieldSupportTicketMessageSenderId: senderID,
+		model.FieldSupportTicketMessageIsAdmin:  adminFlag,
+		model.FieldSupportTicketMessageContent:  content,
+		model.FieldSupportTicketMessageImages:   imagesData,
+	})
+	return err
+}
+
+// Tickets 查询用户的工单，最近有回复的在前
+func (repo *SupportTicketRepo) Tickets(ctx context.Context, userID, page, perPage int64) ([]SupportTicket, query.PaginateMeta, error) {
+	return repo.paginate(ctx, page, perPage, query.Builder().Where(model.FieldSupportTicketUserId, userID))
+}
+
+// AdminTickets 管理员查询工单，status 为 0 时查询所有状态
+func (repo *SupportTicketRepo) AdminTickets(ctx context.Context, status, userID, page, perPage int64) ([]SupportTicket, query.PaginateMeta, error) {
+	q := query.Builder()
+	if status > 0 {
+		q = q.Where(model.FieldSupportTicketStatus, status)
+	}
+
+	if userID > 0 {
+		q = q.Where(model.FieldSupportTicketUserId, userID)
+	}
+
+	return repo.paginate(ctx, page, perPage, q)
+}
+
+func (repo *SupportTicketRepo) paginate(ctx context.Context, page, perPage int64, q query.SQLBuilder) ([]SupportTicket, query.PaginateMeta, error) {
+	items, meta, err := model.NewSupportTicketModel(repo.db).Paginate(ctx, page, perPage, q.OrderBy(model.FieldSupportTicketLastReplyAt, "DESC"))
+	if err != nil {
+		return nil, query.PaginateMeta{}, err
+	}
+
+	tickets := make([]SupportTicket, 0, len(items))
+	for _, item := range items {
+		tickets = append(tickets, supportTicketFromModel(item))
+	}
+
+	return tickets, meta, nil
+}
+
+// Ticket 查询工单，userID 为 0 时不校验工单所属用户，不存在时返回 ErrNotFound
+func (repo *SupportTicketRepo) Ticket(ctx context.Context, userID, ticketID int64) (*SupportTicket, error) {
+	q := query.Builder().Where(model.FieldSupportTicketId, ticketID)
+	if userID > 0 {
+		q = q.Where(model.FieldSupportTicketUserId, userID)
+	}
+
+	item, err := model.NewSupportTicketModel(repo.db).First(ctx, q)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ticket := supportTicketFromModel(*item)
+	return &ticket, nil
+}
+
+// Messages 查询工单的所有消息，按发送时间排序
+func (repo *SupportTicketRepo) Messages(ctx context.Context, ticketID int64) ([]SupportTicketMessage, error) {
+	items, err := model.NewSupportTicketMessageModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldSupportTicketMessageTicketId, ticketID).
+		OrderBy(model.FieldSupportTicketMessageId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]SupportTicketMessage, 0, len(items))
+	for _, item := range items {
+		messages = append(messages, supportTicketMessageFromModel(item))
+	}
+
+	return messages, nil
+}
+
+// AddMessage 回复工单，用户回复时工单变为待处理，客服回复时工单变为已回复，并标记对方有未读消息
+func (repo *SupportTicketRepo) AddMessage(ctx context.Context, ticketID, senderID int64, isAdmin bool, content string, images []string) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		ticket, err := model.NewSupportTicketModel(tx).First(ctx, query.Builder().Where(model.FieldSupportTicketId, ticketID))
+		if err != nil {
+			if errors.Is(err, query.ErrNoResult) {
+				return ErrNotFound
+			}
+
+			return err
+		}
+
+		// 用户不能回复已关闭的工单，客服回复已关闭的工单时重新打开
+		if ticket.Status.ValueOrZero() == SupportTicketStatusClosed && !isAdmin {
+			return ErrSupportTicketClosed
+		}
+
+		if err := createSupportTicketMessage(ctx, tx, ticketID, senderID, isAdmin, content, images); err != nil {
+			return err
+		}
+
+		kv := query.KV{model.FieldSupportTicketLastReplyAt: time.Now()}
+		if isAdmin {
+			kv[model.FieldSupportTicketStatus] = SupportTicketStatusReplied
+			kv[model.FieldSupportTicketUserUnread] = 1
+			kv[model.FieldSupportTicketAdminUnread] = 0
+		} else {
+			kv[model.FieldSupportTicketStatus] = SupportTicketStatusOpen
+			kv[model.FieldSupportTicketAdminUnread] = 1
+			kv[model.FieldSupportTicketUserUnread] = 0
+		}
+
+		_, err = model.NewSupportTicketModel(tx).UpdateFields(ctx, kv, query.Builder().Where(model.FieldSupportTicketId, ticketID))
+		return err
+	})
+}
+
+// UpdateStatus 修改工单状态
+func (repo *SupportTicketRepo) UpdateStatus(ctx context.Context, ticketID, status int64) error {
+	_, err := model.NewSupportTicketModel(repo.db).UpdateFields(ctx, query.KV{
+		model.FieldSupportTicketStatus: status,
+	}, query.Builder().Where(model.FieldSupportTicketId, ticketID))
+	return err
+}
+
+// MarkRead 标记工单消息为已读，isAdmin 为 true 时标记客服已读
+func (repo *SupportTicketRepo) MarkRead(ctx context.Context, ticketID int64, isAdmin bool) error {
+	field := model.FieldSupportTicketUserUnread
+	if isAdmin {
+		field = model.FieldSupportTicketAdminUnread
+	}
+
+	_, err := model.NewSupportTicketModel(repo.db).UpdateFields(ctx, query.KV{field: 0}, query.Builder().
+		Where(model.FieldSupportTicketId, ticketID).
+		Where(field, 1))
+	return err
+}
+
+// UnreadCount 查询用户有未读回复的工单数量
+func (repo *SupportTicketRepo) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	return model.NewSupportTicketModel(repo.db).Count(ctx, query.Builder().
+		Where(model.FieldSupportTicketUserId, userID).
+		Where(model.FieldSupportTicketUserUnread, 1))
+}
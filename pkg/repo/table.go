@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+type TableRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewTableRepo create a new TableRepo
+func NewTableRepo(db *sql.DB, conf *config.Config) *TableRepo {
+	return &TableRepo{db: db, conf: conf}
+}
+
+// TableFileAddReq 表格文件
+type TableFileAddReq struct {
+	UserID   int64
+	Name     string
+	URL      string
+	RowCount int64
+	// Profile 列统计信息，JSON 格式
+	Profile string
+	// Data 表格数据，JSON 格式
+	Data string
+}
+
+// AddFile 保存用户上传的表格文件
+func (repo *TableRepo) AddFile(ctx context.Context, req TableFileAddReq) (int64, error) {
+	return model.NewTableFileModel(repo.db).Create(ctx, query.KV{
+		model.FieldTableFileUserId:   req.UserID,
+		model.FieldTableFileName:     misc.SubString(req.Name, 255),
+		model.FieldTableFileUrl:      req.URL,
+		model.FieldTableFileRowCount: req.RowCount,
+		model.FieldTableFileProfile:  req.Profile,
+		model.FieldTableFileData:     req.Data,
+	})
+}
+
+// GetFile 查询用户的表格文件（包含表格数据）
+func (repo *TableRepo) GetFile(ctx context.Context, userID, id int64) (*model.TableFile, error) {
+	file, err := model.NewTableFileModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldTableFileId, id).
+		Where(model.FieldTableFileUserId, userID))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := file.ToTableFile()
+	return &ret, nil
+}
+
+// GetFiles 查询用户的表格文件列表（不包含表格数据）
+func (repo *TableRepo) GetFiles(ctx context.Context, userID int64) ([]model.TableFile, error) {
+	files, err := model.NewTableFileModel(repo.db).Get(ctx, query.Builder().
+		Select(
+			model.FieldTableFileId,
+			model.FieldTableFileUserId,
+			model.FieldTableFileName,
+			model.FieldTableFileUrl,
+			model.FieldTableFileRowCount,
+			model.FieldTableFileProfile,
+			model.FieldTableFileCreatedAt,
+			model.FieldTableFileUpdatedAt,
+		).
+		Where(model.FieldTableFileUserId, userID).
+		OrderBy(model.FieldTableFileId, "DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(files, func(item model.TableFileN, _ int) model.TableFile {
+		return item.ToTableFile()
+	}), nil
+}
+
+// DeleteFile 删除用户的表格文件
+func (repo *TableRepo) DeleteFile(ctx context.Context, userID, id int64) error {
+	affected, err := model.NewTableFileModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldTableFileId, id).
+		Where(model.FieldTableFileUserId, userID))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/msgcrypt"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+type TopicRepo struct {
+	db     *sql.DB
+	conf   *config.Config
+	cipher *msgcrypt.Cipher
+}
+
+// NewTopicRepo create a new TopicRepo
+func NewTopicRepo(db *sql.DB, conf *config.Config, cipher *msgcrypt.Cipher) *TopicRepo {
+	return &TopicRepo{db: db, conf: conf, cipher: cipher}
+}
+
+// SetRoomTopics 更新房间的话题标签，会替换掉房间原有的全部标签
+func (repo *TopicRepo) SetRoomTopics(ctx context.Context, userID, roomID, roomType int64, topics []string) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewRoomTopicModel(tx).Delete(ctx, query.Builder().Where(model.FieldRoomTopicRoomId, roomID)); err != nil {
+			return fmt.Errorf("delete room topics failed: %w", err)
+		}
+
+		for _, t := range array.Uniq(topics) {
+			if _, err := model.NewRoomTopicModel(tx).Create(ctx, query.KV{
+				model.FieldRoomTopicUserId:   userID,
+				model.FieldRoomTopicRoomId:   roomID,
+				model.FieldRoomTopicRoomType: roomType,
+				model.FieldRoomTopicTopic:    t,
+			}); err != nil {
+				return fmt.Errorf("create room topic failed: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// RoomTopics 查询房间的话题标签，返回 房间 ID => 话题列表
+func (repo *TopicRepo) RoomTopics(ctx context.Context, userID int64, roomIDs []int64) (map[int64][]string, error) {
+	res := make(map[int64][]string)
+	if len(roomIDs) == 0 {
+		return res, nil
+	}
+
+	items, err := model.NewRoomTopicModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldRoomTopicUserId, userID).
+		WhereIn(model.FieldRoomTopicRoomId, roomIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		roomID := item.RoomId.ValueOrZero()
+		res[roomID] = append(res[roomID], item.Topic.ValueOrZero())
+	}
+
+	return res, nil
+}
+
+// RoomsByTopic 按照话题查询用户的会话列表，按最后活跃时间倒序排列
+func (repo *TopicRepo) RoomsByTopic(ctx context.Context, userID int64, topic string, roomTypes []int, limit int64) ([]model.Rooms, error) {
+	q := query.Builder().
+		Where(model.FieldRoomsUserId, userID).
+		WhereIn(model.FieldRoomsRoomType, roomTypes).
+		WhereRaw(
+			"id IN (SELECT room_id FROM room_topic WHERE user_id = ? AND topic = ?)",
+			userID, topic,
+		).
+		OrderBy(model.FieldRoomsLastActiveTime, "DESC").
+		Limit(limit)
+
+	rooms, err := model.NewRoomsModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(rooms, func(item model.RoomsN, _ int) model.Rooms {
+		return item.ToRooms()
+	}), nil
+}
+
+// TopicStat 话题使用统计
+type TopicStat struct {
+	Topic string `json:"topic"`
+	// Rooms 该话题下的会话数量
+	Rooms int64 `json:"rooms"`
+	// Messages 该话题下的消息数量
+	Messages int64 `json:"messages"`
+	// Quota 该话题下消耗的智慧果数量
+	Quota int64 `json:"quota"`
+}
+
+// TopicStats 统计用户每个话题的会话数量、消息数量以及智慧果消耗
+func (repo *TopicRepo) TopicStats(ctx context.Context, userID int64) ([]TopicStat, error) {
+	stats := make(map[string]*TopicStat)
+	queries := []string{
+		// 普通会话
+		`SELECT t.topic, COUNT(DISTINCT t.room_id), COUNT(m.id), COALESCE(SUM(m.quota_consumed), 0)
+FROM room_topic t
+INNER JOIN rooms r ON r.id = t.room_id AND r.deleted_at IS NULL
+LEFT JOIN chat_messages m ON m.room_id = t.room_id AND m.user_id = t.user_id
+WHERE t.user_id = ? AND t.room_type <> ?
+GROUP BY t.topic`,
+		// 群聊
+		`SELECT t.topic, COUNT(DISTINCT t.room_id), COUNT(m.id), COALESCE(SUM(m.quota_consumed), 0)
+FROM room_topic t
+INNER JOIN rooms r ON r.id = t.room_id AND r.deleted_at IS NULL
+LEFT JOIN chat_group_message m ON m.group_id = t.room_id AND m.user_id = t.user_id AND m.deleted_at IS NULL
+WHERE t.user_id = ? AND t.room_type = ?
+GROUP BY t.topic`,
+	}
+
+	for _, sqlStr := range queries {
+		if err := repo.scanTopicStats(ctx, stats, sqlStr, userID, RoomTypeGroupChat); err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]TopicStat, 0, len(stats))
+	for _, stat := range stats {
+		res = append(res, *stat)
+	}
+
+	return res, nil
+}
+
+func (repo *TopicRepo) scanTopicStats(ctx context.Context, stats map[string]*TopicStat, sqlStr string, args ...any) error {
+	rows, err := repo.db.QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return fmt.Errorf("query topic stats failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stat TopicStat
+		if err := rows.Scan(&stat.Topic, &stat.Rooms, &stat.Messages, &stat.Quota); err != nil {
+			return err
+		}
+
+		if exist, ok := stats[stat.Topic]; ok {
+			exist.Rooms += stat.Rooms
+			exist.Messages += stat.Messages
+			exist.Quota += stat.Quota
+		} else {
+			stats[stat.Topic] = &stat
+		}
+	}
+
+	return rows.Err()
+}
+
+// UntaggedRooms 查询自上次打标签之后有新活动的房间（包括从未打过标签的房间）
+func (repo *TopicRepo) UntaggedRooms(ctx context.Context, activeAfter time.Time, limit int64) ([]model.Rooms, error) {
+	q := query.Builder().
+		Where(model.FieldRoomsLastActiveTime, ">", activeAfter).
+		WhereRaw(
+			"last_active_time > COALESCE((SELECT MAX(t.updated_at) FROM room_topic t WHERE t.room_id = rooms.id), '1970-01-01')",
+		).
+		OrderBy(model.FieldRoomsLastActiveTime, "ASC").
+		Limit(limit)
+
+	rooms, err := model.NewRoomsModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(rooms, func(item model.RoomsN, _ int) model.Rooms {
+		return item.ToRooms()
+	}), nil
+}
+
+// RecentUserMessages 查询房间中用户最近发送的消息内容，用于话题分类
+func (repo *TopicRepo) RecentUserMessages(ctx context.Context, room model.Rooms, limit int64) ([]string, error) {
+	if room.RoomType == RoomTypeGroupChat {
+		messages, err := model.NewChatGroupMessageModel(repo.db).Get(ctx, query.Builder().
+			Select(model.FieldChatGroupMessageMessage).
+			Where(model.FieldChatGroupMessageGroupId, room.Id).
+			Where(model.FieldChatGroupMessageUserId, room.UserId).
+			Where(model.FieldChatGroupMessageRole, int64(MessageRoleUser)).
+			OrderBy(model.FieldChatGroupMessageId, "DESC").
+			Limit(limit),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return repo.decryptMessages(ctx, array.Map(messages, func(item model.ChatGroupMessageN, _ int) string { return item.Message.ValueOrZero() }))
+	}
+
+	messages, err := model.NewChatMessagesModel(repo.db).Get(ctx, query.Builder().
+		Select(model.FieldChatMessagesMessage).
+		Where(model.FieldChatMessagesRoomId, room.Id).
+		Where(model.FieldChatMessagesUserId, room.UserId).
+		Where(model.FieldChatMessagesRole, int64(MessageRoleUser)).
+		OrderBy(model.FieldChatMessagesId, "DESC").
+		Limit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.decryptMessages(ctx, array.Map(messages, func(item model.ChatMessagesN, _ int) string { return item.Message.ValueOrZero() }))
+}
+
+func (repo *TopicRepo) decryptMessages(ctx context.Context, messages []string) ([]string, error) {
+	for i, msg := range messages {
+		plain, err := repo.cipher.Decrypt(ctx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt message failed: %w", err)
+		}
+
+		messages[i] = plain
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	// TutorAnswerPolicyGuide 只引导解题思路，不直接给出最终答案
+	TutorAnswerPolicyGuide = "guide"
+	// TutorAnswerPolicyAnswer 引导之后允许给出最终答案
+	TutorAnswerPolicyAnswer = "answer"
+)
+
+// TutorRepo 作业辅导模式：辅导策略设置以及分学科的学习进度
+type TutorRepo struct {
+	db *sql.DB
+}
+
+// NewTutorRepo create a new TutorRepo
+func NewTutorRepo(db *sql.DB) *TutorRepo {
+	return &TutorRepo{db: db}
+}
+
+// TutorSetting 作业辅导设置
+type TutorSetting struct {
+	// AnswerPolicy 答案策略，为空时使用系统默认策略
+	AnswerPolicy string `json:"answer_policy"`
+	// ParentEmail 接收学习周报的家长邮箱
+	ParentEmail string `json:"parent_email"`
+	// WeeklyReport 是否发送学习周报
+	WeeklyReport bool `json:"weekly_report"`
+}
+
+// TutorSetting 查询用户的作业辅导设置，没有设置时返回默认值
+func (repo *TutorRepo) TutorSetting(ctx context.Context, userID int64) (*TutorSetting, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT answer_policy, parent_email, weekly_report FROM tutor_setting WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var setting TutorSetting
+	if rows.Next() {
+		if err := rows.Scan(&setting.AnswerPolicy, &setting.ParentEmail, &setting.WeeklyReport); err != nil {
+			return nil, err
+		}
+	}
+
+	return &setting, rows.Err()
+}
+
+// SetTutorSetting 保存用户的作业辅导设置
+func (repo *TutorRepo) SetTutorSetting(ctx context.Context, userID int64, setting TutorSetting) error {
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO tutor_setting (user_id, answer_policy, parent_email, weekly_report, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE answer_policy = VALUES(answer_policy), parent_email = VALUES(parent_email), weekly_report = VALUES(weekly_report), updated_at = VALUES(updated_at)",
+		userID, setting.AnswerPolicy, setting.ParentEmail, setting.WeeklyReport, time.Now(), time.Now(),
+	)
+	return err
+}
+
+// TutorProgressDelta 一次辅导对学习进度的影响
+type TutorProgressDelta struct {
+	Steps   int64
+	Solved  int64
+	Blocked int64
+}
+
+// AddProgress 累加用户当天在某个学科的学习进度
+func (repo *TutorRepo) AddProgress(ctx context.Context, userID int64, subject string, delta TutorProgressDelta) error {
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO tutor_progress (user_id, subject, stat_date, steps, solved, blocked, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE steps = steps + VALUES(steps), solved = solved + VALUES(solved), blocked = blocked + VALUES(blocked), updated_at = VALUES(updated_at)",
+		userID, subject, NowInDate(), delta.Steps, delta.Solved, delta.Blocked, time.Now(), time.Now(),
+	)
+	return err
+}
+
+// TutorSubjectProgress 某个学科的学习进度
+type TutorSubjectProgress struct {
+	Subject string `json:"subject"`
+	// Steps 辅导的步骤数（提问次数）
+	Steps int64 `json:"steps"`
+	// Solved 独立解决的题目数
+	Solved int64 `json:"solved"`
+	// Blocked 被受限模式拦截的提问数
+	Blocked int64 `json:"blocked"`
+	// Days 有学习记录的天数
+	Days        int64     `json:"days"`
+	LastStudyAt time.Time `json:"last_study_at"`
+}
+
+// Progress 查询用户在 [startDate, endDate) 期间各学科的学习进度，按照辅导步骤数从多到少排序
+func (repo *TutorRepo) Progress(ctx context.Context, userID int64, startDate, endDate time.Time) ([]TutorSubjectProgress, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT subject, SUM(steps), SUM(solved), SUM(blocked), COUNT(*), MAX(updated_at) FROM tutor_progress "+
+			"WHERE user_id = ? AND stat_date >= ? AND stat_date < ? GROUP BY subject ORDER BY SUM(steps) DESC",
+		userID, startDate, endDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]TutorSubjectProgress, 0)
+	for rows.Next() {
+		var item TutorSubjectProgress
+		if err := rows.Scan(&item.Subject, &item.Steps, &item.Solved, &item.Blocked, &item.Days, &item.LastStudyAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
+
+// TutorReportTarget 需要发送学习周报的用户
+type TutorReportTarget struct {
+	UserID      int64
+	ParentEmail string
+}
+
+// ReportCandidates 开启了学习周报、设置了家长邮箱，并且 weekStart 之后还没有发送过周报的用户
+func (repo *TutorRepo) ReportCandidates(ctx context.Context, weekStart time.Time) ([]TutorReportTarget, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT user_id, parent_email FROM tutor_setting WHERE weekly_report = 1 AND parent_email != '' AND (reported_at IS NULL OR reported_at < ?)",
+		weekStart,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]TutorReportTarget, 0)
+	for rows.Next() {
+		var item TutorReportTarget
+		if err := rows.Scan(&item.UserID, &item.ParentEmail); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
+
+// MarkReported 记录本周已经发送学习周报，返回 false 表示已经发送过（其它实例已经处理）
+func (repo *TutorRepo) MarkReported(ctx context.Context, userID int64, weekStart time.Time) (bool, error) {
+	res, err := repo.db.ExecContext(
+		ctx,
+		"UPDATE tutor_setting SET reported_at = ? WHERE user_id = ? AND (reported_at IS NULL OR reported_at < ?)",
+		time.Now(), userID, weekStart,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
@@ -187,6 +187,15 @@ func (repo *UserRepo) UpdatePassword(ctx context.Context, userID int64, password
 	return err
 }
 
+// UpdateUserType 更新用户类型，例如将用户设置为内部用户（管理员）
+func (repo *UserRepo) UpdateUserType(ctx context.Context, userID int64, userType int64) error {
+	_, err := model2.NewUsersModel(repo.db).Update(ctx, query.Builder().Where(model2.FieldUsersId, userID), model2.UsersN{
+		UserType: null.IntFrom(userType),
+	})
+
+	return err
+}
+
 // UpdateAvatarURL 更新用户头像
 func (repo *UserRepo) UpdateAvatarURL(ctx context.Context, userID int64, avatarURL string) error {
 	_, err := model2.NewUsersModel(repo.db).Update(ctx, query.Builder().Where(model2.FieldUsersId, userID), model2.UsersN{
@@ -459,6 +468,28 @@ func (repo *UserRepo) BindPhone(ctx context.Context, userID int64, phone string,
 type UserCustomConfig struct {
 	// HomeModels 主页显示的模型
 	HomeModels []string `json:"home_models,omitempty"`
+	// TrainingDataConsent 是否允许将聊天记录用于模型微调
+	TrainingDataConsent bool `json:"training_data_consent,omitempty"`
+	// Persona 全局指令，自动添加到所有对话中
+	Persona *UserPersona `json:"persona,omitempty"`
+	// PersonaHistory 全局指令的历史版本，最近的在前
+	PersonaHistory []UserPersona `json:"persona_history,omitempty"`
+	// SpendLimit 单次请求消耗的智慧果超过该值时需要确认，0 表示使用系统默认值，-1 表示不限制
+	SpendLimit int64 `json:"spend_limit,omitempty"`
+}
+
+// EffectiveSpendLimit 单次请求的智慧果上限，用户设置优先于系统默认值，返回 0 表示不限制
+func (conf UserCustomConfig) EffectiveSpendLimit(defaultLimit int64) int64 {
+	switch {
+	case conf.SpendLimit < 0:
+		return 0
+	case conf.SpendLimit > 0:
+		return conf.SpendLimit
+	case defaultLimit > 0:
+		return defaultLimit
+	}
+
+	return 0
 }
 
 // CustomConfig 查询用户自定义配置
@@ -623,3 +654,48 @@ func (repo *UserRepo) DeleteAPIKey(ctx context.Context, userID int64, keyID int6
 	_, err := model2.NewUserApiKeyModel(repo.db).UpdateFields(ctx, update, q)
 	return err
 }
+
+// GetAPIKeyByToken 根据 API Token 获取 API Key 信息
+func (repo *UserRepo) GetAPIKeyByToken(ctx context.Context, token string) (*model2.UserApiKey, error) {
+	key, err := model2.NewUserApiKeyModel(repo.db).First(ctx, query.Builder().
+		Where(model2.FieldUserApiKeyToken, token).
+		Where(model2.FieldUserApiKeyStatus, UserAPiKeyStatusActive),
+	)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := key.ToUserApiKey()
+	return &ret, nil
+}
+
+// RecordAPIKeyUsage 记录 API Key 的使用情况，按照 API Key + 接口 + 日期 聚合
+func (repo *UserRepo) RecordAPIKeyUsage(ctx context.Context, userID, keyID int64, endpoint string, coins int64) error {
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO user_api_key_usage (user_id, api_key_id, endpoint, stat_date, request_count, coins) VALUES (?, ?, ?, ?, 1, ?) ON DUPLICATE KEY UPDATE request_count = request_count + 1, coins = coins + VALUES(coins)",
+		userID, keyID, endpoint, time.Now().Format("2006-01-02"), coins,
+	)
+	return err
+}
+
+// GetAPIKeyUsages 获取用户 API Key 最近 days 天的使用情况
+func (repo *UserRepo) GetAPIKeyUsages(ctx context.Context, userID int64, days int64) ([]model2.UserApiKeyUsage, error) {
+	q := query.Builder().
+		Where(model2.FieldUserApiKeyUsageUserId, userID).
+		Where(model2.FieldUserApiKeyUsageStatDate, ">=", time.Now().AddDate(0, 0, -int(days)).Format("2006-01-02")).
+		OrderBy(model2.FieldUserApiKeyUsageStatDate, "DESC")
+
+	usages, err := model2.NewUserApiKeyUsageModel(repo.db).Get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(usages, func(item model2.UserApiKeyUsageN, _ int) model2.UserApiKeyUsage {
+		return item.ToUserApiKeyUsage()
+	}), nil
+}
@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	model2 "github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/must"
+	"gopkg.in/guregu/null.v3"
+)
+
+// ExternalAccount 企业身份源（LDAP/OIDC）中认证通过的用户
+type ExternalAccount struct {
+	Provider string
+	Subject  string
+	Email    string
+	// EmailVerified 邮箱是否经过身份源验证，只有验证过的邮箱才会关联到已有的账号，避免冒用他人邮箱登录
+	EmailVerified bool
+	Realname      string
+	// UserType 按分组映射得到的用户类型，为空时不修改用户类型
+	UserType *int64
+}
+
+// ExternalSignIn 使用企业身份源登录，首次登录时关联邮箱相同的已有账号，或者自动创建新账号
+func (repo *UserRepo) ExternalSignIn(ctx context.Context, account ExternalAccount) (user *model2.Users, eventID int64, err error) {
+	err = eloquent.Transaction(repo.db, func(tx query.Database) error {
+		identity, err := model2.NewUserIdentityModel(tx).First(ctx, query.Builder().
+			Where(model2.FieldUserIdentityProvider, account.Provider).
+			Where(model2.FieldUserIdentitySubject, account.Subject))
+		if err != nil && !errors.Is(err, query.ErrNoResult) {
+			return err
+		}
+
+		if identity != nil {
+			matched, err := model2.NewUsersModel(tx).First(ctx, query.Builder().Where(model2.FieldUsersId, identity.UserId.ValueOrZero()))
+			if err != nil {
+				return err
+			}
+
+			u := matched.ToUsers()
+			user = &u
+
+			identity.Email = null.StringFrom(account.Email)
+			identity.LastLoginAt = null.TimeFrom(time.Now())
+			if err := identity.Save(ctx, model2.FieldUserIdentityEmail, model2.FieldUserIdentityLastLoginAt); err != nil {
+				return err
+			}
+		} else {
+			if account.Email != "" && account.EmailVerified {
+				matched, err := model2.NewUsersModel(tx).First(ctx, query.Builder().Where(model2.FieldUsersEmail, account.Email))
+				if err != nil && !errors.Is(err, query.ErrNoResult) {
+					return err
+				}
+
+				if matched != nil {
+					u := matched.ToUsers()
+					user = &u
+				}
+			}
+
+			if user == nil {
+				user = &model2.Users{
+					Email:    account.Email,
+					Realname: account.Realname,
+					Status:   UserStatusActive,
+				}
+				if account.UserType != nil {
+					user.UserType = *account.UserType
+				}
+
+				id, err := model2.NewUsersModel(tx).Save(ctx, user.ToUsersN(
+					model2.FieldUsersEmail,
+					model2.FieldUsersRealname,
+					model2.FieldUsersStatus,
+					model2.FieldUsersUserType,
+				))
+				if err != nil {
+					return err
+				}
+				user.Id = id
+
+				if eventID, err = model2.NewEventsModel(tx).Save(ctx, model2.EventsN{
+					EventType: null.StringFrom(EventTypeUserCreated),
+					Payload:   null.StringFrom(string(must.Must(json.Marshal(UserCreatedEvent{UserID: user.Id, From: UserCreatedEventSourceSSO})))),
+					Status:    null.StringFrom(EventStatusWaiting),
+				}); err != nil {
+					log.With(user).Errorf("create event failed: %s", err)
+					return err
+				}
+			}
+
+			if _, err := model2.NewUserIdentityModel(tx).Save(ctx, model2.UserIdentityN{
+				UserId:      null.IntFrom(user.Id),
+				Provider:    null.StringFrom(account.Provider),
+				Subject:     null.StringFrom(account.Subject),
+				Email:       null.StringFrom(account.Email),
+				LastLoginAt: null.TimeFrom(time.Now()),
+			}); err != nil {
+				return err
+			}
+		}
+
+		// 身份源中的分组是用户类型的唯一来源，每次登录时同步
+		if account.UserType != nil && user.UserType != *account.UserType {
+			if _, err := model2.NewUsersModel(tx).Update(ctx, query.Builder().Where(model2.FieldUsersId, user.Id), model2.UsersN{
+				UserType: null.IntFrom(*account.UserType),
+			}); err != nil {
+				return err
+			}
+			user.UserType = *account.UserType
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if user.Status == UserStatusDeleted {
+		return nil, 0, ErrUserAccountDisabled
+	}
+
+	return user, eventID, nil
+}
@@ -0,0 +1,232 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+)
+
+type UserStatsRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewUserStatsRepo create a new UserStatsRepo
+func NewUserStatsRepo(db *sql.DB, conf *config.Config) *UserStatsRepo {
+	return &UserStatsRepo{db: db, conf: conf}
+}
+
+// Aggregate 汇总指定日期所有用户的聊天数据（包括群聊），按照 用户 + 房间 + 模型 聚合，重复执行时会覆盖之前的统计结果
+func (repo *UserStatsRepo) Aggregate(ctx context.Context, date time.Time) error {
+	statDate := date.Format("2006-01-02")
+	startTime := statDate + " 00:00:00"
+	endTime := date.AddDate(0, 0, 1).Format("2006-01-02") + " 00:00:00"
+
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_stats WHERE stat_date = ?", statDate); err != nil {
+			return fmt.Errorf("delete user stats failed: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO user_stats (user_id, stat_date, room_id, model, messages, tokens, coins, created_at, updated_at)
+SELECT user_id, ?, room_id, COALESCE(model, ''), SUM(IF(role = ?, 1, 0)), SUM(token_consumed), SUM(quota_consumed), NOW(), NOW()
+FROM chat_messages
+WHERE created_at >= ? AND created_at < ?
+GROUP BY user_id, room_id, COALESCE(model, '')
+ON DUPLICATE KEY UPDATE messages = messages + VALUES(messages), tokens = tokens + VALUES(tokens), coins = coins + VALUES(coins)`,
+			statDate, int64(MessageRoleUser), startTime, endTime,
+		); err != nil {
+			return fmt.Errorf("aggregate chat messages failed: %w", err)
+		}
+
+		// 群聊中用户的提问没有关联成员，模型为空；已删除的消息不计入统计
+		if _, err := tx.ExecContext(ctx, `INSERT INTO user_stats (user_id, stat_date, room_id, model, messages, tokens, coins, created_at, updated_at)
+SELECT msg.user_id, ?, msg.group_id, COALESCE(mem.model_id, ''), SUM(IF(msg.role = ?, 1, 0)), SUM(msg.token_consumed), SUM(msg.quota_consumed), NOW(), NOW()
+FROM chat_group_message msg
+LEFT JOIN chat_group_member mem ON mem.id = msg.member_id
+WHERE msg.created_at >= ? AND msg.created_at < ? AND msg.deleted_at IS NULL
+GROUP BY msg.user_id, msg.group_id, COALESCE(mem.model_id, '')
+ON DUPLICATE KEY UPDATE messages = messages + VALUES(messages), tokens = tokens + VALUES(tokens), coins = coins + VALUES(coins)`,
+			statDate, int64(MessageRoleUser), startTime, endTime,
+		); err != nil {
+			return fmt.Errorf("aggregate group chat messages failed: %w", err)
+		}
+
+		// 无痕模式下没有聊天记录，直接使用记录的用量统计
+		if _, err := tx.ExecContext(ctx, `INSERT INTO user_stats (user_id, stat_date, room_id, model, messages, tokens, coins, created_at, updated_at)
+SELECT user_id, stat_date, room_id, model, messages, tokens, coins, NOW(), NOW()
+FROM incognito_usage
+WHERE stat_date = ?
+ON DUPLICATE KEY UPDATE messages = messages + VALUES(messages), tokens = tokens + VALUES(tokens), coins = coins + VALUES(coins)`,
+			statDate,
+		); err != nil {
+			return fmt.Errorf("aggregate incognito usage failed: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UserDailyStat 用户每日使用统计
+type UserDailyStat struct {
+	Date     string `json:"date"`
+	Messages int64  `json:"messages"`
+	Tokens   int64  `json:"tokens"`
+	Coins    int64  `json:"coins"`
+}
+
+// UserModelStat 用户每个模型的使用统计
+type UserModelStat struct {
+	Model    string `json:"model"`
+	Messages int64  `json:"messages"`
+	Tokens   int64  `json:"tokens"`
+	Coins    int64  `json:"coins"`
+}
+
+// UserRoomStat 用户每个房间（数字人/群聊）的使用统计
+type UserRoomStat struct {
+	RoomID   int64  `json:"room_id"`
+	Name     string `json:"name"`
+	RoomType int64  `json:"room_type"`
+	Messages int64  `json:"messages"`
+	Coins    int64  `json:"coins"`
+}
+
+// DailyStats 查询用户最近 days 天每天的使用统计，没有使用记录的日期不返回
+func (repo *UserStatsRepo) DailyStats(ctx context.Context, userID int64, days int64) ([]UserDailyStat, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT DATE_FORMAT(stat_date, '%Y-%m-%d'), SUM(messages), SUM(tokens), SUM(coins)
+FROM user_stats
+WHERE user_id = ? AND stat_date >= ?
+GROUP BY stat_date
+ORDER BY stat_date ASC`,
+		userID, time.Now().AddDate(0, 0, -int(days)).Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query daily stats failed: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]UserDailyStat, 0)
+	for rows.Next() {
+		var stat UserDailyStat
+		if err := rows.Scan(&stat.Date, &stat.Messages, &stat.Tokens, &stat.Coins); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+// ModelStats 查询用户最近 days 天每个模型的使用统计，按消耗的智慧果倒序排列
+func (repo *UserStatsRepo) ModelStats(ctx context.Context, userID int64, days int64) ([]UserModelStat, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT model, SUM(messages), SUM(tokens), SUM(coins)
+FROM user_stats
+WHERE user_id = ? AND stat_date >= ? AND model <> ''
+GROUP BY model
+ORDER BY SUM(coins) DESC`,
+		userID, time.Now().AddDate(0, 0, -int(days)).Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query model stats failed: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]UserModelStat, 0)
+	for rows.Next() {
+		var stat UserModelStat
+		if err := rows.Scan(&stat.Model, &stat.Messages, &stat.Tokens, &stat.Coins); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+// TopRooms 查询用户最近 days 天最常使用的房间（包括群聊），按消息数量倒序排列
+func (repo *UserStatsRepo) TopRooms(ctx context.Context, userID int64, days int64, limit int64) ([]UserRoomStat, error) {
+	rows, err := repo.db.QueryContext(ctx, `SELECT s.room_id, COALESCE(r.name, ''), COALESCE(r.room_type, 0), SUM(s.messages), SUM(s.coins)
+FROM user_stats s
+LEFT JOIN rooms r ON r.id = s.room_id AND r.user_id = s.user_id
+WHERE s.user_id = ? AND s.stat_date >= ?
+GROUP BY s.room_id, r.name, r.room_type
+ORDER BY SUM(s.messages) DESC
+LIMIT ?`,
+		userID, time.Now().AddDate(0, 0, -int(days)).Format("2006-01-02"), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query room stats failed: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]UserRoomStat, 0)
+	for rows.Next() {
+		var stat UserRoomStat
+		if err := rows.Scan(&stat.RoomID, &stat.Name, &stat.RoomType, &stat.Messages, &stat.Coins); err != nil {
+			return nil, err
+		}
+
+		// 默认房间不在 rooms 表中
+		if stat.RoomID == 1 {
+			stat.Name = GetDefaultRoom().Name
+			stat.RoomType = RoomTypePreset
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+// Streaks 根据有使用记录的日期（格式 2006-01-02）计算当前连续使用天数与最长连续使用天数，
+// 统计数据每天凌晨生成，因此截止到昨天仍然连续的记录也视为当前连续
+func Streaks(dates []string, today time.Time) (current int64, longest int64) {
+	days := make([]time.Time, 0, len(dates))
+	for _, d := range dates {
+		t, err := time.ParseInLocation("2006-01-02", d, today.Location())
+		if err != nil {
+			continue
+		}
+
+		days = append(days, t)
+	}
+
+	if len(days) == 0 {
+		return 0, 0
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	var run int64
+	for i, d := range days {
+		if i > 0 && d.Equal(days[i-1]) {
+			continue
+		}
+
+		if i > 0 && d.Equal(days[i-1].AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+
+		if run > longest {
+			longest = run
+		}
+	}
+
+	todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	last := days[len(days)-1]
+	if last.Equal(todayDate) || last.Equal(todayDate.AddDate(0, 0, -1)) {
+		current = run
+	}
+
+	return current, longest
+}
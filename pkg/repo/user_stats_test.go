@@ -0,0 +1,25 @@
+package repo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestStreaks(t *testing.T) {
+	today := time.Date(2023, 12, 13, 10, 0, 0, 0, time.Local)
+
+	current, longest := repo.Streaks(nil, today)
+	assert.Equal(t, int64(0), current)
+	assert.Equal(t, int64(0), longest)
+
+	current, longest = repo.Streaks([]string{"2023-12-12", "2023-12-01", "2023-12-02", "2023-12-03", "2023-12-11", "2023-12-11"}, today)
+	assert.Equal(t, int64(2), current)
+	assert.Equal(t, int64(3), longest)
+
+	current, longest = repo.Streaks([]string{"2023-12-09", "2023-12-10"}, today)
+	assert.Equal(t, int64(0), current)
+	assert.Equal(t, int64(2), longest)
+}
@@ -0,0 +1,250 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+var (
+	ErrVectorCollectionMismatch = errors.New("vector collection model or dimension mismatch")
+	ErrVectorCollectionExists   = errors.New("vector collection exists")
+)
+
+type VectorRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewVectorRepo create a new VectorRepo
+func NewVectorRepo(db *sql.DB, conf *config.Config) *VectorRepo {
+	return &VectorRepo{db: db, conf: conf}
+}
+
+// GetCollectionByName 根据名称查询用户的向量集合
+func (repo *VectorRepo) GetCollectionByName(ctx context.Context, userID int64, name string) (*model.VectorCollection, error) {
+	col, err := model.NewVectorCollectionModel(repo.db).First(ctx, query.Builder().
+		Where(model.FieldVectorCollectionUserId, userID).
+		Where(model.FieldVectorCollectionName, name))
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	ret := col.ToVectorCollection()
+	return &ret, nil
+}
+
+// GetOrCreateCollection 查询用户的向量集合，不存在时自动创建
+func (repo *VectorRepo) GetOrCreateCollection(ctx context.Context, userID int64, name string, modelID string, dimension int64) (*model.VectorCollection, error) {
+	col, err := repo.GetCollectionByName(ctx, userID, name)
+	if err == nil {
+		if col.Model != modelID || (col.Dimension > 0 && col.Dimension != dimension) {
+			return nil, ErrVectorCollectionMismatch
+		}
+
+		if col.Dimension == 0 {
+			if err := repo.UpdateCollectionDimension(ctx, col.Id, dimension); err != nil {
+				return nil, err
+			}
+			col.Dimension = dimension
+		}
+
+		return col, nil
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if _, err := model.NewVectorCollectionModel(repo.db).Create(ctx, query.KV{
+		model.FieldVectorCollectionUserId:    userID,
+		model.FieldVectorCollectionName:      name,
+		model.FieldVectorCollectionModel:     modelID,
+		model.FieldVectorCollectionDimension: dimension,
+	}); err != nil {
+		return nil, fmt.Errorf("create vector collection failed: %w", err)
+	}
+
+	return repo.GetCollectionByName(ctx, userID, name)
+}
+
+// UpdateCollectionDimension 更新集合的向量维度，只对尚未确定维度的集合生效
+func (repo *VectorRepo) UpdateCollectionDimension(ctx context.Context, collectionID int64, dimension int64) error {
+	_, err := model.NewVectorCollectionModel(repo.db).UpdateFields(
+		ctx,
+		query.KV{model.FieldVectorCollectionDimension: dimension},
+		query.Builder().
+			Where(model.FieldVectorCollectionId, collectionID).
+			Where(model.FieldVectorCollectionDimension, 0),
+	)
+	return err
+}
+
+// VectorDocumentAddReq 向量文档
+type VectorDocumentAddReq struct {
+	Content  string
+	Metadata map[string]any
+	Vector   []float32
+}
+
+// AddDocuments 向集合中添加文档，返回文档 ID 列表
+func (repo *VectorRepo) AddDocuments(ctx context.Context, userID, collectionID int64, docs []VectorDocumentAddReq) ([]int64, error) {
+	ids := make([]int64, 0, len(docs))
+	err := eloquent.Transaction(repo.db, func(tx query.Database) error {
+		for _, doc := range docs {
+			vec, err := json.Marshal(doc.Vector)
+			if err != nil {
+				return fmt.Errorf("marshal vector failed: %w", err)
+			}
+
+			kv := query.KV{
+				model.FieldVectorDocumentCollectionId: collectionID,
+				model.FieldVectorDocumentUserId:       userID,
+				model.FieldVectorDocumentContent:      doc.Content,
+				model.FieldVectorDocumentVector:       string(vec),
+			}
+
+			if len(doc.Metadata) > 0 {
+				meta, err := json.Marshal(doc.Metadata)
+				if err != nil {
+					return fmt.Errorf("marshal metadata failed: %w", err)
+				}
+
+				kv[model.FieldVectorDocumentMetadata] = string(meta)
+			}
+
+			id, err := model.NewVectorDocumentModel(tx).Create(ctx, kv)
+			if err != nil {
+				return fmt.Errorf("create vector document failed: %w", err)
+			}
+
+			ids = append(ids, id)
+		}
+
+		_, err := tx.ExecContext(ctx, "UPDATE vector_collection SET doc_count = doc_count + ? WHERE id = ?", len(docs), collectionID)
+		return err
+	})
+
+	return ids, err
+}
+
+// CreateCollection 创建向量集合
+func (repo *VectorRepo) CreateCollection(ctx context.Context, userID int64, name, description, modelID string) (*model.VectorCollection, error) {
+	exists, err := model.NewVectorCollectionModel(repo.db).Exists(ctx, query.Builder().
+		Where(model.FieldVectorCollectionUserId, userID).
+		Where(model.FieldVectorCollectionName, name))
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, ErrVectorCollectionExists
+	}
+
+	if _, err := model.NewVectorCollectionModel(repo.db).Create(ctx, query.KV{
+		model.FieldVectorCollectionUserId:      userID,
+		model.FieldVectorCollectionName:        name,
+		model.FieldVectorCollectionDescription: description,
+		model.FieldVectorCollectionModel:       modelID,
+	}); err != nil {
+		return nil, fmt.Errorf("create vector collection failed: %w", err)
+	}
+
+	return repo.GetCollectionByName(ctx, userID, name)
+}
+
+// GetCollections 获取用户的所有向量集合
+func (repo *VectorRepo) GetCollections(ctx context.Context, userID int64) ([]model.VectorCollection, error) {
+	cols, err := model.NewVectorCollectionModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldVectorCollectionUserId, userID).
+		OrderBy(model.FieldVectorCollectionId, "DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(cols, func(item model.VectorCollectionN, _ int) model.VectorCollection {
+		return item.ToVectorCollection()
+	}), nil
+}
+
+// DeleteCollection 删除向量集合及其包含的所有文档
+func (repo *VectorRepo) DeleteCollection(ctx context.Context, userID, collectionID int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		if _, err := model.NewVectorDocumentModel(tx).Delete(ctx, query.Builder().
+			Where(model.FieldVectorDocumentCollectionId, collectionID).
+			Where(model.FieldVectorDocumentUserId, userID)); err != nil {
+			return err
+		}
+
+		_, err := model.NewVectorCollectionModel(tx).Delete(ctx, query.Builder().
+			Where(model.FieldVectorCollectionId, collectionID).
+			Where(model.FieldVectorCollectionUserId, userID))
+		return err
+	})
+}
+
+// DeleteDocument 从集合中删除文档
+func (repo *VectorRepo) DeleteDocument(ctx context.Context, userID, collectionID, docID int64) error {
+	return eloquent.Transaction(repo.db, func(tx query.Database) error {
+		affected, err := model.NewVectorDocumentModel(tx).Delete(ctx, query.Builder().
+			Where(model.FieldVectorDocumentId, docID).
+			Where(model.FieldVectorDocumentCollectionId, collectionID).
+			Where(model.FieldVectorDocumentUserId, userID))
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return ErrNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE vector_collection SET doc_count = doc_count - 1 WHERE id = ? AND doc_count > 0", collectionID)
+		return err
+	})
+}
+
+// VectorDocument 向量文档
+type VectorDocument struct {
+	ID       int64
+	Content  string
+	Metadata map[string]any
+	Vector   []float32
+}
+
+// GetDocuments 获取集合中的所有文档（包含向量），用于相似度检索
+func (repo *VectorRepo) GetDocuments(ctx context.Context, collectionID int64) ([]VectorDocument, error) {
+	docs, err := model.NewVectorDocumentModel(repo.db).Get(ctx, query.Builder().
+		Where(model.FieldVectorDocumentCollectionId, collectionID).
+		OrderBy(model.FieldVectorDocumentId, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]VectorDocument, 0, len(docs))
+	for _, doc := range docs {
+		item := VectorDocument{ID: doc.Id.ValueOrZero(), Content: doc.Content.ValueOrZero()}
+		if err := json.Unmarshal([]byte(doc.Vector.ValueOrZero()), &item.Vector); err != nil {
+			return nil, fmt.Errorf("unmarshal vector of document %d failed: %w", item.ID, err)
+		}
+
+		if meta := doc.Metadata.ValueOrZero(); meta != "" {
+			_ = json.Unmarshal([]byte(meta), &item.Metadata)
+		}
+
+		ret = append(ret, item)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// VersionPlatformDefault 默认版本策略，对没有单独配置策略的平台生效
+const VersionPlatformDefault = "*"
+
+// VersionPolicy 客户端版本策略
+type VersionPolicy struct {
+	Platform string `json:"platform"`
+	// MinVersion 最低支持版本，低于该版本的客户端必须升级后才能使用
+	MinVersion string `json:"min_version,omitempty"`
+	// RecommendedVersion 推荐版本，低于该版本的客户端提示升级
+	RecommendedVersion string `json:"recommended_version,omitempty"`
+	// BlockedVersions 存在严重问题被禁止使用的版本
+	BlockedVersions []string `json:"blocked_versions"`
+	UpgradeURL      string   `json:"upgrade_url,omitempty"`
+	Message         string   `json:"message,omitempty"`
+}
+
+// VersionPolicyRepo 客户端版本策略
+type VersionPolicyRepo struct {
+	db   *sql.DB
+	conf *config.Config
+}
+
+// NewVersionPolicyRepo create a new VersionPolicyRepo
+func NewVersionPolicyRepo(db *sql.DB, conf *config.Config) *VersionPolicyRepo {
+	return &VersionPolicyRepo{db: db, conf: conf}
+}
+
+// Policies 查询所有平台的版本策略
+func (repo *VersionPolicyRepo) Policies(ctx context.Context) ([]VersionPolicy, error) {
+	items, err := model.NewClientVersionPolicyModel(repo.db).Get(ctx, query.Builder().OrderBy(model.FieldClientVersionPolicyPlatform, "ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]VersionPolicy, 0, len(items))
+	for _, item := range items {
+		policy := VersionPolicy{
+			Platform:           item.Platform.ValueOrZero(),
+			MinVersion:         item.MinVersion.ValueOrZero(),
+			RecommendedVersion: item.RecommendedVersion.ValueOrZero(),
+			BlockedVersions:    []string{},
+			UpgradeURL:         item.UpgradeUrl.ValueOrZero(),
+			Message:            item.Message.ValueOrZero(),
+		}
+
+		if v := item.BlockedVersions.ValueOrZero(); v != "" {
+			if err := json.Unmarshal([]byte(v), &policy.BlockedVersions); err != nil {
+				return nil, fmt.Errorf("unmarshal blocked versions of platform %s failed: %w", policy.Platform, err)
+			}
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// UpdatePolicy 新增或更新平台的版本策略
+func (repo *VersionPolicyRepo) UpdatePolicy(ctx context.Context, policy VersionPolicy) error {
+	blockedVersions, _ := json.Marshal(ternary.If(policy.BlockedVersions == nil, []string{}, policy.BlockedVersions))
+
+	_, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO client_version_policy (platform, min_version, recommended_version, blocked_versions, upgrade_url, message, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW()) ON DUPLICATE KEY UPDATE min_version = VALUES(min_version), recommended_version = VALUES(recommended_version), blocked_versions = VALUES(blocked_versions), upgrade_url = VALUES(upgrade_url), message = VALUES(message), updated_at = NOW()",
+		policy.Platform, policy.MinVersion, policy.RecommendedVersion, string(blockedVersions), policy.UpgradeURL, policy.Message,
+	)
+	return err
+}
+
+// DeletePolicy 删除平台的版本策略
+func (repo *VersionPolicyRepo) DeletePolicy(ctx context.Context, platform string) error {
+	_, err := model.NewClientVersionPolicyModel(repo.db).Delete(ctx, query.Builder().Where(model.FieldClientVersionPolicyPlatform, platform))
+	return err
+}
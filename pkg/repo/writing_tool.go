@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/eloquent/query"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// WritingToolRepo 写作工具操作记录
+type WritingToolRepo struct {
+	db *sql.DB
+}
+
+// NewWritingToolRepo create a new WritingToolRepo
+func NewWritingToolRepo(db *sql.DB) *WritingToolRepo {
+	return &WritingToolRepo{db: db}
+}
+
+// WritingToolHistory 一次写作工具操作
+type WritingToolHistory struct {
+	ID            int64             `json:"id"`
+	Operation     string            `json:"operation"`
+	Options       map[string]string `json:"options,omitempty"`
+	Model         string            `json:"model"`
+	Input         string            `json:"input"`
+	Output        string            `json:"output"`
+	QuotaConsumed int64             `json:"quota_consumed"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+func writingToolHistoryFromModel(item model.WritingToolHistoryN) WritingToolHistory {
+	ret := WritingToolHistory{
+		ID:            item.Id.ValueOrZero(),
+		Operation:     item.Operation.ValueOrZero(),
+		Model:         item.Model.ValueOrZero(),
+		Input:         item.Input.ValueOrZero(),
+		Output:        item.Output.ValueOrZero(),
+		QuotaConsumed: item.QuotaConsumed.ValueOrZero(),
+		CreatedAt:     item.CreatedAt.ValueOrZero(),
+	}
+
+	if options := item.Options.ValueOrZero(); options != "" {
+		_ = json.Unmarshal([]byte(options), &ret.Options)
+	}
+
+	return ret
+}
+
+// Create 保存写作工具操作记录
+func (repo *WritingToolRepo) Create(ctx context.Context, userID int64, history WritingToolHistory) (int64, error) {
+	var options string
+	if len(history.Options) > 0 {
+		data, _ := json.Marshal(history.Options)
+		options = string(data)
+	}
+
+	return model.NewWritingToolHistoryModel(repo.db).Create(ctx, query.KV{
+		model.FieldWritingToolHistoryUserId:        userID,
+		model.FieldWritingToolHistoryOperation:     history.Operation,
+		model.FieldWritingToolHistoryOptions:       options,
+		model.FieldWritingToolHistoryModel:         history.Model,
+		model.FieldWritingToolHistoryInput:         history.Input,
+		model.FieldWritingToolHistoryOutput:        history.Output,
+		model.FieldWritingToolHistoryQuotaConsumed: history.QuotaConsumed,
+	})
+}
+
+// Histories 分页查询用户的写作工具操作记录，按照创建时间倒序，operation 为空时查询所有操作
+func (repo *WritingToolRepo) Histories(ctx context.Context, userID int64, operation string, page, perPage int64) ([]WritingToolHistory, query.PaginateMeta, error) {
+	q := query.Builder().Where(model.FieldWritingToolHistoryUserId, userID)
+	if operation != "" {
+		q = q.Where(model.FieldWritingToolHistoryOperation, operation)
+	}
+
+	items, meta, err := model.NewWritingToolHistoryModel(repo.db).Paginate(ctx, page, perPage, q.OrderBy(model.FieldWritingToolHistoryId, "DESC"))
+	if err != nil {
+		return nil, meta, err
+	}
+
+	return array.Map(items, func(item model.WritingToolHistoryN, _ int) WritingToolHistory {
+		return writingToolHistoryFromModel(item)
+	}), meta, nil
+}
+
+// Delete 删除用户的写作工具操作记录
+func (repo *WritingToolRepo) Delete(ctx context.Context, userID, id int64) error {
+	affected, err := model.NewWritingToolHistoryModel(repo.db).Delete(ctx, query.Builder().
+		Where(model.FieldWritingToolHistoryId, id).
+		Where(model.FieldWritingToolHistoryUserId, userID))
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
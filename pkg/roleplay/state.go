@@ -0,0 +1,163 @@
+package roleplay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxCharacters 状态中最多保留的角色数量
+	MaxCharacters = 10
+	// MaxStats 单个角色最多保留的属性数量
+	MaxStats = 20
+	// MaxInventory 物品栏最多保留的物品数量
+	MaxInventory = 50
+	// maxTextLength 场景描述等文本字段的最大字符数
+	maxTextLength = 1000
+)
+
+var ErrInvalidState = errors.New("invalid role-play state")
+
+// Character 角色信息
+type Character struct {
+	Name string `json:"name"`
+	// Stats 角色属性，例如 {"hp": 100, "mp": 20}
+	Stats map[string]int64 `json:"stats,omitempty"`
+	// Status 角色当前状态描述，例如 “受伤”、“中毒”
+	Status string `json:"status,omitempty"`
+}
+
+// State 角色扮演的结构化状态，每轮对话后由模型更新，并注入到下一轮的提示语中
+type State struct {
+	// Scene 当前场景描述
+	Scene      string      `json:"scene"`
+	Characters []Character `json:"characters"`
+	Inventory  []string    `json:"inventory"`
+	// Notes 需要模型记住的其它关键信息，例如任务进度
+	Notes string `json:"notes,omitempty"`
+}
+
+// Parse 解析 JSON 格式的状态，空字符串返回空状态
+func Parse(data string) (State, error) {
+	var state State
+	if strings.TrimSpace(data) == "" {
+		return state.Normalize(), nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return state, fmt.Errorf("%w: %v", ErrInvalidState, err)
+	}
+
+	return state.Normalize(), nil
+}
+
+// JSON 将状态序列化为 JSON 字符串
+func (s State) JSON() string {
+	data, _ := json.Marshal(s.Normalize())
+	return string(data)
+}
+
+// Normalize 清理状态中的无效数据并限制数据规模，避免状态无限膨胀
+func (s State) Normalize() State {
+	ret := State{
+		Scene:      truncate(strings.TrimSpace(s.Scene), maxTextLength),
+		Notes:      truncate(strings.TrimSpace(s.Notes), maxTextLength),
+		Characters: make([]Character, 0),
+		Inventory:  make([]string, 0),
+	}
+
+	for _, c := range s.Characters {
+		c.Name = truncate(strings.TrimSpace(c.Name), 50)
+		if c.Name == "" {
+			continue
+		}
+
+		stats := make(map[string]int64)
+		for _, k := range sortedKeys(c.Stats) {
+			key := truncate(strings.TrimSpace(k), 30)
+			if key == "" || len(stats) >= MaxStats {
+				continue
+			}
+
+			stats[key] = c.Stats[k]
+		}
+
+		ret.Characters = append(ret.Characters, Character{Name: c.Name, Stats: stats, Status: truncate(strings.TrimSpace(c.Status), 100)})
+		if len(ret.Characters) >= MaxCharacters {
+			break
+		}
+	}
+
+	for _, item := range s.Inventory {
+		item = truncate(strings.TrimSpace(item), 50)
+		if item == "" {
+			continue
+		}
+
+		ret.Inventory = append(ret.Inventory, item)
+		if len(ret.Inventory) >= MaxInventory {
+			break
+		}
+	}
+
+	return ret
+}
+
+// Prompt 将状态渲染为注入到系统提示语中的文本
+func (s State) Prompt() string {
+	return "Current state of the story (authoritative, keep your reply consistent with it):\n" + s.JSON()
+}
+
+// UpdateInstruction 生成更新状态的提示语，要求模型根据本轮对话输出完整的新状态
+func UpdateInstruction(prev State, userMessage, reply string) string {
+	return fmt.Sprintf(`You maintain the structured state of a role-play story.
+
+Previous state:
+%s
+
+Latest turn:
+User: %s
+Narrator: %s
+
+Update the state to reflect everything that happened in the latest turn (scene changes, character stat changes, items gained or lost, important facts). Output only the complete new state as a JSON object with this schema:
+{"scene": "...", "characters": [{"name": "...", "stats": {"hp": 100}, "status": "..."}], "inventory": ["..."], "notes": "..."}`,
+		prev.JSON(), userMessage, reply,
+	)
+}
+
+// ParseUpdate 解析模型输出的新状态，解析失败时返回原状态
+func ParseUpdate(prev State, text string) (State, error) {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return prev, ErrInvalidState
+	}
+
+	next, err := Parse(text[start : end+1])
+	if err != nil {
+		return prev, err
+	}
+
+	return next, nil
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	return string(runes[:length])
+}
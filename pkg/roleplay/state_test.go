@@ -0,0 +1,46 @@
+package roleplay_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/roleplay"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestParse(t *testing.T) {
+	state, err := roleplay.Parse("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(state.Characters))
+	assert.Equal(t, 0, len(state.Inventory))
+
+	_, err = roleplay.Parse("not json")
+	assert.True(t, errors.Is(err, roleplay.ErrInvalidState))
+}
+
+func TestParseUpdate(t *testing.T) {
+	prev := roleplay.State{Scene: "村庄"}
+
+	next, err := roleplay.ParseUpdate(prev, "```json\n{\"scene\": \"森林\", \"characters\": [{\"name\": \"勇者\", \"stats\": {\"hp\": 80}}, {\"name\": \" \"}], \"inventory\": [\"火把\", \"\"]}\n```")
+	assert.NoError(t, err)
+	assert.Equal(t, "森林", next.Scene)
+	assert.Equal(t, 1, len(next.Characters))
+	assert.Equal(t, int64(80), next.Characters[0].Stats["hp"])
+	assert.Equal(t, []string{"火把"}, next.Inventory)
+
+	kept, err := roleplay.ParseUpdate(prev, "no state here")
+	assert.True(t, errors.Is(err, roleplay.ErrInvalidState))
+	assert.Equal(t, "村庄", kept.Scene)
+}
+
+func TestNormalizeLimits(t *testing.T) {
+	state := roleplay.State{Scene: strings.Repeat("a", 2000)}
+	for i := 0; i < roleplay.MaxInventory+10; i++ {
+		state.Inventory = append(state.Inventory, "item")
+	}
+
+	normalized := state.Normalize()
+	assert.Equal(t, roleplay.MaxInventory, len(normalized.Inventory))
+	assert.Equal(t, 1000, len(normalized.Scene))
+}
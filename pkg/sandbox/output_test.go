@@ -0,0 +1,22 @@
+package sandbox
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestCappedBuffer(t *testing.T) {
+	buf := &cappedBuffer{limit: 8}
+
+	n, err := buf.Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	// 超出部分被丢弃，但是写入方看到的仍然是全部写入成功
+	n, err = buf.Write([]byte(strings.Repeat("x", 100)))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, n)
+	assert.Equal(t, "helloxxx", buf.String())
+}
@@ -0,0 +1,9 @@
+package sandbox
+
+import "github.com/mylxsw/glacier/infra"
+
+type Provider struct{}
+
+func (Provider) Register(binder infra.Binder) {
+	binder.MustSingleton(New)
+}
@@ -0,0 +1,172 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/asteria/log"
+)
+
+var ErrSandboxDisabled = errors.New("code sandbox is disabled")
+
+// maxOutputLength 标准输出/错误输出最大保留长度
+const maxOutputLength = 16 * 1024
+
+// maxOutputBytes 执行过程中标准输出/错误输出最多缓存的字节数，UTF-8 字符最多占用 4 个字节
+const maxOutputBytes = maxOutputLength * 4
+
+// Result 代码执行结果
+type Result struct {
+	Stdout   string        `json:"stdout"`
+	Stderr   string        `json:"stderr"`
+	ExitCode int           `json:"exit_code"`
+	Duration time.Duration `json:"duration"`
+	TimedOut bool          `json:"timed_out,omitempty"`
+}
+
+// Sandbox 基于 Docker（可选 gVisor 运行时）的代码执行沙箱，每次执行都会创建一个全新的容器
+type Sandbox struct {
+	enabled bool
+	docker  string
+	image   string
+	runtime string
+	memory  string
+	cpus    string
+	timeout time.Duration
+}
+
+func New(conf *config.Config) *Sandbox {
+	return &Sandbox{
+		enabled: conf.EnableSandbox,
+		docker:  conf.SandboxDockerBin,
+		image:   conf.SandboxImage,
+		runtime: conf.SandboxRuntime,
+		memory:  conf.SandboxMemory,
+		cpus:    conf.SandboxCPUs,
+		timeout: conf.SandboxTimeout,
+	}
+}
+
+// Enabled 沙箱是否可用
+func (sb *Sandbox) Enabled() bool {
+	return sb.enabled
+}
+
+// Args 构建 docker run 命令参数，容器禁用网络、只读文件系统并限制资源，name 为容器名称，超时后通过名称强制删除容器
+func (sb *Sandbox) Args(name string) []string {
+	args := []string{
+		"run", "--rm", "-i",
+		"--name", name,
+		"--network", "none",
+		"--read-only",
+		"--tmpfs", "/tmp:rw,size=64m",
+		"--pids-limit", "64",
+		"--memory", sb.memory,
+		"--cpus", sb.cpus,
+		"--security-opt", "no-new-privileges",
+		"--cap-drop", "ALL",
+		"--user", "65534:65534",
+		"--workdir", "/tmp",
+	}
+
+	if sb.runtime != "" {
+		args = append(args, "--runtime", sb.runtime)
+	}
+
+	return append(args, sb.image, "python3", "-")
+}
+
+// RunPython 在沙箱中执行 Python 代码，代码通过标准输入传入
+func (sb *Sandbox) RunPython(ctx context.Context, code string) (*Result, error) {
+	if !sb.enabled {
+		return nil, ErrSandboxDisabled
+	}
+
+	name, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("generate container name failed: %w", err)
+	}
+	name = "aidea-sandbox-" + name
+
+	ctx, cancel := context.WithTimeout(ctx, sb.timeout)
+	defer cancel()
+
+	stdout, stderr := &cappedBuffer{limit: maxOutputBytes}, &cappedBuffer{limit: maxOutputBytes}
+	cmd := exec.CommandContext(ctx, sb.docker, sb.Args(name)...)
+	cmd.Stdin = bytes.NewBufferString(code)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	startTime := time.Now()
+	err = cmd.Run()
+
+	// 超时或者请求取消时只会结束 docker 客户端进程，容器仍然在运行，需要强制删除
+	if ctx.Err() != nil {
+		sb.removeContainer(name)
+	}
+
+	res := &Result{
+		Stdout:   misc.SubString(stdout.String(), maxOutputLength),
+		Stderr:   misc.SubString(stderr.String(), maxOutputLength),
+		Duration: time.Since(startTime),
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		res.TimedOut = true
+		res.ExitCode = -1
+		return res, nil
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			res.ExitCode = exitErr.ExitCode()
+			return res, nil
+		}
+
+		return nil, fmt.Errorf("run sandbox failed: %w", err)
+	}
+
+	return res, nil
+}
+
+// removeContainer 强制删除容器，使用独立的 context，不受已经结束的请求影响
+func (sb *Sandbox) removeContainer(name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if out, err := exec.CommandContext(ctx, sb.docker, "rm", "-f", name).CombinedOutput(); err != nil {
+		log.F(log.M{"container": name, "output": string(out)}).Errorf("remove sandbox container failed: %v", err)
+	}
+}
+
+// cappedBuffer 最多保存 limit 字节的输出，超出部分直接丢弃，避免代码大量输出时耗尽服务端内存
+type cappedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if rest := b.limit - b.buf.Len(); rest > 0 {
+		if len(p) > rest {
+			p = p[:rest]
+		}
+
+		b.buf.Write(p)
+	}
+
+	// 丢弃的部分同样视为写入成功，避免代码因为输出管道错误而提前退出
+	return n, nil
+}
+
+func (b *cappedBuffer) String() string {
+	return b.buf.String()
+}
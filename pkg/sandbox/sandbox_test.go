@@ -0,0 +1,39 @@
+package sandbox_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/sandbox"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestSandboxArgs(t *testing.T) {
+	sb := sandbox.New(&config.Config{
+		EnableSandbox:    true,
+		SandboxDockerBin: "docker",
+		SandboxImage:     "python:3.11-slim",
+		SandboxRuntime:   "runsc",
+		SandboxMemory:    "256m",
+		SandboxCPUs:      "0.5",
+		SandboxTimeout:   10 * time.Second,
+	})
+
+	args := strings.Join(sb.Args("aidea-sandbox-test"), " ")
+	assert.True(t, strings.Contains(args, "--name aidea-sandbox-test"))
+	assert.True(t, strings.Contains(args, "--network none"))
+	assert.True(t, strings.Contains(args, "--memory 256m"))
+	assert.True(t, strings.Contains(args, "--runtime runsc"))
+	assert.True(t, strings.HasSuffix(args, "python:3.11-slim python3 -"))
+}
+
+func TestSandboxDisabled(t *testing.T) {
+	sb := sandbox.New(&config.Config{EnableSandbox: false})
+
+	_, err := sb.RunPython(context.TODO(), "print(1)")
+	assert.True(t, errors.Is(err, sandbox.ErrSandboxDisabled))
+}
@@ -0,0 +1,263 @@
+// Package scim 组织成员同步接口使用的 SCIM 2.0 资源格式，只实现了用户资源以及 HR/IdP 系统常用的操作
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaOrgUser      = "urn:aidea:params:scim:schemas:extension:org:2.0:User"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+var (
+	ErrUnsupportedFilter = errors.New("only filters like `userName eq \"value\"` are supported")
+	ErrUnsupportedPatch  = errors.New("unsupported patch operation")
+)
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// OrgExtension 组织成员属性，role 可选值为 member、admin，monthlyLimit 为每月可使用的组织智慧果上限，0 表示不限制
+type OrgExtension struct {
+	Role         string `json:"role,omitempty"`
+	MonthlyLimit *int64 `json:"monthlyLimit,omitempty"`
+}
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+}
+
+// User SCIM 用户资源
+type User struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	UserName    string        `json:"userName"`
+	DisplayName string        `json:"displayName,omitempty"`
+	Name        *Name         `json:"name,omitempty"`
+	Active      *bool         `json:"active,omitempty"`
+	Emails      []Email       `json:"emails,omitempty"`
+	Org         *OrgExtension `json:"urn:aidea:params:scim:schemas:extension:org:2.0:User,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+// Username 用户的登录名（邮箱或者手机号），userName 为空时使用主邮箱
+func (u User) Username() string {
+	if name := strings.TrimSpace(u.UserName); name != "" {
+		return name
+	}
+
+	for _, email := range u.Emails {
+		if email.Primary {
+			return strings.TrimSpace(email.Value)
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return strings.TrimSpace(u.Emails[0].Value)
+	}
+
+	return ""
+}
+
+// Realname 用户的姓名
+func (u User) Realname() string {
+	if name := strings.TrimSpace(u.DisplayName); name != "" {
+		return name
+	}
+
+	if u.Name == nil {
+		return ""
+	}
+
+	if name := strings.TrimSpace(u.Name.Formatted); name != "" {
+		return name
+	}
+
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// IsActive 未指定 active 时视为启用
+func (u User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []User   `json:"Resources"`
+}
+
+// NewListResponse 分页返回用户列表，startIndex 从 1 开始
+func NewListResponse(users []User, startIndex, count int) ListResponse {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	res := ListResponse{Schemas: []string{SchemaListResponse}, TotalResults: len(users), StartIndex: startIndex, Resources: []User{}}
+	if startIndex <= len(users) && count > 0 {
+		end := startIndex - 1 + count
+		if end > len(users) {
+			end = len(users)
+		}
+
+		res.Resources = users[startIndex-1 : end]
+	}
+
+	res.ItemsPerPage = len(res.Resources)
+	return res
+}
+
+type Error struct {
+	Schemas []string `json:"schemas"`
+	Status  string   `json:"status"`
+	Detail  string   `json:"detail,omitempty"`
+}
+
+func NewError(status int, detail string) Error {
+	return Error{Schemas: []string{SchemaError}, Status: strconv.Itoa(status), Detail: detail}
+}
+
+// ParseFilter 解析用户查询条件，只支持 <属性> eq "<值>"，属性名不区分大小写
+func ParseFilter(filter string) (attr string, value string, err error) {
+	segs := strings.SplitN(strings.TrimSpace(filter), " ", 3)
+	if len(segs) != 3 || !strings.EqualFold(segs[1], "eq") {
+		return "", "", ErrUnsupportedFilter
+	}
+
+	value, err = strconv.Unquote(strings.TrimSpace(segs[2]))
+	if err != nil {
+		return "", "", ErrUnsupportedFilter
+	}
+
+	return strings.ToLower(segs[0]), value, nil
+}
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyPatch 修改用户的启用状态、姓名以及组织成员属性，path 为空时 value 为包含待修改属性的对象
+func (u *User) ApplyPatch(ops []PatchOperation) error {
+	for _, op := range ops {
+		if !strings.EqualFold(op.Op, "replace") && !strings.EqualFold(op.Op, "add") {
+			return fmt.Errorf("%w: %s", ErrUnsupportedPatch, op.Op)
+		}
+
+		if op.Path == "" {
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return fmt.Errorf("%w: value should be an object when path is empty", ErrUnsupportedPatch)
+			}
+
+			for path, value := range values {
+				if err := u.applyValue(path, value); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if err := u.applyValue(op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *User) applyValue(path string, value json.RawMessage) error {
+	// 扩展属性可以使用 <schema>:<属性> 的形式，也可以直接使用值为对象的 <schema>
+	if strings.EqualFold(path, SchemaOrgUser) {
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(value, &values); err != nil {
+			return fmt.Errorf("%w: invalid value for %s", ErrUnsupportedPatch, path)
+		}
+
+		for p, v := range values {
+			if err := u.applyValue(SchemaOrgUser+":"+p, v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimPrefix(path, SchemaOrgUser+":")) {
+	case "active":
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+	case "displayname":
+		if err := json.Unmarshal(value, &u.DisplayName); err != nil {
+			return fmt.Errorf("%w: invalid value for %s", ErrUnsupportedPatch, path)
+		}
+	case "role":
+		if u.Org == nil {
+			u.Org = &OrgExtension{}
+		}
+		if err := json.Unmarshal(value, &u.Org.Role); err != nil {
+			return fmt.Errorf("%w: invalid value for %s", ErrUnsupportedPatch, path)
+		}
+	case "monthlylimit":
+		var limit int64
+		if err := json.Unmarshal(value, &limit); err != nil {
+			return fmt.Errorf("%w: invalid value for %s", ErrUnsupportedPatch, path)
+		}
+		if u.Org == nil {
+			u.Org = &OrgExtension{}
+		}
+		u.Org.MonthlyLimit = &limit
+	default:
+		return fmt.Errorf("%w: path %s", ErrUnsupportedPatch, path)
+	}
+
+	return nil
+}
+
+// parseBool 部分 IdP（例如 Azure AD）会使用字符串 "True"/"False" 表示布尔值
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+
+	return false, fmt.Errorf("%w: invalid boolean value %s", ErrUnsupportedPatch, string(value))
+}
@@ -0,0 +1,60 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/scim"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	attr, value, err := scim.ParseFilter(`userName eq "alice@example.com"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "username", attr)
+	assert.Equal(t, "alice@example.com", value)
+
+	_, _, err = scim.ParseFilter(`userName sw "alice"`)
+	assert.True(t, err != nil)
+
+	_, _, err = scim.ParseFilter(`userName eq alice`)
+	assert.True(t, err != nil)
+}
+
+func TestApplyPatch(t *testing.T) {
+	var req scim.PatchRequest
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Replace", "path": "active", "value": "False"},
+			{"op": "replace", "value": {"displayName": "Alice"}},
+			{"op": "replace", "path": "urn:aidea:params:scim:schemas:extension:org:2.0:User:monthlyLimit", "value": 500},
+			{"op": "add", "path": "urn:aidea:params:scim:schemas:extension:org:2.0:User", "value": {"role": "admin"}}
+		]
+	}`), &req))
+
+	user := scim.User{UserName: "alice@example.com"}
+	assert.NoError(t, user.ApplyPatch(req.Operations))
+	assert.False(t, user.IsActive())
+	assert.Equal(t, "Alice", user.Realname())
+	assert.EqualValues(t, 500, *user.Org.MonthlyLimit)
+	assert.Equal(t, "admin", user.Org.Role)
+
+	err := user.ApplyPatch([]scim.PatchOperation{{Op: "remove", Path: "active"}})
+	assert.True(t, err != nil)
+
+	err = user.ApplyPatch([]scim.PatchOperation{{Op: "replace", Path: "password", Value: json.RawMessage(`"x"`)}})
+	assert.True(t, err != nil)
+}
+
+func TestNewListResponse(t *testing.T) {
+	users := []scim.User{{UserName: "a"}, {UserName: "b"}, {UserName: "c"}}
+
+	res := scim.NewListResponse(users, 2, 5)
+	assert.Equal(t, 3, res.TotalResults)
+	assert.Equal(t, 2, res.ItemsPerPage)
+	assert.Equal(t, "b", res.Resources[0].UserName)
+
+	res = scim.NewListResponse(users, 4, 5)
+	assert.Equal(t, 0, res.ItemsPerPage)
+}
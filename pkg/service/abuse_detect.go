@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/redis/go-redis/v9"
+)
+
+// 异常行为类型
+const (
+	// AbuseSignalIdenticalPrompts 大量重复的请求内容
+	AbuseSignalIdenticalPrompts = "identical_prompts"
+	// AbuseSignalScriptedTiming 请求间隔高度规律，疑似脚本调用
+	AbuseSignalScriptedTiming = "scripted_timing"
+	// AbuseSignalProxyLike 请求来源 IP 过多，疑似账号转售或代理共享
+	AbuseSignalProxyLike = "proxy_like"
+)
+
+const (
+	// abuseTimingSamples 用于判断请求间隔是否规律的最近请求数量
+	abuseTimingSamples = 50
+	// abuseTimingMinSamples 请求数量达到该值时才判断请求间隔是否规律
+	abuseTimingMinSamples = 30
+	// abuseTimingMaxCV 请求间隔的变异系数（标准差/平均值）低于该值时认为是脚本调用
+	abuseTimingMaxCV = 0.1
+)
+
+// AbuseStats 用户最近一段时间的请求统计
+type AbuseStats struct {
+	// IdenticalPrompts 一小时内与当前请求内容相同的请求次数
+	IdenticalPrompts int64 `json:"identical_prompts"`
+	// DistinctIPs 一小时内请求来源 IP 数量
+	DistinctIPs int64 `json:"distinct_ips"`
+	// Intervals 最近请求之间的间隔（毫秒）
+	Intervals []int64 `json:"intervals,omitempty"`
+}
+
+// AbuseSignal 检测到的异常行为
+type AbuseSignal struct {
+	Type     string `json:"type"`
+	Evidence string `json:"evidence"`
+}
+
+// DetectAbuse 根据请求统计检测异常行为
+func DetectAbuse(stats AbuseStats, identicalThreshold, ipThreshold int64) []AbuseSignal {
+	signals := make([]AbuseSignal, 0)
+	if identicalThreshold > 0 && stats.IdenticalPrompts >= identicalThreshold {
+		signals = append(signals, AbuseSignal{
+			Type:     AbuseSignalIdenticalPrompts,
+			Evidence: fmt.Sprintf("一小时内相同请求内容 %d 次", stats.IdenticalPrompts),
+		})
+	}
+
+	if ipThreshold > 0 && stats.DistinctIPs >= ipThreshold {
+		signals = append(signals, AbuseSignal{
+			Type:     AbuseSignalProxyLike,
+			Evidence: fmt.Sprintf("一小时内请求来源 IP %d 个", stats.DistinctIPs),
+		})
+	}
+
+	if len(stats.Intervals) >= abuseTimingMinSamples {
+		var sum float64
+		for _, v := range stats.Intervals {
+			sum += float64(v)
+		}
+
+		mean := sum / float64(len(stats.Intervals))
+
+		var variance float64
+		for _, v := range stats.Intervals {
+			variance += math.Pow(float64(v)-mean, 2)
+		}
+
+		if mean > 0 {
+			cv := math.Sqrt(variance/float64(len(stats.Intervals))) / mean
+			if cv < abuseTimingMaxCV {
+				signals = append(signals, AbuseSignal{
+					Type:     AbuseSignalScriptedTiming,
+					Evidence: fmt.Sprintf("最近 %d 次请求平均间隔 %.1f 秒，变异系数 %.3f", len(stats.Intervals)+1, mean/1000, cv),
+				})
+			}
+		}
+	}
+
+	return signals
+}
+
+// AbuseDetectService 异常使用行为检测，检测到异常时自动限流并进入人工审核队列
+type AbuseDetectService struct {
+	conf *config.Config   `autowire:"@"`
+	rep  *repo.Repository `autowire:"@"`
+	rds  *redis.Client    `autowire:"@"`
+}
+
+func NewAbuseDetectService(resolver infra.Resolver) *AbuseDetectService {
+	srv := &AbuseDetectService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+func (srv *AbuseDetectService) throttleKey(userID int64) string {
+	return fmt.Sprintf("abuse:throttle:%d", userID)
+}
+
+// Record 记录用户请求，并检测是否存在异常行为
+func (srv *AbuseDetectService) Record(ctx context.Context, userID int64, ip string, prompt string) {
+	if !srv.conf.EnableAbuseDetect {
+		return
+	}
+
+	stats, err := srv.record(ctx, userID, ip, prompt)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("record abuse stats failed: %v", err)
+		return
+	}
+
+	signals := DetectAbuse(stats, srv.conf.AbuseIdenticalPromptThreshold, srv.conf.AbuseDistinctIPThreshold)
+	if len(signals) == 0 {
+		return
+	}
+
+	// 自动限流，限流期间只触发一次人工审核
+	created, err := srv.rds.SetNX(ctx, srv.throttleKey(userID), "1", srv.conf.AbuseThrottleDuration).Result()
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("throttle abuse user failed: %v", err)
+		return
+	}
+
+	if !created {
+		return
+	}
+
+	evidence, _ := json.Marshal(map[string]any{"signals": signals, "stats": stats, "ip": ip})
+	types := array.Map(signals, func(s AbuseSignal, _ int) string { return s.Type })
+	if _, err := srv.rep.Abuse.AddFlag(ctx, userID, types, string(evidence)); err != nil {
+		log.F(log.M{"user_id": userID, "signals": types}).Errorf("add abuse flag failed: %v", err)
+		return
+	}
+
+	log.F(log.M{"user_id": userID, "signals": types}).Warningf("用户 %d 存在异常使用行为，已自动限流", userID)
+}
+
+func (srv *AbuseDetectService) record(ctx context.Context, userID int64, ip string, prompt string) (AbuseStats, error) {
+	hour := time.Now().Format("2006010215")
+	promptKey := fmt.Sprintf("abuse:prompts:%d:%s", userID, hour)
+	ipKey := fmt.Sprintf("abuse:ips:%d:%s", userID, hour)
+	timingKey := fmt.Sprintf("abuse:timing:%d", userID)
+
+	pipe := srv.rds.TxPipeline()
+	promptCount := pipe.HIncrBy(ctx, promptKey, fmt.Sprintf("%x", md5.Sum([]byte(prompt))), 1)
+	pipe.Expire(ctx, promptKey, 2*time.Hour)
+	if ip != "" {
+		pipe.SAdd(ctx, ipKey, ip)
+		pipe.Expire(ctx, ipKey, 2*time.Hour)
+	}
+	ipCount := pipe.SCard(ctx, ipKey)
+	pipe.LPush(ctx, timingKey, time.Now().UnixMilli())
+	pipe.LTrim(ctx, timingKey, 0, abuseTimingSamples-1)
+	pipe.Expire(ctx, timingKey, time.Hour)
+	timing := pipe.LRange(ctx, timingKey, 0, -1)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return AbuseStats{}, err
+	}
+
+	stats := AbuseStats{IdenticalPrompts: promptCount.Val(), DistinctIPs: ipCount.Val()}
+
+	times := timing.Val()
+	for i := 1; i < len(times); i++ {
+		prev, _ := strconv.ParseInt(times[i-1], 10, 64)
+		cur, _ := strconv.ParseInt(times[i], 10, 64)
+		stats.Intervals = append(stats.Intervals, prev-cur)
+	}
+
+	return stats, nil
+}
+
+// Throttled 用户是否因为异常行为被限流
+func (srv *AbuseDetectService) Throttled(ctx context.Context, userID int64) bool {
+	if !srv.conf.EnableAbuseDetect {
+		return false
+	}
+
+	n, err := srv.rds.Exists(ctx, srv.throttleKey(userID)).Result()
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("check abuse throttle failed: %v", err)
+		return false
+	}
+
+	return n > 0
+}
+
+// Throttle 对用户进行异常行为限流，用于人工审核确认异常后延长限流时间
+func (srv *AbuseDetectService) Throttle(ctx context.Context, userID int64) error {
+	return srv.rds.Set(ctx, srv.throttleKey(userID), "1", srv.conf.AbuseThrottleDuration).Err()
+}
+
+// Unthrottle 解除用户的异常行为限流，用于人工审核确认为误报的情况
+func (srv *AbuseDetectService) Unthrottle(ctx context.Context, userID int64) error {
+	return srv.rds.Del(ctx, srv.throttleKey(userID)).Err()
+}
@@ -0,0 +1,34 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestDetectAbuse(t *testing.T) {
+	assert.Equal(t, 0, len(service.DetectAbuse(service.AbuseStats{IdenticalPrompts: 10, DistinctIPs: 2}, 200, 20)))
+
+	signals := service.DetectAbuse(service.AbuseStats{IdenticalPrompts: 200, DistinctIPs: 25}, 200, 20)
+	assert.Equal(t, 2, len(signals))
+	assert.Equal(t, service.AbuseSignalIdenticalPrompts, signals[0].Type)
+	assert.Equal(t, service.AbuseSignalProxyLike, signals[1].Type)
+
+	// 请求间隔高度规律
+	scripted := make([]int64, 0)
+	human := make([]int64, 0)
+	for i := 0; i < 40; i++ {
+		scripted = append(scripted, 3000+int64(i%3)*10)
+		human = append(human, 2000+int64(i%7)*3000)
+	}
+
+	signals = service.DetectAbuse(service.AbuseStats{Intervals: scripted}, 200, 20)
+	assert.Equal(t, 1, len(signals))
+	assert.Equal(t, service.AbuseSignalScriptedTiming, signals[0].Type)
+
+	assert.Equal(t, 0, len(service.DetectAbuse(service.AbuseStats{Intervals: human}, 200, 20)))
+
+	// 样本数量不足时不判断请求间隔
+	assert.Equal(t, 0, len(service.DetectAbuse(service.AbuseStats{Intervals: scripted[:10]}, 200, 20)))
+}
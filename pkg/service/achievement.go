@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/achievement"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+// AchievementService 成就系统：用户满足条件时解锁成就并奖励少量智慧果
+type AchievementService struct {
+	rep *repo.Repository `autowire:"@"`
+}
+
+func NewAchievementService(resolver infra.Resolver) *AchievementService {
+	svc := &AchievementService{}
+	resolver.MustAutoWire(svc)
+	return svc
+}
+
+// Check 检查用户是否有新解锁的成就，新解锁的成就会发放智慧果奖励，返回本次新解锁的成就
+func (svc *AchievementService) Check(ctx context.Context, userID int64) ([]achievement.Achievement, error) {
+	metrics, err := svc.rep.Achievement.Metrics(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked := make([]achievement.Achievement, 0)
+	for _, a := range achievement.Evaluate(*metrics) {
+		ok, err := svc.rep.Achievement.Unlock(ctx, userID, a)
+		if err != nil {
+			return unlocked, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		unlocked = append(unlocked, a)
+
+		if a.Reward > 0 {
+			if _, err := svc.rep.Quota.AddUserQuota(ctx, userID, a.Reward, time.Now().AddDate(0, 1, 0), "成就奖励："+a.Title, ""); err != nil {
+				log.F(log.M{"user_id": userID, "achievement": a.Key}).Errorf("成就奖励发放失败: %s", err)
+			}
+		}
+	}
+
+	return unlocked, nil
+}
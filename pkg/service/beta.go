@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// betaReloadInterval 内测分组的重新加载周期
+const betaReloadInterval = time.Minute
+
+// BetaGrant 用户通过内测分组获得的内测功能与模型
+type BetaGrant struct {
+	Features []string `json:"features"`
+	Models   []string `json:"models"`
+}
+
+// betaModelIn 模型是否在列表中，支持 Model.ID 与不带分类前缀的模型 ID
+func betaModelIn(model string, models []string) bool {
+	for _, m := range models {
+		if m == model {
+			return true
+		}
+
+		if segs := strings.SplitN(m, ":", 2); len(segs) == 2 && segs[1] == model {
+			return true
+		}
+	}
+
+	return false
+}
+
+// betaAllowed 不在任何内测分组中的功能或者模型对全部用户开放，否则只对包含它的分组的成员开放
+func betaAllowed(cohorts []repo.BetaCohort, memberOf []int64, contains func(cohort repo.BetaCohort) bool) bool {
+	gated := false
+	for _, cohort := range cohorts {
+		if !contains(cohort) {
+			continue
+		}
+
+		if array.In(cohort.ID, memberOf) {
+			return true
+		}
+
+		gated = true
+	}
+
+	return !gated
+}
+
+// BetaFeatureAllowed 用户是否可以使用该功能，cohorts 为启用的内测分组，memberOf 为用户加入的分组
+func BetaFeatureAllowed(cohorts []repo.BetaCohort, memberOf []int64, feature string) bool {
+	return betaAllowed(cohorts, memberOf, func(cohort repo.BetaCohort) bool { return array.In(feature, cohort.Features) })
+}
+
+// BetaModelAllowed 用户是否可以使用该模型，cohorts 为启用的内测分组，memberOf 为用户加入的分组
+func BetaModelAllowed(cohorts []repo.BetaCohort, memberOf []int64, model string) bool {
+	return betaAllowed(cohorts, memberOf, func(cohort repo.BetaCohort) bool { return betaModelIn(model, cohort.Models) })
+}
+
+// BetaGrantOf 用户加入的启用的分组中包含的全部内测功能与模型
+func BetaGrantOf(cohorts []repo.BetaCohort, memberOf []int64) BetaGrant {
+	grant := BetaGrant{Features: []string{}, Models: []string{}}
+	for _, cohort := range cohorts {
+		if !array.In(cohort.ID, memberOf) {
+			continue
+		}
+
+		for _, f := range cohort.Features {
+			if !array.In(f, grant.Features) {
+				grant.Features = append(grant.Features, f)
+			}
+		}
+
+		for _, m := range cohort.Models {
+			if !array.In(m, grant.Models) {
+				grant.Models = append(grant.Models, m)
+			}
+		}
+	}
+
+	return grant
+}
+
+// BetaService 内测计划：启用的内测分组中的功能与模型只对分组成员开放，分组缓存在内存中定期重新加载
+type BetaService struct {
+	rep *repo.Repository `autowire:"@"`
+
+	lock     sync.RWMutex
+	cohorts  []repo.BetaCohort
+	loadedAt time.Time
+}
+
+func NewBetaService(resolver infra.Resolver) *BetaService {
+	srv := &BetaService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Reload 从数据库重新加载启用的内测分组
+func (srv *BetaService) Reload(ctx context.Context) error {
+	cohorts, err := srv.rep.Beta.Cohorts(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.cohorts, srv.loadedAt = cohorts, time.Now()
+	return nil
+}
+
+// Cohorts 启用的内测分组
+func (srv *BetaService) Cohorts(ctx context.Context) []repo.BetaCohort {
+	srv.lock.RLock()
+	loadedAt := srv.loadedAt
+	srv.lock.RUnlock()
+
+	if time.Since(loadedAt) >= betaReloadInterval {
+		if err := srv.Reload(ctx); err != nil {
+			log.Errorf("reload beta cohorts failed: %v", err)
+
+			// 加载失败时继续使用旧的分组，避免每次请求都访问数据库
+			srv.lock.Lock()
+			srv.loadedAt = time.Now()
+			srv.lock.Unlock()
+		}
+	}
+
+	srv.lock.RLock()
+	defer srv.lock.RUnlock()
+
+	return srv.cohorts
+}
+
+// memberOf 用户加入的内测分组，查询失败时按照未加入任何分组处理
+func (srv *BetaService) memberOf(ctx context.Context, userID int64) []int64 {
+	if userID <= 0 {
+		return nil
+	}
+
+	ids, err := srv.rep.Beta.CohortIDs(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("query beta cohorts of user failed: %v", err)
+		return nil
+	}
+
+	return ids
+}
+
+// FeatureAllowed 用户是否可以使用该功能，功能不在任何内测分组中时不查询用户的分组
+func (srv *BetaService) FeatureAllowed(ctx context.Context, userID int64, feature string) bool {
+	cohorts := srv.Cohorts(ctx)
+	if BetaFeatureAllowed(cohorts, nil, feature) {
+		return true
+	}
+
+	return BetaFeatureAllowed(cohorts, srv.memberOf(ctx, userID), feature)
+}
+
+// ModelAllowed 用户是否可以使用该模型，模型不在任何内测分组中时不查询用户的分组
+func (srv *BetaService) ModelAllowed(ctx context.Context, userID int64, model string) bool {
+	cohorts := srv.Cohorts(ctx)
+	if BetaModelAllowed(cohorts, nil, model) {
+		return true
+	}
+
+	return BetaModelAllowed(cohorts, srv.memberOf(ctx, userID), model)
+}
+
+// VisibleModels 从模型列表中去掉用户无权使用的内测模型，userID 为 0 表示未登录用户
+func (srv *BetaService) VisibleModels(ctx context.Context, userID int64, models []chat.Model) []chat.Model {
+	cohorts := srv.Cohorts(ctx)
+	if len(cohorts) == 0 {
+		return models
+	}
+
+	// 列表中没有内测模型时不查询用户的分组
+	var memberOf []int64
+	for _, m := range models {
+		if !BetaModelAllowed(cohorts, nil, m.ID) {
+			memberOf = srv.memberOf(ctx, userID)
+			break
+		}
+	}
+
+	return array.Filter(models, func(m chat.Model, _ int) bool { return BetaModelAllowed(cohorts, memberOf, m.ID) })
+}
+
+// Grant 用户通过内测分组获得的内测功能与模型
+func (srv *BetaService) Grant(ctx context.Context, userID int64) BetaGrant {
+	cohorts := srv.Cohorts(ctx)
+	if len(cohorts) == 0 {
+		return BetaGrantOf(nil, nil)
+	}
+
+	return BetaGrantOf(cohorts, srv.memberOf(ctx, userID))
+}
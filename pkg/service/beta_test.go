@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestBetaAllowed(t *testing.T) {
+	cohorts := []repo.BetaCohort{
+		{ID: 1, Features: []string{repo.BetaFeatureTutor}, Models: []string{"openai:gpt-4-turbo"}},
+		{ID: 2, Features: []string{repo.BetaFeatureTutor, repo.BetaFeatureMockInterview}},
+	}
+
+	// 不在任何内测分组中的功能与模型对全部用户开放
+	assert.True(t, service.BetaFeatureAllowed(cohorts, nil, repo.BetaFeatureStudy))
+	assert.True(t, service.BetaModelAllowed(cohorts, nil, "gpt-3.5-turbo"))
+
+	assert.False(t, service.BetaFeatureAllowed(cohorts, nil, repo.BetaFeatureTutor))
+	assert.True(t, service.BetaFeatureAllowed(cohorts, []int64{2}, repo.BetaFeatureTutor))
+	assert.False(t, service.BetaFeatureAllowed(cohorts, []int64{1}, repo.BetaFeatureMockInterview))
+
+	// 支持带分类前缀以及不带分类前缀的模型 ID
+	assert.False(t, service.BetaModelAllowed(cohorts, []int64{2}, "gpt-4-turbo"))
+	assert.True(t, service.BetaModelAllowed(cohorts, []int64{1}, "gpt-4-turbo"))
+	assert.True(t, service.BetaModelAllowed(cohorts, []int64{1}, "openai:gpt-4-turbo"))
+
+	assert.True(t, service.BetaFeatureAllowed(nil, nil, repo.BetaFeatureTutor))
+}
+
+func TestBetaGrantOf(t *testing.T) {
+	cohorts := []repo.BetaCohort{
+		{ID: 1, Features: []string{repo.BetaFeatureTutor}, Models: []string{"openai:gpt-4-turbo"}},
+		{ID: 2, Features: []string{repo.BetaFeatureTutor, repo.BetaFeatureMockInterview}, Models: []string{}},
+		{ID: 3, Features: []string{repo.BetaFeatureStudy}},
+	}
+
+	grant := service.BetaGrantOf(cohorts, []int64{1, 2})
+	assert.EqualValues(t, []string{repo.BetaFeatureTutor, repo.BetaFeatureMockInterview}, grant.Features)
+	assert.EqualValues(t, []string{"openai:gpt-4-turbo"}, grant.Models)
+
+	empty := service.BetaGrantOf(nil, nil)
+	assert.Equal(t, 0, len(empty.Features))
+	assert.Equal(t, 0, len(empty.Models))
+}
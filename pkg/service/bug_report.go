@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/trace"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// bugReportRequestTTL 请求记录的保留时间，超过该时间的请求无法在问题报告中关联
+	bugReportRequestTTL = 24 * time.Hour
+	// bugReportMaxRequestIDs 每个问题报告最多关联的请求数量
+	bugReportMaxRequestIDs = 20
+	// bugReportMaxDeviceFields 设备信息最多保留的字段数量
+	bugReportMaxDeviceFields = 30
+	// bugReportMaxDeviceValueLength 设备信息每个字段的最大长度
+	bugReportMaxDeviceValueLength = 200
+	// bugReportMaxErrorLength 客户端错误信息的最大长度
+	bugReportMaxErrorLength = 5000
+)
+
+// ErrBugReportEmpty 问题报告中没有任何有效信息
+var ErrBugReportEmpty = errors.New("bug report is empty")
+
+// RequestSummary 一次请求的处理结果摘要，用于问题报告中关联客户端上报的请求
+type RequestSummary struct {
+	UserID    int64     `json:"user_id"`
+	Method    string    `json:"method"`
+	Route     string    `json:"route"`
+	Code      int       `json:"code"`
+	Elapse    int64     `json:"elapse"`
+	Model     string    `json:"model,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BugReportSubmit 客户端提交的问题报告
+type BugReportSubmit struct {
+	Subject         string
+	Description     string
+	AppVersion      string
+	Platform        string
+	PlatformVersion string
+	Device          map[string]string
+	ClientError     string
+	RequestIDs      []string
+}
+
+// BugReportService 客户端问题报告，将客户端上报的请求与服务端的请求记录、异步任务关联后提交到客服工单
+type BugReportService struct {
+	rep *repo.Repository `autowire:"@"`
+	rds *redis.Client    `autowire:"@"`
+}
+
+func NewBugReportService(resolver infra.Resolver) *BugReportService {
+	srv := &BugReportService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+func (srv *BugReportService) requestKey(requestID string) string {
+	return fmt.Sprintf("bug-report:request:%s", requestID)
+}
+
+// RecordRequest 记录登录用户的请求摘要，记录失败时不影响请求
+func (srv *BugReportService) RecordRequest(ctx context.Context, requestID string, summary RequestSummary) {
+	if requestID == "" || summary.UserID <= 0 {
+		return
+	}
+
+	data, _ := json.Marshal(summary)
+	if err := srv.rds.Set(ctx, srv.requestKey(requestID), string(data), bugReportRequestTTL).Err(); err != nil {
+		log.F(log.M{"request_id": requestID, "user_id": summary.UserID}).Warningf("record request summary failed: %v", err)
+	}
+}
+
+// Submit 保存问题报告并创建客服工单，返回工单 ID
+func (srv *BugReportService) Submit(ctx context.Context, userID int64, req BugReportSubmit) (int64, error) {
+	requestIDs := NormalizeBugReportRequestIDs(req.RequestIDs)
+	clientError := misc.SubString(strings.TrimSpace(req.ClientError), bugReportMaxErrorLength)
+	if len(requestIDs) == 0 && clientError == "" && strings.TrimSpace(req.Description) == "" {
+		return 0, ErrBugReportEmpty
+	}
+
+	report := repo.BugReport{
+		UserID: userID,
+		// 截断后会追加省略号，需要预留数据库字段的长度
+		AppVersion:      misc.SubString(req.AppVersion, 27),
+		Platform:        misc.SubString(req.Platform, 27),
+		PlatformVersion: misc.SubString(req.PlatformVersion, 97),
+		Device:          NormalizeBugReportDevice(req.Device),
+		ClientError:     clientError,
+		RequestIDs:      requestIDs,
+		Requests:        srv.correlate(ctx, userID, requestIDs),
+	}
+
+	reportID, err := srv.rep.BugReport.CreateReport(ctx, report)
+	if err != nil {
+		return 0, fmt.Errorf("create bug report failed: %w", err)
+	}
+
+	subject := strings.TrimSpace(req.Subject)
+	if subject == "" {
+		subject = "客户端问题报告"
+	}
+
+	content := strings.TrimSpace(req.Description)
+	if content == "" {
+		content = ternary.If(clientError != "", misc.SubString(clientError, 500), "客户端自动提交的问题报告")
+	}
+
+	ticketID, err := srv.rep.SupportTicket.CreateTicket(ctx, userID, repo.SupportTicketCreate{
+		Subject:        misc.SubString(subject, 100),
+		Category:       repo.SupportTicketAttachmentBugReport,
+		Content:        content,
+		AttachmentType: repo.SupportTicketAttachmentBugReport,
+		AttachmentID:   fmt.Sprintf("%d", reportID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("create bug report ticket failed: %w", err)
+	}
+
+	return ticketID, nil
+}
+
+// correlate 查询客户端上报的请求在服务端的处理结果，以及请求创建的异步任务，只关联属于当前用户的请求
+func (srv *BugReportService) correlate(ctx context.Context, userID int64, requestIDs []string) []repo.BugReportRequest {
+	since := time.Now().Add(-bugReportRequestTTL)
+	requests := make([]repo.BugReportRequest, 0, len(requestIDs))
+	for _, id := range requestIDs {
+		item := repo.BugReportRequest{RequestID: id}
+
+		data, err := srv.rds.Get(ctx, srv.requestKey(id)).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			log.F(log.M{"request_id": id}).Warningf("query request summary failed: %v", err)
+		}
+
+		var summary RequestSummary
+		if data != "" && json.Unmarshal([]byte(data), &summary) == nil && summary.UserID == userID {
+			item.Found = true
+			item.Method = summary.Method
+			item.Route = summary.Route
+			item.Code = summary.Code
+			item.Elapse = summary.Elapse
+			item.Model = summary.Model
+			item.CreatedAt = &summary.CreatedAt
+		}
+
+		tasks, err := srv.rep.Queue.TasksByRequestID(ctx, userID, id, since)
+		if err != nil {
+			log.F(log.M{"request_id": id}).Warningf("query request tasks failed: %v", err)
+		}
+
+		for _, task := range tasks {
+			logs, err := srv.rep.Queue.Logs(ctx, task.TaskId)
+			if err != nil {
+				log.F(log.M{"request_id": id, "task_id": task.TaskId}).Warningf("query task logs failed: %v", err)
+			}
+
+			item.Found = true
+			item.Tasks = append(item.Tasks, repo.BugReportTask{
+				TaskID:    task.TaskId,
+				TaskType:  task.TaskType,
+				Status:    task.Status,
+				CreatedAt: task.CreatedAt,
+				Logs:      logs,
+			})
+		}
+
+		requests = append(requests, item)
+	}
+
+	return requests
+}
+
+// NormalizeBugReportRequestIDs 去除无效以及重复的请求 ID，只保留最近的请求
+func NormalizeBugReportRequestIDs(ids []string) []string {
+	ids = array.Uniq(array.Filter(
+		array.Map(ids, func(id string, _ int) string { return strings.TrimSpace(id) }),
+		func(id string, _ int) bool { return trace.ValidRequestID(id) },
+	))
+
+	if len(ids) > bugReportMaxRequestIDs {
+		ids = ids[len(ids)-bugReportMaxRequestIDs:]
+	}
+
+	return ids
+}
+
+// NormalizeBugReportDevice 限制设备信息的字段数量与长度，避免客户端写入大量数据
+func NormalizeBugReportDevice(device map[string]string) map[string]string {
+	res := make(map[string]string)
+	for k, v := range device {
+		if len(res) >= bugReportMaxDeviceFields {
+			break
+		}
+
+		k = strings.TrimSpace(k)
+		if k == "" || len(k) > 50 {
+			continue
+		}
+
+		res[k] = misc.SubString(strings.TrimSpace(v), bugReportMaxDeviceValueLength)
+	}
+
+	return res
+}
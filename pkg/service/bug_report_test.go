@@ -0,0 +1,38 @@
+package service_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestNormalizeBugReportRequestIDs(t *testing.T) {
+	ids := service.NormalizeBugReportRequestIDs([]string{" abc ", "abc", "", "bad id", "x\n{}", "def"})
+	assert.EqualValues(t, []string{"abc", "def"}, ids)
+
+	var many []string
+	for i := 0; i < 30; i++ {
+		many = append(many, fmt.Sprintf("req-%d", i))
+	}
+
+	ids = service.NormalizeBugReportRequestIDs(many)
+	assert.Equal(t, 20, len(ids))
+	assert.Equal(t, "req-10", ids[0])
+	assert.Equal(t, "req-29", ids[19])
+}
+
+func TestNormalizeBugReportDevice(t *testing.T) {
+	device := service.NormalizeBugReportDevice(map[string]string{
+		"model":                 "iPhone 15",
+		"":                      "empty",
+		strings.Repeat("k", 51): "long key",
+		"note":                  strings.Repeat("a", 300),
+	})
+
+	assert.Equal(t, 2, len(device))
+	assert.Equal(t, "iPhone 15", device["model"])
+	assert.Equal(t, 203, len(device["note"]))
+}
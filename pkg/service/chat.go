@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/rate"
 	"github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/glacier/infra"
 	"github.com/mylxsw/go-utils/must"
 	"github.com/redis/go-redis/v9"
@@ -26,7 +28,7 @@ func NewChatService(resolver infra.Resolver) *ChatService {
 }
 
 func (svc *ChatService) Room(ctx context.Context, userID int64, roomID int64) (*model.Rooms, error) {
-	roomKey := fmt.Sprintf("chat-room:%d:%d:info", userID, roomID)
+	roomKey := roomCacheKey(userID, roomID)
 	if roomStr, err := svc.rds.Get(ctx, roomKey).Result(); err == nil {
 		var room model.Rooms
 		if err := json.Unmarshal([]byte(roomStr), &room); err == nil {
@@ -45,3 +47,33 @@ func (svc *ChatService) Room(ctx context.Context, userID int64, roomID int64) (*
 
 	return room, nil
 }
+
+func roomCacheKey(userID, roomID int64) string {
+	return fmt.Sprintf("chat-room:%d:%d:info", userID, roomID)
+}
+
+// RoomIncognito 房间是否开启了无痕模式，查询失败时按照无痕模式处理，避免保存用户不希望保存的聊天记录
+func (svc *ChatService) RoomIncognito(ctx context.Context, userID, roomID int64) bool {
+	if roomID <= 1 {
+		return false
+	}
+
+	room, err := svc.Room(ctx, userID, roomID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return false
+		}
+
+		log.F(log.M{"user_id": userID, "room_id": roomID}).Errorf("query room failed: %v", err)
+		return true
+	}
+
+	return room.Incognito == 1
+}
+
+// ForgetRoom 清除会话信息缓存，修改会话设置后调用
+func (svc *ChatService) ForgetRoom(ctx context.Context, userID, roomID int64) {
+	if err := svc.rds.Del(ctx, roomCacheKey(userID, roomID)).Err(); err != nil {
+		log.F(log.M{"user_id": userID, "room_id": roomID}).Errorf("delete room cache failed: %v", err)
+	}
+}
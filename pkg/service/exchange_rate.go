@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+const (
+	// exchangeRateCacheKey 人民币汇率的缓存 Key，由定时任务刷新，多个实例共享
+	exchangeRateCacheKey = "exchange-rates:CNY"
+	// exchangeRateCacheTTL 汇率缓存的有效期，刷新失败时在有效期内继续使用旧的汇率
+	exchangeRateCacheTTL = 7 * 24 * time.Hour
+	// exchangeRateLocalTTL 实例内存中汇率的有效期，避免每次请求都查询缓存
+	exchangeRateLocalTTL = 10 * time.Minute
+)
+
+// ExchangeRates 人民币汇率，Rates 为 1 人民币可以兑换的各货币数量
+type ExchangeRates struct {
+	Rates     map[string]float64 `json:"rates"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// ExchangeRateService 汇率服务，用于将人民币价格换算为海外用户看到的展示价格
+type ExchangeRateService struct {
+	conf   *config.Config   `autowire:"@"`
+	rep    *repo.Repository `autowire:"@"`
+	client *http.Client
+
+	lock     sync.RWMutex
+	rates    *ExchangeRates
+	loadedAt time.Time
+}
+
+func NewExchangeRateService(resolver infra.Resolver) *ExchangeRateService {
+	srv := &ExchangeRateService{client: &http.Client{Timeout: 30 * time.Second}}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Rates 返回当前的人民币汇率，汇率尚未加载或者加载失败时返回空的汇率表
+func (srv *ExchangeRateService) Rates(ctx context.Context) map[string]float64 {
+	srv.lock.RLock()
+	if srv.rates != nil && time.Since(srv.loadedAt) < exchangeRateLocalTTL {
+		defer srv.lock.RUnlock()
+		return srv.rates.Rates
+	}
+	srv.lock.RUnlock()
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	if srv.rates != nil && time.Since(srv.loadedAt) < exchangeRateLocalTTL {
+		return srv.rates.Rates
+	}
+
+	data, err := srv.rep.Cache.Get(ctx, exchangeRateCacheKey)
+	if err != nil {
+		if err == repo.ErrNotFound {
+			// 汇率还没有被定时任务刷新过（例如首次部署），立即查询一次
+			go func() {
+				if err := srv.Refresh(context.Background()); err != nil {
+					log.Warningf("refresh exchange rates failed: %v", err)
+				}
+			}()
+		} else {
+			log.Warningf("load exchange rates failed: %v", err)
+		}
+
+		// 加载失败时继续使用内存中已有的汇率，稍后再重试
+		srv.loadedAt = time.Now()
+		if srv.rates == nil {
+			srv.rates = &ExchangeRates{Rates: map[string]float64{}}
+		}
+
+		return srv.rates.Rates
+	}
+
+	var rates ExchangeRates
+	if err := json.Unmarshal([]byte(data), &rates); err != nil {
+		log.Warningf("decode exchange rates failed: %v", err)
+		srv.rates, srv.loadedAt = &ExchangeRates{Rates: map[string]float64{}}, time.Now()
+		return srv.rates.Rates
+	}
+
+	srv.rates, srv.loadedAt = &rates, time.Now()
+	return rates.Rates
+}
+
+// Refresh 从汇率接口查询最新的人民币汇率并写入缓存
+func (srv *ExchangeRateService) Refresh(ctx context.Context) error {
+	if srv.conf.ExchangeRateAPI == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.conf.ExchangeRateAPI, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := srv.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request exchange rate api failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request exchange rate api failed: status %d", resp.StatusCode)
+	}
+
+	var ret struct {
+		Result   string             `json:"result"`
+		BaseCode string             `json:"base_code"`
+		Rates    map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ret); err != nil {
+		return fmt.Errorf("decode exchange rate response failed: %w", err)
+	}
+
+	if ret.Result != "success" || ret.BaseCode != "CNY" || len(ret.Rates) == 0 {
+		return fmt.Errorf("invalid exchange rate response: result=%s, base=%s", ret.Result, ret.BaseCode)
+	}
+
+	rates := ExchangeRates{Rates: ret.Rates, UpdatedAt: time.Now()}
+	data, _ := json.Marshal(rates)
+	if err := srv.rep.Cache.Set(ctx, exchangeRateCacheKey, string(data), exchangeRateCacheTTL); err != nil {
+		return fmt.Errorf("save exchange rates failed: %w", err)
+	}
+
+	srv.lock.Lock()
+	srv.rates, srv.loadedAt = &rates, time.Now()
+	srv.lock.Unlock()
+
+	return nil
+}
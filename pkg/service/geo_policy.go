@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// geoPolicyReloadInterval 地区策略的重新加载周期
+const geoPolicyReloadInterval = time.Minute
+
+// GeoDecision 地区策略的决策结果
+type GeoDecision struct {
+	// Region 请求来源地区
+	Region string `json:"region"`
+	// Policy 生效的策略地区，没有匹配的策略时为空
+	Policy string `json:"policy,omitempty"`
+	// Blocked 当前功能在该地区是否被禁止使用
+	Blocked bool `json:"blocked,omitempty"`
+	// Model 路由后实际使用的模型
+	Model string `json:"model,omitempty"`
+	// Routed 模型是否被路由到了其它模型
+	Routed bool `json:"routed,omitempty"`
+}
+
+// ResolveGeoPolicy 根据地区策略计算请求的决策结果，优先使用地区自己的策略，没有时使用默认策略
+func ResolveGeoPolicy(policies map[string]repo.GeoPolicy, region, feature, model string) GeoDecision {
+	decision := GeoDecision{Region: region, Model: model}
+
+	policy, ok := policies[region]
+	if !ok || region == "" {
+		if policy, ok = policies[repo.GeoRegionDefault]; !ok {
+			return decision
+		}
+	}
+
+	decision.Policy = policy.Region
+	decision.Blocked = array.In(feature, policy.BlockedFeatures)
+
+	if routed, ok := policy.ModelRoutes[model]; ok && routed != "" && routed != model {
+		decision.Model, decision.Routed = routed, true
+	}
+
+	return decision
+}
+
+// GeoPolicyService 地区访问策略，根据请求来源地区限制功能的使用，并将请求路由到该地区合规的模型
+type GeoPolicyService struct {
+	rep *repo.Repository `autowire:"@"`
+
+	lock     sync.RWMutex
+	policies map[string]repo.GeoPolicy
+	loadedAt time.Time
+}
+
+func NewGeoPolicyService(resolver infra.Resolver) *GeoPolicyService {
+	srv := &GeoPolicyService{policies: map[string]repo.GeoPolicy{}}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Reload 从数据库重新加载地区策略
+func (srv *GeoPolicyService) Reload(ctx context.Context) error {
+	items, err := srv.rep.GeoPolicy.Policies(ctx)
+	if err != nil {
+		return err
+	}
+
+	policies := make(map[string]repo.GeoPolicy, len(items))
+	for _, item := range items {
+		policies[item.Region] = item
+	}
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.policies, srv.loadedAt = policies, time.Now()
+	return nil
+}
+
+func (srv *GeoPolicyService) currentPolicies(ctx context.Context) map[string]repo.GeoPolicy {
+	srv.lock.RLock()
+	loadedAt := srv.loadedAt
+	srv.lock.RUnlock()
+
+	if time.Since(loadedAt) >= geoPolicyReloadInterval {
+		if err := srv.Reload(ctx); err != nil {
+			log.Errorf("reload geo policies failed: %v", err)
+
+			// 加载失败时继续使用旧的策略，避免每次请求都访问数据库
+			srv.lock.Lock()
+			srv.loadedAt = time.Now()
+			srv.lock.Unlock()
+		}
+	}
+
+	srv.lock.RLock()
+	defer srv.lock.RUnlock()
+
+	return srv.policies
+}
+
+// Decide 根据请求来源地区计算功能是否可用以及实际使用的模型，model 为空表示该功能与模型无关
+func (srv *GeoPolicyService) Decide(ctx context.Context, region, feature, model string) GeoDecision {
+	return ResolveGeoPolicy(srv.currentPolicies(ctx), region, feature, model)
+}
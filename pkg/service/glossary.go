@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+// GlossaryService 用户术语表：在翻译、写作以及聊天请求中使用用户指定的译法或者写法
+type GlossaryService struct {
+	rep *repo.Repository `autowire:"@"`
+}
+
+func NewGlossaryService(resolver infra.Resolver) *GlossaryService {
+	svc := &GlossaryService{}
+	resolver.MustAutoWire(svc)
+	return svc
+}
+
+// Match 查询用户术语表中出现在 texts 里的术语，查询失败时返回空
+func (svc *GlossaryService) Match(ctx context.Context, userID int64, texts ...string) []repo.GlossaryEntry {
+	entries, err := svc.rep.Glossary.Entries(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("query user glossary failed: %v", err)
+		return nil
+	}
+
+	return MatchGlossary(entries, texts...)
+}
+
+// MatchGlossary 筛选出现在 texts 中的术语（不区分大小写），只把用到的术语发送给模型，避免术语表较大时浪费上下文
+func MatchGlossary(entries []repo.GlossaryEntry, texts ...string) []repo.GlossaryEntry {
+	content := strings.ToLower(strings.Join(texts, "\n"))
+
+	matched := make([]repo.GlossaryEntry, 0)
+	for _, entry := range entries {
+		if entry.Term != "" && strings.Contains(content, strings.ToLower(entry.Term)) {
+			matched = append(matched, entry)
+		}
+	}
+
+	return matched
+}
+
+// GlossaryInstruction 要求模型使用术语表中的译法或者写法的系统提示语
+func GlossaryInstruction(entries []repo.GlossaryEntry) string {
+	var sb strings.Builder
+	sb.WriteString("Use the following terminology exactly as specified, whether translating or writing (term => required translation or spelling):")
+	for _, entry := range entries {
+		sb.WriteString("\n- " + entry.Term + " => " + entry.Replacement)
+		if entry.Note != "" {
+			sb.WriteString(" (" + entry.Note + ")")
+		}
+	}
+
+	return sb.String()
+}
+
+// ApplyGlossary 将用到的术语追加到系统消息中
+func ApplyGlossary(messages chat.Messages, entries []repo.GlossaryEntry) chat.Messages {
+	if len(entries) == 0 {
+		return messages
+	}
+
+	instruction := GlossaryInstruction(entries)
+	if len(messages) > 0 && messages[0].Role == "system" {
+		res := append(chat.Messages{}, messages...)
+		res[0].Content = res[0].Content + "\n\n" + instruction
+		return res
+	}
+
+	res := make(chat.Messages, 0, len(messages)+1)
+	res = append(res, chat.Message{Role: "system", Content: instruction})
+	return append(res, messages...)
+}
+
+// SubstituteGlossary 将原文中的术语替换为指定的译法，用于无法通过提示语控制的机器翻译，
+// 翻译服务通常会保留原文中已经是目标语言的内容。较长的术语优先替换，避免被其中包含的较短术语拆开
+func SubstituteGlossary(text string, entries []repo.GlossaryEntry) string {
+	sorted := append([]repo.GlossaryEntry{}, entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len([]rune(sorted[i].Term)) > len([]rune(sorted[j].Term))
+	})
+
+	patterns := make([]string, 0, len(sorted))
+	replacements := make(map[string]string)
+	for _, entry := range sorted {
+		if entry.Term == "" {
+			continue
+		}
+
+		patterns = append(patterns, regexp.QuoteMeta(entry.Term))
+		replacements[strings.ToLower(entry.Term)] = entry.Replacement
+	}
+
+	if len(patterns) == 0 {
+		return text
+	}
+
+	// 所有术语合并为一个正则表达式，保证替换后的内容不会被再次替换
+	re := regexp.MustCompile("(?i)" + strings.Join(patterns, "|"))
+	return re.ReplaceAllStringFunc(text, func(s string) string {
+		return replacements[strings.ToLower(s)]
+	})
+}
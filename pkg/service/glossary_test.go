@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+var glossaryEntries = []repo.GlossaryEntry{
+	{ID: 1, Term: "AIdea", Replacement: "AIdea"},
+	{ID: 2, Term: "smart fruit", Replacement: "智慧果", Note: "虚拟货币"},
+	{ID: 3, Term: "fruit", Replacement: "水果"},
+}
+
+func TestMatchGlossary(t *testing.T) {
+	matched := service.MatchGlossary(glossaryEntries, "Buy more Smart Fruit in aidea")
+	assert.Equal(t, 3, len(matched))
+
+	matched = service.MatchGlossary(glossaryEntries, "hello", "I like fruit")
+	assert.Equal(t, 1, len(matched))
+	assert.Equal(t, int64(3), matched[0].ID)
+
+	assert.Equal(t, 0, len(service.MatchGlossary(glossaryEntries, "nothing here")))
+}
+
+func TestSubstituteGlossary(t *testing.T) {
+	// 较长的术语优先，替换后的内容不会被再次替换
+	assert.Equal(t, "Buy 智慧果 or 水果", service.SubstituteGlossary("Buy Smart Fruit or fruit", glossaryEntries))
+	assert.Equal(t, "a+b (x)", service.SubstituteGlossary("a+b (x)", []repo.GlossaryEntry{{Term: "a.b", Replacement: "y"}}))
+	assert.Equal(t, "unchanged", service.SubstituteGlossary("unchanged", nil))
+}
+
+func TestApplyGlossary(t *testing.T) {
+	messages := chat.Messages{{Role: "user", Content: "hello"}}
+	assert.Equal(t, 1, len(service.ApplyGlossary(messages, nil)))
+
+	res := service.ApplyGlossary(messages, glossaryEntries[1:2])
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, "system", res[0].Role)
+	assert.True(t, strings.Contains(res[0].Content, "smart fruit => 智慧果 (虚拟货币)"))
+
+	messages = chat.Messages{{Role: "system", Content: "You are a translator"}, {Role: "user", Content: "hello"}}
+	res = service.ApplyGlossary(messages, glossaryEntries[1:2])
+	assert.Equal(t, 2, len(res))
+	assert.True(t, strings.HasPrefix(res[0].Content, "You are a translator\n\n"))
+	assert.Equal(t, "You are a translator", messages[0].Content)
+}
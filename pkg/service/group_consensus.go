@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrConsensusDisabled  = errors.New("group consensus is disabled")
+	ErrConsensusExists    = errors.New("group consensus is running or finished")
+	ErrConsensusNotEnough = errors.New("at least two answers are required")
+)
+
+// GroupConsensusService 群聊综合回答：将多个成员对同一问题的回答合并为一个综合回答，并标注每个观点的来源模型
+type GroupConsensusService struct {
+	conf *config.Config   `autowire:"@"`
+	ct   chat.Chat        `autowire:"@"`
+	rep  *repo.Repository `autowire:"@"`
+	rds  *redis.Client    `autowire:"@"`
+}
+
+func NewGroupConsensusService(resolver infra.Resolver) *GroupConsensusService {
+	svc := &GroupConsensusService{}
+	resolver.MustAutoWire(svc)
+	return svc
+}
+
+// Enabled 是否启用了综合回答
+func (svc *GroupConsensusService) Enabled() bool {
+	return svc.conf.GroupConsensusModel != ""
+}
+
+// Merge 合并问题下所有成功的回答，生成综合回答消息
+func (svc *GroupConsensusService) Merge(ctx context.Context, groupID, userID, questionID int64) (*model.ChatGroupMessage, error) {
+	if !svc.Enabled() {
+		return nil, ErrConsensusDisabled
+	}
+
+	grp, err := svc.rep.ChatGroup.GetGroup(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := svc.rep.ChatGroup.GetChatMessage(ctx, groupID, userID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := svc.rep.ChatGroup.GetAnswers(ctx, groupID, userID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers = array.Filter(answers, func(ans model.ChatGroupMessage, _ int) bool {
+		return ans.Status == repo.MessageStatusSucceed && strings.TrimSpace(ans.Message) != ""
+	})
+	if len(answers) < 2 {
+		return nil, ErrConsensusNotEnough
+	}
+
+	// 同一个问题同时只允许生成一个综合回答
+	lockKey := fmt.Sprintf("group-chat:%d:consensus:%d:lock", groupID, questionID)
+	locked, err := svc.rds.SetNX(ctx, lockKey, "1", 5*time.Minute).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if !locked {
+		return nil, ErrConsensusExists
+	}
+	defer svc.rds.Del(ctx, lockKey)
+
+	existed, err := svc.rep.ChatGroup.GetMessagesByRole(ctx, groupID, userID, questionID, repo.GroupMessageRoleConsensus)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(array.Filter(existed, func(msg model.ChatGroupMessage, _ int) bool { return msg.Status != repo.MessageStatusFailed })) > 0 {
+		return nil, ErrConsensusExists
+	}
+
+	messageID, err := svc.rep.ChatGroup.AddChatMessage(ctx, groupID, userID, repo.ChatGroupMessage{
+		Role:   int64(repo.GroupMessageRoleConsensus),
+		Pid:    questionID,
+		Status: repo.MessageStatusWaiting,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	membersMap := array.ToMap(grp.Members, func(mem model.ChatGroupMember, _ int) int64 { return mem.Id })
+	text, tokens, err := svc.merge(ctx, question.Message, answers, membersMap)
+
+	update := repo.ChatGroupMessageUpdate{Message: text, TokenConsumed: tokens, Status: repo.MessageStatusSucceed}
+	if err != nil {
+		update = repo.ChatGroupMessageUpdate{Message: err.Error(), Status: repo.MessageStatusFailed, Error: err.Error()}
+	} else if update.QuotaConsumed = coins.GetOpenAITextCoins(svc.conf.GroupConsensusModel, tokens); update.QuotaConsumed > 0 {
+		if err := svc.rep.Quota.QuotaConsume(ctx, userID, update.QuotaConsumed, repo.NewQuotaUsedMeta("group_consensus", svc.conf.GroupConsensusModel)); err != nil {
+			log.Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	if err := svc.rep.ChatGroup.UpdateChatMessage(ctx, groupID, userID, messageID, update); err != nil {
+		return nil, fmt.Errorf("update consensus message failed: %w", err)
+	}
+
+	return svc.rep.ChatGroup.GetChatMessage(ctx, groupID, userID, messageID)
+}
+
+func (svc *GroupConsensusService) merge(ctx context.Context, question string, answers []model.ChatGroupMessage, members map[int64]model.ChatGroupMember) (string, int64, error) {
+	req := chat.Request{
+		Model: svc.conf.GroupConsensusModel,
+		Messages: chat.Messages{
+			{Role: "system", Content: consensusSystemPrompt},
+			{Role: "user", Content: BuildConsensusPrompt(question, answers, members)},
+		},
+	}.Init()
+
+	chatCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+
+	resp, err := svc.ct.Chat(chatCtx, req)
+	if err != nil {
+		return "", 0, fmt.Errorf("consensus chat failed: %w", err)
+	}
+
+	if resp.ErrorCode != "" {
+		return "", 0, fmt.Errorf("consensus chat failed: %s %s", resp.ErrorCode, resp.Error)
+	}
+
+	tokens := int64(resp.InputTokens + resp.OutputTokens)
+	if tokens == 0 {
+		realTokens, _ := chat.MessageTokenCount(append(req.Messages, chat.Message{Role: "assistant", Content: resp.Text}), req.Model)
+		tokens = int64(realTokens)
+	}
+
+	return strings.TrimSpace(resp.Text), tokens, nil
+}
+
+const consensusSystemPrompt = `You merge several assistants' answers to the same question into one consolidated answer.
+Keep every correct and useful point, remove duplicates, resolve conflicts by explaining the different opinions, and attribute each point to its source models in square brackets, for example "... [GPT-4, Claude]".
+Reply in Markdown, in the same language as the question.`
+
+// BuildConsensusPrompt 构建合并回答的提示语，每个回答标注来源模型名称
+func BuildConsensusPrompt(question string, answers []model.ChatGroupMessage, members map[int64]model.ChatGroupMember) string {
+	var sb strings.Builder
+	sb.WriteString("Question:\n")
+	sb.WriteString(question)
+	for i, ans := range answers {
+		name := members[ans.MemberId].ModelName
+		if name == "" {
+			name = fmt.Sprintf("Assistant %d", i+1)
+		}
+
+		sb.WriteString(fmt.Sprintf("\n\n[Answer from %s]\n%s", name, ans.Message))
+	}
+
+	return sb.String()
+}
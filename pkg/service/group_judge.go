@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+var (
+	ErrJudgeDisabled    = errors.New("group judge is disabled")
+	ErrJudgeInProgress  = errors.New("group judge is running or finished")
+	ErrJudgeNotEnough   = errors.New("at least two answers are required")
+	ErrJudgeInvalidJSON = errors.New("invalid judge result")
+)
+
+// GroupJudgeService 群聊回答评审：多个成员回答同一个问题时，由评审模型为每个回答打分并选出最佳回答
+type GroupJudgeService struct {
+	conf *config.Config   `autowire:"@"`
+	ct   chat.Chat        `autowire:"@"`
+	rep  *repo.Repository `autowire:"@"`
+}
+
+func NewGroupJudgeService(resolver infra.Resolver) *GroupJudgeService {
+	svc := &GroupJudgeService{}
+	resolver.MustAutoWire(svc)
+	return svc
+}
+
+// JudgeScore 单个回答的评审结果
+type JudgeScore struct {
+	AnswerID int64  `json:"answer_id"`
+	Score    int64  `json:"score"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// JudgeResult 评审结果
+type JudgeResult struct {
+	BestID int64        `json:"best_id"`
+	Scores []JudgeScore `json:"scores"`
+}
+
+// Enabled 是否启用了回答评审
+func (svc *GroupJudgeService) Enabled() bool {
+	return svc.conf.GroupJudgeModel != ""
+}
+
+// Judge 对问题下的所有成功回答进行评审，同一个问题只会评审一次（评审失败后可以重试）
+func (svc *GroupJudgeService) Judge(ctx context.Context, groupID, userID, questionID int64) (*JudgeResult, error) {
+	if !svc.Enabled() {
+		return nil, ErrJudgeDisabled
+	}
+
+	question, err := svc.rep.ChatGroup.GetChatMessage(ctx, groupID, userID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := svc.rep.ChatGroup.GetAnswers(ctx, groupID, userID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers = array.Filter(answers, func(ans model.ChatGroupMessage, _ int) bool {
+		return ans.Status == repo.MessageStatusSucceed && strings.TrimSpace(ans.Message) != ""
+	})
+	if len(answers) < 2 {
+		return nil, ErrJudgeNotEnough
+	}
+
+	ok, err := svc.rep.ChatGroup.StartJudge(ctx, groupID, userID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrJudgeInProgress
+	}
+
+	result, err := svc.judge(ctx, userID, question.Message, answers)
+	if err != nil {
+		if err := svc.rep.ChatGroup.FinishJudge(ctx, questionID, nil, 0); err != nil {
+			log.F(log.M{"question_id": questionID}).Errorf("update judge status failed: %s", err)
+		}
+
+		return nil, err
+	}
+
+	scores := make(map[int64]int64)
+	for _, s := range result.Scores {
+		scores[s.AnswerID] = s.Score
+	}
+
+	if err := svc.rep.ChatGroup.FinishJudge(ctx, questionID, scores, result.BestID); err != nil {
+		return nil, fmt.Errorf("save judge result failed: %w", err)
+	}
+
+	return result, nil
+}
+
+func (svc *GroupJudgeService) judge(ctx context.Context, userID int64, question string, answers []model.ChatGroupMessage) (*JudgeResult, error) {
+	req := chat.Request{
+		Model: svc.conf.GroupJudgeModel,
+		Messages: chat.Messages{
+			{Role: "system", Content: judgeSystemPrompt},
+			{Role: "user", Content: BuildJudgePrompt(question, answers)},
+		},
+	}.Init()
+
+	chatCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+
+	resp, err := svc.ct.Chat(chatCtx, req)
+	if err != nil {
+		return nil, fmt.Errorf("judge chat failed: %w", err)
+	}
+
+	if resp.ErrorCode != "" {
+		return nil, fmt.Errorf("judge chat failed: %s %s", resp.ErrorCode, resp.Error)
+	}
+
+	tokens := int64(resp.InputTokens + resp.OutputTokens)
+	if tokens == 0 {
+		realTokens, _ := chat.MessageTokenCount(append(req.Messages, chat.Message{Role: "assistant", Content: resp.Text}), req.Model)
+		tokens = int64(realTokens)
+	}
+
+	if consumed := coins.GetOpenAITextCoins(req.ResolveCalFeeModel(svc.conf), tokens); consumed > 0 {
+		if err := svc.rep.Quota.QuotaConsume(ctx, userID, consumed, repo.NewQuotaUsedMeta("group_judge", req.Model)); err != nil {
+			log.Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	return ParseJudgeResult(resp.Text, answers)
+}
+
+const judgeSystemPrompt = `You are an impartial judge. Several assistants answered the same question. Evaluate each answer for correctness, helpfulness, completeness and clarity, score it from 0 to 10, and pick the best one. Do not let the order or length of the answers bias you.
+Output only a JSON object with this schema:
+{"scores": [{"index": 1, "score": 8, "reason": "short reason"}], "best": 1}`
+
+// BuildJudgePrompt 构建评审提示语，回答按照序号编号（从 1 开始）
+func BuildJudgePrompt(question string, answers []model.ChatGroupMessage) string {
+	var sb strings.Builder
+	sb.WriteString("Question:\n")
+	sb.WriteString(question)
+	for i, ans := range answers {
+		sb.WriteString(fmt.Sprintf("\n\n[Answer %d]\n%s", i+1, ans.Message))
+	}
+
+	return sb.String()
+}
+
+// ParseJudgeResult 解析评审模型的输出，将回答序号转换为回答 ID
+func ParseJudgeResult(text string, answers []model.ChatGroupMessage) (*JudgeResult, error) {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, ErrJudgeInvalidJSON
+	}
+
+	var raw struct {
+		Scores []struct {
+			Index  int    `json:"index"`
+			Score  int64  `json:"score"`
+			Reason string `json:"reason"`
+		} `json:"scores"`
+		Best int `json:"best"`
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrJudgeInvalidJSON, err)
+	}
+
+	result := JudgeResult{Scores: make([]JudgeScore, 0)}
+	var bestScore int64 = -1
+	for _, s := range raw.Scores {
+		if s.Index < 1 || s.Index > len(answers) {
+			continue
+		}
+
+		score := s.Score
+		if score < 0 {
+			score = 0
+		} else if score > 10 {
+			score = 10
+		}
+
+		answerID := answers[s.Index-1].Id
+		result.Scores = append(result.Scores, JudgeScore{AnswerID: answerID, Score: score, Reason: s.Reason})
+
+		// 模型没有给出有效的最佳回答时，使用得分最高的回答
+		if score > bestScore {
+			bestScore = score
+			result.BestID = answerID
+		}
+	}
+
+	if raw.Best >= 1 && raw.Best <= len(answers) {
+		result.BestID = answers[raw.Best-1].Id
+	}
+
+	if result.BestID == 0 {
+		return nil, ErrJudgeInvalidJSON
+	}
+
+	return &result, nil
+}
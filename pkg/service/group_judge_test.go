@@ -0,0 +1,28 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestParseJudgeResult(t *testing.T) {
+	answers := []model.ChatGroupMessage{{Id: 11, Message: "a"}, {Id: 12, Message: "b"}, {Id: 13, Message: "c"}}
+
+	result, err := service.ParseJudgeResult("```json\n{\"scores\": [{\"index\": 1, \"score\": 6}, {\"index\": 2, \"score\": 15}, {\"index\": 9, \"score\": 10}], \"best\": 2}\n```", answers)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), result.BestID)
+	assert.Equal(t, 2, len(result.Scores))
+	assert.Equal(t, int64(10), result.Scores[1].Score)
+
+	// 最佳回答无效时，使用得分最高的回答
+	result, err = service.ParseJudgeResult(`{"scores": [{"index": 1, "score": 3}, {"index": 3, "score": 7}], "best": 0}`, answers)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13), result.BestID)
+
+	_, err = service.ParseJudgeResult("no json", answers)
+	assert.True(t, errors.Is(err, service.ErrJudgeInvalidJSON))
+}
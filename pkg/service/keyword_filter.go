@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/trace"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+// keywordFilterReloadInterval 关键词规则的重新加载周期，管理员修改规则后，其它实例最迟在该周期后生效
+const keywordFilterReloadInterval = time.Minute
+
+// keywordFilterHitContentMaxLen 命中记录中保存的内容最大长度
+const keywordFilterHitContentMaxLen = 500
+
+// KeywordFilterHit 命中的关键词规则
+type KeywordFilterHit struct {
+	FilterID int64  `json:"filter_id"`
+	Keyword  string `json:"keyword"`
+	Action   int64  `json:"action"`
+}
+
+// KeywordFilterResult 关键词过滤结果
+type KeywordFilterResult struct {
+	// Content 打码后的内容
+	Content string `json:"content"`
+	// Blocked 是否命中了拦截规则
+	Blocked bool `json:"blocked"`
+	// Flagged 是否命中了标记待审核规则
+	Flagged bool `json:"flagged"`
+	// Hits 命中的所有规则
+	Hits []KeywordFilterHit `json:"hits,omitempty"`
+}
+
+type keywordRule struct {
+	id      int64
+	keyword string
+	action  int64
+	scope   int64
+	re      *regexp.Regexp
+}
+
+// KeywordMatcher 关键词匹配器，普通关键词不区分大小写
+type KeywordMatcher struct {
+	rules []keywordRule
+}
+
+// NewKeywordMatcher 根据关键词规则创建匹配器，无效的正则表达式规则会被忽略
+func NewKeywordMatcher(rules []model.KeywordFilter) *KeywordMatcher {
+	matcher := &KeywordMatcher{rules: make([]keywordRule, 0, len(rules))}
+	for _, rule := range rules {
+		if rule.Keyword == "" {
+			continue
+		}
+
+		pattern := "(?i)" + regexp.QuoteMeta(rule.Keyword)
+		if rule.IsRegex == 1 {
+			pattern = rule.Keyword
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.F(log.M{"filter_id": rule.Id, "keyword": rule.Keyword}).Warningf("invalid keyword filter regex: %v", err)
+			continue
+		}
+
+		matcher.rules = append(matcher.rules, keywordRule{
+			id:      rule.Id,
+			keyword: rule.Keyword,
+			action:  rule.Action,
+			scope:   rule.Scope,
+			re:      re,
+		})
+	}
+
+	return matcher
+}
+
+// Apply 对内容执行关键词过滤，scope 为 repo.KeywordFilterScopePrompt 或 repo.KeywordFilterScopeOutput
+func (m *KeywordMatcher) Apply(scope int64, content string) KeywordFilterResult {
+	res := KeywordFilterResult{Content: content}
+	for _, rule := range m.rules {
+		if rule.scope&scope == 0 || !rule.re.MatchString(res.Content) {
+			continue
+		}
+
+		res.Hits = append(res.Hits, KeywordFilterHit{FilterID: rule.id, Keyword: rule.keyword, Action: rule.action})
+
+		switch rule.action {
+		case repo.KeywordFilterActionBlock:
+			res.Blocked = true
+		case repo.KeywordFilterActionMask:
+			res.Content = rule.re.ReplaceAllStringFunc(res.Content, func(s string) string {
+				return strings.Repeat("*", utf8.RuneCountInString(s))
+			})
+		case repo.KeywordFilterActionReview:
+			res.Flagged = true
+		}
+	}
+
+	return res
+}
+
+// KeywordFilterService 部署方自定义的关键词过滤，规则存储在数据库中，定期自动重新加载
+type KeywordFilterService struct {
+	rep *repo.Repository `autowire:"@"`
+
+	lock     sync.RWMutex
+	matcher  *KeywordMatcher
+	loadedAt time.Time
+}
+
+func NewKeywordFilterService(resolver infra.Resolver) *KeywordFilterService {
+	srv := &KeywordFilterService{matcher: NewKeywordMatcher(nil)}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Reload 从数据库重新加载关键词规则
+func (srv *KeywordFilterService) Reload(ctx context.Context) error {
+	rules, err := srv.rep.KeywordFilter.Rules(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	matcher := NewKeywordMatcher(rules)
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.matcher, srv.loadedAt = matcher, time.Now()
+	return nil
+}
+
+func (srv *KeywordFilterService) currentMatcher(ctx context.Context) *KeywordMatcher {
+	srv.lock.RLock()
+	matcher, loadedAt := srv.matcher, srv.loadedAt
+	srv.lock.RUnlock()
+
+	if time.Since(loadedAt) < keywordFilterReloadInterval {
+		return matcher
+	}
+
+	if err := srv.Reload(ctx); err != nil {
+		log.Errorf("reload keyword filter rules failed: %v", err)
+
+		// 加载失败时继续使用旧的规则，避免每次请求都访问数据库
+		srv.lock.Lock()
+		srv.loadedAt = time.Now()
+		srv.lock.Unlock()
+
+		return matcher
+	}
+
+	srv.lock.RLock()
+	defer srv.lock.RUnlock()
+
+	return srv.matcher
+}
+
+// Apply 对内容执行关键词过滤，不记录命中统计，用于流式输出的分片内容
+func (srv *KeywordFilterService) Apply(ctx context.Context, scope int64, content string) KeywordFilterResult {
+	return srv.currentMatcher(ctx).Apply(scope, content)
+}
+
+// Filter 对内容执行关键词过滤，并记录命中统计以及待审核记录
+func (srv *KeywordFilterService) Filter(ctx context.Context, userID int64, scope int64, content string) KeywordFilterResult {
+	res := srv.Apply(ctx, scope, content)
+	if len(res.Hits) == 0 {
+		return res
+	}
+
+	snippet := content
+	if utf8.RuneCountInString(snippet) > keywordFilterHitContentMaxLen {
+		snippet = string([]rune(snippet)[:keywordFilterHitContentMaxLen])
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for _, hit := range res.Hits {
+			if err := srv.rep.KeywordFilter.AddHit(ctx, hit.FilterID, userID, hit.Action, scope, snippet); err != nil {
+				log.F(log.M{"filter_id": hit.FilterID, "user_id": userID}).Errorf("add keyword filter hit failed: %v", err)
+			}
+		}
+	}()
+
+	return res
+}
+
+// ChatFilter 对模型请求的输入与输出执行关键词过滤，模型输出的命中统计记录在请求关联的用户下
+func (srv *KeywordFilterService) ChatFilter() chat.ContentFilter {
+	return keywordChatFilter{srv: srv}
+}
+
+type keywordChatFilter struct {
+	srv *KeywordFilterService
+}
+
+func (f keywordChatFilter) Prompt(ctx context.Context, content string) (string, bool) {
+	res := f.srv.Apply(ctx, repo.KeywordFilterScopePrompt, content)
+	return res.Content, res.Blocked
+}
+
+func (f keywordChatFilter) Output(ctx context.Context, content string, record bool) (string, bool) {
+	if !record {
+		res := f.srv.Apply(ctx, repo.KeywordFilterScopeOutput, content)
+		return res.Content, res.Blocked
+	}
+
+	res := f.srv.Filter(ctx, trace.UserID(ctx), repo.KeywordFilterScopeOutput, content)
+	return res.Content, res.Blocked
+}
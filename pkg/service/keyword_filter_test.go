@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestKeywordMatcher(t *testing.T) {
+	matcher := service.NewKeywordMatcher([]model.KeywordFilter{
+		{Id: 1, Keyword: "Forbidden", Action: repo.KeywordFilterActionBlock, Scope: repo.KeywordFilterScopePrompt},
+		{Id: 2, Keyword: `1[3-9]\d{9}`, IsRegex: 1, Action: repo.KeywordFilterActionMask, Scope: repo.KeywordFilterScopeAll},
+		{Id: 3, Keyword: "竞品", Action: repo.KeywordFilterActionReview, Scope: repo.KeywordFilterScopeOutput},
+		// 无效的正则表达式会被忽略
+		{Id: 4, Keyword: `([`, IsRegex: 1, Action: repo.KeywordFilterActionBlock, Scope: repo.KeywordFilterScopeAll},
+	})
+
+	res := matcher.Apply(repo.KeywordFilterScopePrompt, "this is forbidden")
+	assert.True(t, res.Blocked)
+	assert.Equal(t, 1, len(res.Hits))
+
+	// 拦截规则只对用户输入生效
+	res = matcher.Apply(repo.KeywordFilterScopeOutput, "this is forbidden")
+	assert.False(t, res.Blocked)
+	assert.Equal(t, 0, len(res.Hits))
+
+	res = matcher.Apply(repo.KeywordFilterScopeOutput, "联系 13800138000 了解竞品")
+	assert.False(t, res.Blocked)
+	assert.True(t, res.Flagged)
+	assert.Equal(t, "联系 *********** 了解竞品", res.Content)
+	assert.Equal(t, 2, len(res.Hits))
+}
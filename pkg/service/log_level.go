@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/asteria/level"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// logLevelKey 管理员临时调整的日志级别，多实例部署时所有实例共享
+	logLevelKey = "log-level"
+	// logLevelSyncInterval 各实例同步日志级别的周期
+	logLevelSyncInterval = 10 * time.Second
+)
+
+// ErrInvalidLogLevel 无效的日志级别
+var ErrInvalidLogLevel = errors.New("无效的日志级别")
+
+// LogLevel 当前生效的日志级别
+type LogLevel struct {
+	Level string `json:"level"`
+	// Default 配置文件中的默认日志级别
+	Default string `json:"default"`
+	// ExpiredAt 临时调整的日志级别的失效时间，失效后恢复为默认日志级别
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+}
+
+// LogLevelService 运行时调整日志级别，例如排查问题时临时开启 DEBUG 日志
+type LogLevelService struct {
+	conf *config.Config `autowire:"@"`
+	rds  *redis.Client  `autowire:"@"`
+
+	lock    sync.Mutex
+	current level.Level
+}
+
+func NewLogLevelService(resolver infra.Resolver) *LogLevelService {
+	srv := &LogLevelService{}
+	resolver.MustAutoWire(srv)
+	srv.current = srv.defaultLevel()
+	return srv
+}
+
+func (srv *LogLevelService) defaultLevel() level.Level {
+	if le := level.GetLevelByName(srv.conf.LogLevel); le > 0 {
+		return le
+	}
+
+	return level.Debug
+}
+
+// Current 当前生效的日志级别
+func (srv *LogLevelService) Current(ctx context.Context) (*LogLevel, error) {
+	res := &LogLevel{Level: srv.defaultLevel().GetLevelName(), Default: srv.defaultLevel().GetLevelName()}
+
+	val, err := srv.rds.Get(ctx, logLevelKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return res, nil
+		}
+
+		return nil, err
+	}
+
+	ttl, err := srv.rds.TTL(ctx, logLevelKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Level = val
+	if ttl > 0 {
+		expiredAt := time.Now().Add(ttl)
+		res.ExpiredAt = &expiredAt
+	}
+
+	return res, nil
+}
+
+// Set 临时调整所有实例的日志级别，ttl 到期后恢复为默认日志级别，当前实例立即生效
+func (srv *LogLevelService) Set(ctx context.Context, name string, ttl time.Duration) error {
+	le := level.GetLevelByName(name)
+	if le == 0 {
+		return ErrInvalidLogLevel
+	}
+
+	if err := srv.rds.Set(ctx, logLevelKey, le.GetLevelName(), ttl).Err(); err != nil {
+		return err
+	}
+
+	srv.apply(le)
+	return nil
+}
+
+// Reset 恢复为默认日志级别
+func (srv *LogLevelService) Reset(ctx context.Context) error {
+	if err := srv.rds.Del(ctx, logLevelKey).Err(); err != nil {
+		return err
+	}
+
+	srv.apply(srv.defaultLevel())
+	return nil
+}
+
+// Sync 从 Redis 中同步其它实例调整的日志级别
+func (srv *LogLevelService) Sync(ctx context.Context) error {
+	cur, err := srv.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	if le := level.GetLevelByName(cur.Level); le > 0 {
+		srv.apply(le)
+	}
+
+	return nil
+}
+
+func (srv *LogLevelService) apply(le level.Level) {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	if srv.current == le {
+		return
+	}
+
+	log.F(log.M{"from": srv.current.GetLevelName(), "to": le.GetLevelName()}).Warningf("log level changed")
+	log.All().LogLevel(le)
+	srv.current = le
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	openaiHelper "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/sashabaranov/go-openai"
+)
+
+// 提示语优化的目标模型类型
+const (
+	// MagicPromptTargetSD Stable Diffusion 系列模型，使用逗号分隔的标签，支持排除内容
+	MagicPromptTargetSD = "sd"
+	// MagicPromptTargetDALLE DALL-E 系列模型，使用自然语言描述，不支持排除内容
+	MagicPromptTargetDALLE = "dalle"
+)
+
+const (
+	// magicPromptMaxTokens 优化后的提示语最大 Token 数量
+	magicPromptMaxTokens = 500
+	// magicPromptTimeout 优化提示语的超时时间
+	magicPromptTimeout = 30 * time.Second
+)
+
+var magicPromptSDInstruction = `As an artistic assistant, your task is to rewrite the short theme I provide into a detailed prompt for Stable Diffusion to generate a high-quality image.
+
+- The prompt consists of English tags separated by commas, describing the main subject, texture, additional details, image quality, art style, color tone and lighting, arranged in order of importance.
+- For themes related to people, describe the eyes, nose, lips, appearance, emotions, clothing, posture and background to avoid deformation.
+- The negative prompt consists of English tags separated by commas, describing elements to exclude, such as "nsfw, (low quality, normal quality, worst quality, jpeg artifacts), cropped, lowres, ((watermark))".
+- Keep the tag count within 40, no sentences or explanations, no quotation marks in tags.
+- Themes may be in any language, but your output must be in English.`
+
+var magicPromptDALLEInstruction = `As an artistic assistant, your task is to rewrite the short theme I provide into a detailed prompt for DALL-E to generate a high-quality image.
+
+- The prompt is a fluent English paragraph within 80 words, describing the main subject, composition, details, art style, color tone and lighting.
+- Keep the original intention of the theme, do not add text or watermark to the image.
+- Themes may be in any language, but your output must be in English.
+- The negative prompt is not supported, leave it empty.`
+
+var magicPromptLabelRegexp = regexp.MustCompile(`^\s*(?i:negative prompt|prompt)\s*:\s*`)
+
+// MagicPromptResult 提示语优化结果
+type MagicPromptResult struct {
+	ID             int64  `json:"id"`
+	Prompt         string `json:"prompt"`
+	NegativePrompt string `json:"negative_prompt,omitempty"`
+	QuotaConsumed  int64  `json:"quota_consumed"`
+}
+
+// MagicPromptService 图片提示语优化，将用户输入的简短描述扩写为适合图片生成模型的详细提示语
+type MagicPromptService struct {
+	conf   *config.Config
+	client openaiHelper.Client `autowire:"@"`
+	rep    *repo.Repository    `autowire:"@"`
+}
+
+func NewMagicPromptService(resolver infra.Resolver, conf *config.Config) *MagicPromptService {
+	srv := &MagicPromptService{conf: conf}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// BuildMagicPromptInstruction 构建提示语优化的系统提示语，style 为空时不限制风格
+func BuildMagicPromptInstruction(target string, style *repo.ImagePromptStyle) string {
+	instruction := magicPromptSDInstruction
+	if target == MagicPromptTargetDALLE {
+		instruction = magicPromptDALLEInstruction
+	}
+
+	if style != nil && strings.TrimSpace(style.Instruction) != "" {
+		instruction += fmt.Sprintf("\n\n## Style: %s\n%s", style.Name, strings.TrimSpace(style.Instruction))
+	}
+
+	return instruction + "\n\nOutput as a json, with 'prompt' and 'negative_prompt' as keys."
+}
+
+// ParseMagicPromptAnswer 解析模型输出的优化结果，兼容模型使用 Markdown 代码块包裹 JSON 的情况
+func ParseMagicPromptAnswer(answer string) (prompt string, negativePrompt string, err error) {
+	answer = strings.TrimSpace(answer)
+	if start, end := strings.Index(answer, "{"), strings.LastIndex(answer, "}"); start >= 0 && end > start {
+		answer = answer[start : end+1]
+	}
+
+	var res struct {
+		Prompt          string `json:"prompt"`
+		NegativePrompt  string `json:"negative_prompt"`
+		NegativePrompt2 string `json:"negativePrompt"`
+	}
+	if err := json.Unmarshal([]byte(answer), &res); err != nil {
+		return "", "", fmt.Errorf("invalid answer: %w", err)
+	}
+
+	prompt = strings.TrimSpace(magicPromptLabelRegexp.ReplaceAllString(res.Prompt, ""))
+	if prompt == "" {
+		return "", "", errors.New("empty prompt")
+	}
+
+	negativePrompt = res.NegativePrompt
+	if negativePrompt == "" {
+		negativePrompt = res.NegativePrompt2
+	}
+
+	return prompt, strings.TrimSpace(magicPromptLabelRegexp.ReplaceAllString(negativePrompt, "")), nil
+}
+
+// Enhance 优化用户输入的提示语，按照实际消耗的 Token 扣除智慧果，并保存优化记录用于后续的图片生成任务
+func (srv *MagicPromptService) Enhance(ctx context.Context, userID int64, prompt string, target string, style *repo.ImagePromptStyle) (*MagicPromptResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, magicPromptTimeout)
+	defer cancel()
+
+	resp, err := srv.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: srv.conf.MagicPromptModel,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: BuildMagicPromptInstruction(target, style)},
+			{Role: openai.ChatMessageRoleUser, Content: prompt},
+		},
+		MaxTokens:   magicPromptMaxTokens,
+		Temperature: 0.7,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request model failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("empty response")
+	}
+
+	enhanced, negativePrompt, err := ParseMagicPromptAnswer(resp.Choices[0].Message.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	if target == MagicPromptTargetDALLE {
+		negativePrompt = ""
+	}
+
+	tokens := int64(resp.Usage.TotalTokens)
+	quota := coins.GetOpenAITextCoins(srv.conf.MagicPromptModel, tokens)
+	if quota > 0 {
+		if err := srv.rep.Quota.QuotaConsume(ctx, userID, quota, repo.NewQuotaUsedMeta("magic-prompt", srv.conf.MagicPromptModel)); err != nil {
+			log.F(log.M{"user_id": userID, "quota": quota}).Errorf("提示语优化扣除智慧果失败: %v", err)
+		}
+	}
+
+	item := repo.ImagePromptEnhancement{
+		UserID:         userID,
+		Target:         target,
+		Prompt:         prompt,
+		EnhancedPrompt: enhanced,
+		NegativePrompt: negativePrompt,
+		Model:          srv.conf.MagicPromptModel,
+		TokenConsumed:  tokens,
+		QuotaConsumed:  quota,
+	}
+	if style != nil {
+		item.StyleID = style.ID
+	}
+
+	id, err := srv.rep.ImagePrompt.AddEnhancement(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("save enhancement failed: %w", err)
+	}
+
+	return &MagicPromptResult{ID: id, Prompt: enhanced, NegativePrompt: negativePrompt, QuotaConsumed: quota}, nil
+}
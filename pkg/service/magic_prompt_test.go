@@ -0,0 +1,37 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestParseMagicPromptAnswer(t *testing.T) {
+	prompt, negativePrompt, err := service.ParseMagicPromptAnswer("```json\n{\"prompt\": \"Prompt: a cat, (masterpiece:1.2)\", \"negative_prompt\": \"lowres, watermark\"}\n```")
+	assert.NoError(t, err)
+	assert.Equal(t, "a cat, (masterpiece:1.2)", prompt)
+	assert.Equal(t, "lowres, watermark", negativePrompt)
+
+	_, negativePrompt, err = service.ParseMagicPromptAnswer(`{"prompt": "a dog", "negativePrompt": "blurry"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "blurry", negativePrompt)
+
+	_, _, err = service.ParseMagicPromptAnswer(`{"prompt": ""}`)
+	assert.True(t, err != nil)
+
+	_, _, err = service.ParseMagicPromptAnswer("a cat")
+	assert.True(t, err != nil)
+}
+
+func TestBuildMagicPromptInstruction(t *testing.T) {
+	instruction := service.BuildMagicPromptInstruction(service.MagicPromptTargetDALLE, &repo.ImagePromptStyle{Name: "Ghibli", Instruction: "hand-drawn anime style"})
+	assert.True(t, strings.Contains(instruction, "DALL-E"))
+	assert.True(t, strings.Contains(instruction, "## Style: Ghibli\nhand-drawn anime style"))
+
+	instruction = service.BuildMagicPromptInstruction(service.MagicPromptTargetSD, nil)
+	assert.True(t, strings.Contains(instruction, "Stable Diffusion"))
+	assert.False(t, strings.Contains(instruction, "## Style"))
+}
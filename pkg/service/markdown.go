@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/markdown"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/redis/go-redis/v9"
+)
+
+// markdownCacheTTL Markdown 渲染结果的缓存时间，缓存 Key 由内容的哈希决定，内容不变时结果不变
+const markdownCacheTTL = 7 * 24 * time.Hour
+
+// MarkdownHTML Markdown 渲染结果
+type MarkdownHTML struct {
+	HTML string `json:"html"`
+	// Hash 内容的哈希值，页面可以用来判断内容是否发生变化
+	Hash string `json:"hash"`
+}
+
+// MarkdownService 在服务端将 Markdown（包括数学公式与代码块）渲染为安全的 HTML，用于 Web 分享页面嵌入
+type MarkdownService struct {
+	rds *redis.Client `autowire:"@"`
+}
+
+func NewMarkdownService(resolver infra.Resolver) *MarkdownService {
+	srv := &MarkdownService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// RenderHTML 渲染 Markdown 内容，相同的内容只渲染一次
+func (srv *MarkdownService) RenderHTML(ctx context.Context, content string) *MarkdownHTML {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+	// 渲染规则修改后 RendererVersion 会递增，旧版本的缓存自动失效
+	key := fmt.Sprintf("markdown-html:v%d:%s", markdown.RendererVersion, hash)
+	if res, err := srv.rds.Get(ctx, key).Result(); err == nil {
+		return &MarkdownHTML{HTML: res, Hash: hash}
+	}
+
+	res := markdown.RenderHTML(content)
+	if err := srv.rds.Set(ctx, key, res, markdownCacheTTL).Err(); err != nil {
+		log.F(log.M{"hash": hash}).Warningf("cache markdown html failed: %v", err)
+	}
+
+	return &MarkdownHTML{HTML: res, Hash: hash}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/image"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+const (
+	// messageCardURLTTL 分享卡片签名 URL 的有效期
+	messageCardURLTTL = 24 * time.Hour
+	// messageCardExpireDays 分享卡片文件的保存天数，期间重复导出同一条消息直接使用已生成的文件
+	messageCardExpireDays = 7
+	// messageCardMaxLength 卡片中每段内容的最大长度，超出部分截断
+	messageCardMaxLength = 8000
+)
+
+// MessageCardResult 分享卡片的导出结果
+type MessageCardResult struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MessageCardService 将单条消息或者问答对渲染为分享卡片图片，避免各个客户端渲染效果不一致
+type MessageCardService struct {
+	conf *config.Config     `autowire:"@"`
+	rep  *repo.Repository   `autowire:"@"`
+	up   *uploader.Uploader `autowire:"@"`
+}
+
+func NewMessageCardService(resolver infra.Resolver) *MessageCardService {
+	srv := &MessageCardService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Render 渲染消息的分享卡片，withQuestion 为 true 时 AI 回复与对应的提问一起渲染，消息不存在时返回 repo.ErrNotFound
+func (srv *MessageCardService) Render(ctx context.Context, userID, messageID int64, withQuestion bool) (*MessageCardResult, error) {
+	cacheKey := fmt.Sprintf("message-card:%d:%d:%v", userID, messageID, withQuestion)
+	if key, err := srv.rep.Cache.Get(ctx, cacheKey); err == nil && key != "" {
+		return srv.result(key), nil
+	}
+
+	msg, err := srv.rep.Message.Message(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	isAnswer := msg.Role == int64(repo.MessageRoleAssistant)
+	sections := make([]image.CardSection, 0, 2)
+	if isAnswer && withQuestion && msg.Pid > 0 {
+		question, err := srv.rep.Message.Message(ctx, userID, msg.Pid)
+		if err != nil && !errors.Is(err, repo.ErrNotFound) {
+			return nil, err
+		}
+
+		if question != nil {
+			sections = append(sections, image.CardSection{Label: "问", Content: truncateCardContent(question.Message)})
+		}
+	}
+
+	sections = append(sections, image.CardSection{
+		Label:   ternary.If(isAnswer, "答", "问"),
+		Content: truncateCardContent(msg.Message),
+	})
+
+	subtitle := msg.CreatedAt.Format("2006-01-02")
+	if isAnswer && msg.Model != "" {
+		subtitle = srv.modelName(msg.Model) + " · " + subtitle
+	}
+
+	data, err := image.New(srv.conf.FontPath).MessageCard(image.MessageCard{
+		Sections: sections,
+		Brand:    srv.brand(),
+		Subtitle: subtitle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render message card failed: %w", err)
+	}
+
+	fileURL, err := srv.up.UploadStream(ctx, int(userID), messageCardExpireDays, data, "png")
+	if err != nil {
+		return nil, fmt.Errorf("upload message card failed: %w", err)
+	}
+
+	key := strings.TrimPrefix(strings.TrimPrefix(fileURL, srv.conf.StorageDomain), "/")
+	if err := srv.rep.Cache.Set(ctx, cacheKey, key, (messageCardExpireDays-1)*24*time.Hour); err != nil {
+		log.F(log.M{"user_id": userID, "message_id": messageID}).Warningf("cache message card failed: %v", err)
+	}
+
+	return srv.result(key), nil
+}
+
+func (srv *MessageCardService) result(key string) *MessageCardResult {
+	return &MessageCardResult{
+		URL:       srv.up.MakePrivateURL(key, messageCardURLTTL),
+		ExpiresAt: time.Now().Add(messageCardURLTTL),
+	}
+}
+
+// brand 卡片底部的品牌信息，配置了 Web 地址时附带域名
+func (srv *MessageCardService) brand() string {
+	if u, err := url.Parse(srv.conf.BaseURL); err == nil && u.Host != "" {
+		return "AIdea · " + u.Host
+	}
+
+	return "AIdea"
+}
+
+// modelName 消息中保存的模型 ID 可能带有服务商前缀，卡片中只展示模型名称
+func (srv *MessageCardService) modelName(model string) string {
+	if idx := strings.LastIndex(model, ":"); idx >= 0 {
+		return model[idx+1:]
+	}
+
+	return model
+}
+
+func truncateCardContent(content string) string {
+	runes := []rune(content)
+	if len(runes) <= messageCardMaxLength {
+		return content
+	}
+
+	return string(runes[:messageCardMaxLength]) + "……"
+}
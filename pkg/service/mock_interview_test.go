@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestBuildMockInterviewMessages(t *testing.T) {
+	interview := repo.MockInterview{Role: "后端工程师", Level: "senior", QuestionCount: 3}
+
+	messages := service.BuildMockInterviewMessages(interview, false)
+	assert.Equal(t, 2, len(messages))
+	assert.True(t, strings.Contains(messages[0].Content, `"后端工程师" (senior level)`))
+	assert.Equal(t, "user", messages[1].Role)
+
+	interview.Transcript = []repo.MockInterviewTurn{
+		{Question: "介绍一下你做过的项目", Answer: "我负责过一个订单系统"},
+		{Question: "如何保证接口幂等"},
+	}
+
+	messages = service.BuildMockInterviewMessages(interview, false)
+	assert.Equal(t, 4, len(messages))
+	assert.Equal(t, "assistant", messages[3].Role)
+
+	messages = service.BuildMockInterviewMessages(interview, true)
+	assert.Equal(t, 5, len(messages))
+	assert.True(t, strings.Contains(messages[4].Content, "score report"))
+}
+
+func TestRecordMockInterviewAnswer(t *testing.T) {
+	interview := repo.MockInterview{
+		Role:          "产品经理",
+		QuestionCount: 2,
+		Status:        repo.MockInterviewStatusOngoing,
+		Transcript:    []repo.MockInterviewTurn{{Question: "Q1"}},
+	}
+
+	// 没有回答任何问题时不能提前结束
+	_, _, err := service.RecordMockInterviewAnswer(interview, "", true)
+	assert.Equal(t, service.ErrMockInterviewNoAnswer, err)
+
+	updated, finishing, err := service.RecordMockInterviewAnswer(interview, "A1", false)
+	assert.NoError(t, err)
+	assert.False(t, finishing)
+	assert.Equal(t, int64(1), updated.Answered)
+	assert.Equal(t, "A1", updated.Transcript[0].Answer)
+	assert.Equal(t, "", interview.Transcript[0].Answer)
+
+	// 当前问题已经回答过
+	_, _, err = service.RecordMockInterviewAnswer(updated, "A1", false)
+	assert.Equal(t, service.ErrMockInterviewFinished, err)
+
+	// 回答完所有问题后生成评分报告
+	updated.Transcript = append(updated.Transcript, repo.MockInterviewTurn{Question: "Q2"})
+	_, finishing, err = service.RecordMockInterviewAnswer(updated, "A2", false)
+	assert.NoError(t, err)
+	assert.True(t, finishing)
+
+	// 提前结束
+	_, finishing, err = service.RecordMockInterviewAnswer(updated, "", true)
+	assert.NoError(t, err)
+	assert.True(t, finishing)
+
+	updated.Status = repo.MockInterviewStatusFinished
+	_, _, err = service.RecordMockInterviewAnswer(updated, "A2", false)
+	assert.Equal(t, service.ErrMockInterviewFinished, err)
+}
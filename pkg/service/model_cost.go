@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/channel"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+// ModelCostService 模型上游成本核算，记录每次请求按照服务商价格计算的成本以及向用户收取的智慧果
+type ModelCostService struct {
+	conf *config.Config   `autowire:"@"`
+	rep  *repo.Repository `autowire:"@"`
+}
+
+func NewModelCostService(resolver infra.Resolver) *ModelCostService {
+	srv := &ModelCostService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// ProviderOf 查询模型所属的服务商，无法识别时为 openai（与模型路由的默认实现保持一致）
+func (srv *ModelCostService) ProviderOf(model string) string {
+	if _, ok := channel.Lookup(model); ok {
+		return "channel"
+	}
+
+	model = strings.TrimPrefix(model, channel.Prefix)
+	for _, m := range chat.Models(srv.conf, true) {
+		if m.RealID() == model {
+			return m.Category
+		}
+	}
+
+	return "openai"
+}
+
+// Record 记录一次请求的上游成本，coinsCharged 为本次请求向用户收取的智慧果，免费请求为 0
+func (srv *ModelCostService) Record(ctx context.Context, model string, inputTokens, outputTokens, coinsCharged int64) {
+	cost, priced := coins.GetUpstreamCost(model, inputTokens, outputTokens)
+	usage := repo.ModelCostUsage{
+		Model:        model,
+		Provider:     srv.ProviderOf(model),
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		Cost:         cost,
+		Priced:       priced,
+		Coins:        coinsCharged,
+	}
+
+	if err := srv.rep.ModelCost.Record(ctx, usage); err != nil {
+		log.F(log.M{"usage": usage}).Errorf("record model cost failed: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+// ModelTrialService 高级模型免费试用：每个用户对每个配置了试用次数的模型有固定的免费试用次数，试用期间不消耗智慧果
+type ModelTrialService struct {
+	rep *repo.Repository `autowire:"@"`
+}
+
+func NewModelTrialService(resolver infra.Resolver) *ModelTrialService {
+	srv := &ModelTrialService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// trialModelID 试用次数按照不带分类前缀的模型 ID 记录，例如 openai:gpt-4 与 gpt-4 共享试用次数
+func trialModelID(model string) string {
+	segs := strings.SplitN(model, ":", 2)
+	return segs[len(segs)-1]
+}
+
+// ModelTrials 每个模型剩余的试用次数，key 为不带分类前缀的模型 ID
+type ModelTrials map[string]int64
+
+// Of 模型剩余的试用次数，model 可以带分类前缀
+func (trials ModelTrials) Of(model string) int64 {
+	return trials[trialModelID(model)]
+}
+
+// LeftByModels 用户在所有配置了试用的模型上剩余的试用次数
+func (srv *ModelTrialService) LeftByModels(ctx context.Context, userID int64) ModelTrials {
+	ret := make(ModelTrials)
+
+	trials := coins.TrialModels()
+	if len(trials) == 0 {
+		return ret
+	}
+
+	used, err := srv.rep.ModelTrial.UsedByModels(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("query model trials failed: %s", err)
+		return ret
+	}
+
+	for model, limit := range trials {
+		if left := trialLeft(limit, used[model]); left > 0 {
+			ret[model] = left
+		}
+	}
+
+	return ret
+}
+
+func trialLeft(limit, used int64) int64 {
+	if used >= limit {
+		return 0
+	}
+
+	return limit - used
+}
+
+// Acquire 占用一次试用机会，返回 true 时本次请求不扣除智慧果，请求失败时需要调用 Release 退还
+func (srv *ModelTrialService) Acquire(ctx context.Context, userID int64, model string) bool {
+	limit := coins.GetModelTrialCount(model)
+	if limit <= 0 {
+		return false
+	}
+
+	ok, err := srv.rep.ModelTrial.Acquire(ctx, userID, trialModelID(model), limit)
+	if err != nil {
+		log.F(log.M{"user_id": userID, "model": model}).Errorf("acquire model trial failed: %s", err)
+		return false
+	}
+
+	return ok
+}
+
+// Release 退还一次试用机会
+func (srv *ModelTrialService) Release(ctx context.Context, userID int64, model string) {
+	if err := srv.rep.ModelTrial.Release(ctx, userID, trialModelID(model)); err != nil {
+		log.F(log.M{"user_id": userID, "model": model}).Errorf("release model trial failed: %s", err)
+	}
+}
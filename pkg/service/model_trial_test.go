@@ -0,0 +1,16 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestModelTrials_Of(t *testing.T) {
+	trials := service.ModelTrials{"gpt-4": 3}
+
+	assert.EqualValues(t, 3, trials.Of("gpt-4"))
+	assert.EqualValues(t, 3, trials.Of("openai:gpt-4"))
+	assert.EqualValues(t, 0, trials.Of("openai:gpt-3.5-turbo"))
+}
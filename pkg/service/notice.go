@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// noticeReloadInterval 系统通知的重新加载周期
+const noticeReloadInterval = time.Minute
+
+// ActiveNotices 返回指定时间生效的通知
+func ActiveNotices(notices []repo.Notice, now time.Time) []repo.Notice {
+	return array.Filter(notices, func(n repo.Notice, _ int) bool { return n.ActiveAt(now) })
+}
+
+// MaintenanceFor 返回指定时间暂停了该功能的维护窗口，功能可用时返回 nil
+func MaintenanceFor(notices []repo.Notice, feature string, now time.Time) *repo.Notice {
+	for _, n := range notices {
+		if n.Type == repo.NoticeTypeMaintenance && n.ActiveAt(now) && array.In(feature, n.GatedFeatures) {
+			return &n
+		}
+	}
+
+	return nil
+}
+
+// NoticeService 系统公告与维护窗口，维护期间暂停使用消耗资源的功能，查询类接口保持可用
+type NoticeService struct {
+	rep *repo.Repository `autowire:"@"`
+
+	lock     sync.RWMutex
+	notices  []repo.Notice
+	loadedAt time.Time
+}
+
+func NewNoticeService(resolver infra.Resolver) *NoticeService {
+	srv := &NoticeService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Reload 从数据库重新加载尚未结束的通知
+func (srv *NoticeService) Reload(ctx context.Context) error {
+	notices, err := srv.rep.Notice.Notices(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.notices, srv.loadedAt = notices, time.Now()
+	return nil
+}
+
+func (srv *NoticeService) currentNotices(ctx context.Context) []repo.Notice {
+	srv.lock.RLock()
+	loadedAt := srv.loadedAt
+	srv.lock.RUnlock()
+
+	if time.Since(loadedAt) >= noticeReloadInterval {
+		if err := srv.Reload(ctx); err != nil {
+			log.Errorf("reload system notices failed: %v", err)
+
+			// 加载失败时继续使用旧的通知，避免每次请求都访问数据库
+			srv.lock.Lock()
+			srv.loadedAt = time.Now()
+			srv.lock.Unlock()
+		}
+	}
+
+	srv.lock.RLock()
+	defer srv.lock.RUnlock()
+
+	return srv.notices
+}
+
+// Active 当前生效的公告与维护窗口
+func (srv *NoticeService) Active(ctx context.Context) []repo.Notice {
+	return ActiveNotices(srv.currentNotices(ctx), time.Now())
+}
+
+// Maintenance 当前暂停了该功能的维护窗口，功能可用时返回 nil
+func (srv *NoticeService) Maintenance(ctx context.Context, feature string) *repo.Notice {
+	return MaintenanceFor(srv.currentNotices(ctx), feature, time.Now())
+}
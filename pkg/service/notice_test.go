@@ -0,0 +1,27 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestMaintenanceFor(t *testing.T) {
+	now := time.Date(2023, 12, 31, 12, 0, 0, 0, time.Local)
+	notices := []repo.Notice{
+		{ID: 1, Type: repo.NoticeTypeBanner, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), Status: repo.NoticeStatusEnabled},
+		{ID: 2, Type: repo.NoticeTypeMaintenance, GatedFeatures: []string{repo.GeoFeatureImage}, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), Status: repo.NoticeStatusEnabled},
+		{ID: 3, Type: repo.NoticeTypeMaintenance, GatedFeatures: []string{repo.GeoFeatureChat}, StartAt: now.Add(time.Hour), EndAt: now.Add(2 * time.Hour), Status: repo.NoticeStatusEnabled},
+		{ID: 4, Type: repo.NoticeTypeMaintenance, GatedFeatures: []string{repo.GeoFeatureVoice}, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), Status: repo.NoticeStatusDisabled},
+	}
+
+	assert.Equal(t, 2, len(service.ActiveNotices(notices, now)))
+	assert.Equal(t, int64(2), service.MaintenanceFor(notices, repo.GeoFeatureImage, now).ID)
+	assert.True(t, service.MaintenanceFor(notices, repo.GeoFeatureChat, now) == nil)
+	assert.Equal(t, int64(3), service.MaintenanceFor(notices, repo.GeoFeatureChat, now.Add(90*time.Minute)).ID)
+	assert.True(t, service.MaintenanceFor(notices, repo.GeoFeatureVoice, now) == nil)
+	assert.True(t, service.MaintenanceFor(notices, repo.GeoFeatureChat, now.Add(2*time.Hour)) == nil)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// onboardingReloadInterval 引导内容的重新加载周期
+	onboardingReloadInterval = time.Minute
+	// OnboardingDefaultLanguage 没有用户语言对应的引导内容时使用的语言
+	OnboardingDefaultLanguage = "zh-CHS"
+)
+
+// OnboardingContent 客户端首次启动时展示的引导内容
+type OnboardingContent struct {
+	Steps   []repo.OnboardingItem `json:"steps"`
+	Prompts []repo.OnboardingItem `json:"prompts"`
+	Models  []repo.OnboardingItem `json:"models"`
+	// Personas 可供用户选择的角色，用于筛选示例提示语
+	Personas []string `json:"personas"`
+}
+
+// baseLanguage 语言的主要部分，例如 zh-CHS 返回 zh
+func baseLanguage(language string) string {
+	return strings.ToLower(strings.SplitN(language, "-", 2)[0])
+}
+
+// onboardingLanguage 从已有的语言中选择最匹配用户语言的一个：优先完全匹配，其次主要语言相同，最后使用默认语言
+func onboardingLanguage(available []string, language string) string {
+	for _, lang := range available {
+		if strings.EqualFold(lang, language) {
+			return lang
+		}
+	}
+
+	for _, lang := range available {
+		if baseLanguage(lang) == baseLanguage(language) {
+			return lang
+		}
+	}
+
+	return OnboardingDefaultLanguage
+}
+
+// OnboardingFor 按照用户的语言与角色筛选引导内容，每种类型的内容单独选择语言，某种类型没有翻译时回退到默认语言；
+// persona 为空时返回全部角色的内容，否则只返回通用的以及该角色的内容
+func OnboardingFor(items []repo.OnboardingItem, language, persona string) OnboardingContent {
+	content := OnboardingContent{
+		Steps:    []repo.OnboardingItem{},
+		Prompts:  []repo.OnboardingItem{},
+		Models:   []repo.OnboardingItem{},
+		Personas: []string{},
+	}
+
+	for _, kind := range []string{repo.OnboardingKindStep, repo.OnboardingKindPrompt, repo.OnboardingKindModel} {
+		ofKind := array.Filter(items, func(item repo.OnboardingItem, _ int) bool { return item.Kind == kind })
+		lang := onboardingLanguage(array.Map(ofKind, func(item repo.OnboardingItem, _ int) string { return item.Language }), language)
+
+		for _, item := range ofKind {
+			if item.Language != lang {
+				continue
+			}
+
+			if item.Persona != "" && !array.In(item.Persona, content.Personas) {
+				content.Personas = append(content.Personas, item.Persona)
+			}
+
+			if persona != "" && item.Persona != "" && item.Persona != persona {
+				continue
+			}
+
+			switch kind {
+			case repo.OnboardingKindStep:
+				content.Steps = append(content.Steps, item)
+			case repo.OnboardingKindPrompt:
+				content.Prompts = append(content.Prompts, item)
+			case repo.OnboardingKindModel:
+				content.Models = append(content.Models, item)
+			}
+		}
+	}
+
+	return content
+}
+
+// OnboardingService 新用户引导内容，由管理员维护，缓存在内存中定期重新加载
+type OnboardingService struct {
+	rep *repo.Repository `autowire:"@"`
+
+	lock     sync.RWMutex
+	items    []repo.OnboardingItem
+	loadedAt time.Time
+}
+
+func NewOnboardingService(resolver infra.Resolver) *OnboardingService {
+	srv := &OnboardingService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Reload 从数据库重新加载启用的引导内容
+func (srv *OnboardingService) Reload(ctx context.Context) error {
+	items, err := srv.rep.Onboarding.Items(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.items, srv.loadedAt = items, time.Now()
+	return nil
+}
+
+func (srv *OnboardingService) currentItems(ctx context.Context) []repo.OnboardingItem {
+	srv.lock.RLock()
+	loadedAt := srv.loadedAt
+	srv.lock.RUnlock()
+
+	if time.Since(loadedAt) >= onboardingReloadInterval {
+		if err := srv.Reload(ctx); err != nil {
+			log.Errorf("reload onboarding items failed: %v", err)
+
+			// 加载失败时继续使用旧的内容，避免每次请求都访问数据库
+			srv.lock.Lock()
+			srv.loadedAt = time.Now()
+			srv.lock.Unlock()
+		}
+	}
+
+	srv.lock.RLock()
+	defer srv.lock.RUnlock()
+
+	return srv.items
+}
+
+// Content 用户语言与角色对应的引导内容
+func (srv *OnboardingService) Content(ctx context.Context, language, persona string) OnboardingContent {
+	return OnboardingFor(srv.currentItems(ctx), language, persona)
+}
+
+// IsStep 是否为当前启用的引导步骤
+func (srv *OnboardingService) IsStep(ctx context.Context, id int64) bool {
+	for _, item := range srv.currentItems(ctx) {
+		if item.ID == id && item.Kind == repo.OnboardingKindStep {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestOnboardingFor(t *testing.T) {
+	items := []repo.OnboardingItem{
+		{ID: 1, Kind: repo.OnboardingKindStep, Language: "zh-CHS", Title: "欢迎"},
+		{ID: 2, Kind: repo.OnboardingKindStep, Language: "en", Title: "Welcome"},
+		{ID: 3, Kind: repo.OnboardingKindPrompt, Language: "zh-CHS", Title: "写周报"},
+		{ID: 4, Kind: repo.OnboardingKindPrompt, Language: "zh-CHS", Persona: "学生", Title: "解释概念"},
+		{ID: 5, Kind: repo.OnboardingKindPrompt, Language: "zh-CHS", Persona: "程序员", Title: "代码审查"},
+		{ID: 6, Kind: repo.OnboardingKindModel, Language: "zh-CHS", Payload: "openai:gpt-3.5-turbo"},
+	}
+
+	ids := func(items []repo.OnboardingItem) []int64 {
+		return array.Map(items, func(item repo.OnboardingItem, _ int) int64 { return item.ID })
+	}
+
+	zh := service.OnboardingFor(items, "zh-CHS", "")
+	assert.EqualValues(t, []int64{1}, ids(zh.Steps))
+	assert.EqualValues(t, []int64{3, 4, 5}, ids(zh.Prompts))
+	assert.EqualValues(t, []int64{6}, ids(zh.Models))
+	assert.EqualValues(t, []string{"学生", "程序员"}, zh.Personas)
+
+	student := service.OnboardingFor(items, "zh-CHS", "学生")
+	assert.EqualValues(t, []int64{3, 4}, ids(student.Prompts))
+	assert.EqualValues(t, []string{"学生", "程序员"}, student.Personas)
+
+	// 主要语言相同时使用该语言，没有翻译的类型回退到默认语言
+	en := service.OnboardingFor(items, "en-US", "")
+	assert.EqualValues(t, []int64{2}, ids(en.Steps))
+	assert.EqualValues(t, []int64{3, 4, 5}, ids(en.Prompts))
+	assert.EqualValues(t, []int64{6}, ids(en.Models))
+
+	empty := service.OnboardingFor(nil, "en", "")
+	assert.Equal(t, 0, len(empty.Steps))
+	assert.Equal(t, 0, len(empty.Personas))
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// OrgPolicyViolationError 请求违反了组织策略
+type OrgPolicyViolationError struct {
+	Reason string
+}
+
+func (e *OrgPolicyViolationError) Error() string {
+	return e.Reason
+}
+
+// OrgPolicyService 组织策略检查，对使用组织钱包计费的请求生效
+type OrgPolicyService struct {
+	rep         *repo.Repository `autowire:"@"`
+	securitySrv *SecurityService `autowire:"@"`
+}
+
+func NewOrgPolicyService(resolver infra.Resolver) *OrgPolicyService {
+	srv := &OrgPolicyService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Check 检查请求是否符合 context 中计费组织的策略，违反策略时返回 *OrgPolicyViolationError，并记录组织审计日志；
+// 没有使用组织钱包计费时直接通过
+func (srv *OrgPolicyService) Check(ctx context.Context, userID int64, model string, content string) error {
+	return srv.check(ctx, userID, model, true, content)
+}
+
+// CheckContent 只检查请求内容，用于不使用大模型的功能，例如翻译、语音合成
+func (srv *OrgPolicyService) CheckContent(ctx context.Context, userID int64, content string) error {
+	return srv.check(ctx, userID, "", false, content)
+}
+
+// CheckGroupChat 检查群聊中参与对话的每一个成员使用的模型，memberIDs 为空时检查群组的所有成员，内容只检查一次
+func (srv *OrgPolicyService) CheckGroupChat(ctx context.Context, userID, groupID int64, memberIDs []int64, content string) error {
+	if repo.BillingOrgFromContext(ctx) <= 0 {
+		return nil
+	}
+
+	grp, err := srv.rep.ChatGroup.GetGroup(ctx, groupID, userID)
+	if err != nil {
+		// 群组不存在时由处理器返回错误
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("query group failed: %w", err)
+	}
+
+	checked := false
+	for _, member := range grp.Members {
+		if len(memberIDs) > 0 && !array.In(member.Id, memberIDs) {
+			continue
+		}
+
+		if err := srv.Check(ctx, userID, member.ModelId, ternary.If(checked, "", content)); err != nil {
+			return err
+		}
+
+		checked = true
+	}
+
+	if !checked {
+		return srv.CheckContent(ctx, userID, content)
+	}
+
+	return nil
+}
+
+func (srv *OrgPolicyService) check(ctx context.Context, userID int64, model string, checkModel bool, content string) error {
+	orgID := repo.BillingOrgFromContext(ctx)
+	if orgID <= 0 {
+		return nil
+	}
+
+	policy, err := srv.rep.Org.Policy(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("query org policy failed: %w", err)
+	}
+
+	var violation *OrgPolicyViolationError
+	if checkModel && !policy.ModelAllowed(model) {
+		violation = &OrgPolicyViolationError{Reason: fmt.Sprintf("组织策略不允许使用模型 %s", model)}
+	} else if word := policy.MatchBlockedWord(content); word != "" {
+		violation = &OrgPolicyViolationError{Reason: fmt.Sprintf("请求内容包含组织禁止的敏感词：%s", word)}
+	} else if policy.StrictSafety && content != "" {
+		if res := srv.securitySrv.ChatDetect(content); res != nil && !res.Safe {
+			violation = &OrgPolicyViolationError{Reason: "请求内容违反了组织的内容安全策略"}
+		}
+	}
+
+	if violation == nil {
+		return nil
+	}
+
+	if err := srv.rep.Org.AddAuditLog(ctx, orgID, userID, repo.OrgAuditPolicyViolation, fmt.Sprintf("model=%s, reason=%s", model, violation.Reason)); err != nil {
+		log.F(log.M{"org_id": orgID, "user_id": userID}).Errorf("记录组织审计日志失败: %v", err)
+	}
+
+	return violation
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+)
+
+// personaPrefix 全局指令的前缀，与数字人等自带的系统提示语区分开
+const personaPrefix = "The user has provided the following custom instructions. Follow them in every reply unless they conflict with the instructions that follow:\n\n"
+
+// Persona 查询用户启用的全局指令，未启用时返回 nil
+func (srv *UserService) Persona(ctx context.Context, userID int64) (*repo2.UserPersona, error) {
+	cus, err := srv.userRepo.CustomConfig(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cus.Persona.Enabled() {
+		return nil, nil
+	}
+
+	return cus.Persona, nil
+}
+
+// ApplyPersona 将全局指令添加到对话的系统消息中，对话中已有的系统消息（例如数字人设定）放在全局指令之后，
+// 合并为一条系统消息，避免部分模型不支持多条系统消息
+func ApplyPersona(messages chat.Messages, persona *repo2.UserPersona) chat.Messages {
+	if !persona.Enabled() {
+		return messages
+	}
+
+	instruction := personaPrefix + persona.Content
+	if len(messages) > 0 && messages[0].Role == "system" {
+		res := append(chat.Messages{}, messages...)
+		res[0].Content = instruction + "\n\n" + res[0].Content
+		return res
+	}
+
+	res := make(chat.Messages, 0, len(messages)+1)
+	res = append(res, chat.Message{Role: "system", Content: instruction})
+	return append(res, messages...)
+}
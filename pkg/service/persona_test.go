@@ -0,0 +1,30 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestApplyPersona(t *testing.T) {
+	messages := chat.Messages{{Role: "user", Content: "你好"}}
+	assert.Equal(t, 1, len(service.ApplyPersona(messages, nil)))
+	assert.Equal(t, 1, len(service.ApplyPersona(messages, &repo.UserPersona{Version: 2})))
+
+	persona := &repo.UserPersona{Version: 1, Content: "请用简体中文回答"}
+	res := service.ApplyPersona(messages, persona)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, "system", res[0].Role)
+	assert.True(t, strings.HasSuffix(res[0].Content, "请用简体中文回答"))
+
+	// 已有系统消息时合并为一条，原有的系统消息放在后面
+	withSystem := chat.Messages{{Role: "system", Content: "你是一名翻译"}, {Role: "user", Content: "hello"}}
+	res = service.ApplyPersona(withSystem, persona)
+	assert.Equal(t, 2, len(res))
+	assert.True(t, strings.HasSuffix(res[0].Content, "请用简体中文回答\n\n你是一名翻译"))
+	assert.Equal(t, "你是一名翻译", withSystem[0].Content)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+var (
+	ErrSuggestionDisabled = errors.New("prompt suggestion is disabled")
+)
+
+const (
+	// maxPromptSuggestions 最多返回的追问建议数量
+	maxPromptSuggestions = 3
+	// promptSuggestionCacheTTL 追问建议缓存时长
+	promptSuggestionCacheTTL = 7 * 24 * time.Hour
+)
+
+// PromptSuggestionService 追问建议：根据 AI 的回复，使用低成本模型生成用户接下来可能会问的问题
+type PromptSuggestionService struct {
+	conf *config.Config   `autowire:"@"`
+	ct   chat.Chat        `autowire:"@"`
+	rep  *repo.Repository `autowire:"@"`
+}
+
+func NewPromptSuggestionService(resolver infra.Resolver) *PromptSuggestionService {
+	svc := &PromptSuggestionService{}
+	resolver.MustAutoWire(svc)
+	return svc
+}
+
+// Enabled 指定类型的用户是否可以使用追问建议
+func (svc *PromptSuggestionService) Enabled(userType int64) bool {
+	return svc.conf.PromptSuggestionEnabled(userType)
+}
+
+// Suggest 为 AI 回复的消息生成追问建议，同一条消息的结果会被缓存
+func (svc *PromptSuggestionService) Suggest(ctx context.Context, userID, messageID int64) ([]string, error) {
+	if svc.conf.PromptSuggestionModel == "" {
+		return nil, ErrSuggestionDisabled
+	}
+
+	cacheKey := fmt.Sprintf("prompt-suggestion:%d:%d", userID, messageID)
+	if cached, err := svc.rep.Cache.Get(ctx, cacheKey); err == nil {
+		var suggestions []string
+		if err := json.Unmarshal([]byte(cached), &suggestions); err == nil {
+			return suggestions, nil
+		}
+	}
+
+	question, answer, err := svc.rep.Message.QuestionAnswer(ctx, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(answer) == "" {
+		return []string{}, nil
+	}
+
+	req := chat.Request{
+		Model: svc.conf.PromptSuggestionModel,
+		Messages: chat.Messages{
+			{Role: "system", Content: suggestionSystemPrompt},
+			{Role: "user", Content: BuildSuggestionPrompt(question, answer)},
+		},
+	}.Init()
+
+	chatCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := svc.ct.Chat(chatCtx, req)
+	if err != nil {
+		return nil, fmt.Errorf("suggestion chat failed: %w", err)
+	}
+
+	if resp.ErrorCode != "" {
+		return nil, fmt.Errorf("suggestion chat failed: %s %s", resp.ErrorCode, resp.Error)
+	}
+
+	suggestions := ParseSuggestions(resp.Text)
+	if data, err := json.Marshal(suggestions); err == nil {
+		if err := svc.rep.Cache.Set(ctx, cacheKey, string(data), promptSuggestionCacheTTL); err != nil {
+			log.F(log.M{"message_id": messageID}).Errorf("cache prompt suggestions failed: %s", err)
+		}
+	}
+
+	return suggestions, nil
+}
+
+const suggestionSystemPrompt = `Based on the conversation, suggest up to 3 short follow-up questions the user is most likely to ask next. Each question must be under 30 words and written in the same language as the user's question.
+Output only a JSON array of strings, for example: ["question 1", "question 2"]`
+
+// BuildSuggestionPrompt 构建生成追问建议的提示语，过长的内容会被截断
+func BuildSuggestionPrompt(question, answer string) string {
+	var sb strings.Builder
+	if question != "" {
+		sb.WriteString("User:\n")
+		sb.WriteString(misc.SubString(question, 1000))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("Assistant:\n")
+	sb.WriteString(misc.SubString(answer, 2000))
+
+	return sb.String()
+}
+
+// suggestionListMarker 匹配行首的列表标记，如 "1. "、"2、"、"- "
+var suggestionListMarker = regexp.MustCompile(`^(\d+[.、)]|[-*•])\s*`)
+
+// ParseSuggestions 解析模型输出的追问建议，兼容 JSON 数组以及按行输出的格式
+func ParseSuggestions(text string) []string {
+	var candidates []string
+
+	start, end := strings.Index(text, "["), strings.LastIndex(text, "]")
+	if start < 0 || end < start || json.Unmarshal([]byte(text[start:end+1]), &candidates) != nil {
+		candidates = make([]string, 0)
+		for _, line := range strings.Split(text, "\n") {
+			candidates = append(candidates, suggestionListMarker.ReplaceAllString(strings.TrimSpace(line), ""))
+		}
+	}
+
+	res := make([]string, 0, maxPromptSuggestions)
+	for _, s := range candidates {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		res = append(res, s)
+		if len(res) >= maxPromptSuggestions {
+			break
+		}
+	}
+
+	return res
+}
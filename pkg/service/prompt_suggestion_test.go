@@ -0,0 +1,21 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestParseSuggestions(t *testing.T) {
+	assert.Equal(t, []string{"如何安装 Go？", "什么是 goroutine？"}, service.ParseSuggestions("```json\n[\"如何安装 Go？\", \" \", \"什么是 goroutine？\"]\n```"))
+	assert.Equal(t, []string{"3D 打印贵吗？", "第二个问题", "第三个问题"}, service.ParseSuggestions("1. 3D 打印贵吗？\n2、第二个问题\n- 第三个问题\n4. 第四个问题"))
+	assert.Equal(t, 0, len(service.ParseSuggestions("")))
+}
+
+func TestBuildSuggestionPrompt(t *testing.T) {
+	prompt := service.BuildSuggestionPrompt("", "回答")
+	assert.False(t, strings.Contains(prompt, "User:"))
+	assert.True(t, strings.Contains(prompt, "Assistant:\n回答"))
+}
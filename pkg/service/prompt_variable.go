@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// 内置的提示语变量
+const (
+	PromptVariableDate     = "date"
+	PromptVariableTime     = "time"
+	PromptVariableWeekday  = "weekday"
+	PromptVariableUserName = "user_name"
+	// PromptVariableMemory 用户希望 AI 记住的信息，由用户通过变量管理接口设置
+	PromptVariableMemory = "memory"
+)
+
+// BuiltinPromptVariables 由服务端计算的内置变量，用户不能设置同名的自定义变量
+var BuiltinPromptVariables = []string{PromptVariableDate, PromptVariableTime, PromptVariableWeekday, PromptVariableUserName}
+
+// PromptVariableNamePattern 变量名称的格式
+var PromptVariableNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,31}$`)
+
+var promptVariablePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]{0,31})\s*\}\}`)
+
+var weekdayNames = []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"}
+
+// HasPromptVariables 文本中是否引用了提示语变量
+func HasPromptVariables(text string) bool {
+	return promptVariablePattern.MatchString(text)
+}
+
+// ExpandPromptVariables 展开文本中形如 {{name}} 的变量，未定义的变量保持原样，避免误替换代码等内容中的双花括号
+func ExpandPromptVariables(text string, vars map[string]string) string {
+	return promptVariablePattern.ReplaceAllStringFunc(text, func(s string) string {
+		if v, ok := vars[promptVariablePattern.FindStringSubmatch(s)[1]]; ok {
+			return v
+		}
+
+		return s
+	})
+}
+
+// BuiltinPromptVariableValues 计算内置变量的值
+func BuiltinPromptVariableValues(now time.Time, userName string) map[string]string {
+	return map[string]string{
+		PromptVariableDate:     now.Format("2006-01-02"),
+		PromptVariableTime:     now.Format("15:04"),
+		PromptVariableWeekday:  weekdayNames[now.Weekday()],
+		PromptVariableUserName: userName,
+	}
+}
+
+// PromptVariableService 提示语变量，聊天请求中的变量在服务端展开，使提示语可以在不同设备、不同角色之间通用
+type PromptVariableService struct {
+	rep *repo.Repository `autowire:"@"`
+}
+
+func NewPromptVariableService(resolver infra.Resolver) *PromptVariableService {
+	srv := &PromptVariableService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Values 查询用户可用的所有变量的值，包括内置变量和自定义变量，memory 未设置时展开为空
+func (srv *PromptVariableService) Values(ctx context.Context, userID int64, userName string) (map[string]string, error) {
+	custom, err := srv.rep.PromptVariable.Variables(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]string{PromptVariableMemory: ""}
+	for _, v := range custom {
+		vars[v.Name] = v.Value
+	}
+
+	for k, v := range BuiltinPromptVariableValues(time.Now(), userName) {
+		vars[k] = v
+	}
+
+	return vars, nil
+}
+
+// Expand 展开多条文本中的变量，文本中没有引用变量时不会查询数据库
+func (srv *PromptVariableService) Expand(ctx context.Context, userID int64, userName string, texts ...*string) error {
+	if !array.In(true, array.Map(texts, func(t *string, _ int) bool { return HasPromptVariables(*t) })) {
+		return nil
+	}
+
+	vars, err := srv.Values(ctx, userID, userName)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range texts {
+		if strings.Contains(*t, "{{") {
+			*t = ExpandPromptVariables(*t, vars)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestExpandPromptVariables(t *testing.T) {
+	vars := service.BuiltinPromptVariableValues(time.Date(2023, 12, 30, 9, 5, 0, 0, time.Local), "小明")
+	vars["memory"] = "喜欢简洁的回答"
+	vars["city"] = "杭州"
+
+	assert.Equal(t, "今天是 2023-12-30 星期六 09:05", service.ExpandPromptVariables("今天是 {{date}} {{ weekday }} {{time}}", vars))
+	assert.Equal(t, "你好 小明，你住在杭州。记住：喜欢简洁的回答", service.ExpandPromptVariables("你好 {{user_name}}，你住在{{city}}。记住：{{memory}}", vars))
+	assert.Equal(t, "{{unknown}} 与 {{ x-y }} 保持原样", service.ExpandPromptVariables("{{unknown}} 与 {{ x-y }} 保持原样", vars))
+
+	assert.True(t, service.HasPromptVariables("hi {{date}}"))
+	assert.True(t, !service.HasPromptVariables("func() { return {} }"))
+}
@@ -1,6 +1,13 @@
 package service
 
-import "github.com/mylxsw/glacier/infra"
+import (
+	"context"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
 
 type Provider struct{}
 
@@ -9,4 +16,55 @@ func (Provider) Register(binder infra.Binder) {
 	binder.MustSingleton(NewSecurityService)
 	binder.MustSingleton(NewGalleryService)
 	binder.MustSingleton(NewChatService)
+	binder.MustSingleton(NewGroupJudgeService)
+	binder.MustSingleton(NewGroupConsensusService)
+	binder.MustSingleton(NewPromptSuggestionService)
+	binder.MustSingleton(NewAchievementService)
+	binder.MustSingleton(NewOrgPolicyService)
+	binder.MustSingleton(NewRestrictedModeService)
+	binder.MustSingleton(NewKeywordFilterService)
+	binder.MustSingleton(func(srv *KeywordFilterService) chat.ContentFilter { return srv.ChatFilter() })
+	binder.MustSingleton(NewAbuseDetectService)
+	binder.MustSingleton(NewGeoPolicyService)
+	binder.MustSingleton(NewRoutingRuleService)
+	binder.MustSingleton(NewModelCostService)
+	binder.MustSingleton(NewPromptVariableService)
+	binder.MustSingleton(NewNoticeService)
+	binder.MustSingleton(NewVersionPolicyService)
+	binder.MustSingleton(NewQuotaRefundService)
+	binder.MustSingleton(NewLogLevelService)
+	binder.MustSingleton(NewMagicPromptService)
+	binder.MustSingleton(NewBugReportService)
+	binder.MustSingleton(NewMessageCardService)
+	binder.MustSingleton(NewMarkdownService)
+	binder.MustSingleton(NewExchangeRateService)
+	binder.MustSingleton(NewQuotaForecastService)
+	binder.MustSingleton(NewModelTrialService)
+	binder.MustSingleton(NewGlossaryService)
+	binder.MustSingleton(NewWritingToolService)
+	binder.MustSingleton(NewStudyService)
+	binder.MustSingleton(NewTutorService)
+	binder.MustSingleton(NewMockInterviewService)
+	binder.MustSingleton(NewOnboardingService)
+	binder.MustSingleton(NewBetaService)
+}
+
+// Daemon 定时同步管理员调整的日志级别
+func (Provider) Daemon(ctx context.Context, resolver infra.Resolver) {
+	resolver.MustResolve(func(srv *LogLevelService) {
+		ticker := time.NewTicker(logLevelSyncInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := srv.Sync(ctx); err != nil {
+				log.Errorf("sync log level failed: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
 }
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// quotaForecastWindow 估算消耗速度时参考最近多少天的消耗
+	quotaForecastWindow = 28
+	// quotaForecastHalfLife 消耗速度指数加权平均的半衰期（天）
+	quotaForecastHalfLife = 7
+	// quotaForecastCacheTTL 消耗速度的缓存有效期，由每日定时任务刷新
+	quotaForecastCacheTTL = 36 * time.Hour
+	// quotaForecastCoverDays 推荐充值的产品至少能够使用多少天
+	quotaForecastCoverDays = 30
+)
+
+// QuotaUsageRate 用户智慧果的消耗速度
+type QuotaUsageRate struct {
+	DailyUsage float64   `json:"daily_usage"`
+	Days       int       `json:"days"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// QuotaForecast 用户智慧果余额的使用预测
+type QuotaForecast struct {
+	Balance    int64   `json:"balance"`
+	DailyUsage float64 `json:"daily_usage"`
+	// DaysRemaining 余额预计还能使用的天数，-1 表示近期没有消耗，无法估算
+	DaysRemaining int64      `json:"days_remaining"`
+	RunOutAt      *time.Time `json:"run_out_at,omitempty"`
+	ComputedAt    time.Time  `json:"computed_at"`
+	// Recommended 推荐充值的产品，余额足够使用 quotaForecastCoverDays 天时为空
+	Recommended *coins.Product `json:"recommended,omitempty"`
+}
+
+// QuotaForecastService 根据用户近期的消耗速度预测智慧果余额可以使用的时间
+type QuotaForecastService struct {
+	rep *repo.Repository `autowire:"@"`
+}
+
+func NewQuotaForecastService(resolver infra.Resolver) *QuotaForecastService {
+	srv := &QuotaForecastService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+func (srv *QuotaForecastService) cacheKey(userID int64) string {
+	return fmt.Sprintf("quota-forecast:%d", userID)
+}
+
+// UsageRate 返回用户的消耗速度，优先使用定时任务计算的缓存，缓存不存在时立即计算
+func (srv *QuotaForecastService) UsageRate(ctx context.Context, userID int64) (*QuotaUsageRate, error) {
+	data, err := srv.rep.Cache.Get(ctx, srv.cacheKey(userID))
+	if err == nil {
+		var rate QuotaUsageRate
+		if err := json.Unmarshal([]byte(data), &rate); err == nil {
+			return &rate, nil
+		}
+	} else if err != repo.ErrNotFound {
+		log.F(log.M{"user_id": userID}).Warningf("load quota usage rate failed: %v", err)
+	}
+
+	return srv.RefreshUsageRate(ctx, userID)
+}
+
+// RefreshUsageRate 根据每日配额使用统计重新计算用户的消耗速度并写入缓存
+func (srv *QuotaForecastService) RefreshUsageRate(ctx context.Context, userID int64) (*QuotaUsageRate, error) {
+	user, err := srv.rep.User.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query user failed: %w", err)
+	}
+
+	stats, err := srv.rep.Quota.GetQuotaStatisticsRecently(ctx, userID, quotaForecastWindow+1)
+	if err != nil {
+		return nil, fmt.Errorf("query quota statistics failed: %w", err)
+	}
+
+	used := make(map[string]int64)
+	for _, stat := range stats {
+		used[stat.CalDate.Format("2006-01-02")] += stat.Used
+	}
+
+	// 从昨天开始往前统计，新用户只统计注册之后的日期，避免注册前的空白日期拉低消耗速度
+	usages := make([]int64, 0, quotaForecastWindow)
+	for i := 1; i <= quotaForecastWindow; i++ {
+		date := time.Now().AddDate(0, 0, -i)
+		if len(usages) > 0 && date.Format("2006-01-02") < user.CreatedAt.Format("2006-01-02") {
+			break
+		}
+
+		usages = append(usages, used[date.Format("2006-01-02")])
+	}
+
+	rate := QuotaUsageRate{
+		DailyUsage: coins.ForecastDailyUsage(usages, quotaForecastHalfLife),
+		Days:       len(usages),
+		ComputedAt: time.Now(),
+	}
+
+	data, _ := json.Marshal(rate)
+	if err := srv.rep.Cache.Set(ctx, srv.cacheKey(userID), string(data), quotaForecastCacheTTL); err != nil {
+		log.F(log.M{"user_id": userID}).Warningf("save quota usage rate failed: %v", err)
+	}
+
+	return &rate, nil
+}
+
+// Forecast 预测用户当前的智慧果余额可以使用的时间，并推荐指定地区可见的充值产品
+func (srv *QuotaForecastService) Forecast(ctx context.Context, userID int64, region string) (*QuotaForecast, error) {
+	rate, err := srv.UsageRate(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotas, err := srv.rep.Quota.GetUserQuotaDetails(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query user quota failed: %w", err)
+	}
+
+	var balance int64
+	balances := make([]coins.Balance, 0, len(quotas))
+	for _, quota := range quotas {
+		if quota.Expired {
+			continue
+		}
+
+		// 负余额（欠费）需要先补足
+		balance += quota.Rest
+		balances = append(balances, coins.Balance{Rest: quota.Rest, EndAt: quota.PeriodEndAt})
+	}
+
+	forecast := QuotaForecast{
+		Balance:       balance,
+		DailyUsage:    math.Round(rate.DailyUsage*100) / 100,
+		DaysRemaining: -1,
+		ComputedAt:    rate.ComputedAt,
+	}
+
+	now := time.Now()
+	if balance <= 0 {
+		forecast.RunOutAt, forecast.DaysRemaining = &now, 0
+	} else if runOutAt, ok := coins.ForecastRunOut(now, rate.DailyUsage, balances); ok {
+		forecast.RunOutAt = &runOutAt
+		forecast.DaysRemaining = int64(runOutAt.Sub(now).Hours() / 24)
+	}
+
+	if forecast.DaysRemaining >= 0 && forecast.DaysRemaining < quotaForecastCoverDays {
+		need := int64(math.Ceil(rate.DailyUsage*quotaForecastCoverDays)) - balance
+
+		products := array.Filter(coins.Products, func(p coins.Product, _ int) bool { return p.VisibleIn(region) })
+		forecast.Recommended = coins.RecommendProduct(products, need)
+	}
+
+	return &forecast, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+// UpstreamFailureRefund 计算上游服务在输出过程中失败时应当退还的智慧果，只按照已经输出的内容计费，退还上下文（输入）部分的费用
+func UpstreamFailureRefund(model string, inputTokens, totalTokens, charged int64) int64 {
+	outputTokens := totalTokens - inputTokens
+	if outputTokens < 0 {
+		outputTokens = 0
+	}
+
+	billable := coins.GetOpenAITextCoins(model, outputTokens)
+	if billable >= charged {
+		return 0
+	}
+
+	return charged - billable
+}
+
+// QuotaRefundService 上游服务失败时自动退还未消费的智慧果
+type QuotaRefundService struct {
+	rep       *repo.Repository  `autowire:"@"`
+	modelCost *ModelCostService `autowire:"@"`
+}
+
+func NewQuotaRefundService(resolver infra.Resolver) *QuotaRefundService {
+	srv := &QuotaRefundService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// RefundUpstreamFailure 上游服务在输出过程中失败时，在正常扣费后退还未消费的部分，返回退还的智慧果数量
+func (srv *QuotaRefundService) RefundUpstreamFailure(ctx context.Context, userID int64, model string, inputTokens, totalTokens, charged int64, reason string) int64 {
+	refunded := UpstreamFailureRefund(model, inputTokens, totalTokens, charged)
+	if refunded <= 0 {
+		return 0
+	}
+
+	refund := repo.QuotaRefund{
+		UserID:   userID,
+		OrgID:    repo.BillingOrgFromContext(ctx),
+		Model:    model,
+		Provider: srv.modelCost.ProviderOf(model),
+		Charged:  charged,
+		Refunded: refunded,
+		Reason:   reason,
+	}
+
+	if _, err := srv.rep.QuotaRefund.Refund(ctx, refund); err != nil {
+		log.F(log.M{"refund": refund}).Errorf("refund quota for upstream failure failed: %v", err)
+		return 0
+	}
+
+	log.F(log.M{"refund": refund}).Info("quota refunded for upstream failure")
+	return refunded
+}
@@ -0,0 +1,23 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestUpstreamFailureRefund(t *testing.T) {
+	charged := coins.GetOpenAITextCoins("gpt-4", 3000)
+
+	// 只按照已经输出的 500 个 Token 计费
+	refunded := service.UpstreamFailureRefund("gpt-4", 2500, 3000, charged)
+	assert.Equal(t, charged-coins.GetOpenAITextCoins("gpt-4", 500), refunded)
+
+	// 没有扣费时不退款
+	assert.Equal(t, int64(0), service.UpstreamFailureRefund("gpt-4", 2500, 3000, 0))
+
+	// 输入 Token 数量异常时按照没有输出计算
+	assert.Equal(t, charged, service.UpstreamFailureRefund("gpt-4", 4000, 3000, charged))
+}
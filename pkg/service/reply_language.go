@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/asteria/log"
+)
+
+// ReplyLanguageOff 会话设置为不指定回复语言
+const ReplyLanguageOff = "off"
+
+// ReplyLanguage 支持的回复语言
+type ReplyLanguage struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Prompt 注入到系统提示语中的语言名称
+	Prompt string `json:"-"`
+}
+
+var replyLanguages = []ReplyLanguage{
+	{Code: "zh", Name: "中文", Prompt: "Simplified Chinese"},
+	{Code: "en", Name: "English", Prompt: "English"},
+	{Code: "ja", Name: "日本語", Prompt: "Japanese"},
+	{Code: "ko", Name: "한국어", Prompt: "Korean"},
+	{Code: "fr", Name: "Français", Prompt: "French"},
+	{Code: "de", Name: "Deutsch", Prompt: "German"},
+	{Code: "es", Name: "Español", Prompt: "Spanish"},
+	{Code: "pt", Name: "Português", Prompt: "Portuguese"},
+	{Code: "it", Name: "Italiano", Prompt: "Italian"},
+	{Code: "ru", Name: "Русский", Prompt: "Russian"},
+	{Code: "ar", Name: "العربية", Prompt: "Arabic"},
+	{Code: "th", Name: "ไทย", Prompt: "Thai"},
+}
+
+// ReplyLanguages 支持的回复语言列表
+func ReplyLanguages() []ReplyLanguage {
+	return replyLanguages
+}
+
+// GetReplyLanguage 根据语言代码查询回复语言，不支持的语言返回 nil
+func GetReplyLanguage(code string) *ReplyLanguage {
+	for _, lang := range replyLanguages {
+		if lang.Code == code {
+			return &lang
+		}
+	}
+
+	return nil
+}
+
+// latinStopWords 使用拉丁字母的语言的常用词，用于区分这些语言
+var latinStopWords = map[string][]string{
+	"en": {"the", "and", "is", "are", "what", "how", "you", "this", "that", "with", "for", "can", "please", "of", "to", "my", "it", "in", "do", "why"},
+	"fr": {"le", "la", "les", "est", "et", "des", "une", "un", "je", "vous", "pour", "que", "qui", "dans", "pas", "avec", "comment", "du"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "sie", "wie", "was", "mit", "ein", "eine", "für", "auf", "bitte", "den"},
+	"es": {"el", "la", "los", "las", "es", "y", "que", "de", "una", "por", "para", "cómo", "qué", "con", "mi", "del", "está", "puedes"},
+	"pt": {"o", "os", "as", "é", "e", "que", "de", "uma", "um", "para", "como", "com", "não", "meu", "do", "da", "você", "por"},
+	"it": {"il", "lo", "gli", "è", "e", "che", "di", "una", "un", "per", "come", "con", "non", "mio", "del", "della", "sono"},
+}
+
+var (
+	codeBlockRegexp = regexp.MustCompile("(?s)```.*?```")
+	inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+	urlRegexp       = regexp.MustCompile(`https?://\S+`)
+)
+
+// DetectLanguage 根据文字使用的字符集以及常用词检测语言，无法确定时（例如内容太短、只有代码）返回空字符串
+func DetectLanguage(text string) string {
+	text = codeBlockRegexp.ReplaceAllString(text, " ")
+	text = inlineCodeRegex.ReplaceAllString(text, " ")
+	text = urlRegexp.ReplaceAllString(text, " ")
+
+	var han, kana, hangul, thai int
+	var cyrillic, arabic, latin []string
+
+	// 表意文字以及泰文按照字符计数，其它文字按照单词计数
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.Is(unicode.Mn, r)
+	}) {
+		var wordCyrillic, wordArabic, wordLatin bool
+		for _, r := range word {
+			switch {
+			case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+				kana++
+			case unicode.Is(unicode.Han, r):
+				han++
+			case unicode.Is(unicode.Hangul, r):
+				hangul++
+			case unicode.Is(unicode.Thai, r):
+				thai++
+			case unicode.Is(unicode.Cyrillic, r):
+				wordCyrillic = true
+			case unicode.Is(unicode.Arabic, r):
+				wordArabic = true
+			case unicode.Is(unicode.Latin, r):
+				wordLatin = true
+			}
+		}
+
+		switch {
+		case wordCyrillic:
+			cyrillic = append(cyrillic, word)
+		case wordArabic:
+			arabic = append(arabic, word)
+		case wordLatin:
+			latin = append(latin, strings.ToLower(word))
+		}
+	}
+
+	// 日文中通常混用汉字与假名
+	if kana > 0 && kana+han >= 2 && kana+han >= len(latin) {
+		return "ja"
+	}
+
+	scores := []struct {
+		lang  string
+		count int
+		min   int
+	}{
+		{"zh", han, 2},
+		{"ko", hangul, 2},
+		{"th", thai, 2},
+		{"ru", len(cyrillic), 1},
+		{"ar", len(arabic), 1},
+		{"latin", len(latin), 3},
+	}
+
+	best, bestCount := "", 0
+	for _, s := range scores {
+		if s.count >= s.min && s.count > bestCount {
+			best, bestCount = s.lang, s.count
+		}
+	}
+
+	if best != "latin" {
+		return best
+	}
+
+	return detectLatinLanguage(latin)
+}
+
+// detectLatinLanguage 根据常用词区分使用拉丁字母的语言，没有命中任何常用词时返回空字符串
+func detectLatinLanguage(words []string) string {
+	counts := make(map[string]int)
+	for _, word := range words {
+		for lang, stopWords := range latinStopWords {
+			for _, w := range stopWords {
+				if w == word {
+					counts[lang]++
+				}
+			}
+		}
+	}
+
+	best, bestCount := "", 0
+	for _, lang := range []string{"en", "fr", "de", "es", "pt", "it"} {
+		if counts[lang] > bestCount {
+			best, bestCount = lang, counts[lang]
+		}
+	}
+
+	return best
+}
+
+// ResolveReplyLanguage 确定本次请求的回复语言：用户设置优先，其次是本次提问检测到的语言，最后是会话之前检测到的语言
+func ResolveReplyLanguage(preferred, detected, previous string) string {
+	if preferred == ReplyLanguageOff {
+		return ""
+	}
+
+	if preferred != "" {
+		return preferred
+	}
+
+	if detected != "" {
+		return detected
+	}
+
+	return previous
+}
+
+// ApplyReplyLanguage 在系统消息的末尾追加回复语言要求，避免模型在多轮对话中切换回复语言
+func ApplyReplyLanguage(messages chat.Messages, code string) chat.Messages {
+	lang := GetReplyLanguage(code)
+	if lang == nil {
+		return messages
+	}
+
+	instruction := fmt.Sprintf("Always reply in %s, unless the user explicitly asks for a different language.", lang.Prompt)
+	if len(messages) > 0 && messages[0].Role == "system" {
+		res := append(chat.Messages{}, messages...)
+		res[0].Content = res[0].Content + "\n\n" + instruction
+		return res
+	}
+
+	res := make(chat.Messages, 0, len(messages)+1)
+	res = append(res, chat.Message{Role: "system", Content: instruction})
+	return append(res, messages...)
+}
+
+// ReplyLanguage 检测用户提问的语言，返回会话本次应当使用的回复语言，检测到的语言发生变化时保存到会话中
+func (svc *ChatService) ReplyLanguage(ctx context.Context, userID, roomID int64, prompt string) string {
+	detected := DetectLanguage(prompt)
+
+	// 默认会话不保存设置
+	if roomID <= 1 {
+		return detected
+	}
+
+	room, err := svc.Room(ctx, userID, roomID)
+	if err != nil {
+		log.F(log.M{"user_id": userID, "room_id": roomID}).Errorf("query room failed: %v", err)
+		return detected
+	}
+
+	if detected != "" && detected != room.DetectedLanguage {
+		if err := svc.rep.Room.UpdateDetectedLanguage(ctx, userID, roomID, detected); err != nil {
+			log.F(log.M{"user_id": userID, "room_id": roomID}).Errorf("update room detected language failed: %v", err)
+		} else {
+			svc.ForgetRoom(ctx, userID, roomID)
+		}
+	}
+
+	return ResolveReplyLanguage(room.ReplyLanguage, detected, room.DetectedLanguage)
+}
@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	assert.Equal(t, "zh", service.DetectLanguage("帮我解释一下这段话：The quick brown fox jumps over the lazy dog"))
+	assert.Equal(t, "ja", service.DetectLanguage("このコードを説明してください"))
+	assert.Equal(t, "ko", service.DetectLanguage("안녕하세요, 도와주세요"))
+	assert.Equal(t, "ru", service.DetectLanguage("Привет, как дела?"))
+	assert.Equal(t, "en", service.DetectLanguage("How do I reverse a list in Python?"))
+	assert.Equal(t, "fr", service.DetectLanguage("Comment je peux faire une tarte aux pommes ?"))
+	assert.Equal(t, "de", service.DetectLanguage("Wie spät ist es bitte?"))
+
+	// 内容太短或者只有代码时无法确定
+	assert.Equal(t, "", service.DetectLanguage("ok"))
+	assert.Equal(t, "", service.DetectLanguage("```go\nfunc main() { fmt.Println(\"hello world\") }\n```"))
+}
+
+func TestResolveReplyLanguage(t *testing.T) {
+	assert.Equal(t, "ja", service.ResolveReplyLanguage("ja", "en", "zh"))
+	assert.Equal(t, "", service.ResolveReplyLanguage(service.ReplyLanguageOff, "en", "zh"))
+	assert.Equal(t, "en", service.ResolveReplyLanguage("", "en", "zh"))
+	assert.Equal(t, "zh", service.ResolveReplyLanguage("", "", "zh"))
+}
+
+func TestApplyReplyLanguage(t *testing.T) {
+	messages := chat.Messages{{Role: "system", Content: "You are a translator."}, {Role: "user", Content: "hello"}}
+
+	res := service.ApplyReplyLanguage(messages, "zh")
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, "You are a translator.\n\nAlways reply in Simplified Chinese, unless the user explicitly asks for a different language.", res[0].Content)
+	assert.Equal(t, "You are a translator.", messages[0].Content)
+
+	res = service.ApplyReplyLanguage(chat.Messages{{Role: "user", Content: "hello"}}, "en")
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, "system", res[0].Role)
+
+	assert.Equal(t, 1, len(service.ApplyReplyLanguage(chat.Messages{{Role: "user", Content: "hello"}}, "")))
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// RestrictedModeViolationError 请求被受限模式（家长模式）拦截
+type RestrictedModeViolationError struct {
+	Reason string
+}
+
+func (e *RestrictedModeViolationError) Error() string {
+	return e.Reason
+}
+
+// RestrictedModeService 受限模式（家长模式）检查
+type RestrictedModeService struct {
+	conf        *config.Config   `autowire:"@"`
+	rep         *repo.Repository `autowire:"@"`
+	securitySrv *SecurityService `autowire:"@"`
+}
+
+func NewRestrictedModeService(resolver infra.Resolver) *RestrictedModeService {
+	srv := &RestrictedModeService{}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// CheckChat 检查聊天请求，用户开启受限模式时，只允许使用安全模型列表中的模型，
+// 内容安全检测存在任何风险时都拒绝请求，并且每日使用量不能超过上限
+func (srv *RestrictedModeService) CheckChat(ctx context.Context, userID int64, model string, content string) error {
+	return srv.check(ctx, userID, func() error {
+		if !array.In(model, srv.conf.RestrictedModeModels) {
+			return &RestrictedModeViolationError{Reason: fmt.Sprintf("受限模式下不允许使用模型 %s", model)}
+		}
+
+		if content != "" {
+			if res := srv.securitySrv.ChatDetect(content); res != nil && !res.Safe {
+				return &RestrictedModeViolationError{Reason: "受限模式下请求内容未通过内容安全检测"}
+			}
+		}
+
+		return nil
+	})
+}
+
+// CheckImage 检查图片生成请求，用户开启受限模式时，只允许使用自带内容安全过滤的图片生成服务商
+func (srv *RestrictedModeService) CheckImage(ctx context.Context, userID int64, vendor string, prompt string) error {
+	return srv.check(ctx, userID, func() error {
+		if !array.In(vendor, srv.conf.RestrictedModeImageVendors) {
+			return &RestrictedModeViolationError{Reason: "受限模式下不允许使用该创作模式"}
+		}
+
+		if prompt != "" {
+			if res := srv.securitySrv.PromptDetect(prompt); res != nil && !res.Safe {
+				return &RestrictedModeViolationError{Reason: "受限模式下请求内容未通过内容安全检测"}
+			}
+		}
+
+		return nil
+	})
+}
+
+// CheckUnsupported 检查受限模式下不支持的功能，例如无法在请求之前确定使用的模型或者审核内容的功能，用户开启受限模式时直接拒绝请求
+func (srv *RestrictedModeService) CheckUnsupported(ctx context.Context, userID int64) error {
+	return srv.check(ctx, userID, func() error {
+		return &RestrictedModeViolationError{Reason: "受限模式下不支持该功能"}
+	})
+}
+
+// CheckDailyLimit 只检查受限模式下的每日使用量，用于已经检查过模型与内容的请求，例如确认创作岛报价
+func (srv *RestrictedModeService) CheckDailyLimit(ctx context.Context, userID int64) error {
+	return srv.check(ctx, userID, func() error { return nil })
+}
+
+func (srv *RestrictedModeService) check(ctx context.Context, userID int64, checker func() error) error {
+	mode, err := srv.rep.RestrictedMode.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("query restricted mode failed: %w", err)
+	}
+
+	if err := checker(); err != nil {
+		log.F(log.M{"user_id": userID}).Warningf("请求被受限模式拦截: %v", err)
+		return err
+	}
+
+	if mode.DailyLimit > 0 {
+		used, err := srv.rep.RestrictedMode.TodayUsed(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("query today used failed: %w", err)
+		}
+
+		if used >= mode.DailyLimit {
+			return &RestrictedModeViolationError{Reason: "受限模式下今日使用额度已达上限"}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// routingRuleReloadInterval 模型路由规则的重新加载周期
+const routingRuleReloadInterval = time.Minute
+
+// RoutingRegionEU 路由规则中代表所有欧盟成员国的地区
+const RoutingRegionEU = "EU"
+
+// euRegions 欧盟成员国 ISO 3166-1 国家代码
+var euRegions = []string{
+	"AT", "BE", "BG", "HR", "CY", "CZ", "DK", "EE", "FI", "FR", "DE", "GR", "HU", "IE",
+	"IT", "LV", "LT", "LU", "MT", "NL", "PL", "PT", "RO", "SK", "SI", "ES", "SE",
+}
+
+// RoutingInput 模型路由规则的匹配输入
+type RoutingInput struct {
+	Model        string `json:"model"`
+	PromptTokens int64  `json:"prompt_tokens"`
+	UserLevel    string `json:"user_level,omitempty"`
+	Region       string `json:"region,omitempty"`
+}
+
+// RoutingDecision 模型路由的决策结果
+type RoutingDecision struct {
+	// Model 路由后实际使用的模型
+	Model string `json:"model"`
+	// Routed 模型是否被路由到了其它模型
+	Routed bool `json:"routed,omitempty"`
+	// RuleID 生效的规则，没有规则生效时为 0
+	RuleID   int64  `json:"rule_id,omitempty"`
+	RuleName string `json:"rule_name,omitempty"`
+}
+
+// MatchRoutingRule 判断路由规则的条件是否全部满足
+func MatchRoutingRule(rule repo.RoutingRule, in RoutingInput) bool {
+	cond := rule.Conditions
+	if len(cond.Models) > 0 && !array.In(in.Model, cond.Models) {
+		return false
+	}
+
+	if cond.MinPromptTokens > 0 && in.PromptTokens <= cond.MinPromptTokens {
+		return false
+	}
+
+	if cond.MaxPromptTokens > 0 && in.PromptTokens > cond.MaxPromptTokens {
+		return false
+	}
+
+	if len(cond.UserLevels) > 0 && !array.In(in.UserLevel, cond.UserLevels) {
+		return false
+	}
+
+	if len(cond.Regions) > 0 {
+		if in.Region == "" {
+			return false
+		}
+
+		if !array.In(in.Region, cond.Regions) && !(array.In(RoutingRegionEU, cond.Regions) && array.In(in.Region, euRegions)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EvaluateRoutingRules 按照规则顺序（优先级从高到低）匹配路由规则，第一条满足条件的规则生效
+func EvaluateRoutingRules(rules []repo.RoutingRule, in RoutingInput) RoutingDecision {
+	decision := RoutingDecision{Model: in.Model}
+	for _, rule := range rules {
+		if rule.Status != repo.RoutingRuleStatusEnabled || rule.TargetModel == "" || !MatchRoutingRule(rule, in) {
+			continue
+		}
+
+		decision.RuleID, decision.RuleName = rule.ID, rule.Name
+		if rule.TargetModel != in.Model {
+			decision.Model, decision.Routed = rule.TargetModel, true
+		}
+
+		return decision
+	}
+
+	return decision
+}
+
+// RoutingRuleService 模型路由规则，在请求分发到模型之前根据请求特征将请求路由到其它模型
+type RoutingRuleService struct {
+	rep *repo.Repository `autowire:"@"`
+
+	lock     sync.RWMutex
+	rules    []repo.RoutingRule
+	loadedAt time.Time
+}
+
+func NewRoutingRuleService(resolver infra.Resolver) *RoutingRuleService {
+	srv := &RoutingRuleService{rules: []repo.RoutingRule{}}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Reload 从数据库重新加载模型路由规则
+func (srv *RoutingRuleService) Reload(ctx context.Context) error {
+	rules, err := srv.rep.RoutingRule.Rules(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.rules, srv.loadedAt = rules, time.Now()
+	return nil
+}
+
+// Rules 当前生效的模型路由规则
+func (srv *RoutingRuleService) Rules(ctx context.Context) []repo.RoutingRule {
+	srv.lock.RLock()
+	loadedAt := srv.loadedAt
+	srv.lock.RUnlock()
+
+	if time.Since(loadedAt) >= routingRuleReloadInterval {
+		if err := srv.Reload(ctx); err != nil {
+			log.Errorf("reload routing rules failed: %v", err)
+
+			// 加载失败时继续使用旧的规则，避免每次请求都访问数据库
+			srv.lock.Lock()
+			srv.loadedAt = time.Now()
+			srv.lock.Unlock()
+		}
+	}
+
+	srv.lock.RLock()
+	defer srv.lock.RUnlock()
+
+	return srv.rules
+}
+
+// UserLevel 查询用户等级，存在未过期的付费配额时为 paid，否则为 free
+func (srv *RoutingRuleService) UserLevel(ctx context.Context, userID int64) string {
+	quotas, err := srv.rep.Quota.GetUserQuotaDetails(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("query user quota details failed: %v", err)
+		return repo.RoutingUserLevelFree
+	}
+
+	for _, q := range quotas {
+		if q.PaymentId != "" && !q.Expired {
+			return repo.RoutingUserLevelPaid
+		}
+	}
+
+	return repo.RoutingUserLevelFree
+}
+
+// Decide 计算请求实际使用的模型，只有存在按照用户等级匹配的规则时才会查询用户等级
+func (srv *RoutingRuleService) Decide(ctx context.Context, userID int64, in RoutingInput) RoutingDecision {
+	rules := srv.Rules(ctx)
+	if len(rules) == 0 {
+		return RoutingDecision{Model: in.Model}
+	}
+
+	if in.UserLevel == "" {
+		for _, rule := range rules {
+			if len(rule.Conditions.UserLevels) > 0 {
+				in.UserLevel = srv.UserLevel(ctx, userID)
+				break
+			}
+		}
+	}
+
+	return EvaluateRoutingRules(rules, in)
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestEvaluateRoutingRules(t *testing.T) {
+	rules := []repo.RoutingRule{
+		{
+			ID:          1,
+			Name:        "eu-azure",
+			Priority:    100,
+			Conditions:  repo.RoutingConditions{Regions: []string{service.RoutingRegionEU}},
+			TargetModel: "azure-eu-gpt-4",
+			Status:      repo.RoutingRuleStatusEnabled,
+		},
+		{
+			ID:          2,
+			Name:        "long-context",
+			Priority:    50,
+			Conditions:  repo.RoutingConditions{Models: []string{"gpt-3.5-turbo"}, MinPromptTokens: 3000},
+			TargetModel: "gpt-3.5-turbo-16k",
+			Status:      repo.RoutingRuleStatusEnabled,
+		},
+		{
+			ID:          3,
+			Name:        "free-mini",
+			Priority:    10,
+			Conditions:  repo.RoutingConditions{UserLevels: []string{repo.RoutingUserLevelFree}},
+			TargetModel: "gpt-3.5-turbo",
+			Status:      repo.RoutingRuleStatusEnabled,
+		},
+		{
+			ID:          4,
+			Name:        "disabled",
+			Priority:    1000,
+			TargetModel: "gpt-4",
+			Status:      repo.RoutingRuleStatusDisabled,
+		},
+	}
+
+	decision := service.EvaluateRoutingRules(rules, service.RoutingInput{Model: "gpt-4", Region: "DE", UserLevel: repo.RoutingUserLevelFree})
+	assert.True(t, decision.Routed)
+	assert.Equal(t, "azure-eu-gpt-4", decision.Model)
+	assert.EqualValues(t, 1, decision.RuleID)
+
+	decision = service.EvaluateRoutingRules(rules, service.RoutingInput{Model: "gpt-3.5-turbo", PromptTokens: 5000, Region: "US"})
+	assert.Equal(t, "gpt-3.5-turbo-16k", decision.Model)
+	assert.EqualValues(t, 2, decision.RuleID)
+
+	decision = service.EvaluateRoutingRules(rules, service.RoutingInput{Model: "gpt-4", PromptTokens: 5000, UserLevel: repo.RoutingUserLevelFree})
+	assert.Equal(t, "gpt-3.5-turbo", decision.Model)
+	assert.EqualValues(t, 3, decision.RuleID)
+
+	// 规则匹配但目标模型与请求模型相同，不视为路由
+	decision = service.EvaluateRoutingRules(rules, service.RoutingInput{Model: "gpt-3.5-turbo", PromptTokens: 100, UserLevel: repo.RoutingUserLevelFree})
+	assert.False(t, decision.Routed)
+	assert.EqualValues(t, 3, decision.RuleID)
+
+	decision = service.EvaluateRoutingRules(rules, service.RoutingInput{Model: "gpt-4", UserLevel: repo.RoutingUserLevelPaid})
+	assert.False(t, decision.Routed)
+	assert.Equal(t, "gpt-4", decision.Model)
+	assert.EqualValues(t, 0, decision.RuleID)
+}
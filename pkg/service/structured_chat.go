@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/asteria/log"
+)
+
+// structuredChat 请求模型输出符合 req.ResponseFormat 要求的 JSON，校验失败时将失败原因反馈给模型重新生成，
+// 返回最后一次请求的响应以及校验通过的 JSON
+//
+// 重试请求的上下文中包含之前不符合要求的输出，调用方计费时应当按照原始请求与最终输出计算 Token，重试产生的消耗不向用户收取
+func structuredChat(ctx context.Context, ct chat.Chat, req chat.Request, maxRetries int) (*chat.Response, json.RawMessage, error) {
+	schema, err := req.ParseResponseFormat()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := req.Messages
+
+	var lastErr error
+	for i := 0; i <= maxRetries; i++ {
+		attempt := req
+		attempt.Messages = messages
+
+		chatCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+		resp, err := ct.Chat(chatCtx, attempt)
+		cancel()
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("structured chat failed: %w", err)
+		}
+
+		if resp.ErrorCode != "" {
+			return nil, nil, fmt.Errorf("structured chat failed: %s %s", resp.ErrorCode, resp.Error)
+		}
+
+		structured, err := chat.ExtractJSON(resp.Text, schema)
+		if err == nil {
+			return resp, structured, nil
+		}
+
+		lastErr = err
+		log.F(log.M{"model": req.Model, "retry_times": i, "reply": resp.Text}).Warningf("structured chat output is invalid: %v", err)
+
+		messages = append(
+			append(chat.Messages{}, messages...),
+			chat.Message{Role: "assistant", Content: resp.Text},
+			chat.Message{Role: "user", Content: fmt.Sprintf("你的输出不符合要求：%v。请重新输出，只输出符合要求的 JSON。", err)},
+		)
+	}
+
+	return nil, nil, lastErr
+}
@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestScheduleFlashcardReview(t *testing.T) {
+	now := time.Date(2024, 2, 1, 10, 0, 0, 0, time.Local)
+
+	// 新卡片：1 天、6 天，之后乘以难度系数
+	s := service.ScheduleFlashcardReview(repo.FlashcardSchedule{EaseFactor: repo.DefaultFlashcardEaseFactor}, 4, now)
+	assert.Equal(t, int64(1), s.Repetitions)
+	assert.Equal(t, int64(1), s.IntervalDays)
+	assert.Equal(t, int64(2500), s.EaseFactor)
+	assert.Equal(t, now.AddDate(0, 0, 1), s.DueAt)
+
+	s = service.ScheduleFlashcardReview(s, 5, now)
+	assert.Equal(t, int64(2), s.Repetitions)
+	assert.Equal(t, int64(6), s.IntervalDays)
+	assert.Equal(t, int64(2600), s.EaseFactor)
+
+	s = service.ScheduleFlashcardReview(s, 3, now)
+	assert.Equal(t, int64(3), s.Repetitions)
+	assert.Equal(t, int64(16), s.IntervalDays)
+	assert.Equal(t, int64(2460), s.EaseFactor)
+	assert.Equal(t, now.AddDate(0, 0, 16), s.DueAt)
+
+	// 遗忘后从头开始复习，难度系数不变
+	s = service.ScheduleFlashcardReview(s, 1, now)
+	assert.Equal(t, int64(0), s.Repetitions)
+	assert.Equal(t, int64(1), s.IntervalDays)
+	assert.Equal(t, int64(2460), s.EaseFactor)
+
+	// 难度系数不低于 1.3
+	s = service.ScheduleFlashcardReview(repo.FlashcardSchedule{Repetitions: 3, IntervalDays: 10, EaseFactor: 1350}, 3, now)
+	assert.Equal(t, int64(1300), s.EaseFactor)
+	assert.Equal(t, int64(14), s.IntervalDays)
+}
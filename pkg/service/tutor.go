@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+)
+
+var ErrTutorDisabled = errors.New("tutor mode is disabled")
+
+const (
+	// tutorContextMessages 辅导时使用的最近消息数量
+	tutorContextMessages = 20
+	// tutorMaxRetries 模型输出校验失败时的最大重试次数
+	tutorMaxRetries = 1
+	// tutorReplyTokens 每次辅导回复预估的 Token 数量
+	tutorReplyTokens = 300
+)
+
+// TutorSubject 作业辅导支持的学科
+type TutorSubject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TutorSubjects 作业辅导支持的学科，按照展示顺序排列
+var TutorSubjects = []TutorSubject{
+	{ID: "math", Name: "数学"},
+	{ID: "chinese", Name: "语文"},
+	{ID: "english", Name: "英语"},
+	{ID: "physics", Name: "物理"},
+	{ID: "chemistry", Name: "化学"},
+	{ID: "biology", Name: "生物"},
+	{ID: "history", Name: "历史"},
+	{ID: "geography", Name: "地理"},
+	{ID: "other", Name: "其它"},
+}
+
+// TutorSubjectName 学科的名称，不支持的学科返回 false
+func TutorSubjectName(id string) (string, bool) {
+	for _, subject := range TutorSubjects {
+		if subject.ID == id {
+			return subject.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// tutorSubjectPromptNames 提示语中使用的学科名称
+var tutorSubjectPromptNames = map[string]string{
+	"math":      "math",
+	"chinese":   "Chinese language and literature",
+	"english":   "English",
+	"physics":   "physics",
+	"chemistry": "chemistry",
+	"biology":   "biology",
+	"history":   "history",
+	"geography": "geography",
+}
+
+const tutorPrompt = `You are a patient, encouraging homework tutor for a school-age child. The current subject is %s.
+Teach step by step: in each reply give only the next single step, hint or guiding question, then stop and ask the student to try it. Do not move on to the following step until the student has attempted the current one; if the attempt is wrong, explain the mistake gently and let them try again.
+%s
+Keep replies short, simple and age-appropriate. Stay on schoolwork, and politely decline anything unsafe or unsuitable for children.
+Set "solved" to true only when the student has reached the correct final answer in their latest message.`
+
+const (
+	tutorGuidePolicy  = "Never state the final answer or a complete solution, even if the student asks for it directly or says they give up; guide them to work it out themselves."
+	tutorAnswerPolicy = "Guide the student first. If they are still stuck after trying, or ask to check their work, you may show the final answer with a short explanation."
+)
+
+const tutorSchema = `{
+  "type": "object",
+  "properties": {
+    "reply": {"type": "string", "minLength": 1},
+    "solved": {"type": "boolean"}
+  },
+  "required": ["reply", "solved"]
+}`
+
+// tutorUnsafeReply 辅导回复未通过内容安全检测时返回给用户的内容
+const tutorUnsafeReply = "这个问题我们换个角度来想吧，请把题目再描述一下～"
+
+// ResolveTutorAnswerPolicy 用户设置了有效的答案策略时使用用户的设置，否则使用系统默认策略，默认策略无效时只引导不给出答案
+func ResolveTutorAnswerPolicy(defaultPolicy, userPolicy string) string {
+	for _, policy := range []string{userPolicy, defaultPolicy} {
+		if policy == repo.TutorAnswerPolicyGuide || policy == repo.TutorAnswerPolicyAnswer {
+			return policy
+		}
+	}
+
+	return repo.TutorAnswerPolicyGuide
+}
+
+// BuildTutorMessages 按照学科与答案策略构建辅导的消息，只保留最近的对话
+func BuildTutorMessages(subject, policy string, history chat.Messages) chat.Messages {
+	policyPrompt := tutorGuidePolicy
+	if policy == repo.TutorAnswerPolicyAnswer {
+		policyPrompt = tutorAnswerPolicy
+	}
+
+	subjectName, ok := tutorSubjectPromptNames[subject]
+	if !ok {
+		subjectName = "general schoolwork"
+	}
+
+	if len(history) > tutorContextMessages {
+		history = history[len(history)-tutorContextMessages:]
+	}
+
+	messages := chat.Messages{{Role: "system", Content: fmt.Sprintf(tutorPrompt, subjectName, policyPrompt)}}
+	for _, msg := range history {
+		// 只接受用户与助手的消息，避免客户端覆盖辅导策略
+		if msg.Role == "user" || msg.Role == "assistant" {
+			messages = append(messages, chat.Message{Role: msg.Role, Content: msg.Content})
+		}
+	}
+
+	return messages
+}
+
+// TutorReply 一次辅导的回复
+type TutorReply struct {
+	Reply string `json:"reply"`
+	// Solved 学生是否已经独立得出正确答案
+	Solved        bool   `json:"solved"`
+	Policy        string `json:"policy"`
+	QuotaConsumed int64  `json:"quota_consumed"`
+}
+
+// TutorService 作业辅导模式：按照答案策略逐步引导解题，执行受限模式的安全检查，并记录分学科的学习进度
+type TutorService struct {
+	conf        *config.Config         `autowire:"@"`
+	ct          chat.Chat              `autowire:"@"`
+	rep         *repo.Repository       `autowire:"@"`
+	restricted  *RestrictedModeService `autowire:"@"`
+	securitySrv *SecurityService       `autowire:"@"`
+}
+
+func NewTutorService(resolver infra.Resolver) *TutorService {
+	svc := &TutorService{}
+	resolver.MustAutoWire(svc)
+	return svc
+}
+
+// Enabled 是否启用了作业辅导模式
+func (svc *TutorService) Enabled() bool {
+	return svc.conf.TutorModel != ""
+}
+
+// AnswerPolicy 用户当前生效的答案策略
+func (svc *TutorService) AnswerPolicy(ctx context.Context, userID int64) (string, error) {
+	setting, err := svc.rep.Tutor.TutorSetting(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return ResolveTutorAnswerPolicy(svc.conf.TutorAnswerPolicy, setting.AnswerPolicy), nil
+}
+
+// Prepare 执行安全检查，构建辅导请求并预估消耗的智慧果
+//
+// 开启受限模式的用户按照受限模式的规则检查，其它用户的提问也必须通过内容安全检测，被拦截的提问计入学习进度
+func (svc *TutorService) Prepare(ctx context.Context, userID int64, subject string, history chat.Messages) (chat.Request, int64, error) {
+	if !svc.Enabled() {
+		return chat.Request{}, 0, ErrTutorDisabled
+	}
+
+	question := history[len(history)-1].Content
+	err := svc.restricted.CheckChat(ctx, userID, svc.conf.TutorModel, question)
+	if err == nil {
+		if res := svc.securitySrv.ChatDetect(question); res != nil && !res.Safe {
+			err = &RestrictedModeViolationError{Reason: "提问内容未通过内容安全检测"}
+		}
+	}
+
+	if err != nil {
+		var violation *RestrictedModeViolationError
+		if errors.As(err, &violation) {
+			svc.addProgress(ctx, userID, subject, repo.TutorProgressDelta{Blocked: 1})
+		}
+
+		return chat.Request{}, 0, err
+	}
+
+	policy, err := svc.AnswerPolicy(ctx, userID)
+	if err != nil {
+		return chat.Request{}, 0, err
+	}
+
+	req := chat.Request{
+		Model:    svc.conf.TutorModel,
+		Messages: BuildTutorMessages(subject, policy, history),
+		ResponseFormat: &chat.ResponseFormat{
+			Type:       chat.ResponseFormatJSONSchema,
+			JSONSchema: &chat.JSONSchema{Name: "tutor_reply", Schema: json.RawMessage(tutorSchema)},
+		},
+	}.Init()
+
+	inputTokens, err := chat.MessageTokenCount(req.Messages, req.Model)
+	if err != nil {
+		return req, 0, err
+	}
+
+	return req, coins.GetOpenAITextCoins(req.Model, int64(inputTokens+tutorReplyTokens)), nil
+}
+
+// Chat 执行一步辅导，回复通过内容安全检测后才返回给用户，并按照实际消耗的 Token 扣除智慧果
+func (svc *TutorService) Chat(ctx context.Context, userID int64, subject string, req chat.Request) (*TutorReply, error) {
+	resp, structured, err := structuredChat(ctx, svc.ct, req, tutorMaxRetries)
+	if err != nil {
+		return nil, err
+	}
+
+	var reply TutorReply
+	_ = json.Unmarshal(structured, &reply)
+
+	reply.Reply = strings.TrimSpace(reply.Reply)
+	if res := svc.securitySrv.ChatDetect(reply.Reply); res != nil && !res.Safe {
+		log.F(log.M{"user_id": userID, "subject": subject}).Warningf("辅导回复未通过内容安全检测")
+		reply.Reply, reply.Solved = tutorUnsafeReply, false
+	}
+
+	if reply.Policy, err = svc.AnswerPolicy(ctx, userID); err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("查询辅导答案策略失败: %s", err)
+	}
+
+	// 重试产生的消耗不计费，按照原始请求与最终输出计算消耗的 Token
+	tokens, _ := chat.MessageTokenCount(append(req.Messages, chat.Message{Role: "assistant", Content: resp.Text}), req.Model)
+	reply.QuotaConsumed = coins.GetOpenAITextCoins(req.Model, int64(tokens))
+	if reply.QuotaConsumed > 0 {
+		if err := svc.rep.Quota.QuotaConsume(ctx, userID, reply.QuotaConsumed, repo.NewQuotaUsedMeta("tutor", req.Model)); err != nil {
+			log.F(log.M{"user_id": userID, "quota": reply.QuotaConsumed}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	delta := repo.TutorProgressDelta{Steps: 1}
+	if reply.Solved {
+		delta.Solved = 1
+	}
+
+	svc.addProgress(ctx, userID, subject, delta)
+	return &reply, nil
+}
+
+func (svc *TutorService) addProgress(ctx context.Context, userID int64, subject string, delta repo.TutorProgressDelta) {
+	if err := svc.rep.Tutor.AddProgress(ctx, userID, subject, delta); err != nil {
+		log.F(log.M{"user_id": userID, "subject": subject}).Errorf("记录辅导进度失败: %s", err)
+	}
+}
+
+// TutorWeekStart 本周一的零点
+func TutorWeekStart() time.Time {
+	today := repo.NowInDate()
+	return today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+}
+
+// TutorWeeklyReport 生成学习周报的内容，progress 为上周各学科的学习进度
+func TutorWeeklyReport(nickname string, startDate time.Time, progress []repo.TutorSubjectProgress) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s 至 %s 的作业辅导学习周报：\n\n", nickname, startDate.Format("2006-01-02"), startDate.AddDate(0, 0, 6).Format("2006-01-02")))
+
+	if len(progress) == 0 {
+		sb.WriteString("本周没有使用作业辅导。")
+		return sb.String()
+	}
+
+	var blocked int64
+	for _, item := range progress {
+		name, ok := TutorSubjectName(item.Subject)
+		if !ok {
+			name = item.Subject
+		}
+
+		sb.WriteString(fmt.Sprintf("- %s：学习 %d 天，辅导 %d 步，独立解决 %d 道题\n", name, item.Days, item.Steps, item.Solved))
+		blocked += item.Blocked
+	}
+
+	if blocked > 0 {
+		sb.WriteString(fmt.Sprintf("\n本周有 %d 次提问因内容不适宜被拦截，建议留意孩子的使用情况。", blocked))
+	}
+
+	return sb.String()
+}
@@ -122,6 +122,17 @@ func (srv *UserService) FreeChatRequestCounts(ctx context.Context, userID int64,
 	return leftCount, maxCount
 }
 
+// SpendLimit 用户单次请求的智慧果上限，返回 0 表示不限制，查询用户配置失败时使用系统默认值
+func (srv *UserService) SpendLimit(ctx context.Context, userID int64) int64 {
+	cus, err := srv.userRepo.CustomConfig(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("get user custom config failed: %v", err)
+		cus = &repo2.UserCustomConfig{}
+	}
+
+	return cus.EffectiveSpendLimit(int64(srv.conf.MaxCoinsPerRequest))
+}
+
 // UpdateFreeChatCount 更新免费聊天次数使用情况
 func (srv *UserService) UpdateFreeChatCount(ctx context.Context, userID int64, model string) error {
 	if srv.conf.VirtualModel.NanxianRel != "" && model == chat.ModelNanXian {
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// versionPolicyReloadInterval 客户端版本策略的重新加载周期
+const versionPolicyReloadInterval = time.Minute
+
+// UpgradeRequirement 客户端的升级要求
+type UpgradeRequirement struct {
+	// Required 客户端版本低于最低支持版本或者被禁止使用，必须升级后才能继续使用
+	Required bool `json:"required"`
+	// Recommended 客户端版本低于推荐版本，提示升级但不限制使用
+	Recommended        bool   `json:"recommended"`
+	MinVersion         string `json:"min_version,omitempty"`
+	RecommendedVersion string `json:"recommended_version,omitempty"`
+	URL                string `json:"url,omitempty"`
+	Message            string `json:"message,omitempty"`
+}
+
+// ResolveVersionPolicy 根据版本策略计算客户端的升级要求，优先使用平台自己的策略，没有时使用默认策略，无法识别版本的客户端不做限制
+func ResolveVersionPolicy(policies map[string]repo.VersionPolicy, platform, version string) UpgradeRequirement {
+	policy, ok := policies[platform]
+	if !ok || platform == "" {
+		if policy, ok = policies[repo.VersionPlatformDefault]; !ok {
+			return UpgradeRequirement{}
+		}
+	}
+
+	req := UpgradeRequirement{
+		MinVersion:         policy.MinVersion,
+		RecommendedVersion: policy.RecommendedVersion,
+		URL:                policy.UpgradeURL,
+		Message:            policy.Message,
+	}
+
+	if version == "" {
+		return req
+	}
+
+	req.Required = array.In(version, policy.BlockedVersions) ||
+		(policy.MinVersion != "" && misc.VersionOlder(version, policy.MinVersion))
+	req.Recommended = req.Required ||
+		(policy.RecommendedVersion != "" && misc.VersionOlder(version, policy.RecommendedVersion))
+
+	return req
+}
+
+// VersionPolicyService 客户端版本策略，管理员为各平台设置最低支持版本与推荐版本，拒绝被禁止的版本的请求
+type VersionPolicyService struct {
+	rep *repo.Repository `autowire:"@"`
+
+	lock     sync.RWMutex
+	policies map[string]repo.VersionPolicy
+	loadedAt time.Time
+}
+
+func NewVersionPolicyService(resolver infra.Resolver) *VersionPolicyService {
+	srv := &VersionPolicyService{policies: map[string]repo.VersionPolicy{}}
+	resolver.MustAutoWire(srv)
+	return srv
+}
+
+// Reload 从数据库重新加载客户端版本策略
+func (srv *VersionPolicyService) Reload(ctx context.Context) error {
+	items, err := srv.rep.VersionPolicy.Policies(ctx)
+	if err != nil {
+		return err
+	}
+
+	policies := make(map[string]repo.VersionPolicy, len(items))
+	for _, item := range items {
+		policies[item.Platform] = item
+	}
+
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	srv.policies, srv.loadedAt = policies, time.Now()
+	return nil
+}
+
+func (srv *VersionPolicyService) currentPolicies(ctx context.Context) map[string]repo.VersionPolicy {
+	srv.lock.RLock()
+	loadedAt := srv.loadedAt
+	srv.lock.RUnlock()
+
+	if time.Since(loadedAt) >= versionPolicyReloadInterval {
+		if err := srv.Reload(ctx); err != nil {
+			log.Errorf("reload client version policies failed: %v", err)
+
+			// 加载失败时继续使用旧的策略，避免每次请求都访问数据库
+			srv.lock.Lock()
+			srv.loadedAt = time.Now()
+			srv.lock.Unlock()
+		}
+	}
+
+	srv.lock.RLock()
+	defer srv.lock.RUnlock()
+
+	return srv.policies
+}
+
+// Check 计算客户端的升级要求
+func (srv *VersionPolicyService) Check(ctx context.Context, platform, version string) UpgradeRequirement {
+	return ResolveVersionPolicy(srv.currentPolicies(ctx), platform, version)
+}
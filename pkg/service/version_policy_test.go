@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestResolveVersionPolicy(t *testing.T) {
+	policies := map[string]repo.VersionPolicy{
+		"ios": {Platform: "ios", MinVersion: "1.0.6", RecommendedVersion: "1.0.9", BlockedVersions: []string{"1.0.7"}},
+		"*":   {Platform: "*", RecommendedVersion: "1.0.8"},
+	}
+
+	req := service.ResolveVersionPolicy(policies, "ios", "1.0.5")
+	assert.True(t, req.Required)
+	assert.True(t, req.Recommended)
+
+	req = service.ResolveVersionPolicy(policies, "ios", "1.0.7")
+	assert.True(t, req.Required)
+
+	req = service.ResolveVersionPolicy(policies, "ios", "1.0.8")
+	assert.False(t, req.Required)
+	assert.True(t, req.Recommended)
+
+	req = service.ResolveVersionPolicy(policies, "ios", "1.0.9")
+	assert.False(t, req.Recommended)
+
+	req = service.ResolveVersionPolicy(policies, "android", "1.0.5")
+	assert.False(t, req.Required)
+	assert.True(t, req.Recommended)
+
+	// 无法识别版本的客户端不做限制
+	req = service.ResolveVersionPolicy(policies, "ios", "")
+	assert.False(t, req.Required)
+	assert.Equal(t, "1.0.6", req.MinVersion)
+
+	assert.False(t, service.ResolveVersionPolicy(map[string]repo.VersionPolicy{}, "ios", "1.0.0").Recommended)
+}
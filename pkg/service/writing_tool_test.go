@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestBuildWritingToolMessages(t *testing.T) {
+	for _, op := range service.WritingOperations() {
+		if op.NeedTone {
+			continue
+		}
+
+		messages, err := service.BuildWritingToolMessages(op.ID, "hello world", service.WritingToolOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(messages))
+		assert.Equal(t, "system", messages[0].Role)
+		assert.Equal(t, "hello world", messages[1].Content)
+	}
+
+	messages, err := service.BuildWritingToolMessages("tone", "hello world", service.WritingToolOptions{Tone: "formal"})
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(messages[0].Content, "in a formal tone"))
+
+	_, err = service.BuildWritingToolMessages("tone", "hello world", service.WritingToolOptions{})
+	assert.Equal(t, service.ErrWritingToolTone, err)
+
+	_, err = service.BuildWritingToolMessages("translate", "hello world", service.WritingToolOptions{})
+	assert.Equal(t, service.ErrWritingToolOperation, err)
+}
+
+func TestResolveGrammarEdits(t *testing.T) {
+	text := "我今天很高心。Teh cat sat on teh mat"
+	edits := service.ResolveGrammarEdits(text, []service.GrammarEdit{
+		{Original: "高心", Replacement: "高兴", Type: "spelling"},
+		{Original: "Teh", Replacement: "The", Type: "spelling"},
+		// 没有实际变化
+		{Original: "cat", Replacement: "cat", Type: "style"},
+		{Original: "teh", Replacement: "the", Type: "spelling"},
+		// 原文中不存在
+		{Original: "dog", Replacement: "Dog", Type: "spelling"},
+		// 与前一处修改重叠（位于已经处理的内容之前）
+		{Original: "高心", Replacement: "高兴", Type: "spelling"},
+	})
+
+	assert.Equal(t, 3, len(edits))
+	assert.Equal(t, 4, edits[0].Start)
+	assert.Equal(t, 6, edits[0].End)
+	assert.Equal(t, 7, edits[1].Start)
+	assert.Equal(t, 10, edits[1].End)
+	assert.Equal(t, 22, edits[2].Start)
+	assert.Equal(t, 25, edits[2].End)
+
+	assert.Equal(t, "我今天很高兴。The cat sat on the mat", service.ApplyGrammarEdits(text, edits))
+	assert.Equal(t, text, service.ApplyGrammarEdits(text, nil))
+}
@@ -0,0 +1,156 @@
+package sign
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 请求签名使用的请求头
+const (
+	HeaderTimestamp = "X-Aidea-Timestamp"
+	HeaderNonce     = "X-Aidea-Nonce"
+	HeaderSignature = "X-Aidea-Signature"
+)
+
+// MaxBodySize 参与签名的请求体最大长度，签名校验在鉴权之前执行，需要限制读取的数据量
+const MaxBodySize = 1 << 20
+
+var (
+	ErrMissingSignature = errors.New("missing request signature")
+	ErrExpired          = errors.New("request timestamp expired")
+	ErrInvalidSignature = errors.New("invalid request signature")
+	ErrReplayed         = errors.New("request nonce already used")
+	ErrBodyTooLarge     = errors.New("request body too large")
+)
+
+// Rejected 是否是签名校验不通过导致的错误，Redis 等内部错误不应该拒绝客户端的请求
+func Rejected(err error) bool {
+	return errors.Is(err, ErrMissingSignature) || errors.Is(err, ErrExpired) || errors.Is(err, ErrInvalidSignature) || errors.Is(err, ErrReplayed) || errors.Is(err, ErrBodyTooLarge)
+}
+
+// Signature 计算请求签名
+//
+// 签名内容为 method、path、query、timestamp、nonce 以及请求体的 SHA256 摘要，使用换行符连接后以 HMAC-SHA256 签名，结果为小写十六进制。
+// multipart 请求（文件上传）的请求体不参与签名，按照空请求体计算摘要
+func Signature(key, method, path, query, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		query,
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verifier 客户端请求签名校验，密钥内置在官方客户端中，用于增加脚本调用公开接口的难度
+type Verifier struct {
+	keys      []string
+	tolerance time.Duration
+	rds       *redis.Client
+}
+
+// NewVerifier 创建请求签名校验器，支持多个密钥以便在不同版本的客户端之间轮换密钥
+func NewVerifier(keys []string, tolerance time.Duration, rds *redis.Client) *Verifier {
+	return &Verifier{keys: keys, tolerance: tolerance, rds: rds}
+}
+
+// Verify 校验请求签名，校验通过的 nonce 在有效期内不能重复使用
+func (v *Verifier) Verify(ctx context.Context, r *http.Request, now time.Time) error {
+	timestamp, nonce, signature := r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderNonce), r.Header.Get(HeaderSignature)
+	if timestamp == "" || nonce == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if diff := now.Sub(time.Unix(ts, 0)); diff > v.tolerance || diff < -v.tolerance {
+		return ErrExpired
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		if errors.Is(err, ErrBodyTooLarge) {
+			return err
+		}
+
+		return fmt.Errorf("read request body failed: %w", err)
+	}
+
+	valid := false
+	for _, key := range v.keys {
+		expected := Signature(key, r.Method, r.URL.Path, r.URL.RawQuery, timestamp, nonce, body)
+		if hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+			valid = true
+			break
+		}
+	}
+
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	// 时间戳校验已经拒绝了有效期之外的请求，因此 nonce 只需要保存到有效期结束
+	ok, err := v.rds.SetNX(ctx, "request-sign:nonce:"+nonce, timestamp, 2*v.tolerance).Result()
+	if err != nil {
+		return fmt.Errorf("check request nonce failed: %w", err)
+	}
+
+	if !ok {
+		return ErrReplayed
+	}
+
+	return nil
+}
+
+// readBody 读取参与签名的请求体，读取后重新写回，以便后续的处理器继续读取
+//
+// 最多读取 MaxBodySize 字节，超出时返回 ErrBodyTooLarge，已读取的部分同样会写回，不影响未强制校验签名时的请求处理
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); strings.HasPrefix(mediaType, "multipart/") {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) > MaxBodySize {
+		r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+		return nil, ErrBodyTooLarge
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
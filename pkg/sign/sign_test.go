@@ -0,0 +1,79 @@
+package sign_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/sign"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	verifier := sign.NewVerifier([]string{"old-key", "new-key"}, 5*time.Minute, nil)
+
+	newRequest := func(key string, ts time.Time) *http.Request {
+		body := `{"message":"hello"}`
+		req, _ := http.NewRequest(http.MethodPost, "https://example.com/v1/auth/sign-in?lang=zh", strings.NewReader(body))
+		timestamp := strconv.FormatInt(ts.Unix(), 10)
+		req.Header.Set(sign.HeaderTimestamp, timestamp)
+		req.Header.Set(sign.HeaderNonce, "nonce-1")
+		req.Header.Set(sign.HeaderSignature, sign.Signature(key, http.MethodPost, "/v1/auth/sign-in", "lang=zh", timestamp, "nonce-1", []byte(body)))
+		return req
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/v1/models", nil)
+	assert.True(t, sign.Rejected(verifier.Verify(context.TODO(), req, now)))
+
+	err := verifier.Verify(context.TODO(), newRequest("new-key", now.Add(-10*time.Minute)), now)
+	assert.True(t, sign.Rejected(err))
+	assert.Equal(t, sign.ErrExpired, err)
+
+	err = verifier.Verify(context.TODO(), newRequest("unknown-key", now), now)
+	assert.Equal(t, sign.ErrInvalidSignature, err)
+
+	assert.Equal(t,
+		sign.Signature("key", "post", "/v1/x", "", "1", "n", nil),
+		sign.Signature("key", "POST", "/v1/x", "", "1", "n", nil),
+	)
+}
+
+func TestVerifyBody(t *testing.T) {
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	verifier := sign.NewVerifier([]string{"key"}, 5*time.Minute, nil)
+
+	// 超出长度限制的请求体直接拒绝，已读取的内容写回，后续处理器仍然可以读取完整的请求体
+	body := strings.Repeat("a", sign.MaxBodySize+10)
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/v1/chat", strings.NewReader(body))
+	req.Header.Set(sign.HeaderTimestamp, timestamp)
+	req.Header.Set(sign.HeaderNonce, "nonce-1")
+	req.Header.Set(sign.HeaderSignature, sign.Signature("key", http.MethodPost, "/v1/chat", "", timestamp, "nonce-1", []byte(body)))
+
+	err := verifier.Verify(context.TODO(), req, now)
+	assert.Equal(t, sign.ErrBodyTooLarge, err)
+	assert.True(t, sign.Rejected(err))
+
+	data, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, len(body), len(data))
+
+	// multipart 请求的请求体不参与签名
+	form := "--boundary\r\nContent-Disposition: form-data; name=\"file\"; filename=\"a.txt\"\r\n\r\nhello\r\n--boundary--\r\n"
+	req, _ = http.NewRequest(http.MethodPost, "https://example.com/v1/upload", strings.NewReader(form))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=boundary")
+	req.Header.Set(sign.HeaderTimestamp, timestamp)
+	req.Header.Set(sign.HeaderNonce, "nonce-2")
+	req.Header.Set(sign.HeaderSignature, sign.Signature("key", http.MethodPost, "/v1/upload", "", timestamp, "nonce-2", []byte(form)))
+
+	assert.Equal(t, sign.ErrInvalidSignature, verifier.Verify(context.TODO(), req, now))
+
+	data, err = io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, form, string(data))
+}
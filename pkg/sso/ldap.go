@@ -0,0 +1,144 @@
+package sso
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapTimeout 未指定 context 超时时间时，单次登录的超时时间
+const ldapTimeout = 10 * time.Second
+
+// LDAPConfig LDAP 身份源配置
+type LDAPConfig struct {
+	// Server 服务地址，格式为 ldap://host:389 或 ldaps://host:636
+	Server string
+	// StartTLS 使用 ldap:// 连接后，是否通过 StartTLS 升级为加密连接
+	StartTLS bool
+	// BindDN 查询用户使用的服务账号，留空时匿名查询
+	BindDN       string
+	BindPassword string
+	// BaseDN 查询用户的根节点
+	BaseDN    string
+	UserAttr  string
+	EmailAttr string
+	NameAttr  string
+	GroupAttr string
+}
+
+// LDAP 使用 LDAP 服务校验用户名密码，先使用服务账号按登录名查询用户 DN，再使用用户 DN 与密码认证
+type LDAP struct {
+	conf LDAPConfig
+}
+
+func NewLDAP(conf LDAPConfig) *LDAP {
+	return &LDAP{conf: conf}
+}
+
+// Authenticate 校验用户名密码，返回用户信息
+func (l *LDAP) Authenticate(ctx context.Context, username, password string) (*Identity, error) {
+	// 空密码会被 LDAP 服务视为匿名登录，总是认证成功
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := l.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connect ldap server failed: %w", err)
+	}
+	defer conn.Close()
+
+	if l.conf.BindDN != "" {
+		if err := conn.Bind(l.conf.BindDN, l.conf.BindPassword); err != nil {
+			return nil, fmt.Errorf("bind service account failed: %w", err)
+		}
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		l.conf.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		// 只需要判断登录名是否唯一，最多返回两条记录
+		2,
+		int(ldapTimeout/time.Second),
+		false,
+		fmt.Sprintf("(%s=%s)", ldap.EscapeFilter(l.conf.UserAttr), ldap.EscapeFilter(username)),
+		[]string{l.conf.EmailAttr, l.conf.NameAttr, l.conf.GroupAttr},
+		nil,
+	))
+	// 超过返回记录数限制时，已返回的记录足够判断登录名不唯一
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("search ldap user failed: %w", err)
+	}
+
+	// 登录名不唯一时拒绝登录，避免登录到其他用户的账号
+	if res == nil || len(res.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+
+	entry := res.Entries[0]
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, err
+	}
+
+	return &Identity{
+		Provider:      ProviderLDAP,
+		Subject:       entry.DN,
+		Email:         entry.GetEqualFoldAttributeValue(l.conf.EmailAttr),
+		EmailVerified: true,
+		Name:          entry.GetEqualFoldAttributeValue(l.conf.NameAttr),
+		Groups:        entry.GetEqualFoldAttributeValues(l.conf.GroupAttr),
+	}, nil
+}
+
+// dial 连接 LDAP 服务，连接与每个请求的超时时间以 context 的截止时间为准
+func (l *LDAP) dial(ctx context.Context) (*ldap.Conn, error) {
+	u, err := url.Parse(l.conf.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return nil, fmt.Errorf("unsupported ldap scheme: %s", u.Scheme)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(ldapTimeout)
+	}
+
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return nil, context.DeadlineExceeded
+	}
+
+	tlsConf := &tls.Config{ServerName: u.Hostname()}
+	conn, err := ldap.DialURL(
+		l.conf.Server,
+		ldap.DialWithDialer(&net.Dialer{Deadline: deadline}),
+		ldap.DialWithTLSConfig(tlsConf),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetTimeout(timeout)
+
+	if l.conf.StartTLS && u.Scheme == "ldap" {
+		if err := conn.StartTLS(tlsConf); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start tls failed: %w", err)
+		}
+	}
+
+	return conn, nil
+}
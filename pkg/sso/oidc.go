@@ -0,0 +1,286 @@
+package sso
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// discoveryTTL 身份提供方配置的缓存时间
+	discoveryTTL = time.Hour
+	// jwksRefreshInterval ID Token 使用了未知的公钥时，重新获取公钥的最小间隔
+	jwksRefreshInterval = time.Minute
+)
+
+// OIDCConfig OIDC 身份源配置
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// GroupsClaim ID Token 中用户所属分组对应的字段
+	GroupsClaim string
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDC 使用授权码模式登录，公钥与身份提供方的配置通过 Issuer 自动发现
+type OIDC struct {
+	conf   OIDCConfig
+	client *http.Client
+
+	lock         sync.Mutex
+	discovery    *oidcDiscovery
+	discoveredAt time.Time
+	keys         map[string]*rsa.PublicKey
+	keysAt       time.Time
+}
+
+func NewOIDC(conf OIDCConfig) *OIDC {
+	return &OIDC{conf: conf, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// AuthCodeURL 返回用户登录使用的授权地址，state 用于回调时校验请求来源，nonce 用于校验 ID Token
+func (o *OIDC) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	disc, err := o.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", o.conf.ClientID)
+	params.Set("redirect_uri", o.conf.RedirectURL)
+	params.Set("scope", strings.Join(o.conf.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+
+	sep := "?"
+	if strings.Contains(disc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return disc.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+// Exchange 使用授权码换取 ID Token，校验后返回用户信息
+func (o *OIDC) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	disc, err := o.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", o.conf.RedirectURL)
+	form.Set("client_id", o.conf.ClientID)
+	form.Set("client_secret", o.conf.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, disc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tokenRes struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := o.doJSON(req, &tokenRes); err != nil {
+		return nil, fmt.Errorf("exchange token failed: %w", err)
+	}
+
+	if tokenRes.Error != "" {
+		return nil, fmt.Errorf("exchange token failed: %s(%s)", tokenRes.Error, tokenRes.ErrorDescription)
+	}
+
+	return o.verify(ctx, disc, tokenRes.IDToken, nonce)
+}
+
+// verify 校验 ID Token 的签名、签发方、受众、有效期以及 nonce
+func (o *OIDC) verify(ctx context.Context, disc *oidcDiscovery, idToken, nonce string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"})).ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return o.publicKey(ctx, disc, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !claims.VerifyIssuer(disc.Issuer, true) || !claims.VerifyAudience(o.conf.ClientID, true) {
+		return nil, fmt.Errorf("%w: issuer or audience mismatch", ErrInvalidToken)
+	}
+
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	}
+
+	ident := &Identity{
+		Provider:      ProviderOIDC,
+		Subject:       sub,
+		Email:         stringClaim(claims, "email"),
+		EmailVerified: claims["email_verified"] == true,
+		Name:          stringClaim(claims, "name"),
+		Groups:        stringsClaim(claims, o.conf.GroupsClaim),
+	}
+
+	if ident.Name == "" {
+		ident.Name = stringClaim(claims, "preferred_username")
+	}
+
+	return ident, nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	v, _ := claims[name].(string)
+	return v
+}
+
+// stringsClaim 分组字段可能是字符串数组，也可能是单个字符串
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+
+	return nil
+}
+
+func (o *OIDC) doJSON(req *http.Request, res interface{}) error {
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, res)
+}
+
+func (o *OIDC) discover(ctx context.Context) (*oidcDiscovery, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.discovery != nil && time.Since(o.discoveredAt) < discoveryTTL {
+		return o.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(o.conf.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var disc oidcDiscovery
+	if err := o.doJSON(req, &disc); err != nil {
+		return nil, fmt.Errorf("discover oidc provider failed: %w", err)
+	}
+
+	if disc.AuthorizationEndpoint == "" || disc.TokenEndpoint == "" || disc.JWKSURI == "" {
+		return nil, errors.New("discover oidc provider failed: incomplete provider configuration")
+	}
+
+	o.discovery, o.discoveredAt = &disc, time.Now()
+	return o.discovery, nil
+}
+
+// publicKey 返回签名 ID Token 的公钥，身份提供方轮换公钥后，遇到未知的 kid 时重新获取
+func (o *OIDC) publicKey(ctx context.Context, disc *oidcDiscovery, kid string) (*rsa.PublicKey, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if key, ok := o.keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(o.keysAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, disc.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := o.doJSON(req, &jwks); err != nil {
+		return nil, fmt.Errorf("fetch jwks failed: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	o.keys, o.keysAt = keys, time.Now()
+
+	if key, ok := o.keys[kid]; ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
@@ -0,0 +1,95 @@
+// Package sso 企业私有化部署使用的身份源，支持 LDAP 与 OIDC 登录
+package sso
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 身份源名称
+const (
+	ProviderLDAP = "ldap"
+	ProviderOIDC = "oidc"
+)
+
+var (
+	// ErrInvalidCredentials 用户名或密码错误
+	ErrInvalidCredentials = errors.New("用户名或密码错误")
+	// ErrInvalidToken 身份提供方返回的 ID Token 无效
+	ErrInvalidToken = errors.New("无效的 ID Token")
+)
+
+// Identity 身份源中认证通过的用户
+type Identity struct {
+	// Provider 身份源名称
+	Provider string `json:"provider"`
+	// Subject 用户在身份源中的唯一标识，LDAP 为用户 DN，OIDC 为 sub
+	Subject string `json:"subject"`
+	Email   string `json:"email"`
+	// EmailVerified 邮箱是否经过身份源验证，只有验证过的邮箱才会关联到已有的账号
+	EmailVerified bool     `json:"email_verified"`
+	Name          string   `json:"name"`
+	Groups        []string `json:"groups"`
+}
+
+type groupRule struct {
+	group    string
+	userType int64
+}
+
+// GroupMapping 身份源中的分组与用户类型的映射
+type GroupMapping []groupRule
+
+// ParseGroupMapping 解析分组映射，格式为 <分组>=<用户类型>
+func ParseGroupMapping(items []string) (GroupMapping, error) {
+	mapping := make(GroupMapping, 0, len(items))
+	for _, item := range items {
+		segs := strings.SplitN(item, "=", 2)
+		if len(segs) != 2 || strings.TrimSpace(segs[0]) == "" {
+			return nil, fmt.Errorf("invalid group mapping %q, format should be <group>=<user type>", item)
+		}
+
+		userType, err := strconv.ParseInt(strings.TrimSpace(segs[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user type in group mapping %q: %w", item, err)
+		}
+
+		mapping = append(mapping, groupRule{group: strings.TrimSpace(segs[0]), userType: userType})
+	}
+
+	return mapping, nil
+}
+
+// UserType 按顺序返回第一个匹配的分组对应的用户类型，没有匹配的分组时返回普通用户（0）
+// 未配置映射时第二个返回值为 false，此时不应该修改用户类型
+func (m GroupMapping) UserType(groups []string) (int64, bool) {
+	if len(m) == 0 {
+		return 0, false
+	}
+
+	for _, rule := range m {
+		for _, group := range groups {
+			if groupMatched(rule.group, group) {
+				return rule.userType, true
+			}
+		}
+	}
+
+	return 0, true
+}
+
+// groupMatched 分组名称不区分大小写，LDAP 中的分组为 DN 时，可以只配置第一个 RDN 的值，例如 cn=admins,ou=groups 可以配置为 admins
+func groupMatched(expect, group string) bool {
+	if strings.EqualFold(expect, group) {
+		return true
+	}
+
+	rdn, _, _ := strings.Cut(group, ",")
+	if _, val, ok := strings.Cut(rdn, "="); ok {
+		return strings.EqualFold(expect, strings.TrimSpace(val))
+	}
+
+	return false
+}
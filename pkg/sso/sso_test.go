@@ -0,0 +1,252 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestGroupMapping(t *testing.T) {
+	mapping, err := ParseGroupMapping([]string{"aidea-admins=1", "testers = 2"})
+	assert.NoError(t, err)
+
+	userType, ok := mapping.UserType([]string{"cn=Testers,ou=groups,dc=example,dc=com", "aidea-admins"})
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, userType)
+
+	userType, ok = mapping.UserType([]string{"cn=testers,ou=groups,dc=example,dc=com"})
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, userType)
+
+	userType, ok = mapping.UserType([]string{"others"})
+	assert.True(t, ok)
+	assert.EqualValues(t, 0, userType)
+
+	_, ok = GroupMapping(nil).UserType([]string{"aidea-admins"})
+	assert.False(t, ok)
+
+	_, err = ParseGroupMapping([]string{"aidea-admins"})
+	assert.True(t, err != nil)
+}
+
+// fakeLDAPServer 只支持一个用户的 LDAP 服务，查询条件为 present（例如 uid=*）时返回该用户，模拟未转义的查询条件匹配所有用户
+func fakeLDAPServer(t *testing.T, userDN, password string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	result := func(tag ber.Tag, code int64) *ber.Packet {
+		op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+		op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+		return op
+	}
+
+	entry := func() *ber.Packet {
+		attr := func(name string, vals ...string) *ber.Packet {
+			item := ber.NewSequence("")
+			item.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+			set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+			for _, v := range vals {
+				set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+			}
+			item.AppendChild(set)
+			return item
+		}
+
+		attrs := ber.NewSequence("")
+		attrs.AppendChild(attr("mail", "alice@example.com"))
+		attrs.AppendChild(attr("cn", "Alice"))
+		attrs.AppendChild(attr("memberOf", "cn=admins,ou=groups,dc=example,dc=com", "cn=staff,ou=groups,dc=example,dc=com"))
+
+		op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+		op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, userDN, ""))
+		op.AppendChild(attrs)
+		return op
+	}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				for {
+					msg, err := ber.ReadPacket(conn)
+					if err != nil || len(msg.Children) < 2 {
+						return
+					}
+
+					reply := func(ops ...*ber.Packet) {
+						for _, op := range ops {
+							packet := ber.NewSequence("")
+							packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msg.Children[0].Value, ""))
+							packet.AppendChild(op)
+							_, _ = conn.Write(packet.Bytes())
+						}
+					}
+
+					op := msg.Children[1]
+					switch op.Tag {
+					case ldap.ApplicationBindRequest:
+						dn, pass := op.Children[1].Value.(string), op.Children[2].Data.String()
+						if dn == "" || (dn == userDN && pass == password) || (dn == "cn=service" && pass == "service") {
+							reply(result(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess))
+						} else {
+							reply(result(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials))
+						}
+					case ldap.ApplicationSearchRequest:
+						filter := op.Children[6]
+						matched := filter.Tag == ldap.FilterPresent ||
+							(filter.Tag == ldap.FilterEqualityMatch && filter.Children[1].Data.String() == "alice")
+						if matched {
+							reply(entry())
+						}
+
+						reply(result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+					default:
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return "ldap://" + ln.Addr().String()
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	userDN := "uid=alice,ou=users,dc=example,dc=com"
+	l := NewLDAP(LDAPConfig{
+		Server:       fakeLDAPServer(t, userDN, "secret"),
+		BindDN:       "cn=service",
+		BindPassword: "service",
+		BaseDN:       "ou=users,dc=example,dc=com",
+		UserAttr:     "uid",
+		EmailAttr:    "mail",
+		NameAttr:     "cn",
+		GroupAttr:    "memberOf",
+	})
+
+	ctx := context.Background()
+	ident, err := l.Authenticate(ctx, "alice", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, ProviderLDAP, ident.Provider)
+	assert.Equal(t, userDN, ident.Subject)
+	assert.Equal(t, "alice@example.com", ident.Email)
+	assert.Equal(t, "Alice", ident.Name)
+	assert.Equal(t, 2, len(ident.Groups))
+
+	_, err = l.Authenticate(ctx, "alice", "wrong")
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	_, err = l.Authenticate(ctx, "bob", "secret")
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	// 空密码不能以匿名方式登录
+	_, err = l.Authenticate(ctx, "alice", "")
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	// 登录名中的特殊字符需要转义，不能构造匹配所有用户的查询条件
+	_, err = l.Authenticate(ctx, "*", "secret")
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
+
+func TestOIDCExchange(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	var server *httptest.Server
+	var idToken string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                server.URL,
+			AuthorizationEndpoint: server.URL + "/authorize",
+			TokenEndpoint:         server.URL + "/token",
+			JWKSURI:               server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "k1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "good-code" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	sign := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		assert.NoError(t, err)
+		return signed
+	}
+
+	o := NewOIDC(OIDCConfig{Issuer: server.URL, ClientID: "aidea", ClientSecret: "secret", RedirectURL: "aidea://oidc", Scopes: []string{"openid"}, GroupsClaim: "groups"})
+	ctx := context.Background()
+
+	authURL, err := o.AuthCodeURL(ctx, "state1", "nonce1")
+	assert.NoError(t, err)
+	assert.True(t, len(authURL) > len(server.URL+"/authorize?"))
+
+	claims := jwt.MapClaims{
+		"iss":            server.URL,
+		"aud":            "aidea",
+		"sub":            "user-1",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"nonce":          "nonce1",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"name":           "Alice",
+		"groups":         []string{"admins"},
+	}
+	idToken = sign(claims)
+
+	ident, err := o.Exchange(ctx, "good-code", "nonce1")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", ident.Subject)
+	assert.True(t, ident.EmailVerified)
+	assert.Equal(t, []string{"admins"}, ident.Groups)
+
+	_, err = o.Exchange(ctx, "bad-code", "nonce1")
+	assert.True(t, err != nil)
+
+	_, err = o.Exchange(ctx, "good-code", "nonce2")
+	assert.True(t, err != nil)
+
+	claims["aud"] = "others"
+	idToken = sign(claims)
+	_, err = o.Exchange(ctx, "good-code", "nonce1")
+	assert.True(t, err != nil)
+}
@@ -0,0 +1,315 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxQueryLimit 查询结果最多返回的行数
+const MaxQueryLimit = 100
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Query 受限的表格查询，由模型根据问题生成，服务端校验后在内存中执行，不会执行任何代码或 SQL
+type Query struct {
+	Select     []string    `json:"select,omitempty"`
+	Where      []Condition `json:"where,omitempty"`
+	GroupBy    []string    `json:"group_by,omitempty"`
+	Aggregates []Aggregate `json:"aggregates,omitempty"`
+	OrderBy    string      `json:"order_by,omitempty"`
+	Desc       bool        `json:"desc,omitempty"`
+	Limit      int         `json:"limit,omitempty"`
+}
+
+// Condition 过滤条件，多个条件之间为 AND 关系
+// Op 支持 = != > >= < <= contains
+type Condition struct {
+	Column string `json:"column"`
+	Op     string `json:"op"`
+	Value  string `json:"value"`
+}
+
+// Aggregate 聚合函数，Func 支持 count sum avg min max，count 时 Column 可以为空
+type Aggregate struct {
+	Func   string `json:"func"`
+	Column string `json:"column,omitempty"`
+	As     string `json:"as,omitempty"`
+}
+
+func (agg Aggregate) name() string {
+	if agg.As != "" {
+		return agg.As
+	}
+
+	if agg.Column == "" {
+		return agg.Func
+	}
+
+	return fmt.Sprintf("%s(%s)", agg.Func, agg.Column)
+}
+
+// Validate 检查查询中引用的列、操作符和聚合函数是否合法
+func (q Query) Validate(t *Table) error {
+	check := func(col string) error {
+		if t.columnIndex(col) < 0 {
+			return fmt.Errorf("%w: unknown column %q", ErrInvalidQuery, col)
+		}
+		return nil
+	}
+
+	for _, col := range append(append([]string{}, q.Select...), q.GroupBy...) {
+		if err := check(col); err != nil {
+			return err
+		}
+	}
+
+	for _, cond := range q.Where {
+		if err := check(cond.Column); err != nil {
+			return err
+		}
+
+		switch cond.Op {
+		case "=", "!=", ">", ">=", "<", "<=", "contains":
+		default:
+			return fmt.Errorf("%w: unknown operator %q", ErrInvalidQuery, cond.Op)
+		}
+	}
+
+	for _, agg := range q.Aggregates {
+		switch agg.Func {
+		case "count":
+			if agg.Column == "" {
+				continue
+			}
+		case "sum", "avg", "min", "max":
+			if agg.Column == "" {
+				return fmt.Errorf("%w: %s requires a column", ErrInvalidQuery, agg.Func)
+			}
+		default:
+			return fmt.Errorf("%w: unknown aggregate %q", ErrInvalidQuery, agg.Func)
+		}
+
+		if err := check(agg.Column); err != nil {
+			return err
+		}
+	}
+
+	if len(q.GroupBy) > 0 && len(q.Aggregates) == 0 {
+		return fmt.Errorf("%w: group_by requires aggregates", ErrInvalidQuery)
+	}
+
+	if q.Limit < 0 {
+		return fmt.Errorf("%w: invalid limit", ErrInvalidQuery)
+	}
+
+	return nil
+}
+
+// Execute 执行查询，返回结果表格
+func (t *Table) Execute(q Query) (*Table, error) {
+	if err := q.Validate(t); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0)
+	for _, row := range t.Rows {
+		if t.match(row, q.Where) {
+			rows = append(rows, row)
+		}
+	}
+
+	var result *Table
+	if len(q.Aggregates) > 0 {
+		result = t.aggregate(rows, q)
+	} else {
+		columns := q.Select
+		if len(columns) == 0 {
+			columns = t.Columns
+		}
+
+		result = &Table{Columns: columns, Rows: make([][]string, 0, len(rows))}
+		for _, row := range rows {
+			item := make([]string, len(columns))
+			for i, col := range columns {
+				item[i] = row[t.columnIndex(col)]
+			}
+			result.Rows = append(result.Rows, item)
+		}
+	}
+
+	if q.OrderBy != "" {
+		idx := result.columnIndex(q.OrderBy)
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: unknown order column %q", ErrInvalidQuery, q.OrderBy)
+		}
+
+		sort.SliceStable(result.Rows, func(i, j int) bool {
+			cmp := compareValue(result.Rows[i][idx], result.Rows[j][idx])
+			return (q.Desc && cmp > 0) || (!q.Desc && cmp < 0)
+		})
+	}
+
+	limit := q.Limit
+	if limit == 0 || limit > MaxQueryLimit {
+		limit = MaxQueryLimit
+	}
+
+	return result.Snippet(limit), nil
+}
+
+func (t *Table) match(row []string, conditions []Condition) bool {
+	for _, cond := range conditions {
+		val := row[t.columnIndex(cond.Column)]
+		if cond.Op == "contains" {
+			if !strings.Contains(strings.ToLower(val), strings.ToLower(cond.Value)) {
+				return false
+			}
+			continue
+		}
+
+		cmp := compareValue(val, cond.Value)
+		ok := false
+		switch cond.Op {
+		case "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *Table) aggregate(rows [][]string, q Query) *Table {
+	groups := make(map[string][][]string)
+	keys := make([]string, 0)
+	for _, row := range rows {
+		parts := make([]string, len(q.GroupBy))
+		for i, col := range q.GroupBy {
+			parts[i] = row[t.columnIndex(col)]
+		}
+
+		key := strings.Join(parts, "\x00")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	// 没有分组时，即使没有匹配的行也返回一行聚合结果
+	if len(q.GroupBy) == 0 && len(keys) == 0 {
+		keys = append(keys, "")
+	}
+
+	columns := append([]string{}, q.GroupBy...)
+	for _, agg := range q.Aggregates {
+		columns = append(columns, agg.name())
+	}
+
+	result := &Table{Columns: columns, Rows: make([][]string, 0, len(keys))}
+	for _, key := range keys {
+		groupRows := groups[key]
+
+		item := make([]string, 0, len(columns))
+		if len(q.GroupBy) > 0 {
+			item = append(item, strings.Split(key, "\x00")...)
+		}
+
+		for _, agg := range q.Aggregates {
+			item = append(item, t.aggregateValue(groupRows, agg))
+		}
+
+		result.Rows = append(result.Rows, item)
+	}
+
+	return result
+}
+
+func (t *Table) aggregateValue(rows [][]string, agg Aggregate) string {
+	if agg.Func == "count" {
+		if agg.Column == "" {
+			return strconv.Itoa(len(rows))
+		}
+
+		count := 0
+		idx := t.columnIndex(agg.Column)
+		for _, row := range rows {
+			if row[idx] != "" {
+				count++
+			}
+		}
+		return strconv.Itoa(count)
+	}
+
+	idx := t.columnIndex(agg.Column)
+	values := make([]float64, 0, len(rows))
+	for _, row := range rows {
+		if num, ok := parseNumber(row[idx]); ok {
+			values = append(values, num)
+		}
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	var ret float64
+	switch agg.Func {
+	case "sum", "avg":
+		for _, v := range values {
+			ret += v
+		}
+		if agg.Func == "avg" {
+			ret = ret / float64(len(values))
+		}
+	case "min":
+		ret = values[0]
+		for _, v := range values[1:] {
+			if v < ret {
+				ret = v
+			}
+		}
+	case "max":
+		ret = values[0]
+		for _, v := range values[1:] {
+			if v > ret {
+				ret = v
+			}
+		}
+	}
+
+	return strconv.FormatFloat(ret, 'f', -1, 64)
+}
+
+// compareValue 两个值都是数值时按照数值比较，否则按照字符串比较
+func compareValue(a, b string) int {
+	na, okA := parseNumber(a)
+	nb, okB := parseNumber(b)
+	if okA && okB {
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(a, b)
+}
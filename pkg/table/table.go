@@ -0,0 +1,352 @@
+package table
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported table format")
+	ErrEmptyTable        = errors.New("table is empty")
+	ErrTooManyRows       = errors.New("table has too many rows")
+)
+
+// Table 二维表格，第一行作为表头
+type Table struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+// Load 根据文件扩展名解析 CSV 或 XLSX 文件，maxRows 为 0 时不限制行数
+func Load(filename string, data []byte, maxRows int) (*Table, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".csv":
+		records, err = parseCSV(data)
+	case ".xlsx":
+		records, err = parseXLSX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return build(records, maxRows)
+}
+
+func build(records [][]string, maxRows int) (*Table, error) {
+	if len(records) < 2 {
+		return nil, ErrEmptyTable
+	}
+
+	if maxRows > 0 && len(records)-1 > maxRows {
+		return nil, ErrTooManyRows
+	}
+
+	// 表头为空或重复时自动生成列名，保证列名唯一
+	columns := make([]string, len(records[0]))
+	seen := make(map[string]bool)
+	for i, col := range records[0] {
+		col = strings.TrimSpace(col)
+		if col == "" || seen[col] {
+			col = fmt.Sprintf("column_%d", i+1)
+		}
+
+		seen[col] = true
+		columns[i] = col
+	}
+
+	rows := make([][]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make([]string, len(columns))
+		copy(row, rec)
+
+		empty := true
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+			if row[i] != "" {
+				empty = false
+			}
+		}
+
+		if !empty {
+			rows = append(rows, row)
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyTable
+	}
+
+	return &Table{Columns: columns, Rows: rows}, nil
+}
+
+func parseCSV(data []byte) ([][]string, error) {
+	// 去掉 Excel 导出 CSV 时附带的 UTF-8 BOM
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse csv failed: %w", err)
+	}
+
+	return records, nil
+}
+
+type xlsxSharedStrings struct {
+	Items []struct {
+		T string `xml:"t"`
+		R []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline struct {
+				T string `xml:"t"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// parseXLSX 解析 XLSX 文件中的第一个工作表，只读取单元格的值，不计算公式
+func parseXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open xlsx failed: %w", err)
+	}
+
+	files := make(map[string]*zip.File)
+	sheets := make([]string, 0)
+	for _, f := range zr.File {
+		files[f.Name] = f
+		if strings.HasPrefix(f.Name, "xl/worksheets/") && strings.HasSuffix(f.Name, ".xml") {
+			sheets = append(sheets, f.Name)
+		}
+	}
+
+	if len(sheets) == 0 {
+		return nil, ErrEmptyTable
+	}
+
+	sheetName := "xl/worksheets/sheet1.xml"
+	if _, ok := files[sheetName]; !ok {
+		sort.Strings(sheets)
+		sheetName = sheets[0]
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var ss xlsxSharedStrings
+		if err := decodeXML(f, &ss); err != nil {
+			return nil, fmt.Errorf("parse shared strings failed: %w", err)
+		}
+
+		for _, item := range ss.Items {
+			text := item.T
+			for _, r := range item.R {
+				text += r.T
+			}
+			shared = append(shared, text)
+		}
+	}
+
+	var sheet xlsxSheet
+	if err := decodeXML(files[sheetName], &sheet); err != nil {
+		return nil, fmt.Errorf("parse sheet failed: %w", err)
+	}
+
+	records := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		record := make([]string, 0)
+		for i, cell := range row.Cells {
+			idx := columnIndex(cell.Ref)
+			if idx < 0 {
+				idx = i
+			}
+
+			for len(record) <= idx {
+				record = append(record, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				n, err := strconv.Atoi(cell.Value)
+				if err == nil && n >= 0 && n < len(shared) {
+					record[idx] = shared[n]
+				}
+			case "inlineStr":
+				record[idx] = cell.Inline.T
+			default:
+				record[idx] = cell.Value
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func decodeXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(io.LimitReader(rc, 64*1024*1024)).Decode(v)
+}
+
+// columnIndex 将单元格引用（如 AB12）转换为从 0 开始的列序号
+func columnIndex(ref string) int {
+	idx := 0
+	n := 0
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+
+		idx = idx*26 + int(c-'A'+1)
+		n++
+	}
+
+	if n == 0 {
+		return -1
+	}
+
+	return idx - 1
+}
+
+// ColumnProfile 列的统计信息，用于让模型了解表格结构
+type ColumnProfile struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	NonEmpty int      `json:"non_empty"`
+	Distinct int      `json:"distinct"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Samples  []string `json:"samples,omitempty"`
+}
+
+// Profile 统计每一列的类型、非空数量、去重数量、数值范围以及示例值
+func (t *Table) Profile() []ColumnProfile {
+	profiles := make([]ColumnProfile, len(t.Columns))
+	for i, col := range t.Columns {
+		p := ColumnProfile{Name: col, Type: "number"}
+		distinct := make(map[string]bool)
+		for _, row := range t.Rows {
+			val := row[i]
+			if val == "" {
+				continue
+			}
+
+			p.NonEmpty++
+			if !distinct[val] {
+				distinct[val] = true
+				if len(p.Samples) < 5 {
+					p.Samples = append(p.Samples, val)
+				}
+			}
+
+			if p.Type != "number" {
+				continue
+			}
+
+			num, ok := parseNumber(val)
+			if !ok {
+				p.Type = "text"
+				p.Min, p.Max = nil, nil
+				continue
+			}
+
+			if p.Min == nil || num < *p.Min {
+				p.Min = &num
+			}
+			if p.Max == nil || num > *p.Max {
+				p.Max = &num
+			}
+		}
+
+		if p.NonEmpty == 0 {
+			p.Type = "text"
+		}
+
+		p.Distinct = len(distinct)
+		profiles[i] = p
+	}
+
+	return profiles
+}
+
+// Snippet 返回前 n 行组成的新表格
+func (t *Table) Snippet(n int) *Table {
+	if n <= 0 || n >= len(t.Rows) {
+		return t
+	}
+
+	return &Table{Columns: t.Columns, Rows: t.Rows[:n]}
+}
+
+// Markdown 以 Markdown 表格格式输出
+func (t *Table) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("| " + strings.Join(t.Columns, " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(t.Columns)) + "\n")
+	for _, row := range t.Rows {
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+
+	return sb.String()
+}
+
+func (t *Table) columnIndex(name string) int {
+	for i, col := range t.Columns {
+		if col == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// parseNumber 解析数值，支持千分位分隔符和百分号
+func parseNumber(val string) (float64, bool) {
+	val = strings.ReplaceAll(strings.TrimSpace(val), ",", "")
+	percent := strings.HasSuffix(val, "%")
+	val = strings.TrimSuffix(val, "%")
+
+	num, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	if percent {
+		num = num / 100
+	}
+
+	return num, true
+}
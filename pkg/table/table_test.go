@@ -0,0 +1,85 @@
+package table_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/table"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+const testCSV = "\xef\xbb\xbfcity,product,amount\n北京,apple,10\n上海,apple,1,000\n北京,pear,5\n,,\n广州,pear,7.5\n"
+
+func loadTestTable(t *testing.T) *table.Table {
+	tbl, err := table.Load("sales.csv", []byte("city,product,amount\n北京,apple,10\n上海,apple,\"1,000\"\n北京,pear,5\n,,\n广州,pear,7.5\n"), 0)
+	assert.NoError(t, err)
+	return tbl
+}
+
+func TestLoad(t *testing.T) {
+	tbl := loadTestTable(t)
+	assert.Equal(t, []string{"city", "product", "amount"}, tbl.Columns)
+	assert.Equal(t, 4, len(tbl.Rows))
+
+	_, err := table.Load("sales.csv", []byte(testCSV), 2)
+	assert.True(t, errors.Is(err, table.ErrTooManyRows))
+
+	_, err = table.Load("sales.txt", []byte(testCSV), 0)
+	assert.True(t, errors.Is(err, table.ErrUnsupportedFormat))
+
+	profiles := tbl.Profile()
+	assert.Equal(t, "text", profiles[0].Type)
+	assert.Equal(t, 3, profiles[0].Distinct)
+	assert.Equal(t, "number", profiles[2].Type)
+	assert.Equal(t, 5.0, *profiles[2].Min)
+	assert.Equal(t, 1000.0, *profiles[2].Max)
+}
+
+func TestExecute(t *testing.T) {
+	tbl := loadTestTable(t)
+
+	res, err := tbl.Execute(table.Query{
+		GroupBy:    []string{"product"},
+		Aggregates: []table.Aggregate{{Func: "sum", Column: "amount", As: "total"}, {Func: "count"}},
+		OrderBy:    "total",
+		Desc:       true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"product", "total", "count"}, res.Columns)
+	assert.Equal(t, [][]string{{"apple", "1010", "2"}, {"pear", "12.5", "2"}}, res.Rows)
+
+	res, err = tbl.Execute(table.Query{
+		Select: []string{"city"},
+		Where:  []table.Condition{{Column: "amount", Op: ">=", Value: "7.5"}, {Column: "product", Op: "contains", Value: "PE"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"广州"}}, res.Rows)
+
+	_, err = tbl.Execute(table.Query{Select: []string{"price"}})
+	assert.True(t, errors.Is(err, table.ErrInvalidQuery))
+
+	_, err = tbl.Execute(table.Query{Where: []table.Condition{{Column: "city", Op: "; DROP", Value: "x"}}})
+	assert.True(t, errors.Is(err, table.ErrInvalidQuery))
+}
+
+func TestLoadXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{
+		"xl/sharedStrings.xml":     `<sst><si><t>name</t></si><si><t>score</t></si><si><r><t>张</t></r><r><t>三</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row><row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>98.5</v></c></row></sheetData></worksheet>`,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, _ = w.Write([]byte(content))
+	}
+	assert.NoError(t, zw.Close())
+
+	tbl, err := table.Load("scores.XLSX", buf.Bytes(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "column_2", "score"}, tbl.Columns)
+	assert.Equal(t, [][]string{{"张三", "", "98.5"}}, tbl.Rows)
+}
@@ -0,0 +1,175 @@
+package topic
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// Coding 编程开发
+	Coding = "coding"
+	// Writing 写作创作
+	Writing = "writing"
+	// Study 学习教育
+	Study = "study"
+	// Work 职场办公
+	Work = "work"
+	// Translation 翻译
+	Translation = "translation"
+	// Life 生活百科
+	Life = "life"
+	// Entertainment 娱乐休闲
+	Entertainment = "entertainment"
+	// Other 其它
+	Other = "other"
+)
+
+// MaxTopics 单个会话最多标记的话题数量
+const MaxTopics = 3
+
+// Topic 话题定义
+type Topic struct {
+	Name     string `json:"name"`
+	Title    string `json:"title"`
+	keywords []string
+}
+
+var topics = []Topic{
+	{
+		Name:  Coding,
+		Title: "编程",
+		keywords: []string{
+			"代码", "编程", "函数", "接口", "报错", "bug", "debug", "算法", "数据库", "编译",
+			"golang", "python", "java", "javascript", "typescript", "sql", "html", "css", "linux", "docker",
+			"func ", "class ", "import ", "```", "git ", "api", "json", "正则",
+		},
+	},
+	{
+		Name:  Writing,
+		Title: "写作",
+		keywords: []string{
+			"写一篇", "文章", "作文", "小说", "诗", "文案", "标题", "润色", "改写", "续写",
+			"故事", "剧本", "大纲", "essay", "poem", "story", "rewrite", "copywriting",
+		},
+	},
+	{
+		Name:  Study,
+		Title: "学习",
+		keywords: []string{
+			"学习", "考试", "题目", "解题", "数学", "物理", "化学", "历史", "概念", "原理",
+			"公式", "论文", "作业", "知识点", "homework", "exam", "explain", "math",
+		},
+	},
+	{
+		Name:  Work,
+		Title: "工作",
+		keywords: []string{
+			"工作", "周报", "日报", "会议", "邮件", "简历", "面试", "汇报", "方案", "ppt",
+			"excel", "项目", "客户", "老板", "同事", "meeting", "email", "resume", "report",
+		},
+	},
+	{
+		Name:  Translation,
+		Title: "翻译",
+		keywords: []string{
+			"翻译", "译成", "英文", "中文", "日语", "韩语", "translate", "translation", "in english", "in chinese",
+		},
+	},
+	{
+		Name:  Life,
+		Title: "生活",
+		keywords: []string{
+			"健康", "饮食", "菜谱", "做饭", "旅游", "旅行", "减肥", "睡眠", "购物", "租房",
+			"装修", "宠物", "育儿", "医院", "recipe", "travel", "health",
+		},
+	},
+	{
+		Name:  Entertainment,
+		Title: "娱乐",
+		keywords: []string{
+			"游戏", "电影", "电视剧", "动漫", "音乐", "笑话", "段子", "星座", "聊天", "角色扮演",
+			"game", "movie", "music", "joke",
+		},
+	},
+	{
+		Name:  Other,
+		Title: "其它",
+	},
+}
+
+// Topics 返回所有支持的话题
+func Topics() []Topic {
+	return topics
+}
+
+// Valid 判断话题名称是否合法
+func Valid(name string) bool {
+	for _, t := range topics {
+		if t.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Title 返回话题的展示名称
+func Title(name string) string {
+	for _, t := range topics {
+		if t.Name == name {
+			return t.Title
+		}
+	}
+
+	return name
+}
+
+// Classify 基于关键词对文本进行话题分类，按命中次数由高到低最多返回 MaxTopics 个话题，
+// 没有命中任何关键词时返回 Other
+func Classify(text string) []string {
+	text = strings.ToLower(text)
+
+	type hit struct {
+		name  string
+		count int
+		index int
+	}
+
+	hits := make([]hit, 0)
+	for i, t := range topics {
+		count := 0
+		for _, kw := range t.keywords {
+			count += strings.Count(text, kw)
+		}
+
+		if count > 0 {
+			hits = append(hits, hit{name: t.Name, count: count, index: i})
+		}
+	}
+
+	if len(hits) == 0 {
+		return []string{Other}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].count == hits[j].count {
+			return hits[i].index < hits[j].index
+		}
+
+		return hits[i].count > hits[j].count
+	})
+
+	// 只保留命中次数不低于最高命中次数 1/3 的话题，避免偶然命中的关键词产生噪音
+	threshold := (hits[0].count + 2) / 3
+
+	res := make([]string, 0, MaxTopics)
+	for _, h := range hits {
+		if h.count < threshold || len(res) >= MaxTopics {
+			break
+		}
+
+		res = append(res, h.name)
+	}
+
+	return res
+}
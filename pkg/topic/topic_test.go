@@ -0,0 +1,24 @@
+package topic_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/topic"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, []string{topic.Other}, topic.Classify("你好"))
+	assert.Equal(t, []string{topic.Coding}, topic.Classify("这段 Golang 代码编译报错了，帮我 debug 一下"))
+	assert.Equal(t, []string{topic.Translation}, topic.Classify("请把下面这段话翻译成英文"))
+
+	res := topic.Classify("帮我写一篇关于学习数学的文章，再写一篇考试总结的文章")
+	assert.True(t, len(res) <= topic.MaxTopics)
+	assert.Equal(t, topic.Writing, res[0])
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, topic.Valid(topic.Coding))
+	assert.False(t, topic.Valid("unknown"))
+	assert.Equal(t, "编程", topic.Title(topic.Coding))
+}
@@ -0,0 +1,98 @@
+// Package trace 请求关联 ID，用于将一次请求在服务端产生的所有日志（包括异步任务）关联起来
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/mylxsw/asteria/log"
+)
+
+// HeaderRequestID 请求关联 ID 的请求头与响应头
+const HeaderRequestID = "X-Request-Id"
+
+// maxRequestIDLength 客户端或网关传入的请求 ID 的最大长度
+const maxRequestIDLength = 64
+
+type requestIDKey struct{}
+type userIDKey struct{}
+
+// NewRequestID 生成一个新的请求 ID
+func NewRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// ValidRequestID 检查网关传入的请求 ID 是否可以直接使用，避免在日志中写入任意内容
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithRequestID 在 context 中写入请求 ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID 返回 context 中的请求 ID，不存在时返回空
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+
+	return ""
+}
+
+// WithUserID 在 context 中写入发起请求的用户 ID
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserID 返回 context 中发起请求的用户 ID，不存在时返回 0
+func UserID(ctx context.Context) int64 {
+	if id, ok := ctx.Value(userIDKey{}).(int64); ok {
+		return id
+	}
+
+	return 0
+}
+
+// Fields 返回 context 中的关联字段，用于结构化日志
+func Fields(ctx context.Context) log.M {
+	fields := log.M{}
+	if id := RequestID(ctx); id != "" {
+		fields["request_id"] = id
+	}
+
+	if uid := UserID(ctx); uid > 0 {
+		fields["user_id"] = uid
+	}
+
+	return fields
+}
+
+// Log 返回携带 context 中关联字段的日志记录器
+func Log(ctx context.Context) log.Logger {
+	return log.F(Fields(ctx))
+}
+
+// F 返回携带 context 中关联字段以及指定字段的日志记录器
+func F(ctx context.Context, fields log.M) log.Logger {
+	merged := Fields(ctx)
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return log.F(merged)
+}
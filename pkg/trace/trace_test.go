@@ -0,0 +1,32 @@
+package trace_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/trace"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestValidRequestID(t *testing.T) {
+	assert.True(t, trace.ValidRequestID(trace.NewRequestID()))
+	assert.True(t, trace.ValidRequestID("5f2b-ab_c.1"))
+	assert.False(t, trace.ValidRequestID(""))
+	assert.False(t, trace.ValidRequestID("abc def"))
+	assert.False(t, trace.ValidRequestID("abc\n{\"level\":\"ERROR\"}"))
+	assert.False(t, trace.ValidRequestID(strings.Repeat("a", 65)))
+}
+
+func TestFields(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, 0, len(trace.Fields(ctx)))
+
+	ctx = trace.WithUserID(trace.WithRequestID(ctx, "req-1"), 42)
+	assert.Equal(t, "req-1", trace.RequestID(ctx))
+	assert.EqualValues(t, 42, trace.UserID(ctx))
+
+	fields := trace.Fields(ctx)
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.EqualValues(t, 42, fields["user_id"])
+}
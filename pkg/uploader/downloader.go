@@ -41,15 +41,27 @@ func BuildImageURLWithFilter(remoteURL string, filter, storageDomain string) str
 
 var (
 	ErrFileForbidden = fmt.Errorf("文件违规已被禁用")
+	// ErrFileTooLarge 远程文件超过允许下载的大小
+	ErrFileTooLarge = fmt.Errorf("文件太大")
 )
 
 // DownloadRemoteFile download remote file to local
 func DownloadRemoteFile(ctx context.Context, remoteURL string) (string, error) {
+	return DownloadRemoteFileWithLimit(ctx, remoteURL, 0)
+}
+
+// DownloadRemoteFileWithLimit 下载远程文件到本地，maxSize 大于 0 时超过该大小立即停止下载并返回 ErrFileTooLarge
+func DownloadRemoteFileWithLimit(ctx context.Context, remoteURL string, maxSize int64) (string, error) {
 	if str.HasSuffixes(strings.ToLower(remoteURL), supportImages) {
 		remoteURL = remoteURL + "-thumb"
 	}
 
-	resp, err := http.Get(remoteURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, remoteURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -62,6 +74,10 @@ func DownloadRemoteFile(ctx context.Context, remoteURL string) (string, error) {
 		return "", fmt.Errorf("download remote file failed: [%d] %s", resp.StatusCode, resp.Status)
 	}
 
+	if maxSize > 0 && resp.ContentLength > maxSize {
+		return "", ErrFileTooLarge
+	}
+
 	prefix, _ := uuid.GenerateUUID()
 	savePath := filepath.Join(os.TempDir(), prefix+"-"+filepath.Base(remoteURL))
 	f, err := os.Create(savePath)
@@ -70,7 +86,19 @@ func DownloadRemoteFile(ctx context.Context, remoteURL string) (string, error) {
 	}
 	defer f.Close()
 
-	if _, err := io.Copy(f, resp.Body); err != nil {
+	var body io.Reader = resp.Body
+	if maxSize > 0 {
+		// 服务端返回的 Content-Length 不可信（或者没有返回），多读取一个字节用于判断是否超出大小限制
+		body = io.LimitReader(resp.Body, maxSize+1)
+	}
+
+	written, err := io.Copy(f, body)
+	if err == nil && maxSize > 0 && written > maxSize {
+		err = ErrFileTooLarge
+	}
+
+	if err != nil {
+		_ = os.Remove(savePath)
 		return "", err
 	}
 
@@ -0,0 +1,34 @@
+package uploader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	uploader2 "github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestDownloadRemoteFileWithLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 使用流式输出，不返回 Content-Length，验证下载过程中的大小限制
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer server.Close()
+
+	_, err := uploader2.DownloadRemoteFileWithLimit(context.TODO(), server.URL+"/doc.txt", 99)
+	assert.True(t, errors.Is(err, uploader2.ErrFileTooLarge))
+
+	savePath, err := uploader2.DownloadRemoteFileWithLimit(context.TODO(), server.URL+"/doc.txt", 100)
+	assert.NoError(t, err)
+	defer os.Remove(savePath)
+
+	stat, err := os.Stat(savePath)
+	assert.NoError(t, err)
+	assert.EqualValues(t, int64(100), stat.Size())
+}
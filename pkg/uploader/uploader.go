@@ -4,22 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/provenance"
 	"github.com/mylxsw/aidea-server/pkg/proxy"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-uuid"
 	"github.com/mylxsw/aidea-server/config"
 	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/go-utils/array"
 	"github.com/mylxsw/go-utils/must"
 	"github.com/mylxsw/go-utils/ternary"
 	qiniuAuth "github.com/qiniu/go-sdk/v7/auth"
 	"github.com/qiniu/go-sdk/v7/cdn"
+	"github.com/qiniu/go-sdk/v7/client"
 	"github.com/qiniu/go-sdk/v7/storage"
 )
 
@@ -178,6 +183,17 @@ func (u *Uploader) uploadRemoteFile(ctx context.Context, url string, uid int, ex
 
 // UploadStream 上传文件流
 func (u *Uploader) UploadStream(ctx context.Context, uid int, expireAfterDays int, data []byte, ext string) (string, error) {
+	// 生成的图片嵌入 AI 生成内容水印
+	if watermark, ok := provenance.WatermarkFromContext(ctx); ok && u.conf.EnableImageWatermark {
+		if array.In(strings.ToLower(strings.TrimPrefix(ext, ".")), []string{"png", "jpg", "jpeg"}) {
+			if marked, err := provenance.EmbedPNG(data, watermark); err != nil {
+				log.F(log.M{"uid": uid}).Warningf("embed image watermark failed: %v", err)
+			} else {
+				data, ext = marked, "png"
+			}
+		}
+	}
+
 	res, err := u.uploadStream(ctx, uid, expireAfterDays, data, ext)
 	if err != nil {
 		time.Sleep(500 * time.Millisecond)
@@ -255,6 +271,27 @@ func (u *Uploader) ForbidFile(ctx context.Context, pathWithoutURLPrefix string)
 	return bucketManager.UpdateObjectStatus(u.conf.StorageBucket, pathWithoutURLPrefix, false)
 }
 
+// StatFile 查询文件的哈希与大小，文件不存在时 exist 为 false
+func (u *Uploader) StatFile(ctx context.Context, pathWithoutURLPrefix string) (hash string, size int64, exist bool, err error) {
+	mac := qiniuAuth.New(u.conf.StorageAppKey, u.conf.StorageAppSecret)
+	cfg := storage.Config{
+		UseHTTPS: true,
+	}
+
+	bucketManager := storage.NewBucketManager(mac, &cfg)
+	info, err := bucketManager.Stat(u.conf.StorageBucket, pathWithoutURLPrefix)
+	if err != nil {
+		var errInfo *client.ErrorInfo
+		if errors.As(err, &errInfo) && errInfo.Code == 612 {
+			return "", 0, false, nil
+		}
+
+		return "", 0, false, err
+	}
+
+	return info.Hash, info.Fsize, true, nil
+}
+
 // RefreshCDN 刷新 CDN 缓存
 func (u *Uploader) RefreshCDN(ctx context.Context, urls []string) (cdn.RefreshResp, error) {
 	mac := qiniuAuth.New(u.conf.StorageAppKey, u.conf.StorageAppSecret)
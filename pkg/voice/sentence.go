@@ -0,0 +1,74 @@
+package voice
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// sentenceMinLength 句子的最小长度（字符），过短的句子与后面的内容合并后再合成语音
+	sentenceMinLength = 6
+	// sentenceMaxLength 句子的最大长度（字符），超过时在逗号等位置提前断句，减少首个语音片段的等待时间
+	sentenceMaxLength = 120
+)
+
+// SentenceSplitter 将模型流式输出的文本切分为句子，用于逐句合成语音
+type SentenceSplitter struct {
+	buf []rune
+}
+
+// Write 写入一段模型输出，返回已经完整的句子
+func (s *SentenceSplitter) Write(text string) []string {
+	var sentences []string
+	for _, r := range text {
+		s.buf = append(s.buf, r)
+
+		if isSentenceEnd(s.buf) && len(s.buf) >= sentenceMinLength {
+			sentences = s.appendSentence(sentences, len(s.buf))
+			continue
+		}
+
+		if len(s.buf) >= sentenceMaxLength {
+			idx := strings.LastIndexAny(string(s.buf), "，,、：:")
+			if idx < 0 {
+				sentences = s.appendSentence(sentences, len(s.buf))
+			} else {
+				sentences = s.appendSentence(sentences, len([]rune(string(s.buf)[:idx]))+1)
+			}
+		}
+	}
+
+	return sentences
+}
+
+// Flush 返回剩余的内容
+func (s *SentenceSplitter) Flush() string {
+	rest := strings.TrimSpace(string(s.buf))
+	s.buf = nil
+	return rest
+}
+
+func (s *SentenceSplitter) appendSentence(sentences []string, n int) []string {
+	sentence := strings.TrimSpace(string(s.buf[:n]))
+	s.buf = append([]rune{}, s.buf[n:]...)
+
+	if sentence == "" {
+		return sentences
+	}
+
+	return append(sentences, sentence)
+}
+
+// isSentenceEnd 中文标点直接断句，英文句号等需要后面跟随空白字符，避免把小数、缩写断开
+func isSentenceEnd(buf []rune) bool {
+	last := buf[len(buf)-1]
+	if strings.ContainsRune("。！？；\n", last) {
+		return true
+	}
+
+	if len(buf) >= 2 && unicode.IsSpace(last) && strings.ContainsRune(".!?;", buf[len(buf)-2]) {
+		return true
+	}
+
+	return false
+}
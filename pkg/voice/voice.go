@@ -1,6 +1,7 @@
 package voice
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
 	"fmt"
@@ -53,6 +54,16 @@ func (v *Voice) Text2VoiceCached(ctx context.Context, voice oai.SpeechVoice, con
 }
 
 func (v *Voice) Text2Voice(ctx context.Context, voice oai.SpeechVoice, content string) (string, error) {
+	data, err := v.Synthesize(ctx, voice, content)
+	if err != nil {
+		return "", err
+	}
+
+	return v.up.UploadStream(ctx, 0, 14, data, "mp3")
+}
+
+// Synthesize 语音合成，返回 mp3 格式的音频内容
+func (v *Voice) Synthesize(ctx context.Context, voice oai.SpeechVoice, content string) ([]byte, error) {
 	speech, err := v.client.CreateSpeech(ctx, oai.CreateSpeechRequest{
 		Model:          "tts-1",
 		Input:          content,
@@ -60,13 +71,28 @@ func (v *Voice) Text2Voice(ctx context.Context, voice oai.SpeechVoice, content s
 		ResponseFormat: oai.SpeechResponseFormatMp3,
 	})
 	if err != nil {
-		return "", fmt.Errorf("语音合成失败: %w", err)
+		return nil, fmt.Errorf("语音合成失败: %w", err)
 	}
+	defer speech.Close()
 
 	data, err := io.ReadAll(speech)
 	if err != nil {
-		return "", fmt.Errorf("读取语音流失败: %w", err)
+		return nil, fmt.Errorf("读取语音流失败: %w", err)
 	}
 
-	return v.up.UploadStream(ctx, 0, 14, data, "mp3")
+	return data, nil
+}
+
+// Transcribe 使用 Whisper 模型识别一段语音，format 为音频格式，例如 wav、m4a、webm
+func (v *Voice) Transcribe(ctx context.Context, audio []byte, format string) (string, error) {
+	res, err := v.client.CreateTranscription(ctx, oai.AudioRequest{
+		Model:    "whisper-1",
+		FilePath: "audio." + format,
+		Reader:   bytes.NewReader(audio),
+	})
+	if err != nil {
+		return "", fmt.Errorf("语音识别失败: %w", err)
+	}
+
+	return res.Text, nil
 }
@@ -3,7 +3,11 @@ package voice_test
 import (
 	"fmt"
 	"math"
+	"strings"
 	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/voice"
+	"github.com/mylxsw/go-utils/assert"
 )
 
 func TestPayCount(t *testing.T) {
@@ -11,3 +15,24 @@ func TestPayCount(t *testing.T) {
 	fmt.Println(int64(math.Ceil(float64(2) / 2.0)))
 	fmt.Println(int64(math.Ceil(float64(3) / 2.0)))
 }
+
+func TestSentenceSplitter(t *testing.T) {
+	var sp voice.SentenceSplitter
+
+	var sentences []string
+	for _, delta := range []string{"你好！", "今天天气", "很好，适合出去走走。The price is 3.", "5 dollars. OK", "? 好的"} {
+		sentences = append(sentences, sp.Write(delta)...)
+	}
+
+	// 过短的句子与后面的内容合并
+	assert.Equal(t, 2, len(sentences))
+	assert.Equal(t, "你好！今天天气很好，适合出去走走。", sentences[0])
+	assert.Equal(t, "The price is 3.5 dollars.", sentences[1])
+	assert.Equal(t, "OK? 好的", sp.Flush())
+	assert.Equal(t, "", sp.Flush())
+
+	// 过长的句子在逗号处提前断句
+	sentences = sp.Write(strings.Repeat("很长", 40) + "，" + strings.Repeat("的句子", 20))
+	assert.Equal(t, 1, len(sentences))
+	assert.True(t, strings.HasSuffix(sentences[0], "，"))
+}
@@ -0,0 +1,13 @@
+package auth
+
+import "github.com/mylxsw/aidea-server/pkg/misc"
+
+// APIKeyCredential API Key 认证模式下，当前请求使用的 API Key
+type APIKeyCredential struct {
+	Token string `json:"-"`
+}
+
+// Masked 返回脱敏后的 API Key，用于日志输出
+func (cred APIKeyCredential) Masked() string {
+	return misc.MaskStr(cred.Token, 6)
+}
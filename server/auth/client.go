@@ -11,6 +11,19 @@ type ClientInfo struct {
 	PlatformVersion string `json:"platform_version"`
 	Language        string `json:"language"`
 	IP              string `json:"ip"`
+	// Region 请求来源地区（ISO 3166-1 国家代码，大写），无法识别时为空
+	Region string `json:"region,omitempty"`
+	// StoreRegion 客户端应用商店所在地区（ISO 3166-1 国家代码，大写），由客户端上报，未上报时为空
+	StoreRegion string `json:"store_region,omitempty"`
+}
+
+// PricingRegion 用于计算商品价格的地区，优先使用应用商店所在地区，其次使用请求来源地区
+func (inf ClientInfo) PricingRegion() string {
+	if inf.StoreRegion != "" {
+		return inf.StoreRegion
+	}
+
+	return inf.Region
 }
 
 // IsIOS 返回客户端是否是 IOS 平台
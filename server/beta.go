@@ -0,0 +1,32 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+)
+
+// betaRoute 可以通过内测分组分阶段开放的接口
+type betaRoute struct {
+	prefix  string
+	feature string
+}
+
+var betaRoutes = []betaRoute{
+	{prefix: "/v1/study", feature: repo.BetaFeatureStudy},
+	{prefix: "/v1/tutor", feature: repo.BetaFeatureTutor},
+	{prefix: "/v2/creative-island/mock-interview", feature: repo.BetaFeatureMockInterview},
+	{prefix: "/v1/writing-tools", feature: repo.BetaFeatureWritingTools},
+	{prefix: "/v1/model-comparisons", feature: repo.BetaFeatureModelComparison},
+}
+
+// betaFeature 请求所属的可以分阶段开放的功能，不属于任何内测功能时返回空
+func betaFeature(path string) string {
+	for _, r := range betaRoutes {
+		if strings.HasPrefix(path, r.prefix) {
+			return r.feature
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/achievement"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// leaderboardSize 排行榜展示的用户数量
+const leaderboardSize = 50
+
+// AchievementController 成就与排行榜
+type AchievementController struct {
+	conf            *config.Config
+	translater      youdao.Translater            `autowire:"@"`
+	achievementRepo *repo2.AchievementRepo       `autowire:"@"`
+	achievementSrv  *service2.AchievementService `autowire:"@"`
+}
+
+// NewAchievementController 创建成就控制器
+func NewAchievementController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &AchievementController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *AchievementController) Register(router web.Router) {
+	router.Group("/achievements", func(router web.Router) {
+		router.Get("/", ctl.Achievements)
+		router.Get("/notifications", ctl.Notifications)
+		router.Get("/leaderboard", ctl.Leaderboard)
+		router.Post("/leaderboard/membership", ctl.UpdateLeaderboardMembership)
+	})
+}
+
+// Achievements 返回所有成就以及当前用户的解锁状态，查询时会检查是否有新解锁的成就
+func (ctl *AchievementController) Achievements(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if _, err := ctl.achievementSrv.Check(ctx, user.ID); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("检查用户成就失败: %v", err)
+	}
+
+	unlocked, err := ctl.achievementRepo.UserAchievements(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户成就失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	unlockedMap := array.ToMap(unlocked, func(item model.UserAchievement, _ int) string { return item.Achievement })
+
+	var points int64
+	items := array.Map(achievement.All(), func(a achievement.Achievement, _ int) web.M {
+		item := web.M{
+			"key":         a.Key,
+			"title":       a.Title,
+			"description": a.Description,
+			"reward":      a.Reward,
+			"points":      a.Points,
+			"unlocked":    false,
+		}
+
+		if ua, ok := unlockedMap[a.Key]; ok {
+			item["unlocked"] = true
+			item["unlocked_at"] = ua.CreatedAt
+			points += ua.Points
+		}
+
+		return item
+	})
+
+	joined, err := ctl.achievementRepo.IsLeaderboardMember(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户排行榜状态失败: %v", err)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":               items,
+		"points":             points,
+		"leaderboard_joined": joined,
+	})
+}
+
+// Notifications 返回用户新解锁但还未通知的成就，每个成就只会返回一次，客户端可据此弹出解锁提示
+func (ctl *AchievementController) Notifications(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	items, err := ctl.achievementRepo.PopUnnotified(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户成就通知失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	res := make([]web.M, 0, len(items))
+	for _, item := range items {
+		a, ok := achievement.Get(item.Achievement)
+		if !ok {
+			continue
+		}
+
+		res = append(res, web.M{
+			"key":         a.Key,
+			"title":       a.Title,
+			"description": a.Description,
+			"reward":      item.Reward,
+			"unlocked_at": item.CreatedAt,
+		})
+	}
+
+	return webCtx.JSON(web.M{"data": res})
+}
+
+// Leaderboard 成就排行榜，只包含主动加入排行榜的用户
+func (ctl *AchievementController) Leaderboard(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	items, err := ctl.achievementRepo.Leaderboard(ctx, leaderboardSize)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询成就排行榜失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items})
+}
+
+// UpdateLeaderboardMembership 加入或退出成就排行榜
+func (ctl *AchievementController) UpdateLeaderboardMembership(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	join := webCtx.InputWithDefault("join", "false") == "true"
+	if err := ctl.achievementRepo.SetLeaderboardMember(ctx, user.ID, join); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("更新用户排行榜状态失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"join": join})
+}
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// AbuseController 异常使用行为审核
+type AbuseController struct {
+	trans     youdao.Translater           `autowire:"@"`
+	abuseRepo *repo.AbuseRepo             `autowire:"@"`
+	abuseSrv  *service.AbuseDetectService `autowire:"@"`
+}
+
+func NewAbuseController(resolver infra.Resolver) web.Controller {
+	ctl := AbuseController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *AbuseController) Register(router web.Router) {
+	router.Group("/abuse-flags", func(router web.Router) {
+		router.Get("/", ctl.Flags)
+		router.Put("/{id}", ctl.Review)
+	})
+}
+
+// Flags 异常行为审核队列，默认只返回待审核的记录，status=0 时返回所有记录
+func (ctl *AbuseController) Flags(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	limit := webCtx.Int64Input("limit", 100)
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	flags, err := ctl.abuseRepo.Flags(ctx, webCtx.Int64Input("status", repo.AbuseFlagStatusPending), limit)
+	if err != nil {
+		log.Errorf("query abuse flags failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": flags})
+}
+
+// Review 审核异常行为，确认异常时延长限流时间，误报时立即解除限流
+func (ctl *AbuseController) Review(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	status := webCtx.Int64Input("status", 0)
+	if status != repo.AbuseFlagStatusConfirmed && status != repo.AbuseFlagStatusDismissed {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	flag, err := ctl.abuseRepo.Flag(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.Errorf("query abuse flag failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.abuseRepo.Review(ctx, flag.Id, status, webCtx.Input("note")); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "该记录已审核，请勿重复操作"), http.StatusBadRequest)
+		}
+
+		log.Errorf("review abuse flag failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if status == repo.AbuseFlagStatusConfirmed {
+		err = ctl.abuseSrv.Throttle(ctx, flag.UserId)
+	} else {
+		err = ctl.abuseSrv.Unthrottle(ctx, flag.UserId)
+	}
+
+	if err != nil {
+		log.F(log.M{"user_id": flag.UserId}).Errorf("update abuse throttle failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
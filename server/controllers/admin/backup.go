@@ -0,0 +1,159 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/backup"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// BackupController 备份与恢复，用于私有部署迁移到新的环境
+type BackupController struct {
+	conf     *config.Config     `autowire:"@"`
+	trans    youdao.Translater  `autowire:"@"`
+	db       *sql.DB            `autowire:"@"`
+	uploader *uploader.Uploader `autowire:"@"`
+
+	// lock 同一时间只允许执行一个备份或恢复任务
+	lock sync.Mutex
+}
+
+func NewBackupController(resolver infra.Resolver) web.Controller {
+	ctl := BackupController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *BackupController) Register(router web.Router) {
+	router.Group("/backups", func(router web.Router) {
+		router.Get("/", ctl.Backups)
+		router.Post("/", ctl.Create)
+		router.Post("/{name}/verify", ctl.Verify)
+		router.Post("/{name}/restore", ctl.Restore)
+	})
+}
+
+// backupStorage 使用当前部署的对象存储校验文件以及重新签名私有文件地址
+type backupStorage struct {
+	uploader *uploader.Uploader
+}
+
+func (s backupStorage) Stat(ctx context.Context, key string) (*backup.Asset, error) {
+	hash, size, exist, err := s.uploader.StatFile(ctx, key)
+	if err != nil || !exist {
+		return nil, err
+	}
+
+	return &backup.Asset{Key: key, Hash: hash, Size: size}, nil
+}
+
+func (s backupStorage) MakePrivateURL(key string, ttl time.Duration) string {
+	return s.uploader.MakePrivateURL(key, ttl)
+}
+
+func (ctl *BackupController) backupError(webCtx web.Context, err error) web.Response {
+	switch {
+	case errors.Is(err, backup.ErrInvalidBackup):
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	case errors.Is(err, backup.ErrChecksumFailed), errors.Is(err, backup.ErrTargetNotEmpty), errors.Is(err, backup.ErrTableNotMigrate):
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusUnprocessableEntity)
+	default:
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+}
+
+// Backups 备份列表
+func (ctl *BackupController) Backups(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	manifests, err := backup.Backups(ctl.conf.BackupDir)
+	if err != nil {
+		log.Errorf("list backups failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": manifests})
+}
+
+// Create 创建备份，在一致性快照中导出数据库，同时导出对象存储的文件清单
+func (ctl *BackupController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if !ctl.lock.TryLock() {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "已有备份或恢复任务正在执行"), http.StatusConflict)
+	}
+	defer ctl.lock.Unlock()
+
+	dumper := backup.Dumper{
+		DB:            ctl.db,
+		Dir:           ctl.conf.BackupDir,
+		ServerVersion: controllers.CurrentVersion,
+		StorageDomain: ctl.conf.StorageDomain,
+	}
+
+	// 备份耗时较长，不随请求的取消而中断
+	manifest, err := dumper.Dump(context.Background())
+	if err != nil {
+		log.F(log.M{"admin": user.ID}).Errorf("create backup failed: %v", err)
+		return ctl.backupError(webCtx, err)
+	}
+
+	log.F(log.M{"admin": user.ID, "name": manifest.Name}).Infof("backup created")
+	return webCtx.JSON(web.M{"data": manifest})
+}
+
+// Verify 校验备份文件的完整性，assets=true 时同时校验对象存储中的文件
+func (ctl *BackupController) Verify(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var storage backup.Storage
+	if webCtx.Input("assets") == "true" {
+		storage = backupStorage{uploader: ctl.uploader}
+	}
+
+	res, err := backup.Verify(ctx, ctl.conf.BackupDir, webCtx.PathVar("name"), storage)
+	if err != nil {
+		log.F(log.M{"name": webCtx.PathVar("name")}).Errorf("verify backup failed: %v", err)
+		return ctl.backupError(webCtx, err)
+	}
+
+	return webCtx.JSON(web.M{"data": res})
+}
+
+// Restore 将备份恢复到当前部署，目标数据库需要先完成迁移，恢复完成后需要重启服务以清理缓存
+// 请求中的 confirm 必须与备份名称一致，避免误操作
+func (ctl *BackupController) Restore(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	name := webCtx.PathVar("name")
+	if webCtx.Input("confirm") != name {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "请输入备份名称确认恢复操作"), http.StatusBadRequest)
+	}
+
+	if !ctl.lock.TryLock() {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "已有备份或恢复任务正在执行"), http.StatusConflict)
+	}
+	defer ctl.lock.Unlock()
+
+	restorer := backup.Restorer{
+		DB:            ctl.db,
+		Dir:           ctl.conf.BackupDir,
+		StorageDomain: ctl.conf.StorageDomain,
+		Storage:       backupStorage{uploader: ctl.uploader},
+		Force:         webCtx.Input("force") == "true",
+	}
+
+	res, err := restorer.Restore(context.Background(), name)
+	if err != nil {
+		log.F(log.M{"admin": user.ID, "name": name}).Errorf("restore backup failed: %v", err)
+		return ctl.backupError(webCtx, err)
+	}
+
+	log.F(log.M{"admin": user.ID, "name": name, "result": res}).Warningf("backup restored")
+	return webCtx.JSON(web.M{"data": res})
+}
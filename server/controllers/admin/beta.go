@@ -0,0 +1,282 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// BetaController 内测分组、分组成员以及内测反馈管理
+type BetaController struct {
+	conf     *config.Config       `autowire:"@"`
+	trans    youdao.Translater    `autowire:"@"`
+	betaRepo *repo.BetaRepo       `autowire:"@"`
+	betaSrv  *service.BetaService `autowire:"@"`
+	userSrv  *service.UserService `autowire:"@"`
+}
+
+func NewBetaController(resolver infra.Resolver) web.Controller {
+	ctl := BetaController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *BetaController) Register(router web.Router) {
+	router.Group("/beta/cohorts", func(router web.Router) {
+		router.Get("/", ctl.Cohorts)
+		router.Post("/", ctl.Create)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+
+		router.Get("/{id}/members", ctl.Members)
+		router.Post("/{id}/members", ctl.AddMember)
+		router.Delete("/{id}/members/{user_id}", ctl.RemoveMember)
+
+		router.Get("/{id}/feedback", ctl.Feedbacks)
+	})
+}
+
+// Cohorts 全部内测分组，包括禁用的分组，以及各个分组的成员数量
+func (ctl *BetaController) Cohorts(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cohorts, err := ctl.betaRepo.Cohorts(ctx, false)
+	if err != nil {
+		log.Errorf("query beta cohorts failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	counts, err := ctl.betaRepo.MemberCounts(ctx)
+	if err != nil {
+		log.Errorf("query beta member counts failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": cohorts, "members": counts, "features": repo.BetaFeatures})
+}
+
+// Create 新增内测分组
+func (ctl *BetaController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var cohort repo.BetaCohort
+	if err := webCtx.Unmarshal(&cohort); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateBetaCohort(&cohort, ctl.modelIDs()); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.betaRepo.CreateCohort(ctx, cohort)
+	if err != nil {
+		log.Errorf("create beta cohort failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Update 更新内测分组，功能正式发布时从分组中移除即可对全部用户开放
+func (ctl *BetaController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var cohort repo.BetaCohort
+	if err := webCtx.Unmarshal(&cohort); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateBetaCohort(&cohort, ctl.modelIDs()); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.betaRepo.UpdateCohort(ctx, int64(id), cohort); err != nil {
+		log.Errorf("update beta cohort failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除内测分组以及分组成员
+func (ctl *BetaController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.betaRepo.DeleteCohort(ctx, int64(id)); err != nil {
+		log.Errorf("delete beta cohort failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Members 内测分组成员列表
+func (ctl *BetaController) Members(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	members, err := ctl.betaRepo.Members(ctx, int64(id))
+	if err != nil {
+		log.Errorf("query beta members failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": members})
+}
+
+// AddMember 将用户添加到内测分组，管理员添加时不受成员数量上限限制
+func (ctl *BetaController) AddMember(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	userID := webCtx.Int64Input("user_id", 0)
+	if _, err := ctl.userSrv.GetUserByID(ctx, userID, false); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "用户不存在"), http.StatusBadRequest)
+		}
+
+		log.Errorf("query user failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.betaRepo.Join(ctx, int64(id), userID, repo.BetaMemberSourceAdmin, 0); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.Errorf("add beta member failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// RemoveMember 将用户移出内测分组
+func (ctl *BetaController) RemoveMember(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	userID, err := strconv.ParseInt(webCtx.PathVar("user_id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.betaRepo.Leave(ctx, int64(id), userID); err != nil {
+		log.Errorf("remove beta member failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Feedbacks 内测分组的反馈列表，以及各个功能的反馈数量与平均评分
+func (ctl *BetaController) Feedbacks(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	page := webCtx.Int64Input("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	perPage := webCtx.Int64Input("per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	feedbacks, meta, err := ctl.betaRepo.Feedbacks(ctx, int64(id), webCtx.Input("feature"), page, perPage)
+	if err != nil {
+		log.Errorf("query beta feedbacks failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	stats, err := ctl.betaRepo.FeedbackStats(ctx, int64(id))
+	if err != nil {
+		log.Errorf("query beta feedback stats failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":      feedbacks,
+		"stats":     stats,
+		"page":      meta.Page,
+		"per_page":  meta.PerPage,
+		"total":     meta.Total,
+		"last_page": meta.LastPage,
+	})
+}
+
+func (ctl *BetaController) modelIDs() []string {
+	return array.Map(chat.Models(ctl.conf, true), func(m chat.Model, _ int) string { return m.ID })
+}
+
+// reload 立即在当前实例生效，其它实例会在下一个加载周期生效
+func (ctl *BetaController) reload(ctx context.Context) {
+	if err := ctl.betaSrv.Reload(ctx); err != nil {
+		log.Errorf("reload beta cohorts failed: %v", err)
+	}
+}
+
+func validateBetaCohort(cohort *repo.BetaCohort, modelIDs []string) error {
+	cohort.Name, cohort.Description = strings.TrimSpace(cohort.Name), strings.TrimSpace(cohort.Description)
+	if cohort.Name == "" || len([]rune(cohort.Name)) > 100 {
+		return errors.New("分组名称不能为空，且不能超过 100 个字符")
+	}
+
+	if len(cohort.Features) == 0 && len(cohort.Models) == 0 {
+		return errors.New("内测分组至少需要包含一个功能或者模型")
+	}
+
+	for _, f := range cohort.Features {
+		if !array.In(f, repo.BetaFeatures) {
+			return errors.New("不支持内测的功能：" + f)
+		}
+	}
+
+	for _, m := range cohort.Models {
+		if !array.In(m, modelIDs) {
+			return errors.New("模型不存在：" + m)
+		}
+	}
+
+	if cohort.Capacity < 0 {
+		return errors.New("成员数量上限不能小于 0")
+	}
+
+	if cohort.Status == 0 {
+		cohort.Status = repo.BetaCohortStatusEnabled
+	}
+
+	if cohort.Status != repo.BetaCohortStatusEnabled && cohort.Status != repo.BetaCohortStatusDisabled {
+		return errors.New("无效的分组状态")
+	}
+
+	return nil
+}
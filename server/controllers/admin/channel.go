@@ -0,0 +1,429 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/channel"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// ChannelController 自定义上游渠道管理
+type ChannelController struct {
+	conf        *config.Config    `autowire:"@"`
+	trans       youdao.Translater `autowire:"@"`
+	channelRepo *repo.ChannelRepo `autowire:"@"`
+	loader      *channel.Loader   `autowire:"@"`
+}
+
+func NewChannelController(resolver infra.Resolver) web.Controller {
+	ctl := ChannelController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *ChannelController) Register(router web.Router) {
+	router.Group("/channels", func(router web.Router) {
+		router.Get("/", ctl.Channels)
+		router.Post("/", ctl.Create)
+		router.Get("/routing", ctl.Routing)
+		router.Put("/routing/{model}/pin", ctl.Pin)
+		router.Put("/{id}", ctl.Update)
+		router.Post("/{id}/test", ctl.Test)
+		router.Put("/{id}/verify", ctl.Verify)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// maskSecret 隐藏渠道密钥，只保留首尾少量字符用于辨认
+func maskSecret(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+
+	return secret[:3] + "****" + secret[len(secret)-4:]
+}
+
+// Channels 自定义渠道列表
+func (ctl *ChannelController) Channels(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	channels, err := ctl.channelRepo.Channels(ctx, false)
+	if err != nil {
+		log.Errorf("query channels failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": array.Map(channels, func(ch repo.Channel, _ int) repo.Channel {
+		ch.Secret = maskSecret(ch.Secret)
+		return ch
+	})})
+}
+
+// Create 新增自定义渠道
+func (ctl *ChannelController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var ch repo.Channel
+	if err := webCtx.Unmarshal(&ch); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.validate(&ch); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.channelRepo.CreateChannel(ctx, ch)
+	if err != nil {
+		log.Errorf("create channel failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Update 更新自定义渠道，secret 为空时保留原有的密钥
+func (ctl *ChannelController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	old, err := ctl.channelRepo.Channel(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.Errorf("query channel failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	var ch repo.Channel
+	if err := webCtx.Unmarshal(&ch); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if ch.Secret == "" || ch.Secret == maskSecret(old.Secret) {
+		ch.Secret = old.Secret
+	}
+
+	if err := ctl.validate(&ch); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.channelRepo.UpdateChannel(ctx, int64(id), ch); err != nil {
+		log.Errorf("update channel failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	// 上游配置发生变化后需要重新测试验证
+	if old.Verified() && upstreamChanged(*old, ch) {
+		if err := ctl.channelRepo.SetVerified(ctx, int64(id), false); err != nil {
+			log.Errorf("reset channel verification failed: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// upstreamChanged 渠道的上游地址、密钥或模型是否发生了变化，只修改名称、价格等配置时不影响验证状态
+func upstreamChanged(old, ch repo.Channel) bool {
+	if old.Type != ch.Type || old.Server != ch.Server || old.Secret != ch.Secret {
+		return true
+	}
+
+	upstreamModels := func(models []repo.ChannelModel) []string {
+		return array.Map(models, func(m repo.ChannelModel, _ int) string { return m.ID + "=" + m.UpstreamModel() })
+	}
+
+	oldModels := upstreamModels(old.Models)
+	for _, m := range upstreamModels(ch.Models) {
+		if !array.In(m, oldModels) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ctl *ChannelController) channel(ctx context.Context, webCtx web.Context) (*repo.Channel, web.Response) {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	ch, err := ctl.channelRepo.Channel(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.Errorf("query channel failed: %v", err)
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return ch, nil
+}
+
+type ChannelTestRequest struct {
+	// Model 测试的模型 ID，为空时测试渠道的第一个模型
+	Model string `json:"model"`
+	// Prompt 测试提示语，为空时使用默认的提示语
+	Prompt string `json:"prompt"`
+}
+
+// Test 使用测试提示语通过渠道发起请求，返回原始的请求、响应以及计费结果，请求失败时错误信息在结果中返回
+func (ctl *ChannelController) Test(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	ch, errResp := ctl.channel(ctx, webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	var req ChannelTestRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	req.Model, req.Prompt = strings.TrimSpace(req.Model), strings.TrimSpace(req.Prompt)
+	if req.Model != "" && !array.In(req.Model, array.Map(ch.Models, func(m repo.ChannelModel, _ int) string { return m.ID })) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	result, err := channel.Test(ctx, *ch, req.Model, req.Prompt)
+	if err != nil {
+		log.F(log.M{"channel_id": ch.ID, "model": req.Model}).Errorf("test channel failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	log.F(log.M{
+		"channel_id": ch.ID,
+		"model":      result.Model,
+		"latency":    result.Latency,
+		"error":      result.Error,
+		"admin_id":   user.ID,
+	}).Infof("channel test finished")
+
+	return webCtx.JSON(result)
+}
+
+type ChannelVerifyRequest struct {
+	Verified bool `json:"verified"`
+}
+
+// Verify 标记渠道的验证状态，只有验证通过的渠道才会进入模型路由
+func (ctl *ChannelController) Verify(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	ch, errResp := ctl.channel(ctx, webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	var req ChannelVerifyRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.channelRepo.SetVerified(ctx, ch.ID, req.Verified); err != nil {
+		log.Errorf("update channel verification failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除自定义渠道
+func (ctl *ChannelController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.channelRepo.DeleteChannel(ctx, int64(id)); err != nil {
+		log.Errorf("delete channel failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// ChannelRouting 提供同一模型的渠道及其路由统计
+type ChannelRouting struct {
+	Model      string                    `json:"model"`
+	Selected   int64                     `json:"selected"`
+	Candidates []ChannelRoutingCandidate `json:"candidates"`
+}
+
+type ChannelRoutingCandidate struct {
+	ChannelID   int64   `json:"channel_id"`
+	ChannelName string  `json:"channel_name"`
+	Pinned      bool    `json:"pinned"`
+	Samples     int     `json:"samples"`
+	P95Latency  float64 `json:"p95_latency"`
+	ErrorRate   float64 `json:"error_rate"`
+	Healthy     bool    `json:"healthy"`
+	// Warm 开启了保持加载的模型当前是否处于加载状态
+	Warm bool `json:"warm"`
+}
+
+// Routing 当前实例中每个模型的渠道延迟、错误率以及当前选择的渠道
+func (ctl *ChannelController) Routing(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	routings := array.Map(channel.Models(), func(m channel.Model, _ int) ChannelRouting {
+		candidates := channel.Candidates(m.ID)
+		return ChannelRouting{
+			Model:    m.ID,
+			Selected: channel.Select(candidates).ChannelID,
+			Candidates: array.Map(candidates, func(c channel.Model, _ int) ChannelRoutingCandidate {
+				stats := channel.StatsOf(c.ChannelID, c.ID)
+				return ChannelRoutingCandidate{
+					ChannelID:   c.ChannelID,
+					ChannelName: c.ChannelName,
+					Pinned:      c.Pinned,
+					Samples:     stats.Samples,
+					P95Latency:  stats.P95Latency.Seconds(),
+					ErrorRate:   stats.ErrorRate,
+					Healthy:     stats.Healthy(),
+					Warm:        c.Warm(),
+				}
+			}),
+		}
+	})
+
+	return webCtx.JSON(web.M{"data": routings})
+}
+
+type ChannelPinRequest struct {
+	// ChannelID 固定使用的渠道，为 0 时取消固定，恢复按照延迟和错误率自动选择
+	ChannelID int64 `json:"channel_id"`
+}
+
+// Pin 将模型固定到指定渠道，同一模型在其它渠道上的固定会被取消
+func (ctl *ChannelController) Pin(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	model := webCtx.PathVar("model")
+
+	var req ChannelPinRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	channels, err := ctl.channelRepo.Channels(ctx, false)
+	if err != nil {
+		log.Errorf("query channels failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if req.ChannelID > 0 && !array.In(req.ChannelID, array.Map(
+		array.Filter(channels, func(ch repo.Channel, _ int) bool {
+			return array.In(model, array.Map(ch.Models, func(m repo.ChannelModel, _ int) string { return m.ID }))
+		}),
+		func(ch repo.Channel, _ int) int64 { return ch.ID },
+	)) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	for _, ch := range channels {
+		changed := false
+		for i, m := range ch.Models {
+			if m.ID != model {
+				continue
+			}
+
+			if pinned := ch.ID == req.ChannelID; m.Pinned != pinned {
+				ch.Models[i].Pinned, changed = pinned, true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := ctl.channelRepo.UpdateChannel(ctx, ch.ID, ch); err != nil {
+			log.Errorf("update channel failed: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *ChannelController) reload(ctx context.Context) {
+	if err := ctl.loader.Reload(ctx); err != nil {
+		log.Errorf("reload channels failed: %v", err)
+	}
+}
+
+func (ctl *ChannelController) validate(ch *repo.Channel) error {
+	ch.Name, ch.Server = strings.TrimSpace(ch.Name), strings.TrimSpace(ch.Server)
+	if ch.Name == "" || len([]rune(ch.Name)) > 100 {
+		return errors.New("渠道名称不能为空且不能超过 100 个字符")
+	}
+
+	if ch.Type == "" {
+		ch.Type = repo.ChannelTypeOpenAI
+	}
+
+	if ch.Type != repo.ChannelTypeOpenAI {
+		return errors.New("不支持的渠道类型")
+	}
+
+	if u, err := url.Parse(ch.Server); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("无效的渠道服务地址")
+	}
+
+	if ch.Status == 0 {
+		ch.Status = repo.ChannelStatusEnabled
+	}
+
+	if ch.Status != repo.ChannelStatusEnabled && ch.Status != repo.ChannelStatusDisabled {
+		return errors.New("无效的渠道状态")
+	}
+
+	if len(ch.Models) == 0 {
+		return errors.New("渠道至少需要提供一个模型")
+	}
+
+	// 渠道模型不能与内置模型重名，避免覆盖内置模型的路由
+	builtin := array.Map(
+		array.Filter(chat2.Models(ctl.conf, true), func(m chat2.Model, _ int) bool { return m.Category != "channel" }),
+		func(m chat2.Model, _ int) string { return m.RealID() },
+	)
+
+	ids := make(map[string]bool)
+	for i, m := range ch.Models {
+		m.ID, m.Name = strings.TrimSpace(m.ID), strings.TrimSpace(m.Name)
+		if m.ID == "" || strings.Contains(m.ID, ":") {
+			return errors.New("模型 ID 不能为空且不能包含冒号")
+		}
+
+		if ids[m.ID] || array.In(m.ID, builtin) {
+			return errors.New("模型 ID 重复：" + m.ID)
+		}
+
+		if m.Price < 0 || m.MaxContext < 0 {
+			return errors.New("无效的模型价格或上下文长度")
+		}
+
+		if m.Name == "" {
+			m.Name = m.ID
+		}
+
+		ids[m.ID] = true
+		ch.Models[i] = m
+	}
+
+	return nil
+}
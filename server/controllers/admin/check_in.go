@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// CheckInController 签到奖励曲线管理
+type CheckInController struct {
+	trans       youdao.Translater `autowire:"@"`
+	checkInRepo *repo.CheckInRepo `autowire:"@"`
+}
+
+func NewCheckInController(resolver infra.Resolver) web.Controller {
+	ctl := CheckInController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *CheckInController) Register(router web.Router) {
+	router.Group("/check-in", func(router web.Router) {
+		router.Get("/rewards", ctl.Rewards)
+		router.Put("/rewards", ctl.UpdateRewards)
+	})
+}
+
+// Rewards 查询签到奖励曲线
+func (ctl *CheckInController) Rewards(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	rewards, err := ctl.checkInRepo.Rewards(ctx)
+	if err != nil {
+		log.Errorf("query check-in rewards failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": rewards})
+}
+
+type CheckInRewardsRequest struct {
+	// Rewards 奖励曲线，连续签到天数不能重复，超过最大天数时使用最大天数的奖励
+	Rewards []repo.CheckInReward `json:"rewards"`
+}
+
+// UpdateRewards 更新签到奖励曲线
+func (ctl *CheckInController) UpdateRewards(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req CheckInRewardsRequest
+	if err := webCtx.Unmarshal(&req); err != nil || len(req.Rewards) == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	days := make(map[int64]bool)
+	for _, r := range req.Rewards {
+		if r.StreakDay <= 0 || r.Coins < 0 || days[r.StreakDay] {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+
+		days[r.StreakDay] = true
+	}
+
+	if err := ctl.checkInRepo.UpdateRewards(ctx, req.Rewards); err != nil {
+		log.Errorf("update check-in rewards failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
@@ -25,6 +25,7 @@ type CreativeIslandController struct {
 	trans        youdao.Translater  `autowire:"@"`
 	creativeRepo *repo.CreativeRepo `autowire:"@"`
 	uploader     *uploader.Uploader `autowire:"@"`
+	queueRepo    *repo.QueueRepo    `autowire:"@"`
 }
 
 func NewCreativeIslandController(resolver infra.Resolver) web.Controller {
@@ -36,6 +37,7 @@ func NewCreativeIslandController(resolver infra.Resolver) web.Controller {
 func (ctl *CreativeIslandController) Register(router web.Router) {
 	router.Group("/creative-island", func(router web.Router) {
 		router.Put("/histories/{id}/forbid", ctl.ForbidCreativeHistory)
+		router.Get("/tasks/{task_id}/logs", ctl.TaskLogs)
 	})
 }
 
@@ -104,3 +106,36 @@ func (ctl *CreativeIslandController) ForbidCreativeHistory(ctx context.Context,
 		"message": "success",
 	})
 }
+
+// TaskLogs 查询任务的处理步骤日志，用于排查用户反馈的任务耗时较长或失败的问题
+func (ctl *CreativeIslandController) TaskLogs(ctx context.Context, webCtx web.Context) web.Response {
+	task, err := ctl.queueRepo.Task(ctx, webCtx.PathVar("task_id"))
+	if err != nil {
+		if err == repo.ErrNotFound {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.Errorf("query task failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	logs, err := ctl.queueRepo.Logs(ctx, task.TaskId)
+	if err != nil {
+		log.With(task).Errorf("query task logs failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"task": web.M{
+			"task_id":    task.TaskId,
+			"uid":        task.Uid,
+			"task_type":  task.TaskType,
+			"queue_name": task.QueueName,
+			"title":      task.Title,
+			"status":     task.Status,
+			"created_at": task.CreatedAt.Format(time.RFC3339),
+			"updated_at": task.UpdatedAt.Format(time.RFC3339),
+		},
+		"data": logs,
+	})
+}
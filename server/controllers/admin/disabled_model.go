@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// DisabledModelController 运行时禁用模型管理
+type DisabledModelController struct {
+	conf  *config.Config    `autowire:"@"`
+	trans youdao.Translater `autowire:"@"`
+	rep   *repo.Repository  `autowire:"@"`
+}
+
+func NewDisabledModelController(resolver infra.Resolver) web.Controller {
+	ctl := DisabledModelController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *DisabledModelController) Register(router web.Router) {
+	router.Group("/disabled-models", func(router web.Router) {
+		router.Get("/", ctl.DisabledModels)
+		router.Post("/", ctl.Disable)
+		router.Delete("/", ctl.Enable)
+	})
+}
+
+// DisabledModels 被禁用的模型列表
+func (ctl *DisabledModelController) DisabledModels(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	models, err := ctl.rep.DisabledModel.DisabledModels(ctx)
+	if err != nil {
+		log.Errorf("query disabled models failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": models})
+}
+
+// Disable 禁用模型，禁用后客户端模型列表中不再展示，且无法再使用该模型聊天
+func (ctl *DisabledModelController) Disable(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	modelID, reason := strings.TrimSpace(webCtx.Input("model")), strings.TrimSpace(webCtx.Input("reason"))
+	if !ctl.modelExist(modelID) || len([]rune(reason)) > 255 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.rep.DisabledModel.Disable(ctx, modelID, reason); err != nil {
+		log.Errorf("disable model %s failed: %v", modelID, err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	log.F(log.M{"user_id": user.ID, "model": modelID, "reason": reason}).Infof("model disabled")
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Enable 取消禁用模型
+func (ctl *DisabledModelController) Enable(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	modelID := strings.TrimSpace(webCtx.Input("model"))
+	if modelID == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.rep.DisabledModel.Enable(ctx, modelID); err != nil {
+		log.Errorf("enable model %s failed: %v", modelID, err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	log.F(log.M{"user_id": user.ID, "model": modelID}).Infof("model enabled")
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *DisabledModelController) modelExist(modelID string) bool {
+	return modelID != "" && array.In(modelID, array.Map(chat.Models(ctl.conf, true), func(m chat.Model, _ int) string { return m.ID }))
+}
+
+// reload 立即在当前实例生效，其它实例会在下一个加载周期生效
+func (ctl *DisabledModelController) reload(ctx context.Context) {
+	if err := chat.ReloadDisabledModels(ctx, ctl.rep); err != nil {
+		log.Errorf("reload disabled models failed: %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+const (
+	// FineTuneFormatChat 对话格式：{"messages": [{"role": "user", ...}, {"role": "assistant", ...}]}
+	FineTuneFormatChat = "chat"
+	// FineTuneFormatCompletion 补全格式：{"prompt": "...", "completion": "..."}
+	FineTuneFormatCompletion = "completion"
+)
+
+type FineTuneController struct {
+	trans       youdao.Translater `autowire:"@"`
+	messageRepo *repo.MessageRepo `autowire:"@"`
+	userRepo    *repo.UserRepo    `autowire:"@"`
+}
+
+func NewFineTuneController(resolver infra.Resolver) web.Controller {
+	ctl := FineTuneController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *FineTuneController) Register(router web.Router) {
+	router.Group("/fine-tuning", func(router web.Router) {
+		router.Get("/export", ctl.Export)
+	})
+}
+
+type fineTuneChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type fineTuneChatLine struct {
+	Messages []fineTuneChatMessage `json:"messages"`
+}
+
+type fineTuneCompletionLine struct {
+	Prompt     string `json:"prompt"`
+	Completion string `json:"completion"`
+}
+
+// Export 导出微调数据集（JSONL 格式）
+// 只导出用户已授权用于模型微调的聊天记录，导出内容会移除个人敏感信息
+func (ctl *FineTuneController) Export(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	format := webCtx.InputWithDefault("format", FineTuneFormatChat)
+	if format != FineTuneFormatChat && format != FineTuneFormatCompletion {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	limit := webCtx.Int64Input("limit", 1000)
+	if limit <= 0 || limit > 10000 {
+		limit = 1000
+	}
+
+	filter := repo.FineTuneFilter{
+		Rating:  webCtx.Int64Input("rating", repo.MessageRatingLike),
+		Model:   webCtx.Input("model"),
+		StartID: webCtx.Int64Input("start_id", 0),
+		Limit:   500,
+	}
+
+	if startDate := webCtx.Input("start_date"); startDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+		filter.StartTime = t
+	}
+
+	if endDate := webCtx.Input("end_date"); endDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", endDate, time.Local)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+		filter.EndTime = t.AddDate(0, 0, 1)
+	}
+
+	consents := make(map[int64]bool)
+	lines := make([][]byte, 0)
+	var lastID int64
+
+	for int64(len(lines)) < limit {
+		samples, err := ctl.messageRepo.FineTuneSamples(ctx, filter)
+		if err != nil {
+			log.F(log.M{"filter": filter}).Errorf("query fine-tune samples failed: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		for _, sample := range samples {
+			lastID = sample.AnswerID
+			if !ctl.hasTrainingConsent(ctx, consents, sample.UserID) {
+				continue
+			}
+
+			if sample.Question == "" || sample.Answer == "" {
+				continue
+			}
+
+			lines = append(lines, buildFineTuneLine(format, sample))
+			if int64(len(lines)) >= limit {
+				break
+			}
+		}
+
+		if int64(len(samples)) < filter.Limit {
+			break
+		}
+
+		filter.StartID = lastID
+	}
+
+	log.F(log.M{"admin_id": user.ID, "format": format, "count": len(lines), "last_id": lastID}).Info("export fine-tune dataset")
+
+	return webCtx.Raw(func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/jsonl; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=fine-tune-%s.jsonl", time.Now().Format("20060102150405")))
+		// 下一次导出时可以使用该 ID 作为 start_id 继续导出
+		w.Header().Set("X-Next-Start-ID", strconv.Itoa(int(lastID)))
+
+		for _, line := range lines {
+			_, _ = w.Write(line)
+			_, _ = w.Write([]byte("\n"))
+		}
+	})
+}
+
+// hasTrainingConsent 检查用户是否授权聊天记录用于模型微调
+func (ctl *FineTuneController) hasTrainingConsent(ctx context.Context, consents map[int64]bool, userID int64) bool {
+	if consent, ok := consents[userID]; ok {
+		return consent
+	}
+
+	cus, err := ctl.userRepo.CustomConfig(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("get user custom config failed: %v", err)
+		return false
+	}
+
+	consents[userID] = cus.TrainingDataConsent
+	return cus.TrainingDataConsent
+}
+
+func buildFineTuneLine(format string, sample repo.FineTuneSample) []byte {
+	question, answer := misc.ScrubPII(sample.Question), misc.ScrubPII(sample.Answer)
+
+	var data []byte
+	if format == FineTuneFormatCompletion {
+		data, _ = json.Marshal(fineTuneCompletionLine{Prompt: question, Completion: answer})
+	} else {
+		data, _ = json.Marshal(fineTuneChatLine{Messages: []fineTuneChatMessage{
+			{Role: "user", Content: question},
+			{Role: "assistant", Content: answer},
+		}})
+	}
+
+	return data
+}
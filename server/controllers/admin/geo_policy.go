@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+var geoRegionPattern = regexp.MustCompile(`^([A-Z]{2}|\*)$`)
+
+// geoFeatures 地区策略可以限制的功能
+var geoFeatures = []string{repo.GeoFeatureChat, repo.GeoFeatureCreative, repo.GeoFeatureVoice, repo.GeoFeatureImage}
+
+// GeoPolicyController 地区访问策略管理
+type GeoPolicyController struct {
+	trans         youdao.Translater         `autowire:"@"`
+	geoPolicyRepo *repo.GeoPolicyRepo       `autowire:"@"`
+	geoPolicySrv  *service.GeoPolicyService `autowire:"@"`
+}
+
+func NewGeoPolicyController(resolver infra.Resolver) web.Controller {
+	ctl := GeoPolicyController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *GeoPolicyController) Register(router web.Router) {
+	router.Group("/geo-policies", func(router web.Router) {
+		router.Get("/", ctl.Policies)
+		router.Put("/{region}", ctl.UpdatePolicy)
+		router.Delete("/{region}", ctl.DeletePolicy)
+	})
+}
+
+// Policies 地区策略列表
+func (ctl *GeoPolicyController) Policies(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	policies, err := ctl.geoPolicyRepo.Policies(ctx)
+	if err != nil {
+		log.Errorf("query geo policies failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": policies, "features": geoFeatures})
+}
+
+// UpdatePolicy 新增或更新地区策略，region 为 ISO 3166-1 国家代码，* 表示默认策略
+func (ctl *GeoPolicyController) UpdatePolicy(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	region := strings.ToUpper(webCtx.PathVar("region"))
+	if !geoRegionPattern.MatchString(region) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	var policy repo.GeoPolicy
+	if err := webCtx.Unmarshal(&policy); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	for _, f := range policy.BlockedFeatures {
+		if !array.In(f, geoFeatures) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+	}
+
+	policy.Region = region
+	if err := ctl.geoPolicyRepo.UpdatePolicy(ctx, policy); err != nil {
+		log.Errorf("update geo policy failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// DeletePolicy 删除地区策略
+func (ctl *GeoPolicyController) DeletePolicy(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if err := ctl.geoPolicyRepo.DeletePolicy(ctx, strings.ToUpper(webCtx.PathVar("region"))); err != nil {
+		log.Errorf("delete geo policy failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *GeoPolicyController) reload(ctx context.Context) {
+	if err := ctl.geoPolicySrv.Reload(ctx); err != nil {
+		log.Errorf("reload geo policies failed: %v", err)
+	}
+}
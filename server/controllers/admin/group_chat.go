@@ -0,0 +1,139 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+type GroupChatController struct {
+	trans     youdao.Translater   `autowire:"@"`
+	groupRepo *repo.ChatGroupRepo `autowire:"@"`
+}
+
+func NewGroupChatController(resolver infra.Resolver) web.Controller {
+	ctl := GroupChatController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *GroupChatController) Register(router web.Router) {
+	router.Group("/group-chat", func(router web.Router) {
+		router.Get("/win-rates", ctl.WinRates)
+		router.Get("/compositions", ctl.Compositions)
+		router.Post("/compositions", ctl.CreateComposition)
+		router.Put("/compositions/{id}", ctl.UpdateComposition)
+		router.Delete("/compositions/{id}", ctl.DeleteComposition)
+	})
+}
+
+// WinRates 所有用户群聊中各模型被选为最佳回答的胜率
+func (ctl *GroupChatController) WinRates(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	rates, err := ctl.groupRepo.ModelWinRates(ctx, 0)
+	if err != nil {
+		log.Errorf("query model win rates failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": rates})
+}
+
+// Compositions 所有推荐的群组组合，包括已禁用的组合
+func (ctl *GroupChatController) Compositions(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	comps, err := ctl.groupRepo.Compositions(ctx, false)
+	if err != nil {
+		log.Errorf("query group compositions failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": comps})
+}
+
+// parseComposition 解析并校验推荐的群组组合
+func (ctl *GroupChatController) parseComposition(webCtx web.Context) (*repo.GroupComposition, bool) {
+	var comp repo.GroupComposition
+	if err := webCtx.Unmarshal(&comp); err != nil {
+		return nil, false
+	}
+
+	comp.Name = strings.TrimSpace(comp.Name)
+	if comp.Status == 0 {
+		comp.Status = repo.GroupCompositionStatusEnabled
+	}
+
+	if comp.Name == "" || len(comp.Members) == 0 {
+		return nil, false
+	}
+
+	if comp.Status != repo.GroupCompositionStatusEnabled && comp.Status != repo.GroupCompositionStatusDisabled {
+		return nil, false
+	}
+
+	for _, mem := range comp.Members {
+		if strings.TrimSpace(mem.ModelID) == "" {
+			return nil, false
+		}
+	}
+
+	return &comp, true
+}
+
+// CreateComposition 新增推荐的群组组合
+func (ctl *GroupChatController) CreateComposition(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	comp, ok := ctl.parseComposition(webCtx)
+	if !ok {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	id, err := ctl.groupRepo.CreateComposition(ctx, *comp)
+	if err != nil {
+		log.Errorf("create group composition failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// UpdateComposition 更新推荐的群组组合
+func (ctl *GroupChatController) UpdateComposition(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	comp, ok := ctl.parseComposition(webCtx)
+	if !ok {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.groupRepo.UpdateComposition(ctx, int64(id), *comp); err != nil {
+		log.Errorf("update group composition failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeleteComposition 删除推荐的群组组合
+func (ctl *GroupChatController) DeleteComposition(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.groupRepo.DeleteComposition(ctx, int64(id)); err != nil {
+		log.Errorf("delete group composition failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
@@ -0,0 +1,133 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// ImagePromptStyleController 提示语优化风格管理
+type ImagePromptStyleController struct {
+	trans           youdao.Translater     `autowire:"@"`
+	imagePromptRepo *repo.ImagePromptRepo `autowire:"@"`
+}
+
+func NewImagePromptStyleController(resolver infra.Resolver) web.Controller {
+	ctl := ImagePromptStyleController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *ImagePromptStyleController) Register(router web.Router) {
+	router.Group("/image-prompt-styles", func(router web.Router) {
+		router.Get("/", ctl.Styles)
+		router.Post("/", ctl.Create)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// Styles 提示语优化风格列表，包含已禁用的风格
+func (ctl *ImagePromptStyleController) Styles(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	styles, err := ctl.imagePromptRepo.Styles(ctx, false)
+	if err != nil {
+		log.Errorf("query image prompt styles failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": styles})
+}
+
+// Create 新增提示语优化风格
+func (ctl *ImagePromptStyleController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var style repo.ImagePromptStyle
+	if err := webCtx.Unmarshal(&style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateImagePromptStyle(&style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.imagePromptRepo.CreateStyle(ctx, style)
+	if err != nil {
+		log.Errorf("create image prompt style failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Update 更新提示语优化风格
+func (ctl *ImagePromptStyleController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var style repo.ImagePromptStyle
+	if err := webCtx.Unmarshal(&style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateImagePromptStyle(&style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.imagePromptRepo.UpdateStyle(ctx, int64(id), style); err != nil {
+		log.Errorf("update image prompt style failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除提示语优化风格
+func (ctl *ImagePromptStyleController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.imagePromptRepo.DeleteStyle(ctx, int64(id)); err != nil {
+		log.Errorf("delete image prompt style failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+func validateImagePromptStyle(style *repo.ImagePromptStyle) error {
+	style.Name, style.Instruction = strings.TrimSpace(style.Name), strings.TrimSpace(style.Instruction)
+	if style.Name == "" || len([]rune(style.Name)) > 50 {
+		return errors.New("风格名称不能为空且不能超过 50 个字符")
+	}
+
+	if style.Instruction == "" {
+		return errors.New("风格要求不能为空")
+	}
+
+	if len([]rune(style.Description)) > 255 || len(style.Preview) > 255 {
+		return errors.New("风格描述与预览图地址不能超过 255 个字符")
+	}
+
+	if style.Status == 0 {
+		style.Status = repo.ImagePromptStyleStatusEnabled
+	}
+
+	if style.Status != repo.ImagePromptStyleStatusEnabled && style.Status != repo.ImagePromptStyleStatusDisabled {
+		return errors.New("无效的风格状态")
+	}
+
+	return nil
+}
@@ -0,0 +1,414 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// imagePreviewMaxSize 预览图的最大文件大小
+const imagePreviewMaxSize = 5 * 1024 * 1024
+
+// ImageStyleController Stable Diffusion 模型（Checkpoint）、LoRA 与风格管理
+type ImageStyleController struct {
+	trans        youdao.Translater  `autowire:"@"`
+	creativeRepo *repo.CreativeRepo `autowire:"@"`
+	uploader     *uploader.Uploader `autowire:"@"`
+}
+
+func NewImageStyleController(resolver infra.Resolver) web.Controller {
+	ctl := ImageStyleController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *ImageStyleController) Register(router web.Router) {
+	router.Group("/image-models", func(router web.Router) {
+		router.Get("/", ctl.Models)
+		router.Post("/", ctl.CreateModel)
+		router.Put("/{id}", ctl.UpdateModel)
+		router.Delete("/{id}", ctl.DeleteModel)
+	})
+
+	router.Group("/image-loras", func(router web.Router) {
+		router.Get("/", ctl.Loras)
+		router.Post("/", ctl.CreateLora)
+		router.Put("/{id}", ctl.UpdateLora)
+		router.Delete("/{id}", ctl.DeleteLora)
+	})
+
+	router.Group("/image-styles", func(router web.Router) {
+		router.Get("/", ctl.Styles)
+		router.Post("/", ctl.CreateStyle)
+		router.Put("/{id}", ctl.UpdateStyle)
+		router.Delete("/{id}", ctl.DeleteStyle)
+	})
+
+	router.Post("/image-previews", ctl.UploadPreview)
+}
+
+// Models 图片模型（Checkpoint）列表，包含已禁用的模型
+func (ctl *ImageStyleController) Models(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	models, err := ctl.creativeRepo.AllModels(ctx)
+	if err != nil {
+		log.Errorf("query image models failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": models})
+}
+
+// CreateModel 新增图片模型（Checkpoint）
+func (ctl *ImageStyleController) CreateModel(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var m repo.ImageModel
+	if err := webCtx.Unmarshal(&m); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateImageModel(&m); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.creativeRepo.CreateModel(ctx, m)
+	if err != nil {
+		if errors.Is(err, repo.ErrImageModelExists) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "模型 ID 已存在"), http.StatusBadRequest)
+		}
+
+		log.Errorf("create image model failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// UpdateModel 更新图片模型（Checkpoint）
+func (ctl *ImageStyleController) UpdateModel(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var m repo.ImageModel
+	if err := webCtx.Unmarshal(&m); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateImageModel(&m); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.creativeRepo.UpdateModel(ctx, int64(id), m); err != nil {
+		if errors.Is(err, repo.ErrImageModelExists) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "模型 ID 已存在"), http.StatusBadRequest)
+		}
+
+		log.Errorf("update image model failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeleteModel 删除图片模型（Checkpoint）
+func (ctl *ImageStyleController) DeleteModel(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.creativeRepo.DeleteModel(ctx, int64(id)); err != nil {
+		log.Errorf("delete image model failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Loras LoRA 列表，包含已禁用的 LoRA
+func (ctl *ImageStyleController) Loras(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	loras, err := ctl.creativeRepo.Loras(ctx, false)
+	if err != nil {
+		log.Errorf("query image loras failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": loras})
+}
+
+// CreateLora 新增 LoRA
+func (ctl *ImageStyleController) CreateLora(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var lora repo.ImageLora
+	if err := webCtx.Unmarshal(&lora); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateImageLora(&lora); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.creativeRepo.CreateLora(ctx, lora)
+	if err != nil {
+		log.Errorf("create image lora failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// UpdateLora 更新 LoRA
+func (ctl *ImageStyleController) UpdateLora(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var lora repo.ImageLora
+	if err := webCtx.Unmarshal(&lora); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateImageLora(&lora); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.creativeRepo.UpdateLora(ctx, int64(id), lora); err != nil {
+		log.Errorf("update image lora failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeleteLora 删除 LoRA
+func (ctl *ImageStyleController) DeleteLora(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.creativeRepo.DeleteLora(ctx, int64(id)); err != nil {
+		log.Errorf("delete image lora failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Styles 风格列表，包含已禁用的风格
+func (ctl *ImageStyleController) Styles(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	styles, err := ctl.creativeRepo.AllFilters(ctx)
+	if err != nil {
+		log.Errorf("query image styles failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": styles})
+}
+
+// CreateStyle 新增风格
+func (ctl *ImageStyleController) CreateStyle(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var style repo.ImageFilter
+	if err := webCtx.Unmarshal(&style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.validateStyle(ctx, &style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.creativeRepo.CreateFilter(ctx, style)
+	if err != nil {
+		log.Errorf("create image style failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// UpdateStyle 更新风格
+func (ctl *ImageStyleController) UpdateStyle(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var style repo.ImageFilter
+	if err := webCtx.Unmarshal(&style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.validateStyle(ctx, &style); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.creativeRepo.UpdateFilter(ctx, int64(id), style); err != nil {
+		log.Errorf("update image style failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeleteStyle 删除风格
+func (ctl *ImageStyleController) DeleteStyle(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.creativeRepo.DeleteFilter(ctx, int64(id)); err != nil {
+		log.Errorf("delete image style failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// UploadPreview 上传模型、LoRA 或风格的预览图，返回图片地址
+func (ctl *ImageStyleController) UploadPreview(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	uploadedFile, err := webCtx.File("file")
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	defer func() { misc.NoError(uploadedFile.Delete()) }()
+
+	if uploadedFile.Size() > imagePreviewMaxSize {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrFileTooLarge), http.StatusBadRequest)
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(uploadedFile.Name()), "."))
+	if !array.In(ext, []string{"png", "jpg", "jpeg", "webp"}) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "只支持 png、jpg、webp 格式的图片"), http.StatusBadRequest)
+	}
+
+	data, err := os.ReadFile(uploadedFile.GetTempFilename())
+	if err != nil {
+		log.Errorf("read uploaded preview failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	url, err := ctl.uploader.UploadStream(ctx, int(user.ID), 0, data, ext)
+	if err != nil {
+		log.Errorf("upload preview failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"url": url})
+}
+
+func normalizeImageStyleStatus(status *int64) error {
+	if *status == 0 {
+		*status = repo.ImageStyleStatusEnabled
+	}
+
+	if *status != repo.ImageStyleStatusEnabled && *status != repo.ImageStyleStatusDisabled {
+		return errors.New("无效的状态")
+	}
+
+	return nil
+}
+
+func validateImageModel(m *repo.ImageModel) error {
+	m.ModelId, m.ModelName = strings.TrimSpace(m.ModelId), strings.TrimSpace(m.ModelName)
+	m.Vendor, m.RealModel = strings.TrimSpace(m.Vendor), strings.TrimSpace(m.RealModel)
+	if m.ModelId == "" || m.ModelName == "" || m.Vendor == "" || m.RealModel == "" {
+		return errors.New("模型 ID、名称、服务商与实际模型不能为空")
+	}
+
+	return normalizeImageStyleStatus(&m.Status)
+}
+
+func validateImageLora(lora *repo.ImageLora) error {
+	lora.Name, lora.File = strings.TrimSpace(lora.Name), strings.TrimSpace(lora.File)
+	if lora.Name == "" || len([]rune(lora.Name)) > 100 {
+		return errors.New("LoRA 名称不能为空且不能超过 100 个字符")
+	}
+
+	// 文件名会直接拼接到提示语中，不能包含 LoRA 语法的分隔符
+	if lora.File == "" || len(lora.File) > 255 || strings.ContainsAny(lora.File, "<>:,") {
+		return errors.New("无效的 LoRA 文件名")
+	}
+
+	if lora.SourceURL != "" && !strings.HasPrefix(lora.SourceURL, "http://") && !strings.HasPrefix(lora.SourceURL, "https://") {
+		return errors.New("无效的 LoRA 来源地址")
+	}
+
+	if lora.DefaultWeight == 0 {
+		lora.DefaultWeight = 0.8
+	}
+
+	if lora.DefaultWeight < -2 || lora.DefaultWeight > 2 {
+		return errors.New("LoRA 权重范围为 -2 到 2")
+	}
+
+	return normalizeImageStyleStatus(&lora.Status)
+}
+
+func (ctl *ImageStyleController) validateStyle(ctx context.Context, style *repo.ImageFilter) error {
+	style.Name, style.ModelId = strings.TrimSpace(style.Name), strings.TrimSpace(style.ModelId)
+	if style.Name == "" || style.ModelId == "" {
+		return errors.New("风格名称与模型不能为空")
+	}
+
+	meta := &style.ImageMeta
+	for _, s := range meta.Supports {
+		if !array.In(s, []string{"text-to-image", "image-to-image"}) {
+			return errors.New("无效的风格支持类型")
+		}
+	}
+
+	if meta.Mode != "" && !array.In(meta.Mode, []string{"canny", "mlsd", "pose", "scribble"}) {
+		return errors.New("无效的图生图模式")
+	}
+
+	if d := meta.Defaults; d != nil {
+		if d.Steps != 0 && !array.In(d.Steps, []int64{30, 50, 100, 150}) {
+			return errors.New("无效的默认步数")
+		}
+
+		if d.ImageRatio != "" && !array.In(d.ImageRatio, []string{"1:1", "4:3", "3:4", "3:2", "2:3", "16:9"}) {
+			return errors.New("无效的默认图片比例")
+		}
+
+		if d.ImageStrength < 0 || d.ImageStrength > 1 {
+			return errors.New("无效的默认图片强度")
+		}
+	}
+
+	for _, lora := range meta.Loras {
+		if lora.Weight < -2 || lora.Weight > 2 {
+			return errors.New("LoRA 权重范围为 -2 到 2")
+		}
+	}
+
+	if len(meta.Loras) > 0 {
+		loras, err := ctl.creativeRepo.LorasByIDs(ctx, array.Map(meta.Loras, func(item repo.ImageFilterLora, _ int) int64 { return item.ID }))
+		if err != nil {
+			log.Errorf("query image loras failed: %v", err)
+			return errors.New(common.ErrInternalError)
+		}
+
+		if len(loras) != len(array.Uniq(array.Map(meta.Loras, func(item repo.ImageFilterLora, _ int) int64 { return item.ID }))) {
+			return errors.New("LoRA 不存在或已禁用")
+		}
+	}
+
+	return normalizeImageStyleStatus(&style.Status)
+}
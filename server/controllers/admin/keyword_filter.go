@@ -0,0 +1,231 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// keywordFilterRegexPrefix 批量导入的关键词列表中，以该前缀开头的行为正则表达式
+const keywordFilterRegexPrefix = "re:"
+
+// KeywordFilterController 关键词过滤规则管理
+type KeywordFilterController struct {
+	trans             youdao.Translater             `autowire:"@"`
+	keywordFilterRepo *repo.KeywordFilterRepo       `autowire:"@"`
+	keywordFilterSrv  *service.KeywordFilterService `autowire:"@"`
+}
+
+func NewKeywordFilterController(resolver infra.Resolver) web.Controller {
+	ctl := KeywordFilterController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *KeywordFilterController) Register(router web.Router) {
+	router.Group("/keyword-filters", func(router web.Router) {
+		router.Get("/", ctl.Rules)
+		router.Post("/", ctl.Import)
+		router.Post("/reload", ctl.Reload)
+		router.Get("/hits", ctl.Hits)
+		router.Put("/hits/{id}/reviewed", ctl.MarkHitReviewed)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// Rules 关键词规则列表，包含每条规则的命中次数与最后命中时间
+func (ctl *KeywordFilterController) Rules(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	rules, err := ctl.keywordFilterRepo.Rules(ctx, false)
+	if err != nil {
+		log.Errorf("query keyword filter rules failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": rules})
+}
+
+type KeywordFilterImportRequest struct {
+	// Rules 关键词规则
+	Rules []repo.KeywordFilterRule `json:"rules"`
+	// Text 关键词列表，每行一个关键词，以 re: 开头的行为正则表达式，使用 Action 和 Scope 作为规则的动作与生效范围
+	Text   string `json:"text"`
+	Action int64  `json:"action"`
+	Scope  int64  `json:"scope"`
+}
+
+// Import 批量导入关键词规则
+func (ctl *KeywordFilterController) Import(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req KeywordFilterImportRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	rules := req.Rules
+	for _, line := range strings.Split(req.Text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		rule := repo.KeywordFilterRule{Keyword: line, Action: req.Action, Scope: req.Scope}
+		if strings.HasPrefix(line, keywordFilterRegexPrefix) {
+			rule.Keyword, rule.IsRegex = strings.TrimPrefix(line, keywordFilterRegexPrefix), true
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	for _, rule := range rules {
+		if err := validateKeywordFilterRule(rule); err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+		}
+	}
+
+	if err := ctl.keywordFilterRepo.Import(ctx, rules); err != nil {
+		log.Errorf("import keyword filter rules failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{"count": len(rules)})
+}
+
+type KeywordFilterUpdateRequest struct {
+	repo.KeywordFilterRule
+	Status int64 `json:"status"`
+}
+
+// Update 更新关键词规则
+func (ctl *KeywordFilterController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var req KeywordFilterUpdateRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if req.Status != repo.KeywordFilterStatusEnabled && req.Status != repo.KeywordFilterStatusDisabled {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateKeywordFilterRule(req.KeywordFilterRule); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.keywordFilterRepo.Update(ctx, int64(id), req.KeywordFilterRule, req.Status); err != nil {
+		log.Errorf("update keyword filter rule failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除关键词规则
+func (ctl *KeywordFilterController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.keywordFilterRepo.Delete(ctx, int64(id)); err != nil {
+		log.Errorf("delete keyword filter rule failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Reload 立即重新加载关键词规则，其它实例会在下一个加载周期自动生效
+func (ctl *KeywordFilterController) Reload(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if err := ctl.keywordFilterSrv.Reload(ctx); err != nil {
+		log.Errorf("reload keyword filter rules failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Hits 关键词命中记录，status=1 时为待审核记录
+func (ctl *KeywordFilterController) Hits(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	limit := webCtx.Int64Input("limit", 100)
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	hits, err := ctl.keywordFilterRepo.Hits(ctx, webCtx.Int64Input("filter_id", 0), webCtx.Int64Input("status", 0), limit)
+	if err != nil {
+		log.Errorf("query keyword filter hits failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": hits})
+}
+
+// MarkHitReviewed 将待审核的命中记录标记为已审核
+func (ctl *KeywordFilterController) MarkHitReviewed(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.keywordFilterRepo.MarkHitReviewed(ctx, int64(id)); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.Errorf("mark keyword filter hit reviewed failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *KeywordFilterController) reload(ctx context.Context) {
+	if err := ctl.keywordFilterSrv.Reload(ctx); err != nil {
+		log.Errorf("reload keyword filter rules failed: %v", err)
+	}
+}
+
+func validateKeywordFilterRule(rule repo.KeywordFilterRule) error {
+	if strings.TrimSpace(rule.Keyword) == "" || len([]rune(rule.Keyword)) > 255 {
+		return errors.New("关键词不能为空且不能超过 255 个字符")
+	}
+
+	if rule.Action < repo.KeywordFilterActionBlock || rule.Action > repo.KeywordFilterActionReview {
+		return errors.New("无效的命中动作")
+	}
+
+	if rule.Scope < repo.KeywordFilterScopePrompt || rule.Scope > repo.KeywordFilterScopeAll {
+		return errors.New("无效的生效范围")
+	}
+
+	if rule.IsRegex {
+		if _, err := regexp.Compile(rule.Keyword); err != nil {
+			return errors.New("无效的正则表达式")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+const (
+	// defaultLogLevelTTL 临时调整日志级别的默认有效期
+	defaultLogLevelTTL = time.Hour
+	// maxLogLevelTTL 临时调整日志级别的最大有效期，避免 DEBUG 日志长期开启
+	maxLogLevelTTL = 24 * time.Hour
+)
+
+// LogLevelController 运行时调整日志级别
+type LogLevelController struct {
+	trans youdao.Translater        `autowire:"@"`
+	srv   *service.LogLevelService `autowire:"@"`
+}
+
+func NewLogLevelController(resolver infra.Resolver) web.Controller {
+	ctl := LogLevelController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *LogLevelController) Register(router web.Router) {
+	router.Group("/log-level", func(router web.Router) {
+		router.Get("/", ctl.Current)
+		router.Put("/", ctl.Update)
+		router.Delete("/", ctl.Reset)
+	})
+}
+
+// Current 当前生效的日志级别
+func (ctl *LogLevelController) Current(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	res, err := ctl.srv.Current(ctx)
+	if err != nil {
+		log.Errorf("query log level failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": res})
+}
+
+// Update 临时调整所有实例的日志级别，ttl 为有效期，例如 30m，到期后恢复为默认日志级别
+func (ctl *LogLevelController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	ttl := defaultLogLevelTTL
+	if v := webCtx.Input("ttl"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 || d > maxLogLevelTTL {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+
+		ttl = d
+	}
+
+	if err := ctl.srv.Set(ctx, webCtx.Input("level"), ttl); err != nil {
+		if errors.Is(err, service.ErrInvalidLogLevel) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+		}
+
+		log.Errorf("update log level failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	log.F(log.M{"user_id": user.ID, "level": webCtx.Input("level"), "ttl": ttl.String()}).Warning("log level updated by admin")
+	return ctl.Current(ctx, webCtx, user)
+}
+
+// Reset 恢复为默认日志级别
+func (ctl *LogLevelController) Reset(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if err := ctl.srv.Reset(ctx); err != nil {
+		log.Errorf("reset log level failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return ctl.Current(ctx, webCtx, user)
+}
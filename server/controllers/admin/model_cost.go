@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// modelCostMaxDays 毛利报表单次查询的最大天数
+const modelCostMaxDays = 366
+
+// ModelCostController 模型上游成本与毛利报表
+type ModelCostController struct {
+	trans         youdao.Translater   `autowire:"@"`
+	modelCostRepo *repo.ModelCostRepo `autowire:"@"`
+}
+
+func NewModelCostController(resolver infra.Resolver) web.Controller {
+	ctl := ModelCostController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *ModelCostController) Register(router web.Router) {
+	router.Group("/model-costs", func(router web.Router) {
+		router.Get("/report", ctl.Report)
+	})
+}
+
+// Report 毛利报表，默认为最近 7 天按照 日期 + 模型 + 服务商 聚合，结果按照毛利从低到高排序
+// group_by 为逗号分隔的聚合维度，可选值为 date、model、provider
+func (ctl *ModelCostController) Report(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	startDate, endDate := today.AddDate(0, 0, -6), today
+
+	if v := webCtx.Input("start_date"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+		startDate = t
+	}
+
+	if v := webCtx.Input("end_date"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+		endDate = t
+	}
+
+	if endDate.Before(startDate) || endDate.Sub(startDate) > modelCostMaxDays*24*time.Hour {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	groupBy := []string{repo.ModelCostGroupByDate, repo.ModelCostGroupByModel, repo.ModelCostGroupByProvider}
+	if v := webCtx.Input("group_by"); v != "" {
+		groupBy = array.Filter(
+			array.Map(strings.Split(v, ","), func(g string, _ int) string { return strings.TrimSpace(g) }),
+			func(g string, _ int) bool { return g != "" },
+		)
+
+		for _, g := range groupBy {
+			if !array.In(g, []string{repo.ModelCostGroupByDate, repo.ModelCostGroupByModel, repo.ModelCostGroupByProvider}) {
+				return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+			}
+		}
+	}
+
+	items, err := ctl.modelCostRepo.Report(ctx, startDate, endDate, groupBy)
+	if err != nil {
+		log.F(log.M{"group_by": groupBy}).Errorf("query model cost report failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":       items,
+		"start_date": startDate.Format("2006-01-02"),
+		"end_date":   endDate.Format("2006-01-02"),
+		"group_by":   groupBy,
+	})
+}
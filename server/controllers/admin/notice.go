@@ -0,0 +1,163 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// NoticeController 系统公告与维护窗口管理
+type NoticeController struct {
+	trans      youdao.Translater      `autowire:"@"`
+	noticeRepo *repo.NoticeRepo       `autowire:"@"`
+	noticeSrv  *service.NoticeService `autowire:"@"`
+}
+
+func NewNoticeController(resolver infra.Resolver) web.Controller {
+	ctl := NoticeController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *NoticeController) Register(router web.Router) {
+	router.Group("/notices", func(router web.Router) {
+		router.Get("/", ctl.Notices)
+		router.Post("/", ctl.Create)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// Notices 系统通知列表
+func (ctl *NoticeController) Notices(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	notices, err := ctl.noticeRepo.Notices(ctx, webCtx.Input("unexpired") == "true")
+	if err != nil {
+		log.Errorf("query notices failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": notices, "features": geoFeatures})
+}
+
+// Create 新增公告横幅或维护窗口
+func (ctl *NoticeController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var notice repo.Notice
+	if err := webCtx.Unmarshal(&notice); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateNotice(&notice); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.noticeRepo.CreateNotice(ctx, notice)
+	if err != nil {
+		log.Errorf("create notice failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Update 更新公告横幅或维护窗口，例如提前结束维护
+func (ctl *NoticeController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var notice repo.Notice
+	if err := webCtx.Unmarshal(&notice); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateNotice(&notice); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.noticeRepo.UpdateNotice(ctx, int64(id), notice); err != nil {
+		log.Errorf("update notice failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除公告横幅或维护窗口
+func (ctl *NoticeController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.noticeRepo.DeleteNotice(ctx, int64(id)); err != nil {
+		log.Errorf("delete notice failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *NoticeController) reload(ctx context.Context) {
+	if err := ctl.noticeSrv.Reload(ctx); err != nil {
+		log.Errorf("reload notices failed: %v", err)
+	}
+}
+
+func validateNotice(notice *repo.Notice) error {
+	notice.Title, notice.Content = strings.TrimSpace(notice.Title), strings.TrimSpace(notice.Content)
+	if notice.Type != repo.NoticeTypeBanner && notice.Type != repo.NoticeTypeMaintenance {
+		return errors.New("无效的通知类型")
+	}
+
+	if notice.Level == "" {
+		notice.Level = repo.NoticeLevelInfo
+	}
+
+	if !array.In(notice.Level, []string{repo.NoticeLevelInfo, repo.NoticeLevelWarning, repo.NoticeLevelError}) {
+		return errors.New("无效的通知级别")
+	}
+
+	if notice.Content == "" || len([]rune(notice.Title)) > 255 {
+		return errors.New("通知内容不能为空，标题不能超过 255 个字符")
+	}
+
+	if notice.StartAt.IsZero() || !notice.EndAt.After(notice.StartAt) {
+		return errors.New("结束时间必须晚于开始时间")
+	}
+
+	if notice.Type == repo.NoticeTypeBanner {
+		notice.GatedFeatures = []string{}
+	}
+
+	for _, f := range notice.GatedFeatures {
+		if !array.In(f, geoFeatures) {
+			return errors.New("不支持暂停的功能：" + f)
+		}
+	}
+
+	if notice.Status == 0 {
+		notice.Status = repo.NoticeStatusEnabled
+	}
+
+	if notice.Status != repo.NoticeStatusEnabled && notice.Status != repo.NoticeStatusDisabled {
+		return errors.New("无效的通知状态")
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// OnboardingController 新用户引导内容管理
+type OnboardingController struct {
+	trans          youdao.Translater          `autowire:"@"`
+	onboardingRepo *repo.OnboardingRepo       `autowire:"@"`
+	onboardingSrv  *service.OnboardingService `autowire:"@"`
+}
+
+func NewOnboardingController(resolver infra.Resolver) web.Controller {
+	ctl := OnboardingController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *OnboardingController) Register(router web.Router) {
+	router.Group("/onboarding", func(router web.Router) {
+		router.Get("/", ctl.Items)
+		router.Post("/", ctl.Create)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// Items 全部引导内容，包括禁用的内容，以及各个引导步骤的完成人数
+func (ctl *OnboardingController) Items(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	items, err := ctl.onboardingRepo.Items(ctx, false)
+	if err != nil {
+		log.Errorf("query onboarding items failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	counts, err := ctl.onboardingRepo.CompletionCounts(ctx)
+	if err != nil {
+		log.Errorf("query onboarding completion counts failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items, "completions": counts})
+}
+
+// Create 新增引导内容
+func (ctl *OnboardingController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var item repo.OnboardingItem
+	if err := webCtx.Unmarshal(&item); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateOnboardingItem(&item); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.onboardingRepo.CreateItem(ctx, item)
+	if err != nil {
+		log.Errorf("create onboarding item failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Update 更新引导内容
+func (ctl *OnboardingController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var item repo.OnboardingItem
+	if err := webCtx.Unmarshal(&item); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateOnboardingItem(&item); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.onboardingRepo.UpdateItem(ctx, int64(id), item); err != nil {
+		log.Errorf("update onboarding item failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除引导内容
+func (ctl *OnboardingController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.onboardingRepo.DeleteItem(ctx, int64(id)); err != nil {
+		log.Errorf("delete onboarding item failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *OnboardingController) reload(ctx context.Context) {
+	if err := ctl.onboardingSrv.Reload(ctx); err != nil {
+		log.Errorf("reload onboarding items failed: %v", err)
+	}
+}
+
+func validateOnboardingItem(item *repo.OnboardingItem) error {
+	item.Title, item.Content = strings.TrimSpace(item.Title), strings.TrimSpace(item.Content)
+	item.Language, item.Persona, item.Payload = strings.TrimSpace(item.Language), strings.TrimSpace(item.Persona), strings.TrimSpace(item.Payload)
+
+	if !array.In(item.Kind, []string{repo.OnboardingKindStep, repo.OnboardingKindPrompt, repo.OnboardingKindModel}) {
+		return errors.New("无效的引导内容类型")
+	}
+
+	if item.Language == "" {
+		item.Language = service.OnboardingDefaultLanguage
+	}
+
+	if len(item.Language) > 20 || len([]rune(item.Persona)) > 50 {
+		return errors.New("语言不能超过 20 个字符，角色不能超过 50 个字符")
+	}
+
+	if item.Title == "" || len([]rune(item.Title)) > 255 {
+		return errors.New("标题不能为空，且不能超过 255 个字符")
+	}
+
+	if item.Kind == repo.OnboardingKindPrompt && item.Content == "" {
+		return errors.New("示例提示语内容不能为空")
+	}
+
+	if item.Kind == repo.OnboardingKindModel && item.Payload == "" {
+		return errors.New("推荐模型需要指定模型 ID")
+	}
+
+	if len([]rune(item.Payload)) > 255 {
+		return errors.New("附加数据不能超过 255 个字符")
+	}
+
+	if item.Status == 0 {
+		item.Status = repo.OnboardingStatusEnabled
+	}
+
+	if item.Status != repo.OnboardingStatusEnabled && item.Status != repo.OnboardingStatusDisabled {
+		return errors.New("无效的引导内容状态")
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// PaymentRefundController 第三方支付平台退款记录的财务复核
+type PaymentRefundController struct {
+	trans   youdao.Translater `autowire:"@"`
+	payRepo *repo.PaymentRepo `autowire:"@"`
+}
+
+func NewPaymentRefundController(resolver infra.Resolver) web.Controller {
+	ctl := PaymentRefundController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *PaymentRefundController) Register(router web.Router) {
+	router.Group("/payment-refunds", func(router web.Router) {
+		router.Get("/", ctl.Refunds)
+		router.Put("/{id}/review", ctl.Review)
+	})
+}
+
+// Refunds 退款记录列表，reviewed 参数为 true/false 时按照复核状态筛选
+func (ctl *PaymentRefundController) Refunds(ctx context.Context, webCtx web.Context) web.Response {
+	page := webCtx.Int64Input("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	perPage := webCtx.Int64Input("per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	var reviewed *bool
+	if v := webCtx.Input("reviewed"); v == "true" || v == "false" {
+		val := v == "true"
+		reviewed = &val
+	}
+
+	refunds, meta, err := ctl.payRepo.PaymentRefunds(ctx, reviewed, page, perPage)
+	if err != nil {
+		log.Errorf("query payment refunds failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":      refunds,
+		"page":      meta.Page,
+		"per_page":  meta.PerPage,
+		"total":     meta.Total,
+		"last_page": meta.LastPage,
+	})
+}
+
+// Review 标记退款记录已复核
+func (ctl *PaymentRefundController) Review(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	note := strings.TrimSpace(webCtx.Input("note"))
+	if len([]rune(note)) > 255 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.payRepo.ReviewPaymentRefund(ctx, id, note); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"refund_id": id, "admin_id": user.ID}).Errorf("review payment refund failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
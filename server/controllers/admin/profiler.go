@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/profiler"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+const (
+	// maxPerfReportDays 性能报告最多查询的天数
+	maxPerfReportDays = 90
+	// perfReportLimit 性能报告中每类统计返回的条数
+	perfReportLimit = 20
+)
+
+// ProfilerController 慢查询、慢接口统计与 pprof
+type ProfilerController struct {
+	conf  *config.Config    `autowire:"@"`
+	trans youdao.Translater `autowire:"@"`
+	repo  *repo.Repository  `autowire:"@"`
+}
+
+func NewProfilerController(resolver infra.Resolver) web.Controller {
+	ctl := ProfilerController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *ProfilerController) Register(router web.Router) {
+	router.Group("/perf", func(router web.Router) {
+		router.Get("/report", ctl.Report)
+	})
+
+	router.Group("/pprof", func(router web.Router) {
+		router.Get("/", ctl.Pprof)
+		router.Get("/{name}", ctl.Pprof)
+		router.Post("/symbol", ctl.Pprof)
+	})
+}
+
+// Report 最近 days 天（默认 7 天）平均耗时最高的接口与数据库查询
+func (ctl *ProfilerController) Report(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	days := int64(7)
+	if v := webCtx.Input("days"); v != "" {
+		d, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || d <= 0 || d > maxPerfReportDays {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+
+		days = d
+	}
+
+	minCount := webCtx.Int64Input("min_count", 1)
+	endDate := time.Now()
+	startDate := endDate.AddDate(0, 0, -int(days-1))
+
+	endpoints, err := ctl.repo.PerfStat.Slowest(ctx, profiler.KindEndpoint, startDate, endDate, minCount, perfReportLimit)
+	if err != nil {
+		log.Errorf("query slowest endpoints failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	queries, err := ctl.repo.PerfStat.Slowest(ctx, profiler.KindQuery, startDate, endDate, minCount, perfReportLimit)
+	if err != nil {
+		log.Errorf("query slowest queries failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data": web.M{
+			"start_date": startDate.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+			"endpoints":  endpoints,
+			"queries":    queries,
+		},
+	})
+}
+
+// Pprof 运行时性能分析，需要通过 enable-pprof 启用，例如 go tool pprof -http=:8080 'https://host/v1/admin/pprof/profile?seconds=30'
+func (ctl *ProfilerController) Pprof(webCtx web.Context, user *auth.User) web.Response {
+	if !ctl.conf.EnablePprof {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	name := webCtx.PathVar("name")
+	log.F(log.M{"user_id": user.ID, "name": name}).Info("pprof accessed by admin")
+
+	req := webCtx.Request().Raw()
+	return webCtx.Raw(func(w http.ResponseWriter) {
+		switch name {
+		case "":
+			// pprof.Index 只能识别 /debug/pprof/ 前缀，其它路径下固定展示索引页
+			pprof.Index(w, req)
+		case "cmdline":
+			pprof.Cmdline(w, req)
+		case "profile":
+			pprof.Profile(w, req)
+		case "symbol":
+			pprof.Symbol(w, req)
+		case "trace":
+			pprof.Trace(w, req)
+		default:
+			pprof.Handler(name).ServeHTTP(w, req)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// PromoController 抽奖活动管理
+type PromoController struct {
+	trans     youdao.Translater `autowire:"@"`
+	promoRepo *repo.PromoRepo   `autowire:"@"`
+}
+
+func NewPromoController(resolver infra.Resolver) web.Controller {
+	ctl := PromoController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *PromoController) Register(router web.Router) {
+	router.Group("/promo-events", func(router web.Router) {
+		router.Get("/", ctl.Events)
+		router.Post("/", ctl.Create)
+		router.Put("/{id}/status", ctl.UpdateStatus)
+		router.Get("/{id}/report", ctl.Report)
+	})
+}
+
+// Events 活动列表
+func (ctl *PromoController) Events(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	events, err := ctl.promoRepo.Events(ctx, false)
+	if err != nil {
+		log.Errorf("query promo events failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": events})
+}
+
+type PromoEventRequest struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	StartAt     time.Time `json:"start_at"`
+	EndAt       time.Time `json:"end_at"`
+	// DailyLimit 每个用户每天最多抽奖次数，0 表示不限制
+	DailyLimit int64 `json:"daily_limit"`
+	// TotalLimit 每个用户活动期间最多抽奖次数，0 表示不限制
+	TotalLimit int64                `json:"total_limit"`
+	Prizes     []repo.PromoPrizeReq `json:"prizes"`
+}
+
+// Create 创建活动，所有奖品的中奖概率（万分比）之和不能超过 10000，剩余部分为未中奖
+func (ctl *PromoController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req PromoEventRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || !req.EndAt.After(req.StartAt) || req.DailyLimit < 0 || req.TotalLimit < 0 || len(req.Prizes) == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	var probability int64
+	for _, p := range req.Prizes {
+		if p.Name == "" || p.Probability < 0 || p.Value <= 0 || p.Stock < -1 ||
+			(p.PrizeType != repo.PromoPrizeTypeCoins && p.PrizeType != repo.PromoPrizeTypeCoupon) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+
+		probability += p.Probability
+	}
+
+	if probability > repo.PromoProbabilityBase {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	id, err := ctl.promoRepo.CreateEvent(ctx, model.PromoEvent{
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      repo.PromoEventStatusEnabled,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		DailyLimit:  req.DailyLimit,
+		TotalLimit:  req.TotalLimit,
+	}, req.Prizes)
+	if err != nil {
+		log.Errorf("create promo event failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// UpdateStatus 启用或禁用活动
+func (ctl *PromoController) UpdateStatus(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	eventID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	status := webCtx.Int64Input("status", 0)
+	if status != repo.PromoEventStatusEnabled && status != repo.PromoEventStatusDisabled {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.promoRepo.UpdateEventStatus(ctx, int64(eventID), status); err != nil {
+		log.Errorf("update promo event status failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Report 活动奖品发放统计
+func (ctl *PromoController) Report(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	eventID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	event, err := ctl.promoRepo.Event(ctx, int64(eventID))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.Errorf("query promo event failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	prizes, err := ctl.promoRepo.Prizes(ctx, event.Id)
+	if err != nil {
+		log.Errorf("query promo prizes failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	report, err := ctl.promoRepo.Report(ctx, event.Id)
+	if err != nil {
+		log.Errorf("query promo report failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"event":  event,
+		"prizes": prizes,
+		"report": report,
+	})
+}
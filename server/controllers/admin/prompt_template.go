@@ -0,0 +1,152 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// PromptTemplateController 提示语模板管理，用于简历优化等需要可维护提示语的场景
+type PromptTemplateController struct {
+	trans   youdao.Translater        `autowire:"@"`
+	tplRepo *repo.PromptTemplateRepo `autowire:"@"`
+}
+
+func NewPromptTemplateController(resolver infra.Resolver) web.Controller {
+	ctl := PromptTemplateController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *PromptTemplateController) Register(router web.Router) {
+	router.Group("/prompt-templates", func(router web.Router) {
+		router.Get("/", ctl.Templates)
+		router.Post("/", ctl.Create)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// Templates 提示语模板列表，可以通过 scene 参数筛选场景
+func (ctl *PromptTemplateController) Templates(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	templates, err := ctl.tplRepo.Templates(ctx, webCtx.Input("scene"), false)
+	if err != nil {
+		log.Errorf("query prompt templates failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": templates})
+}
+
+type PromptTemplateRequest struct {
+	Scene       string `json:"scene"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// SystemPrompt 系统提示语
+	SystemPrompt string `json:"system_prompt"`
+	// UserPrompt 用户提示语，支持 {{resume}}、{{job_description}}、{{feedback}} 等变量
+	UserPrompt string `json:"user_prompt"`
+	Sort       int64  `json:"sort"`
+	Status     int64  `json:"status"`
+}
+
+func (req PromptTemplateRequest) toModel() model.PromptTemplate {
+	return model.PromptTemplate{
+		Scene:        req.Scene,
+		Name:         req.Name,
+		Description:  req.Description,
+		SystemPrompt: req.SystemPrompt,
+		UserPrompt:   req.UserPrompt,
+		Sort:         req.Sort,
+		Status:       req.Status,
+	}
+}
+
+func (ctl *PromptTemplateController) parseRequest(webCtx web.Context) (*PromptTemplateRequest, bool) {
+	var req PromptTemplateRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return nil, false
+	}
+
+	req.Scene, req.Name = strings.TrimSpace(req.Scene), strings.TrimSpace(req.Name)
+	if req.Scene == "" || req.Name == "" || strings.TrimSpace(req.UserPrompt) == "" {
+		return nil, false
+	}
+
+	if req.Status != repo.PromptTemplateStatusEnabled {
+		req.Status = repo.PromptTemplateStatusDisabled
+	}
+
+	return &req, true
+}
+
+// Create 创建提示语模板
+func (ctl *PromptTemplateController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	req, ok := ctl.parseRequest(webCtx)
+	if !ok {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	id, err := ctl.tplRepo.CreateTemplate(ctx, req.toModel())
+	if err != nil {
+		log.F(log.M{"req": req}).Errorf("create prompt template failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Update 更新提示语模板
+func (ctl *PromptTemplateController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	req, ok := ctl.parseRequest(webCtx)
+	if !ok {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if _, err := ctl.tplRepo.Template(ctx, int64(id)); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"id": id}).Errorf("query prompt template failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.tplRepo.UpdateTemplate(ctx, int64(id), req.toModel()); err != nil {
+		log.F(log.M{"id": id, "req": req}).Errorf("update prompt template failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除提示语模板
+func (ctl *PromptTemplateController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.tplRepo.DeleteTemplate(ctx, int64(id)); err != nil {
+		log.F(log.M{"id": id}).Errorf("delete prompt template failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
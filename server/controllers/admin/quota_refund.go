@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// QuotaRefundController 上游服务异常退款报表
+type QuotaRefundController struct {
+	trans           youdao.Translater     `autowire:"@"`
+	quotaRefundRepo *repo.QuotaRefundRepo `autowire:"@"`
+}
+
+func NewQuotaRefundController(resolver infra.Resolver) web.Controller {
+	ctl := QuotaRefundController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *QuotaRefundController) Register(router web.Router) {
+	router.Group("/quota-refunds", func(router web.Router) {
+		router.Get("/report", ctl.Report)
+	})
+}
+
+// Report 退款报表，默认为最近 30 天按照 服务商 + 模型 聚合，结果按照退款数量从高到低排序
+func (ctl *QuotaRefundController) Report(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	startDate, endDate := today.AddDate(0, 0, -29), today
+
+	if v := webCtx.Input("start_date"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+		startDate = t
+	}
+
+	if v := webCtx.Input("end_date"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+		endDate = t
+	}
+
+	if endDate.Before(startDate) || endDate.Sub(startDate) > modelCostMaxDays*24*time.Hour {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// end_date 当天的退款也包含在报表中
+	items, err := ctl.quotaRefundRepo.Report(ctx, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		log.Errorf("query quota refund report failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":       items,
+		"start_date": startDate.Format("2006-01-02"),
+		"end_date":   endDate.Format("2006-01-02"),
+	})
+}
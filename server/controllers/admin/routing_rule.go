@@ -0,0 +1,221 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// RoutingRuleController 模型路由规则管理
+type RoutingRuleController struct {
+	trans           youdao.Translater           `autowire:"@"`
+	routingRuleRepo *repo.RoutingRuleRepo       `autowire:"@"`
+	routingRuleSrv  *service.RoutingRuleService `autowire:"@"`
+}
+
+func NewRoutingRuleController(resolver infra.Resolver) web.Controller {
+	ctl := RoutingRuleController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *RoutingRuleController) Register(router web.Router) {
+	router.Group("/routing-rules", func(router web.Router) {
+		router.Get("/", ctl.Rules)
+		router.Post("/", ctl.Create)
+		router.Post("/evaluate", ctl.Evaluate)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// Rules 模型路由规则列表，按照匹配顺序排序
+func (ctl *RoutingRuleController) Rules(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	rules, err := ctl.routingRuleRepo.Rules(ctx, false)
+	if err != nil {
+		log.Errorf("query routing rules failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": rules})
+}
+
+// Create 新增模型路由规则
+func (ctl *RoutingRuleController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var rule repo.RoutingRule
+	if err := webCtx.Unmarshal(&rule); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateRoutingRule(&rule); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	id, err := ctl.routingRuleRepo.CreateRule(ctx, rule)
+	if err != nil {
+		log.Errorf("create routing rule failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Update 更新模型路由规则
+func (ctl *RoutingRuleController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var rule repo.RoutingRule
+	if err := webCtx.Unmarshal(&rule); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := validateRoutingRule(&rule); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+	}
+
+	if err := ctl.routingRuleRepo.UpdateRule(ctx, int64(id), rule); err != nil {
+		log.Errorf("update routing rule failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除模型路由规则
+func (ctl *RoutingRuleController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.routingRuleRepo.DeleteRule(ctx, int64(id)); err != nil {
+		log.Errorf("delete routing rule failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+type RoutingRuleEvaluateRequest struct {
+	service.RoutingInput
+	// UserID 指定时使用该用户的实际等级，优先级低于 UserLevel
+	UserID int64 `json:"user_id,omitempty"`
+	// Rules 待验证的规则，为空时使用当前已启用的规则
+	Rules []repo.RoutingRule `json:"rules,omitempty"`
+}
+
+// RoutingRuleTrace 规则的匹配详情
+type RoutingRuleTrace struct {
+	RuleID   int64  `json:"rule_id"`
+	RuleName string `json:"rule_name"`
+	Priority int64  `json:"priority"`
+	Matched  bool   `json:"matched"`
+}
+
+// Evaluate 模拟执行模型路由规则，不会产生实际请求，用于在规则生效前验证规则是否符合预期
+func (ctl *RoutingRuleController) Evaluate(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req RoutingRuleEvaluateRequest
+	if err := webCtx.Unmarshal(&req); err != nil || req.Model == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	rules := req.Rules
+	if len(rules) == 0 {
+		var err error
+		if rules, err = ctl.routingRuleRepo.Rules(ctx, true); err != nil {
+			log.Errorf("query routing rules failed: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	} else {
+		for i := range rules {
+			if err := validateRoutingRule(&rules[i]); err != nil {
+				return webCtx.JSONError(common.Text(webCtx, ctl.trans, err.Error()), http.StatusBadRequest)
+			}
+		}
+
+		sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+	}
+
+	in := req.RoutingInput
+	in.Region = strings.ToUpper(in.Region)
+	if in.UserLevel == "" && req.UserID > 0 {
+		in.UserLevel = ctl.routingRuleSrv.UserLevel(ctx, req.UserID)
+	}
+
+	return webCtx.JSON(web.M{
+		"input":    in,
+		"decision": service.EvaluateRoutingRules(rules, in),
+		"trace": array.Map(rules, func(rule repo.RoutingRule, _ int) RoutingRuleTrace {
+			return RoutingRuleTrace{
+				RuleID:   rule.ID,
+				RuleName: rule.Name,
+				Priority: rule.Priority,
+				Matched:  rule.Status == repo.RoutingRuleStatusEnabled && service.MatchRoutingRule(rule, in),
+			}
+		}),
+	})
+}
+
+func (ctl *RoutingRuleController) reload(ctx context.Context) {
+	if err := ctl.routingRuleSrv.Reload(ctx); err != nil {
+		log.Errorf("reload routing rules failed: %v", err)
+	}
+}
+
+func validateRoutingRule(rule *repo.RoutingRule) error {
+	rule.Name, rule.TargetModel = strings.TrimSpace(rule.Name), strings.TrimSpace(rule.TargetModel)
+	if rule.Name == "" || len([]rune(rule.Name)) > 100 {
+		return errors.New("规则名称不能为空且不能超过 100 个字符")
+	}
+
+	if rule.TargetModel == "" {
+		return errors.New("目标模型不能为空")
+	}
+
+	if rule.Status == 0 {
+		rule.Status = repo.RoutingRuleStatusEnabled
+	}
+
+	if rule.Status != repo.RoutingRuleStatusEnabled && rule.Status != repo.RoutingRuleStatusDisabled {
+		return errors.New("无效的规则状态")
+	}
+
+	cond := &rule.Conditions
+	if cond.MinPromptTokens < 0 || cond.MaxPromptTokens < 0 || (cond.MaxPromptTokens > 0 && cond.MaxPromptTokens <= cond.MinPromptTokens) {
+		return errors.New("无效的 Token 数量范围")
+	}
+
+	for _, level := range cond.UserLevels {
+		if level != repo.RoutingUserLevelFree && level != repo.RoutingUserLevelPaid {
+			return errors.New("无效的用户等级")
+		}
+	}
+
+	cond.Regions = array.Map(cond.Regions, func(region string, _ int) string { return strings.ToUpper(strings.TrimSpace(region)) })
+	for _, region := range cond.Regions {
+		if !geoRegionPattern.MatchString(region) || region == repo.GeoRegionDefault {
+			return errors.New("无效的地区代码")
+		}
+	}
+
+	return nil
+}
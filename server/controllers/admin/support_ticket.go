@@ -0,0 +1,249 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// SupportTicketController 客服工单处理
+type SupportTicketController struct {
+	trans      youdao.Translater       `autowire:"@"`
+	ticketRepo *repo.SupportTicketRepo `autowire:"@"`
+	roomRepo   *repo.RoomRepo          `autowire:"@"`
+	msgRepo    *repo.MessageRepo       `autowire:"@"`
+	queueRepo  *repo.QueueRepo         `autowire:"@"`
+	bugRepo    *repo.BugReportRepo     `autowire:"@"`
+	userRepo   *repo.UserRepo          `autowire:"@"`
+	queue      *queue.Queue            `autowire:"@"`
+}
+
+func NewSupportTicketController(resolver infra.Resolver) web.Controller {
+	ctl := SupportTicketController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *SupportTicketController) Register(router web.Router) {
+	router.Group("/support-tickets", func(router web.Router) {
+		router.Get("/", ctl.Tickets)
+		router.Get("/{id}", ctl.Ticket)
+		router.Post("/{id}/messages", ctl.Reply)
+		router.Put("/{id}/status", ctl.UpdateStatus)
+	})
+}
+
+// Tickets 工单列表，可以按照状态和用户筛选
+func (ctl *SupportTicketController) Tickets(ctx context.Context, webCtx web.Context) web.Response {
+	page := webCtx.Int64Input("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	perPage := webCtx.Int64Input("per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	tickets, meta, err := ctl.ticketRepo.AdminTickets(ctx, webCtx.Int64Input("status", 0), webCtx.Int64Input("user_id", 0), page, perPage)
+	if err != nil {
+		log.Errorf("query support tickets failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":      tickets,
+		"page":      meta.Page,
+		"per_page":  meta.PerPage,
+		"total":     meta.Total,
+		"last_page": meta.LastPage,
+	})
+}
+
+func (ctl *SupportTicketController) ticket(ctx context.Context, webCtx web.Context) (*repo.SupportTicket, web.Response) {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	ticket, err := ctl.ticketRepo.Ticket(ctx, 0, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"ticket_id": id}).Errorf("query support ticket failed: %v", err)
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return ticket, nil
+}
+
+// Ticket 工单详情，包含关联的对话、消息或者任务信息，查看后用户消息标记为已读
+func (ctl *SupportTicketController) Ticket(ctx context.Context, webCtx web.Context) web.Response {
+	ticket, errResp := ctl.ticket(ctx, webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	messages, err := ctl.ticketRepo.Messages(ctx, ticket.ID)
+	if err != nil {
+		log.F(log.M{"ticket_id": ticket.ID}).Errorf("query support ticket messages failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if ticket.AdminUnread {
+		if err := ctl.ticketRepo.MarkRead(ctx, ticket.ID, true); err != nil {
+			log.F(log.M{"ticket_id": ticket.ID}).Errorf("mark support ticket read failed: %v", err)
+		}
+	}
+
+	return webCtx.JSON(web.M{
+		"ticket":     ticket,
+		"messages":   messages,
+		"attachment": ctl.attachment(ctx, ticket),
+	})
+}
+
+// attachment 查询工单关联的对象，查询失败时不影响工单详情的展示
+func (ctl *SupportTicketController) attachment(ctx context.Context, ticket *repo.SupportTicket) web.M {
+	switch ticket.AttachmentType {
+	case repo.SupportTicketAttachmentRoom:
+		id, _ := strconv.ParseInt(ticket.AttachmentID, 10, 64)
+		room, err := ctl.roomRepo.Room(ctx, ticket.UserID, id)
+		if err != nil {
+			log.F(log.M{"ticket_id": ticket.ID, "room_id": id}).Warningf("query support ticket room failed: %v", err)
+			return nil
+		}
+
+		return web.M{"room": room}
+	case repo.SupportTicketAttachmentMessage:
+		id, _ := strconv.ParseInt(ticket.AttachmentID, 10, 64)
+		question, answer, err := ctl.msgRepo.QuestionAnswer(ctx, ticket.UserID, id)
+		if err != nil {
+			log.F(log.M{"ticket_id": ticket.ID, "message_id": id}).Warningf("query support ticket message failed: %v", err)
+			return nil
+		}
+
+		return web.M{"question": question, "answer": answer}
+	case repo.SupportTicketAttachmentTask:
+		task, err := ctl.queueRepo.Task(ctx, ticket.AttachmentID)
+		if err != nil {
+			log.F(log.M{"ticket_id": ticket.ID, "task_id": ticket.AttachmentID}).Warningf("query support ticket task failed: %v", err)
+			return nil
+		}
+
+		logs, err := ctl.queueRepo.Logs(ctx, task.TaskId)
+		if err != nil {
+			log.F(log.M{"ticket_id": ticket.ID, "task_id": task.TaskId}).Warningf("query support ticket task logs failed: %v", err)
+		}
+
+		return web.M{
+			"task": web.M{
+				"task_id":    task.TaskId,
+				"task_type":  task.TaskType,
+				"status":     task.Status,
+				"created_at": task.CreatedAt,
+				"updated_at": task.UpdatedAt,
+			},
+			"logs": logs,
+		}
+	case repo.SupportTicketAttachmentBugReport:
+		id, _ := strconv.ParseInt(ticket.AttachmentID, 10, 64)
+		report, err := ctl.bugRepo.Report(ctx, id)
+		if err != nil {
+			log.F(log.M{"ticket_id": ticket.ID, "report_id": id}).Warningf("query support ticket bug report failed: %v", err)
+			return nil
+		}
+
+		return web.M{"bug_report": report}
+	}
+
+	return nil
+}
+
+// Reply 客服回复工单，回复后通过邮件通知用户，客户端通过未读标记展示提醒
+func (ctl *SupportTicketController) Reply(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	ticket, errResp := ctl.ticket(ctx, webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	content := strings.TrimSpace(webCtx.Input("content"))
+	if content == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	images := array.Filter(
+		array.Map(strings.Split(webCtx.Input("images"), ","), func(item string, _ int) string { return strings.TrimSpace(item) }),
+		func(item string, _ int) bool { return item != "" },
+	)
+
+	if err := ctl.ticketRepo.AddMessage(ctx, ticket.ID, user.ID, true, content, images); err != nil {
+		log.F(log.M{"ticket_id": ticket.ID}).Errorf("reply support ticket failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.notifyUser(ctx, ticket)
+
+	return webCtx.JSON(web.M{})
+}
+
+// notifyUser 通过邮件通知用户工单有新的回复，用户没有绑定邮箱时只通过未读标记提醒
+func (ctl *SupportTicketController) notifyUser(ctx context.Context, ticket *repo.SupportTicket) {
+	u, err := ctl.userRepo.GetUserByID(ctx, ticket.UserID)
+	if err != nil {
+		log.F(log.M{"ticket_id": ticket.ID, "user_id": ticket.UserID}).Errorf("query support ticket user failed: %v", err)
+		return
+	}
+
+	if u.Email == "" {
+		return
+	}
+
+	payload := &queue.MailPayload{
+		To:        []string{u.Email},
+		Subject:   fmt.Sprintf("您的工单「%s」有新的回复", ticket.Subject),
+		Body:      fmt.Sprintf("您好，您提交的工单「%s」已收到客服回复，请打开应用查看详情。", ticket.Subject),
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := ctl.queue.EnqueueContext(ctx, payload, queue.NewMailTask, asynq.Queue("mail")); err != nil {
+		log.F(log.M{"ticket_id": ticket.ID, "user_id": ticket.UserID}).Errorf("enqueue support ticket mail failed: %v", err)
+	}
+}
+
+// UpdateStatus 修改工单状态
+func (ctl *SupportTicketController) UpdateStatus(ctx context.Context, webCtx web.Context) web.Response {
+	ticket, errResp := ctl.ticket(ctx, webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	status := webCtx.Int64Input("status", 0)
+	if !array.In(status, []int64{repo.SupportTicketStatusOpen, repo.SupportTicketStatusReplied, repo.SupportTicketStatusClosed}) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.ticketRepo.UpdateStatus(ctx, ticket.ID, status); err != nil {
+		log.F(log.M{"ticket_id": ticket.ID, "status": status}).Errorf("update support ticket status failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
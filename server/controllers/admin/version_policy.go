@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+var (
+	versionPlatformPattern = regexp.MustCompile(`^([a-z]{2,16}|\*)$`)
+	versionPattern         = regexp.MustCompile(`^\d+(\.\d+){0,3}$`)
+)
+
+// VersionPolicyController 客户端版本策略管理
+type VersionPolicyController struct {
+	trans             youdao.Translater             `autowire:"@"`
+	versionPolicyRepo *repo.VersionPolicyRepo       `autowire:"@"`
+	versionPolicySrv  *service.VersionPolicyService `autowire:"@"`
+}
+
+func NewVersionPolicyController(resolver infra.Resolver) web.Controller {
+	ctl := VersionPolicyController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *VersionPolicyController) Register(router web.Router) {
+	router.Group("/version-policies", func(router web.Router) {
+		router.Get("/", ctl.Policies)
+		router.Put("/{platform}", ctl.UpdatePolicy)
+		router.Delete("/{platform}", ctl.DeletePolicy)
+	})
+}
+
+// Policies 客户端版本策略列表
+func (ctl *VersionPolicyController) Policies(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	policies, err := ctl.versionPolicyRepo.Policies(ctx)
+	if err != nil {
+		log.Errorf("query client version policies failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": policies})
+}
+
+// UpdatePolicy 新增或更新客户端版本策略，platform 为客户端平台，* 表示默认策略
+func (ctl *VersionPolicyController) UpdatePolicy(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	platform := strings.ToLower(webCtx.PathVar("platform"))
+	if !versionPlatformPattern.MatchString(platform) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	var policy repo.VersionPolicy
+	if err := webCtx.Unmarshal(&policy); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	versions := append([]string{policy.MinVersion, policy.RecommendedVersion}, policy.BlockedVersions...)
+	if array.In(false, array.Map(versions, func(v string, _ int) bool { return v == "" || versionPattern.MatchString(v) })) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "无效的版本号"), http.StatusBadRequest)
+	}
+
+	policy.Platform = platform
+	if err := ctl.versionPolicyRepo.UpdatePolicy(ctx, policy); err != nil {
+		log.Errorf("update client version policy failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+// DeletePolicy 删除客户端版本策略
+func (ctl *VersionPolicyController) DeletePolicy(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if err := ctl.versionPolicyRepo.DeletePolicy(ctx, strings.ToLower(webCtx.PathVar("platform"))); err != nil {
+		log.Errorf("delete client version policy failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.reload(ctx)
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *VersionPolicyController) reload(ctx context.Context) {
+	if err := ctl.versionPolicySrv.Reload(ctx); err != nil {
+		log.Errorf("reload client version policies failed: %v", err)
+	}
+}
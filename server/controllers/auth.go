@@ -364,7 +364,7 @@ func (ctl *AuthController) bindPhone(ctx context.Context, webCtx web.Context, cu
 			CreatedAt:  time.Now(),
 		}
 
-		if _, err := ctl.queue.Enqueue(&payload, queue.NewBindPhoneTask, asynq.Queue("user")); err != nil {
+		if _, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewBindPhoneTask, asynq.Queue("user")); err != nil {
 			log.WithFields(log.Fields{
 				"user_id":  user.Id,
 				"username": username,
@@ -535,7 +535,7 @@ func (ctl *AuthController) sendEmailCode(ctx context.Context, webCtx web.Context
 		CreatedAt: time.Now(),
 	}
 
-	taskId, err := ctl.queue.Enqueue(mailPayload, queue.NewMailTask, asynq.Queue("mail"))
+	taskId, err := ctl.queue.EnqueueContext(ctx, mailPayload, queue.NewMailTask, asynq.Queue("mail"))
 	if err != nil {
 		log.WithFields(log.Fields{
 			"username": username,
@@ -623,7 +623,7 @@ func (ctl *AuthController) sendSMSCode(ctx context.Context, webCtx web.Context,
 		CreatedAt: time.Now(),
 	}
 
-	taskId, err := ctl.queue.Enqueue(smsPayload, queue.NewSMSVerifyCodeTask, asynq.Queue("mail"))
+	taskId, err := ctl.queue.EnqueueContext(ctx, smsPayload, queue.NewSMSVerifyCodeTask, asynq.Queue("mail"))
 	if err != nil {
 		log.WithFields(log.Fields{
 			"username": username,
@@ -766,7 +766,7 @@ func (ctl *AuthController) signUpSendEmailCode(ctx context.Context, webCtx web.C
 		CreatedAt: time.Now(),
 	}
 
-	taskId, err := ctl.queue.Enqueue(mailPayload, queue.NewMailTask, asynq.Queue("mail"))
+	taskId, err := ctl.queue.EnqueueContext(ctx, mailPayload, queue.NewMailTask, asynq.Queue("mail"))
 	if err != nil {
 		log.WithFields(log.Fields{
 			"username": username,
@@ -915,7 +915,7 @@ func (ctl *AuthController) createAccount(ctx context.Context, webCtx web.Context
 			payload.Phone = username
 		}
 
-		if _, err := ctl.queue.Enqueue(&payload, queue.NewSignupTask, asynq.Queue("user")); err != nil {
+		if _, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewSignupTask, asynq.Queue("user")); err != nil {
 			log.WithFields(log.Fields{
 				"username": username,
 				"event_id": eventID,
@@ -1052,7 +1052,7 @@ func appleSignIn(
 			CreatedAt:  time.Now(),
 		}
 
-		if _, err := qu.Enqueue(&payload, queue.NewSignupTask, asynq.Queue("user")); err != nil {
+		if _, err := qu.EnqueueContext(ctx, &payload, queue.NewSignupTask, asynq.Queue("user")); err != nil {
 			log.WithFields(log.Fields{
 				"username": email,
 				"event_id": eventID,
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// maxBetaFeedbackLength 内测反馈的最大字数
+const maxBetaFeedbackLength = 2000
+
+// BetaController 内测计划：用户自行加入开放报名的内测分组，并提交内测反馈
+type BetaController struct {
+	translater youdao.Translater     `autowire:"@"`
+	limiter    *rate.RateLimiter     `autowire:"@"`
+	betaRepo   *repo2.BetaRepo       `autowire:"@"`
+	betaSrv    *service2.BetaService `autowire:"@"`
+}
+
+// NewBetaController 创建内测计划控制器
+func NewBetaController(resolver infra.Resolver) web.Controller {
+	ctl := &BetaController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *BetaController) Register(router web.Router) {
+	router.Group("/beta", func(router web.Router) {
+		router.Get("/", ctl.Cohorts)
+		router.Post("/cohorts/{id}/join", ctl.Join)
+		router.Post("/cohorts/{id}/leave", ctl.Leave)
+		router.Post("/feedback", ctl.Feedback)
+	})
+}
+
+// betaCohort 用户可见的内测分组
+type betaCohort struct {
+	repo2.BetaCohort
+	Joined bool `json:"joined"`
+}
+
+// Cohorts 开放报名的以及用户已经加入的内测分组，以及用户当前获得的内测功能与模型
+func (ctl *BetaController) Cohorts(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	joined, err := ctl.betaRepo.CohortIDs(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户的内测分组失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	cohorts := ctl.betaSrv.Cohorts(ctx)
+	res := make([]betaCohort, 0, len(cohorts))
+	for _, cohort := range cohorts {
+		isMember := array.In(cohort.ID, joined)
+		if cohort.OpenEnroll || isMember {
+			res = append(res, betaCohort{BetaCohort: cohort, Joined: isMember})
+		}
+	}
+
+	return webCtx.JSON(web.M{"data": res, "grant": service2.BetaGrantOf(cohorts, joined)})
+}
+
+// cohort 查询请求中指定的启用的内测分组
+func (ctl *BetaController) cohort(ctx context.Context, webCtx web.Context) (*repo2.BetaCohort, web.Response) {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if cohort := findBetaCohort(ctl.betaSrv.Cohorts(ctx), id); cohort != nil {
+		return cohort, nil
+	}
+
+	return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+}
+
+func findBetaCohort(cohorts []repo2.BetaCohort, id int64) *repo2.BetaCohort {
+	for _, cohort := range cohorts {
+		if cohort.ID == id {
+			return &cohort
+		}
+	}
+
+	return nil
+}
+
+// Join 加入开放报名的内测分组
+func (ctl *BetaController) Join(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cohort, resp := ctl.cohort(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	if !cohort.OpenEnroll {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "该内测暂未开放报名"), http.StatusForbidden)
+	}
+
+	if err := ctl.betaRepo.Join(ctx, cohort.ID, user.ID, repo2.BetaMemberSourceSelf, cohort.Capacity); err != nil {
+		switch {
+		case errors.Is(err, repo2.ErrBetaCohortFull):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "内测名额已满"), http.StatusConflict)
+		case errors.Is(err, repo2.ErrNotFound):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "cohort_id": cohort.ID}).Errorf("加入内测分组失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Leave 退出内测分组，退出后无法继续使用该分组中的内测功能与模型
+func (ctl *BetaController) Leave(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cohort, resp := ctl.cohort(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	if err := ctl.betaRepo.Leave(ctx, cohort.ID, user.ID); err != nil {
+		log.F(log.M{"user_id": user.ID, "cohort_id": cohort.ID}).Errorf("退出内测分组失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Feedback 提交内测反馈，只有分组成员可以提交
+// 请求参数：
+// - cohort_id: 内测分组 ID
+// - feature: 反馈的内测功能或者模型，可选，为空时表示对分组的整体反馈
+// - rating: 评分 1-5，可选
+// - content: 反馈内容，未评分时必填
+func (ctl *BetaController) Feedback(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo) web.Response {
+	feedback := repo2.BetaFeedback{
+		CohortID:      webCtx.Int64Input("cohort_id", 0),
+		UserID:        user.ID,
+		Feature:       strings.TrimSpace(webCtx.Input("feature")),
+		Rating:        webCtx.Int64Input("rating", 0),
+		Content:       strings.TrimSpace(webCtx.Input("content")),
+		Platform:      client.Platform,
+		ClientVersion: client.Version,
+	}
+
+	if feedback.Rating < 0 || feedback.Rating > 5 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if (feedback.Content == "" && feedback.Rating == 0) || len([]rune(feedback.Content)) > maxBetaFeedbackLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, fmt.Sprintf("请输入反馈内容，最多 %d 个字", maxBetaFeedbackLength)), http.StatusBadRequest)
+	}
+
+	joined, err := ctl.betaRepo.CohortIDs(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户的内测分组失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	cohort := findBetaCohort(ctl.betaSrv.Cohorts(ctx), feedback.CohortID)
+	if cohort == nil || !array.In(cohort.ID, joined) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "只有内测成员可以提交内测反馈"), http.StatusForbidden)
+	}
+
+	if feedback.Feature != "" && !array.In(feedback.Feature, cohort.Features) && !array.In(feedback.Feature, cohort.Models) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.limiter.Allow(ctx, fmt.Sprintf("beta:feedback:%d:limit", user.ID), rate.MaxRequestsInPeriod(10, time.Hour)); err != nil {
+		if errors.Is(err, rate.ErrRateLimitExceeded) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "反馈过于频繁，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("检查内测反馈频率失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	id, err := ctl.betaRepo.CreateFeedback(ctx, feedback)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "cohort_id": cohort.ID}).Errorf("保存内测反馈失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// bootstrapCacheTTL 启动配置中与用户无关部分的缓存时间
+const bootstrapCacheTTL = time.Minute
+
+// ModelPrice 模型价格摘要
+type ModelPrice struct {
+	ModelID string `json:"model_id"`
+	Name    string `json:"name"`
+	// CoinsPer1K 每 1000 Token 消耗的智慧果数量
+	CoinsPer1K int64 `json:"coins_per_1k"`
+}
+
+// PricingSummary 生成可用聊天模型的价格摘要
+func PricingSummary(models []chat.Model) []ModelPrice {
+	models = array.Filter(models, func(m chat.Model, _ int) bool { return m.IsChat && !m.Disabled })
+	return array.Map(models, func(m chat.Model, _ int) ModelPrice {
+		return ModelPrice{
+			ModelID:    m.ID,
+			Name:       m.Name,
+			CoinsPer1K: coins.GetOpenAITextCoins(m.RealID(), 1000),
+		}
+	})
+}
+
+type bootstrapCatalog struct {
+	models   []chat.Model
+	prices   []ModelPrice
+	cachedAt time.Time
+}
+
+// BootstrapController 客户端启动配置，将启动时需要的多个请求合并为一个
+type BootstrapController struct {
+	conf             *config.Config                `autowire:"@"`
+	userSvc          *service.UserService          `autowire:"@"`
+	versionPolicySrv *service.VersionPolicyService `autowire:"@"`
+	betaSrv          *service.BetaService          `autowire:"@"`
+	info             *InfoController
+
+	lock     sync.RWMutex
+	catalogs map[string]bootstrapCatalog
+}
+
+// NewBootstrapController 创建客户端启动配置控制器
+func NewBootstrapController(resolver infra.Resolver) web.Controller {
+	ctl := &BootstrapController{
+		info:     NewInfoController(resolver).(*InfoController),
+		catalogs: make(map[string]bootstrapCatalog),
+	}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *BootstrapController) Register(router web.Router) {
+	router.Get("/bootstrap", ctl.Bootstrap)
+}
+
+// Bootstrap 客户端启动时需要的全部配置：模型列表、功能开关、价格摘要、公告、升级要求，登录用户还包括用户信息与智慧果余额
+func (ctl *BootstrapController) Bootstrap(ctx context.Context, webCtx web.Context, user *auth.UserOptional, client *auth.ClientInfo) web.Response {
+	catalog := ctl.catalog(user, client)
+
+	// 模型列表按照客户端版本缓存，内测模型只对内测分组成员可见，因此在缓存之后按照用户过滤
+	var userID int64
+	if user.User != nil {
+		userID = user.User.ID
+	}
+
+	models, prices := ctl.betaSrv.VisibleModels(ctx, userID, catalog.models), catalog.prices
+	if len(models) != len(catalog.models) {
+		prices = PricingSummary(models)
+	}
+
+	res := web.M{
+		"server_version": CurrentVersion,
+		"models":         models,
+		"capabilities":   ctl.info.capabilities(ctx, user, client),
+		"pricing": web.M{
+			"models":      prices,
+			"free_models": ctl.info.freeChatCounts(ctx, user, client),
+		},
+		"notices": ctl.info.noticeSrv.Active(ctx),
+		// 客户端升级要求，必须升级时客户端应当阻止用户继续使用
+		"upgrade": ctl.versionPolicySrv.Check(ctx, client.Platform, client.Version),
+	}
+
+	if user.User == nil {
+		return webCtx.JSON(res)
+	}
+
+	u, err := ctl.userSvc.GetUserByID(ctx, user.User.ID, false)
+	if err != nil || u.Status == repo2.UserStatusDeleted {
+		// 用户信息加载失败时不影响其它配置的返回，客户端会降级为单独请求用户信息
+		log.F(log.M{"user_id": user.User.ID}).Errorf("bootstrap: get user failed: %v", err)
+		return webCtx.JSON(res)
+	}
+
+	profile := auth.CreateAuthUserFromModel(u)
+	if profile.Phone != "" {
+		profile.Phone = misc.MaskPhoneNumber(profile.Phone)
+	}
+	res["user"] = profile
+
+	quota, err := ctl.userSvc.UserQuota(ctx, user.User.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.User.ID}).Errorf("bootstrap: get user quota failed: %v", err)
+	} else {
+		res["quota"] = quota
+	}
+
+	return webCtx.JSON(res)
+}
+
+// catalog 模型列表与价格只与客户端版本、是否启用国产化模式相关，按照这两者缓存
+func (ctl *BootstrapController) catalog(user *auth.UserOptional, client *auth.ClientInfo) bootstrapCatalog {
+	cnMode := client.IsCNLocalMode(ctl.conf) && (user.User == nil || !user.User.ExtraPermissionUser())
+	key := fmt.Sprintf("%s:%v", client.Version, cnMode)
+
+	ctl.lock.RLock()
+	cached, ok := ctl.catalogs[key]
+	ctl.lock.RUnlock()
+
+	if ok && time.Since(cached.cachedAt) < bootstrapCacheTTL {
+		return cached
+	}
+
+	models := clientModels(ctl.conf, client, user)
+	cached = bootstrapCatalog{models: models, prices: PricingSummary(models), cachedAt: time.Now()}
+
+	ctl.lock.Lock()
+	// 客户端版本号由请求传入，写入时清理过期的缓存，避免缓存无限增长
+	for k, v := range ctl.catalogs {
+		if time.Since(v.cachedAt) >= bootstrapCacheTTL {
+			delete(ctl.catalogs, k)
+		}
+	}
+	ctl.catalogs[key] = cached
+	ctl.lock.Unlock()
+
+	return cached
+}
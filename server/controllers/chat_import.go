@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/aidea-server/pkg/chatimport"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// chatImportMaxFileSize 导入文件的最大尺寸
+	chatImportMaxFileSize = 100 * 1024 * 1024
+	// chatImportMaxConversations 单次最多导入的会话数量
+	chatImportMaxConversations = 5000
+)
+
+// ChatImportController 从其它平台导入聊天记录
+type ChatImportController struct {
+	translater     youdao.Translater     `autowire:"@"`
+	queue          *queue.Queue          `autowire:"@"`
+	chatImportRepo *repo2.ChatImportRepo `autowire:"@"`
+}
+
+// NewChatImportController 创建聊天记录导入控制器
+func NewChatImportController(resolver infra.Resolver) web.Controller {
+	ctl := &ChatImportController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *ChatImportController) Register(router web.Router) {
+	router.Group("/chat-imports", func(router web.Router) {
+		router.Post("/", ctl.Create)
+		router.Get("/", ctl.Imports)
+		router.Get("/{id}", ctl.Import)
+	})
+}
+
+// ChatImport 导入任务及进度
+type ChatImport struct {
+	ID       int64  `json:"id"`
+	Source   string `json:"source"`
+	Status   string `json:"status"`
+	Total    int64  `json:"total"`
+	Imported int64  `json:"imported"`
+	Skipped  int64  `json:"skipped"`
+	Failed   int64  `json:"failed"`
+	// Progress 导入进度，取值范围 0-100
+	Progress    int64 `json:"progress"`
+	CreatedAt   int64 `json:"created_at"`
+	CompletedAt int64 `json:"completed_at,omitempty"`
+}
+
+var chatImportStatusText = map[int64]string{
+	repo2.ChatImportStatusPending: "pending",
+	repo2.ChatImportStatusRunning: "in_progress",
+	repo2.ChatImportStatusSucceed: "completed",
+	repo2.ChatImportStatusFailed:  "failed",
+}
+
+func buildChatImport(imp model.ChatImport) ChatImport {
+	ret := ChatImport{
+		ID:        imp.Id,
+		Source:    imp.Source,
+		Status:    chatImportStatusText[imp.Status],
+		Total:     imp.Total,
+		Imported:  imp.Imported,
+		Skipped:   imp.Skipped,
+		Failed:    imp.Failed,
+		Progress:  100,
+		CreatedAt: imp.CreatedAt.Unix(),
+	}
+
+	if imp.Total > 0 {
+		ret.Progress = (imp.Imported + imp.Skipped + imp.Failed) * 100 / imp.Total
+	}
+
+	if !imp.CompletedAt.IsZero() {
+		ret.CompletedAt = imp.CompletedAt.Unix()
+	}
+
+	return ret
+}
+
+// Create 上传 ChatGPT 导出的聊天记录（zip 压缩包或者其中的 conversations.json），异步导入为聊天室
+func (ctl *ChatImportController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	uploadedFile, err := webCtx.File("file")
+	if err != nil {
+		log.Errorf("upload file failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	defer func() { misc.NoError(uploadedFile.Delete()) }()
+
+	if uploadedFile.Size() > chatImportMaxFileSize {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrFileTooLarge), http.StatusBadRequest)
+	}
+
+	conversations, err := chatimport.ParseChatGPTFile(uploadedFile.GetTempFilename())
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Warningf("parse chatgpt export failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "无法识别的文件，请上传 ChatGPT 导出的压缩包或 conversations.json"), http.StatusBadRequest)
+	}
+
+	if len(conversations) == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件中没有可以导入的会话"), http.StatusBadRequest)
+	}
+
+	if len(conversations) > chatImportMaxConversations {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "单次最多导入 5000 个会话"), http.StatusBadRequest)
+	}
+
+	importID, err := ctl.chatImportRepo.CreateImport(ctx, user.ID, chatimport.SourceChatGPT, conversations)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("create chat import failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	payload := queue.ChatImportPayload{
+		ImportID:  importID,
+		UserID:    user.ID,
+		CreatedAt: time.Now(),
+	}
+
+	taskID, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewChatImportTask, asynq.Queue(queue.BatchQueueName), asynq.Timeout(6*time.Hour))
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "import_id": importID}).Errorf("enqueue chat import task failed: %s", err)
+		if err := ctl.chatImportRepo.UpdateImportStatus(ctx, importID, repo2.ChatImportStatusFailed); err != nil {
+			log.F(log.M{"import_id": importID}).Errorf("update chat import status failed: %s", err)
+		}
+
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.chatImportRepo.UpdateImportTaskID(ctx, importID, taskID); err != nil {
+		log.F(log.M{"import_id": importID, "task_id": taskID}).Errorf("update chat import task id failed: %s", err)
+	}
+
+	imp, err := ctl.chatImportRepo.GetImport(ctx, user.ID, importID)
+	if err != nil {
+		log.F(log.M{"import_id": importID}).Errorf("query chat import failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildChatImport(*imp))
+}
+
+// Imports 最近的导入任务
+func (ctl *ChatImportController) Imports(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	imports, err := ctl.chatImportRepo.GetImports(ctx, user.ID, 20)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query chat imports failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data": array.Map(imports, func(item model.ChatImport, _ int) ChatImport { return buildChatImport(item) }),
+	})
+}
+
+// Import 导入任务的进度
+func (ctl *ChatImportController) Import(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	imp, err := ctl.chatImportRepo.GetImport(ctx, user.ID, int64(id))
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("query chat import failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildChatImport(*imp))
+}
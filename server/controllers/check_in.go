@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// CheckInController 每日签到
+type CheckInController struct {
+	conf        *config.Config
+	translater  youdao.Translater  `autowire:"@"`
+	checkInRepo *repo2.CheckInRepo `autowire:"@"`
+}
+
+// NewCheckInController 创建每日签到控制器
+func NewCheckInController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &CheckInController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *CheckInController) Register(router web.Router) {
+	router.Group("/check-in", func(router web.Router) {
+		router.Get("/", ctl.Status)
+		router.Post("/", ctl.CheckIn)
+	})
+}
+
+// Status 查询当前用户的签到状态，包括今天是否已签到、连续签到天数以及下一次签到可以获得的奖励
+func (ctl *CheckInController) Status(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	rewards, err := ctl.checkInRepo.Rewards(ctx)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询签到奖励失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	last, err := ctl.checkInRepo.LastCheckIn(ctx, user.ID)
+	if err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户签到记录失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	var streak int64
+	checkedIn := false
+	if last != nil {
+		switch last.CheckInDate.Format("2006-01-02") {
+		case today:
+			streak, checkedIn = last.Streak, true
+		case yesterday:
+			streak = last.Streak
+		}
+	}
+
+	return webCtx.JSON(web.M{
+		"checked_in":  checkedIn,
+		"streak":      streak,
+		"next_reward": repo2.CheckInRewardForStreak(rewards, streak+1),
+		"rewards":     rewards,
+	})
+}
+
+// CheckIn 签到，每个用户每天只能签到一次
+func (ctl *CheckInController) CheckIn(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	res, err := ctl.checkInRepo.CheckIn(ctx, user.ID, time.Now())
+	if err != nil {
+		if errors.Is(err, repo2.ErrAlreadyCheckedIn) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "今天已经签到过了"), http.StatusConflict)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("用户签到失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"streak": res.Streak,
+		"coins":  res.Coins,
+	})
+}
@@ -0,0 +1,340 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	openaiHelper "github.com/mylxsw/aidea-server/pkg/ai/openai"
+	"github.com/mylxsw/aidea-server/pkg/ai/zhipu"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/sandbox"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+	"github.com/sashabaranov/go-openai"
+)
+
+// codeInterpreterMaxRounds 单次请求中模型最多调用代码执行工具的轮次
+const codeInterpreterMaxRounds = 5
+
+// codeInterpreterTool 提供给模型的代码执行工具定义
+var codeInterpreterTool = openai.Tool{
+	Type: openai.ToolTypeFunction,
+	Function: openai.FunctionDefinition{
+		Name:        "run_python",
+		Description: "Execute Python 3 code in an isolated sandbox without network access. Use it for math, data processing and other computation tasks. Print the values you need, only stdout and stderr are returned.",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"code": {"type": "string", "description": "The Python 3 source code to execute"}
+			},
+			"required": ["code"]
+		}`),
+	},
+}
+
+// CodeInterpreterController 代码解释器，模型可以通过函数调用在沙箱中执行代码
+type CodeInterpreterController struct {
+	conf        *config.Config
+	client      openaiHelper.Client   `autowire:"@"`
+	zhipu       *zhipu.Zhipu          `autowire:"@"`
+	sandbox     *sandbox.Sandbox      `autowire:"@"`
+	translater  youdao.Translater     `autowire:"@"`
+	messageRepo *repo2.MessageRepo    `autowire:"@"`
+	quotaRepo   *repo2.QuotaRepo      `autowire:"@"`
+	userSrv     *service2.UserService `autowire:"@"`
+	chatSrv     *service2.ChatService `autowire:"@"`
+}
+
+// NewCodeInterpreterController 创建代码解释器控制器
+func NewCodeInterpreterController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &CodeInterpreterController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *CodeInterpreterController) Register(router web.Router) {
+	router.Group("/code-interpreter", func(router web.Router) {
+		router.Post("/completions", ctl.Completions)
+		router.Get("/messages/{id}/executions", ctl.Executions)
+	})
+}
+
+type CodeInterpreterRequest struct {
+	Model    string         `json:"model"`
+	Messages chat2.Messages `json:"messages"`
+	RoomID   int64          `json:"room_id,omitempty"`
+}
+
+type CodeExecution struct {
+	Code       string `json:"code"`
+	Stdout     string `json:"stdout,omitempty"`
+	Stderr     string `json:"stderr,omitempty"`
+	ExitCode   int64  `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
+	TimedOut   bool   `json:"timed_out,omitempty"`
+	Coins      int64  `json:"coins"`
+}
+
+// Completions 支持代码执行的聊天补全（非流式）
+func (ctl *CodeInterpreterController) Completions(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if !ctl.sandbox.Enabled() {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "代码执行功能暂未开放"), http.StatusServiceUnavailable)
+	}
+
+	var req CodeInterpreterRequest
+	if err := webCtx.Unmarshal(&req); err != nil || len(req.Messages) == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 只有 OpenAI 和智谱 AI 的模型支持函数调用
+	supported := array.Filter(chat2.Models(ctl.conf, false), func(item chat2.Model, _ int) bool {
+		return (item.Category == "openai" || item.Category == "zhipu") && item.RealID() == req.Model
+	})
+	if len(supported) == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "当前模型不支持代码执行"), http.StatusBadRequest)
+	}
+
+	inputTokens, err := chat2.MessageTokenCount(req.Messages, req.Model)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 预估本次请求最多消耗的智慧果：上下文 Token + 所有轮次的代码执行都达到超时时间
+	estimated := coins.GetOpenAITextCoins(req.Model, int64(inputTokens)) + coins.GetSandboxCoins(ctl.conf.SandboxTimeout)*codeInterpreterMaxRounds
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < estimated {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, estimated); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("freeze user quota failed: %s", err)
+	} else {
+		defer func(ctx context.Context) {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, estimated); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("unfreeze user quota failed: %s", err)
+			}
+		}(ctx)
+	}
+
+	incognito := ctl.chatSrv.RoomIncognito(ctx, user.ID, req.RoomID)
+	questionID, err := ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
+		UserID:    user.ID,
+		Message:   req.Messages[len(req.Messages)-1].Content,
+		Role:      repo2.MessageRoleUser,
+		RoomID:    req.RoomID,
+		Model:     req.Model,
+		Incognito: incognito,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存用户聊天请求失败（问题部分）: %s", err)
+	}
+
+	messages := array.Map(req.Messages, func(item chat2.Message, _ int) openai.ChatCompletionMessage {
+		return openai.ChatCompletionMessage{Role: item.Role, Content: item.Content}
+	})
+
+	replyText, totalTokens, executions, err := ctl.completionWithTools(ctx, user, req.Model, messages)
+
+	var sandboxCoins int64
+	for _, exec := range executions {
+		sandboxCoins += exec.Coins
+	}
+
+	chatCoins := coins.GetOpenAITextCoins(req.Model, totalTokens)
+	if chatCoins > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, chatCoins, repo2.NewQuotaUsedMeta("chat", req.Model)); err != nil {
+			log.F(log.M{"user_id": user.ID, "quota": chatCoins}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	if sandboxCoins > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, sandboxCoins, repo2.NewQuotaUsedMeta("code-interpreter", req.Model)); err != nil {
+			log.F(log.M{"user_id": user.ID, "quota": sandboxCoins}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	var errorMessage string
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "model": req.Model}).Errorf("code interpreter completion failed: %s", err)
+		errorMessage = err.Error()
+	}
+
+	answerID, err2 := ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
+		UserID:        user.ID,
+		Message:       replyText,
+		Role:          repo2.MessageRoleAssistant,
+		QuotaConsumed: chatCoins + sandboxCoins,
+		TokenConsumed: totalTokens,
+		RoomID:        req.RoomID,
+		Model:         req.Model,
+		PID:           questionID,
+		Status:        int64(ternary.If(errorMessage != "", repo2.MessageStatusFailed, repo2.MessageStatusSucceed)),
+		Error:         errorMessage,
+		Incognito:     incognito,
+	})
+	if err2 != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("add message failed: %s", err2)
+	}
+
+	if answerID > 0 && len(executions) > 0 {
+		if err := ctl.messageRepo.AddCodeExecutions(ctx, array.Map(executions, func(item CodeExecution, _ int) repo2.CodeExecutionAddReq {
+			return repo2.CodeExecutionAddReq{
+				MessageID:  answerID,
+				UserID:     user.ID,
+				Language:   "python",
+				Code:       item.Code,
+				Stdout:     item.Stdout,
+				Stderr:     item.Stderr,
+				ExitCode:   item.ExitCode,
+				DurationMs: item.DurationMs,
+				Coins:      item.Coins,
+			}
+		})); err != nil {
+			log.F(log.M{"user_id": user.ID, "message_id": answerID}).Errorf("save code executions failed: %s", err)
+		}
+	}
+
+	if err != nil && replyText == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"question_id":    questionID,
+		"answer_id":      answerID,
+		"text":           replyText,
+		"executions":     executions,
+		"quota_consumed": chatCoins + sandboxCoins,
+		"token_consumed": totalTokens,
+	})
+}
+
+// createChatCompletion 根据模型选择支持工具调用的服务提供商
+func (ctl *CodeInterpreterController) createChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	if zhipu.SupportModel(req.Model) {
+		return ctl.zhipu.Chat(ctx, req)
+	}
+
+	return ctl.client.CreateChatCompletion(ctx, req)
+}
+
+// completionWithTools 调用模型，模型请求执行代码时在沙箱中执行，并将结果返回给模型，直到模型给出最终答复
+func (ctl *CodeInterpreterController) completionWithTools(ctx context.Context, user *auth.User, model string, messages []openai.ChatCompletionMessage) (string, int64, []CodeExecution, error) {
+	executions := make([]CodeExecution, 0)
+	var totalTokens int64
+
+	for round := 0; round <= codeInterpreterMaxRounds; round++ {
+		chatCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+		req := openai.ChatCompletionRequest{Model: model, Messages: messages}
+		// 达到最大轮次后不再提供工具，要求模型直接给出答复
+		if round < codeInterpreterMaxRounds {
+			req.Tools = []openai.Tool{codeInterpreterTool}
+		}
+
+		resp, err := ctl.createChatCompletion(chatCtx, req)
+		cancel()
+		if err != nil {
+			return "", totalTokens, executions, fmt.Errorf("chat completion failed: %w", err)
+		}
+
+		totalTokens += int64(resp.Usage.TotalTokens)
+		if len(resp.Choices) == 0 {
+			return "", totalTokens, executions, errors.New("empty response from model")
+		}
+
+		msg := resp.Choices[0].Message
+		if len(msg.ToolCalls) == 0 {
+			return msg.Content, totalTokens, executions, nil
+		}
+
+		messages = append(messages, msg)
+		for _, call := range msg.ToolCalls {
+			exec, output := ctl.runToolCall(ctx, user, call)
+			if exec != nil {
+				executions = append(executions, *exec)
+			}
+
+			messages = append(messages, openai.ChatCompletionMessage{
+				Role:       openai.ChatMessageRoleTool,
+				Content:    output,
+				ToolCallID: call.ID,
+			})
+		}
+	}
+
+	return "", totalTokens, executions, errors.New("too many tool calls")
+}
+
+// runToolCall 执行模型请求的代码，返回执行记录以及需要回传给模型的结果
+func (ctl *CodeInterpreterController) runToolCall(ctx context.Context, user *auth.User, call openai.ToolCall) (*CodeExecution, string) {
+	if call.Function.Name != codeInterpreterTool.Function.Name {
+		return nil, fmt.Sprintf("unknown function: %s", call.Function.Name)
+	}
+
+	var args struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil || args.Code == "" {
+		return nil, "invalid arguments: code is required"
+	}
+
+	res, err := ctl.sandbox.RunPython(ctx, args.Code)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("run code in sandbox failed: %s", err)
+		return nil, "sandbox is temporarily unavailable"
+	}
+
+	exec := CodeExecution{
+		Code:       args.Code,
+		Stdout:     res.Stdout,
+		Stderr:     res.Stderr,
+		ExitCode:   int64(res.ExitCode),
+		DurationMs: res.Duration.Milliseconds(),
+		TimedOut:   res.TimedOut,
+		Coins:      coins.GetSandboxCoins(res.Duration),
+	}
+
+	output, _ := json.Marshal(web.M{
+		"stdout":    res.Stdout,
+		"stderr":    res.Stderr,
+		"exit_code": res.ExitCode,
+		"timed_out": res.TimedOut,
+	})
+
+	return &exec, string(output)
+}
+
+// Executions 查询消息关联的代码执行记录
+func (ctl *CodeInterpreterController) Executions(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	messageID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	items, err := ctl.messageRepo.GetCodeExecutions(ctx, user.ID, int64(messageID))
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "message_id": messageID}).Errorf("query code executions failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items})
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// maxCoinTransferNoteLength 转赠留言的最大长度
+const maxCoinTransferNoteLength = 100
+
+// CoinTransferController 用户之间转赠智慧果
+type CoinTransferController struct {
+	conf         *config.Config         `autowire:"@"`
+	trans        youdao.Translater      `autowire:"@"`
+	transferRepo *repo.CoinTransferRepo `autowire:"@"`
+	userRepo     *repo.UserRepo         `autowire:"@"`
+	userSrv      *service.UserService   `autowire:"@"`
+	limiter      *rate.RateLimiter      `autowire:"@"`
+}
+
+// NewCoinTransferController 创建智慧果转赠控制器
+func NewCoinTransferController(resolver infra.Resolver) web.Controller {
+	ctl := &CoinTransferController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *CoinTransferController) Register(router web.Router) {
+	router.Group("/coin-transfers", func(router web.Router) {
+		router.Get("/", ctl.Transfers)
+		router.Post("/", ctl.Transfer)
+		router.Get("/limits", ctl.Limits)
+	})
+}
+
+func (ctl *CoinTransferController) limits() repo.CoinTransferLimits {
+	return repo.CoinTransferLimits{
+		Daily:           int64(ctl.conf.CoinTransferDailyLimit),
+		Monthly:         int64(ctl.conf.CoinTransferMonthlyLimit),
+		DailyRecipients: int64(ctl.conf.CoinTransferDailyRecipients),
+	}
+}
+
+// Limits 转赠限额以及当前用户已经使用的额度
+func (ctl *CoinTransferController) Limits(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if !ctl.conf.EnableCoinTransfer {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "智慧果转赠功能未开启"), http.StatusForbidden)
+	}
+
+	usage, err := ctl.transferRepo.Usage(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询智慧果转赠额度失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"limits":           ctl.limits(),
+		"usage":            usage,
+		"today_recipients": len(usage.TodayRecipients),
+	})
+}
+
+// Transfer 将智慧果转赠给其他用户，通过 to_user_id 或者 to_phone 指定接收用户
+func (ctl *CoinTransferController) Transfer(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo) web.Response {
+	if !ctl.conf.EnableCoinTransfer {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "智慧果转赠功能未开启"), http.StatusForbidden)
+	}
+
+	amount := webCtx.Int64Input("amount", 0)
+	if amount <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "转赠数量必须大于 0"), http.StatusBadRequest)
+	}
+
+	note := strings.TrimSpace(webCtx.Input("note"))
+	if len([]rune(note)) > maxCoinTransferNoteLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "留言不能超过 100 个字符"), http.StatusBadRequest)
+	}
+
+	// 防止批量注册的小号把赠送的智慧果集中到一个账号
+	if user.Phone == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "请先绑定手机号后再转赠智慧果"), http.StatusForbidden)
+	}
+
+	if ctl.conf.CoinTransferMinAccountAge > 0 && time.Since(user.CreatedAt) < ctl.conf.CoinTransferMinAccountAge {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "账号注册时间太短，暂时无法转赠智慧果"), http.StatusForbidden)
+	}
+
+	if err := ctl.limiter.Allow(ctx, fmt.Sprintf("coin-transfer:%d:limit", user.ID), rate.MaxRequestsInPeriod(10, time.Hour)); err != nil {
+		if err == rate.ErrRateLimitExceeded {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "操作频率过高，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	var err error
+	var toUserID int64
+	var toName, toPhone string
+	if phone := strings.TrimSpace(webCtx.Input("to_phone")); phone != "" {
+		receiver, e := ctl.userRepo.GetUserByPhone(ctx, phone)
+		if e == nil {
+			toUserID, toName, toPhone = receiver.Id, receiver.Realname, receiver.Phone
+		}
+		err = e
+	} else if id := webCtx.Int64Input("to_user_id", 0); id > 0 {
+		receiver, e := ctl.userRepo.GetUserByID(ctx, id)
+		if e == nil {
+			toUserID, toName, toPhone = receiver.Id, receiver.Realname, receiver.Phone
+		}
+		err = e
+	} else {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "请指定接收智慧果的用户"), http.StatusBadRequest)
+	}
+
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) || errors.Is(err, repo.ErrUserAccountDisabled) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "接收智慧果的用户不存在"), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("查询接收智慧果的用户失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if toUserID == user.ID {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "不能转赠给自己"), http.StatusBadRequest)
+	}
+
+	// 冻结中的智慧果（进行中的任务预扣）不能转出
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < amount {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	transfer, err := ctl.transferRepo.Transfer(ctx, repo.CoinTransferRequest{
+		FromUserID: user.ID,
+		ToUserID:   toUserID,
+		Amount:     amount,
+		Note:       note,
+		ClientIP:   client.IP,
+	}, ctl.limits())
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCoinTransferBalanceNotEnough):
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+		case errors.Is(err, repo.ErrCoinTransferDailyLimit):
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "超过今日转赠额度"), http.StatusForbidden)
+		case errors.Is(err, repo.ErrCoinTransferMonthlyLimit):
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "超过本月转赠额度"), http.StatusForbidden)
+		case errors.Is(err, repo.ErrCoinTransferRecipientLimit):
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "今日转赠的用户数量已达上限"), http.StatusForbidden)
+		case errors.Is(err, repo.ErrCoinTransferInvalid):
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "to_user_id": toUserID, "amount": amount}).Errorf("转赠智慧果失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data": transfer,
+		"to_user": web.M{
+			"id":    toUserID,
+			"name":  toName,
+			"phone": misc.MaskPhoneNumber(toPhone),
+		},
+	})
+}
+
+// CoinTransferItem 转赠记录，Direction 为 out 表示当前用户转出，in 表示当前用户收到
+type CoinTransferItem struct {
+	repo.CoinTransfer
+	Direction string `json:"direction"`
+	// Counterpart 对方用户的名称
+	Counterpart string `json:"counterpart"`
+}
+
+// Transfers 当前用户转出和收到的转赠记录
+func (ctl *CoinTransferController) Transfers(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	page := webCtx.Int64Input("page", 1)
+	if page < 1 || page > 1000 {
+		page = 1
+	}
+
+	perPage := webCtx.Int64Input("per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	transfers, meta, err := ctl.transferRepo.Transfers(ctx, user.ID, page, perPage)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询智慧果转赠记录失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	names := make(map[int64]string)
+	items := make([]CoinTransferItem, 0, len(transfers))
+	for _, transfer := range transfers {
+		item := CoinTransferItem{CoinTransfer: transfer, Direction: "out"}
+		counterpart := transfer.ToUserID
+		if transfer.ToUserID == user.ID {
+			item.Direction, counterpart = "in", transfer.FromUserID
+		}
+
+		name, ok := names[counterpart]
+		if !ok {
+			if u, err := ctl.userRepo.GetUserByID(ctx, counterpart); err == nil {
+				name = u.Realname
+				if name == "" {
+					name = misc.MaskPhoneNumber(u.Phone)
+				}
+			}
+			names[counterpart] = name
+		}
+
+		item.Counterpart = name
+		items = append(items, item)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":      items,
+		"page":      meta.Page,
+		"per_page":  meta.PerPage,
+		"total":     meta.Total,
+		"last_page": meta.LastPage,
+	})
+}
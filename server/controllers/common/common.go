@@ -13,6 +13,7 @@ const (
 	ErrInvalidCredential = "无效的凭证"
 	ErrNotFound          = "资源不存在"
 	ErrFileTooLarge      = "文件太大"
+	ErrRegionRestricted  = "当前地区暂不支持该功能"
 )
 
 func GetLanguage(webCtx web.Context) string {
@@ -32,3 +33,11 @@ func Text(webCtx web.Context, translater youdao.Translater, text string) string
 
 	return text
 }
+
+// ContextKeyUpstreamModel 请求实际使用的上游模型，由控制器写入，记录在访问日志中
+const ContextKeyUpstreamModel = "aidea-upstream-model"
+
+// SetUpstreamModel 记录请求实际使用的上游模型
+func SetUpstreamModel(webCtx web.Context, model string) {
+	webCtx.Set(ContextKeyUpstreamModel, model)
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"sort"
+
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/glacier/web"
+)
+
+// CompatShim 接口兼容层，接口响应发生不兼容的变更时，为旧版本客户端将新格式的响应转换回旧的格式
+type CompatShim struct {
+	// Route 接口标识，例如 group-chat.messages
+	Route string
+	// Before 低于该版本的客户端需要转换
+	Before string
+	// Convert 将新格式的响应转换为旧格式
+	Convert func(data web.M) web.M
+}
+
+var compatShims []CompatShim
+
+// RegisterCompatShim 注册接口兼容转换，应当在服务启动前注册
+func RegisterCompatShim(shims ...CompatShim) {
+	compatShims = append(compatShims, shims...)
+	sort.SliceStable(compatShims, func(i, j int) bool {
+		return misc.VersionNewer(compatShims[i].Before, compatShims[j].Before)
+	})
+}
+
+// Compat 按照客户端版本转换接口响应，越新的变更越先撤销，未知版本的客户端视为最新版本
+func Compat(route, clientVersion string, data web.M) web.M {
+	if clientVersion == "" {
+		return data
+	}
+
+	for _, shim := range compatShims {
+		if shim.Route == route && misc.VersionOlder(clientVersion, shim.Before) {
+			data = shim.Convert(data)
+		}
+	}
+
+	return data
+}
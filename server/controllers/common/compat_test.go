@@ -0,0 +1,38 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestCompat(t *testing.T) {
+	var applied []string
+	shim := func(name string) func(data web.M) web.M {
+		return func(data web.M) web.M {
+			applied = append(applied, name)
+			return data
+		}
+	}
+
+	common.RegisterCompatShim(
+		common.CompatShim{Route: "test.route", Before: "1.0.8", Convert: shim("1.0.8")},
+		common.CompatShim{Route: "test.route", Before: "1.0.10", Convert: shim("1.0.10")},
+		common.CompatShim{Route: "test.other", Before: "1.0.10", Convert: shim("other")},
+	)
+
+	common.Compat("test.route", "", web.M{})
+	assert.Equal(t, 0, len(applied))
+
+	common.Compat("test.route", "1.0.10", web.M{})
+	assert.Equal(t, 0, len(applied))
+
+	common.Compat("test.route", "1.0.9", web.M{})
+	assert.EqualValues(t, []string{"1.0.10"}, applied)
+
+	applied = nil
+	common.Compat("test.route", "1.0.6", web.M{})
+	assert.EqualValues(t, []string{"1.0.10", "1.0.8"}, applied)
+}
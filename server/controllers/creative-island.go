@@ -450,11 +450,13 @@ func (ctl *CreativeIslandController) completionsDeepAI(ctx context.Context, webC
 	}
 
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(
+	taskID, err := ctl.queue.EnqueueContext(
+		ctx,
 		&queue.DeepAICompletionPayload{
 			Model:          stylePreset,
 			Quota:          quotaConsume,
 			UID:            user.ID,
+			OrgID:          repo2.BillingOrgFromContext(ctx),
 			Prompt:         prompt,
 			NegativePrompt: negativePrompt,
 			Width:          int64(width),
@@ -581,11 +583,13 @@ func (ctl *CreativeIslandController) completionsStabilityAI(ctx context.Context,
 	}
 
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(
+	taskID, err := ctl.queue.EnqueueContext(
+		ctx,
 		&queue.StabilityAICompletionPayload{
 			Model:          item.Model,
 			Quota:          quotaConsume,
 			UID:            user.ID,
+			OrgID:          repo2.BillingOrgFromContext(ctx),
 			Prompt:         prompt,
 			NegativePrompt: negativePrompt,
 			Width:          int64(width),
@@ -725,11 +729,13 @@ func (ctl *CreativeIslandController) completionsLeapAI(ctx context.Context, webC
 	}
 
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(
+	taskID, err := ctl.queue.EnqueueContext(
+		ctx,
 		&queue.LeapAICompletionPayload{
 			Model:          item.Model,
 			Quota:          quotaConsume,
 			UID:            user.ID,
+			OrgID:          repo2.BillingOrgFromContext(ctx),
 			Prompt:         prompt,
 			NegativePrompt: negativePrompt,
 			Width:          int64(width),
@@ -849,11 +855,13 @@ func (ctl *CreativeIslandController) completionsOpenAI(ctx context.Context, webC
 	}
 
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(
+	taskID, err := ctl.queue.EnqueueContext(
+		ctx,
 		&queue.OpenAICompletionPayload{
 			Model:     item.Model,
 			Quota:     quotaConsumed,
 			UID:       user.ID,
+			OrgID:     repo2.BillingOrgFromContext(ctx),
 			Prompts:   messages,
 			WordCount: wordCount,
 			CreatedAt: time.Now(),
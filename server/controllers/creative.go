@@ -75,6 +75,7 @@ func (ctl *CreativeController) GalleryItem(ctx context.Context, webCtx web.Conte
 		return webCtx.JSON(web.M{
 			"data":             item,
 			"is_internal_user": user.User != nil && user.User.InternalUser(),
+			"provenance":       repo.GalleryProvenance(*item),
 		})
 	}
 
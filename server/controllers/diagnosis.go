@@ -2,19 +2,25 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/rate"
 	"net/http"
 	"time"
 
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
 	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/glacier/infra"
 	"github.com/mylxsw/glacier/web"
 )
 
 type DiagnosisController struct {
-	limiter *rate.RateLimiter `autowire:"@"`
+	limiter   *rate.RateLimiter         `autowire:"@"`
+	trans     youdao.Translater         `autowire:"@"`
+	bugReport *service.BugReportService `autowire:"@"`
 }
 
 func NewDiagnosisController(resolver infra.Resolver) web.Controller {
@@ -27,6 +33,7 @@ func NewDiagnosisController(resolver infra.Resolver) web.Controller {
 func (ctl *DiagnosisController) Register(router web.Router) {
 	router.Group("/diagnosis", func(router web.Router) {
 		router.Post("/upload", ctl.uploadDiagnosisInfo)
+		router.Post("/bug-reports", ctl.submitBugReport)
 	})
 }
 
@@ -49,3 +56,50 @@ func (ctl *DiagnosisController) uploadDiagnosisInfo(ctx context.Context, webCtx
 
 	return webCtx.JSON(web.M{})
 }
+
+// BugReportRequest 客户端出错时提交的问题报告
+type BugReportRequest struct {
+	Subject     string            `json:"subject,omitempty"`
+	Description string            `json:"description,omitempty"`
+	ClientError string            `json:"client_error,omitempty"`
+	RequestIDs  []string          `json:"request_ids,omitempty"`
+	Device      map[string]string `json:"device,omitempty"`
+}
+
+// submitBugReport 提交问题报告，服务端关联最近请求的处理结果与异步任务日志后生成客服工单
+func (ctl *DiagnosisController) submitBugReport(ctx context.Context, webCtx web.Context, user *auth.User, info *auth.ClientInfo) web.Response {
+	err := ctl.limiter.Allow(ctx, fmt.Sprintf("diagnosis:bug-report:%d:limit", user.ID), rate.MaxRequestsInPeriod(3, 10*time.Minute))
+	if err != nil {
+		if err == rate.ErrRateLimitExceeded {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "操作频率过高，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	var req BugReportRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	ticketID, err := ctl.bugReport.Submit(ctx, user.ID, service.BugReportSubmit{
+		Subject:         req.Subject,
+		Description:     req.Description,
+		AppVersion:      info.Version,
+		Platform:        info.Platform,
+		PlatformVersion: info.PlatformVersion,
+		Device:          req.Device,
+		ClientError:     req.ClientError,
+		RequestIDs:      req.RequestIDs,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrBugReportEmpty) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("提交问题报告失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"ticket_id": ticketID})
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+const (
+	// maxGlossaryEntries 每个用户术语表中最多的术语数量
+	maxGlossaryEntries = 200
+	// maxGlossaryTermLength 术语原文的最大长度
+	maxGlossaryTermLength = 100
+	// maxGlossaryReplacementLength 译法、写法以及备注的最大长度
+	maxGlossaryReplacementLength = 255
+)
+
+// GlossaryController 用户术语表管理
+type GlossaryController struct {
+	translater   youdao.Translater   `autowire:"@"`
+	glossaryRepo *repo2.GlossaryRepo `autowire:"@"`
+}
+
+// NewGlossaryController 创建术语表控制器
+func NewGlossaryController(resolver infra.Resolver) web.Controller {
+	ctl := &GlossaryController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *GlossaryController) Register(router web.Router) {
+	router.Group("/users/glossary", func(router web.Router) {
+		router.Get("/", ctl.Entries)
+		router.Post("/", ctl.Set)
+		router.Put("/{id}", ctl.Update)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+// Entries 当前用户的术语表
+func (ctl *GlossaryController) Entries(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	entries, err := ctl.glossaryRepo.Entries(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询术语表失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": entries})
+}
+
+// parseEntry 解析并校验请求中的术语
+func (ctl *GlossaryController) parseEntry(webCtx web.Context) (repo2.GlossaryEntry, web.Response) {
+	entry := repo2.GlossaryEntry{
+		Term:        strings.TrimSpace(webCtx.Input("term")),
+		Replacement: strings.TrimSpace(webCtx.Input("replacement")),
+		Note:        strings.TrimSpace(webCtx.Input("note")),
+	}
+
+	if entry.Term == "" || entry.Replacement == "" {
+		return entry, webCtx.JSONError(common.Text(webCtx, ctl.translater, "术语和译法不能为空"), http.StatusBadRequest)
+	}
+
+	if len([]rune(entry.Term)) > maxGlossaryTermLength ||
+		len([]rune(entry.Replacement)) > maxGlossaryReplacementLength ||
+		len([]rune(entry.Note)) > maxGlossaryReplacementLength {
+		return entry, webCtx.JSONError(common.Text(webCtx, ctl.translater, "术语内容过长"), http.StatusBadRequest)
+	}
+
+	return entry, nil
+}
+
+// Set 新增术语，术语已经存在时更新译法
+func (ctl *GlossaryController) Set(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	entry, errResp := ctl.parseEntry(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	entries, err := ctl.glossaryRepo.Entries(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询术语表失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if findGlossaryEntry(entries, entry.Term) == nil && len(entries) >= maxGlossaryEntries {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "术语数量已达到上限"), http.StatusBadRequest)
+	}
+
+	if err := ctl.glossaryRepo.SetEntry(ctx, user.ID, entry); err != nil {
+		log.F(log.M{"user_id": user.ID, "term": entry.Term}).Errorf("保存术语失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Update 修改术语
+func (ctl *GlossaryController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	entry, errResp := ctl.parseEntry(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	entry.ID = id
+
+	entries, err := ctl.glossaryRepo.Entries(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询术语表失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if exist := findGlossaryEntry(entries, entry.Term); exist != nil && exist.ID != id {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "术语已经存在"), http.StatusBadRequest)
+	}
+
+	if err := ctl.glossaryRepo.UpdateEntry(ctx, user.ID, entry); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("修改术语失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// findGlossaryEntry 查找术语（不区分大小写，与数据库唯一索引一致）
+func findGlossaryEntry(entries []repo2.GlossaryEntry, term string) *repo2.GlossaryEntry {
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Term, term) {
+			return &entry
+		}
+	}
+
+	return nil
+}
+
+// Delete 删除术语
+func (ctl *GlossaryController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.glossaryRepo.DeleteEntry(ctx, user.ID, id); err != nil {
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("删除术语失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
@@ -19,18 +19,22 @@ import (
 	"github.com/mylxsw/aidea-server/server/controllers/common"
 	"github.com/mylxsw/asteria/log"
 
+	"github.com/hibiken/asynq"
 	"github.com/mylxsw/aidea-server/internal/queue"
 	"github.com/mylxsw/aidea-server/server/auth"
 	"github.com/mylxsw/glacier/infra"
 	"github.com/mylxsw/glacier/web"
 	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
 )
 
 type GroupChatController struct {
-	conf    *config.Config       `autowire:"@"`
-	repo    *repo2.Repository    `autowire:"@"`
-	queue   *queue.Queue         `autowire:"@"`
-	userSrv *service.UserService `autowire:"@"`
+	conf         *config.Config                 `autowire:"@"`
+	repo         *repo2.Repository              `autowire:"@"`
+	queue        *queue.Queue                   `autowire:"@"`
+	userSrv      *service.UserService           `autowire:"@"`
+	judgeSrv     *service.GroupJudgeService     `autowire:"@"`
+	consensusSrv *service.GroupConsensusService `autowire:"@"`
 }
 
 func NewGroupChatController(resolver infra.Resolver) web.Controller {
@@ -39,17 +43,29 @@ func NewGroupChatController(resolver infra.Resolver) web.Controller {
 	return ctl
 }
 
+// 群聊接口的兼容层标识，接口响应发生不兼容的变更时通过 common.RegisterCompatShim 为旧版本客户端注册转换
+const (
+	compatRouteGroup         = "group-chat.group"
+	compatRouteGroupMessages = "group-chat.messages"
+)
+
 func (ctl *GroupChatController) Register(router web.Router) {
 	router.Group("/group-chat", func(router web.Router) {
 		router.Get("/", ctl.Groups)
 		router.Post("/", ctl.CreateGroup)
+		router.Get("/stats/win-rates", ctl.WinRates)
+		router.Get("/compositions", ctl.Compositions)
 		router.Get("/{group_id}", ctl.Group)
 		router.Put("/{group_id}", ctl.UpdateGroup)
 		router.Delete("/{group_id}", ctl.DeleteGroup)
 		router.Get("/{group_id}/messages", ctl.GroupMessages)
 		router.Post("/{group_id}/chat", ctl.Chat)
 		router.Post("/{group_id}/chat-system", ctl.ChatSystem)
+		router.Post("/{group_id}/debate", ctl.Debate)
 		router.Delete("/{group_id}/chat/{message_id}", ctl.DeleteMessage)
+		router.Post("/{group_id}/chat/{message_id}/judge", ctl.Judge)
+		router.Post("/{group_id}/chat/{message_id}/vote", ctl.Vote)
+		router.Post("/{group_id}/chat/{message_id}/merge", ctl.Merge)
 		router.Delete("/{group_id}/all-chat", ctl.DeleteAllMessages)
 
 		router.Get("/{group_id}/chat-messages", ctl.ChatMessageStatus)
@@ -60,6 +76,8 @@ type GroupCreateRequest struct {
 	Name      string         `json:"name"`
 	AvatarURL string         `json:"avatar_url,omitempty"`
 	Members   []repo2.Member `json:"members,omitempty"`
+	// CompositionID 使用推荐的群组组合创建群组，指定后忽略 Members，Name 和 AvatarURL 为空时使用组合的设置
+	CompositionID int64 `json:"composition_id,omitempty"`
 }
 
 // CreateGroup 创建群组
@@ -70,6 +88,26 @@ func (ctl *GroupChatController) CreateGroup(ctx context.Context, webCtx web.Cont
 	}
 	req.Name = strings.TrimSpace(req.Name)
 
+	if req.CompositionID > 0 {
+		comp, err := ctl.repo.ChatGroup.Composition(ctx, req.CompositionID)
+		if err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				return webCtx.JSONError("composition not found", http.StatusNotFound)
+			}
+
+			log.F(log.M{"composition_id": req.CompositionID, "user_id": user.ID}).Errorf("query group composition failed: %s", err)
+			return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+		}
+
+		req.Members = ctl.availableMembers(comp.Members)
+		if len(req.Members) == 0 {
+			return webCtx.JSONError("models in composition are unavailable", http.StatusBadRequest)
+		}
+
+		req.Name = ternary.If(req.Name == "", comp.Name, req.Name)
+		req.AvatarURL = ternary.If(req.AvatarURL == "", comp.AvatarURL, req.AvatarURL)
+	}
+
 	if len(req.Members) == 0 {
 		req.Members = array.Map(
 			array.Filter(chat2.Models(ctl.conf, false), func(m chat2.Model, _ int) bool {
@@ -112,6 +150,52 @@ func (ctl *GroupChatController) CreateGroup(ctx context.Context, webCtx web.Cont
 	})
 }
 
+// Compositions 推荐的群组组合，只返回当前可用的模型成员
+func (ctl *GroupChatController) Compositions(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	comps, err := ctl.repo.ChatGroup.Compositions(ctx, true)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query group compositions failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	comps = array.Filter(
+		array.Map(comps, func(comp repo2.GroupComposition, _ int) repo2.GroupComposition {
+			comp.Members = ctl.availableMembers(comp.Members)
+			return comp
+		}),
+		func(comp repo2.GroupComposition, _ int) bool { return len(comp.Members) > 0 },
+	)
+
+	return webCtx.JSON(web.M{"data": comps})
+}
+
+// availableMembers 过滤掉当前不可用的模型成员，并补全成员名称
+func (ctl *GroupChatController) availableMembers(members []repo2.Member) []repo2.Member {
+	models := array.ToMap(chat2.Models(ctl.conf, false), func(m chat2.Model, _ int) string { return m.RealID() })
+
+	members = array.Map(members, func(mem repo2.Member, _ int) repo2.Member {
+		if segs := strings.SplitN(mem.ModelID, ":", 2); len(segs) == 2 {
+			mem.ModelID = segs[1]
+		}
+
+		return mem
+	})
+
+	return array.Map(
+		array.Filter(members, func(mem repo2.Member, _ int) bool {
+			_, ok := models[mem.ModelID]
+			return ok
+		}),
+		func(mem repo2.Member, _ int) repo2.Member {
+			if mem.ModelName == "" {
+				mem.ModelName = models[mem.ModelID].ShortName
+			}
+
+			return mem
+		},
+	)
+}
+
 // Groups 获取用户的群组列表
 func (ctl *GroupChatController) Groups(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
 	groups, err := ctl.repo.ChatGroup.Groups(ctx, user.ID, RoomsQueryLimit)
@@ -153,7 +237,7 @@ func (ctl *GroupChatController) Group(ctx context.Context, webCtx web.Context, u
 		return item.RealID()
 	})
 
-	return webCtx.JSON(web.M{
+	return webCtx.JSON(common.Compat(compatRouteGroup, client.Version, web.M{
 		"group": grp.Group,
 		"members": array.Map(
 			grp.Members,
@@ -167,7 +251,7 @@ func (ctl *GroupChatController) Group(ctx context.Context, webCtx web.Context, u
 				}
 			},
 		),
-	})
+	}))
 }
 
 // UpdateGroup 更新群组
@@ -216,7 +300,7 @@ func (ctl *GroupChatController) DeleteGroup(ctx context.Context, webCtx web.Cont
 }
 
 // GroupMessages 获取群组消息
-func (ctl *GroupChatController) GroupMessages(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+func (ctl *GroupChatController) GroupMessages(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo) web.Response {
 	groupID, err := strconv.Atoi(webCtx.PathVar("group_id"))
 	if err != nil {
 		return webCtx.JSONError("invalid group id", http.StatusBadRequest)
@@ -233,17 +317,23 @@ func (ctl *GroupChatController) GroupMessages(ctx context.Context, webCtx web.Co
 		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
 	}
 
-	return webCtx.JSON(web.M{
+	return webCtx.JSON(common.Compat(compatRouteGroupMessages, client.Version, web.M{
 		"data":     messages,
 		"start_id": startID,
 		"last_id":  lastID,
 		"per_page": perPage,
-	})
+	}))
 }
 
 type GroupChatRequest struct {
 	Message   string  `json:"message,omitempty"`
 	MemberIDs []int64 `json:"member_ids,omitempty"`
+	// AutoJudge 多个成员回答时，是否在所有回答完成后自动由评审模型选出最佳回答
+	AutoJudge bool `json:"auto_judge,omitempty"`
+	// AutoMerge 多个成员回答时，是否在所有回答完成后自动合并生成综合回答
+	AutoMerge bool `json:"auto_merge,omitempty"`
+	// DisablePersona 本次对话不使用用户的全局指令
+	DisablePersona bool `json:"disable_persona,omitempty"`
 }
 
 type GroupChatMember struct {
@@ -340,6 +430,19 @@ func (ctl *GroupChatController) Chat(ctx context.Context, webCtx web.Context, us
 		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
 	}
 
+	// 用户的全局指令，可以在全局指令设置中关闭群聊使用，或者在单次对话中关闭
+	var persona *repo2.UserPersona
+	if !req.DisablePersona {
+		persona, err = ctl.userSrv.Persona(ctx, user.ID)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID}).Errorf("query user persona failed: %v", err)
+		}
+
+		if persona != nil && persona.SkipGroupChat {
+			persona = nil
+		}
+	}
+
 	qas := buildQuestionFromChatGroupMessages(contextMessages)
 	messagesPerMembers := make(map[int64]GroupChatMessages)
 	for _, memberID := range availableMembers {
@@ -362,7 +465,7 @@ func (ctl *GroupChatController) Chat(ctx context.Context, webCtx web.Context, us
 		}
 
 		memberMessages = append(memberMessages, chat2.Message{Role: "user", Content: req.Message})
-		messagesPerMembers[memberID] = GroupChatMessages{Messages: memberMessages}
+		messagesPerMembers[memberID] = GroupChatMessages{Messages: service.ApplyPersona(memberMessages, persona)}
 	}
 
 	log.With(messagesPerMembers).Debugf("group chat messages per members")
@@ -448,6 +551,7 @@ func (ctl *GroupChatController) Chat(ctx context.Context, webCtx web.Context, us
 		// 将消息放入队列中，等待处理
 		payload := queue.GroupChatPayload{
 			UserID:          user.ID,
+			OrgID:           repo2.BillingOrgFromContext(ctx),
 			GroupID:         grp.Group.Id,
 			MemberID:        memberID,
 			QuestionID:      questionID,
@@ -456,10 +560,12 @@ func (ctl *GroupChatController) Chat(ctx context.Context, webCtx web.Context, us
 			ContextMessages: mpm.Messages,
 			CreatedAt:       time.Now(),
 			FreezedCoins:    mpm.NeedCoins,
+			AutoJudge:       req.AutoJudge && len(messagesPerMembers) > 1 && ctl.judgeSrv.Enabled(),
+			AutoMerge:       req.AutoMerge && len(messagesPerMembers) > 1 && ctl.consensusSrv.Enabled(),
 		}
 
 		// 加入异步任务队列
-		taskID, err := ctl.queue.Enqueue(&payload, queue.NewGroupChatTask)
+		taskID, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewGroupChatTask)
 		if err != nil {
 			log.With(payload).Errorf("enqueue chat task failed: %s", err)
 			continue
@@ -478,6 +584,255 @@ func (ctl *GroupChatController) Chat(ctx context.Context, webCtx web.Context, us
 	})
 }
 
+const (
+	// groupDebateDefaultRounds 群聊辩论默认轮数
+	groupDebateDefaultRounds = 2
+	// groupDebateMaxRounds 群聊辩论最大轮数
+	groupDebateMaxRounds = 5
+	// groupDebateTokensPerTurn 预估的每次发言 Token 数，用于冻结智慧果
+	groupDebateTokensPerTurn = 400
+)
+
+type GroupDebateRequest struct {
+	Message   string  `json:"message,omitempty"`
+	MemberIDs []int64 `json:"member_ids,omitempty"`
+	// Rounds 辩论轮数，每一轮所有成员按顺序各发言一次
+	Rounds int `json:"rounds,omitempty"`
+}
+
+// Debate 发起群聊辩论，成员按顺序轮流发言并回应其它成员的观点，最后生成总结
+func (ctl *GroupChatController) Debate(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	groupID, err := strconv.Atoi(webCtx.PathVar("group_id"))
+	if err != nil {
+		return webCtx.JSONError("invalid group id", http.StatusBadRequest)
+	}
+
+	var req GroupDebateRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(err.Error(), http.StatusBadRequest)
+	}
+
+	if strings.TrimSpace(req.Message) == "" {
+		return webCtx.JSONError("empty messages", http.StatusBadRequest)
+	}
+
+	if req.Rounds <= 0 {
+		req.Rounds = groupDebateDefaultRounds
+	}
+
+	if req.Rounds > groupDebateMaxRounds {
+		return webCtx.JSONError(fmt.Sprintf("rounds must not exceed %d", groupDebateMaxRounds), http.StatusBadRequest)
+	}
+
+	grp, err := ctl.repo.ChatGroup.GetGroup(ctx, int64(groupID), user.ID)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError("group not found", http.StatusNotFound)
+		}
+
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	// 未指定成员时，所有成员都参与辩论；成员的发言顺序与群组中的顺序一致
+	supportMembers := array.Map(grp.Members, func(m model.ChatGroupMember, _ int) int64 { return m.Id })
+	if len(req.MemberIDs) == 0 {
+		req.MemberIDs = supportMembers
+	}
+
+	availableMembers := array.Intersect(req.MemberIDs, supportMembers)
+	if len(availableMembers) < 2 {
+		return webCtx.JSONError("at least two members are required for a debate", http.StatusBadRequest)
+	}
+
+	membersMap := array.ToMap(grp.Members, func(mem model.ChatGroupMember, _ int) int64 { return mem.Id })
+	members := array.Map(availableMembers, func(memID int64, _ int) queue.GroupDebateMember {
+		return queue.GroupDebateMember{ID: memID, ModelID: membersMap[memID].ModelId, ModelName: membersMap[memID].ModelName}
+	})
+
+	topicTokens, err := chat2.MessageTokenCount(chat2.Messages{{Role: "user", Content: req.Message}}, members[0].ModelID)
+	if err != nil {
+		return webCtx.JSONError(err.Error(), http.StatusBadRequest)
+	}
+
+	// 每次发言的上下文包含之前所有的发言，按照发言次数递增估算
+	var needCoins int64
+	var turn int64
+	for round := 0; round < req.Rounds; round++ {
+		for _, mem := range members {
+			needCoins += coins.GetOpenAITextCoins(mem.ModelID, int64(topicTokens)+groupDebateTokensPerTurn*(turn+1))
+			turn++
+		}
+	}
+	needCoins += coins.GetOpenAITextCoins(members[0].ModelID, int64(topicTokens)+groupDebateTokensPerTurn*(turn+1))
+
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"req": req}).Errorf("get user quota failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < needCoins {
+		return webCtx.JSONError(common.ErrQuotaNotEnough, http.StatusPaymentRequired)
+	}
+
+	questionID, err := ctl.repo.ChatGroup.AddChatMessage(ctx, grp.Group.Id, user.ID, repo2.ChatGroupMessage{
+		Message: req.Message,
+		Role:    int64(repo2.MessageRoleUser),
+		Status:  repo2.MessageStatusSucceed,
+	})
+	if err != nil {
+		log.With(req).Errorf("add chat message failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, needCoins); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": needCoins}).Errorf("群聊辩论冻结用户智慧果失败: %s", err)
+		needCoins = 0
+	}
+
+	payload := queue.GroupDebatePayload{
+		UserID:       user.ID,
+		OrgID:        repo2.BillingOrgFromContext(ctx),
+		GroupID:      grp.Group.Id,
+		QuestionID:   questionID,
+		Topic:        req.Message,
+		Rounds:       req.Rounds,
+		Members:      members,
+		CreatedAt:    time.Now(),
+		FreezedCoins: needCoins,
+	}
+
+	taskID, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewGroupDebateTask, asynq.Timeout(30*time.Minute))
+	if err != nil {
+		log.With(payload).Errorf("enqueue group debate task failed: %s", err)
+		if needCoins > 0 {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, needCoins); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": needCoins}).Errorf("群聊辩论释放用户智慧果失败: %s", err)
+			}
+		}
+
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"task_id":     taskID,
+		"question_id": questionID,
+		"rounds":      req.Rounds,
+		"members":     availableMembers,
+	})
+}
+
+// Judge 由评审模型对问题下的多个回答打分，并选出最佳回答
+func (ctl *GroupChatController) Judge(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	groupID, err := strconv.Atoi(webCtx.PathVar("group_id"))
+	if err != nil {
+		return webCtx.JSONError("invalid group id", http.StatusBadRequest)
+	}
+
+	messageID, err := strconv.Atoi(webCtx.PathVar("message_id"))
+	if err != nil {
+		return webCtx.JSONError("invalid message id", http.StatusBadRequest)
+	}
+
+	result, err := ctl.judgeSrv.Judge(ctx, int64(groupID), user.ID, int64(messageID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrJudgeDisabled):
+			return webCtx.JSONError("judge is not enabled", http.StatusBadRequest)
+		case errors.Is(err, service.ErrJudgeNotEnough):
+			return webCtx.JSONError("at least two answers are required", http.StatusBadRequest)
+		case errors.Is(err, service.ErrJudgeInProgress):
+			return webCtx.JSONError("answers have been judged", http.StatusConflict)
+		case errors.Is(err, repo2.ErrNotFound):
+			return webCtx.JSONError("message not found", http.StatusNotFound)
+		}
+
+		log.F(log.M{"group_id": groupID, "message_id": messageID}).Errorf("judge group answers failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": result})
+}
+
+// Merge 将问题下多个成员的回答合并为一个综合回答
+func (ctl *GroupChatController) Merge(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	groupID, err := strconv.Atoi(webCtx.PathVar("group_id"))
+	if err != nil {
+		return webCtx.JSONError("invalid group id", http.StatusBadRequest)
+	}
+
+	messageID, err := strconv.Atoi(webCtx.PathVar("message_id"))
+	if err != nil {
+		return webCtx.JSONError("invalid message id", http.StatusBadRequest)
+	}
+
+	msg, err := ctl.consensusSrv.Merge(ctx, int64(groupID), user.ID, int64(messageID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrConsensusDisabled):
+			return webCtx.JSONError("consensus is not enabled", http.StatusBadRequest)
+		case errors.Is(err, service.ErrConsensusNotEnough):
+			return webCtx.JSONError("at least two answers are required", http.StatusBadRequest)
+		case errors.Is(err, service.ErrConsensusExists):
+			return webCtx.JSONError("answers have been merged", http.StatusConflict)
+		case errors.Is(err, repo2.ErrNotFound):
+			return webCtx.JSONError("message not found", http.StatusNotFound)
+		}
+
+		log.F(log.M{"group_id": groupID, "message_id": messageID}).Errorf("merge group answers failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": repo2.ChatGroupMessageRes{
+		ChatGroupMessage: *msg,
+		Type:             repo2.ResolveGroupMessageType(msg.Role),
+	}})
+}
+
+type GroupVoteRequest struct {
+	AnswerID int64 `json:"answer_id"`
+}
+
+// Vote 用户投票选择最佳回答，覆盖评审模型的选择
+func (ctl *GroupChatController) Vote(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	groupID, err := strconv.Atoi(webCtx.PathVar("group_id"))
+	if err != nil {
+		return webCtx.JSONError("invalid group id", http.StatusBadRequest)
+	}
+
+	messageID, err := strconv.Atoi(webCtx.PathVar("message_id"))
+	if err != nil {
+		return webCtx.JSONError("invalid message id", http.StatusBadRequest)
+	}
+
+	var req GroupVoteRequest
+	if err := webCtx.Unmarshal(&req); err != nil || req.AnswerID <= 0 {
+		return webCtx.JSONError("invalid answer id", http.StatusBadRequest)
+	}
+
+	if err := ctl.repo.ChatGroup.VoteBest(ctx, int64(groupID), user.ID, int64(messageID), req.AnswerID); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError("answer not found", http.StatusNotFound)
+		}
+
+		log.F(log.M{"group_id": groupID, "message_id": messageID, "answer_id": req.AnswerID}).Errorf("vote best answer failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// WinRates 当前用户群聊中各模型被选为最佳回答的胜率
+func (ctl *GroupChatController) WinRates(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	rates, err := ctl.repo.ChatGroup.ModelWinRates(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query model win rates failed: %s", err)
+		return webCtx.JSONError("internal server error", http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": rates})
+}
+
 // ChatSystem 发起系统消息
 func (ctl *GroupChatController) ChatSystem(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
 	groupID, err := strconv.Atoi(webCtx.PathVar("group_id"))
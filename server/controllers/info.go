@@ -23,9 +23,12 @@ import (
 
 // InfoController 信息控制器
 type InfoController struct {
-	conf    *config.Config       `autowire:"@"`
-	userSvc *service.UserService `autowire:"@"`
-	rds     *redis.Client        `autowire:"@"`
+	conf             *config.Config                `autowire:"@"`
+	userSvc          *service.UserService          `autowire:"@"`
+	rds              *redis.Client                 `autowire:"@"`
+	noticeSrv        *service.NoticeService        `autowire:"@"`
+	versionPolicySrv *service.VersionPolicyService `autowire:"@"`
+	betaSrv          *service.BetaService          `autowire:"@"`
 }
 
 // NewInfoController 创建信息控制器
@@ -61,6 +64,12 @@ var qrCodes = []string{
 
 // FreeChatCounts 免费聊天额度统计
 func (ctl *InfoController) FreeChatCounts(ctx context.Context, webCtx web.Context, user *auth.UserOptional, client *auth.ClientInfo) web.Response {
+	return webCtx.JSON(web.M{
+		"data": ctl.freeChatCounts(ctx, user, client),
+	})
+}
+
+func (ctl *InfoController) freeChatCounts(ctx context.Context, user *auth.UserOptional, client *auth.ClientInfo) []service.FreeChatState {
 	userID := ternary.IfLazy(user.User != nil, func() int64 { return user.User.ID }, func() int64 { return 0 })
 	freeModels := ctl.userSvc.FreeChatStatistics(ctx, userID)
 	if client.IsCNLocalMode(ctl.conf) && (user.User == nil || !user.User.ExtraPermissionUser()) {
@@ -69,9 +78,7 @@ func (ctl *InfoController) FreeChatCounts(ctx context.Context, webCtx web.Contex
 		})
 	}
 
-	return webCtx.JSON(web.M{
-		"data": freeModels,
-	})
+	return freeModels
 }
 
 func (ctl *InfoController) shareInfo(ctx web.Context, user *auth.UserOptional) web.Response {
@@ -115,12 +122,15 @@ func (ctl *InfoController) VersionCheck(ctx web.Context) web.Response {
 		hasUpdate = misc.VersionNewer(CurrentVersion, clientVersion)
 	}
 
+	// 管理员配置的版本策略
+	upgrade := ctl.versionPolicySrv.Check(ctx.Context(), clientOS, clientVersion)
+
 	return ctx.JSON(web.M{
-		"has_update":     hasUpdate,
+		"has_update":     hasUpdate || upgrade.Recommended,
 		"server_version": CurrentVersion,
-		"force_update":   false,
-		"url":            "https://aidea.aicode.cc",
-		"message":        fmt.Sprintf("新版本 %s 发布啦，赶快去更新吧！", CurrentVersion),
+		"force_update":   upgrade.Required,
+		"url":            ternary.If(upgrade.URL != "", upgrade.URL, "https://aidea.aicode.cc"),
+		"message":        ternary.If(upgrade.Message != "", upgrade.Message, fmt.Sprintf("新版本 %s 发布啦，赶快去更新吧！", CurrentVersion)),
 	})
 }
 
@@ -143,14 +153,28 @@ type HomeModel struct {
 
 // Capabilities 获取 AI 平台的能力列表
 func (ctl *InfoController) Capabilities(ctx context.Context, webCtx web.Context, user *auth.UserOptional, client *auth.ClientInfo) web.Response {
+	return webCtx.JSON(ctl.capabilities(ctx, user, client))
+}
+
+func (ctl *InfoController) capabilities(ctx context.Context, user *auth.UserOptional, client *auth.ClientInfo) web.M {
 	enableOpenAI, homeModels := ctl.loadHomeModels(ctx, ctl.conf, client, user)
-	return webCtx.JSON(web.M{
+
+	var userID int64
+	if user.User != nil {
+		userID = user.User.ID
+	}
+
+	return web.M{
 		// 是否启用苹果 App 支付
 		"applepay_enabled": ctl.conf.EnableApplePay,
 		// 是否启用支付宝支付 @deprecated(since 1.0.8)
 		"alipay_enabled": ctl.conf.EnableAlipay,
 		// 是否启用支付宝支付
 		"other_pay_enabled": ctl.conf.EnableAlipay,
+		// 是否允许用户之间转赠智慧果
+		"coin_transfer_enabled": ctl.conf.EnableCoinTransfer,
+		// 是否启用余额不足时自动充值（支付宝商家扣款）
+		"auto_topup_enabled": ctl.conf.EnableAutoTopup && ctl.conf.EnableAlipay,
 		// 是否启用讯飞星火模型
 		"xfyunai_enabled": ctl.conf.EnableXFYunAI,
 		// 是否启用百度文心千帆模型
@@ -185,7 +209,11 @@ func (ctl *InfoController) Capabilities(ctx context.Context, webCtx web.Context,
 		"support_api_keys": ctl.conf.EnableAPIKeys,
 		// 服务状态页
 		"service_status_page": ctl.conf.ServiceStatusPage,
-	})
+		// 当前生效的公告与维护窗口
+		"notices": ctl.noticeSrv.Active(ctx),
+		// 用户通过内测计划获得的内测功能与模型
+		"beta": ctl.betaSrv.Grant(ctx, userID),
+	}
 }
 
 func (ctl *InfoController) loadHomeModels(ctx context.Context, conf *config.Config, client *auth.ClientInfo, user *auth.UserOptional) (enableOpenAI bool, homeModels []HomeModel) {
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// markdownMaxLength 公开渲染接口允许的最大内容长度
+const markdownMaxLength = 20000
+
+// MarkdownController Markdown 渲染，供 Web 分享页面使用，无需登录
+type MarkdownController struct {
+	trans       youdao.Translater        `autowire:"@"`
+	markdownSrv *service.MarkdownService `autowire:"@"`
+	limiter     *rate.RateLimiter        `autowire:"@"`
+}
+
+func NewMarkdownController(resolver infra.Resolver) web.Controller {
+	ctl := MarkdownController{}
+	resolver.MustAutoWire(&ctl)
+	return &ctl
+}
+
+func (ctl *MarkdownController) Register(router web.Router) {
+	router.Group("/markdown", func(router web.Router) {
+		router.Post("/render", ctl.Render)
+	})
+}
+
+// Render 将 Markdown 内容渲染为 HTML
+func (ctl *MarkdownController) Render(ctx context.Context, webCtx web.Context, info *auth.ClientInfo) web.Response {
+	content := webCtx.Input("content")
+	if strings.TrimSpace(content) == "" || utf8.RuneCountInString(content) > markdownMaxLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.limiter.Allow(ctx, fmt.Sprintf("markdown:render:%s:limit", info.IP), rate.MaxRequestsInPeriod(60, time.Minute)); err != nil {
+		if err == rate.ErrRateLimitExceeded {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "操作频率过高，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(ctl.markdownSrv.RenderHTML(ctx, content))
+}
@@ -1,13 +1,31 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
 	"github.com/mylxsw/glacier/infra"
 	"github.com/mylxsw/glacier/web"
 )
 
 type MessageController struct {
-	// repo *repo.Repository `autowire:"@"`
-	// conf *config.Config   `autowire:"@"`
+	trans         youdao.Translater                `autowire:"@"`
+	messageRepo   *repo.MessageRepo                `autowire:"@"`
+	suggestionSrv *service.PromptSuggestionService `autowire:"@"`
+	cardSrv       *service.MessageCardService      `autowire:"@"`
+	markdownSrv   *service.MarkdownService         `autowire:"@"`
+	limiter       *rate.RateLimiter                `autowire:"@"`
 }
 
 func NewMessageController(resolver infra.Resolver) web.Controller {
@@ -18,6 +36,111 @@ func NewMessageController(resolver infra.Resolver) web.Controller {
 
 func (ctl *MessageController) Register(router web.Router) {
 	router.Group("/messages", func(router web.Router) {
-
+		// 对 AI 回复进行评价
+		router.Post("/{id}/rating", ctl.Rate)
+		// 根据 AI 回复生成追问建议
+		router.Get("/{id}/suggestions", ctl.Suggestions)
+		// 将消息导出为分享卡片图片
+		router.Post("/{id}/card", ctl.Card)
+		// 将消息渲染为 HTML
+		router.Get("/{id}/html", ctl.HTML)
 	})
 }
+
+// Rate 对 AI 回复的消息进行评价，rating 取值：1-赞 -1-踩 0-取消评价
+func (ctl *MessageController) Rate(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	messageID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil || messageID <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	rating := webCtx.Int64Input("rating", repo.MessageRatingNone)
+	if rating != repo.MessageRatingLike && rating != repo.MessageRatingDislike && rating != repo.MessageRatingNone {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.messageRepo.RateMessage(ctx, user.ID, int64(messageID), rating); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "message_id": messageID}).Errorf("rate message failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Suggestions 为 AI 回复的消息生成追问建议，用于客户端展示快捷提问，未启用或当前用户不可用时返回空列表
+func (ctl *MessageController) Suggestions(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	messageID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil || messageID <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if !ctl.suggestionSrv.Enabled(user.UserType) {
+		return webCtx.JSON(web.M{"data": []string{}})
+	}
+
+	suggestions, err := ctl.suggestionSrv.Suggest(ctx, user.ID, int64(messageID))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		// 追问建议属于辅助功能，生成失败时不影响客户端正常使用
+		log.F(log.M{"user_id": user.ID, "message_id": messageID}).Errorf("generate prompt suggestions failed: %v", err)
+		return webCtx.JSON(web.M{"data": []string{}})
+	}
+
+	return webCtx.JSON(web.M{"data": suggestions})
+}
+
+// Card 将消息渲染为分享卡片图片，返回带签名的图片地址，with_question 为 true 时 AI 回复与对应的提问一起导出
+func (ctl *MessageController) Card(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	messageID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil || messageID <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	// 图片渲染比较消耗资源，限制导出频率
+	if err := ctl.limiter.Allow(ctx, fmt.Sprintf("message-card:%d:limit", user.ID), rate.MaxRequestsInPeriod(20, time.Minute)); err != nil {
+		if err == rate.ErrRateLimitExceeded {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "操作频率过高，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	res, err := ctl.cardSrv.Render(ctx, user.ID, int64(messageID), webCtx.Input("with_question") == "true")
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "message_id": messageID}).Errorf("render message card failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(res)
+}
+
+// HTML 将消息的 Markdown 内容渲染为 HTML，用于 Web 分享页面嵌入
+func (ctl *MessageController) HTML(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	messageID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil || messageID <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	msg, err := ctl.messageRepo.Message(ctx, user.ID, int64(messageID))
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "message_id": messageID}).Errorf("query message failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(ctl.markdownSrv.RenderHTML(ctx, msg.Message))
+}
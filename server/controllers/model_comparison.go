@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+const (
+	// modelComparisonMinModels 模型对比最少选择的模型数量
+	modelComparisonMinModels = 2
+	// modelComparisonMaxModels 模型对比最多选择的模型数量
+	modelComparisonMaxModels = 4
+)
+
+// ModelComparisonController 模型对比：同一个问题同时发送给多个模型，对比回答内容、耗时以及费用
+type ModelComparisonController struct {
+	conf           *config.Config             `autowire:"@"`
+	chat           chat2.Chat                 `autowire:"@"`
+	translater     youdao.Translater          `autowire:"@"`
+	comparisonRepo *repo2.ModelComparisonRepo `autowire:"@"`
+	quotaRepo      *repo2.QuotaRepo           `autowire:"@"`
+	userSrv        *service2.UserService      `autowire:"@"`
+	betaSrv        *service2.BetaService      `autowire:"@"`
+}
+
+// NewModelComparisonController 创建模型对比控制器
+func NewModelComparisonController(resolver infra.Resolver) web.Controller {
+	ctl := &ModelComparisonController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *ModelComparisonController) Register(router web.Router) {
+	router.Group("/model-comparisons", func(router web.Router) {
+		router.Post("/", ctl.Compare)
+		router.Get("/", ctl.Comparisons)
+		router.Get("/{id}", ctl.Comparison)
+		router.Delete("/{id}", ctl.Delete)
+	})
+}
+
+type ModelComparisonRequest struct {
+	Prompt string   `json:"prompt"`
+	Models []string `json:"models"`
+}
+
+// Compare 并行请求所选的模型，每个模型的调用分别按照正常的聊天请求计费，结果保存后返回
+func (ctl *ModelComparisonController) Compare(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req ModelComparisonRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	req.Prompt = strings.TrimSpace(req.Prompt)
+	if req.Prompt == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "请输入要对比的问题"), http.StatusBadRequest)
+	}
+
+	// 去重时保留模型的选择顺序，结果按照相同的顺序返回
+	models := make([]string, 0, len(req.Models))
+	for _, m := range req.Models {
+		if !array.In(m, models) {
+			models = append(models, m)
+		}
+	}
+
+	req.Models = models
+	if len(req.Models) < modelComparisonMinModels || len(req.Models) > modelComparisonMaxModels {
+		return webCtx.JSONError(
+			fmt.Sprintf(common.Text(webCtx, ctl.translater, "请选择 %d 到 %d 个模型进行对比"), modelComparisonMinModels, modelComparisonMaxModels),
+			http.StatusBadRequest,
+		)
+	}
+
+	// 内测模型只对内测分组成员开放
+	available := array.ToMap(array.Filter(ctl.betaSrv.VisibleModels(ctx, user.ID, chat2.Models(ctl.conf, false)), func(item chat2.Model, _ int) bool {
+		return item.IsChat && !item.IsImage
+	}), func(item chat2.Model, _ int) string { return item.ID })
+
+	// 免费模型不冻结智慧果，其它模型按照输入内容预估费用
+	var needCoins int64
+	requests := make([]chat2.Request, 0, len(req.Models))
+	for _, m := range req.Models {
+		if _, ok := available[m]; !ok {
+			return webCtx.JSONError(fmt.Sprintf(common.Text(webCtx, ctl.translater, "模型 %s 不支持对比"), m), http.StatusBadRequest)
+		}
+
+		fixed, inputTokens, err := chat2.Request{Model: m, Messages: chat2.Messages{{Role: "user", Content: req.Prompt}}}.Init().Fix(ctl.chat, 1)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, err.Error()), http.StatusBadRequest)
+		}
+
+		requests = append(requests, *fixed)
+
+		if leftCount, _ := ctl.userSrv.FreeChatRequestCounts(ctx, user.ID, fixed.Model); leftCount <= 0 {
+			needCoins += coins.GetOpenAITextCoins(fixed.ResolveCalFeeModel(ctl.conf), inputTokens) + 3
+		}
+	}
+
+	if needCoins > 0 {
+		quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		if quota.Rest-quota.Freezed < needCoins {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+		}
+
+		if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, needCoins); err != nil {
+			log.F(log.M{"user_id": user.ID, "quota": needCoins}).Errorf("freeze user quota failed: %s", err)
+		} else {
+			defer func() {
+				if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, needCoins); err != nil {
+					log.F(log.M{"user_id": user.ID, "quota": needCoins}).Errorf("unfreeze user quota failed: %s", err)
+				}
+			}()
+		}
+	}
+
+	results := make([]repo2.ModelComparisonResult, len(requests))
+
+	var wg sync.WaitGroup
+	for i, r := range requests {
+		wg.Add(1)
+		go func(i int, r chat2.Request) {
+			defer wg.Done()
+			results[i] = ctl.compareOne(ctx, user.ID, req.Models[i], r)
+		}(i, r)
+	}
+	wg.Wait()
+
+	id, err := ctl.comparisonRepo.Create(ctx, user.ID, req.Prompt, results)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存模型对比结果失败: %s", err)
+	}
+
+	return webCtx.JSON(web.M{"id": id, "results": results})
+}
+
+// compareOne 请求单个模型并扣除智慧果，请求失败时不计费
+func (ctl *ModelComparisonController) compareOne(ctx context.Context, userID int64, modelID string, req chat2.Request) repo2.ModelComparisonResult {
+	result := repo2.ModelComparisonResult{Model: modelID}
+
+	chatCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	resp, err := ctl.chat.Chat(chatCtx, req)
+	result.Latency = time.Since(startTime).Milliseconds()
+
+	if err == nil && resp.ErrorCode != "" {
+		err = fmt.Errorf("%s %s", resp.ErrorCode, resp.Error)
+	}
+
+	if err != nil {
+		log.F(log.M{"user_id": userID, "model": modelID}).Errorf("模型对比请求失败: %s", err)
+		result.Error = ternary.If(errors.Is(err, chat2.ErrContentFilter), chat2.ErrContentFilter.Error(), "模型请求失败")
+		return result
+	}
+
+	result.Text = resp.Text
+	result.TokenConsumed = int64(resp.InputTokens + resp.OutputTokens)
+	if result.TokenConsumed == 0 {
+		realTokens, _ := chat2.MessageTokenCount(append(req.Messages, chat2.Message{Role: "assistant", Content: resp.Text}), req.Model)
+		result.TokenConsumed = int64(realTokens)
+	}
+
+	if resp.Text == "" {
+		return result
+	}
+
+	// 免费模型与普通聊天一样不扣除智慧果，只计入免费次数
+	if leftCount, _ := ctl.userSrv.FreeChatRequestCounts(ctx, userID, req.Model); leftCount > 0 {
+		if err := ctl.userSrv.UpdateFreeChatCount(ctx, userID, req.Model); err != nil {
+			log.F(log.M{"user_id": userID, "model": req.Model}).Errorf("update free chat count failed: %s", err)
+		}
+
+		return result
+	}
+
+	result.QuotaConsumed = coins.GetOpenAITextCoins(req.ResolveCalFeeModel(ctl.conf), result.TokenConsumed)
+	if result.QuotaConsumed > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, userID, result.QuotaConsumed, repo2.NewQuotaUsedMeta("model_comparison", req.Model)); err != nil {
+			log.F(log.M{"user_id": userID, "quota": result.QuotaConsumed}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	return result
+}
+
+// Comparisons 当前用户的模型对比记录
+func (ctl *ModelComparisonController) Comparisons(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	page := webCtx.Int64Input("page", 1)
+	if page < 1 || page > 1000 {
+		page = 1
+	}
+
+	perPage := webCtx.Int64Input("per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	items, meta, err := ctl.comparisonRepo.Comparisons(ctx, user.ID, page, perPage)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询模型对比记录失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":      items,
+		"page":      meta.Page,
+		"per_page":  meta.PerPage,
+		"total":     meta.Total,
+		"last_page": meta.LastPage,
+	})
+}
+
+// Comparison 查看模型对比详情
+func (ctl *ModelComparisonController) Comparison(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	item, err := ctl.comparisonRepo.Get(ctx, user.ID, int64(id))
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("查询模型对比记录失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": item})
+}
+
+// Delete 删除模型对比记录
+func (ctl *ModelComparisonController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.comparisonRepo.Delete(ctx, user.ID, int64(id)); err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("删除模型对比记录失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"context"
+
 	"github.com/mylxsw/aidea-server/pkg/ai/chat"
 	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/service"
 
 	"github.com/mylxsw/aidea-server/config"
 	"github.com/mylxsw/aidea-server/server/auth"
@@ -26,10 +29,29 @@ func (ctl *ModelController) Register(router web.Router) {
 	})
 }
 
-// Models 获取模型列表
-func (ctl *ModelController) Models(ctx web.Context, client *auth.ClientInfo, user *auth.UserOptional) web.Response {
+// Models 获取模型列表，已登录用户同时返回每个模型剩余的免费试用次数
+func (ctl *ModelController) Models(ctx context.Context, webCtx web.Context, client *auth.ClientInfo, user *auth.UserOptional, trialSrv *service.ModelTrialService, betaSrv *service.BetaService) web.Response {
+	if user.User == nil {
+		return webCtx.JSON(betaSrv.VisibleModels(ctx, 0, clientModels(ctl.conf, client, user)))
+	}
+
+	models := betaSrv.VisibleModels(ctx, user.User.ID, clientModels(ctl.conf, client, user))
+
+	trials := trialSrv.LeftByModels(ctx, user.User.ID)
+	if len(trials) == 0 {
+		return webCtx.JSON(models)
+	}
+
+	return webCtx.JSON(array.Map(models, func(item chat.Model, _ int) chat.Model {
+		item.TrialLeft = trials.Of(item.ID)
+		return item
+	}))
+}
+
+// clientModels 返回客户端可见的模型列表，1.0.6 之后的版本返回全部模型，不可用的模型标记为禁用
+func clientModels(conf *config.Config, client *auth.ClientInfo, user *auth.UserOptional) []chat.Model {
 	if client.Version == "" || misc.VersionNewer(client.Version, "1.0.6") {
-		models := array.Map(chat.Models(ctl.conf, true), func(item chat.Model, _ int) chat.Model {
+		return array.Map(chat.Models(conf, true), func(item chat.Model, _ int) chat.Model {
 			if item.Disabled {
 				return item
 			}
@@ -44,18 +66,16 @@ func (ctl *ModelController) Models(ctx web.Context, client *auth.ClientInfo, use
 				return item
 			}
 
-			if client.IsCNLocalMode(ctl.conf) && item.IsSensitiveModel() && (user.User == nil || !user.User.ExtraPermissionUser()) {
+			if client.IsCNLocalMode(conf) && item.IsSensitiveModel() && (user.User == nil || !user.User.ExtraPermissionUser()) {
 				item.Disabled = true
 				return item
 			}
 
 			return item
 		})
-
-		return ctx.JSON(models)
 	}
 
-	models := array.Filter(chat.Models(ctl.conf, false), func(item chat.Model, _ int) bool {
+	return array.Filter(chat.Models(conf, false), func(item chat.Model, _ int) bool {
 		if item.VersionMin != "" && misc.VersionOlder(client.Version, item.VersionMin) {
 			return false
 		}
@@ -64,8 +84,6 @@ func (ctl *ModelController) Models(ctx web.Context, client *auth.ClientInfo, use
 			return false
 		}
 
-		return !(client.IsCNLocalMode(ctl.conf) && item.IsSensitiveModel() && (user.User == nil || !user.User.ExtraPermissionUser()))
+		return !(client.IsCNLocalMode(conf) && item.IsSensitiveModel() && (user.User == nil || !user.User.ExtraPermissionUser()))
 	})
-
-	return ctx.JSON(models)
 }
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/moonshot"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// moonshotMaxFileSize Moonshot 文档问答单个文件最大支持 20MB
+const moonshotMaxFileSize = 20 * 1024 * 1024
+
+// MoonshotController Moonshot 文档问答，将文件转交给 Moonshot 抽取内容，对话时通过 file_id 引用
+type MoonshotController struct {
+	conf       *config.Config
+	ms         *moonshot.Moonshot `autowire:"@"`
+	translater youdao.Translater  `autowire:"@"`
+}
+
+// NewMoonshotController 创建 Moonshot 文档问答控制器
+func NewMoonshotController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &MoonshotController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *MoonshotController) Register(router web.Router) {
+	router.Group("/moonshot", func(router web.Router) {
+		router.Post("/files", ctl.UploadFile)
+	})
+}
+
+type MoonshotUploadRequest struct {
+	// URL 客户端上传到存储后的文件地址
+	URL string `json:"url"`
+	// Name 文件名称，Moonshot 根据扩展名识别文件类型
+	Name string `json:"name"`
+}
+
+// UploadFile 上传文档到 Moonshot，返回的 file_id 可以在对话消息中以 type=file 的方式引用
+func (ctl *MoonshotController) UploadFile(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if !ctl.conf.EnableMoonshot {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var req MoonshotUploadRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 只允许下载客户端上传到存储中的文件，避免服务端被用于访问内网地址
+	storageDomain := strings.TrimSuffix(ctl.conf.StorageDomain, "/")
+	if storageDomain == "" || !strings.HasPrefix(req.URL, storageDomain+"/") {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if req.Name == "" {
+		req.Name = path.Base(req.URL)
+	}
+
+	savePath, err := uploader.DownloadRemoteFileWithLimit(ctx, req.URL, moonshotMaxFileSize)
+	if err != nil {
+		if errors.Is(err, uploader.ErrFileTooLarge) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrFileTooLarge), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "url": req.URL}).Errorf("下载文档失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件下载失败"), http.StatusBadRequest)
+	}
+	defer os.Remove(savePath)
+
+	data, err := os.ReadFile(savePath)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "url": req.URL}).Errorf("读取文档失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	file, err := ctl.ms.UploadFile(ctx, req.Name, data)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "url": req.URL}).Errorf("上传文档到 Moonshot 失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文档解析失败"), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"file_id":  file.ID,
+		"filename": file.Filename,
+		"bytes":    file.Bytes,
+	})
+}
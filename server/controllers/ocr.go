@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/pkg/ocr"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// OCRController 文字识别，用户拍摄的文档图片识别为文本后可以直接用于聊天
+type OCRController struct {
+	conf       *config.Config
+	ocr        *ocr.OCR              `autowire:"@"`
+	translater youdao.Translater     `autowire:"@"`
+	ocrRepo    *repo2.OCRRepo        `autowire:"@"`
+	quotaRepo  *repo2.QuotaRepo      `autowire:"@"`
+	userSrv    *service2.UserService `autowire:"@"`
+}
+
+// NewOCRController 创建文字识别控制器
+func NewOCRController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &OCRController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *OCRController) Register(router web.Router) {
+	router.Group("/ocr", func(router web.Router) {
+		router.Post("/", ctl.Recognize)
+		router.Get("/", ctl.Histories)
+		router.Get("/{id}", ctl.Result)
+	})
+}
+
+type OCRRequest struct {
+	// Images 待识别的图片地址，每张图片为一页
+	Images []string `json:"images"`
+}
+
+// OCRPageResult 单页识别结果
+type OCRPageResult struct {
+	Image      string     `json:"image"`
+	Text       string     `json:"text"`
+	Confidence float64    `json:"confidence"`
+	Lines      []ocr.Line `json:"lines,omitempty"`
+}
+
+// Recognize 识别图片中的文字，每张图片作为一页计费
+func (ctl *OCRController) Recognize(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if ctl.ocr.Engine() == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文字识别功能暂未开放"), http.StatusServiceUnavailable)
+	}
+
+	var req OCRRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	images := array.Filter(
+		array.Map(req.Images, func(item string, _ int) string { return strings.TrimSpace(item) }),
+		func(item string, _ int) bool { return item != "" },
+	)
+	if len(images) == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if ctl.conf.OCRMaxPages > 0 && len(images) > ctl.conf.OCRMaxPages {
+		return webCtx.JSONError(fmt.Sprintf(common.Text(webCtx, ctl.translater, "单次最多识别 %d 页"), ctl.conf.OCRMaxPages), http.StatusBadRequest)
+	}
+
+	// 只允许识别客户端上传到存储中的图片，避免服务端被用于访问内网地址
+	storageDomain := strings.TrimSuffix(ctl.conf.StorageDomain, "/")
+	for _, img := range images {
+		if storageDomain == "" || !strings.HasPrefix(img, storageDomain+"/") {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+	}
+
+	estimated := coins.GetOCRCoins(len(images))
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < estimated {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, estimated); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("freeze user quota failed: %s", err)
+	} else {
+		defer func(ctx context.Context) {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, estimated); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("unfreeze user quota failed: %s", err)
+			}
+		}(ctx)
+	}
+
+	pages := make([]OCRPageResult, 0, len(images))
+	for _, img := range images {
+		page, err := ctl.ocr.RecognizeURL(ctx, img)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID, "image": img, "engine": ctl.ocr.Engine()}).Errorf("文字识别失败: %s", err)
+			// 已经识别成功的页面依然计费并保存，失败的页面不计费
+			if len(pages) == 0 {
+				return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文字识别失败，请稍后再试"), http.StatusInternalServerError)
+			}
+
+			break
+		}
+
+		pages = append(pages, OCRPageResult{
+			Image:      img,
+			Text:       page.Text(),
+			Confidence: page.Confidence(),
+			Lines:      page.Lines,
+		})
+	}
+
+	consumed := coins.GetOCRCoins(len(pages))
+	if consumed > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, consumed, repo2.NewQuotaUsedMeta("ocr", ctl.ocr.Engine())); err != nil {
+			log.F(log.M{"user_id": user.ID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	var confidence float64
+	for _, p := range pages {
+		confidence += p.Confidence
+	}
+	confidence = confidence / float64(len(pages))
+
+	text := strings.Join(array.Map(pages, func(item OCRPageResult, _ int) string { return item.Text }), "\n\n")
+	imagesData, _ := json.Marshal(images[:len(pages)])
+	resultData, _ := json.Marshal(pages)
+
+	id, err := ctl.ocrRepo.AddResult(ctx, repo2.OCRResultAddReq{
+		UserID:     user.ID,
+		Engine:     ctl.ocr.Engine(),
+		Pages:      int64(len(pages)),
+		Images:     string(imagesData),
+		Text:       text,
+		Result:     string(resultData),
+		Confidence: int64(math.Round(confidence * 100)),
+		Coins:      consumed,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存文字识别结果失败: %s", err)
+	}
+
+	return webCtx.JSON(web.M{
+		"id":         id,
+		"text":       text,
+		"pages":      pages,
+		"confidence": confidence,
+		"coins":      consumed,
+		"failed":     len(images) - len(pages),
+	})
+}
+
+// Histories 用户最近的文字识别记录
+func (ctl *OCRController) Histories(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	items, err := ctl.ocrRepo.GetResults(ctx, user.ID, 100)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询文字识别记录失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items})
+}
+
+// Result 查询单次文字识别结果
+func (ctl *OCRController) Result(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	res, err := ctl.ocrRepo.GetResult(ctx, user.ID, int64(id))
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("查询文字识别结果失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	var pages []OCRPageResult
+	_ = json.Unmarshal([]byte(res.Result), &pages)
+
+	return webCtx.JSON(web.M{
+		"id":         res.Id,
+		"engine":     res.Engine,
+		"text":       res.Text,
+		"pages":      pages,
+		"confidence": float64(res.Confidence) / 100,
+		"coins":      res.Coins,
+		"created_at": res.CreatedAt,
+	})
+}
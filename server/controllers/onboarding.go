@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// OnboardingController 新用户引导：引导步骤、示例提示语与推荐模型由服务端下发，无需发布新版本即可调整首次使用体验
+type OnboardingController struct {
+	conf           *config.Config              `autowire:"@"`
+	translater     youdao.Translater           `autowire:"@"`
+	onboardingRepo *repo2.OnboardingRepo       `autowire:"@"`
+	onboardingSrv  *service2.OnboardingService `autowire:"@"`
+	betaSrv        *service2.BetaService       `autowire:"@"`
+}
+
+// NewOnboardingController 创建新用户引导控制器
+func NewOnboardingController(resolver infra.Resolver) web.Controller {
+	ctl := &OnboardingController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *OnboardingController) Register(router web.Router) {
+	router.Group("/onboarding", func(router web.Router) {
+		router.Get("/", ctl.Onboarding)
+		router.Post("/progress", ctl.Complete)
+	})
+}
+
+// onboardingModel 推荐模型，附带当前客户端可用的模型信息
+type onboardingModel struct {
+	repo2.OnboardingItem
+	Model chat.Model `json:"model"`
+}
+
+// Onboarding 当前语言的引导内容，登录用户还包括已经完成的引导步骤
+// 请求参数：
+// - persona: 用户选择的角色，可选，为空时返回全部角色的示例提示语
+func (ctl *OnboardingController) Onboarding(ctx context.Context, webCtx web.Context, user *auth.UserOptional, client *auth.ClientInfo) web.Response {
+	content := ctl.onboardingSrv.Content(ctx, common.GetLanguage(webCtx), webCtx.Input("persona"))
+
+	var userID int64
+	if user.User != nil {
+		userID = user.User.ID
+	}
+
+	// 推荐模型只返回当前客户端可以使用的模型，内测模型只推荐给内测分组成员
+	available := array.ToMap(
+		array.Filter(ctl.betaSrv.VisibleModels(ctx, userID, clientModels(ctl.conf, client, user)), func(item chat.Model, _ int) bool { return !item.Disabled }),
+		func(item chat.Model, _ int) string { return item.ID },
+	)
+
+	models := make([]onboardingModel, 0, len(content.Models))
+	for _, item := range content.Models {
+		if m, ok := available[item.Payload]; ok {
+			models = append(models, onboardingModel{OnboardingItem: item, Model: m})
+		}
+	}
+
+	res := web.M{
+		"steps":    content.Steps,
+		"prompts":  content.Prompts,
+		"models":   models,
+		"personas": content.Personas,
+	}
+
+	if user.User == nil {
+		return webCtx.JSON(res)
+	}
+
+	completed, err := ctl.onboardingRepo.CompletedSteps(ctx, user.User.ID)
+	if err != nil {
+		// 进度加载失败时不影响引导内容的返回
+		log.F(log.M{"user_id": user.User.ID}).Errorf("查询新用户引导进度失败: %v", err)
+		return webCtx.JSON(res)
+	}
+
+	stepIDs := array.Map(content.Steps, func(item repo2.OnboardingItem, _ int) int64 { return item.ID })
+	completedSteps := array.Filter(stepIDs, func(id int64, _ int) bool { return array.In(id, completed) })
+
+	res["completed_steps"] = completedSteps
+	res["completed"] = len(completedSteps) == len(stepIDs)
+
+	return webCtx.JSON(res)
+}
+
+// Complete 记录用户完成的引导步骤
+// 请求参数：
+// - step_id: 完成的引导步骤 ID
+// - skip: 跳过引导，当前语言的全部引导步骤都记录为已完成
+func (ctl *OnboardingController) Complete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var stepIDs []int64
+	if webCtx.Input("skip") == "true" {
+		content := ctl.onboardingSrv.Content(ctx, common.GetLanguage(webCtx), "")
+		stepIDs = array.Map(content.Steps, func(item repo2.OnboardingItem, _ int) int64 { return item.ID })
+	} else {
+		stepID := webCtx.Int64Input("step_id", 0)
+		if !ctl.onboardingSrv.IsStep(ctx, stepID) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		stepIDs = []int64{stepID}
+	}
+
+	if err := ctl.onboardingRepo.CompleteSteps(ctx, user.ID, stepIDs); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("记录新用户引导进度失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
@@ -9,11 +9,13 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/ai/control"
 	openaiHelper "github.com/mylxsw/aidea-server/pkg/ai/openai"
 	"github.com/mylxsw/aidea-server/pkg/ai/streamwriter"
+	"github.com/mylxsw/aidea-server/pkg/jsonschema"
 	"github.com/mylxsw/aidea-server/pkg/misc"
 	"github.com/mylxsw/aidea-server/pkg/rate"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	service2 "github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/aidea-server/pkg/tencent"
+	"github.com/mylxsw/aidea-server/pkg/trace"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"net/http"
 	"os"
@@ -37,16 +39,28 @@ import (
 
 // OpenAIController OpenAI 控制器
 type OpenAIController struct {
-	conf        *config.Config
-	chat        chat2.Chat                `autowire:"@"`
-	client      openaiHelper.Client       `autowire:"@"`
-	translater  youdao.Translater         `autowire:"@"`
-	tencent     *tencent.Tencent          `autowire:"@"`
-	messageRepo *repo2.MessageRepo        `autowire:"@"`
-	securitySrv *service2.SecurityService `autowire:"@"`
-	userSrv     *service2.UserService     `autowire:"@"`
-	chatSrv     *service2.ChatService     `autowire:"@"`
-	limiter     *rate.RateLimiter         `autowire:"@"`
+	conf           *config.Config
+	chat           chat2.Chat                      `autowire:"@"`
+	client         openaiHelper.Client             `autowire:"@"`
+	translater     youdao.Translater               `autowire:"@"`
+	tencent        *tencent.Tencent                `autowire:"@"`
+	messageRepo    *repo2.MessageRepo              `autowire:"@"`
+	securitySrv    *service2.SecurityService       `autowire:"@"`
+	userSrv        *service2.UserService           `autowire:"@"`
+	chatSrv        *service2.ChatService           `autowire:"@"`
+	orgPolicy      *service2.OrgPolicyService      `autowire:"@"`
+	restrictedSrv  *service2.RestrictedModeService `autowire:"@"`
+	keywordFilter  *service2.KeywordFilterService  `autowire:"@"`
+	abuseSrv       *service2.AbuseDetectService    `autowire:"@"`
+	geoPolicy      *service2.GeoPolicyService      `autowire:"@"`
+	routingRule    *service2.RoutingRuleService    `autowire:"@"`
+	modelCost      *service2.ModelCostService      `autowire:"@"`
+	promptVariable *service2.PromptVariableService `autowire:"@"`
+	quotaRefund    *service2.QuotaRefundService    `autowire:"@"`
+	modelTrial     *service2.ModelTrialService     `autowire:"@"`
+	glossary       *service2.GlossaryService       `autowire:"@"`
+	limiter        *rate.RateLimiter               `autowire:"@"`
+	betaSrv        *service2.BetaService           `autowire:"@"`
 
 	upgrader websocket.Upgrader
 
@@ -86,7 +100,11 @@ func (ctl *OpenAIController) Register(router web.Router) {
 
 // audioTranscriptions 语音转文本
 // https://platform.openai.com/docs/api-reference/audio/createTranscription
-func (ctl *OpenAIController) audioTranscriptions(ctx context.Context, webCtx web.Context, user *auth.User, quotaRepo *repo2.QuotaRepo) web.Response {
+func (ctl *OpenAIController) audioTranscriptions(ctx context.Context, webCtx web.Context, user *auth.User, quotaRepo *repo2.QuotaRepo, client *auth.ClientInfo) web.Response {
+	if ctl.geoPolicy.Decide(ctx, client.Region, repo2.GeoFeatureVoice, "").Blocked {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrRegionRestricted), http.StatusUnavailableForLegalReasons)
+	}
+
 	// TODO 增加客户端控制语音转文本的参数：model/file/language/prompt/response_format/temperature
 	model := ternary.If(ctl.conf.UseTencentVoiceToText, "tencent", "whisper-1")
 
@@ -205,6 +223,32 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 	}
 	defer sw.Close()
 
+	// 展开提示语中的变量，如 {{date}}、{{user_name}}、{{memory}} 以及用户自定义的变量
+	ctl.expandPromptVariables(ctx, user, req)
+
+	// 用户自定义的全局指令，API 模式下由调用方自行控制系统提示语
+	if !ctl.apiMode {
+		ctl.applyPersona(ctx, user, req)
+
+		// 要求模型使用用户提问的语言（或者会话设置的语言）回复
+		if ctl.conf.EnableReplyLanguage && len(req.Messages) > 0 {
+			lang := ctl.chatSrv.ReplyLanguage(ctx, user.ID, req.RoomID, req.Messages[len(req.Messages)-1].Content)
+			req.Messages = service2.ApplyReplyLanguage(req.Messages, lang)
+		}
+
+		// 用户术语表，请求时可通过 glossary=false 关闭
+		if webCtx.Input("glossary") != "false" {
+			contents := make([]string, 0, len(req.Messages))
+			for _, msg := range req.Messages {
+				if msg.Role != "system" {
+					contents = append(contents, msg.Content)
+				}
+			}
+
+			req.Messages = service2.ApplyGlossary(req.Messages, ctl.glossary.Match(ctx, user.ID, contents...))
+		}
+	}
+
 	// 请求参数预处理
 	var inputTokenCount, maxContextLen int64
 
@@ -234,6 +278,50 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 		return
 	}
 
+	// 结构化输出，schema 为空表示输出普通文本
+	schema, err := req.ParseResponseFormat()
+	if err != nil {
+		misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, err.Error())), http.StatusBadRequest))
+		return
+	}
+
+	// 内测中的模型只对包含该模型的内测分组成员开放
+	if !ctl.betaSrv.ModelAllowed(ctx, user.ID, req.Model) {
+		misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, "该模型正在内测中，加入内测计划后即可使用")), http.StatusForbidden))
+		return
+	}
+
+	// 地区访问策略，根据请求来源地区限制功能的使用，并将请求路由到该地区合规的模型
+	geoDecision := ctl.geoPolicy.Decide(ctx, ternary.IfLazy(client != nil, func() string { return client.Region }, func() string { return "" }), repo2.GeoFeatureChat, req.Model)
+	if geoDecision.Blocked {
+		misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, common.ErrRegionRestricted)), http.StatusUnavailableForLegalReasons))
+		return
+	}
+
+	req.Model = geoDecision.Model
+
+	// 管理员配置的模型路由规则，例如长上下文请求使用长上下文模型，免费用户使用低成本模型
+	routingDecision := ctl.routingRule.Decide(ctx, user.ID, service2.RoutingInput{
+		Model:        req.Model,
+		PromptTokens: inputTokenCount,
+		Region:       ternary.IfLazy(client != nil, func() string { return client.Region }, func() string { return "" }),
+	})
+	req.Model = routingDecision.Model
+	common.SetUpstreamModel(webCtx, req.Model)
+
+	// 组织策略与受限模式检查
+	if err := ctl.policyPass(ctx, webCtx, user, req, sw); err != nil {
+		return
+	}
+
+	// 部署方自定义的关键词过滤
+	if err := ctl.keywordFilterPass(ctx, user, req, sw); err != nil {
+		return
+	}
+
+	// 异常使用行为检测
+	ctl.abuseSrv.Record(ctx, user.ID, ternary.IfLazy(client != nil, func() string { return client.IP }, func() string { return "" }), req.Messages[len(req.Messages)-1].Content)
+
 	// 基于模型的流控，避免单一模型用户过度使用
 	if err := ctl.rateLimitPass(ctx, user, req, sw); err != nil {
 		return
@@ -242,7 +330,20 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 	// 免费模型
 	// 获取当前用户剩余的智慧果数量，如果不足，则返回错误
 	leftCount, maxFreeCount := ctl.userSrv.FreeChatRequestCounts(ctx, user.ID, req.Model)
-	if leftCount <= 0 {
+
+	// 高级模型试用，试用次数用完之后才扣除智慧果
+	var trialConsumed bool
+	trial := leftCount <= 0 && ctl.modelTrial.Acquire(ctx, user.ID, req.Model)
+	if trial {
+		defer func(ctx context.Context) {
+			// 没有产生有效回复时退还试用次数
+			if !trialConsumed {
+				ctl.modelTrial.Release(ctx, user.ID, req.Model)
+			}
+		}(ctx)
+	}
+
+	if leftCount <= 0 && !trial {
 		quota, needCoins, err := ctl.queryChatQuota(ctx, quotaRepo, user, sw, webCtx, req, inputTokenCount, maxFreeCount)
 		if err != nil {
 			return
@@ -259,6 +360,17 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 			return
 		}
 
+		// 预计消耗超过单次请求上限时需要用户确认（confirm_spend=true），避免误操作消耗大量智慧果，API 模式下不检查
+		if !ctl.apiMode && webCtx.Input("confirm_spend") != "true" {
+			if limit := ctl.userSrv.SpendLimit(ctx, user.ID); limit > 0 && needCoins > limit {
+				misc.NoError(sw.WriteErrorStream(
+					fmt.Errorf(common.Text(webCtx, ctl.translater, "本次请求预计消耗 %d 个智慧果，超过单次请求上限 %d，请确认后重试"), needCoins, limit),
+					http.StatusPreconditionRequired,
+				))
+				return
+			}
+		}
+
 		// 冻结本次所需要的智慧果
 		if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, needCoins); err != nil {
 			log.F(log.M{"user_id": user.ID, "quota": needCoins}).Errorf("freeze user quota failed: %s", err)
@@ -287,6 +399,8 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 			"client":  client,
 			"room_id": req.RoomID,
 			"elapse":  time.Since(startTime).Seconds(),
+			"geo":     geoDecision,
+			"routing": routingDecision,
 		}).
 			Infof(
 				"接收到聊天请求，模型 %s, 上下文消息数量 %d, 输入 token 数量 %d，总计 token 数量 %d",
@@ -301,15 +415,22 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 	questionID := ctl.saveChatQuestion(ctx, user, req)
 
 	// 发起聊天请求并返回 SSE/WS 流
-	replyText, err := ctl.handleChat(ctx, req, user, sw, webCtx, questionID, 0)
+	var replyText string
+	var structured json.RawMessage
+	if schema != nil {
+		replyText, structured, err = ctl.handleStructuredChat(ctx, req, schema, user, sw, webCtx, questionID)
+	} else {
+		replyText, err = ctl.handleChat(ctx, req, user, sw, webCtx, questionID, 0)
+	}
+
 	if errors.Is(err, ErrChatResponseHasSent) {
 		return
 	}
 
-	// 以下两种情况再次尝试
+	// 以下两种情况再次尝试（结构化输出在 handleStructuredChat 中自行重试）
 	// 1. 聊天响应为空
 	// 2. 两次响应之间等待时间过长，强制中断，同时响应为空
-	if errors.Is(err, ErrChatResponseEmpty) || (errors.Is(err, ErrChatResponseGapTimeout) && replyText == "") {
+	if schema == nil && (errors.Is(err, ErrChatResponseEmpty) || (errors.Is(err, ErrChatResponseGapTimeout) && replyText == "")) {
 		// 如果用户等待时间超过 60s，则不再重试，避免用户等待时间过长
 		if startTime.Add(60 * time.Second).After(time.Now()) {
 			log.F(log.M{"req": req, "user_id": user.ID}).Warningf("聊天响应为空，尝试再次请求，模型：%s", req.Model)
@@ -321,21 +442,34 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 		}
 	}
 
+	// 上游服务在输出过程中失败（异常中断或者长时间无响应），已经输出的内容正常返回，扣费后自动退还未消费的部分
+	upstreamFailed := replyText != "" && (errors.Is(err, ErrChatUpstreamInterrupted) || errors.Is(err, ErrChatResponseGapTimeout))
+	upstreamFailedReason := ternary.IfLazy(err == nil, func() string { return "" }, func() string { return err.Error() })
+	if errors.Is(err, ErrChatUpstreamInterrupted) {
+		err = nil
+	}
+
 	chatErrorMessage := ternary.IfLazy(err == nil, func() string { return "" }, func() string { return err.Error() })
 	if chatErrorMessage != "" {
 		log.F(log.M{"req": req, "user_id": user.ID, "reply": replyText, "elapse": time.Since(startTime).Seconds()}).
 			Errorf("聊天失败，模型：%s，错误：%s", req.Model, chatErrorMessage)
 	}
 
+	// 模型输出的关键词过滤由聊天客户端统一执行，这里只对跨分片的关键词打码后再保存
+	if replyText != "" {
+		replyText = ctl.keywordFilter.Apply(ctx, repo2.KeywordFilterScopeOutput, replyText).Content
+	}
+
 	// 返回自定义控制信息，告诉客户端当前消耗情况
-	realTokenConsumed, quotaConsumed = ctl.resolveConsumeQuota(req, replyText, leftCount > 0)
+	realTokenConsumed, quotaConsumed = ctl.resolveConsumeQuota(req, replyText, leftCount > 0 || trial)
+	trialConsumed = trial && replyText != ""
 
 	func() {
 		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
 
 		// 写入用户消息
-		answerID := ctl.saveChatAnswer(ctx, user, replyText, quotaConsumed, realTokenConsumed, req, questionID, chatErrorMessage)
+		answerID := ctl.saveChatAnswer(ctx, user, replyText, string(structured), quotaConsumed, realTokenConsumed, req, questionID, chatErrorMessage)
 
 		if errors.Is(ErrChatResponseEmpty, err) {
 			misc.NoError(sw.WriteErrorStream(err, http.StatusInternalServerError))
@@ -364,6 +498,7 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 	}
 
 	// 扣除智慧果
+	var quotaRefunded int64
 	if leftCount <= 0 && quotaConsumed > 0 {
 		func() {
 			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -371,7 +506,23 @@ func (ctl *OpenAIController) Chat(ctx context.Context, webCtx web.Context, user
 
 			if err := quotaRepo.QuotaConsume(ctx, user.ID, quotaConsumed, repo2.NewQuotaUsedMeta("chat", req.Model)); err != nil {
 				log.Errorf("used quota add failed: %s", err)
+				return
 			}
+
+			if upstreamFailed {
+				quotaRefunded = ctl.quotaRefund.RefundUpstreamFailure(ctx, user.ID, req.ResolveCalFeeModel(ctl.conf), inputTokenCount, int64(realTokenConsumed), quotaConsumed, upstreamFailedReason)
+			}
+		}()
+	}
+
+	// 记录上游成本，免费请求同样会产生上游成本
+	if replyText != "" {
+		func() {
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			outputTokens := int64(realTokenConsumed) - inputTokenCount
+			ctl.modelCost.Record(ctx, req.ResolveCalFeeModel(ctl.conf), inputTokenCount, ternary.If(outputTokens > 0, outputTokens, 0), quotaConsumed-quotaRefunded)
 		}()
 	}
 }
@@ -404,13 +555,23 @@ func (ctl *OpenAIController) handleChat(
 			return "", ErrChatResponseHasSent
 		}
 
-		log.WithFields(log.Fields{"req": req, "user_id": user.ID, "retry_times": retryTimes}).Errorf("聊天请求失败，模型 %s: %v", req.Model, err)
+		// 模型已被管理员禁用
+		if errors.Is(err, chat2.ErrModelDisabled) {
+			misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, err.Error())), http.StatusBadRequest))
+			return "", ErrChatResponseHasSent
+		}
+
+		trace.F(ctx, log.M{"req": req, "user_id": user.ID, "model": req.Model, "retry_times": retryTimes}).Errorf("聊天请求失败，模型 %s: %v", req.Model, err)
 
 		misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, common.ErrInternalError)), http.StatusInternalServerError))
 		return "", ErrChatResponseHasSent
 	}
 
 	replyText, err := ctl.writeChatResponse(ctx, req, stream, user, sw)
+	if errors.Is(err, ErrChatUpstreamInterrupted) && strings.TrimSpace(replyText) == "" {
+		return "", ErrChatResponseEmpty
+	}
+
 	if err != nil {
 		return replyText, err
 	}
@@ -424,12 +585,137 @@ func (ctl *OpenAIController) handleChat(
 	return replyText, nil
 }
 
+// expandPromptVariables 展开请求消息中引用的提示语变量，展开失败时保持原样
+func (ctl *OpenAIController) expandPromptVariables(ctx context.Context, user *auth.User, req *chat2.Request) {
+	texts := make([]*string, 0, len(req.Messages))
+	for i := range req.Messages {
+		texts = append(texts, &req.Messages[i].Content)
+		for _, part := range req.Messages[i].MultipartContents {
+			if part != nil && part.Type == "text" {
+				texts = append(texts, &part.Text)
+			}
+		}
+	}
+
+	if err := ctl.promptVariable.Expand(ctx, user.ID, user.Name, texts...); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("expand prompt variables failed: %v", err)
+	}
+}
+
+// applyPersona 将用户的全局指令添加到对话中，查询失败时不影响对话
+func (ctl *OpenAIController) applyPersona(ctx context.Context, user *auth.User, req *chat2.Request) {
+	persona, err := ctl.userSrv.Persona(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("query user persona failed: %v", err)
+		return
+	}
+
+	req.Messages = service2.ApplyPersona(req.Messages, persona)
+}
+
+// structuredChatMaxRetries 结构化输出校验失败时的最大重试次数
+const structuredChatMaxRetries = 2
+
+// handleStructuredChat 结构化输出聊天，模型的输出通过 JSON Schema 校验后才会一次性返回给客户端，
+// 校验失败时将失败原因反馈给模型重新生成，重试产生的消耗不向用户收取
+func (ctl *OpenAIController) handleStructuredChat(
+	ctx context.Context,
+	req *chat2.Request,
+	schema *jsonschema.Schema,
+	user *auth.User,
+	sw *streamwriter.StreamWriter,
+	webCtx web.Context,
+	questionID int64,
+) (string, json.RawMessage, error) {
+	messages := req.Messages
+
+	var lastErr error
+	for i := 0; i <= structuredChatMaxRetries; i++ {
+		attempt := *req
+		attempt.Messages = messages
+
+		chatCtx, cancel := context.WithTimeout(ctx, 180*time.Second)
+		res, err := ctl.chat.Chat(chatCtx, attempt)
+		cancel()
+
+		if err != nil {
+			ctl.makeChatQuestionFailed(ctx, questionID, err)
+
+			if errors.Is(err, chat2.ErrContentFilter) {
+				ctl.sendViolateContentPolicyResp(sw, "")
+				return "", nil, ErrChatResponseHasSent
+			}
+
+			log.WithFields(log.Fields{"req": req, "user_id": user.ID, "retry_times": i}).Errorf("结构化输出聊天请求失败，模型 %s: %v", req.Model, err)
+
+			misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, common.ErrInternalError)), http.StatusInternalServerError))
+			return "", nil, ErrChatResponseHasSent
+		}
+
+		replyText := strings.TrimSpace(res.Text)
+		structured, err := chat2.ExtractJSON(replyText, schema)
+		if err == nil {
+			resp := ChatCompletionStreamResponse{
+				ID:      "1",
+				Created: time.Now().Unix(),
+				Model:   req.Model,
+				Object:  "chat.completion",
+				Choices: []ChatCompletionStreamChoice{
+					{Delta: ChatCompletionStreamChoiceDelta{Role: "assistant", Content: string(structured)}},
+				},
+			}
+
+			if err := sw.WriteStream(resp); err != nil {
+				log.F(log.M{"req": req, "user_id": user.ID}).Warningf("write response failed: %v", err)
+			}
+
+			return replyText, structured, nil
+		}
+
+		lastErr = err
+		log.F(log.M{"user_id": user.ID, "retry_times": i, "reply": replyText}).Warningf("结构化输出校验失败，模型 %s: %v", req.Model, err)
+
+		messages = append(
+			append(chat2.Messages{}, messages...),
+			chat2.Message{Role: "assistant", Content: replyText},
+			chat2.Message{Role: "user", Content: fmt.Sprintf("你的输出不符合要求：%v。请重新输出，只输出符合要求的 JSON。", err)},
+		)
+	}
+
+	ctl.makeChatQuestionFailed(ctx, questionID, lastErr)
+	misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, chat2.ErrInvalidJSONResponse.Error())), http.StatusUnprocessableEntity))
+	return "", nil, ErrChatResponseHasSent
+}
+
 var (
 	ErrChatResponseEmpty      = errors.New("聊天响应为空")
 	ErrChatResponseHasSent    = errors.New("聊天响应已经发送")
 	ErrChatResponseGapTimeout = errors.New("两次响应之间等待时间过长，强制中断")
+	// ErrChatUpstreamInterrupted 上游服务在输出过程中返回了错误
+	ErrChatUpstreamInterrupted = errors.New("上游服务输出过程中出现错误")
 )
 
+// chatModelLoadingWait 自托管模型加载期间等待首个响应的最长时间，超过聊天请求的超时时间时以聊天请求的超时时间为准
+const chatModelLoadingWait = 180 * time.Second
+
+// buildChatNoticeMessage 构建提示消息，该消息为系统消息，用于在模型输出之前告诉 AIdea 客户端当前的处理状态
+func buildChatNoticeMessage(req *chat2.Request, info string) ChatCompletionStreamResponse {
+	return ChatCompletionStreamResponse{
+		ID:      "notice",
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Choices: []ChatCompletionStreamChoice{
+			{
+				Delta: ChatCompletionStreamChoiceDelta{
+					Content: FinalMessage{Type: "notice", Info: info}.ToJSON(),
+					Role:    "system",
+				},
+			},
+		},
+		Model: req.Model,
+	}
+}
+
 func (ctl *OpenAIController) writeChatResponse(ctx context.Context, req *chat2.Request, stream <-chan chat2.Response, user *auth.User, sw *streamwriter.StreamWriter) (string, error) {
 	var replyText string
 
@@ -438,6 +724,7 @@ func (ctl *OpenAIController) writeChatResponse(ctx context.Context, req *chat2.R
 	defer timer.Stop()
 
 	id := 0
+	var upstreamErr error
 	for {
 		if id > 0 {
 			timer.Reset(30 * time.Second)
@@ -450,18 +737,36 @@ func (ctl *OpenAIController) writeChatResponse(ctx context.Context, req *chat2.R
 			return replyText, nil
 		case res, ok := <-stream:
 			if !ok {
-				return replyText, nil
+				return replyText, upstreamErr
+			}
+
+			// 自托管模型正在加载，通知客户端并延长等待首个响应的时间
+			if res.Loading {
+				timer.Reset(chatModelLoadingWait)
+				if err := sw.WriteStream(buildChatNoticeMessage(req, "模型正在加载中，首次响应需要较长时间，请耐心等待")); err != nil {
+					log.F(log.M{"req": req, "user_id": user.ID}).Warningf("write response failed: %v", err)
+					return replyText, nil
+				}
+
+				continue
 			}
 
 			id++
 
+			// 服务商在输出过程中检测到内容违反安全策略，或者模型输出命中关键词拦截规则
+			if res.ErrorCode == chat2.ErrorCodeContentFilter {
+				ctl.sendViolateContentPolicyResp(sw, "")
+				return replyText, ErrChatResponseHasSent
+			}
+
 			if res.ErrorCode != "" {
 				log.WithFields(log.Fields{"req": req, "user_id": user.ID}).Errorf("聊天响应失败: %v", res)
 
+				upstreamErr = fmt.Errorf("%w: %s %s", ErrChatUpstreamInterrupted, res.ErrorCode, res.Error)
 				if res.Error != "" {
 					res.Text = fmt.Sprintf("\n\n---\n抱歉，我们遇到了一些错误，以下是错误详情：\n%s\n", res.Error)
 				} else {
-					return replyText, nil
+					return replyText, upstreamErr
 				}
 			} else {
 				replyText += res.Text
@@ -582,6 +887,18 @@ func (ctl *OpenAIController) queryChatQuota(
 }
 
 func (ctl *OpenAIController) rateLimitPass(ctx context.Context, user *auth.User, req *chat2.Request, sw *streamwriter.StreamWriter) error {
+	// 存在异常使用行为的用户，无论是否启用模型流控，都进行严格的限流
+	if ctl.abuseSrv.Throttled(ctx, user.ID) {
+		if err := ctl.limiter.Allow(ctx, fmt.Sprintf("chat-limit:u:%d:abuse:minute", user.ID), redis_rate.PerMinute(2)); err != nil {
+			if errors.Is(err, rate.ErrRateLimitExceeded) {
+				misc.NoError(sw.WriteErrorStream(errors.New("检测到异常使用行为，当前账号已被限流，请稍后再试"), http.StatusTooManyRequests))
+				return rate.ErrRateLimitExceeded
+			}
+
+			log.F(log.M{"user_id": user.ID}).Errorf("check abuse rate limit failed: %s", err)
+		}
+	}
+
 	if ctl.conf.EnableModelRateLimit {
 		if err := ctl.limiter.Allow(ctx, fmt.Sprintf("chat-limit:u:%d:m:%s:minute", user.ID, req.Model), redis_rate.PerMinute(5)); err != nil {
 			if errors.Is(err, rate.ErrRateLimitExceeded) {
@@ -596,7 +913,7 @@ func (ctl *OpenAIController) rateLimitPass(ctx context.Context, user *auth.User,
 	return nil
 }
 
-func (ctl *OpenAIController) saveChatAnswer(ctx context.Context, user *auth.User, replyText string, quotaConsumed int64, realWordCount int, req *chat2.Request, questionID int64, chatErrorMessage string) int64 {
+func (ctl *OpenAIController) saveChatAnswer(ctx context.Context, user *auth.User, replyText string, structured string, quotaConsumed int64, realWordCount int, req *chat2.Request, questionID int64, chatErrorMessage string) int64 {
 	if ctl.conf.EnableRecordChat && !ctl.apiMode {
 		answerID, err := ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
 			UserID:        user.ID,
@@ -609,6 +926,8 @@ func (ctl *OpenAIController) saveChatAnswer(ctx context.Context, user *auth.User
 			PID:           questionID,
 			Status:        int64(ternary.If(chatErrorMessage != "", repo2.MessageStatusFailed, repo2.MessageStatusSucceed)),
 			Error:         chatErrorMessage,
+			Structured:    structured,
+			Incognito:     ctl.chatSrv.RoomIncognito(ctx, user.ID, req.RoomID),
 		})
 		if err != nil {
 			log.With(req).Errorf("add message failed: %s", err)
@@ -655,12 +974,13 @@ func (ctl *OpenAIController) makeChatQuestionFailed(ctx context.Context, questio
 func (ctl *OpenAIController) saveChatQuestion(ctx context.Context, user *auth.User, req *chat2.Request) int64 {
 	if ctl.conf.EnableRecordChat && !ctl.apiMode {
 		qid, err := ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
-			UserID:  user.ID,
-			Message: req.Messages[len(req.Messages)-1].Content,
-			Role:    repo2.MessageRoleUser,
-			RoomID:  req.RoomID,
-			Model:   req.Model,
-			Status:  repo2.MessageStatusSucceed,
+			UserID:    user.ID,
+			Message:   req.Messages[len(req.Messages)-1].Content,
+			Role:      repo2.MessageRoleUser,
+			RoomID:    req.RoomID,
+			Model:     req.Model,
+			Status:    repo2.MessageStatusSucceed,
+			Incognito: ctl.chatSrv.RoomIncognito(ctx, user.ID, req.RoomID),
 		})
 		if err != nil {
 			log.F(log.M{"req": req, "user_id": user.ID}).Errorf("保存用户聊天请求失败（问题部分）: %s", err)
@@ -691,6 +1011,46 @@ func (ctl *OpenAIController) loadRoomContextLen(ctx context.Context, roomID int6
 	return maxContextLength
 }
 
+// policyPass 检查请求是否符合组织策略（使用组织钱包计费时）以及受限模式（家长模式）的限制
+func (ctl *OpenAIController) policyPass(ctx context.Context, webCtx web.Context, user *auth.User, req *chat2.Request, sw *streamwriter.StreamWriter) error {
+	content := req.Messages[len(req.Messages)-1].Content
+
+	err := ctl.orgPolicy.Check(ctx, user.ID, req.Model, content)
+	if err == nil {
+		err = ctl.restrictedSrv.CheckChat(ctx, user.ID, req.Model, content)
+	}
+
+	if err != nil {
+		var orgViolation *service2.OrgPolicyViolationError
+		var restrictedViolation *service2.RestrictedModeViolationError
+		switch {
+		case errors.As(err, &orgViolation):
+			misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, orgViolation.Reason)), http.StatusForbidden))
+		case errors.As(err, &restrictedViolation):
+			misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, restrictedViolation.Reason)), http.StatusForbidden))
+		default:
+			log.F(log.M{"user_id": user.ID}).Errorf("check request policy failed: %s", err)
+			misc.NoError(sw.WriteErrorStream(errors.New(common.Text(webCtx, ctl.translater, common.ErrInternalError)), http.StatusInternalServerError))
+		}
+	}
+
+	return err
+}
+
+// keywordFilterPass 对用户输入执行关键词过滤，命中拦截规则时拒绝请求，命中打码规则时替换请求内容
+func (ctl *OpenAIController) keywordFilterPass(ctx context.Context, user *auth.User, req *chat2.Request, sw *streamwriter.StreamWriter) error {
+	last := len(req.Messages) - 1
+	res := ctl.keywordFilter.Filter(ctx, user.ID, repo2.KeywordFilterScopePrompt, req.Messages[last].Content)
+	if res.Blocked {
+		log.F(log.M{"user_id": user.ID, "hits": res.Hits}).Warningf("用户 %d 的请求命中关键词拦截规则", user.ID)
+		ctl.sendViolateContentPolicyResp(sw, "")
+		return errors.New("违规内容")
+	}
+
+	req.Messages[last].Content = res.Content
+	return nil
+}
+
 // 内容安全检测
 func (ctl *OpenAIController) contentSafety(req *chat2.Request, user *auth.User, sw *streamwriter.StreamWriter) error {
 	// API 模式下，不进行内容安全检测
@@ -730,7 +1090,11 @@ func (ctl *OpenAIController) sendViolateContentPolicyResp(sw *streamwriter.Strea
 }
 
 // Images 图像生成接口，接口参数参考 https://platform.openai.com/docs/api-reference/images/create
-func (ctl *OpenAIController) Images(ctx context.Context, webCtx web.Context, user *auth.User, quotaRepo *repo2.QuotaRepo) web.Response {
+func (ctl *OpenAIController) Images(ctx context.Context, webCtx web.Context, user *auth.User, quotaRepo *repo2.QuotaRepo, client *auth.ClientInfo) web.Response {
+	if ctl.geoPolicy.Decide(ctx, client.Region, repo2.GeoFeatureImage, "").Blocked {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrRegionRestricted), http.StatusUnavailableForLegalReasons)
+	}
+
 	var req openai.ImageRequest
 	if err := webCtx.Unmarshal(&req); err != nil {
 		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
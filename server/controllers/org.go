@@ -0,0 +1,751 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// OrgController 组织（团队）管理，组织成员共享组织钱包中的智慧果
+type OrgController struct {
+	conf       *config.Config
+	translater youdao.Translater `autowire:"@"`
+	orgRepo    *repo2.OrgRepo    `autowire:"@"`
+	userRepo   *repo2.UserRepo   `autowire:"@"`
+}
+
+// NewOrgController 创建组织控制器
+func NewOrgController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &OrgController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *OrgController) Register(router web.Router) {
+	router.Group("/orgs", func(router web.Router) {
+		router.Get("/", ctl.Orgs)
+		router.Post("/", ctl.CreateOrg)
+
+		router.Get("/invitations", ctl.Invitations)
+		router.Post("/{id}/invitation", ctl.AcceptInvitation)
+		router.Delete("/{id}/invitation", ctl.DeclineInvitation)
+
+		router.Get("/{id}", ctl.Org)
+		router.Delete("/{id}/membership", ctl.Leave)
+
+		router.Get("/{id}/members", ctl.Members)
+		router.Post("/{id}/members", ctl.InviteMember)
+		router.Put("/{id}/members/{user_id}", ctl.UpdateMember)
+		router.Delete("/{id}/members/{user_id}", ctl.RemoveMember)
+
+		router.Get("/{id}/usage", ctl.Usage)
+		router.Get("/{id}/usage/export", ctl.UsageExport)
+		router.Get("/{id}/usage/subscription", ctl.UsageSubscription)
+		router.Put("/{id}/usage/subscription", ctl.UpdateUsageSubscription)
+
+		router.Get("/{id}/policy", ctl.Policy)
+		router.Put("/{id}/policy", ctl.UpdatePolicy)
+		router.Get("/{id}/audit-logs", ctl.AuditLogs)
+
+		router.Get("/{id}/provision-tokens", ctl.ProvisionTokens)
+		router.Post("/{id}/provision-tokens", ctl.CreateProvisionToken)
+		router.Delete("/{id}/provision-tokens/{token_id}", ctl.DisableProvisionToken)
+
+		router.Get("/{id}/domains", ctl.Domains)
+		router.Post("/{id}/domains", ctl.AddDomain)
+		router.Post("/{id}/domains/{domain_id}/verify", ctl.VerifyDomain)
+		router.Delete("/{id}/domains/{domain_id}", ctl.RemoveDomain)
+	})
+}
+
+// Orgs 当前用户加入的组织
+func (ctl *OrgController) Orgs(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	orgs, err := ctl.orgRepo.UserOrgs(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户组织失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": orgs})
+}
+
+// CreateOrg 创建组织，创建者成为组织所有者
+func (ctl *OrgController) CreateOrg(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	name := strings.TrimSpace(webCtx.Input("name"))
+	if name == "" || len([]rune(name)) > 50 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	id, err := ctl.orgRepo.CreateOrg(ctx, user.ID, name)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("创建组织失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// Org 组织详情，所有者可以查看组织钱包余额，成员可以查看自己本月的额度使用情况
+func (ctl *OrgController) Org(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, false)
+	if resp != nil {
+		return resp
+	}
+
+	org, err := ctl.orgRepo.Org(ctx, member.OrgId)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织信息失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	used, err := ctl.orgRepo.MemberMonthUsed(ctx, member.OrgId, user.ID)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId, "user_id": user.ID}).Errorf("查询成员本月使用量失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	res := web.M{
+		"id":            org.Id,
+		"name":          org.Name,
+		"role":          member.Role,
+		"monthly_limit": member.MonthlyLimit,
+		"month_used":    used,
+		"month_remain":  repo2.OrgMonthlyRemaining(member.MonthlyLimit, used),
+	}
+
+	if member.Role == repo2.OrgRoleOwner {
+		quota, err := ctl.orgRepo.OrgQuota(ctx, member.OrgId)
+		if err != nil {
+			log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织配额失败: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		res["quota"] = quota
+	}
+
+	return webCtx.JSON(res)
+}
+
+// Invitations 当前用户收到的组织邀请
+func (ctl *OrgController) Invitations(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	items, err := ctl.orgRepo.Invitations(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询组织邀请失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items})
+}
+
+// AcceptInvitation 接受组织邀请
+func (ctl *OrgController) AcceptInvitation(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	orgID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.orgRepo.AcceptInvitation(ctx, int64(orgID), user.ID); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"org_id": orgID, "user_id": user.ID}).Errorf("接受组织邀请失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeclineInvitation 拒绝组织邀请
+func (ctl *OrgController) DeclineInvitation(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	return ctl.Leave(ctx, webCtx, user)
+}
+
+// Leave 退出组织，组织所有者不能退出
+func (ctl *OrgController) Leave(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	orgID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.orgRepo.RemoveMember(ctx, int64(orgID), user.ID); err != nil {
+		log.F(log.M{"org_id": orgID, "user_id": user.ID}).Errorf("退出组织失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Members 组织成员列表（仅所有者与管理员）
+func (ctl *OrgController) Members(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.manager(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	items, err := ctl.orgRepo.Members(ctx, member.OrgId)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织成员失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items})
+}
+
+// InviteMember 通过邮箱或者手机号邀请用户加入组织（仅所有者与管理员）
+func (ctl *OrgController) InviteMember(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.manager(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	username := strings.TrimSpace(webCtx.Input("username"))
+	if username == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	var invitee *model.Users
+	var err error
+	if strings.Contains(username, "@") {
+		invitee, err = ctl.userRepo.GetUserByEmail(ctx, username)
+	} else {
+		invitee, err = ctl.userRepo.GetUserByPhone(ctx, username)
+	}
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) || errors.Is(err, repo2.ErrUserAccountDisabled) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "用户不存在"), http.StatusNotFound)
+		}
+
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询被邀请用户失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.orgRepo.Invite(ctx, member.OrgId, user.ID, invitee.Id); err != nil {
+		if errors.Is(err, repo2.ErrOrgMemberExists) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "该用户已经是组织成员或者已被邀请"), http.StatusConflict)
+		}
+
+		log.F(log.M{"org_id": member.OrgId, "user_id": invitee.Id}).Errorf("邀请组织成员失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditMemberInvited, fmt.Sprintf("user_id=%d", invitee.Id))
+
+	return webCtx.JSON(web.M{"user_id": invitee.Id})
+}
+
+// UpdateMember 设置成员每月可使用的组织智慧果上限（仅所有者与管理员），0 表示不限制
+func (ctl *OrgController) UpdateMember(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.manager(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	memberID, err := strconv.Atoi(webCtx.PathVar("user_id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	limit := webCtx.Int64Input("monthly_limit", 0)
+	if limit < 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.orgRepo.UpdateMemberLimit(ctx, member.OrgId, int64(memberID), limit); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"org_id": member.OrgId, "user_id": memberID}).Errorf("更新成员额度失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditMemberLimitUpdated, fmt.Sprintf("user_id=%d, monthly_limit=%d", memberID, limit))
+
+	return webCtx.JSON(web.M{})
+}
+
+// RemoveMember 移除组织成员（仅所有者与管理员）
+func (ctl *OrgController) RemoveMember(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.manager(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	memberID, err := strconv.Atoi(webCtx.PathVar("user_id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.orgRepo.RemoveMember(ctx, member.OrgId, int64(memberID)); err != nil {
+		log.F(log.M{"org_id": member.OrgId, "user_id": memberID}).Errorf("移除组织成员失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditMemberRemoved, fmt.Sprintf("user_id=%d", memberID))
+
+	return webCtx.JSON(web.M{})
+}
+
+// Usage 组织的汇总使用报告（仅所有者），month 格式为 2006-01，默认为本月
+func (ctl *OrgController) Usage(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	month := time.Now()
+	if m := webCtx.Input("month"); m != "" {
+		parsed, err := time.ParseInLocation("2006-01", m, time.Local)
+		if err != nil {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+		}
+
+		month = parsed
+	}
+
+	startAt := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	report, err := ctl.orgRepo.UsageReport(ctx, member.OrgId, startAt, startAt.AddDate(0, 1, 0))
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织使用报告失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"month":  startAt.Format("2006-01"),
+		"report": report,
+	})
+}
+
+// orgUsageExportMaxDays 单次导出使用统计的最大天数
+const orgUsageExportMaxDays = 366
+
+// UsageExport 导出组织成员每天每个模型的使用统计（仅所有者），用于内部成本分摊
+// start、end 格式为 2006-01-02，默认为本月，format 可选值为 csv、json，统计数据每天凌晨汇总，不包含当天
+func (ctl *OrgController) UsageExport(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	endDate := now
+	for _, item := range []struct {
+		name string
+		dst  *time.Time
+	}{{"start", &startDate}, {"end", &endDate}} {
+		if v := webCtx.Input(item.name); v != "" {
+			parsed, err := time.ParseInLocation("2006-01-02", v, time.Local)
+			if err != nil {
+				return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+			}
+
+			*item.dst = parsed
+		}
+	}
+
+	if endDate.Before(startDate) || endDate.Sub(startDate) > orgUsageExportMaxDays*24*time.Hour {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	rows, err := ctl.orgRepo.UsageExport(ctx, member.OrgId, startDate, endDate)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("导出组织使用统计失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if webCtx.Input("format") != "csv" {
+		return webCtx.JSON(web.M{
+			"start": startDate.Format("2006-01-02"),
+			"end":   endDate.Format("2006-01-02"),
+			"data":  rows,
+		})
+	}
+
+	data, err := repo2.OrgUsageCSV(rows)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("生成组织使用统计 CSV 失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	filename := fmt.Sprintf("org-%d-usage-%s-%s.csv", member.OrgId, startDate.Format("20060102"), endDate.Format("20060102"))
+	return webCtx.Raw(func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		_, _ = w.Write(data)
+	})
+}
+
+// UsageSubscription 查询组织使用报告的邮件订阅（仅所有者）
+func (ctl *OrgController) UsageSubscription(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	sub, err := ctl.orgRepo.UsageSubscription(ctx, member.OrgId)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSON(repo2.OrgUsageSubscription{OrgID: member.OrgId, Emails: []string{}, Frequency: repo2.OrgUsageFrequencyMonthly})
+		}
+
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织使用报告订阅失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(sub)
+}
+
+// UpdateUsageSubscription 设置组织使用报告的邮件订阅（仅所有者），emails 为逗号分隔的邮箱列表，为空时取消订阅
+func (ctl *OrgController) UpdateUsageSubscription(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	frequency := webCtx.InputWithDefault("frequency", repo2.OrgUsageFrequencyMonthly)
+	if frequency != repo2.OrgUsageFrequencyWeekly && frequency != repo2.OrgUsageFrequencyMonthly {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	emails := array.Uniq(array.Filter(
+		array.Map(strings.Split(webCtx.Input("emails"), ","), func(e string, _ int) string { return strings.TrimSpace(e) }),
+		func(e string, _ int) bool { return e != "" },
+	))
+	if len(emails) > 10 || len(array.Filter(emails, func(e string, _ int) bool { return !isEmail(e) })) > 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "邮箱格式错误，最多支持 10 个邮箱"), http.StatusBadRequest)
+	}
+
+	if err := ctl.orgRepo.UpdateUsageSubscription(ctx, member.OrgId, emails, frequency); err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("更新组织使用报告订阅失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditUsageSubscriptionUpdated, fmt.Sprintf("emails=%s, frequency=%s", strings.Join(emails, ","), frequency))
+
+	return webCtx.JSON(web.M{})
+}
+
+// Policy 查询组织策略（仅所有者）
+func (ctl *OrgController) Policy(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	policy, err := ctl.orgRepo.Policy(ctx, member.OrgId)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织策略失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(policy)
+}
+
+// UpdatePolicy 更新组织策略（仅所有者），限制成员可以使用的模型以及内容安全策略
+func (ctl *OrgController) UpdatePolicy(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	var policy repo2.OrgPolicy
+	if err := webCtx.Unmarshal(&policy); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	policy.AllowedModels = array.Uniq(array.Filter(
+		array.Map(policy.AllowedModels, func(m string, _ int) string { return strings.TrimSpace(m) }),
+		func(m string, _ int) bool { return m != "" },
+	))
+	policy.BlockedWords = array.Uniq(array.Filter(
+		array.Map(policy.BlockedWords, func(w string, _ int) string { return strings.TrimSpace(w) }),
+		func(w string, _ int) bool { return w != "" },
+	))
+
+	if err := ctl.orgRepo.UpdatePolicy(ctx, member.OrgId, policy); err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("更新组织策略失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	detail, _ := json.Marshal(policy)
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditPolicyUpdated, misc.SubString(string(detail), 1000))
+
+	return webCtx.JSON(policy)
+}
+
+// AuditLogs 查询组织审计日志（仅所有者），可以通过 action 参数筛选操作类型
+func (ctl *OrgController) AuditLogs(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	items, err := ctl.orgRepo.AuditLogs(ctx, member.OrgId, webCtx.Input("action"), 200)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织审计日志失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items})
+}
+
+// ProvisionTokens 成员同步接口的访问 Token 列表（仅所有者）
+func (ctl *OrgController) ProvisionTokens(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	items, err := ctl.orgRepo.ProvisionTokens(ctx, member.OrgId)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询成员同步 Token 失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items})
+}
+
+// CreateProvisionToken 创建成员同步接口的访问 Token（仅所有者），Token 只在创建时返回一次
+func (ctl *OrgController) CreateProvisionToken(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	name := strings.TrimSpace(webCtx.Input("name"))
+	if name == "" || len([]rune(name)) > 50 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	token, err := ctl.orgRepo.CreateProvisionToken(ctx, member.OrgId, user.ID, name)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("创建成员同步 Token 失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditProvisionTokenCreated, fmt.Sprintf("name=%s", name))
+
+	return webCtx.JSON(web.M{"token": token})
+}
+
+// DisableProvisionToken 禁用成员同步接口的访问 Token（仅所有者）
+func (ctl *OrgController) DisableProvisionToken(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	tokenID, err := strconv.Atoi(webCtx.PathVar("token_id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.orgRepo.DisableProvisionToken(ctx, member.OrgId, int64(tokenID)); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"org_id": member.OrgId, "token_id": tokenID}).Errorf("禁用成员同步 Token 失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditProvisionTokenDisabled, fmt.Sprintf("token_id=%d", tokenID))
+
+	return webCtx.JSON(web.M{})
+}
+
+// orgDomainRegex 邮箱域名格式
+var orgDomainRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// orgDomainTXTPrefix 域名验证使用的 DNS TXT 记录值前缀
+const orgDomainTXTPrefix = "aidea-verification="
+
+// Domains 查询组织的邮箱域名（仅所有者），验证通过的域名下的邮箱可以由成员同步接口直接创建并启用账号
+func (ctl *OrgController) Domains(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	items, err := ctl.orgRepo.Domains(ctx, member.OrgId)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId}).Errorf("查询组织域名失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": items, "txt_prefix": orgDomainTXTPrefix})
+}
+
+// AddDomain 添加待验证的邮箱域名（仅所有者），需要在域名的 DNS 中添加 TXT 记录后完成验证
+func (ctl *OrgController) AddDomain(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(webCtx.Input("domain")), "@"))
+	if len(domain) > 255 || !orgDomainRegex.MatchString(domain) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	item, err := ctl.orgRepo.AddDomain(ctx, member.OrgId, domain)
+	if err != nil {
+		if errors.Is(err, repo2.ErrOrgDomainExists) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "该域名已经添加"), http.StatusConflict)
+		}
+
+		log.F(log.M{"org_id": member.OrgId, "domain": domain}).Errorf("添加组织域名失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditDomainAdded, fmt.Sprintf("domain=%s", domain))
+
+	return webCtx.JSON(web.M{"data": item, "txt_record": orgDomainTXTPrefix + item.Token})
+}
+
+// VerifyDomain 检查域名的 DNS TXT 记录，包含验证值时将域名标记为已验证（仅所有者）
+func (ctl *OrgController) VerifyDomain(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	domainID, err := strconv.Atoi(webCtx.PathVar("domain_id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	item, err := ctl.orgRepo.Domain(ctx, member.OrgId, int64(domainID))
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"org_id": member.OrgId, "domain_id": domainID}).Errorf("查询组织域名失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if item.Verified() {
+		return webCtx.JSON(web.M{"data": item})
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	records, err := net.DefaultResolver.LookupTXT(lookupCtx, item.Domain)
+	if err != nil || !array.In(orgDomainTXTPrefix+item.Token, records) {
+		log.F(log.M{"org_id": member.OrgId, "domain": item.Domain}).Debugf("组织域名验证失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "未找到域名验证的 TXT 记录，DNS 记录生效可能需要一段时间，请稍后再试"), http.StatusBadRequest)
+	}
+
+	if err := ctl.orgRepo.MarkDomainVerified(ctx, member.OrgId, item.ID); err != nil {
+		if errors.Is(err, repo2.ErrOrgDomainVerifiedByOther) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "该域名已经被其它组织验证"), http.StatusConflict)
+		}
+
+		log.F(log.M{"org_id": member.OrgId, "domain": item.Domain}).Errorf("验证组织域名失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditDomainVerified, fmt.Sprintf("domain=%s", item.Domain))
+
+	item, err = ctl.orgRepo.Domain(ctx, member.OrgId, item.ID)
+	if err != nil {
+		log.F(log.M{"org_id": member.OrgId, "domain_id": domainID}).Errorf("查询组织域名失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": item})
+}
+
+// RemoveDomain 删除组织的邮箱域名（仅所有者），已经加入组织的成员不受影响
+func (ctl *OrgController) RemoveDomain(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	member, resp := ctl.member(ctx, webCtx, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	domainID, err := strconv.Atoi(webCtx.PathVar("domain_id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.orgRepo.RemoveDomain(ctx, member.OrgId, int64(domainID)); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"org_id": member.OrgId, "domain_id": domainID}).Errorf("删除组织域名失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.audit(ctx, member.OrgId, user.ID, repo2.OrgAuditDomainRemoved, fmt.Sprintf("domain_id=%d", domainID))
+
+	return webCtx.JSON(web.M{})
+}
+
+// audit 记录组织审计日志，失败时只记录错误日志
+func (ctl *OrgController) audit(ctx context.Context, orgID, userID int64, action, detail string) {
+	if err := ctl.orgRepo.AddAuditLog(ctx, orgID, userID, action, detail); err != nil {
+		log.F(log.M{"org_id": orgID, "user_id": userID, "action": action}).Errorf("记录组织审计日志失败: %v", err)
+	}
+}
+
+// member 查询当前用户在路径参数指定的组织中的成员信息，ownerOnly 为 true 时只允许组织所有者访问
+func (ctl *OrgController) member(ctx context.Context, webCtx web.Context, user *auth.User, ownerOnly bool) (*model.OrgMember, web.Response) {
+	orgID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	member, err := ctl.orgRepo.ActiveMember(ctx, int64(orgID), user.ID)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"org_id": orgID, "user_id": user.ID}).Errorf("查询组织成员失败: %v", err)
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if ownerOnly && member.Role != repo2.OrgRoleOwner {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, "只有组织所有者可以执行该操作"), http.StatusForbidden)
+	}
+
+	return member, nil
+}
+
+// manager 查询当前用户在路径参数指定的组织中的成员信息，只允许组织所有者与管理员访问
+func (ctl *OrgController) manager(ctx context.Context, webCtx web.Context, user *auth.User) (*model.OrgMember, web.Response) {
+	member, resp := ctl.member(ctx, webCtx, user, false)
+	if resp != nil {
+		return nil, resp
+	}
+
+	if member.Role != repo2.OrgRoleOwner && member.Role != repo2.OrgRoleAdmin {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, "只有组织所有者或管理员可以执行该操作"), http.StatusForbidden)
+	}
+
+	return member, nil
+}
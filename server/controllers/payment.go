@@ -3,14 +3,18 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/mylxsw/aidea-server/pkg/dingding"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/awa/go-iap/appstore"
 	"github.com/mylxsw/aidea-server/config"
 	"github.com/mylxsw/aidea-server/internal/payment/applepay"
 
@@ -30,9 +34,14 @@ type PaymentController struct {
 	translater youdao.Translater  `autowire:"@"`
 	queue      *queue.Queue       `autowire:"@"`
 	payRepo    *repo2.PaymentRepo `autowire:"@"`
+	orgRepo    *repo2.OrgRepo     `autowire:"@"`
 	alipay     alipay.Alipay      `autowire:"@"`
 	applepay   applepay.ApplePay  `autowire:"@"`
 	conf       *config.Config     `autowire:"@"`
+	ding       *dingding.Dingding `autowire:"@"`
+
+	autoTopupRepo   *repo2.AutoTopupRepo         `autowire:"@"`
+	exchangeRateSrv *service.ExchangeRateService `autowire:"@"`
 }
 
 func NewPaymentController(resolver infra.Resolver) web.Controller {
@@ -76,11 +85,60 @@ func (p *PaymentController) Register(router web.Router) {
 		// 支付结果回调通知
 		router.Group("/callback", func(router web.Router) {
 			router.Post("/alipay-notify", p.AlipayNotify)
+			// App Store 服务器通知（V2）
+			router.Post("/apple-notify", p.AppleNotify)
+			// 支付宝商家扣款签约、解约通知
+			router.Post("/alipay-agreement-notify", p.AlipayAgreementNotify)
+		})
+
+		// 余额不足时自动充值（支付宝商家扣款）
+		router.Group("/auto-topup", func(router web.Router) {
+			router.Get("/", p.AutoTopup)
+			router.Put("/", p.SaveAutoTopup)
+			router.Delete("/", p.CancelAutoTopup)
 		})
 
 	})
 }
 
+// paymentOrg 读取请求中要充值的组织 ID，为组织充值时只有组织所有者可以发起支付，返回 0 表示为个人充值
+func (ctl *PaymentController) paymentOrg(ctx context.Context, webCtx web.Context, user *auth.User) (int64, web.Response) {
+	orgID := webCtx.Int64Input("org_id", 0)
+	if orgID <= 0 {
+		return 0, nil
+	}
+
+	member, err := ctl.orgRepo.ActiveMember(ctx, orgID, user.ID)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return 0, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.WithFields(log.Fields{"org_id": orgID, "user_id": user.ID}).Errorf("query org member failed: %s", err)
+		return 0, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if member.Role != repo2.OrgRoleOwner {
+		return 0, webCtx.JSONError(common.Text(webCtx, ctl.translater, "只有组织所有者可以为组织充值"), http.StatusForbidden)
+	}
+
+	return orgID, nil
+}
+
+// bindPaymentOrg 将支付订单关联到组织，支付完成后智慧果充值到组织的共享钱包
+func (ctl *PaymentController) bindPaymentOrg(ctx context.Context, webCtx web.Context, user *auth.User, orgID int64, paymentID string) web.Response {
+	if orgID <= 0 {
+		return nil
+	}
+
+	if err := ctl.orgRepo.BindPayment(ctx, orgID, user.ID, paymentID); err != nil {
+		log.WithFields(log.Fields{"org_id": orgID, "payment_id": paymentID}).Errorf("bind org payment failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 // CreateAlipay 发起支付宝付款
 func (ctl *PaymentController) CreateAlipay(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
 	if !ctl.alipay.Enabled() {
@@ -106,6 +164,11 @@ func (ctl *PaymentController) CreateAlipay(ctx context.Context, webCtx web.Conte
 		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
 	}
 
+	orgID, resp := ctl.paymentOrg(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
 	paymentID, err := ctl.payRepo.CreateAliPayment(ctx, user.ID, productId, source)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -117,6 +180,10 @@ func (ctl *PaymentController) CreateAlipay(ctx context.Context, webCtx web.Conte
 		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
 	}
 
+	if resp := ctl.bindPaymentOrg(ctx, webCtx, user, orgID, paymentID); resp != nil {
+		return resp
+	}
+
 	passbackParams := url.Values{}
 
 	passbackParams.Add("user_id", strconv.Itoa(int(user.ID)))
@@ -351,11 +418,23 @@ func (ctl *PaymentController) AlipayNotify(ctx context.Context, webCtx web.Conte
 	if err != nil {
 		// 如果已经处理过了，直接返回成功
 		if err == repo2.ErrPaymentHasBeenProcessed {
+			if status == int64(repo2.PaymentStatusSuccess) {
+				log.WithFields(log.Fields{"payment_id": paymentId, "trade_no": tradeNo}).Warning("alipay callback retried, payment has been processed")
+			}
+
 			return webCtx.Raw(func(w http.ResponseWriter) {
 				w.Write([]byte("success"))
 			})
 		}
 
+		// 支付宝交易号已经在其它订单中处理过，当前订单不再充值，返回成功避免支付宝继续重试
+		if errors.Is(err, repo2.ErrPaymentDuplicated) {
+			ctl.alertDuplicatePayment(int64(userId), paymentId, "alipay:"+tradeNo, "alipay-purchase")
+			return webCtx.Raw(func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte("success"))
+			})
+		}
+
 		log.WithFields(log.Fields{
 			"err":        err.Error(),
 			"payment_id": paymentId,
@@ -376,7 +455,7 @@ func (ctl *PaymentController) AlipayNotify(ctx context.Context, webCtx web.Conte
 			EventID:   eventID,
 		}
 
-		if _, err := ctl.queue.Enqueue(&payload, queue.NewPaymentTask); err != nil {
+		if _, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewPaymentTask); err != nil {
 			log.WithFields(log.Fields{"err": err}).Error("enqueue payment task failed")
 		}
 	}
@@ -388,15 +467,34 @@ func (ctl *PaymentController) AlipayNotify(ctx context.Context, webCtx web.Conte
 
 // AppleProducts 支付产品清单
 func (ctl *PaymentController) AppleProducts(ctx context.Context, webCtx web.Context, client *auth.ClientInfo) web.Response {
+	// 根据用户所在地区展示对应货币的价格
+	region := client.PricingRegion()
+	currency := coins.CurrencyForRegion(region)
+	var rates map[string]float64
+	if currency != coins.CurrencyCNY {
+		rates = ctl.exchangeRateSrv.Rates(ctx)
+	}
+
 	products := array.Map(coins.Products, func(product coins.Product, _ int) coins.Product {
 		product.ExpirePolicyText = product.GetExpirePolicyText()
 		if product.RetailPrice == 0 {
 			product.RetailPrice = product.Quota
 		}
+
+		// 缺少汇率时使用人民币价格展示
+		price, estimated, ok := product.LocalPrice(currency, rates)
+		product.Currency = ternary.If(ok, currency, coins.CurrencyCNY)
+		product.Price = ternary.If(ok, price, product.RetailPrice)
+		product.PriceText = coins.FormatPrice(product.Currency, product.Price)
+		product.PriceEstimated = estimated
 		return product
 	})
 
 	products = array.Filter(products, func(prod coins.Product, _ int) bool {
+		if !prod.VisibleIn(region) {
+			return false
+		}
+
 		if prod.PlatformLimit == "" {
 			return true
 		}
@@ -437,6 +535,11 @@ func (ctl *PaymentController) CreateApplePayment(ctx context.Context, webCtx web
 		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
 	}
 
+	orgID, resp := ctl.paymentOrg(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
 	paymentID, err := ctl.payRepo.CreateApplePayment(ctx, user.ID, productId)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -447,6 +550,10 @@ func (ctl *PaymentController) CreateApplePayment(ctx context.Context, webCtx web
 		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
 	}
 
+	if resp := ctl.bindPaymentOrg(ctx, webCtx, user, orgID, paymentID); resp != nil {
+		return resp
+	}
+
 	log.WithFields(log.Fields{
 		"payment_id": paymentID,
 		"product_id": productId,
@@ -556,6 +663,26 @@ func (ctl *PaymentController) VerifyApplePayment(ctx context.Context, webCtx web
 	applePayment.Status = int64(repo2.PaymentStatusSuccess)
 	eventID, err := ctl.payRepo.CompleteApplePayment(ctx, user.ID, paymentId, applePayment)
 	if err != nil {
+		// 客户端重复提交验证，或者同一笔 Apple 交易被用于多个订单，都不再重复充值，返回成功使客户端结束交易
+		if errors.Is(err, repo2.ErrPaymentHasBeenProcessed) || errors.Is(err, repo2.ErrPaymentDuplicated) {
+			log.WithFields(log.Fields{
+				"err":           err.Error(),
+				"apple_payment": applePayment,
+				"payment_id":    paymentId,
+			}).Warning("apple payment has been processed")
+
+			if errors.Is(err, repo2.ErrPaymentDuplicated) {
+				ctl.alertDuplicatePayment(user.ID, paymentId, "apple:"+applePayment.TransactionID, "apple-purchase")
+			}
+
+			return webCtx.JSON(map[string]interface{}{
+				"status":     "ok",
+				"id":         paymentId,
+				"env":        resp.Environment,
+				"duplicated": true,
+			})
+		}
+
 		log.WithFields(log.Fields{
 			"err":           err.Error(),
 			"apple_payment": applePayment,
@@ -578,7 +705,7 @@ func (ctl *PaymentController) VerifyApplePayment(ctx context.Context, webCtx web
 		EventID:   eventID,
 	}
 
-	if _, err := ctl.queue.Enqueue(&payload, queue.NewPaymentTask); err != nil {
+	if _, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewPaymentTask); err != nil {
 		log.WithFields(log.Fields{"err": err}).Error("enqueue payment task failed")
 	}
 
@@ -615,3 +742,93 @@ func (ctl *PaymentController) CancelApplePayment(ctx context.Context, webCtx web
 		"id":     paymentId,
 	})
 }
+
+// AppleNotify App Store 服务器通知（V2）回调，处理退款与撤销通知，扣回对应的智慧果
+// https://developer.apple.com/documentation/appstoreservernotifications
+func (ctl *PaymentController) AppleNotify(ctx context.Context, webCtx web.Context) web.Response {
+	if !ctl.applepay.Enabled() {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "Apple 应用内支付功能尚未开启"), http.StatusBadRequest)
+	}
+
+	var body appstore.SubscriptionNotificationV2SignedPayload
+	if err := json.Unmarshal(webCtx.Body(), &body); err != nil || body.SignedPayload == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	notification, err := applepay.ParseNotification(body.SignedPayload, ctl.conf.ApplePayBundleID)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err.Error()}).Error("apple notification invalid")
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	log.WithFields(log.Fields{"notification": notification}).Info("apple notification")
+
+	if !notification.IsRefund() || notification.Transaction == nil || notification.Transaction.TransactionId == "" {
+		return webCtx.JSON(web.M{})
+	}
+
+	payload, _ := json.Marshal(notification)
+	refund, err := ctl.payRepo.RefundPayment(ctx, repo2.PaymentRefundRequest{
+		TransactionKey:   "apple:" + notification.Transaction.TransactionId,
+		NotificationID:   notification.UUID,
+		NotificationType: string(notification.Type),
+		Environment:      notification.Environment,
+		Payload:          string(payload),
+	})
+	if err != nil {
+		if errors.Is(err, repo2.ErrPaymentHasBeenProcessed) {
+			return webCtx.JSON(web.M{})
+		}
+
+		log.WithFields(log.Fields{
+			"err":          err.Error(),
+			"notification": notification,
+		}).Error("process apple refund failed")
+
+		// 返回错误状态码，App Store 会重新发送通知
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	log.WithFields(log.Fields{"refund": refund}).Warning("apple payment refunded")
+
+	go func() {
+		content := fmt.Sprintf(
+			`Apple 交易 %s 已%s（订单号：%s，用户 ID：%d），应扣回 %d 个智慧果，实际扣回 %d 个，欠费 %d 个，请财务复核。`,
+			notification.Transaction.TransactionId,
+			ternary.If(notification.Type == appstore.NotificationTypeV2Revoke, "撤销", "退款"),
+			ternary.If(refund.PaymentID != "", refund.PaymentID, "未匹配到订单"),
+			refund.UserID,
+			refund.Quantity,
+			refund.ClawedBack,
+			refund.Debt,
+		)
+		if err := ctl.ding.Send(dingding.NewMarkdownMessage(notification.Environment+": Apple 退款通知", content, []string{})); err != nil {
+			log.Errorf("发送钉钉通知失败: %s", err)
+		}
+	}()
+
+	return webCtx.JSON(web.M{})
+}
+
+// alertDuplicatePayment 通知管理员已拦截重复的支付
+func (ctl *PaymentController) alertDuplicatePayment(userID int64, paymentID, transactionKey, source string) {
+	log.WithFields(log.Fields{
+		"user_id":         userID,
+		"payment_id":      paymentID,
+		"transaction_key": transactionKey,
+		"source":          source,
+	}).Warning("duplicate payment blocked")
+
+	go func() {
+		content := fmt.Sprintf(
+			`用户（ID：%d）的充值订单 %s 使用的交易 %s 已经在其它订单中处理过，已拦截本次充值，充值来源为 %s。`,
+			userID,
+			paymentID,
+			transactionKey,
+			source,
+		)
+		if err := ctl.ding.Send(dingding.NewMarkdownMessage("拦截重复支付", content, []string{})); err != nil {
+			log.Errorf("发送钉钉通知失败: %s", err)
+		}
+	}()
+}
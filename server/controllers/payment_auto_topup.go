@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mylxsw/aidea-server/internal/coins"
+	"github.com/mylxsw/aidea-server/internal/payment/alipay"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// autoTopupEnabled 自动充值依赖支付宝商家扣款
+func (ctl *PaymentController) autoTopupEnabled() bool {
+	return ctl.conf.EnableAutoTopup && ctl.alipay.Enabled()
+}
+
+// AutoTopup 当前用户的自动充值设置以及本月已经自动充值的金额
+func (ctl *PaymentController) AutoTopup(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if !ctl.autoTopupEnabled() {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "自动充值功能尚未开启"), http.StatusForbidden)
+	}
+
+	setting, err := ctl.autoTopupRepo.Get(ctx, user.ID)
+	if err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询自动充值设置失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	spent, err := ctl.autoTopupRepo.MonthSpent(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询本月自动充值金额失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	resp := web.M{"data": setting, "month_spent": spent}
+	if setting != nil && setting.NotifiedAt != nil {
+		// 已经通知用户即将扣款，客户端可以提示用户在扣款前取消
+		resp["charge_at"] = setting.NotifiedAt.Add(ctl.conf.AutoTopupNoticePeriod)
+	}
+
+	return webCtx.JSON(resp)
+}
+
+// SaveAutoTopup 开启或者修改自动充值，首次开启时返回支付宝签约链接，用户签约完成后生效
+func (ctl *PaymentController) SaveAutoTopup(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if !ctl.autoTopupEnabled() {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "自动充值功能尚未开启"), http.StatusForbidden)
+	}
+
+	// 扣款之前需要通过邮件提醒用户，没有可以送达的通知渠道时不允许开启自动充值
+	if !ctl.conf.EnableMail || user.Email == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "开启自动充值需要先绑定邮箱，用于接收扣款提醒"), http.StatusBadRequest)
+	}
+
+	source := webCtx.InputWithDefault("source", "app")
+	if !array.In(source, []string{"app", "web", "wap"}) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	product := coins.GetProduct(webCtx.Input("product_id"))
+	if product == nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	threshold := webCtx.Int64Input("threshold", 0)
+	if threshold <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "请设置触发自动充值的余额"), http.StatusBadRequest)
+	}
+
+	// 每月上限至少能够充值一次
+	monthlyCap := webCtx.Int64Input("monthly_cap", 0)
+	if monthlyCap < product.RetailPrice {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "每月自动充值上限不能低于所选产品的价格"), http.StatusBadRequest)
+	}
+
+	setting, err := ctl.autoTopupRepo.Save(ctx, user.ID, repo2.AutoTopupSettings{
+		ProductID:  product.ID,
+		Threshold:  threshold,
+		MonthlyCap: monthlyCap,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存自动充值设置失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if setting.Status == repo2.AutoTopupStatusActive {
+		return webCtx.JSON(web.M{"data": setting})
+	}
+
+	signURL, err := ctl.alipay.AgreementSign(ctx, source, alipay.AgreementSign{
+		ExternalAgreementNo: setting.ExternalAgreementNo,
+		NotifyURL:           ctl.conf.AliPayAgreementNotifyURL,
+		ReturnURL:           ctl.conf.AliPayReturnURL,
+	}, !ctl.conf.AlipaySandbox)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("生成支付宝签约链接失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": setting, "sign_url": signURL, "sandbox": ctl.conf.AlipaySandbox})
+}
+
+// CancelAutoTopup 关闭自动充值，同时解除支付宝商家扣款协议
+func (ctl *PaymentController) CancelAutoTopup(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	setting, err := ctl.autoTopupRepo.Cancel(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSON(web.M{})
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("取消自动充值失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	// 解约失败时自动充值已经取消，不会再扣款，用户也可以在支付宝中自行解约
+	if setting.AgreementNo != "" && ctl.alipay.Enabled() {
+		if err := ctl.alipay.AgreementUnsign(ctx, setting.AgreementNo, !ctl.conf.AlipaySandbox); err != nil {
+			log.F(log.M{"user_id": user.ID, "agreement_no": setting.AgreementNo}).Errorf("解除支付宝商家扣款协议失败: %v", err)
+		}
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// AlipayAgreementNotify 支付宝商家扣款签约、解约结果通知 https://opendocs.alipay.com/open/08bg92
+func (ctl *PaymentController) AlipayAgreementNotify(ctx context.Context, webCtx web.Context) web.Response {
+	if !ctl.alipay.Enabled() {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "支付宝支付功能尚未开启"), http.StatusBadRequest)
+	}
+
+	notifyType := webCtx.Input("notify_type")
+	externalAgreementNo := webCtx.Input("external_agreement_no")
+	agreementNo := webCtx.Input("agreement_no")
+	alipayUserID := webCtx.Input("alipay_user_id")
+	status := webCtx.Input("status")
+
+	params := make(map[string]interface{})
+	// 注意：这里的 PostForm 必须在调用 webCtx.Input 等方法之后才能使用（ParseForm）
+	for k, v := range webCtx.Request().Raw().PostForm {
+		params[k] = v[0]
+	}
+
+	if ok, err := ctl.alipay.VerifyCallbackSign(params); err != nil || !ok {
+		log.F(log.M{"params": params, "err": err}).Error("verify alipay agreement notify sign failed")
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	log.F(log.M{
+		"notify_type":           notifyType,
+		"external_agreement_no": externalAgreementNo,
+		"agreement_no":          agreementNo,
+		"status":                status,
+	}).Info("alipay agreement notify")
+
+	switch notifyType {
+	case "dut_user_sign":
+		if status != "NORMAL" {
+			break
+		}
+
+		if _, err := ctl.autoTopupRepo.Activate(ctx, externalAgreementNo, agreementNo, alipayUserID); err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				// 用户在签约完成前已经取消或者修改了设置，解除这个过期的协议
+				log.F(log.M{"external_agreement_no": externalAgreementNo}).Warning("auto topup not pending, unsign agreement")
+				if err := ctl.alipay.AgreementUnsign(ctx, agreementNo, !ctl.conf.AlipaySandbox); err != nil {
+					log.F(log.M{"agreement_no": agreementNo}).Errorf("解除支付宝商家扣款协议失败: %v", err)
+				}
+				break
+			}
+
+			log.F(log.M{"external_agreement_no": externalAgreementNo}).Errorf("激活自动充值失败: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	case "dut_user_unsign":
+		if err := ctl.autoTopupRepo.CancelByAgreement(ctx, agreementNo); err != nil {
+			log.F(log.M{"agreement_no": agreementNo}).Errorf("取消自动充值失败: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	}
+
+	return webCtx.Raw(func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte("success"))
+	})
+}
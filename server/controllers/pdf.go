@@ -0,0 +1,459 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/ai/embedding"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/pdf"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+const (
+	// pdfChunkSize PDF 片段长度（字符数）
+	pdfChunkSize = 800
+	// pdfChunkOverlap 相邻 PDF 片段重叠的字符数
+	pdfChunkOverlap = 100
+	// pdfRetrieveTopK 每次对话检索的片段数量
+	pdfRetrieveTopK = 5
+	// pdfEmbeddingBatchSize 向量化时单次请求的片段数量
+	pdfEmbeddingBatchSize = 16
+)
+
+// PDFController 与 PDF 文档对话，回答中包含页码引用，客户端可以据此跳转到对应页面
+type PDFController struct {
+	conf        *config.Config
+	chat        chat2.Chat            `autowire:"@"`
+	embed       embedding.Embedding   `autowire:"@"`
+	extractor   *pdf.Extractor        `autowire:"@"`
+	translater  youdao.Translater     `autowire:"@"`
+	pdfRepo     *repo2.PDFRepo        `autowire:"@"`
+	messageRepo *repo2.MessageRepo    `autowire:"@"`
+	quotaRepo   *repo2.QuotaRepo      `autowire:"@"`
+	userSrv     *service2.UserService `autowire:"@"`
+	chatSrv     *service2.ChatService `autowire:"@"`
+}
+
+// NewPDFController 创建 PDF 对话控制器
+func NewPDFController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &PDFController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *PDFController) Register(router web.Router) {
+	router.Group("/pdf", func(router web.Router) {
+		router.Post("/", ctl.Upload)
+		router.Get("/", ctl.Documents)
+		router.Get("/{id}", ctl.Document)
+		router.Delete("/{id}", ctl.Delete)
+		router.Post("/{id}/chat", ctl.Chat)
+	})
+}
+
+// PDFCitation 回答中引用的 PDF 页码
+type PDFCitation struct {
+	Page    int64   `json:"page"`
+	Excerpt string  `json:"excerpt"`
+	Score   float64 `json:"score"`
+}
+
+type PDFUploadRequest struct {
+	// URL 客户端上传到存储后的文件地址
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// freezeQuota 检查用户智慧果余量，并冻结本次请求所需的智慧果，返回的函数用于解冻
+func (ctl *PDFController) freezeQuota(ctx context.Context, webCtx web.Context, userID int64, needCoins int64) (func(), web.Response) {
+	quota, err := ctl.userSrv.UserQuota(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < needCoins {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, userID, needCoins); err != nil {
+		log.F(log.M{"user_id": userID, "quota": needCoins}).Errorf("freeze user quota failed: %s", err)
+		return func() {}, nil
+	}
+
+	return func() {
+		if err := ctl.userSrv.UnfreezeUserQuota(ctx, userID, needCoins); err != nil {
+			log.F(log.M{"user_id": userID, "quota": needCoins}).Errorf("unfreeze user quota failed: %s", err)
+		}
+	}, nil
+}
+
+// Upload 上传 PDF 文档，服务端提取逐页文本，切分后向量化存储
+func (ctl *PDFController) Upload(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req PDFUploadRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 只允许下载客户端上传到存储中的文件，避免服务端被用于访问内网地址
+	storageDomain := strings.TrimSuffix(ctl.conf.StorageDomain, "/")
+	if storageDomain == "" || !strings.HasPrefix(req.URL, storageDomain+"/") {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if req.Name == "" {
+		req.Name = "PDF"
+	}
+
+	extractCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	pages, err := ctl.extractor.ExtractURL(extractCtx, req.URL)
+	if err != nil {
+		switch {
+		case errors.Is(err, pdf.ErrFileTooLarge):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件过大"), http.StatusBadRequest)
+		case errors.Is(err, pdf.ErrTooManyPages):
+			return webCtx.JSONError(fmt.Sprintf(common.Text(webCtx, ctl.translater, "文档页数不能超过 %d 页"), ctl.conf.PDFMaxPages), http.StatusBadRequest)
+		case errors.Is(err, pdf.ErrNoTextContent):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文档中没有可识别的文字，扫描件请先使用文字识别"), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "url": req.URL}).Errorf("提取 PDF 文本失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文档解析失败"), http.StatusInternalServerError)
+	}
+
+	chunks := pdf.ChunkPages(pages, pdfChunkSize, pdfChunkOverlap)
+	contents := array.Map(chunks, func(item pdf.Chunk, _ int) string { return item.Content })
+
+	modelID := ctl.conf.PDFEmbeddingModel
+	unfreeze, errResp := ctl.freezeQuota(ctx, webCtx, user.ID, coins.GetEmbeddingCoins(modelID, array.Reduce(contents, func(carry int64, item string) int64 {
+		return carry + misc.WordCount(item)
+	}, 0)))
+	if errResp != nil {
+		return errResp
+	}
+	defer unfreeze()
+
+	embedCtx, cancel2 := context.WithTimeout(ctx, 180*time.Second)
+	defer cancel2()
+
+	vectors := make([][]float32, 0, len(contents))
+	var tokens int64
+	for i := 0; i < len(contents); i += pdfEmbeddingBatchSize {
+		end := i + pdfEmbeddingBatchSize
+		if end > len(contents) {
+			end = len(contents)
+		}
+
+		resp, err := ctl.embed.Embedding(embedCtx, embedding.Request{Model: modelID, Input: contents[i:end]})
+		if err != nil {
+			log.F(log.M{"user_id": user.ID, "model": modelID}).Errorf("PDF 向量化失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文档解析失败"), http.StatusBadGateway)
+		}
+
+		vectors = append(vectors, resp.Data...)
+		tokens += resp.Tokens
+	}
+
+	consumed := coins.GetEmbeddingCoins(modelID, tokens)
+	if consumed > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, consumed, repo2.NewQuotaUsedMeta("embedding", modelID)); err != nil {
+			log.F(log.M{"user_id": user.ID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	id, err := ctl.pdfRepo.AddDocument(ctx, repo2.PDFDocumentAddReq{
+		UserID: user.ID,
+		Name:   req.Name,
+		URL:    req.URL,
+		Model:  modelID,
+		Pages:  int64(len(pages)),
+		Coins:  consumed,
+		Chunks: array.Map(chunks, func(item pdf.Chunk, i int) repo2.PDFChunk {
+			return repo2.PDFChunk{Page: int64(item.Page), Content: item.Content, Vector: vectors[i]}
+		}),
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存 PDF 文档失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"id":     id,
+		"name":   req.Name,
+		"pages":  len(pages),
+		"chunks": len(chunks),
+		"coins":  consumed,
+	})
+}
+
+// Documents 用户上传的 PDF 文档列表
+func (ctl *PDFController) Documents(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	docs, err := ctl.pdfRepo.GetDocuments(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询 PDF 文档列表失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": docs})
+}
+
+// resolveDocumentID 解析路径参数中的文档 ID
+func (ctl *PDFController) resolveDocumentID(webCtx web.Context) (int64, web.Response) {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return 0, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	return int64(id), nil
+}
+
+// Document 查询 PDF 文档详情
+func (ctl *PDFController) Document(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, errResp := ctl.resolveDocumentID(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	doc, err := ctl.pdfRepo.GetDocument(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("查询 PDF 文档失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(doc)
+}
+
+// Delete 删除 PDF 文档
+func (ctl *PDFController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, errResp := ctl.resolveDocumentID(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := ctl.pdfRepo.DeleteDocument(ctx, user.ID, id); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("删除 PDF 文档失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+type PDFChatRequest struct {
+	Model    string         `json:"model"`
+	Messages chat2.Messages `json:"messages"`
+	RoomID   int64          `json:"room_id,omitempty"`
+}
+
+// Chat 基于 PDF 文档内容回答问题（非流式），回答中使用 [P页码] 标记引用来源
+func (ctl *PDFController) Chat(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, errResp := ctl.resolveDocumentID(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	var req PDFChatRequest
+	if err := webCtx.Unmarshal(&req); err != nil || len(req.Messages) == 0 || req.Messages[len(req.Messages)-1].Role != "user" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if !array.In(req.Model, array.Map(chat2.Models(ctl.conf, false), func(item chat2.Model, _ int) string { return item.RealID() })) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidModel), http.StatusBadRequest)
+	}
+
+	doc, err := ctl.pdfRepo.GetDocument(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("查询 PDF 文档失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	question := req.Messages[len(req.Messages)-1].Content
+	chatReq := chat2.Request{Model: req.Model, Messages: req.Messages}
+	inputTokens, err := chat2.MessageTokenCount(chatReq.Messages, chatReq.Model)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 检索到的片段会作为上下文追加到请求中，这里额外预留检索片段的费用
+	unfreeze, errResp := ctl.freezeQuota(
+		ctx, webCtx, user.ID,
+		coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), int64(inputTokens)+pdfChunkSize*pdfRetrieveTopK)+coins.GetEmbeddingCoins(doc.Model, misc.WordCount(question)),
+	)
+	if errResp != nil {
+		return errResp
+	}
+	defer unfreeze()
+
+	embedCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	embedResp, err := ctl.embed.Embedding(embedCtx, embedding.Request{Model: doc.Model, Input: []string{question}})
+	if err != nil || len(embedResp.Data) == 0 {
+		log.F(log.M{"user_id": user.ID, "model": doc.Model}).Errorf("问题向量化失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusBadGateway)
+	}
+
+	if embedCoins := coins.GetEmbeddingCoins(doc.Model, embedResp.Tokens); embedCoins > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, embedCoins, repo2.NewQuotaUsedMeta("embedding", doc.Model)); err != nil {
+			log.F(log.M{"user_id": user.ID, "quota": embedCoins}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	chunks, err := ctl.pdfRepo.GetChunks(ctx, doc.Id)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "id": doc.Id}).Errorf("查询 PDF 片段失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	scored := embedding.TopK(embedResp.Data[0], array.Map(chunks, func(item repo2.PDFChunk, _ int) []float32 { return item.Vector }), pdfRetrieveTopK, 0)
+
+	excerpts := make([]string, 0, len(scored))
+	for _, s := range scored {
+		excerpts = append(excerpts, fmt.Sprintf("[P%d]\n%s", chunks[s.Index].Page, chunks[s.Index].Content))
+	}
+
+	systemPrompt := fmt.Sprintf(
+		"You are answering questions about the document \"%s\". Answer only based on the following excerpts. "+
+			"Each excerpt starts with its page number like [P3]. After every statement, cite the page it comes from using the same [P<page>] format. "+
+			"If the excerpts do not contain the answer, say so.\n\n%s",
+		doc.Name,
+		strings.Join(excerpts, "\n\n"),
+	)
+	chatReq.Messages = append(chat2.Messages{{Role: "system", Content: systemPrompt}}, array.Filter(req.Messages, func(item chat2.Message, _ int) bool { return item.Role != "system" })...)
+
+	fixed, _, err := chatReq.Init().Fix(ctl.chat, 10)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	incognito := ctl.chatSrv.RoomIncognito(ctx, user.ID, req.RoomID)
+	questionID, err := ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
+		UserID:    user.ID,
+		Message:   question,
+		Role:      repo2.MessageRoleUser,
+		RoomID:    req.RoomID,
+		Model:     req.Model,
+		Incognito: incognito,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存用户聊天请求失败（问题部分）: %s", err)
+	}
+
+	chatCtx, cancel2 := context.WithTimeout(ctx, 180*time.Second)
+	defer cancel2()
+
+	resp, err := ctl.chat.Chat(chatCtx, *fixed)
+	if err == nil && resp.ErrorCode != "" {
+		err = fmt.Errorf("%s %s", resp.ErrorCode, resp.Error)
+	}
+
+	var replyText, errorMessage string
+	var citations []PDFCitation
+	var consumed int64
+	var realTokens int
+	if err == nil {
+		replyText = resp.Text
+		realTokens, _ = chat2.MessageTokenCount(append(fixed.Messages, chat2.Message{Role: "assistant", Content: replyText}), fixed.Model)
+		consumed = coins.GetOpenAITextCoins(fixed.ResolveCalFeeModel(ctl.conf), int64(realTokens))
+		if consumed > 0 {
+			if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, consumed, repo2.NewQuotaUsedMeta("pdf-chat", req.Model)); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+			}
+		}
+
+		citations = resolvePDFCitations(replyText, chunks, scored)
+	} else {
+		log.F(log.M{"user_id": user.ID, "model": req.Model, "document_id": doc.Id}).Errorf("PDF 对话失败: %s", err)
+		errorMessage = err.Error()
+	}
+
+	citationsData, _ := json.Marshal(citations)
+	answerID, err2 := ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
+		UserID:        user.ID,
+		Message:       replyText,
+		Role:          repo2.MessageRoleAssistant,
+		QuotaConsumed: consumed,
+		TokenConsumed: int64(realTokens),
+		RoomID:        req.RoomID,
+		Model:         req.Model,
+		PID:           questionID,
+		Status:        int64(ternary.If(errorMessage != "", repo2.MessageStatusFailed, repo2.MessageStatusSucceed)),
+		Error:         errorMessage,
+		Citations:     ternary.If(len(citations) > 0, string(citationsData), ""),
+		Incognito:     incognito,
+	})
+	if err2 != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("add message failed: %s", err2)
+	}
+
+	if err != nil {
+		if errors.Is(err, chat2.ErrContentFilter) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, chat2.ErrContentFilter.Error()), http.StatusBadRequest)
+		}
+
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusBadGateway)
+	}
+
+	return webCtx.JSON(web.M{
+		"id":          answerID,
+		"question_id": questionID,
+		"text":        replyText,
+		"citations":   citations,
+		"coins":       consumed,
+	})
+}
+
+// resolvePDFCitations 根据回答中的页码标记生成引用列表，模型没有标记页码时使用检索到的片段作为引用
+func resolvePDFCitations(replyText string, chunks []repo2.PDFChunk, scored []embedding.Scored) []PDFCitation {
+	cited := pdf.ParseCitations(replyText)
+
+	citations := make([]PDFCitation, 0)
+	seen := make(map[int64]bool)
+	for _, s := range scored {
+		chunk := chunks[s.Index]
+		if seen[chunk.Page] || (len(cited) > 0 && !array.In(int(chunk.Page), cited)) {
+			continue
+		}
+
+		seen[chunk.Page] = true
+		citations = append(citations, PDFCitation{
+			Page:    chunk.Page,
+			Excerpt: misc.SubString(chunk.Content, 200),
+			Score:   s.Score,
+		})
+	}
+
+	return citations
+}
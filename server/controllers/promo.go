@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// PromoController 抽奖活动
+type PromoController struct {
+	conf       *config.Config
+	translater youdao.Translater `autowire:"@"`
+	promoRepo  *repo2.PromoRepo  `autowire:"@"`
+}
+
+// NewPromoController 创建抽奖活动控制器
+func NewPromoController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &PromoController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *PromoController) Register(router web.Router) {
+	router.Group("/promo", func(router web.Router) {
+		router.Get("/events", ctl.Events)
+		router.Post("/events/{id}/draw", ctl.Draw)
+		router.Get("/draws", ctl.Draws)
+		router.Post("/coupons/redeem", ctl.RedeemCoupon)
+	})
+}
+
+// Events 当前正在进行中的活动列表，包含奖品以及用户剩余的抽奖次数
+func (ctl *PromoController) Events(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	events, err := ctl.promoRepo.Events(ctx, true)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询活动列表失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	res := make([]web.M, 0, len(events))
+	for _, event := range events {
+		prizes, err := ctl.promoRepo.Prizes(ctx, event.Id)
+		if err != nil {
+			log.F(log.M{"event_id": event.Id}).Errorf("查询活动奖品失败: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		today, total, err := ctl.promoRepo.UserDrawCount(ctx, event.Id, user.ID)
+		if err != nil {
+			log.F(log.M{"event_id": event.Id, "user_id": user.ID}).Errorf("查询用户抽奖次数失败: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		// -1 表示不限制次数
+		remain := int64(-1)
+		if event.DailyLimit > 0 {
+			remain = event.DailyLimit - today
+		}
+		if event.TotalLimit > 0 && (remain < 0 || event.TotalLimit-total < remain) {
+			remain = event.TotalLimit - total
+		}
+		if remain < -1 {
+			remain = 0
+		}
+
+		res = append(res, web.M{
+			"id":          event.Id,
+			"name":        event.Name,
+			"description": event.Description,
+			"start_at":    event.StartAt,
+			"end_at":      event.EndAt,
+			"remain":      remain,
+			"prizes": array.Map(prizes, func(p model.PromoPrize, _ int) web.M {
+				return web.M{"id": p.Id, "name": p.Name, "prize_type": p.PrizeType, "value": p.Value}
+			}),
+		})
+	}
+
+	return webCtx.JSON(web.M{"data": res})
+}
+
+// Draw 抽奖
+func (ctl *PromoController) Draw(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	eventID, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil || eventID <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	draw, err := ctl.promoRepo.Draw(ctx, int64(eventID), user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo2.ErrNotFound):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		case errors.Is(err, repo2.ErrPromoEventNotActive):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "活动未开始或已结束"), http.StatusBadRequest)
+		case errors.Is(err, repo2.ErrPromoDrawLimit):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "抽奖次数已用完"), http.StatusTooManyRequests)
+		}
+
+		log.F(log.M{"event_id": eventID, "user_id": user.ID}).Errorf("抽奖失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"id":          draw.Id,
+		"prize_id":    draw.PrizeId,
+		"prize_type":  draw.PrizeType,
+		"value":       draw.Value,
+		"coupon_code": draw.CouponCode,
+	})
+}
+
+// Draws 用户的抽奖记录
+func (ctl *PromoController) Draws(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	draws, err := ctl.promoRepo.UserDraws(ctx, user.ID, 100)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询抽奖记录失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": draws})
+}
+
+// RedeemCoupon 兑换抽奖获得的兑换券
+func (ctl *PromoController) RedeemCoupon(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	code := strings.ToUpper(strings.TrimSpace(webCtx.Input("code")))
+	if code == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	draw, err := ctl.promoRepo.RedeemCoupon(ctx, user.ID, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo2.ErrNotFound):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		case errors.Is(err, repo2.ErrCouponRedeemed):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "兑换券已被使用"), http.StatusConflict)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("兑换券兑换失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"value": draw.Value})
+}
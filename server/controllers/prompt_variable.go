@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// maxPromptVariables 每个用户最多可以设置的自定义变量数量
+	maxPromptVariables = 50
+	// maxPromptVariableValueLength 变量值的最大长度
+	maxPromptVariableValueLength = 4000
+)
+
+// PromptVariableController 提示语变量管理
+type PromptVariableController struct {
+	translater         youdao.Translater               `autowire:"@"`
+	promptVariableRepo *repo2.PromptVariableRepo       `autowire:"@"`
+	promptVariable     *service2.PromptVariableService `autowire:"@"`
+}
+
+// NewPromptVariableController 创建提示语变量控制器
+func NewPromptVariableController(resolver infra.Resolver) web.Controller {
+	ctl := &PromptVariableController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *PromptVariableController) Register(router web.Router) {
+	router.Group("/users/prompt-variables", func(router web.Router) {
+		router.Get("/", ctl.Variables)
+		router.Post("/preview", ctl.Preview)
+		router.Put("/{name}", ctl.Set)
+		router.Delete("/{name}", ctl.Delete)
+	})
+}
+
+// Variables 当前用户的自定义变量以及可用的内置变量
+func (ctl *PromptVariableController) Variables(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	vars, err := ctl.promptVariableRepo.Variables(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询提示语变量失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":    vars,
+		"builtin": service2.BuiltinPromptVariableValues(time.Now(), user.Name),
+	})
+}
+
+// Preview 预览提示语中的变量展开后的内容
+func (ctl *PromptVariableController) Preview(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	text := webCtx.Input("text")
+	if err := ctl.promptVariable.Expand(ctx, user.ID, user.Name, &text); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("展开提示语变量失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"text": text})
+}
+
+// Set 新增或更新自定义变量，memory 变量用于保存希望 AI 记住的信息
+func (ctl *PromptVariableController) Set(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	name := webCtx.PathVar("name")
+	if !service2.PromptVariableNamePattern.MatchString(name) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "变量名称只能包含字母、数字和下划线，且不能以数字开头"), http.StatusBadRequest)
+	}
+
+	if array.In(name, service2.BuiltinPromptVariables) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "不能覆盖内置变量"), http.StatusBadRequest)
+	}
+
+	value := strings.TrimSpace(webCtx.Input("value"))
+	if len([]rune(value)) > maxPromptVariableValueLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "变量值过长"), http.StatusBadRequest)
+	}
+
+	vars, err := ctl.promptVariableRepo.Variables(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询提示语变量失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	exists := array.In(name, array.Map(vars, func(v repo2.PromptVariable, _ int) string { return v.Name }))
+	if !exists && len(vars) >= maxPromptVariables {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "自定义变量数量已达到上限"), http.StatusBadRequest)
+	}
+
+	if err := ctl.promptVariableRepo.SetVariable(ctx, user.ID, name, value); err != nil {
+		log.F(log.M{"user_id": user.ID, "name": name}).Errorf("保存提示语变量失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Delete 删除自定义变量
+func (ctl *PromptVariableController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if err := ctl.promptVariableRepo.DeleteVariable(ctx, user.ID, webCtx.PathVar("name")); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("删除提示语变量失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
@@ -0,0 +1,522 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/voice"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	oai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	// realtimeVoiceIdleTimeout 客户端超过该时间没有发送任何消息时结束会话
+	realtimeVoiceIdleTimeout = 2 * time.Minute
+	// realtimeVoiceSettleInterval 会话期间结算费用的周期，结算时检查剩余的智慧果是否足够继续对话
+	realtimeVoiceSettleInterval = time.Minute
+	// realtimeVoiceMaxAudioSize 单次发言的最大音频大小
+	realtimeVoiceMaxAudioSize = 2 * 1024 * 1024
+	// realtimeVoiceMaxHistory 会话中作为上下文的最大对话轮数
+	realtimeVoiceMaxHistory = 10
+)
+
+// 实时语音对话的事件类型
+const (
+	// 客户端发送的事件，音频数据使用二进制消息发送
+	realtimeEventCommit    = "commit"
+	realtimeEventInterrupt = "interrupt"
+	realtimeEventEnd       = "end"
+
+	// 服务端发送的事件，response.audio 事件之后紧跟一条包含 mp3 音频的二进制消息
+	realtimeEventSessionStarted = "session.started"
+	realtimeEventSessionEnded   = "session.ended"
+	realtimeEventTranscript     = "transcript.user"
+	realtimeEventTextDelta      = "response.text.delta"
+	realtimeEventAudio          = "response.audio"
+	realtimeEventResponseDone   = "response.done"
+	realtimeEventError          = "error"
+)
+
+// RealtimeVoiceController 实时语音对话，语音识别 → 模型对话 → 语音合成，支持在 AI 回复过程中打断
+type RealtimeVoiceController struct {
+	conf        *config.Config
+	voice       *voice.Voice               `autowire:"@"`
+	chat        chat2.Chat                 `autowire:"@"`
+	translater  youdao.Translater          `autowire:"@"`
+	roomRepo    *repo2.RoomRepo            `autowire:"@"`
+	messageRepo *repo2.MessageRepo         `autowire:"@"`
+	quotaRepo   *repo2.QuotaRepo           `autowire:"@"`
+	userSrv     *service2.UserService      `autowire:"@"`
+	geoPolicy   *service2.GeoPolicyService `autowire:"@"`
+
+	upgrader websocket.Upgrader
+}
+
+// NewRealtimeVoiceController 创建实时语音对话控制器
+func NewRealtimeVoiceController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &RealtimeVoiceController{conf: conf}
+	resolver.MustAutoWire(ctl)
+
+	ctl.upgrader = websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+	return ctl
+}
+
+func (ctl *RealtimeVoiceController) Register(router web.Router) {
+	router.Group("/voice", func(router web.Router) {
+		router.Get("/realtime", ctl.Realtime)
+	})
+}
+
+// RealtimeEvent 实时语音对话的事件
+type RealtimeEvent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	// Seq 语音片段的序号
+	Seq int `json:"seq,omitempty"`
+	// Interrupted 回复是否被用户打断
+	Interrupted bool   `json:"interrupted,omitempty"`
+	Seconds     int64  `json:"seconds,omitempty"`
+	Coins       int64  `json:"coins,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Realtime 实时语音对话（WebSocket），按照会话时长计费，对话内容保存到 room_id 对应的会话历史中
+//
+// 查询参数：room_id 会话 ID，model 使用的模型（为空时使用会话的模型），voice 回复使用的音色，format 上传的音频格式（默认 wav）
+//
+// 客户端通过二进制消息发送用户的语音，一句话说完后发送 commit 事件；AI 回复期间收到新的语音或者 interrupt 事件时，
+// 停止当前回复（打断），已经播放的内容会保存到会话历史中；发送 end 事件结束会话
+func (ctl *RealtimeVoiceController) Realtime(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo, w http.ResponseWriter) {
+	conn, err := ctl.upgrader.Upgrade(w, webCtx.Request().Raw(), nil)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("upgrade websocket failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	sess := &realtimeSession{ctl: ctl, conn: conn, user: user, webCtx: webCtx}
+	if errMsg := sess.init(ctx, client); errMsg != "" {
+		sess.write(RealtimeEvent{Type: realtimeEventError, Error: sess.text(errMsg)})
+		return
+	}
+
+	sess.run(ctx)
+}
+
+type realtimeSession struct {
+	ctl    *RealtimeVoiceController
+	conn   *websocket.Conn
+	user   *auth.User
+	webCtx web.Context
+
+	roomID       int64
+	incognito    bool
+	model        string
+	systemPrompt string
+	voice        oai.SpeechVoice
+	format       string
+
+	writeLock sync.Mutex
+	audio     bytes.Buffer
+
+	// history 会话中已完成的对话
+	history chat2.Messages
+
+	// 当前正在进行的回复
+	cancelResponse context.CancelFunc
+	responseDone   chan struct{}
+
+	startedAt     time.Time
+	billedSeconds int64
+}
+
+func (sess *realtimeSession) init(ctx context.Context, client *auth.ClientInfo) string {
+	ctl, webCtx := sess.ctl, sess.webCtx
+	if !ctl.conf.EnableWebsocket {
+		return "实时语音对话功能暂未开放"
+	}
+
+	if ctl.geoPolicy.Decide(ctx, client.Region, repo2.GeoFeatureVoice, "").Blocked {
+		return common.ErrRegionRestricted
+	}
+
+	sess.roomID = webCtx.Int64Input("room_id", 0)
+	sess.model = webCtx.Input("model")
+	sess.voice = oai.SpeechVoice(webCtx.InputWithDefault("voice", string(oai.VoiceNova)))
+	sess.format = webCtx.InputWithDefault("format", "wav")
+
+	if sess.roomID > 0 {
+		room, err := ctl.roomRepo.Room(ctx, sess.user.ID, sess.roomID)
+		if err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				return common.ErrNotFound
+			}
+
+			log.F(log.M{"user_id": sess.user.ID, "room_id": sess.roomID}).Errorf("查询会话失败: %v", err)
+			return common.ErrInternalError
+		}
+
+		if sess.model == "" {
+			sess.model = room.Model
+		}
+		sess.systemPrompt = room.SystemPrompt
+		sess.incognito = room.Incognito == 1
+	}
+
+	sess.model = chat2.Request{Model: sess.model}.Init().Model
+	if sess.model == "" {
+		return common.ErrInvalidRequest
+	}
+
+	// 至少需要足够支付一个结算周期的智慧果
+	if !sess.quotaEnough(ctx) {
+		return common.ErrQuotaNotEnough
+	}
+
+	return ""
+}
+
+func (sess *realtimeSession) text(msg string) string {
+	return common.Text(sess.webCtx, sess.ctl.translater, msg)
+}
+
+func (sess *realtimeSession) quotaEnough(ctx context.Context) bool {
+	quota, err := sess.ctl.userSrv.UserQuota(ctx, sess.user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": sess.user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return false
+	}
+
+	return quota.Rest-quota.Freezed >= coins.GetRealtimeVoiceCoins(realtimeVoiceSettleInterval)
+}
+
+func (sess *realtimeSession) write(evt RealtimeEvent) {
+	data, _ := json.Marshal(evt)
+
+	sess.writeLock.Lock()
+	defer sess.writeLock.Unlock()
+
+	if err := sess.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		log.F(log.M{"user_id": sess.user.ID}).Debugf("write realtime event failed: %v", err)
+	}
+}
+
+// writeAudio 发送一个语音片段，事件与音频数据需要连续发送
+func (sess *realtimeSession) writeAudio(seq int, text string, audio []byte) error {
+	data, _ := json.Marshal(RealtimeEvent{Type: realtimeEventAudio, Seq: seq, Text: text})
+
+	sess.writeLock.Lock()
+	defer sess.writeLock.Unlock()
+
+	if err := sess.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return err
+	}
+
+	return sess.conn.WriteMessage(websocket.BinaryMessage, audio)
+}
+
+func (sess *realtimeSession) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sess.startedAt = time.Now()
+	sess.write(RealtimeEvent{Type: realtimeEventSessionStarted, Coins: coins.GetRealtimeVoiceCoins(time.Second)})
+
+	// 客户端的消息在单独的协程中读取，以便在等待消息时也能够结算费用
+	messages := make(chan realtimeMessage)
+	go func() {
+		defer close(messages)
+		for {
+			_ = sess.conn.SetReadDeadline(time.Now().Add(realtimeVoiceIdleTimeout))
+			typ, data, err := sess.conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case messages <- realtimeMessage{typ: typ, data: data}:
+			}
+		}
+	}()
+
+	maxDuration := sess.ctl.conf.RealtimeVoiceMaxDuration
+	if maxDuration <= 0 {
+		maxDuration = 30 * time.Minute
+	}
+	deadline := time.NewTimer(maxDuration)
+	defer deadline.Stop()
+
+	settleTicker := time.NewTicker(realtimeVoiceSettleInterval)
+	defer settleTicker.Stop()
+
+	defer func() {
+		sess.interrupt()
+
+		seconds, consumed := sess.settle(true)
+		sess.write(RealtimeEvent{Type: realtimeEventSessionEnded, Seconds: seconds, Coins: consumed})
+	}()
+
+	for {
+		select {
+		case <-deadline.C:
+			sess.write(RealtimeEvent{Type: realtimeEventError, Error: sess.text("已达到单次语音对话的最长时长")})
+			return
+		case <-settleTicker.C:
+			sess.settle(false)
+			if !sess.quotaEnough(ctx) {
+				sess.write(RealtimeEvent{Type: realtimeEventError, Error: sess.text(common.ErrQuotaNotEnough)})
+				return
+			}
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			if msg.typ == websocket.BinaryMessage {
+				// AI 回复过程中用户开始说话，打断当前的回复
+				if sess.audio.Len() == 0 {
+					sess.interrupt()
+				}
+
+				if sess.audio.Len()+len(msg.data) > realtimeVoiceMaxAudioSize {
+					sess.audio.Reset()
+					sess.write(RealtimeEvent{Type: realtimeEventError, Error: sess.text(common.ErrFileTooLarge)})
+					continue
+				}
+
+				sess.audio.Write(msg.data)
+				continue
+			}
+
+			var evt RealtimeEvent
+			if err := json.Unmarshal(msg.data, &evt); err != nil {
+				sess.write(RealtimeEvent{Type: realtimeEventError, Error: sess.text(common.ErrInvalidRequest)})
+				continue
+			}
+
+			switch evt.Type {
+			case realtimeEventCommit:
+				if sess.audio.Len() == 0 {
+					continue
+				}
+
+				audio := append([]byte{}, sess.audio.Bytes()...)
+				sess.audio.Reset()
+
+				sess.interrupt()
+				sess.respond(ctx, audio)
+			case realtimeEventInterrupt:
+				sess.interrupt()
+			case realtimeEventEnd:
+				return
+			}
+		}
+	}
+}
+
+type realtimeMessage struct {
+	typ  int
+	data []byte
+}
+
+// interrupt 停止当前的回复，并等待回复结束，保证会话历史的顺序
+func (sess *realtimeSession) interrupt() {
+	if sess.cancelResponse == nil {
+		return
+	}
+
+	sess.cancelResponse()
+	<-sess.responseDone
+
+	sess.cancelResponse, sess.responseDone = nil, nil
+}
+
+// respond 在单独的协程中识别用户的语音，并流式生成文本回复以及语音
+func (sess *realtimeSession) respond(ctx context.Context, audio []byte) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	sess.cancelResponse, sess.responseDone = cancel, done
+
+	go func() {
+		defer close(done)
+		defer cancel()
+
+		question, err := sess.ctl.voice.Transcribe(ctx, audio, sess.format)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.F(log.M{"user_id": sess.user.ID}).Errorf("实时语音对话识别失败: %v", err)
+				sess.write(RealtimeEvent{Type: realtimeEventError, Error: sess.text("语音识别失败，请重新说一遍")})
+			}
+			return
+		}
+
+		question = strings.TrimSpace(question)
+		if question == "" {
+			return
+		}
+
+		sess.write(RealtimeEvent{Type: realtimeEventTranscript, Text: question})
+
+		answer, interrupted := sess.reply(ctx, question)
+		sess.write(RealtimeEvent{Type: realtimeEventResponseDone, Text: answer, Interrupted: interrupted})
+
+		// 会话被关闭时 ctx 已经取消，使用新的上下文保存对话记录
+		sess.saveTranscript(context.Background(), question, answer)
+	}()
+}
+
+// reply 生成回复并逐句合成语音，返回已经发送给用户的回复内容
+func (sess *realtimeSession) reply(ctx context.Context, question string) (string, bool) {
+	messages := chat2.Messages{}
+	if sess.systemPrompt != "" {
+		messages = append(messages, chat2.Message{Role: "system", Content: sess.systemPrompt})
+	}
+
+	history := sess.history
+	if len(history) > realtimeVoiceMaxHistory*2 {
+		history = history[len(history)-realtimeVoiceMaxHistory*2:]
+	}
+	messages = append(append(messages, history...), chat2.Message{Role: "user", Content: question})
+
+	stream, err := sess.ctl.chat.ChatStream(ctx, chat2.Request{Model: sess.model, Messages: messages, RoomID: sess.roomID})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.F(log.M{"user_id": sess.user.ID, "model": sess.model}).Errorf("实时语音对话请求失败: %v", err)
+
+			errMsg := common.ErrInternalError
+			if errors.Is(err, chat2.ErrModelDisabled) || errors.Is(err, chat2.ErrContentFilter) {
+				errMsg = err.Error()
+			}
+			sess.write(RealtimeEvent{Type: realtimeEventError, Error: sess.text(errMsg)})
+		}
+		return "", ctx.Err() != nil
+	}
+
+	var spoken string
+	var seq int
+	var splitter voice.SentenceSplitter
+
+	speak := func(sentence string) bool {
+		if sentence == "" {
+			return true
+		}
+
+		data, err := sess.ctl.voice.Synthesize(ctx, sess.voice, sentence)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.F(log.M{"user_id": sess.user.ID}).Errorf("实时语音对话合成语音失败: %v", err)
+			}
+			return false
+		}
+
+		seq++
+		if err := sess.writeAudio(seq, sentence, data); err != nil {
+			return false
+		}
+
+		spoken += sentence
+		return true
+	}
+
+	for res := range stream {
+		if res.ErrorCode != "" {
+			log.F(log.M{"user_id": sess.user.ID, "model": sess.model}).Errorf("实时语音对话响应失败: %v", res)
+			return spoken, false
+		}
+
+		if res.Text == "" {
+			continue
+		}
+
+		sess.write(RealtimeEvent{Type: realtimeEventTextDelta, Text: res.Text})
+		for _, sentence := range splitter.Write(res.Text) {
+			if !speak(sentence) {
+				return spoken, ctx.Err() != nil
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return spoken, true
+	}
+
+	speak(splitter.Flush())
+	return spoken, ctx.Err() != nil
+}
+
+// saveTranscript 保存对话记录，被打断的回复只保存已经播放的内容
+func (sess *realtimeSession) saveTranscript(ctx context.Context, question, answer string) {
+	sess.history = append(sess.history, chat2.Message{Role: "user", Content: question}, chat2.Message{Role: "assistant", Content: answer})
+
+	questionID, err := sess.ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
+		UserID:    sess.user.ID,
+		Message:   question,
+		Role:      repo2.MessageRoleUser,
+		RoomID:    sess.roomID,
+		Model:     sess.model,
+		Incognito: sess.incognito,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": sess.user.ID}).Errorf("保存实时语音对话记录失败（问题部分）: %s", err)
+	}
+
+	if answer == "" {
+		return
+	}
+
+	if _, err := sess.ctl.messageRepo.Add(ctx, repo2.MessageAddReq{
+		UserID:    sess.user.ID,
+		Message:   answer,
+		Role:      repo2.MessageRoleAssistant,
+		RoomID:    sess.roomID,
+		Model:     sess.model,
+		PID:       questionID,
+		Incognito: sess.incognito,
+	}); err != nil {
+		log.F(log.M{"user_id": sess.user.ID}).Errorf("保存实时语音对话记录失败（回答部分）: %s", err)
+	}
+}
+
+// settle 按照会话时长结算费用，会话期间只结算完整的秒数，会话结束时向上取整，返回会话时长以及累计消耗的智慧果
+func (sess *realtimeSession) settle(final bool) (int64, int64) {
+	elapsed := time.Since(sess.startedAt)
+
+	seconds := int64(elapsed / time.Second)
+	if final && elapsed%time.Second > 0 {
+		seconds++
+	}
+
+	if delta := seconds - sess.billedSeconds; delta > 0 {
+		consumed := coins.GetRealtimeVoiceCoins(time.Duration(delta) * time.Second)
+		if err := sess.ctl.quotaRepo.QuotaConsume(context.Background(), sess.user.ID, consumed, repo2.NewQuotaUsedMeta("realtime-voice", sess.model)); err != nil {
+			log.F(log.M{"user_id": sess.user.ID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+		} else {
+			sess.billedSeconds = seconds
+		}
+	}
+
+	return seconds, coins.GetRealtimeVoiceCoins(time.Duration(sess.billedSeconds) * time.Second)
+}
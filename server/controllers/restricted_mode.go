@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+var restrictedModePINPattern = regexp.MustCompile(`^\d{4,6}$`)
+
+// RestrictedModeController 受限模式（家长模式），通过 PIN 开启与关闭
+type RestrictedModeController struct {
+	conf           *config.Config
+	translater     youdao.Translater         `autowire:"@"`
+	limiter        *rate.RateLimiter         `autowire:"@"`
+	restrictedRepo *repo2.RestrictedModeRepo `autowire:"@"`
+}
+
+// NewRestrictedModeController 创建受限模式控制器
+func NewRestrictedModeController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &RestrictedModeController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *RestrictedModeController) Register(router web.Router) {
+	router.Group("/users/restricted-mode", func(router web.Router) {
+		router.Get("/", ctl.Status)
+		router.Post("/", ctl.Enable)
+		router.Put("/", ctl.Update)
+		router.Delete("/", ctl.Disable)
+	})
+}
+
+// Status 查询当前用户的受限模式状态
+func (ctl *RestrictedModeController) Status(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	item, err := ctl.restrictedRepo.Get(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSON(web.M{"enabled": false})
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("查询受限模式失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	used, err := ctl.restrictedRepo.TodayUsed(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询今日使用额度失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"enabled":        true,
+		"daily_limit":    item.DailyLimit,
+		"today_used":     used,
+		"models":         ctl.conf.RestrictedModeModels,
+		"image_vendors":  ctl.conf.RestrictedModeImageVendors,
+		"strict_content": true,
+	})
+}
+
+// Enable 开启受限模式，需要设置 4-6 位数字 PIN，关闭或修改设置时需要校验该 PIN
+func (ctl *RestrictedModeController) Enable(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	pin := webCtx.Input("pin")
+	if !restrictedModePINPattern.MatchString(pin) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "PIN 必须为 4-6 位数字"), http.StatusBadRequest)
+	}
+
+	dailyLimit := webCtx.Int64Input("daily_limit", ctl.conf.RestrictedModeDailyLimit)
+	if dailyLimit <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.restrictedRepo.Enable(ctx, user.ID, pin, dailyLimit); err != nil {
+		if errors.Is(err, repo2.ErrRestrictedModeEnabled) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "受限模式已开启"), http.StatusConflict)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("开启受限模式失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Update 修改受限模式的每日使用上限，需要校验 PIN
+func (ctl *RestrictedModeController) Update(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	dailyLimit := webCtx.Int64Input("daily_limit", 0)
+	if dailyLimit <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if resp := ctl.verifyPIN(ctx, webCtx, user); resp != nil {
+		return resp
+	}
+
+	if err := ctl.restrictedRepo.UpdateDailyLimit(ctx, user.ID, dailyLimit); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("修改受限模式设置失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Disable 关闭受限模式，需要校验 PIN
+func (ctl *RestrictedModeController) Disable(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if resp := ctl.verifyPIN(ctx, webCtx, user); resp != nil {
+		return resp
+	}
+
+	if err := ctl.restrictedRepo.Disable(ctx, user.ID); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("关闭受限模式失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// verifyPIN 校验请求中的 PIN，为防止暴力破解，每个用户 10 分钟内最多尝试 5 次
+func (ctl *RestrictedModeController) verifyPIN(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if err := ctl.limiter.Allow(ctx, fmt.Sprintf("restricted-mode:pin:%d:limit", user.ID), rate.MaxRequestsInPeriod(5, 10*time.Minute)); err != nil {
+		if errors.Is(err, rate.ErrRateLimitExceeded) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "PIN 尝试次数过多，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("检查 PIN 校验频率失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.restrictedRepo.VerifyPIN(ctx, user.ID, webCtx.Input("pin")); err != nil {
+		switch {
+		case errors.Is(err, repo2.ErrNotFound):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "受限模式未开启"), http.StatusNotFound)
+		case errors.Is(err, repo2.ErrRestrictedModeInvalidPIN):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "PIN 错误"), http.StatusForbidden)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("校验受限模式 PIN 失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return nil
+}
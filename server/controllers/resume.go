@@ -0,0 +1,325 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// resumeMaxLength 简历和职位描述的最大字符数
+	resumeMaxLength = 8000
+	// resumeOutputTokens 预估的模型输出 Token 数，用于冻结智慧果
+	resumeOutputTokens = 1500
+)
+
+// defaultResumeTemplate 后台没有配置模板时使用的默认提示语
+var defaultResumeTemplate = model.PromptTemplate{
+	Scene:        repo2.PromptTemplateSceneResume,
+	Name:         "默认",
+	SystemPrompt: "You are an experienced career coach and professional resume writer. Rewrite resumes so that they are concise, achievement-oriented, quantified where possible and tailored to the target job, without inventing experience the candidate does not have.",
+	UserPrompt:   "Resume:\n{{resume}}\n\nTarget job description:\n{{job_description}}\n\nFeedback on the previous version (may be empty):\n{{feedback}}",
+}
+
+// resumeOutputInstruction 要求模型以固定的 JSON 格式输出结果，追加在所有模板之后
+const resumeOutputInstruction = `
+
+Reply in the same language as the resume, and output only a JSON object with this schema:
+{"bullet_points": ["improved resume bullet point", ...], "cover_letter": "a cover letter for the target job"}`
+
+// ResumeController 简历优化：根据目标职位描述优化简历要点并生成求职信，结果按版本保存，用户可以基于反馈持续迭代
+type ResumeController struct {
+	conf       *config.Config
+	chat       chat2.Chat                `autowire:"@"`
+	translater youdao.Translater         `autowire:"@"`
+	tplRepo    *repo2.PromptTemplateRepo `autowire:"@"`
+	resumeRepo *repo2.ResumeRepo         `autowire:"@"`
+	quotaRepo  *repo2.QuotaRepo          `autowire:"@"`
+	userSrv    *service2.UserService     `autowire:"@"`
+}
+
+// NewResumeController 创建简历优化控制器
+func NewResumeController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &ResumeController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *ResumeController) Register(router web.Router) {
+	router.Group("/resume", func(router web.Router) {
+		router.Get("/templates", ctl.Templates)
+		router.Post("/polish", ctl.Polish)
+		router.Get("/threads", ctl.Threads)
+		router.Get("/threads/{id}", ctl.ThreadVersions)
+		router.Get("/{id}", ctl.Version)
+	})
+}
+
+// Templates 可用的简历优化模板，不返回提示语内容
+func (ctl *ResumeController) Templates(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	templates, err := ctl.tplRepo.Templates(ctx, repo2.PromptTemplateSceneResume, true)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询简历优化模板失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data": array.Map(templates, func(item model.PromptTemplate, _ int) web.M {
+			return web.M{"id": item.Id, "name": item.Name, "description": item.Description}
+		}),
+	})
+}
+
+type ResumePolishRequest struct {
+	// Model 使用的模型，留空时使用默认模型
+	Model string `json:"model"`
+	// TemplateID 模板 ID，为 0 时使用默认模板
+	TemplateID     int64  `json:"template_id"`
+	Resume         string `json:"resume"`
+	JobDescription string `json:"job_description"`
+	// ThreadID 基于已有的简历线程继续迭代，为 0 时创建新的线程
+	ThreadID int64 `json:"thread_id"`
+	// Feedback 用户对上一个版本的修改意见
+	Feedback string `json:"feedback"`
+}
+
+// ResumePolishResult 模型输出的简历优化结果
+type ResumePolishResult struct {
+	BulletPoints []string `json:"bullet_points"`
+	CoverLetter  string   `json:"cover_letter"`
+}
+
+// Polish 优化简历，生成新的版本
+func (ctl *ResumeController) Polish(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req ResumePolishRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	req.Resume, req.JobDescription, req.Feedback = strings.TrimSpace(req.Resume), strings.TrimSpace(req.JobDescription), strings.TrimSpace(req.Feedback)
+	if req.Model == "" {
+		req.Model = ctl.conf.ResumePolishModel
+	}
+
+	// 在已有线程上迭代时，未提供的简历和职位描述沿用最新版本的内容
+	if req.ThreadID > 0 {
+		versions, err := ctl.resumeRepo.GetThreadVersions(ctx, user.ID, req.ThreadID)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID, "thread_id": req.ThreadID}).Errorf("查询简历版本失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		if len(versions) == 0 {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		if req.Resume == "" {
+			req.Resume = versions[0].Resume
+		}
+
+		if req.JobDescription == "" {
+			req.JobDescription = versions[0].JobDescription
+		}
+
+		if req.TemplateID == 0 {
+			req.TemplateID = versions[0].TemplateId
+		}
+	}
+
+	if req.Resume == "" || len([]rune(req.Resume)) > resumeMaxLength || len([]rune(req.JobDescription)) > resumeMaxLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if !array.In(req.Model, array.Map(chat2.Models(ctl.conf, false), func(item chat2.Model, _ int) string { return item.RealID() })) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidModel), http.StatusBadRequest)
+	}
+
+	tpl := defaultResumeTemplate
+	if req.TemplateID > 0 {
+		t, err := ctl.tplRepo.Template(ctx, req.TemplateID)
+		if err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+			}
+
+			log.F(log.M{"user_id": user.ID, "template_id": req.TemplateID}).Errorf("查询简历优化模板失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		if t.Scene != repo2.PromptTemplateSceneResume || t.Status != repo2.PromptTemplateStatusEnabled {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		tpl = *t
+	}
+
+	replacer := strings.NewReplacer(
+		"{{resume}}", req.Resume,
+		"{{job_description}}", req.JobDescription,
+		"{{feedback}}", req.Feedback,
+	)
+
+	chatReq := chat2.Request{
+		Model: req.Model,
+		Messages: chat2.Messages{
+			{Role: "system", Content: replacer.Replace(tpl.SystemPrompt) + resumeOutputInstruction},
+			{Role: "user", Content: replacer.Replace(tpl.UserPrompt)},
+		},
+	}.Init()
+
+	inputTokens, err := chat2.MessageTokenCount(chatReq.Messages, chatReq.Model)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	estimated := coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), int64(inputTokens)+resumeOutputTokens)
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < estimated {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, estimated); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("freeze user quota failed: %s", err)
+	} else {
+		defer func(ctx context.Context) {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, estimated); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("unfreeze user quota failed: %s", err)
+			}
+		}(ctx)
+	}
+
+	text, tokens, err := chatComplete(ctx, ctl.chat, chatReq)
+	consumed := coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), tokens)
+	if consumed > 0 {
+		if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, consumed, repo2.NewQuotaUsedMeta("resume-polish", req.Model)); err != nil {
+			log.F(log.M{"user_id": user.ID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, chat2.ErrContentFilter) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, chat2.ErrContentFilter.Error()), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "model": req.Model}).Errorf("简历优化失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusBadGateway)
+	}
+
+	var result ResumePolishResult
+	if err := json.Unmarshal([]byte(extractJSONObject(text)), &result); err != nil {
+		log.F(log.M{"user_id": user.ID, "model": req.Model, "text": text}).Warningf("简历优化结果无法解析: %s", err)
+		// 模型未按照格式输出时，将完整回复作为求职信保存，避免用户已消耗的智慧果白白浪费
+		result = ResumePolishResult{CoverLetter: text}
+	}
+
+	bulletPoints, _ := json.Marshal(result.BulletPoints)
+	polish, err := ctl.resumeRepo.AddPolish(ctx, repo2.ResumePolishAddReq{
+		UserID:         user.ID,
+		ThreadID:       req.ThreadID,
+		TemplateID:     req.TemplateID,
+		Model:          req.Model,
+		Resume:         req.Resume,
+		JobDescription: req.JobDescription,
+		Feedback:       req.Feedback,
+		BulletPoints:   string(bulletPoints),
+		CoverLetter:    result.CoverLetter,
+		Coins:          consumed,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存简历优化结果失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildResumePolishResponse(*polish))
+}
+
+// Threads 用户的简历线程列表，每个线程返回最新版本
+func (ctl *ResumeController) Threads(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	threads, err := ctl.resumeRepo.GetThreads(ctx, user.ID, 100)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询简历线程失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": threads})
+}
+
+// ThreadVersions 简历线程的所有版本
+func (ctl *ResumeController) ThreadVersions(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	versions, err := ctl.resumeRepo.GetThreadVersions(ctx, user.ID, int64(id))
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "thread_id": id}).Errorf("查询简历版本失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": array.Map(versions, func(item model.ResumePolish, _ int) web.M {
+		return buildResumePolishResponse(item)
+	})})
+}
+
+// Version 简历优化的单个版本
+func (ctl *ResumeController) Version(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	polish, err := ctl.resumeRepo.GetPolish(ctx, user.ID, int64(id))
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("查询简历版本失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(buildResumePolishResponse(*polish))
+}
+
+func buildResumePolishResponse(item model.ResumePolish) web.M {
+	var bulletPoints []string
+	_ = json.Unmarshal([]byte(item.BulletPoints), &bulletPoints)
+
+	return web.M{
+		"id":              item.Id,
+		"thread_id":       item.ThreadId,
+		"version":         item.Version,
+		"template_id":     item.TemplateId,
+		"model":           item.Model,
+		"resume":          item.Resume,
+		"job_description": item.JobDescription,
+		"feedback":        item.Feedback,
+		"bullet_points":   bulletPoints,
+		"cover_letter":    item.CoverLetter,
+		"coins":           item.Coins,
+		"created_at":      item.CreatedAt,
+	}
+}
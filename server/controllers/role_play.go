@@ -0,0 +1,356 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/misc"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/roleplay"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// rolePlayHistoryMessages 每轮对话携带的历史消息数量
+	rolePlayHistoryMessages = 20
+	// rolePlayMaxSettingLength 故事设定的最大字符数
+	rolePlayMaxSettingLength = 4000
+	// rolePlayOutputTokens 预估的单次模型输出 Token 数，用于冻结智慧果
+	rolePlayOutputTokens = 800
+)
+
+// RolePlayController 角色扮演：会话携带结构化状态（角色属性、物品、场景），每轮对话后由模型更新状态并注入到下一轮的提示语中
+type RolePlayController struct {
+	conf         *config.Config
+	chat         chat2.Chat            `autowire:"@"`
+	translater   youdao.Translater     `autowire:"@"`
+	rolePlayRepo *repo2.RolePlayRepo   `autowire:"@"`
+	quotaRepo    *repo2.QuotaRepo      `autowire:"@"`
+	userSrv      *service2.UserService `autowire:"@"`
+}
+
+// NewRolePlayController 创建角色扮演控制器
+func NewRolePlayController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &RolePlayController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *RolePlayController) Register(router web.Router) {
+	router.Group("/role-play", func(router web.Router) {
+		router.Post("/", ctl.Create)
+		router.Get("/", ctl.Sessions)
+		router.Get("/{id}", ctl.Session)
+		router.Delete("/{id}", ctl.Delete)
+		router.Put("/{id}/state", ctl.UpdateState)
+		router.Get("/{id}/messages", ctl.Messages)
+		router.Post("/{id}/chat", ctl.Chat)
+	})
+}
+
+type RolePlayCreateRequest struct {
+	Title string `json:"title"`
+	Model string `json:"model"`
+	// Setting 故事设定，包括世界观、角色设定、玩法规则等
+	Setting string `json:"setting"`
+	// State 初始状态，可选
+	State *roleplay.State `json:"state,omitempty"`
+}
+
+// Create 创建角色扮演会话
+func (ctl *RolePlayController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req RolePlayCreateRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	req.Title, req.Setting = strings.TrimSpace(req.Title), strings.TrimSpace(req.Setting)
+	if req.Setting == "" || len([]rune(req.Setting)) > rolePlayMaxSettingLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if !array.In(req.Model, array.Map(chat2.Models(ctl.conf, false), func(item chat2.Model, _ int) string { return item.RealID() })) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidModel), http.StatusBadRequest)
+	}
+
+	if req.Title == "" {
+		req.Title = misc.SubString(req.Setting, 20)
+	}
+
+	var state roleplay.State
+	if req.State != nil {
+		state = *req.State
+	}
+
+	id, err := ctl.rolePlayRepo.CreateSession(ctx, repo2.RolePlaySessionAddReq{
+		UserID:  user.ID,
+		Title:   req.Title,
+		Model:   req.Model,
+		Setting: req.Setting,
+		State:   state.JSON(),
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("创建角色扮演会话失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id, "state": state.Normalize()})
+}
+
+// Sessions 用户的角色扮演会话列表
+func (ctl *RolePlayController) Sessions(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	sessions, err := ctl.rolePlayRepo.GetSessions(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询角色扮演会话列表失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": array.Map(sessions, func(item model.RolePlaySession, _ int) web.M {
+		return buildRolePlaySessionResponse(item)
+	})})
+}
+
+// Session 角色扮演会话详情，包含当前状态
+func (ctl *RolePlayController) Session(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	sess, resp := ctl.loadSession(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	return webCtx.JSON(buildRolePlaySessionResponse(*sess))
+}
+
+// Delete 删除角色扮演会话
+func (ctl *RolePlayController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.rolePlayRepo.DeleteSession(ctx, user.ID, int64(id)); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("删除角色扮演会话失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// UpdateState 客户端手动修改会话状态
+func (ctl *RolePlayController) UpdateState(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	sess, resp := ctl.loadSession(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	var state roleplay.State
+	if err := webCtx.Unmarshal(&state); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.rolePlayRepo.UpdateState(ctx, user.ID, sess.Id, state.JSON()); err != nil {
+		log.F(log.M{"user_id": user.ID, "id": sess.Id}).Errorf("更新角色扮演状态失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"state": state.Normalize()})
+}
+
+// Messages 角色扮演会话的消息列表，assistant 消息附带该轮对话后的状态快照
+func (ctl *RolePlayController) Messages(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	sess, resp := ctl.loadSession(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	messages, err := ctl.rolePlayRepo.GetMessages(ctx, sess.Id, 200)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "id": sess.Id}).Errorf("查询角色扮演消息失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": array.Map(messages, func(item model.RolePlayMessage, _ int) web.M {
+		ret := web.M{
+			"id":         item.Id,
+			"role":       item.Role,
+			"content":    item.Content,
+			"coins":      item.Coins,
+			"created_at": item.CreatedAt,
+		}
+
+		if item.State != "" {
+			state, _ := roleplay.Parse(item.State)
+			ret["state"] = state
+		}
+
+		return ret
+	})})
+}
+
+type RolePlayChatRequest struct {
+	Message string `json:"message"`
+}
+
+// Chat 进行一轮角色扮演对话：先根据设定、当前状态和历史消息生成回复，再通过结构化输出更新状态
+func (ctl *RolePlayController) Chat(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req RolePlayChatRequest
+	if err := webCtx.Unmarshal(&req); err != nil || strings.TrimSpace(req.Message) == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	sess, resp := ctl.loadSession(ctx, webCtx, user)
+	if resp != nil {
+		return resp
+	}
+
+	state, err := roleplay.Parse(sess.State)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "id": sess.Id}).Warningf("角色扮演状态解析失败，使用空状态: %s", err)
+	}
+
+	history, err := ctl.rolePlayRepo.GetMessages(ctx, sess.Id, rolePlayHistoryMessages)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "id": sess.Id}).Errorf("查询角色扮演消息失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	messages := chat2.Messages{{Role: "system", Content: sess.Setting + "\n\n" + state.Prompt()}}
+	for _, msg := range history {
+		messages = append(messages, chat2.Message{Role: msg.Role, Content: msg.Content})
+	}
+	messages = append(messages, chat2.Message{Role: "user", Content: req.Message})
+
+	chatReq := chat2.Request{Model: sess.Model, Messages: messages}.Init()
+	inputTokens, err := chat2.MessageTokenCount(chatReq.Messages, chatReq.Model)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 每轮对话调用两次模型：生成回复、更新状态
+	estimated := coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), int64(inputTokens)+rolePlayOutputTokens*3)
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < estimated {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, estimated); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("freeze user quota failed: %s", err)
+	} else {
+		defer func(ctx context.Context) {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, estimated); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("unfreeze user quota failed: %s", err)
+			}
+		}(ctx)
+	}
+
+	var totalTokens int64
+	defer func() {
+		consumed := coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), totalTokens)
+		if consumed > 0 {
+			if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, consumed, repo2.NewQuotaUsedMeta("role-play", sess.Model)); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+			}
+		}
+	}()
+
+	reply, tokens, err := chatComplete(ctx, ctl.chat, chatReq)
+	totalTokens += tokens
+	if err != nil {
+		if errors.Is(err, chat2.ErrContentFilter) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, chat2.ErrContentFilter.Error()), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "model": sess.Model}).Errorf("角色扮演对话失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusBadGateway)
+	}
+
+	// 状态更新失败时保留上一轮的状态，不影响本轮回复
+	stateReq := chat2.Request{
+		Model:    sess.Model,
+		Messages: chat2.Messages{{Role: "user", Content: roleplay.UpdateInstruction(state, req.Message, reply)}},
+	}.Init()
+	stateText, tokens, err := chatComplete(ctx, ctl.chat, stateReq)
+	totalTokens += tokens
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "model": sess.Model}).Errorf("角色扮演状态更新失败: %s", err)
+	} else if state, err = roleplay.ParseUpdate(state, stateText); err != nil {
+		log.F(log.M{"user_id": user.ID, "model": sess.Model, "text": stateText}).Warningf("角色扮演状态无法解析: %s", err)
+	}
+
+	consumed := coins.GetOpenAITextCoins(chatReq.ResolveCalFeeModel(ctl.conf), totalTokens)
+	replyID, err := ctl.rolePlayRepo.AddTurn(ctx, repo2.RolePlayTurn{
+		UserID:      user.ID,
+		SessionID:   sess.Id,
+		UserMessage: req.Message,
+		Reply:       reply,
+		State:       state.JSON(),
+		Coins:       consumed,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "id": sess.Id}).Errorf("保存角色扮演对话失败: %s", err)
+	}
+
+	return webCtx.JSON(web.M{
+		"id":    replyID,
+		"reply": reply,
+		"state": state,
+		"coins": consumed,
+	})
+}
+
+// loadSession 根据路径参数查询当前用户的会话，查询失败时返回错误响应
+func (ctl *RolePlayController) loadSession(ctx context.Context, webCtx web.Context, user *auth.User) (*model.RolePlaySession, web.Response) {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	sess, err := ctl.rolePlayRepo.GetSession(ctx, user.ID, int64(id))
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("查询角色扮演会话失败: %s", err)
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return sess, nil
+}
+
+func buildRolePlaySessionResponse(item model.RolePlaySession) web.M {
+	state, _ := roleplay.Parse(item.State)
+	return web.M{
+		"id":         item.Id,
+		"title":      item.Title,
+		"model":      item.Model,
+		"setting":    item.Setting,
+		"state":      state,
+		"turns":      item.Turns,
+		"coins":      item.Coins,
+		"created_at": item.CreatedAt,
+		"updated_at": item.UpdatedAt,
+	}
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+)
+
+// roomFolderNameMaxLength 文件夹名称的最大长度（字符）
+const roomFolderNameMaxLength = 30
+
+// Folders 获取用户的会话文件夹列表
+func (ctl *RoomController) Folders(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	folders, err := ctl.roomRepo.Folders(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户会话文件夹失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": folders})
+}
+
+func (ctl *RoomController) parseFolderName(webCtx web.Context) (string, web.Response) {
+	name := strings.TrimSpace(webCtx.Input("name"))
+	if name == "" {
+		return "", webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件夹名称不能为空"), http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(name) > roomFolderNameMaxLength {
+		return "", webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件夹名称过长"), http.StatusBadRequest)
+	}
+
+	return name, nil
+}
+
+func (ctl *RoomController) folderError(webCtx web.Context, err error) web.Response {
+	switch {
+	case errors.Is(err, repo2.ErrNotFound):
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件夹不存在"), http.StatusNotFound)
+	case errors.Is(err, repo2.ErrRoomFolderExists):
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件夹名称已存在"), http.StatusBadRequest)
+	case errors.Is(err, repo2.ErrRoomFolderLimitExceeded):
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件夹数量已达上限"), http.StatusBadRequest)
+	}
+
+	return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+}
+
+// CreateFolder 创建会话文件夹
+func (ctl *RoomController) CreateFolder(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	name, errResp := ctl.parseFolderName(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	id, err := ctl.roomRepo.CreateFolder(ctx, user.ID, name, webCtx.Int64Input("sort", 0))
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "name": name}).Errorf("创建会话文件夹失败: %v", err)
+		return ctl.folderError(webCtx, err)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// UpdateFolder 修改会话文件夹的名称与排序
+func (ctl *RoomController) UpdateFolder(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	folderID, err := strconv.ParseInt(webCtx.PathVar("folder_id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	name, errResp := ctl.parseFolderName(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := ctl.roomRepo.UpdateFolder(ctx, user.ID, folderID, name, webCtx.Int64Input("sort", 0)); err != nil {
+		log.F(log.M{"user_id": user.ID, "folder_id": folderID}).Errorf("修改会话文件夹失败: %v", err)
+		return ctl.folderError(webCtx, err)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeleteFolder 删除会话文件夹，文件夹中的会话不会被删除
+func (ctl *RoomController) DeleteFolder(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	folderID, err := strconv.ParseInt(webCtx.PathVar("folder_id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.roomRepo.DeleteFolder(ctx, user.ID, folderID); err != nil {
+		log.F(log.M{"user_id": user.ID, "folder_id": folderID}).Errorf("删除会话文件夹失败: %v", err)
+		return ctl.folderError(webCtx, err)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// roomID 解析路径中的会话 ID，默认会话（ID 为 1）不是真实存在的会话，不支持整理
+func (ctl *RoomController) roomID(webCtx web.Context) (int64, web.Response) {
+	roomID, err := strconv.ParseInt(webCtx.PathVar("room_id"), 10, 64)
+	if err != nil || roomID <= 1 {
+		return 0, webCtx.JSONError("invalid room id", http.StatusBadRequest)
+	}
+
+	return roomID, nil
+}
+
+// MoveRoomToFolder 将会话移动到文件夹中，folder_id 为 0 时移出文件夹
+func (ctl *RoomController) MoveRoomToFolder(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	roomID, errResp := ctl.roomID(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	folderID := webCtx.Int64Input("folder_id", 0)
+	if folderID < 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.roomRepo.MoveToFolder(ctx, user.ID, roomID, folderID); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "room_id": roomID, "folder_id": folderID}).Errorf("移动会话到文件夹失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// PinRoom 置顶或者取消置顶会话
+func (ctl *RoomController) PinRoom(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	roomID, errResp := ctl.roomID(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	pinned := webCtx.Input("pinned") == "true"
+	if err := ctl.roomRepo.Pin(ctx, user.ID, roomID, pinned); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "数字人不存在"), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "room_id": roomID}).Errorf("置顶会话失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"pinned": pinned})
+}
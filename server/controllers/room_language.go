@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+)
+
+// ReplyLanguages 会话可以设置的回复语言
+func (ctl *RoomController) ReplyLanguages(webCtx web.Context) web.Response {
+	return webCtx.JSON(web.M{
+		"enabled": ctl.conf.EnableReplyLanguage,
+		"data":    service2.ReplyLanguages(),
+	})
+}
+
+// UpdateReplyLanguage 设置会话的回复语言，language 为空时跟随用户提问的语言，为 off 时不指定回复语言
+func (ctl *RoomController) UpdateReplyLanguage(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	roomID, errResp := ctl.roomID(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	language := webCtx.Input("language")
+	if language != "" && language != service2.ReplyLanguageOff && service2.GetReplyLanguage(language) == nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "不支持的回复语言"), http.StatusBadRequest)
+	}
+
+	if err := ctl.roomRepo.UpdateReplyLanguage(ctx, user.ID, roomID, language); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "数字人不存在"), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "room_id": roomID}).Errorf("设置会话回复语言失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ctl.chatSrv.ForgetRoom(ctx, user.ID, roomID)
+
+	return webCtx.JSON(web.M{"language": language})
+}
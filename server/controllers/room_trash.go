@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+)
+
+// TrashRooms 获取回收站中的会话列表，以及会话占用的存储
+func (ctl *RoomController) TrashRooms(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	rooms, err := ctl.roomRepo.TrashRooms(ctx, user.ID, ctl.conf.RoomTrashRetention, RoomsQueryLimit)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询回收站会话列表失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	usage, err := ctl.roomRepo.StorageUsage(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询会话存储占用失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":           rooms,
+		"usage":          usage,
+		"retention_days": int64(ctl.conf.RoomTrashRetention.Hours() / 24),
+	})
+}
+
+// RestoreRoom 从回收站中恢复会话
+func (ctl *RoomController) RestoreRoom(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	roomID, errResp := ctl.roomID(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := ctl.roomRepo.RestoreRoom(ctx, user.ID, roomID, ctl.conf.RoomTrashRetention); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "会话不存在或者已经超过恢复期限"), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "room_id": roomID}).Errorf("恢复回收站会话失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
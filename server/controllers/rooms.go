@@ -7,6 +7,7 @@ import (
 	"github.com/mylxsw/aidea-server/pkg/misc"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"net/http"
 	"strconv"
@@ -21,13 +22,15 @@ import (
 	"github.com/mylxsw/glacier/infra"
 	"github.com/mylxsw/glacier/web"
 	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
 )
 
 // RoomController 数字人
 type RoomController struct {
-	roomRepo   *repo2.RoomRepo   `autowire:"@"`
-	translater youdao.Translater `autowire:"@"`
-	conf       *config.Config    `autowire:"@"`
+	roomRepo   *repo2.RoomRepo       `autowire:"@"`
+	translater youdao.Translater     `autowire:"@"`
+	conf       *config.Config        `autowire:"@"`
+	chatSrv    *service2.ChatService `autowire:"@"`
 }
 
 func NewRoomController(resolver infra.Resolver) web.Controller {
@@ -42,10 +45,20 @@ func (ctl *RoomController) Register(router web.Router) {
 	router.Group("/rooms", func(router web.Router) {
 		router.Post("/", ctl.CreateRoom)
 		router.Get("/", ctl.Rooms)
+		router.Get("/folders", ctl.Folders)
+		router.Post("/folders", ctl.CreateFolder)
+		router.Put("/folders/{folder_id}", ctl.UpdateFolder)
+		router.Delete("/folders/{folder_id}", ctl.DeleteFolder)
+		router.Get("/trash", ctl.TrashRooms)
+		router.Get("/reply-languages", ctl.ReplyLanguages)
+		router.Post("/trash/{room_id}/restore", ctl.RestoreRoom)
 		router.Get("/{room_id}", ctl.Room)
 		router.Delete("/{room_id}", ctl.DeleteRoom)
 		router.Put("/{room_id}", ctl.UpdateRoom)
 		router.Put("/{room_id}/active-time", ctl.UpdateRoomActiveTime)
+		router.Put("/{room_id}/folder", ctl.MoveRoomToFolder)
+		router.Put("/{room_id}/pin", ctl.PinRoom)
+		router.Put("/{room_id}/reply-language", ctl.UpdateReplyLanguage)
 	})
 
 	router.Group("/room-galleries", func(router web.Router) {
@@ -125,6 +138,18 @@ func (ctl *RoomController) Galleries(ctx context.Context, webCtx web.Context, cl
 			return false
 		}
 
+		if !ctl.conf.EnableDeepSeek && item.Vendor == "deepseek" {
+			return false
+		}
+
+		if !ctl.conf.EnableMoonshot && item.Vendor == "moonshot" {
+			return false
+		}
+
+		if !ctl.conf.EnableZhipu && item.Vendor == "zhipu" {
+			return false
+		}
+
 		// 检查版本是否满足条件
 		if item.VersionMax == "" && item.VersionMin == "" {
 			return true
@@ -281,6 +306,7 @@ func (ctl *RoomController) CreateRoom(ctx context.Context, webCtx web.Context, u
 		AvatarId:       req.AvatarID,
 		AvatarUrl:      req.AvatarURL,
 		InitMessage:    req.InitMessage,
+		Incognito:      ternary.If(req.Incognito != nil && *req.Incognito, int64(1), int64(0)),
 	}
 
 	id, err := ctl.roomRepo.Create(ctx, user.ID, &room, true)
@@ -347,7 +373,7 @@ func (ctl *RoomController) Room(ctx context.Context, webCtx web.Context, user *a
 	return webCtx.JSON(room)
 }
 
-// DeleteRoom 删除数字人
+// DeleteRoom 删除数字人，删除后放入回收站，保留期限内可以恢复
 func (ctl *RoomController) DeleteRoom(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
 	roomID, err := strconv.Atoi(webCtx.PathVar("room_id"))
 	if err != nil {
@@ -373,6 +399,8 @@ type RoomRequest struct {
 	SystemPrompt string `json:"system_prompt,omitempty"`
 	InitMessage  string `json:"init_message,omitempty"`
 	MaxContext   int64  `json:"max_context,omitempty"`
+	// Incognito 无痕模式，为 nil 时表示未指定，更新时保持原有设置
+	Incognito *bool `json:"incognito,omitempty"`
 }
 
 func (ctl *RoomController) parseRoomRequest(webCtx web.Context, isUpdate bool) (*RoomRequest, error) {
@@ -433,6 +461,11 @@ func (ctl *RoomController) parseRoomRequest(webCtx web.Context, isUpdate bool) (
 
 	req.SystemPrompt = systemPrompt
 
+	if incognito := webCtx.Input("incognito"); incognito != "" {
+		enabled := incognito == "true" || incognito == "1"
+		req.Incognito = &enabled
+	}
+
 	avatarId := webCtx.Int64Input("avatar_id", 0)
 	avatarUrl := webCtx.Input("avatar_url")
 
@@ -520,11 +553,24 @@ func (ctl *RoomController) UpdateRoom(ctx context.Context, webCtx web.Context, u
 		room.RoomType = repo2.RoomTypePresetCustom
 	}
 
+	// 无痕模式只影响消息是否保存，不属于房间内容的变化
+	if req.Incognito != nil {
+		// 群聊消息单独保存，不支持无痕模式
+		if *req.Incognito && room.RoomType == repo2.RoomTypeGroupChat {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "群聊不支持无痕模式"), http.StatusBadRequest)
+		}
+
+		room.Incognito = ternary.If(*req.Incognito, int64(1), int64(0))
+	}
+
 	if err := ctl.roomRepo.Update(ctx, user.ID, req.RoomID, room); err != nil {
 		log.F(log.M{"user_id": user.ID, "room_id": req.RoomID}).Errorf("更新用户房间失败: %v", err)
 		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
 	}
 
+	// 房间设置（包括无痕模式）已变更，清除缓存
+	ctl.chatSrv.ForgetRoom(ctx, user.ID, req.RoomID)
+
 	return webCtx.JSON(room)
 }
 
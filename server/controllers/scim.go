@@ -0,0 +1,490 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/scim"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// scimMaxPageSize 用户列表单页最多返回的数量
+const scimMaxPageSize = 200
+
+// SCIMController 组织成员同步接口，企业的 HR/IdP 系统使用组织所有者创建的 Token 创建、停用成员账号，以及设置成员的角色与额度
+type SCIMController struct {
+	queue    *queue.Queue    `autowire:"@"`
+	orgRepo  *repo2.OrgRepo  `autowire:"@"`
+	userRepo *repo2.UserRepo `autowire:"@"`
+}
+
+// NewSCIMController 创建组织成员同步控制器
+func NewSCIMController(resolver infra.Resolver) web.Controller {
+	ctl := &SCIMController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *SCIMController) Register(router web.Router) {
+	router.Group("/scim/v2", func(router web.Router) {
+		router.Get("/Users", ctl.Users)
+		router.Post("/Users", ctl.CreateUser)
+		router.Get("/Users/{id}", ctl.User)
+		router.Put("/Users/{id}", ctl.ReplaceUser)
+		router.Patch("/Users/{id}", ctl.PatchUser)
+		router.Delete("/Users/{id}", ctl.DeleteUser)
+	})
+}
+
+func scimError(webCtx web.Context, status int, detail string) web.Response {
+	return webCtx.JSONWithCode(scim.NewError(status, detail), status)
+}
+
+// org 校验请求中的 Token，返回 Token 所属的组织 ID
+func (ctl *SCIMController) org(ctx context.Context, webCtx web.Context) (int64, web.Response) {
+	token := strings.TrimSpace(strings.TrimPrefix(webCtx.Header("Authorization"), "Bearer "))
+	if token == "" {
+		return 0, scimError(webCtx, http.StatusUnauthorized, "missing bearer token")
+	}
+
+	item, err := ctl.orgRepo.ProvisionTokenOrg(ctx, token)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return 0, scimError(webCtx, http.StatusUnauthorized, "invalid bearer token")
+		}
+
+		log.Errorf("查询成员同步 Token 失败: %v", err)
+		return 0, scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	return item.OrgId, nil
+}
+
+func scimRole(role int64) string {
+	return ternary.If(role == repo2.OrgRoleAdmin, "admin", ternary.If(role == repo2.OrgRoleOwner, "owner", "member"))
+}
+
+func scimUser(item repo2.OrgMemberItem) scim.User {
+	active := item.Status == repo2.OrgMemberStatusActive
+	limit := item.MonthlyLimit
+	user := scim.User{
+		Schemas:     []string{scim.SchemaUser, scim.SchemaOrgUser},
+		ID:          strconv.Itoa(int(item.UserID)),
+		UserName:    ternary.If(item.Email != "", item.Email, item.Phone),
+		DisplayName: item.Name,
+		Active:      &active,
+		Org:         &scim.OrgExtension{Role: scimRole(item.Role), MonthlyLimit: &limit},
+		Meta:        &scim.Meta{ResourceType: "User", Created: item.CreatedAt},
+	}
+
+	if item.Email != "" {
+		user.Emails = []scim.Email{{Value: item.Email, Primary: true}}
+	}
+
+	return user
+}
+
+// members 查询组织中的所有成员，组织所有者不通过该接口管理
+func (ctl *SCIMController) members(ctx context.Context, orgID int64) ([]scim.User, error) {
+	items, err := ctl.orgRepo.Members(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return array.Map(
+		array.Filter(items, func(item repo2.OrgMemberItem, _ int) bool { return item.Role != repo2.OrgRoleOwner }),
+		func(item repo2.OrgMemberItem, _ int) scim.User { return scimUser(item) },
+	), nil
+}
+
+func (ctl *SCIMController) member(ctx context.Context, webCtx web.Context, orgID int64) (*scim.User, web.Response) {
+	users, err := ctl.members(ctx, orgID)
+	if err != nil {
+		log.F(log.M{"org_id": orgID}).Errorf("查询组织成员失败: %v", err)
+		return nil, scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	id := webCtx.PathVar("id")
+	for _, u := range users {
+		if u.ID == id {
+			return &u, nil
+		}
+	}
+
+	return nil, scimError(webCtx, http.StatusNotFound, "user not found")
+}
+
+// Users 查询组织成员，支持 userName eq "xxx" 查询条件
+func (ctl *SCIMController) Users(ctx context.Context, webCtx web.Context) web.Response {
+	orgID, resp := ctl.org(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	users, err := ctl.members(ctx, orgID)
+	if err != nil {
+		log.F(log.M{"org_id": orgID}).Errorf("查询组织成员失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	if filter := webCtx.Input("filter"); filter != "" {
+		attr, value, err := scim.ParseFilter(filter)
+		if err != nil || attr != "username" {
+			return scimError(webCtx, http.StatusBadRequest, scim.ErrUnsupportedFilter.Error())
+		}
+
+		users = array.Filter(users, func(u scim.User, _ int) bool { return strings.EqualFold(u.UserName, value) })
+	}
+
+	count := int(webCtx.Int64Input("count", scimMaxPageSize))
+	if count > scimMaxPageSize {
+		count = scimMaxPageSize
+	}
+
+	return webCtx.JSON(scim.NewListResponse(users, int(webCtx.Int64Input("startIndex", 1)), count))
+}
+
+// User 查询组织成员
+func (ctl *SCIMController) User(ctx context.Context, webCtx web.Context) web.Response {
+	orgID, resp := ctl.org(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	user, resp := ctl.member(ctx, webCtx, orgID)
+	if resp != nil {
+		return resp
+	}
+
+	return webCtx.JSON(user)
+}
+
+// CreateUser 将用户加入组织
+//
+// 用户不存在时，只为组织已验证域名下的邮箱自动创建账号，成员不需要接受邀请；
+// 其它已经存在的用户只会收到组织邀请，需要用户接受邀请后才能成为组织成员
+func (ctl *SCIMController) CreateUser(ctx context.Context, webCtx web.Context) web.Response {
+	orgID, resp := ctl.org(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	var req scim.User
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return scimError(webCtx, http.StatusBadRequest, "invalid request body")
+	}
+
+	user, err := ctl.provisionAccount(ctx, orgID, req)
+	if err != nil {
+		if errors.Is(err, repo2.ErrUserAccountDisabled) {
+			return scimError(webCtx, http.StatusConflict, err.Error())
+		}
+
+		if errors.Is(err, errInvalidSCIMUserName) {
+			return scimError(webCtx, http.StatusBadRequest, err.Error())
+		}
+
+		if errors.Is(err, errSCIMAccountNotAllowed) {
+			return scimError(webCtx, http.StatusForbidden, err.Error())
+		}
+
+		log.F(log.M{"org_id": orgID}).Errorf("创建成员账号失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	if _, err := ctl.orgRepo.OrgMember(ctx, orgID, user.Id); err == nil {
+		return scimError(webCtx, http.StatusConflict, "user is already a member of the organization")
+	} else if !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"org_id": orgID, "user_id": user.Id}).Errorf("查询组织成员失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	activatable, err := ctl.activatable(ctx, orgID, user.Id)
+	if err != nil {
+		log.F(log.M{"org_id": orgID, "user_id": user.Id}).Errorf("查询成员账号来源失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	// 不能直接启用的账号，按照正常的邀请流程加入组织
+	if !activatable {
+		if err := ctl.orgRepo.Invite(ctx, orgID, 0, user.Id); err != nil {
+			if errors.Is(err, repo2.ErrOrgMemberExists) {
+				return scimError(webCtx, http.StatusConflict, "user is already a member of the organization")
+			}
+
+			log.F(log.M{"org_id": orgID, "user_id": user.Id}).Errorf("邀请组织成员失败: %v", err)
+			return scimError(webCtx, http.StatusInternalServerError, "internal error")
+		}
+
+		ctl.audit(ctx, orgID, repo2.OrgAuditMemberInvited, fmt.Sprintf("user_id=%d", user.Id))
+	}
+
+	if resp := ctl.save(ctx, webCtx, orgID, user.Id, req); resp != nil {
+		return resp
+	}
+
+	return ctl.respondMember(ctx, webCtx, orgID, user.Id, http.StatusCreated)
+}
+
+// ReplaceUser 使用请求中的属性覆盖成员的状态、角色与额度
+func (ctl *SCIMController) ReplaceUser(ctx context.Context, webCtx web.Context) web.Response {
+	orgID, resp := ctl.org(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	current, resp := ctl.member(ctx, webCtx, orgID)
+	if resp != nil {
+		return resp
+	}
+
+	var req scim.User
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return scimError(webCtx, http.StatusBadRequest, "invalid request body")
+	}
+
+	userID, _ := strconv.Atoi(current.ID)
+	if resp := ctl.save(ctx, webCtx, orgID, int64(userID), req); resp != nil {
+		return resp
+	}
+
+	return ctl.respondMember(ctx, webCtx, orgID, int64(userID), http.StatusOK)
+}
+
+// PatchUser 修改成员的部分属性，HR 系统一般使用 active=false 停用离职员工
+func (ctl *SCIMController) PatchUser(ctx context.Context, webCtx web.Context) web.Response {
+	orgID, resp := ctl.org(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	current, resp := ctl.member(ctx, webCtx, orgID)
+	if resp != nil {
+		return resp
+	}
+
+	var req scim.PatchRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return scimError(webCtx, http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := current.ApplyPatch(req.Operations); err != nil {
+		return scimError(webCtx, http.StatusBadRequest, err.Error())
+	}
+
+	userID, _ := strconv.Atoi(current.ID)
+	if resp := ctl.save(ctx, webCtx, orgID, int64(userID), *current); resp != nil {
+		return resp
+	}
+
+	return ctl.respondMember(ctx, webCtx, orgID, int64(userID), http.StatusOK)
+}
+
+// DeleteUser 将成员移出组织，用户的个人账号不受影响
+func (ctl *SCIMController) DeleteUser(ctx context.Context, webCtx web.Context) web.Response {
+	orgID, resp := ctl.org(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	current, resp := ctl.member(ctx, webCtx, orgID)
+	if resp != nil {
+		return resp
+	}
+
+	userID, _ := strconv.Atoi(current.ID)
+	if err := ctl.orgRepo.RemoveMember(ctx, orgID, int64(userID)); err != nil {
+		log.F(log.M{"org_id": orgID, "user_id": userID}).Errorf("移除组织成员失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	ctl.audit(ctx, orgID, repo2.OrgAuditMemberDeprovisioned, fmt.Sprintf("user_id=%d", userID))
+
+	return webCtx.Raw(func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+var (
+	errInvalidSCIMUserName   = errors.New("userName should be an email or a phone number")
+	errSCIMAccountNotAllowed = errors.New("user not found, accounts can only be created for emails in a verified domain of the organization")
+)
+
+// provisionAccount 按邮箱或者手机号查询用户，不存在时只为组织已验证域名下的邮箱自动创建账号
+func (ctl *SCIMController) provisionAccount(ctx context.Context, orgID int64, req scim.User) (*model.Users, error) {
+	username := req.Username()
+
+	var user *model.Users
+	var err error
+	switch {
+	case isEmail(username):
+		user, err = ctl.userRepo.GetUserByEmail(ctx, username)
+	case isPhoneNumber(username):
+		user, err = ctl.userRepo.GetUserByPhone(ctx, username)
+	default:
+		return nil, errInvalidSCIMUserName
+	}
+	if err == nil || !errors.Is(err, repo2.ErrNotFound) {
+		return user, err
+	}
+
+	// 无法确认手机号以及未验证域名下的邮箱属于该组织，不能为其创建账号
+	if !isEmail(username) {
+		return nil, errSCIMAccountNotAllowed
+	}
+
+	verified, err := ctl.orgRepo.EmailInVerifiedDomain(ctx, orgID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !verified {
+		return nil, errSCIMAccountNotAllowed
+	}
+
+	user, eventID, err := ctl.userRepo.SignUpEmail(ctx, username, "", req.Realname())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctl.orgRepo.MarkProvisionedAccount(ctx, orgID, user.Id); err != nil {
+		return nil, err
+	}
+
+	if eventID > 0 {
+		payload := queue.SignupPayload{
+			UserID:    user.Id,
+			Email:     username,
+			EventID:   eventID,
+			CreatedAt: time.Now(),
+		}
+
+		if _, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewSignupTask, asynq.Queue("user")); err != nil {
+			log.WithFields(log.Fields{
+				"username": username,
+				"event_id": eventID,
+			}).Errorf("failed to enqueue signup task: %s", err)
+		}
+	}
+
+	return user, nil
+}
+
+// activatable 成员同步接口是否可以直接启用该账号，只有成员同步接口创建的账号以及组织已验证域名下的邮箱账号可以直接启用
+func (ctl *SCIMController) activatable(ctx context.Context, orgID, userID int64) (bool, error) {
+	provisioned, err := ctl.orgRepo.ProvisionedAccount(ctx, orgID, userID)
+	if err != nil || provisioned {
+		return provisioned, err
+	}
+
+	user, err := ctl.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if user.Email == "" {
+		return false, nil
+	}
+
+	return ctl.orgRepo.EmailInVerifiedDomain(ctx, orgID, user.Email)
+}
+
+// memberStatus 成员同步后的状态，不能直接启用的账号保持在邀请状态，需要用户接受邀请
+func (ctl *SCIMController) memberStatus(ctx context.Context, orgID, userID int64, active bool) (int64, error) {
+	if !active {
+		return repo2.OrgMemberStatusDisabled, nil
+	}
+
+	member, err := ctl.orgRepo.OrgMember(ctx, orgID, userID)
+	if err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		return 0, err
+	}
+
+	// 用户已经接受邀请
+	if member != nil && member.Status == repo2.OrgMemberStatusActive {
+		return repo2.OrgMemberStatusActive, nil
+	}
+
+	activatable, err := ctl.activatable(ctx, orgID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return ternary.If(activatable, repo2.OrgMemberStatusActive, repo2.OrgMemberStatusInvited), nil
+}
+
+// save 保存成员的状态、角色与额度，未指定的额度保持为 0（不限制）
+func (ctl *SCIMController) save(ctx context.Context, webCtx web.Context, orgID, userID int64, req scim.User) web.Response {
+	role, limit := repo2.OrgRoleMember, int64(0)
+	if req.Org != nil {
+		switch req.Org.Role {
+		case "", "member":
+		case "admin":
+			role = repo2.OrgRoleAdmin
+		default:
+			return scimError(webCtx, http.StatusBadRequest, "role should be member or admin")
+		}
+
+		if req.Org.MonthlyLimit != nil {
+			if *req.Org.MonthlyLimit < 0 {
+				return scimError(webCtx, http.StatusBadRequest, "monthlyLimit should not be negative")
+			}
+
+			limit = *req.Org.MonthlyLimit
+		}
+	}
+
+	status, err := ctl.memberStatus(ctx, orgID, userID, req.IsActive())
+	if err != nil {
+		log.F(log.M{"org_id": orgID, "user_id": userID}).Errorf("查询组织成员状态失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	if err := ctl.orgRepo.ProvisionMember(ctx, orgID, userID, role, status, limit); err != nil {
+		if errors.Is(err, repo2.ErrOrgOwnerImmutable) {
+			return scimError(webCtx, http.StatusForbidden, err.Error())
+		}
+
+		log.F(log.M{"org_id": orgID, "user_id": userID}).Errorf("同步组织成员失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	ctl.audit(ctx, orgID, repo2.OrgAuditMemberProvisioned, fmt.Sprintf("user_id=%d, role=%d, status=%d, monthly_limit=%d", userID, role, status, limit))
+	return nil
+}
+
+func (ctl *SCIMController) respondMember(ctx context.Context, webCtx web.Context, orgID, userID int64, status int) web.Response {
+	users, err := ctl.members(ctx, orgID)
+	if err != nil {
+		log.F(log.M{"org_id": orgID}).Errorf("查询组织成员失败: %v", err)
+		return scimError(webCtx, http.StatusInternalServerError, "internal error")
+	}
+
+	for _, u := range users {
+		if u.ID == strconv.Itoa(int(userID)) {
+			return webCtx.JSONWithCode(u, status)
+		}
+	}
+
+	return scimError(webCtx, http.StatusNotFound, "user not found")
+}
+
+// audit 记录组织审计日志，操作人为 0 表示由成员同步接口执行
+func (ctl *SCIMController) audit(ctx context.Context, orgID int64, action, detail string) {
+	if err := ctl.orgRepo.AddAuditLog(ctx, orgID, 0, action, detail); err != nil {
+		log.F(log.M{"org_id": orgID, "action": action}).Errorf("记录组织审计日志失败: %v", err)
+	}
+}
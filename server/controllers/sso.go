@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hibiken/asynq"
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/sso"
+	"github.com/mylxsw/aidea-server/pkg/token"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/must"
+	"github.com/redis/go-redis/v9"
+)
+
+// oidcStateTTL 发起 OIDC 登录后，完成授权的有效期
+const oidcStateTTL = 10 * time.Minute
+
+// SSOController 企业私有化部署使用的 LDAP/OIDC 登录，首次登录时自动创建用户，并按分组设置用户类型
+type SSOController struct {
+	conf       *config.Config    `autowire:"@"`
+	translater youdao.Translater `autowire:"@"`
+	queue      *queue.Queue      `autowire:"@"`
+	limiter    *rate.RateLimiter `autowire:"@"`
+	tk         *token.Token      `autowire:"@"`
+	rds        *redis.Client     `autowire:"@"`
+	userRepo   *repo2.UserRepo   `autowire:"@"`
+
+	ldap    *sso.LDAP
+	oidc    *sso.OIDC
+	mapping sso.GroupMapping
+}
+
+// NewSSOController 创建企业登录控制器，分组映射配置错误时启动失败
+func NewSSOController(resolver infra.Resolver) web.Controller {
+	ctl := SSOController{}
+	resolver.MustAutoWire(&ctl)
+
+	ctl.mapping = must.Must(sso.ParseGroupMapping(ctl.conf.SSOGroupUserTypes))
+
+	if ctl.conf.EnableLDAP {
+		ctl.ldap = sso.NewLDAP(sso.LDAPConfig{
+			Server:       ctl.conf.LDAPServer,
+			StartTLS:     ctl.conf.LDAPStartTLS,
+			BindDN:       ctl.conf.LDAPBindDN,
+			BindPassword: ctl.conf.LDAPBindPassword,
+			BaseDN:       ctl.conf.LDAPBaseDN,
+			UserAttr:     ctl.conf.LDAPUserAttr,
+			EmailAttr:    ctl.conf.LDAPEmailAttr,
+			NameAttr:     ctl.conf.LDAPNameAttr,
+			GroupAttr:    ctl.conf.LDAPGroupAttr,
+		})
+	}
+
+	if ctl.conf.EnableOIDC {
+		ctl.oidc = sso.NewOIDC(sso.OIDCConfig{
+			Issuer:       ctl.conf.OIDCIssuer,
+			ClientID:     ctl.conf.OIDCClientID,
+			ClientSecret: ctl.conf.OIDCClientSecret,
+			RedirectURL:  ctl.conf.OIDCRedirectURL,
+			Scopes:       ctl.conf.OIDCScopes,
+			GroupsClaim:  ctl.conf.OIDCGroupsClaim,
+		})
+	}
+
+	return &ctl
+}
+
+func (ctl *SSOController) Register(router web.Router) {
+	router.Group("/auth", func(router web.Router) {
+		router.Get("/sso", ctl.methods)
+		router.Post("/sign-in-ldap", ctl.signInWithLDAP)
+		router.Get("/oidc/authorize", ctl.oidcAuthorize)
+		router.Post("/oidc/callback", ctl.oidcCallback)
+	})
+}
+
+// methods 返回已启用的企业登录方式，客户端据此展示登录入口
+func (ctl *SSOController) methods(ctx context.Context, webCtx web.Context) web.Response {
+	return webCtx.JSON(web.M{
+		"ldap": ctl.ldap != nil,
+		"oidc": ctl.oidc != nil,
+	})
+}
+
+// signInWithLDAP 使用 LDAP 账号登录
+func (ctl *SSOController) signInWithLDAP(ctx context.Context, webCtx web.Context) web.Response {
+	if ctl.ldap == nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	username := strings.TrimSpace(webCtx.Input("username"))
+	password := webCtx.Input("password")
+	if username == "" || password == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "用户名或密码不能为空"), http.StatusBadRequest)
+	}
+
+	if err := ctl.limiter.Allow(ctx, fmt.Sprintf("auth:ldap:%s:login", username), rate.MaxRequestsInPeriod(5, 10*time.Minute)); err != nil {
+		if errors.Is(err, rate.ErrRateLimitExceeded) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "登录频率过高，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		log.WithFields(log.Fields{"username": username}).Errorf("failed to check login rate: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ident, err := ctl.ldap.Authenticate(ctx, username, password)
+	if err != nil {
+		if errors.Is(err, sso.ErrInvalidCredentials) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "用户名或密码错误"), http.StatusBadRequest)
+		}
+
+		log.WithFields(log.Fields{"username": username}).Errorf("ldap authenticate failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return ctl.signIn(ctx, webCtx, ident)
+}
+
+func oidcStateKey(state string) string {
+	return "auth:oidc:state:" + state
+}
+
+// oidcAuthorize 发起 OIDC 登录，返回授权地址，客户端在浏览器中打开，授权完成后从回调地址中获取 code 和 state
+func (ctl *SSOController) oidcAuthorize(ctx context.Context, webCtx web.Context) web.Response {
+	if ctl.oidc == nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	state, _ := uuid.GenerateUUID()
+	nonce, _ := uuid.GenerateUUID()
+
+	authURL, err := ctl.oidc.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		log.Errorf("build oidc authorize url failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.rds.Set(ctx, oidcStateKey(state), nonce, oidcStateTTL).Err(); err != nil {
+		log.Errorf("save oidc state failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"url":   authURL,
+		"state": state,
+	})
+}
+
+// oidcCallback 使用授权码完成 OIDC 登录，state 只能使用一次
+func (ctl *SSOController) oidcCallback(ctx context.Context, webCtx web.Context) web.Response {
+	if ctl.oidc == nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	code := strings.TrimSpace(webCtx.Input("code"))
+	state := strings.TrimSpace(webCtx.Input("state"))
+	if code == "" || state == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	nonce, err := ctl.rds.GetDel(ctx, oidcStateKey(state)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "登录已过期，请重新登录"), http.StatusBadRequest)
+		}
+
+		log.Errorf("get oidc state failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	ident, err := ctl.oidc.Exchange(ctx, code, nonce)
+	if err != nil {
+		log.Errorf("oidc exchange failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidCredential), http.StatusBadRequest)
+	}
+
+	return ctl.signIn(ctx, webCtx, ident)
+}
+
+// signIn 登录或自动创建身份源对应的用户
+func (ctl *SSOController) signIn(ctx context.Context, webCtx web.Context, ident *sso.Identity) web.Response {
+	account := repo2.ExternalAccount{
+		Provider:      ident.Provider,
+		Subject:       ident.Subject,
+		Email:         ident.Email,
+		EmailVerified: ident.EmailVerified,
+		Realname:      ident.Name,
+	}
+
+	if userType, ok := ctl.mapping.UserType(ident.Groups); ok {
+		account.UserType = &userType
+	}
+
+	user, eventID, err := ctl.userRepo.ExternalSignIn(ctx, account)
+	if err != nil {
+		if errors.Is(err, repo2.ErrUserAccountDisabled) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, err.Error()), http.StatusForbidden)
+		}
+
+		log.With(ident).Errorf("sso sign in failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if eventID > 0 {
+		payload := queue.SignupPayload{
+			UserID:    user.Id,
+			Email:     user.Email,
+			EventID:   eventID,
+			CreatedAt: time.Now(),
+		}
+
+		if _, err := ctl.queue.EnqueueContext(ctx, &payload, queue.NewSignupTask, asynq.Queue("user")); err != nil {
+			log.WithFields(log.Fields{
+				"user_id":  user.Id,
+				"event_id": eventID,
+			}).Errorf("failed to enqueue signup task: %s", err)
+		}
+	}
+
+	return webCtx.JSON(buildUserLoginRes(user, eventID > 0, ctl.tk))
+}
@@ -0,0 +1,338 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+const (
+	// defaultFlashcardCount 默认生成的卡片数量
+	defaultFlashcardCount = 10
+	// maxFlashcardCount 单次最多生成的卡片数量
+	maxFlashcardCount = 30
+	// maxStudyNotesLength 上传笔记的最大字数
+	maxStudyNotesLength = 20000
+	// maxFlashcardLength 卡片正面、背面内容的最大字数
+	maxFlashcardLength = 1000
+)
+
+// StudyController 学习模式：记忆卡片生成以及间隔重复复习
+type StudyController struct {
+	conf          *config.Config         `autowire:"@"`
+	translater    youdao.Translater      `autowire:"@"`
+	flashcardRepo *repo2.FlashcardRepo   `autowire:"@"`
+	studySrv      *service2.StudyService `autowire:"@"`
+	userSrv       *service2.UserService  `autowire:"@"`
+	chatSrv       *service2.ChatService  `autowire:"@"`
+}
+
+// NewStudyController 创建学习模式控制器
+func NewStudyController(resolver infra.Resolver) web.Controller {
+	ctl := &StudyController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *StudyController) Register(router web.Router) {
+	router.Group("/study", func(router web.Router) {
+		router.Get("/decks", ctl.Decks)
+		router.Post("/decks", ctl.CreateDeck)
+		router.Get("/decks/{id}/cards", ctl.DeckCards)
+		router.Delete("/decks/{id}", ctl.DeleteDeck)
+
+		router.Put("/cards/{id}", ctl.UpdateCard)
+		router.Delete("/cards/{id}", ctl.DeleteCard)
+		router.Post("/cards/{id}/review", ctl.Review)
+
+		router.Get("/reviews", ctl.Reviews)
+
+		router.Get("/settings", ctl.Settings)
+		router.Put("/settings", ctl.UpdateSettings)
+	})
+}
+
+// Decks 当前用户的卡片组
+func (ctl *StudyController) Decks(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	decks, err := ctl.flashcardRepo.Decks(ctx, user.ID, service2.StudyDueBefore())
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询卡片组失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": decks, "enabled": ctl.studySrv.Enabled()})
+}
+
+type CreateDeckRequest struct {
+	Name string `json:"name"`
+	// RoomID 从会话生成卡片
+	RoomID int64 `json:"room_id,omitempty"`
+	// Notes 从笔记生成卡片
+	Notes string `json:"notes,omitempty"`
+	// Count 生成的卡片数量
+	Count int `json:"count,omitempty"`
+}
+
+// CreateDeck 从会话或者笔记生成卡片组
+func (ctl *StudyController) CreateDeck(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req CreateDeckRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if req.Count <= 0 {
+		req.Count = defaultFlashcardCount
+	}
+
+	if req.Count > maxFlashcardCount {
+		req.Count = maxFlashcardCount
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if len([]rune(req.Name)) > 100 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "卡片组名称过长"), http.StatusBadRequest)
+	}
+
+	var source, material string
+	switch {
+	case req.RoomID > 0 && req.Notes == "":
+		source = repo2.FlashcardSourceConversation
+
+		room, err := ctl.chatSrv.Room(ctx, user.ID, req.RoomID)
+		if err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+			}
+
+			log.F(log.M{"user_id": user.ID, "room_id": req.RoomID}).Errorf("查询会话失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		if req.Name == "" {
+			req.Name = room.Name
+		}
+
+		material, err = ctl.studySrv.ConversationMaterial(ctx, user.ID, req.RoomID)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID, "room_id": req.RoomID}).Errorf("查询会话消息失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	case req.RoomID <= 0 && req.Notes != "":
+		if len([]rune(req.Notes)) > maxStudyNotesLength {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "笔记内容过长，请分段处理"), http.StatusBadRequest)
+		}
+
+		source, material = repo2.FlashcardSourceNotes, req.Notes
+	default:
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "请选择会话或者上传笔记"), http.StatusBadRequest)
+	}
+
+	if req.Name == "" {
+		req.Name = "记忆卡片"
+	}
+
+	chatReq, estimate, err := ctl.studySrv.PrepareGenerate(material, req.Count)
+	if err != nil {
+		switch {
+		case errors.Is(err, service2.ErrStudyDisabled):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "学习模式暂不可用"), http.StatusServiceUnavailable)
+		case errors.Is(err, service2.ErrStudyNoContent):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "没有可以生成卡片的内容"), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("prepare flashcard generation failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if estimate > 0 {
+		quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		if quota.Rest-quota.Freezed < estimate {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+		}
+	}
+
+	cards, quotaConsumed, err := ctl.studySrv.Generate(ctx, user.ID, chatReq, req.Count)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("generate flashcards failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "卡片生成失败，请稍后再试"), http.StatusInternalServerError)
+	}
+
+	deckID, err := ctl.flashcardRepo.CreateDeck(ctx, user.ID, req.Name, source, req.RoomID, cards)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存卡片组失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"id":             deckID,
+		"name":           req.Name,
+		"card_count":     len(cards),
+		"quota_consumed": quotaConsumed,
+	})
+}
+
+// DeckCards 卡片组中的所有卡片
+func (ctl *StudyController) DeckCards(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	cards, err := ctl.flashcardRepo.DeckCards(ctx, user.ID, id)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "deck_id": id}).Errorf("查询卡片失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": cards})
+}
+
+// DeleteDeck 删除卡片组
+func (ctl *StudyController) DeleteDeck(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.flashcardRepo.DeleteDeck(ctx, user.ID, id); err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"user_id": user.ID, "deck_id": id}).Errorf("删除卡片组失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// UpdateCard 修改卡片内容
+func (ctl *StudyController) UpdateCard(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	content := repo2.FlashcardContent{
+		Front: strings.TrimSpace(webCtx.Input("front")),
+		Back:  strings.TrimSpace(webCtx.Input("back")),
+	}
+
+	if content.Front == "" || content.Back == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "卡片内容不能为空"), http.StatusBadRequest)
+	}
+
+	if len([]rune(content.Front)) > maxFlashcardLength || len([]rune(content.Back)) > maxFlashcardLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "卡片内容过长"), http.StatusBadRequest)
+	}
+
+	if err := ctl.flashcardRepo.UpdateCard(ctx, user.ID, id, content); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "card_id": id}).Errorf("修改卡片失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeleteCard 删除卡片
+func (ctl *StudyController) DeleteCard(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.flashcardRepo.DeleteCard(ctx, user.ID, id); err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"user_id": user.ID, "card_id": id}).Errorf("删除卡片失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Reviews 今天需要复习的卡片，可以按照卡片组筛选
+func (ctl *StudyController) Reviews(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	limit := webCtx.Int64Input("limit", 50)
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	cards, err := ctl.flashcardRepo.DueCards(ctx, user.ID, webCtx.Int64Input("deck_id", 0), service2.StudyDueBefore(), limit)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询待复习的卡片失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": cards})
+}
+
+// Review 提交复习结果，quality 为回忆程度评分：0-完全忘记 1-看到答案后想起 2-答错但答案很熟悉 3-费力想起 4-稍加思考后想起 5-立即想起
+func (ctl *StudyController) Review(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	quality := webCtx.IntInput("quality", -1)
+	if quality < 0 || quality > 5 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	card, err := ctl.studySrv.Review(ctx, user.ID, id, quality)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "card_id": id}).Errorf("保存复习结果失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": card})
+}
+
+// Settings 学习模式设置，reminder_available 表示服务端是否可以发送复习提醒
+func (ctl *StudyController) Settings(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	setting, err := ctl.flashcardRepo.StudySetting(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询学习模式设置失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": setting, "reminder_available": ctl.conf.EnableMail})
+}
+
+// UpdateSettings 修改学习模式设置
+func (ctl *StudyController) UpdateSettings(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var setting repo2.StudySetting
+	if err := webCtx.Unmarshal(&setting); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if setting.ReminderHour < 0 || setting.ReminderHour > 23 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.flashcardRepo.SetStudySetting(ctx, user.ID, setting); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存学习模式设置失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// maxSupportTicketSubjectLength 工单标题的最大长度
+	maxSupportTicketSubjectLength = 100
+	// maxSupportTicketContentLength 工单消息内容的最大长度
+	maxSupportTicketContentLength = 5000
+	// maxSupportTicketImages 每条工单消息最多可以附带的图片数量
+	maxSupportTicketImages = 4
+)
+
+// SupportTicketController 客服工单
+type SupportTicketController struct {
+	trans      youdao.Translater       `autowire:"@"`
+	ticketRepo *repo.SupportTicketRepo `autowire:"@"`
+	roomRepo   *repo.RoomRepo          `autowire:"@"`
+	msgRepo    *repo.MessageRepo       `autowire:"@"`
+	queueRepo  *repo.QueueRepo         `autowire:"@"`
+}
+
+// NewSupportTicketController 创建客服工单控制器
+func NewSupportTicketController(resolver infra.Resolver) web.Controller {
+	ctl := &SupportTicketController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *SupportTicketController) Register(router web.Router) {
+	router.Group("/support-tickets", func(router web.Router) {
+		router.Get("/", ctl.Tickets)
+		router.Post("/", ctl.Create)
+		router.Get("/unread", ctl.UnreadCount)
+		router.Get("/{id}", ctl.Ticket)
+		router.Post("/{id}/messages", ctl.Reply)
+		router.Put("/{id}/close", ctl.Close)
+	})
+}
+
+// Tickets 当前用户的工单列表
+func (ctl *SupportTicketController) Tickets(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	page := webCtx.Int64Input("page", 1)
+	if page < 1 || page > 1000 {
+		page = 1
+	}
+
+	perPage := webCtx.Int64Input("per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	tickets, meta, err := ctl.ticketRepo.Tickets(ctx, user.ID, page, perPage)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询工单列表失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":      tickets,
+		"page":      meta.Page,
+		"per_page":  meta.PerPage,
+		"total":     meta.Total,
+		"last_page": meta.LastPage,
+	})
+}
+
+// UnreadCount 有未读客服回复的工单数量，客户端用于展示提醒标记
+func (ctl *SupportTicketController) UnreadCount(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	count, err := ctl.ticketRepo.UnreadCount(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询未读工单数量失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"count": count})
+}
+
+// Create 创建工单，可以关联一个对话、一条消息或者一个异步任务，方便客服排查问题
+func (ctl *SupportTicketController) Create(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	subject := strings.TrimSpace(webCtx.Input("subject"))
+	if subject == "" || len([]rune(subject)) > maxSupportTicketSubjectLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "工单标题不能为空且不能超过 100 个字符"), http.StatusBadRequest)
+	}
+
+	content, images, errResp := ctl.resolveMessage(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	attachmentType := webCtx.Input("attachment_type")
+	attachmentID := strings.TrimSpace(webCtx.Input("attachment_id"))
+	if attachmentType != "" || attachmentID != "" {
+		if err := ctl.checkAttachment(ctx, user.ID, attachmentType, attachmentID); err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return webCtx.JSONError(common.Text(webCtx, ctl.trans, "关联的对话或任务不存在"), http.StatusBadRequest)
+			}
+
+			log.F(log.M{"user_id": user.ID, "attachment_type": attachmentType, "attachment_id": attachmentID}).Errorf("查询工单关联对象失败: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	}
+
+	id, err := ctl.ticketRepo.CreateTicket(ctx, user.ID, repo.SupportTicketCreate{
+		Subject:        subject,
+		Category:       strings.TrimSpace(webCtx.Input("category")),
+		Content:        content,
+		Images:         images,
+		AttachmentType: attachmentType,
+		AttachmentID:   attachmentID,
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("创建工单失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// checkAttachment 检查工单关联的对象是否属于当前用户
+func (ctl *SupportTicketController) checkAttachment(ctx context.Context, userID int64, attachmentType, attachmentID string) error {
+	switch attachmentType {
+	case repo.SupportTicketAttachmentRoom, repo.SupportTicketAttachmentMessage:
+		id, err := strconv.ParseInt(attachmentID, 10, 64)
+		if err != nil || id <= 0 {
+			return repo.ErrNotFound
+		}
+
+		if attachmentType == repo.SupportTicketAttachmentRoom {
+			_, err = ctl.roomRepo.Room(ctx, userID, id)
+		} else {
+			_, _, err = ctl.msgRepo.QuestionAnswer(ctx, userID, id)
+		}
+
+		return err
+	case repo.SupportTicketAttachmentTask:
+		task, err := ctl.queueRepo.Task(ctx, attachmentID)
+		if err != nil {
+			return err
+		}
+
+		if task.Uid != userID {
+			return repo.ErrNotFound
+		}
+
+		return nil
+	}
+
+	return repo.ErrNotFound
+}
+
+// resolveMessage 读取并校验工单消息的内容与图片
+func (ctl *SupportTicketController) resolveMessage(webCtx web.Context) (string, []string, web.Response) {
+	content := strings.TrimSpace(webCtx.Input("content"))
+	if content == "" || len([]rune(content)) > maxSupportTicketContentLength {
+		return "", nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, "内容不能为空且不能超过 5000 个字符"), http.StatusBadRequest)
+	}
+
+	// 图片地址使用逗号分隔，只允许 http(s) 地址
+	images := array.Filter(
+		array.Map(strings.Split(webCtx.Input("images"), ","), func(item string, _ int) string { return strings.TrimSpace(item) }),
+		func(item string, _ int) bool {
+			return strings.HasPrefix(item, "https://") || strings.HasPrefix(item, "http://")
+		},
+	)
+	if len(images) > maxSupportTicketImages {
+		return "", nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, "最多只能上传 4 张图片"), http.StatusBadRequest)
+	}
+
+	return content, images, nil
+}
+
+// ticket 查询当前用户的工单，不存在时返回错误响应
+func (ctl *SupportTicketController) ticket(ctx context.Context, webCtx web.Context, user *auth.User) (*repo.SupportTicket, web.Response) {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	ticket, err := ctl.ticketRepo.Ticket(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "ticket_id": id}).Errorf("查询工单失败: %v", err)
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return ticket, nil
+}
+
+// Ticket 工单详情，查看后客服回复标记为已读
+func (ctl *SupportTicketController) Ticket(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	ticket, errResp := ctl.ticket(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	messages, err := ctl.ticketRepo.Messages(ctx, ticket.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "ticket_id": ticket.ID}).Errorf("查询工单消息失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if ticket.UserUnread {
+		if err := ctl.ticketRepo.MarkRead(ctx, ticket.ID, false); err != nil {
+			log.F(log.M{"user_id": user.ID, "ticket_id": ticket.ID}).Errorf("标记工单已读失败: %v", err)
+		}
+	}
+
+	return webCtx.JSON(web.M{
+		"ticket":   ticket,
+		"messages": messages,
+	})
+}
+
+// Reply 用户回复工单，已关闭的工单不能继续回复
+func (ctl *SupportTicketController) Reply(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	ticket, errResp := ctl.ticket(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	content, images, errResp := ctl.resolveMessage(webCtx)
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := ctl.ticketRepo.AddMessage(ctx, ticket.ID, user.ID, false, content, images); err != nil {
+		if errors.Is(err, repo.ErrSupportTicketClosed) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "工单已关闭，如有问题请重新提交工单"), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "ticket_id": ticket.ID}).Errorf("回复工单失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// Close 用户关闭工单
+func (ctl *SupportTicketController) Close(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	ticket, errResp := ctl.ticket(ctx, webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := ctl.ticketRepo.UpdateStatus(ctx, ticket.ID, repo.SupportTicketStatusClosed); err != nil {
+		log.F(log.M{"user_id": user.ID, "ticket_id": ticket.ID}).Errorf("关闭工单失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
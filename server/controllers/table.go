@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/internal/coins"
+	chat2 "github.com/mylxsw/aidea-server/pkg/ai/chat"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/table"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+const (
+	// tableMaxFileSize 表格文件最大支持 10MB
+	tableMaxFileSize = 10 * 1024 * 1024
+	// tableSnippetRows 提供给模型和客户端的结果表格最多行数
+	tableSnippetRows = 20
+)
+
+// TableController 表格问答：上传 CSV/XLSX 文件后，模型根据问题生成受限的查询，服务端执行查询后再由模型给出回答
+type TableController struct {
+	conf       *config.Config
+	chat       chat2.Chat            `autowire:"@"`
+	translater youdao.Translater     `autowire:"@"`
+	tableRepo  *repo2.TableRepo      `autowire:"@"`
+	quotaRepo  *repo2.QuotaRepo      `autowire:"@"`
+	userSrv    *service2.UserService `autowire:"@"`
+}
+
+// NewTableController 创建表格问答控制器
+func NewTableController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &TableController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *TableController) Register(router web.Router) {
+	router.Group("/tables", func(router web.Router) {
+		router.Post("/", ctl.Upload)
+		router.Get("/", ctl.Files)
+		router.Delete("/{id}", ctl.Delete)
+		router.Post("/{id}/ask", ctl.Ask)
+	})
+}
+
+type TableUploadRequest struct {
+	// URL 客户端上传到存储后的文件地址
+	URL string `json:"url"`
+	// Name 文件名称，需要包含 .csv 或 .xlsx 扩展名
+	Name string `json:"name"`
+}
+
+// Upload 上传表格文件，服务端解析并统计每一列的信息
+func (ctl *TableController) Upload(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req TableUploadRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 只允许下载客户端上传到存储中的文件，避免服务端被用于访问内网地址
+	storageDomain := strings.TrimSuffix(ctl.conf.StorageDomain, "/")
+	if storageDomain == "" || !strings.HasPrefix(req.URL, storageDomain+"/") {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if req.Name == "" {
+		req.Name = path.Base(req.URL)
+	}
+
+	savePath, err := uploader.DownloadRemoteFileWithLimit(ctx, req.URL, tableMaxFileSize)
+	if err != nil {
+		if errors.Is(err, uploader.ErrFileTooLarge) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrFileTooLarge), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "url": req.URL}).Errorf("下载表格文件失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件下载失败"), http.StatusBadRequest)
+	}
+	defer os.Remove(savePath)
+
+	data, err := os.ReadFile(savePath)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "url": req.URL}).Errorf("读取表格文件失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	tbl, err := table.Load(req.Name, data, ctl.conf.TableMaxRows)
+	if err != nil {
+		switch {
+		case errors.Is(err, table.ErrUnsupportedFormat):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "仅支持 CSV 和 XLSX 格式的文件"), http.StatusBadRequest)
+		case errors.Is(err, table.ErrTooManyRows):
+			return webCtx.JSONError(fmt.Sprintf(common.Text(webCtx, ctl.translater, "表格行数不能超过 %d 行"), ctl.conf.TableMaxRows), http.StatusBadRequest)
+		case errors.Is(err, table.ErrEmptyTable):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "表格中没有数据"), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "url": req.URL}).Warningf("解析表格文件失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "文件解析失败"), http.StatusBadRequest)
+	}
+
+	profile := tbl.Profile()
+	profileData, _ := json.Marshal(profile)
+	tableData, _ := json.Marshal(tbl)
+
+	id, err := ctl.tableRepo.AddFile(ctx, repo2.TableFileAddReq{
+		UserID:   user.ID,
+		Name:     req.Name,
+		URL:      req.URL,
+		RowCount: int64(len(tbl.Rows)),
+		Profile:  string(profileData),
+		Data:     string(tableData),
+	})
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存表格文件失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"id":        id,
+		"name":      req.Name,
+		"row_count": len(tbl.Rows),
+		"profile":   profile,
+	})
+}
+
+// Files 用户上传的表格文件列表
+func (ctl *TableController) Files(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	files, err := ctl.tableRepo.GetFiles(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询表格文件列表失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": files})
+}
+
+// Delete 删除表格文件
+func (ctl *TableController) Delete(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.tableRepo.DeleteFile(ctx, user.ID, int64(id)); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("删除表格文件失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+type TableAskRequest struct {
+	Model    string `json:"model"`
+	Question string `json:"question"`
+}
+
+// tableQueryPrompt 要求模型将问题转换为受限的 JSON 查询
+const tableQueryPrompt = `You are a data analyst. The user has a table with the following columns (JSON profile with type, distinct count, range and sample values):
+
+%s
+
+Translate the user's question into a single JSON query object with this schema, and output only the JSON:
+{
+  "select": ["column", ...],                                   // optional, columns to return when not aggregating
+  "where": [{"column": "...", "op": "=|!=|>|>=|<|<=|contains", "value": "..."}],  // optional, combined with AND
+  "group_by": ["column", ...],                                 // optional, requires aggregates
+  "aggregates": [{"func": "count|sum|avg|min|max", "column": "...", "as": "alias"}],  // optional
+  "order_by": "column or alias",                               // optional
+  "desc": true,                                                // optional
+  "limit": 20                                                  // optional, at most 100
+}
+Column names must exactly match the profile.`
+
+// Ask 对表格提问，返回回答、执行的查询以及查询结果片段
+func (ctl *TableController) Ask(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	var req TableAskRequest
+	if err := webCtx.Unmarshal(&req); err != nil || strings.TrimSpace(req.Question) == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if !array.In(req.Model, array.Map(chat2.Models(ctl.conf, false), func(item chat2.Model, _ int) string { return item.RealID() })) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidModel), http.StatusBadRequest)
+	}
+
+	file, err := ctl.tableRepo.GetFile(ctx, user.ID, int64(id))
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("查询表格文件失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	var tbl table.Table
+	if err := json.Unmarshal([]byte(file.Data), &tbl); err != nil {
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("解析表格数据失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	queryReq := chat2.Request{
+		Model: req.Model,
+		Messages: chat2.Messages{
+			{Role: "system", Content: fmt.Sprintf(tableQueryPrompt, file.Profile)},
+			{Role: "user", Content: req.Question},
+		},
+	}.Init()
+
+	inputTokens, err := chat2.MessageTokenCount(queryReq.Messages, queryReq.Model)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	// 一次提问需要调用两次模型：生成查询、根据查询结果回答，第二次请求额外包含结果片段
+	estimated := coins.GetOpenAITextCoins(queryReq.ResolveCalFeeModel(ctl.conf), int64(inputTokens)*2+2000)
+	quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < estimated {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	if err := ctl.userSrv.FreezeUserQuota(ctx, user.ID, estimated); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("freeze user quota failed: %s", err)
+	} else {
+		defer func(ctx context.Context) {
+			if err := ctl.userSrv.UnfreezeUserQuota(ctx, user.ID, estimated); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": estimated}).Errorf("unfreeze user quota failed: %s", err)
+			}
+		}(ctx)
+	}
+
+	var totalTokens int64
+	defer func() {
+		consumed := coins.GetOpenAITextCoins(queryReq.ResolveCalFeeModel(ctl.conf), totalTokens)
+		if consumed > 0 {
+			if err := ctl.quotaRepo.QuotaConsume(ctx, user.ID, consumed, repo2.NewQuotaUsedMeta("table-qa", req.Model)); err != nil {
+				log.F(log.M{"user_id": user.ID, "quota": consumed}).Errorf("used quota add failed: %s", err)
+			}
+		}
+	}()
+
+	queryText, tokens, err := chatComplete(ctx, ctl.chat, queryReq)
+	totalTokens += tokens
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "model": req.Model}).Errorf("生成表格查询失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusBadGateway)
+	}
+
+	var q table.Query
+	if err := json.Unmarshal([]byte(extractJSONObject(queryText)), &q); err != nil {
+		log.F(log.M{"user_id": user.ID, "model": req.Model, "query": queryText}).Warningf("模型生成的表格查询无法解析: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "无法理解这个问题，请换一种问法"), http.StatusUnprocessableEntity)
+	}
+
+	result, err := tbl.Execute(q)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "model": req.Model, "query": q}).Warningf("执行表格查询失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "无法理解这个问题，请换一种问法"), http.StatusUnprocessableEntity)
+	}
+
+	snippet := result.Snippet(tableSnippetRows)
+	answerReq := chat2.Request{
+		Model: req.Model,
+		Messages: chat2.Messages{
+			{Role: "system", Content: "You are a data analyst. Answer the user's question concisely based only on the query result below, in the same language as the question.\n\n" + snippet.Markdown()},
+			{Role: "user", Content: req.Question},
+		},
+	}.Init()
+
+	answer, tokens, err := chatComplete(ctx, ctl.chat, answerReq)
+	totalTokens += tokens
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "model": req.Model}).Errorf("生成表格问答回答失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusBadGateway)
+	}
+
+	return webCtx.JSON(web.M{
+		"answer":     answer,
+		"query":      q,
+		"table":      snippet,
+		"total_rows": len(result.Rows),
+	})
+}
+
+// chatComplete 非流式调用模型，返回回复内容以及消耗的 Token 数
+func chatComplete(ctx context.Context, ct chat2.Chat, req chat2.Request) (string, int64, error) {
+	chatCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+
+	resp, err := ct.Chat(chatCtx, req)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if resp.ErrorCode != "" {
+		return "", 0, fmt.Errorf("%s %s", resp.ErrorCode, resp.Error)
+	}
+
+	tokens, _ := chat2.MessageTokenCount(append(req.Messages, chat2.Message{Role: "assistant", Content: resp.Text}), req.Model)
+	return resp.Text, int64(tokens), nil
+}
+
+// extractJSONObject 提取模型回复中的 JSON 对象，忽略 Markdown 代码块等多余内容
+func extractJSONObject(text string) string {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return text
+	}
+
+	return text[start : end+1]
+}
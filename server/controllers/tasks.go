@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 type TaskController struct {
 	conf       *config.Config
 	queueRepo  *repo2.QueueRepo  `autowire:"@"`
+	queue      *queue.Queue      `autowire:"@"`
 	translater youdao.Translater `autowire:"@"`
 }
 
@@ -32,6 +34,8 @@ func NewTaskController(resolver infra.Resolver, conf *config.Config) web.Control
 func (ctl *TaskController) Register(router web.Router) {
 	router.Group("/tasks", func(router web.Router) {
 		router.Get("/{task_id}/status", ctl.taskStatus)
+		router.Get("/{task_id}/queue-updates", ctl.queueUpdates)
+		router.Get("/{task_id}/logs", ctl.taskLogs)
 	})
 }
 
@@ -78,7 +82,126 @@ func (ctl *TaskController) taskStatus(ctx context.Context, webCtx web.Context, u
 		})
 	}
 
+	// 排队中的任务返回排队位置与预计开始时间
+	eta, err := ctl.queue.ETA(ctx, *task)
+	if err != nil {
+		log.With(task).Errorf("query task eta failed: %v", err)
+	}
+
+	if eta != nil {
+		return webCtx.JSON(web.M{
+			"status":             task.Status,
+			"position":           eta.Position,
+			"wait":               eta.Wait,
+			"estimated_start_at": eta.EstimatedStartAt.Format(time.RFC3339),
+		})
+	}
+
 	return webCtx.JSON(web.M{
 		"status": task.Status,
 	})
 }
+
+// taskLogs 任务的处理步骤日志
+func (ctl *TaskController) taskLogs(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	task, err := ctl.queueRepo.Task(ctx, webCtx.PathVar("task_id"))
+	if err != nil {
+		if err == repo2.ErrNotFound {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+		}
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if task.Uid != user.ID {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	logs, err := ctl.queueRepo.Logs(ctx, task.TaskId)
+	if err != nil {
+		log.With(task).Errorf("query task logs failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"status":     task.Status,
+		"created_at": task.CreatedAt.Format(time.RFC3339),
+		"data":       logs,
+	})
+}
+
+const (
+	// queueUpdatesInterval 检查排队位置变化的时间间隔
+	queueUpdatesInterval = 3 * time.Second
+	// queueUpdatesMaxDuration 排队位置推送连接的最长保持时间，超过后客户端需要重新连接
+	queueUpdatesMaxDuration = 30 * time.Minute
+)
+
+// queueUpdates 以 SSE 的方式推送排队中任务的位置变化，只在位置变化较大时推送，任务开始处理后推送任务状态并结束
+func (ctl *TaskController) queueUpdates(ctx context.Context, webCtx web.Context, user *auth.User, w http.ResponseWriter) {
+	task, err := ctl.queueRepo.Task(ctx, webCtx.PathVar("task_id"))
+	if err != nil || task.Uid != user.ID {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"error":%q}`, common.Text(webCtx, ctl.translater, common.ErrNotFound))))
+		return
+	}
+
+	if ctl.conf.EnableCORS {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	write := func(payload any) bool {
+		data, _ := json.Marshal(payload)
+		if _, err := w.Write([]byte("data: " + string(data) + "\n\n")); err != nil {
+			return false
+		}
+
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		return true
+	}
+	defer func() { _, _ = w.Write([]byte("data: [DONE]\n\n")) }()
+
+	ctx, cancel := context.WithTimeout(ctx, queueUpdatesMaxDuration)
+	defer cancel()
+
+	ticker := time.NewTicker(queueUpdatesInterval)
+	defer ticker.Stop()
+
+	lastPosition := int64(-1)
+	for {
+		eta, err := ctl.queue.ETA(ctx, *task)
+		if err != nil {
+			log.With(task).Errorf("query task eta failed: %v", err)
+		} else if eta == nil {
+			write(web.M{"status": task.Status})
+			return
+		} else if lastPosition < 0 || queue.PositionChangedSignificantly(lastPosition, eta.Position) {
+			lastPosition = eta.Position
+			if !write(web.M{
+				"status":             task.Status,
+				"position":           eta.Position,
+				"wait":               eta.Wait,
+				"estimated_start_at": eta.EstimatedStartAt.Format(time.RFC3339),
+			}) {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if task, err = ctl.queueRepo.Task(ctx, task.TaskId); err != nil {
+			log.F(log.M{"user_id": user.ID}).Errorf("query task failed: %v", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/mylxsw/aidea-server/config"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/repo/model"
+	"github.com/mylxsw/aidea-server/pkg/topic"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/array"
+)
+
+// TopicController 会话话题：按话题浏览历史会话以及查看每个话题的使用统计
+type TopicController struct {
+	conf       *config.Config
+	translater youdao.Translater `autowire:"@"`
+	topicRepo  *repo2.TopicRepo  `autowire:"@"`
+}
+
+// NewTopicController 创建会话话题控制器
+func NewTopicController(resolver infra.Resolver, conf *config.Config) web.Controller {
+	ctl := &TopicController{conf: conf}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *TopicController) Register(router web.Router) {
+	router.Group("/topics", func(router web.Router) {
+		router.Get("/", ctl.Topics)
+		router.Get("/{topic}/rooms", ctl.Rooms)
+	})
+}
+
+// Topics 返回所有话题以及当前用户在每个话题下的使用统计，按会话数量倒序排列
+func (ctl *TopicController) Topics(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	stats, err := ctl.topicRepo.TopicStats(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询话题统计失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	statsByTopic := array.ToMap(stats, func(item repo2.TopicStat, _ int) string { return item.Topic })
+
+	res := array.Map(topic.Topics(), func(t topic.Topic, _ int) web.M {
+		stat := statsByTopic[t.Name]
+		return web.M{
+			"name":     t.Name,
+			"title":    t.Title,
+			"rooms":    stat.Rooms,
+			"messages": stat.Messages,
+			"quota":    stat.Quota,
+		}
+	})
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i]["rooms"].(int64) > res[j]["rooms"].(int64)
+	})
+
+	return webCtx.JSON(web.M{"data": res})
+}
+
+// Rooms 查询某个话题下的历史会话
+func (ctl *TopicController) Rooms(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	name := webCtx.PathVar("topic")
+	if !topic.Valid(name) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	roomTypes := []int{repo2.RoomTypePreset, repo2.RoomTypePresetCustom, repo2.RoomTypeCustom, repo2.RoomTypeGroupChat}
+	rooms, err := ctl.topicRepo.RoomsByTopic(ctx, user.ID, name, roomTypes, RoomsQueryLimit)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "topic": name}).Errorf("按话题查询会话列表失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	roomTopics, err := ctl.topicRepo.RoomTopics(ctx, user.ID, array.Map(rooms, func(item model.Rooms, _ int) int64 { return item.Id }))
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询会话话题失败: %v", err)
+	}
+
+	return webCtx.JSON(web.M{"data": array.Map(rooms, func(item model.Rooms, _ int) web.M {
+		return web.M{
+			"id":               item.Id,
+			"name":             item.Name,
+			"description":      item.Description,
+			"model":            item.Model,
+			"avatar_url":       item.AvatarUrl,
+			"room_type":        item.RoomType,
+			"last_active_time": item.LastActiveTime,
+			"topics":           roomTopics[item.Id],
+		}
+	})})
+}
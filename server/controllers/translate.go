@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/mylxsw/aidea-server/pkg/misc"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
 	youdao2 "github.com/mylxsw/aidea-server/pkg/youdao"
 	"net/http"
 	"strings"
@@ -37,7 +38,7 @@ func (ctl *TranslateController) Register(router web.Router) {
 }
 
 // Translate 翻译
-func (ctl *TranslateController) translate(ctx context.Context, webCtx web.Context, user *auth.User, quotaRepo *repo2.QuotaRepo, cacheRepo *repo2.CacheRepo) web.Response {
+func (ctl *TranslateController) translate(ctx context.Context, webCtx web.Context, user *auth.User, quotaRepo *repo2.QuotaRepo, cacheRepo *repo2.CacheRepo, glossarySrv *service2.GlossaryService) web.Response {
 	text := strings.TrimSpace(webCtx.Input("text"))
 	if text == "" {
 		return webCtx.JSONError("text is required", http.StatusBadRequest)
@@ -56,6 +57,11 @@ func (ctl *TranslateController) translate(ctx context.Context, webCtx web.Contex
 	from := strings.TrimSpace(webCtx.InputWithDefault("from", youdao2.LanguageAuto))
 	target := strings.TrimSpace(webCtx.InputWithDefault("to", common.GetLanguage(webCtx)))
 
+	// 机器翻译无法通过提示语控制，翻译前将用户术语表中的术语替换为指定的译法，请求时可通过 glossary=false 关闭
+	if webCtx.Input("glossary") != "false" {
+		text = service2.SubstituteGlossary(text, glossarySrv.Match(ctx, user.ID, text))
+	}
+
 	res, err := ctl.translater.Translate(ctx, from, target, text)
 	if err != nil {
 		log.Errorf("translate failed: %s", err)
@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/rate"
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// maxTutorMessageLength 辅导时单条消息的最大字数
+const maxTutorMessageLength = 2000
+
+// TutorController 作业辅导模式
+type TutorController struct {
+	translater     youdao.Translater         `autowire:"@"`
+	limiter        *rate.RateLimiter         `autowire:"@"`
+	tutorRepo      *repo2.TutorRepo          `autowire:"@"`
+	restrictedRepo *repo2.RestrictedModeRepo `autowire:"@"`
+	tutorSrv       *service2.TutorService    `autowire:"@"`
+	userSrv        *service2.UserService     `autowire:"@"`
+}
+
+// NewTutorController 创建作业辅导控制器
+func NewTutorController(resolver infra.Resolver) web.Controller {
+	ctl := &TutorController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *TutorController) Register(router web.Router) {
+	router.Group("/tutor", func(router web.Router) {
+		router.Get("/subjects", ctl.Subjects)
+		router.Post("/chat", ctl.Chat)
+		router.Get("/progress", ctl.Progress)
+
+		router.Get("/settings", ctl.Settings)
+		router.Put("/settings", ctl.UpdateSettings)
+	})
+}
+
+// Subjects 支持的学科以及当前生效的答案策略
+func (ctl *TutorController) Subjects(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	policy, err := ctl.tutorSrv.AnswerPolicy(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询辅导答案策略失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	subjects := make([]service2.TutorSubject, 0, len(service2.TutorSubjects))
+	for _, subject := range service2.TutorSubjects {
+		subjects = append(subjects, service2.TutorSubject{ID: subject.ID, Name: common.Text(webCtx, ctl.translater, subject.Name)})
+	}
+
+	return webCtx.JSON(web.M{
+		"data":    subjects,
+		"policy":  policy,
+		"enabled": ctl.tutorSrv.Enabled(),
+	})
+}
+
+type TutorChatRequest struct {
+	Subject string `json:"subject"`
+	// Messages 当前题目的对话记录，最后一条必须是学生的消息
+	Messages chat.Messages `json:"messages"`
+}
+
+// Chat 执行一步辅导
+func (ctl *TutorController) Chat(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req TutorChatRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if _, ok := service2.TutorSubjectName(req.Subject); !ok {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "不支持的学科"), http.StatusBadRequest)
+	}
+
+	if len(req.Messages) == 0 || req.Messages[len(req.Messages)-1].Role != "user" || strings.TrimSpace(req.Messages[len(req.Messages)-1].Content) == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "请输入你的问题或者解答"), http.StatusBadRequest)
+	}
+
+	for _, msg := range req.Messages {
+		if len([]rune(msg.Content)) > maxTutorMessageLength {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "消息内容过长"), http.StatusBadRequest)
+		}
+	}
+
+	chatReq, estimate, err := ctl.tutorSrv.Prepare(ctx, user.ID, req.Subject, req.Messages)
+	if err != nil {
+		var violation *service2.RestrictedModeViolationError
+		switch {
+		case errors.Is(err, service2.ErrTutorDisabled):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "作业辅导暂不可用"), http.StatusServiceUnavailable)
+		case errors.As(err, &violation):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, violation.Reason), http.StatusForbidden)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("prepare tutor request failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if estimate > 0 {
+		quota, err := ctl.userSrv.UserQuota(ctx, user.ID)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID}).Errorf("查询用户智慧果余量失败: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		if quota.Rest-quota.Freezed < estimate {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+		}
+	}
+
+	reply, err := ctl.tutorSrv.Chat(ctx, user.ID, req.Subject, chatReq)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("tutor chat failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "辅导失败，请稍后再试"), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": reply})
+}
+
+// Progress 各学科的学习进度，包括本周以及累计的进度
+func (ctl *TutorController) Progress(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	weekStart, end := service2.TutorWeekStart(), repo2.NowInDate().AddDate(0, 0, 1)
+
+	week, err := ctl.tutorRepo.Progress(ctx, user.ID, weekStart, end)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询辅导进度失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	total, err := ctl.tutorRepo.Progress(ctx, user.ID, time.Time{}, end)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询辅导进度失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"week": week, "total": total})
+}
+
+// Settings 作业辅导设置，parent_required 表示修改设置时是否需要家长 PIN
+func (ctl *TutorController) Settings(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	setting, err := ctl.tutorRepo.TutorSetting(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询作业辅导设置失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	_, err = ctl.restrictedRepo.Get(ctx, user.ID)
+	if err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询受限模式失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": setting, "parent_required": err == nil})
+}
+
+// UpdateSettings 修改作业辅导设置，开启受限模式（家长模式）时需要校验 PIN，避免孩子自行修改答案策略或者关闭周报
+func (ctl *TutorController) UpdateSettings(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var setting repo2.TutorSetting
+	if err := webCtx.Unmarshal(&setting); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if setting.AnswerPolicy != "" && setting.AnswerPolicy != repo2.TutorAnswerPolicyGuide && setting.AnswerPolicy != repo2.TutorAnswerPolicyAnswer {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	setting.ParentEmail = strings.TrimSpace(setting.ParentEmail)
+	if setting.ParentEmail != "" && !isEmail(setting.ParentEmail) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "邮箱格式错误"), http.StatusBadRequest)
+	}
+
+	if setting.WeeklyReport && setting.ParentEmail == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "发送学习周报需要设置家长邮箱"), http.StatusBadRequest)
+	}
+
+	if resp := ctl.verifyParent(ctx, webCtx, user); resp != nil {
+		return resp
+	}
+
+	if err := ctl.tutorRepo.SetTutorSetting(ctx, user.ID, setting); err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("保存作业辅导设置失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// verifyParent 用户开启受限模式时校验请求中的 PIN，与受限模式共用 PIN 的尝试次数限制
+func (ctl *TutorController) verifyParent(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if _, err := ctl.restrictedRepo.Get(ctx, user.ID); err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return nil
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("查询受限模式失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.limiter.Allow(ctx, fmt.Sprintf("restricted-mode:pin:%d:limit", user.ID), rate.MaxRequestsInPeriod(5, 10*time.Minute)); err != nil {
+		if errors.Is(err, rate.ErrRateLimitExceeded) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "PIN 尝试次数过多，请稍后再试"), http.StatusTooManyRequests)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("检查 PIN 校验频率失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if err := ctl.restrictedRepo.VerifyPIN(ctx, user.ID, webCtx.Input("pin")); err != nil {
+		if errors.Is(err, repo2.ErrRestrictedModeInvalidPIN) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "PIN 错误"), http.StatusForbidden)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("校验受限模式 PIN 失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return nil
+}
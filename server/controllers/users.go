@@ -64,13 +64,26 @@ func (ctl *UserController) Register(router web.Router) {
 		// 获取当前用户配额情况统计
 		router.Get("/quota/usage-stat", ctl.UserQuotaUsageStatistics)
 		router.Get("/quota/usage-stat/{date}", ctl.UserQuotaUsageDetails)
+		// 预测当前用户的智慧果余额还能使用多久
+		router.Get("/quota/forecast", ctl.UserQuotaForecast)
 
 		// 用户免费聊天次数统计
 		router.Get("/stat/free-chat-counts", ctl.UserFreeChatCounts)
 		router.Get("/stat/free-chat-counts/{model}", ctl.UserFreeChatCountsForModel)
+		// 个人使用统计（使用热力图、模型用量、常用房间、连续使用天数）
+		router.Get("/stat/personal", ctl.UserPersonalStatistics)
 
 		// 自定义首页模型
 		router.Post("/custom/home-models", ctl.CustomHomeModels)
+		// 是否允许聊天记录用于模型微调
+		router.Post("/custom/training-consent", ctl.CustomTrainingConsent)
+		// 全局指令，自动添加到所有对话中
+		router.Get("/custom/persona", ctl.CustomPersona)
+		router.Post("/custom/persona", ctl.UpdateCustomPersona)
+		router.Post("/custom/persona/restore", ctl.RestoreCustomPersona)
+		// 单次请求的智慧果上限，超过时需要确认
+		router.Get("/custom/spend-limit", ctl.CustomSpendLimit)
+		router.Post("/custom/spend-limit", ctl.UpdateCustomSpendLimit)
 
 		// 重置密码
 		router.Post("/reset-password/sms-code", ctl.SendResetPasswordSMSCode)
@@ -284,7 +297,7 @@ func (ctl *UserController) SendResetPasswordSMSCode(ctx context.Context, webCtx
 		CreatedAt: time.Now(),
 	}
 
-	taskId, err := ctl.queue.Enqueue(smsPayload, queue.NewSMSVerifyCodeTask, asynq.Queue("mail"))
+	taskId, err := ctl.queue.EnqueueContext(ctx, smsPayload, queue.NewSMSVerifyCodeTask, asynq.Queue("mail"))
 	if err != nil {
 		log.WithFields(log.Fields{
 			"username": username,
@@ -455,6 +468,79 @@ func (ctl *UserController) UserQuota(ctx context.Context, webCtx web.Context, us
 	})
 }
 
+// UserQuotaForecast 根据近期的消耗速度预测当前用户的智慧果余额还能使用多久，余额不足时推荐充值产品
+func (ctl *UserController) UserQuotaForecast(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo, forecastSrv *service2.QuotaForecastService) web.Response {
+	forecast, err := forecastSrv.Forecast(ctx, user.ID, client.PricingRegion())
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("预测用户智慧果余额失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(forecast)
+}
+
+const (
+	// personalStatDefaultDays 个人统计默认统计的天数
+	personalStatDefaultDays = 90
+	// personalStatMaxDays 个人统计最多统计的天数
+	personalStatMaxDays = 365
+)
+
+// UserPersonalStatistics 获取当前用户的个人使用统计，数据由每日定时任务汇总，不包含当天的数据
+func (ctl *UserController) UserPersonalStatistics(ctx context.Context, webCtx web.Context, user *auth.User, statsRepo *repo2.UserStatsRepo) web.Response {
+	days := webCtx.Int64Input("days", personalStatDefaultDays)
+	if days <= 0 || days > personalStatMaxDays {
+		days = personalStatDefaultDays
+	}
+
+	daily, err := statsRepo.DailyStats(ctx, user.ID, personalStatMaxDays)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户每日统计失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	models, err := statsRepo.ModelStats(ctx, user.ID, days)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户模型统计失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	rooms, err := statsRepo.TopRooms(ctx, user.ID, days, 10)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户常用房间统计失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	// 连续使用天数基于最近一年的数据计算，热力图只返回请求的天数
+	activeDates := array.Map(
+		array.Filter(daily, func(item repo2.UserDailyStat, _ int) bool { return item.Messages > 0 }),
+		func(item repo2.UserDailyStat, _ int) string { return item.Date },
+	)
+	currentStreak, longestStreak := repo2.Streaks(activeDates, time.Now())
+
+	startDate := time.Now().AddDate(0, 0, -int(days)).Format("2006-01-02")
+	daily = array.Filter(daily, func(item repo2.UserDailyStat, _ int) bool { return item.Date >= startDate })
+
+	var totalMessages, totalTokens, totalCoins int64
+	for _, item := range daily {
+		totalMessages += item.Messages
+		totalTokens += item.Tokens
+		totalCoins += item.Coins
+	}
+
+	return webCtx.JSON(web.M{
+		"days":           days,
+		"daily":          daily,
+		"models":         models,
+		"rooms":          rooms,
+		"current_streak": currentStreak,
+		"longest_streak": longestStreak,
+		"total_messages": totalMessages,
+		"total_tokens":   totalTokens,
+		"total_coins":    totalCoins,
+	})
+}
+
 type QuotaUsageStatistics struct {
 	Date string `json:"date"`
 	Used int64  `json:"used"`
@@ -625,3 +711,118 @@ func (ctl *UserController) CustomHomeModels(ctx context.Context, webCtx web.Cont
 
 	return webCtx.JSON(web.M{})
 }
+
+// CustomTrainingConsent 设置是否允许将聊天记录用于模型微调
+func (ctl *UserController) CustomTrainingConsent(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cus, err := ctl.userRepo.CustomConfig(ctx, user.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("get user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	cus.TrainingDataConsent = webCtx.InputWithDefault("consent", "false") == "true"
+	if err := ctl.userRepo.UpdateCustomConfig(ctx, user.ID, *cus); err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("update user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"consent": cus.TrainingDataConsent})
+}
+
+// CustomPersona 查询全局指令及其历史版本
+func (ctl *UserController) CustomPersona(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cus, err := ctl.userRepo.CustomConfig(ctx, user.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("get user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"persona":    cus.Persona,
+		"history":    cus.PersonaHistory,
+		"max_length": repo2.PersonaMaxLength,
+	})
+}
+
+// UpdateCustomPersona 修改全局指令，content 为空时停用，修改前的内容保存为历史版本
+func (ctl *UserController) UpdateCustomPersona(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	content := strings.TrimSpace(webCtx.Input("content"))
+	if len([]rune(content)) > repo2.PersonaMaxLength {
+		return webCtx.JSONError(fmt.Sprintf(common.Text(webCtx, ctl.translater, "全局指令不能超过 %d 个字符"), repo2.PersonaMaxLength), http.StatusBadRequest)
+	}
+
+	cus, err := ctl.userRepo.CustomConfig(ctx, user.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("get user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	persona := cus.SetPersona(content, webCtx.Input("skip_group_chat") == "true", time.Now())
+	if err := ctl.userRepo.UpdateCustomConfig(ctx, user.ID, *cus); err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("update user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"persona": persona})
+}
+
+// RestoreCustomPersona 恢复全局指令的历史版本
+func (ctl *UserController) RestoreCustomPersona(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cus, err := ctl.userRepo.CustomConfig(ctx, user.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("get user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	persona, err := cus.RestorePersona(webCtx.Int64Input("version", 0), time.Now())
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	if err := ctl.userRepo.UpdateCustomConfig(ctx, user.ID, *cus); err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("update user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"persona": persona})
+}
+
+// CustomSpendLimit 查询单次请求的智慧果上限
+func (ctl *UserController) CustomSpendLimit(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	cus, err := ctl.userRepo.CustomConfig(ctx, user.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("get user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"limit":         cus.SpendLimit,
+		"default_limit": ctl.conf.MaxCoinsPerRequest,
+		"effective":     cus.EffectiveSpendLimit(int64(ctl.conf.MaxCoinsPerRequest)),
+	})
+}
+
+// UpdateCustomSpendLimit 设置单次请求的智慧果上限，limit 为 0 时使用系统默认值，为 -1 时不限制
+func (ctl *UserController) UpdateCustomSpendLimit(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	limit := webCtx.Int64Input("limit", 0)
+	if limit < -1 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	cus, err := ctl.userRepo.CustomConfig(ctx, user.ID)
+	if err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("get user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	cus.SpendLimit = limit
+	if err := ctl.userRepo.UpdateCustomConfig(ctx, user.ID, *cus); err != nil {
+		log.WithFields(log.Fields{"user_id": user.ID}).Errorf("update user custom config failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"limit":     cus.SpendLimit,
+		"effective": cus.EffectiveSpendLimit(int64(ctl.conf.MaxCoinsPerRequest)),
+	})
+}
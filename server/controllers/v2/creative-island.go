@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/mylxsw/aidea-server/pkg/misc"
+	"github.com/mylxsw/aidea-server/pkg/provenance"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	service2 "github.com/mylxsw/aidea-server/pkg/service"
 	"github.com/mylxsw/aidea-server/pkg/uploader"
@@ -20,6 +21,7 @@ import (
 	"github.com/mylxsw/go-utils/ternary"
 	"github.com/redis/go-redis/v9"
 
+	"github.com/hibiken/asynq"
 	"github.com/mylxsw/aidea-server/config"
 	"github.com/mylxsw/aidea-server/internal/coins"
 	"github.com/mylxsw/aidea-server/internal/queue"
@@ -33,19 +35,30 @@ import (
 )
 
 const (
-	AllInOneIslandID = "all-in-one"
+	AllInOneIslandID     = "all-in-one"
+	EssayGradingIslandID = "essay-grading"
+	AvatarPackIslandID   = "avatar-pack"
 )
 
 // CreativeIslandController 创作岛
 type CreativeIslandController struct {
-	conf         *config.Config
-	quotaRepo    *repo2.QuotaRepo          `autowire:"@"`
-	queue        *queue.Queue              `autowire:"@"`
-	trans        youdao.Translater         `autowire:"@"`
-	creativeRepo *repo2.CreativeRepo       `autowire:"@"`
-	securitySrv  *service2.SecurityService `autowire:"@"`
-	userSvc      *service2.UserService     `autowire:"@"`
-	rds          *redis.Client             `autowire:"@"`
+	conf          *config.Config
+	quotaRepo     *repo2.QuotaRepo                `autowire:"@"`
+	queue         *queue.Queue                    `autowire:"@"`
+	trans         youdao.Translater               `autowire:"@"`
+	creativeRepo  *repo2.CreativeRepo             `autowire:"@"`
+	securitySrv   *service2.SecurityService       `autowire:"@"`
+	userSvc       *service2.UserService           `autowire:"@"`
+	restrictedSrv *service2.RestrictedModeService `autowire:"@"`
+	keywordFilter *service2.KeywordFilterService  `autowire:"@"`
+	geoPolicy     *service2.GeoPolicyService      `autowire:"@"`
+	rds           *redis.Client                   `autowire:"@"`
+	imagePrompt   *repo2.ImagePromptRepo          `autowire:"@"`
+	magicPrompt   *service2.MagicPromptService    `autowire:"@"`
+	avatarPack    *repo2.AvatarPackRepo           `autowire:"@"`
+	imagePreset   *repo2.ImagePresetRepo          `autowire:"@"`
+	interviewRepo *repo2.MockInterviewRepo        `autowire:"@"`
+	interviewSrv  *service2.MockInterviewService  `autowire:"@"`
 }
 
 // NewCreativeIslandController create a new CreativeIslandController
@@ -64,6 +77,15 @@ func (ctl *CreativeIslandController) Register(router web.Router) {
 		router.Get("/capacity", ctl.Capacity)
 		router.Get("/models", ctl.Models)
 		router.Get("/filters", ctl.ImageStyles)
+		router.Get("/prompt-styles", ctl.PromptStyles)
+		router.Post("/prompt/enhance", ctl.EnhancePrompt)
+
+		router.Group("/presets", func(router web.Router) {
+			router.Get("/", ctl.Presets)
+			router.Post("/", ctl.CreatePreset)
+			router.Put("/{id}", ctl.UpdatePreset)
+			router.Delete("/{id}", ctl.DeletePreset)
+		})
 
 		router.Group("/histories", func(router web.Router) {
 			router.Get("/", ctl.Histories)
@@ -84,7 +106,24 @@ func (ctl *CreativeIslandController) Register(router web.Router) {
 			router.Post("/colorize", ctl.ImageColorize)
 			// QR 生成、艺术字生成
 			router.Post("/artistic-text", ctl.ArtisticText)
+			// 作文批改
+			router.Post("/essay-grading", ctl.EssayGrading)
+			// 数字分身写真
+			router.Post("/avatar-pack", ctl.AvatarPack)
+		})
+
+		router.Get("/avatar-pack/themes", ctl.AvatarPackThemes)
+
+		// 模拟面试
+		router.Group("/mock-interview", func(router web.Router) {
+			router.Post("/", ctl.StartMockInterview)
+			router.Get("/{id}", ctl.MockInterview)
+			router.Post("/{id}/answer", ctl.AnswerMockInterview)
 		})
+
+		// 先报价后确认的任务创建方式，避免价格较高的任务产生意外扣费
+		router.Post("/quotes", ctl.Quote)
+		router.Post("/quotes/{id}/confirm", ctl.ConfirmQuote)
 	})
 }
 
@@ -167,6 +206,42 @@ func (ctl *CreativeIslandController) Items(ctx context.Context, webCtx web.Conte
 		})
 	}
 
+	if ctl.conf.EssayGradingModel != "" {
+		items = append(items, CreativeIslandItem{
+			ID:           EssayGradingIslandID,
+			Title:        "作文批改",
+			TitleColor:   "FFFFFFFF",
+			PreviewImage: "https://ssl.aicode.cc/ai-server/assets/background/essay-grading.jpg-thumb1000",
+			RouteURI:     "/creative-island/essay-grading",
+			Note:         "输入或拍照上传作文，按照评分标准给出分项得分、优缺点以及修改建议。",
+			Size:         SizeMedium,
+		})
+	}
+
+	if ctl.interviewSrv.Enabled() {
+		items = append(items, CreativeIslandItem{
+			ID:           service2.MockInterviewIslandID,
+			Title:        "模拟面试",
+			TitleColor:   "FFFFFFFF",
+			PreviewImage: "https://ssl.aicode.cc/ai-server/assets/background/mock-interview.jpg-thumb1000",
+			RouteURI:     "/creative-island/mock-interview",
+			Note:         "选择面试岗位，由 AI 面试官逐题提问，面试结束后从沟通表达、专业深度等方面评分并给出改进建议。",
+			Size:         SizeMedium,
+		})
+	}
+
+	if ctl.conf.EnableAvatarPack {
+		items = append(items, CreativeIslandItem{
+			ID:           AvatarPackIslandID,
+			Title:        "数字分身",
+			TitleColor:   "FFFFFFFF",
+			PreviewImage: "https://ssl.aicode.cc/ai-server/assets/background/avatar-pack.jpg-thumb1000",
+			RouteURI:     "/creative-island/avatar-pack",
+			Note:         "上传几张自拍照，生成一整套不同主题的个人写真，自拍照在生成完成后自动删除。",
+			Size:         SizeMedium,
+		})
+	}
+
 	// 如果中等大小的项目不足 2 个，则把所有的项目都设置为大尺寸
 	// TODO 临时处理
 	if len(array.Filter(items, func(item CreativeIslandItem, _ int) bool { return item.Size == SizeMedium })) < 2 {
@@ -237,6 +312,10 @@ func (ctl *CreativeIslandController) loadAllModels(ctx context.Context) []repo2.
 			return ctl.conf.EnableOpenAIDalle
 		}
 
+		if m.Vendor == "zhipu" {
+			return ctl.conf.EnableZhipu
+		}
+
 		return true
 	})
 }
@@ -271,6 +350,88 @@ func (ctl *CreativeIslandController) ImageStyles(ctx context.Context, webCtx web
 	})
 }
 
+// PromptStyles 提示语优化可选的风格
+func (ctl *CreativeIslandController) PromptStyles(ctx context.Context, webCtx web.Context) web.Response {
+	styles, err := ctl.imagePrompt.Styles(ctx, true)
+	if err != nil {
+		log.Errorf("query image prompt styles failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	// 风格要求是提供给模型的提示语，不返回给客户端
+	return webCtx.JSON(web.M{
+		"data": array.Map(styles, func(item repo2.ImagePromptStyle, _ int) repo2.ImagePromptStyle {
+			item.Instruction = ""
+			return item
+		}),
+	})
+}
+
+// magicPromptMaxWords 提示语优化时，用户输入的最大字数
+const magicPromptMaxWords = 500
+
+// EnhancePrompt 提示语优化，将用户输入的简短描述扩写为详细的图片生成提示语
+// 请求参数：
+// - prompt: 用户输入的提示语
+// - style_id: 风格 ID，可选
+// - target: 目标模型类型，sd 或 dalle，默认为 sd
+func (ctl *CreativeIslandController) EnhancePrompt(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	prompt := strings.TrimSpace(webCtx.Input("prompt"))
+	if prompt == "" {
+		return webCtx.JSONError("prompt is required", http.StatusBadRequest)
+	}
+
+	if misc.WordCount(prompt) > magicPromptMaxWords {
+		return webCtx.JSONError(fmt.Sprintf("提示语输入字数不能超过 %d", magicPromptMaxWords), http.StatusBadRequest)
+	}
+
+	target := webCtx.InputWithDefault("target", service2.MagicPromptTargetSD)
+	if !array.In(target, []string{service2.MagicPromptTargetSD, service2.MagicPromptTargetDALLE}) {
+		return webCtx.JSONError("invalid target", http.StatusBadRequest)
+	}
+
+	var style *repo2.ImagePromptStyle
+	if styleID := webCtx.Int64Input("style_id", 0); styleID > 0 {
+		var err error
+		if style, err = ctl.imagePrompt.Style(ctx, styleID); err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				return webCtx.JSONError("invalid style_id", http.StatusBadRequest)
+			}
+
+			log.F(log.M{"style_id": styleID}).Errorf("query image prompt style failed: %v", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+	}
+
+	quota, err := ctl.userSvc.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.Errorf("get user quota failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < coins.GetOpenAITextCoins(ctl.conf.MagicPromptModel, int64(misc.WordCount(prompt))) {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	// 内容安全检测
+	if checkRes := ctl.securitySrv.PromptDetect(prompt); checkRes != nil && checkRes.IsReallyUnSafe() {
+		log.WithFields(log.Fields{
+			"user_id": user.ID,
+			"details": checkRes.ReasonDetail(),
+			"content": prompt,
+		}).Errorf("用户 %d 违规，违规内容：%s", user.ID, checkRes.Reason)
+		return webCtx.JSONError(fmt.Sprintf("内容违规，已被系统拦截，如有疑问邮件联系：support@aicode.cc\n\n原因：%s", checkRes.ReasonDetail()), http.StatusNotAcceptable)
+	}
+
+	res, err := ctl.magicPrompt.Enhance(ctx, user.ID, prompt, target, style)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "prompt": prompt}).Errorf("提示语优化失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": res})
+}
+
 // Capacity 文生图、图生图支持的能力，用于控制客户端显示哪些允许用户配置的参数
 func (ctl *CreativeIslandController) Capacity(ctx context.Context, webCtx web.Context, user *auth.UserOptional) web.Response {
 	mode := webCtx.InputWithDefault("mode", "text-to-image")
@@ -300,6 +461,10 @@ func (ctl *CreativeIslandController) Capacity(ctx context.Context, webCtx web.Co
 				return false
 			}
 
+			if !ctl.conf.EnableZhipu && item.Vendor == "zhipu" {
+				return false
+			}
+
 			return str.In(mode, item.Supports)
 		}),
 		func(f1, f2 ImageStyle) bool { return sortorder.NaturalLess(f1.Name, f2.Name) },
@@ -411,10 +576,16 @@ func (ctl *CreativeIslandController) Histories(ctx context.Context, webCtx web.C
 		perPage = 20
 	}
 
+	// mode=essay-grading 时查询作文批改的历史记录，mode=avatar-pack 时查询数字分身写真的历史记录，
+	// mode=mock-interview 时查询模拟面试的历史记录，否则查询绘图相关的历史记录
+	islandID := AllInOneIslandID
+	if mode := webCtx.Input("mode"); mode == EssayGradingIslandID || mode == AvatarPackIslandID || mode == service2.MockInterviewIslandID {
+		islandID = mode
+	}
 	items, meta, err := ctl.creativeRepo.HistoryRecordPaginate(ctx, user.ID, repo2.CreativeHistoryQuery{
 		Page:        page,
 		PerPage:     perPage,
-		IslandId:    AllInOneIslandID,
+		IslandId:    islandID,
 		IslandModel: webCtx.Input("model"),
 	})
 	if err != nil {
@@ -435,8 +606,15 @@ func (ctl *CreativeIslandController) Histories(ctx context.Context, webCtx web.C
 				data, _ := json.Marshal(map[string]any{"image": image})
 				item.Arguments = string(data)
 			}
+
+			// 模拟面试保留面试 ID，用于客户端查看面试过程
+			if interviewID, ok := arguments["interview_id"]; ok {
+				data, _ := json.Marshal(map[string]any{"interview_id": interviewID})
+				item.Arguments = string(data)
+			}
 		}
 
+		prompt := item.Prompt
 		item.Prompt = ""
 		item.QuotaUsed = 0
 
@@ -455,6 +633,12 @@ func (ctl *CreativeIslandController) Histories(ctx context.Context, webCtx web.C
 			item.IslandTitle = "高清修复"
 		case int64(repo2.IslandTypeImageColorization):
 			item.IslandTitle = "图片上色"
+		case int64(repo2.IslandTypeEssayGrading):
+			item.IslandTitle = "作文批改"
+		case int64(repo2.IslandTypeAvatarPack):
+			item.IslandTitle = "数字分身"
+		case int64(repo2.IslandTypeMockInterview):
+			item.IslandTitle = "模拟面试：" + prompt
 		}
 
 		// 客户端目前不支持封禁状态展示，这里转换为失败
@@ -485,6 +669,8 @@ func (ctl *CreativeIslandController) Histories(ctx context.Context, webCtx web.C
 type CreativeHistoryItemResp struct {
 	repo2.CreativeHistoryItem
 	ShowBetaFeature bool `json:"show_beta_feature,omitempty"`
+	// Provenance AI 生成内容的来源信息，只有生成成功的记录才有
+	Provenance *provenance.Metadata `json:"provenance,omitempty"`
 }
 
 // HistoryItem 获取创作岛项目的历史记录详情
@@ -514,10 +700,17 @@ func (ctl *CreativeIslandController) HistoryItem(ctx context.Context, webCtx web
 		item.Status = int64(repo2.CreativeStatusFailed)
 	}
 
-	return webCtx.JSON(CreativeHistoryItemResp{
+	resp := CreativeHistoryItemResp{
 		CreativeHistoryItem: *item,
 		ShowBetaFeature:     user.InternalUser(),
-	})
+	}
+
+	if item.Status == int64(repo2.CreativeStatusSuccess) {
+		meta := item.Provenance()
+		resp.Provenance = &meta
+	}
+
+	return webCtx.JSON(resp)
 }
 
 // DeleteHistoryItem 删除创作岛项目的历史记录
@@ -583,21 +776,33 @@ func (ctl *CreativeIslandController) resolveImageCompletionRequest(ctx context.C
 		return nil, webCtx.JSONError("prompt is required", http.StatusBadRequest)
 	}
 
-	negativePrompt := strings.ReplaceAll(strings.TrimSpace(webCtx.Input("negative_prompt")), "，", ",")
+	// 使用参数预设时，用户没有指定的参数使用预设中的值
+	var preset repo2.ImagePresetParams
+	presetID := webCtx.Int64Input("preset_id", 0)
+	if presetID > 0 {
+		item, err := ctl.imagePreset.Preset(ctx, user.ID, presetID)
+		if err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				return nil, webCtx.JSONError("invalid preset_id", http.StatusBadRequest)
+			}
+
+			log.F(log.M{"user_id": user.ID, "preset_id": presetID}).Errorf("查询参数预设失败: %v", err)
+			return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		preset = item.Params
+	}
+
+	negativePrompt := strings.ReplaceAll(strings.TrimSpace(webCtx.InputWithDefault("negative_prompt", preset.NegativePrompt)), "，", ",")
 	if misc.WordCount(negativePrompt) > 1000 {
 		return nil, webCtx.JSONError(fmt.Sprintf("排除内容输入字数不能超过 %d", 1000), http.StatusBadRequest)
 	}
 
-	imageCount := webCtx.Int64Input("image_count", 1)
+	imageCount := webCtx.Int64Input("image_count", ternary.If(preset.ImageCount > 0, preset.ImageCount, 1))
 	if imageCount < 1 || imageCount > 4 {
 		return nil, webCtx.JSONError("invalid image count", http.StatusBadRequest)
 	}
 
-	steps := webCtx.IntInput("steps", 30)
-	if !array.In(steps, []int{30, 50, 100, 150}) {
-		return nil, webCtx.JSONError("invalid steps", http.StatusBadRequest)
-	}
-
 	// AI 自动改写
 	aiRewrite := webCtx.InputWithDefault("ai_rewrite", "false") == "true"
 	// 图生图模式，不启用 AI 改写
@@ -605,45 +810,90 @@ func (ctl *CreativeIslandController) resolveImageCompletionRequest(ctx context.C
 		aiRewrite = false
 	}
 
+	// 使用提示语优化的结果生成图片，优化后的提示语不再使用 AI 改写
+	var originalPrompt string
+	enhancementID := webCtx.Int64Input("prompt_enhancement_id", 0)
+	if enhancementID > 0 {
+		enhancement, err := ctl.imagePrompt.Enhancement(ctx, user.ID, enhancementID)
+		if err != nil {
+			if errors.Is(err, repo2.ErrNotFound) {
+				return nil, webCtx.JSONError("invalid prompt_enhancement_id", http.StatusBadRequest)
+			}
+
+			log.F(log.M{"user_id": user.ID, "enhancement_id": enhancementID}).Errorf("查询提示语优化记录失败: %v", err)
+			return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		originalPrompt = enhancement.Prompt
+		prompt = enhancement.EnhancedPrompt
+		if webCtx.Input("negative_prompt") == "" {
+			negativePrompt = enhancement.NegativePrompt
+		}
+
+		aiRewrite = false
+	}
+
 	upscaleBy := webCtx.InputWithDefault("upscale_by", "x1")
 	if !array.In(upscaleBy, []string{"x1", "x2", "x4"}) {
 		return nil, webCtx.JSONError("invalid upscale_by", http.StatusBadRequest)
 	}
 
-	stylePreset := webCtx.Input("style_preset")
+	stylePreset := webCtx.InputWithDefault("style_preset", preset.StylePreset)
 
-	modelID := webCtx.InputWithDefault(
-		"model",
-		ternary.If(image != "", ctl.conf.DefaultImageToImageModel, ctl.conf.DefaultTextToImageModel),
-	)
-	filterID := webCtx.Int64Input("filter_id", 0)
+	defaultModelID := ternary.If(image != "", ctl.conf.DefaultImageToImageModel, ctl.conf.DefaultTextToImageModel)
+	modelID := webCtx.InputWithDefault("model", ternary.If(preset.Model != "", preset.Model, defaultModelID))
+	filterID := webCtx.Int64Input("filter_id", preset.FilterID)
 	var filterName, defaultFilterMode string
+	var style *ImageStyle
 	if filterID > 0 {
-		filter := ctl.getStyleByID(ctx, filterID)
-		if filter == nil {
+		style = ctl.getStyleByID(ctx, filterID)
+		if style == nil {
 			return nil, webCtx.JSONError("invalid filter_id", http.StatusBadRequest)
 		}
 
-		modelID = filter.ModelID
-		filterName = filter.Name
-		defaultFilterMode = filter.Mode
+		modelID = style.ModelID
+		filterName = style.Name
+		defaultFilterMode = style.Mode
 	} else {
 		// 如果没有指定 filter， 则自动根据模型补充 filter 信息
 		mode := ternary.If(image != "", "image-to-image", "text-to-image")
-		filter := ctl.getStyleByModelID(ctx, modelID, mode)
-		if filter != nil {
-			filterID = filter.ID
-			filterName = filter.Name
-			defaultFilterMode = filter.Mode
+		style = ctl.getStyleByModelID(ctx, modelID, mode)
+		if style != nil {
+			filterID = style.ID
+			filterName = style.Name
+			defaultFilterMode = style.Mode
 		}
 	}
 
+	// 用户没有指定的参数，使用风格的默认参数
+	defaults := repo2.ImageFilterDefaults{Steps: 30, ImageRatio: "1:1", ImageStrength: 0.65}
+	var extraCoins int64
+	if style != nil {
+		if style.Defaults != nil {
+			defaults.Steps = ternary.If(style.Defaults.Steps > 0, style.Defaults.Steps, defaults.Steps)
+			defaults.ImageRatio = ternary.If(style.Defaults.ImageRatio != "", style.Defaults.ImageRatio, defaults.ImageRatio)
+			defaults.ImageStrength = ternary.If(style.Defaults.ImageStrength > 0, style.Defaults.ImageStrength, defaults.ImageStrength)
+		}
+
+		extraCoins = style.ExtraCoins
+	}
+
+	// 参数预设优先于风格的默认参数
+	defaults.Steps = ternary.If(preset.Steps > 0, preset.Steps, defaults.Steps)
+	defaults.ImageRatio = ternary.If(preset.ImageRatio != "", preset.ImageRatio, defaults.ImageRatio)
+	defaults.ImageStrength = ternary.If(preset.ImageStrength > 0, preset.ImageStrength, defaults.ImageStrength)
+
+	steps := webCtx.IntInput("steps", int(defaults.Steps))
+	if !array.In(steps, []int{30, 50, 100, 150}) {
+		return nil, webCtx.JSONError("invalid steps", http.StatusBadRequest)
+	}
+
 	vendorModel := ctl.getVendorModel(ctx, modelID)
 	if vendorModel == nil {
 		return nil, webCtx.JSONError("没有找到匹配的模型", http.StatusBadRequest)
 	}
 
-	imageRatio := webCtx.InputWithDefault("image_ratio", "1:1")
+	imageRatio := webCtx.InputWithDefault("image_ratio", defaults.ImageRatio)
 	if !array.In(imageRatio, []string{"1:1", "4:3", "3:4", "3:2", "2:3", "16:9"}) {
 		return nil, webCtx.JSONError("invalid image ratio", http.StatusBadRequest)
 	}
@@ -660,13 +910,17 @@ func (ctl *CreativeIslandController) resolveImageCompletionRequest(ctx context.C
 
 	// 根据模型配置，自动调整相关参数（width/height）
 	dimension := vendorModel.GetDimension(imageRatio)
+	// 预设中保存了自定义尺寸，且用户没有指定图片比例时，使用预设的尺寸
+	if preset.Width > 0 && preset.Height > 0 && webCtx.Input("image_ratio") == "" {
+		dimension.Width, dimension.Height = int(preset.Width), int(preset.Height)
+	}
 
 	width, height := webCtx.IntInput("width", dimension.Width), webCtx.IntInput("height", dimension.Height)
 	if width < 1 || height < 1 || width > 2048 || height > 2048 {
 		return nil, webCtx.JSONError("invalid width or height", http.StatusBadRequest)
 	}
 
-	imageStrength := webCtx.Float64Input("image_strength", 0.65)
+	imageStrength := webCtx.Float64Input("image_strength", defaults.ImageStrength)
 	if imageStrength < 0 || imageStrength > 1 {
 		return nil, webCtx.JSONError("invalid image_strength", http.StatusBadRequest)
 	}
@@ -705,8 +959,12 @@ func (ctl *CreativeIslandController) resolveImageCompletionRequest(ctx context.C
 		FilterName:     filterName,
 		GalleryCopyID:  webCtx.Int64Input("gallery_copy_id", 0),
 
+		OriginalPrompt:      originalPrompt,
+		PromptEnhancementID: enhancementID,
+		PresetID:            presetID,
+
 		UID:       user.ID,
-		Quota:     int64(coins.GetUnifiedImageGenCoins(vendorModel.Model)) * imageCount,
+		Quota:     styleImageCoins(int64(coins.GetUnifiedImageGenCoins(vendorModel.Model)), extraCoins) * imageCount,
 		CreatedAt: time.Now(),
 
 		Vendor:    vendorModel.Vendor,
@@ -760,6 +1018,19 @@ type ImageStyle struct {
 	Prompt         string   `json:"-"`
 	NegativePrompt string   `json:"-"`
 	Supports       []string `json:"-"`
+	// Defaults 风格的默认生成参数
+	Defaults *repo2.ImageFilterDefaults `json:"defaults,omitempty"`
+	// ExtraCoins 使用该风格时每张图片调整的智慧果数量
+	ExtraCoins int64 `json:"extra_coins,omitempty"`
+}
+
+// styleImageCoins 使用风格时每张图片的价格，风格优惠后价格不低于 0
+func styleImageCoins(modelCoins, extraCoins int64) int64 {
+	if modelCoins+extraCoins < 0 {
+		return 0
+	}
+
+	return modelCoins + extraCoins
 }
 
 func (ctl *CreativeIslandController) getAllImageStyles(ctx context.Context) []ImageStyle {
@@ -781,6 +1052,8 @@ func (ctl *CreativeIslandController) getAllImageStyles(ctx context.Context) []Im
 			NegativePrompt: f.ImageMeta.NegativePrompt,
 			Supports:       f.ImageMeta.Supports,
 			Vendor:         f.Vendor,
+			Defaults:       f.ImageMeta.Defaults,
+			ExtraCoins:     f.ImageMeta.ExtraCoins,
 		}
 	})
 }
@@ -878,40 +1151,45 @@ func (vm VendorModel) GetDimension(ratio string) repo2.Dimension {
 }
 
 func (ctl *CreativeIslandController) ImageUpscale(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	req, errResp := ctl.resolveImageUpscaleRequest(webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	return ctl.submitImageUpscale(ctx, webCtx, user, req)
+}
+
+// resolveImageUpscaleRequest 解析图片放大请求参数
+func (ctl *CreativeIslandController) resolveImageUpscaleRequest(webCtx web.Context, user *auth.User) (*queue.ImageUpscalePayload, web.Response) {
 	image := webCtx.Input("image")
 	if image != "" && !str.HasPrefixes(image, []string{"http://", "https://"}) {
-		return webCtx.JSONError("invalid image", http.StatusBadRequest)
+		return nil, webCtx.JSONError("invalid image", http.StatusBadRequest)
 	}
 
 	// 图片地址检查
 	if !strings.HasPrefix(image, ctl.conf.StorageDomain) {
-		return webCtx.JSONError("invalid image", http.StatusBadRequest)
-	}
-
-	// 检查用户是否有足够的智慧果
-	quota, err := ctl.userSvc.UserQuota(ctx, user.ID)
-	if err != nil {
-		log.Errorf("get user quota failed: %s", err)
-		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
-	}
-
-	quotaConsume := int64(coins.GetUnifiedImageGenCoins(""))
-	if quota.Rest-quota.Freezed < quotaConsume {
-		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+		return nil, webCtx.JSONError("invalid image", http.StatusBadRequest)
 	}
 
-	upscaleBy := "x4"
-
-	req := queue.ImageUpscalePayload{
+	return &queue.ImageUpscalePayload{
 		UserID:    user.ID,
 		Image:     image,
-		UpscaleBy: upscaleBy,
-		Quota:     quotaConsume,
+		UpscaleBy: "x4",
+		Quota:     int64(coins.GetUnifiedImageGenCoins("")),
 		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (ctl *CreativeIslandController) submitImageUpscale(ctx context.Context, webCtx web.Context, user *auth.User, req *queue.ImageUpscalePayload) web.Response {
+	if resp := ctl.checkQuotaEnough(ctx, webCtx, user, req.Quota); resp != nil {
+		return resp
 	}
 
+	// 确认报价时的计费钱包可能与报价时不同，以提交任务时的计费钱包为准
+	req.OrgID = repo2.BillingOrgFromContext(ctx)
+
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(&req, queue.NewImageUpscaleTask)
+	taskID, err := ctl.queue.EnqueueContext(ctx, req, queue.NewImageUpscaleTask)
 	if err != nil {
 		log.Errorf("enqueue task failed: %s", err)
 		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
@@ -935,8 +1213,8 @@ func (ctl *CreativeIslandController) ImageUpscale(ctx context.Context, webCtx we
 	}
 
 	arg := repo2.CreativeRecordArguments{
-		Image:     image,
-		UpscaleBy: upscaleBy,
+		Image:     req.Image,
+		UpscaleBy: req.UpscaleBy,
 	}
 
 	// 保存历史记录
@@ -952,37 +1230,43 @@ func (ctl *CreativeIslandController) ImageUpscale(ctx context.Context, webCtx we
 }
 
 func (ctl *CreativeIslandController) ImageColorize(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	req, errResp := ctl.resolveImageColorizeRequest(webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	return ctl.submitImageColorize(ctx, webCtx, user, req)
+}
+
+// resolveImageColorizeRequest 解析图片上色请求参数
+func (ctl *CreativeIslandController) resolveImageColorizeRequest(webCtx web.Context, user *auth.User) (*queue.ImageColorizationPayload, web.Response) {
 	image := webCtx.Input("image")
 	if image != "" && !str.HasPrefixes(image, []string{"http://", "https://"}) {
-		return webCtx.JSONError("invalid image", http.StatusBadRequest)
+		return nil, webCtx.JSONError("invalid image", http.StatusBadRequest)
 	}
 
 	// 图片地址检查
 	if !strings.HasPrefix(image, ctl.conf.StorageDomain) {
-		return webCtx.JSONError("invalid image", http.StatusBadRequest)
-	}
-
-	// 检查用户是否有足够的智慧果
-	quota, err := ctl.userSvc.UserQuota(ctx, user.ID)
-	if err != nil {
-		log.Errorf("get user quota failed: %s", err)
-		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
-	}
-
-	quotaConsume := int64(coins.GetUnifiedImageGenCoins(""))
-	if quota.Rest-quota.Freezed < quotaConsume {
-		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+		return nil, webCtx.JSONError("invalid image", http.StatusBadRequest)
 	}
 
-	req := queue.ImageColorizationPayload{
+	return &queue.ImageColorizationPayload{
 		UserID:    user.ID,
 		Image:     image,
-		Quota:     quotaConsume,
+		Quota:     int64(coins.GetUnifiedImageGenCoins("")),
 		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (ctl *CreativeIslandController) submitImageColorize(ctx context.Context, webCtx web.Context, user *auth.User, req *queue.ImageColorizationPayload) web.Response {
+	if resp := ctl.checkQuotaEnough(ctx, webCtx, user, req.Quota); resp != nil {
+		return resp
 	}
 
+	req.OrgID = repo2.BillingOrgFromContext(ctx)
+
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(&req, queue.NewImageColorizationTask)
+	taskID, err := ctl.queue.EnqueueContext(ctx, req, queue.NewImageColorizationTask)
 	if err != nil {
 		log.Errorf("enqueue task failed: %s", err)
 		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
@@ -1006,7 +1290,7 @@ func (ctl *CreativeIslandController) ImageColorize(ctx context.Context, webCtx w
 	}
 
 	arg := repo2.CreativeRecordArguments{
-		Image: image,
+		Image: req.Image,
 	}
 
 	// 保存历史记录
@@ -1094,6 +1378,7 @@ func (ctl *CreativeIslandController) ArtisticText(ctx context.Context, webCtx we
 
 	req := queue.ArtisticTextCompletionPayload{
 		Quota:     quotaConsume,
+		OrgID:     repo2.BillingOrgFromContext(ctx),
 		CreatedAt: time.Now(),
 
 		Text:           text,
@@ -1118,7 +1403,7 @@ func (ctl *CreativeIslandController) ArtisticText(ctx context.Context, webCtx we
 	}
 
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(&req, queue.NewArtisticTextCompletionTask)
+	taskID, err := ctl.queue.EnqueueContext(ctx, &req, queue.NewArtisticTextCompletionTask)
 	if err != nil {
 		log.Errorf("enqueue task failed: %s", err)
 		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
@@ -1189,6 +1474,32 @@ func (ctl *CreativeIslandController) Completions(ctx context.Context, webCtx web
 		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
 	}
 
+	// 地区访问策略
+	if decision := ctl.geoPolicy.Decide(ctx, client.Region, repo2.GeoFeatureCreative, ""); decision.Blocked {
+		log.F(log.M{"user_id": user.ID, "geo": decision}).Warningf("用户 %d 所在地区禁止使用创作岛", user.ID)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrRegionRestricted), http.StatusUnavailableForLegalReasons)
+	}
+
+	// 受限模式（家长模式）检查
+	if err := ctl.restrictedSrv.CheckImage(ctx, user.ID, req.Vendor, req.Prompt); err != nil {
+		var violation *service2.RestrictedModeViolationError
+		if errors.As(err, &violation) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, violation.Reason), http.StatusForbidden)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("check restricted mode failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	// 部署方自定义的关键词过滤
+	filterRes := ctl.keywordFilter.Filter(ctx, user.ID, repo2.KeywordFilterScopePrompt, req.Prompt)
+	if filterRes.Blocked {
+		log.F(log.M{"user_id": user.ID, "hits": filterRes.Hits}).Warningf("用户 %d 的创作请求命中关键词拦截规则", user.ID)
+		return webCtx.JSONError("内容违规，已被系统拦截，如有疑问邮件联系：support@aicode.cc", http.StatusNotAcceptable)
+	}
+
+	req.Prompt = filterRes.Content
+
 	// 内容安全检测
 	if checkRes := ctl.securitySrv.PromptDetect(req.Prompt); checkRes != nil {
 		if checkRes.IsReallyUnSafe() {
@@ -1201,8 +1512,10 @@ func (ctl *CreativeIslandController) Completions(ctx context.Context, webCtx web
 		}
 	}
 
+	req.OrgID = repo2.BillingOrgFromContext(ctx)
+
 	// 加入异步任务队列
-	taskID, err := ctl.queue.Enqueue(req, queue.NewImageCompletionTask)
+	taskID, err := ctl.queue.EnqueueContext(ctx, req, queue.NewImageCompletionTask)
 	if err != nil {
 		log.Errorf("enqueue task failed: %s", err)
 		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
@@ -1260,5 +1573,410 @@ func (*CreativeIslandController) buildHistorySaveRecord(req *queue.ImageCompleti
 		FilterName:     req.FilterName,
 		GalleryCopyID:  req.GalleryCopyID,
 		Seed:           req.Seed,
+
+		OriginalPrompt:      req.OriginalPrompt,
+		PromptEnhancementID: req.PromptEnhancementID,
+		PresetID:            req.PresetID,
+	}
+}
+
+// essayGradingMaxImages 作文批改最多支持上传的图片数量
+const essayGradingMaxImages = 4
+
+// EssayGrading 作文批改
+// 请求参数：
+// - text: 作文内容，与 images 二选一
+// - images: 作文图片地址，多张图片使用英文逗号分隔，服务端识别文字后批改
+// - grade: 年级或考试类型，可选
+// - requirement: 作文题目要求，可选
+func (ctl *CreativeIslandController) EssayGrading(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	if ctl.conf.EssayGradingModel == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "作文批改功能暂未开放"), http.StatusServiceUnavailable)
+	}
+
+	text := strings.TrimSpace(webCtx.Input("text"))
+	images := array.Filter(strings.Split(webCtx.Input("images"), ","), func(item string, _ int) bool { return strings.TrimSpace(item) != "" })
+	images = array.Map(images, func(item string, _ int) string { return strings.TrimSpace(item) })
+
+	if (text == "" && len(images) == 0) || (text != "" && len(images) > 0) {
+		return webCtx.JSONError("text or images is required", http.StatusBadRequest)
+	}
+
+	if len(images) > essayGradingMaxImages {
+		return webCtx.JSONError(fmt.Sprintf("最多支持上传 %d 张图片", essayGradingMaxImages), http.StatusBadRequest)
 	}
+
+	for _, image := range images {
+		// 图片地址检查
+		if !strings.HasPrefix(image, ctl.conf.StorageDomain) {
+			return webCtx.JSONError("invalid image", http.StatusBadRequest)
+		}
+	}
+
+	if misc.WordCount(text) > 5000 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "作文字数不能超过 5000 字"), http.StatusBadRequest)
+	}
+
+	grade := misc.WordTruncate(strings.TrimSpace(webCtx.Input("grade")), 20)
+	requirement := misc.WordTruncate(strings.TrimSpace(webCtx.Input("requirement")), 500)
+
+	// 内容安全检测
+	if text != "" {
+		if checkRes := ctl.securitySrv.PromptDetect(text); checkRes != nil && checkRes.IsReallyUnSafe() {
+			log.WithFields(log.Fields{
+				"user_id": user.ID,
+				"details": checkRes.ReasonDetail(),
+				"content": text,
+			}).Warningf("用户 %d 违规，违规内容：%s", user.ID, checkRes.Reason)
+			return webCtx.JSONError(fmt.Sprintf("内容违规，已被系统拦截，如有疑问邮件联系：support@aicode.cc\n\n原因：%s", checkRes.ReasonDetail()), http.StatusNotAcceptable)
+		}
+	}
+
+	// 检查用户是否有足够的智慧果
+	quota, err := ctl.userSvc.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.Errorf("get user quota failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	quotaConsume := coins.GetEssayGradingCoins(len(images))
+	if quota.Rest-quota.Freezed < quotaConsume {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	req := queue.EssayGradingPayload{
+		UserID:      user.ID,
+		OrgID:       repo2.BillingOrgFromContext(ctx),
+		Model:       ctl.conf.EssayGradingModel,
+		Text:        text,
+		Images:      images,
+		Grade:       grade,
+		Requirement: requirement,
+		Quota:       quotaConsume,
+		CreatedAt:   time.Now(),
+	}
+
+	// 加入异步任务队列
+	taskID, err := ctl.queue.EnqueueContext(ctx, &req, queue.NewEssayGradingTask)
+	if err != nil {
+		log.Errorf("enqueue task failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+	log.WithFields(log.Fields{"task_id": taskID}).Debugf("enqueue task success: %s", taskID)
+
+	// 冻结智慧果
+	if err := ctl.userSvc.FreezeUserQuota(ctx, user.ID, req.Quota); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": req.Quota, "task_id": taskID}).Errorf("创作岛冻结用户配额失败: %s", err)
+	}
+
+	if err := ctl.rds.SetEx(ctx, fmt.Sprintf("creative-island:%d:task:%s:quota-freeze", user.ID, taskID), req.Quota, 5*time.Minute).Err(); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": req.Quota, "task_id": taskID}).Errorf("创作岛用户配额已冻结，更新 Redis 任务与配额关系失败: %s", err)
+	}
+
+	creativeItem := repo2.CreativeItem{
+		IslandId:    EssayGradingIslandID,
+		IslandType:  repo2.IslandTypeEssayGrading,
+		IslandModel: req.Model,
+		Prompt:      text,
+		TaskId:      taskID,
+		Status:      repo2.CreativeStatusPending,
+	}
+
+	arg := repo2.CreativeRecordArguments{
+		Images:      images,
+		Grade:       grade,
+		Requirement: requirement,
+	}
+
+	// 保存历史记录
+	if _, err := ctl.creativeRepo.CreateRecordWithArguments(ctx, user.ID, &creativeItem, &arg); err != nil {
+		log.Errorf("create creative item failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"task_id": taskID, // 任务 ID
+		"wait":    60,     // 等待时间
+	})
+}
+
+const (
+	// avatarPackMinSelfies 数字分身写真最少需要上传的自拍照数量
+	avatarPackMinSelfies = 3
+	// avatarPackMaxSelfies 数字分身写真最多支持上传的自拍照数量
+	avatarPackMaxSelfies = 10
+)
+
+// avatarPackWait 数字分身写真的预计等待时间（秒），每张图片约 60 秒
+func avatarPackWait(theme *queue.AvatarPackTheme) int64 {
+	return 60 * int64(len(theme.Prompts))
+}
+
+// AvatarPackThemes 数字分身写真支持的主题
+func (ctl *CreativeIslandController) AvatarPackThemes(ctx context.Context, webCtx web.Context) web.Response {
+	themes := array.Map(queue.AvatarPackThemes, func(theme queue.AvatarPackTheme, _ int) web.M {
+		return web.M{
+			"id":            theme.ID,
+			"name":          theme.Name,
+			"description":   theme.Description,
+			"preview_image": theme.PreviewImage,
+			"count":         len(theme.Prompts),
+			"coins":         coins.GetAvatarPackCoins(len(theme.Prompts)),
+		}
+	})
+
+	return webCtx.JSON(web.M{
+		"data":            themes,
+		"consent_version": queue.AvatarPackConsentVersion,
+		"min_selfies":     avatarPackMinSelfies,
+		"max_selfies":     avatarPackMaxSelfies,
+	})
+}
+
+// AvatarPack 数字分身写真
+// 请求参数：
+// - theme: 写真主题
+// - selfies: 自拍照地址，多张图片使用英文逗号分隔
+// - consent: 用户是否同意授权使用自拍照生成写真，必须为 true
+// - consent_version: 用户同意的授权协议版本，必须与当前版本一致
+func (ctl *CreativeIslandController) AvatarPack(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo) web.Response {
+	req, errResp := ctl.resolveAvatarPackRequest(webCtx, user)
+	if errResp != nil {
+		return errResp
+	}
+
+	return ctl.submitAvatarPack(ctx, webCtx, user, client, req)
+}
+
+// resolveAvatarPackRequest 解析数字分身写真请求参数
+func (ctl *CreativeIslandController) resolveAvatarPackRequest(webCtx web.Context, user *auth.User) (*queue.AvatarPackPayload, web.Response) {
+	if !ctl.conf.EnableAvatarPack || ctl.conf.AvatarPackModelVersion == "" {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, "数字分身功能暂未开放"), http.StatusServiceUnavailable)
+	}
+
+	if webCtx.Input("consent") != "true" || webCtx.Input("consent_version") != queue.AvatarPackConsentVersion {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, "请阅读并同意数字分身授权协议"), http.StatusBadRequest)
+	}
+
+	theme := queue.AvatarPackThemeByID(webCtx.Input("theme"))
+	if theme == nil {
+		return nil, webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	selfies := array.Filter(strings.Split(webCtx.Input("selfies"), ","), func(item string, _ int) bool { return strings.TrimSpace(item) != "" })
+	selfies = array.Uniq(array.Map(selfies, func(item string, _ int) string { return strings.TrimSpace(item) }))
+	if len(selfies) < avatarPackMinSelfies || len(selfies) > avatarPackMaxSelfies {
+		return nil, webCtx.JSONError(fmt.Sprintf("请上传 %d-%d 张自拍照", avatarPackMinSelfies, avatarPackMaxSelfies), http.StatusBadRequest)
+	}
+
+	for _, selfie := range selfies {
+		// 只允许使用用户上传到存储中的图片，避免使用他人的照片地址
+		if !strings.HasPrefix(selfie, ctl.conf.StorageDomain) {
+			return nil, webCtx.JSONError("invalid image", http.StatusBadRequest)
+		}
+	}
+
+	return &queue.AvatarPackPayload{
+		UserID:    user.ID,
+		Theme:     theme.ID,
+		Model:     ctl.conf.AvatarPackModelVersion,
+		Selfies:   selfies,
+		Quota:     coins.GetAvatarPackCoins(len(theme.Prompts)),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (ctl *CreativeIslandController) submitAvatarPack(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo, req *queue.AvatarPackPayload) web.Response {
+	theme := queue.AvatarPackThemeByID(req.Theme)
+	if theme == nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if resp := ctl.checkQuotaEnough(ctx, webCtx, user, req.Quota); resp != nil {
+		return resp
+	}
+
+	req.OrgID = repo2.BillingOrgFromContext(ctx)
+
+	// 加入异步任务队列，每张图片都需要单独生成，任务执行时间较长
+	taskID, err := ctl.queue.EnqueueContext(ctx, req, queue.NewAvatarPackTask, asynq.Timeout(30*time.Minute))
+	if err != nil {
+		log.Errorf("enqueue task failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+	log.WithFields(log.Fields{"task_id": taskID}).Debugf("enqueue task success: %s", taskID)
+
+	// 保存授权记录，自拍照在任务完成或超过保留期限后删除
+	if _, err := ctl.avatarPack.AddConsent(ctx, repo2.AvatarPackConsent{
+		UserID:         user.ID,
+		TaskID:         taskID,
+		ConsentVersion: queue.AvatarPackConsentVersion,
+		ClientIP:       client.IP,
+		Selfies:        req.Selfies,
+		ConsentedAt:    time.Now(),
+	}); err != nil {
+		log.F(log.M{"user_id": user.ID, "task_id": taskID}).Errorf("保存数字分身授权记录失败: %s", err)
+	}
+
+	// 冻结智慧果
+	if err := ctl.userSvc.FreezeUserQuota(ctx, user.ID, req.Quota); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": req.Quota, "task_id": taskID}).Errorf("创作岛冻结用户配额失败: %s", err)
+	}
+
+	if err := ctl.rds.SetEx(ctx, fmt.Sprintf("creative-island:%d:task:%s:quota-freeze", user.ID, taskID), req.Quota, 30*time.Minute).Err(); err != nil {
+		log.F(log.M{"user_id": user.ID, "quota": req.Quota, "task_id": taskID}).Errorf("创作岛用户配额已冻结，更新 Redis 任务与配额关系失败: %s", err)
+	}
+
+	creativeItem := repo2.CreativeItem{
+		IslandId:    AvatarPackIslandID,
+		IslandType:  repo2.IslandTypeAvatarPack,
+		IslandModel: req.Model,
+		Prompt:      theme.Name,
+		TaskId:      taskID,
+		Status:      repo2.CreativeStatusPending,
+	}
+
+	// 历史记录中不保存自拍照地址，自拍照删除后不再可用
+	arg := repo2.CreativeRecordArguments{Theme: theme.ID}
+	if _, err := ctl.creativeRepo.CreateRecordWithArguments(ctx, user.ID, &creativeItem, &arg); err != nil {
+		log.Errorf("create creative item failed: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"task_id": taskID,                // 任务 ID
+		"wait":    avatarPackWait(theme), // 等待时间
+	})
+}
+
+// Presets 用户保存的图片生成参数预设
+func (ctl *CreativeIslandController) Presets(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	presets, err := ctl.imagePreset.Presets(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询参数预设失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": presets})
+}
+
+// CreatePreset 新增图片生成参数预设
+// 请求参数：
+// - name: 预设名称
+// - model, filter_id, image_ratio, width, height, steps, image_count, image_strength, negative_prompt, style_preset: 生成参数，均为可选
+func (ctl *CreativeIslandController) CreatePreset(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	name, params, resp := ctl.resolvePresetRequest(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	id, err := ctl.imagePreset.CreatePreset(ctx, user.ID, name, *params)
+	if err != nil {
+		return ctl.presetError(webCtx, user, err)
+	}
+
+	return webCtx.JSON(web.M{"id": id})
+}
+
+// UpdatePreset 更新图片生成参数预设，请求参数与 CreatePreset 相同，未指定的生成参数会被清空
+func (ctl *CreativeIslandController) UpdatePreset(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil || id <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	name, params, resp := ctl.resolvePresetRequest(ctx, webCtx)
+	if resp != nil {
+		return resp
+	}
+
+	if err := ctl.imagePreset.UpdatePreset(ctx, user.ID, int64(id), name, *params); err != nil {
+		return ctl.presetError(webCtx, user, err)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+// DeletePreset 删除图片生成参数预设
+func (ctl *CreativeIslandController) DeletePreset(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.Atoi(webCtx.PathVar("id"))
+	if err != nil || id <= 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.imagePreset.DeletePreset(ctx, user.ID, int64(id)); err != nil {
+		log.F(log.M{"user_id": user.ID, "preset_id": id}).Errorf("删除参数预设失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
+
+func (ctl *CreativeIslandController) presetError(webCtx web.Context, user *auth.User, err error) web.Response {
+	switch {
+	case errors.Is(err, repo2.ErrNotFound):
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	case errors.Is(err, repo2.ErrImagePresetExists):
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "预设名称已存在"), http.StatusBadRequest)
+	case errors.Is(err, repo2.ErrImagePresetLimitExceeded):
+		return webCtx.JSONError(fmt.Sprintf("最多只能保存 %d 个预设", repo2.ImagePresetMaxPerUser), http.StatusBadRequest)
+	}
+
+	log.F(log.M{"user_id": user.ID}).Errorf("保存参数预设失败: %v", err)
+	return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+}
+
+// resolvePresetRequest 解析参数预设，参数的取值范围与生成图片时一致
+func (ctl *CreativeIslandController) resolvePresetRequest(ctx context.Context, webCtx web.Context) (string, *repo2.ImagePresetParams, web.Response) {
+	name := strings.TrimSpace(webCtx.Input("name"))
+	if name == "" || misc.WordCount(name) > 20 {
+		return "", nil, webCtx.JSONError("预设名称不能为空，且不能超过 20 个字", http.StatusBadRequest)
+	}
+
+	params := repo2.ImagePresetParams{
+		Model:          webCtx.Input("model"),
+		FilterID:       webCtx.Int64Input("filter_id", 0),
+		ImageRatio:     webCtx.Input("image_ratio"),
+		Width:          webCtx.Int64Input("width", 0),
+		Height:         webCtx.Int64Input("height", 0),
+		Steps:          webCtx.Int64Input("steps", 0),
+		ImageCount:     webCtx.Int64Input("image_count", 0),
+		ImageStrength:  webCtx.Float64Input("image_strength", 0),
+		NegativePrompt: strings.ReplaceAll(strings.TrimSpace(webCtx.Input("negative_prompt")), "，", ","),
+		StylePreset:    webCtx.Input("style_preset"),
+	}
+
+	if params.Model != "" && ctl.getVendorModel(ctx, params.Model) == nil {
+		return "", nil, webCtx.JSONError("没有找到匹配的模型", http.StatusBadRequest)
+	}
+
+	if params.FilterID > 0 && ctl.getStyleByID(ctx, params.FilterID) == nil {
+		return "", nil, webCtx.JSONError("invalid filter_id", http.StatusBadRequest)
+	}
+
+	if params.ImageRatio != "" && !array.In(params.ImageRatio, []string{"1:1", "4:3", "3:4", "3:2", "2:3", "16:9"}) {
+		return "", nil, webCtx.JSONError("invalid image ratio", http.StatusBadRequest)
+	}
+
+	if params.Width < 0 || params.Height < 0 || params.Width > 2048 || params.Height > 2048 || (params.Width > 0) != (params.Height > 0) {
+		return "", nil, webCtx.JSONError("invalid width or height", http.StatusBadRequest)
+	}
+
+	if params.Steps != 0 && !array.In(params.Steps, []int64{30, 50, 100, 150}) {
+		return "", nil, webCtx.JSONError("invalid steps", http.StatusBadRequest)
+	}
+
+	if params.ImageCount < 0 || params.ImageCount > 4 {
+		return "", nil, webCtx.JSONError("invalid image count", http.StatusBadRequest)
+	}
+
+	if params.ImageStrength < 0 || params.ImageStrength > 1 {
+		return "", nil, webCtx.JSONError("invalid image_strength", http.StatusBadRequest)
+	}
+
+	if misc.WordCount(params.NegativePrompt) > 1000 {
+		return "", nil, webCtx.JSONError(fmt.Sprintf("排除内容输入字数不能超过 %d", 1000), http.StatusBadRequest)
+	}
+
+	return name, &params, nil
 }
@@ -0,0 +1,217 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/mylxsw/aidea-server/internal/queue"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+	"github.com/redis/go-redis/v9"
+)
+
+// 支持先报价后确认的创作岛任务类型
+const (
+	CreativeQuoteTypeAvatarPack = "avatar-pack"
+	CreativeQuoteTypeUpscale    = "upscale"
+	CreativeQuoteTypeColorize   = "colorize"
+)
+
+// CreativeQuote 创作岛任务报价，确认时使用报价时的参数与价格创建任务
+type CreativeQuote struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	UserID    int64           `json:"user_id"`
+	Coins     int64           `json:"coins"`
+	Wait      int64           `json:"wait"`
+	Payload   json.RawMessage `json:"payload"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+func creativeQuoteKey(id string) string {
+	return fmt.Sprintf("creative-island:quote:%s", id)
+}
+
+// checkQuotaEnough 检查用户是否有足够的智慧果
+func (ctl *CreativeIslandController) checkQuotaEnough(ctx context.Context, webCtx web.Context, user *auth.User, cost int64) web.Response {
+	quota, err := ctl.userSvc.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.Errorf("get user quota failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < cost {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	return nil
+}
+
+// Quote 创作岛任务报价，返回任务的准确价格与预计等待时间，报价在有效期内确认才会创建任务
+// 请求参数：
+// - type: 任务类型，avatar-pack/upscale/colorize
+// - 其它参数与对应任务的创建接口一致
+func (ctl *CreativeIslandController) Quote(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var payload queue.Payload
+	var wait int64
+	var errResp web.Response
+
+	quoteType := webCtx.Input("type")
+	switch quoteType {
+	case CreativeQuoteTypeAvatarPack:
+		var req *queue.AvatarPackPayload
+		if req, errResp = ctl.resolveAvatarPackRequest(webCtx, user); errResp == nil {
+			payload, wait = req, avatarPackWait(queue.AvatarPackThemeByID(req.Theme))
+		}
+	case CreativeQuoteTypeUpscale:
+		var req *queue.ImageUpscalePayload
+		if req, errResp = ctl.resolveImageUpscaleRequest(webCtx, user); errResp == nil {
+			payload, wait = req, 60
+		}
+	case CreativeQuoteTypeColorize:
+		var req *queue.ImageColorizationPayload
+		if req, errResp = ctl.resolveImageColorizeRequest(webCtx, user); errResp == nil {
+			payload, wait = req, 60
+		}
+	default:
+		return webCtx.JSONError("invalid type", http.StatusBadRequest)
+	}
+
+	if errResp != nil {
+		return errResp
+	}
+
+	ttl := ctl.conf.CreativeQuoteTTL
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+
+	id, _ := uuid.GenerateUUID()
+	data, _ := json.Marshal(payload)
+	quote := CreativeQuote{
+		ID:        id,
+		Type:      quoteType,
+		UserID:    user.ID,
+		Coins:     payload.GetQuota(),
+		Wait:      wait,
+		Payload:   data,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	quoteData, _ := json.Marshal(quote)
+	if err := ctl.rds.SetEx(ctx, creativeQuoteKey(quote.ID), string(quoteData), ttl).Err(); err != nil {
+		log.F(log.M{"user_id": user.ID, "type": quoteType}).Errorf("保存创作岛任务报价失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	quota, err := ctl.userSvc.UserQuota(ctx, user.ID)
+	if err != nil {
+		log.Errorf("get user quota failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"quote_id":   quote.ID,
+		"type":       quote.Type,
+		"coins":      quote.Coins,
+		"wait":       quote.Wait,
+		"enough":     quota.Rest-quota.Freezed >= quote.Coins,
+		"expires_at": quote.ExpiresAt,
+	})
+}
+
+// claimQuoteScript 报价内容未变化时删除报价，多个确认请求并发时只有一个可以成功
+var claimQuoteScript = redis.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`)
+
+// ConfirmQuote 确认报价并创建任务，使用报价时锁定的参数与价格，每个报价只能确认一次
+// 只有报价所属用户在智慧果足够时才会消耗报价，任务提交失败时恢复报价，用户可以重新确认
+func (ctl *CreativeIslandController) ConfirmQuote(ctx context.Context, webCtx web.Context, user *auth.User, client *auth.ClientInfo) web.Response {
+	id := webCtx.PathVar("id")
+	key := creativeQuoteKey(id)
+
+	data, err := ctl.rds.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "报价已过期，请重新获取报价"), http.StatusGone)
+		}
+
+		log.F(log.M{"user_id": user.ID, "quote_id": id}).Errorf("查询创作岛任务报价失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	var quote CreativeQuote
+	if err := json.Unmarshal([]byte(data), &quote); err != nil {
+		log.F(log.M{"user_id": user.ID, "quote_id": id}).Errorf("解析创作岛任务报价失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quote.UserID != user.ID {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+	}
+
+	var submit func() web.Response
+	switch quote.Type {
+	case CreativeQuoteTypeAvatarPack:
+		var req queue.AvatarPackPayload
+		if err := json.Unmarshal(quote.Payload, &req); err == nil {
+			submit = func() web.Response {
+				req.CreatedAt = time.Now()
+				return ctl.submitAvatarPack(ctx, webCtx, user, client, &req)
+			}
+		}
+	case CreativeQuoteTypeUpscale:
+		var req queue.ImageUpscalePayload
+		if err := json.Unmarshal(quote.Payload, &req); err == nil {
+			submit = func() web.Response {
+				req.CreatedAt = time.Now()
+				return ctl.submitImageUpscale(ctx, webCtx, user, &req)
+			}
+		}
+	case CreativeQuoteTypeColorize:
+		var req queue.ImageColorizationPayload
+		if err := json.Unmarshal(quote.Payload, &req); err == nil {
+			submit = func() web.Response {
+				req.CreatedAt = time.Now()
+				return ctl.submitImageColorize(ctx, webCtx, user, &req)
+			}
+		}
+	}
+
+	if submit == nil {
+		log.F(log.M{"user_id": user.ID, "quote": quote}).Errorf("无效的创作岛任务报价")
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	// 智慧果不足时保留报价，用户充值后可以继续确认
+	if resp := ctl.checkQuotaEnough(ctx, webCtx, user, quote.Coins); resp != nil {
+		return resp
+	}
+
+	claimed, err := claimQuoteScript.Run(ctx, ctl.rds, []string{key}, data).Int()
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "quote_id": id}).Errorf("确认创作岛任务报价失败: %v", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if claimed == 0 {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "报价已确认或已过期"), http.StatusGone)
+	}
+
+	resp := submit()
+	if resp.Code() >= http.StatusBadRequest {
+		if ttl := time.Until(quote.ExpiresAt); ttl > 0 {
+			if err := ctl.rds.SetNX(ctx, key, data, ttl).Err(); err != nil {
+				log.F(log.M{"user_id": user.ID, "quote_id": id}).Errorf("恢复创作岛任务报价失败: %v", err)
+			}
+		}
+	}
+
+	return resp
+}
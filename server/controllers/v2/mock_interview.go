@@ -0,0 +1,184 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/web"
+)
+
+const (
+	// mockInterviewDefaultQuestions 模拟面试默认的题目数量
+	mockInterviewDefaultQuestions = 5
+	// mockInterviewMaxQuestions 模拟面试最多的题目数量
+	mockInterviewMaxQuestions = 10
+	// mockInterviewMaxRoleLength 面试岗位名称的最大字数
+	mockInterviewMaxRoleLength = 50
+	// mockInterviewMaxAnswerLength 单个回答的最大字数
+	mockInterviewMaxAnswerLength = 3000
+)
+
+// StartMockInterview 开始模拟面试，返回第一个问题
+// 请求参数：
+// - role: 面试岗位
+// - level: 岗位级别，可选，junior/middle/senior
+// - questions: 题目数量，可选，默认 5 题，最多 10 题
+func (ctl *CreativeIslandController) StartMockInterview(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	role := strings.TrimSpace(webCtx.Input("role"))
+	if role == "" || len([]rune(role)) > mockInterviewMaxRoleLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "请输入面试岗位，最多 50 个字"), http.StatusBadRequest)
+	}
+
+	level := webCtx.Input("level")
+	if _, ok := service2.MockInterviewLevels[level]; level != "" && !ok {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	questions := webCtx.Int64Input("questions", mockInterviewDefaultQuestions)
+	if questions < 1 || questions > mockInterviewMaxQuestions {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, fmt.Sprintf("题目数量必须在 1-%d 之间", mockInterviewMaxQuestions)), http.StatusBadRequest)
+	}
+
+	if resp := ctl.mockInterviewContentPass(ctx, webCtx, user, role); resp != nil {
+		return resp
+	}
+
+	step, estimate, err := ctl.interviewSrv.PrepareStart(role, level, questions)
+	if err != nil {
+		if errors.Is(err, service2.ErrMockInterviewDisabled) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "模拟面试功能暂未开放"), http.StatusServiceUnavailable)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("prepare mock interview failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return ctl.runMockInterview(ctx, webCtx, user, step, estimate)
+}
+
+// MockInterview 查询模拟面试的过程
+func (ctl *CreativeIslandController) MockInterview(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	interview, err := ctl.interviewRepo.Get(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "interview_id": id}).Errorf("查询模拟面试失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": interview})
+}
+
+// AnswerMockInterview 回答当前问题，返回下一个问题；回答完所有问题或者提前结束时返回评分报告
+// 请求参数：
+// - answer: 当前问题的回答，提前结束时可以为空
+// - finish: 是否提前结束面试，可选
+func (ctl *CreativeIslandController) AnswerMockInterview(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	answer := strings.TrimSpace(webCtx.Input("answer"))
+	if len([]rune(answer)) > mockInterviewMaxAnswerLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "回答内容过长"), http.StatusBadRequest)
+	}
+
+	interview, err := ctl.interviewRepo.Get(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, repo2.ErrNotFound) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrNotFound), http.StatusNotFound)
+		}
+
+		log.F(log.M{"user_id": user.ID, "interview_id": id}).Errorf("查询模拟面试失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if answer != "" {
+		if resp := ctl.mockInterviewContentPass(ctx, webCtx, user, answer); resp != nil {
+			return resp
+		}
+	}
+
+	step, estimate, err := ctl.interviewSrv.PrepareAnswer(*interview, answer, webCtx.Input("finish") == "true")
+	if err != nil {
+		switch {
+		case errors.Is(err, service2.ErrMockInterviewFinished):
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "面试已结束"), http.StatusConflict)
+		case errors.Is(err, service2.ErrMockInterviewNoAnswer):
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "请输入你的回答"), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "interview_id": id}).Errorf("prepare mock interview failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return ctl.runMockInterview(ctx, webCtx, user, step, estimate)
+}
+
+// mockInterviewContentPass 面试岗位以及回答需要通过受限模式以及内容安全检测
+func (ctl *CreativeIslandController) mockInterviewContentPass(ctx context.Context, webCtx web.Context, user *auth.User, content string) web.Response {
+	if err := ctl.restrictedSrv.CheckChat(ctx, user.ID, ctl.conf.MockInterviewModel, content); err != nil {
+		var violation *service2.RestrictedModeViolationError
+		if errors.As(err, &violation) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, violation.Reason), http.StatusForbidden)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("check restricted mode failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if checkRes := ctl.securitySrv.ChatDetect(content); checkRes != nil && checkRes.IsReallyUnSafe() {
+		log.WithFields(log.Fields{
+			"user_id": user.ID,
+			"details": checkRes.ReasonDetail(),
+			"content": content,
+		}).Warningf("用户 %d 违规，违规内容：%s", user.ID, checkRes.Reason)
+		return webCtx.JSONError(fmt.Sprintf("内容违规，已被系统拦截，如有疑问邮件联系：support@aicode.cc\n\n原因：%s", checkRes.ReasonDetail()), http.StatusNotAcceptable)
+	}
+
+	return nil
+}
+
+// runMockInterview 检查智慧果余量后执行面试的下一步
+func (ctl *CreativeIslandController) runMockInterview(ctx context.Context, webCtx web.Context, user *auth.User, step *service2.MockInterviewStep, estimate int64) web.Response {
+	if estimate > 0 {
+		quota, err := ctl.userSvc.UserQuota(ctx, user.ID)
+		if err != nil {
+			log.F(log.M{"user_id": user.ID}).Errorf("get user quota failed: %s", err)
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrInternalError), http.StatusInternalServerError)
+		}
+
+		if quota.Rest-quota.Freezed < estimate {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+		}
+	}
+
+	res, err := ctl.interviewSrv.Run(ctx, user.ID, step)
+	if err != nil {
+		if errors.Is(err, repo2.ErrMockInterviewChanged) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.trans, "该问题已经回答过了，请刷新后重试"), http.StatusConflict)
+		}
+
+		log.F(log.M{"user_id": user.ID, "interview_id": step.Interview.ID}).Errorf("mock interview failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.trans, "面试官暂时无法响应，请稍后再试"), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": res})
+}
@@ -119,6 +119,18 @@ func (ctl *RoomController) Rooms(ctx context.Context, webCtx web.Context, user *
 				return false
 			}
 
+			if !ctl.conf.EnableDeepSeek && item.Vendor == "deepseek" {
+				return false
+			}
+
+			if !ctl.conf.EnableMoonshot && item.Vendor == "moonshot" {
+				return false
+			}
+
+			if !ctl.conf.EnableZhipu && item.Vendor == "zhipu" {
+				return false
+			}
+
 			if item.VersionMax == "" && item.VersionMin == "" {
 				return true
 			}
@@ -135,6 +147,13 @@ func (ctl *RoomController) Rooms(ctx context.Context, webCtx web.Context, user *
 		})
 	}
 
+	// 查询失败时不影响会话列表的展示，客户端按照未分组处理
+	folders, err := ctl.roomRepo.Folders(ctx, user.ID)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询用户会话文件夹失败: %v", err)
+		folders = make([]repo.RoomFolder, 0)
+	}
+
 	models := array.ToMap(
 		chat.Models(ctl.conf, true),
 		func(item chat.Model, _ int) string { return item.RealID() },
@@ -163,5 +182,6 @@ func (ctl *RoomController) Rooms(ctx context.Context, webCtx web.Context, user *
 			return item
 		}),
 		"suggests": suggests,
+		"folders":  folders,
 	})
 }
@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
+	service2 "github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/youdao"
+	"github.com/mylxsw/aidea-server/server/auth"
+	"github.com/mylxsw/aidea-server/server/controllers/common"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/glacier/infra"
+	"github.com/mylxsw/glacier/web"
+)
+
+// maxWritingToolTextLength 写作工具单次处理的最大字数
+const maxWritingToolTextLength = 5000
+
+// WritingToolController 写作工具：客户端编辑器的改写、扩写、缩写以及调整语气操作
+type WritingToolController struct {
+	translater     youdao.Translater            `autowire:"@"`
+	writingTool    *service2.WritingToolService `autowire:"@"`
+	writingToolRep *repo2.WritingToolRepo       `autowire:"@"`
+	userSrv        *service2.UserService        `autowire:"@"`
+}
+
+// NewWritingToolController 创建写作工具控制器
+func NewWritingToolController(resolver infra.Resolver) web.Controller {
+	ctl := &WritingToolController{}
+	resolver.MustAutoWire(ctl)
+	return ctl
+}
+
+func (ctl *WritingToolController) Register(router web.Router) {
+	router.Group("/writing-tools", func(router web.Router) {
+		router.Get("/", ctl.Operations)
+		router.Post("/", ctl.Run)
+		router.Post("/grammar-check", ctl.GrammarCheck)
+		router.Get("/histories", ctl.Histories)
+		router.Delete("/histories/{id}", ctl.DeleteHistory)
+	})
+}
+
+// Operations 写作工具支持的操作以及语气
+func (ctl *WritingToolController) Operations(ctx context.Context, webCtx web.Context) web.Response {
+	return webCtx.JSON(web.M{
+		"enabled":    ctl.writingTool.Enabled(),
+		"operations": service2.WritingOperations(),
+		"tones":      service2.WritingTones(),
+	})
+}
+
+type WritingToolRequest struct {
+	Operation string                      `json:"operation"`
+	Text      string                      `json:"text"`
+	Options   service2.WritingToolOptions `json:"options"`
+	// Glossary 是否使用用户的术语表，默认使用
+	Glossary *bool `json:"glossary,omitempty"`
+}
+
+// Run 执行写作操作
+func (ctl *WritingToolController) Run(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req WritingToolRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "请输入要处理的内容"), http.StatusBadRequest)
+	}
+
+	if len([]rune(req.Text)) > maxWritingToolTextLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "内容过长，请分段处理"), http.StatusBadRequest)
+	}
+
+	messages, estimate, err := ctl.writingTool.Prepare(ctx, user.ID, req.Operation, req.Text, req.Options, req.Glossary == nil || *req.Glossary)
+	if err != nil {
+		switch {
+		case errors.Is(err, service2.ErrWritingToolDisabled):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "写作工具暂不可用"), http.StatusServiceUnavailable)
+		case errors.Is(err, service2.ErrWritingToolOperation):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "不支持的操作"), http.StatusBadRequest)
+		case errors.Is(err, service2.ErrWritingToolTone):
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "请选择目标语气"), http.StatusBadRequest)
+		}
+
+		log.F(log.M{"user_id": user.ID, "operation": req.Operation}).Errorf("prepare writing tool request failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if errResp := ctl.quotaPass(ctx, webCtx, user.ID, estimate); errResp != nil {
+		return errResp
+	}
+
+	history, err := ctl.writingTool.Run(ctx, user.ID, req.Operation, req.Text, req.Options, messages)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID, "operation": req.Operation}).Errorf("writing tool failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": history})
+}
+
+type GrammarCheckRequest struct {
+	Text string `json:"text"`
+	// Glossary 是否按照用户术语表中的写法检查，默认使用
+	Glossary *bool `json:"glossary,omitempty"`
+}
+
+// GrammarCheck 语法与拼写检查，以修改范围、替换内容以及说明的形式返回每一处修改，客户端可以据此显示修订标记
+func (ctl *WritingToolController) GrammarCheck(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	var req GrammarCheckRequest
+	if err := webCtx.Unmarshal(&req); err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if strings.TrimSpace(req.Text) == "" {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "请输入要处理的内容"), http.StatusBadRequest)
+	}
+
+	if len([]rune(req.Text)) > maxWritingToolTextLength {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, "内容过长，请分段处理"), http.StatusBadRequest)
+	}
+
+	// 修改范围基于原文计算，因此不去除首尾空白
+	chatReq, estimate, err := ctl.writingTool.PrepareGrammarCheck(ctx, user.ID, req.Text, req.Glossary == nil || *req.Glossary)
+	if err != nil {
+		if errors.Is(err, service2.ErrWritingToolDisabled) {
+			return webCtx.JSONError(common.Text(webCtx, ctl.translater, "写作工具暂不可用"), http.StatusServiceUnavailable)
+		}
+
+		log.F(log.M{"user_id": user.ID}).Errorf("prepare grammar check request failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if errResp := ctl.quotaPass(ctx, webCtx, user.ID, estimate); errResp != nil {
+		return errResp
+	}
+
+	result, err := ctl.writingTool.GrammarCheck(ctx, user.ID, req.Text, chatReq)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("grammar check failed: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{"data": result})
+}
+
+// quotaPass 检查用户的智慧果余量是否足够支付预估的费用
+func (ctl *WritingToolController) quotaPass(ctx context.Context, webCtx web.Context, userID int64, estimate int64) web.Response {
+	if estimate <= 0 {
+		return nil
+	}
+
+	quota, err := ctl.userSrv.UserQuota(ctx, userID)
+	if err != nil {
+		log.F(log.M{"user_id": userID}).Errorf("查询用户智慧果余量失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	if quota.Rest-quota.Freezed < estimate {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrQuotaNotEnough), http.StatusPaymentRequired)
+	}
+
+	return nil
+}
+
+// Histories 当前用户的写作工具操作记录，可以按照操作类型筛选
+func (ctl *WritingToolController) Histories(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	page := webCtx.Int64Input("page", 1)
+	if page < 1 || page > 1000 {
+		page = 1
+	}
+
+	perPage := webCtx.Int64Input("per_page", 20)
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	items, meta, err := ctl.writingToolRep.Histories(ctx, user.ID, webCtx.Input("operation"), page, perPage)
+	if err != nil {
+		log.F(log.M{"user_id": user.ID}).Errorf("查询写作工具操作记录失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{
+		"data":      items,
+		"page":      meta.Page,
+		"per_page":  meta.PerPage,
+		"total":     meta.Total,
+		"last_page": meta.LastPage,
+	})
+}
+
+// DeleteHistory 删除写作工具操作记录
+func (ctl *WritingToolController) DeleteHistory(ctx context.Context, webCtx web.Context, user *auth.User) web.Response {
+	id, err := strconv.ParseInt(webCtx.PathVar("id"), 10, 64)
+	if err != nil {
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInvalidRequest), http.StatusBadRequest)
+	}
+
+	if err := ctl.writingToolRep.Delete(ctx, user.ID, id); err != nil && !errors.Is(err, repo2.ErrNotFound) {
+		log.F(log.M{"user_id": user.ID, "id": id}).Errorf("删除写作工具操作记录失败: %s", err)
+		return webCtx.JSONError(common.Text(webCtx, ctl.translater, common.ErrInternalError), http.StatusInternalServerError)
+	}
+
+	return webCtx.JSON(web.M{})
+}
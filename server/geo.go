@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/glacier/web"
+)
+
+// geoRoute 地区访问策略需要检查的接口，在请求分发到处理器之前统一检查功能是否在请求来源地区被禁止使用
+//
+// 聊天（/v1/chat/completions）还需要根据请求的模型路由到该地区合规的模型，在处理器中完成
+type geoRoute struct {
+	prefix  string
+	suffix  string
+	feature string
+	// allMethods 所有请求方法都需要检查，例如 WebSocket 方式的聊天、实时语音使用 GET 请求，默认只检查 POST 请求
+	allMethods bool
+}
+
+var geoRoutes = []geoRoute{
+	{prefix: "/v1/chat/completions", feature: repo.GeoFeatureChat, allMethods: true},
+	{prefix: "/v1/group-chat", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/role-play", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/tutor/chat", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/writing-tools", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/pdf/", suffix: "/chat", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/tables/", suffix: "/ask", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/code-interpreter/completions", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/model-comparisons", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/resume/polish", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/study/decks", feature: repo.GeoFeatureChat},
+	{prefix: "/v2/creative-island/mock-interview", feature: repo.GeoFeatureChat},
+
+	{prefix: "/v1/creative-island", feature: repo.GeoFeatureCreative},
+	{prefix: "/v2/creative-island/completions", feature: repo.GeoFeatureCreative},
+	{prefix: "/v2/creative-island/quotes", feature: repo.GeoFeatureCreative},
+	{prefix: "/v2/creative-island/prompt/enhance", feature: repo.GeoFeatureCreative},
+	{prefix: "/v1/images/generations", feature: repo.GeoFeatureImage},
+	{prefix: "/v1/audio", feature: repo.GeoFeatureVoice},
+	{prefix: "/v1/voice/realtime", feature: repo.GeoFeatureVoice, allMethods: true},
+	{prefix: "/v1/voice", feature: repo.GeoFeatureVoice},
+}
+
+// clientRegion 请求来源地区，由部署在前面的网关通过请求头传递
+func clientRegion(webCtx web.Context, conf *config.Config) string {
+	return strings.ToUpper(strings.TrimSpace(webCtx.Header(conf.GeoRegionHeader)))
+}
+
+// geoFeature 请求所属的受地区访问策略限制的功能，不需要检查时返回空
+func geoFeature(method, path string) string {
+	for _, r := range geoRoutes {
+		if !strings.HasPrefix(path, r.prefix) || !strings.HasSuffix(path, r.suffix) {
+			continue
+		}
+
+		if r.allMethods || method == http.MethodPost {
+			return r.feature
+		}
+
+		return ""
+	}
+
+	return ""
+}
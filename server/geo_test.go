@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestGeoRoutes(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, rule := range registeredRoutes(t) {
+		registered[rule.GetPath()] = true
+	}
+
+	// 路由表中的每一项都需要对应已注册的接口，避免接口路径变更后检查失效
+	for _, r := range geoRoutes {
+		matched := false
+		for path := range registered {
+			if strings.HasPrefix(path, r.prefix) && strings.HasSuffix(path, r.suffix) {
+				matched = true
+				break
+			}
+		}
+
+		assert.True(t, matched, r.prefix+"*"+r.suffix)
+	}
+
+	for _, path := range []string{
+		"/v1/group-chat/{group_id}/chat",
+		"/v1/role-play/{id}/chat",
+		"/v1/tutor/chat",
+		"/v1/writing-tools/",
+		"/v1/pdf/{id}/chat",
+		"/v2/creative-island/mock-interview/{id}/answer",
+	} {
+		assert.True(t, registered[path], path)
+		assert.Equal(t, repo.GeoFeatureChat, geoFeature(http.MethodPost, path))
+	}
+
+	assert.Equal(t, repo.GeoFeatureChat, geoFeature(http.MethodGet, "/v1/chat/completions"))
+	assert.Equal(t, repo.GeoFeatureVoice, geoFeature(http.MethodGet, "/v1/voice/realtime"))
+	assert.Equal(t, "", geoFeature(http.MethodGet, "/v1/group-chat/{group_id}/messages"))
+}
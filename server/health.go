@@ -0,0 +1,268 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/repo"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
+	"github.com/mylxsw/asteria/log"
+	"github.com/mylxsw/go-utils/array"
+	"github.com/mylxsw/go-utils/ternary"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// probeTimeout 单个依赖检查的超时时间
+	probeTimeout = 3 * time.Second
+	// probeCacheTTL 检查结果的缓存时间，避免探针频繁访问依赖服务
+	probeCacheTTL = 5 * time.Second
+	// probeStorageKey 用于检查对象存储是否可用的文件，文件不存在不影响检查结果
+	probeStorageKey = "healthz/probe"
+)
+
+// 依赖状态
+const (
+	DependencyUp      = "UP"
+	DependencyDown    = "DOWN"
+	DependencySkipped = "SKIPPED"
+)
+
+// DependencyStatus 依赖服务的检查结果，接口公开访问，只返回状态，错误信息记录在日志中
+type DependencyStatus struct {
+	Status string `json:"status"`
+}
+
+// ProbeResult 所有依赖服务的检查结果，未启用的依赖不包含在结果中
+type ProbeResult struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+}
+
+// Ready 就绪依赖（数据库、Redis）是否可用
+func (r ProbeResult) Ready() bool {
+	return r.Status == DependencyUp
+}
+
+// Prober 检查数据库、Redis、对象存储以及聊天渠道是否可用，用于 Kubernetes 的就绪探针，
+// 只有数据库和 Redis 影响就绪状态，对象存储与聊天渠道只报告状态
+type Prober struct {
+	conf     *config.Config
+	db       *sql.DB
+	rds      *redis.Client
+	uploader *uploader.Uploader
+	rep      *repo.Repository
+
+	lock   sync.Mutex
+	cached *ProbeResult
+}
+
+func NewProber(conf *config.Config, db *sql.DB, rds *redis.Client, up *uploader.Uploader, rep *repo.Repository) *Prober {
+	return &Prober{conf: conf, db: db, rds: rds, uploader: up, rep: rep}
+}
+
+// Probe 检查所有依赖服务，检查结果会缓存一段时间
+//
+// 检查使用独立的超时 context 执行，不受调用方请求取消的影响，避免缓存不完整的检查结果
+func (p *Prober) Probe() ProbeResult {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.cached != nil && time.Since(p.cached.CheckedAt) < probeCacheTTL {
+		return *p.cached
+	}
+
+	checks := map[string]func(ctx context.Context) (string, error){
+		"database": func(ctx context.Context) (string, error) {
+			return DependencyUp, p.db.PingContext(ctx)
+		},
+		"redis": func(ctx context.Context) (string, error) {
+			return DependencyUp, p.rds.Ping(ctx).Err()
+		},
+		"storage": p.probeStorage,
+		"chat":    p.probeChat,
+	}
+	// readinessDependencies 影响就绪状态的依赖
+	readinessDependencies := []string{"database", "redis"}
+
+	res := ProbeResult{Status: DependencyUp, Dependencies: make(map[string]DependencyStatus), CheckedAt: time.Now()}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) (string, error)) {
+			defer wg.Done()
+
+			status, err := runProbe(check)
+			if err != nil {
+				log.F(log.M{"dependency": name}).Warningf("dependency probe failed: %v", err)
+			}
+
+			if status == DependencySkipped {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			res.Dependencies[name] = DependencyStatus{Status: status}
+			if status == DependencyDown && array.In(name, readinessDependencies) {
+				res.Status = DependencyDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	p.cached = &res
+	return res
+}
+
+// runProbe 执行单个检查，七牛等 SDK 不支持 context，超时后不再等待检查结果
+func runProbe(check func(ctx context.Context) (string, error)) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	type result struct {
+		status string
+		err    error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		status, err := check(ctx)
+		ch <- result{status: status, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return DependencyDown, ctx.Err()
+	case r := <-ch:
+		if r.err != nil {
+			return DependencyDown, r.err
+		}
+
+		return r.status, nil
+	}
+}
+
+func (p *Prober) probeStorage(ctx context.Context) (string, error) {
+	if p.conf.StorageAppKey == "" {
+		return DependencySkipped, nil
+	}
+
+	_, _, _, err := p.uploader.StatFile(ctx, probeStorageKey)
+	return DependencyUp, err
+}
+
+// probeChat 至少一个聊天渠道的上游服务可以建立连接
+func (p *Prober) probeChat(ctx context.Context) (string, error) {
+	servers := p.chatServers(ctx)
+	if len(servers) == 0 {
+		// 部分服务商使用的 SDK 不支持配置服务地址，只要有可用的聊天模型即可
+		if len(array.Filter(chat.Models(p.conf, false), func(m chat.Model, _ int) bool { return m.IsChat })) > 0 {
+			return DependencyUp, nil
+		}
+
+		return DependencyDown, errors.New("no chat channel available")
+	}
+
+	ch := make(chan error, len(servers))
+	for _, server := range servers {
+		go func(server string) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", server)
+			if err == nil {
+				_ = conn.Close()
+			}
+
+			ch <- err
+		}(server)
+	}
+
+	var lastErr error
+	for range servers {
+		if lastErr = <-ch; lastErr == nil {
+			return DependencyUp, nil
+		}
+	}
+
+	return DependencyDown, fmt.Errorf("all %d chat channels unreachable, last error: %v", len(servers), lastErr)
+}
+
+// chatServers 已启用的聊天渠道的上游服务地址，格式为 host:port
+func (p *Prober) chatServers(ctx context.Context) []string {
+	var servers []string
+	if p.conf.EnableOpenAI {
+		servers = append(servers, p.conf.OpenAIServers...)
+	}
+
+	for _, item := range []struct {
+		enabled bool
+		server  string
+	}{
+		{p.conf.EnableAnthropic, p.conf.AnthropicServer},
+		{p.conf.EnableGoogleAI, p.conf.GoogleAIServer},
+		{p.conf.EnableOneAPI, p.conf.OneAPIServer},
+		{p.conf.EnableOpenRouter, p.conf.OpenRouterServer},
+		{p.conf.EnableDeepSeek, p.conf.DeepSeekServer},
+		{p.conf.EnableMoonshot, p.conf.MoonshotServer},
+		{p.conf.EnableZhipu, p.conf.ZhipuServer},
+	} {
+		if item.enabled {
+			servers = append(servers, item.server)
+		}
+	}
+
+	// 自定义渠道
+	if channels, err := p.rep.Channel.Channels(ctx, true); err == nil {
+		for _, c := range channels {
+			if c.Verified() {
+				servers = append(servers, c.Server)
+			}
+		}
+	}
+
+	return array.Uniq(array.Filter(array.Map(servers, func(s string, _ int) string { return hostPort(s) }), func(s string, _ int) bool { return s != "" }))
+}
+
+// hostPort 将服务地址转换为 host:port 格式，无法解析时返回空
+func hostPort(server string) string {
+	u, err := url.Parse(server)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+
+	if u.Port() != "" {
+		return u.Host
+	}
+
+	return net.JoinHostPort(u.Hostname(), ternary.If(u.Scheme == "http", "80", "443"))
+}
+
+// livenessHandler 存活探针，只要服务进程能够处理请求即可，不检查依赖服务，避免依赖故障导致服务被重启
+func livenessHandler(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(writer).Encode(DependencyStatus{Status: DependencyUp})
+}
+
+// readinessHandler 就绪探针，返回依赖服务的检查结果，数据库或 Redis 不可用时返回 503
+func readinessHandler(prober *Prober) http.HandlerFunc {
+	return func(writer http.ResponseWriter, _ *http.Request) {
+		res := prober.Probe()
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(ternary.If(res.Ready(), http.StatusOK, http.StatusServiceUnavailable))
+		_ = json.NewEncoder(writer).Encode(res)
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+// keywordFilterRoute 需要对用户输入执行关键词过滤的接口，在请求分发到处理器之前统一检查，命中拦截规则时拒绝请求
+//
+// 打码规则以及模型输出的过滤由聊天客户端统一执行；聊天（/v1/chat/completions）支持 WebSocket 方式传递消息，
+// 创作岛通用创作（/v2/creative-island/completions）需要将打码后的提示语用于生成，这两个接口在处理器中完成
+type keywordFilterRoute struct {
+	prefix      string
+	suffix      string
+	contentKeys []string
+}
+
+var keywordFilterRoutes = []keywordFilterRoute{
+	{prefix: "/v1/group-chat/", suffix: "/chat", contentKeys: []string{"message"}},
+	{prefix: "/v1/group-chat/", suffix: "/debate", contentKeys: []string{"message"}},
+	{prefix: "/v1/role-play/", suffix: "/chat", contentKeys: []string{"message"}},
+	{prefix: "/v1/tutor/chat", contentKeys: []string{"messages"}},
+	{prefix: "/v1/writing-tools", contentKeys: []string{"text"}},
+	{prefix: "/v1/pdf/", suffix: "/chat", contentKeys: []string{"messages"}},
+	{prefix: "/v1/tables/", suffix: "/ask", contentKeys: []string{"question"}},
+	{prefix: "/v1/code-interpreter/completions", contentKeys: []string{"messages"}},
+	{prefix: "/v1/model-comparisons", contentKeys: []string{"prompt"}},
+	{prefix: "/v1/resume/polish", contentKeys: []string{"resume", "job_description", "feedback"}},
+	{prefix: "/v1/study/decks", contentKeys: []string{"name", "notes"}},
+	{prefix: "/v1/translate", contentKeys: []string{"text"}},
+	{prefix: "/v1/voice/text2voice", contentKeys: []string{"text"}},
+	{prefix: "/v1/images/generations", contentKeys: []string{"prompt"}},
+	{prefix: "/v1/creative-island/completions/", contentKeys: []string{"prompt"}},
+
+	{prefix: "/v2/creative-island/completions/essay-grading", contentKeys: []string{"text", "requirement"}},
+	{prefix: "/v2/creative-island/completions/artistic-text", contentKeys: []string{"text", "prompt"}},
+	{prefix: "/v2/creative-island/prompt/enhance", contentKeys: []string{"prompt"}},
+	{prefix: "/v2/creative-island/mock-interview", contentKeys: []string{"role", "answer"}},
+}
+
+// keywordFilterContentKeys 请求中需要执行关键词过滤的参数，不需要过滤时返回 nil
+func keywordFilterContentKeys(method, path string) []string {
+	if method != http.MethodPost {
+		return nil
+	}
+
+	for _, r := range keywordFilterRoutes {
+		if strings.HasPrefix(path, r.prefix) && strings.HasSuffix(path, r.suffix) {
+			return r.contentKeys
+		}
+	}
+
+	return nil
+}
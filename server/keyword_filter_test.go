@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestKeywordFilterRoutes(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, rule := range registeredRoutes(t) {
+		registered[rule.GetPath()] = true
+	}
+
+	// 路由表中的每一项都需要对应已注册的接口，避免接口路径变更后过滤失效
+	for _, r := range keywordFilterRoutes {
+		matched := false
+		for path := range registered {
+			if strings.HasPrefix(path, r.prefix) && strings.HasSuffix(path, r.suffix) {
+				matched = true
+				break
+			}
+		}
+
+		assert.True(t, matched, r.prefix+"*"+r.suffix)
+	}
+
+	for path, keys := range map[string][]string{
+		"/v1/group-chat/{group_id}/chat":                 {"message"},
+		"/v1/group-chat/{group_id}/debate":               {"message"},
+		"/v1/role-play/{id}/chat":                        {"message"},
+		"/v1/tutor/chat":                                 {"messages"},
+		"/v1/writing-tools/grammar-check":                {"text"},
+		"/v1/pdf/{id}/chat":                              {"messages"},
+		"/v1/tables/{id}/ask":                            {"question"},
+		"/v1/model-comparisons/":                         {"prompt"},
+		"/v1/images/generations":                         {"prompt"},
+		"/v1/translate/":                                 {"text"},
+		"/v2/creative-island/mock-interview/{id}/answer": {"role", "answer"},
+	} {
+		assert.True(t, registered[path], path)
+		assert.Equal(t, keys, keywordFilterContentKeys(http.MethodPost, path))
+	}
+
+	// 聊天与创作岛通用创作在处理器中过滤
+	assert.Equal(t, 0, len(keywordFilterContentKeys(http.MethodPost, "/v1/chat/completions")))
+	assert.Equal(t, 0, len(keywordFilterContentKeys(http.MethodPost, "/v2/creative-island/completions/")))
+	assert.Equal(t, 0, len(keywordFilterContentKeys(http.MethodGet, "/v1/group-chat/{group_id}/chat")))
+}
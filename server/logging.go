@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+
+	"github.com/mylxsw/aidea-server/pkg/trace"
+	"github.com/mylxsw/glacier/web"
+)
+
+// contextKeyRequestContext 当前请求使用的 context，包含请求 ID、用户 ID 等日志关联字段
+const contextKeyRequestContext = "aidea-request-context"
+
+// bindRequestContext 将 ctx 作为当前请求的 context，控制器中注入的 context.Context 即为该 ctx
+func bindRequestContext(webCtx web.Context, ctx context.Context) {
+	webCtx.Set(contextKeyRequestContext, ctx)
+	webCtx.Provide(func() context.Context { return ctx })
+}
+
+// requestContext 返回当前请求的 context，不存在时返回 fallback
+func requestContext(webCtx web.Context, fallback context.Context) context.Context {
+	if ctx, ok := webCtx.Get(contextKeyRequestContext).(context.Context); ok {
+		return ctx
+	}
+
+	return fallback
+}
+
+// requestID 使用网关传入的请求 ID，不存在或者格式不合法时生成新的请求 ID
+func requestID(webCtx web.Context) string {
+	if id := webCtx.Header(trace.HeaderRequestID); trace.ValidRequestID(id) {
+		return id
+	}
+
+	return trace.NewRequestID()
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/pkg/repo"
+)
+
+// maintenanceRoute 维护窗口可以暂停的接口
+type maintenanceRoute struct {
+	prefix  string
+	feature string
+	// allMethods 所有请求方法都会被暂停，例如 WebSocket 方式的聊天使用 GET 请求
+	allMethods bool
+}
+
+var maintenanceRoutes = []maintenanceRoute{
+	{prefix: "/v1/chat/completions", feature: repo.GeoFeatureChat, allMethods: true},
+	{prefix: "/v1/group-chat", feature: repo.GeoFeatureChat},
+	{prefix: "/v1/creative-island", feature: repo.GeoFeatureCreative},
+	{prefix: "/v2/creative-island/completions", feature: repo.GeoFeatureCreative},
+	{prefix: "/v1/images", feature: repo.GeoFeatureImage},
+	{prefix: "/v1/audio", feature: repo.GeoFeatureVoice},
+	{prefix: "/v1/voice", feature: repo.GeoFeatureVoice},
+}
+
+// maintenanceFeature 请求所属的可以被维护窗口暂停的功能，查询类请求返回空，维护期间保持可用
+func maintenanceFeature(method, path string) string {
+	for _, r := range maintenanceRoutes {
+		if !strings.HasPrefix(path, r.prefix) {
+			continue
+		}
+
+		if r.allMethods || (method != http.MethodGet && method != http.MethodHead) {
+			return r.feature
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/glacier/web"
+)
+
+// orgPolicyCheck 检查使用组织钱包计费的请求是否符合组织策略
+type orgPolicyCheck func(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.OrgPolicyService, userID int64) error
+
+// orgBillingRoute 允许使用组织钱包计费的接口，在请求分发到处理器之前统一检查组织策略，未列出的接口不允许使用组织钱包计费
+//
+// check 为 nil 表示无法在请求之前确定使用的模型或者审核内容，该接口不允许使用组织钱包计费，但仍然可以使用组织钱包查询该功能的记录（非 POST 请求）
+type orgBillingRoute struct {
+	prefix string
+	suffix string
+	// allMethods 所有请求方法都需要检查，例如 WebSocket 方式的实时语音使用 GET 请求，默认只检查 POST 请求
+	allMethods bool
+	check      orgPolicyCheck
+}
+
+var orgBillingRoutes = []orgBillingRoute{
+	// 聊天需要根据最终使用的模型检查，在处理器中完成
+	{prefix: "/v1/chat/completions", allMethods: true, check: orgCheckedInHandler},
+	{prefix: "/v1/users/quota"},
+	{prefix: "/v1/tasks"},
+
+	{prefix: "/v1/role-play"},
+	{prefix: "/v1/voice/realtime", allMethods: true},
+	{prefix: "/v1/ocr"},
+
+	{prefix: "/v1/group-chat/", suffix: "/chat", check: orgGroupChat},
+	{prefix: "/v1/group-chat/", suffix: "/debate", check: orgGroupChat},
+	{prefix: "/v1/group-chat/", suffix: "/judge", check: orgFixedChat(func(conf *config.Config) string { return conf.GroupJudgeModel })},
+	{prefix: "/v1/group-chat/", suffix: "/merge", check: orgFixedChat(func(conf *config.Config) string { return conf.GroupConsensusModel })},
+	{prefix: "/v1/group-chat"},
+
+	{prefix: "/v1/pdf/", suffix: "/chat", check: orgChat(nil, "messages")},
+	{prefix: "/v1/pdf"},
+	{prefix: "/v1/tables/", suffix: "/ask", check: orgChat(nil, "question")},
+	{prefix: "/v1/code-interpreter/completions", check: orgChat(nil, "messages")},
+	{prefix: "/v1/model-comparisons", check: orgModelComparison},
+	{prefix: "/v1/resume/polish", check: orgChat(func(conf *config.Config) string { return conf.ResumePolishModel }, "resume", "job_description", "feedback")},
+	{prefix: "/v1/writing-tools", check: orgFixedChat(func(conf *config.Config) string { return conf.WritingToolModel }, "text")},
+	{prefix: "/v1/study/decks", check: orgFixedChat(func(conf *config.Config) string { return conf.StudyModel }, "name", "notes")},
+	{prefix: "/v1/tutor/chat", check: orgFixedChat(func(conf *config.Config) string { return conf.TutorModel }, "messages")},
+	{prefix: "/v1/translate", check: orgContent("text")},
+	{prefix: "/v1/voice/text2voice", check: orgContent("text")},
+	{prefix: "/v1/audio/transcriptions", check: orgContent()},
+	{prefix: "/v1/images/generations", check: orgContent("prompt")},
+
+	{prefix: "/v2/creative-island/completions/essay-grading", check: orgFixedChat(func(conf *config.Config) string { return conf.EssayGradingModel }, "text", "requirement")},
+	{prefix: "/v2/creative-island/completions/artistic-text", check: orgContent("text", "prompt")},
+	{prefix: "/v2/creative-island/completions/upscale", check: orgContent()},
+	{prefix: "/v2/creative-island/completions/colorize", check: orgContent()},
+	{prefix: "/v2/creative-island/completions/avatar-pack", check: orgContent()},
+	{prefix: "/v2/creative-island/completions", check: orgContent("prompt", "negative_prompt")},
+	{prefix: "/v2/creative-island/quotes", check: orgContent()},
+	{prefix: "/v2/creative-island/prompt/enhance", check: orgFixedChat(func(conf *config.Config) string { return conf.MagicPromptModel }, "prompt")},
+	{prefix: "/v2/creative-island/mock-interview", check: orgFixedChat(func(conf *config.Config) string { return conf.MockInterviewModel }, "role", "answer")},
+	{prefix: "/v2/creative-island"},
+}
+
+// orgBillingRouteCheck 使用组织钱包计费时请求需要执行的组织策略检查，supported 为 false 表示该接口不允许使用组织钱包计费
+func orgBillingRouteCheck(method, path string) (check orgPolicyCheck, supported bool) {
+	for _, r := range orgBillingRoutes {
+		if !strings.HasPrefix(path, r.prefix) || !strings.HasSuffix(path, r.suffix) {
+			continue
+		}
+
+		if r.allMethods || method == http.MethodPost {
+			return r.check, r.check != nil
+		}
+
+		return nil, true
+	}
+
+	return nil, false
+}
+
+// orgCheckedInHandler 需要在处理器中根据最终使用的模型检查组织策略的接口
+func orgCheckedInHandler(context.Context, web.Context, *config.Config, *service.OrgPolicyService, int64) error {
+	return nil
+}
+
+// orgChat 模型由请求参数 model 指定的聊天类接口，defaultModel 不为空时，未指定模型时使用默认模型
+func orgChat(defaultModel func(conf *config.Config) string, contentKeys ...string) orgPolicyCheck {
+	return func(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.OrgPolicyService, userID int64) error {
+		model := webCtx.Input("model")
+		if model == "" && defaultModel != nil {
+			model = defaultModel(conf)
+		}
+
+		return srv.Check(ctx, userID, model, requestContent(webCtx, contentKeys...))
+	}
+}
+
+// orgFixedChat 使用配置中指定的模型的聊天类接口
+func orgFixedChat(model func(conf *config.Config) string, contentKeys ...string) orgPolicyCheck {
+	return func(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.OrgPolicyService, userID int64) error {
+		return srv.Check(ctx, userID, model(conf), requestContent(webCtx, contentKeys...))
+	}
+}
+
+// orgContent 不使用大模型的接口，只检查请求内容
+func orgContent(contentKeys ...string) orgPolicyCheck {
+	return func(ctx context.Context, webCtx web.Context, _ *config.Config, srv *service.OrgPolicyService, userID int64) error {
+		return srv.CheckContent(ctx, userID, requestContent(webCtx, contentKeys...))
+	}
+}
+
+// orgGroupChat 群聊需要检查参与对话的每一个成员使用的模型，自动评审、自动合并时还需要检查评审与合并使用的模型
+func orgGroupChat(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.OrgPolicyService, userID int64) error {
+	groupID, _ := strconv.ParseInt(webCtx.PathVar("group_id"), 10, 64)
+
+	var req struct {
+		Message   string  `json:"message"`
+		MemberIDs []int64 `json:"member_ids"`
+		AutoJudge bool    `json:"auto_judge"`
+		AutoMerge bool    `json:"auto_merge"`
+	}
+	_ = webCtx.Unmarshal(&req)
+
+	if err := srv.CheckGroupChat(ctx, userID, groupID, req.MemberIDs, req.Message); err != nil {
+		return err
+	}
+
+	if req.AutoJudge && conf.GroupJudgeModel != "" {
+		if err := srv.Check(ctx, userID, conf.GroupJudgeModel, ""); err != nil {
+			return err
+		}
+	}
+
+	if req.AutoMerge && conf.GroupConsensusModel != "" {
+		if err := srv.Check(ctx, userID, conf.GroupConsensusModel, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orgModelComparison 模型对比需要检查参与对比的每一个模型
+func orgModelComparison(ctx context.Context, webCtx web.Context, _ *config.Config, srv *service.OrgPolicyService, userID int64) error {
+	var req struct {
+		Prompt string   `json:"prompt"`
+		Models []string `json:"models"`
+	}
+	_ = webCtx.Unmarshal(&req)
+
+	if len(req.Models) == 0 {
+		return srv.CheckContent(ctx, userID, req.Prompt)
+	}
+
+	for _, model := range req.Models {
+		if err := srv.Check(ctx, userID, model, req.Prompt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
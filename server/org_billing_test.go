@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+// 会扣除配额的接口，使用组织钱包计费时都需要检查组织策略
+var orgBillableRoutes = []string{
+	"/v1/chat/completions",
+	"/v1/images/generations",
+	"/v1/audio/transcriptions",
+	"/v1/group-chat/{group_id}/chat",
+	"/v1/group-chat/{group_id}/debate",
+	"/v1/group-chat/{group_id}/chat/{message_id}/judge",
+	"/v1/group-chat/{group_id}/chat/{message_id}/merge",
+	"/v1/tutor/chat",
+	"/v1/translate/",
+	"/v1/voice/text2voice",
+	"/v1/pdf/{id}/chat",
+	"/v1/tables/{id}/ask",
+	"/v1/code-interpreter/completions",
+	"/v1/model-comparisons/",
+	"/v1/resume/polish",
+	"/v1/writing-tools/",
+	"/v1/writing-tools/grammar-check",
+	"/v1/study/decks",
+	"/v2/creative-island/completions/",
+	"/v2/creative-island/completions/upscale",
+	"/v2/creative-island/completions/colorize",
+	"/v2/creative-island/completions/artistic-text",
+	"/v2/creative-island/completions/essay-grading",
+	"/v2/creative-island/completions/avatar-pack",
+	"/v2/creative-island/quotes",
+	"/v2/creative-island/quotes/{id}/confirm",
+	"/v2/creative-island/prompt/enhance",
+	"/v2/creative-island/mock-interview/",
+	"/v2/creative-island/mock-interview/{id}/answer",
+}
+
+func TestOrgBillingRoutes(t *testing.T) {
+	rules := registeredRoutes(t)
+
+	registered := make(map[string]bool)
+	for _, rule := range rules {
+		registered[rule.GetPath()] = true
+	}
+
+	for _, path := range orgBillableRoutes {
+		assert.True(t, registered[path], path)
+
+		check, supported := orgBillingRouteCheck(http.MethodPost, path)
+		assert.True(t, supported, path)
+		assert.True(t, check != nil, path)
+	}
+
+	// 路由表中的每一项都需要对应已注册的接口，避免接口路径变更后检查失效
+	for _, r := range orgBillingRoutes {
+		matched := false
+		for path := range registered {
+			if strings.HasPrefix(path, r.prefix) && strings.HasSuffix(path, r.suffix) {
+				matched = true
+				break
+			}
+		}
+
+		assert.True(t, matched, r.prefix+"*"+r.suffix)
+	}
+
+	// 未列出的接口默认不允许使用组织钱包计费
+	for _, rule := range rules {
+		path := rule.GetPath()
+
+		listed := false
+		for _, r := range orgBillingRoutes {
+			if strings.HasPrefix(path, r.prefix) && strings.HasSuffix(path, r.suffix) {
+				listed = true
+				break
+			}
+		}
+
+		if !listed {
+			_, supported := orgBillingRouteCheck(http.MethodPost, path)
+			assert.False(t, supported, path)
+		}
+	}
+}
@@ -2,12 +2,18 @@ package server
 
 import (
 	"context"
+	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"github.com/mylxsw/aidea-server/pkg/profiler"
 	"github.com/mylxsw/aidea-server/pkg/rate"
 	repo2 "github.com/mylxsw/aidea-server/pkg/repo"
 	"github.com/mylxsw/aidea-server/pkg/service"
+	"github.com/mylxsw/aidea-server/pkg/sign"
 	"github.com/mylxsw/aidea-server/pkg/token"
+	"github.com/mylxsw/aidea-server/pkg/trace"
+	"github.com/mylxsw/aidea-server/pkg/uploader"
 	"github.com/mylxsw/aidea-server/pkg/youdao"
 	"net/http"
 	"runtime/debug"
@@ -31,8 +37,10 @@ import (
 	"github.com/mylxsw/glacier/listener"
 	"github.com/mylxsw/glacier/web"
 	"github.com/mylxsw/go-utils/str"
+	"github.com/mylxsw/go-utils/ternary"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 )
 
 var ErrUserDestroyed = errors.New("user is destroyed")
@@ -96,12 +104,60 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 		"/v1/rooms",           // 数字人管理
 		"/v1/room-galleries",  // 数字人 Gallery
 		"/v1/voice",           // 语音合成
+		"/v1/messages",        // 聊天消息
 		"/v1/admin",           // 管理员接口
 
+		"/v1/code-interpreter", // 代码解释器
+		"/v1/ocr",              // 文字识别
+		"/v1/pdf",              // PDF 对话
+		"/v1/tables",           // 表格问答
+		"/v1/moonshot",         // Moonshot 文档问答
+		"/v1/resume",           // 简历优化
+		"/v1/role-play",        // 角色扮演
+		"/v1/topics",           // 会话话题
+		"/v1/achievements",     // 成就与排行榜
+		"/v1/check-in",         // 每日签到
+		"/v1/promo",            // 抽奖活动
+		"/v1/orgs",             // 组织（团队）
+		"/v1/support-tickets",  // 客服工单
+		"/v1/coin-transfers",   // 智慧果转赠
+		"/v1/chat-imports",     // 聊天记录导入
+
+		"/v1/payment/auto-topup",  // 自动充值
+		"/v1/model-comparisons",   // 模型对比
+		"/v1/writing-tools",       // 写作工具
+		"/v1/study",               // 学习模式
+		"/v1/tutor",               // 作业辅导
+		"/v1/onboarding/progress", // 新用户引导进度
+		"/v1/beta",                // 内测计划
+
+		"/v1/diagnosis/bug-reports", // 问题报告
+
 		// v2 版本
-		"/v2/creative-island/histories",   // 创作岛历史记录
-		"/v2/creative-island/completions", // 创作岛生成操作
-		"/v2/rooms",                       // 数字人管理
+		"/v2/creative-island/histories",      // 创作岛历史记录
+		"/v2/creative-island/completions",    // 创作岛生成操作
+		"/v2/creative-island/mock-interview", // 模拟面试
+		"/v2/creative-island/prompt/",        // 提示语优化（不包括公开的 prompt-styles）
+		"/v2/creative-island/presets",        // 创作预设
+		"/v2/creative-island/quotes",         // 创作岛任务报价
+		"/v2/rooms",                          // 数字人管理
+	}
+
+	// 不校验客户端请求签名的 URLs：第三方服务的回调接口，以及在浏览器中访问的公开页面
+	signExemptPrefix := []string{
+		"/v1/callback",         // 登录、存储回调
+		"/v1/payment/callback", // 支付结果回调
+		"/v1/scim",             // 组织成员同步接口，由企业的 HR/IdP 系统调用
+		"/public",              // 公开访问信息
+	}
+
+	// 不受客户端版本策略限制的 URLs，被禁止的版本仍然可以获取升级信息
+	versionGateExemptPrefix := []string{
+		"/v1/bootstrap",        // 客户端启动配置，包含升级要求
+		"/v1/callback",         // 登录、存储回调
+		"/v1/payment/callback", // 支付结果回调
+		"/v1/scim",             // 组织成员同步接口
+		"/public",              // 公开访问信息，包含版本检查
 	}
 
 	// Prometheus 监控指标
@@ -113,14 +169,30 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 	)
 
 	// 添加 web 中间件
-	resolver.MustResolve(func(tk *token.Token, userSrv *service.UserService, limiter *redis_rate.Limiter, translater youdao.Translater) {
+	resolver.MustResolve(func(appCtx context.Context, tk *token.Token, userSrv *service.UserService, orgRepo *repo2.OrgRepo, limiter *redis_rate.Limiter, translater youdao.Translater, noticeSrv *service.NoticeService, versionPolicySrv *service.VersionPolicyService, bugReportSrv *service.BugReportService, betaSrv *service.BetaService, restrictedSrv *service.RestrictedModeService, orgPolicySrv *service.OrgPolicyService, geoSrv *service.GeoPolicyService, keywordFilterSrv *service.KeywordFilterService, rds *redis.Client) {
+		signVerifier := sign.NewVerifier(conf.RequestSignKeys, conf.RequestSignTolerance, rds)
 		mws = append(mws, func(handler web.WebHandler) web.WebHandler {
 			return func(ctx web.Context) web.Response {
+				// 请求关联 ID，请求产生的日志以及异步任务的日志都会携带该 ID
+				reqID := requestID(ctx)
+				ctx.Response().Header(trace.HeaderRequestID, reqID)
+				bindRequestContext(ctx, trace.WithRequestID(appCtx, reqID))
+
+				// 接口版本与废弃提示
+				writeVersionHeaders(ctx.Response(), ctx.Request().Raw().URL.Path)
+
 				ctx.Response().Header("aidea-global-alert-id", "20231204")
 				//ctx.Response().Header("aidea-global-alert-type", "info")
 				//ctx.Response().Header("aidea-global-alert-pages", "")
 				//ctx.Response().Header("aidea-global-alert-msg", base64.StdEncoding.EncodeToString([]byte("服务器正在维护中，预计 2023 年 11 月 12 日 00:00:00 恢复，[查看详情](https://status.aicode.cc/status/aidea)。")))
 
+				// 管理员配置的公告横幅与维护窗口，使用客户端已经支持的全局提示展示
+				if notices := noticeSrv.Active(ctx.Context()); len(notices) > 0 {
+					ctx.Response().Header("aidea-global-alert-id", fmt.Sprintf("notice-%d", notices[0].ID))
+					ctx.Response().Header("aidea-global-alert-type", notices[0].Level)
+					ctx.Response().Header("aidea-global-alert-msg", base64.StdEncoding.EncodeToString([]byte(notices[0].Content)))
+				}
+
 				return handler(ctx)
 			}
 		})
@@ -130,6 +202,38 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 				return webCtx.JSON(web.M{})
 			}
 
+			// 客户端请求签名校验，增加脱离官方客户端调用接口的难度
+			if conf.EnableRequestSign && !str.HasPrefixes(webCtx.Request().Raw().URL.Path, signExemptPrefix) {
+				if err := signVerifier.Verify(webCtx.Context(), webCtx.Request().Raw(), time.Now()); err != nil {
+					log.F(log.M{"ip": webCtx.Header("X-Real-IP"), "path": webCtx.Request().Raw().URL.Path}).Warningf("request signature verify failed: %v", err)
+					if conf.RequestSignEnforce && sign.Rejected(err) {
+						return webCtx.JSONError(common.Text(webCtx, translater, "请求签名无效，请使用官方客户端访问"), http.StatusForbidden)
+					}
+				}
+			}
+
+			// 维护期间暂停使用消耗资源的功能，查询类接口保持可用
+			if feature := maintenanceFeature(webCtx.Method(), webCtx.Request().Raw().URL.Path); feature != "" {
+				if notice := noticeSrv.Maintenance(webCtx.Context(), feature); notice != nil {
+					return webCtx.JSONError(
+						common.Text(webCtx, translater, "系统维护中，该功能暂停使用，预计恢复时间：")+notice.EndAt.Format("2006-01-02 15:04"),
+						http.StatusServiceUnavailable,
+					)
+				}
+			}
+
+			// 低于最低支持版本或者被禁止的客户端版本，必须升级后才能继续使用
+			if !str.HasPrefixes(webCtx.Request().Raw().URL.Path, versionGateExemptPrefix) {
+				upgrade := versionPolicySrv.Check(webCtx.Context(), readFromWebContext(webCtx, "platform"), readFromWebContext(webCtx, "client-version"))
+				if upgrade.Required {
+					return webCtx.JSONWithCode(web.M{
+						"error":   common.Text(webCtx, translater, ternary.If(upgrade.Message != "", upgrade.Message, "当前版本已停止服务，请升级到最新版本后继续使用")),
+						"code":    "upgrade_required",
+						"upgrade": upgrade,
+					}, http.StatusUpgradeRequired)
+				}
+			}
+
 			// 基于客户端 IP 的限流
 			clientIP := webCtx.Header("X-Real-IP")
 			if clientIP == "" {
@@ -164,9 +268,10 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 					platform,
 				).Inc()
 
-				log.F(log.M{
+				fields := log.M{
 					"method":   cal.Method,
 					"url":      cal.URL,
+					"route":    path,
 					"code":     cal.ResponseCode,
 					"elapse":   cal.Elapse.Milliseconds(),
 					"ip":       cal.Context.Header("X-Real-IP"),
@@ -174,7 +279,37 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 					"ver":      readFromWebContext(cal.Context, "client-version"),
 					"plat":     platform,
 					"plat-ver": readFromWebContext(cal.Context, "platform-version"),
-				}).Debug("request")
+				}
+				if model, ok := cal.Context.Get(common.ContextKeyUpstreamModel).(string); ok && model != "" {
+					fields["model"] = model
+				}
+
+				// 未匹配到路由的请求（例如扫描器）不参与接口耗时统计
+				if path != "" {
+					profiler.Record(profiler.KindEndpoint, cal.Method+" "+path, cal.Elapse, conf.SlowEndpointThreshold)
+				}
+
+				reqCtx := requestContext(cal.Context, appCtx)
+				trace.F(reqCtx, fields).Debug("request")
+
+				// 记录登录用户的请求摘要，客户端提交问题报告时用于关联请求的处理结果
+				if uid := trace.UserID(reqCtx); uid > 0 && path != "" {
+					summary := service.RequestSummary{
+						UserID:    uid,
+						Method:    cal.Method,
+						Route:     path,
+						Code:      cal.ResponseCode,
+						Elapse:    cal.Elapse.Milliseconds(),
+						CreatedAt: time.Now(),
+					}
+					if model, ok := fields["model"].(string); ok {
+						summary.Model = model
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+					bugReportSrv.RecordRequest(ctx, trace.RequestID(reqCtx), summary)
+					cancel()
+				}
 			}),
 			authHandler(
 				func(webCtx web.Context, credential string) error {
@@ -238,6 +373,20 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 						webCtx.Provide(func() *auth.UserOptional {
 							return &auth.UserOptional{User: user}
 						})
+						bindRequestContext(webCtx, trace.WithUserID(requestContext(webCtx, appCtx), user.ID))
+
+						// 请求中指定了计费组织时，该请求的配额查询与扣除都使用组织的共享钱包
+						if orgID, _ := strconv.Atoi(readFromWebContext(webCtx, "billing-org")); orgID > 0 {
+							if _, err := orgRepo.ActiveMember(ctx, int64(orgID), user.ID); err != nil {
+								if errors.Is(err, repo2.ErrNotFound) {
+									return errors.New("permission denied, not a member of the billing organization")
+								}
+
+								return err
+							}
+
+							bindRequestContext(webCtx, repo2.WithBillingOrg(requestContext(webCtx, appCtx), int64(orgID)))
+						}
 					} else {
 						webCtx.Provide(func() *auth.UserOptional { return &auth.UserOptional{User: user} })
 					}
@@ -253,6 +402,8 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 							PlatformVersion: readFromWebContext(ctx, "platform-version"),
 							Language:        readFromWebContext(ctx, "language"),
 							IP:              ctx.Header("X-Real-IP"),
+							Region:          clientRegion(ctx, conf),
+							StoreRegion:     strings.ToUpper(strings.TrimSpace(readFromWebContext(ctx, "store-region"))),
 						}
 					})
 
@@ -273,6 +424,77 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 				},
 			),
 		)
+
+		mws = append(mws, mw.BeforeInterceptor(func(webCtx web.Context) web.Response {
+			// 内测中的功能只对包含该功能的内测分组成员开放，需要在鉴权之后执行
+			if feature := betaFeature(webCtx.Request().Raw().URL.Path); feature != "" {
+				reqCtx := requestContext(webCtx, appCtx)
+				if !betaSrv.FeatureAllowed(reqCtx, trace.UserID(reqCtx), feature) {
+					return webCtx.JSONWithCode(web.M{
+						"error": common.Text(webCtx, translater, "该功能正在内测中，加入内测计划后即可使用"),
+						"code":  "beta_required",
+					}, http.StatusForbidden)
+				}
+			}
+
+			// 地区访问策略，请求来源地区禁止使用的功能在请求分发之前统一拒绝
+			if feature := geoFeature(webCtx.Method(), webCtx.Request().Raw().URL.Path); feature != "" {
+				if geoSrv.Decide(requestContext(webCtx, appCtx), clientRegion(webCtx, conf), feature, "").Blocked {
+					return webCtx.JSONError(common.Text(webCtx, translater, common.ErrRegionRestricted), http.StatusUnavailableForLegalReasons)
+				}
+			}
+
+			// 使用组织钱包计费的请求需要符合组织策略，无法检查组织策略的接口不允许使用组织钱包计费
+			if reqCtx := requestContext(webCtx, appCtx); repo2.BillingOrgFromContext(reqCtx) > 0 {
+				check, supported := orgBillingRouteCheck(webCtx.Method(), webCtx.Request().Raw().URL.Path)
+				if !supported {
+					return webCtx.JSONError(common.Text(webCtx, translater, "该功能不支持使用组织钱包计费"), http.StatusForbidden)
+				}
+
+				if check != nil {
+					userID := trace.UserID(reqCtx)
+					if err := check(reqCtx, webCtx, conf, orgPolicySrv, userID); err != nil {
+						var violation *service.OrgPolicyViolationError
+						if errors.As(err, &violation) {
+							return webCtx.JSONError(common.Text(webCtx, translater, violation.Reason), http.StatusForbidden)
+						}
+
+						log.F(log.M{"user_id": userID}).Errorf("check org policy failed: %s", err)
+						return webCtx.JSONError(common.Text(webCtx, translater, common.ErrInternalError), http.StatusInternalServerError)
+					}
+				}
+			}
+
+			// 受限模式（家长模式）在请求分发之前统一检查，需要在鉴权之后执行
+			if check := restrictedRouteCheck(webCtx.Method(), webCtx.Request().Raw().URL.Path); check != nil {
+				reqCtx := requestContext(webCtx, appCtx)
+				if userID := trace.UserID(reqCtx); userID > 0 {
+					if err := check(reqCtx, webCtx, conf, restrictedSrv, userID); err != nil {
+						var violation *service.RestrictedModeViolationError
+						if errors.As(err, &violation) {
+							return webCtx.JSONError(common.Text(webCtx, translater, violation.Reason), http.StatusForbidden)
+						}
+
+						log.F(log.M{"user_id": userID}).Errorf("check restricted mode failed: %s", err)
+						return webCtx.JSONError(common.Text(webCtx, translater, common.ErrInternalError), http.StatusInternalServerError)
+					}
+				}
+			}
+
+			// 部署方自定义的关键词过滤，用户输入命中拦截规则时在请求分发之前统一拒绝，需要在鉴权之后执行
+			if keys := keywordFilterContentKeys(webCtx.Method(), webCtx.Request().Raw().URL.Path); len(keys) > 0 {
+				if content := requestContent(webCtx, keys...); content != "" {
+					reqCtx := requestContext(webCtx, appCtx)
+					userID := trace.UserID(reqCtx)
+					if res := keywordFilterSrv.Filter(reqCtx, userID, repo2.KeywordFilterScopePrompt, content); res.Blocked {
+						log.F(log.M{"user_id": userID, "hits": res.Hits}).Warningf("用户 %d 的请求命中关键词拦截规则", userID)
+						return webCtx.JSONError(common.Text(webCtx, translater, "内容违规，已被系统拦截，如有疑问邮件联系：support@aicode.cc"), http.StatusNotAcceptable)
+					}
+				}
+			}
+
+			return nil
+		}))
 	})
 
 	// 注册控制器，所有的控制器 API 都以 `/server` 作为接口前缀
@@ -303,6 +525,35 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 		controllers.NewVoiceController(resolver),
 		controllers.NewNotificationController(resolver),
 		controllers.NewArticleController(resolver),
+		controllers.NewMessageController(resolver),
+		controllers.NewCodeInterpreterController(resolver, conf),
+		controllers.NewOCRController(resolver, conf),
+		controllers.NewPDFController(resolver, conf),
+		controllers.NewTableController(resolver, conf),
+		controllers.NewResumeController(resolver, conf),
+		controllers.NewRolePlayController(resolver, conf),
+		controllers.NewTopicController(resolver, conf),
+		controllers.NewAchievementController(resolver, conf),
+		controllers.NewCheckInController(resolver, conf),
+		controllers.NewPromoController(resolver, conf),
+		controllers.NewOrgController(resolver, conf),
+		controllers.NewRestrictedModeController(resolver, conf),
+		controllers.NewPromptVariableController(resolver),
+		controllers.NewBootstrapController(resolver),
+		controllers.NewChatImportController(resolver),
+		controllers.NewSSOController(resolver),
+		controllers.NewSCIMController(resolver),
+		controllers.NewRealtimeVoiceController(resolver, conf),
+		controllers.NewMoonshotController(resolver, conf),
+		controllers.NewSupportTicketController(resolver),
+		controllers.NewCoinTransferController(resolver),
+		controllers.NewModelComparisonController(resolver),
+		controllers.NewGlossaryController(resolver),
+		controllers.NewWritingToolController(resolver),
+		controllers.NewStudyController(resolver),
+		controllers.NewTutorController(resolver),
+		controllers.NewOnboardingController(resolver),
+		controllers.NewBetaController(resolver),
 	)
 
 	r.Controllers(
@@ -322,6 +573,30 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 	r.Controllers(
 		"/v1/admin",
 		admin.NewCreativeIslandController(resolver),
+		admin.NewFineTuneController(resolver),
+		admin.NewPromptTemplateController(resolver),
+		admin.NewGroupChatController(resolver),
+		admin.NewCheckInController(resolver),
+		admin.NewPromoController(resolver),
+		admin.NewKeywordFilterController(resolver),
+		admin.NewAbuseController(resolver),
+		admin.NewGeoPolicyController(resolver),
+		admin.NewChannelController(resolver),
+		admin.NewRoutingRuleController(resolver),
+		admin.NewModelCostController(resolver),
+		admin.NewNoticeController(resolver),
+		admin.NewVersionPolicyController(resolver),
+		admin.NewQuotaRefundController(resolver),
+		admin.NewBackupController(resolver),
+		admin.NewDisabledModelController(resolver),
+		admin.NewLogLevelController(resolver),
+		admin.NewProfilerController(resolver),
+		admin.NewImagePromptStyleController(resolver),
+		admin.NewImageStyleController(resolver),
+		admin.NewSupportTicketController(resolver),
+		admin.NewPaymentRefundController(resolver),
+		admin.NewOnboardingController(resolver),
+		admin.NewBetaController(resolver),
 	)
 
 	// 公开访问信息
@@ -329,13 +604,18 @@ func routes(resolver infra.Resolver, router web.Router, mw web.RequestMiddleware
 		"/public",
 		controllers.NewInfoController(resolver),
 		controllers.NewPaymentPublicController(resolver),
+		controllers.NewMarkdownController(resolver),
 	)
 }
 
 func muxRoutes(resolver infra.Resolver, router *mux.Router) {
-	resolver.MustResolve(func(conf *config.Config) {
+	resolver.MustResolve(func(conf *config.Config, db *sql.DB, rds *redis.Client, up *uploader.Uploader, rep *repo2.Repository) {
 		// 添加 prometheus metrics 支持
 		router.PathPrefix("/metrics").Handler(PrometheusHandler{token: conf.PrometheusToken})
+		// Kubernetes 存活与就绪探针，需要在 /health 之前注册，避免被前缀匹配
+		prober := NewProber(conf, db, rds, up, rep)
+		router.Path("/healthz").HandlerFunc(livenessHandler)
+		router.Path("/readyz").HandlerFunc(readinessHandler(prober))
 		// 添加健康检查接口支持
 		router.PathPrefix("/health").Handler(HealthCheck{})
 		// Universal Links
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/aidea-server/pkg/ai/chat"
+	"github.com/mylxsw/aidea-server/pkg/service"
+	v2 "github.com/mylxsw/aidea-server/server/controllers/v2"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-utils/ternary"
+)
+
+// restrictedCheck 检查请求是否符合受限模式（家长模式）的限制
+type restrictedCheck func(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.RestrictedModeService, userID int64) error
+
+// restrictedRoute 受限模式下需要检查的接口，在请求分发到处理器之前统一检查
+//
+// 聊天（/v1/chat/completions）、创作岛通用创作、模拟面试以及 AI 导师需要根据最终使用的模型检查，在处理器中完成，不在此列出
+type restrictedRoute struct {
+	prefix string
+	suffix string
+	// allMethods 所有请求方法都需要检查，例如 WebSocket 方式的实时语音使用 GET 请求，默认只检查 POST 请求
+	allMethods bool
+	check      restrictedCheck
+}
+
+var restrictedRoutes = []restrictedRoute{
+	// 群聊、角色扮演的模型来自群组成员或会话设置，实时语音无法在请求之前审核内容
+	{prefix: "/v1/group-chat", check: restrictedUnsupported},
+	{prefix: "/v1/role-play", check: restrictedUnsupported},
+	{prefix: "/v1/voice/realtime", allMethods: true, check: restrictedUnsupported},
+	// 旧版创作岛的服务商由创作项决定，受限模式下只能使用新版创作岛
+	{prefix: "/v1/creative-island/completions", check: restrictedUnsupported},
+
+	{prefix: "/v1/model-comparisons", check: restrictedModelComparison},
+	{prefix: "/v1/pdf/", suffix: "/chat", check: restrictedChat(nil, "messages")},
+	{prefix: "/v1/tables/", suffix: "/ask", check: restrictedChat(nil, "question")},
+	{prefix: "/v1/code-interpreter/completions", check: restrictedChat(nil, "messages")},
+	{prefix: "/v1/resume/polish", check: restrictedChat(func(conf *config.Config) string { return conf.ResumePolishModel }, "resume", "job_description", "feedback")},
+	{prefix: "/v1/writing-tools", check: restrictedFixedChat(func(conf *config.Config) string { return conf.WritingToolModel }, "text")},
+	{prefix: "/v1/study/decks", check: restrictedFixedChat(func(conf *config.Config) string { return conf.StudyModel }, "name", "notes")},
+
+	{prefix: "/v2/creative-island/completions/essay-grading", check: restrictedFixedChat(func(conf *config.Config) string { return conf.EssayGradingModel }, "text", "requirement")},
+
+	{prefix: "/v1/images/generations", check: restrictedImage("dalle", "prompt")},
+	{prefix: "/v2/creative-island/completions/upscale", check: restrictedImage("deepai")},
+	{prefix: "/v2/creative-island/completions/colorize", check: restrictedImage("deepai")},
+	{prefix: "/v2/creative-island/completions/artistic-text", check: restrictedImage("leptonai", "text", "prompt")},
+	{prefix: "/v2/creative-island/completions/avatar-pack", check: restrictedImage("replicate")},
+	{prefix: "/v2/creative-island/quotes/", suffix: "/confirm", check: restrictedDailyLimit},
+	{prefix: "/v2/creative-island/quotes", check: restrictedQuote},
+}
+
+// restrictedRouteCheck 请求需要执行的受限模式检查，不需要检查时返回 nil
+func restrictedRouteCheck(method, path string) restrictedCheck {
+	for _, r := range restrictedRoutes {
+		if !strings.HasPrefix(path, r.prefix) || !strings.HasSuffix(path, r.suffix) {
+			continue
+		}
+
+		if r.allMethods || method == http.MethodPost {
+			return r.check
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func restrictedUnsupported(ctx context.Context, _ web.Context, _ *config.Config, srv *service.RestrictedModeService, userID int64) error {
+	return srv.CheckUnsupported(ctx, userID)
+}
+
+func restrictedDailyLimit(ctx context.Context, _ web.Context, _ *config.Config, srv *service.RestrictedModeService, userID int64) error {
+	return srv.CheckDailyLimit(ctx, userID)
+}
+
+// restrictedChat 模型由请求参数 model 指定的聊天类接口，defaultModel 不为空时，未指定模型时使用默认模型
+func restrictedChat(defaultModel func(conf *config.Config) string, contentKeys ...string) restrictedCheck {
+	return func(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.RestrictedModeService, userID int64) error {
+		model := webCtx.Input("model")
+		if model == "" && defaultModel != nil {
+			model = defaultModel(conf)
+		}
+
+		return srv.CheckChat(ctx, userID, model, requestContent(webCtx, contentKeys...))
+	}
+}
+
+// restrictedFixedChat 使用配置中指定的模型的聊天类接口
+func restrictedFixedChat(model func(conf *config.Config) string, contentKeys ...string) restrictedCheck {
+	return func(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.RestrictedModeService, userID int64) error {
+		return srv.CheckChat(ctx, userID, model(conf), requestContent(webCtx, contentKeys...))
+	}
+}
+
+// restrictedImage 使用固定服务商的创作类接口
+func restrictedImage(vendor string, promptKeys ...string) restrictedCheck {
+	return func(ctx context.Context, webCtx web.Context, _ *config.Config, srv *service.RestrictedModeService, userID int64) error {
+		return srv.CheckImage(ctx, userID, vendor, requestContent(webCtx, promptKeys...))
+	}
+}
+
+// restrictedModelComparison 模型对比需要检查参与对比的每一个模型，内容只需要检测一次
+func restrictedModelComparison(ctx context.Context, webCtx web.Context, _ *config.Config, srv *service.RestrictedModeService, userID int64) error {
+	var req struct {
+		Prompt string   `json:"prompt"`
+		Models []string `json:"models"`
+	}
+	_ = webCtx.Unmarshal(&req)
+
+	// 没有指定模型时，使用空模型检查，受限模式下直接拒绝
+	if len(req.Models) == 0 {
+		req.Models = []string{""}
+	}
+
+	for i, model := range req.Models {
+		if err := srv.CheckChat(ctx, userID, model, ternary.If(i == 0, req.Prompt, "")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restrictedQuote 创作岛报价，按照报价的任务类型检查
+func restrictedQuote(ctx context.Context, webCtx web.Context, conf *config.Config, srv *service.RestrictedModeService, userID int64) error {
+	switch webCtx.Input("type") {
+	case v2.CreativeQuoteTypeUpscale, v2.CreativeQuoteTypeColorize:
+		return restrictedImage("deepai")(ctx, webCtx, conf, srv, userID)
+	case v2.CreativeQuoteTypeAvatarPack:
+		return restrictedImage("replicate")(ctx, webCtx, conf, srv, userID)
+	default:
+		return restrictedUnsupported(ctx, webCtx, conf, srv, userID)
+	}
+}
+
+// requestContent 读取请求中需要审核的用户输入，messages 为聊天消息列表，只审核最后一条消息
+func requestContent(webCtx web.Context, keys ...string) string {
+	contents := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == "messages" {
+			var req struct {
+				Messages chat.Messages `json:"messages"`
+			}
+			if err := webCtx.Unmarshal(&req); err == nil && len(req.Messages) > 0 {
+				contents = append(contents, req.Messages[len(req.Messages)-1].Content)
+			}
+
+			continue
+		}
+
+		if val := strings.TrimSpace(webCtx.Input(key)); val != "" {
+			contents = append(contents, val)
+		}
+	}
+
+	return strings.Join(contents, "\n")
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mylxsw/go-utils/assert"
+)
+
+func TestRestrictedRoutes(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, rule := range registeredRoutes(t) {
+		registered[rule.GetPath()] = true
+	}
+
+	// 路由表中的每一项都需要对应已注册的接口，避免接口路径变更后检查失效
+	for _, r := range restrictedRoutes {
+		matched := false
+		for path := range registered {
+			if strings.HasPrefix(path, r.prefix) && strings.HasSuffix(path, r.suffix) {
+				matched = true
+				break
+			}
+		}
+
+		assert.True(t, matched, r.prefix+"*"+r.suffix)
+	}
+
+	for _, path := range []string{
+		"/v1/images/generations",
+		"/v1/resume/polish",
+		"/v2/creative-island/completions/essay-grading",
+	} {
+		assert.True(t, registered[path], path)
+		assert.True(t, restrictedRouteCheck(http.MethodPost, path) != nil, path)
+	}
+}
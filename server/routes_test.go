@@ -0,0 +1,54 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/mylxsw/aidea-server/config"
+	"github.com/mylxsw/glacier/web"
+	"github.com/mylxsw/go-ioc"
+)
+
+// routeResolver 只用于收集注册的路由，控制器只注入配置，其它依赖保持为空
+type routeResolver struct {
+	ioc.Container
+	conf *config.Config
+}
+
+func (r routeResolver) MustAutoWire(object any) {
+	val := reflect.ValueOf(object).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if _, ok := field.Tag.Lookup("autowire"); ok && field.Type == reflect.TypeOf(r.conf) {
+			reflect.NewAt(field.Type, unsafe.Pointer(val.Field(i).UnsafeAddr())).Elem().Set(reflect.ValueOf(r.conf))
+		}
+	}
+}
+
+func (r routeResolver) AutoWire(object any) error {
+	r.MustAutoWire(object)
+	return nil
+}
+
+func (r routeResolver) MustResolve(any) {}
+
+func (r routeResolver) Resolve(any) error { return nil }
+
+// registeredRoutes 返回服务注册的所有路由
+func registeredRoutes(t *testing.T) []web.RouteRule {
+	conf := &config.Config{}
+
+	container := ioc.New()
+	container.MustSingleton(func() *config.Config { return conf })
+
+	router := web.NewRouter(&web.Config{})
+	routes(routeResolver{Container: container, conf: conf}, router, web.NewRequestMiddleware())
+
+	rules := router.GetRoutes()
+	if len(rules) == 0 {
+		t.Fatal("no routes registered")
+	}
+
+	return rules
+}
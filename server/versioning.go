@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mylxsw/glacier/web"
+)
+
+// deprecatedRoute 已废弃的接口，旧版本客户端仍然可以访问，响应中会携带废弃提示头
+type deprecatedRoute struct {
+	path string
+	// prefix 为 true 时，匹配该路径下的所有接口
+	prefix bool
+	// since 废弃该接口的客户端版本
+	since string
+	// successor 替代的接口
+	successor string
+	// sunset 计划下线时间，为空表示暂无下线计划
+	sunset time.Time
+}
+
+var deprecatedRoutes = []deprecatedRoute{
+	{path: "/v1/payment/others/products", since: "1.0.9", successor: "/v1/payment/products"},
+	{path: "/v1/payment/apple/products", since: "1.0.9", successor: "/v1/payment/products"},
+	{path: "/v1/payment/alipay/products", since: "1.0.8", successor: "/v1/payment/products"},
+	{path: "/v1/payment/alipay", prefix: true, since: "1.0.8", successor: "/v1/payment/others"},
+}
+
+// apiVersion 请求的接口版本，例如 v1、v2，不属于版本化接口时返回空
+func apiVersion(path string) string {
+	segs := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	if len(segs[0]) > 1 && segs[0][0] == 'v' && strings.Trim(segs[0][1:], "0123456789") == "" {
+		return segs[0]
+	}
+
+	return ""
+}
+
+// deprecation 查询请求的接口是否已废弃，未废弃时返回 nil
+func deprecation(path string) *deprecatedRoute {
+	path = strings.TrimSuffix(path, "/")
+	for _, r := range deprecatedRoutes {
+		if path == r.path || (r.prefix && strings.HasPrefix(path, r.path+"/")) {
+			return &r
+		}
+	}
+
+	return nil
+}
+
+// writeVersionHeaders 在响应中写入接口版本，已废弃的接口按照 Deprecation/Sunset 规范写入废弃提示头
+func writeVersionHeaders(resp web.ResponseCreator, path string) {
+	if ver := apiVersion(path); ver != "" {
+		resp.Header("aidea-api-version", ver)
+	}
+
+	dep := deprecation(path)
+	if dep == nil {
+		return
+	}
+
+	resp.Header("Deprecation", "true")
+	resp.Header("aidea-deprecated-since", dep.since)
+	if dep.successor != "" {
+		resp.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, dep.successor))
+	}
+
+	if !dep.sunset.IsZero() {
+		resp.Header("Sunset", dep.sunset.UTC().Format(http.TimeFormat))
+	}
+}